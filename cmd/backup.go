@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	"charm.land/huh/v2"
@@ -21,16 +22,20 @@ var backupCmd = &cobra.Command{
 
 Backups include a full SQL dump and any configured media/path data, saved as
 a zip archive. Backup records are stored in the database for history tracking.
+With backup_media set, media objects from the buckets are included; with a
+backup encryption passphrase or recipient set, archives are encrypted.
 
 Subcommands:
   create    Create a full backup archive
   restore   Restore from a backup archive file
   list      Show backup history from the database
+  keygen    Generate a key pair for encrypted backups
 
 Examples:
   modula backup create
   modula backup restore ./backups/backup-2024-01-15.zip
-  modula backup list`,
+  modula backup list
+  modula backup keygen --out /etc/modula/backup.key`,
 }
 
 // backupCreateCmd represents the backup create subcommand that creates a full backup of the database and configured paths.
@@ -42,6 +47,12 @@ var backupCreateCmd = &cobra.Command{
 The backup is saved as a zip file and a record is inserted into the backups table
 with status, duration, and file size. On failure, the record is updated to "failed".
 
+When backup_media is "archive", every media original and variant referenced
+by the database is copied from the media buckets into the archive; when it is
+"sibling", they go into a separate .media.zip archive next to it. When
+backup_encryption_passphrase or backup_encryption_recipient is set, the
+archives are encrypted as they are written and end in .enc.
+
 Examples:
   modula backup create`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -112,6 +123,9 @@ Examples:
 			"path", path,
 			"size", formatBytes(sizeBytes),
 		)
+		if cfg.Backup_Media == backup.MediaSibling {
+			utility.DefaultLogger.Info("media archive created", "path", backup.MediaArchivePath(path))
+		}
 
 		return nil
 	},
@@ -129,6 +143,12 @@ and node ID, then prompts for confirmation before replacing the current database
 This is a destructive operation: the existing database is replaced with the
 backup contents.
 
+Encrypted archives (.enc) are decrypted with backup_encryption_passphrase or
+the key in backup_encryption_identity_file. If the backup includes media,
+each object is checked against its recorded SHA-256 before the database is
+replaced, then uploaded to the configured media buckets. A sibling media
+archive must be in the same directory as the backup.
+
 Arguments:
   path   Path to the backup zip archive
 
@@ -156,6 +176,8 @@ Examples:
 			"version", manifest.Version,
 			"node_id", manifest.NodeID,
 			"db_name", manifest.DbName,
+			"encrypted", manifest.Encrypted,
+			"media", manifest.Media,
 		)
 
 		// Confirm with user
@@ -259,8 +281,49 @@ func formatBytes(b int64) string {
 	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
 }
 
+// backupKeygenCmd generates a key pair for encrypting backups to a recipient.
+var backupKeygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Generate a key pair for encrypted backups",
+	Long: `Generate an X25519 key pair for backup encryption.
+
+The public key goes in backup_encryption_recipient: the server encrypts
+backups to it but cannot decrypt them. Keep the private key off the server
+and set backup_encryption_identity_file to its path only where backups are
+restored.
+
+Prints both keys to stdout, or writes the private key with 0600 permissions
+when --out is given and prints the public key.
+
+Examples:
+  modula backup keygen
+  modula backup keygen --out ./backup.key`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		identity, recipient, err := backup.GenerateKeyPair()
+		if err != nil {
+			return err
+		}
+
+		out, _ := cmd.Flags().GetString("out")
+		if out == "" {
+			fmt.Printf("private key: %s\n", identity)
+			fmt.Printf("public key:  %s\n", recipient)
+			return nil
+		}
+		if err := os.WriteFile(out, []byte(identity+"\n"), 0600); err != nil {
+			return fmt.Errorf("writing key file: %w", err)
+		}
+		fmt.Printf("wrote private key to %s\n", out)
+		fmt.Printf("public key: %s\n", recipient)
+		return nil
+	},
+}
+
 func init() {
 	backupCmd.AddCommand(backupCreateCmd)
 	backupCmd.AddCommand(backupRestoreCmd)
 	backupCmd.AddCommand(backupListCmd)
+	backupCmd.AddCommand(backupKeygenCmd)
+
+	backupKeygenCmd.Flags().String("out", "", "Write the private key to this file")
 }
@@ -600,7 +600,7 @@ func TestRootCommand_Structure(t *testing.T) {
 	expectedSubcommands := []string{
 		"serve", "init", "version", "update", "tui",
 		"cert", "db", "config", "backup", "plugin", "deploy",
-		"connect", "mcp", "pipeline",
+		"connect", "mcp", "pipeline", "schema", "export", "import",
 	}
 
 	subCmds := rootCmd.Commands()
@@ -642,7 +642,7 @@ func TestRootCommand_PersistentFlags(t *testing.T) {
 func TestDbCommand_Subcommands(t *testing.T) {
 	t.Parallel()
 
-	expectedSubs := []string{"init", "wipe", "wipe-redeploy", "reset", "export"}
+	expectedSubs := []string{"init", "wipe", "wipe-redeploy", "reset", "export", "migrate"}
 	subCmds := dbCmd.Commands()
 	cmdNames := make(map[string]bool, len(subCmds))
 	for _, c := range subCmds {
@@ -676,7 +676,7 @@ func TestConfigCommand_Subcommands(t *testing.T) {
 func TestBackupCommand_Subcommands(t *testing.T) {
 	t.Parallel()
 
-	expectedSubs := []string{"create", "restore", "list"}
+	expectedSubs := []string{"create", "restore", "list", "keygen"}
 	subCmds := backupCmd.Commands()
 	cmdNames := make(map[string]bool, len(subCmds))
 	for _, c := range subCmds {
@@ -727,6 +727,81 @@ func TestDeployCommand_Subcommands(t *testing.T) {
 	}
 }
 
+func TestSchemaCommand_Subcommands(t *testing.T) {
+	t.Parallel()
+
+	expectedSubs := []string{"export", "diff", "apply"}
+	subCmds := schemaCmd.Commands()
+	cmdNames := make(map[string]bool, len(subCmds))
+	for _, c := range subCmds {
+		cmdNames[c.Name()] = true
+	}
+
+	for _, name := range expectedSubs {
+		if !cmdNames[name] {
+			t.Errorf("expected schema subcommand %q not found", name)
+		}
+	}
+}
+
+func TestExportCommand_Subcommands(t *testing.T) {
+	t.Parallel()
+
+	subCmds := exportCmd.Commands()
+	cmdNames := make(map[string]bool, len(subCmds))
+	for _, c := range subCmds {
+		cmdNames[c.Name()] = true
+	}
+	for _, name := range []string{"site", "sheet"} {
+		if !cmdNames[name] {
+			t.Errorf("expected export subcommand %q not found", name)
+		}
+	}
+}
+
+func TestImportSheetCommand_Flags(t *testing.T) {
+	t.Parallel()
+
+	if len(importCmd.Commands()) != 1 || importCmd.Commands()[0] != importSheetCmd {
+		t.Errorf("import subcommands = %v, want [sheet]", importCmd.Commands())
+	}
+	expectedFlags := []string{"format", "key", "map", "dry-run", "batch-size", "json"}
+	for _, name := range expectedFlags {
+		if importSheetCmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected flag %q on import sheet command", name)
+		}
+	}
+}
+
+func TestExportSiteCommand_Flags(t *testing.T) {
+	t.Parallel()
+
+	expectedFlags := []string{"out", "format", "locale", "media", "media-base-url", "body-field", "full"}
+	for _, name := range expectedFlags {
+		if exportSiteCmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected flag %q on export site command", name)
+		}
+	}
+	if f := exportSiteCmd.Flags().Lookup("out"); f != nil && f.DefValue != "./site" {
+		t.Errorf("out default: got %q, want %q", f.DefValue, "./site")
+	}
+}
+
+func TestSchemaApplyCommand_Flags(t *testing.T) {
+	t.Parallel()
+
+	expectedFlags := []string{"allow-destructive", "dry-run", "json"}
+	for _, name := range expectedFlags {
+		f := schemaApplyCmd.Flags().Lookup(name)
+		if f == nil {
+			t.Errorf("expected flag %q on schema apply command", name)
+		}
+	}
+	if f := schemaApplyCmd.Flags().Lookup("allow-destructive"); f != nil && f.DefValue != "false" {
+		t.Errorf("allow-destructive default: got %q, want %q", f.DefValue, "false")
+	}
+}
+
 func TestTuiCommand_NoSubcommands(t *testing.T) {
 	t.Parallel()
 
@@ -778,6 +853,8 @@ func TestCommandArgsConstraints(t *testing.T) {
 		{name: "deploy import", use: "import <file>"},
 		{name: "deploy pull", use: "pull <source>"},
 		{name: "deploy push", use: "push <target>"},
+		{name: "schema diff", use: "diff <file>"},
+		{name: "schema apply", use: "apply <file>"},
 	}
 
 	for _, tt := range exactOneArgCmds {
@@ -37,8 +37,9 @@ var configShowCmd = &cobra.Command{
 	Short: "Print the loaded configuration as JSON",
 	Long: `Load modula.config.json and print its full contents as formatted JSON to stdout.
 
-Reads from the path specified by --config (default: ./modula.config.json). Environment
-variable references (${VAR}) in the config file are expanded before display.
+Reads from the path specified by --config (default: ./modula.config.json). Sensitive
+fields are redacted. Values loaded from ${env:NAME} or ${file:/path} references are
+shown as the reference, never as the resolved secret.
 
 When using --overlay, the default output is the merged config. Use --raw to print
 the overlay file contents only (useful for seeing what a specific environment overrides).
@@ -63,7 +64,7 @@ Examples:
 			if err != nil {
 				return fmt.Errorf("loading overlay file: %w", err)
 			}
-			formatted, err := utility.FormatJSON(rawCfg)
+			formatted, err := utility.FormatJSON(config.RedactedConfig(*rawCfg))
 			if err != nil {
 				return fmt.Errorf("formatting overlay: %w", err)
 			}
@@ -76,7 +77,7 @@ Examples:
 			return fmt.Errorf("loading configuration: %w", err)
 		}
 
-		formatted, err := utility.FormatJSON(cfg)
+		formatted, err := utility.FormatJSON(config.RedactedConfig(*cfg))
 		if err != nil {
 			return fmt.Errorf("formatting configuration: %w", err)
 		}
@@ -113,7 +113,7 @@ Examples:
 			return fmt.Errorf("modula.config.json must have either remote_url or db_driver")
 		}
 
-		model, _ := tui.InitialModel(&verbose, cfg, driver, utility.DefaultLogger, nil, mgr, nil, nil)
+		model, _ := tui.InitialModel(&verbose, cfg, driver, utility.DefaultLogger, nil, mgr, tuiPermissions(driver), nil, nil)
 		model.IsRemote = cfg.Remote_URL != ""
 		model.RemoteURL = cfg.Remote_URL
 		if model.IsRemote {
@@ -1,13 +1,17 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"charm.land/huh/v2"
 	"github.com/hegner123/modulacms/internal/auth"
+	"github.com/hegner123/modulacms/internal/config"
 	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/dbmigrate"
 	"github.com/hegner123/modulacms/internal/install"
 	"github.com/hegner123/modulacms/internal/utility"
 	"github.com/spf13/cobra"
@@ -27,13 +31,15 @@ Subcommands:
   wipe-redeploy  Drop all tables, recreate schema, and re-seed with new admin password
   reset          Delete the SQLite database file (SQLite only)
   export         Dump the database to a SQL file
+  migrate        Copy the database to another database, such as SQLite to PostgreSQL
 
 Examples:
   modula db init
   modula db wipe
   modula db wipe-redeploy
   modula db reset
-  modula db export`,
+  modula db export
+  modula db migrate --to modula.postgres.config.json`,
 }
 
 // dbInitCmd creates database tables and bootstrap data after prompting for admin credentials.
@@ -327,6 +333,142 @@ Examples:
 	},
 }
 
+// dbMigrateCmd copies the configured database to the database described by
+// another config file.
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Copy the database to another database",
+	Long: `Copy every table from the configured database to the database in another
+config file. The drivers may differ, so this moves an installation between
+SQLite, MySQL, and PostgreSQL.
+
+The destination schema is created if missing. Rows are streamed in batches
+and converted to the destination's types; IDs, HLC timestamps, and content
+tree pointers are copied unchanged. Afterwards every table's row count and
+checksum are compared on both sides.
+
+Progress is saved to the state file after every batch. If the migration is
+interrupted, run the same command again to resume. The state file is
+removed once the migration completes.
+
+Stop the server before migrating. Both configs must use the same
+encryption key, since encrypted values are copied as stored.
+
+Flags:
+  --to          Config file of the destination database (required)
+  --state       Migration state file (default: modula-db-migrate.state.json)
+  --batch-size  Rows copied per transaction (default: 500)
+  --overwrite   Delete existing rows in the destination first
+
+Examples:
+  modula db migrate --to modula.postgres.config.json
+  modula db migrate --to /etc/modula/mysql.config.json --batch-size 2000`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configureLogger()
+
+		toPath, _ := cmd.Flags().GetString("to")
+		statePath, _ := cmd.Flags().GetString("state")
+		batchSize, _ := cmd.Flags().GetInt("batch-size")
+		overwrite, _ := cmd.Flags().GetBool("overwrite")
+
+		dstCfg, err := loadMigrateDestination(toPath)
+		if err != nil {
+			return err
+		}
+
+		mgr, src, err := loadConfigAndDB()
+		if err != nil {
+			return err
+		}
+		defer closeDBWithLog()
+
+		srcCfg, err := mgr.Config()
+		if err != nil {
+			return fmt.Errorf("reading configuration: %w", err)
+		}
+
+		dst, err := db.OpenDriver(*dstCfg)
+		if err != nil {
+			return fmt.Errorf("opening destination database: %w", err)
+		}
+		defer func() {
+			if con, _, err := dst.GetConnection(); err == nil {
+				if err := con.Close(); err != nil {
+					utility.DefaultLogger.Warn("closing destination database", err)
+				}
+			}
+		}()
+
+		utility.DefaultLogger.Info("migrating database", "from", srcCfg.Db_Driver, "to", dstCfg.Db_Driver)
+		report, err := dbmigrate.Migrate(context.Background(), src, *srcCfg, dst, *dstCfg, dbmigrate.Options{
+			StatePath: statePath,
+			BatchSize: batchSize,
+			Overwrite: overwrite,
+		})
+		if report != nil {
+			printMigrateReport(cmd, report)
+		}
+		if err != nil {
+			if errors.Is(err, dbmigrate.ErrVerificationFailed) {
+				return fmt.Errorf("%w; the state file %s was kept", err, statePath)
+			}
+			return fmt.Errorf("database migration failed (run again to resume): %w", err)
+		}
+		if len(report.Pending) > 0 {
+			utility.DefaultLogger.Warn("database migration incomplete; start the server against the destination once so plugins create their tables, then run this command again", nil, "pending", report.Pending)
+			return nil
+		}
+		utility.DefaultLogger.Info("database migration complete")
+		return nil
+	},
+}
+
+// loadMigrateDestination loads the destination config for db migrate. A
+// relative SQLite path is resolved against the config file's directory.
+func loadMigrateDestination(path string) (*config.Config, error) {
+	if path == "" {
+		return nil, fmt.Errorf("--to is required")
+	}
+	mgr := config.NewManager(config.NewFileProvider(path))
+	if err := mgr.Load(); err != nil {
+		return nil, fmt.Errorf("loading destination configuration: %w", err)
+	}
+	cfg, err := mgr.Config()
+	if err != nil {
+		return nil, fmt.Errorf("reading destination configuration: %w", err)
+	}
+	if cfg.Db_Driver == config.Sqlite && cfg.Db_URL != "" && !filepath.IsAbs(cfg.Db_URL) {
+		abs, err := filepath.Abs(filepath.Join(filepath.Dir(path), cfg.Db_URL))
+		if err != nil {
+			return nil, fmt.Errorf("resolving destination database path: %w", err)
+		}
+		cfg.Db_URL = abs
+	}
+	return cfg, nil
+}
+
+// printMigrateReport writes the per-table verification results.
+func printMigrateReport(cmd *cobra.Command, report *dbmigrate.Report) {
+	out := cmd.OutOrStdout()
+	if report.Resumed {
+		fmt.Fprintln(out, "Resumed an interrupted migration.")
+	}
+	for _, t := range report.Tables {
+		status := "ok"
+		if !t.Verified {
+			status = fmt.Sprintf("MISMATCH (source %d rows %.12s, destination %d rows %.12s)",
+				t.SourceRows, t.SourceChecksum, t.DestRows, t.DestChecksum)
+		}
+		fmt.Fprintf(out, "%-32s %8d rows  %s\n", t.Table, t.SourceRows, status)
+	}
+	for _, p := range report.Pending {
+		fmt.Fprintf(out, "%-32s pending\n", p)
+	}
+	for _, w := range report.Warnings {
+		fmt.Fprintf(out, "warning: %s\n", w)
+	}
+}
+
 func init() {
 	dbCmd.AddCommand(dbInitCmd)
 	dbCmd.AddCommand(dbWipeCmd)
@@ -335,4 +477,10 @@ func init() {
 	dbCmd.AddCommand(dbExportCmd)
 
 	dbExportCmd.Flags().String("file", "", "Output file path (default: timestamped file in current directory)")
+
+	dbCmd.AddCommand(dbMigrateCmd)
+	dbMigrateCmd.Flags().String("to", "", "Config file of the destination database (required)")
+	dbMigrateCmd.Flags().String("state", "modula-db-migrate.state.json", "Migration state file")
+	dbMigrateCmd.Flags().Int("batch-size", dbmigrate.DefaultBatchSize, "Rows copied per transaction")
+	dbMigrateCmd.Flags().Bool("overwrite", false, "Delete existing rows in the destination first")
 }
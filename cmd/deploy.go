@@ -3,11 +3,14 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/hegner123/modulacms/internal/config"
 	"github.com/hegner123/modulacms/internal/db"
 	"github.com/hegner123/modulacms/internal/deploy"
+	"github.com/hegner123/modulacms/internal/encryption"
 	"github.com/hegner123/modulacms/internal/utility"
 	"github.com/spf13/cobra"
 )
@@ -48,17 +51,29 @@ var deployExportCmd = &cobra.Command{
 	Long: `Export content tables from the local database to a JSON file.
 
 By default, exports all sync-eligible tables. Use --tables to export only
-specific tables. The output includes a manifest with table names, row counts,
-schema version, and timestamp.
+specific tables, or --route and --datatype to export a scoped subset: a
+route's content tree, or datatypes with their fields. Importing a scoped
+file touches only the rows in that scope. The output includes a manifest
+with table names, row counts, schema version, and timestamp.
+
+Secret columns (webhook secrets, OAuth tokens) are redacted unless
+--target-key-file names the importing instance's encryption key, in which
+case they are re-encrypted for it.
 
 Flags:
-  --file     Output file path (required)
-  --tables   Comma-separated table names to export (default: all sync tables)
-  --json     Print the export manifest as JSON instead of log output
+  --file             Output file path (required)
+  --tables           Comma-separated table names to export (default: all sync tables)
+  --route            Route slug or ID to export with its content tree (repeatable)
+  --datatype         Datatype name or ID to export with its fields (repeatable)
+  --strategy         Import strategy recorded in the manifest: overwrite or merge
+  --target-key-file  Encryption key file of the importing instance
+  --json             Print the export manifest as JSON instead of log output
 
 Examples:
   modula deploy export --file data.json
+  modula deploy export --file data.json --target-key-file ./staging.key
   modula deploy export --file content-only.json --tables content_data,content_tree
+  modula deploy export --file blog.json --route blog --strategy merge
   modula deploy export --file data.json --json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		configureLogger()
@@ -91,6 +106,17 @@ Examples:
 		}
 		includePlugins, _ := cmd.Flags().GetBool("include-plugins")
 		opts.IncludePlugins = includePlugins
+		if err := applyScopeFlags(cmd, &opts); err != nil {
+			return err
+		}
+
+		if keyFile, _ := cmd.Flags().GetString("target-key-file"); keyFile != "" {
+			keyring, kErr := encryption.KeyringFromConfig(config.Config{Encryption_Key_File: keyFile})
+			if kErr != nil {
+				return kErr
+			}
+			opts.TargetKeyring = keyring
+		}
 
 		ctx := context.Background()
 		manifest, actualPath, err := deploy.ExportToFile(ctx, driver, opts, outFile)
@@ -126,7 +152,8 @@ var deployImportCmd = &cobra.Command{
 
 By default, creates a pre-import backup and a snapshot for rollback. Use
 --dry-run to validate the payload and see an impact report without modifying
-the database. Supports gzip-compressed files (.json.gz).
+the database; for scoped or merge files the report lists row changes and
+merge conflicts. Supports gzip-compressed files (.json.gz).
 
 Arguments:
   file   Path to the JSON export file
@@ -166,7 +193,7 @@ Examples:
 			if lErr != nil {
 				return lErr
 			}
-			result := deploy.BuildDryRunResult(payload, driver)
+			result := deploy.DryRunPayload(context.Background(), *cfg, driver, payload)
 
 			if jsonOutput {
 				data, _ := json.MarshalIndent(result, "", "  ")
@@ -408,11 +435,21 @@ database.
 The source environment must be configured in modula.config.json under deploy_environments
 with a name, URL, and API key. A pre-import backup is created by default.
 
+--route and --datatype pull a scoped subset and leave other local rows alone.
+--strategy merge runs a three-way merge against the last snapshot shared with
+the remote: local edits that do not conflict are kept. Run with --dry-run to
+list conflicts, then repeat with --resolve for each one, in the form
+table/row_id/column=source|target (omit /column for whole-row conflicts).
+
 Arguments:
   source   Environment name (from modula.config.json deploy_environments)
 
 Flags:
   --tables        Comma-separated table names (default: all sync tables)
+  --route         Route slug or ID to sync with its content tree (repeatable)
+  --datatype      Datatype name or ID to sync with its fields (repeatable)
+  --strategy      overwrite (default) or merge
+  --resolve       Conflict resolution table/row_id[/column]=source|target (repeatable)
   --skip-backup   Skip the pre-import backup
   --dry-run       Validate and show impact report without importing
   --json          Output results as JSON
@@ -420,7 +457,10 @@ Flags:
 Examples:
   modula deploy pull staging
   modula deploy pull production --tables content_data,content_tree
-  modula deploy pull staging --dry-run`,
+  modula deploy pull staging --dry-run
+  modula deploy pull staging --route blog --strategy merge --dry-run
+  modula deploy pull staging --route blog --strategy merge \
+    --resolve content_fields/01J.../field_value=target`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		configureLogger()
@@ -448,6 +488,9 @@ Examples:
 		}
 		includePlugins, _ := cmd.Flags().GetBool("include-plugins")
 		opts.IncludePlugins = includePlugins
+		if err := applyScopeFlags(cmd, &opts); err != nil {
+			return err
+		}
 
 		ctx := context.Background()
 		result, err := deploy.Pull(ctx, *cfg, driver, envName, opts, skipBackup, dryRun)
@@ -455,6 +498,8 @@ Examples:
 			if result != nil && jsonOutput {
 				data, _ := json.MarshalIndent(result, "", "  ")
 				fmt.Println(string(data))
+			} else if result != nil && errors.Is(err, deploy.ErrUnresolvedConflicts) {
+				printDryRunResult(result)
 			}
 			return fmt.Errorf("pull failed: %w", err)
 		}
@@ -499,18 +544,28 @@ environment.
 The target environment must be configured in modula.config.json under deploy_environments
 with a name, URL, and API key.
 
+--route and --datatype push a scoped subset and leave other remote rows alone.
+--strategy merge runs a three-way merge on the remote against the last
+snapshot it imported from this instance; see "deploy pull" for --resolve.
+
 Arguments:
   target   Environment name (from modula.config.json deploy_environments)
 
 Flags:
   --tables     Comma-separated table names (default: all sync tables)
+  --route      Route slug or ID to sync with its content tree (repeatable)
+  --datatype   Datatype name or ID to sync with its fields (repeatable)
+  --strategy   overwrite (default) or merge
+  --resolve    Conflict resolution table/row_id[/column]=source|target (repeatable)
   --dry-run    Validate and show impact report without pushing
   --json       Output results as JSON
 
 Examples:
   modula deploy push staging
   modula deploy push production --tables content_data,content_tree
-  modula deploy push staging --dry-run`,
+  modula deploy push staging --dry-run
+  modula deploy push production --datatype Article --datatype Author
+  modula deploy push production --route blog --strategy merge --dry-run`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		configureLogger()
@@ -537,6 +592,9 @@ Examples:
 		}
 		includePlugins, _ := cmd.Flags().GetBool("include-plugins")
 		opts.IncludePlugins = includePlugins
+		if err := applyScopeFlags(cmd, &opts); err != nil {
+			return err
+		}
 
 		ctx := context.Background()
 		result, err := deploy.Push(ctx, *cfg, driver, envName, opts, dryRun)
@@ -544,6 +602,8 @@ Examples:
 			if result != nil && jsonOutput {
 				data, _ := json.MarshalIndent(result, "", "  ")
 				fmt.Println(string(data))
+			} else if result != nil && errors.Is(err, deploy.ErrUnresolvedConflicts) {
+				printDryRunResult(result)
 			}
 			return fmt.Errorf("push failed: %w", err)
 		}
@@ -723,6 +783,11 @@ func printDryRunResult(result *deploy.SyncResult) {
 		"strategy", string(result.Strategy),
 	)
 	for _, t := range result.TablesAffected {
+		if c, ok := result.Changes[t]; ok {
+			utility.DefaultLogger.Info("  "+t, "rows", result.RowCounts[t],
+				"inserted", c.Inserted, "updated", c.Updated, "deleted", c.Deleted)
+			continue
+		}
 		utility.DefaultLogger.Info("  "+t, "rows", result.RowCounts[t])
 	}
 
@@ -732,6 +797,17 @@ func printDryRunResult(result *deploy.SyncResult) {
 		}
 	}
 
+	if len(result.Conflicts) > 0 {
+		utility.DefaultLogger.Warn("merge conflicts", nil, "count", len(result.Conflicts), "base", result.BaseSnapshotID)
+		for _, c := range result.Conflicts {
+			key := c.Table + "/" + c.RowID
+			if c.Column != "" {
+				key += "/" + c.Column
+			}
+			utility.DefaultLogger.Warn("  "+key, nil, "source", c.Source, "target", c.Target)
+		}
+	}
+
 	if len(result.Errors) > 0 {
 		for _, e := range result.Errors {
 			utility.DefaultLogger.Warn(fmt.Sprintf("  [%s] %s: %s", e.Phase, e.Table, e.Message), nil)
@@ -739,6 +815,50 @@ func printDryRunResult(result *deploy.SyncResult) {
 	}
 }
 
+// applyScopeFlags reads --route, --datatype, --strategy, and (when defined)
+// --resolve into opts.
+func applyScopeFlags(cmd *cobra.Command, opts *deploy.ExportOptions) error {
+	opts.Routes, _ = cmd.Flags().GetStringSlice("route")
+	opts.Datatypes, _ = cmd.Flags().GetStringSlice("datatype")
+	strategy, _ := cmd.Flags().GetString("strategy")
+	switch deploy.MergeStrategy(strategy) {
+	case "", deploy.StrategyOverwrite, deploy.StrategyMerge:
+		opts.Strategy = deploy.MergeStrategy(strategy)
+	default:
+		return fmt.Errorf("invalid --strategy %q: must be overwrite or merge", strategy)
+	}
+	if cmd.Flags().Lookup("resolve") == nil {
+		return nil
+	}
+	resolves, _ := cmd.Flags().GetStringArray("resolve")
+	for _, r := range resolves {
+		res, err := parseResolveFlag(r)
+		if err != nil {
+			return err
+		}
+		opts.Resolutions = append(opts.Resolutions, res)
+	}
+	return nil
+}
+
+// parseResolveFlag parses a --resolve value of the form
+// table/row_id[/column]=source|target.
+func parseResolveFlag(flag string) (deploy.ConflictResolution, error) {
+	key, take, ok := strings.Cut(flag, "=")
+	if !ok || (take != deploy.TakeSource && take != deploy.TakeTarget) {
+		return deploy.ConflictResolution{}, fmt.Errorf("invalid --resolve %q: want table/row_id[/column]=source|target", flag)
+	}
+	parts := strings.Split(key, "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return deploy.ConflictResolution{}, fmt.Errorf("invalid --resolve %q: want table/row_id[/column]=source|target", flag)
+	}
+	res := deploy.ConflictResolution{Table: parts[0], RowID: parts[1], Take: take}
+	if len(parts) == 3 {
+		res.Column = parts[2]
+	}
+	return res, nil
+}
+
 // parseTablesFlag splits a comma-separated table names string into validated DBTable values.
 // Returns nil for empty input. Returns nil with non-empty input if any name is invalid.
 func parseTablesFlag(flag string) []db.DBTable {
@@ -781,6 +901,10 @@ func init() {
 	deployExportCmd.Flags().String("file", "", "Output file path (required)")
 	deployExportCmd.Flags().String("tables", "", "Comma-separated table names (default: all sync tables)")
 	deployExportCmd.Flags().Bool("include-plugins", false, "Include plugin table data in export")
+	deployExportCmd.Flags().StringSlice("route", nil, "Route slug or ID to export with its content tree")
+	deployExportCmd.Flags().StringSlice("datatype", nil, "Datatype name or ID to export with its fields")
+	deployExportCmd.Flags().String("strategy", "", "Import strategy: overwrite or merge")
+	deployExportCmd.Flags().String("target-key-file", "", "Re-encrypt secret columns for the instance using this key file (default: redact)")
 	deployExportCmd.Flags().Bool("json", false, "Output as JSON")
 
 	// deploy import flags
@@ -791,6 +915,10 @@ func init() {
 	// deploy pull flags
 	deployPullCmd.Flags().String("tables", "", "Comma-separated table names (default: all sync tables)")
 	deployPullCmd.Flags().Bool("include-plugins", false, "Include plugin table data")
+	deployPullCmd.Flags().StringSlice("route", nil, "Route slug or ID to sync with its content tree")
+	deployPullCmd.Flags().StringSlice("datatype", nil, "Datatype name or ID to sync with its fields")
+	deployPullCmd.Flags().String("strategy", "", "Import strategy: overwrite or merge")
+	deployPullCmd.Flags().StringArray("resolve", nil, "Merge conflict resolution: table/row_id[/column]=source|target")
 	deployPullCmd.Flags().Bool("skip-backup", false, "Skip pre-import backup")
 	deployPullCmd.Flags().Bool("dry-run", false, "Validate only, show impact report without importing")
 	deployPullCmd.Flags().Bool("json", false, "Output as JSON")
@@ -798,6 +926,10 @@ func init() {
 	// deploy push flags
 	deployPushCmd.Flags().String("tables", "", "Comma-separated table names (default: all sync tables)")
 	deployPushCmd.Flags().Bool("include-plugins", false, "Include plugin table data")
+	deployPushCmd.Flags().StringSlice("route", nil, "Route slug or ID to sync with its content tree")
+	deployPushCmd.Flags().StringSlice("datatype", nil, "Datatype name or ID to sync with its fields")
+	deployPushCmd.Flags().String("strategy", "", "Import strategy: overwrite or merge")
+	deployPushCmd.Flags().StringArray("resolve", nil, "Merge conflict resolution: table/row_id[/column]=source|target")
 	deployPushCmd.Flags().Bool("dry-run", false, "Validate only, show impact report without importing")
 	deployPushCmd.Flags().Bool("json", false, "Output as JSON")
 
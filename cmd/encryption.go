@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/encryption"
+	"github.com/hegner123/modulacms/internal/utility"
+	"github.com/spf13/cobra"
+)
+
+// encryptionCmd is the root command for encryption-at-rest key management.
+var encryptionCmd = &cobra.Command{
+	Use:   "encryption",
+	Short: "encryption key management commands",
+	Long: `Manage the master key used to encrypt secret columns at rest.
+
+Webhook secrets and OAuth access/refresh tokens are sealed with a per-value
+data key, which is wrapped by the master key from encryption_key (or
+encryption_key_file) in modula.config.json.
+
+Subcommands:
+  keygen      Generate a new master key
+  reencrypt   Re-seal every secret column under the current master key
+
+Rotating the key:
+  1. Move the current key into encryption_previous_keys
+  2. Set encryption_key to a key from 'modula encryption keygen'
+  3. Run 'modula encryption reencrypt'
+  4. Remove the old key from encryption_previous_keys
+
+Examples:
+  modula encryption keygen --out /etc/modula/encryption.key
+  modula encryption reencrypt`,
+}
+
+// encryptionKeygenCmd generates a new random master key.
+var encryptionKeygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Generate a new encryption master key",
+	Long: `Generate a random 32-byte master key, base64 encoded.
+
+Prints the key to stdout, or writes it with 0600 permissions when --out is
+given. Reference the file from modula.config.json with encryption_key_file or
+"encryption_key": "${file:/path/to/key}".
+
+Examples:
+  modula encryption keygen
+  modula encryption keygen --out ./encryption.key`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, err := encryption.GenerateKey()
+		if err != nil {
+			return err
+		}
+
+		out, _ := cmd.Flags().GetString("out")
+		if out == "" {
+			fmt.Println(key)
+			return nil
+		}
+		if err := os.WriteFile(out, []byte(key+"\n"), 0600); err != nil {
+			return fmt.Errorf("writing key file: %w", err)
+		}
+		fmt.Printf("wrote encryption key to %s\n", out)
+		return nil
+	},
+}
+
+// encryptionReencryptCmd rewraps every designated column under the primary key.
+var encryptionReencryptCmd = &cobra.Command{
+	Use:   "reencrypt",
+	Short: "Re-seal secret columns under the current encryption key",
+	Long: `Rewrite every secret column so it is sealed under the current encryption_key.
+
+Plaintext values written before encryption was enabled are encrypted, and
+values sealed under a key listed in encryption_previous_keys are rewrapped.
+Values plugins sealed with db.encrypt are rewrapped too; plaintext in plugin
+tables is left alone. Values already sealed under the current key are left
+as they are, so the command is safe to rerun.
+
+Examples:
+  modula encryption reencrypt`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configureLogger()
+
+		mgr, driver, err := loadConfigAndDB()
+		if err != nil {
+			return err
+		}
+		defer closeDBWithLog()
+
+		cfg, err := mgr.Config()
+		if err != nil {
+			return fmt.Errorf("reading configuration: %w", err)
+		}
+
+		keyring := db.ColumnKeyring()
+		if keyring == nil {
+			return fmt.Errorf("no encryption_key or encryption_key_file is configured")
+		}
+
+		result, err := db.RewrapEncryptedColumns(context.Background(), driver, db.DialectFromString(string(cfg.Db_Driver)), keyring)
+		if err != nil {
+			return fmt.Errorf("reencrypt failed after %d rows: %w", result.Rewritten, err)
+		}
+
+		utility.DefaultLogger.Info("reencrypt complete",
+			"key_id", keyring.PrimaryID(),
+			"rows_scanned", result.Scanned,
+			"rows_rewritten", result.Rewritten)
+		return nil
+	},
+}
+
+func init() {
+	encryptionKeygenCmd.Flags().String("out", "", "Write the key to this file instead of stdout")
+
+	encryptionCmd.AddCommand(encryptionKeygenCmd)
+	encryptionCmd.AddCommand(encryptionReencryptCmd)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/hegner123/modulacms/internal/siteexport"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export content",
+	Long: `Export content for use outside the CMS.
+
+Subcommands:
+  site    Write every published route as a static site bundle
+  sheet   Write the entries of a datatype as a CSV or XLSX file
+
+Examples:
+  modula export site --out ./content
+  modula export site --out ./content --format markdown --media
+  modula export sheet product --file products.xlsx`,
+}
+
+// --- export site ---
+
+var exportSiteCmd = &cobra.Command{
+	Use:   "site",
+	Short: "Write every published route as a static site bundle",
+	Long: `Write one file per published route and locale to a directory, plus a
+manifest.json index, for static site generators.
+
+Content comes from published versions, as served by /api/v1/content. Files
+are JSON in an output format (clean, raw, contentful, sanity, strapi,
+wordpress) or Markdown with YAML front matter, where --body-field becomes the
+document body. The home page is index.json or index.md; /blog/post becomes
+blog/post.json. With i18n enabled each locale gets its own directory.
+
+With --media, the originals and variants of media referenced by the exported
+content are downloaded under media/. --media-base-url rewrites media URLs in
+the exported files to point at the downloads.
+
+Exports are incremental: when the directory holds a manifest from a previous
+export in the same format, routes whose published version and composed
+references are unchanged are not rewritten, and files of routes no longer
+published are removed. Use --full to write everything again.
+
+Flags:
+  --out              Output directory (default: ./site)
+  --format           markdown or an output format (default: output_format)
+  --locale           Locales to export, repeatable (default: all enabled)
+  --media            Download referenced media
+  --media-base-url   URL prefix for downloaded media in exported files
+  --body-field       Markdown body field (default: body)
+  --full             Ignore the previous manifest and write every file
+
+Examples:
+  modula export site --out ./content
+  modula export site --out ./content --format markdown --media --media-base-url /media-files
+  modula export site --out ./content --locale en --locale fr --full`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configureLogger()
+
+		out, _ := cmd.Flags().GetString("out")
+		full, _ := cmd.Flags().GetBool("full")
+		opts := siteexport.Options{}
+		opts.Format, _ = cmd.Flags().GetString("format")
+		opts.Locales, _ = cmd.Flags().GetStringSlice("locale")
+		opts.Media, _ = cmd.Flags().GetBool("media")
+		opts.MediaBaseURL, _ = cmd.Flags().GetString("media-base-url")
+		opts.BodyField, _ = cmd.Flags().GetString("body-field")
+
+		// The config directory becomes the working directory when the
+		// config loads, so resolve --out first.
+		dir, err := filepath.Abs(out)
+		if err != nil {
+			return fmt.Errorf("resolve %s: %w", out, err)
+		}
+		if !full {
+			prev, err := readSiteManifest(dir)
+			if err != nil {
+				return err
+			}
+			opts.Previous = prev
+		}
+
+		mgr, driver, err := loadConfigAndDB()
+		if err != nil {
+			return err
+		}
+		defer closeDBWithLog()
+		cfg, err := mgr.Config()
+		if err != nil {
+			return err
+		}
+
+		res, err := siteexport.Run(context.Background(), driver, *cfg, siteexport.DirSink{Dir: dir}, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Exported %d routes to %s: %d written, %d unchanged, %d removed, %d media downloaded.\n",
+			len(res.Manifest.Entries), dir, res.Written, res.Unchanged, res.Removed, res.Media)
+		return nil
+	},
+}
+
+// readSiteManifest reads the manifest of a previous export in dir. It
+// returns nil when there is none.
+func readSiteManifest(dir string) (*siteexport.Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, siteexport.IndexName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read previous manifest: %w", err)
+	}
+	var manifest siteexport.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parse %s: %w (use --full to export everything)", siteexport.IndexName, err)
+	}
+	return &manifest, nil
+}
+
+func init() {
+	exportSiteCmd.Flags().String("out", "./site", "Output directory")
+	exportSiteCmd.Flags().String("format", "", "markdown or an output format (default: output_format)")
+	exportSiteCmd.Flags().StringSlice("locale", nil, "Locales to export (default: all enabled)")
+	exportSiteCmd.Flags().Bool("media", false, "Download referenced media")
+	exportSiteCmd.Flags().String("media-base-url", "", "URL prefix for downloaded media in exported files")
+	exportSiteCmd.Flags().String("body-field", "", "Field written as the Markdown body (default: body)")
+	exportSiteCmd.Flags().Bool("full", false, "Ignore the previous manifest and write every file")
+
+	exportCmd.AddCommand(exportSiteCmd)
+}
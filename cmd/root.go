@@ -64,12 +64,16 @@ func init() {
 	rootCmd.AddCommand(dbCmd)
 	rootCmd.AddCommand(configParentCmd)
 	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(encryptionCmd)
 	rootCmd.AddCommand(pluginCmd)
 	rootCmd.AddCommand(pipelineCmd)
 	rootCmd.AddCommand(deployCmd)
 	rootCmd.AddCommand(connectCmd)
 	rootCmd.AddCommand(mcpCmd)
 	rootCmd.AddCommand(scaffoldCmd)
+	rootCmd.AddCommand(schemaCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
 }
 
 // Execute runs the root CLI command and returns any error encountered.
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/definitions"
+	"github.com/spf13/cobra"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Export, diff, and apply the schema as a versioned file",
+	Long: `Manage datatypes, fields, and validations as code.
+
+Export writes the live schema to a YAML or JSON file that can be committed and
+reviewed. Diff compares a file against the database and prints the plan.
+Apply executes that plan, creating, updating, and retiring datatypes and
+fields so the database matches the file. Applying the same file twice is a
+no-op.
+
+Datatypes and fields are matched by machine name, validations by name, and
+field roles by role label, so a file exported from one environment applies to
+another.
+
+Subcommands:
+  export   Write the live schema to a file
+  diff     Show the changes needed to match a file
+  apply    Change the database to match a file
+
+Examples:
+  modula schema export --file schema.yaml
+  modula schema diff schema.yaml
+  modula schema apply schema.yaml
+  modula schema apply schema.yaml --allow-destructive`,
+}
+
+// --- schema export ---
+
+var schemaExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the live schema to a file",
+	Long: `Write the live datatypes, fields, validations, and field UI config to a
+versioned schema file.
+
+The format follows the file extension (.json for JSON, YAML otherwise) unless
+--format is given. Without --file the schema is written to stdout.
+
+Flags:
+  --file     Output file path (default: stdout)
+  --format   yaml or json (default: from --file extension, else yaml)
+
+Examples:
+  modula schema export --file schema.yaml
+  modula schema export --format json > schema.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configureLogger()
+
+		outFile, _ := cmd.Flags().GetString("file")
+		format, _ := cmd.Flags().GetString("format")
+		if format == "" {
+			format = definitions.SchemaFileFormat(outFile)
+		}
+
+		_, driver, err := loadConfigAndDB()
+		if err != nil {
+			return err
+		}
+		defer closeDBWithLog()
+
+		live, err := definitions.LoadLiveSchema(driver)
+		if err != nil {
+			return err
+		}
+		file, err := definitions.ExportSchema(live)
+		if err != nil {
+			return err
+		}
+		data, err := definitions.MarshalSchemaFile(file, format)
+		if err != nil {
+			return err
+		}
+
+		if outFile == "" {
+			_, err = cmd.OutOrStdout().Write(data)
+			return err
+		}
+		if err := os.WriteFile(outFile, data, 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", outFile, err)
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d datatypes and %d validations to %s.\n",
+			len(file.Datatypes), len(file.Validations), outFile)
+		return nil
+	},
+}
+
+// --- schema diff ---
+
+var schemaDiffCmd = &cobra.Command{
+	Use:   "diff <file>",
+	Short: "Show the changes needed to match a schema file",
+	Long: `Compare a schema file against the database and print the plan that
+"modula schema apply" would execute. The database is not modified.
+
+Changes are marked "+" (create), "~" (update), and "-" (retire). Retiring a
+datatype or field and changing a field type are flagged as destructive.
+
+Arguments:
+  file   Path to a YAML or JSON schema file
+
+Flags:
+  --exit-code   Exit with an error when the database differs from the file
+  --json        Print the plan as JSON
+
+Examples:
+  modula schema diff schema.yaml
+  modula schema diff schema.yaml --exit-code`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configureLogger()
+
+		exitCode, _ := cmd.Flags().GetBool("exit-code")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+
+		_, driver, err := loadConfigAndDB()
+		if err != nil {
+			return err
+		}
+		defer closeDBWithLog()
+
+		plan, err := loadSchemaPlan(driver, args[0])
+		if err != nil {
+			return err
+		}
+		if err := printSchemaPlan(cmd, plan, jsonOutput); err != nil {
+			return err
+		}
+		if exitCode && !plan.Empty() {
+			return fmt.Errorf("schema differs from %s: %s", args[0], plan.Summary())
+		}
+		return nil
+	},
+}
+
+// --- schema apply ---
+
+var schemaApplyCmd = &cobra.Command{
+	Use:   "apply <file>",
+	Short: "Change the database to match a schema file",
+	Long: `Create, update, and retire datatypes, fields, and validations so the
+database matches a schema file. The plan is printed before it is applied.
+
+Destructive changes (retiring a datatype or field, or changing a field type)
+are refused unless --allow-destructive is given. Datatypes that still have
+content are never retired. Validations missing from the file are left in
+place because admin fields may share them. Apply asks for confirmation on a
+terminal; --yes skips the prompt.
+
+Created records are authored by the system user.
+
+Arguments:
+  file   Path to a YAML or JSON schema file
+
+Flags:
+  --allow-destructive   Allow retiring datatypes and fields and changing field types
+  --dry-run             Print the plan without applying it
+  --json                Print the plan as JSON
+
+Examples:
+  modula schema apply schema.yaml
+  modula schema apply schema.yaml --dry-run
+  modula schema apply schema.yaml --allow-destructive`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configureLogger()
+
+		allowDestructive, _ := cmd.Flags().GetBool("allow-destructive")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+
+		mgr, driver, err := loadConfigAndDB()
+		if err != nil {
+			return err
+		}
+		defer closeDBWithLog()
+
+		cfg, err := mgr.Config()
+		if err != nil {
+			return fmt.Errorf("reading configuration: %w", err)
+		}
+
+		plan, err := loadSchemaPlan(driver, args[0])
+		if err != nil {
+			return err
+		}
+		if err := printSchemaPlan(cmd, plan, jsonOutput); err != nil {
+			return err
+		}
+		if dryRun || plan.Empty() {
+			return nil
+		}
+		if n := len(plan.Destructive()); n > 0 && !allowDestructive {
+			return fmt.Errorf("plan has %d destructive changes; re-run with --allow-destructive to apply them", n)
+		}
+		if !confirmAction(cmd, yesFlag || jsonOutput, fmt.Sprintf("Apply %d changes?", len(plan.Changes))) {
+			fmt.Fprintln(cmd.ErrOrStderr(), "Cancelled.")
+			return nil
+		}
+
+		systemUser, err := driver.GetUserByEmail(types.Email("system@modula.local"))
+		if err != nil {
+			return fmt.Errorf("looking up system user: %w", err)
+		}
+		ac := audited.Ctx(types.NodeID(cfg.Node_ID), systemUser.UserID, "schema-apply", "cli")
+
+		applied, err := definitions.ApplySchemaPlan(context.Background(), driver, ac, plan, definitions.ApplyOptions{
+			AuthorID:         systemUser.UserID,
+			AllowDestructive: allowDestructive,
+		})
+		if err != nil {
+			return err
+		}
+		if !jsonOutput {
+			fmt.Fprintf(cmd.OutOrStdout(), "Applied %d changes.\n", applied)
+		}
+		return nil
+	},
+}
+
+// loadSchemaPlan reads a schema file and diffs it against the database.
+func loadSchemaPlan(driver db.DbDriver, path string) (definitions.SchemaPlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return definitions.SchemaPlan{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	file, err := definitions.ParseSchemaFile(data)
+	if err != nil {
+		return definitions.SchemaPlan{}, fmt.Errorf("%s: %w", path, err)
+	}
+	live, err := definitions.LoadLiveSchema(driver)
+	if err != nil {
+		return definitions.SchemaPlan{}, err
+	}
+	return definitions.DiffSchema(file, live)
+}
+
+// printSchemaPlan writes the plan as text or JSON.
+func printSchemaPlan(cmd *cobra.Command, plan definitions.SchemaPlan, jsonOutput bool) error {
+	if jsonOutput {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+	fmt.Fprint(cmd.OutOrStdout(), plan.Render())
+	return nil
+}
+
+func init() {
+	schemaExportCmd.Flags().String("file", "", "Output file path (default: stdout)")
+	schemaExportCmd.Flags().String("format", "", "Output format: yaml or json (default: from --file extension)")
+
+	schemaDiffCmd.Flags().Bool("exit-code", false, "Exit with an error when the database differs from the file")
+	schemaDiffCmd.Flags().Bool("json", false, "Print the plan as JSON")
+
+	schemaApplyCmd.Flags().Bool("allow-destructive", false, "Allow retiring datatypes and fields and changing field types")
+	schemaApplyCmd.Flags().Bool("dry-run", false, "Print the plan without applying it")
+	schemaApplyCmd.Flags().Bool("json", false, "Print the plan as JSON")
+
+	schemaCmd.AddCommand(schemaExportCmd)
+	schemaCmd.AddCommand(schemaDiffCmd)
+	schemaCmd.AddCommand(schemaApplyCmd)
+}
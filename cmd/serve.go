@@ -21,8 +21,10 @@ import (
 	"charm.land/wish/v2/logging"
 	"github.com/charmbracelet/ssh"
 	"github.com/hegner123/modulacms/internal/auth"
+	"github.com/hegner123/modulacms/internal/backup"
 	"github.com/hegner123/modulacms/internal/bucket"
 	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/contentmigration"
 	"github.com/hegner123/modulacms/internal/db"
 	"github.com/hegner123/modulacms/internal/db/audited"
 	"github.com/hegner123/modulacms/internal/db/types"
@@ -197,6 +199,42 @@ Examples:
 			utility.DefaultLogger.Warn("ensureWebhookPermissions failed", ensureErr)
 		}
 
+		// Ensure multi-role and user group tables exist and legacy single-role
+		// assignments are mirrored into user_roles (backfill for upgrades).
+		if ensureErr := db.EnsureUserRoles(rootCtx, driver); ensureErr != nil {
+			utility.DefaultLogger.Warn("ensureUserRoles failed", ensureErr)
+		}
+
+		// Ensure account lockout and password history tables exist (upgrades).
+		if ensureErr := db.EnsureAccountSecurityTables(driver); ensureErr != nil {
+			utility.DefaultLogger.Warn("ensureAccountSecurityTables failed", ensureErr)
+		}
+
+		// Ensure the shared rate limit counter table exists (upgrades).
+		if ensureErr := db.EnsureRateLimitTable(driver); ensureErr != nil {
+			utility.DefaultLogger.Warn("ensureRateLimitTable failed", ensureErr)
+		}
+
+		// Ensure the content migration record table exists (upgrades).
+		if ensureErr := db.EnsureContentMigrationTable(driver); ensureErr != nil {
+			utility.DefaultLogger.Warn("ensureContentMigrationTable failed", ensureErr)
+		}
+
+		// Ensure the content lease table exists (upgrades).
+		if ensureErr := db.EnsureContentLeaseTable(driver); ensureErr != nil {
+			utility.DefaultLogger.Warn("ensureContentLeaseTable failed", ensureErr)
+		}
+
+		// Ensure the translation memory and review tables exist (upgrades).
+		if ensureErr := db.EnsureTranslationTables(driver); ensureErr != nil {
+			utility.DefaultLogger.Warn("ensureTranslationTables failed", ensureErr)
+		}
+
+		// Ensure the blueprint tables exist (upgrades).
+		if ensureErr := db.EnsureBlueprintTables(driver); ensureErr != nil {
+			utility.DefaultLogger.Warn("ensureBlueprintTables failed", ensureErr)
+		}
+
 		cfg, err := mgr.Config()
 		if err != nil {
 			return err
@@ -311,6 +349,10 @@ Examples:
 			}
 		}
 
+		// The permission cache is shared by the TUI and the HTTP stack. It is
+		// loaded below, after the SSH server starts.
+		pc := middleware.NewPermissionCache()
+
 		// SSH server — started before permission cache so it remains available
 		// even when the HTTP stack cannot initialize (e.g. missing bootstrap data).
 		sshServer, err := wish.NewServer(
@@ -323,7 +365,7 @@ Examples:
 				middleware.SSHSessionLoggingMiddleware(cfg),
 				middleware.SSHAuthenticationMiddleware(cfg),
 				middleware.SSHAuthorizationMiddleware(cfg),
-				tui.CliMiddleware(&verbose, cfg, driver, utility.DefaultLogger, pluginManager, mgr, dbReadyCh, dispatcher),
+				tui.CliMiddleware(&verbose, cfg, driver, utility.DefaultLogger, pluginManager, mgr, pc, dbReadyCh, dispatcher),
 				logging.Middleware(),
 			),
 		)
@@ -352,7 +394,6 @@ Examples:
 		sshOnly := false
 		utility.DefaultLogger.Info("loading permission cache")
 		utility.DefaultLogger.Debug("building in-memory PermissionCache from role_permissions table, build-then-swap for lock-free reads")
-		pc := middleware.NewPermissionCache()
 		if pcErr := pc.Load(driver); pcErr != nil {
 			utility.DefaultLogger.Error("permission cache load failed — placeholder HTTP mode until DB init via SSH", pcErr)
 			sshOnly = true
@@ -379,10 +420,35 @@ Examples:
 			pluginMgr = pluginManager
 		}
 		svc.Plugins = service.NewPluginService(pluginMgr)
+		var migrationSource service.ContentMigrationSource
+		if pluginManager != nil {
+			migrationSource = pluginManager
+		}
+		svc.ContentMigrations = service.NewContentMigrationService(driver, contentmigration.Default(), migrationSource)
 		svc.Webhooks = service.NewWebhookService(driver, mgr, dispatcher)
 		svc.Locales = service.NewLocaleService(driver, mgr)
+		var translationSource service.TranslationProviderSource
+		if pluginManager != nil {
+			translationSource = pluginManager
+		}
+		svc.Translations = service.NewTranslationService(driver, mgr, svc.Locales, translationSource)
 		svc.Search = service.NewSearchService(searchSvc)
 
+		// Scheduled backups — verified archives go to backup_target and are
+		// pruned by the GFS retention settings. Failures notify admins.
+		if cfg.Backup_Schedule != "" {
+			if schedErr := backup.StartScheduler(rootCtx, mgr, driver, svc.Backup.NotifyFailure); schedErr != nil {
+				utility.DefaultLogger.Error("backup scheduler disabled", schedErr)
+			} else {
+				utility.DefaultLogger.Info("backup scheduler armed", "schedule", cfg.Backup_Schedule, "target", cfg.BackupTarget())
+			}
+		}
+
+		// Rate limiter shared by every handler build. The database store
+		// counts across replicas; memory is per process.
+		rateLimiter := middleware.NewPolicyRateLimiter(cfg.RateLimitPolicies(), middleware.NewRateLimitStore(*cfg, driver))
+		defer rateLimiter.Close()
+
 		// buildRealHandler creates the full router + middleware stack.
 		buildRealHandler := func() http.Handler {
 			// Ensure S3 buckets exist (media + backup)
@@ -424,12 +490,13 @@ Examples:
 			fullHandler := middleware.Chain(
 				middleware.HookRunnerMiddleware(hookRunner),
 				middleware.ReadHookRunnerMiddleware(readHookRunner),
-			)(middleware.DefaultMiddlewareChain(mgr, pc, utility.GlobalObservability)(mux))
+			)(middleware.DefaultMiddlewareChain(mgr, pc, utility.GlobalObservability, rateLimiter)(mux))
 
 			// MCP server (Model Context Protocol for AI tooling).
 			// Direct mode: tools call services directly without HTTP round-trips.
-			// Authentication is handled by the DefaultMiddlewareChain; per-tool
-			// permission checks are enforced by the MCP PermissionMiddleware.
+			// The handler requires an API token or OAuth bearer token, resolves
+			// permissions through the PermissionCache, and applies per-token
+			// tool allowlists from mcp_token_tools.
 			if cfg.MCP_Enabled {
 				mcpHandler := mcpserver.DirectHandler(svc)
 				mux.Handle("/mcp", mcpHandler)
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/service"
+	"github.com/hegner123/modulacms/internal/spreadsheet"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import content",
+	Long: `Import content into the CMS.
+
+Subcommands:
+  sheet   Create and update the entries of a datatype from a CSV or XLSX file
+
+Examples:
+  modula import sheet product products.xlsx --dry-run
+  modula import sheet product products.csv --key slug`,
+}
+
+// newSheetService builds a ContentSheetService outside the server.
+func newSheetService(driver db.DbDriver, mgr *config.Manager) *service.ContentSheetService {
+	return service.NewContentSheetService(driver, mgr,
+		service.NewContentService(driver, mgr, nil),
+		service.NewRouteService(driver, mgr))
+}
+
+// sheetFormat returns --format, or the format implied by the file name.
+func sheetFormat(cmd *cobra.Command, file string) (string, error) {
+	format, _ := cmd.Flags().GetString("format")
+	if format == "" {
+		format = spreadsheet.FormatFromName(file)
+	}
+	if format == "" {
+		format = spreadsheet.FormatCSV
+	}
+	if format != spreadsheet.FormatCSV && format != spreadsheet.FormatXLSX {
+		return "", fmt.Errorf("unsupported format %q (want csv or xlsx)", format)
+	}
+	return format, nil
+}
+
+// --- export sheet ---
+
+var exportSheetCmd = &cobra.Command{
+	Use:   "sheet <datatype>",
+	Short: "Write the entries of a datatype as a CSV or XLSX file",
+	Long: `Write every entry of a datatype as one spreadsheet row, with one column
+per field and, when i18n is enabled, one column per translatable field and
+locale (title@fr). The _content_id, _slug, and _parent_id columns identify
+each entry, so the file can be edited and imported back with "import sheet".
+
+Values are the working copy (drafts), not the published versions.
+
+Arguments:
+  datatype   Datatype name or ID
+
+Flags:
+  --file     Output file (default: stdout)
+  --format   csv or xlsx (default: from --file extension, else csv)
+  --locale   Translation locales to include, repeatable (default: all enabled)
+
+Examples:
+  modula export sheet product --file products.xlsx
+  modula export sheet product --locale fr > products.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configureLogger()
+
+		file, _ := cmd.Flags().GetString("file")
+		locales, _ := cmd.Flags().GetStringSlice("locale")
+		format, err := sheetFormat(cmd, file)
+		if err != nil {
+			return err
+		}
+		// The config directory becomes the working directory when the
+		// config loads, so resolve --file first.
+		if file != "" {
+			if file, err = filepath.Abs(file); err != nil {
+				return err
+			}
+		}
+
+		mgr, driver, err := loadConfigAndDB()
+		if err != nil {
+			return err
+		}
+		defer closeDBWithLog()
+
+		sheet, err := newSheetService(driver, mgr).Export(context.Background(), service.SheetExportParams{
+			Datatype: args[0],
+			Locales:  locales,
+		})
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := spreadsheet.Write(&buf, format, sheet); err != nil {
+			return err
+		}
+		if file == "" {
+			_, err = cmd.OutOrStdout().Write(buf.Bytes())
+			return err
+		}
+		if err := os.WriteFile(file, buf.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", file, err)
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d %s entries to %s.\n", len(sheet.Rows), args[0], file)
+		return nil
+	},
+}
+
+// --- import sheet ---
+
+var importSheetCmd = &cobra.Command{
+	Use:   "sheet <datatype> <file>",
+	Short: "Create and update the entries of a datatype from a CSV or XLSX file",
+	Long: `Upsert one entry of a datatype per spreadsheet row.
+
+Columns are matched to fields by name or label; "title@fr" targets the fr
+translation of title. --map renames columns (--map "Product Name:title") or
+ignores them (--map "Notes:"). Unknown columns are ignored.
+
+Rows match existing entries by _content_id or _slug (--key picks one;
+by default _content_id is used when present). Unmatched rows create draft
+entries: a row with _slug creates a route with the entry as its root, a row
+with _parent_id adds the entry under that parent.
+
+Every row is validated first, with the field's validation rules. Rows with
+errors are reported and skipped; the rest are written in batches, each in
+one transaction with an audit record per change. --dry-run stops after
+validation and prints what would change. Changes are authored by the
+system user.
+
+Arguments:
+  datatype   Datatype name or ID
+  file       CSV or XLSX file
+
+Flags:
+  --format       csv or xlsx (default: from the file extension)
+  --key          Match rows by id or slug (default: id, then slug)
+  --map          Column:target mapping, repeatable
+  --dry-run      Validate and report without writing
+  --batch-size   Rows per transaction (default: 100)
+  --json         Print the report as JSON
+
+Examples:
+  modula import sheet product products.xlsx --dry-run
+  modula import sheet product products.csv --key slug --map "Name:title" --map "Name (FR):title@fr"`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configureLogger()
+
+		format, err := sheetFormat(cmd, args[1])
+		if err != nil {
+			return err
+		}
+		params := service.SheetImportParams{Datatype: args[0]}
+		params.Key, _ = cmd.Flags().GetString("key")
+		params.DryRun, _ = cmd.Flags().GetBool("dry-run")
+		params.BatchSize, _ = cmd.Flags().GetInt("batch-size")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		maps, _ := cmd.Flags().GetStringArray("map")
+		for _, m := range maps {
+			column, target, ok := strings.Cut(m, ":")
+			if !ok {
+				return fmt.Errorf("--map %q must be Column:target", m)
+			}
+			if params.Mapping == nil {
+				params.Mapping = map[string]string{}
+			}
+			params.Mapping[column] = target
+		}
+
+		data, err := os.ReadFile(args[1])
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", args[1], err)
+		}
+		sheet, err := spreadsheet.Read(format, data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[1], err)
+		}
+
+		mgr, driver, err := loadConfigAndDB()
+		if err != nil {
+			return err
+		}
+		defer closeDBWithLog()
+		cfg, err := mgr.Config()
+		if err != nil {
+			return fmt.Errorf("reading configuration: %w", err)
+		}
+		systemUser, err := driver.GetUserByEmail(types.Email("system@modula.local"))
+		if err != nil {
+			return fmt.Errorf("looking up system user: %w", err)
+		}
+		ac := audited.Ctx(types.NodeID(cfg.Node_ID), systemUser.UserID, "sheet-import", "cli")
+
+		report, err := newSheetService(driver, mgr).Import(context.Background(), ac, sheet, params)
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			out, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(out))
+		} else {
+			printSheetReport(cmd, report)
+		}
+		if report.Failed > 0 {
+			return fmt.Errorf("%d rows failed", report.Failed)
+		}
+		return nil
+	},
+}
+
+// printSheetReport writes a text summary of an import report.
+func printSheetReport(cmd *cobra.Command, report *service.SheetImportReport) {
+	out := cmd.OutOrStdout()
+	for _, c := range report.Columns {
+		switch {
+		case c.Ignored:
+			fmt.Fprintf(out, "  column %q: ignored\n", c.Column)
+		case c.Locale != "":
+			fmt.Fprintf(out, "  column %q -> %s@%s\n", c.Column, c.Target, c.Locale)
+		default:
+			fmt.Fprintf(out, "  column %q -> %s\n", c.Column, c.Target)
+		}
+	}
+	for _, row := range report.Rows {
+		if row.Action == "unchanged" || row.Action == "failed" {
+			continue
+		}
+		name := row.Slug
+		if name == "" {
+			name = row.ContentDataID.String()
+		}
+		fmt.Fprintf(out, "  row %d: %s %s %s\n", row.Row, row.Action, name, strings.Join(row.Changed, ", "))
+	}
+	for _, e := range report.Errors {
+		if e.Field != "" {
+			fmt.Fprintf(out, "  row %d: %s: %s\n", e.Row, e.Field, e.Message)
+		} else {
+			fmt.Fprintf(out, "  row %d: %s\n", e.Row, e.Message)
+		}
+	}
+	verb := "Imported"
+	if report.DryRun {
+		verb = "Dry run"
+	}
+	fmt.Fprintf(out, "%s: %d created, %d updated, %d unchanged, %d failed.\n",
+		verb, report.Created, report.Updated, report.Unchanged, report.Failed)
+}
+
+func init() {
+	exportSheetCmd.Flags().String("file", "", "Output file (default: stdout)")
+	exportSheetCmd.Flags().String("format", "", "csv or xlsx (default: from --file extension, else csv)")
+	exportSheetCmd.Flags().StringSlice("locale", nil, "Translation locales to include (default: all enabled)")
+
+	importSheetCmd.Flags().String("format", "", "csv or xlsx (default: from the file extension)")
+	importSheetCmd.Flags().String("key", "", "Match rows by id or slug (default: id, then slug)")
+	importSheetCmd.Flags().StringArray("map", nil, "Column:target mapping (repeatable)")
+	importSheetCmd.Flags().Bool("dry-run", false, "Validate and report without writing")
+	importSheetCmd.Flags().Int("batch-size", 0, "Rows per transaction (default: 100)")
+	importSheetCmd.Flags().Bool("json", false, "Print the report as JSON")
+
+	exportCmd.AddCommand(exportSheetCmd)
+	importCmd.AddCommand(importSheetCmd)
+}
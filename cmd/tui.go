@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/middleware"
 	"github.com/hegner123/modulacms/internal/registry"
 	"github.com/hegner123/modulacms/internal/tui"
 	"github.com/hegner123/modulacms/internal/utility"
@@ -90,7 +92,7 @@ Examples:
 		if err != nil {
 			return err
 		}
-		model, _ := tui.InitialModel(&verbose, cfg, driver, utility.DefaultLogger, nil, mgr, nil, nil)
+		model, _ := tui.InitialModel(&verbose, cfg, driver, utility.DefaultLogger, nil, mgr, tuiPermissions(driver), nil, nil)
 		if _, ok := tui.CliRun(&model); !ok {
 			os.Exit(1)
 		}
@@ -98,3 +100,13 @@ Examples:
 		return nil
 	},
 }
+
+// tuiPermissions loads the permission cache for a standalone TUI session. A
+// failed load leaves the cache empty, so permission-gated actions are refused.
+func tuiPermissions(driver db.DbDriver) *middleware.PermissionCache {
+	pc := middleware.NewPermissionCache()
+	if err := pc.Load(driver); err != nil {
+		utility.DefaultLogger.Warn("permission cache load failed", err)
+	}
+	return pc
+}
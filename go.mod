@@ -38,6 +38,7 @@ require (
 	golang.org/x/oauth2 v0.29.0
 	golang.org/x/sync v0.20.0
 	golang.org/x/time v0.15.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -44,6 +44,7 @@ var NavItems = []NavItem{
 	// Users & Access
 	{Section: "Users & Access", Label: "Users", Href: "/admin/users", Icon: "users", Permission: "users:read"},
 	{Section: "Users & Access", Label: "Roles", Href: "/admin/users/roles", Icon: "shield", Permission: "roles:read"},
+	{Section: "Users & Access", Label: "Groups", Href: "/admin/users/groups", Icon: "users", Permission: "roles:read"},
 	{Section: "Users & Access", Label: "Tokens", Href: "/admin/users/tokens", Icon: "key", Permission: "tokens:read"},
 	{Section: "Users & Access", Label: "Sessions", Href: "/admin/sessions", Icon: "monitor", Permission: "sessions:read"},
 
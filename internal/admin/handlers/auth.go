@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"net"
 	"net/http"
 	"strings"
@@ -17,6 +18,7 @@ import (
 	"github.com/hegner123/modulacms/internal/db/types"
 	"github.com/hegner123/modulacms/internal/email"
 	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/service"
 	"github.com/hegner123/modulacms/internal/utility"
 )
 
@@ -38,9 +40,11 @@ func LoginPageHandler() http.HandlerFunc {
 }
 
 // LoginSubmitHandler processes login form submissions.
-func LoginSubmitHandler(mgr *config.Manager) http.HandlerFunc {
+// Credentials are checked by the auth service so that account lockout and
+// password expiry apply to the admin panel as well as the API.
+func LoginSubmitHandler(svc *service.Registry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		cfg, err := mgr.Config()
+		cfg, err := svc.Config()
 		if err != nil {
 			http.Error(w, "configuration unavailable", http.StatusInternalServerError)
 			return
@@ -67,52 +71,33 @@ func LoginSubmitHandler(mgr *config.Manager) http.HandlerFunc {
 			return
 		}
 
-		d := db.ConfigDB(*cfg)
-
-		user, userErr := d.GetUserByEmail(types.Email(email))
-		if userErr != nil || user == nil {
-			w.WriteHeader(http.StatusUnprocessableEntity)
-			Render(w, r, pages.Login(csrfToken, utility.Version, nextURL, "Invalid credentials"))
-			return
-		}
-
-		if !auth.CheckPasswordHash(password, user.Hash) {
-			w.WriteHeader(http.StatusUnprocessableEntity)
-			Render(w, r, pages.Login(csrfToken, utility.Version, nextURL, "Invalid credentials"))
-			return
-		}
-
-		// Generate session token
-		tokenBytes := make([]byte, 32)
-		if _, randErr := rand.Read(tokenBytes); randErr != nil {
-			utility.DefaultLogger.Error("failed to generate session token", randErr)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-			return
-		}
-		sessionToken := hex.EncodeToString(tokenBytes)
-
-		// Create session in database
-		expiresAt := types.NewTimestamp(time.Now().Add(24 * time.Hour))
 		clientIP, _, splitErr := net.SplitHostPort(r.RemoteAddr)
 		if splitErr != nil {
 			clientIP = r.RemoteAddr
 		}
-		ac := audited.Ctx(types.NodeID(cfg.Node_ID), user.UserID, middleware.RequestIDFromContext(r.Context()), clientIP)
-		_, sessionErr := d.CreateSession(r.Context(), ac, db.CreateSessionParams{
-			UserID:      types.NullableUserID{ID: user.UserID, Valid: true},
-			ExpiresAt:   expiresAt,
-			SessionData: db.NewNullString(sessionToken),
-			IpAddress:   db.NewNullString(r.RemoteAddr),
-			UserAgent:   db.NewNullString(r.UserAgent()),
+		result, loginErr := svc.Auth.Login(r.Context(), service.LoginInput{
+			Email:     email,
+			Password:  password,
+			IPAddress: clientIP,
+			UserAgent: r.UserAgent(),
 		})
-		if sessionErr != nil {
-			utility.DefaultLogger.Error("failed to create session", sessionErr)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		if loginErr != nil {
+			switch {
+			case service.IsForbidden(loginErr):
+				w.WriteHeader(http.StatusForbidden)
+				Render(w, r, pages.Login(csrfToken, utility.Version, nextURL, "Your password has expired. Reset it to sign in."))
+			case service.IsUnauthorized(loginErr), service.IsValidation(loginErr):
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				Render(w, r, pages.Login(csrfToken, utility.Version, nextURL, "Invalid credentials"))
+			default:
+				utility.DefaultLogger.Error("failed to sign in", loginErr)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+			}
 			return
 		}
 
 		// Set cookie
-		if cookieErr := middleware.WriteCookie(w, cfg, sessionToken, user.UserID); cookieErr != nil {
+		if cookieErr := middleware.WriteCookie(w, cfg, result.SessionToken, result.User.UserID); cookieErr != nil {
 			utility.DefaultLogger.Error("failed to set cookie", cookieErr)
 			http.Error(w, "Internal server error", http.StatusInternalServerError)
 			return
@@ -283,8 +268,9 @@ func ResetPasswordPageHandler(driver db.DbDriver) http.HandlerFunc {
 
 // ResetPasswordSubmitHandler processes the password reset form.
 // Validates the token, updates the password, and revokes the token.
-func ResetPasswordSubmitHandler(mgr *config.Manager, driver db.DbDriver) http.HandlerFunc {
+func ResetPasswordSubmitHandler(svc *service.Registry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		driver := svc.Driver()
 		if parseErr := r.ParseForm(); parseErr != nil {
 			http.Error(w, "Invalid form data", http.StatusBadRequest)
 			return
@@ -306,12 +292,6 @@ func ResetPasswordSubmitHandler(mgr *config.Manager, driver db.DbDriver) http.Ha
 			return
 		}
 
-		if len(password) < 8 {
-			w.WriteHeader(http.StatusUnprocessableEntity)
-			Render(w, r, pages.ResetPassword(csrfToken, utility.Version, token, "Password must be at least 8 characters.", ""))
-			return
-		}
-
 		if password != confirmPassword {
 			w.WriteHeader(http.StatusUnprocessableEntity)
 			Render(w, r, pages.ResetPassword(csrfToken, utility.Version, token, "Passwords do not match.", ""))
@@ -340,6 +320,18 @@ func ResetPasswordSubmitHandler(mgr *config.Manager, driver db.DbDriver) http.Ha
 			return
 		}
 
+		if policyErr := svc.Auth.CheckNewPassword(user, password); policyErr != nil {
+			var ve *service.ValidationError
+			if !errors.As(policyErr, &ve) || len(ve.Errors) == 0 {
+				utility.DefaultLogger.Error("failed to check password policy", policyErr)
+				Render(w, r, pages.ResetPassword(csrfToken, utility.Version, token, "An error occurred. Please try again.", ""))
+				return
+			}
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			Render(w, r, pages.ResetPassword(csrfToken, utility.Version, token, ve.Errors[0].Message+".", ""))
+			return
+		}
+
 		hash, err := auth.HashPassword(password)
 		if err != nil {
 			utility.DefaultLogger.Error("failed to hash password", err)
@@ -347,7 +339,7 @@ func ResetPasswordSubmitHandler(mgr *config.Manager, driver db.DbDriver) http.Ha
 			return
 		}
 
-		cfg, cfgErr := mgr.Config()
+		cfg, cfgErr := svc.Config()
 		if cfgErr != nil {
 			Render(w, r, pages.ResetPassword(csrfToken, utility.Version, token, "An error occurred. Please try again.", ""))
 			return
@@ -374,6 +366,7 @@ func ResetPasswordSubmitHandler(mgr *config.Manager, driver db.DbDriver) http.Ha
 			Render(w, r, pages.ResetPassword(csrfToken, utility.Version, token, "An error occurred. Please try again.", ""))
 			return
 		}
+		svc.Auth.PasswordChanged(user.UserID, user.Hash, hash)
 
 		// Revoke the used token.
 		_, revokeErr := driver.UpdateToken(r.Context(), ac, db.UpdateTokenParams{
@@ -1587,7 +1587,13 @@ func ContentPublishHandler(driver db.DbDriver, mgr *config.Manager, dispatcher p
 		contentID := types.ContentID(id)
 		locale := r.URL.Query().Get("locale")
 		publishAll := !cfg.Node_Level_Publish
-		_, pubErr := publishing.PublishContent(r.Context(), driver, contentID, locale, user.UserID, ac, cfg.VersionMaxPerContent(), publishAll, dispatcher, nil)
+		var pubErr error
+		if cfg.I18n_Require_Current_Translations && cfg.I18nEnabled() {
+			pubErr = publishing.CheckTranslationsCurrent(r.Context(), driver, contentID, locale, cfg.I18nDefaultLocale())
+		}
+		if pubErr == nil {
+			_, pubErr = publishing.PublishContent(r.Context(), driver, contentID, locale, user.UserID, ac, cfg.VersionMaxPerContent(), publishAll, dispatcher, nil)
+		}
 		if pubErr != nil {
 			utility.DefaultLogger.Error("admin publish content failed", pubErr)
 			toastMsg := fmt.Sprintf(`{"showToast": {"message": "Publish failed: %s", "type": "error"}}`, pubErr.Error())
@@ -1837,7 +1843,10 @@ func ContentRestoreVersionHandler(driver db.DbDriver, mgr *config.Manager) http.
 	}
 }
 
-// ContentVersionCompareHandler returns a side-by-side diff of two version snapshots.
+// ContentVersionCompareHandler returns the diff between two version snapshots,
+// or between a version and the live draft ("draft"). Version a is the old side
+// and defaults to the published version; b is the new side and defaults to
+// the draft.
 func ContentVersionCompareHandler(driver db.DbDriver) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := r.PathValue("id")
@@ -1846,28 +1855,16 @@ func ContentVersionCompareHandler(driver db.DbDriver) http.HandlerFunc {
 			return
 		}
 
-		aID := r.URL.Query().Get("a")
-		bID := r.URL.Query().Get("b")
-		if aID == "" || bID == "" {
-			http.Error(w, "Both version IDs (a and b) are required", http.StatusBadRequest)
-			return
-		}
-
-		versionA, errA := driver.GetContentVersion(types.ContentVersionID(aID))
-		if errA != nil {
-			utility.DefaultLogger.Error("failed to get version A", errA)
-			http.Error(w, "failed to load version A", http.StatusInternalServerError)
-			return
-		}
-
-		versionB, errB := driver.GetContentVersion(types.ContentVersionID(bID))
-		if errB != nil {
-			utility.DefaultLogger.Error("failed to get version B", errB)
-			http.Error(w, "failed to load version B", http.StatusInternalServerError)
+		q := r.URL.Query()
+		diff, err := publishing.DiffContentVersions(r.Context(), driver, types.ContentID(id),
+			types.ContentVersionID(q.Get("a")), types.ContentVersionID(q.Get("b")), q.Get("locale"))
+		if err != nil {
+			utility.DefaultLogger.Error("failed to diff content versions", err)
+			http.Error(w, "failed to compare versions", http.StatusInternalServerError)
 			return
 		}
 
-		Render(w, r, partials.VersionDiff(*versionA, *versionB))
+		Render(w, r, partials.VersionDiff(diff))
 	}
 }
 
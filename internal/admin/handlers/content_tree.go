@@ -195,7 +195,8 @@ func ContentTreePageViewHandler(driver db.DbDriver, mgr *config.Manager) http.Ha
 
 		// Build components
 		csrfToken := CSRFTokenFromContext(r.Context())
-		pageView := partials.ContentPageView(pageTitle, status, rootID, childBlocks, hasPublishPerm, csrfToken)
+		canForceLease := middleware.ContextIsAdmin(r.Context())
+		pageView := partials.ContentPageView(pageTitle, status, rootID, childBlocks, hasPublishPerm, canForceLease, csrfToken)
 		sidebarComp := partials.ContentTreeBlockSidebar(rootNode, sidebarChildren, content.ContentDataID.String(), pageTitle)
 		breadcrumbComp := partials.ContentBreadcrumbNav(crumbs)
 
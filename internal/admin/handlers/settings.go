@@ -34,10 +34,14 @@ func SettingsHandler(svc *service.Registry) http.HandlerFunc {
 			}
 		}
 
+		// Render references rather than resolved values; a saved form posts
+		// the reference back unchanged.
+		display := config.UnresolvedConfig(*cfg)
+
 		layout := NewAdminData(r, "Settings")
 		RenderNav(w, r, "Settings",
-			pages.SettingsContent(cfg, layout.CSRFToken, searchStatus),
-			pages.Settings(layout, cfg, searchStatus))
+			pages.SettingsContent(&display, layout.CSRFToken, searchStatus),
+			pages.Settings(layout, &display, searchStatus))
 	}
 }
 
@@ -390,9 +394,10 @@ func setJSONIfPresent(r *http.Request, updates map[string]any, key string) {
 }
 
 // setOauthEndpointIfPresent builds the oauth_endpoint map from individual
-// form fields (oauth_auth_url, oauth_token_url, oauth_userinfo_url).
+// form fields (oauth_auth_url, oauth_token_url, oauth_userinfo_url,
+// oauth_introspection_url).
 func setOauthEndpointIfPresent(r *http.Request, updates map[string]any) {
-	hasAny := r.Form.Has("oauth_auth_url") || r.Form.Has("oauth_token_url") || r.Form.Has("oauth_userinfo_url")
+	hasAny := r.Form.Has("oauth_auth_url") || r.Form.Has("oauth_token_url") || r.Form.Has("oauth_userinfo_url") || r.Form.Has("oauth_introspection_url")
 	if !hasAny {
 		return
 	}
@@ -415,6 +420,12 @@ func setOauthEndpointIfPresent(r *http.Request, updates map[string]any) {
 			endpoints[string(config.OauthUserInfoURL)] = v
 		}
 	}
+	if r.Form.Has("oauth_introspection_url") {
+		v := strings.TrimSpace(r.FormValue("oauth_introspection_url"))
+		if v != "" {
+			endpoints[string(config.OauthIntrospectionURL)] = v
+		}
+	}
 	updates["oauth_endpoint"] = endpoints
 }
 
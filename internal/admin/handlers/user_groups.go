@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/admin/pages"
+	"github.com/hegner123/modulacms/internal/admin/partials"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/service"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// UserGroupsListHandler handles GET /admin/users/groups.
+// Lists user groups with a sidebar; the first group's detail is rendered by default.
+func UserGroupsListHandler(svc *service.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groups, err := svc.RBAC.ListUserGroups(r.Context())
+		if err != nil {
+			utility.DefaultLogger.Error("failed to list user groups", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		groupList := make([]db.UserGroups, 0)
+		if groups != nil {
+			groupList = *groups
+		}
+
+		var defaultRoles, defaultMembers []partials.CheckOption
+		if len(groupList) > 0 {
+			defaultRoles, defaultMembers = buildUserGroupOptions(r, svc, groupList[0].UserGroupID)
+		}
+
+		csrfToken := CSRFTokenFromContext(r.Context())
+
+		if IsNavHTMX(r) {
+			w.Header().Set("HX-Trigger", `{"pageTitle": "User Groups"}`)
+			Render(w, r, pages.UserGroupsListContent(groupList, defaultRoles, defaultMembers, csrfToken))
+			return
+		}
+
+		layout := NewAdminData(r, "User Groups")
+		Render(w, r, pages.UserGroupsList(layout, groupList, defaultRoles, defaultMembers, csrfToken))
+	}
+}
+
+// UserGroupDetailHandler handles GET /admin/users/groups/{id}.
+// Returns the detail partial for a single group (HTMX swap target).
+func UserGroupDetailHandler(svc *service.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		if id == "" {
+			http.Error(w, "missing group ID", http.StatusBadRequest)
+			return
+		}
+
+		group, err := svc.RBAC.GetUserGroup(r.Context(), types.UserGroupID(id))
+		if err != nil {
+			utility.DefaultLogger.Error("failed to get user group", err)
+			http.Error(w, "Group not found", http.StatusNotFound)
+			return
+		}
+
+		roles, members := buildUserGroupOptions(r, svc, group.UserGroupID)
+
+		csrfToken := CSRFTokenFromContext(r.Context())
+		Render(w, r, partials.UserGroupDetail(*group, roles, members, csrfToken))
+	}
+}
+
+// UserGroupNewFormHandler handles GET /admin/users/groups/new.
+// Returns the new-group form partial (HTMX swap target).
+func UserGroupNewFormHandler(svc *service.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		csrfToken := CSRFTokenFromContext(r.Context())
+		Render(w, r, partials.UserGroupNewForm(csrfToken))
+	}
+}
+
+// UserGroupCreateHandler handles POST /admin/users/groups.
+func UserGroupCreateHandler(svc *service.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c, cfgErr := svc.Config()
+		if cfgErr != nil {
+			http.Error(w, "configuration unavailable", http.StatusInternalServerError)
+			return
+		}
+
+		if parseErr := r.ParseForm(); parseErr != nil {
+			http.Error(w, "Invalid form data", http.StatusBadRequest)
+			return
+		}
+
+		ac := middleware.AuditContextFromRequest(r, *c)
+
+		_, err := svc.RBAC.CreateUserGroup(r.Context(), ac, service.CreateUserGroupInput{
+			Label:       strings.TrimSpace(r.FormValue("label")),
+			Description: strings.TrimSpace(r.FormValue("description")),
+		})
+		if err != nil {
+			service.HandleServiceError(w, r, err)
+			return
+		}
+
+		if !IsHTMX(r) {
+			http.Redirect(w, r, "/admin/users/groups", http.StatusSeeOther)
+			return
+		}
+		w.Header().Set("HX-Trigger", `{"showToast": {"message": "Group created", "type": "success"}}`)
+		w.Header().Set("HX-Redirect", "/admin/users/groups")
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// UserGroupUpdateHandler handles POST /admin/users/groups/{id}.
+// Updates the group's label and description, then syncs its roles and members.
+func UserGroupUpdateHandler(svc *service.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c, cfgErr := svc.Config()
+		if cfgErr != nil {
+			http.Error(w, "configuration unavailable", http.StatusInternalServerError)
+			return
+		}
+
+		id := r.PathValue("id")
+		if id == "" {
+			http.Error(w, "missing group ID", http.StatusBadRequest)
+			return
+		}
+
+		if parseErr := r.ParseForm(); parseErr != nil {
+			http.Error(w, "Invalid form data", http.StatusBadRequest)
+			return
+		}
+
+		ac := middleware.AuditContextFromRequest(r, *c)
+		groupID := types.UserGroupID(id)
+
+		_, err := svc.RBAC.UpdateUserGroup(r.Context(), ac, service.UpdateUserGroupInput{
+			UserGroupID: groupID,
+			Label:       strings.TrimSpace(r.FormValue("label")),
+			Description: strings.TrimSpace(r.FormValue("description")),
+		})
+		if err != nil {
+			service.HandleServiceError(w, r, err)
+			return
+		}
+
+		var roleIDs []types.RoleID
+		for _, rid := range r.Form["roles"] {
+			if trimmed := strings.TrimSpace(rid); trimmed != "" {
+				roleIDs = append(roleIDs, types.RoleID(trimmed))
+			}
+		}
+		failedRoles, syncErr := svc.RBAC.SyncUserGroupRoles(r.Context(), ac, groupID, roleIDs, middleware.ContextIsAdmin(r.Context()))
+		if syncErr != nil {
+			utility.DefaultLogger.Error("failed to sync user group roles", syncErr)
+		}
+		if len(failedRoles) > 0 {
+			utility.DefaultLogger.Error("some group roles failed to assign", nil, "failed_ids", failedRoles)
+		}
+
+		var userIDs []types.UserID
+		for _, uid := range r.Form["members"] {
+			if trimmed := strings.TrimSpace(uid); trimmed != "" {
+				userIDs = append(userIDs, types.UserID(trimmed))
+			}
+		}
+		failedMembers, syncErr := svc.RBAC.SyncUserGroupMembers(r.Context(), ac, groupID, userIDs, middleware.ContextIsAdmin(r.Context()))
+		if syncErr != nil {
+			utility.DefaultLogger.Error("failed to sync user group members", syncErr)
+		}
+		if len(failedMembers) > 0 {
+			utility.DefaultLogger.Error("some group members failed to add", nil, "failed_ids", failedMembers)
+		}
+
+		if !IsHTMX(r) {
+			http.Redirect(w, r, "/admin/users/groups", http.StatusSeeOther)
+			return
+		}
+		w.Header().Set("HX-Trigger", `{"showToast": {"message": "Group updated", "type": "success"}}`)
+		w.Header().Set("HX-Redirect", "/admin/users/groups")
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// UserGroupDeleteHandler handles DELETE /admin/users/groups/{id}.
+// HTMX-only endpoint. Delegates junction cleanup to RBACService.
+func UserGroupDeleteHandler(svc *service.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c, cfgErr := svc.Config()
+		if cfgErr != nil {
+			http.Error(w, "configuration unavailable", http.StatusInternalServerError)
+			return
+		}
+
+		if !IsHTMX(r) {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := r.PathValue("id")
+		if id == "" {
+			http.Error(w, "missing group ID", http.StatusBadRequest)
+			return
+		}
+
+		ac := middleware.AuditContextFromRequest(r, *c)
+		if err := svc.RBAC.DeleteUserGroup(r.Context(), ac, types.UserGroupID(id)); err != nil {
+			service.HandleServiceError(w, r, err)
+			return
+		}
+
+		w.Header().Set("HX-Trigger", `{"showToast": {"message": "Group deleted", "type": "success"}}`)
+		w.Header().Set("HX-Redirect", "/admin/users/groups")
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// buildUserGroupOptions assembles the role and member checkbox lists for a group.
+func buildUserGroupOptions(r *http.Request, svc *service.Registry, groupID types.UserGroupID) ([]partials.CheckOption, []partials.CheckOption) {
+	grantedRoles := make(map[types.RoleID]bool)
+	if grs, err := svc.RBAC.ListUserGroupRoles(r.Context(), groupID); err != nil {
+		utility.DefaultLogger.Error("failed to list user group roles", err)
+	} else if grs != nil {
+		for _, gr := range *grs {
+			grantedRoles[gr.RoleID] = true
+		}
+	}
+
+	members := make(map[types.UserID]bool)
+	if ms, err := svc.RBAC.ListUserGroupMembers(r.Context(), groupID); err != nil {
+		utility.DefaultLogger.Error("failed to list user group members", err)
+	} else if ms != nil {
+		for _, m := range *ms {
+			members[m.UserID] = true
+		}
+	}
+
+	var roleOpts []partials.CheckOption
+	if roles, err := svc.RBAC.ListRoles(r.Context()); err != nil {
+		utility.DefaultLogger.Error("failed to list roles", err)
+	} else if roles != nil {
+		for _, role := range *roles {
+			roleOpts = append(roleOpts, partials.CheckOption{
+				Value:   role.RoleID.String(),
+				Label:   role.Label,
+				Checked: grantedRoles[role.RoleID],
+			})
+		}
+	}
+
+	var memberOpts []partials.CheckOption
+	if users, err := svc.Users.ListUsers(r.Context()); err != nil {
+		utility.DefaultLogger.Error("failed to list users", err)
+	} else if users != nil {
+		for _, u := range *users {
+			memberOpts = append(memberOpts, partials.CheckOption{
+				Value:   u.UserID.String(),
+				Label:   u.Username,
+				Checked: members[u.UserID],
+			})
+		}
+	}
+
+	return roleOpts, memberOpts
+}
@@ -101,21 +101,23 @@ func UserDetailHandler(svc *service.Registry) http.HandlerFunc {
 			oauthConfigured = cfg.Oauth_Client_Id != ""
 		}
 
+		access := buildUserAccess(r, svc, *user, *roles)
+
 		csrfToken := CSRFTokenFromContext(r.Context())
 
 		if IsNavHTMX(r) {
 			w.Header().Set("HX-Trigger", `{"pageTitle": "User: `+user.Name+`"}`)
-			Render(w, r, partials.UserDetailContent(*user, *roles, sshKeys, oauthConns, oauthConfigured, csrfToken))
+			Render(w, r, partials.UserDetailContent(*user, *roles, access, sshKeys, oauthConns, oauthConfigured, csrfToken))
 			return
 		}
 
 		if IsHTMX(r) {
-			Render(w, r, partials.UserDetailContent(*user, *roles, sshKeys, oauthConns, oauthConfigured, csrfToken))
+			Render(w, r, partials.UserDetailContent(*user, *roles, access, sshKeys, oauthConns, oauthConfigured, csrfToken))
 			return
 		}
 
 		layout := NewAdminData(r, "User: "+user.Name)
-		Render(w, r, pages.UserDetail(layout, *user, *roles, sshKeys, oauthConns, oauthConfigured, csrfToken))
+		Render(w, r, pages.UserDetail(layout, *user, *roles, access, sshKeys, oauthConns, oauthConfigured, csrfToken))
 	}
 }
 
@@ -270,6 +272,124 @@ func UserDeleteHandler(svc *service.Registry) http.HandlerFunc {
 }
 
 // userServiceErrorToMap converts a user service error into a map[string]string
+
+// UserAccessUpdateHandler handles POST /admin/users/{id}/access.
+// Syncs additional role assignments and group memberships via RBACService.
+func UserAccessUpdateHandler(svc *service.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c, cfgErr := svc.Config()
+		if cfgErr != nil {
+			http.Error(w, "configuration unavailable", http.StatusInternalServerError)
+			return
+		}
+
+		id := r.PathValue("id")
+		if id == "" {
+			http.Error(w, "missing user ID", http.StatusBadRequest)
+			return
+		}
+
+		if parseErr := r.ParseForm(); parseErr != nil {
+			http.Error(w, "Invalid form data", http.StatusBadRequest)
+			return
+		}
+
+		var roleIDs []types.RoleID
+		for _, rid := range r.Form["roles"] {
+			if trimmed := strings.TrimSpace(rid); trimmed != "" {
+				roleIDs = append(roleIDs, types.RoleID(trimmed))
+			}
+		}
+		var groupIDs []types.UserGroupID
+		for _, gid := range r.Form["groups"] {
+			if trimmed := strings.TrimSpace(gid); trimmed != "" {
+				groupIDs = append(groupIDs, types.UserGroupID(trimmed))
+			}
+		}
+
+		ac := middleware.AuditContextFromRequest(r, *c)
+		userID := types.UserID(id)
+
+		failedRoles, err := svc.RBAC.SyncUserRoles(r.Context(), ac, userID, roleIDs, middleware.ContextIsAdmin(r.Context()))
+		if err != nil {
+			service.HandleServiceError(w, r, err)
+			return
+		}
+		if len(failedRoles) > 0 {
+			utility.DefaultLogger.Error("some roles failed to assign", nil, "failed_ids", failedRoles)
+		}
+
+		failedGroups, err := svc.RBAC.SyncUserGroupMemberships(r.Context(), ac, userID, groupIDs, middleware.ContextIsAdmin(r.Context()))
+		if err != nil {
+			service.HandleServiceError(w, r, err)
+			return
+		}
+		if len(failedGroups) > 0 {
+			utility.DefaultLogger.Error("some group memberships failed to save", nil, "failed_ids", failedGroups)
+		}
+
+		if !IsHTMX(r) {
+			http.Redirect(w, r, "/admin/users/"+id, http.StatusSeeOther)
+			return
+		}
+		w.Header().Set("HX-Trigger", `{"showToast": {"message": "Access updated", "type": "success"}}`)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// buildUserAccess assembles the role and group checkbox lists for a user.
+// The primary role is always checked and cannot be unchecked here.
+func buildUserAccess(r *http.Request, svc *service.Registry, user db.Users, roles []db.Roles) partials.UserAccessData {
+	assignedRoles := make(map[types.RoleID]bool)
+	if urs, err := svc.RBAC.ListUserRoles(r.Context(), user.UserID); err != nil {
+		utility.DefaultLogger.Error("failed to list user roles", err)
+	} else if urs != nil {
+		for _, ur := range *urs {
+			assignedRoles[ur.RoleID] = true
+		}
+	}
+
+	var access partials.UserAccessData
+	for _, role := range roles {
+		primary := string(role.RoleID) == user.Role
+		opt := partials.CheckOption{
+			Value:    role.RoleID.String(),
+			Label:    role.Label,
+			Checked:  primary || assignedRoles[role.RoleID],
+			Disabled: primary,
+		}
+		if primary {
+			opt.Note = "Primary"
+		}
+		access.Roles = append(access.Roles, opt)
+	}
+
+	memberOf := make(map[types.UserGroupID]bool)
+	if members, err := svc.RBAC.ListUserGroupMembershipsByUser(r.Context(), user.UserID); err != nil {
+		utility.DefaultLogger.Error("failed to list user group memberships", err)
+	} else if members != nil {
+		for _, m := range *members {
+			memberOf[m.UserGroupID] = true
+		}
+	}
+
+	groups, err := svc.RBAC.ListUserGroups(r.Context())
+	if err != nil {
+		utility.DefaultLogger.Error("failed to list user groups", err)
+		return access
+	}
+	if groups != nil {
+		for _, g := range *groups {
+			access.Groups = append(access.Groups, partials.CheckOption{
+				Value:   g.UserGroupID.String(),
+				Label:   g.Label,
+				Checked: memberOf[g.UserGroupID],
+			})
+		}
+	}
+	return access
+}
+
 // suitable for form re-rendering with field-level error messages.
 func userServiceErrorToMap(err error) map[string]string {
 	if service.IsValidation(err) {
@@ -117,6 +117,21 @@ func ResourceDisplayName(s string) string {
 	return strings.Join(parts, " ")
 }
 
+// CheckOption is one checkbox entry in a role, group, or member assignment list.
+type CheckOption struct {
+	Value    string
+	Label    string
+	Checked  bool
+	Disabled bool
+	Note     string
+}
+
+// UserAccessData holds the role and group assignment lists for the user detail page.
+type UserAccessData struct {
+	Roles  []CheckOption
+	Groups []CheckOption
+}
+
 // PaginationPageData holds pagination state for use in templ partials.
 // This type lives in partials to avoid import cycles between handlers and pages/partials.
 type PaginationPageData struct {
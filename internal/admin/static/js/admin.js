@@ -418,6 +418,7 @@ const componentFiles = [
     '/admin/static/js/components/mcms-media-grid.js',
     '/admin/static/js/components/mcms-focal-point.js',
     '/admin/static/js/components/mcms-publish-button.js',
+    '/admin/static/js/components/mcms-presence.js',
     '/admin/static/js/components/mcms-command-palette.js',
     '/admin/static/js/components/mcms-repeater.js',
 ];
@@ -563,6 +564,14 @@ document.addEventListener('click', function(e) {
     });
     item.dataset.selected = '';
     item.classList.add('selected'); // DUAL: data-selected + class
+    // Show what restoring this version would change in the current draft.
+    var diffPanel = list.querySelector('[data-version-diff-panel]');
+    if (diffPanel && list.dataset.compareUrl && typeof htmx !== 'undefined') {
+        htmx.ajax('GET', list.dataset.compareUrl + '?a=draft&b=' + encodeURIComponent(item.dataset.versionId), {
+            target: diffPanel,
+            swap: 'innerHTML'
+        });
+    }
     var dialog = document.getElementById('version-history-dialog');
     if (!dialog) return;
     var hiddenInput = dialog.querySelector('#version-restore-id');
@@ -0,0 +1,175 @@
+/**
+ * <mcms-presence> -- Edit lease and presence badges for a content page.
+ *
+ * On connect, takes an editing lease on the content node. If another user is
+ * already editing, falls back to a viewing lease and shows who holds the
+ * node. Renews the lease with a heartbeat, shows who else is viewing or
+ * editing from the presence stream, and releases the lease when the page is
+ * left. Leases are advisory: they warn, they do not block saving.
+ *
+ * Attributes:
+ *   content-id - Content node the page edits
+ *   can-force  - Present when the user may take over another editor's lease
+ */
+class McmsPresence extends HTMLElement {
+    connectedCallback() {
+        this._contentId = this.getAttribute('content-id') || '';
+        this._canForce = this.hasAttribute('can-force');
+        this._lease = null;
+        this._presence = null;
+        this._lost = false;
+        if (!this._contentId) return;
+
+        var self = this;
+        this._onPageHide = function() { self._release(); };
+        window.addEventListener('pagehide', this._onPageHide);
+
+        this._acquire(false);
+        this._heartbeat = setInterval(function() { self._renew(); }, 20000);
+        this._source = new EventSource('/api/v1/content/leases/stream?content_id=' + encodeURIComponent(this._contentId));
+        this._source.addEventListener('presence', function(e) {
+            try {
+                self._presence = JSON.parse(e.data);
+            } catch (err) {
+                return;
+            }
+            self._render();
+        });
+    }
+
+    disconnectedCallback() {
+        clearInterval(this._heartbeat);
+        if (this._source) this._source.close();
+        if (this._onPageHide) window.removeEventListener('pagehide', this._onPageHide);
+        this._release();
+    }
+
+    // Takes an editing lease, or a viewing lease when the node is held.
+    _acquire(force) {
+        var self = this;
+        this._request('POST', '/api/v1/content/leases', {
+            content_data_id: this._contentId,
+            mode: 'editing',
+            lease_id: this._lease ? this._lease.lease_id : '',
+            client: 'admin',
+            force: force
+        }).then(function(res) {
+            if (res.status === 409 || res.status === 403) {
+                return self._request('POST', '/api/v1/content/leases', {
+                    content_data_id: self._contentId,
+                    mode: 'viewing',
+                    lease_id: self._lease ? self._lease.lease_id : '',
+                    client: 'admin'
+                });
+            }
+            return res;
+        }).then(function(res) {
+            if (!res.ok) return null;
+            return res.json();
+        }).then(function(lease) {
+            if (lease) {
+                self._lease = lease;
+                self._lost = false;
+            }
+            self._render();
+        }).catch(function() {});
+    }
+
+    _renew() {
+        if (!this._lease) return;
+        var self = this;
+        var wasEditing = this._lease.mode === 'editing';
+        this._request('POST', '/api/v1/content/leases/heartbeat', { lease_id: this._lease.lease_id })
+            .then(function(res) {
+                if (res.status === 409) {
+                    // The lease expired while the tab was asleep; take a new one.
+                    self._lease = null;
+                    self._lost = wasEditing;
+                    self._acquire(false);
+                    return null;
+                }
+                return res.ok ? res.json() : null;
+            }).then(function(lease) {
+                if (!lease) return;
+                self._lease = lease;
+                if (wasEditing && lease.mode !== 'editing') self._lost = true;
+                self._render();
+            }).catch(function() {});
+    }
+
+    _release() {
+        if (!this._lease) return;
+        var id = this._lease.lease_id;
+        this._lease = null;
+        fetch('/api/v1/content/leases?lease_id=' + encodeURIComponent(id), {
+            method: 'DELETE',
+            credentials: 'same-origin',
+            keepalive: true,
+            headers: { 'X-CSRF-Token': this._getCSRF() }
+        }).catch(function() {});
+    }
+
+    _request(method, url, body) {
+        return fetch(url, {
+            method: method,
+            credentials: 'same-origin',
+            headers: { 'Content-Type': 'application/json', 'X-CSRF-Token': this._getCSRF() },
+            body: JSON.stringify(body)
+        });
+    }
+
+    _render() {
+        var mine = this._lease ? this._lease.lease_id : '';
+        var editor = this._presence ? this._presence.editor : null;
+        var viewers = this._presence ? this._presence.viewers || [] : [];
+        var html = '<div class="inline-flex items-center gap-2 text-xs">';
+
+        if (editor && editor.lease_id !== mine) {
+            html += '<span class="inline-flex items-center gap-1.5 rounded-full bg-yellow-500/10 px-2.5 py-1 font-medium text-yellow-400">'
+                + '<span class="size-2 rounded-full bg-yellow-400"></span>'
+                + this._escape(this._name(editor)) + ' is editing</span>';
+            if (this._canForce) {
+                html += '<button type="button" class="rounded-md bg-white/10 px-2 py-1 font-medium text-white hover:bg-white/20" data-presence-force>Take over</button>';
+            }
+        } else if (this._lost) {
+            html += '<span class="inline-flex items-center gap-1.5 rounded-full bg-red-500/10 px-2.5 py-1 font-medium text-red-400">Edit lease lost</span>';
+        } else if (this._lease && this._lease.mode === 'editing') {
+            html += '<span class="inline-flex items-center gap-1.5 rounded-full bg-green-500/10 px-2.5 py-1 font-medium text-green-400">'
+                + '<span class="size-2 rounded-full bg-green-400"></span>Editing</span>';
+        }
+
+        var others = viewers.filter(function(v) { return v.lease_id !== mine; });
+        if (others.length > 0) {
+            var self = this;
+            var names = others.map(function(v) { return self._name(v); }).join(', ');
+            html += '<span class="inline-flex items-center gap-1.5 text-gray-400" title="' + this._escape(names) + '">'
+                + '<span class="size-2 rounded-full bg-gray-400"></span>'
+                + others.length + (others.length === 1 ? ' viewer' : ' viewers') + '</span>';
+        }
+        html += '</div>';
+        this.innerHTML = html;
+
+        var force = this.querySelector('[data-presence-force]');
+        if (force) {
+            var el = this;
+            force.addEventListener('click', function() { el._acquire(true); });
+        }
+    }
+
+    _name(lease) {
+        return lease.username || lease.user_id;
+    }
+
+    _escape(s) {
+        var div = document.createElement('div');
+        div.textContent = s;
+        return div.innerHTML;
+    }
+
+    _getCSRF() {
+        var meta = document.querySelector('meta[name="csrf-token"]');
+        return meta ? meta.content : '';
+    }
+}
+
+customElements.define('mcms-presence', McmsPresence);
@@ -8,6 +8,8 @@
  *   status        - Current status: "published" or "draft"
  *   publish-url   - URL for publish/update action (POST)
  *   unpublish-url - URL for unpublish action (POST)
+ *   diff-url      - Optional URL of the published-vs-draft diff, shown in the
+ *                   publish confirmation (GET)
  *   target        - HTMX swap target (default: #main-content)
  */
 class McmsPublishButton extends HTMLElement {
@@ -15,6 +17,7 @@ class McmsPublishButton extends HTMLElement {
         this._status = this.getAttribute('status') || 'draft';
         this._publishUrl = this.getAttribute('publish-url') || '';
         this._unpublishUrl = this.getAttribute('unpublish-url') || '';
+        this._diffUrl = this.getAttribute('diff-url') || '';
         this._target = this.getAttribute('target') || '#main-content';
         this._open = false;
         this._render();
@@ -70,6 +73,7 @@ class McmsPublishButton extends HTMLElement {
         html += '<mcms-dialog data-publish-dialog>'
             + '<div class="p-6">'
             + '<p class="text-sm text-gray-400" data-publish-dialog-msg></p>'
+            + '<div class="mt-4 max-h-96 overflow-y-auto hidden" data-publish-dialog-diff></div>'
             + '</div>'
             + '<div class="flex justify-end gap-x-3 border-t border-white/10 bg-white/5 px-6 py-3">'
             + '<button type="button" class="rounded-md bg-white/10 px-3 py-2 text-sm font-semibold text-white shadow-xs hover:bg-white/20" data-publish-cancel>Cancel</button>'
@@ -88,16 +92,29 @@ class McmsPublishButton extends HTMLElement {
         var menu = this.querySelector('[data-publish-menu]');
         var dialog = this.querySelector('[data-publish-dialog]');
         var dialogMsg = this.querySelector('[data-publish-dialog-msg]');
+        var dialogDiff = this.querySelector('[data-publish-dialog-diff]');
         var cancelBtn = this.querySelector('[data-publish-cancel]');
         var confirmBtn = this.querySelector('[data-publish-confirm]');
         var pendingAction = null;
 
+        // Load the changes a publish would make into the dialog, or hide the
+        // diff for actions that do not publish the draft.
+        var showDiff = function(show) {
+            dialogDiff.innerHTML = '';
+            dialogDiff.classList.toggle('hidden', !show || !self._diffUrl);
+            if (show && self._diffUrl && typeof htmx !== 'undefined') {
+                dialogDiff.innerHTML = '<p class="text-sm text-gray-500">Loading changes...</p>';
+                htmx.ajax('GET', self._diffUrl, { target: dialogDiff, swap: 'innerHTML' });
+            }
+        };
+
         // Primary button: Publish (draft) or Update (published)
         actionBtn.addEventListener('click', function() {
             pendingAction = { url: self._publishUrl };
             dialogMsg.textContent = isPublished
                 ? 'Update this content? This creates a new public snapshot.'
                 : 'Publish this content? This creates a public snapshot.';
+            showDiff(true);
             dialog.open();
         });
 
@@ -117,6 +134,7 @@ class McmsPublishButton extends HTMLElement {
                 menu.classList.add('hidden'); self._open = false;
                 pendingAction = { url: self._publishUrl };
                 dialogMsg.textContent = 'Update this content? This creates a new public snapshot.';
+                showDiff(true);
                 dialog.open();
             });
         }
@@ -128,6 +146,7 @@ class McmsPublishButton extends HTMLElement {
                 menu.classList.add('hidden'); self._open = false;
                 pendingAction = { url: self._unpublishUrl };
                 dialogMsg.textContent = 'Unpublish this content? It will no longer be publicly visible.';
+                showDiff(false);
                 dialog.open();
             });
         }
@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// breachedPrefixLen is the number of hex characters of the SHA-1 hash used to
+// name a range file, matching the k-anonymity scheme of Pwned Passwords.
+const breachedPrefixLen = 5
+
+// BreachedList checks passwords against a local copy of a breached password
+// corpus keyed by SHA-1, such as the Have I Been Pwned Pwned Passwords set.
+// Nothing is sent over the network.
+//
+// Two layouts are supported:
+//   - A directory of range files named by the first five hex characters of the
+//     hash ("21BD1" or "21BD1.txt"), each holding "SUFFIX:COUNT" lines. This is
+//     the k-anonymity layout written by the Pwned Passwords downloader; a
+//     lookup reads only the range file for the password's prefix.
+//   - A single file of "HASH" or "HASH:COUNT" lines, loaded into memory and
+//     grouped by prefix. Suitable for curated lists, not the full corpus.
+type BreachedList struct {
+	dir     string
+	buckets map[string]map[string]struct{}
+}
+
+// OpenBreachedList opens the breached password list at path, which may be a
+// directory of range files or a single hash file.
+func OpenBreachedList(path string) (*BreachedList, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("open breached password list: %w", err)
+	}
+	if info.IsDir() {
+		return &BreachedList{dir: path}, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open breached password list: %w", err)
+	}
+	defer f.Close()
+
+	b := &BreachedList{buckets: make(map[string]map[string]struct{})}
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		hash, ok := parseHashLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		if len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("breached password list %s line %d: expected a 40 character SHA-1 hash", path, line)
+		}
+		prefix, suffix := hash[:breachedPrefixLen], hash[breachedPrefixLen:]
+		if b.buckets[prefix] == nil {
+			b.buckets[prefix] = make(map[string]struct{})
+		}
+		b.buckets[prefix][suffix] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read breached password list: %w", err)
+	}
+	return b, nil
+}
+
+// Contains reports whether password appears in the list.
+func (b *BreachedList) Contains(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:breachedPrefixLen], hash[breachedPrefixLen:]
+
+	if b.dir == "" {
+		_, found := b.buckets[prefix][suffix]
+		return found, nil
+	}
+	return b.rangeFileContains(prefix, suffix)
+}
+
+// rangeFileContains scans the range file for prefix. A missing range file
+// means no breached hash shares the prefix.
+func (b *BreachedList) rangeFileContains(prefix, suffix string) (bool, error) {
+	var f *os.File
+	var err error
+	for _, name := range []string{prefix, prefix + ".txt", strings.ToLower(prefix), strings.ToLower(prefix) + ".txt"} {
+		f, err = os.Open(filepath.Join(b.dir, name))
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return false, fmt.Errorf("open breached password range %s: %w", prefix, err)
+		}
+	}
+	if f == nil {
+		return false, nil
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		candidate, ok := parseHashLine(scanner.Text())
+		if ok && candidate == suffix {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("read breached password range %s: %w", prefix, err)
+	}
+	return false, nil
+}
+
+// parseHashLine returns the upper-cased hash from a "HASH" or "HASH:COUNT"
+// line. Blank lines and lines starting with '#' are skipped.
+func parseHashLine(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", false
+	}
+	hash, _, _ := strings.Cut(line, ":")
+	return strings.ToUpper(strings.TrimSpace(hash)), true
+}
+
+// breachedCache holds the most recently opened list so that a single hash
+// file is parsed once rather than on every password change. It is reloaded
+// when the configured path or the file's modification time changes.
+var breachedCache struct {
+	mu      sync.Mutex
+	path    string
+	modTime time.Time
+	list    *BreachedList
+}
+
+// loadBreachedList returns the cached list for path, opening it if needed.
+func loadBreachedList(path string) (*BreachedList, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("open breached password list: %w", err)
+	}
+
+	breachedCache.mu.Lock()
+	defer breachedCache.mu.Unlock()
+	if breachedCache.list != nil && breachedCache.path == path && breachedCache.modTime.Equal(info.ModTime()) {
+		return breachedCache.list, nil
+	}
+	list, err := OpenBreachedList(path)
+	if err != nil {
+		return nil, err
+	}
+	breachedCache.path = path
+	breachedCache.modTime = info.ModTime()
+	breachedCache.list = list
+	return list, nil
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hegner123/modulacms/internal/config"
+)
+
+// MaxPasswordLength is the bcrypt input limit enforced by HashPassword.
+const MaxPasswordLength = 72
+
+// PolicyError reports a password that breaks the password policy. Its message
+// is safe to show to the user.
+type PolicyError struct {
+	Message string
+}
+
+func (e *PolicyError) Error() string {
+	return e.Message
+}
+
+// IsPolicyError reports whether err is a *PolicyError.
+func IsPolicyError(err error) bool {
+	var target *PolicyError
+	return errors.As(err, &target)
+}
+
+// PasswordPolicy holds the password rules that need no stored state: length
+// and the breached password list. Reuse history and expiry are enforced by the
+// service layer, which has access to password_history.
+type PasswordPolicy struct {
+	MinLength int
+	Breached  *BreachedList
+}
+
+// PasswordPolicyFromConfig builds the policy from cfg. When a breached
+// password list is configured but cannot be opened, the policy is returned
+// without it alongside the error, so callers can log and continue.
+func PasswordPolicyFromConfig(cfg config.Config) (PasswordPolicy, error) {
+	p := PasswordPolicy{MinLength: cfg.PasswordMinLength()}
+	if cfg.Password_Breached_List == "" {
+		return p, nil
+	}
+	list, err := loadBreachedList(cfg.Password_Breached_List)
+	if err != nil {
+		return p, err
+	}
+	p.Breached = list
+	return p, nil
+}
+
+// Check returns a *PolicyError describing the first rule the password breaks.
+// Any other error means the breached password list could not be read.
+func (p PasswordPolicy) Check(password string) error {
+	if len(password) < p.MinLength {
+		return &PolicyError{Message: fmt.Sprintf("password must be at least %d characters", p.MinLength)}
+	}
+	if len(password) > MaxPasswordLength {
+		return &PolicyError{Message: fmt.Sprintf("password must not exceed %d bytes", MaxPasswordLength)}
+	}
+	if p.Breached == nil {
+		return nil
+	}
+	found, err := p.Breached.Contains(password)
+	if err != nil {
+		return err
+	}
+	if found {
+		return &PolicyError{Message: "password appears in a list of breached passwords; choose a different one"}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// White-box tests for password_policy.go and breached.go.
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// SHA-1 of "password", split at the k-anonymity prefix.
+const (
+	passwordHashPrefix = "5BAA6"
+	passwordHashSuffix = "1E4C9B93F3F0682250B6CF8331B7EE68FD8"
+)
+
+// ---------------------------------------------------------------------------
+// BreachedList
+// ---------------------------------------------------------------------------
+
+func TestBreachedList_HashFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	content := "# comment line\n\n" + strings.ToLower(passwordHashPrefix+passwordHashSuffix) + ":3861493\r\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := OpenBreachedList(path)
+	if err != nil {
+		t.Fatalf("OpenBreachedList: %v", err)
+	}
+	assertBreached(t, list, "password", true)
+	assertBreached(t, list, "correcthorsebatterystaple", false)
+}
+
+func TestBreachedList_HashFile_RejectsMalformedLine(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(path, []byte("NOTAHASH:1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenBreachedList(path); err == nil {
+		t.Fatal("expected error for malformed hash line")
+	}
+}
+
+func TestBreachedList_RangeDirectory(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	content := "0018A45C4D1DEF81644B54AB7F969B88D65:1\n" + passwordHashSuffix + ":3861493\n"
+	if err := os.WriteFile(filepath.Join(dir, passwordHashPrefix+".txt"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := OpenBreachedList(dir)
+	if err != nil {
+		t.Fatalf("OpenBreachedList: %v", err)
+	}
+	assertBreached(t, list, "password", true)
+	// No range file exists for this prefix.
+	assertBreached(t, list, "correcthorsebatterystaple", false)
+}
+
+func TestOpenBreachedList_MissingPath(t *testing.T) {
+	t.Parallel()
+
+	if _, err := OpenBreachedList(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("expected error for missing path")
+	}
+}
+
+func assertBreached(t *testing.T, list *BreachedList, password string, want bool) {
+	t.Helper()
+	got, err := list.Contains(password)
+	if err != nil {
+		t.Fatalf("Contains(%q): %v", password, err)
+	}
+	if got != want {
+		t.Errorf("Contains(%q) = %v, want %v", password, got, want)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// PasswordPolicy.Check
+// ---------------------------------------------------------------------------
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(path, []byte(passwordHashPrefix+passwordHashSuffix+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	list, err := OpenBreachedList(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := PasswordPolicy{MinLength: 8, Breached: list}
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  string
+	}{
+		{name: "valid", password: "correcthorsebatterystaple"},
+		{name: "too short", password: "short", wantErr: "at least 8"},
+		{name: "too long", password: strings.Repeat("a", 73), wantErr: "must not exceed"},
+		{name: "breached", password: "password", wantErr: "breached"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := policy.Check(tt.password)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !IsPolicyError(err) {
+				t.Fatalf("expected *PolicyError, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q does not contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
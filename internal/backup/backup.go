@@ -40,6 +40,13 @@ type BackupManifest struct {
 	Version   string `json:"version"`
 	NodeID    string `json:"node_id"`
 	DbName    string `json:"db_name"`
+	// Media is the backup_media mode the archive was created with, empty
+	// when media objects are not included.
+	Media string `json:"media,omitempty"`
+	// MediaArchive is the file name of the sibling media archive.
+	MediaArchive string `json:"media_archive,omitempty"`
+	// Encrypted is set for archives sealed with backup encryption.
+	Encrypted bool `json:"encrypted,omitempty"`
 }
 
 // TimestampBackupName returns a backup filename with the given output directory and timestamp.
@@ -52,7 +59,28 @@ func TimestampBackupName(output string, timestamp string) string {
 // For MySQL and PostgreSQL, the driver parameter is used for a pure-Go export
 // via DeployOps. For SQLite, the database file is copied directly and driver
 // may be nil.
+//
+// When backup_media is set, every media original and variant referenced by
+// the database is copied from the media buckets into the archive, or into a
+// sibling archive at MediaArchivePath. When a backup encryption passphrase
+// or recipient is configured, the archives are encrypted as they are written
+// and their names end in EncryptedExt.
 func CreateFullBackup(cfg config.Config, driver db.DbDriver) (path string, sizeBytes int64, err error) {
+	enc, err := EncryptionFromConfig(cfg)
+	if err != nil {
+		return "", 0, err
+	}
+	var stores mediaStores
+	if cfg.Backup_Media != "" {
+		stores, err = newMediaStores(cfg)
+		if err != nil {
+			return "", 0, err
+		}
+	}
+	return createFullBackup(context.Background(), cfg, driver, enc, stores)
+}
+
+func createFullBackup(ctx context.Context, cfg config.Config, driver db.DbDriver, enc *Encryption, stores mediaStores) (path string, sizeBytes int64, err error) {
 	// Determine output directory
 	outputDir := cfg.Backup_Option
 	if outputDir == "" {
@@ -66,96 +94,167 @@ func CreateFullBackup(cfg config.Config, driver db.DbDriver) (path string, sizeB
 	// Generate filename with timestamp
 	ts := time.Now().UTC().Format("20060102_150405")
 	filename := fmt.Sprintf("backup_%s.zip", ts)
+	if enc.Encrypts() {
+		filename += EncryptedExt
+	}
 	fullPath := filepath.Join(backupDir, filename)
 
-	// Create the zip file
-	zipFile, err := os.Create(fullPath)
-	if err != nil {
-		return "", 0, fmt.Errorf("failed to create backup file: %w", err)
+	manifest := BackupManifest{
+		Driver:    string(cfg.Db_Driver),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Version:   utility.GetCurrentVersion(),
+		NodeID:    cfg.Node_ID,
+		DbName:    cfg.Db_Name,
+		Media:     cfg.Backup_Media,
+		Encrypted: enc.Encrypts(),
 	}
 
-	zipClosed := false
-	defer func() {
-		if !zipClosed {
-			zipFile.Close()
+	// Collect media keys before writing anything, so a database error
+	// fails the backup early.
+	var mediaKeys map[string][]string
+	switch cfg.Backup_Media {
+	case "":
+	case MediaArchive, MediaSibling:
+		mediaKeys, err = referencedMedia(driver, cfg)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to collect media: %w", err)
 		}
-	}()
+		if cfg.Backup_Media == MediaSibling {
+			manifest.MediaArchive = filepath.Base(MediaArchivePath(fullPath))
+		}
+	default:
+		return "", 0, fmt.Errorf("unsupported backup_media %q", cfg.Backup_Media)
+	}
 
-	zipWriter := zip.NewWriter(zipFile)
-	writerClosed := false
-	defer func() {
-		if !writerClosed {
-			zipWriter.Close()
+	err = writeArchive(fullPath, &manifest, enc, func(zipWriter *zip.Writer) error {
+		// Add database to zip based on driver type
+		switch cfg.Db_Driver {
+		case config.Sqlite:
+			if addErr := addSQLiteDB(zipWriter, cfg.Db_URL); addErr != nil {
+				return fmt.Errorf("failed to add SQLite database: %w", addErr)
+			}
+		case config.Mysql, config.Psql:
+			if driver == nil {
+				return fmt.Errorf("driver required for %s backup", cfg.Db_Driver)
+			}
+			if addErr := addGoSQLDump(zipWriter, driver); addErr != nil {
+				return fmt.Errorf("failed to add database dump: %w", addErr)
+			}
+		default:
+			return fmt.Errorf("unsupported database driver: %s", cfg.Db_Driver)
 		}
-	}()
 
-	// Add database to zip based on driver type
-	switch cfg.Db_Driver {
-	case config.Sqlite:
-		if addErr := addSQLiteDB(zipWriter, cfg.Db_URL); addErr != nil {
-			return "", 0, fmt.Errorf("failed to add SQLite database: %w", addErr)
+		if err := writeManifest(zipWriter, &manifest); err != nil {
+			return err
 		}
-	case config.Mysql, config.Psql:
-		if driver == nil {
-			return "", 0, fmt.Errorf("driver required for %s backup", cfg.Db_Driver)
+
+		// Add extra backup paths
+		for _, p := range cfg.Backup_Paths {
+			if p == "" {
+				continue
+			}
+			if !utility.DirExists(p) && !utility.FileExists(p) {
+				continue
+			}
+			if addErr := addFilesToZip(zipWriter, p, filepath.Join("extra", filepath.Base(p))); addErr != nil {
+				return fmt.Errorf("failed to add backup path %s: %w", p, addErr)
+			}
 		}
-		if addErr := addGoSQLDump(zipWriter, driver); addErr != nil {
-			return "", 0, fmt.Errorf("failed to add database dump: %w", addErr)
+
+		if cfg.Backup_Media == MediaArchive {
+			if _, mediaErr := writeMedia(ctx, zipWriter, stores, mediaKeys); mediaErr != nil {
+				return fmt.Errorf("failed to add media: %w", mediaErr)
+			}
 		}
-	default:
-		return "", 0, fmt.Errorf("unsupported database driver: %s", cfg.Db_Driver)
+		return nil
+	})
+	if err != nil {
+		return "", 0, err
 	}
 
-	// Write manifest
-	manifest := BackupManifest{
-		Driver:    string(cfg.Db_Driver),
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Version:   utility.GetCurrentVersion(),
-		NodeID:    cfg.Node_ID,
-		DbName:    cfg.Db_Name,
+	if cfg.Backup_Media == MediaSibling {
+		mediaPath := MediaArchivePath(fullPath)
+		err = writeArchive(mediaPath, &manifest, enc, func(zipWriter *zip.Writer) error {
+			if err := writeManifest(zipWriter, &manifest); err != nil {
+				return err
+			}
+			if _, mediaErr := writeMedia(ctx, zipWriter, stores, mediaKeys); mediaErr != nil {
+				return fmt.Errorf("failed to add media: %w", mediaErr)
+			}
+			return nil
+		})
+		if err != nil {
+			os.Remove(fullPath)
+			return "", 0, err
+		}
 	}
-	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+
+	// Get file size
+	info, err := os.Stat(fullPath)
 	if err != nil {
-		return "", 0, fmt.Errorf("failed to marshal manifest: %w", err)
+		return fullPath, 0, fmt.Errorf("backup created but failed to stat: %w", err)
 	}
-	manifestWriter, err := zipWriter.Create("manifest.json")
+
+	return fullPath, info.Size(), nil
+}
+
+// writeArchive creates a zip archive at path, filled by fill, encrypting it
+// as it is written when enc encrypts. A failed archive is removed.
+func writeArchive(path string, manifest *BackupManifest, enc *Encryption, fill func(*zip.Writer) error) (err error) {
+	file, err := os.Create(path)
 	if err != nil {
-		return "", 0, fmt.Errorf("failed to create manifest in archive: %w", err)
-	}
-	if _, err = manifestWriter.Write(manifestJSON); err != nil {
-		return "", 0, fmt.Errorf("failed to write manifest: %w", err)
+		return fmt.Errorf("failed to create backup file: %w", err)
 	}
-
-	// Add extra backup paths
-	for _, p := range cfg.Backup_Paths {
-		if p == "" {
-			continue
-		}
-		if !utility.DirExists(p) && !utility.FileExists(p) {
-			continue
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(path)
 		}
-		if addErr := addFilesToZip(zipWriter, p, filepath.Join("extra", filepath.Base(p))); addErr != nil {
-			return "", 0, fmt.Errorf("failed to add backup path %s: %w", p, addErr)
+	}()
+
+	var out io.Writer = file
+	var sealer io.WriteCloser
+	if enc.Encrypts() {
+		sealer, err = newEncryptWriter(file, manifest, enc)
+		if err != nil {
+			return fmt.Errorf("failed to start encryption: %w", err)
 		}
+		out = sealer
 	}
 
-	// Close writers before stat
+	zipWriter := zip.NewWriter(out)
+	if err = fill(zipWriter); err != nil {
+		zipWriter.Close()
+		return err
+	}
 	if err = zipWriter.Close(); err != nil {
-		return "", 0, fmt.Errorf("failed to finalize zip: %w", err)
+		return fmt.Errorf("failed to finalize zip: %w", err)
 	}
-	writerClosed = true
-	if err = zipFile.Close(); err != nil {
-		return "", 0, fmt.Errorf("failed to close zip file: %w", err)
+	if sealer != nil {
+		if err = sealer.Close(); err != nil {
+			return fmt.Errorf("failed to finalize encryption: %w", err)
+		}
 	}
-	zipClosed = true
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("failed to close zip file: %w", err)
+	}
+	return nil
+}
 
-	// Get file size
-	info, err := os.Stat(fullPath)
+// writeManifest adds manifest.json to the archive.
+func writeManifest(zipWriter *zip.Writer, manifest *BackupManifest) error {
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
 	if err != nil {
-		return fullPath, 0, fmt.Errorf("backup created but failed to stat: %w", err)
+		return fmt.Errorf("failed to marshal manifest: %w", err)
 	}
-
-	return fullPath, info.Size(), nil
+	manifestWriter, err := zipWriter.Create("manifest.json")
+	if err != nil {
+		return fmt.Errorf("failed to create manifest in archive: %w", err)
+	}
+	if _, err = manifestWriter.Write(manifestJSON); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
 }
 
 func addSQLiteDB(zw *zip.Writer, dbPath string) error {
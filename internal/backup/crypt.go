@@ -0,0 +1,508 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Encrypted archive format
+//
+// An encrypted archive is the zip archive sealed with AES-256-GCM in 64 KiB
+// chunks. The file starts with encryptedMagic, a 4-byte big-endian header
+// length and a JSON header. The header carries the manifest in the clear, so
+// archives can be listed without the key, and the random file key wrapped
+// once per configured key: under a key derived from the passphrase with
+// scrypt, and under an X25519 shared secret for the recipient key. Every
+// chunk is authenticated together with a hash of the header, so a modified
+// header or manifest fails decryption. The chunk nonce is a counter with a
+// final-chunk flag, so truncated or reordered archives are rejected.
+
+// encryptedMagic starts every encrypted archive.
+const encryptedMagic = "modula-backup-encrypted/v1\n"
+
+// EncryptedExt is appended to the names of encrypted archives.
+const EncryptedExt = ".enc"
+
+// chunkSize is the plaintext size of each sealed chunk.
+const chunkSize = 64 * 1024
+
+// maxHeaderSize bounds the header read before anything is authenticated.
+const maxHeaderSize = 1 << 20
+
+// scryptLogN is the scrypt work factor for new archives (N = 2^15).
+// maxScryptLogN bounds the factor accepted from an archive header.
+const (
+	scryptLogN    = 15
+	maxScryptLogN = 22
+)
+
+// Key prefixes for recipient (public) and identity (private) keys.
+const (
+	recipientPrefix = "modula-backup-pub1:"
+	identityPrefix  = "modula-backup-key1:"
+)
+
+// ErrNoDecryptionKey is returned when an archive is encrypted and none of
+// the configured keys can open it.
+var ErrNoDecryptionKey = errors.New("backup archive is encrypted and no configured key opens it")
+
+// Encryption holds the keys used to encrypt and decrypt backup archives.
+// New archives are encrypted to the passphrase and the recipient, whichever
+// are set; either one opens them.
+type Encryption struct {
+	Passphrase string
+	Recipient  *ecdh.PublicKey
+	Identity   *ecdh.PrivateKey
+}
+
+// EncryptionFromConfig reads backup_encryption_passphrase,
+// backup_encryption_recipient and backup_encryption_identity_file. It
+// returns nil when none are set.
+func EncryptionFromConfig(cfg config.Config) (*Encryption, error) {
+	if cfg.Backup_Encryption_Passphrase == "" && cfg.Backup_Encryption_Recipient == "" && cfg.Backup_Encryption_Identity_File == "" {
+		return nil, nil
+	}
+	e := &Encryption{Passphrase: cfg.Backup_Encryption_Passphrase}
+	if cfg.Backup_Encryption_Recipient != "" {
+		pub, err := ParseRecipient(cfg.Backup_Encryption_Recipient)
+		if err != nil {
+			return nil, fmt.Errorf("backup_encryption_recipient: %w", err)
+		}
+		e.Recipient = pub
+	}
+	if cfg.Backup_Encryption_Identity_File != "" {
+		data, err := os.ReadFile(cfg.Backup_Encryption_Identity_File)
+		if err != nil {
+			return nil, fmt.Errorf("read backup_encryption_identity_file: %w", err)
+		}
+		priv, err := ParseIdentity(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("backup_encryption_identity_file: %w", err)
+		}
+		e.Identity = priv
+	}
+	return e, nil
+}
+
+// Encrypts reports whether new archives are encrypted.
+func (e *Encryption) Encrypts() bool {
+	return e != nil && (e.Passphrase != "" || e.Recipient != nil)
+}
+
+// Decrypts reports whether a key is configured that can open archives: the
+// passphrase or the identity. A server holding only the recipient key can
+// write archives it cannot read.
+func (e *Encryption) Decrypts() bool {
+	return e != nil && (e.Passphrase != "" || e.Identity != nil)
+}
+
+// GenerateKeyPair returns a new identity (private key) and the matching
+// recipient (public key) for backup_encryption_identity_file and
+// backup_encryption_recipient.
+func GenerateKeyPair() (identity, recipient string, err error) {
+	priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("generate key: %w", err)
+	}
+	return identityPrefix + base64.RawURLEncoding.EncodeToString(priv.Bytes()),
+		recipientPrefix + base64.RawURLEncoding.EncodeToString(priv.PublicKey().Bytes()), nil
+}
+
+// ParseRecipient parses a recipient key from GenerateKeyPair.
+func ParseRecipient(s string) (*ecdh.PublicKey, error) {
+	raw, ok := strings.CutPrefix(strings.TrimSpace(s), recipientPrefix)
+	if !ok {
+		return nil, fmt.Errorf("recipient key must start with %q", recipientPrefix)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient key: %w", err)
+	}
+	return ecdh.X25519().NewPublicKey(b)
+}
+
+// ParseIdentity parses an identity key from GenerateKeyPair.
+func ParseIdentity(s string) (*ecdh.PrivateKey, error) {
+	raw, ok := strings.CutPrefix(strings.TrimSpace(s), identityPrefix)
+	if !ok {
+		return nil, fmt.Errorf("identity key must start with %q", identityPrefix)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid identity key: %w", err)
+	}
+	return ecdh.X25519().NewPrivateKey(b)
+}
+
+// encryptionHeader is the JSON header of an encrypted archive.
+type encryptionHeader struct {
+	Manifest *BackupManifest `json:"manifest"`
+	Stanzas  []keyStanza     `json:"stanzas"`
+	// Salt is mixed into the payload key so no two archives share one.
+	Salt []byte `json:"salt"`
+}
+
+// keyStanza is the file key wrapped under one configured key.
+type keyStanza struct {
+	Type string `json:"type"` // "scrypt" or "x25519"
+	// Salt and LogN are the scrypt parameters.
+	Salt []byte `json:"salt,omitempty"`
+	LogN int    `json:"log_n,omitempty"`
+	// Ephemeral is the sender's X25519 public key.
+	Ephemeral []byte `json:"ephemeral,omitempty"`
+	// WrappedKey is nonce || AES-256-GCM(file key).
+	WrappedKey []byte `json:"wrapped_key"`
+}
+
+// IsEncrypted reports whether the file at path is an encrypted archive.
+func IsEncrypted(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	buf := make([]byte, len(encryptedMagic))
+	if _, err := io.ReadFull(f, buf); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, nil
+		}
+		return false, err
+	}
+	return string(buf) == encryptedMagic, nil
+}
+
+// newEncryptWriter writes the header for manifest to w and returns a writer
+// that seals everything written to it. Close writes the final chunk but does
+// not close w.
+func newEncryptWriter(w io.Writer, manifest *BackupManifest, e *Encryption) (io.WriteCloser, error) {
+	if !e.Encrypts() {
+		return nil, fmt.Errorf("no backup encryption passphrase or recipient configured")
+	}
+	fileKey := make([]byte, 32)
+	if _, err := rand.Read(fileKey); err != nil {
+		return nil, fmt.Errorf("generate file key: %w", err)
+	}
+	h := encryptionHeader{Manifest: manifest, Salt: make([]byte, 16)}
+	if _, err := rand.Read(h.Salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+
+	if e.Passphrase != "" {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, fmt.Errorf("generate salt: %w", err)
+		}
+		kek, err := scrypt.Key([]byte(e.Passphrase), salt, 1<<scryptLogN, 8, 1, 32)
+		if err != nil {
+			return nil, fmt.Errorf("derive passphrase key: %w", err)
+		}
+		wrapped, err := wrapKey(kek, fileKey)
+		if err != nil {
+			return nil, err
+		}
+		h.Stanzas = append(h.Stanzas, keyStanza{Type: "scrypt", Salt: salt, LogN: scryptLogN, WrappedKey: wrapped})
+	}
+	if e.Recipient != nil {
+		eph, err := ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generate ephemeral key: %w", err)
+		}
+		shared, err := eph.ECDH(e.Recipient)
+		if err != nil {
+			return nil, fmt.Errorf("x25519: %w", err)
+		}
+		kek, err := x25519KEK(shared, eph.PublicKey().Bytes(), e.Recipient.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		wrapped, err := wrapKey(kek, fileKey)
+		if err != nil {
+			return nil, err
+		}
+		h.Stanzas = append(h.Stanzas, keyStanza{Type: "x25519", Ephemeral: eph.PublicKey().Bytes(), WrappedKey: wrapped})
+	}
+
+	headerJSON, err := json.Marshal(h)
+	if err != nil {
+		return nil, fmt.Errorf("encode encryption header: %w", err)
+	}
+	var prefix bytes.Buffer
+	prefix.WriteString(encryptedMagic)
+	binary.Write(&prefix, binary.BigEndian, uint32(len(headerJSON)))
+	prefix.Write(headerJSON)
+	if _, err := w.Write(prefix.Bytes()); err != nil {
+		return nil, fmt.Errorf("write encryption header: %w", err)
+	}
+
+	aead, err := payloadAEAD(fileKey, h.Salt)
+	if err != nil {
+		return nil, err
+	}
+	ad := sha256.Sum256(prefix.Bytes())
+	return &encryptWriter{w: w, aead: aead, ad: ad[:], buf: make([]byte, 0, chunkSize)}, nil
+}
+
+// encryptWriter seals its input in chunkSize chunks.
+type encryptWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	ad      []byte
+	buf     []byte
+	counter uint64
+	closed  bool
+}
+
+func (ew *encryptWriter) Write(p []byte) (int, error) {
+	if ew.closed {
+		return 0, errors.New("write to closed encrypt writer")
+	}
+	n := 0
+	for len(p) > 0 {
+		// A full buffer is flushed only once more data arrives, so the
+		// last chunk is always written by Close with the final flag set.
+		if len(ew.buf) == chunkSize {
+			if err := ew.flush(false); err != nil {
+				return n, err
+			}
+		}
+		c := copy(ew.buf[len(ew.buf):chunkSize], p)
+		ew.buf = ew.buf[:len(ew.buf)+c]
+		p = p[c:]
+		n += c
+	}
+	return n, nil
+}
+
+func (ew *encryptWriter) Close() error {
+	if ew.closed {
+		return nil
+	}
+	ew.closed = true
+	return ew.flush(true)
+}
+
+func (ew *encryptWriter) flush(final bool) error {
+	sealed := ew.aead.Seal(nil, chunkNonce(ew.counter, final), ew.buf, ew.ad)
+	if _, err := ew.w.Write(sealed); err != nil {
+		return fmt.Errorf("write encrypted chunk: %w", err)
+	}
+	ew.counter++
+	ew.buf = ew.buf[:0]
+	return nil
+}
+
+// readEncryptionHeader reads the magic and header from r. It returns the
+// header and the hash that authenticates it.
+func readEncryptionHeader(r io.Reader) (*encryptionHeader, []byte, error) {
+	magic := make([]byte, len(encryptedMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != encryptedMagic {
+		return nil, nil, fmt.Errorf("not an encrypted backup archive")
+	}
+	var size uint32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, nil, fmt.Errorf("read encryption header: %w", err)
+	}
+	if size > maxHeaderSize {
+		return nil, nil, fmt.Errorf("encryption header is %d bytes, limit %d", size, maxHeaderSize)
+	}
+	headerJSON := make([]byte, size)
+	if _, err := io.ReadFull(r, headerJSON); err != nil {
+		return nil, nil, fmt.Errorf("read encryption header: %w", err)
+	}
+	var h encryptionHeader
+	if err := json.Unmarshal(headerJSON, &h); err != nil {
+		return nil, nil, fmt.Errorf("parse encryption header: %w", err)
+	}
+
+	hash := sha256.New()
+	hash.Write(magic)
+	binary.Write(hash, binary.BigEndian, size)
+	hash.Write(headerJSON)
+	return &h, hash.Sum(nil), nil
+}
+
+// DecryptFile decrypts the archive at src into a new file at dst and
+// returns its manifest. A wrong key, a modified header, or a truncated or
+// altered archive is an error, and dst is removed.
+func DecryptFile(src, dst string, e *Encryption) (*BackupManifest, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", src, err)
+	}
+	defer in.Close()
+	r := bufio.NewReaderSize(in, chunkSize+64)
+
+	h, ad, err := readEncryptionHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	fileKey, err := unwrapFileKey(h, e)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := payloadAEAD(fileKey, h.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("create %s: %w", dst, err)
+	}
+	if err := decryptChunks(r, out, aead, ad); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return nil, err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return nil, fmt.Errorf("close %s: %w", dst, err)
+	}
+	return h.Manifest, nil
+}
+
+// decryptChunks opens chunks from r until the final one. A chunk is known
+// to be the last when no data follows it, and it must carry the final flag.
+func decryptChunks(r *bufio.Reader, w io.Writer, aead cipher.AEAD, ad []byte) error {
+	sealedSize := chunkSize + aead.Overhead()
+	buf := make([]byte, sealedSize)
+	for counter := uint64(0); ; counter++ {
+		n, err := io.ReadFull(r, buf)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("read encrypted chunk: %w", err)
+		}
+		final := n < sealedSize
+		if !final {
+			if _, peekErr := r.Peek(1); errors.Is(peekErr, io.EOF) {
+				final = true
+			}
+		}
+		plain, openErr := aead.Open(buf[:0], chunkNonce(counter, final), buf[:n], ad)
+		if openErr != nil {
+			return fmt.Errorf("decrypt backup archive: wrong key, or the archive is corrupt or truncated")
+		}
+		if _, err := w.Write(plain); err != nil {
+			return fmt.Errorf("write decrypted archive: %w", err)
+		}
+		if final {
+			return nil
+		}
+	}
+}
+
+// unwrapFileKey opens the first stanza a configured key matches.
+func unwrapFileKey(h *encryptionHeader, e *Encryption) ([]byte, error) {
+	if e == nil {
+		return nil, ErrNoDecryptionKey
+	}
+	for _, s := range h.Stanzas {
+		var kek []byte
+		switch {
+		case s.Type == "scrypt" && e.Passphrase != "":
+			if s.LogN < 1 || s.LogN > maxScryptLogN {
+				return nil, fmt.Errorf("scrypt work factor %d out of range", s.LogN)
+			}
+			k, err := scrypt.Key([]byte(e.Passphrase), s.Salt, 1<<s.LogN, 8, 1, 32)
+			if err != nil {
+				return nil, fmt.Errorf("derive passphrase key: %w", err)
+			}
+			kek = k
+		case s.Type == "x25519" && e.Identity != nil:
+			eph, err := ecdh.X25519().NewPublicKey(s.Ephemeral)
+			if err != nil {
+				continue
+			}
+			shared, err := e.Identity.ECDH(eph)
+			if err != nil {
+				continue
+			}
+			k, err := x25519KEK(shared, s.Ephemeral, e.Identity.PublicKey().Bytes())
+			if err != nil {
+				continue
+			}
+			kek = k
+		default:
+			continue
+		}
+		if fileKey, err := unwrapKey(kek, s.WrappedKey); err == nil {
+			return fileKey, nil
+		}
+	}
+	return nil, ErrNoDecryptionKey
+}
+
+// x25519KEK derives the key-wrapping key from an X25519 shared secret,
+// bound to the ephemeral and recipient public keys.
+func x25519KEK(shared, ephemeral, recipient []byte) ([]byte, error) {
+	salt := append(append([]byte{}, ephemeral...), recipient...)
+	return hkdf.Key(sha256.New, shared, salt, "modula-backup x25519", 32)
+}
+
+// payloadAEAD derives the chunk cipher from the file key.
+func payloadAEAD(fileKey, salt []byte) (cipher.AEAD, error) {
+	key, err := hkdf.Key(sha256.New, fileKey, salt, "modula-backup payload", 32)
+	if err != nil {
+		return nil, fmt.Errorf("derive payload key: %w", err)
+	}
+	return newGCM(key)
+}
+
+// chunkNonce is an 11-byte big-endian counter followed by the final flag.
+func chunkNonce(counter uint64, final bool) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce[3:11], counter)
+	if final {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// wrapKey seals fileKey under kek and returns nonce || ciphertext.
+func wrapKey(kek, fileKey []byte) ([]byte, error) {
+	aead, err := newGCM(kek)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, fileKey, nil), nil
+}
+
+// unwrapKey reverses wrapKey.
+func unwrapKey(kek, wrapped []byte) ([]byte, error) {
+	aead, err := newGCM(kek)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, errors.New("wrapped key too short")
+	}
+	return aead.Open(nil, wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():], nil)
+}
@@ -0,0 +1,185 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// encryptToFile seals data into a new file under dir and returns its path.
+func encryptToFile(t *testing.T, dir string, data []byte, manifest *BackupManifest, e *Encryption) string {
+	t.Helper()
+	path := filepath.Join(dir, "archive.zip.enc")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	defer f.Close()
+	w, err := newEncryptWriter(f, manifest, e)
+	if err != nil {
+		t.Fatalf("newEncryptWriter: %v", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return path
+}
+
+func randomBytes(t *testing.T, n int) []byte {
+	t.Helper()
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatalf("rand: %v", err)
+	}
+	return b
+}
+
+func testKeys(t *testing.T) (identity, recipient *Encryption) {
+	t.Helper()
+	id, pub, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+	priv, err := ParseIdentity(id)
+	if err != nil {
+		t.Fatalf("ParseIdentity: %v", err)
+	}
+	rcpt, err := ParseRecipient(pub)
+	if err != nil {
+		t.Fatalf("ParseRecipient: %v", err)
+	}
+	return &Encryption{Identity: priv}, &Encryption{Recipient: rcpt}
+}
+
+func TestEncryption_RoundTrip(t *testing.T) {
+	t.Parallel()
+	identity, recipient := testKeys(t)
+	manifest := &BackupManifest{Driver: "sqlite", NodeID: "node-enc", Encrypted: true}
+
+	tests := []struct {
+		name    string
+		size    int
+		encrypt *Encryption
+		decrypt *Encryption
+	}{
+		{"empty passphrase", 0, &Encryption{Passphrase: "correct horse"}, &Encryption{Passphrase: "correct horse"}},
+		{"one byte", 1, &Encryption{Passphrase: "pw"}, &Encryption{Passphrase: "pw"}},
+		{"chunk minus one", chunkSize - 1, &Encryption{Passphrase: "pw"}, &Encryption{Passphrase: "pw"}},
+		{"exact chunk", chunkSize, recipient, identity},
+		{"chunk plus one", chunkSize + 1, recipient, identity},
+		{"three chunks", 3 * chunkSize, recipient, identity},
+		{"both keys, opened by identity", 1000, &Encryption{Passphrase: "pw", Recipient: recipient.Recipient}, identity},
+		{"both keys, opened by passphrase", 1000, &Encryption{Passphrase: "pw", Recipient: recipient.Recipient}, &Encryption{Passphrase: "pw"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			data := randomBytes(t, tt.size)
+			src := encryptToFile(t, dir, data, manifest, tt.encrypt)
+
+			encrypted, err := IsEncrypted(src)
+			if err != nil || !encrypted {
+				t.Fatalf("IsEncrypted = %v, %v", encrypted, err)
+			}
+			got, err := ReadManifest(src)
+			if err != nil {
+				t.Fatalf("ReadManifest: %v", err)
+			}
+			if got.NodeID != "node-enc" || !got.Encrypted {
+				t.Errorf("ReadManifest = %+v", got)
+			}
+
+			dst := filepath.Join(dir, "out.zip")
+			if _, err := DecryptFile(src, dst, tt.decrypt); err != nil {
+				t.Fatalf("DecryptFile: %v", err)
+			}
+			plain, err := os.ReadFile(dst)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if !bytes.Equal(plain, data) {
+				t.Errorf("decrypted %d bytes, want %d matching bytes", len(plain), len(data))
+			}
+		})
+	}
+}
+
+func TestEncryption_Rejects(t *testing.T) {
+	t.Parallel()
+	identity, recipient := testKeys(t)
+	otherIdentity, _ := testKeys(t)
+	manifest := &BackupManifest{Driver: "sqlite", NodeID: "node-enc"}
+	data := randomBytes(t, 2*chunkSize+10)
+
+	t.Run("wrong key", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		src := encryptToFile(t, dir, data, manifest, &Encryption{Passphrase: "right", Recipient: recipient.Recipient})
+		for _, e := range []*Encryption{nil, {Passphrase: "wrong"}, otherIdentity} {
+			if _, err := DecryptFile(src, filepath.Join(dir, "out"), e); !errors.Is(err, ErrNoDecryptionKey) {
+				t.Errorf("DecryptFile(%+v) = %v, want ErrNoDecryptionKey", e, err)
+			}
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		src := encryptToFile(t, dir, data, manifest, recipient)
+		raw, err := os.ReadFile(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Drop the final chunk: what remains ends on a chunk boundary.
+		if err := os.WriteFile(src, raw[:len(raw)-(10+16)], 0o600); err != nil {
+			t.Fatal(err)
+		}
+		dst := filepath.Join(dir, "out")
+		if _, err := DecryptFile(src, dst, identity); err == nil {
+			t.Fatal("truncated archive decrypted")
+		}
+		if _, err := os.Stat(dst); !errors.Is(err, os.ErrNotExist) {
+			t.Error("partial output left behind")
+		}
+	})
+
+	t.Run("modified manifest", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		src := encryptToFile(t, dir, data, manifest, recipient)
+		raw, err := os.ReadFile(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tampered := bytes.Replace(raw, []byte("node-enc"), []byte("node-xyz"), 1)
+		if bytes.Equal(tampered, raw) {
+			t.Fatal("manifest not found in header")
+		}
+		if err := os.WriteFile(src, tampered, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := DecryptFile(src, filepath.Join(dir, "out"), identity); err == nil {
+			t.Fatal("archive with a modified header decrypted")
+		}
+	})
+}
+
+func TestParseKeys_Invalid(t *testing.T) {
+	t.Parallel()
+	if _, err := ParseRecipient("age1qqqq"); err == nil {
+		t.Error("ParseRecipient accepted a foreign key")
+	}
+	if _, err := ParseIdentity(recipientPrefix + "AAAA"); err == nil {
+		t.Error("ParseIdentity accepted a public key")
+	}
+	if _, err := ParseRecipient(recipientPrefix + "short"); err == nil {
+		t.Error("ParseRecipient accepted a short key")
+	}
+}
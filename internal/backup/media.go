@@ -0,0 +1,328 @@
+package backup
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hegner123/modulacms/internal/bucket"
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+)
+
+// Values of backup_media.
+const (
+	// MediaArchive stores media objects in the backup archive.
+	MediaArchive = "archive"
+	// MediaSibling stores media objects in a separate archive next to the
+	// backup, named by MediaArchivePath.
+	MediaSibling = "sibling"
+)
+
+// Media sets: the bucket a media object belongs to.
+const (
+	mediaSetPublic = "media"
+	mediaSetAdmin  = "admin_media"
+)
+
+// mediaIndexName is the archive entry listing the media objects.
+const mediaIndexName = "media/index.json"
+
+// MediaObject is one media object stored in a backup.
+type MediaObject struct {
+	Set         string `json:"set"`
+	Key         string `json:"key"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256"`
+	ContentType string `json:"content_type,omitempty"`
+}
+
+// entryName is the object's path inside the archive.
+func (o MediaObject) entryName() string {
+	return path.Join("media", o.Set, o.Key)
+}
+
+// MediaArchivePath returns the path of the sibling media archive for the
+// backup archive at backupPath: backup_X.zip becomes backup_X.media.zip,
+// and backup_X.zip.enc becomes backup_X.media.zip.enc.
+func MediaArchivePath(backupPath string) string {
+	base, encrypted := strings.CutSuffix(backupPath, EncryptedExt)
+	base = strings.TrimSuffix(base, ".zip") + ".media.zip"
+	if encrypted {
+		base += EncryptedExt
+	}
+	return base
+}
+
+// objectStore reads and writes the objects of one media bucket.
+type objectStore interface {
+	Get(ctx context.Context, key string) (body io.ReadCloser, contentType string, err error)
+	Put(ctx context.Context, key string, body io.ReadSeeker, size int64, contentType string) error
+	// Size returns the stored size of an object.
+	Size(ctx context.Context, key string) (int64, error)
+}
+
+// mediaStores maps a media set to its bucket.
+type mediaStores map[string]objectStore
+
+// newMediaStores connects to the public and admin media buckets.
+func newMediaStores(cfg config.Config) (mediaStores, error) {
+	if cfg.Bucket_Media == "" {
+		return nil, fmt.Errorf("backup_media is set but bucket_media is empty")
+	}
+	acl := cfg.Bucket_Default_ACL
+	if acl == "" {
+		acl = "public-read"
+	}
+	publicSvc, err := bucket.GetS3Creds(&cfg).GetBucket()
+	if err != nil {
+		return nil, fmt.Errorf("connect to media bucket: %w", err)
+	}
+	adminSvc, err := bucket.S3Credentials{
+		AccessKey:      cfg.AdminBucketAccessKey(),
+		SecretKey:      cfg.AdminBucketSecretKey(),
+		URL:            cfg.AdminBucketEndpointURL(),
+		Region:         cfg.Bucket_Region,
+		ForcePathStyle: cfg.Bucket_Force_Path_Style,
+	}.GetBucket()
+	if err != nil {
+		return nil, fmt.Errorf("connect to admin media bucket: %w", err)
+	}
+	return mediaStores{
+		mediaSetPublic: s3ObjectStore{svc: publicSvc, bucket: cfg.Bucket_Media, acl: acl},
+		mediaSetAdmin:  s3ObjectStore{svc: adminSvc, bucket: cfg.AdminBucketMedia(), acl: acl},
+	}, nil
+}
+
+// referencedMedia returns the object keys of every original and variant
+// referenced by the media and admin_media tables, by set. URLs outside the
+// configured buckets are skipped.
+func referencedMedia(driver db.DbDriver, cfg config.Config) (map[string][]string, error) {
+	if driver == nil {
+		return nil, fmt.Errorf("driver required to back up media")
+	}
+	keys := map[string]map[string]bool{mediaSetPublic: {}, mediaSetAdmin: {}}
+	add := func(set, prefix, url string, srcset db.NullString) {
+		urls := []string{url}
+		if srcset.Valid && srcset.String != "" {
+			var variants []string
+			if err := json.Unmarshal([]byte(srcset.String), &variants); err == nil {
+				urls = append(urls, variants...)
+			}
+		}
+		for _, u := range urls {
+			if key, ok := strings.CutPrefix(u, prefix); ok && key != "" {
+				keys[set][key] = true
+			}
+		}
+	}
+
+	media, err := driver.ListMedia()
+	if err != nil {
+		return nil, fmt.Errorf("list media: %w", err)
+	}
+	publicPrefix := cfg.BucketPublicURL() + "/" + cfg.Bucket_Media + "/"
+	if media != nil {
+		for _, m := range *media {
+			add(mediaSetPublic, publicPrefix, string(m.URL), m.Srcset)
+		}
+	}
+	adminMedia, err := driver.ListAdminMedia()
+	if err != nil {
+		return nil, fmt.Errorf("list admin media: %w", err)
+	}
+	adminPrefix := cfg.AdminBucketPublicURL() + "/" + cfg.AdminBucketMedia() + "/"
+	if adminMedia != nil {
+		for _, m := range *adminMedia {
+			add(mediaSetAdmin, adminPrefix, string(m.URL), m.Srcset)
+		}
+	}
+
+	out := make(map[string][]string, len(keys))
+	for set, m := range keys {
+		for k := range m {
+			out[set] = append(out[set], k)
+		}
+		sort.Strings(out[set])
+	}
+	return out, nil
+}
+
+// writeMedia streams each referenced object into the archive and writes
+// the index with each object's size and SHA-256. It returns the number of
+// objects written.
+func writeMedia(ctx context.Context, zw *zip.Writer, stores mediaStores, keys map[string][]string) (int, error) {
+	var index []MediaObject
+	for _, set := range []string{mediaSetPublic, mediaSetAdmin} {
+		store, ok := stores[set]
+		if !ok && len(keys[set]) > 0 {
+			return 0, fmt.Errorf("no store for %s", set)
+		}
+		for _, key := range keys[set] {
+			obj, err := writeMediaObject(ctx, zw, store, set, key)
+			if err != nil {
+				return 0, err
+			}
+			index = append(index, obj)
+		}
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("encode media index: %w", err)
+	}
+	w, err := zw.Create(mediaIndexName)
+	if err != nil {
+		return 0, fmt.Errorf("create media index in archive: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return 0, fmt.Errorf("write media index: %w", err)
+	}
+	return len(index), nil
+}
+
+func writeMediaObject(ctx context.Context, zw *zip.Writer, store objectStore, set, key string) (MediaObject, error) {
+	obj := MediaObject{Set: set, Key: key}
+	body, contentType, err := store.Get(ctx, key)
+	if err != nil {
+		return obj, fmt.Errorf("download %s/%s: %w", set, key, err)
+	}
+	defer body.Close()
+	obj.ContentType = contentType
+
+	// Media is usually compressed already.
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: obj.entryName(), Method: zip.Store})
+	if err != nil {
+		return obj, fmt.Errorf("create %s in archive: %w", obj.entryName(), err)
+	}
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(w, h), body)
+	if err != nil {
+		return obj, fmt.Errorf("copy %s/%s to archive: %w", set, key, err)
+	}
+	obj.Size = n
+	obj.SHA256 = hex.EncodeToString(h.Sum(nil))
+	return obj, nil
+}
+
+// readMediaIndex reads the media index from an extracted archive.
+func readMediaIndex(dir string) ([]MediaObject, error) {
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(mediaIndexName)))
+	if err != nil {
+		return nil, fmt.Errorf("read media index: %w", err)
+	}
+	var index []MediaObject
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("parse media index: %w", err)
+	}
+	return index, nil
+}
+
+// verifyMedia checks every extracted media object against the index.
+func verifyMedia(dir string, index []MediaObject) error {
+	for _, obj := range index {
+		if obj.Set != mediaSetPublic && obj.Set != mediaSetAdmin {
+			return fmt.Errorf("media index has unknown set %q", obj.Set)
+		}
+		if obj.Key == "" || strings.Contains(obj.Key, "..") || strings.HasPrefix(obj.Key, "/") {
+			return fmt.Errorf("media index has invalid key %q", obj.Key)
+		}
+		sum, err := fileSHA256(filepath.Join(dir, filepath.FromSlash(obj.entryName())))
+		if err != nil {
+			return err
+		}
+		if sum != obj.SHA256 {
+			return fmt.Errorf("media %s/%s checksum %s, want %s", obj.Set, obj.Key, sum, obj.SHA256)
+		}
+	}
+	return nil
+}
+
+// restoreMedia uploads every indexed object from an extracted archive to
+// its bucket and confirms the stored size. It returns the number restored.
+func restoreMedia(ctx context.Context, dir string, index []MediaObject, stores mediaStores) (int, error) {
+	for i, obj := range index {
+		store, ok := stores[obj.Set]
+		if !ok {
+			return i, fmt.Errorf("no store for %s", obj.Set)
+		}
+		if err := restoreMediaObject(ctx, dir, store, obj); err != nil {
+			return i, err
+		}
+	}
+	return len(index), nil
+}
+
+func restoreMediaObject(ctx context.Context, dir string, store objectStore, obj MediaObject) error {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(obj.entryName())))
+	if err != nil {
+		return fmt.Errorf("open %s: %w", obj.entryName(), err)
+	}
+	defer f.Close()
+	if err := store.Put(ctx, obj.Key, f, obj.Size, obj.ContentType); err != nil {
+		return fmt.Errorf("upload %s/%s: %w", obj.Set, obj.Key, err)
+	}
+	size, err := store.Size(ctx, obj.Key)
+	if err != nil {
+		return fmt.Errorf("confirm upload of %s/%s: %w", obj.Set, obj.Key, err)
+	}
+	if size != obj.Size {
+		return fmt.Errorf("uploaded %s/%s is %d bytes, want %d", obj.Set, obj.Key, size, obj.Size)
+	}
+	return nil
+}
+
+// s3ObjectStore is an objectStore for one S3 bucket.
+type s3ObjectStore struct {
+	svc    *s3.S3
+	bucket string
+	acl    string
+}
+
+func (s s3ObjectStore) Get(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	out, err := s.svc.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return out.Body, aws.StringValue(out.ContentType), nil
+}
+
+func (s s3ObjectStore) Put(ctx context.Context, key string, body io.ReadSeeker, size int64, contentType string) error {
+	input := &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		Body:          body,
+		ContentLength: aws.Int64(size),
+		ACL:           aws.String(s.acl),
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+	_, err := s.svc.PutObjectWithContext(ctx, input)
+	return err
+}
+
+func (s s3ObjectStore) Size(ctx context.Context, key string) (int64, error) {
+	head, err := s.svc.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return aws.Int64Value(head.ContentLength), nil
+}
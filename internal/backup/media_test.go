@@ -0,0 +1,321 @@
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// memStore is an in-memory objectStore.
+type memStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func newMemStore() *memStore {
+	return &memStore{objects: map[string][]byte{}, types: map[string]string{}}
+}
+
+func (s *memStore) Get(_ context.Context, key string) (io.ReadCloser, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, "", fmt.Errorf("no such key %s", key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), s.types[key], nil
+}
+
+func (s *memStore) Put(_ context.Context, key string, body io.ReadSeeker, _ int64, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = data
+	s.types[key] = contentType
+	return nil
+}
+
+func (s *memStore) Size(_ context.Context, key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.objects[key]
+	if !ok {
+		return 0, fmt.Errorf("no such key %s", key)
+	}
+	return int64(len(data)), nil
+}
+
+// mediaDriver serves media rows for referencedMedia. Other DbDriver
+// methods are not used.
+type mediaDriver struct {
+	db.DbDriver
+	media []db.Media
+	admin []db.AdminMedia
+}
+
+func (d mediaDriver) ListMedia() (*[]db.Media, error)           { return &d.media, nil }
+func (d mediaDriver) ListAdminMedia() (*[]db.AdminMedia, error) { return &d.admin, nil }
+
+// mediaFixture is a SQLite file backup with public and admin media in the
+// buckets.
+type mediaFixture struct {
+	dir    string
+	cfg    config.Config
+	driver mediaDriver
+	stores mediaStores
+	public *memStore
+	admin  *memStore
+}
+
+func newMediaFixture(t *testing.T, mode string) *mediaFixture {
+	t.Helper()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "app.db")
+	createTempFile(t, dbPath, "SQLite format 3\x00media-backup")
+	cfg := config.Config{
+		Db_Driver:         config.Sqlite,
+		Db_URL:            dbPath,
+		Node_ID:           "node-media",
+		Backup_Option:     dir,
+		Backup_Media:      mode,
+		Bucket_Media:      "media",
+		Bucket_Public_URL: "https://cdn.example.com",
+	}
+	public, admin := newMemStore(), newMemStore()
+	public.objects["2026/3/photo.jpg"] = []byte("original-jpeg")
+	public.types["2026/3/photo.jpg"] = "image/jpeg"
+	public.objects["2026/3/photo-640w.webp"] = []byte("variant-webp")
+	admin.objects["admin/logo.png"] = []byte("admin-png")
+
+	driver := mediaDriver{
+		media: []db.Media{{
+			URL:    types.URL("https://cdn.example.com/media/2026/3/photo.jpg"),
+			Srcset: db.NewNullString(`["https://cdn.example.com/media/2026/3/photo-640w.webp"]`),
+		}, {
+			// Hosted elsewhere: not backed up.
+			URL: types.URL("https://elsewhere.example.com/image.png"),
+		}},
+		admin: []db.AdminMedia{{URL: types.URL("https://cdn.example.com/media/admin/logo.png")}},
+	}
+	return &mediaFixture{
+		dir:    dir,
+		cfg:    cfg,
+		driver: driver,
+		stores: mediaStores{mediaSetPublic: public, mediaSetAdmin: admin},
+		public: public,
+		admin:  admin,
+	}
+}
+
+// restoreInto restores the backup at path to a new database file with empty
+// buckets and returns them.
+func (f *mediaFixture) restoreInto(t *testing.T, path string, enc *Encryption) (string, mediaStores, error) {
+	t.Helper()
+	cfg := f.cfg
+	cfg.Db_URL = filepath.Join(t.TempDir(), "restored.db")
+	stores := mediaStores{mediaSetPublic: newMemStore(), mediaSetAdmin: newMemStore()}
+	err := restoreFromBackup(context.Background(), cfg, path, enc, func() (mediaStores, error) { return stores, nil })
+	return cfg.Db_URL, stores, err
+}
+
+func TestBackupMedia_ArchiveEncryptedRoundTrip(t *testing.T) {
+	t.Parallel()
+	f := newMediaFixture(t, MediaArchive)
+	enc := &Encryption{Passphrase: "backup-pass"}
+
+	path, _, err := createFullBackup(context.Background(), f.cfg, f.driver, enc, f.stores)
+	if err != nil {
+		t.Fatalf("createFullBackup: %v", err)
+	}
+	if !strings.HasSuffix(path, ".zip"+EncryptedExt) {
+		t.Errorf("path = %s, want .zip.enc suffix", path)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, []byte("original-jpeg")) || bytes.Contains(raw, []byte("media-backup")) {
+		t.Error("encrypted archive contains plaintext")
+	}
+
+	if _, _, err := f.restoreInto(t, path, nil); err == nil {
+		t.Error("restore without a key succeeded")
+	}
+
+	dbPath, stores, err := f.restoreInto(t, path, enc)
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if got, _ := os.ReadFile(dbPath); string(got) != "SQLite format 3\x00media-backup" {
+		t.Errorf("restored database = %q", got)
+	}
+	public := stores[mediaSetPublic].(*memStore)
+	if len(public.objects) != 2 || string(public.objects["2026/3/photo-640w.webp"]) != "variant-webp" {
+		t.Errorf("public objects = %v", public.objects)
+	}
+	if public.types["2026/3/photo.jpg"] != "image/jpeg" {
+		t.Errorf("content type = %q, want image/jpeg", public.types["2026/3/photo.jpg"])
+	}
+	admin := stores[mediaSetAdmin].(*memStore)
+	if string(admin.objects["admin/logo.png"]) != "admin-png" {
+		t.Errorf("admin objects = %v", admin.objects)
+	}
+}
+
+func TestBackupMedia_Sibling(t *testing.T) {
+	t.Parallel()
+	f := newMediaFixture(t, MediaSibling)
+
+	path, _, err := createFullBackup(context.Background(), f.cfg, f.driver, nil, f.stores)
+	if err != nil {
+		t.Fatalf("createFullBackup: %v", err)
+	}
+	for _, name := range zipEntryNames(t, path) {
+		if strings.HasPrefix(name, "media/") {
+			t.Errorf("backup archive contains %s; media belongs in the sibling", name)
+		}
+	}
+	mediaPath := MediaArchivePath(path)
+	if got := readZipEntry(t, mediaPath, "media/media/2026/3/photo.jpg"); got != "original-jpeg" {
+		t.Errorf("sibling photo = %q", got)
+	}
+
+	_, stores, err := f.restoreInto(t, path, nil)
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if n := len(stores[mediaSetPublic].(*memStore).objects); n != 2 {
+		t.Errorf("restored %d public objects, want 2", n)
+	}
+
+	if err := os.Remove(mediaPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := f.restoreInto(t, path, nil); err == nil || !strings.Contains(err.Error(), "media archive") {
+		t.Errorf("restore without the sibling: err = %v", err)
+	}
+}
+
+func TestBackupMedia_CorruptObjectStopsRestore(t *testing.T) {
+	t.Parallel()
+	f := newMediaFixture(t, MediaSibling)
+	path, _, err := createFullBackup(context.Background(), f.cfg, f.driver, nil, f.stores)
+	if err != nil {
+		t.Fatalf("createFullBackup: %v", err)
+	}
+
+	// Rewrite the sibling with one object's content changed. The zip CRC is
+	// valid; only the recorded SHA-256 catches it.
+	mediaPath := MediaArchivePath(path)
+	r, err := zip.OpenReader(mediaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, zf := range r.File {
+		rc, err := zf.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if zf.Name == "media/admin_media/admin/logo.png" {
+			data = []byte("tampered")
+		}
+		w, err := zw.Create(zf.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(data)
+	}
+	r.Close()
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(mediaPath, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dbPath, stores, err := f.restoreInto(t, path, nil)
+	if err == nil || !strings.Contains(err.Error(), "media verification failed") {
+		t.Fatalf("restore: err = %v, want media verification failure", err)
+	}
+	if _, statErr := os.Stat(dbPath); !os.IsNotExist(statErr) {
+		t.Error("database was restored despite corrupt media")
+	}
+	if n := len(stores[mediaSetPublic].(*memStore).objects); n != 0 {
+		t.Errorf("%d objects uploaded despite corrupt media", n)
+	}
+}
+
+func TestMediaArchivePath(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"backups/backup_20260301_030000.zip":     "backups/backup_20260301_030000.media.zip",
+		"backups/backup_20260301_030000.zip.enc": "backups/backup_20260301_030000.media.zip.enc",
+		"s3://b/backups/n/backup_1.zip.enc":      "s3://b/backups/n/backup_1.media.zip.enc",
+	}
+	for in, want := range tests {
+		if got := MediaArchivePath(in); got != want {
+			t.Errorf("MediaArchivePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestVerifyArchive_Encrypted(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "app.db")
+	createTempFile(t, dbPath, "SQLite format 3\x00verify")
+	_, pub, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.Config{
+		Db_Driver:                    config.Sqlite,
+		Db_URL:                       dbPath,
+		Node_ID:                      "node-v",
+		Backup_Option:                dir,
+		Backup_Encryption_Passphrase: "pw",
+	}
+	path, _, err := CreateFullBackup(cfg, nil)
+	if err != nil {
+		t.Fatalf("CreateFullBackup: %v", err)
+	}
+	if _, _, err := VerifyArchive(path, cfg); err != nil {
+		t.Errorf("VerifyArchive with passphrase: %v", err)
+	}
+
+	wrong := cfg
+	wrong.Backup_Encryption_Passphrase = "nope"
+	if _, _, err := VerifyArchive(path, wrong); err == nil {
+		t.Error("VerifyArchive with the wrong passphrase succeeded")
+	}
+
+	// A server with only the recipient key can check the header only.
+	recipientOnly := cfg
+	recipientOnly.Backup_Encryption_Passphrase = ""
+	recipientOnly.Backup_Encryption_Recipient = pub
+	if _, manifest, err := VerifyArchive(path, recipientOnly); err != nil || !manifest.Encrypted {
+		t.Errorf("VerifyArchive with recipient only = %+v, %v", manifest, err)
+	}
+}
@@ -2,6 +2,7 @@ package backup
 
 import (
 	"archive/zip"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,7 +16,17 @@ import (
 )
 
 // ReadManifest extracts and parses the manifest.json from a backup archive.
+// For an encrypted archive the manifest is read from its header, which does
+// not need the key; it is authenticated when the archive is decrypted.
 func ReadManifest(backupPath string) (*BackupManifest, error) {
+	encrypted, err := IsEncrypted(backupPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup archive: %w", err)
+	}
+	if encrypted {
+		return readEncryptedManifest(backupPath)
+	}
+
 	r, err := zip.OpenReader(backupPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open backup archive: %w", err)
@@ -48,10 +59,41 @@ func ReadManifest(backupPath string) (*BackupManifest, error) {
 	return nil, fmt.Errorf("manifest.json not found in backup archive")
 }
 
+func readEncryptedManifest(backupPath string) (*BackupManifest, error) {
+	f, err := os.Open(backupPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup archive: %w", err)
+	}
+	defer f.Close()
+	h, _, err := readEncryptionHeader(f)
+	if err != nil {
+		return nil, err
+	}
+	if h.Manifest == nil {
+		return nil, fmt.Errorf("manifest not found in encrypted backup archive")
+	}
+	return h.Manifest, nil
+}
+
 // RestoreFromBackup restores a backup archive to the configured database.
 // For SQLite, this replaces the database file.
 // For MySQL/PostgreSQL, this pipes the SQL dump into the respective client.
+//
+// Encrypted archives are decrypted with the configured backup passphrase or
+// identity. When the archive includes media, every object is checked
+// against its recorded SHA-256 before the database is touched, then
+// uploaded to the configured media buckets.
 func RestoreFromBackup(cfg config.Config, backupPath string) error {
+	enc, err := EncryptionFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+	return restoreFromBackup(context.Background(), cfg, backupPath, enc, func() (mediaStores, error) {
+		return newMediaStores(cfg)
+	})
+}
+
+func restoreFromBackup(ctx context.Context, cfg config.Config, backupPath string, enc *Encryption, openStores func() (mediaStores, error)) error {
 	// Read and verify manifest
 	manifest, err := ReadManifest(backupPath)
 	if err != nil {
@@ -70,10 +112,46 @@ func RestoreFromBackup(cfg config.Config, backupPath string) error {
 	defer os.RemoveAll(tempDir)
 
 	// Extract zip to temp dir
-	if err := unzip(backupPath, tempDir); err != nil {
+	if err := extractArchive(backupPath, tempDir, enc); err != nil {
 		return fmt.Errorf("failed to extract backup archive: %w", err)
 	}
 
+	// Verify media before replacing anything
+	var mediaDir string
+	var mediaIndex []MediaObject
+	switch manifest.Media {
+	case "":
+	case MediaArchive:
+		mediaDir = tempDir
+	case MediaSibling:
+		mediaPath := filepath.Join(filepath.Dir(backupPath), manifest.MediaArchive)
+		if manifest.MediaArchive == "" || !utility.FileExists(mediaPath) {
+			return fmt.Errorf("media archive %q not found next to the backup", manifest.MediaArchive)
+		}
+		mediaDir = filepath.Join(tempDir, "media-archive")
+		if err := extractArchive(mediaPath, mediaDir, enc); err != nil {
+			return fmt.Errorf("failed to extract media archive: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported media mode %q in backup manifest", manifest.Media)
+	}
+	var stores mediaStores
+	if mediaDir != "" {
+		mediaIndex, err = readMediaIndex(mediaDir)
+		if err != nil {
+			return err
+		}
+		if err := verifyMedia(mediaDir, mediaIndex); err != nil {
+			return fmt.Errorf("media verification failed: %w", err)
+		}
+		if len(mediaIndex) > 0 {
+			stores, err = openStores()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	// Restore database based on driver type
 	switch cfg.Db_Driver {
 	case config.Sqlite:
@@ -100,9 +178,39 @@ func RestoreFromBackup(cfg config.Config, backupPath string) error {
 		}
 	}
 
+	if len(mediaIndex) > 0 {
+		restored, err := restoreMedia(ctx, mediaDir, mediaIndex, stores)
+		if err != nil {
+			return fmt.Errorf("media restore failed after %d of %d objects: %w", restored, len(mediaIndex), err)
+		}
+		utility.DefaultLogger.Info("restored media objects", "count", restored)
+	}
+
 	return nil
 }
 
+// extractArchive unzips the archive at src into dest, decrypting it first
+// when it is encrypted.
+func extractArchive(src, dest string, enc *Encryption) error {
+	encrypted, err := IsEncrypted(src)
+	if err != nil {
+		return err
+	}
+	if !encrypted {
+		return unzip(src, dest)
+	}
+	tmp, err := os.CreateTemp("", "modula-decrypt-*.zip")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+	if _, err := DecryptFile(src, tmp.Name(), enc); err != nil {
+		return err
+	}
+	return unzip(tmp.Name(), dest)
+}
+
 func restoreSQLite(cfg config.Config, tempDir string) error {
 	srcDB := filepath.Join(tempDir, "database.db")
 	if !utility.FileExists(srcDB) {
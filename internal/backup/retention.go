@@ -0,0 +1,80 @@
+package backup
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hegner123/modulacms/internal/db"
+)
+
+// RetentionPolicy is a grandfather-father-son policy: keep the newest backup
+// of each of the last Daily days, Weekly ISO weeks and Monthly months that
+// have a backup. A backup kept by any rule is kept. Periods without backups
+// do not count, so a paused schedule does not age out the backups it left.
+type RetentionPolicy struct {
+	Daily   int
+	Weekly  int
+	Monthly int
+}
+
+// Enabled reports whether the policy prunes anything. A policy with every
+// count at zero keeps all backups.
+func (p RetentionPolicy) Enabled() bool {
+	return p.Daily > 0 || p.Weekly > 0 || p.Monthly > 0
+}
+
+// Expired returns the backups the policy does not keep, oldest first. The
+// newest backup is always kept. Backups without a start time are never
+// expired.
+func (p RetentionPolicy) Expired(backups []db.Backup) []db.Backup {
+	if !p.Enabled() {
+		return nil
+	}
+	sorted := make([]db.Backup, 0, len(backups))
+	for _, b := range backups {
+		if b.StartedAt.Valid {
+			sorted = append(sorted, b)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartedAt.Time.After(sorted[j].StartedAt.Time)
+	})
+
+	keep := make(map[int]bool, len(sorted))
+	if len(sorted) > 0 {
+		keep[0] = true
+	}
+	rules := []struct {
+		count  int
+		period func(db.Backup) string
+	}{
+		{p.Daily, func(b db.Backup) string { return b.StartedAt.Time.UTC().Format("2006-01-02") }},
+		{p.Weekly, func(b db.Backup) string {
+			y, w := b.StartedAt.Time.UTC().ISOWeek()
+			return fmt.Sprintf("%d-W%02d", y, w)
+		}},
+		{p.Monthly, func(b db.Backup) string { return b.StartedAt.Time.UTC().Format("2006-01") }},
+	}
+	for _, rule := range rules {
+		seen := make(map[string]bool, rule.count)
+		for i, b := range sorted {
+			if len(seen) >= rule.count {
+				break
+			}
+			key := rule.period(b)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			keep[i] = true
+		}
+	}
+
+	var expired []db.Backup
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if !keep[i] {
+			expired = append(expired, sorted[i])
+		}
+	}
+	return expired
+}
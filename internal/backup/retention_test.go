@@ -0,0 +1,104 @@
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// dailyBackups returns one backup per day at 02:00 UTC for n days ending on
+// last, newest first. BackupIDs are the dates.
+func dailyBackups(last time.Time, n int) []db.Backup {
+	out := make([]db.Backup, 0, n)
+	for i := 0; i < n; i++ {
+		day := last.AddDate(0, 0, -i)
+		out = append(out, db.Backup{
+			BackupID:  types.BackupID(day.Format("2006-01-02")),
+			StartedAt: types.NewTimestamp(day),
+		})
+	}
+	return out
+}
+
+func backupIDs(backups []db.Backup) map[types.BackupID]bool {
+	ids := make(map[types.BackupID]bool, len(backups))
+	for _, b := range backups {
+		ids[b.BackupID] = true
+	}
+	return ids
+}
+
+func TestRetentionPolicy_Disabled(t *testing.T) {
+	t.Parallel()
+	backups := dailyBackups(time.Date(2026, 3, 31, 2, 0, 0, 0, time.UTC), 10)
+	if got := (RetentionPolicy{}).Expired(backups); len(got) != 0 {
+		t.Errorf("Expired = %d backups, want 0", len(got))
+	}
+}
+
+func TestRetentionPolicy_Expired_GFS(t *testing.T) {
+	t.Parallel()
+	// 120 daily backups ending Tuesday 2026-03-31.
+	backups := dailyBackups(time.Date(2026, 3, 31, 2, 0, 0, 0, time.UTC), 120)
+	policy := RetentionPolicy{Daily: 3, Weekly: 2, Monthly: 3}
+
+	expired := policy.Expired(backups)
+	kept := backupIDs(backups)
+	for id := range backupIDs(expired) {
+		delete(kept, id)
+	}
+
+	want := map[types.BackupID]bool{
+		// Daily: the last three days.
+		"2026-03-31": true, "2026-03-30": true, "2026-03-29": true,
+		// Weekly: the newest of this ISO week (03-31) and last (03-29).
+		// Monthly: the newest of March (03-31), February and January.
+		"2026-02-28": true, "2026-01-31": true,
+	}
+	if len(kept) != len(want) {
+		t.Errorf("kept %d backups %v, want %d", len(kept), kept, len(want))
+	}
+	for id := range want {
+		if !kept[id] {
+			t.Errorf("backup %s expired, want kept", id)
+		}
+	}
+
+	for i := 1; i < len(expired); i++ {
+		if expired[i].StartedAt.Time.Before(expired[i-1].StartedAt.Time) {
+			t.Fatalf("Expired not oldest first: %s before %s", expired[i-1].BackupID, expired[i].BackupID)
+		}
+	}
+}
+
+func TestRetentionPolicy_Expired_KeepsNewest(t *testing.T) {
+	t.Parallel()
+	backups := dailyBackups(time.Date(2026, 3, 31, 2, 0, 0, 0, time.UTC), 5)
+	// Only monthly retention, and two backups on the newest day.
+	backups = append(backups, db.Backup{
+		BackupID:  "newest",
+		StartedAt: types.NewTimestamp(time.Date(2026, 3, 31, 20, 0, 0, 0, time.UTC)),
+	})
+	expired := backupIDs(RetentionPolicy{Monthly: 1}.Expired(backups))
+	if expired["newest"] {
+		t.Error("newest backup expired")
+	}
+	if len(expired) != 5 {
+		t.Errorf("expired %d backups, want 5", len(expired))
+	}
+}
+
+func TestRetentionPolicy_Expired_SkipsMissingStartTime(t *testing.T) {
+	t.Parallel()
+	backups := dailyBackups(time.Date(2026, 3, 31, 2, 0, 0, 0, time.UTC), 3)
+	backups = append(backups, db.Backup{BackupID: "undated"})
+	expired := backupIDs(RetentionPolicy{Daily: 1}.Expired(backups))
+	if expired["undated"] {
+		t.Error("backup without start time expired")
+	}
+	if len(expired) != 2 {
+		t.Errorf("expired %d backups, want 2", len(expired))
+	}
+}
@@ -0,0 +1,539 @@
+package backup
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hegner123/modulacms/internal/bucket"
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// TriggeredBySchedule is the backups.triggered_by value of scheduled backups.
+// Retention only prunes backups with this value, never manual ones.
+const TriggeredBySchedule = "schedule"
+
+// bucketPrefix is the key prefix for archives in the backup bucket. Archives
+// are stored under bucketPrefix + node ID.
+const bucketPrefix = "backups/"
+
+// Failure describes a scheduled backup that did not complete.
+type Failure struct {
+	BackupID types.BackupID
+	// Stage is the step that failed: "create", "verify", "upload", "record"
+	// or "prune".
+	Stage string
+	Err   error
+	At    time.Time
+}
+
+// FailureNotifier is called when a scheduled backup fails.
+type FailureNotifier func(ctx context.Context, f Failure)
+
+// ScheduledResult describes a completed scheduled backup.
+type ScheduledResult struct {
+	BackupID    types.BackupID
+	StoragePath string
+	SizeBytes   int64
+	Checksum    string
+	Pruned      int
+}
+
+// StartScheduler runs scheduled backups in a background goroutine until ctx
+// is canceled. The schedule is read from backup_schedule once; the target,
+// retention and notification settings are re-read from mgr before each run.
+// It returns an error if the schedule cannot be parsed.
+func StartScheduler(ctx context.Context, mgr *config.Manager, driver db.DbDriver, notify FailureNotifier) error {
+	cfg, err := mgr.Config()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	schedule, err := ParseSchedule(cfg.Backup_Schedule)
+	if err != nil {
+		return err
+	}
+	if schedule.Next(time.Now()).IsZero() {
+		return fmt.Errorf("backup schedule %q never fires", cfg.Backup_Schedule)
+	}
+
+	go func() {
+		for {
+			next := schedule.Next(time.Now())
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			current, cfgErr := mgr.Config()
+			if cfgErr != nil {
+				utility.DefaultLogger.Error("scheduled backup: load config", cfgErr)
+				continue
+			}
+			result, failure := RunScheduled(ctx, *current, driver)
+			if failure != nil {
+				utility.DefaultLogger.Error("scheduled backup failed", failure.Err, "stage", failure.Stage, "backup_id", failure.BackupID)
+				if notify != nil {
+					notify(ctx, *failure)
+				}
+				continue
+			}
+			utility.DefaultLogger.Info("scheduled backup completed",
+				"backup_id", result.BackupID,
+				"path", result.StoragePath,
+				"size_bytes", result.SizeBytes,
+				"pruned", result.Pruned,
+			)
+		}
+	}()
+	return nil
+}
+
+// RunScheduled creates a backup archive, verifies it, moves it to the
+// configured target and records it in the backups table, then prunes
+// scheduled backups outside the retention policy. A failure in any step is
+// recorded as a failed backup and returned. Pruning failures are returned
+// with the result of the completed backup.
+func RunScheduled(ctx context.Context, cfg config.Config, driver db.DbDriver) (*ScheduledResult, *Failure) {
+	backupID := types.NewBackupID()
+	started := time.Now().UTC()
+	fail := func(stage string, err error) *Failure {
+		recordFailedBackup(cfg, driver, backupID, started, stage, err)
+		return &Failure{BackupID: backupID, Stage: stage, Err: err, At: time.Now().UTC()}
+	}
+
+	store, err := newArchiveStore(cfg)
+	if err != nil {
+		return nil, fail("upload", err)
+	}
+
+	path, size, err := CreateFullBackup(cfg, driver)
+	if err != nil {
+		return nil, fail("create", err)
+	}
+
+	checksum, manifest, err := VerifyArchive(path, cfg)
+	if err != nil {
+		os.Remove(path)
+		os.Remove(MediaArchivePath(path))
+		return nil, fail("verify", err)
+	}
+	var mediaChecksum string
+	if manifest.MediaArchive != "" {
+		mediaChecksum, _, err = VerifyArchive(MediaArchivePath(path), cfg)
+		if err != nil {
+			os.Remove(path)
+			os.Remove(MediaArchivePath(path))
+			return nil, fail("verify", fmt.Errorf("media archive: %w", err))
+		}
+	}
+
+	// The media archive goes first: once the backup itself is stored,
+	// pruning finds its sibling by name.
+	var mediaStoragePath string
+	if manifest.MediaArchive != "" {
+		mediaStoragePath, err = store.Put(ctx, MediaArchivePath(path), mediaChecksum)
+		if err != nil {
+			return nil, fail("upload", fmt.Errorf("media archive: %w", err))
+		}
+	}
+	storagePath, err := store.Put(ctx, path, checksum)
+	if err != nil {
+		// Without its backup the stored media archive is an orphan that
+		// pruning would never find.
+		if mediaStoragePath != "" {
+			if delErr := deleteStored(ctx, cfg, mediaStoragePath); delErr != nil {
+				utility.DefaultLogger.Warn("failed to remove orphaned media archive", delErr, "path", mediaStoragePath)
+			}
+		}
+		return nil, fail("upload", err)
+	}
+
+	meta := map[string]any{
+		"target":   cfg.BackupTarget(),
+		"verified": true,
+		"manifest": manifest,
+	}
+	if mediaStoragePath != "" {
+		meta["media_archive"] = mediaStoragePath
+		meta["media_checksum"] = mediaChecksum
+	}
+	metadata, err := json.Marshal(meta)
+	if err != nil {
+		return nil, fail("record", err)
+	}
+	if _, err := driver.CreateBackup(db.CreateBackupParams{
+		BackupID:    backupID,
+		NodeID:      types.NodeID(cfg.Node_ID),
+		BackupType:  types.BackupTypeFull,
+		Status:      types.BackupStatusInProgress,
+		StartedAt:   types.NewTimestamp(started),
+		StoragePath: storagePath,
+		TriggeredBy: types.NullableString{String: TriggeredBySchedule, Valid: true},
+		Metadata:    types.JSONData{Data: json.RawMessage(metadata), Valid: true},
+	}); err != nil {
+		return nil, &Failure{BackupID: backupID, Stage: "record", Err: fmt.Errorf("record backup %s: %w", storagePath, err), At: time.Now().UTC()}
+	}
+	if err := driver.UpdateBackupStatus(db.UpdateBackupStatusParams{
+		BackupID:    backupID,
+		Status:      types.BackupStatusCompleted,
+		CompletedAt: types.NewTimestamp(time.Now().UTC()),
+		DurationMs:  types.NullableInt64{Int64: time.Since(started).Milliseconds(), Valid: true},
+		SizeBytes:   types.NullableInt64{Int64: size, Valid: true},
+		Checksum:    types.NullableString{String: checksum, Valid: true},
+	}); err != nil {
+		return nil, &Failure{BackupID: backupID, Stage: "record", Err: fmt.Errorf("complete backup record: %w", err), At: time.Now().UTC()}
+	}
+
+	result := &ScheduledResult{BackupID: backupID, StoragePath: storagePath, SizeBytes: size, Checksum: checksum}
+	pruned, err := PruneScheduled(ctx, cfg, driver)
+	result.Pruned = pruned
+	if err != nil {
+		return result, &Failure{BackupID: backupID, Stage: "prune", Err: err, At: time.Now().UTC()}
+	}
+	return result, nil
+}
+
+// recordFailedBackup inserts a failed backups row. Errors are logged only,
+// since the caller is already reporting a failure.
+func recordFailedBackup(cfg config.Config, driver db.DbDriver, id types.BackupID, started time.Time, stage string, cause error) {
+	if _, err := driver.CreateBackup(db.CreateBackupParams{
+		BackupID:    id,
+		NodeID:      types.NodeID(cfg.Node_ID),
+		BackupType:  types.BackupTypeFull,
+		Status:      types.BackupStatusInProgress,
+		StartedAt:   types.NewTimestamp(started),
+		StoragePath: "",
+		TriggeredBy: types.NullableString{String: TriggeredBySchedule, Valid: true},
+		Metadata:    types.JSONData{Valid: false},
+	}); err != nil {
+		utility.DefaultLogger.Warn("failed to record failed backup", err)
+		return
+	}
+	if err := driver.UpdateBackupStatus(db.UpdateBackupStatusParams{
+		BackupID:     id,
+		Status:       types.BackupStatusFailed,
+		CompletedAt:  types.NewTimestamp(time.Now().UTC()),
+		DurationMs:   types.NullableInt64{Int64: time.Since(started).Milliseconds(), Valid: true},
+		ErrorMessage: types.NullableString{String: stage + ": " + cause.Error(), Valid: true},
+	}); err != nil {
+		utility.DefaultLogger.Warn("failed to record backup failure", err)
+	}
+}
+
+// PruneScheduled deletes this node's completed scheduled backups that fall
+// outside the configured retention policy, removing the archive before the
+// record. It returns the number of backups pruned. An archive that cannot be
+// deleted keeps its record so the next run retries it.
+func PruneScheduled(ctx context.Context, cfg config.Config, driver db.DbDriver) (int, error) {
+	policy := RetentionPolicy{
+		Daily:   cfg.Backup_Retain_Daily,
+		Weekly:  cfg.Backup_Retain_Weekly,
+		Monthly: cfg.Backup_Retain_Monthly,
+	}
+	if !policy.Enabled() {
+		return 0, nil
+	}
+
+	count, err := driver.CountBackups()
+	if err != nil {
+		return 0, fmt.Errorf("count backups: %w", err)
+	}
+	all, err := driver.ListBackups(db.ListBackupsParams{Limit: *count, Offset: 0})
+	if err != nil {
+		return 0, fmt.Errorf("list backups: %w", err)
+	}
+	var scheduled []db.Backup
+	if all != nil {
+		for _, b := range *all {
+			if b.NodeID == types.NodeID(cfg.Node_ID) &&
+				b.Status == types.BackupStatusCompleted &&
+				b.TriggeredBy.Valid && b.TriggeredBy.String == TriggeredBySchedule {
+				scheduled = append(scheduled, b)
+			}
+		}
+	}
+
+	pruned := 0
+	var errs []error
+	for _, b := range policy.Expired(scheduled) {
+		if err := deleteArchive(ctx, cfg, b.StoragePath); err != nil {
+			errs = append(errs, fmt.Errorf("delete archive %s: %w", b.StoragePath, err))
+			continue
+		}
+		if err := driver.DeleteBackup(b.BackupID); err != nil {
+			errs = append(errs, fmt.Errorf("delete backup record %s: %w", b.BackupID, err))
+			continue
+		}
+		pruned++
+	}
+	return pruned, errors.Join(errs...)
+}
+
+// VerifyArchive checks that a backup archive is readable and belongs to this
+// installation: the manifest must parse and match the configured driver and
+// node, and every entry must decompress with a valid CRC. An encrypted
+// archive is decrypted first, which authenticates every chunk; when only a
+// recipient key is configured the contents cannot be read and only the
+// header is checked. It returns the archive's SHA-256 checksum in hex and
+// the manifest.
+func VerifyArchive(path string, cfg config.Config) (string, *BackupManifest, error) {
+	manifest, err := ReadManifest(path)
+	if err != nil {
+		return "", nil, err
+	}
+	if manifest.Driver != string(cfg.Db_Driver) {
+		return "", nil, fmt.Errorf("manifest driver %q does not match configured driver %q", manifest.Driver, cfg.Db_Driver)
+	}
+	if manifest.NodeID != cfg.Node_ID {
+		return "", nil, fmt.Errorf("manifest node %q does not match node %q", manifest.NodeID, cfg.Node_ID)
+	}
+
+	zipPath := path
+	encrypted, err := IsEncrypted(path)
+	if err != nil {
+		return "", nil, err
+	}
+	if encrypted {
+		enc, err := EncryptionFromConfig(cfg)
+		if err != nil {
+			return "", nil, err
+		}
+		if !enc.Decrypts() {
+			checksum, err := fileSHA256(path)
+			if err != nil {
+				return "", nil, err
+			}
+			return checksum, manifest, nil
+		}
+		tmp, err := os.CreateTemp("", "modula-verify-*.zip")
+		if err != nil {
+			return "", nil, fmt.Errorf("create temp file: %w", err)
+		}
+		tmp.Close()
+		defer os.Remove(tmp.Name())
+		if _, err := DecryptFile(path, tmp.Name(), enc); err != nil {
+			return "", nil, err
+		}
+		zipPath = tmp.Name()
+	}
+
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("open archive: %w", err)
+	}
+	defer r.Close()
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			return "", nil, fmt.Errorf("open %s: %w", f.Name, err)
+		}
+		// Reading to EOF makes archive/zip check the entry's CRC-32.
+		_, err = io.Copy(io.Discard, rc)
+		rc.Close()
+		if err != nil {
+			return "", nil, fmt.Errorf("read %s: %w", f.Name, err)
+		}
+	}
+
+	checksum, err := fileSHA256(path)
+	if err != nil {
+		return "", nil, err
+	}
+	return checksum, manifest, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("checksum %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// archiveStore keeps verified archives at the backup target.
+type archiveStore interface {
+	// Put moves the archive at localPath to the target, confirms the stored
+	// copy matches checksum, and returns its storage path.
+	Put(ctx context.Context, localPath, checksum string) (string, error)
+}
+
+func newArchiveStore(cfg config.Config) (archiveStore, error) {
+	switch cfg.BackupTarget() {
+	case "local":
+		return localStore{dir: cfg.BackupLocalDir()}, nil
+	case "bucket":
+		if cfg.Bucket_Backup == "" {
+			return nil, fmt.Errorf("backup_target is bucket but bucket_backup is empty")
+		}
+		svc, err := bucket.GetS3Creds(&cfg).GetBucket()
+		if err != nil {
+			return nil, fmt.Errorf("connect to backup bucket: %w", err)
+		}
+		return bucketStore{svc: svc, bucket: cfg.Bucket_Backup, node: cfg.Node_ID}, nil
+	default:
+		return nil, fmt.Errorf("unsupported backup_target %q", cfg.Backup_Target)
+	}
+}
+
+// deleteArchive removes a stored archive and its sibling media archive, if
+// any, by the archive's storage path, which records the target it was
+// written to.
+func deleteArchive(ctx context.Context, cfg config.Config, storagePath string) error {
+	if storagePath == "" {
+		return nil
+	}
+	if err := deleteStored(ctx, cfg, MediaArchivePath(storagePath)); err != nil {
+		return err
+	}
+	return deleteStored(ctx, cfg, storagePath)
+}
+
+// deleteStored removes one stored file. A missing file is not an error.
+func deleteStored(ctx context.Context, cfg config.Config, storagePath string) error {
+	if strings.HasPrefix(storagePath, "s3://") {
+		bucketName, key, _ := strings.Cut(strings.TrimPrefix(storagePath, "s3://"), "/")
+		svc, err := bucket.GetS3Creds(&cfg).GetBucket()
+		if err != nil {
+			return fmt.Errorf("connect to backup bucket: %w", err)
+		}
+		_, err = svc.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(bucketName),
+			Key:    aws.String(key),
+		})
+		return err
+	}
+	if err := os.Remove(storagePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// localStore keeps archives in a local directory.
+type localStore struct {
+	dir string
+}
+
+func (s localStore) Put(_ context.Context, localPath, checksum string) (string, error) {
+	dir, err := filepath.Abs(s.dir)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", s.dir, err)
+	}
+	src, err := filepath.Abs(localPath)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", localPath, err)
+	}
+	if filepath.Dir(src) == dir {
+		return src, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create %s: %w", dir, err)
+	}
+	dst := filepath.Join(dir, filepath.Base(src))
+	if err := copyFile(src, dst); err != nil {
+		os.Remove(dst)
+		return "", err
+	}
+	sum, err := fileSHA256(dst)
+	if err != nil || sum != checksum {
+		os.Remove(dst)
+		if err == nil {
+			err = fmt.Errorf("checksum mismatch after copy to %s", dst)
+		}
+		return "", err
+	}
+	if err := os.Remove(src); err != nil {
+		utility.DefaultLogger.Warn("failed to remove staged backup archive", err, "path", src)
+	}
+	return dst, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", src, err)
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", dst, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("copy to %s: %w", dst, err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", dst, err)
+	}
+	return nil
+}
+
+// bucketStore uploads archives to the backup bucket.
+type bucketStore struct {
+	svc    *s3.S3
+	bucket string
+	node   string
+}
+
+// Put uploads the archive with its SHA-256 in the object metadata, then
+// reads the object's size and metadata back before removing the local copy.
+func (s bucketStore) Put(ctx context.Context, localPath, checksum string) (string, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", localPath, err)
+	}
+	defer f.Close()
+
+	key := bucketPrefix + s.node + "/" + filepath.Base(localPath)
+	payload, err := bucket.UploadPrep(key, s.bucket, f, "private")
+	if err != nil {
+		return "", err
+	}
+	payload.Metadata = map[string]*string{"Sha256": aws.String(checksum)}
+	if _, err := s.svc.PutObjectWithContext(ctx, payload); err != nil {
+		return "", fmt.Errorf("upload %s to %s: %w", key, s.bucket, err)
+	}
+
+	head, err := s.svc.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return "", fmt.Errorf("confirm upload of %s: %w", key, err)
+	}
+	if aws.Int64Value(head.ContentLength) != aws.Int64Value(payload.ContentLength) {
+		return "", fmt.Errorf("uploaded %s is %d bytes, want %d", key, aws.Int64Value(head.ContentLength), aws.Int64Value(payload.ContentLength))
+	}
+	for k, v := range head.Metadata {
+		if strings.EqualFold(k, "Sha256") && aws.StringValue(v) != checksum {
+			return "", fmt.Errorf("uploaded %s checksum %s, want %s", key, aws.StringValue(v), checksum)
+		}
+	}
+
+	f.Close()
+	if err := os.Remove(localPath); err != nil {
+		utility.DefaultLogger.Warn("failed to remove uploaded backup archive", err, "path", localPath)
+	}
+	return "s3://" + s.bucket + "/" + key, nil
+}
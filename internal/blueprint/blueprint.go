@@ -0,0 +1,220 @@
+// Package blueprint defines reusable content subtrees. A blueprint is a tree
+// of datatype nodes with field values that may contain placeholders:
+// "{{name}}" is replaced by the value of a declared variable when the
+// blueprint is instantiated, and "{{@key}}" by the new content ID of the node
+// with that key, so "_id" fields can point at other nodes of the same tree.
+// The package only parses, validates and expands definitions; creating the
+// content is the service layer's job.
+package blueprint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxNodes bounds the nodes of one blueprint. Instantiation creates them all
+// in one transaction.
+const MaxNodes = 500
+
+var (
+	namePattern        = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+	identPattern       = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	placeholderPattern = regexp.MustCompile(`\{\{\s*(@?[A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+)
+
+// Definition is a blueprint as stored and as accepted in definition files.
+type Definition struct {
+	// Name identifies the blueprint: lowercase letters, digits, "-" and "_".
+	Name        string     `json:"name"`
+	Label       string     `json:"label"`
+	Description string     `json:"description,omitempty"`
+	Variables   []Variable `json:"variables,omitempty"`
+	Root        Node       `json:"root"`
+}
+
+// Variable is a value supplied when the blueprint is instantiated. A variable
+// without a default that is not required expands to "".
+type Variable struct {
+	Name     string `json:"name"`
+	Label    string `json:"label,omitempty"`
+	Default  string `json:"default,omitempty"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// Node is one content node of the blueprint tree. Datatype is a datatype
+// name; Fields maps field names to values, which may contain placeholders.
+// Fields of the datatype that are not listed are created empty. Key is only
+// needed when another node refers to this one with "{{@key}}".
+type Node struct {
+	Key      string            `json:"key,omitempty"`
+	Datatype string            `json:"datatype"`
+	Fields   map[string]string `json:"fields,omitempty"`
+	Children []Node            `json:"children,omitempty"`
+}
+
+// Parse decodes and validates a JSON definition. Unknown properties are
+// rejected so that typos in hand-written files are reported.
+func Parse(data []byte) (*Definition, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var def Definition
+	if err := dec.Decode(&def); err != nil {
+		return nil, fmt.Errorf("parse blueprint: %w", err)
+	}
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+// Marshal encodes def as indented JSON, the form stored and exported.
+func Marshal(def *Definition) ([]byte, error) {
+	return json.MarshalIndent(def, "", "  ")
+}
+
+// Validate checks the name, that variables and node keys are unique, and
+// that every placeholder names a declared variable or node key.
+func (def *Definition) Validate() error {
+	if !namePattern.MatchString(def.Name) {
+		return fmt.Errorf("blueprint name %q must be 1-64 lowercase letters, digits, '-' or '_'", def.Name)
+	}
+	vars := make(map[string]bool, len(def.Variables))
+	for _, v := range def.Variables {
+		if !identPattern.MatchString(v.Name) {
+			return fmt.Errorf("variable name %q must be a letter or '_' followed by letters, digits or '_'", v.Name)
+		}
+		if vars[v.Name] {
+			return fmt.Errorf("variable %q is declared twice", v.Name)
+		}
+		vars[v.Name] = true
+	}
+
+	keys := make(map[string]bool)
+	count := 0
+	var check func(n *Node, path string) error
+	check = func(n *Node, path string) error {
+		count++
+		if count > MaxNodes {
+			return fmt.Errorf("blueprint has more than %d nodes", MaxNodes)
+		}
+		if strings.TrimSpace(n.Datatype) == "" {
+			return fmt.Errorf("%s: datatype is required", path)
+		}
+		if n.Key != "" {
+			if !identPattern.MatchString(n.Key) {
+				return fmt.Errorf("%s: key %q must be a letter or '_' followed by letters, digits or '_'", path, n.Key)
+			}
+			if keys[n.Key] {
+				return fmt.Errorf("%s: key %q is used twice", path, n.Key)
+			}
+			keys[n.Key] = true
+		}
+		for i := range n.Children {
+			if err := check(&n.Children[i], fmt.Sprintf("%s.children[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := check(&def.Root, "root"); err != nil {
+		return err
+	}
+
+	var unknown error
+	def.Walk(func(n *Node, _ *Node) {
+		for field, value := range n.Fields {
+			if unknown != nil {
+				return
+			}
+			for _, name := range Placeholders(value) {
+				if ref, ok := strings.CutPrefix(name, "@"); ok {
+					if !keys[ref] {
+						unknown = fmt.Errorf("field %q of %s refers to unknown node key %q", field, n.Datatype, ref)
+					}
+				} else if !vars[name] {
+					unknown = fmt.Errorf("field %q of %s uses undeclared variable %q", field, n.Datatype, name)
+				}
+			}
+		}
+	})
+	return unknown
+}
+
+// Walk calls fn for every node in pre-order, parents before their children
+// and children in order. parent is nil for the root.
+func (def *Definition) Walk(fn func(n *Node, parent *Node)) {
+	var walk func(n, parent *Node)
+	walk = func(n, parent *Node) {
+		fn(n, parent)
+		for i := range n.Children {
+			walk(&n.Children[i], n)
+		}
+	}
+	walk(&def.Root, nil)
+}
+
+// Count returns the number of nodes in the tree.
+func (def *Definition) Count() int {
+	n := 0
+	def.Walk(func(*Node, *Node) { n++ })
+	return n
+}
+
+// Resolve returns the value of every declared variable: the supplied value,
+// else the default. It fails when a required variable has no value or a
+// supplied name is not declared.
+func (def *Definition) Resolve(values map[string]string) (map[string]string, error) {
+	declared := make(map[string]bool, len(def.Variables))
+	out := make(map[string]string, len(def.Variables))
+	for _, v := range def.Variables {
+		declared[v.Name] = true
+		value, ok := values[v.Name]
+		if !ok || value == "" {
+			value = v.Default
+		}
+		if v.Required && strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("variable %q is required", v.Name)
+		}
+		out[v.Name] = value
+	}
+	for name := range values {
+		if !declared[name] {
+			return nil, fmt.Errorf("variable %q is not declared by blueprint %q", name, def.Name)
+		}
+	}
+	return out, nil
+}
+
+// Expand replaces the placeholders in value: "{{name}}" with vars[name] and
+// "{{@key}}" with ids[key]. Placeholders with no entry expand to "".
+func Expand(value string, vars, ids map[string]string) string {
+	if !strings.Contains(value, "{{") {
+		return value
+	}
+	return placeholderPattern.ReplaceAllStringFunc(value, func(m string) string {
+		name := placeholderPattern.FindStringSubmatch(m)[1]
+		if ref, ok := strings.CutPrefix(name, "@"); ok {
+			return ids[ref]
+		}
+		return vars[name]
+	})
+}
+
+// Placeholders returns the names used by the placeholders in value, in
+// order. Node references keep their "@" prefix.
+func Placeholders(value string) []string {
+	var out []string
+	for _, m := range placeholderPattern.FindAllStringSubmatch(value, -1) {
+		out = append(out, m[1])
+	}
+	return out
+}
+
+// Ref returns the placeholder that expands to the new ID of the node with
+// key.
+func Ref(key string) string {
+	return "{{@" + key + "}}"
+}
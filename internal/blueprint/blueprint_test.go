@@ -0,0 +1,117 @@
+package blueprint
+
+import (
+	"strings"
+	"testing"
+)
+
+const landing = `{
+  "name": "landing-page",
+  "label": "Landing page",
+  "variables": [
+    {"name": "title", "required": true},
+    {"name": "cta", "default": "Sign up"}
+  ],
+  "root": {
+    "datatype": "page",
+    "fields": {"title": "{{title}}", "featured": "{{@hero}}"},
+    "children": [
+      {"key": "hero", "datatype": "hero", "fields": {"heading": "{{ title }} — {{cta}}"}},
+      {"datatype": "footer"}
+    ]
+  }
+}`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	def, err := Parse([]byte(landing))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if def.Count() != 3 {
+		t.Errorf("Count = %d, want 3", def.Count())
+	}
+	var order []string
+	def.Walk(func(n *Node, parent *Node) {
+		name := n.Datatype
+		if parent != nil {
+			name = parent.Datatype + "/" + name
+		}
+		order = append(order, name)
+	})
+	if got := strings.Join(order, ","); got != "page,page/hero,page/footer" {
+		t.Errorf("Walk order = %s", got)
+	}
+
+	if _, err := Parse([]byte(`{"name": "x", "root": {"datatype": "page"}, "extra": 1}`)); err == nil {
+		t.Error("unknown properties should be rejected")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		def  Definition
+		want string
+	}{
+		{"bad name", Definition{Name: "Landing Page", Root: Node{Datatype: "page"}}, "blueprint name"},
+		{"no datatype", Definition{Name: "a", Root: Node{}}, "datatype is required"},
+		{"duplicate variable", Definition{Name: "a", Variables: []Variable{{Name: "x"}, {Name: "x"}}, Root: Node{Datatype: "page"}}, "declared twice"},
+		{"duplicate key", Definition{Name: "a", Root: Node{Key: "k", Datatype: "page", Children: []Node{{Key: "k", Datatype: "hero"}}}}, "used twice"},
+		{"undeclared variable", Definition{Name: "a", Root: Node{Datatype: "page", Fields: map[string]string{"title": "{{missing}}"}}}, "undeclared variable"},
+		{"unknown ref", Definition{Name: "a", Root: Node{Datatype: "page", Fields: map[string]string{"link": "{{@nope}}"}}}, "unknown node key"},
+	}
+	for _, tt := range tests {
+		err := tt.def.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Validate = %v, want error containing %q", tt.name, err, tt.want)
+		}
+	}
+
+	big := Definition{Name: "big", Root: Node{Datatype: "page"}}
+	for range MaxNodes {
+		big.Root.Children = append(big.Root.Children, Node{Datatype: "row"})
+	}
+	if err := big.Validate(); err == nil {
+		t.Error("a tree over MaxNodes should be rejected")
+	}
+}
+
+func TestResolveAndExpand(t *testing.T) {
+	t.Parallel()
+
+	def, err := Parse([]byte(landing))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if _, err := def.Resolve(nil); err == nil {
+		t.Error("missing required variable should fail")
+	}
+	if _, err := def.Resolve(map[string]string{"title": "Hi", "other": "x"}); err == nil {
+		t.Error("undeclared variable should fail")
+	}
+	vars, err := def.Resolve(map[string]string{"title": "Spring sale"})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if vars["cta"] != "Sign up" {
+		t.Errorf("default not applied: %v", vars)
+	}
+
+	ids := map[string]string{"hero": "01HEROID"}
+	if got := Expand(def.Root.Children[0].Fields["heading"], vars, ids); got != "Spring sale — Sign up" {
+		t.Errorf("Expand heading = %q", got)
+	}
+	if got := Expand(def.Root.Fields["featured"], vars, ids); got != "01HEROID" {
+		t.Errorf("Expand ref = %q", got)
+	}
+	if got := Expand("{ not {{ a placeholder", vars, ids); got != "{ not {{ a placeholder" {
+		t.Errorf("Expand without placeholders = %q", got)
+	}
+	if got := Placeholders(Ref("hero") + " {{title}}"); strings.Join(got, ",") != "@hero,title" {
+		t.Errorf("Placeholders = %v", got)
+	}
+}
@@ -3,7 +3,11 @@
 // settings, SSL/TLS configuration, plugin runtime options, and observability.
 package config
 
-import "strings"
+import (
+	"path/filepath"
+	"strings"
+	"time"
+)
 
 // Endpoint identifies OAuth provider endpoint types.
 type Endpoint string
@@ -27,6 +31,9 @@ const (
 	OauthAuthURL     Endpoint = "oauth_auth_url"
 	OauthTokenURL    Endpoint = "oauth_token_url"
 	OauthUserInfoURL Endpoint = "oauth_userinfo_url"
+	// OauthIntrospectionURL is the RFC 7662 token introspection endpoint.
+	// It is required to accept provider access tokens as MCP bearer tokens.
+	OauthIntrospectionURL Endpoint = "oauth_introspection_url"
 )
 
 // Supported database drivers for Modula.
@@ -181,46 +188,62 @@ type Config struct {
 	Db_User           string            `json:"db_username"`
 	Db_Password       string            `json:"db_password"`
 
+	// Encryption at rest for designated columns (webhook secrets, OAuth tokens)
+	Encryption_Key           string   `json:"encryption_key"`           // base64 32-byte master key
+	Encryption_Key_File      string   `json:"encryption_key_file"`      // path to a file holding the master key
+	Encryption_Previous_Keys []string `json:"encryption_previous_keys"` // retired master keys, still accepted for decryption
+
 	// Remote connection (mutually exclusive with Db_Driver for connect command)
-	Remote_URL              string              `json:"remote_url"`
-	Remote_API_Key          string              `json:"remote_api_key"`
-	Bucket_Region           string              `json:"bucket_region"`
-	Bucket_Media            string              `json:"bucket_media"`
-	Bucket_Backup           string              `json:"bucket_backup"`
-	Bucket_Endpoint         string              `json:"bucket_endpoint"`
-	Bucket_Access_Key       string              `json:"bucket_access_key"`
-	Bucket_Secret_Key       string              `json:"bucket_secret_key"`
-	Bucket_Public_URL       string              `json:"bucket_public_url"`
-	Bucket_Default_ACL      string              `json:"bucket_default_acl"`
-	Bucket_Force_Path_Style bool                `json:"bucket_force_path_style"`
-	Bucket_Force_HTTP       bool                `json:"bucket_force_http"`
-	Bucket_Admin_Media      string              `json:"bucket_admin_media"`
-	Bucket_Admin_Endpoint   string              `json:"bucket_admin_endpoint"`
-	Bucket_Admin_Access_Key string              `json:"bucket_admin_access_key"`
-	Bucket_Admin_Secret_Key string              `json:"bucket_admin_secret_key"`
-	Bucket_Admin_Public_URL string              `json:"bucket_admin_public_url"`
-	Max_Upload_Size         int64               `json:"max_upload_size"` // bytes, default 10MB (10485760)
-	Backup_Option           string              `json:"backup_option"`
-	Backup_Paths            []string            `json:"backup_paths"`
-	Oauth_Client_Id         string              `json:"oauth_client_id"`
-	Oauth_Client_Secret     string              `json:"oauth_client_secret"`
-	Oauth_Scopes            []string            `json:"oauth_scopes"`
-	Oauth_Endpoint          map[Endpoint]string `json:"oauth_endpoint"`
-	Oauth_Provider_Name     string              `json:"oauth_provider_name"`
-	Oauth_Redirect_URL      string              `json:"oauth_redirect_url"`
-	Oauth_Success_Redirect  string              `json:"oauth_success_redirect"`
-	Cors_Origins            []string            `json:"cors_origins"`
-	Cors_Methods            []string            `json:"cors_methods"`
-	Cors_Headers            []string            `json:"cors_headers"`
-	Cors_Credentials        bool                `json:"cors_credentials"`
-	Custom_Style_Path       string              `json:"custom_style_path"`
-	Update_Auto_Enabled     bool                `json:"update_auto_enabled"`
-	Update_Check_Interval   string              `json:"update_check_interval"`
-	Update_Channel          string              `json:"update_channel"`
-	Update_Notify_Only      bool                `json:"update_notify_only"`
-	Output_Format           OutputFormat        `json:"output_format"`
-	Space_ID                string              `json:"space_id"`
-	Node_ID                 string              `json:"node_id"`
+	Remote_URL                      string              `json:"remote_url"`
+	Remote_API_Key                  string              `json:"remote_api_key"`
+	Bucket_Region                   string              `json:"bucket_region"`
+	Bucket_Media                    string              `json:"bucket_media"`
+	Bucket_Backup                   string              `json:"bucket_backup"`
+	Bucket_Endpoint                 string              `json:"bucket_endpoint"`
+	Bucket_Access_Key               string              `json:"bucket_access_key"`
+	Bucket_Secret_Key               string              `json:"bucket_secret_key"`
+	Bucket_Public_URL               string              `json:"bucket_public_url"`
+	Bucket_Default_ACL              string              `json:"bucket_default_acl"`
+	Bucket_Force_Path_Style         bool                `json:"bucket_force_path_style"`
+	Bucket_Force_HTTP               bool                `json:"bucket_force_http"`
+	Bucket_Admin_Media              string              `json:"bucket_admin_media"`
+	Bucket_Admin_Endpoint           string              `json:"bucket_admin_endpoint"`
+	Bucket_Admin_Access_Key         string              `json:"bucket_admin_access_key"`
+	Bucket_Admin_Secret_Key         string              `json:"bucket_admin_secret_key"`
+	Bucket_Admin_Public_URL         string              `json:"bucket_admin_public_url"`
+	Max_Upload_Size                 int64               `json:"max_upload_size"` // bytes, default 10MB (10485760)
+	Backup_Option                   string              `json:"backup_option"`
+	Backup_Paths                    []string            `json:"backup_paths"`
+	Backup_Schedule                 string              `json:"backup_schedule"`                 // cron expression in UTC ("0 3 * * *") or @hourly/@daily/@weekly/@monthly; empty disables scheduled backups
+	Backup_Target                   string              `json:"backup_target"`                   // where scheduled archives are kept: "local" (default) or "bucket" (bucket_backup)
+	Backup_Local_Dir                string              `json:"backup_local_dir"`                // directory for the local target, default <backup_option>/backups
+	Backup_Retain_Daily             int                 `json:"backup_retain_daily"`             // newest scheduled backup of each of the last N days to keep
+	Backup_Retain_Weekly            int                 `json:"backup_retain_weekly"`            // newest scheduled backup of each of the last N weeks to keep
+	Backup_Retain_Monthly           int                 `json:"backup_retain_monthly"`           // newest scheduled backup of each of the last N months to keep
+	Backup_Notify_Emails            []string            `json:"backup_notify_emails"`            // addresses emailed when a scheduled backup fails
+	Backup_Media                    string              `json:"backup_media"`                    // media objects in backups: "" (none), "archive" (inside the backup) or "sibling" (separate archive)
+	Backup_Encryption_Passphrase    string              `json:"backup_encryption_passphrase"`    // encrypts backup archives with a key derived from this passphrase
+	Backup_Encryption_Recipient     string              `json:"backup_encryption_recipient"`     // encrypts backup archives to this public key ('modula backup keygen')
+	Backup_Encryption_Identity_File string              `json:"backup_encryption_identity_file"` // private key file that decrypts archives encrypted to the recipient
+	Oauth_Client_Id                 string              `json:"oauth_client_id"`
+	Oauth_Client_Secret             string              `json:"oauth_client_secret"`
+	Oauth_Scopes                    []string            `json:"oauth_scopes"`
+	Oauth_Endpoint                  map[Endpoint]string `json:"oauth_endpoint"`
+	Oauth_Provider_Name             string              `json:"oauth_provider_name"`
+	Oauth_Redirect_URL              string              `json:"oauth_redirect_url"`
+	Oauth_Success_Redirect          string              `json:"oauth_success_redirect"`
+	Cors_Origins                    []string            `json:"cors_origins"`
+	Cors_Methods                    []string            `json:"cors_methods"`
+	Cors_Headers                    []string            `json:"cors_headers"`
+	Cors_Credentials                bool                `json:"cors_credentials"`
+	Custom_Style_Path               string              `json:"custom_style_path"`
+	Update_Auto_Enabled             bool                `json:"update_auto_enabled"`
+	Update_Check_Interval           string              `json:"update_check_interval"`
+	Update_Channel                  string              `json:"update_channel"`
+	Update_Notify_Only              bool                `json:"update_notify_only"`
+	Output_Format                   OutputFormat        `json:"output_format"`
+	Space_ID                        string              `json:"space_id"`
+	Node_ID                         string              `json:"node_id"`
 
 	// Observability - Metrics and Error Tracking
 	Observability_Enabled        bool              `json:"observability_enabled"`
@@ -253,6 +276,20 @@ type Config struct {
 	Email_AWS_Secret_Access_Key string        `json:"email_aws_secret_access_key"`
 	Password_Reset_URL          string        `json:"password_reset_url"`
 
+	// Account security
+	Auth_Lockout_Threshold    int    `json:"auth_lockout_threshold"`    // failed sign-ins before an account locks, default 5; negative disables lockout
+	Auth_Lockout_Duration     string `json:"auth_lockout_duration"`     // first lockout length, doubled on each repeat lockout, default "15m"
+	Auth_Lockout_Max_Duration string `json:"auth_lockout_max_duration"` // upper bound for progressive lockouts, default "24h"
+	Auth_Lockout_Notify       bool   `json:"auth_lockout_notify"`       // email users when their account is locked or unlocked
+	Password_Min_Length       int    `json:"password_min_length"`       // default 8
+	Password_History          int    `json:"password_history"`          // previous passwords that cannot be reused, 0 = no reuse check
+	Password_Expiry_Days      int    `json:"password_expiry_days"`      // days before a password must be changed, 0 = never expires
+	Password_Breached_List    string `json:"password_breached_list"`    // SHA-1 breached password list: a hash file or a directory of 5-character prefix range files
+
+	// HTTP rate limiting
+	Rate_Limit_Store    string            `json:"rate_limit_store"`    // "memory" (default, per process) or "database" (shared across replicas)
+	Rate_Limit_Policies []RateLimitPolicy `json:"rate_limit_policies"` // evaluated in order, first match wins; unset = DefaultRateLimitPolicies, [] disables
+
 	// Plugin runtime configuration
 	Plugin_Enabled   bool   `json:"plugin_enabled"`
 	Plugin_Directory string `json:"plugin_directory"` // path to plugins dir, e.g. "./plugins/"
@@ -300,6 +337,9 @@ type Config struct {
 	Deploy_Environments []DeployEnvironmentConfig `json:"deploy_environments"`
 	Deploy_Snapshot_Dir string                    `json:"deploy_snapshot_dir"`
 
+	// Content import
+	Import_Uploads_Dir string `json:"import_uploads_dir"` // local copy of wp-content/uploads for WordPress attachment import
+
 	// Tree composition depth limit
 	Composition_Max_Depth int `json:"composition_max_depth"`
 
@@ -315,6 +355,20 @@ type Config struct {
 	I18n_Enabled        bool   `json:"i18n_enabled"`        // default false
 	I18n_Default_Locale string `json:"i18n_default_locale"` // default "en"
 
+	// Translation pre-fill: a machine-translation provider ("deepl",
+	// "libretranslate" or a plugin provider "<plugin>:<name>"; empty disables
+	// machine translation) and the lowest fuzzy translation memory match score
+	// (percent) offered as a pre-fill.
+	Translation_Provider         string `json:"translation_provider"`
+	Translation_Provider_URL     string `json:"translation_provider_url"`
+	Translation_Provider_API_Key string `json:"translation_provider_api_key"`
+	Translation_Memory_Min_Score int    `json:"translation_memory_min_score"` // default 75
+
+	// When true, a locale cannot be published while any of its required
+	// translatable fields is missing or stale (translated from an older
+	// default-locale value).
+	I18n_Require_Current_Translations bool `json:"i18n_require_current_translations"` // default false
+
 	// Webhooks
 	Webhook_Enabled                 bool `json:"webhook_enabled"`
 	Webhook_Timeout                 int  `json:"webhook_timeout"`
@@ -324,15 +378,20 @@ type Config struct {
 	Webhook_Delivery_Retention_Days int  `json:"webhook_delivery_retention_days"`
 
 	// MCP server (Model Context Protocol for AI tooling)
-	MCP_Enabled bool   `json:"mcp_enabled"`
-	MCP_Proxy_Token string `json:"mcp_proxy_token"` // API token for connecting to a remote CMS in proxy mode
-	MCP_URL     string `json:"mcp_url"`     // URL the MCP server connects to (falls back to localhost:port)
+	MCP_Enabled     bool                `json:"mcp_enabled"`
+	MCP_Proxy_Token string              `json:"mcp_proxy_token"` // API token for connecting to a remote CMS in proxy mode
+	MCP_URL         string              `json:"mcp_url"`         // URL the MCP server connects to (falls back to localhost:port)
+	MCP_Token_Tools map[string][]string `json:"mcp_token_tools"` // API token ID -> tools the token may call on /mcp; tool names, globs, or permission patterns like "content:*"
 
 	// Search
 	Search_Enabled bool   `json:"search_enabled"`
 	Search_Path    string `json:"search_path"`
 
 	KeyBindings KeyMap `json:"keybindings"`
+
+	// sources records keys whose loaded value came from a ${env:}/${file:}
+	// reference or a MODULA_* override. Nil for configs built in code.
+	sources configSources
 }
 
 // DeployEnvironmentConfig describes a remote Modula instance for deploy operations.
@@ -342,6 +401,44 @@ type DeployEnvironmentConfig struct {
 	URL    string   `json:"url"`
 	APIKey string   `json:"api_key"`
 	Tables []string `json:"tables,omitempty"`
+
+	// EncryptionKey is the environment's encryption_key. When set, push
+	// re-encrypts secret columns for the environment instead of redacting them.
+	EncryptionKey string `json:"encryption_key,omitempty"`
+}
+
+// RateLimitPolicy limits requests to a group of routes. Requests are counted
+// in fixed windows against a key derived from the request: the client IP,
+// the authenticated user, or the API token.
+type RateLimitPolicy struct {
+	Name   string   `json:"name"`
+	Routes []string `json:"routes"`           // path prefixes, optionally "METHOD /prefix"; empty matches every route
+	Key    string   `json:"key"`              // "ip" (default), "user" or "token"
+	Users  []string `json:"users,omitempty"`  // user IDs or usernames; when set, only these users match
+	Tokens []string `json:"tokens,omitempty"` // API token IDs; when set, only requests authenticated with these tokens match
+	Limit  int      `json:"limit"`            // requests allowed per window
+	Window string   `json:"window"`           // window length, default "1m"
+}
+
+// WindowDuration returns the policy window. Falls back to one minute if
+// unset or unparseable.
+func (p RateLimitPolicy) WindowDuration() time.Duration {
+	d, err := time.ParseDuration(p.Window)
+	if err != nil || d <= 0 {
+		return time.Minute
+	}
+	return d
+}
+
+// DefaultRateLimitPolicies returns the policies used when rate_limit_policies
+// is not configured: the auth endpoints keep their 10 requests per minute per
+// IP, and the public content API gets a generous per-IP ceiling.
+func DefaultRateLimitPolicies() []RateLimitPolicy {
+	return []RateLimitPolicy{
+		{Name: "auth", Routes: []string{"/api/v1/auth/"}, Key: "ip", Limit: 10, Window: "1m"},
+		{Name: "admin-auth", Routes: []string{"POST /admin/login", "POST /admin/forgot-password", "POST /admin/reset-password"}, Key: "ip", Limit: 10, Window: "1m"},
+		{Name: "public-content", Routes: []string{"GET /api/v1/content/", "GET /api/v1/query/", "GET /api/v1/globals", "GET /api/v1/search"}, Key: "ip", Limit: 600, Window: "1m"},
+	}
 }
 
 // BucketEndpointURL returns Bucket_Endpoint prefixed with the scheme
@@ -465,6 +562,91 @@ func (c Config) RichtextToolbar() []string {
 	return c.Richtext_Toolbar
 }
 
+// AuthLockoutThreshold returns the number of consecutive failed sign-ins that
+// lock an account. Falls back to 5 if not configured. A negative value
+// disables lockout and returns 0.
+func (c Config) AuthLockoutThreshold() int {
+	if c.Auth_Lockout_Threshold < 0 {
+		return 0
+	}
+	if c.Auth_Lockout_Threshold == 0 {
+		return 5
+	}
+	return c.Auth_Lockout_Threshold
+}
+
+// AuthLockoutDuration returns the length of an account's first lockout.
+// Falls back to 15 minutes if unset or unparseable.
+func (c Config) AuthLockoutDuration() time.Duration {
+	d, err := time.ParseDuration(c.Auth_Lockout_Duration)
+	if err != nil || d <= 0 {
+		return 15 * time.Minute
+	}
+	return d
+}
+
+// AuthLockoutMaxDuration returns the upper bound for progressive lockouts.
+// Falls back to 24 hours if unset or unparseable, and is never shorter than
+// AuthLockoutDuration.
+func (c Config) AuthLockoutMaxDuration() time.Duration {
+	d, err := time.ParseDuration(c.Auth_Lockout_Max_Duration)
+	if err != nil || d <= 0 {
+		d = 24 * time.Hour
+	}
+	return max(d, c.AuthLockoutDuration())
+}
+
+// PasswordMinLength returns the minimum password length.
+// Falls back to 8 if not configured.
+func (c Config) PasswordMinLength() int {
+	if c.Password_Min_Length <= 0 {
+		return 8
+	}
+	return c.Password_Min_Length
+}
+
+// BackupTarget returns where scheduled backup archives are kept, "local" or
+// "bucket". Falls back to "local" if not configured.
+func (c Config) BackupTarget() string {
+	if c.Backup_Target == "" {
+		return "local"
+	}
+	return c.Backup_Target
+}
+
+// BackupLocalDir returns the directory scheduled backups are kept in for the
+// local target. Falls back to the backups directory under backup_option,
+// where CreateFullBackup writes archives.
+func (c Config) BackupLocalDir() string {
+	if c.Backup_Local_Dir != "" {
+		return c.Backup_Local_Dir
+	}
+	dir := c.Backup_Option
+	if dir == "" {
+		dir = "./"
+	}
+	return filepath.Join(dir, "backups")
+}
+
+// RateLimitStore returns the rate limiter counter store, "memory" or
+// "database". Falls back to "memory" if not configured.
+func (c Config) RateLimitStore() string {
+	if c.Rate_Limit_Store == "" {
+		return "memory"
+	}
+	return c.Rate_Limit_Store
+}
+
+// RateLimitPolicies returns the configured rate limit policies, or
+// DefaultRateLimitPolicies when rate_limit_policies is absent. An explicit
+// empty list disables rate limiting.
+func (c Config) RateLimitPolicies() []RateLimitPolicy {
+	if c.Rate_Limit_Policies == nil {
+		return DefaultRateLimitPolicies()
+	}
+	return c.Rate_Limit_Policies
+}
+
 // WebhookEnabled returns whether webhooks are active.
 func (c Config) WebhookEnabled() bool { return c.Webhook_Enabled }
 
@@ -528,6 +710,15 @@ func (c Config) I18nDefaultLocale() string {
 	return c.I18n_Default_Locale
 }
 
+// TranslationMemoryMinScore returns the lowest fuzzy match score, in percent,
+// that pre-fills a translation. Falls back to 75 if not configured.
+func (c Config) TranslationMemoryMinScore() int {
+	if c.Translation_Memory_Min_Score <= 0 {
+		return 75
+	}
+	return min(c.Translation_Memory_Min_Score, 100)
+}
+
 // MaxUploadSize returns the configured maximum upload size in bytes.
 // Falls back to 10 MB if no positive value is configured, ensuring
 // backward compatibility with config files that omit this field.
@@ -44,6 +44,11 @@ func DefaultConfig() Config {
 	c.Db_Password = ""
 	c.Backup_Option = "./"
 	c.Backup_Paths = []string{""}
+	c.Backup_Schedule = ""
+	c.Backup_Target = "local"
+	c.Backup_Retain_Daily = 7
+	c.Backup_Retain_Weekly = 4
+	c.Backup_Retain_Monthly = 6
 	c.Bucket_Force_Path_Style = true
 	c.Bucket_Region = "us-east-1"
 	c.Max_Upload_Size = 10 << 20 // 10 MB
@@ -65,9 +70,10 @@ func DefaultConfig() Config {
 	c.Oauth_Client_Secret = ""
 	c.Oauth_Scopes = []string{"openid", "profile", "email"}
 	c.Oauth_Endpoint = map[Endpoint]string{
-		OauthAuthURL:     "",
-		OauthTokenURL:    "",
-		OauthUserInfoURL: "",
+		OauthAuthURL:          "",
+		OauthTokenURL:         "",
+		OauthUserInfoURL:      "",
+		OauthIntrospectionURL: "",
 	}
 	c.Oauth_Provider_Name = ""
 	c.Oauth_Redirect_URL = ""
@@ -104,12 +110,27 @@ func DefaultConfig() Config {
 	c.Email_AWS_Secret_Access_Key = ""
 	c.Password_Reset_URL = ""
 
+	// Default account security settings
+	c.Auth_Lockout_Threshold = 5
+	c.Auth_Lockout_Duration = "15m"
+	c.Auth_Lockout_Max_Duration = "24h"
+	c.Auth_Lockout_Notify = false
+	c.Password_Min_Length = 8
+	c.Password_History = 0
+	c.Password_Expiry_Days = 0
+	c.Password_Breached_List = ""
+
+	// Default rate limiting settings
+	c.Rate_Limit_Store = "memory"
+	c.Rate_Limit_Policies = DefaultRateLimitPolicies()
+
 	// Default deploy settings
 	c.Deploy_Snapshot_Dir = "./deploy/snapshots"
 
 	// Default i18n settings
 	c.I18n_Enabled = false
 	c.I18n_Default_Locale = "en"
+	c.Translation_Memory_Min_Score = 75
 
 	// Default webhook settings
 	c.Webhook_Enabled = false
@@ -221,11 +221,12 @@ func TestDefaultConfig_OauthEndpointMap(t *testing.T) {
 		t.Fatal("DefaultConfig().Oauth_Endpoint is nil, want populated map")
 	}
 
-	// All three endpoint keys should be present with empty values
+	// All endpoint keys should be present with empty values
 	endpoints := []config.Endpoint{
 		config.OauthAuthURL,
 		config.OauthTokenURL,
 		config.OauthUserInfoURL,
+		config.OauthIntrospectionURL,
 	}
 	for _, ep := range endpoints {
 		t.Run(string(ep), func(t *testing.T) {
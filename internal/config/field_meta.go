@@ -10,6 +10,7 @@ const (
 	CategoryCORS          FieldCategory = "cors"
 	CategoryCookie        FieldCategory = "cookie"
 	CategoryOAuth         FieldCategory = "oauth"
+	CategorySecurity      FieldCategory = "security"
 	CategoryObservability FieldCategory = "observability"
 	CategoryEmail         FieldCategory = "email"
 	CategoryPlugin        FieldCategory = "plugin"
@@ -32,6 +33,7 @@ func AllCategories() []FieldCategory {
 		CategoryCORS,
 		CategoryCookie,
 		CategoryOAuth,
+		CategorySecurity,
 		CategoryObservability,
 		CategoryEmail,
 		CategoryPlugin,
@@ -61,6 +63,8 @@ func CategoryLabel(c FieldCategory) string {
 		return "cookie Settings"
 	case CategoryOAuth:
 		return "OAuth Settings"
+	case CategorySecurity:
+		return "Account Security"
 	case CategoryObservability:
 		return "observability Settings"
 	case CategoryEmail:
@@ -130,6 +134,9 @@ var FieldRegistry = []FieldMeta{
 	{JSONKey: "db_name", Label: "DB Name", Category: CategoryDatabase, HotReloadable: false, Description: "database name", Example: "modula"},
 	{JSONKey: "db_username", Label: "DB Username", Category: CategoryDatabase, HotReloadable: false, Description: "database username", Example: "modula"},
 	{JSONKey: "db_password", Label: "DB Password", Category: CategoryDatabase, HotReloadable: false, Sensitive: true, Description: "database password", Example: "secret"},
+	{JSONKey: "encryption_key", Label: "Encryption Key", Category: CategoryDatabase, HotReloadable: false, Sensitive: true, Description: "Base64 32-byte master key for encrypting secret columns at rest (generate with 'modula encryption keygen')", Example: "${file:/run/secrets/modula_key}"},
+	{JSONKey: "encryption_key_file", Label: "Encryption Key File", Category: CategoryDatabase, HotReloadable: false, Description: "Path to a file holding the master key; used when encryption_key is empty", Example: "/etc/modula/encryption.key"},
+	{JSONKey: "encryption_previous_keys", Label: "Previous Encryption Keys", Category: CategoryDatabase, HotReloadable: false, Sensitive: true, Description: "Retired master keys still accepted for decryption until 'modula encryption reencrypt' has run", Example: "[\"old-base64-key\"]"},
 
 	// Storage (S3)
 	{JSONKey: "bucket_region", Label: "bucket Region", Category: CategoryStorage, HotReloadable: true, Description: "S3 bucket region", Example: "us-east-1"},
@@ -144,6 +151,17 @@ var FieldRegistry = []FieldMeta{
 	{JSONKey: "bucket_force_http", Label: "Force HTTP", Category: CategoryStorage, HotReloadable: true, Description: "Use HTTP instead of HTTPS for bucket connections (for co-located S3 on same network)", Example: "true"},
 	{JSONKey: "backup_option", Label: "backup Option", Category: CategoryStorage, HotReloadable: true, Description: "backup storage location", Example: "s3"},
 	{JSONKey: "backup_paths", Label: "backup Paths", Category: CategoryStorage, HotReloadable: true, Description: "Additional backup paths", Example: "/var/backups/modula"},
+	{JSONKey: "backup_schedule", Label: "Backup Schedule", Category: CategoryStorage, HotReloadable: false, Description: "Cron expression (UTC) for scheduled backups; empty disables", Example: "0 3 * * *"},
+	{JSONKey: "backup_target", Label: "Backup Target", Category: CategoryStorage, HotReloadable: true, Description: "Where scheduled backups are kept: local or bucket", Example: "bucket"},
+	{JSONKey: "backup_local_dir", Label: "Backup Local Directory", Category: CategoryStorage, HotReloadable: true, Description: "Directory for scheduled backups when the target is local", Example: "/var/backups/modula"},
+	{JSONKey: "backup_retain_daily", Label: "Keep Daily Backups", Category: CategoryStorage, HotReloadable: true, Description: "Days for which the newest scheduled backup is kept", Example: "7"},
+	{JSONKey: "backup_retain_weekly", Label: "Keep Weekly Backups", Category: CategoryStorage, HotReloadable: true, Description: "Weeks for which the newest scheduled backup is kept", Example: "4"},
+	{JSONKey: "backup_retain_monthly", Label: "Keep Monthly Backups", Category: CategoryStorage, HotReloadable: true, Description: "Months for which the newest scheduled backup is kept", Example: "6"},
+	{JSONKey: "backup_notify_emails", Label: "Backup Failure Emails", Category: CategoryStorage, HotReloadable: true, Description: "Addresses emailed when a scheduled backup fails", Example: "ops@example.com"},
+	{JSONKey: "backup_media", Label: "Backup Media", Category: CategoryStorage, HotReloadable: true, Description: "Include media objects in backups: archive, sibling, or empty for none", Example: "sibling"},
+	{JSONKey: "backup_encryption_passphrase", Label: "Backup Encryption Passphrase", Category: CategoryStorage, HotReloadable: true, Sensitive: true, Description: "Passphrase that encrypts and decrypts backup archives", Example: "${file:/run/secrets/backup_passphrase}"},
+	{JSONKey: "backup_encryption_recipient", Label: "Backup Encryption Recipient", Category: CategoryStorage, HotReloadable: true, Description: "Public key backups are encrypted to (generate with 'modula backup keygen')", Example: "modula-backup-pub1:..."},
+	{JSONKey: "backup_encryption_identity_file", Label: "Backup Encryption Identity File", Category: CategoryStorage, HotReloadable: true, Description: "Private key file that decrypts backups encrypted to the recipient", Example: "/etc/modula/backup.key"},
 
 	// CORS
 	{JSONKey: "cors_origins", Label: "Allowed Origins", Category: CategoryCORS, HotReloadable: true, Description: "CORS allowed origins", Example: "https://example.com,https://admin.example.com"},
@@ -195,6 +213,17 @@ var FieldRegistry = []FieldMeta{
 	{JSONKey: "email_aws_secret_access_key", Label: "AWS Secret Access Key", Category: CategoryEmail, HotReloadable: true, Sensitive: true, Description: "AWS secret access key for SES", Example: "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY"},
 	{JSONKey: "password_reset_url", Label: "Password Reset URL", Category: CategoryEmail, HotReloadable: true, Description: "URL template for password reset links in emails", Example: "https://admin.example.com/reset-password"},
 
+	// Account security
+	{JSONKey: "auth_lockout_threshold", Label: "Lockout Threshold", Category: CategorySecurity, HotReloadable: true, Description: "Consecutive failed sign-ins before an account is locked (negative disables lockout)", Example: "5"},
+	{JSONKey: "auth_lockout_duration", Label: "Lockout Duration", Category: CategorySecurity, HotReloadable: true, Description: "Length of the first lockout; doubled on each repeat lockout", Example: "15m"},
+	{JSONKey: "auth_lockout_max_duration", Label: "Max Lockout Duration", Category: CategorySecurity, HotReloadable: true, Description: "Upper bound for progressive lockouts", Example: "24h"},
+	{JSONKey: "auth_lockout_notify", Label: "Lockout Emails", Category: CategorySecurity, HotReloadable: true, Description: "Email users when their account is locked or unlocked", Example: "true"},
+	{JSONKey: "password_min_length", Label: "Min Password Length", Category: CategorySecurity, HotReloadable: true, Description: "Minimum password length", Example: "12"},
+	{JSONKey: "password_history", Label: "Password History", Category: CategorySecurity, HotReloadable: true, Description: "Number of previous passwords that cannot be reused (0 disables)", Example: "5"},
+	{JSONKey: "password_expiry_days", Label: "Password Expiry (days)", Category: CategorySecurity, HotReloadable: true, Description: "Days before a password must be changed (0 = never)", Example: "90"},
+	{JSONKey: "password_breached_list", Label: "Breached Password List", Category: CategorySecurity, HotReloadable: true, Description: "SHA-1 breached password list: a hash file or a directory of prefix range files", Example: "/var/lib/modula/pwned"},
+	{JSONKey: "rate_limit_store", Label: "Rate Limit Store", Category: CategorySecurity, HotReloadable: false, Description: "Where rate limit counters live: memory (per process) or database (shared across replicas)", Example: "database"},
+
 	// Plugin — Core
 	{JSONKey: "plugin_enabled", Label: "plugin Enabled", Category: CategoryPlugin, HotReloadable: false, Description: "Enable plugin system", Example: "true"},
 	{JSONKey: "plugin_directory", Label: "plugin Directory", Category: CategoryPlugin, HotReloadable: false, Description: "Path to plugins directory", Example: "./plugins"},
@@ -239,6 +268,9 @@ var FieldRegistry = []FieldMeta{
 	// Deploy
 	{JSONKey: "deploy_snapshot_dir", Label: "snapshot Directory", Category: CategoryDeploy, HotReloadable: true, Description: "Local directory for deploy snapshots", Example: "./deploy/snapshots"},
 
+	// Import
+	{JSONKey: "import_uploads_dir", Label: "Import Uploads Directory", Category: CategoryStorage, HotReloadable: true, Description: "Local copy of wp-content/uploads used to import WordPress attachments", Example: "./wp-content/uploads"},
+
 	// Publishing
 	{JSONKey: "composition_max_depth", Label: "Composition Max Depth", Category: CategoryPublishing, HotReloadable: true, Description: "Max depth for recursive content tree composition", Example: "10"},
 	{JSONKey: "publish_schedule_interval", Label: "Schedule Interval (s)", Category: CategoryPublishing, HotReloadable: true, Description: "Seconds between scheduled publish checks", Example: "60"},
@@ -247,6 +279,11 @@ var FieldRegistry = []FieldMeta{
 	// Internationalization
 	{JSONKey: "i18n_enabled", Label: "I18n Enabled", Category: CategoryI18n, HotReloadable: false, Description: "Enable internationalization support", Example: "true"},
 	{JSONKey: "i18n_default_locale", Label: "Default Locale", Category: CategoryI18n, HotReloadable: true, Description: "Default locale code (BCP 47)", Example: "en"},
+	{JSONKey: "translation_provider", Label: "Translation Provider", Category: CategoryI18n, HotReloadable: true, Description: "Machine translation for new translations: deepl, libretranslate or a plugin provider <plugin>:<name> (empty disables)", Example: "deepl"},
+	{JSONKey: "translation_provider_url", Label: "Translation Provider URL", Category: CategoryI18n, HotReloadable: true, Description: "Base URL of the translation API (required for libretranslate, defaults to api.deepl.com)", Example: "http://localhost:5000"},
+	{JSONKey: "translation_provider_api_key", Label: "Translation Provider API Key", Category: CategoryI18n, HotReloadable: true, Sensitive: true, Description: "API key for the translation provider", Example: "your-deepl-key:fx"},
+	{JSONKey: "translation_memory_min_score", Label: "Translation Memory Min Score", Category: CategoryI18n, HotReloadable: true, Description: "Lowest fuzzy translation memory match (percent) used as a pre-fill", Example: "75"},
+	{JSONKey: "i18n_require_current_translations", Label: "Require Current Translations", Category: CategoryI18n, HotReloadable: true, Description: "Block publishing a locale while required fields are missing or stale", Example: "true"},
 
 	// Webhooks
 	{JSONKey: "webhook_enabled", Label: "Webhooks Enabled", Category: CategoryWebhook, HotReloadable: false, Description: "Enable webhook event notifications", Example: "true"},
@@ -33,7 +33,8 @@ func NewFileProvider(path string) *FileProvider {
 	return &FileProvider{path: path}
 }
 
-// Get implements the Provider interface
+// Get implements the Provider interface. MODULA_* environment overrides are
+// applied and ${env:NAME} / ${file:/path} references are resolved.
 func (fp *FileProvider) Get() (*Config, error) {
 	file, err := os.Open(fp.path)
 	if err != nil {
@@ -50,18 +51,25 @@ func (fp *FileProvider) Get() (*Config, error) {
 		return nil, fmt.Errorf("reading config file: %w", err)
 	}
 
-	var config Config
-	if err := json.Unmarshal(bytes, &config); err != nil {
+	var raw map[string]any
+	if err := json.Unmarshal(bytes, &raw); err != nil {
 		return nil, fmt.Errorf("parsing config JSON: %w", err)
 	}
 
-	return &config, nil
+	config, err := decodeConfig(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing config JSON: %w", err)
+	}
+
+	return config, nil
 }
 
 // Save persists the configuration to the file atomically.
 // It writes to a temporary file first, then renames to avoid partial writes.
+// Values loaded from references or MODULA_* overrides are written back in
+// their file form, so resolved secrets never reach the disk.
 func (fp *FileProvider) Save(c *Config) error {
-	data, err := json.MarshalIndent(c, "", "  ")
+	data, err := json.MarshalIndent(persistableConfig(*c), "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshaling config: %w", err)
 	}
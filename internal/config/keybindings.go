@@ -40,6 +40,10 @@ const (
 	ActionTabNext      Action = "tab_next"
 	ActionAdminToggle  Action = "admin_toggle"
 	ActionSearch       Action = "search"
+	ActionTranslations Action = "translations"
+	ActionPrefill      Action = "prefill"
+	ActionReview       Action = "review"
+	ActionBlueprint    Action = "blueprint"
 )
 
 // KeyMap maps semantic actions to one or more key strings (as reported by
@@ -84,6 +88,10 @@ func DefaultKeyMap() KeyMap {
 		ActionTabNext:      {"]"},
 		ActionAdminToggle:  {"ctrl+a"},
 		ActionSearch:       {"/"},
+		ActionTranslations: {"t"},
+		ActionPrefill:      {"M"},
+		ActionReview:       {"r"},
+		ActionBlueprint:    {"b"},
 	}
 }
 
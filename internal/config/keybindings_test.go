@@ -48,6 +48,9 @@ func TestDefaultKeyMap_AllActionsBound(t *testing.T) {
 		// ActionScreenReset intentionally has no default binding.
 		config.ActionTabPrev,
 		config.ActionTabNext,
+		config.ActionPrefill,
+		config.ActionReview,
+		config.ActionBlueprint,
 	}
 
 	km := config.DefaultKeyMap()
@@ -494,6 +497,8 @@ func TestActionConstants_StringValues(t *testing.T) {
 		{name: "screen_reset", got: config.ActionScreenReset, want: "screen_reset"},
 		{name: "tab_prev", got: config.ActionTabPrev, want: "tab_prev"},
 		{name: "tab_next", got: config.ActionTabNext, want: "tab_next"},
+		{name: "prefill", got: config.ActionPrefill, want: "prefill"},
+		{name: "review", got: config.ActionReview, want: "review"},
 	}
 
 	for _, tt := range tests {
@@ -32,7 +32,8 @@ func NewLayeredFileProvider(basePath, overlayPath string) *LayeredFileProvider {
 }
 
 // Get reads both files, marshals each to map[string]any, merges them with
-// MergeMaps, and unmarshals the result to *Config.
+// MergeMaps, and unmarshals the result to *Config. Overrides and references
+// are applied after merging, so either layer may contain references.
 func (lp *LayeredFileProvider) Get() (*Config, error) {
 	baseMap, err := readJSONMap(lp.base.Path())
 	if err != nil {
@@ -46,17 +47,12 @@ func (lp *LayeredFileProvider) Get() (*Config, error) {
 
 	merged := MergeMaps(baseMap, overlayMap)
 
-	mergedBytes, err := json.Marshal(merged)
+	cfg, err := decodeConfig(merged)
 	if err != nil {
-		return nil, fmt.Errorf("marshaling merged config: %w", err)
-	}
-
-	var cfg Config
-	if err := json.Unmarshal(mergedBytes, &cfg); err != nil {
 		return nil, fmt.Errorf("parsing merged config: %w", err)
 	}
 
-	return &cfg, nil
+	return cfg, nil
 }
 
 // Save persists the configuration to the overlay file. When layered, the
@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 )
@@ -97,8 +98,9 @@ func (m *Manager) Snapshot() (Config, error) {
 // It validates the result, saves to disk if a Saver is configured, swaps the
 // in-memory config, and notifies OnChange listeners.
 //
-// Values that are the redaction placeholder ("********") are skipped to prevent
-// accidentally overwriting sensitive fields when a client round-trips redacted config.
+// Values that are or contain the redaction placeholder ("********") are skipped
+// to prevent accidentally overwriting sensitive fields when a client
+// round-trips redacted config.
 func (m *Manager) Update(updates map[string]any) (ValidationResult, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -125,12 +127,18 @@ func (m *Manager) Update(updates map[string]any) (ValidationResult, error) {
 	// Filter out redacted values before merging.
 	filtered := make(map[string]any, len(updates))
 	for k, v := range updates {
-		if strVal, ok := v.(string); ok && IsRedactedValue(strVal) {
+		if containsRedacted(v) {
 			continue
 		}
 		filtered[k] = v
 	}
 
+	// Resolve references in incoming values; the reference is what gets saved.
+	sources, err := trackUpdates(current.sources, filtered)
+	if err != nil {
+		return ValidationResult{}, fmt.Errorf("resolving config references: %w", err)
+	}
+
 	currentMap = MergeMaps(currentMap, filtered)
 
 	mergedBytes, err := json.Marshal(currentMap)
@@ -142,6 +150,7 @@ func (m *Manager) Update(updates map[string]any) (ValidationResult, error) {
 	if err := json.Unmarshal(mergedBytes, &proposed); err != nil {
 		return ValidationResult{}, fmt.Errorf("unmarshaling merged config: %w", err)
 	}
+	proposed.sources = sources
 
 	result := ValidateUpdate(current, proposed)
 	if !result.Valid {
@@ -201,3 +210,21 @@ func (m *Manager) OnChange(fn func(Config)) {
 	defer m.mu.Unlock()
 	m.onChange = append(m.onChange, fn)
 }
+
+// containsRedacted reports whether v holds the redaction placeholder anywhere,
+// including inside lists and objects such as deploy_environments entries.
+func containsRedacted(v any) bool {
+	switch val := v.(type) {
+	case string:
+		return IsRedactedValue(val)
+	case []any:
+		return slices.ContainsFunc(val, containsRedacted)
+	case map[string]any:
+		for _, item := range val {
+			if containsRedacted(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -1,50 +1,72 @@
 package config
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"reflect"
+)
 
 const redactedValue = "********"
 
 // RedactedConfig returns a copy of the config with sensitive fields replaced
-// by a redaction placeholder.
+// by a redaction placeholder. Values loaded from ${env:} or ${file:}
+// references show the reference instead of the resolved value, so the output
+// never contains a resolved secret.
 func RedactedConfig(c Config) Config {
-	redacted := c
-	sensitive := SensitiveKeys()
+	redacted := UnresolvedConfig(c)
+	v := reflect.ValueOf(&redacted).Elem()
+	fields := configFields()
 
-	if sensitive["auth_salt"] {
-		redacted.Auth_Salt = redactedValue
+	for key := range SensitiveKeys() {
+		f, ok := fields[key]
+		if !ok {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		switch {
+		case f.Type.Kind() == reflect.String:
+			redactString(fv)
+		case f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.String:
+			// Copy first so the source config's backing array is untouched.
+			items := reflect.MakeSlice(f.Type, fv.Len(), fv.Len())
+			reflect.Copy(items, fv)
+			for i := range items.Len() {
+				redactString(items.Index(i))
+			}
+			fv.Set(items)
+		}
 	}
-	if sensitive["db_password"] {
-		redacted.Db_Password = redactedValue
-	}
-	if sensitive["bucket_access_key"] {
-		redacted.Bucket_Access_Key = redactedValue
-	}
-	if sensitive["bucket_secret_key"] {
-		redacted.Bucket_Secret_Key = redactedValue
-	}
-	if sensitive["oauth_client_id"] {
-		redacted.Oauth_Client_Id = redactedValue
-	}
-	if sensitive["oauth_client_secret"] {
-		redacted.Oauth_Client_Secret = redactedValue
-	}
-	if sensitive["observability_dsn"] {
-		redacted.Observability_DSN = redactedValue
-	}
-	if sensitive["email_password"] {
-		redacted.Email_Password = redactedValue
-	}
-	if sensitive["email_api_key"] {
-		redacted.Email_API_Key = redactedValue
-	}
-	if sensitive["email_aws_access_key_id"] {
-		redacted.Email_AWS_Access_Key_ID = redactedValue
+	redacted.Deploy_Environments = redactDeployEnvironments(redacted.Deploy_Environments)
+
+	return redacted
+}
+
+// redactDeployEnvironments returns a copy of the deploy environments with
+// each environment's API key and encryption key redacted. Unset keys stay
+// empty so the output still shows which environments have none.
+func redactDeployEnvironments(envs []DeployEnvironmentConfig) []DeployEnvironmentConfig {
+	if len(envs) == 0 {
+		return envs
 	}
-	if sensitive["email_aws_secret_access_key"] {
-		redacted.Email_AWS_Secret_Access_Key = redactedValue
+	out := make([]DeployEnvironmentConfig, len(envs))
+	copy(out, envs)
+	for i := range out {
+		for _, key := range []*string{&out[i].APIKey, &out[i].EncryptionKey} {
+			if *key != "" {
+				redactString(reflect.ValueOf(key).Elem())
+			}
+		}
 	}
+	return out
+}
 
-	return redacted
+// redactString replaces a settable string value with the redaction
+// placeholder. A reference names where the secret lives, not the secret
+// itself, so references are left as they are.
+func redactString(v reflect.Value) {
+	if referencePattern.MatchString(v.String()) {
+		return
+	}
+	v.SetString(redactedValue)
 }
 
 // RedactedJSON marshals a redacted copy of the config to JSON.
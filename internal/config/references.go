@@ -0,0 +1,323 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// EnvOverridePrefix is the prefix for environment variables that override a
+// config key. MODULA_DB_PASSWORD overrides "db_password".
+const EnvOverridePrefix = "MODULA_"
+
+// referencePattern matches ${env:NAME} and ${file:/path} references inside
+// string values.
+var referencePattern = regexp.MustCompile(`\$\{(env|file):([^}]*)\}`)
+
+// configSource records where a top-level key's loaded value came from.
+type configSource struct {
+	// file is the value as written in the config file; nil when the key was
+	// absent and only set by an override.
+	file json.RawMessage
+	// resolved is the value after overrides and references were applied.
+	resolved json.RawMessage
+	// display is the printable form: the reference text, or the redaction
+	// placeholder for a sensitive override.
+	display json.RawMessage
+}
+
+// configSources maps JSON keys to the source of their loaded value.
+type configSources map[string]configSource
+
+// configFields maps each Config JSON key to its struct field.
+var configFields = sync.OnceValue(func() map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	t := reflect.TypeFor[Config]()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = f
+	}
+	return fields
+})
+
+// decodeConfig applies MODULA_* overrides to a decoded config file, resolves
+// ${env:} and ${file:} references, and unmarshals the result. The returned
+// Config remembers the source form of every value it resolved so that saving
+// and printing never expose the resolved value.
+func decodeConfig(m map[string]any) (*Config, error) {
+	if m == nil {
+		m = make(map[string]any)
+	}
+
+	sources, err := resolveConfigMap(m)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling resolved config: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	cfg.sources = sources
+	return &cfg, nil
+}
+
+// resolveConfigMap rewrites m in place. Overrides are applied before
+// references are resolved, so an override may itself be a reference.
+func resolveConfigMap(m map[string]any) (configSources, error) {
+	fields := configFields()
+	fileValues := make(map[string]any)
+	overridden := make(map[string]bool)
+
+	for _, kv := range os.Environ() {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(name, EnvOverridePrefix) {
+			continue
+		}
+		key := strings.ToLower(strings.TrimPrefix(name, EnvOverridePrefix))
+		field, ok := fields[key]
+		if !ok {
+			continue
+		}
+		decoded, err := decodeOverride(field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if existing, ok := m[key]; ok {
+			fileValues[key] = existing
+		}
+		m[key] = decoded
+		overridden[key] = true
+	}
+
+	sensitive := SensitiveKeys()
+	sources := make(configSources)
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		raw := m[key]
+		resolved, hasRef, err := resolveValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("config key %q: %w", key, err)
+		}
+		if !hasRef && !overridden[key] {
+			continue
+		}
+		m[key] = resolved
+
+		var src configSource
+		if overridden[key] {
+			if fv, ok := fileValues[key]; ok {
+				src.file = mustMarshal(fv)
+			}
+		} else {
+			src.file = mustMarshal(raw)
+		}
+		src.resolved = mustMarshal(resolved)
+		switch {
+		case hasRef:
+			src.display = mustMarshal(raw)
+		case sensitive[key]:
+			src.display = mustMarshal(redactedValue)
+		default:
+			src.display = src.resolved
+		}
+		sources[key] = src
+	}
+	return sources, nil
+}
+
+// decodeOverride converts an override's string value to the decoded JSON form
+// of the target field. String fields take the value verbatim; every other
+// field expects JSON (true, 8080, ["a","b"]).
+func decodeOverride(t reflect.Type, value string) (any, error) {
+	if t.Kind() == reflect.String {
+		return value, nil
+	}
+	if err := json.Unmarshal([]byte(value), reflect.New(t).Interface()); err != nil {
+		return nil, fmt.Errorf("invalid value for %s field: %w", t, err)
+	}
+	var decoded any
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return nil, fmt.Errorf("invalid value for %s field: %w", t, err)
+	}
+	return decoded, nil
+}
+
+// resolveValue walks a decoded JSON value and resolves references in every
+// string it contains. hasRef reports whether any reference was found.
+func resolveValue(v any) (any, bool, error) {
+	switch t := v.(type) {
+	case string:
+		if !referencePattern.MatchString(t) {
+			return t, false, nil
+		}
+		resolved, err := resolveReferences(t)
+		return resolved, true, err
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		hasRef := false
+		for k, item := range t {
+			resolved, found, err := resolveValue(item)
+			if err != nil {
+				return nil, false, fmt.Errorf("%s: %w", k, err)
+			}
+			out[k] = resolved
+			hasRef = hasRef || found
+		}
+		return out, hasRef, nil
+	case []any:
+		out := make([]any, len(t))
+		hasRef := false
+		for i, item := range t {
+			resolved, found, err := resolveValue(item)
+			if err != nil {
+				return nil, false, fmt.Errorf("[%d]: %w", i, err)
+			}
+			out[i] = resolved
+			hasRef = hasRef || found
+		}
+		return out, hasRef, nil
+	default:
+		return v, false, nil
+	}
+}
+
+// resolveReferences replaces every reference in s with the environment
+// variable's value or the file's contents, minus trailing newlines.
+func resolveReferences(s string) (string, error) {
+	var firstErr error
+	out := referencePattern.ReplaceAllStringFunc(s, func(ref string) string {
+		if firstErr != nil {
+			return ref
+		}
+		parts := referencePattern.FindStringSubmatch(ref)
+		kind, target := parts[1], strings.TrimSpace(parts[2])
+		if target == "" {
+			firstErr = fmt.Errorf("empty %s reference", kind)
+			return ref
+		}
+		if kind == "env" {
+			value, ok := os.LookupEnv(target)
+			if !ok {
+				firstErr = fmt.Errorf("environment variable %s is not set", target)
+				return ref
+			}
+			return value
+		}
+		data, err := os.ReadFile(target)
+		if err != nil {
+			firstErr = fmt.Errorf("reading secret file: %w", err)
+			return ref
+		}
+		return strings.TrimRight(string(data), "\r\n")
+	})
+	return out, firstErr
+}
+
+// trackUpdates resolves references in incoming update values (rewriting
+// updates in place) and returns the source records for the updated config.
+// A literal equal to a key's resolved value is a round-trip of the loaded
+// config and keeps the record; any other literal drops it.
+func trackUpdates(existing configSources, updates map[string]any) (configSources, error) {
+	sources := make(configSources, len(existing))
+	maps.Copy(sources, existing)
+
+	for key, v := range updates {
+		resolved, hasRef, err := resolveValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("config key %q: %w", key, err)
+		}
+		if hasRef {
+			raw := mustMarshal(v)
+			sources[key] = configSource{file: raw, resolved: mustMarshal(resolved), display: raw}
+			updates[key] = resolved
+			continue
+		}
+		if src, ok := sources[key]; ok && !sameJSON(mustMarshal(v), src.resolved) {
+			delete(sources, key)
+		}
+	}
+	return sources, nil
+}
+
+// UnresolvedConfig returns a copy of c in which every value loaded from a
+// reference shows the reference again and every MODULA_* override of a
+// sensitive key shows the redaction placeholder. Values changed since loading
+// are left as they are.
+func UnresolvedConfig(c Config) Config {
+	return withSourceValues(c, func(src configSource) json.RawMessage { return src.display })
+}
+
+// persistableConfig returns the config as it should be written to disk:
+// references are restored and overridden keys go back to their file values.
+func persistableConfig(c Config) Config {
+	return withSourceValues(c, func(src configSource) json.RawMessage { return src.file })
+}
+
+// withSourceValues replaces each recorded key that still holds its resolved
+// value with the form chosen by pick.
+func withSourceValues(c Config, pick func(configSource) json.RawMessage) Config {
+	if len(c.sources) == 0 {
+		return c
+	}
+	out := c
+	v := reflect.ValueOf(&out).Elem()
+	fields := configFields()
+	for key, src := range c.sources {
+		f, ok := fields[key]
+		if !ok {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		current, err := json.Marshal(fv.Interface())
+		if err != nil || !sameJSON(current, src.resolved) {
+			continue
+		}
+		prev := reflect.ValueOf(fv.Interface())
+		fv.Set(reflect.Zero(f.Type))
+		raw := pick(src)
+		if raw == nil {
+			continue
+		}
+		if err := json.Unmarshal(raw, fv.Addr().Interface()); err != nil {
+			fv.Set(prev)
+		}
+	}
+	return out
+}
+
+// sameJSON reports whether two JSON documents decode to equal values.
+func sameJSON(a, b []byte) bool {
+	var av, bv any
+	if json.Unmarshal(a, &av) != nil || json.Unmarshal(b, &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+// mustMarshal marshals a decoded JSON value. Values that came out of
+// json.Unmarshal always marshal, so an error yields JSON null.
+func mustMarshal(v any) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return json.RawMessage("null")
+	}
+	return data
+}
@@ -0,0 +1,278 @@
+package config_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/config"
+)
+
+// writeConfigFile writes body to a temp modula.config.json and returns its path.
+func writeConfigFile(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "modula.config.json")
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+	return path
+}
+
+func TestFileProvider_Get_EnvReference(t *testing.T) {
+	t.Setenv("TEST_MODULA_DB_PW", "hunter2")
+	path := writeConfigFile(t, `{"db_password": "${env:TEST_MODULA_DB_PW}", "db_url": "postgres://app:${env:TEST_MODULA_DB_PW}@db/app"}`)
+
+	cfg, err := config.NewFileProvider(path).Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if cfg.Db_Password != "hunter2" {
+		t.Errorf("Db_Password = %q, want %q", cfg.Db_Password, "hunter2")
+	}
+	if cfg.Db_URL != "postgres://app:hunter2@db/app" {
+		t.Errorf("Db_URL = %q, want embedded reference resolved", cfg.Db_URL)
+	}
+}
+
+func TestFileProvider_Get_FileReference(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "bucket_secret")
+	if err := os.WriteFile(secret, []byte("s3-secret\n"), 0600); err != nil {
+		t.Fatalf("writing secret: %v", err)
+	}
+	path := writeConfigFile(t, `{"bucket_secret_key": "${file:`+secret+`}"}`)
+
+	cfg, err := config.NewFileProvider(path).Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if cfg.Bucket_Secret_Key != "s3-secret" {
+		t.Errorf("Bucket_Secret_Key = %q, want trailing newline trimmed", cfg.Bucket_Secret_Key)
+	}
+}
+
+func TestFileProvider_Get_UnresolvableReference(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"unset env", `{"db_password": "${env:TEST_MODULA_UNSET_VAR}"}`, "TEST_MODULA_UNSET_VAR is not set"},
+		{"missing file", `{"db_password": "${file:/nonexistent/modula-secret}"}`, "reading secret file"},
+		{"empty name", `{"db_password": "${env:}"}`, "empty env reference"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := config.NewFileProvider(writeConfigFile(t, tt.body)).Get()
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %q, want it to contain %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFileProvider_Get_EnvOverrides(t *testing.T) {
+	t.Setenv("MODULA_PORT", ":9999")
+	t.Setenv("MODULA_WEBHOOK_ENABLED", "true")
+	t.Setenv("MODULA_WEBHOOK_WORKERS", "7")
+	t.Setenv("MODULA_CORS_ORIGINS", `["https://a.example","https://b.example"]`)
+	t.Setenv("MODULA_NOT_A_CONFIG_KEY", "ignored")
+	path := writeConfigFile(t, `{"port": ":8080", "webhook_enabled": false}`)
+
+	cfg, err := config.NewFileProvider(path).Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if cfg.Port != ":9999" {
+		t.Errorf("Port = %q, want override :9999", cfg.Port)
+	}
+	if !cfg.Webhook_Enabled {
+		t.Error("Webhook_Enabled = false, want override true")
+	}
+	if cfg.Webhook_Workers != 7 {
+		t.Errorf("Webhook_Workers = %d, want 7", cfg.Webhook_Workers)
+	}
+	if len(cfg.Cors_Origins) != 2 || cfg.Cors_Origins[1] != "https://b.example" {
+		t.Errorf("Cors_Origins = %v, want override list", cfg.Cors_Origins)
+	}
+}
+
+func TestFileProvider_Get_InvalidEnvOverride(t *testing.T) {
+	t.Setenv("MODULA_WEBHOOK_WORKERS", "many")
+
+	_, err := config.NewFileProvider(writeConfigFile(t, `{}`)).Get()
+	if err == nil {
+		t.Fatal("expected error for non-numeric override, got nil")
+	}
+	if !strings.Contains(err.Error(), "MODULA_WEBHOOK_WORKERS") {
+		t.Errorf("error = %q, want it to name the variable", err.Error())
+	}
+}
+
+func TestFileProvider_Get_OverrideMayBeReference(t *testing.T) {
+	t.Setenv("TEST_MODULA_SALT", "pepper")
+	t.Setenv("MODULA_AUTH_SALT", "${env:TEST_MODULA_SALT}")
+
+	cfg, err := config.NewFileProvider(writeConfigFile(t, `{"auth_salt": "file-salt"}`)).Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if cfg.Auth_Salt != "pepper" {
+		t.Errorf("Auth_Salt = %q, want %q", cfg.Auth_Salt, "pepper")
+	}
+}
+
+func TestFileProvider_Save_KeepsReferences(t *testing.T) {
+	t.Setenv("TEST_MODULA_DB_PW", "hunter2")
+	t.Setenv("MODULA_PORT", ":9999")
+	path := writeConfigFile(t, `{"db_driver": "sqlite", "db_url": "./modula.db", "ssh_port": "2233", "db_password": "${env:TEST_MODULA_DB_PW}", "port": ":8080", "client_site": "old.example.com"}`)
+
+	mgr := config.NewManager(config.NewFileProvider(path))
+	if err := mgr.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if _, err := mgr.Update(map[string]any{"client_site": "new.example.com"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading saved config: %v", err)
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Fatalf("saved config contains the resolved secret:\n%s", data)
+	}
+
+	var saved map[string]any
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("parsing saved config: %v", err)
+	}
+	if saved["db_password"] != "${env:TEST_MODULA_DB_PW}" {
+		t.Errorf("db_password = %v, want reference preserved", saved["db_password"])
+	}
+	if saved["port"] != ":8080" {
+		t.Errorf("port = %v, want file value preserved under override", saved["port"])
+	}
+	if saved["client_site"] != "new.example.com" {
+		t.Errorf("client_site = %v, want updated value", saved["client_site"])
+	}
+}
+
+func TestManager_Update_ResolvesReferences(t *testing.T) {
+	t.Setenv("TEST_MODULA_SMTP_PW", "smtp-secret")
+	path := writeConfigFile(t, `{"db_driver": "sqlite", "db_url": "./modula.db", "port": ":8080", "ssh_port": "2233"}`)
+
+	mgr := config.NewManager(config.NewFileProvider(path))
+	if err := mgr.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if _, err := mgr.Update(map[string]any{"email_password": "${env:TEST_MODULA_SMTP_PW}"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	cfg, err := mgr.Config()
+	if err != nil {
+		t.Fatalf("Config: %v", err)
+	}
+	if cfg.Email_Password != "smtp-secret" {
+		t.Errorf("Email_Password = %q, want resolved value in memory", cfg.Email_Password)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading saved config: %v", err)
+	}
+	if strings.Contains(string(data), "smtp-secret") {
+		t.Errorf("saved config contains the resolved secret:\n%s", data)
+	}
+}
+
+func TestRedactedConfig_NeverPrintsResolvedSecrets(t *testing.T) {
+	t.Setenv("TEST_MODULA_DB_PW", "hunter2")
+	t.Setenv("MODULA_BUCKET_SECRET_KEY", "override-secret")
+	t.Setenv("MODULA_MCP_PROXY_TOKEN", "proxy-secret")
+	path := writeConfigFile(t, `{"db_password": "${env:TEST_MODULA_DB_PW}", "db_url": "postgres://app:${env:TEST_MODULA_DB_PW}@db/app", "email_password": "literal-secret"}`)
+
+	cfg, err := config.NewFileProvider(path).Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	out, err := config.RedactedJSON(*cfg)
+	if err != nil {
+		t.Fatalf("RedactedJSON: %v", err)
+	}
+	for _, secret := range []string{"hunter2", "override-secret", "proxy-secret", "literal-secret"} {
+		if strings.Contains(string(out), secret) {
+			t.Errorf("redacted output contains %q:\n%s", secret, out)
+		}
+	}
+
+	redacted := config.RedactedConfig(*cfg)
+	if redacted.Db_Password != "${env:TEST_MODULA_DB_PW}" {
+		t.Errorf("Db_Password = %q, want the reference", redacted.Db_Password)
+	}
+	if redacted.Db_URL != "postgres://app:${env:TEST_MODULA_DB_PW}@db/app" {
+		t.Errorf("Db_URL = %q, want the reference", redacted.Db_URL)
+	}
+	if !config.IsRedactedValue(redacted.Bucket_Secret_Key) {
+		t.Errorf("Bucket_Secret_Key = %q, want redaction placeholder", redacted.Bucket_Secret_Key)
+	}
+
+	// The source config is not modified.
+	if cfg.Db_Password != "hunter2" {
+		t.Errorf("source Db_Password = %q, want resolved value untouched", cfg.Db_Password)
+	}
+}
+
+func TestRedactedConfig_RedactsSensitiveLists(t *testing.T) {
+	cfg := config.Config{Encryption_Previous_Keys: []string{"old-key", "${file:/run/secrets/older}"}}
+
+	redacted := config.RedactedConfig(cfg)
+	if !config.IsRedactedValue(redacted.Encryption_Previous_Keys[0]) {
+		t.Errorf("Encryption_Previous_Keys[0] = %q, want redaction placeholder", redacted.Encryption_Previous_Keys[0])
+	}
+	if redacted.Encryption_Previous_Keys[1] != "${file:/run/secrets/older}" {
+		t.Errorf("Encryption_Previous_Keys[1] = %q, want the reference", redacted.Encryption_Previous_Keys[1])
+	}
+	if cfg.Encryption_Previous_Keys[0] != "old-key" {
+		t.Error("redaction modified the source config's slice")
+	}
+}
+
+func TestRedactedConfig_RedactsDeployEnvironmentKeys(t *testing.T) {
+	cfg := config.Config{Deploy_Environments: []config.DeployEnvironmentConfig{
+		{Name: "prod", URL: "https://prod.example.com", APIKey: "prod-api-key", EncryptionKey: "prod-master-key"},
+		{Name: "staging", URL: "https://staging.example.com", APIKey: "${env:STAGING_KEY}"},
+	}}
+
+	redacted := config.RedactedConfig(cfg)
+	prod := redacted.Deploy_Environments[0]
+	if !config.IsRedactedValue(prod.APIKey) || !config.IsRedactedValue(prod.EncryptionKey) {
+		t.Errorf("prod keys = %q/%q, want redaction placeholders", prod.APIKey, prod.EncryptionKey)
+	}
+	staging := redacted.Deploy_Environments[1]
+	if staging.APIKey != "${env:STAGING_KEY}" {
+		t.Errorf("staging APIKey = %q, want the reference", staging.APIKey)
+	}
+	if staging.EncryptionKey != "" {
+		t.Errorf("staging EncryptionKey = %q, want empty", staging.EncryptionKey)
+	}
+	if cfg.Deploy_Environments[0].EncryptionKey != "prod-master-key" {
+		t.Error("redaction modified the source config's deploy environments")
+	}
+
+	out, err := config.RedactedJSON(cfg)
+	if err != nil {
+		t.Fatalf("RedactedJSON: %v", err)
+	}
+	for _, secret := range []string{"prod-api-key", "prod-master-key"} {
+		if strings.Contains(string(out), secret) {
+			t.Errorf("redacted output contains %q:\n%s", secret, out)
+		}
+	}
+}
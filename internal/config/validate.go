@@ -1,6 +1,13 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
 
 // ValidationResult holds the outcome of a configuration validation.
 type ValidationResult struct {
@@ -63,6 +70,98 @@ func Validate(c Config) ValidationResult {
 		}
 	}
 
+	if c.Auth_Lockout_Duration != "" {
+		if _, err := time.ParseDuration(c.Auth_Lockout_Duration); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("auth_lockout_duration %q is not a valid duration", c.Auth_Lockout_Duration))
+		}
+	}
+	if c.Auth_Lockout_Max_Duration != "" {
+		if _, err := time.ParseDuration(c.Auth_Lockout_Max_Duration); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("auth_lockout_max_duration %q is not a valid duration", c.Auth_Lockout_Max_Duration))
+		}
+	}
+	if c.Password_Min_Length > 72 {
+		result.Errors = append(result.Errors, "password_min_length cannot exceed 72 (the bcrypt input limit)")
+	}
+
+	switch c.Rate_Limit_Store {
+	case "", "memory", "database":
+	default:
+		result.Errors = append(result.Errors, fmt.Sprintf("rate_limit_store %q must be \"memory\" or \"database\"", c.Rate_Limit_Store))
+	}
+	policyNames := make(map[string]bool, len(c.Rate_Limit_Policies))
+	for i, p := range c.Rate_Limit_Policies {
+		if p.Name == "" {
+			result.Errors = append(result.Errors, fmt.Sprintf("rate_limit_policies[%d] has no name", i))
+		} else if policyNames[p.Name] {
+			result.Errors = append(result.Errors, fmt.Sprintf("rate_limit_policies: duplicate policy name %q", p.Name))
+		}
+		policyNames[p.Name] = true
+		switch p.Key {
+		case "", "ip", "user", "token":
+		default:
+			result.Errors = append(result.Errors, fmt.Sprintf("rate_limit_policies[%d] key %q must be ip, user or token", i, p.Key))
+		}
+		if p.Limit <= 0 {
+			result.Errors = append(result.Errors, fmt.Sprintf("rate_limit_policies[%d] limit must be greater than 0", i))
+		}
+		if p.Window != "" {
+			if d, err := time.ParseDuration(p.Window); err != nil || d <= 0 {
+				result.Errors = append(result.Errors, fmt.Sprintf("rate_limit_policies[%d] window %q is not a valid duration", i, p.Window))
+			}
+		}
+	}
+
+	for _, tokenID := range slices.Sorted(maps.Keys(c.MCP_Token_Tools)) {
+		for _, pattern := range c.MCP_Token_Tools[tokenID] {
+			if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+				result.Errors = append(result.Errors, fmt.Sprintf("mcp_token_tools[%q] pattern %q is not a valid glob", tokenID, pattern))
+			}
+		}
+	}
+
+	switch c.Backup_Target {
+	case "", "local":
+	case "bucket":
+		if c.Bucket_Backup == "" {
+			result.Errors = append(result.Errors, "backup_target is \"bucket\" but bucket_backup is empty")
+		}
+	default:
+		result.Errors = append(result.Errors, fmt.Sprintf("backup_target %q must be \"local\" or \"bucket\"", c.Backup_Target))
+	}
+	if c.Backup_Retain_Daily < 0 || c.Backup_Retain_Weekly < 0 || c.Backup_Retain_Monthly < 0 {
+		result.Errors = append(result.Errors, "backup_retain_daily, backup_retain_weekly and backup_retain_monthly cannot be negative")
+	}
+	switch c.Backup_Media {
+	case "", "archive", "sibling":
+		if c.Backup_Media != "" && c.Bucket_Media == "" {
+			result.Errors = append(result.Errors, fmt.Sprintf("backup_media is %q but bucket_media is empty", c.Backup_Media))
+		}
+	default:
+		result.Errors = append(result.Errors, fmt.Sprintf("backup_media %q must be empty, \"archive\" or \"sibling\"", c.Backup_Media))
+	}
+	if c.Backup_Encryption_Recipient != "" && !strings.HasPrefix(c.Backup_Encryption_Recipient, "modula-backup-pub1:") {
+		result.Errors = append(result.Errors, "backup_encryption_recipient must be a key from 'modula backup keygen' (modula-backup-pub1:...)")
+	}
+
+	switch c.Translation_Provider {
+	case "", "deepl":
+		if c.Translation_Provider != "" && c.Translation_Provider_API_Key == "" {
+			result.Errors = append(result.Errors, "translation_provider is \"deepl\" but translation_provider_api_key is empty")
+		}
+	case "libretranslate":
+		if c.Translation_Provider_URL == "" {
+			result.Errors = append(result.Errors, "translation_provider is \"libretranslate\" but translation_provider_url is empty")
+		}
+	default:
+		if !strings.Contains(c.Translation_Provider, ":") {
+			result.Errors = append(result.Errors, fmt.Sprintf("translation_provider %q must be \"deepl\", \"libretranslate\" or a plugin provider \"<plugin>:<name>\"", c.Translation_Provider))
+		}
+	}
+	if c.Translation_Memory_Min_Score < 0 || c.Translation_Memory_Min_Score > 100 {
+		result.Errors = append(result.Errors, "translation_memory_min_score must be between 0 and 100")
+	}
+
 	if c.Observability_Sample_Rate < 0 || c.Observability_Sample_Rate > 1 {
 		result.Warnings = append(result.Warnings, "observability_sample_rate should be between 0.0 and 1.0")
 	}
@@ -268,6 +367,54 @@ func configFieldString(c Config, key string) string {
 		return c.Update_Channel
 	case "update_notify_only":
 		return fmt.Sprintf("%t", c.Update_Notify_Only)
+	case "auth_lockout_threshold":
+		return fmt.Sprintf("%d", c.Auth_Lockout_Threshold)
+	case "auth_lockout_duration":
+		return c.Auth_Lockout_Duration
+	case "auth_lockout_max_duration":
+		return c.Auth_Lockout_Max_Duration
+	case "auth_lockout_notify":
+		return fmt.Sprintf("%t", c.Auth_Lockout_Notify)
+	case "password_min_length":
+		return fmt.Sprintf("%d", c.Password_Min_Length)
+	case "password_history":
+		return fmt.Sprintf("%d", c.Password_History)
+	case "password_expiry_days":
+		return fmt.Sprintf("%d", c.Password_Expiry_Days)
+	case "password_breached_list":
+		return c.Password_Breached_List
+	case "rate_limit_store":
+		return c.Rate_Limit_Store
+	case "backup_schedule":
+		return c.Backup_Schedule
+	case "backup_target":
+		return c.Backup_Target
+	case "backup_local_dir":
+		return c.Backup_Local_Dir
+	case "backup_retain_daily":
+		return fmt.Sprintf("%d", c.Backup_Retain_Daily)
+	case "backup_retain_weekly":
+		return fmt.Sprintf("%d", c.Backup_Retain_Weekly)
+	case "backup_retain_monthly":
+		return fmt.Sprintf("%d", c.Backup_Retain_Monthly)
+	case "backup_media":
+		return c.Backup_Media
+	case "backup_encryption_passphrase":
+		return c.Backup_Encryption_Passphrase
+	case "backup_encryption_recipient":
+		return c.Backup_Encryption_Recipient
+	case "backup_encryption_identity_file":
+		return c.Backup_Encryption_Identity_File
+	case "translation_provider":
+		return c.Translation_Provider
+	case "translation_provider_url":
+		return c.Translation_Provider_URL
+	case "translation_provider_api_key":
+		return c.Translation_Provider_API_Key
+	case "translation_memory_min_score":
+		return fmt.Sprintf("%d", c.Translation_Memory_Min_Score)
+	case "i18n_require_current_translations":
+		return fmt.Sprintf("%t", c.I18n_Require_Current_Translations)
 	case "mcp_enabled":
 		return fmt.Sprintf("%t", c.MCP_Enabled)
 	case "mcp_proxy_token":
@@ -0,0 +1,150 @@
+// Package contentmigration rewrites stored content field values after a field
+// changes type or structure. A migration is a versioned transform over the
+// values of one field: it can convert a value in place (text to select),
+// split it across several fields, or move it to a field on a related content
+// node. Migrations are written in Go and registered at init time, or
+// registered by Lua plugins, and each one runs once per database.
+package contentmigration
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// SourceGo marks migrations compiled into the binary. Plugin migrations use
+// "plugin:<name>".
+const SourceGo = "go"
+
+// Migration is one versioned content transform over the stored values of a
+// single field.
+type Migration struct {
+	// ID identifies the migration and orders it against others; a migration
+	// with a recorded ID never runs again. Prefix it with a version or date,
+	// e.g. "0003_status_to_select".
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	// Datatype and Field are the machine names of the field whose values are
+	// rewritten.
+	Datatype string `json:"datatype"`
+	Field    string `json:"field"`
+	// Source is SourceGo or "plugin:<name>".
+	Source    string        `json:"source"`
+	Transform TransformFunc `json:"-"`
+}
+
+// Value is one stored value handed to a transform.
+type Value struct {
+	ContentFieldID types.ContentFieldID `json:"content_field_id"`
+	ContentDataID  types.ContentID      `json:"content_data_id"`
+	Locale         string               `json:"locale"`
+	Field          string               `json:"field"`
+	Value          string               `json:"value"`
+}
+
+// Result is what a transform wants written for one value. The zero Result
+// leaves the value unchanged.
+type Result struct {
+	// Values maps target fields to their new values. A bare field name is a
+	// field of the same content node; "datatype.field" is a field on the
+	// nearest child or ancestor node of that datatype. Naming the source
+	// field rewrites the value in place.
+	Values map[string]string `json:"values,omitempty"`
+	// Remove deletes the source value once the targets are written. It is
+	// ignored when the source field is itself a target.
+	Remove bool `json:"remove,omitempty"`
+}
+
+// TransformFunc computes the new values for one stored value. Transforms
+// run twice per value during a migration (once to validate, once to
+// write), so they must be deterministic and free of side effects.
+type TransformFunc func(ctx context.Context, v Value) (Result, error)
+
+// Set returns a Result that rewrites the source field in place.
+func Set(field, value string) Result {
+	return Result{Values: map[string]string{field: value}}
+}
+
+var migrationIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:-]*$`)
+
+// Validate reports whether a migration is complete enough to run.
+func (m Migration) Validate() error {
+	if !migrationIDPattern.MatchString(m.ID) {
+		return fmt.Errorf("contentmigration: invalid migration id %q (letters, digits, _ . : - only)", m.ID)
+	}
+	if m.Datatype == "" || m.Field == "" {
+		return fmt.Errorf("contentmigration: migration %q: datatype and field are required", m.ID)
+	}
+	if m.Transform == nil {
+		return fmt.Errorf("contentmigration: migration %q: transform is required", m.ID)
+	}
+	return nil
+}
+
+// Registry holds the migrations available to run, keyed by ID.
+type Registry struct {
+	mu         sync.RWMutex
+	migrations map[string]Migration
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{migrations: make(map[string]Migration)}
+}
+
+// Register adds a migration. IDs must be unique within the registry.
+func (r *Registry) Register(m Migration) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	if m.Source == "" {
+		m.Source = SourceGo
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.migrations[m.ID]; exists {
+		return fmt.Errorf("contentmigration: migration %q is already registered", m.ID)
+	}
+	r.migrations[m.ID] = m
+	return nil
+}
+
+// Get returns the migration with the given ID.
+func (r *Registry) Get(id string) (Migration, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	m, ok := r.migrations[id]
+	return m, ok
+}
+
+// List returns all migrations ordered by ID.
+func (r *Registry) List() []Migration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]Migration, 0, len(r.migrations))
+	for _, m := range r.migrations {
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+var defaultRegistry = NewRegistry()
+
+// Default returns the registry that Register adds Go migrations to.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Register adds a Go migration to the default registry. It is meant to be
+// called from init functions and panics on an invalid or duplicate
+// migration, like database/sql.Register.
+func Register(m Migration) {
+	if err := defaultRegistry.Register(m); err != nil {
+		panic(err)
+	}
+}
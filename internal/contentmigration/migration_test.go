@@ -0,0 +1,56 @@
+package contentmigration
+
+import (
+	"context"
+	"testing"
+)
+
+func noop(context.Context, Value) (Result, error) { return Result{}, nil }
+
+func TestMigrationValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		m       Migration
+		wantErr bool
+	}{
+		{"valid", Migration{ID: "0001_a", Datatype: "page", Field: "title", Transform: noop}, false},
+		{"plugin id", Migration{ID: "seo:0001", Datatype: "page", Field: "title", Transform: noop}, false},
+		{"empty id", Migration{Datatype: "page", Field: "title", Transform: noop}, true},
+		{"bad id", Migration{ID: "a b", Datatype: "page", Field: "title", Transform: noop}, true},
+		{"no field", Migration{ID: "x", Datatype: "page", Transform: noop}, true},
+		{"no transform", Migration{ID: "x", Datatype: "page", Field: "title"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.m.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	for _, id := range []string{"0002_b", "0001_a"} {
+		if err := r.Register(Migration{ID: id, Datatype: "page", Field: "title", Transform: noop}); err != nil {
+			t.Fatalf("Register(%s): %v", id, err)
+		}
+	}
+	if err := r.Register(Migration{ID: "0001_a", Datatype: "page", Field: "body", Transform: noop}); err == nil {
+		t.Error("duplicate Register succeeded")
+	}
+
+	list := r.List()
+	if len(list) != 2 || list[0].ID != "0001_a" || list[1].ID != "0002_b" {
+		t.Fatalf("List() = %v", list)
+	}
+	if list[0].Source != SourceGo {
+		t.Errorf("Source = %q, want %q", list[0].Source, SourceGo)
+	}
+	if _, ok := r.Get("0003_c"); ok {
+		t.Error("Get of unknown id succeeded")
+	}
+}
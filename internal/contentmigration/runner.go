@@ -0,0 +1,586 @@
+package contentmigration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/validation"
+)
+
+// DefaultBatchSize is the number of source values rewritten per transaction.
+const DefaultBatchSize = 100
+
+// maxReportIssues caps the issues listed in a Report; InvalidValues still
+// counts all of them.
+const maxReportIssues = 200
+
+var (
+	// ErrAlreadyApplied is returned by Run when the migration is recorded as
+	// having run to completion.
+	ErrAlreadyApplied = errors.New("contentmigration: migration has already run")
+	// ErrInvalidValues is returned by Run when migrated values would fail
+	// their target field's type or validation config and invalid values were
+	// not allowed. Nothing is written.
+	ErrInvalidValues = errors.New("contentmigration: migrated values fail validation")
+)
+
+// Options controls Run.
+type Options struct {
+	// DryRun computes and validates every rewrite without writing or
+	// recording anything.
+	DryRun bool
+	// AllowInvalid writes values even when they fail validation.
+	AllowInvalid bool
+	// BatchSize is the number of source values per transaction
+	// (default DefaultBatchSize).
+	BatchSize int
+	// AuthorID is recorded on created values and on the migration record.
+	AuthorID types.UserID
+}
+
+// Report summarizes a migration run or dry run.
+type Report struct {
+	MigrationID string `json:"migration_id"`
+	DryRun      bool   `json:"dry_run"`
+	// ResumedAfter is the last value an earlier, interrupted run applied.
+	// When set, the counts cover only the values after it.
+	ResumedAfter  types.ContentFieldID `json:"resumed_after,omitempty"`
+	Scanned       int                  `json:"rows_scanned"`
+	Changed       int                  `json:"rows_changed"`
+	Created       int                  `json:"values_created"`
+	Updated       int                  `json:"values_updated"`
+	Removed       int                  `json:"values_removed"`
+	Batches       int                  `json:"batches"`
+	InvalidValues int                  `json:"invalid_values"`
+	Issues        []Issue              `json:"issues"`
+}
+
+// Issue is a migrated value that fails validation, or a source value the
+// transform could not handle.
+type Issue struct {
+	ContentDataID types.ContentID `json:"content_data_id"`
+	Locale        string          `json:"locale"`
+	Field         string          `json:"field"`
+	Value         string          `json:"value"`
+	Messages      []string        `json:"messages"`
+}
+
+// Run executes a migration against the database. Every value is first
+// transformed and validated against its target field in a pass that writes
+// nothing; unless opts.DryRun is set, a second pass then rewrites the values
+// in batches, each batch in one transaction with every row change audited,
+// and the migration is recorded as completed so it does not run again.
+//
+// Each batch records the last value it applied in the migration record in
+// the same transaction, so a failure mid-run leaves the migration recorded
+// as incomplete and a rerun resumes after the last committed batch instead
+// of reapplying it. Values the interrupted run created in its own source
+// field are not known to the rerun and are transformed again.
+func Run(ctx context.Context, driver db.DbDriver, ac audited.AuditContext, m Migration, opts Options) (Report, error) {
+	report := Report{MigrationID: m.ID, DryRun: opts.DryRun, Issues: []Issue{}}
+	if err := m.Validate(); err != nil {
+		return report, err
+	}
+	if !opts.DryRun && opts.AuthorID.IsZero() {
+		return report, fmt.Errorf("contentmigration: authorID cannot be empty")
+	}
+	var resume *db.ContentMigration
+	if rec, err := driver.GetContentMigration(m.ID); err == nil {
+		if rec.Completed && !opts.DryRun {
+			return report, fmt.Errorf("%w: %s", ErrAlreadyApplied, m.ID)
+		}
+		if !rec.Completed {
+			resume = rec
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return report, fmt.Errorf("contentmigration: check %s: %w", m.ID, err)
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if m.Source == "" {
+		m.Source = SourceGo
+	}
+
+	r, err := newRunner(driver, ac, m, opts)
+	if err != nil {
+		return report, err
+	}
+	if resume != nil {
+		r.recorded = true
+		r.last = resume.LastContentFieldID
+		r.priorScanned = resume.RowsScanned
+		r.priorChanged = resume.RowsChanged
+	}
+
+	// Pass 1: transform and validate without writing.
+	preflight, err := r.pass(ctx, false)
+	if err != nil {
+		return preflight, err
+	}
+	if opts.DryRun {
+		return preflight, nil
+	}
+	if preflight.InvalidValues > 0 && !opts.AllowInvalid {
+		return preflight, fmt.Errorf("%w: %d value(s) in %s", ErrInvalidValues, preflight.InvalidValues, m.ID)
+	}
+
+	// Pass 2: write.
+	result, err := r.pass(ctx, true)
+	if err != nil {
+		return result, err
+	}
+	if err := db.WithTransaction(ctx, r.conn, func(tx *sql.Tx) error {
+		return r.saveProgress(ctx, tx, r.last, result, true)
+	}); err != nil {
+		return result, fmt.Errorf("contentmigration: record %s: %w", m.ID, err)
+	}
+	return result, nil
+}
+
+// runner resolves field names once and carries the state of one pass.
+type runner struct {
+	driver db.DbDriver
+	conn   *sql.DB
+	ac     audited.AuditContext
+	m      Migration
+	opts   Options
+	now    types.Timestamp
+
+	source      db.Fields
+	datatypes   map[types.DatatypeID]db.Datatypes
+	byName      map[string]types.DatatypeID
+	fields      map[types.DatatypeID]map[string]db.Fields
+	validations map[types.ValidationID]string
+
+	// created holds the IDs of values created in the current pass, so that
+	// a migration writing to its own source field does not revisit them.
+	created map[types.ContentFieldID]bool
+
+	// recorded is set once the migration record exists. last is the last
+	// source value applied by a committed batch, and the prior counts are
+	// those of an interrupted run being resumed.
+	recorded     bool
+	last         types.ContentFieldID
+	priorScanned int64
+	priorChanged int64
+}
+
+func newRunner(driver db.DbDriver, ac audited.AuditContext, m Migration, opts Options) (*runner, error) {
+	conn, _, err := driver.GetConnection()
+	if err != nil {
+		return nil, fmt.Errorf("contentmigration: get connection: %w", err)
+	}
+	r := &runner{
+		driver:      driver,
+		conn:        conn,
+		ac:          ac,
+		m:           m,
+		opts:        opts,
+		now:         types.TimestampNow(),
+		datatypes:   make(map[types.DatatypeID]db.Datatypes),
+		byName:      make(map[string]types.DatatypeID),
+		fields:      make(map[types.DatatypeID]map[string]db.Fields),
+		validations: make(map[types.ValidationID]string),
+	}
+
+	dts, err := driver.ListDatatypes()
+	if err != nil {
+		return nil, fmt.Errorf("contentmigration: list datatypes: %w", err)
+	}
+	if dts != nil {
+		for _, dt := range *dts {
+			r.datatypes[dt.DatatypeID] = dt
+			r.byName[dt.Name] = dt.DatatypeID
+		}
+	}
+	dtID, ok := r.byName[m.Datatype]
+	if !ok {
+		return nil, fmt.Errorf("contentmigration: migration %q: unknown datatype %q", m.ID, m.Datatype)
+	}
+	fields, err := r.fieldsOf(dtID)
+	if err != nil {
+		return nil, err
+	}
+	source, ok := fields[m.Field]
+	if !ok {
+		return nil, fmt.Errorf("contentmigration: migration %q: datatype %q has no field %q", m.ID, m.Datatype, m.Field)
+	}
+	r.source = source
+	return r, nil
+}
+
+// fieldsOf returns the fields of a datatype by machine name.
+func (r *runner) fieldsOf(id types.DatatypeID) (map[string]db.Fields, error) {
+	if fields, ok := r.fields[id]; ok {
+		return fields, nil
+	}
+	list, err := r.driver.ListFieldsByDatatypeID(types.NullableDatatypeID{ID: id, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("contentmigration: list fields of %s: %w", id, err)
+	}
+	fields := make(map[string]db.Fields)
+	if list != nil {
+		for _, f := range *list {
+			fields[f.Name] = f
+		}
+	}
+	r.fields[id] = fields
+	return fields, nil
+}
+
+// validationConfig returns the ValidationConfig JSON for a field.
+func (r *runner) validationConfig(id types.NullableValidationID) string {
+	if !id.Valid || id.ID.IsZero() {
+		return ""
+	}
+	if config, ok := r.validations[id.ID]; ok {
+		return config
+	}
+	config := ""
+	if v, err := r.driver.GetValidation(id.ID); err == nil && v != nil {
+		config = v.Config
+	}
+	r.validations[id.ID] = config
+	return config
+}
+
+// pass walks the stored values of the source field after r.last in batches.
+// With write false each batch runs in a transaction that is rolled back; with
+// write true each batch also records its progress before it commits.
+func (r *runner) pass(ctx context.Context, write bool) (Report, error) {
+	report := Report{MigrationID: r.m.ID, DryRun: !write, ResumedAfter: r.last, Issues: []Issue{}}
+	r.created = make(map[types.ContentFieldID]bool)
+	after := r.last
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		var last types.ContentFieldID
+		var n int
+		batch := func(tx *sql.Tx) error {
+			rows, err := db.ListContentFieldsByFieldInTx(r.driver, ctx, tx, r.source.FieldID, after, int64(r.opts.BatchSize))
+			if err != nil {
+				return err
+			}
+			n = len(rows)
+			for _, cf := range rows {
+				last = cf.ContentFieldID
+				if r.created[cf.ContentFieldID] {
+					continue
+				}
+				if err := r.migrateValue(ctx, tx, cf, write, &report); err != nil {
+					return fmt.Errorf("content field %s: %w", cf.ContentFieldID, err)
+				}
+			}
+			if write && n > 0 {
+				return r.saveProgress(ctx, tx, last, report, false)
+			}
+			return nil
+		}
+
+		var err error
+		if write {
+			err = db.WithTransaction(ctx, r.conn, batch)
+		} else {
+			err = rollbackTx(ctx, r.conn, batch)
+		}
+		if err != nil {
+			return report, fmt.Errorf("contentmigration: %s batch %d: %w", r.m.ID, report.Batches+1, err)
+		}
+		if n == 0 {
+			return report, nil
+		}
+		report.Batches++
+		after = last
+		if write {
+			r.recorded = true
+			r.last = last
+		}
+		if n < r.opts.BatchSize {
+			return report, nil
+		}
+	}
+}
+
+// saveProgress records that the migration has applied every source value up
+// to last, creating the migration record on its first save. Counts include
+// those of a resumed run.
+func (r *runner) saveProgress(ctx context.Context, tx *sql.Tx, last types.ContentFieldID, report Report, completed bool) error {
+	scanned := r.priorScanned + int64(report.Scanned)
+	changed := r.priorChanged + int64(report.Changed)
+	if r.recorded {
+		return db.UpdateContentMigrationProgressInTx(r.driver, ctx, tx, db.UpdateContentMigrationProgressParams{
+			RowsScanned:        scanned,
+			RowsChanged:        changed,
+			LastContentFieldID: last,
+			Completed:          completed,
+			MigrationID:        r.m.ID,
+		})
+	}
+	return db.RecordContentMigrationInTx(r.driver, ctx, tx, db.RecordContentMigrationParams{
+		MigrationID:        r.m.ID,
+		Source:             r.m.Source,
+		Description:        r.m.Description,
+		RowsScanned:        scanned,
+		RowsChanged:        changed,
+		LastContentFieldID: last,
+		Completed:          completed,
+		AuthorID:           r.opts.AuthorID,
+		DateCreated:        r.now,
+	})
+}
+
+// rollbackTx runs fn in a transaction that is always rolled back.
+func rollbackTx(ctx context.Context, conn *sql.DB, fn db.TxFunc) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	return fn(tx)
+}
+
+// target is one resolved write of a transform Result.
+type target struct {
+	name  string
+	node  db.ContentData
+	field db.Fields
+	value string
+}
+
+// migrateValue transforms one stored value and, when write is set, applies
+// the result. Validation failures and transform errors become report issues
+// during the validation pass; during the write pass transform errors abort
+// the batch.
+func (r *runner) migrateValue(ctx context.Context, tx *sql.Tx, cf db.ContentFields, write bool, report *Report) error {
+	report.Scanned++
+	if !cf.ContentDataID.Valid {
+		return nil
+	}
+	res, err := r.m.Transform(ctx, Value{
+		ContentFieldID: cf.ContentFieldID,
+		ContentDataID:  cf.ContentDataID.ID,
+		Locale:         cf.Locale,
+		Field:          r.m.Field,
+		Value:          cf.FieldValue,
+	})
+	if err != nil {
+		if write {
+			return fmt.Errorf("transform: %w", err)
+		}
+		r.addIssue(report, cf.ContentDataID.ID, cf.Locale, r.m.Field, cf.FieldValue, "transform failed: "+err.Error())
+		return nil
+	}
+	if len(res.Values) == 0 && !res.Remove {
+		return nil
+	}
+
+	node, err := db.GetContentDataInTx(r.driver, ctx, tx, cf.ContentDataID.ID)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(res.Values))
+	for name := range res.Values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	targets := make([]target, 0, len(names))
+	keepSource := false
+	for _, name := range names {
+		t, err := r.resolveTarget(ctx, tx, *node, name)
+		if err != nil {
+			if write {
+				return err
+			}
+			r.addIssue(report, node.ContentDataID, cf.Locale, name, res.Values[name], err.Error())
+			continue
+		}
+		t.value = res.Values[name]
+		if t.field.FieldID == r.source.FieldID && t.node.ContentDataID == node.ContentDataID {
+			keepSource = true
+		}
+		if fe := validation.ValidateField(validation.FieldInput{
+			FieldID:    t.field.FieldID,
+			Label:      t.field.Label,
+			FieldType:  t.field.Type,
+			Value:      t.value,
+			Validation: r.validationConfig(t.field.ValidationID),
+			Data:       t.field.Data,
+		}); fe != nil {
+			r.addIssue(report, t.node.ContentDataID, cf.Locale, name, t.value, fe.Messages...)
+		}
+		targets = append(targets, t)
+	}
+
+	changed := false
+	for _, t := range targets {
+		locale := cf.Locale
+		if !t.field.Translatable {
+			locale = ""
+		}
+		existing := &cf
+		if t.field.FieldID != r.source.FieldID || t.node.ContentDataID != node.ContentDataID || locale != cf.Locale {
+			existing, err = db.FindContentFieldInTx(r.driver, ctx, tx, t.node.ContentDataID, t.field.FieldID, locale)
+			if err != nil {
+				return err
+			}
+		}
+		if existing != nil && existing.FieldValue == t.value {
+			continue
+		}
+		changed = true
+		if existing == nil {
+			report.Created++
+			if !write {
+				continue
+			}
+			created, err := db.CreateContentFieldInTx(r.driver, ctx, tx, r.ac, db.CreateContentFieldParams{
+				RouteID:       t.node.RouteID,
+				RootID:        t.node.RootID,
+				ContentDataID: types.NullableContentID{ID: t.node.ContentDataID, Valid: true},
+				FieldID:       types.NullableFieldID{ID: t.field.FieldID, Valid: true},
+				FieldValue:    t.value,
+				Locale:        locale,
+				AuthorID:      r.opts.AuthorID,
+				DateCreated:   r.now,
+				DateModified:  r.now,
+			})
+			if err != nil {
+				return err
+			}
+			r.created[created.ContentFieldID] = true
+			continue
+		}
+		report.Updated++
+		if !write {
+			continue
+		}
+		if err := db.UpdateContentFieldInTx(r.driver, ctx, tx, r.ac, db.UpdateContentFieldParams{
+			RouteID:        existing.RouteID,
+			RootID:         existing.RootID,
+			ContentDataID:  existing.ContentDataID,
+			FieldID:        existing.FieldID,
+			FieldValue:     t.value,
+			Locale:         existing.Locale,
+			AuthorID:       existing.AuthorID,
+			DateCreated:    existing.DateCreated,
+			DateModified:   r.now,
+			ContentFieldID: existing.ContentFieldID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if res.Remove && !keepSource {
+		changed = true
+		report.Removed++
+		if write {
+			if err := db.DeleteContentFieldInTx(r.driver, ctx, tx, r.ac, cf.ContentFieldID); err != nil {
+				return err
+			}
+		}
+	}
+	if changed {
+		report.Changed++
+	}
+	return nil
+}
+
+// resolveTarget finds the content node and field definition for a Result
+// key. A bare name is a field of the source node's datatype; for
+// "datatype.field" the node is the source node itself when it has that
+// datatype, else its first direct child of that datatype, else its nearest
+// ancestor of that datatype.
+func (r *runner) resolveTarget(ctx context.Context, tx *sql.Tx, node db.ContentData, name string) (target, error) {
+	dtName, fieldName, qualified := strings.Cut(name, ".")
+	if !qualified {
+		fieldName, dtName = dtName, ""
+	}
+	if !node.DatatypeID.Valid {
+		return target{}, fmt.Errorf("content node %s has no datatype", node.ContentDataID)
+	}
+
+	found := node
+	if qualified {
+		want, ok := r.byName[dtName]
+		if !ok {
+			return target{}, fmt.Errorf("unknown datatype %q", dtName)
+		}
+		var err error
+		found, ok, err = r.findNode(ctx, tx, node, want)
+		if err != nil {
+			return target{}, err
+		}
+		if !ok {
+			return target{}, fmt.Errorf("no %q node under or above content node %s", dtName, node.ContentDataID)
+		}
+	}
+
+	fields, err := r.fieldsOf(found.DatatypeID.ID)
+	if err != nil {
+		return target{}, err
+	}
+	field, ok := fields[fieldName]
+	if !ok {
+		return target{}, fmt.Errorf("datatype %q has no field %q", r.datatypes[found.DatatypeID.ID].Name, fieldName)
+	}
+	return target{name: name, node: found, field: field}, nil
+}
+
+// findNode looks for a node of the wanted datatype at, directly below, or
+// above the given node.
+func (r *runner) findNode(ctx context.Context, tx *sql.Tx, node db.ContentData, want types.DatatypeID) (db.ContentData, bool, error) {
+	if node.DatatypeID.ID == want {
+		return node, true, nil
+	}
+	children, err := db.ListContentDataChildIDsInTx(r.driver, ctx, tx, node.ContentDataID)
+	if err != nil {
+		return db.ContentData{}, false, err
+	}
+	for _, id := range children {
+		child, err := db.GetContentDataInTx(r.driver, ctx, tx, id)
+		if err != nil {
+			return db.ContentData{}, false, err
+		}
+		if child.DatatypeID.Valid && child.DatatypeID.ID == want {
+			return *child, true, nil
+		}
+	}
+	seen := map[types.ContentID]bool{node.ContentDataID: true}
+	for parent := node.ParentID; parent.Valid && !seen[parent.ID]; {
+		seen[parent.ID] = true
+		p, err := db.GetContentDataInTx(r.driver, ctx, tx, parent.ID)
+		if err != nil {
+			return db.ContentData{}, false, err
+		}
+		if p.DatatypeID.Valid && p.DatatypeID.ID == want {
+			return *p, true, nil
+		}
+		parent = p.ParentID
+	}
+	return db.ContentData{}, false, nil
+}
+
+func (r *runner) addIssue(report *Report, node types.ContentID, locale, field, value string, messages ...string) {
+	report.InvalidValues++
+	if len(report.Issues) >= maxReportIssues {
+		return
+	}
+	report.Issues = append(report.Issues, Issue{
+		ContentDataID: node,
+		Locale:        locale,
+		Field:         field,
+		Value:         value,
+		Messages:      messages,
+	})
+}
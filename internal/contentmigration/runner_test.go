@@ -0,0 +1,366 @@
+package contentmigration_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/contentmigration"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// fixture is a SQLite database with one "person" datatype whose fields are
+// name (text), first (text), last (text) and status (select draft/live).
+type fixture struct {
+	d      db.Database
+	ac     audited.AuditContext
+	user   types.UserID
+	fields map[string]types.FieldID
+	dt     types.DatatypeID
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	conn, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "migration_test.db"))
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if _, err := conn.Exec("PRAGMA foreign_keys=ON;"); err != nil {
+		t.Fatalf("PRAGMA foreign_keys: %v", err)
+	}
+
+	d := db.Database{
+		Connection: conn,
+		Context:    context.Background(),
+		Config:     config.Config{Node_ID: types.NewNodeID().String()},
+	}
+	if err := d.CreateAllTables(); err != nil {
+		t.Fatalf("CreateAllTables: %v", err)
+	}
+
+	ctx := context.Background()
+	ac := audited.Ctx(types.NodeID(d.Config.Node_ID), types.UserID(""), "test", "127.0.0.1")
+	role, err := d.CreateRole(ctx, ac, db.CreateRoleParams{Label: "test-role"})
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+	user, err := d.CreateUser(ctx, ac, db.CreateUserParams{
+		Username:     "migrator",
+		Name:         "Migrator",
+		Email:        types.Email("migrator@example.com"),
+		Hash:         "fakehash",
+		Role:         role.RoleID.String(),
+		DateCreated:  types.TimestampNow(),
+		DateModified: types.TimestampNow(),
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	ac.UserID = user.UserID
+
+	dt, err := d.CreateDatatype(ctx, ac, db.CreateDatatypeParams{
+		Name:         "person",
+		Label:        "Person",
+		Type:         "_root",
+		AuthorID:     user.UserID,
+		DateCreated:  types.TimestampNow(),
+		DateModified: types.TimestampNow(),
+	})
+	if err != nil {
+		t.Fatalf("CreateDatatype: %v", err)
+	}
+
+	f := &fixture{d: d, ac: ac, user: user.UserID, fields: map[string]types.FieldID{}, dt: dt.DatatypeID}
+	for i, spec := range []struct {
+		name string
+		typ  types.FieldType
+		data string
+	}{
+		{"name", types.FieldTypeText, ""},
+		{"first", types.FieldTypeText, ""},
+		{"last", types.FieldTypeText, ""},
+		{"status", types.FieldTypeSelect, `{"options":["draft","live"]}`},
+	} {
+		field, err := d.CreateField(ctx, ac, db.CreateFieldParams{
+			FieldID:      types.NewFieldID(),
+			ParentID:     types.NullableDatatypeID{ID: dt.DatatypeID, Valid: true},
+			SortOrder:    int64(i),
+			Name:         spec.name,
+			Label:        strings.ToUpper(spec.name[:1]) + spec.name[1:],
+			Data:         spec.data,
+			Type:         spec.typ,
+			AuthorID:     types.NullableUserID{ID: user.UserID, Valid: true},
+			DateCreated:  types.TimestampNow(),
+			DateModified: types.TimestampNow(),
+		})
+		if err != nil {
+			t.Fatalf("CreateField %s: %v", spec.name, err)
+		}
+		f.fields[spec.name] = field.FieldID
+	}
+	return f
+}
+
+// node creates a content node with the given field values.
+func (f *fixture) node(t *testing.T, values map[string]string) types.ContentID {
+	t.Helper()
+	ctx := context.Background()
+	cd, err := f.d.CreateContentData(ctx, f.ac, db.CreateContentDataParams{
+		DatatypeID:   types.NullableDatatypeID{ID: f.dt, Valid: true},
+		AuthorID:     f.user,
+		Status:       types.ContentStatusDraft,
+		DateCreated:  types.TimestampNow(),
+		DateModified: types.TimestampNow(),
+	})
+	if err != nil {
+		t.Fatalf("CreateContentData: %v", err)
+	}
+	for name, value := range values {
+		if _, err := f.d.CreateContentField(ctx, f.ac, db.CreateContentFieldParams{
+			ContentDataID: types.NullableContentID{ID: cd.ContentDataID, Valid: true},
+			FieldID:       types.NullableFieldID{ID: f.fields[name], Valid: true},
+			FieldValue:    value,
+			AuthorID:      f.user,
+			DateCreated:   types.TimestampNow(),
+			DateModified:  types.TimestampNow(),
+		}); err != nil {
+			t.Fatalf("CreateContentField %s: %v", name, err)
+		}
+	}
+	return cd.ContentDataID
+}
+
+// values returns the stored values of a node by field name.
+func (f *fixture) values(t *testing.T, id types.ContentID) map[string]string {
+	t.Helper()
+	rows, err := f.d.ListContentFieldsByContentData(types.NullableContentID{ID: id, Valid: true})
+	if err != nil {
+		t.Fatalf("ListContentFieldsByContentData: %v", err)
+	}
+	byID := make(map[types.FieldID]string, len(f.fields))
+	for name, fid := range f.fields {
+		byID[fid] = name
+	}
+	out := make(map[string]string)
+	if rows != nil {
+		for _, row := range *rows {
+			out[byID[row.FieldID.ID]] = row.FieldValue
+		}
+	}
+	return out
+}
+
+func lowerStatus() contentmigration.Migration {
+	return contentmigration.Migration{
+		ID:       "0001_status_lowercase",
+		Datatype: "person",
+		Field:    "status",
+		Transform: func(_ context.Context, v contentmigration.Value) (contentmigration.Result, error) {
+			return contentmigration.Set("status", strings.ToLower(strings.TrimSpace(v.Value))), nil
+		},
+	}
+}
+
+func TestRun_RewritesInPlaceAndRecords(t *testing.T) {
+	t.Parallel()
+	f := newFixture(t)
+	ctx := context.Background()
+	a := f.node(t, map[string]string{"status": "Draft "})
+	b := f.node(t, map[string]string{"status": "live"})
+
+	report, err := contentmigration.Run(ctx, f.d, f.ac, lowerStatus(), contentmigration.Options{AuthorID: f.user, BatchSize: 1})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if report.Scanned != 2 || report.Changed != 1 || report.Updated != 1 || report.Batches != 2 {
+		t.Errorf("report = %+v, want 2 scanned, 1 changed, 1 updated, 2 batches", report)
+	}
+	if got := f.values(t, a)["status"]; got != "draft" {
+		t.Errorf("node a status = %q, want draft", got)
+	}
+	if got := f.values(t, b)["status"]; got != "live" {
+		t.Errorf("node b status = %q, want live", got)
+	}
+
+	run, err := f.d.GetContentMigration("0001_status_lowercase")
+	if err != nil {
+		t.Fatalf("GetContentMigration: %v", err)
+	}
+	if !run.Completed || run.RowsScanned != 2 || run.RowsChanged != 1 || run.Source != contentmigration.SourceGo {
+		t.Errorf("recorded run = %+v", run)
+	}
+
+	_, err = contentmigration.Run(ctx, f.d, f.ac, lowerStatus(), contentmigration.Options{AuthorID: f.user})
+	if !errors.Is(err, contentmigration.ErrAlreadyApplied) {
+		t.Errorf("second Run error = %v, want ErrAlreadyApplied", err)
+	}
+}
+
+func TestRun_ResumesAfterLastCommittedBatch(t *testing.T) {
+	t.Parallel()
+	f := newFixture(t)
+	ctx := context.Background()
+	nodes := []types.ContentID{
+		f.node(t, map[string]string{"status": "Draft"}),
+		f.node(t, map[string]string{"status": "Live"}),
+		f.node(t, map[string]string{"status": "DRAFT"}),
+	}
+
+	// Fail the second value of the write pass; the three preflight calls
+	// come first.
+	calls := 0
+	failing := lowerStatus()
+	failing.Transform = func(ctx context.Context, v contentmigration.Value) (contentmigration.Result, error) {
+		calls++
+		if calls == 5 {
+			return contentmigration.Result{}, errors.New("interrupted")
+		}
+		return lowerStatus().Transform(ctx, v)
+	}
+	if _, err := contentmigration.Run(ctx, f.d, f.ac, failing, contentmigration.Options{AuthorID: f.user, BatchSize: 1}); err == nil {
+		t.Fatal("expected the interrupted run to fail")
+	}
+	partial, err := f.d.GetContentMigration("0001_status_lowercase")
+	if err != nil {
+		t.Fatalf("GetContentMigration after interruption: %v", err)
+	}
+	if partial.Completed || partial.LastContentFieldID == "" || partial.RowsScanned != 1 {
+		t.Fatalf("partial record = %+v, want incomplete after one value", partial)
+	}
+
+	var seen []types.ContentFieldID
+	resumed := lowerStatus()
+	resumed.Transform = func(ctx context.Context, v contentmigration.Value) (contentmigration.Result, error) {
+		seen = append(seen, v.ContentFieldID)
+		return lowerStatus().Transform(ctx, v)
+	}
+	report, err := contentmigration.Run(ctx, f.d, f.ac, resumed, contentmigration.Options{AuthorID: f.user, BatchSize: 1})
+	if err != nil {
+		t.Fatalf("resumed Run: %v", err)
+	}
+	if report.ResumedAfter != partial.LastContentFieldID || report.Scanned != 2 {
+		t.Errorf("report = %+v, want 2 scanned after %s", report, partial.LastContentFieldID)
+	}
+	for _, id := range seen {
+		if id == partial.LastContentFieldID {
+			t.Errorf("resumed run revisited applied value %s", id)
+		}
+	}
+	for _, n := range nodes {
+		if got := f.values(t, n)["status"]; got != strings.ToLower(got) {
+			t.Errorf("node %s status = %q, want lowercase", n, got)
+		}
+	}
+
+	run, err := f.d.GetContentMigration("0001_status_lowercase")
+	if err != nil {
+		t.Fatalf("GetContentMigration: %v", err)
+	}
+	if !run.Completed || run.RowsScanned != 3 || run.RowsChanged != 3 {
+		t.Errorf("recorded run = %+v, want completed with 3 scanned and 3 changed", run)
+	}
+	_, err = contentmigration.Run(ctx, f.d, f.ac, lowerStatus(), contentmigration.Options{AuthorID: f.user})
+	if !errors.Is(err, contentmigration.ErrAlreadyApplied) {
+		t.Errorf("third Run error = %v, want ErrAlreadyApplied", err)
+	}
+}
+
+func TestRun_DryRunReportsInvalidValues(t *testing.T) {
+	t.Parallel()
+	f := newFixture(t)
+	ctx := context.Background()
+	a := f.node(t, map[string]string{"status": "Draft"})
+	b := f.node(t, map[string]string{"status": "Archived"})
+
+	report, err := contentmigration.Run(ctx, f.d, f.ac, lowerStatus(), contentmigration.Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry Run: %v", err)
+	}
+	if !report.DryRun || report.Changed != 2 || report.InvalidValues != 1 || len(report.Issues) != 1 {
+		t.Fatalf("report = %+v, want dry run with 2 changes and 1 issue", report)
+	}
+	if issue := report.Issues[0]; issue.ContentDataID != b || issue.Value != "archived" || len(issue.Messages) == 0 {
+		t.Errorf("issue = %+v", issue)
+	}
+	if got := f.values(t, a)["status"]; got != "Draft" {
+		t.Errorf("dry run wrote status %q", got)
+	}
+
+	_, err = contentmigration.Run(ctx, f.d, f.ac, lowerStatus(), contentmigration.Options{AuthorID: f.user})
+	if !errors.Is(err, contentmigration.ErrInvalidValues) {
+		t.Fatalf("Run error = %v, want ErrInvalidValues", err)
+	}
+	if got := f.values(t, a)["status"]; got != "Draft" {
+		t.Errorf("refused run wrote status %q", got)
+	}
+	if _, err := f.d.GetContentMigration("0001_status_lowercase"); err == nil {
+		t.Error("refused run was recorded")
+	}
+
+	if _, err := contentmigration.Run(ctx, f.d, f.ac, lowerStatus(), contentmigration.Options{AuthorID: f.user, AllowInvalid: true}); err != nil {
+		t.Fatalf("Run AllowInvalid: %v", err)
+	}
+	if got := f.values(t, b)["status"]; got != "archived" {
+		t.Errorf("node b status = %q, want archived", got)
+	}
+}
+
+func TestRun_SplitsFieldAndRemovesSource(t *testing.T) {
+	t.Parallel()
+	f := newFixture(t)
+	ctx := context.Background()
+	a := f.node(t, map[string]string{"name": "Ada Lovelace", "last": "Byron"})
+	b := f.node(t, map[string]string{"name": "Plato"})
+
+	split := contentmigration.Migration{
+		ID:       "0002_split_name",
+		Datatype: "person",
+		Field:    "name",
+		Transform: func(_ context.Context, v contentmigration.Value) (contentmigration.Result, error) {
+			first, last, _ := strings.Cut(v.Value, " ")
+			values := map[string]string{"first": first}
+			if last != "" {
+				values["person.last"] = last
+			}
+			return contentmigration.Result{Values: values, Remove: true}, nil
+		},
+	}
+	report, err := contentmigration.Run(ctx, f.d, f.ac, split, contentmigration.Options{AuthorID: f.user})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if report.Created != 2 || report.Updated != 1 || report.Removed != 2 {
+		t.Errorf("report = %+v, want 2 created, 1 updated, 2 removed", report)
+	}
+
+	gotA := f.values(t, a)
+	if gotA["first"] != "Ada" || gotA["last"] != "Lovelace" {
+		t.Errorf("node a = %v", gotA)
+	}
+	if _, ok := gotA["name"]; ok {
+		t.Error("node a still has name")
+	}
+	gotB := f.values(t, b)
+	if gotB["first"] != "Plato" || len(gotB) != 1 {
+		t.Errorf("node b = %v", gotB)
+	}
+}
+
+func TestRun_UnknownField(t *testing.T) {
+	t.Parallel()
+	f := newFixture(t)
+	m := lowerStatus()
+	m.Field = "missing"
+	if _, err := contentmigration.Run(context.Background(), f.d, f.ac, m, contentmigration.Options{DryRun: true}); err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+}
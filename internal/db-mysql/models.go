@@ -200,6 +200,26 @@ type BackupVerification struct {
 	DurationMs       types.NullableInt64      `json:"duration_ms"`
 }
 
+type BlueprintInstances struct {
+	ContentDataID types.ContentID       `json:"content_data_id"`
+	BlueprintID   string                `json:"blueprint_id"`
+	RouteID       types.NullableRouteID `json:"route_id"`
+	Variables     string                `json:"variables"`
+	AuthorID      types.UserID          `json:"author_id"`
+	DateCreated   types.Timestamp       `json:"date_created"`
+}
+
+type Blueprints struct {
+	BlueprintID  string          `json:"blueprint_id"`
+	Name         string          `json:"name"`
+	Label        string          `json:"label"`
+	Description  string          `json:"description"`
+	Definition   string          `json:"definition"`
+	AuthorID     types.UserID    `json:"author_id"`
+	DateCreated  types.Timestamp `json:"date_created"`
+	DateModified types.Timestamp `json:"date_modified"`
+}
+
 type ChangeEvent struct {
 	EventID       types.EventID        `json:"event_id"`
 	HlcTimestamp  types.HLC            `json:"hlc_timestamp"`
@@ -251,6 +271,29 @@ type ContentFields struct {
 	DateModified   types.Timestamp         `json:"date_modified"`
 }
 
+type ContentLeases struct {
+	LeaseID       string          `json:"lease_id"`
+	ContentDataID types.ContentID `json:"content_data_id"`
+	UserID        types.UserID    `json:"user_id"`
+	Username      string          `json:"username"`
+	Client        string          `json:"client"`
+	Mode          string          `json:"mode"`
+	AcquiredAt    int64           `json:"acquired_at"`
+	ExpiresAt     int64           `json:"expires_at"`
+}
+
+type ContentMigrations struct {
+	MigrationID        string          `json:"migration_id"`
+	Source             string          `json:"source"`
+	Description        string          `json:"description"`
+	RowsScanned        int64           `json:"rows_scanned"`
+	RowsChanged        int64           `json:"rows_changed"`
+	LastContentFieldID string          `json:"last_content_field_id"`
+	Completed          types.SafeBool  `json:"completed"`
+	AuthorID           types.UserID    `json:"author_id"`
+	DateCreated        types.Timestamp `json:"date_created"`
+}
+
 type ContentRelations struct {
 	ContentRelationID types.ContentRelationID `json:"content_relation_id"`
 	SourceContentID   types.ContentID         `json:"source_content_id"`
@@ -364,6 +407,13 @@ type MediaFolders struct {
 	DateModified types.Timestamp             `json:"date_modified"`
 }
 
+type PasswordHistory struct {
+	ID          types.PasswordHistoryID `json:"id"`
+	UserID      types.UserID            `json:"user_id"`
+	Hash        string                  `json:"hash"`
+	DateCreated types.Timestamp         `json:"date_created"`
+}
+
 type Permissions struct {
 	PermissionID    types.PermissionID `json:"permission_id"`
 	Label           string             `json:"label"`
@@ -398,6 +448,13 @@ type Plugins struct {
 	DateModified   types.Timestamp    `json:"date_modified"`
 }
 
+type RateLimitCounters struct {
+	LimiterKey  string `json:"limiter_key"`
+	WindowStart int64  `json:"window_start"`
+	Hits        int64  `json:"hits"`
+	ExpiresAt   int64  `json:"expires_at"`
+}
+
 type RolePermissions struct {
 	ID           types.RolePermissionID `json:"id"`
 	RoleID       types.RoleID           `json:"role_id"`
@@ -447,6 +504,67 @@ type Tokens struct {
 	Revoked   bool                 `json:"revoked"`
 }
 
+type TranslationMemory struct {
+	TmID          string          `json:"tm_id"`
+	SourceLocale  string          `json:"source_locale"`
+	TargetLocale  string          `json:"target_locale"`
+	SourceHash    string          `json:"source_hash"`
+	SourceText    string          `json:"source_text"`
+	TargetText    string          `json:"target_text"`
+	SourceLength  int64           `json:"source_length"`
+	ContentDataID types.ContentID `json:"content_data_id"`
+	FieldID       types.FieldID   `json:"field_id"`
+	UpdatedAt     int64           `json:"updated_at"`
+}
+
+type TranslationReviews struct {
+	ContentFieldID types.ContentFieldID `json:"content_field_id"`
+	ContentDataID  types.ContentID      `json:"content_data_id"`
+	Locale         string               `json:"locale"`
+	Origin         string               `json:"origin"`
+	Provider       string               `json:"provider"`
+	Score          int64                `json:"score"`
+	CreatedAt      int64                `json:"created_at"`
+}
+
+type TranslationSources struct {
+	ContentFieldID types.ContentFieldID `json:"content_field_id"`
+	ContentDataID  types.ContentID      `json:"content_data_id"`
+	FieldID        types.FieldID        `json:"field_id"`
+	Locale         string               `json:"locale"`
+	SourceHash     string               `json:"source_hash"`
+	RecordedAt     int64                `json:"recorded_at"`
+}
+
+type UserGroupMembers struct {
+	ID          types.UserGroupMemberID `json:"id"`
+	UserGroupID types.UserGroupID       `json:"user_group_id"`
+	UserID      types.UserID            `json:"user_id"`
+}
+
+type UserGroupRoles struct {
+	ID          types.UserGroupRoleID `json:"id"`
+	UserGroupID types.UserGroupID     `json:"user_group_id"`
+	RoleID      types.RoleID          `json:"role_id"`
+}
+
+type UserGroups struct {
+	UserGroupID  types.UserGroupID `json:"user_group_id"`
+	Label        string            `json:"label"`
+	Description  string            `json:"description"`
+	DateCreated  types.Timestamp   `json:"date_created"`
+	DateModified types.Timestamp   `json:"date_modified"`
+}
+
+type UserLockouts struct {
+	UserID         types.UserID    `json:"user_id"`
+	FailedAttempts int64           `json:"failed_attempts"`
+	LockoutCount   int64           `json:"lockout_count"`
+	LockedUntil    types.Timestamp `json:"locked_until"`
+	LastFailedAt   types.Timestamp `json:"last_failed_at"`
+	DateModified   types.Timestamp `json:"date_modified"`
+}
+
 type UserOauth struct {
 	UserOAuthID         types.UserOauthID    `json:"user_oauth_id"`
 	UserID              types.NullableUserID `json:"user_id"`
@@ -458,6 +576,12 @@ type UserOauth struct {
 	DateCreated         types.Timestamp      `json:"date_created"`
 }
 
+type UserRoles struct {
+	ID     types.UserRoleID `json:"id"`
+	UserID types.UserID     `json:"user_id"`
+	RoleID types.RoleID     `json:"role_id"`
+}
+
 type UserSshKeys struct {
 	SSHKeyID    string               `json:"ssh_key_id"`
 	UserID      types.NullableUserID `json:"user_id"`
@@ -447,6 +447,28 @@ func (q *Queries) CountBackupsByNode(ctx context.Context, arg CountBackupsByNode
 	return count, err
 }
 
+const countBlueprintInstances = `-- name: CountBlueprintInstances :one
+SELECT COUNT(*) FROM blueprint_instances
+`
+
+func (q *Queries) CountBlueprintInstances(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countBlueprintInstances)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countBlueprints = `-- name: CountBlueprints :one
+SELECT COUNT(*) FROM blueprints
+`
+
+func (q *Queries) CountBlueprints(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countBlueprints)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countChangeEvents = `-- name: CountChangeEvents :one
 SELECT COUNT(*) FROM change_events
 `
@@ -544,6 +566,28 @@ func (q *Queries) CountContentField(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countContentLeases = `-- name: CountContentLeases :one
+SELECT COUNT(*) FROM content_leases
+`
+
+func (q *Queries) CountContentLeases(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countContentLeases)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countContentMigrations = `-- name: CountContentMigrations :one
+SELECT COUNT(*) FROM content_migrations
+`
+
+func (q *Queries) CountContentMigrations(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countContentMigrations)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countContentRelation = `-- name: CountContentRelation :one
 SELECT COUNT(*) FROM content_relations
 `
@@ -707,6 +751,17 @@ func (q *Queries) CountMediaUnfiled(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countPasswordHistory = `-- name: CountPasswordHistory :one
+SELECT COUNT(*) FROM password_history
+`
+
+func (q *Queries) CountPasswordHistory(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPasswordHistory)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countPermission = `-- name: CountPermission :one
 SELECT COUNT(*)
 FROM permissions
@@ -741,6 +796,17 @@ func (q *Queries) CountPlugins(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countRateLimitCounters = `-- name: CountRateLimitCounters :one
+SELECT COUNT(*) FROM rate_limit_counters
+`
+
+func (q *Queries) CountRateLimitCounters(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countRateLimitCounters)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countRole = `-- name: CountRole :one
 SELECT COUNT(*)
 FROM roles
@@ -812,6 +878,55 @@ func (q *Queries) CountToken(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countTranslationMemory = `-- name: CountTranslationMemory :one
+SELECT COUNT(*) FROM translation_memory
+`
+
+func (q *Queries) CountTranslationMemory(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTranslationMemory)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTranslationMemoryByPair = `-- name: CountTranslationMemoryByPair :one
+SELECT COUNT(*) FROM translation_memory WHERE source_locale = ? AND target_locale = ?
+`
+
+type CountTranslationMemoryByPairParams struct {
+	SourceLocale string `json:"source_locale"`
+	TargetLocale string `json:"target_locale"`
+}
+
+func (q *Queries) CountTranslationMemoryByPair(ctx context.Context, arg CountTranslationMemoryByPairParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTranslationMemoryByPair, arg.SourceLocale, arg.TargetLocale)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTranslationReviews = `-- name: CountTranslationReviews :one
+SELECT COUNT(*) FROM translation_reviews
+`
+
+func (q *Queries) CountTranslationReviews(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTranslationReviews)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTranslationSources = `-- name: CountTranslationSources :one
+SELECT COUNT(*) FROM translation_sources
+`
+
+func (q *Queries) CountTranslationSources(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTranslationSources)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countUser = `-- name: CountUser :one
 SELECT COUNT(*)
 FROM users
@@ -824,6 +939,51 @@ func (q *Queries) CountUser(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countUserGroup = `-- name: CountUserGroup :one
+SELECT COUNT(*)
+FROM user_groups
+`
+
+func (q *Queries) CountUserGroup(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserGroup)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUserGroupMember = `-- name: CountUserGroupMember :one
+SELECT COUNT(*) FROM user_group_members
+`
+
+func (q *Queries) CountUserGroupMember(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserGroupMember)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUserGroupRole = `-- name: CountUserGroupRole :one
+SELECT COUNT(*) FROM user_group_roles
+`
+
+func (q *Queries) CountUserGroupRole(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserGroupRole)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUserLockouts = `-- name: CountUserLockouts :one
+SELECT COUNT(*) FROM user_lockouts
+`
+
+func (q *Queries) CountUserLockouts(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserLockouts)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countUserOauths = `-- name: CountUserOauths :one
 SELECT COUNT(*)
 FROM user_oauth
@@ -836,6 +996,17 @@ func (q *Queries) CountUserOauths(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countUserRole = `-- name: CountUserRole :one
+SELECT COUNT(*) FROM user_roles
+`
+
+func (q *Queries) CountUserRole(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserRole)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countUserSshKeys = `-- name: CountUserSshKeys :one
 SELECT COUNT(*) FROM user_ssh_keys
 `
@@ -1903,6 +2074,109 @@ func (q *Queries) CreateBackupTables(ctx context.Context) error {
 	return err
 }
 
+const createBlueprint = `-- name: CreateBlueprint :exec
+INSERT INTO blueprints (blueprint_id, name, label, description, definition, author_id, date_created, date_modified)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateBlueprintParams struct {
+	BlueprintID  string          `json:"blueprint_id"`
+	Name         string          `json:"name"`
+	Label        string          `json:"label"`
+	Description  string          `json:"description"`
+	Definition   string          `json:"definition"`
+	AuthorID     types.UserID    `json:"author_id"`
+	DateCreated  types.Timestamp `json:"date_created"`
+	DateModified types.Timestamp `json:"date_modified"`
+}
+
+func (q *Queries) CreateBlueprint(ctx context.Context, arg CreateBlueprintParams) error {
+	_, err := q.db.ExecContext(ctx, createBlueprint,
+		arg.BlueprintID,
+		arg.Name,
+		arg.Label,
+		arg.Description,
+		arg.Definition,
+		arg.AuthorID,
+		arg.DateCreated,
+		arg.DateModified,
+	)
+	return err
+}
+
+const createBlueprintInstance = `-- name: CreateBlueprintInstance :exec
+INSERT INTO blueprint_instances (content_data_id, blueprint_id, route_id, variables, author_id, date_created)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateBlueprintInstanceParams struct {
+	ContentDataID types.ContentID       `json:"content_data_id"`
+	BlueprintID   string                `json:"blueprint_id"`
+	RouteID       types.NullableRouteID `json:"route_id"`
+	Variables     string                `json:"variables"`
+	AuthorID      types.UserID          `json:"author_id"`
+	DateCreated   types.Timestamp       `json:"date_created"`
+}
+
+func (q *Queries) CreateBlueprintInstance(ctx context.Context, arg CreateBlueprintInstanceParams) error {
+	_, err := q.db.ExecContext(ctx, createBlueprintInstance,
+		arg.ContentDataID,
+		arg.BlueprintID,
+		arg.RouteID,
+		arg.Variables,
+		arg.AuthorID,
+		arg.DateCreated,
+	)
+	return err
+}
+
+const createBlueprintInstancesIndexBlueprint = `-- name: CreateBlueprintInstancesIndexBlueprint :exec
+CREATE INDEX idx_blueprint_instances_blueprint ON blueprint_instances(blueprint_id)
+`
+
+func (q *Queries) CreateBlueprintInstancesIndexBlueprint(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createBlueprintInstancesIndexBlueprint)
+	return err
+}
+
+const createBlueprintInstancesTable = `-- name: CreateBlueprintInstancesTable :exec
+CREATE TABLE IF NOT EXISTS blueprint_instances (
+    content_data_id VARCHAR(26) NOT NULL,
+    blueprint_id VARCHAR(26) NOT NULL,
+    route_id VARCHAR(26) NULL,
+    variables TEXT NOT NULL,
+    author_id VARCHAR(26) NOT NULL,
+    date_created TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (content_data_id)
+)
+`
+
+func (q *Queries) CreateBlueprintInstancesTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createBlueprintInstancesTable)
+	return err
+}
+
+const createBlueprintsTable = `-- name: CreateBlueprintsTable :exec
+CREATE TABLE IF NOT EXISTS blueprints (
+    blueprint_id VARCHAR(26) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    label VARCHAR(255) NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    definition MEDIUMTEXT NOT NULL,
+    author_id VARCHAR(26) NOT NULL,
+    date_created TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    date_modified TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (blueprint_id),
+    CONSTRAINT name
+        UNIQUE (name)
+)
+`
+
+func (q *Queries) CreateBlueprintsTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createBlueprintsTable)
+	return err
+}
+
 const createChangeEventsTable = `-- name: CreateChangeEventsTable :exec
 CREATE TABLE IF NOT EXISTS change_events (
     event_id CHAR(26) PRIMARY KEY,
@@ -2138,6 +2412,93 @@ func (q *Queries) CreateContentFieldTable(ctx context.Context) error {
 	return err
 }
 
+const createContentLease = `-- name: CreateContentLease :exec
+INSERT INTO content_leases (lease_id, content_data_id, user_id, username, client, mode, acquired_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateContentLeaseParams struct {
+	LeaseID       string          `json:"lease_id"`
+	ContentDataID types.ContentID `json:"content_data_id"`
+	UserID        types.UserID    `json:"user_id"`
+	Username      string          `json:"username"`
+	Client        string          `json:"client"`
+	Mode          string          `json:"mode"`
+	AcquiredAt    int64           `json:"acquired_at"`
+	ExpiresAt     int64           `json:"expires_at"`
+}
+
+func (q *Queries) CreateContentLease(ctx context.Context, arg CreateContentLeaseParams) error {
+	_, err := q.db.ExecContext(ctx, createContentLease,
+		arg.LeaseID,
+		arg.ContentDataID,
+		arg.UserID,
+		arg.Username,
+		arg.Client,
+		arg.Mode,
+		arg.AcquiredAt,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const createContentLeasesIndexContent = `-- name: CreateContentLeasesIndexContent :exec
+CREATE INDEX idx_content_leases_content ON content_leases(content_data_id)
+`
+
+func (q *Queries) CreateContentLeasesIndexContent(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createContentLeasesIndexContent)
+	return err
+}
+
+const createContentLeasesIndexEditing = `-- name: CreateContentLeasesIndexEditing :exec
+CREATE UNIQUE INDEX idx_content_leases_editing ON content_leases((CASE WHEN mode = 'editing' THEN content_data_id END))
+`
+
+func (q *Queries) CreateContentLeasesIndexEditing(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createContentLeasesIndexEditing)
+	return err
+}
+
+const createContentLeasesTable = `-- name: CreateContentLeasesTable :exec
+CREATE TABLE IF NOT EXISTS content_leases (
+    lease_id VARCHAR(26) NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    user_id VARCHAR(26) NOT NULL,
+    username VARCHAR(255) NOT NULL DEFAULT '',
+    client VARCHAR(32) NOT NULL DEFAULT '',
+    mode VARCHAR(16) NOT NULL,
+    acquired_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (lease_id)
+)
+`
+
+func (q *Queries) CreateContentLeasesTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createContentLeasesTable)
+	return err
+}
+
+const createContentMigrationsTable = `-- name: CreateContentMigrationsTable :exec
+CREATE TABLE IF NOT EXISTS content_migrations (
+    migration_id VARCHAR(255) NOT NULL,
+    source VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    rows_scanned BIGINT NOT NULL DEFAULT 0,
+    rows_changed BIGINT NOT NULL DEFAULT 0,
+    last_content_field_id VARCHAR(26) NOT NULL DEFAULT '',
+    completed TINYINT NOT NULL DEFAULT 0,
+    author_id VARCHAR(26) NOT NULL,
+    date_created TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (migration_id)
+)
+`
+
+func (q *Queries) CreateContentMigrationsTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createContentMigrationsTable)
+	return err
+}
+
 const createContentRelation = `-- name: CreateContentRelation :exec
 INSERT INTO content_relations (
     content_relation_id,
@@ -2824,6 +3185,52 @@ func (q *Queries) CreateMediaTable(ctx context.Context) error {
 	return err
 }
 
+const createPasswordHistory = `-- name: CreatePasswordHistory :exec
+INSERT INTO password_history (id, user_id, hash, date_created) VALUES (?, ?, ?, ?)
+`
+
+type CreatePasswordHistoryParams struct {
+	ID          types.PasswordHistoryID `json:"id"`
+	UserID      types.UserID            `json:"user_id"`
+	Hash        string                  `json:"hash"`
+	DateCreated types.Timestamp         `json:"date_created"`
+}
+
+func (q *Queries) CreatePasswordHistory(ctx context.Context, arg CreatePasswordHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, createPasswordHistory,
+		arg.ID,
+		arg.UserID,
+		arg.Hash,
+		arg.DateCreated,
+	)
+	return err
+}
+
+const createPasswordHistoryIndexUser = `-- name: CreatePasswordHistoryIndexUser :exec
+CREATE INDEX idx_password_history_user ON password_history(user_id)
+`
+
+func (q *Queries) CreatePasswordHistoryIndexUser(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createPasswordHistoryIndexUser)
+	return err
+}
+
+const createPasswordHistoryTable = `-- name: CreatePasswordHistoryTable :exec
+CREATE TABLE IF NOT EXISTS password_history (
+    id VARCHAR(26) NOT NULL,
+    user_id VARCHAR(26) NOT NULL,
+    hash TEXT NOT NULL,
+    date_created TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_password_history_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+)
+`
+
+func (q *Queries) CreatePasswordHistoryTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createPasswordHistoryTable)
+	return err
+}
+
 const createPermission = `-- name: CreatePermission :exec
 INSERT INTO permissions(
     permission_id,
@@ -2979,6 +3386,30 @@ func (q *Queries) CreatePluginsTable(ctx context.Context) error {
 	return err
 }
 
+const createRateLimitCountersIndexExpires = `-- name: CreateRateLimitCountersIndexExpires :exec
+CREATE INDEX idx_rate_limit_counters_expires ON rate_limit_counters(expires_at)
+`
+
+func (q *Queries) CreateRateLimitCountersIndexExpires(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createRateLimitCountersIndexExpires)
+	return err
+}
+
+const createRateLimitCountersTable = `-- name: CreateRateLimitCountersTable :exec
+CREATE TABLE IF NOT EXISTS rate_limit_counters (
+    limiter_key VARCHAR(255) NOT NULL,
+    window_start BIGINT NOT NULL,
+    hits BIGINT NOT NULL DEFAULT 0,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (limiter_key)
+)
+`
+
+func (q *Queries) CreateRateLimitCountersTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createRateLimitCountersTable)
+	return err
+}
+
 const createRole = `-- name: CreateRole :exec
 INSERT INTO roles (role_id, label, system_protected) VALUES (?,?,?)
 `
@@ -3278,19 +3709,208 @@ func (q *Queries) CreateTokenTable(ctx context.Context) error {
 	return err
 }
 
-const createUser = `-- name: CreateUser :exec
-INSERT INTO users (
-    user_id,
-    username,
-    name,
-    email,
-    hash,
-    role,
-    date_created,
-    date_modified
-) VALUES (
-    ?,
-    ?,
+const createTranslationMemory = `-- name: CreateTranslationMemory :exec
+INSERT INTO translation_memory (tm_id, source_locale, target_locale, source_hash, source_text, target_text, source_length, content_data_id, field_id, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateTranslationMemoryParams struct {
+	TmID          string          `json:"tm_id"`
+	SourceLocale  string          `json:"source_locale"`
+	TargetLocale  string          `json:"target_locale"`
+	SourceHash    string          `json:"source_hash"`
+	SourceText    string          `json:"source_text"`
+	TargetText    string          `json:"target_text"`
+	SourceLength  int64           `json:"source_length"`
+	ContentDataID types.ContentID `json:"content_data_id"`
+	FieldID       types.FieldID   `json:"field_id"`
+	UpdatedAt     int64           `json:"updated_at"`
+}
+
+func (q *Queries) CreateTranslationMemory(ctx context.Context, arg CreateTranslationMemoryParams) error {
+	_, err := q.db.ExecContext(ctx, createTranslationMemory,
+		arg.TmID,
+		arg.SourceLocale,
+		arg.TargetLocale,
+		arg.SourceHash,
+		arg.SourceText,
+		arg.TargetText,
+		arg.SourceLength,
+		arg.ContentDataID,
+		arg.FieldID,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const createTranslationMemoryIndexLength = `-- name: CreateTranslationMemoryIndexLength :exec
+CREATE INDEX idx_translation_memory_length ON translation_memory(source_locale, target_locale, source_length)
+`
+
+func (q *Queries) CreateTranslationMemoryIndexLength(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createTranslationMemoryIndexLength)
+	return err
+}
+
+const createTranslationMemoryIndexSource = `-- name: CreateTranslationMemoryIndexSource :exec
+CREATE UNIQUE INDEX idx_translation_memory_source ON translation_memory(source_locale, target_locale, source_hash)
+`
+
+func (q *Queries) CreateTranslationMemoryIndexSource(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createTranslationMemoryIndexSource)
+	return err
+}
+
+const createTranslationMemoryTable = `-- name: CreateTranslationMemoryTable :exec
+CREATE TABLE IF NOT EXISTS translation_memory (
+    tm_id VARCHAR(26) NOT NULL,
+    source_locale VARCHAR(35) NOT NULL,
+    target_locale VARCHAR(35) NOT NULL,
+    source_hash VARCHAR(64) NOT NULL,
+    source_text LONGTEXT NOT NULL,
+    target_text LONGTEXT NOT NULL,
+    source_length BIGINT NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    field_id VARCHAR(26) NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (tm_id)
+)
+`
+
+func (q *Queries) CreateTranslationMemoryTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createTranslationMemoryTable)
+	return err
+}
+
+const createTranslationReview = `-- name: CreateTranslationReview :exec
+INSERT INTO translation_reviews (content_field_id, content_data_id, locale, origin, provider, score, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateTranslationReviewParams struct {
+	ContentFieldID types.ContentFieldID `json:"content_field_id"`
+	ContentDataID  types.ContentID      `json:"content_data_id"`
+	Locale         string               `json:"locale"`
+	Origin         string               `json:"origin"`
+	Provider       string               `json:"provider"`
+	Score          int64                `json:"score"`
+	CreatedAt      int64                `json:"created_at"`
+}
+
+func (q *Queries) CreateTranslationReview(ctx context.Context, arg CreateTranslationReviewParams) error {
+	_, err := q.db.ExecContext(ctx, createTranslationReview,
+		arg.ContentFieldID,
+		arg.ContentDataID,
+		arg.Locale,
+		arg.Origin,
+		arg.Provider,
+		arg.Score,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createTranslationReviewsIndexLocale = `-- name: CreateTranslationReviewsIndexLocale :exec
+CREATE INDEX idx_translation_reviews_locale ON translation_reviews(locale)
+`
+
+func (q *Queries) CreateTranslationReviewsIndexLocale(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createTranslationReviewsIndexLocale)
+	return err
+}
+
+const createTranslationReviewsTable = `-- name: CreateTranslationReviewsTable :exec
+CREATE TABLE IF NOT EXISTS translation_reviews (
+    content_field_id VARCHAR(26) NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    origin VARCHAR(16) NOT NULL,
+    provider VARCHAR(255) NOT NULL DEFAULT '',
+    score BIGINT NOT NULL DEFAULT 0,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (content_field_id)
+)
+`
+
+func (q *Queries) CreateTranslationReviewsTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createTranslationReviewsTable)
+	return err
+}
+
+const createTranslationSource = `-- name: CreateTranslationSource :exec
+INSERT INTO translation_sources (content_field_id, content_data_id, field_id, locale, source_hash, recorded_at)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateTranslationSourceParams struct {
+	ContentFieldID types.ContentFieldID `json:"content_field_id"`
+	ContentDataID  types.ContentID      `json:"content_data_id"`
+	FieldID        types.FieldID        `json:"field_id"`
+	Locale         string               `json:"locale"`
+	SourceHash     string               `json:"source_hash"`
+	RecordedAt     int64                `json:"recorded_at"`
+}
+
+func (q *Queries) CreateTranslationSource(ctx context.Context, arg CreateTranslationSourceParams) error {
+	_, err := q.db.ExecContext(ctx, createTranslationSource,
+		arg.ContentFieldID,
+		arg.ContentDataID,
+		arg.FieldID,
+		arg.Locale,
+		arg.SourceHash,
+		arg.RecordedAt,
+	)
+	return err
+}
+
+const createTranslationSourcesIndexField = `-- name: CreateTranslationSourcesIndexField :exec
+CREATE INDEX idx_translation_sources_field ON translation_sources(content_data_id, field_id)
+`
+
+func (q *Queries) CreateTranslationSourcesIndexField(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createTranslationSourcesIndexField)
+	return err
+}
+
+const createTranslationSourcesIndexLocale = `-- name: CreateTranslationSourcesIndexLocale :exec
+CREATE INDEX idx_translation_sources_locale ON translation_sources(locale)
+`
+
+func (q *Queries) CreateTranslationSourcesIndexLocale(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createTranslationSourcesIndexLocale)
+	return err
+}
+
+const createTranslationSourcesTable = `-- name: CreateTranslationSourcesTable :exec
+CREATE TABLE IF NOT EXISTS translation_sources (
+    content_field_id VARCHAR(26) NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    field_id VARCHAR(26) NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    source_hash VARCHAR(64) NOT NULL,
+    recorded_at BIGINT NOT NULL,
+    PRIMARY KEY (content_field_id)
+)
+`
+
+func (q *Queries) CreateTranslationSourcesTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createTranslationSourcesTable)
+	return err
+}
+
+const createUser = `-- name: CreateUser :exec
+INSERT INTO users (
+    user_id,
+    username,
+    name,
+    email,
+    hash,
+    role,
+    date_created,
+    date_modified
+) VALUES (
+    ?,
+    ?,
     ?,
     ?,
     ?,
@@ -3325,6 +3945,176 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
 	return err
 }
 
+const createUserGroup = `-- name: CreateUserGroup :exec
+INSERT INTO user_groups (
+    user_group_id,
+    label,
+    description,
+    date_created,
+    date_modified
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
+`
+
+type CreateUserGroupParams struct {
+	UserGroupID  types.UserGroupID `json:"user_group_id"`
+	Label        string            `json:"label"`
+	Description  string            `json:"description"`
+	DateCreated  types.Timestamp   `json:"date_created"`
+	DateModified types.Timestamp   `json:"date_modified"`
+}
+
+func (q *Queries) CreateUserGroup(ctx context.Context, arg CreateUserGroupParams) error {
+	_, err := q.db.ExecContext(ctx, createUserGroup,
+		arg.UserGroupID,
+		arg.Label,
+		arg.Description,
+		arg.DateCreated,
+		arg.DateModified,
+	)
+	return err
+}
+
+const createUserGroupMember = `-- name: CreateUserGroupMember :exec
+INSERT INTO user_group_members (id, user_group_id, user_id) VALUES (?, ?, ?)
+`
+
+type CreateUserGroupMemberParams struct {
+	ID          types.UserGroupMemberID `json:"id"`
+	UserGroupID types.UserGroupID       `json:"user_group_id"`
+	UserID      types.UserID            `json:"user_id"`
+}
+
+func (q *Queries) CreateUserGroupMember(ctx context.Context, arg CreateUserGroupMemberParams) error {
+	_, err := q.db.ExecContext(ctx, createUserGroupMember, arg.ID, arg.UserGroupID, arg.UserID)
+	return err
+}
+
+const createUserGroupMembersIndexGroup = `-- name: CreateUserGroupMembersIndexGroup :exec
+CREATE INDEX idx_user_group_members_group ON user_group_members(user_group_id)
+`
+
+func (q *Queries) CreateUserGroupMembersIndexGroup(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createUserGroupMembersIndexGroup)
+	return err
+}
+
+const createUserGroupMembersIndexUser = `-- name: CreateUserGroupMembersIndexUser :exec
+CREATE INDEX idx_user_group_members_user ON user_group_members(user_id)
+`
+
+func (q *Queries) CreateUserGroupMembersIndexUser(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createUserGroupMembersIndexUser)
+	return err
+}
+
+const createUserGroupMembersTable = `-- name: CreateUserGroupMembersTable :exec
+CREATE TABLE IF NOT EXISTS user_group_members (
+    id VARCHAR(26) NOT NULL,
+    user_group_id VARCHAR(26) NOT NULL,
+    user_id VARCHAR(26) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_ugm_group FOREIGN KEY (user_group_id) REFERENCES user_groups(user_group_id) ON DELETE CASCADE,
+    CONSTRAINT fk_ugm_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_group_member UNIQUE (user_group_id, user_id)
+)
+`
+
+func (q *Queries) CreateUserGroupMembersTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createUserGroupMembersTable)
+	return err
+}
+
+const createUserGroupRole = `-- name: CreateUserGroupRole :exec
+INSERT INTO user_group_roles (id, user_group_id, role_id) VALUES (?, ?, ?)
+`
+
+type CreateUserGroupRoleParams struct {
+	ID          types.UserGroupRoleID `json:"id"`
+	UserGroupID types.UserGroupID     `json:"user_group_id"`
+	RoleID      types.RoleID          `json:"role_id"`
+}
+
+func (q *Queries) CreateUserGroupRole(ctx context.Context, arg CreateUserGroupRoleParams) error {
+	_, err := q.db.ExecContext(ctx, createUserGroupRole, arg.ID, arg.UserGroupID, arg.RoleID)
+	return err
+}
+
+const createUserGroupRolesIndexGroup = `-- name: CreateUserGroupRolesIndexGroup :exec
+CREATE INDEX idx_user_group_roles_group ON user_group_roles(user_group_id)
+`
+
+func (q *Queries) CreateUserGroupRolesIndexGroup(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createUserGroupRolesIndexGroup)
+	return err
+}
+
+const createUserGroupRolesIndexRole = `-- name: CreateUserGroupRolesIndexRole :exec
+CREATE INDEX idx_user_group_roles_role ON user_group_roles(role_id)
+`
+
+func (q *Queries) CreateUserGroupRolesIndexRole(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createUserGroupRolesIndexRole)
+	return err
+}
+
+const createUserGroupRolesTable = `-- name: CreateUserGroupRolesTable :exec
+CREATE TABLE IF NOT EXISTS user_group_roles (
+    id VARCHAR(26) NOT NULL,
+    user_group_id VARCHAR(26) NOT NULL,
+    role_id VARCHAR(26) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_ugr_group FOREIGN KEY (user_group_id) REFERENCES user_groups(user_group_id) ON DELETE CASCADE,
+    CONSTRAINT fk_ugr_role FOREIGN KEY (role_id) REFERENCES roles(role_id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_group_role UNIQUE (user_group_id, role_id)
+)
+`
+
+func (q *Queries) CreateUserGroupRolesTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createUserGroupRolesTable)
+	return err
+}
+
+const createUserGroupTable = `-- name: CreateUserGroupTable :exec
+CREATE TABLE IF NOT EXISTS user_groups (
+    user_group_id VARCHAR(26) PRIMARY KEY NOT NULL,
+    label         VARCHAR(255) NOT NULL,
+    description   TEXT NOT NULL,
+    date_created  TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    date_modified TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT uq_user_group_label
+        UNIQUE (label)
+)
+`
+
+func (q *Queries) CreateUserGroupTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createUserGroupTable)
+	return err
+}
+
+const createUserLockoutsTable = `-- name: CreateUserLockoutsTable :exec
+CREATE TABLE IF NOT EXISTS user_lockouts (
+    user_id VARCHAR(26) NOT NULL,
+    failed_attempts BIGINT NOT NULL DEFAULT 0,
+    lockout_count BIGINT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NULL,
+    last_failed_at TIMESTAMP NULL,
+    date_modified TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id),
+    CONSTRAINT fk_user_lockouts_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+)
+`
+
+func (q *Queries) CreateUserLockoutsTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createUserLockoutsTable)
+	return err
+}
+
 const createUserOauth = `-- name: CreateUserOauth :exec
 INSERT INTO user_oauth (
     user_oauth_id,
@@ -3393,6 +4183,56 @@ func (q *Queries) CreateUserOauthTable(ctx context.Context) error {
 	return err
 }
 
+const createUserRole = `-- name: CreateUserRole :exec
+INSERT INTO user_roles (id, user_id, role_id) VALUES (?, ?, ?)
+`
+
+type CreateUserRoleParams struct {
+	ID     types.UserRoleID `json:"id"`
+	UserID types.UserID     `json:"user_id"`
+	RoleID types.RoleID     `json:"role_id"`
+}
+
+func (q *Queries) CreateUserRole(ctx context.Context, arg CreateUserRoleParams) error {
+	_, err := q.db.ExecContext(ctx, createUserRole, arg.ID, arg.UserID, arg.RoleID)
+	return err
+}
+
+const createUserRolesIndexRole = `-- name: CreateUserRolesIndexRole :exec
+CREATE INDEX idx_user_roles_role ON user_roles(role_id)
+`
+
+func (q *Queries) CreateUserRolesIndexRole(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createUserRolesIndexRole)
+	return err
+}
+
+const createUserRolesIndexUser = `-- name: CreateUserRolesIndexUser :exec
+CREATE INDEX idx_user_roles_user ON user_roles(user_id)
+`
+
+func (q *Queries) CreateUserRolesIndexUser(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createUserRolesIndexUser)
+	return err
+}
+
+const createUserRolesTable = `-- name: CreateUserRolesTable :exec
+CREATE TABLE IF NOT EXISTS user_roles (
+    id VARCHAR(26) NOT NULL,
+    user_id VARCHAR(26) NOT NULL,
+    role_id VARCHAR(26) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_ur_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT fk_ur_role FOREIGN KEY (role_id) REFERENCES roles(role_id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_role UNIQUE (user_id, role_id)
+)
+`
+
+func (q *Queries) CreateUserRolesTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createUserRolesTable)
+	return err
+}
+
 const createUserSshKey = `-- name: CreateUserSshKey :exec
 INSERT INTO user_ssh_keys (
     ssh_key_id,
@@ -3911,6 +4751,32 @@ func (q *Queries) DeleteBackupSet(ctx context.Context, arg DeleteBackupSetParams
 	return err
 }
 
+const deleteBlueprint = `-- name: DeleteBlueprint :exec
+DELETE FROM blueprints WHERE blueprint_id = ?
+`
+
+type DeleteBlueprintParams struct {
+	BlueprintID string `json:"blueprint_id"`
+}
+
+func (q *Queries) DeleteBlueprint(ctx context.Context, arg DeleteBlueprintParams) error {
+	_, err := q.db.ExecContext(ctx, deleteBlueprint, arg.BlueprintID)
+	return err
+}
+
+const deleteBlueprintInstancesByBlueprint = `-- name: DeleteBlueprintInstancesByBlueprint :exec
+DELETE FROM blueprint_instances WHERE blueprint_id = ?
+`
+
+type DeleteBlueprintInstancesByBlueprintParams struct {
+	BlueprintID string `json:"blueprint_id"`
+}
+
+func (q *Queries) DeleteBlueprintInstancesByBlueprint(ctx context.Context, arg DeleteBlueprintInstancesByBlueprintParams) error {
+	_, err := q.db.ExecContext(ctx, deleteBlueprintInstancesByBlueprint, arg.BlueprintID)
+	return err
+}
+
 const deleteChangeEvent = `-- name: DeleteChangeEvent :exec
 DELETE FROM change_events
 WHERE event_id = ?
@@ -3969,6 +4835,19 @@ func (q *Queries) DeleteContentField(ctx context.Context, arg DeleteContentField
 	return err
 }
 
+const deleteContentLease = `-- name: DeleteContentLease :exec
+DELETE FROM content_leases WHERE lease_id = ?
+`
+
+type DeleteContentLeaseParams struct {
+	LeaseID string `json:"lease_id"`
+}
+
+func (q *Queries) DeleteContentLease(ctx context.Context, arg DeleteContentLeaseParams) error {
+	_, err := q.db.ExecContext(ctx, deleteContentLease, arg.LeaseID)
+	return err
+}
+
 const deleteContentRelation = `-- name: DeleteContentRelation :exec
 DELETE FROM content_relations
 WHERE content_relation_id = ?
@@ -4011,6 +4890,32 @@ func (q *Queries) DeleteDatatype(ctx context.Context, arg DeleteDatatypeParams)
 	return err
 }
 
+const deleteExpiredContentLeases = `-- name: DeleteExpiredContentLeases :exec
+DELETE FROM content_leases WHERE expires_at < ?
+`
+
+type DeleteExpiredContentLeasesParams struct {
+	ExpiresAt int64 `json:"expires_at"`
+}
+
+func (q *Queries) DeleteExpiredContentLeases(ctx context.Context, arg DeleteExpiredContentLeasesParams) error {
+	_, err := q.db.ExecContext(ctx, deleteExpiredContentLeases, arg.ExpiresAt)
+	return err
+}
+
+const deleteExpiredRateLimitCounters = `-- name: DeleteExpiredRateLimitCounters :exec
+DELETE FROM rate_limit_counters WHERE expires_at < ?
+`
+
+type DeleteExpiredRateLimitCountersParams struct {
+	ExpiresAt int64 `json:"expires_at"`
+}
+
+func (q *Queries) DeleteExpiredRateLimitCounters(ctx context.Context, arg DeleteExpiredRateLimitCountersParams) error {
+	_, err := q.db.ExecContext(ctx, deleteExpiredRateLimitCounters, arg.ExpiresAt)
+	return err
+}
+
 const deleteField = `-- name: DeleteField :exec
 DELETE FROM fields
 WHERE field_id = ?
@@ -4122,16 +5027,29 @@ func (q *Queries) DeleteOldBackups(ctx context.Context, arg DeleteOldBackupsPara
 	return err
 }
 
-const deletePermission = `-- name: DeletePermission :exec
-DELETE FROM permissions
-WHERE permission_id = ?
+const deletePasswordHistory = `-- name: DeletePasswordHistory :exec
+DELETE FROM password_history WHERE id = ?
 `
 
-type DeletePermissionParams struct {
-	PermissionID types.PermissionID `json:"permission_id"`
+type DeletePasswordHistoryParams struct {
+	ID types.PasswordHistoryID `json:"id"`
 }
 
-func (q *Queries) DeletePermission(ctx context.Context, arg DeletePermissionParams) error {
+func (q *Queries) DeletePasswordHistory(ctx context.Context, arg DeletePasswordHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, deletePasswordHistory, arg.ID)
+	return err
+}
+
+const deletePermission = `-- name: DeletePermission :exec
+DELETE FROM permissions
+WHERE permission_id = ?
+`
+
+type DeletePermissionParams struct {
+	PermissionID types.PermissionID `json:"permission_id"`
+}
+
+func (q *Queries) DeletePermission(ctx context.Context, arg DeletePermissionParams) error {
 	_, err := q.db.ExecContext(ctx, deletePermission, arg.PermissionID)
 	return err
 }
@@ -4273,6 +5191,45 @@ func (q *Queries) DeleteToken(ctx context.Context, arg DeleteTokenParams) error
 	return err
 }
 
+const deleteTranslationMemory = `-- name: DeleteTranslationMemory :exec
+DELETE FROM translation_memory WHERE tm_id = ?
+`
+
+type DeleteTranslationMemoryParams struct {
+	TmID string `json:"tm_id"`
+}
+
+func (q *Queries) DeleteTranslationMemory(ctx context.Context, arg DeleteTranslationMemoryParams) error {
+	_, err := q.db.ExecContext(ctx, deleteTranslationMemory, arg.TmID)
+	return err
+}
+
+const deleteTranslationReview = `-- name: DeleteTranslationReview :exec
+DELETE FROM translation_reviews WHERE content_field_id = ?
+`
+
+type DeleteTranslationReviewParams struct {
+	ContentFieldID types.ContentFieldID `json:"content_field_id"`
+}
+
+func (q *Queries) DeleteTranslationReview(ctx context.Context, arg DeleteTranslationReviewParams) error {
+	_, err := q.db.ExecContext(ctx, deleteTranslationReview, arg.ContentFieldID)
+	return err
+}
+
+const deleteTranslationSource = `-- name: DeleteTranslationSource :exec
+DELETE FROM translation_sources WHERE content_field_id = ?
+`
+
+type DeleteTranslationSourceParams struct {
+	ContentFieldID types.ContentFieldID `json:"content_field_id"`
+}
+
+func (q *Queries) DeleteTranslationSource(ctx context.Context, arg DeleteTranslationSourceParams) error {
+	_, err := q.db.ExecContext(ctx, deleteTranslationSource, arg.ContentFieldID)
+	return err
+}
+
 const deleteUser = `-- name: DeleteUser :exec
 DELETE FROM users
 WHERE user_id = ?
@@ -4287,6 +5244,85 @@ func (q *Queries) DeleteUser(ctx context.Context, arg DeleteUserParams) error {
 	return err
 }
 
+const deleteUserGroup = `-- name: DeleteUserGroup :exec
+DELETE FROM user_groups
+WHERE user_group_id = ?
+`
+
+type DeleteUserGroupParams struct {
+	UserGroupID types.UserGroupID `json:"user_group_id"`
+}
+
+func (q *Queries) DeleteUserGroup(ctx context.Context, arg DeleteUserGroupParams) error {
+	_, err := q.db.ExecContext(ctx, deleteUserGroup, arg.UserGroupID)
+	return err
+}
+
+const deleteUserGroupMember = `-- name: DeleteUserGroupMember :exec
+DELETE FROM user_group_members WHERE id = ?
+`
+
+type DeleteUserGroupMemberParams struct {
+	ID types.UserGroupMemberID `json:"id"`
+}
+
+func (q *Queries) DeleteUserGroupMember(ctx context.Context, arg DeleteUserGroupMemberParams) error {
+	_, err := q.db.ExecContext(ctx, deleteUserGroupMember, arg.ID)
+	return err
+}
+
+const deleteUserGroupMemberByUserGroupID = `-- name: DeleteUserGroupMemberByUserGroupID :exec
+DELETE FROM user_group_members WHERE user_group_id = ?
+`
+
+type DeleteUserGroupMemberByUserGroupIDParams struct {
+	UserGroupID types.UserGroupID `json:"user_group_id"`
+}
+
+func (q *Queries) DeleteUserGroupMemberByUserGroupID(ctx context.Context, arg DeleteUserGroupMemberByUserGroupIDParams) error {
+	_, err := q.db.ExecContext(ctx, deleteUserGroupMemberByUserGroupID, arg.UserGroupID)
+	return err
+}
+
+const deleteUserGroupRole = `-- name: DeleteUserGroupRole :exec
+DELETE FROM user_group_roles WHERE id = ?
+`
+
+type DeleteUserGroupRoleParams struct {
+	ID types.UserGroupRoleID `json:"id"`
+}
+
+func (q *Queries) DeleteUserGroupRole(ctx context.Context, arg DeleteUserGroupRoleParams) error {
+	_, err := q.db.ExecContext(ctx, deleteUserGroupRole, arg.ID)
+	return err
+}
+
+const deleteUserGroupRoleByUserGroupID = `-- name: DeleteUserGroupRoleByUserGroupID :exec
+DELETE FROM user_group_roles WHERE user_group_id = ?
+`
+
+type DeleteUserGroupRoleByUserGroupIDParams struct {
+	UserGroupID types.UserGroupID `json:"user_group_id"`
+}
+
+func (q *Queries) DeleteUserGroupRoleByUserGroupID(ctx context.Context, arg DeleteUserGroupRoleByUserGroupIDParams) error {
+	_, err := q.db.ExecContext(ctx, deleteUserGroupRoleByUserGroupID, arg.UserGroupID)
+	return err
+}
+
+const deleteUserLockout = `-- name: DeleteUserLockout :exec
+DELETE FROM user_lockouts WHERE user_id = ?
+`
+
+type DeleteUserLockoutParams struct {
+	UserID types.UserID `json:"user_id"`
+}
+
+func (q *Queries) DeleteUserLockout(ctx context.Context, arg DeleteUserLockoutParams) error {
+	_, err := q.db.ExecContext(ctx, deleteUserLockout, arg.UserID)
+	return err
+}
+
 const deleteUserOauth = `-- name: DeleteUserOauth :exec
 DELETE FROM user_oauth
 WHERE user_oauth_id = ?
@@ -4301,6 +5337,32 @@ func (q *Queries) DeleteUserOauth(ctx context.Context, arg DeleteUserOauthParams
 	return err
 }
 
+const deleteUserRole = `-- name: DeleteUserRole :exec
+DELETE FROM user_roles WHERE id = ?
+`
+
+type DeleteUserRoleParams struct {
+	ID types.UserRoleID `json:"id"`
+}
+
+func (q *Queries) DeleteUserRole(ctx context.Context, arg DeleteUserRoleParams) error {
+	_, err := q.db.ExecContext(ctx, deleteUserRole, arg.ID)
+	return err
+}
+
+const deleteUserRoleByUserID = `-- name: DeleteUserRoleByUserID :exec
+DELETE FROM user_roles WHERE user_id = ?
+`
+
+type DeleteUserRoleByUserIDParams struct {
+	UserID types.UserID `json:"user_id"`
+}
+
+func (q *Queries) DeleteUserRoleByUserID(ctx context.Context, arg DeleteUserRoleByUserIDParams) error {
+	_, err := q.db.ExecContext(ctx, deleteUserRoleByUserID, arg.UserID)
+	return err
+}
+
 const deleteUserSshKey = `-- name: DeleteUserSshKey :exec
 DELETE FROM user_ssh_keys
 WHERE ssh_key_id = ?
@@ -4524,6 +5586,24 @@ func (q *Queries) DropBackupsTable(ctx context.Context) error {
 	return err
 }
 
+const dropBlueprintInstancesTable = `-- name: DropBlueprintInstancesTable :exec
+DROP TABLE IF EXISTS blueprint_instances
+`
+
+func (q *Queries) DropBlueprintInstancesTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, dropBlueprintInstancesTable)
+	return err
+}
+
+const dropBlueprintsTable = `-- name: DropBlueprintsTable :exec
+DROP TABLE IF EXISTS blueprints
+`
+
+func (q *Queries) DropBlueprintsTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, dropBlueprintsTable)
+	return err
+}
+
 const dropChangeEventsTable = `-- name: DropChangeEventsTable :exec
 DROP TABLE IF EXISTS change_events
 `
@@ -4551,6 +5631,24 @@ func (q *Queries) DropContentFieldTable(ctx context.Context) error {
 	return err
 }
 
+const dropContentLeasesTable = `-- name: DropContentLeasesTable :exec
+DROP TABLE IF EXISTS content_leases
+`
+
+func (q *Queries) DropContentLeasesTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, dropContentLeasesTable)
+	return err
+}
+
+const dropContentMigrationsTable = `-- name: DropContentMigrationsTable :exec
+DROP TABLE IF EXISTS content_migrations
+`
+
+func (q *Queries) DropContentMigrationsTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, dropContentMigrationsTable)
+	return err
+}
+
 const dropContentRelationTable = `-- name: DropContentRelationTable :exec
 DROP TABLE IF EXISTS content_relations
 `
@@ -4641,6 +5739,15 @@ func (q *Queries) DropMediaTable(ctx context.Context) error {
 	return err
 }
 
+const dropPasswordHistoryTable = `-- name: DropPasswordHistoryTable :exec
+DROP TABLE IF EXISTS password_history
+`
+
+func (q *Queries) DropPasswordHistoryTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, dropPasswordHistoryTable)
+	return err
+}
+
 const dropPermissionTable = `-- name: DropPermissionTable :exec
 DROP TABLE permissions
 `
@@ -4668,6 +5775,15 @@ func (q *Queries) DropPluginsTable(ctx context.Context) error {
 	return err
 }
 
+const dropRateLimitCountersTable = `-- name: DropRateLimitCountersTable :exec
+DROP TABLE IF EXISTS rate_limit_counters
+`
+
+func (q *Queries) DropRateLimitCountersTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, dropRateLimitCountersTable)
+	return err
+}
+
 const dropRolePermissionsTable = `-- name: DropRolePermissionsTable :exec
 DROP TABLE IF EXISTS role_permissions
 `
@@ -4722,6 +5838,69 @@ func (q *Queries) DropTokenTable(ctx context.Context) error {
 	return err
 }
 
+const dropTranslationMemoryTable = `-- name: DropTranslationMemoryTable :exec
+DROP TABLE IF EXISTS translation_memory
+`
+
+func (q *Queries) DropTranslationMemoryTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, dropTranslationMemoryTable)
+	return err
+}
+
+const dropTranslationReviewsTable = `-- name: DropTranslationReviewsTable :exec
+DROP TABLE IF EXISTS translation_reviews
+`
+
+func (q *Queries) DropTranslationReviewsTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, dropTranslationReviewsTable)
+	return err
+}
+
+const dropTranslationSourcesTable = `-- name: DropTranslationSourcesTable :exec
+DROP TABLE IF EXISTS translation_sources
+`
+
+func (q *Queries) DropTranslationSourcesTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, dropTranslationSourcesTable)
+	return err
+}
+
+const dropUserGroupMembersTable = `-- name: DropUserGroupMembersTable :exec
+DROP TABLE IF EXISTS user_group_members
+`
+
+func (q *Queries) DropUserGroupMembersTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, dropUserGroupMembersTable)
+	return err
+}
+
+const dropUserGroupRolesTable = `-- name: DropUserGroupRolesTable :exec
+DROP TABLE IF EXISTS user_group_roles
+`
+
+func (q *Queries) DropUserGroupRolesTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, dropUserGroupRolesTable)
+	return err
+}
+
+const dropUserGroupTable = `-- name: DropUserGroupTable :exec
+DROP TABLE IF EXISTS user_groups
+`
+
+func (q *Queries) DropUserGroupTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, dropUserGroupTable)
+	return err
+}
+
+const dropUserLockoutsTable = `-- name: DropUserLockoutsTable :exec
+DROP TABLE IF EXISTS user_lockouts
+`
+
+func (q *Queries) DropUserLockoutsTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, dropUserLockoutsTable)
+	return err
+}
+
 const dropUserOauthTable = `-- name: DropUserOauthTable :exec
 DROP TABLE user_oauth
 `
@@ -4731,6 +5910,15 @@ func (q *Queries) DropUserOauthTable(ctx context.Context) error {
 	return err
 }
 
+const dropUserRolesTable = `-- name: DropUserRolesTable :exec
+DROP TABLE IF EXISTS user_roles
+`
+
+func (q *Queries) DropUserRolesTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, dropUserRolesTable)
+	return err
+}
+
 const dropUserSshKeyTable = `-- name: DropUserSshKeyTable :exec
 DROP TABLE user_ssh_keys
 `
@@ -5617,6 +6805,76 @@ func (q *Queries) GetBackupsByStatus(ctx context.Context, arg GetBackupsByStatus
 	return items, nil
 }
 
+const getBlueprint = `-- name: GetBlueprint :one
+SELECT blueprint_id, name, label, description, definition, author_id, date_created, date_modified FROM blueprints WHERE blueprint_id = ? LIMIT 1
+`
+
+type GetBlueprintParams struct {
+	BlueprintID string `json:"blueprint_id"`
+}
+
+func (q *Queries) GetBlueprint(ctx context.Context, arg GetBlueprintParams) (Blueprints, error) {
+	row := q.db.QueryRowContext(ctx, getBlueprint, arg.BlueprintID)
+	var i Blueprints
+	err := row.Scan(
+		&i.BlueprintID,
+		&i.Name,
+		&i.Label,
+		&i.Description,
+		&i.Definition,
+		&i.AuthorID,
+		&i.DateCreated,
+		&i.DateModified,
+	)
+	return i, err
+}
+
+const getBlueprintByName = `-- name: GetBlueprintByName :one
+SELECT blueprint_id, name, label, description, definition, author_id, date_created, date_modified FROM blueprints WHERE name = ? LIMIT 1
+`
+
+type GetBlueprintByNameParams struct {
+	Name string `json:"name"`
+}
+
+func (q *Queries) GetBlueprintByName(ctx context.Context, arg GetBlueprintByNameParams) (Blueprints, error) {
+	row := q.db.QueryRowContext(ctx, getBlueprintByName, arg.Name)
+	var i Blueprints
+	err := row.Scan(
+		&i.BlueprintID,
+		&i.Name,
+		&i.Label,
+		&i.Description,
+		&i.Definition,
+		&i.AuthorID,
+		&i.DateCreated,
+		&i.DateModified,
+	)
+	return i, err
+}
+
+const getBlueprintInstance = `-- name: GetBlueprintInstance :one
+SELECT content_data_id, blueprint_id, route_id, variables, author_id, date_created FROM blueprint_instances WHERE content_data_id = ? LIMIT 1
+`
+
+type GetBlueprintInstanceParams struct {
+	ContentDataID types.ContentID `json:"content_data_id"`
+}
+
+func (q *Queries) GetBlueprintInstance(ctx context.Context, arg GetBlueprintInstanceParams) (BlueprintInstances, error) {
+	row := q.db.QueryRowContext(ctx, getBlueprintInstance, arg.ContentDataID)
+	var i BlueprintInstances
+	err := row.Scan(
+		&i.ContentDataID,
+		&i.BlueprintID,
+		&i.RouteID,
+		&i.Variables,
+		&i.AuthorID,
+		&i.DateCreated,
+	)
+	return i, err
+}
+
 const getChangeEvent = `-- name: GetChangeEvent :one
 SELECT event_id, hlc_timestamp, wall_timestamp, node_id, table_name, record_id, operation, action, user_id, old_values, new_values, metadata, request_id, ip, synced_at, consumed_at FROM change_events
 WHERE event_id = ? LIMIT 1
@@ -5916,6 +7174,55 @@ func (q *Queries) GetContentFieldsByRoute(ctx context.Context, arg GetContentFie
 	return items, nil
 }
 
+const getContentLease = `-- name: GetContentLease :one
+SELECT lease_id, content_data_id, user_id, username, client, mode, acquired_at, expires_at FROM content_leases WHERE lease_id = ? LIMIT 1
+`
+
+type GetContentLeaseParams struct {
+	LeaseID string `json:"lease_id"`
+}
+
+func (q *Queries) GetContentLease(ctx context.Context, arg GetContentLeaseParams) (ContentLeases, error) {
+	row := q.db.QueryRowContext(ctx, getContentLease, arg.LeaseID)
+	var i ContentLeases
+	err := row.Scan(
+		&i.LeaseID,
+		&i.ContentDataID,
+		&i.UserID,
+		&i.Username,
+		&i.Client,
+		&i.Mode,
+		&i.AcquiredAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getContentMigration = `-- name: GetContentMigration :one
+SELECT migration_id, source, description, rows_scanned, rows_changed, last_content_field_id, completed, author_id, date_created FROM content_migrations WHERE migration_id = ? LIMIT 1
+`
+
+type GetContentMigrationParams struct {
+	MigrationID string `json:"migration_id"`
+}
+
+func (q *Queries) GetContentMigration(ctx context.Context, arg GetContentMigrationParams) (ContentMigrations, error) {
+	row := q.db.QueryRowContext(ctx, getContentMigration, arg.MigrationID)
+	var i ContentMigrations
+	err := row.Scan(
+		&i.MigrationID,
+		&i.Source,
+		&i.Description,
+		&i.RowsScanned,
+		&i.RowsChanged,
+		&i.LastContentFieldID,
+		&i.Completed,
+		&i.AuthorID,
+		&i.DateCreated,
+	)
+	return i, err
+}
+
 const getContentRelation = `-- name: GetContentRelation :one
 SELECT content_relation_id, source_content_id, target_content_id, field_id, sort_order, date_created FROM content_relations
 WHERE content_relation_id = ? LIMIT 1
@@ -7054,6 +8361,26 @@ func (q *Queries) GetPublishedSnapshot(ctx context.Context, arg GetPublishedSnap
 	return i, err
 }
 
+const getRateLimitCounter = `-- name: GetRateLimitCounter :one
+SELECT limiter_key, window_start, hits, expires_at FROM rate_limit_counters WHERE limiter_key = ? LIMIT 1
+`
+
+type GetRateLimitCounterParams struct {
+	LimiterKey string `json:"limiter_key"`
+}
+
+func (q *Queries) GetRateLimitCounter(ctx context.Context, arg GetRateLimitCounterParams) (RateLimitCounters, error) {
+	row := q.db.QueryRowContext(ctx, getRateLimitCounter, arg.LimiterKey)
+	var i RateLimitCounters
+	err := row.Scan(
+		&i.LimiterKey,
+		&i.WindowStart,
+		&i.Hits,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
 const getRole = `-- name: GetRole :one
 SELECT role_id, label, system_protected FROM roles
 WHERE role_id = ? LIMIT 1
@@ -7495,22 +8822,123 @@ func (q *Queries) GetTokenByUserId(ctx context.Context, arg GetTokenByUserIdPara
 	return items, nil
 }
 
-const getUnconsumedEvents = `-- name: GetUnconsumedEvents :many
-SELECT event_id, hlc_timestamp, wall_timestamp, node_id, table_name, record_id, operation, action, user_id, old_values, new_values, metadata, request_id, ip, synced_at, consumed_at FROM change_events
-WHERE consumed_at IS NULL
-ORDER BY hlc_timestamp ASC
-LIMIT ?
+const getTranslationMemory = `-- name: GetTranslationMemory :one
+SELECT tm_id, source_locale, target_locale, source_hash, source_text, target_text, source_length, content_data_id, field_id, updated_at FROM translation_memory WHERE tm_id = ? LIMIT 1
 `
 
-type GetUnconsumedEventsParams struct {
-	Limit int32 `json:"limit"`
+type GetTranslationMemoryParams struct {
+	TmID string `json:"tm_id"`
 }
 
-func (q *Queries) GetUnconsumedEvents(ctx context.Context, arg GetUnconsumedEventsParams) ([]ChangeEvent, error) {
-	rows, err := q.db.QueryContext(ctx, getUnconsumedEvents, arg.Limit)
-	if err != nil {
-		return nil, err
-	}
+func (q *Queries) GetTranslationMemory(ctx context.Context, arg GetTranslationMemoryParams) (TranslationMemory, error) {
+	row := q.db.QueryRowContext(ctx, getTranslationMemory, arg.TmID)
+	var i TranslationMemory
+	err := row.Scan(
+		&i.TmID,
+		&i.SourceLocale,
+		&i.TargetLocale,
+		&i.SourceHash,
+		&i.SourceText,
+		&i.TargetText,
+		&i.SourceLength,
+		&i.ContentDataID,
+		&i.FieldID,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTranslationMemoryBySource = `-- name: GetTranslationMemoryBySource :one
+SELECT tm_id, source_locale, target_locale, source_hash, source_text, target_text, source_length, content_data_id, field_id, updated_at FROM translation_memory
+WHERE source_locale = ? AND target_locale = ? AND source_hash = ?
+LIMIT 1
+`
+
+type GetTranslationMemoryBySourceParams struct {
+	SourceLocale string `json:"source_locale"`
+	TargetLocale string `json:"target_locale"`
+	SourceHash   string `json:"source_hash"`
+}
+
+func (q *Queries) GetTranslationMemoryBySource(ctx context.Context, arg GetTranslationMemoryBySourceParams) (TranslationMemory, error) {
+	row := q.db.QueryRowContext(ctx, getTranslationMemoryBySource, arg.SourceLocale, arg.TargetLocale, arg.SourceHash)
+	var i TranslationMemory
+	err := row.Scan(
+		&i.TmID,
+		&i.SourceLocale,
+		&i.TargetLocale,
+		&i.SourceHash,
+		&i.SourceText,
+		&i.TargetText,
+		&i.SourceLength,
+		&i.ContentDataID,
+		&i.FieldID,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTranslationReview = `-- name: GetTranslationReview :one
+SELECT content_field_id, content_data_id, locale, origin, provider, score, created_at FROM translation_reviews WHERE content_field_id = ? LIMIT 1
+`
+
+type GetTranslationReviewParams struct {
+	ContentFieldID types.ContentFieldID `json:"content_field_id"`
+}
+
+func (q *Queries) GetTranslationReview(ctx context.Context, arg GetTranslationReviewParams) (TranslationReviews, error) {
+	row := q.db.QueryRowContext(ctx, getTranslationReview, arg.ContentFieldID)
+	var i TranslationReviews
+	err := row.Scan(
+		&i.ContentFieldID,
+		&i.ContentDataID,
+		&i.Locale,
+		&i.Origin,
+		&i.Provider,
+		&i.Score,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTranslationSource = `-- name: GetTranslationSource :one
+SELECT content_field_id, content_data_id, field_id, locale, source_hash, recorded_at FROM translation_sources WHERE content_field_id = ? LIMIT 1
+`
+
+type GetTranslationSourceParams struct {
+	ContentFieldID types.ContentFieldID `json:"content_field_id"`
+}
+
+func (q *Queries) GetTranslationSource(ctx context.Context, arg GetTranslationSourceParams) (TranslationSources, error) {
+	row := q.db.QueryRowContext(ctx, getTranslationSource, arg.ContentFieldID)
+	var i TranslationSources
+	err := row.Scan(
+		&i.ContentFieldID,
+		&i.ContentDataID,
+		&i.FieldID,
+		&i.Locale,
+		&i.SourceHash,
+		&i.RecordedAt,
+	)
+	return i, err
+}
+
+const getUnconsumedEvents = `-- name: GetUnconsumedEvents :many
+SELECT event_id, hlc_timestamp, wall_timestamp, node_id, table_name, record_id, operation, action, user_id, old_values, new_values, metadata, request_id, ip, synced_at, consumed_at FROM change_events
+WHERE consumed_at IS NULL
+ORDER BY hlc_timestamp ASC
+LIMIT ?
+`
+
+type GetUnconsumedEventsParams struct {
+	Limit int32 `json:"limit"`
+}
+
+func (q *Queries) GetUnconsumedEvents(ctx context.Context, arg GetUnconsumedEventsParams) ([]ChangeEvent, error) {
+	rows, err := q.db.QueryContext(ctx, getUnconsumedEvents, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 	items := []ChangeEvent{}
 	for rows.Next() {
@@ -7726,6 +9154,80 @@ func (q *Queries) GetUserBySSHFingerprint(ctx context.Context, arg GetUserBySSHF
 	return i, err
 }
 
+const getUserGroup = `-- name: GetUserGroup :one
+SELECT user_group_id, label, description, date_created, date_modified FROM user_groups
+WHERE user_group_id = ? LIMIT 1
+`
+
+type GetUserGroupParams struct {
+	UserGroupID types.UserGroupID `json:"user_group_id"`
+}
+
+func (q *Queries) GetUserGroup(ctx context.Context, arg GetUserGroupParams) (UserGroups, error) {
+	row := q.db.QueryRowContext(ctx, getUserGroup, arg.UserGroupID)
+	var i UserGroups
+	err := row.Scan(
+		&i.UserGroupID,
+		&i.Label,
+		&i.Description,
+		&i.DateCreated,
+		&i.DateModified,
+	)
+	return i, err
+}
+
+const getUserGroupByLabel = `-- name: GetUserGroupByLabel :one
+SELECT user_group_id, label, description, date_created, date_modified FROM user_groups
+WHERE label = ? LIMIT 1
+`
+
+type GetUserGroupByLabelParams struct {
+	Label string `json:"label"`
+}
+
+func (q *Queries) GetUserGroupByLabel(ctx context.Context, arg GetUserGroupByLabelParams) (UserGroups, error) {
+	row := q.db.QueryRowContext(ctx, getUserGroupByLabel, arg.Label)
+	var i UserGroups
+	err := row.Scan(
+		&i.UserGroupID,
+		&i.Label,
+		&i.Description,
+		&i.DateCreated,
+		&i.DateModified,
+	)
+	return i, err
+}
+
+const getUserGroupMember = `-- name: GetUserGroupMember :one
+SELECT id, user_group_id, user_id FROM user_group_members WHERE id = ? LIMIT 1
+`
+
+type GetUserGroupMemberParams struct {
+	ID types.UserGroupMemberID `json:"id"`
+}
+
+func (q *Queries) GetUserGroupMember(ctx context.Context, arg GetUserGroupMemberParams) (UserGroupMembers, error) {
+	row := q.db.QueryRowContext(ctx, getUserGroupMember, arg.ID)
+	var i UserGroupMembers
+	err := row.Scan(&i.ID, &i.UserGroupID, &i.UserID)
+	return i, err
+}
+
+const getUserGroupRole = `-- name: GetUserGroupRole :one
+SELECT id, user_group_id, role_id FROM user_group_roles WHERE id = ? LIMIT 1
+`
+
+type GetUserGroupRoleParams struct {
+	ID types.UserGroupRoleID `json:"id"`
+}
+
+func (q *Queries) GetUserGroupRole(ctx context.Context, arg GetUserGroupRoleParams) (UserGroupRoles, error) {
+	row := q.db.QueryRowContext(ctx, getUserGroupRole, arg.ID)
+	var i UserGroupRoles
+	err := row.Scan(&i.ID, &i.UserGroupID, &i.RoleID)
+	return i, err
+}
+
 const getUserId = `-- name: GetUserId :one
 SELECT user_id FROM users
 WHERE email = ? LIMIT 1
@@ -7742,6 +9244,28 @@ func (q *Queries) GetUserId(ctx context.Context, arg GetUserIdParams) (types.Use
 	return user_id, err
 }
 
+const getUserLockout = `-- name: GetUserLockout :one
+SELECT user_id, failed_attempts, lockout_count, locked_until, last_failed_at, date_modified FROM user_lockouts WHERE user_id = ? LIMIT 1
+`
+
+type GetUserLockoutParams struct {
+	UserID types.UserID `json:"user_id"`
+}
+
+func (q *Queries) GetUserLockout(ctx context.Context, arg GetUserLockoutParams) (UserLockouts, error) {
+	row := q.db.QueryRowContext(ctx, getUserLockout, arg.UserID)
+	var i UserLockouts
+	err := row.Scan(
+		&i.UserID,
+		&i.FailedAttempts,
+		&i.LockoutCount,
+		&i.LockedUntil,
+		&i.LastFailedAt,
+		&i.DateModified,
+	)
+	return i, err
+}
+
 const getUserOauth = `-- name: GetUserOauth :one
 SELECT user_oauth_id, user_id, oauth_provider, oauth_provider_user_id, access_token, refresh_token, token_expires_at, date_created
 FROM user_oauth
@@ -7871,6 +9395,21 @@ func (q *Queries) GetUserOauthId(ctx context.Context, arg GetUserOauthIdParams)
 	return user_id, err
 }
 
+const getUserRole = `-- name: GetUserRole :one
+SELECT id, user_id, role_id FROM user_roles WHERE id = ? LIMIT 1
+`
+
+type GetUserRoleParams struct {
+	ID types.UserRoleID `json:"id"`
+}
+
+func (q *Queries) GetUserRole(ctx context.Context, arg GetUserRoleParams) (UserRoles, error) {
+	row := q.db.QueryRowContext(ctx, getUserRole, arg.ID)
+	var i UserRoles
+	err := row.Scan(&i.ID, &i.UserID, &i.RoleID)
+	return i, err
+}
+
 const getUserSshKey = `-- name: GetUserSshKey :one
 SELECT ssh_key_id, user_id, public_key, key_type, fingerprint, label, date_created, last_used FROM user_ssh_keys
 WHERE ssh_key_id = ?
@@ -8088,6 +9627,46 @@ func (q *Queries) IncrementBackupSetCompleted(ctx context.Context, arg Increment
 	return err
 }
 
+const incrementRateLimitCounter = `-- name: IncrementRateLimitCounter :exec
+INSERT INTO rate_limit_counters (limiter_key, window_start, hits, expires_at)
+VALUES (?, ?, 1, ?)
+ON DUPLICATE KEY UPDATE
+    hits = IF(window_start = VALUES(window_start), hits + 1, 1),
+    window_start = VALUES(window_start),
+    expires_at = VALUES(expires_at)
+`
+
+type IncrementRateLimitCounterParams struct {
+	LimiterKey  string `json:"limiter_key"`
+	WindowStart int64  `json:"window_start"`
+	ExpiresAt   int64  `json:"expires_at"`
+}
+
+func (q *Queries) IncrementRateLimitCounter(ctx context.Context, arg IncrementRateLimitCounterParams) error {
+	_, err := q.db.ExecContext(ctx, incrementRateLimitCounter, arg.LimiterKey, arg.WindowStart, arg.ExpiresAt)
+	return err
+}
+
+const incrementUserLockout = `-- name: IncrementUserLockout :exec
+INSERT INTO user_lockouts (user_id, failed_attempts, lockout_count, last_failed_at, date_modified)
+VALUES (?, 1, 0, ?, ?)
+ON DUPLICATE KEY UPDATE
+    failed_attempts = failed_attempts + 1,
+    last_failed_at = VALUES(last_failed_at),
+    date_modified = VALUES(date_modified)
+`
+
+type IncrementUserLockoutParams struct {
+	UserID       types.UserID    `json:"user_id"`
+	LastFailedAt types.Timestamp `json:"last_failed_at"`
+	DateModified types.Timestamp `json:"date_modified"`
+}
+
+func (q *Queries) IncrementUserLockout(ctx context.Context, arg IncrementUserLockoutParams) error {
+	_, err := q.db.ExecContext(ctx, incrementUserLockout, arg.UserID, arg.LastFailedAt, arg.DateModified)
+	return err
+}
+
 const listActiveWebhooks = `-- name: ListActiveWebhooks :many
 SELECT webhook_id, name, url, secret, events, is_active, headers, author_id, date_created, date_modified FROM webhooks
 WHERE is_active = 1
@@ -10786,6 +12365,119 @@ func (q *Queries) ListBackups(ctx context.Context, arg ListBackupsParams) ([]Bac
 	return items, nil
 }
 
+const listBlueprintInstances = `-- name: ListBlueprintInstances :many
+SELECT bi.content_data_id, bi.blueprint_id, bi.route_id, bi.variables, bi.author_id, bi.date_created FROM blueprint_instances bi
+JOIN content_data cd ON cd.content_data_id = bi.content_data_id
+ORDER BY bi.blueprint_id, bi.date_created, bi.content_data_id
+`
+
+func (q *Queries) ListBlueprintInstances(ctx context.Context) ([]BlueprintInstances, error) {
+	rows, err := q.db.QueryContext(ctx, listBlueprintInstances)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BlueprintInstances{}
+	for rows.Next() {
+		var i BlueprintInstances
+		if err := rows.Scan(
+			&i.ContentDataID,
+			&i.BlueprintID,
+			&i.RouteID,
+			&i.Variables,
+			&i.AuthorID,
+			&i.DateCreated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBlueprintInstancesByBlueprint = `-- name: ListBlueprintInstancesByBlueprint :many
+SELECT bi.content_data_id, bi.blueprint_id, bi.route_id, bi.variables, bi.author_id, bi.date_created FROM blueprint_instances bi
+JOIN content_data cd ON cd.content_data_id = bi.content_data_id
+WHERE bi.blueprint_id = ?
+ORDER BY bi.date_created, bi.content_data_id
+`
+
+type ListBlueprintInstancesByBlueprintParams struct {
+	BlueprintID string `json:"blueprint_id"`
+}
+
+func (q *Queries) ListBlueprintInstancesByBlueprint(ctx context.Context, arg ListBlueprintInstancesByBlueprintParams) ([]BlueprintInstances, error) {
+	rows, err := q.db.QueryContext(ctx, listBlueprintInstancesByBlueprint, arg.BlueprintID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BlueprintInstances{}
+	for rows.Next() {
+		var i BlueprintInstances
+		if err := rows.Scan(
+			&i.ContentDataID,
+			&i.BlueprintID,
+			&i.RouteID,
+			&i.Variables,
+			&i.AuthorID,
+			&i.DateCreated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBlueprints = `-- name: ListBlueprints :many
+SELECT blueprint_id, name, label, description, definition, author_id, date_created, date_modified FROM blueprints ORDER BY name
+`
+
+func (q *Queries) ListBlueprints(ctx context.Context) ([]Blueprints, error) {
+	rows, err := q.db.QueryContext(ctx, listBlueprints)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Blueprints{}
+	for rows.Next() {
+		var i Blueprints
+		if err := rows.Scan(
+			&i.BlueprintID,
+			&i.Name,
+			&i.Label,
+			&i.Description,
+			&i.Definition,
+			&i.AuthorID,
+			&i.DateCreated,
+			&i.DateModified,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listChangeEvents = `-- name: ListChangeEvents :many
 SELECT event_id, hlc_timestamp, wall_timestamp, node_id, table_name, record_id, operation, action, user_id, old_values, new_values, metadata, request_id, ip, synced_at, consumed_at FROM change_events
 ORDER BY hlc_timestamp DESC
@@ -12101,27 +13793,35 @@ func (q *Queries) ListContentFieldsWithFieldByContentData(ctx context.Context, a
 	return items, nil
 }
 
-const listContentRelations = `-- name: ListContentRelations :many
-SELECT content_relation_id, source_content_id, target_content_id, field_id, sort_order, date_created FROM content_relations
-ORDER BY date_created
+const listContentLeasesByContent = `-- name: ListContentLeasesByContent :many
+SELECT lease_id, content_data_id, user_id, username, client, mode, acquired_at, expires_at FROM content_leases
+WHERE content_data_id = ? AND expires_at >= ?
+ORDER BY acquired_at, lease_id
 `
 
-func (q *Queries) ListContentRelations(ctx context.Context) ([]ContentRelations, error) {
-	rows, err := q.db.QueryContext(ctx, listContentRelations)
+type ListContentLeasesByContentParams struct {
+	ContentDataID types.ContentID `json:"content_data_id"`
+	ExpiresAt     int64           `json:"expires_at"`
+}
+
+func (q *Queries) ListContentLeasesByContent(ctx context.Context, arg ListContentLeasesByContentParams) ([]ContentLeases, error) {
+	rows, err := q.db.QueryContext(ctx, listContentLeasesByContent, arg.ContentDataID, arg.ExpiresAt)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ContentRelations{}
+	items := []ContentLeases{}
 	for rows.Next() {
-		var i ContentRelations
+		var i ContentLeases
 		if err := rows.Scan(
-			&i.ContentRelationID,
-			&i.SourceContentID,
-			&i.TargetContentID,
-			&i.FieldID,
-			&i.SortOrder,
-			&i.DateCreated,
+			&i.LeaseID,
+			&i.ContentDataID,
+			&i.UserID,
+			&i.Username,
+			&i.Client,
+			&i.Mode,
+			&i.AcquiredAt,
+			&i.ExpiresAt,
 		); err != nil {
 			return nil, err
 		}
@@ -12136,29 +13836,101 @@ func (q *Queries) ListContentRelations(ctx context.Context) ([]ContentRelations,
 	return items, nil
 }
 
-const listContentRelationsBySource = `-- name: ListContentRelationsBySource :many
-SELECT content_relation_id, source_content_id, target_content_id, field_id, sort_order, date_created FROM content_relations
-WHERE source_content_id = ?
-ORDER BY sort_order
+const listContentMigrations = `-- name: ListContentMigrations :many
+SELECT migration_id, source, description, rows_scanned, rows_changed, last_content_field_id, completed, author_id, date_created FROM content_migrations ORDER BY date_created, migration_id
 `
 
-type ListContentRelationsBySourceParams struct {
-	SourceContentID types.ContentID `json:"source_content_id"`
-}
-
-func (q *Queries) ListContentRelationsBySource(ctx context.Context, arg ListContentRelationsBySourceParams) ([]ContentRelations, error) {
-	rows, err := q.db.QueryContext(ctx, listContentRelationsBySource, arg.SourceContentID)
+func (q *Queries) ListContentMigrations(ctx context.Context) ([]ContentMigrations, error) {
+	rows, err := q.db.QueryContext(ctx, listContentMigrations)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ContentRelations{}
+	items := []ContentMigrations{}
 	for rows.Next() {
-		var i ContentRelations
+		var i ContentMigrations
 		if err := rows.Scan(
-			&i.ContentRelationID,
-			&i.SourceContentID,
-			&i.TargetContentID,
+			&i.MigrationID,
+			&i.Source,
+			&i.Description,
+			&i.RowsScanned,
+			&i.RowsChanged,
+			&i.LastContentFieldID,
+			&i.Completed,
+			&i.AuthorID,
+			&i.DateCreated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listContentRelations = `-- name: ListContentRelations :many
+SELECT content_relation_id, source_content_id, target_content_id, field_id, sort_order, date_created FROM content_relations
+ORDER BY date_created
+`
+
+func (q *Queries) ListContentRelations(ctx context.Context) ([]ContentRelations, error) {
+	rows, err := q.db.QueryContext(ctx, listContentRelations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ContentRelations{}
+	for rows.Next() {
+		var i ContentRelations
+		if err := rows.Scan(
+			&i.ContentRelationID,
+			&i.SourceContentID,
+			&i.TargetContentID,
+			&i.FieldID,
+			&i.SortOrder,
+			&i.DateCreated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listContentRelationsBySource = `-- name: ListContentRelationsBySource :many
+SELECT content_relation_id, source_content_id, target_content_id, field_id, sort_order, date_created FROM content_relations
+WHERE source_content_id = ?
+ORDER BY sort_order
+`
+
+type ListContentRelationsBySourceParams struct {
+	SourceContentID types.ContentID `json:"source_content_id"`
+}
+
+func (q *Queries) ListContentRelationsBySource(ctx context.Context, arg ListContentRelationsBySourceParams) ([]ContentRelations, error) {
+	rows, err := q.db.QueryContext(ctx, listContentRelationsBySource, arg.SourceContentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ContentRelations{}
+	for rows.Next() {
+		var i ContentRelations
+		if err := rows.Scan(
+			&i.ContentRelationID,
+			&i.SourceContentID,
+			&i.TargetContentID,
 			&i.FieldID,
 			&i.SortOrder,
 			&i.DateCreated,
@@ -13532,6 +15304,42 @@ func (q *Queries) ListMediaUnfiledPaginated(ctx context.Context, arg ListMediaUn
 	return items, nil
 }
 
+const listPasswordHistoryByUserID = `-- name: ListPasswordHistoryByUserID :many
+SELECT id, user_id, hash, date_created FROM password_history WHERE user_id = ? ORDER BY date_created DESC, id DESC
+`
+
+type ListPasswordHistoryByUserIDParams struct {
+	UserID types.UserID `json:"user_id"`
+}
+
+func (q *Queries) ListPasswordHistoryByUserID(ctx context.Context, arg ListPasswordHistoryByUserIDParams) ([]PasswordHistory, error) {
+	rows, err := q.db.QueryContext(ctx, listPasswordHistoryByUserID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PasswordHistory{}
+	for rows.Next() {
+		var i PasswordHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Hash,
+			&i.DateCreated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listPendingRetries = `-- name: ListPendingRetries :many
 SELECT delivery_id, webhook_id, event, payload, status, attempts, last_status_code, last_error, next_retry_at, created_at, completed_at FROM webhook_deliveries
 WHERE status = 'retrying' AND next_retry_at <= ?
@@ -14068,29 +15876,719 @@ func (q *Queries) ListRootContentSummary(ctx context.Context) ([]ListRootContent
 	return items, nil
 }
 
-const listRoute = `-- name: ListRoute :many
-SELECT route_id, slug, title, status, author_id, date_created, date_modified FROM routes
-ORDER BY slug
+const listRoute = `-- name: ListRoute :many
+SELECT route_id, slug, title, status, author_id, date_created, date_modified FROM routes
+ORDER BY slug
+`
+
+func (q *Queries) ListRoute(ctx context.Context) ([]Routes, error) {
+	rows, err := q.db.QueryContext(ctx, listRoute)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Routes{}
+	for rows.Next() {
+		var i Routes
+		if err := rows.Scan(
+			&i.RouteID,
+			&i.Slug,
+			&i.Title,
+			&i.Status,
+			&i.AuthorID,
+			&i.DateCreated,
+			&i.DateModified,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoutePaginated = `-- name: ListRoutePaginated :many
+SELECT route_id, slug, title, status, author_id, date_created, date_modified FROM routes
+ORDER BY slug
+LIMIT ? OFFSET ?
+`
+
+type ListRoutePaginatedParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListRoutePaginated(ctx context.Context, arg ListRoutePaginatedParams) ([]Routes, error) {
+	rows, err := q.db.QueryContext(ctx, listRoutePaginated, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Routes{}
+	for rows.Next() {
+		var i Routes
+		if err := rows.Scan(
+			&i.RouteID,
+			&i.Slug,
+			&i.Title,
+			&i.Status,
+			&i.AuthorID,
+			&i.DateCreated,
+			&i.DateModified,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoutesByDatatype = `-- name: ListRoutesByDatatype :many
+SELECT DISTINCT r.route_id, r.slug, r.title, r.status, r.author_id, r.date_created, r.date_modified
+FROM routes r
+INNER JOIN content_data cd ON r.route_id = cd.route_id
+WHERE cd.datatype_id = ?
+ORDER BY r.title
+`
+
+type ListRoutesByDatatypeParams struct {
+	DatatypeID types.NullableDatatypeID `json:"datatype_id"`
+}
+
+func (q *Queries) ListRoutesByDatatype(ctx context.Context, arg ListRoutesByDatatypeParams) ([]Routes, error) {
+	rows, err := q.db.QueryContext(ctx, listRoutesByDatatype, arg.DatatypeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Routes{}
+	for rows.Next() {
+		var i Routes
+		if err := rows.Scan(
+			&i.RouteID,
+			&i.Slug,
+			&i.Title,
+			&i.Status,
+			&i.AuthorID,
+			&i.DateCreated,
+			&i.DateModified,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSession = `-- name: ListSession :many
+SELECT session_id, user_id, date_created, expires_at, last_access, ip_address, user_agent, session_data FROM sessions
+`
+
+func (q *Queries) ListSession(ctx context.Context) ([]Sessions, error) {
+	rows, err := q.db.QueryContext(ctx, listSession)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Sessions{}
+	for rows.Next() {
+		var i Sessions
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.UserID,
+			&i.DateCreated,
+			&i.ExpiresAt,
+			&i.LastAccess,
+			&i.IpAddress,
+			&i.UserAgent,
+			&i.SessionData,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTable = `-- name: ListTable :many
+SELECT id, label, author_id FROM tables
+ORDER BY label
+`
+
+func (q *Queries) ListTable(ctx context.Context) ([]Tables, error) {
+	rows, err := q.db.QueryContext(ctx, listTable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tables{}
+	for rows.Next() {
+		var i Tables
+		if err := rows.Scan(&i.ID, &i.Label, &i.AuthorID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listToken = `-- name: ListToken :many
+SELECT id, user_id, token_type, token, issued_at, expires_at, revoked FROM tokens
+`
+
+func (q *Queries) ListToken(ctx context.Context) ([]Tokens, error) {
+	rows, err := q.db.QueryContext(ctx, listToken)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tokens{}
+	for rows.Next() {
+		var i Tokens
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.TokenType,
+			&i.Tokens,
+			&i.IssuedAt,
+			&i.ExpiresAt,
+			&i.Revoked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTranslationMemoryByPair = `-- name: ListTranslationMemoryByPair :many
+SELECT tm_id, source_locale, target_locale, source_hash, source_text, target_text, source_length, content_data_id, field_id, updated_at FROM translation_memory
+WHERE source_locale = ? AND target_locale = ?
+ORDER BY updated_at DESC, tm_id
+LIMIT ? OFFSET ?
+`
+
+type ListTranslationMemoryByPairParams struct {
+	SourceLocale string `json:"source_locale"`
+	TargetLocale string `json:"target_locale"`
+	Limit        int32  `json:"limit"`
+	Offset       int32  `json:"offset"`
+}
+
+func (q *Queries) ListTranslationMemoryByPair(ctx context.Context, arg ListTranslationMemoryByPairParams) ([]TranslationMemory, error) {
+	rows, err := q.db.QueryContext(ctx, listTranslationMemoryByPair,
+		arg.SourceLocale,
+		arg.TargetLocale,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TranslationMemory{}
+	for rows.Next() {
+		var i TranslationMemory
+		if err := rows.Scan(
+			&i.TmID,
+			&i.SourceLocale,
+			&i.TargetLocale,
+			&i.SourceHash,
+			&i.SourceText,
+			&i.TargetText,
+			&i.SourceLength,
+			&i.ContentDataID,
+			&i.FieldID,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTranslationMemoryCandidates = `-- name: ListTranslationMemoryCandidates :many
+SELECT tm_id, source_locale, target_locale, source_hash, source_text, target_text, source_length, content_data_id, field_id, updated_at FROM translation_memory
+WHERE source_locale = ? AND target_locale = ?
+  AND source_length BETWEEN ? AND ?
+ORDER BY updated_at DESC, tm_id
+LIMIT ?
+`
+
+type ListTranslationMemoryCandidatesParams struct {
+	SourceLocale string `json:"source_locale"`
+	TargetLocale string `json:"target_locale"`
+	MinLength    int64  `json:"min_length"`
+	MaxLength    int64  `json:"max_length"`
+	Limit        int32  `json:"limit"`
+}
+
+func (q *Queries) ListTranslationMemoryCandidates(ctx context.Context, arg ListTranslationMemoryCandidatesParams) ([]TranslationMemory, error) {
+	rows, err := q.db.QueryContext(ctx, listTranslationMemoryCandidates,
+		arg.SourceLocale,
+		arg.TargetLocale,
+		arg.MinLength,
+		arg.MaxLength,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TranslationMemory{}
+	for rows.Next() {
+		var i TranslationMemory
+		if err := rows.Scan(
+			&i.TmID,
+			&i.SourceLocale,
+			&i.TargetLocale,
+			&i.SourceHash,
+			&i.SourceText,
+			&i.TargetText,
+			&i.SourceLength,
+			&i.ContentDataID,
+			&i.FieldID,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTranslationReviewsByLocale = `-- name: ListTranslationReviewsByLocale :many
+SELECT tr.content_field_id, tr.content_data_id, tr.locale, tr.origin, tr.provider, tr.score, tr.created_at FROM translation_reviews tr
+JOIN content_fields cf ON cf.content_field_id = tr.content_field_id
+WHERE tr.locale = ?
+ORDER BY tr.content_data_id, tr.content_field_id
+`
+
+type ListTranslationReviewsByLocaleParams struct {
+	Locale string `json:"locale"`
+}
+
+func (q *Queries) ListTranslationReviewsByLocale(ctx context.Context, arg ListTranslationReviewsByLocaleParams) ([]TranslationReviews, error) {
+	rows, err := q.db.QueryContext(ctx, listTranslationReviewsByLocale, arg.Locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TranslationReviews{}
+	for rows.Next() {
+		var i TranslationReviews
+		if err := rows.Scan(
+			&i.ContentFieldID,
+			&i.ContentDataID,
+			&i.Locale,
+			&i.Origin,
+			&i.Provider,
+			&i.Score,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTranslationReviewsByRoute = `-- name: ListTranslationReviewsByRoute :many
+SELECT tr.content_field_id, tr.content_data_id, tr.locale, tr.origin, tr.provider, tr.score, tr.created_at FROM translation_reviews tr
+JOIN content_fields cf ON cf.content_field_id = tr.content_field_id
+WHERE cf.route_id = ? AND tr.locale = ?
+ORDER BY tr.content_data_id, tr.content_field_id
+`
+
+type ListTranslationReviewsByRouteParams struct {
+	RouteID types.NullableRouteID `json:"route_id"`
+	Locale  string                `json:"locale"`
+}
+
+func (q *Queries) ListTranslationReviewsByRoute(ctx context.Context, arg ListTranslationReviewsByRouteParams) ([]TranslationReviews, error) {
+	rows, err := q.db.QueryContext(ctx, listTranslationReviewsByRoute, arg.RouteID, arg.Locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TranslationReviews{}
+	for rows.Next() {
+		var i TranslationReviews
+		if err := rows.Scan(
+			&i.ContentFieldID,
+			&i.ContentDataID,
+			&i.Locale,
+			&i.Origin,
+			&i.Provider,
+			&i.Score,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTranslationSourcesByField = `-- name: ListTranslationSourcesByField :many
+SELECT ts.content_field_id, ts.content_data_id, ts.field_id, ts.locale, ts.source_hash, ts.recorded_at FROM translation_sources ts
+JOIN content_fields cf ON cf.content_field_id = ts.content_field_id
+WHERE ts.content_data_id = ? AND ts.field_id = ?
+ORDER BY ts.locale, ts.content_field_id
+`
+
+type ListTranslationSourcesByFieldParams struct {
+	ContentDataID types.ContentID `json:"content_data_id"`
+	FieldID       types.FieldID   `json:"field_id"`
+}
+
+func (q *Queries) ListTranslationSourcesByField(ctx context.Context, arg ListTranslationSourcesByFieldParams) ([]TranslationSources, error) {
+	rows, err := q.db.QueryContext(ctx, listTranslationSourcesByField, arg.ContentDataID, arg.FieldID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TranslationSources{}
+	for rows.Next() {
+		var i TranslationSources
+		if err := rows.Scan(
+			&i.ContentFieldID,
+			&i.ContentDataID,
+			&i.FieldID,
+			&i.Locale,
+			&i.SourceHash,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTranslationSourcesByLocale = `-- name: ListTranslationSourcesByLocale :many
+SELECT ts.content_field_id, ts.content_data_id, ts.field_id, ts.locale, ts.source_hash, ts.recorded_at FROM translation_sources ts
+JOIN content_fields cf ON cf.content_field_id = ts.content_field_id
+WHERE ts.locale = ?
+ORDER BY ts.content_data_id, ts.content_field_id
+`
+
+type ListTranslationSourcesByLocaleParams struct {
+	Locale string `json:"locale"`
+}
+
+func (q *Queries) ListTranslationSourcesByLocale(ctx context.Context, arg ListTranslationSourcesByLocaleParams) ([]TranslationSources, error) {
+	rows, err := q.db.QueryContext(ctx, listTranslationSourcesByLocale, arg.Locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TranslationSources{}
+	for rows.Next() {
+		var i TranslationSources
+		if err := rows.Scan(
+			&i.ContentFieldID,
+			&i.ContentDataID,
+			&i.FieldID,
+			&i.Locale,
+			&i.SourceHash,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTranslationSourcesByRoute = `-- name: ListTranslationSourcesByRoute :many
+SELECT ts.content_field_id, ts.content_data_id, ts.field_id, ts.locale, ts.source_hash, ts.recorded_at FROM translation_sources ts
+JOIN content_fields cf ON cf.content_field_id = ts.content_field_id
+WHERE cf.route_id = ? AND ts.locale = ?
+ORDER BY ts.content_data_id, ts.content_field_id
+`
+
+type ListTranslationSourcesByRouteParams struct {
+	RouteID types.NullableRouteID `json:"route_id"`
+	Locale  string                `json:"locale"`
+}
+
+func (q *Queries) ListTranslationSourcesByRoute(ctx context.Context, arg ListTranslationSourcesByRouteParams) ([]TranslationSources, error) {
+	rows, err := q.db.QueryContext(ctx, listTranslationSourcesByRoute, arg.RouteID, arg.Locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TranslationSources{}
+	for rows.Next() {
+		var i TranslationSources
+		if err := rows.Scan(
+			&i.ContentFieldID,
+			&i.ContentDataID,
+			&i.FieldID,
+			&i.Locale,
+			&i.SourceHash,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUser = `-- name: ListUser :many
+SELECT user_id, username, name, email, hash, role, date_created, date_modified FROM users 
+ORDER BY user_id
+`
+
+func (q *Queries) ListUser(ctx context.Context) ([]Users, error) {
+	rows, err := q.db.QueryContext(ctx, listUser)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Users{}
+	for rows.Next() {
+		var i Users
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Username,
+			&i.Name,
+			&i.Email,
+			&i.Hash,
+			&i.Roles,
+			&i.DateCreated,
+			&i.DateModified,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserGroup = `-- name: ListUserGroup :many
+SELECT user_group_id, label, description, date_created, date_modified FROM user_groups
+ORDER BY label
+`
+
+func (q *Queries) ListUserGroup(ctx context.Context) ([]UserGroups, error) {
+	rows, err := q.db.QueryContext(ctx, listUserGroup)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserGroups{}
+	for rows.Next() {
+		var i UserGroups
+		if err := rows.Scan(
+			&i.UserGroupID,
+			&i.Label,
+			&i.Description,
+			&i.DateCreated,
+			&i.DateModified,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserGroupMember = `-- name: ListUserGroupMember :many
+SELECT id, user_group_id, user_id FROM user_group_members ORDER BY id
+`
+
+func (q *Queries) ListUserGroupMember(ctx context.Context) ([]UserGroupMembers, error) {
+	rows, err := q.db.QueryContext(ctx, listUserGroupMember)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserGroupMembers{}
+	for rows.Next() {
+		var i UserGroupMembers
+		if err := rows.Scan(&i.ID, &i.UserGroupID, &i.UserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserGroupMemberByUserGroupID = `-- name: ListUserGroupMemberByUserGroupID :many
+SELECT id, user_group_id, user_id FROM user_group_members WHERE user_group_id = ? ORDER BY id
+`
+
+type ListUserGroupMemberByUserGroupIDParams struct {
+	UserGroupID types.UserGroupID `json:"user_group_id"`
+}
+
+func (q *Queries) ListUserGroupMemberByUserGroupID(ctx context.Context, arg ListUserGroupMemberByUserGroupIDParams) ([]UserGroupMembers, error) {
+	rows, err := q.db.QueryContext(ctx, listUserGroupMemberByUserGroupID, arg.UserGroupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserGroupMembers{}
+	for rows.Next() {
+		var i UserGroupMembers
+		if err := rows.Scan(&i.ID, &i.UserGroupID, &i.UserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserGroupMemberByUserID = `-- name: ListUserGroupMemberByUserID :many
+SELECT id, user_group_id, user_id FROM user_group_members WHERE user_id = ? ORDER BY id
+`
+
+type ListUserGroupMemberByUserIDParams struct {
+	UserID types.UserID `json:"user_id"`
+}
+
+func (q *Queries) ListUserGroupMemberByUserID(ctx context.Context, arg ListUserGroupMemberByUserIDParams) ([]UserGroupMembers, error) {
+	rows, err := q.db.QueryContext(ctx, listUserGroupMemberByUserID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserGroupMembers{}
+	for rows.Next() {
+		var i UserGroupMembers
+		if err := rows.Scan(&i.ID, &i.UserGroupID, &i.UserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserGroupRole = `-- name: ListUserGroupRole :many
+SELECT id, user_group_id, role_id FROM user_group_roles ORDER BY id
 `
 
-func (q *Queries) ListRoute(ctx context.Context) ([]Routes, error) {
-	rows, err := q.db.QueryContext(ctx, listRoute)
+func (q *Queries) ListUserGroupRole(ctx context.Context) ([]UserGroupRoles, error) {
+	rows, err := q.db.QueryContext(ctx, listUserGroupRole)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Routes{}
+	items := []UserGroupRoles{}
 	for rows.Next() {
-		var i Routes
-		if err := rows.Scan(
-			&i.RouteID,
-			&i.Slug,
-			&i.Title,
-			&i.Status,
-			&i.AuthorID,
-			&i.DateCreated,
-			&i.DateModified,
-		); err != nil {
+		var i UserGroupRoles
+		if err := rows.Scan(&i.ID, &i.UserGroupID, &i.RoleID); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -14104,35 +16602,24 @@ func (q *Queries) ListRoute(ctx context.Context) ([]Routes, error) {
 	return items, nil
 }
 
-const listRoutePaginated = `-- name: ListRoutePaginated :many
-SELECT route_id, slug, title, status, author_id, date_created, date_modified FROM routes
-ORDER BY slug
-LIMIT ? OFFSET ?
+const listUserGroupRoleByRoleID = `-- name: ListUserGroupRoleByRoleID :many
+SELECT id, user_group_id, role_id FROM user_group_roles WHERE role_id = ? ORDER BY id
 `
 
-type ListRoutePaginatedParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+type ListUserGroupRoleByRoleIDParams struct {
+	RoleID types.RoleID `json:"role_id"`
 }
 
-func (q *Queries) ListRoutePaginated(ctx context.Context, arg ListRoutePaginatedParams) ([]Routes, error) {
-	rows, err := q.db.QueryContext(ctx, listRoutePaginated, arg.Limit, arg.Offset)
+func (q *Queries) ListUserGroupRoleByRoleID(ctx context.Context, arg ListUserGroupRoleByRoleIDParams) ([]UserGroupRoles, error) {
+	rows, err := q.db.QueryContext(ctx, listUserGroupRoleByRoleID, arg.RoleID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Routes{}
+	items := []UserGroupRoles{}
 	for rows.Next() {
-		var i Routes
-		if err := rows.Scan(
-			&i.RouteID,
-			&i.Slug,
-			&i.Title,
-			&i.Status,
-			&i.AuthorID,
-			&i.DateCreated,
-			&i.DateModified,
-		); err != nil {
+		var i UserGroupRoles
+		if err := rows.Scan(&i.ID, &i.UserGroupID, &i.RoleID); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -14146,36 +16633,24 @@ func (q *Queries) ListRoutePaginated(ctx context.Context, arg ListRoutePaginated
 	return items, nil
 }
 
-const listRoutesByDatatype = `-- name: ListRoutesByDatatype :many
-SELECT DISTINCT r.route_id, r.slug, r.title, r.status, r.author_id, r.date_created, r.date_modified
-FROM routes r
-INNER JOIN content_data cd ON r.route_id = cd.route_id
-WHERE cd.datatype_id = ?
-ORDER BY r.title
+const listUserGroupRoleByUserGroupID = `-- name: ListUserGroupRoleByUserGroupID :many
+SELECT id, user_group_id, role_id FROM user_group_roles WHERE user_group_id = ? ORDER BY id
 `
 
-type ListRoutesByDatatypeParams struct {
-	DatatypeID types.NullableDatatypeID `json:"datatype_id"`
+type ListUserGroupRoleByUserGroupIDParams struct {
+	UserGroupID types.UserGroupID `json:"user_group_id"`
 }
 
-func (q *Queries) ListRoutesByDatatype(ctx context.Context, arg ListRoutesByDatatypeParams) ([]Routes, error) {
-	rows, err := q.db.QueryContext(ctx, listRoutesByDatatype, arg.DatatypeID)
+func (q *Queries) ListUserGroupRoleByUserGroupID(ctx context.Context, arg ListUserGroupRoleByUserGroupIDParams) ([]UserGroupRoles, error) {
+	rows, err := q.db.QueryContext(ctx, listUserGroupRoleByUserGroupID, arg.UserGroupID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Routes{}
+	items := []UserGroupRoles{}
 	for rows.Next() {
-		var i Routes
-		if err := rows.Scan(
-			&i.RouteID,
-			&i.Slug,
-			&i.Title,
-			&i.Status,
-			&i.AuthorID,
-			&i.DateCreated,
-			&i.DateModified,
-		); err != nil {
+		var i UserGroupRoles
+		if err := rows.Scan(&i.ID, &i.UserGroupID, &i.RoleID); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -14189,28 +16664,26 @@ func (q *Queries) ListRoutesByDatatype(ctx context.Context, arg ListRoutesByData
 	return items, nil
 }
 
-const listSession = `-- name: ListSession :many
-SELECT session_id, user_id, date_created, expires_at, last_access, ip_address, user_agent, session_data FROM sessions
+const listUserLockouts = `-- name: ListUserLockouts :many
+SELECT user_id, failed_attempts, lockout_count, locked_until, last_failed_at, date_modified FROM user_lockouts ORDER BY date_modified DESC
 `
 
-func (q *Queries) ListSession(ctx context.Context) ([]Sessions, error) {
-	rows, err := q.db.QueryContext(ctx, listSession)
+func (q *Queries) ListUserLockouts(ctx context.Context) ([]UserLockouts, error) {
+	rows, err := q.db.QueryContext(ctx, listUserLockouts)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Sessions{}
+	items := []UserLockouts{}
 	for rows.Next() {
-		var i Sessions
+		var i UserLockouts
 		if err := rows.Scan(
-			&i.SessionID,
 			&i.UserID,
-			&i.DateCreated,
-			&i.ExpiresAt,
-			&i.LastAccess,
-			&i.IpAddress,
-			&i.UserAgent,
-			&i.SessionData,
+			&i.FailedAttempts,
+			&i.LockoutCount,
+			&i.LockedUntil,
+			&i.LastFailedAt,
+			&i.DateModified,
 		); err != nil {
 			return nil, err
 		}
@@ -14225,21 +16698,31 @@ func (q *Queries) ListSession(ctx context.Context) ([]Sessions, error) {
 	return items, nil
 }
 
-const listTable = `-- name: ListTable :many
-SELECT id, label, author_id FROM tables
-ORDER BY label
+const listUserOauth = `-- name: ListUserOauth :many
+SELECT user_oauth_id, user_id, oauth_provider, oauth_provider_user_id, access_token, refresh_token, token_expires_at, date_created
+FROM user_oauth
+ORDER BY user_oauth_id
 `
 
-func (q *Queries) ListTable(ctx context.Context) ([]Tables, error) {
-	rows, err := q.db.QueryContext(ctx, listTable)
+func (q *Queries) ListUserOauth(ctx context.Context) ([]UserOauth, error) {
+	rows, err := q.db.QueryContext(ctx, listUserOauth)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Tables{}
+	items := []UserOauth{}
 	for rows.Next() {
-		var i Tables
-		if err := rows.Scan(&i.ID, &i.Label, &i.AuthorID); err != nil {
+		var i UserOauth
+		if err := rows.Scan(
+			&i.UserOAuthID,
+			&i.UserID,
+			&i.OauthProvider,
+			&i.OAuthProviderUserID,
+			&i.AccessToken,
+			&i.RefreshToken,
+			&i.TokenExpiresAt,
+			&i.DateCreated,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -14253,28 +16736,20 @@ func (q *Queries) ListTable(ctx context.Context) ([]Tables, error) {
 	return items, nil
 }
 
-const listToken = `-- name: ListToken :many
-SELECT id, user_id, token_type, token, issued_at, expires_at, revoked FROM tokens
+const listUserRole = `-- name: ListUserRole :many
+SELECT id, user_id, role_id FROM user_roles ORDER BY id
 `
 
-func (q *Queries) ListToken(ctx context.Context) ([]Tokens, error) {
-	rows, err := q.db.QueryContext(ctx, listToken)
+func (q *Queries) ListUserRole(ctx context.Context) ([]UserRoles, error) {
+	rows, err := q.db.QueryContext(ctx, listUserRole)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Tokens{}
+	items := []UserRoles{}
 	for rows.Next() {
-		var i Tokens
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.TokenType,
-			&i.Tokens,
-			&i.IssuedAt,
-			&i.ExpiresAt,
-			&i.Revoked,
-		); err != nil {
+		var i UserRoles
+		if err := rows.Scan(&i.ID, &i.UserID, &i.RoleID); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -14288,30 +16763,24 @@ func (q *Queries) ListToken(ctx context.Context) ([]Tokens, error) {
 	return items, nil
 }
 
-const listUser = `-- name: ListUser :many
-SELECT user_id, username, name, email, hash, role, date_created, date_modified FROM users 
-ORDER BY user_id
+const listUserRoleByRoleID = `-- name: ListUserRoleByRoleID :many
+SELECT id, user_id, role_id FROM user_roles WHERE role_id = ? ORDER BY id
 `
 
-func (q *Queries) ListUser(ctx context.Context) ([]Users, error) {
-	rows, err := q.db.QueryContext(ctx, listUser)
+type ListUserRoleByRoleIDParams struct {
+	RoleID types.RoleID `json:"role_id"`
+}
+
+func (q *Queries) ListUserRoleByRoleID(ctx context.Context, arg ListUserRoleByRoleIDParams) ([]UserRoles, error) {
+	rows, err := q.db.QueryContext(ctx, listUserRoleByRoleID, arg.RoleID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Users{}
+	items := []UserRoles{}
 	for rows.Next() {
-		var i Users
-		if err := rows.Scan(
-			&i.UserID,
-			&i.Username,
-			&i.Name,
-			&i.Email,
-			&i.Hash,
-			&i.Roles,
-			&i.DateCreated,
-			&i.DateModified,
-		); err != nil {
+		var i UserRoles
+		if err := rows.Scan(&i.ID, &i.UserID, &i.RoleID); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -14325,31 +16794,24 @@ func (q *Queries) ListUser(ctx context.Context) ([]Users, error) {
 	return items, nil
 }
 
-const listUserOauth = `-- name: ListUserOauth :many
-SELECT user_oauth_id, user_id, oauth_provider, oauth_provider_user_id, access_token, refresh_token, token_expires_at, date_created
-FROM user_oauth
-ORDER BY user_oauth_id
+const listUserRoleByUserID = `-- name: ListUserRoleByUserID :many
+SELECT id, user_id, role_id FROM user_roles WHERE user_id = ? ORDER BY id
 `
 
-func (q *Queries) ListUserOauth(ctx context.Context) ([]UserOauth, error) {
-	rows, err := q.db.QueryContext(ctx, listUserOauth)
+type ListUserRoleByUserIDParams struct {
+	UserID types.UserID `json:"user_id"`
+}
+
+func (q *Queries) ListUserRoleByUserID(ctx context.Context, arg ListUserRoleByUserIDParams) ([]UserRoles, error) {
+	rows, err := q.db.QueryContext(ctx, listUserRoleByUserID, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []UserOauth{}
+	items := []UserRoles{}
 	for rows.Next() {
-		var i UserOauth
-		if err := rows.Scan(
-			&i.UserOAuthID,
-			&i.UserID,
-			&i.OauthProvider,
-			&i.OAuthProviderUserID,
-			&i.AccessToken,
-			&i.RefreshToken,
-			&i.TokenExpiresAt,
-			&i.DateCreated,
-		); err != nil {
+		var i UserRoles
+		if err := rows.Scan(&i.ID, &i.UserID, &i.RoleID); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -14796,6 +17258,35 @@ func (q *Queries) ListWebhooksPaginated(ctx context.Context, arg ListWebhooksPag
 	return items, nil
 }
 
+const lockUserLockout = `-- name: LockUserLockout :execrows
+UPDATE user_lockouts
+SET failed_attempts = 0,
+    lockout_count = lockout_count + 1,
+    locked_until = ?,
+    date_modified = ?
+WHERE user_id = ? AND failed_attempts >= ?
+`
+
+type LockUserLockoutParams struct {
+	LockedUntil    types.Timestamp `json:"locked_until"`
+	DateModified   types.Timestamp `json:"date_modified"`
+	UserID         types.UserID    `json:"user_id"`
+	FailedAttempts int64           `json:"failed_attempts"`
+}
+
+func (q *Queries) LockUserLockout(ctx context.Context, arg LockUserLockoutParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, lockUserLockout,
+		arg.LockedUntil,
+		arg.DateModified,
+		arg.UserID,
+		arg.FailedAttempts,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const markEventConsumed = `-- name: MarkEventConsumed :exec
 UPDATE change_events
 SET consumed_at = CURRENT_TIMESTAMP
@@ -15055,6 +17546,38 @@ func (q *Queries) RecordChangeEvent(ctx context.Context, arg RecordChangeEventPa
 	return err
 }
 
+const recordContentMigration = `-- name: RecordContentMigration :exec
+INSERT INTO content_migrations (migration_id, source, description, rows_scanned, rows_changed, last_content_field_id, completed, author_id, date_created)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type RecordContentMigrationParams struct {
+	MigrationID        string          `json:"migration_id"`
+	Source             string          `json:"source"`
+	Description        string          `json:"description"`
+	RowsScanned        int64           `json:"rows_scanned"`
+	RowsChanged        int64           `json:"rows_changed"`
+	LastContentFieldID string          `json:"last_content_field_id"`
+	Completed          types.SafeBool  `json:"completed"`
+	AuthorID           types.UserID    `json:"author_id"`
+	DateCreated        types.Timestamp `json:"date_created"`
+}
+
+func (q *Queries) RecordContentMigration(ctx context.Context, arg RecordContentMigrationParams) error {
+	_, err := q.db.ExecContext(ctx, recordContentMigration,
+		arg.MigrationID,
+		arg.Source,
+		arg.Description,
+		arg.RowsScanned,
+		arg.RowsChanged,
+		arg.LastContentFieldID,
+		arg.Completed,
+		arg.AuthorID,
+		arg.DateCreated,
+	)
+	return err
+}
+
 const updateAdminContentData = `-- name: UpdateAdminContentData :exec
 UPDATE admin_content_data
 SET parent_id = ?,
@@ -15663,6 +18186,33 @@ func (q *Queries) UpdateBackupStatus(ctx context.Context, arg UpdateBackupStatus
 	return err
 }
 
+const updateBlueprint = `-- name: UpdateBlueprint :exec
+UPDATE blueprints
+SET name = ?, label = ?, description = ?, definition = ?, date_modified = ?
+WHERE blueprint_id = ?
+`
+
+type UpdateBlueprintParams struct {
+	Name         string          `json:"name"`
+	Label        string          `json:"label"`
+	Description  string          `json:"description"`
+	Definition   string          `json:"definition"`
+	DateModified types.Timestamp `json:"date_modified"`
+	BlueprintID  string          `json:"blueprint_id"`
+}
+
+func (q *Queries) UpdateBlueprint(ctx context.Context, arg UpdateBlueprintParams) error {
+	_, err := q.db.ExecContext(ctx, updateBlueprint,
+		arg.Name,
+		arg.Label,
+		arg.Description,
+		arg.Definition,
+		arg.DateModified,
+		arg.BlueprintID,
+	)
+	return err
+}
+
 const updateContentData = `-- name: UpdateContentData :exec
 UPDATE content_data
 SET route_id = ?,
@@ -15853,6 +18403,49 @@ func (q *Queries) UpdateContentField(ctx context.Context, arg UpdateContentField
 	return err
 }
 
+const updateContentLease = `-- name: UpdateContentLease :exec
+UPDATE content_leases SET mode = ?, expires_at = ? WHERE lease_id = ?
+`
+
+type UpdateContentLeaseParams struct {
+	Mode      string `json:"mode"`
+	ExpiresAt int64  `json:"expires_at"`
+	LeaseID   string `json:"lease_id"`
+}
+
+func (q *Queries) UpdateContentLease(ctx context.Context, arg UpdateContentLeaseParams) error {
+	_, err := q.db.ExecContext(ctx, updateContentLease, arg.Mode, arg.ExpiresAt, arg.LeaseID)
+	return err
+}
+
+const updateContentMigrationProgress = `-- name: UpdateContentMigrationProgress :exec
+UPDATE content_migrations
+SET rows_scanned = ?,
+    rows_changed = ?,
+    last_content_field_id = ?,
+    completed = ?
+WHERE migration_id = ?
+`
+
+type UpdateContentMigrationProgressParams struct {
+	RowsScanned        int64          `json:"rows_scanned"`
+	RowsChanged        int64          `json:"rows_changed"`
+	LastContentFieldID string         `json:"last_content_field_id"`
+	Completed          types.SafeBool `json:"completed"`
+	MigrationID        string         `json:"migration_id"`
+}
+
+func (q *Queries) UpdateContentMigrationProgress(ctx context.Context, arg UpdateContentMigrationProgressParams) error {
+	_, err := q.db.ExecContext(ctx, updateContentMigrationProgress,
+		arg.RowsScanned,
+		arg.RowsChanged,
+		arg.LastContentFieldID,
+		arg.Completed,
+		arg.MigrationID,
+	)
+	return err
+}
+
 const updateContentRelationSortOrder = `-- name: UpdateContentRelationSortOrder :exec
 UPDATE content_relations
 SET sort_order = ?
@@ -16434,6 +19027,48 @@ func (q *Queries) UpdateToken(ctx context.Context, arg UpdateTokenParams) error
 	return err
 }
 
+const updateTranslationMemory = `-- name: UpdateTranslationMemory :exec
+UPDATE translation_memory
+SET target_text = ?, content_data_id = ?, field_id = ?, updated_at = ?
+WHERE tm_id = ?
+`
+
+type UpdateTranslationMemoryParams struct {
+	TargetText    string          `json:"target_text"`
+	ContentDataID types.ContentID `json:"content_data_id"`
+	FieldID       types.FieldID   `json:"field_id"`
+	UpdatedAt     int64           `json:"updated_at"`
+	TmID          string          `json:"tm_id"`
+}
+
+func (q *Queries) UpdateTranslationMemory(ctx context.Context, arg UpdateTranslationMemoryParams) error {
+	_, err := q.db.ExecContext(ctx, updateTranslationMemory,
+		arg.TargetText,
+		arg.ContentDataID,
+		arg.FieldID,
+		arg.UpdatedAt,
+		arg.TmID,
+	)
+	return err
+}
+
+const updateTranslationSource = `-- name: UpdateTranslationSource :exec
+UPDATE translation_sources
+SET source_hash = ?, recorded_at = ?
+WHERE content_field_id = ?
+`
+
+type UpdateTranslationSourceParams struct {
+	SourceHash     string               `json:"source_hash"`
+	RecordedAt     int64                `json:"recorded_at"`
+	ContentFieldID types.ContentFieldID `json:"content_field_id"`
+}
+
+func (q *Queries) UpdateTranslationSource(ctx context.Context, arg UpdateTranslationSourceParams) error {
+	_, err := q.db.ExecContext(ctx, updateTranslationSource, arg.SourceHash, arg.RecordedAt, arg.ContentFieldID)
+	return err
+}
+
 const updateUser = `-- name: UpdateUser :exec
 UPDATE users
     SET username = ?,
@@ -16471,6 +19106,34 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
 	return err
 }
 
+const updateUserGroup = `-- name: UpdateUserGroup :exec
+UPDATE user_groups
+SET label = ?,
+    description = ?,
+    date_created = ?,
+    date_modified = ?
+WHERE user_group_id = ?
+`
+
+type UpdateUserGroupParams struct {
+	Label        string            `json:"label"`
+	Description  string            `json:"description"`
+	DateCreated  types.Timestamp   `json:"date_created"`
+	DateModified types.Timestamp   `json:"date_modified"`
+	UserGroupID  types.UserGroupID `json:"user_group_id"`
+}
+
+func (q *Queries) UpdateUserGroup(ctx context.Context, arg UpdateUserGroupParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserGroup,
+		arg.Label,
+		arg.Description,
+		arg.DateCreated,
+		arg.DateModified,
+		arg.UserGroupID,
+	)
+	return err
+}
+
 const updateUserOauth = `-- name: UpdateUserOauth :exec
 UPDATE user_oauth
 SET access_token = ?,
@@ -16666,3 +19329,35 @@ func (q *Queries) UpdateWebhookDeliveryStatus(ctx context.Context, arg UpdateWeb
 	)
 	return err
 }
+
+const upsertUserLockout = `-- name: UpsertUserLockout :exec
+INSERT INTO user_lockouts (user_id, failed_attempts, lockout_count, locked_until, last_failed_at, date_modified)
+VALUES (?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+    failed_attempts = VALUES(failed_attempts),
+    lockout_count = VALUES(lockout_count),
+    locked_until = VALUES(locked_until),
+    last_failed_at = VALUES(last_failed_at),
+    date_modified = VALUES(date_modified)
+`
+
+type UpsertUserLockoutParams struct {
+	UserID         types.UserID    `json:"user_id"`
+	FailedAttempts int64           `json:"failed_attempts"`
+	LockoutCount   int64           `json:"lockout_count"`
+	LockedUntil    types.Timestamp `json:"locked_until"`
+	LastFailedAt   types.Timestamp `json:"last_failed_at"`
+	DateModified   types.Timestamp `json:"date_modified"`
+}
+
+func (q *Queries) UpsertUserLockout(ctx context.Context, arg UpsertUserLockoutParams) error {
+	_, err := q.db.ExecContext(ctx, upsertUserLockout,
+		arg.UserID,
+		arg.FailedAttempts,
+		arg.LockoutCount,
+		arg.LockedUntil,
+		arg.LastFailedAt,
+		arg.DateModified,
+	)
+	return err
+}
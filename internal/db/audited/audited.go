@@ -388,3 +388,54 @@ func Delete[T any](cmd DeleteCommand[T]) error {
 
 	return err
 }
+
+// DeleteInTx executes an audited delete operation within an existing transaction.
+// The caller is responsible for committing or rolling back the transaction,
+// and for firing after-hooks post-commit.
+func DeleteInTx[T any](cmd DeleteCommand[T], tx *sql.Tx) error {
+	ctx := cmd.Context()
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+	}
+
+	auditCtx := cmd.AuditContext()
+	tableName := cmd.TableName()
+	runner := auditCtx.HookRunner
+
+	before, err := cmd.GetBefore(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("get before state: %w", err)
+	}
+	oldValues, err := marshalToJSONData(before)
+	if err != nil {
+		return fmt.Errorf("marshal before state: %w", err)
+	}
+
+	// Run before_delete hooks inside the transaction.
+	if runner != nil && runner.HasHooks(HookBeforeDelete, tableName) {
+		if hookErr := runner.RunBeforeHooks(ctx, HookBeforeDelete, tableName, before); hookErr != nil {
+			return hookErr
+		}
+	}
+
+	if err := cmd.Execute(ctx, tx); err != nil {
+		return fmt.Errorf("execute delete: %w", err)
+	}
+
+	return cmd.Recorder().Record(ctx, tx, ChangeEventParams{
+		EventID:      types.NewEventID(),
+		HlcTimestamp: types.HLCNow(),
+		NodeID:       auditCtx.NodeID,
+		TableName:    tableName,
+		RecordID:     cmd.GetID(),
+		Operation:    types.OpDelete,
+		Action:       types.ActionDelete,
+		UserID:       types.NullableUserID{ID: auditCtx.UserID, Valid: auditCtx.UserID != ""},
+		OldValues:    oldValues,
+		RequestID:    types.NullableString{String: auditCtx.RequestID, Valid: auditCtx.RequestID != ""},
+		IP:           types.NullableString{String: auditCtx.IP, Valid: auditCtx.IP != ""},
+	})
+}
@@ -0,0 +1,748 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// Blueprints are reusable content subtrees. The definition column holds the
+// JSON tree (see package blueprint); blueprint_instances links the root of
+// each tree created from a blueprint back to it, for reporting. Instance rows
+// whose content was deleted are ignored by the list queries.
+
+///////////////////////////////
+// STRUCTS
+//////////////////////////////
+
+// Blueprint is a saved content subtree with placeholder field values.
+type Blueprint struct {
+	BlueprintID  string          `json:"blueprint_id"`
+	Name         string          `json:"name"`
+	Label        string          `json:"label"`
+	Description  string          `json:"description"`
+	Definition   string          `json:"definition"`
+	AuthorID     types.UserID    `json:"author_id"`
+	DateCreated  types.Timestamp `json:"date_created"`
+	DateModified types.Timestamp `json:"date_modified"`
+}
+
+// CreateBlueprintParams contains parameters for saving a blueprint.
+type CreateBlueprintParams struct {
+	BlueprintID  string          `json:"blueprint_id"`
+	Name         string          `json:"name"`
+	Label        string          `json:"label"`
+	Description  string          `json:"description"`
+	Definition   string          `json:"definition"`
+	AuthorID     types.UserID    `json:"author_id"`
+	DateCreated  types.Timestamp `json:"date_created"`
+	DateModified types.Timestamp `json:"date_modified"`
+}
+
+// UpdateBlueprintParams contains parameters for replacing a blueprint.
+type UpdateBlueprintParams struct {
+	Name         string          `json:"name"`
+	Label        string          `json:"label"`
+	Description  string          `json:"description"`
+	Definition   string          `json:"definition"`
+	DateModified types.Timestamp `json:"date_modified"`
+	BlueprintID  string          `json:"blueprint_id"`
+}
+
+// BlueprintInstance links the root of a content tree to the blueprint it was
+// created from. Variables is the JSON object of values substituted.
+type BlueprintInstance struct {
+	ContentDataID types.ContentID       `json:"content_data_id"`
+	BlueprintID   string                `json:"blueprint_id"`
+	RouteID       types.NullableRouteID `json:"route_id"`
+	Variables     string                `json:"variables"`
+	AuthorID      types.UserID          `json:"author_id"`
+	DateCreated   types.Timestamp       `json:"date_created"`
+}
+
+// CreateBlueprintInstanceParams contains parameters for recording an instance.
+type CreateBlueprintInstanceParams struct {
+	ContentDataID types.ContentID       `json:"content_data_id"`
+	BlueprintID   string                `json:"blueprint_id"`
+	RouteID       types.NullableRouteID `json:"route_id"`
+	Variables     string                `json:"variables"`
+	AuthorID      types.UserID          `json:"author_id"`
+	DateCreated   types.Timestamp       `json:"date_created"`
+}
+
+// CreateBlueprintInstanceInTx records an instance in an existing
+// transaction, so the link commits or rolls back with the content it
+// describes.
+func CreateBlueprintInstanceInTx(d DbDriver, ctx context.Context, tx *sql.Tx, params CreateBlueprintInstanceParams) error {
+	var err error
+	switch d.(type) {
+	case Database:
+		err = mdb.New(tx).CreateBlueprintInstance(ctx, mdb.CreateBlueprintInstanceParams{
+			ContentDataID: params.ContentDataID,
+			BlueprintID:   params.BlueprintID,
+			RouteID:       params.RouteID,
+			Variables:     params.Variables,
+			AuthorID:      params.AuthorID,
+			DateCreated:   params.DateCreated,
+		})
+	case MysqlDatabase:
+		err = mdbm.New(tx).CreateBlueprintInstance(ctx, mdbm.CreateBlueprintInstanceParams{
+			ContentDataID: params.ContentDataID,
+			BlueprintID:   params.BlueprintID,
+			RouteID:       params.RouteID,
+			Variables:     params.Variables,
+			AuthorID:      params.AuthorID,
+			DateCreated:   params.DateCreated,
+		})
+	case PsqlDatabase:
+		err = mdbp.New(tx).CreateBlueprintInstance(ctx, mdbp.CreateBlueprintInstanceParams{
+			ContentDataID: params.ContentDataID,
+			BlueprintID:   params.BlueprintID,
+			RouteID:       params.RouteID,
+			Variables:     params.Variables,
+			AuthorID:      params.AuthorID,
+			DateCreated:   params.DateCreated,
+		})
+	default:
+		return fmt.Errorf("tx create blueprint_instance: unsupported driver type %T", d)
+	}
+	if err != nil {
+		return fmt.Errorf("tx create blueprint_instance: %w", err)
+	}
+	return nil
+}
+
+///////////////////////////////
+// SQLITE
+//////////////////////////////
+
+// MAPS
+
+// MapBlueprint converts a sqlc-generated type to the wrapper type.
+func (d Database) MapBlueprint(a mdb.Blueprints) Blueprint {
+	return Blueprint{
+		BlueprintID:  a.BlueprintID,
+		Name:         a.Name,
+		Label:        a.Label,
+		Description:  a.Description,
+		Definition:   a.Definition,
+		AuthorID:     a.AuthorID,
+		DateCreated:  a.DateCreated,
+		DateModified: a.DateModified,
+	}
+}
+
+// MapBlueprintInstance converts a sqlc-generated type to the wrapper type.
+func (d Database) MapBlueprintInstance(a mdb.BlueprintInstances) BlueprintInstance {
+	return BlueprintInstance{
+		ContentDataID: a.ContentDataID,
+		BlueprintID:   a.BlueprintID,
+		RouteID:       a.RouteID,
+		Variables:     a.Variables,
+		AuthorID:      a.AuthorID,
+		DateCreated:   a.DateCreated,
+	}
+}
+
+// QUERIES
+
+// CreateBlueprintTable creates the blueprints table.
+func (d Database) CreateBlueprintTable() error {
+	queries := mdb.New(d.Connection)
+	return queries.CreateBlueprintsTable(d.Context)
+}
+
+// CreateBlueprintInstanceTable creates the blueprint_instances table and its
+// index.
+func (d Database) CreateBlueprintInstanceTable() error {
+	queries := mdb.New(d.Connection)
+	if err := queries.CreateBlueprintInstancesTable(d.Context); err != nil {
+		return err
+	}
+	return queries.CreateBlueprintInstancesIndexBlueprint(d.Context)
+}
+
+// CreateBlueprint saves a blueprint and returns it.
+func (d Database) CreateBlueprint(params CreateBlueprintParams) (*Blueprint, error) {
+	queries := mdb.New(d.Connection)
+	err := queries.CreateBlueprint(d.Context, mdb.CreateBlueprintParams{
+		BlueprintID:  params.BlueprintID,
+		Name:         params.Name,
+		Label:        params.Label,
+		Description:  params.Description,
+		Definition:   params.Definition,
+		AuthorID:     params.AuthorID,
+		DateCreated:  params.DateCreated,
+		DateModified: params.DateModified,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blueprint: %v", err)
+	}
+	res := Blueprint(params)
+	return &res, nil
+}
+
+// GetBlueprint returns a blueprint by ID. The error wraps sql.ErrNoRows when
+// there is none.
+func (d Database) GetBlueprint(id string) (*Blueprint, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetBlueprint(d.Context, mdb.GetBlueprintParams{BlueprintID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blueprint: %w", err)
+	}
+	res := d.MapBlueprint(row)
+	return &res, nil
+}
+
+// GetBlueprintByName returns a blueprint by its unique name. The error wraps
+// sql.ErrNoRows when there is none.
+func (d Database) GetBlueprintByName(name string) (*Blueprint, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetBlueprintByName(d.Context, mdb.GetBlueprintByNameParams{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blueprint by name: %w", err)
+	}
+	res := d.MapBlueprint(row)
+	return &res, nil
+}
+
+// ListBlueprints returns every blueprint ordered by name.
+func (d Database) ListBlueprints() (*[]Blueprint, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListBlueprints(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprints: %v", err)
+	}
+	res := []Blueprint{}
+	for _, v := range rows {
+		res = append(res, d.MapBlueprint(v))
+	}
+	return &res, nil
+}
+
+// UpdateBlueprint replaces the name, label, description and definition of a
+// blueprint.
+func (d Database) UpdateBlueprint(params UpdateBlueprintParams) error {
+	queries := mdb.New(d.Connection)
+	err := queries.UpdateBlueprint(d.Context, mdb.UpdateBlueprintParams{
+		Name:         params.Name,
+		Label:        params.Label,
+		Description:  params.Description,
+		Definition:   params.Definition,
+		DateModified: params.DateModified,
+		BlueprintID:  params.BlueprintID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update blueprint: %v", err)
+	}
+	return nil
+}
+
+// DeleteBlueprint removes a blueprint. Its instance links are left to the
+// caller.
+func (d Database) DeleteBlueprint(id string) error {
+	queries := mdb.New(d.Connection)
+	if err := queries.DeleteBlueprint(d.Context, mdb.DeleteBlueprintParams{BlueprintID: id}); err != nil {
+		return fmt.Errorf("failed to delete blueprint: %v", err)
+	}
+	return nil
+}
+
+// CountBlueprints returns the number of blueprints.
+func (d Database) CountBlueprints() (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountBlueprints(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count blueprints: %v", err)
+	}
+	return &c, nil
+}
+
+// GetBlueprintInstance returns the blueprint link of a content node. The
+// error wraps sql.ErrNoRows when the node was not created from a blueprint.
+func (d Database) GetBlueprintInstance(id types.ContentID) (*BlueprintInstance, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetBlueprintInstance(d.Context, mdb.GetBlueprintInstanceParams{ContentDataID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blueprint instance: %w", err)
+	}
+	res := d.MapBlueprintInstance(row)
+	return &res, nil
+}
+
+// ListBlueprintInstances returns every instance whose content still exists,
+// grouped by blueprint.
+func (d Database) ListBlueprintInstances() (*[]BlueprintInstance, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListBlueprintInstances(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprint instances: %v", err)
+	}
+	res := []BlueprintInstance{}
+	for _, v := range rows {
+		res = append(res, d.MapBlueprintInstance(v))
+	}
+	return &res, nil
+}
+
+// ListBlueprintInstancesByBlueprint returns the instances of one blueprint
+// whose content still exists, oldest first.
+func (d Database) ListBlueprintInstancesByBlueprint(id string) (*[]BlueprintInstance, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListBlueprintInstancesByBlueprint(d.Context, mdb.ListBlueprintInstancesByBlueprintParams{BlueprintID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprint instances: %v", err)
+	}
+	res := []BlueprintInstance{}
+	for _, v := range rows {
+		res = append(res, d.MapBlueprintInstance(v))
+	}
+	return &res, nil
+}
+
+// DeleteBlueprintInstancesByBlueprint removes the instance links of a
+// blueprint. The content itself is not touched.
+func (d Database) DeleteBlueprintInstancesByBlueprint(id string) error {
+	queries := mdb.New(d.Connection)
+	if err := queries.DeleteBlueprintInstancesByBlueprint(d.Context, mdb.DeleteBlueprintInstancesByBlueprintParams{BlueprintID: id}); err != nil {
+		return fmt.Errorf("failed to delete blueprint instances: %v", err)
+	}
+	return nil
+}
+
+// CountBlueprintInstances returns the number of recorded instances.
+func (d Database) CountBlueprintInstances() (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountBlueprintInstances(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count blueprint instances: %v", err)
+	}
+	return &c, nil
+}
+
+///////////////////////////////
+// MYSQL
+//////////////////////////////
+
+// MAPS
+
+// MapBlueprint converts a sqlc-generated type to the wrapper type.
+func (d MysqlDatabase) MapBlueprint(a mdbm.Blueprints) Blueprint {
+	return Blueprint{
+		BlueprintID:  a.BlueprintID,
+		Name:         a.Name,
+		Label:        a.Label,
+		Description:  a.Description,
+		Definition:   a.Definition,
+		AuthorID:     a.AuthorID,
+		DateCreated:  a.DateCreated,
+		DateModified: a.DateModified,
+	}
+}
+
+// MapBlueprintInstance converts a sqlc-generated type to the wrapper type.
+func (d MysqlDatabase) MapBlueprintInstance(a mdbm.BlueprintInstances) BlueprintInstance {
+	return BlueprintInstance{
+		ContentDataID: a.ContentDataID,
+		BlueprintID:   a.BlueprintID,
+		RouteID:       a.RouteID,
+		Variables:     a.Variables,
+		AuthorID:      a.AuthorID,
+		DateCreated:   a.DateCreated,
+	}
+}
+
+// QUERIES
+
+// CreateBlueprintTable creates the blueprints table.
+func (d MysqlDatabase) CreateBlueprintTable() error {
+	queries := mdbm.New(d.Connection)
+	return queries.CreateBlueprintsTable(d.Context)
+}
+
+// CreateBlueprintInstanceTable creates the blueprint_instances table and its
+// index.
+func (d MysqlDatabase) CreateBlueprintInstanceTable() error {
+	queries := mdbm.New(d.Connection)
+	if err := queries.CreateBlueprintInstancesTable(d.Context); err != nil {
+		return err
+	}
+	// MySQL has no CREATE INDEX IF NOT EXISTS; a second run reports the
+	// existing index, which is expected.
+	if err := queries.CreateBlueprintInstancesIndexBlueprint(d.Context); err != nil && !strings.Contains(err.Error(), "Duplicate key name") {
+		return err
+	}
+	return nil
+}
+
+// CreateBlueprint saves a blueprint and returns it.
+func (d MysqlDatabase) CreateBlueprint(params CreateBlueprintParams) (*Blueprint, error) {
+	queries := mdbm.New(d.Connection)
+	err := queries.CreateBlueprint(d.Context, mdbm.CreateBlueprintParams{
+		BlueprintID:  params.BlueprintID,
+		Name:         params.Name,
+		Label:        params.Label,
+		Description:  params.Description,
+		Definition:   params.Definition,
+		AuthorID:     params.AuthorID,
+		DateCreated:  params.DateCreated,
+		DateModified: params.DateModified,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blueprint: %v", err)
+	}
+	res := Blueprint(params)
+	return &res, nil
+}
+
+// GetBlueprint returns a blueprint by ID. The error wraps sql.ErrNoRows when
+// there is none.
+func (d MysqlDatabase) GetBlueprint(id string) (*Blueprint, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetBlueprint(d.Context, mdbm.GetBlueprintParams{BlueprintID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blueprint: %w", err)
+	}
+	res := d.MapBlueprint(row)
+	return &res, nil
+}
+
+// GetBlueprintByName returns a blueprint by its unique name. The error wraps
+// sql.ErrNoRows when there is none.
+func (d MysqlDatabase) GetBlueprintByName(name string) (*Blueprint, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetBlueprintByName(d.Context, mdbm.GetBlueprintByNameParams{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blueprint by name: %w", err)
+	}
+	res := d.MapBlueprint(row)
+	return &res, nil
+}
+
+// ListBlueprints returns every blueprint ordered by name.
+func (d MysqlDatabase) ListBlueprints() (*[]Blueprint, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListBlueprints(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprints: %v", err)
+	}
+	res := []Blueprint{}
+	for _, v := range rows {
+		res = append(res, d.MapBlueprint(v))
+	}
+	return &res, nil
+}
+
+// UpdateBlueprint replaces the name, label, description and definition of a
+// blueprint.
+func (d MysqlDatabase) UpdateBlueprint(params UpdateBlueprintParams) error {
+	queries := mdbm.New(d.Connection)
+	err := queries.UpdateBlueprint(d.Context, mdbm.UpdateBlueprintParams{
+		Name:         params.Name,
+		Label:        params.Label,
+		Description:  params.Description,
+		Definition:   params.Definition,
+		DateModified: params.DateModified,
+		BlueprintID:  params.BlueprintID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update blueprint: %v", err)
+	}
+	return nil
+}
+
+// DeleteBlueprint removes a blueprint. Its instance links are left to the
+// caller.
+func (d MysqlDatabase) DeleteBlueprint(id string) error {
+	queries := mdbm.New(d.Connection)
+	if err := queries.DeleteBlueprint(d.Context, mdbm.DeleteBlueprintParams{BlueprintID: id}); err != nil {
+		return fmt.Errorf("failed to delete blueprint: %v", err)
+	}
+	return nil
+}
+
+// CountBlueprints returns the number of blueprints.
+func (d MysqlDatabase) CountBlueprints() (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountBlueprints(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count blueprints: %v", err)
+	}
+	return &c, nil
+}
+
+// GetBlueprintInstance returns the blueprint link of a content node. The
+// error wraps sql.ErrNoRows when the node was not created from a blueprint.
+func (d MysqlDatabase) GetBlueprintInstance(id types.ContentID) (*BlueprintInstance, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetBlueprintInstance(d.Context, mdbm.GetBlueprintInstanceParams{ContentDataID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blueprint instance: %w", err)
+	}
+	res := d.MapBlueprintInstance(row)
+	return &res, nil
+}
+
+// ListBlueprintInstances returns every instance whose content still exists,
+// grouped by blueprint.
+func (d MysqlDatabase) ListBlueprintInstances() (*[]BlueprintInstance, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListBlueprintInstances(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprint instances: %v", err)
+	}
+	res := []BlueprintInstance{}
+	for _, v := range rows {
+		res = append(res, d.MapBlueprintInstance(v))
+	}
+	return &res, nil
+}
+
+// ListBlueprintInstancesByBlueprint returns the instances of one blueprint
+// whose content still exists, oldest first.
+func (d MysqlDatabase) ListBlueprintInstancesByBlueprint(id string) (*[]BlueprintInstance, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListBlueprintInstancesByBlueprint(d.Context, mdbm.ListBlueprintInstancesByBlueprintParams{BlueprintID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprint instances: %v", err)
+	}
+	res := []BlueprintInstance{}
+	for _, v := range rows {
+		res = append(res, d.MapBlueprintInstance(v))
+	}
+	return &res, nil
+}
+
+// DeleteBlueprintInstancesByBlueprint removes the instance links of a
+// blueprint. The content itself is not touched.
+func (d MysqlDatabase) DeleteBlueprintInstancesByBlueprint(id string) error {
+	queries := mdbm.New(d.Connection)
+	if err := queries.DeleteBlueprintInstancesByBlueprint(d.Context, mdbm.DeleteBlueprintInstancesByBlueprintParams{BlueprintID: id}); err != nil {
+		return fmt.Errorf("failed to delete blueprint instances: %v", err)
+	}
+	return nil
+}
+
+// CountBlueprintInstances returns the number of recorded instances.
+func (d MysqlDatabase) CountBlueprintInstances() (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountBlueprintInstances(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count blueprint instances: %v", err)
+	}
+	return &c, nil
+}
+
+///////////////////////////////
+// POSTGRES
+//////////////////////////////
+
+// MAPS
+
+// MapBlueprint converts a sqlc-generated type to the wrapper type.
+func (d PsqlDatabase) MapBlueprint(a mdbp.Blueprints) Blueprint {
+	return Blueprint{
+		BlueprintID:  a.BlueprintID,
+		Name:         a.Name,
+		Label:        a.Label,
+		Description:  a.Description,
+		Definition:   a.Definition,
+		AuthorID:     a.AuthorID,
+		DateCreated:  a.DateCreated,
+		DateModified: a.DateModified,
+	}
+}
+
+// MapBlueprintInstance converts a sqlc-generated type to the wrapper type.
+func (d PsqlDatabase) MapBlueprintInstance(a mdbp.BlueprintInstances) BlueprintInstance {
+	return BlueprintInstance{
+		ContentDataID: a.ContentDataID,
+		BlueprintID:   a.BlueprintID,
+		RouteID:       a.RouteID,
+		Variables:     a.Variables,
+		AuthorID:      a.AuthorID,
+		DateCreated:   a.DateCreated,
+	}
+}
+
+// QUERIES
+
+// CreateBlueprintTable creates the blueprints table.
+func (d PsqlDatabase) CreateBlueprintTable() error {
+	queries := mdbp.New(d.Connection)
+	return queries.CreateBlueprintsTable(d.Context)
+}
+
+// CreateBlueprintInstanceTable creates the blueprint_instances table and its
+// index.
+func (d PsqlDatabase) CreateBlueprintInstanceTable() error {
+	queries := mdbp.New(d.Connection)
+	if err := queries.CreateBlueprintInstancesTable(d.Context); err != nil {
+		return err
+	}
+	return queries.CreateBlueprintInstancesIndexBlueprint(d.Context)
+}
+
+// CreateBlueprint saves a blueprint and returns it.
+func (d PsqlDatabase) CreateBlueprint(params CreateBlueprintParams) (*Blueprint, error) {
+	queries := mdbp.New(d.Connection)
+	err := queries.CreateBlueprint(d.Context, mdbp.CreateBlueprintParams{
+		BlueprintID:  params.BlueprintID,
+		Name:         params.Name,
+		Label:        params.Label,
+		Description:  params.Description,
+		Definition:   params.Definition,
+		AuthorID:     params.AuthorID,
+		DateCreated:  params.DateCreated,
+		DateModified: params.DateModified,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blueprint: %v", err)
+	}
+	res := Blueprint(params)
+	return &res, nil
+}
+
+// GetBlueprint returns a blueprint by ID. The error wraps sql.ErrNoRows when
+// there is none.
+func (d PsqlDatabase) GetBlueprint(id string) (*Blueprint, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetBlueprint(d.Context, mdbp.GetBlueprintParams{BlueprintID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blueprint: %w", err)
+	}
+	res := d.MapBlueprint(row)
+	return &res, nil
+}
+
+// GetBlueprintByName returns a blueprint by its unique name. The error wraps
+// sql.ErrNoRows when there is none.
+func (d PsqlDatabase) GetBlueprintByName(name string) (*Blueprint, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetBlueprintByName(d.Context, mdbp.GetBlueprintByNameParams{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blueprint by name: %w", err)
+	}
+	res := d.MapBlueprint(row)
+	return &res, nil
+}
+
+// ListBlueprints returns every blueprint ordered by name.
+func (d PsqlDatabase) ListBlueprints() (*[]Blueprint, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListBlueprints(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprints: %v", err)
+	}
+	res := []Blueprint{}
+	for _, v := range rows {
+		res = append(res, d.MapBlueprint(v))
+	}
+	return &res, nil
+}
+
+// UpdateBlueprint replaces the name, label, description and definition of a
+// blueprint.
+func (d PsqlDatabase) UpdateBlueprint(params UpdateBlueprintParams) error {
+	queries := mdbp.New(d.Connection)
+	err := queries.UpdateBlueprint(d.Context, mdbp.UpdateBlueprintParams{
+		Name:         params.Name,
+		Label:        params.Label,
+		Description:  params.Description,
+		Definition:   params.Definition,
+		DateModified: params.DateModified,
+		BlueprintID:  params.BlueprintID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update blueprint: %v", err)
+	}
+	return nil
+}
+
+// DeleteBlueprint removes a blueprint. Its instance links are left to the
+// caller.
+func (d PsqlDatabase) DeleteBlueprint(id string) error {
+	queries := mdbp.New(d.Connection)
+	if err := queries.DeleteBlueprint(d.Context, mdbp.DeleteBlueprintParams{BlueprintID: id}); err != nil {
+		return fmt.Errorf("failed to delete blueprint: %v", err)
+	}
+	return nil
+}
+
+// CountBlueprints returns the number of blueprints.
+func (d PsqlDatabase) CountBlueprints() (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountBlueprints(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count blueprints: %v", err)
+	}
+	return &c, nil
+}
+
+// GetBlueprintInstance returns the blueprint link of a content node. The
+// error wraps sql.ErrNoRows when the node was not created from a blueprint.
+func (d PsqlDatabase) GetBlueprintInstance(id types.ContentID) (*BlueprintInstance, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetBlueprintInstance(d.Context, mdbp.GetBlueprintInstanceParams{ContentDataID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blueprint instance: %w", err)
+	}
+	res := d.MapBlueprintInstance(row)
+	return &res, nil
+}
+
+// ListBlueprintInstances returns every instance whose content still exists,
+// grouped by blueprint.
+func (d PsqlDatabase) ListBlueprintInstances() (*[]BlueprintInstance, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListBlueprintInstances(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprint instances: %v", err)
+	}
+	res := []BlueprintInstance{}
+	for _, v := range rows {
+		res = append(res, d.MapBlueprintInstance(v))
+	}
+	return &res, nil
+}
+
+// ListBlueprintInstancesByBlueprint returns the instances of one blueprint
+// whose content still exists, oldest first.
+func (d PsqlDatabase) ListBlueprintInstancesByBlueprint(id string) (*[]BlueprintInstance, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListBlueprintInstancesByBlueprint(d.Context, mdbp.ListBlueprintInstancesByBlueprintParams{BlueprintID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprint instances: %v", err)
+	}
+	res := []BlueprintInstance{}
+	for _, v := range rows {
+		res = append(res, d.MapBlueprintInstance(v))
+	}
+	return &res, nil
+}
+
+// DeleteBlueprintInstancesByBlueprint removes the instance links of a
+// blueprint. The content itself is not touched.
+func (d PsqlDatabase) DeleteBlueprintInstancesByBlueprint(id string) error {
+	queries := mdbp.New(d.Connection)
+	if err := queries.DeleteBlueprintInstancesByBlueprint(d.Context, mdbp.DeleteBlueprintInstancesByBlueprintParams{BlueprintID: id}); err != nil {
+		return fmt.Errorf("failed to delete blueprint instances: %v", err)
+	}
+	return nil
+}
+
+// CountBlueprintInstances returns the number of recorded instances.
+func (d PsqlDatabase) CountBlueprintInstances() (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountBlueprintInstances(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count blueprint instances: %v", err)
+	}
+	return &c, nil
+}
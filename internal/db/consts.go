@@ -45,12 +45,26 @@ const (
 	User                    DBTable = "users"
 	User_oauth              DBTable = "user_oauth"
 	User_ssh_keys           DBTable = "user_ssh_keys"
+	User_roles              DBTable = "user_roles"
+	User_groups             DBTable = "user_groups"
+	User_group_members      DBTable = "user_group_members"
+	User_group_roles        DBTable = "user_group_roles"
+	User_lockouts           DBTable = "user_lockouts"
 	ValidationT             DBTable = "validations"
 	Admin_validation        DBTable = "admin_validations"
 	Admin_media             DBTable = "admin_media"
 	Admin_media_folder      DBTable = "admin_media_folders"
 	WebhookT                DBTable = "webhooks"
 	Webhook_deliveries      DBTable = "webhook_deliveries"
+	Password_history        DBTable = "password_history"
+	Content_migration       DBTable = "content_migrations"
+	Content_leases          DBTable = "content_leases"
+	Translation_memory      DBTable = "translation_memory"
+	Translation_reviews     DBTable = "translation_reviews"
+	Translation_sources     DBTable = "translation_sources"
+	Blueprints              DBTable = "blueprints"
+	Blueprint_instances     DBTable = "blueprint_instances"
+	PluginT                 DBTable = "plugins"
 )
 
 // allTables is the exhaustive set of known table names for validation.
@@ -90,12 +104,20 @@ var allTables = map[DBTable]struct{}{
 	User:                    {},
 	User_oauth:              {},
 	User_ssh_keys:           {},
+	User_roles:              {},
+	User_groups:             {},
+	User_group_members:      {},
+	User_group_roles:        {},
+	User_lockouts:           {},
 	ValidationT:             {},
 	Admin_validation:        {},
 	Admin_media:             {},
 	Admin_media_folder:      {},
 	WebhookT:                {},
 	Webhook_deliveries:      {},
+	Password_history:        {},
+	Content_migration:       {},
+	PluginT:                 {},
 }
 
 // AllTablesExported returns a copy of the allTables map for external iteration.
@@ -179,6 +201,11 @@ var TableStructMap = map[DBTable]reflect.Type{
 	User:                    reflect.TypeFor[Users](),
 	User_oauth:              reflect.TypeFor[UserOauth](),
 	User_ssh_keys:           reflect.TypeFor[UserSshKeys](),
+	User_roles:              reflect.TypeFor[UserRoles](),
+	User_groups:             reflect.TypeFor[UserGroups](),
+	User_group_members:      reflect.TypeFor[UserGroupMembers](),
+	User_group_roles:        reflect.TypeFor[UserGroupRoles](),
+	User_lockouts:           reflect.TypeFor[UserLockout](),
 	ValidationT:             reflect.TypeFor[Validation](),
 	Admin_validation:        reflect.TypeFor[AdminValidation](),
 	Admin_media:             reflect.TypeFor[AdminMedia](),
@@ -334,6 +361,26 @@ func CastToTypedSlice(result any, table DBTable) any {
 		if slice, ok := result.([]UserSshKeys); ok {
 			return slice
 		}
+	case User_roles:
+		if slice, ok := result.([]UserRoles); ok {
+			return slice
+		}
+	case User_groups:
+		if slice, ok := result.([]UserGroups); ok {
+			return slice
+		}
+	case User_group_members:
+		if slice, ok := result.([]UserGroupMembers); ok {
+			return slice
+		}
+	case User_group_roles:
+		if slice, ok := result.([]UserGroupRoles); ok {
+			return slice
+		}
+	case User_lockouts:
+		if slice, ok := result.([]UserLockout); ok {
+			return slice
+		}
 	case ValidationT:
 		if slice, ok := result.([]Validation); ok {
 			return slice
@@ -31,6 +31,28 @@ func GetContentDataInTx(d DbDriver, ctx context.Context, tx *sql.Tx, id types.Co
 	}
 }
 
+// CreateContentDataInTx creates a content data row with audit trail in an
+// existing transaction. Sibling pointers are stored as given; splicing the
+// node into a chain is the caller's job.
+func CreateContentDataInTx(d DbDriver, ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params CreateContentDataParams) (*ContentData, error) {
+	switch drv := d.(type) {
+	case Database:
+		cmd := Database{}.NewContentDataCmd(ctx, ac, params)
+		result, err := audited.CreateInTx(cmd, tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create content_data: %w", err)
+		}
+		r := drv.MapContentData(result)
+		return &r, nil
+	case MysqlDatabase:
+		return createContentDataInTxMySQL(drv, ctx, tx, ac, params)
+	case PsqlDatabase:
+		return createContentDataInTxPsql(drv, ctx, tx, ac, params)
+	default:
+		return nil, fmt.Errorf("tx create content_data: unsupported driver type %T", d)
+	}
+}
+
 // UpdateContentDataInTx updates a content data row within the provided transaction,
 // including audited change event recording. The update and its audit record are
 // part of the caller's transaction — they commit or rollback together.
@@ -20,6 +20,17 @@ func getContentDataInTxMySQL(d MysqlDatabase, ctx context.Context, tx *sql.Tx, i
 	return &res, nil
 }
 
+func createContentDataInTxMySQL(d MysqlDatabase, ctx context.Context, tx *sql.Tx,
+	ac audited.AuditContext, params CreateContentDataParams) (*ContentData, error) {
+	cmd := MysqlDatabase{}.NewContentDataCmd(ctx, ac, params)
+	result, err := audited.CreateInTx(cmd, tx)
+	if err != nil {
+		return nil, fmt.Errorf("tx create content_data: %w", err)
+	}
+	r := d.MapContentData(result)
+	return &r, nil
+}
+
 func updateContentDataInTxMySQL(ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params UpdateContentDataParams) error {
 	cmd := MysqlDatabase{}.UpdateContentDataCmd(ctx, ac, params)
 	return audited.UpdateInTx(cmd, tx)
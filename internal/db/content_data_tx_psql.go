@@ -20,6 +20,17 @@ func getContentDataInTxPsql(d PsqlDatabase, ctx context.Context, tx *sql.Tx, id
 	return &res, nil
 }
 
+func createContentDataInTxPsql(d PsqlDatabase, ctx context.Context, tx *sql.Tx,
+	ac audited.AuditContext, params CreateContentDataParams) (*ContentData, error) {
+	cmd := PsqlDatabase{}.NewContentDataCmd(ctx, ac, params)
+	result, err := audited.CreateInTx(cmd, tx)
+	if err != nil {
+		return nil, fmt.Errorf("tx create content_data: %w", err)
+	}
+	r := d.MapContentData(result)
+	return &r, nil
+}
+
 func updateContentDataInTxPsql(ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params UpdateContentDataParams) error {
 	cmd := PsqlDatabase{}.UpdateContentDataCmd(ctx, ac, params)
 	return audited.UpdateInTx(cmd, tx)
@@ -52,6 +52,7 @@ func rowToContentFields(row Row) ContentFields {
 	cf := ContentFields{}
 	cf.ContentFieldID = types.ContentFieldID(rowString(row, "content_field_id"))
 	cf.RouteID = rowNullableRouteID(row, "route_id")
+	cf.RootID = rowNullableContentID(row, "root_id")
 	cf.ContentDataID = rowNullableContentID(row, "content_data_id")
 	cf.FieldID = rowNullableFieldID(row, "field_id")
 	cf.FieldValue = rowString(row, "field_value")
@@ -0,0 +1,157 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// txDialect returns the query builder dialect for a concrete driver, for
+// InTx helpers built on QSelect.
+func txDialect(d DbDriver) (Dialect, error) {
+	switch d.(type) {
+	case Database:
+		return DialectSQLite, nil
+	case MysqlDatabase:
+		return DialectMySQL, nil
+	case PsqlDatabase:
+		return DialectPostgres, nil
+	default:
+		return DialectSQLite, fmt.Errorf("unsupported driver type %T", d)
+	}
+}
+
+// ListContentFieldsByFieldInTx returns up to limit content fields for a field
+// definition, ordered by content_field_id and starting after the given ID.
+// Keyset pagination keeps pages stable when earlier pages delete rows.
+func ListContentFieldsByFieldInTx(d DbDriver, ctx context.Context, tx *sql.Tx, fieldID types.FieldID, after types.ContentFieldID, limit int64) ([]ContentFields, error) {
+	dialect, err := txDialect(d)
+	if err != nil {
+		return nil, fmt.Errorf("tx list content_fields by field: %w", err)
+	}
+	where := map[string]any{"field_id": fieldID.String()}
+	if after != "" {
+		where["content_field_id"] = Gt(string(after))
+	}
+	rows, err := QSelect(ctx, tx, dialect, SelectParams{
+		Table:   "content_fields",
+		Where:   where,
+		OrderBy: "content_field_id",
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tx list content_fields by field %s: %w", fieldID, err)
+	}
+	result := make([]ContentFields, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, rowToContentFields(row))
+	}
+	return result, nil
+}
+
+// FindContentFieldInTx returns the value row for one field of a content node
+// in a locale, or nil when the node has no value for it.
+func FindContentFieldInTx(d DbDriver, ctx context.Context, tx *sql.Tx, contentDataID types.ContentID, fieldID types.FieldID, locale string) (*ContentFields, error) {
+	dialect, err := txDialect(d)
+	if err != nil {
+		return nil, fmt.Errorf("tx find content_field: %w", err)
+	}
+	rows, err := QSelect(ctx, tx, dialect, SelectParams{
+		Table: "content_fields",
+		Where: map[string]any{
+			"content_data_id": contentDataID.String(),
+			"field_id":        fieldID.String(),
+			"locale":          locale,
+		},
+		OrderBy: "content_field_id",
+		Limit:   1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tx find content_field for %s/%s: %w", contentDataID, fieldID, err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	cf := rowToContentFields(rows[0])
+	return &cf, nil
+}
+
+// ListContentDataChildIDsInTx returns the IDs of the direct children of a
+// content node.
+func ListContentDataChildIDsInTx(d DbDriver, ctx context.Context, tx *sql.Tx, parentID types.ContentID) ([]types.ContentID, error) {
+	dialect, err := txDialect(d)
+	if err != nil {
+		return nil, fmt.Errorf("tx list content_data children: %w", err)
+	}
+	rows, err := QSelect(ctx, tx, dialect, SelectParams{
+		Table:   "content_data",
+		Columns: []string{"content_data_id"},
+		Where:   map[string]any{"parent_id": parentID.String()},
+		OrderBy: "content_data_id",
+		Limit:   -1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tx list children of %s: %w", parentID, err)
+	}
+	ids := make([]types.ContentID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, types.ContentID(rowString(row, "content_data_id")))
+	}
+	return ids, nil
+}
+
+// CreateContentFieldInTx creates a content field with audit trail in an
+// existing transaction.
+func CreateContentFieldInTx(d DbDriver, ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params CreateContentFieldParams) (*ContentFields, error) {
+	switch drv := d.(type) {
+	case Database:
+		cmd := Database{}.NewContentFieldCmd(ctx, ac, params)
+		result, err := audited.CreateInTx(cmd, tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create content_field: %w", err)
+		}
+		r := drv.MapContentField(result)
+		return &r, nil
+	case MysqlDatabase:
+		return createContentFieldInTxMySQL(drv, ctx, tx, ac, params)
+	case PsqlDatabase:
+		return createContentFieldInTxPsql(drv, ctx, tx, ac, params)
+	default:
+		return nil, fmt.Errorf("tx create content_field: unsupported driver type %T", d)
+	}
+}
+
+// UpdateContentFieldInTx updates a content field with audit trail in an
+// existing transaction.
+func UpdateContentFieldInTx(d DbDriver, ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params UpdateContentFieldParams) error {
+	switch d.(type) {
+	case Database:
+		cmd := Database{}.UpdateContentFieldCmd(ctx, ac, params)
+		return audited.UpdateInTx(cmd, tx)
+	case MysqlDatabase:
+		return updateContentFieldInTxMySQL(ctx, tx, ac, params)
+	case PsqlDatabase:
+		return updateContentFieldInTxPsql(ctx, tx, ac, params)
+	default:
+		return fmt.Errorf("tx update content_field: unsupported driver type %T", d)
+	}
+}
+
+// DeleteContentFieldInTx deletes a content field with audit trail in an
+// existing transaction.
+func DeleteContentFieldInTx(d DbDriver, ctx context.Context, tx *sql.Tx, ac audited.AuditContext, id types.ContentFieldID) error {
+	switch d.(type) {
+	case Database:
+		cmd := Database{}.DeleteContentFieldCmd(ctx, ac, id)
+		return audited.DeleteInTx(cmd, tx)
+	case MysqlDatabase:
+		return deleteContentFieldInTxMySQL(ctx, tx, ac, id)
+	case PsqlDatabase:
+		return deleteContentFieldInTxPsql(ctx, tx, ac, id)
+	default:
+		return fmt.Errorf("tx delete content_field: unsupported driver type %T", d)
+	}
+}
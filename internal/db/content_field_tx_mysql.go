@@ -0,0 +1,31 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+func createContentFieldInTxMySQL(d MysqlDatabase, ctx context.Context, tx *sql.Tx,
+	ac audited.AuditContext, params CreateContentFieldParams) (*ContentFields, error) {
+	cmd := MysqlDatabase{}.NewContentFieldCmd(ctx, ac, params)
+	result, err := audited.CreateInTx(cmd, tx)
+	if err != nil {
+		return nil, fmt.Errorf("tx create content_field: %w", err)
+	}
+	r := d.MapContentField(result)
+	return &r, nil
+}
+
+func updateContentFieldInTxMySQL(ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params UpdateContentFieldParams) error {
+	cmd := MysqlDatabase{}.UpdateContentFieldCmd(ctx, ac, params)
+	return audited.UpdateInTx(cmd, tx)
+}
+
+func deleteContentFieldInTxMySQL(ctx context.Context, tx *sql.Tx, ac audited.AuditContext, id types.ContentFieldID) error {
+	cmd := MysqlDatabase{}.DeleteContentFieldCmd(ctx, ac, id)
+	return audited.DeleteInTx(cmd, tx)
+}
@@ -0,0 +1,31 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+func createContentFieldInTxPsql(d PsqlDatabase, ctx context.Context, tx *sql.Tx,
+	ac audited.AuditContext, params CreateContentFieldParams) (*ContentFields, error) {
+	cmd := PsqlDatabase{}.NewContentFieldCmd(ctx, ac, params)
+	result, err := audited.CreateInTx(cmd, tx)
+	if err != nil {
+		return nil, fmt.Errorf("tx create content_field: %w", err)
+	}
+	r := d.MapContentField(result)
+	return &r, nil
+}
+
+func updateContentFieldInTxPsql(ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params UpdateContentFieldParams) error {
+	cmd := PsqlDatabase{}.UpdateContentFieldCmd(ctx, ac, params)
+	return audited.UpdateInTx(cmd, tx)
+}
+
+func deleteContentFieldInTxPsql(ctx context.Context, tx *sql.Tx, ac audited.AuditContext, id types.ContentFieldID) error {
+	cmd := PsqlDatabase{}.DeleteContentFieldCmd(ctx, ac, id)
+	return audited.DeleteInTx(cmd, tx)
+}
@@ -0,0 +1,424 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// Content leases are advisory edit locks and presence records for content
+// nodes. Each row is one client session viewing or editing a node, kept alive
+// by heartbeats until expires_at. Rows are short-lived, written without
+// audited commands and never recorded in change_events; forced takeovers are
+// audited by the presence package.
+
+///////////////////////////////
+// STRUCTS
+//////////////////////////////
+
+// ContentLease is one client's hold on a content node. AcquiredAt and
+// ExpiresAt are Unix seconds.
+type ContentLease struct {
+	LeaseID       string          `json:"lease_id"`
+	ContentDataID types.ContentID `json:"content_data_id"`
+	UserID        types.UserID    `json:"user_id"`
+	Username      string          `json:"username"`
+	Client        string          `json:"client"`
+	Mode          string          `json:"mode"`
+	AcquiredAt    int64           `json:"acquired_at"`
+	ExpiresAt     int64           `json:"expires_at"`
+}
+
+// CreateContentLeaseParams contains parameters for creating a content lease.
+type CreateContentLeaseParams struct {
+	LeaseID       string          `json:"lease_id"`
+	ContentDataID types.ContentID `json:"content_data_id"`
+	UserID        types.UserID    `json:"user_id"`
+	Username      string          `json:"username"`
+	Client        string          `json:"client"`
+	Mode          string          `json:"mode"`
+	AcquiredAt    int64           `json:"acquired_at"`
+	ExpiresAt     int64           `json:"expires_at"`
+}
+
+// UpdateContentLeaseParams changes a lease's mode and extends its expiry.
+type UpdateContentLeaseParams struct {
+	LeaseID   string `json:"lease_id"`
+	Mode      string `json:"mode"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+///////////////////////////////
+// SQLITE
+//////////////////////////////
+
+// MAPS
+
+// MapContentLease converts a sqlc-generated type to the wrapper type.
+func (d Database) MapContentLease(a mdb.ContentLeases) ContentLease {
+	return ContentLease{
+		LeaseID:       a.LeaseID,
+		ContentDataID: a.ContentDataID,
+		UserID:        a.UserID,
+		Username:      a.Username,
+		Client:        a.Client,
+		Mode:          a.Mode,
+		AcquiredAt:    a.AcquiredAt,
+		ExpiresAt:     a.ExpiresAt,
+	}
+}
+
+// QUERIES
+
+// CreateContentLeaseTable creates the content_leases table and its index.
+func (d Database) CreateContentLeaseTable() error {
+	queries := mdb.New(d.Connection)
+	if err := queries.CreateContentLeasesTable(d.Context); err != nil {
+		return err
+	}
+	if err := queries.CreateContentLeasesIndexContent(d.Context); err != nil {
+		return err
+	}
+	return queries.CreateContentLeasesIndexEditing(d.Context)
+}
+
+// CreateContentLease inserts a lease and returns it.
+func (d Database) CreateContentLease(params CreateContentLeaseParams) (*ContentLease, error) {
+	queries := mdb.New(d.Connection)
+	err := queries.CreateContentLease(d.Context, mdb.CreateContentLeaseParams{
+		LeaseID:       params.LeaseID,
+		ContentDataID: params.ContentDataID,
+		UserID:        params.UserID,
+		Username:      params.Username,
+		Client:        params.Client,
+		Mode:          params.Mode,
+		AcquiredAt:    params.AcquiredAt,
+		ExpiresAt:     params.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create content lease: %v", err)
+	}
+	res := ContentLease(params)
+	return &res, nil
+}
+
+// GetContentLease returns a lease by ID, expired or not.
+func (d Database) GetContentLease(id string) (*ContentLease, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetContentLease(d.Context, mdb.GetContentLeaseParams{LeaseID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content lease: %w", err)
+	}
+	res := d.MapContentLease(row)
+	return &res, nil
+}
+
+// ListContentLeasesByContent returns the leases on a node that expire at or
+// after the given Unix time, oldest first.
+func (d Database) ListContentLeasesByContent(contentID types.ContentID, now int64) (*[]ContentLease, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListContentLeasesByContent(d.Context, mdb.ListContentLeasesByContentParams{
+		ContentDataID: contentID,
+		ExpiresAt:     now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content leases: %v", err)
+	}
+	res := []ContentLease{}
+	for _, v := range rows {
+		res = append(res, d.MapContentLease(v))
+	}
+	return &res, nil
+}
+
+// UpdateContentLease changes a lease's mode and expiry.
+func (d Database) UpdateContentLease(params UpdateContentLeaseParams) error {
+	queries := mdb.New(d.Connection)
+	err := queries.UpdateContentLease(d.Context, mdb.UpdateContentLeaseParams{
+		Mode:      params.Mode,
+		ExpiresAt: params.ExpiresAt,
+		LeaseID:   params.LeaseID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update content lease: %v", err)
+	}
+	return nil
+}
+
+// DeleteContentLease removes a lease.
+func (d Database) DeleteContentLease(id string) error {
+	queries := mdb.New(d.Connection)
+	if err := queries.DeleteContentLease(d.Context, mdb.DeleteContentLeaseParams{LeaseID: id}); err != nil {
+		return fmt.Errorf("failed to delete content lease: %v", err)
+	}
+	return nil
+}
+
+// DeleteExpiredContentLeases removes leases that expired before the given Unix time.
+func (d Database) DeleteExpiredContentLeases(before int64) error {
+	queries := mdb.New(d.Connection)
+	return queries.DeleteExpiredContentLeases(d.Context, mdb.DeleteExpiredContentLeasesParams{ExpiresAt: before})
+}
+
+// CountContentLeases returns the number of stored content leases.
+func (d Database) CountContentLeases() (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountContentLeases(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count content leases: %v", err)
+	}
+	return &c, nil
+}
+
+///////////////////////////////
+// MYSQL
+//////////////////////////////
+
+// MAPS
+
+// MapContentLease converts a sqlc-generated type to the wrapper type.
+func (d MysqlDatabase) MapContentLease(a mdbm.ContentLeases) ContentLease {
+	return ContentLease{
+		LeaseID:       a.LeaseID,
+		ContentDataID: a.ContentDataID,
+		UserID:        a.UserID,
+		Username:      a.Username,
+		Client:        a.Client,
+		Mode:          a.Mode,
+		AcquiredAt:    a.AcquiredAt,
+		ExpiresAt:     a.ExpiresAt,
+	}
+}
+
+// QUERIES
+
+// CreateContentLeaseTable creates the content_leases table and its index.
+func (d MysqlDatabase) CreateContentLeaseTable() error {
+	queries := mdbm.New(d.Connection)
+	if err := queries.CreateContentLeasesTable(d.Context); err != nil {
+		return err
+	}
+	// MySQL has no CREATE INDEX IF NOT EXISTS; a second run reports the
+	// existing index, which is expected.
+	if err := queries.CreateContentLeasesIndexContent(d.Context); err != nil && !strings.Contains(err.Error(), "Duplicate key name") {
+		return err
+	}
+	if err := queries.CreateContentLeasesIndexEditing(d.Context); err != nil && !strings.Contains(err.Error(), "Duplicate key name") {
+		return err
+	}
+	return nil
+}
+
+// CreateContentLease inserts a lease and returns it.
+func (d MysqlDatabase) CreateContentLease(params CreateContentLeaseParams) (*ContentLease, error) {
+	queries := mdbm.New(d.Connection)
+	err := queries.CreateContentLease(d.Context, mdbm.CreateContentLeaseParams{
+		LeaseID:       params.LeaseID,
+		ContentDataID: params.ContentDataID,
+		UserID:        params.UserID,
+		Username:      params.Username,
+		Client:        params.Client,
+		Mode:          params.Mode,
+		AcquiredAt:    params.AcquiredAt,
+		ExpiresAt:     params.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create content lease: %v", err)
+	}
+	res := ContentLease(params)
+	return &res, nil
+}
+
+// GetContentLease returns a lease by ID, expired or not.
+func (d MysqlDatabase) GetContentLease(id string) (*ContentLease, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetContentLease(d.Context, mdbm.GetContentLeaseParams{LeaseID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content lease: %w", err)
+	}
+	res := d.MapContentLease(row)
+	return &res, nil
+}
+
+// ListContentLeasesByContent returns the leases on a node that expire at or
+// after the given Unix time, oldest first.
+func (d MysqlDatabase) ListContentLeasesByContent(contentID types.ContentID, now int64) (*[]ContentLease, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListContentLeasesByContent(d.Context, mdbm.ListContentLeasesByContentParams{
+		ContentDataID: contentID,
+		ExpiresAt:     now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content leases: %v", err)
+	}
+	res := []ContentLease{}
+	for _, v := range rows {
+		res = append(res, d.MapContentLease(v))
+	}
+	return &res, nil
+}
+
+// UpdateContentLease changes a lease's mode and expiry.
+func (d MysqlDatabase) UpdateContentLease(params UpdateContentLeaseParams) error {
+	queries := mdbm.New(d.Connection)
+	err := queries.UpdateContentLease(d.Context, mdbm.UpdateContentLeaseParams{
+		Mode:      params.Mode,
+		ExpiresAt: params.ExpiresAt,
+		LeaseID:   params.LeaseID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update content lease: %v", err)
+	}
+	return nil
+}
+
+// DeleteContentLease removes a lease.
+func (d MysqlDatabase) DeleteContentLease(id string) error {
+	queries := mdbm.New(d.Connection)
+	if err := queries.DeleteContentLease(d.Context, mdbm.DeleteContentLeaseParams{LeaseID: id}); err != nil {
+		return fmt.Errorf("failed to delete content lease: %v", err)
+	}
+	return nil
+}
+
+// DeleteExpiredContentLeases removes leases that expired before the given Unix time.
+func (d MysqlDatabase) DeleteExpiredContentLeases(before int64) error {
+	queries := mdbm.New(d.Connection)
+	return queries.DeleteExpiredContentLeases(d.Context, mdbm.DeleteExpiredContentLeasesParams{ExpiresAt: before})
+}
+
+// CountContentLeases returns the number of stored content leases.
+func (d MysqlDatabase) CountContentLeases() (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountContentLeases(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count content leases: %v", err)
+	}
+	return &c, nil
+}
+
+///////////////////////////////
+// POSTGRES
+//////////////////////////////
+
+// MAPS
+
+// MapContentLease converts a sqlc-generated type to the wrapper type.
+func (d PsqlDatabase) MapContentLease(a mdbp.ContentLeases) ContentLease {
+	return ContentLease{
+		LeaseID:       a.LeaseID,
+		ContentDataID: a.ContentDataID,
+		UserID:        a.UserID,
+		Username:      a.Username,
+		Client:        a.Client,
+		Mode:          a.Mode,
+		AcquiredAt:    a.AcquiredAt,
+		ExpiresAt:     a.ExpiresAt,
+	}
+}
+
+// QUERIES
+
+// CreateContentLeaseTable creates the content_leases table and its index.
+func (d PsqlDatabase) CreateContentLeaseTable() error {
+	queries := mdbp.New(d.Connection)
+	if err := queries.CreateContentLeasesTable(d.Context); err != nil {
+		return err
+	}
+	if err := queries.CreateContentLeasesIndexContent(d.Context); err != nil {
+		return err
+	}
+	return queries.CreateContentLeasesIndexEditing(d.Context)
+}
+
+// CreateContentLease inserts a lease and returns it.
+func (d PsqlDatabase) CreateContentLease(params CreateContentLeaseParams) (*ContentLease, error) {
+	queries := mdbp.New(d.Connection)
+	err := queries.CreateContentLease(d.Context, mdbp.CreateContentLeaseParams{
+		LeaseID:       params.LeaseID,
+		ContentDataID: params.ContentDataID,
+		UserID:        params.UserID,
+		Username:      params.Username,
+		Client:        params.Client,
+		Mode:          params.Mode,
+		AcquiredAt:    params.AcquiredAt,
+		ExpiresAt:     params.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create content lease: %v", err)
+	}
+	res := ContentLease(params)
+	return &res, nil
+}
+
+// GetContentLease returns a lease by ID, expired or not.
+func (d PsqlDatabase) GetContentLease(id string) (*ContentLease, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetContentLease(d.Context, mdbp.GetContentLeaseParams{LeaseID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content lease: %w", err)
+	}
+	res := d.MapContentLease(row)
+	return &res, nil
+}
+
+// ListContentLeasesByContent returns the leases on a node that expire at or
+// after the given Unix time, oldest first.
+func (d PsqlDatabase) ListContentLeasesByContent(contentID types.ContentID, now int64) (*[]ContentLease, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListContentLeasesByContent(d.Context, mdbp.ListContentLeasesByContentParams{
+		ContentDataID: contentID,
+		ExpiresAt:     now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content leases: %v", err)
+	}
+	res := []ContentLease{}
+	for _, v := range rows {
+		res = append(res, d.MapContentLease(v))
+	}
+	return &res, nil
+}
+
+// UpdateContentLease changes a lease's mode and expiry.
+func (d PsqlDatabase) UpdateContentLease(params UpdateContentLeaseParams) error {
+	queries := mdbp.New(d.Connection)
+	err := queries.UpdateContentLease(d.Context, mdbp.UpdateContentLeaseParams{
+		Mode:      params.Mode,
+		ExpiresAt: params.ExpiresAt,
+		LeaseID:   params.LeaseID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update content lease: %v", err)
+	}
+	return nil
+}
+
+// DeleteContentLease removes a lease.
+func (d PsqlDatabase) DeleteContentLease(id string) error {
+	queries := mdbp.New(d.Connection)
+	if err := queries.DeleteContentLease(d.Context, mdbp.DeleteContentLeaseParams{LeaseID: id}); err != nil {
+		return fmt.Errorf("failed to delete content lease: %v", err)
+	}
+	return nil
+}
+
+// DeleteExpiredContentLeases removes leases that expired before the given Unix time.
+func (d PsqlDatabase) DeleteExpiredContentLeases(before int64) error {
+	queries := mdbp.New(d.Connection)
+	return queries.DeleteExpiredContentLeases(d.Context, mdbp.DeleteExpiredContentLeasesParams{ExpiresAt: before})
+}
+
+// CountContentLeases returns the number of stored content leases.
+func (d PsqlDatabase) CountContentLeases() (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountContentLeases(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count content leases: %v", err)
+	}
+	return &c, nil
+}
@@ -0,0 +1,96 @@
+// Integration tests for the content_leases presence table.
+//
+// The table is NON-audited (no ctx/ac parameters on mutations).
+package db
+
+import (
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+func TestDatabase_ContentLease_Lifecycle(t *testing.T) {
+	t.Parallel()
+	d := testIntegrationDB(t)
+
+	contentID := types.NewContentID()
+	userID := types.NewUserID()
+	editor, err := d.CreateContentLease(CreateContentLeaseParams{
+		LeaseID:       types.NewULID().String(),
+		ContentDataID: contentID,
+		UserID:        userID,
+		Username:      "alice",
+		Client:        "admin",
+		Mode:          "editing",
+		AcquiredAt:    1000,
+		ExpiresAt:     1060,
+	})
+	if err != nil {
+		t.Fatalf("CreateContentLease: %v", err)
+	}
+	viewer, err := d.CreateContentLease(CreateContentLeaseParams{
+		LeaseID:       types.NewULID().String(),
+		ContentDataID: contentID,
+		UserID:        types.NewUserID(),
+		Username:      "bob",
+		Client:        "tui",
+		Mode:          "viewing",
+		AcquiredAt:    1010,
+		ExpiresAt:     1030,
+	})
+	if err != nil {
+		t.Fatalf("CreateContentLease (viewer): %v", err)
+	}
+
+	got, err := d.GetContentLease(editor.LeaseID)
+	if err != nil {
+		t.Fatalf("GetContentLease: %v", err)
+	}
+	if got.UserID != userID || got.Username != "alice" || got.Mode != "editing" {
+		t.Errorf("GetContentLease = %+v", got)
+	}
+
+	// Leases that expired before the given time are left out.
+	live, err := d.ListContentLeasesByContent(contentID, 1040)
+	if err != nil {
+		t.Fatalf("ListContentLeasesByContent: %v", err)
+	}
+	if len(*live) != 1 || (*live)[0].LeaseID != editor.LeaseID {
+		t.Errorf("live leases = %+v, want only the editor", *live)
+	}
+
+	// A node has at most one editing lease.
+	if err := d.UpdateContentLease(UpdateContentLeaseParams{LeaseID: viewer.LeaseID, Mode: "editing", ExpiresAt: 1100}); err == nil {
+		t.Fatal("UpdateContentLease to a second editing lease: expected error")
+	}
+	if err := d.UpdateContentLease(UpdateContentLeaseParams{LeaseID: editor.LeaseID, Mode: "viewing", ExpiresAt: 1060}); err != nil {
+		t.Fatalf("UpdateContentLease (editor): %v", err)
+	}
+	if err := d.UpdateContentLease(UpdateContentLeaseParams{LeaseID: viewer.LeaseID, Mode: "editing", ExpiresAt: 1100}); err != nil {
+		t.Fatalf("UpdateContentLease: %v", err)
+	}
+	live, err = d.ListContentLeasesByContent(contentID, 1040)
+	if err != nil {
+		t.Fatalf("ListContentLeasesByContent after update: %v", err)
+	}
+	if len(*live) != 2 || (*live)[1].Mode != "editing" {
+		t.Errorf("live leases after update = %+v", *live)
+	}
+
+	if err := d.DeleteContentLease(editor.LeaseID); err != nil {
+		t.Fatalf("DeleteContentLease: %v", err)
+	}
+	if _, err := d.GetContentLease(editor.LeaseID); err == nil {
+		t.Error("GetContentLease after delete: expected error")
+	}
+	if err := d.DeleteExpiredContentLeases(1200); err != nil {
+		t.Fatalf("DeleteExpiredContentLeases: %v", err)
+	}
+	count, err := d.CountContentLeases()
+	if err != nil {
+		t.Fatalf("CountContentLeases: %v", err)
+	}
+	if *count != 0 {
+		t.Errorf("count after sweep = %d, want 0", *count)
+	}
+}
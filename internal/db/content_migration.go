@@ -0,0 +1,312 @@
+package db
+
+import (
+	"fmt"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// Content migrations rewrite content_fields values after a field changes type
+// or structure. This table records which migrations have run so each one is
+// applied once, and how far an interrupted run got so a rerun resumes there;
+// the rewrites themselves are audited per row.
+
+///////////////////////////////
+// STRUCTS
+//////////////////////////////
+
+// ContentMigration records one content migration. Until Completed is set it
+// is a migration whose run stopped partway, and LastContentFieldID is the
+// last source value its committed batches applied.
+type ContentMigration struct {
+	MigrationID        string               `json:"migration_id"`
+	Source             string               `json:"source"`
+	Description        string               `json:"description"`
+	RowsScanned        int64                `json:"rows_scanned"`
+	RowsChanged        int64                `json:"rows_changed"`
+	LastContentFieldID types.ContentFieldID `json:"last_content_field_id"`
+	Completed          bool                 `json:"completed"`
+	AuthorID           types.UserID         `json:"author_id"`
+	DateCreated        types.Timestamp      `json:"date_created"`
+}
+
+// RecordContentMigrationParams contains parameters for recording a migration.
+type RecordContentMigrationParams struct {
+	MigrationID        string               `json:"migration_id"`
+	Source             string               `json:"source"`
+	Description        string               `json:"description"`
+	RowsScanned        int64                `json:"rows_scanned"`
+	RowsChanged        int64                `json:"rows_changed"`
+	LastContentFieldID types.ContentFieldID `json:"last_content_field_id"`
+	Completed          bool                 `json:"completed"`
+	AuthorID           types.UserID         `json:"author_id"`
+	DateCreated        types.Timestamp      `json:"date_created"`
+}
+
+// UpdateContentMigrationProgressParams contains parameters for recording how
+// far a migration has run.
+type UpdateContentMigrationProgressParams struct {
+	RowsScanned        int64                `json:"rows_scanned"`
+	RowsChanged        int64                `json:"rows_changed"`
+	LastContentFieldID types.ContentFieldID `json:"last_content_field_id"`
+	Completed          bool                 `json:"completed"`
+	MigrationID        string               `json:"migration_id"`
+}
+
+///////////////////////////////
+// SQLITE
+//////////////////////////////
+
+// MAPS
+
+// MapContentMigration converts a sqlc-generated type to the wrapper type.
+func (d Database) MapContentMigration(a mdb.ContentMigrations) ContentMigration {
+	return ContentMigration{
+		MigrationID:        a.MigrationID,
+		Source:             a.Source,
+		Description:        a.Description,
+		RowsScanned:        a.RowsScanned,
+		RowsChanged:        a.RowsChanged,
+		LastContentFieldID: types.ContentFieldID(a.LastContentFieldID),
+		Completed:          a.Completed.Val,
+		AuthorID:           a.AuthorID,
+		DateCreated:        a.DateCreated,
+	}
+}
+
+// QUERIES
+
+// CreateContentMigrationTable creates the content_migrations table.
+func (d Database) CreateContentMigrationTable() error {
+	queries := mdb.New(d.Connection)
+	return queries.CreateContentMigrationsTable(d.Context)
+}
+
+// GetContentMigration returns the record for a migration that has run or
+// started.
+func (d Database) GetContentMigration(id string) (*ContentMigration, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetContentMigration(d.Context, mdb.GetContentMigrationParams{MigrationID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content migration: %w", err)
+	}
+	res := d.MapContentMigration(row)
+	return &res, nil
+}
+
+// ListContentMigrations returns every recorded migration in the order they ran.
+func (d Database) ListContentMigrations() (*[]ContentMigration, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListContentMigrations(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content migrations: %v", err)
+	}
+	res := []ContentMigration{}
+	for _, v := range rows {
+		res = append(res, d.MapContentMigration(v))
+	}
+	return &res, nil
+}
+
+// RecordContentMigration records a migration and its progress.
+func (d Database) RecordContentMigration(params RecordContentMigrationParams) error {
+	queries := mdb.New(d.Connection)
+	err := queries.RecordContentMigration(d.Context, mdb.RecordContentMigrationParams{
+		MigrationID:        params.MigrationID,
+		Source:             params.Source,
+		Description:        params.Description,
+		RowsScanned:        params.RowsScanned,
+		RowsChanged:        params.RowsChanged,
+		LastContentFieldID: string(params.LastContentFieldID),
+		Completed:          types.NewSafeBool(params.Completed),
+		AuthorID:           params.AuthorID,
+		DateCreated:        params.DateCreated,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record content migration: %v", err)
+	}
+	return nil
+}
+
+// CountContentMigrations returns the number of recorded migrations.
+func (d Database) CountContentMigrations() (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountContentMigrations(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count content migrations: %v", err)
+	}
+	return &c, nil
+}
+
+///////////////////////////////
+// MYSQL
+//////////////////////////////
+
+// MAPS
+
+// MapContentMigration converts a sqlc-generated type to the wrapper type.
+func (d MysqlDatabase) MapContentMigration(a mdbm.ContentMigrations) ContentMigration {
+	return ContentMigration{
+		MigrationID:        a.MigrationID,
+		Source:             a.Source,
+		Description:        a.Description,
+		RowsScanned:        a.RowsScanned,
+		RowsChanged:        a.RowsChanged,
+		LastContentFieldID: types.ContentFieldID(a.LastContentFieldID),
+		Completed:          a.Completed.Val,
+		AuthorID:           a.AuthorID,
+		DateCreated:        a.DateCreated,
+	}
+}
+
+// QUERIES
+
+// CreateContentMigrationTable creates the content_migrations table.
+func (d MysqlDatabase) CreateContentMigrationTable() error {
+	queries := mdbm.New(d.Connection)
+	return queries.CreateContentMigrationsTable(d.Context)
+}
+
+// GetContentMigration returns the record for a migration that has run or
+// started.
+func (d MysqlDatabase) GetContentMigration(id string) (*ContentMigration, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetContentMigration(d.Context, mdbm.GetContentMigrationParams{MigrationID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content migration: %w", err)
+	}
+	res := d.MapContentMigration(row)
+	return &res, nil
+}
+
+// ListContentMigrations returns every recorded migration in the order they ran.
+func (d MysqlDatabase) ListContentMigrations() (*[]ContentMigration, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListContentMigrations(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content migrations: %v", err)
+	}
+	res := []ContentMigration{}
+	for _, v := range rows {
+		res = append(res, d.MapContentMigration(v))
+	}
+	return &res, nil
+}
+
+// RecordContentMigration records a migration and its progress.
+func (d MysqlDatabase) RecordContentMigration(params RecordContentMigrationParams) error {
+	queries := mdbm.New(d.Connection)
+	err := queries.RecordContentMigration(d.Context, mdbm.RecordContentMigrationParams{
+		MigrationID:        params.MigrationID,
+		Source:             params.Source,
+		Description:        params.Description,
+		RowsScanned:        params.RowsScanned,
+		RowsChanged:        params.RowsChanged,
+		LastContentFieldID: string(params.LastContentFieldID),
+		Completed:          types.NewSafeBool(params.Completed),
+		AuthorID:           params.AuthorID,
+		DateCreated:        params.DateCreated,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record content migration: %v", err)
+	}
+	return nil
+}
+
+// CountContentMigrations returns the number of recorded migrations.
+func (d MysqlDatabase) CountContentMigrations() (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountContentMigrations(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count content migrations: %v", err)
+	}
+	return &c, nil
+}
+
+///////////////////////////////
+// POSTGRES
+//////////////////////////////
+
+// MAPS
+
+// MapContentMigration converts a sqlc-generated type to the wrapper type.
+func (d PsqlDatabase) MapContentMigration(a mdbp.ContentMigrations) ContentMigration {
+	return ContentMigration{
+		MigrationID:        a.MigrationID,
+		Source:             a.Source,
+		Description:        a.Description,
+		RowsScanned:        a.RowsScanned,
+		RowsChanged:        a.RowsChanged,
+		LastContentFieldID: types.ContentFieldID(a.LastContentFieldID),
+		Completed:          a.Completed.Val,
+		AuthorID:           a.AuthorID,
+		DateCreated:        a.DateCreated,
+	}
+}
+
+// QUERIES
+
+// CreateContentMigrationTable creates the content_migrations table.
+func (d PsqlDatabase) CreateContentMigrationTable() error {
+	queries := mdbp.New(d.Connection)
+	return queries.CreateContentMigrationsTable(d.Context)
+}
+
+// GetContentMigration returns the record for a migration that has run or
+// started.
+func (d PsqlDatabase) GetContentMigration(id string) (*ContentMigration, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetContentMigration(d.Context, mdbp.GetContentMigrationParams{MigrationID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content migration: %w", err)
+	}
+	res := d.MapContentMigration(row)
+	return &res, nil
+}
+
+// ListContentMigrations returns every recorded migration in the order they ran.
+func (d PsqlDatabase) ListContentMigrations() (*[]ContentMigration, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListContentMigrations(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content migrations: %v", err)
+	}
+	res := []ContentMigration{}
+	for _, v := range rows {
+		res = append(res, d.MapContentMigration(v))
+	}
+	return &res, nil
+}
+
+// RecordContentMigration records a migration and its progress.
+func (d PsqlDatabase) RecordContentMigration(params RecordContentMigrationParams) error {
+	queries := mdbp.New(d.Connection)
+	err := queries.RecordContentMigration(d.Context, mdbp.RecordContentMigrationParams{
+		MigrationID:        params.MigrationID,
+		Source:             params.Source,
+		Description:        params.Description,
+		RowsScanned:        params.RowsScanned,
+		RowsChanged:        params.RowsChanged,
+		LastContentFieldID: string(params.LastContentFieldID),
+		Completed:          types.NewSafeBool(params.Completed),
+		AuthorID:           params.AuthorID,
+		DateCreated:        params.DateCreated,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record content migration: %v", err)
+	}
+	return nil
+}
+
+// CountContentMigrations returns the number of recorded migrations.
+func (d PsqlDatabase) CountContentMigrations() (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountContentMigrations(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count content migrations: %v", err)
+	}
+	return &c, nil
+}
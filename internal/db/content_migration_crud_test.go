@@ -0,0 +1,106 @@
+// Integration tests for the content_migrations run ledger.
+//
+// The table is NON-audited (no ctx/ac parameters on mutations).
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+func TestDatabase_ContentMigration_RecordAndList(t *testing.T) {
+	t.Parallel()
+	d := testIntegrationDB(t)
+
+	if _, err := d.GetContentMigration("0001_a"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("GetContentMigration before record: err = %v, want sql.ErrNoRows", err)
+	}
+
+	author := types.NewUserID()
+	for i, id := range []string{"0002_b", "0001_a"} {
+		err := d.RecordContentMigration(RecordContentMigrationParams{
+			MigrationID: id,
+			Source:      "go",
+			Description: "test " + id,
+			RowsScanned: int64(10 * (i + 1)),
+			RowsChanged: int64(i + 1),
+			AuthorID:    author,
+			DateCreated: types.TimestampNow(),
+		})
+		if err != nil {
+			t.Fatalf("RecordContentMigration(%s): %v", id, err)
+		}
+	}
+
+	got, err := d.GetContentMigration("0002_b")
+	if err != nil {
+		t.Fatalf("GetContentMigration: %v", err)
+	}
+	if got.RowsScanned != 10 || got.RowsChanged != 1 || got.AuthorID != author || got.Source != "go" {
+		t.Errorf("GetContentMigration = %+v", got)
+	}
+
+	if err := d.RecordContentMigration(RecordContentMigrationParams{MigrationID: "0001_a", Source: "go", AuthorID: author, DateCreated: types.TimestampNow()}); err == nil {
+		t.Error("recording the same migration twice succeeded")
+	}
+
+	list, err := d.ListContentMigrations()
+	if err != nil {
+		t.Fatalf("ListContentMigrations: %v", err)
+	}
+	if len(*list) != 2 {
+		t.Fatalf("ListContentMigrations len = %d, want 2", len(*list))
+	}
+	count, err := d.CountContentMigrations()
+	if err != nil {
+		t.Fatalf("CountContentMigrations: %v", err)
+	}
+	if *count != 2 {
+		t.Errorf("CountContentMigrations = %d, want 2", *count)
+	}
+}
+
+func TestDatabase_ContentMigration_ProgressInTx(t *testing.T) {
+	t.Parallel()
+	d := testIntegrationDB(t)
+	author := types.NewUserID()
+
+	tx, err := d.Connection.BeginTx(d.Context, nil)
+	if err != nil {
+		t.Fatalf("BeginTx: %v", err)
+	}
+	if err := RecordContentMigrationInTx(d, d.Context, tx, RecordContentMigrationParams{
+		MigrationID:        "0001_a",
+		Source:             "go",
+		RowsScanned:        5,
+		RowsChanged:        2,
+		LastContentFieldID: "01JAAAAAAAAAAAAAAAAAAAAAAA",
+		AuthorID:           author,
+		DateCreated:        types.TimestampNow(),
+	}); err != nil {
+		t.Fatalf("RecordContentMigrationInTx: %v", err)
+	}
+	if err := UpdateContentMigrationProgressInTx(d, d.Context, tx, UpdateContentMigrationProgressParams{
+		RowsScanned:        8,
+		RowsChanged:        3,
+		LastContentFieldID: "01JBBBBBBBBBBBBBBBBBBBBBBB",
+		Completed:          true,
+		MigrationID:        "0001_a",
+	}); err != nil {
+		t.Fatalf("UpdateContentMigrationProgressInTx: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	got, err := d.GetContentMigration("0001_a")
+	if err != nil {
+		t.Fatalf("GetContentMigration: %v", err)
+	}
+	if !got.Completed || got.RowsScanned != 8 || got.RowsChanged != 3 || got.LastContentFieldID != "01JBBBBBBBBBBBBBBBBBBBBBBB" {
+		t.Errorf("GetContentMigration = %+v", got)
+	}
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// RecordContentMigrationInTx records a migration in an existing transaction,
+// so a batch of rewrites and the progress it made commit together. Like
+// RecordContentMigration it is not audited.
+func RecordContentMigrationInTx(d DbDriver, ctx context.Context, tx *sql.Tx, params RecordContentMigrationParams) error {
+	var err error
+	switch d.(type) {
+	case Database:
+		err = mdb.New(tx).RecordContentMigration(ctx, mdb.RecordContentMigrationParams{
+			MigrationID:        params.MigrationID,
+			Source:             params.Source,
+			Description:        params.Description,
+			RowsScanned:        params.RowsScanned,
+			RowsChanged:        params.RowsChanged,
+			LastContentFieldID: string(params.LastContentFieldID),
+			Completed:          types.NewSafeBool(params.Completed),
+			AuthorID:           params.AuthorID,
+			DateCreated:        params.DateCreated,
+		})
+	case MysqlDatabase:
+		err = mdbm.New(tx).RecordContentMigration(ctx, mdbm.RecordContentMigrationParams{
+			MigrationID:        params.MigrationID,
+			Source:             params.Source,
+			Description:        params.Description,
+			RowsScanned:        params.RowsScanned,
+			RowsChanged:        params.RowsChanged,
+			LastContentFieldID: string(params.LastContentFieldID),
+			Completed:          types.NewSafeBool(params.Completed),
+			AuthorID:           params.AuthorID,
+			DateCreated:        params.DateCreated,
+		})
+	case PsqlDatabase:
+		err = mdbp.New(tx).RecordContentMigration(ctx, mdbp.RecordContentMigrationParams{
+			MigrationID:        params.MigrationID,
+			Source:             params.Source,
+			Description:        params.Description,
+			RowsScanned:        params.RowsScanned,
+			RowsChanged:        params.RowsChanged,
+			LastContentFieldID: string(params.LastContentFieldID),
+			Completed:          types.NewSafeBool(params.Completed),
+			AuthorID:           params.AuthorID,
+			DateCreated:        params.DateCreated,
+		})
+	default:
+		return fmt.Errorf("tx record content_migration: unsupported driver type %T", d)
+	}
+	if err != nil {
+		return fmt.Errorf("tx record content_migration %s: %w", params.MigrationID, err)
+	}
+	return nil
+}
+
+// UpdateContentMigrationProgressInTx records how far a migration has run in
+// an existing transaction. It is not audited.
+func UpdateContentMigrationProgressInTx(d DbDriver, ctx context.Context, tx *sql.Tx, params UpdateContentMigrationProgressParams) error {
+	var err error
+	switch d.(type) {
+	case Database:
+		err = mdb.New(tx).UpdateContentMigrationProgress(ctx, mdb.UpdateContentMigrationProgressParams{
+			RowsScanned:        params.RowsScanned,
+			RowsChanged:        params.RowsChanged,
+			LastContentFieldID: string(params.LastContentFieldID),
+			Completed:          types.NewSafeBool(params.Completed),
+			MigrationID:        params.MigrationID,
+		})
+	case MysqlDatabase:
+		err = mdbm.New(tx).UpdateContentMigrationProgress(ctx, mdbm.UpdateContentMigrationProgressParams{
+			RowsScanned:        params.RowsScanned,
+			RowsChanged:        params.RowsChanged,
+			LastContentFieldID: string(params.LastContentFieldID),
+			Completed:          types.NewSafeBool(params.Completed),
+			MigrationID:        params.MigrationID,
+		})
+	case PsqlDatabase:
+		err = mdbp.New(tx).UpdateContentMigrationProgress(ctx, mdbp.UpdateContentMigrationProgressParams{
+			RowsScanned:        params.RowsScanned,
+			RowsChanged:        params.RowsChanged,
+			LastContentFieldID: string(params.LastContentFieldID),
+			Completed:          types.NewSafeBool(params.Completed),
+			MigrationID:        params.MigrationID,
+		})
+	default:
+		return fmt.Errorf("tx update content_migration progress: unsupported driver type %T", d)
+	}
+	if err != nil {
+		return fmt.Errorf("tx update content_migration %s progress: %w", params.MigrationID, err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hegner123/modulacms/internal/db/audited"
+)
+
+// CreateContentRelationInTx creates a content relation with audit trail in an
+// existing transaction.
+func CreateContentRelationInTx(d DbDriver, ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params CreateContentRelationParams) (*ContentRelations, error) {
+	switch drv := d.(type) {
+	case Database:
+		cmd := Database{}.NewContentRelationCmd(ctx, ac, params)
+		result, err := audited.CreateInTx(cmd, tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create content_relation: %w", err)
+		}
+		r := drv.MapContentRelation(result)
+		return &r, nil
+	case MysqlDatabase:
+		return createContentRelationInTxMySQL(drv, ctx, tx, ac, params)
+	case PsqlDatabase:
+		return createContentRelationInTxPsql(drv, ctx, tx, ac, params)
+	default:
+		return nil, fmt.Errorf("tx create content_relation: unsupported driver type %T", d)
+	}
+}
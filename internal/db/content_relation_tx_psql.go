@@ -0,0 +1,20 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hegner123/modulacms/internal/db/audited"
+)
+
+func createContentRelationInTxPsql(d PsqlDatabase, ctx context.Context, tx *sql.Tx,
+	ac audited.AuditContext, params CreateContentRelationParams) (*ContentRelations, error) {
+	cmd := PsqlDatabase{}.NewContentRelationCmd(ctx, ac, params)
+	result, err := audited.CreateInTx(cmd, tx)
+	if err != nil {
+		return nil, fmt.Errorf("tx create content_relation: %w", err)
+	}
+	r := d.MapContentRelation(result)
+	return &r, nil
+}
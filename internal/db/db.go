@@ -99,6 +99,13 @@ type DbDriver interface {
 	AdminMediaRepository
 	AdminMediaFolderRepository
 	FieldPluginConfigRepository
+	RateLimitRepository
+	ContentMigrationRepository
+	ContentLeaseRepository
+	TranslationMemoryRepository
+	TranslationReviewRepository
+	TranslationSourceRepository
+	BlueprintRepository
 }
 
 // GetConnection returns the database connection and context
@@ -349,6 +356,76 @@ func (d Database) CreateAllTables() error {
 		return err
 	}
 
+	err = d.CreateUserRolesTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateUserGroupTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateUserGroupMembersTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateUserGroupRolesTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateUserLockoutTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreatePasswordHistoryTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateRateLimitCounterTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateContentMigrationTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateContentLeaseTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateTranslationMemoryTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateTranslationReviewTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateTranslationSourceTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateBlueprintTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateBlueprintInstanceTable()
+	if err != nil {
+		return err
+	}
+
 	// Tier 7: Plugin system tables (plugins before pipelines for FK)
 	err = d.CreatePluginTable()
 	if err != nil {
@@ -1524,6 +1601,76 @@ func (d MysqlDatabase) CreateAllTables() error {
 		return err
 	}
 
+	err = d.CreateUserRolesTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateUserGroupTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateUserGroupMembersTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateUserGroupRolesTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateUserLockoutTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreatePasswordHistoryTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateRateLimitCounterTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateContentMigrationTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateContentLeaseTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateTranslationMemoryTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateTranslationReviewTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateTranslationSourceTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateBlueprintTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateBlueprintInstanceTable()
+	if err != nil {
+		return err
+	}
+
 	// Tier 7: Plugin system tables (plugins before pipelines for FK)
 	err = d.CreatePluginTable()
 	if err != nil {
@@ -2659,6 +2806,76 @@ func (d PsqlDatabase) CreateAllTables() error {
 		return err
 	}
 
+	err = d.CreateUserRolesTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateUserGroupTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateUserGroupMembersTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateUserGroupRolesTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateUserLockoutTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreatePasswordHistoryTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateRateLimitCounterTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateContentMigrationTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateContentLeaseTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateTranslationMemoryTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateTranslationReviewTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateTranslationSourceTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateBlueprintTable()
+	if err != nil {
+		return err
+	}
+
+	err = d.CreateBlueprintInstanceTable()
+	if err != nil {
+		return err
+	}
+
 	// Tier 7: Plugin system tables (plugins before pipelines for FK)
 	err = d.CreatePluginTable()
 	if err != nil {
@@ -10,7 +10,8 @@ import (
 // ColumnMeta describes a single column from database catalog introspection.
 type ColumnMeta struct {
 	Name      string
-	IsInteger bool // INTEGER, INT, BIGINT, SMALLINT, TINYINT, SERIAL
+	IsInteger bool   // INTEGER, INT, BIGINT, SMALLINT, TINYINT, SERIAL
+	Type      string // lower-cased declared or catalog type, e.g. "text", "boolean", "tinyint(1)"
 }
 
 // FKViolation describes a single foreign key constraint violation.
@@ -64,6 +65,12 @@ type DeployOps interface {
 	// Integer columns (detected via IntrospectColumns) are scanned as int64;
 	// all others are scanned as string (or nil for NULL).
 	QueryAllRows(ctx context.Context, table DBTable) ([]string, [][]any, error)
+
+	// QueryRowsAfter returns up to limit rows ordered by the table's first
+	// column, which is its primary key, starting after the key after. An
+	// empty after starts at the first row. Values are scanned as in
+	// QueryAllRows. Used to stream large tables in key order.
+	QueryRowsAfter(ctx context.Context, table DBTable, after string, limit int) ([]string, [][]any, error)
 }
 
 // ImportFunc is the callback executed inside ImportAtomic.
@@ -236,7 +243,7 @@ func (s *sqliteDeployOps) IntrospectColumns(ctx context.Context, table DBTable)
 		}
 		upper := strings.ToUpper(colType)
 		isInt := strings.Contains(upper, "INT") || upper == "SERIAL"
-		cols = append(cols, ColumnMeta{Name: name, IsInteger: isInt})
+		cols = append(cols, ColumnMeta{Name: name, IsInteger: isInt, Type: strings.ToLower(colType)})
 	}
 	if len(cols) == 0 {
 		return nil, fmt.Errorf("table %q does not exist or has no columns", string(table))
@@ -255,6 +262,17 @@ func (s *sqliteDeployOps) QueryAllRows(ctx context.Context, table DBTable) ([]st
 	return queryAllRowsGeneric(ctx, s.pool, table, colMeta)
 }
 
+func (s *sqliteDeployOps) QueryRowsAfter(ctx context.Context, table DBTable, after string, limit int) ([]string, [][]any, error) {
+	if !IsValidTable(table) {
+		return nil, nil, fmt.Errorf("query rows after: unknown table %q", string(table))
+	}
+	colMeta, err := s.IntrospectColumns(ctx, table)
+	if err != nil {
+		return nil, nil, err
+	}
+	return queryRowsAfterGeneric(ctx, s.pool, table, colMeta, "?", after, limit)
+}
+
 // ---------- PostgreSQL ----------
 
 type psqlDeployOps struct {
@@ -420,7 +438,7 @@ func (p *psqlDeployOps) IntrospectColumns(ctx context.Context, table DBTable) ([
 		}
 		upper := strings.ToUpper(dataType)
 		isInt := strings.Contains(upper, "INT") || upper == "SERIAL" || upper == "BIGSERIAL" || upper == "SMALLSERIAL"
-		cols = append(cols, ColumnMeta{Name: name, IsInteger: isInt})
+		cols = append(cols, ColumnMeta{Name: name, IsInteger: isInt, Type: strings.ToLower(dataType)})
 	}
 	if len(cols) == 0 {
 		return nil, fmt.Errorf("table %q does not exist or has no columns", string(table))
@@ -439,6 +457,17 @@ func (p *psqlDeployOps) QueryAllRows(ctx context.Context, table DBTable) ([]stri
 	return queryAllRowsGeneric(ctx, p.pool, table, colMeta)
 }
 
+func (p *psqlDeployOps) QueryRowsAfter(ctx context.Context, table DBTable, after string, limit int) ([]string, [][]any, error) {
+	if !IsValidTable(table) {
+		return nil, nil, fmt.Errorf("query rows after: unknown table %q", string(table))
+	}
+	colMeta, err := p.IntrospectColumns(ctx, table)
+	if err != nil {
+		return nil, nil, err
+	}
+	return queryRowsAfterGeneric(ctx, p.pool, table, colMeta, "$1", after, limit)
+}
+
 // ---------- Shared FK verification ----------
 
 // fkWithPK holds a foreign key definition together with the child table's
@@ -621,7 +650,7 @@ func (m *mysqlDeployOps) IntrospectColumns(ctx context.Context, table DBTable) (
 		return nil, fmt.Errorf("introspect: unknown table %q", string(table))
 	}
 	rows, err := m.pool.QueryContext(ctx,
-		"SELECT COLUMN_NAME, DATA_TYPE FROM information_schema.COLUMNS WHERE TABLE_SCHEMA=DATABASE() AND TABLE_NAME=? ORDER BY ORDINAL_POSITION",
+		"SELECT COLUMN_NAME, DATA_TYPE, COLUMN_TYPE FROM information_schema.COLUMNS WHERE TABLE_SCHEMA=DATABASE() AND TABLE_NAME=? ORDER BY ORDINAL_POSITION",
 		string(table),
 	)
 	if err != nil {
@@ -631,13 +660,15 @@ func (m *mysqlDeployOps) IntrospectColumns(ctx context.Context, table DBTable) (
 
 	var cols []ColumnMeta
 	for rows.Next() {
-		var name, dataType string
-		if err := rows.Scan(&name, &dataType); err != nil {
+		var name, dataType, columnType string
+		if err := rows.Scan(&name, &dataType, &columnType); err != nil {
 			return nil, fmt.Errorf("scan column info for %s: %w", table, err)
 		}
 		upper := strings.ToUpper(dataType)
 		isInt := strings.Contains(upper, "INT") || upper == "SERIAL"
-		cols = append(cols, ColumnMeta{Name: name, IsInteger: isInt})
+		// COLUMN_TYPE keeps the display width, which is how MySQL marks
+		// BOOLEAN columns: tinyint(1).
+		cols = append(cols, ColumnMeta{Name: name, IsInteger: isInt, Type: strings.ToLower(columnType)})
 	}
 	if len(cols) == 0 {
 		return nil, fmt.Errorf("table %q does not exist or has no columns", string(table))
@@ -656,6 +687,17 @@ func (m *mysqlDeployOps) QueryAllRows(ctx context.Context, table DBTable) ([]str
 	return queryAllRowsGeneric(ctx, m.pool, table, colMeta)
 }
 
+func (m *mysqlDeployOps) QueryRowsAfter(ctx context.Context, table DBTable, after string, limit int) ([]string, [][]any, error) {
+	if !IsValidTable(table) {
+		return nil, nil, fmt.Errorf("query rows after: unknown table %q", string(table))
+	}
+	colMeta, err := m.IntrospectColumns(ctx, table)
+	if err != nil {
+		return nil, nil, err
+	}
+	return queryRowsAfterGeneric(ctx, m.pool, table, colMeta, "?", after, limit)
+}
+
 // ---------- Shared helpers ----------
 
 // queryAllRowsGeneric implements QueryAllRows for all backends.
@@ -676,17 +718,52 @@ func queryAllRowsGeneric(ctx context.Context, pool *sql.DB, table DBTable, colMe
 	}
 	defer rows.Close()
 
+	result, err := scanRowsGeneric(rows, table, intSet, len(colMeta))
+	return colNames, result, err
+}
+
+// queryRowsAfterGeneric implements QueryRowsAfter for all backends. The
+// first introspected column is the key; placeholder is the backend's first
+// parameter placeholder.
+func queryRowsAfterGeneric(ctx context.Context, pool *sql.DB, table DBTable, colMeta []ColumnMeta, placeholder string, after string, limit int) ([]string, [][]any, error) {
+	if limit <= 0 {
+		return nil, nil, fmt.Errorf("query rows after %s: limit must be positive, got %d", table, limit)
+	}
+	intSet := make(map[int]bool, len(colMeta))
+	colNames := make([]string, len(colMeta))
+	for i, cm := range colMeta {
+		colNames[i] = cm.Name
+		if cm.IsInteger {
+			intSet[i] = true
+		}
+	}
+	key := colNames[0]
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE %s > %s ORDER BY %s LIMIT %d;", table, key, placeholder, key, limit)
+	rows, err := pool.QueryContext(ctx, query, after)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query rows after %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	result, err := scanRowsGeneric(rows, table, intSet, len(colMeta))
+	return colNames, result, err
+}
+
+// scanRowsGeneric scans every column as *sql.NullString, converting the
+// columns in intSet to int64 and NULLs to nil.
+func scanRowsGeneric(rows *sql.Rows, table DBTable, intSet map[int]bool, numCols int) ([][]any, error) {
 	var result [][]any
 	for rows.Next() {
-		scanTargets := make([]any, len(colMeta))
+		scanTargets := make([]any, numCols)
 		for i := range scanTargets {
 			scanTargets[i] = new(sql.NullString)
 		}
 		if err := rows.Scan(scanTargets...); err != nil {
-			return nil, nil, fmt.Errorf("scan row in %s: %w", table, err)
+			return nil, fmt.Errorf("scan row in %s: %w", table, err)
 		}
 
-		row := make([]any, len(colMeta))
+		row := make([]any, numCols)
 		for i, target := range scanTargets {
 			ns := target.(*sql.NullString)
 			if !ns.Valid {
@@ -704,7 +781,7 @@ func queryAllRowsGeneric(ctx context.Context, pool *sql.DB, table DBTable, colMe
 		result = append(result, row)
 	}
 
-	return colNames, result, rows.Err()
+	return result, rows.Err()
 }
 
 // ---------- Constructor ----------
@@ -0,0 +1,266 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/hegner123/modulacms/internal/encryption"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// EncryptedColumn names the columns of a table that hold secrets sealed at
+// rest, together with the table's primary key column.
+type EncryptedColumn struct {
+	Table    DBTable
+	IDColumn string
+	Columns  []string
+}
+
+// EncryptedColumns lists every column the wrappers seal on write and open on
+// read. Deploy export redacts or re-encrypts these columns and
+// RewrapEncryptedColumns rewrites them after a key rotation.
+var EncryptedColumns = []EncryptedColumn{
+	{Table: WebhookT, IDColumn: "webhook_id", Columns: []string{"secret"}},
+	{Table: User_oauth, IDColumn: "user_oauth_id", Columns: []string{"access_token", "refresh_token"}},
+}
+
+// EncryptedColumnsFor returns the encrypted columns of table, if any.
+func EncryptedColumnsFor(table DBTable) (EncryptedColumn, bool) {
+	for _, ec := range EncryptedColumns {
+		if ec.Table == table {
+			return ec, true
+		}
+	}
+	return EncryptedColumn{}, false
+}
+
+// columnKeyring is the keyring used by sealColumn and openColumn. Nil leaves
+// designated columns stored as plaintext.
+var columnKeyring atomic.Pointer[encryption.Keyring]
+
+// SetColumnKeyring installs the keyring used for designated columns.
+// InitDB calls this from config; passing nil disables encryption.
+func SetColumnKeyring(k *encryption.Keyring) {
+	columnKeyring.Store(k)
+}
+
+// ColumnKeyring returns the installed keyring, or nil when encryption at rest
+// is not configured.
+func ColumnKeyring() *encryption.Keyring {
+	return columnKeyring.Load()
+}
+
+// sealColumn encrypts a designated column value before it is written.
+// Without a keyring the value is stored as plaintext.
+func sealColumn(v string) (string, error) {
+	k := columnKeyring.Load()
+	if k == nil {
+		return v, nil
+	}
+	sealed, err := k.Seal(v)
+	if err != nil {
+		return "", fmt.Errorf("encrypting column value: %w", err)
+	}
+	return sealed, nil
+}
+
+// sealColumns seals each designated column value in place. It stops at the
+// first failure; callers must not write the values when it returns an error.
+func sealColumns(vs ...*string) error {
+	for _, v := range vs {
+		sealed, err := sealColumn(*v)
+		if err != nil {
+			return err
+		}
+		*v = sealed
+	}
+	return nil
+}
+
+// openColumn decrypts a designated column value after it is read. Plaintext
+// rows written before encryption was enabled pass through unchanged. A value
+// that cannot be opened (missing key, tampered ciphertext) is logged and
+// returned as stored, so callers fail at the point of use rather than here.
+func openColumn(v string) string {
+	if !encryption.IsSealed(v) {
+		return v
+	}
+	k := columnKeyring.Load()
+	if k == nil {
+		utility.DefaultLogger.Warn("encrypted column value read without an encryption key configured", nil)
+		return v
+	}
+	opened, err := k.Open(v)
+	if err != nil {
+		utility.DefaultLogger.Error("decrypting column value", err)
+		return v
+	}
+	return opened
+}
+
+// RewrapResult counts the work done by RewrapEncryptedColumns.
+type RewrapResult struct {
+	Scanned   int
+	Rewritten int
+}
+
+// RewrapEncryptedColumns seals every designated column under k's primary key.
+// Plaintext values are encrypted and values sealed under a previous key are
+// rewrapped, as are values plugins sealed into their own tables. Rows already sealed under the primary key are left untouched, so
+// the operation can be rerun safely after an interruption.
+func RewrapEncryptedColumns(ctx context.Context, driver DbDriver, d Dialect, k *encryption.Keyring) (RewrapResult, error) {
+	var result RewrapResult
+	con, _, err := driver.GetConnection()
+	if err != nil {
+		return result, fmt.Errorf("get connection: %w", err)
+	}
+
+	for _, ec := range EncryptedColumns {
+		rows, err := QSelect(ctx, con, d, SelectParams{
+			Table:   string(ec.Table),
+			Columns: append([]string{ec.IDColumn}, ec.Columns...),
+			Limit:   -1,
+		})
+		if err != nil {
+			return result, fmt.Errorf("select %s: %w", ec.Table, err)
+		}
+
+		for _, row := range rows {
+			result.Scanned++
+			set := make(map[string]any)
+			for _, col := range ec.Columns {
+				v := rowString(row, col)
+				if !k.NeedsRewrap(v) {
+					continue
+				}
+				rewrapped, err := k.Rewrap(v)
+				if err != nil {
+					return result, fmt.Errorf("%s %v column %s: %w", ec.Table, row[ec.IDColumn], col, err)
+				}
+				set[col] = rewrapped
+			}
+			if len(set) == 0 {
+				continue
+			}
+			if _, err := QUpdate(ctx, con, d, UpdateParams{
+				Table: string(ec.Table),
+				Set:   set,
+				Where: map[string]any{ec.IDColumn: row[ec.IDColumn]},
+			}); err != nil {
+				return result, fmt.Errorf("update %s %v: %w", ec.Table, row[ec.IDColumn], err)
+			}
+			result.Rewritten++
+		}
+	}
+
+	if err := rewrapPluginTables(ctx, con, d, k, &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// pluginSystemTables are plugin_ tables owned by the plugin runtime rather
+// than created by a plugin through db.define_table.
+var pluginSystemTables = map[string]bool{
+	"plugin_routes":   true,
+	"plugin_hooks":    true,
+	"plugin_requests": true,
+}
+
+// rewrapPluginTables rewraps values plugins sealed with db.encrypt. Plugins
+// do not declare which columns they seal, so every value in a plugin table
+// that carries the sealed prefix is considered. Plaintext is left alone: a
+// plugin stores it deliberately. Without this pass, dropping a previous key
+// after rotation would leave plugin secrets undecryptable.
+func rewrapPluginTables(ctx context.Context, con *sql.DB, d Dialect, k *encryption.Keyring, result *RewrapResult) error {
+	tables, err := listPluginTables(ctx, con, d)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		rows, err := QSelect(ctx, con, d, SelectParams{Table: table, Limit: -1})
+		if err != nil {
+			return fmt.Errorf("select %s: %w", table, err)
+		}
+		for _, row := range rows {
+			result.Scanned++
+			id, ok := row["id"]
+			if !ok {
+				// Tables from define_table always have an id column; skip
+				// anything else rather than guess at its key.
+				break
+			}
+			set := make(map[string]any)
+			for col, raw := range row {
+				var v string
+				switch val := raw.(type) {
+				case string:
+					v = val
+				case []byte:
+					v = string(val)
+				default:
+					continue
+				}
+				if !encryption.IsSealed(v) || !k.NeedsRewrap(v) {
+					continue
+				}
+				rewrapped, err := k.Rewrap(v)
+				if err != nil {
+					return fmt.Errorf("%s %v column %s: %w", table, id, col, err)
+				}
+				set[col] = rewrapped
+			}
+			if len(set) == 0 {
+				continue
+			}
+			if _, err := QUpdate(ctx, con, d, UpdateParams{
+				Table: table,
+				Set:   set,
+				Where: map[string]any{"id": id},
+			}); err != nil {
+				return fmt.Errorf("update %s %v: %w", table, id, err)
+			}
+			result.Rewritten++
+		}
+	}
+	return nil
+}
+
+// listPluginTables returns the tables plugins created, excluding the plugin
+// runtime's own tables.
+func listPluginTables(ctx context.Context, con *sql.DB, d Dialect) ([]string, error) {
+	var query string
+	switch d {
+	case DialectSQLite:
+		query = "SELECT name FROM sqlite_master WHERE type='table' AND name LIKE 'plugin_%'"
+	case DialectMySQL:
+		query = "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name LIKE 'plugin_%'"
+	case DialectPostgres:
+		query = "SELECT tablename FROM pg_tables WHERE schemaname = 'public' AND tablename LIKE 'plugin_%'"
+	default:
+		return nil, fmt.Errorf("unsupported dialect for plugin table listing: %d", d)
+	}
+	rows, err := con.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list plugin tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("scan plugin table name: %w", err)
+		}
+		if pluginSystemTables[name] || ValidTableName(name) != nil {
+			continue
+		}
+		tables = append(tables, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list plugin tables: %w", err)
+	}
+	return tables, nil
+}
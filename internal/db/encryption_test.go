@@ -0,0 +1,243 @@
+// Integration tests for encryption at rest of designated columns.
+// Not parallel: the column keyring is process-wide, and parallel tests only
+// start once every sequential test has finished.
+package db
+
+import (
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/encryption"
+)
+
+// useColumnKeyring installs k for the duration of the test.
+func useColumnKeyring(t *testing.T, k *encryption.Keyring) {
+	t.Helper()
+	prev := ColumnKeyring()
+	SetColumnKeyring(k)
+	t.Cleanup(func() { SetColumnKeyring(prev) })
+}
+
+// storedWebhookSecret reads the secret column as stored, bypassing the mappers.
+func storedWebhookSecret(t *testing.T, d Database, id types.WebhookID) string {
+	t.Helper()
+	row, err := QSelectOne(d.Context, d.Connection, DialectSQLite, SelectParams{
+		Table:   string(WebhookT),
+		Columns: []string{"secret"},
+		Where:   map[string]any{"webhook_id": id.String()},
+	})
+	if err != nil {
+		t.Fatalf("select stored secret: %v", err)
+	}
+	if row == nil {
+		t.Fatalf("webhook %s not found", id)
+	}
+	return rowString(row, "secret")
+}
+
+func TestDatabase_EncryptedColumns_WebhookSecret(t *testing.T) {
+	oldKey, err := encryption.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	oldRing, err := encryption.NewKeyring(oldKey)
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	useColumnKeyring(t, oldRing)
+
+	d, seed := testSeededDB(t)
+	ac := testAuditCtxWithUser(d, seed.User.UserID)
+	now := types.TimestampNow()
+
+	created, err := d.CreateWebhook(d.Context, ac, CreateWebhookParams{
+		Name:         "encrypted",
+		URL:          "https://example.com/hook",
+		Secret:       "whsec_plaintext",
+		Events:       []string{"content.published"},
+		IsActive:     true,
+		Headers:      map[string]string{},
+		AuthorID:     seed.User.UserID,
+		DateCreated:  now,
+		DateModified: now,
+	})
+	if err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+	if created.Secret != "whsec_plaintext" {
+		t.Errorf("created Secret = %q, want plaintext", created.Secret)
+	}
+
+	stored := storedWebhookSecret(t, d, created.WebhookID)
+	if !encryption.IsSealed(stored) || encryption.KeyID(stored) != oldRing.PrimaryID() {
+		t.Fatalf("stored secret %q is not sealed under the configured key", stored)
+	}
+
+	got, err := d.GetWebhook(created.WebhookID)
+	if err != nil {
+		t.Fatalf("GetWebhook: %v", err)
+	}
+	if got.Secret != "whsec_plaintext" {
+		t.Errorf("GetWebhook Secret = %q, want plaintext", got.Secret)
+	}
+
+	// Rotate: new primary key, old key kept for decryption, then rewrap.
+	newKey, err := encryption.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	rotated, err := encryption.NewKeyring(newKey, oldKey)
+	if err != nil {
+		t.Fatalf("NewKeyring rotated: %v", err)
+	}
+	SetColumnKeyring(rotated)
+
+	result, err := RewrapEncryptedColumns(d.Context, d, DialectSQLite, rotated)
+	if err != nil {
+		t.Fatalf("RewrapEncryptedColumns: %v", err)
+	}
+	if result.Rewritten != 1 {
+		t.Errorf("Rewritten = %d, want 1", result.Rewritten)
+	}
+	if id := encryption.KeyID(storedWebhookSecret(t, d, created.WebhookID)); id != rotated.PrimaryID() {
+		t.Errorf("stored key id after rewrap = %q, want %q", id, rotated.PrimaryID())
+	}
+
+	// A second run finds nothing to do.
+	result, err = RewrapEncryptedColumns(d.Context, d, DialectSQLite, rotated)
+	if err != nil {
+		t.Fatalf("second RewrapEncryptedColumns: %v", err)
+	}
+	if result.Rewritten != 0 {
+		t.Errorf("second run Rewritten = %d, want 0", result.Rewritten)
+	}
+
+	newOnly, err := encryption.NewKeyring(newKey)
+	if err != nil {
+		t.Fatalf("NewKeyring new: %v", err)
+	}
+	SetColumnKeyring(newOnly)
+	got, err = d.GetWebhook(created.WebhookID)
+	if err != nil {
+		t.Fatalf("GetWebhook after rotation: %v", err)
+	}
+	if got.Secret != "whsec_plaintext" {
+		t.Errorf("Secret after rotation = %q, want plaintext", got.Secret)
+	}
+}
+
+func TestDatabase_EncryptedColumns_PlaintextRowsStillRead(t *testing.T) {
+	useColumnKeyring(t, nil)
+	d, seed := testSeededDB(t)
+	ac := testAuditCtxWithUser(d, seed.User.UserID)
+	now := types.TimestampNow()
+
+	created, err := d.CreateWebhook(d.Context, ac, CreateWebhookParams{
+		Name:         "legacy",
+		URL:          "https://example.com/legacy",
+		Secret:       "legacy-secret",
+		Events:       []string{},
+		Headers:      map[string]string{},
+		AuthorID:     seed.User.UserID,
+		DateCreated:  now,
+		DateModified: now,
+	})
+	if err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+	if stored := storedWebhookSecret(t, d, created.WebhookID); stored != "legacy-secret" {
+		t.Fatalf("stored secret without keyring = %q, want plaintext", stored)
+	}
+
+	key, err := encryption.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	k, err := encryption.NewKeyring(key)
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	SetColumnKeyring(k)
+
+	got, err := d.GetWebhook(created.WebhookID)
+	if err != nil {
+		t.Fatalf("GetWebhook: %v", err)
+	}
+	if got.Secret != "legacy-secret" {
+		t.Errorf("Secret = %q, want plaintext row read unchanged", got.Secret)
+	}
+}
+
+func TestRewrapEncryptedColumns_PluginSealedValues(t *testing.T) {
+	oldKey, err := encryption.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	oldRing, err := encryption.NewKeyring(oldKey)
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	useColumnKeyring(t, oldRing)
+
+	d := testIntegrationDB(t)
+	if _, err := d.Connection.ExecContext(d.Context,
+		"CREATE TABLE plugin_stripe_credentials (id TEXT PRIMARY KEY, provider TEXT, api_key TEXT)"); err != nil {
+		t.Fatalf("create plugin table: %v", err)
+	}
+	sealed, err := oldRing.Seal("sk_live_plugin")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	// A plaintext value in a plugin table is the plugin's choice and must
+	// not be sealed by the rewrap.
+	if _, err := d.Connection.ExecContext(d.Context,
+		"INSERT INTO plugin_stripe_credentials (id, provider, api_key) VALUES ('a', 'stripe', ?), ('b', 'plain', 'not-a-secret')",
+		sealed); err != nil {
+		t.Fatalf("insert plugin rows: %v", err)
+	}
+
+	newKey, err := encryption.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	rotated, err := encryption.NewKeyring(newKey, oldKey)
+	if err != nil {
+		t.Fatalf("NewKeyring rotated: %v", err)
+	}
+	SetColumnKeyring(rotated)
+
+	result, err := RewrapEncryptedColumns(d.Context, d, DialectSQLite, rotated)
+	if err != nil {
+		t.Fatalf("RewrapEncryptedColumns: %v", err)
+	}
+	if result.Rewritten != 1 {
+		t.Errorf("Rewritten = %d, want 1", result.Rewritten)
+	}
+
+	stored := func(id string) string {
+		t.Helper()
+		row, err := QSelectOne(d.Context, d.Connection, DialectSQLite, SelectParams{
+			Table:   "plugin_stripe_credentials",
+			Columns: []string{"api_key"},
+			Where:   map[string]any{"id": id},
+		})
+		if err != nil {
+			t.Fatalf("select plugin row %s: %v", id, err)
+		}
+		return rowString(row, "api_key")
+	}
+	if got := encryption.KeyID(stored("a")); got != rotated.PrimaryID() {
+		t.Errorf("plugin value key id = %q, want %q", got, rotated.PrimaryID())
+	}
+	if got := stored("b"); got != "not-a-secret" {
+		t.Errorf("plaintext plugin value = %q, want it left unchanged", got)
+	}
+
+	newOnly, err := encryption.NewKeyring(newKey)
+	if err != nil {
+		t.Fatalf("NewKeyring new: %v", err)
+	}
+	if got, err := newOnly.Open(stored("a")); err != nil || got != "sk_live_plugin" {
+		t.Errorf("Open with new key only = %q, %v; want sk_live_plugin", got, err)
+	}
+}
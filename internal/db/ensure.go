@@ -388,6 +388,161 @@ func EnsureWebhookPermissions(ctx context.Context, driver DbDriver) error {
 	return nil
 }
 
+// EnsureAccountSecurityTables creates the user_lockouts and password_history
+// tables on databases installed before account lockout existed. This is
+// idempotent — safe to call on every boot.
+func EnsureAccountSecurityTables(driver DbDriver) error {
+	if err := driver.CreateUserLockoutTable(); err != nil {
+		return fmt.Errorf("create user_lockouts table: %w", err)
+	}
+	if err := driver.CreatePasswordHistoryTable(); err != nil {
+		return fmt.Errorf("create password_history table: %w", err)
+	}
+	return nil
+}
+
+// EnsureRateLimitTable creates the rate_limit_counters table used by the
+// database rate limiter store on databases installed before it existed. This
+// is idempotent — safe to call on every boot.
+func EnsureRateLimitTable(driver DbDriver) error {
+	if err := driver.CreateRateLimitCounterTable(); err != nil {
+		return fmt.Errorf("create rate_limit_counters table: %w", err)
+	}
+	return nil
+}
+
+// EnsureContentMigrationTable creates the content_migrations table that
+// records which content migrations have run, on databases installed before it
+// existed. This is idempotent — safe to call on every boot.
+func EnsureContentMigrationTable(driver DbDriver) error {
+	if err := driver.CreateContentMigrationTable(); err != nil {
+		return fmt.Errorf("create content_migrations table: %w", err)
+	}
+	return nil
+}
+
+// EnsureContentLeaseTable creates the content_leases table that holds edit
+// leases and presence, on databases installed before it existed. This is
+// idempotent — safe to call on every boot.
+func EnsureContentLeaseTable(driver DbDriver) error {
+	if err := driver.CreateContentLeaseTable(); err != nil {
+		return fmt.Errorf("create content_leases table: %w", err)
+	}
+	return nil
+}
+
+// EnsureTranslationTables creates the translation_memory,
+// translation_reviews and translation_sources tables, on databases installed
+// before they existed.
+// This is idempotent — safe to call on every boot.
+func EnsureTranslationTables(driver DbDriver) error {
+	if err := driver.CreateTranslationMemoryTable(); err != nil {
+		return fmt.Errorf("create translation_memory table: %w", err)
+	}
+	if err := driver.CreateTranslationReviewTable(); err != nil {
+		return fmt.Errorf("create translation_reviews table: %w", err)
+	}
+	if err := driver.CreateTranslationSourceTable(); err != nil {
+		return fmt.Errorf("create translation_sources table: %w", err)
+	}
+	return nil
+}
+
+// EnsureBlueprintTables creates the blueprints and blueprint_instances
+// tables, on databases installed before they existed. This is idempotent —
+// safe to call on every boot.
+func EnsureBlueprintTables(driver DbDriver) error {
+	if err := driver.CreateBlueprintTable(); err != nil {
+		return fmt.Errorf("create blueprints table: %w", err)
+	}
+	if err := driver.CreateBlueprintInstanceTable(); err != nil {
+		return fmt.Errorf("create blueprint_instances table: %w", err)
+	}
+	return nil
+}
+
+// EnsureUserRoles creates the multi-role and user group tables and backfills
+// user_roles from the legacy single users.role column. This is idempotent —
+// safe to call on every boot. Every user's primary role is kept present in
+// user_roles so that the assignment table is the complete record of roles.
+func EnsureUserRoles(ctx context.Context, driver DbDriver) error {
+	if err := driver.CreateUserRolesTable(); err != nil {
+		return fmt.Errorf("create user_roles table: %w", err)
+	}
+	if err := driver.CreateUserGroupTable(); err != nil {
+		return fmt.Errorf("create user_groups table: %w", err)
+	}
+	if err := driver.CreateUserGroupMembersTable(); err != nil {
+		return fmt.Errorf("create user_group_members table: %w", err)
+	}
+	if err := driver.CreateUserGroupRolesTable(); err != nil {
+		return fmt.Errorf("create user_group_roles table: %w", err)
+	}
+
+	users, err := driver.ListUsers()
+	if err != nil {
+		return fmt.Errorf("list users: %w", err)
+	}
+	if users == nil || len(*users) == 0 {
+		return nil
+	}
+
+	roles, err := driver.ListRoles()
+	if err != nil {
+		return fmt.Errorf("list roles: %w", err)
+	}
+	knownRoles := make(map[types.RoleID]struct{})
+	if roles != nil {
+		for _, r := range *roles {
+			knownRoles[r.RoleID] = struct{}{}
+		}
+	}
+
+	assignments, err := driver.ListUserRoles()
+	if err != nil {
+		return fmt.Errorf("list user roles: %w", err)
+	}
+	assigned := make(map[types.UserID]map[types.RoleID]struct{})
+	if assignments != nil {
+		for _, ur := range *assignments {
+			if assigned[ur.UserID] == nil {
+				assigned[ur.UserID] = make(map[types.RoleID]struct{})
+			}
+			assigned[ur.UserID][ur.RoleID] = struct{}{}
+		}
+	}
+
+	var systemUserID types.UserID
+	for _, u := range *users {
+		roleID := types.RoleID(u.Role)
+		if roleID.IsZero() {
+			continue
+		}
+		if _, ok := knownRoles[roleID]; !ok {
+			continue
+		}
+		if _, ok := assigned[u.UserID][roleID]; ok {
+			continue
+		}
+		if systemUserID.IsZero() {
+			systemUserID, err = findSystemUserID(driver)
+			if err != nil {
+				return fmt.Errorf("find system user for user role backfill: %w", err)
+			}
+		}
+		ac := audited.Ctx(types.NewNodeID(), systemUserID, "ensure-user-roles", "system")
+		if _, createErr := driver.CreateUserRole(ctx, ac, CreateUserRoleParams{
+			UserID: u.UserID,
+			RoleID: roleID,
+		}); createErr != nil {
+			return fmt.Errorf("backfill role for user %s: %w", u.UserID, createErr)
+		}
+		utility.DefaultLogger.Info("backfilled user role assignment", "user", u.Username, "role", roleID)
+	}
+
+	return nil
+}
+
 // findSystemUserID returns the UserID of the "system" user.
 func findSystemUserID(driver DbDriver) (types.UserID, error) {
 	users, err := driver.ListUsers()
@@ -138,3 +138,36 @@ func TestEnsureSystemData_CreatesFieldTypeIfMissing(t *testing.T) {
 		t.Errorf("admin_field_type.Type = %q, want %q", aft.Type, "_id")
 	}
 }
+
+func TestEnsureUserRoles_BackfillsPrimaryRole(t *testing.T) {
+	t.Parallel()
+	d, seed := testSeededDB(t)
+	ctx := context.Background()
+
+	if err := EnsureUserRoles(ctx, d); err != nil {
+		t.Fatalf("EnsureUserRoles: %v", err)
+	}
+
+	assignments, err := d.ListUserRolesByUserID(seed.User.UserID)
+	if err != nil {
+		t.Fatalf("ListUserRolesByUserID: %v", err)
+	}
+	if len(*assignments) != 1 {
+		t.Fatalf("expected 1 user role after backfill, got %d", len(*assignments))
+	}
+	if (*assignments)[0].RoleID != seed.Role.RoleID {
+		t.Errorf("backfilled role = %s, want %s", (*assignments)[0].RoleID, seed.Role.RoleID)
+	}
+
+	// Second run must not duplicate the assignment.
+	if err := EnsureUserRoles(ctx, d); err != nil {
+		t.Fatalf("EnsureUserRoles (second run): %v", err)
+	}
+	assignments, err = d.ListUserRolesByUserID(seed.User.UserID)
+	if err != nil {
+		t.Fatalf("ListUserRolesByUserID: %v", err)
+	}
+	if len(*assignments) != 1 {
+		t.Errorf("expected 1 user role after second run, got %d", len(*assignments))
+	}
+}
@@ -1,6 +1,9 @@
 package db
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+)
 
 // GenericHeaders returns the column header names for the given table.
 func GenericHeaders(t DBTable) []string {
@@ -470,6 +473,41 @@ func GenericHeaders(t DBTable) []string {
 			"date_created",
 			"last_used",
 		}
+	case User_roles:
+		return []string{
+			"id",
+			"user_id",
+			"role_id",
+		}
+	case User_groups:
+		return []string{
+			"user_group_id",
+			"label",
+			"description",
+			"date_created",
+			"date_modified",
+		}
+	case User_group_members:
+		return []string{
+			"id",
+			"user_group_id",
+			"user_id",
+		}
+	case User_group_roles:
+		return []string{
+			"id",
+			"user_group_id",
+			"role_id",
+		}
+	case User_lockouts:
+		return []string{
+			"user_id",
+			"failed_attempts",
+			"lockout_count",
+			"locked_until",
+			"last_failed_at",
+			"date_modified",
+		}
 	case WebhookT:
 		return []string{
 			"webhook_id",
@@ -1312,6 +1350,97 @@ func GenericList(t DBTable, d DbDriver) ([][]string, error) {
 	case User_ssh_keys:
 		// ListUserSshKeys requires a user ID parameter; list all not supported.
 		return nil, fmt.Errorf("table %q requires user ID parameter for listing", t)
+	case User_roles:
+		a, err := d.ListUserRoles()
+		if err != nil {
+			return nil, err
+		}
+		var collection [][]string
+		for i := range len(*a) {
+			rows := *a
+			row := rows[i]
+			r := []string{
+				row.ID.String(),
+				row.UserID.String(),
+				row.RoleID.String(),
+			}
+			collection = append(collection, r)
+		}
+		return collection, nil
+	case User_groups:
+		a, err := d.ListUserGroups()
+		if err != nil {
+			return nil, err
+		}
+		var collection [][]string
+		for i := range len(*a) {
+			rows := *a
+			row := rows[i]
+			s := MapStringUserGroup(row)
+			r := []string{
+				s.UserGroupID,
+				s.Label,
+				s.Description,
+				s.DateCreated,
+				s.DateModified,
+			}
+			collection = append(collection, r)
+		}
+		return collection, nil
+	case User_group_members:
+		a, err := d.ListUserGroupMembers()
+		if err != nil {
+			return nil, err
+		}
+		var collection [][]string
+		for i := range len(*a) {
+			rows := *a
+			row := rows[i]
+			r := []string{
+				row.ID.String(),
+				row.UserGroupID.String(),
+				row.UserID.String(),
+			}
+			collection = append(collection, r)
+		}
+		return collection, nil
+	case User_group_roles:
+		a, err := d.ListUserGroupRoles()
+		if err != nil {
+			return nil, err
+		}
+		var collection [][]string
+		for i := range len(*a) {
+			rows := *a
+			row := rows[i]
+			r := []string{
+				row.ID.String(),
+				row.UserGroupID.String(),
+				row.RoleID.String(),
+			}
+			collection = append(collection, r)
+		}
+		return collection, nil
+	case User_lockouts:
+		a, err := d.ListUserLockouts()
+		if err != nil {
+			return nil, err
+		}
+		var collection [][]string
+		for i := range len(*a) {
+			rows := *a
+			row := rows[i]
+			r := []string{
+				row.UserID.String(),
+				strconv.FormatInt(row.FailedAttempts, 10),
+				strconv.FormatInt(row.LockoutCount, 10),
+				row.LockedUntil.String(),
+				row.LastFailedAt.String(),
+				row.DateModified.String(),
+			}
+			collection = append(collection, r)
+		}
+		return collection, nil
 	case WebhookT:
 		a, err := d.ListWebhooks()
 		if err != nil {
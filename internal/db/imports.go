@@ -144,6 +144,15 @@ type StringRoles struct {
 	SystemProtected string `json:"system_protected"`
 }
 
+// StringUserGroups represents user group data as strings for TUI display.
+type StringUserGroups struct {
+	UserGroupID  string `json:"user_group_id"`
+	Label        string `json:"label"`
+	Description  string `json:"description"`
+	DateCreated  string `json:"date_created"`
+	DateModified string `json:"date_modified"`
+}
+
 // StringPermissions represents permission data as strings for TUI display.
 type StringPermissions struct {
 	PermissionID string `json:"permission_id"`
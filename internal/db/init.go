@@ -12,6 +12,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 	config "github.com/hegner123/modulacms/internal/config"
 	_ "github.com/hegner123/modulacms/internal/db/dbmetrics" // registers instrumented drivers
+	"github.com/hegner123/modulacms/internal/encryption"
 	utility "github.com/hegner123/modulacms/internal/utility"
 	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
@@ -165,6 +166,13 @@ func (d PsqlDatabase) GetDb(verbose *bool) DbDriver {
 // Call once at application startup before any handlers execute.
 func InitDB(env config.Config) (DbDriver, error) {
 	dbOnce.Do(func() {
+		keyring, err := encryption.KeyringFromConfig(env)
+		if err != nil {
+			dbInitErr = fmt.Errorf("loading encryption key: %w", err)
+			return
+		}
+		SetColumnKeyring(keyring)
+
 		verbose := true
 		switch env.Db_Driver {
 		case config.Sqlite:
@@ -216,6 +224,13 @@ func ConfigDB(env config.Config) DbDriver {
 	}
 
 	// Fallback: create a one-off connection for CLI/install usage
+	if ColumnKeyring() == nil {
+		keyring, err := encryption.KeyringFromConfig(env)
+		if err != nil {
+			utility.DefaultLogger.Error("loading encryption key", err)
+		}
+		SetColumnKeyring(keyring)
+	}
 	verbose := false
 	switch env.Db_Driver {
 	case config.Sqlite:
@@ -231,6 +246,28 @@ func ConfigDB(env config.Config) DbDriver {
 	return nil
 }
 
+// OpenDriver opens a new connection pool for cfg without touching the
+// singleton, for commands that work with two databases at once such as
+// "modula db migrate". The caller closes the pool from GetConnection.
+func OpenDriver(cfg config.Config) (DbDriver, error) {
+	verbose := false
+	var d DbDriver
+	switch cfg.Db_Driver {
+	case config.Sqlite:
+		d = Database{Src: cfg.Db_URL, Config: cfg}.GetDb(&verbose)
+	case config.Mysql:
+		d = MysqlDatabase{Src: cfg.Db_Name, Config: cfg}.GetDb(&verbose)
+	case config.Psql:
+		d = PsqlDatabase{Src: cfg.Db_Name, Config: cfg}.GetDb(&verbose)
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", cfg.Db_Driver)
+	}
+	if err := d.Ping(); err != nil {
+		return nil, fmt.Errorf("connect to %s database: %w", cfg.Db_Driver, err)
+	}
+	return d, nil
+}
+
 // CloseDB closes the singleton database connection pool.
 // Call during graceful shutdown.
 func CloseDB() error {
@@ -0,0 +1,227 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+)
+
+// Rate limit counters back the shared rate limiter store so that replicas
+// behind a load balancer count requests against the same budget. Rows are
+// short-lived fixed-window counters, written without audited commands and
+// never recorded in change_events.
+
+///////////////////////////////
+// STRUCTS
+//////////////////////////////
+
+// RateLimitCounter is the hit count for one limiter key in its current window.
+type RateLimitCounter struct {
+	LimiterKey  string `json:"limiter_key"`
+	WindowStart int64  `json:"window_start"`
+	Hits        int64  `json:"hits"`
+	ExpiresAt   int64  `json:"expires_at"`
+}
+
+// IncrementRateLimitCounterParams identifies the counter and window to count
+// a hit against. WindowStart and ExpiresAt are Unix seconds. A counter whose
+// stored window differs from WindowStart is reset to one hit.
+type IncrementRateLimitCounterParams struct {
+	LimiterKey  string `json:"limiter_key"`
+	WindowStart int64  `json:"window_start"`
+	ExpiresAt   int64  `json:"expires_at"`
+}
+
+///////////////////////////////
+// SQLITE
+//////////////////////////////
+
+// MAPS
+
+// MapRateLimitCounter converts a sqlc-generated type to the wrapper type.
+func (d Database) MapRateLimitCounter(a mdb.RateLimitCounters) RateLimitCounter {
+	return RateLimitCounter{
+		LimiterKey:  a.LimiterKey,
+		WindowStart: a.WindowStart,
+		Hits:        a.Hits,
+		ExpiresAt:   a.ExpiresAt,
+	}
+}
+
+// QUERIES
+
+// CreateRateLimitCounterTable creates the rate_limit_counters table and its index.
+func (d Database) CreateRateLimitCounterTable() error {
+	queries := mdb.New(d.Connection)
+	if err := queries.CreateRateLimitCountersTable(d.Context); err != nil {
+		return err
+	}
+	return queries.CreateRateLimitCountersIndexExpires(d.Context)
+}
+
+// IncrementRateLimitCounter counts one hit and returns the counter afterwards.
+func (d Database) IncrementRateLimitCounter(params IncrementRateLimitCounterParams) (*RateLimitCounter, error) {
+	queries := mdb.New(d.Connection)
+	err := queries.IncrementRateLimitCounter(d.Context, mdb.IncrementRateLimitCounterParams{
+		LimiterKey:  params.LimiterKey,
+		WindowStart: params.WindowStart,
+		ExpiresAt:   params.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment rate limit counter: %v", err)
+	}
+	row, err := queries.GetRateLimitCounter(d.Context, mdb.GetRateLimitCounterParams{LimiterKey: params.LimiterKey})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate limit counter: %v", err)
+	}
+	res := d.MapRateLimitCounter(row)
+	return &res, nil
+}
+
+// DeleteExpiredRateLimitCounters removes counters that expired before the given Unix time.
+func (d Database) DeleteExpiredRateLimitCounters(before int64) error {
+	queries := mdb.New(d.Connection)
+	return queries.DeleteExpiredRateLimitCounters(d.Context, mdb.DeleteExpiredRateLimitCountersParams{ExpiresAt: before})
+}
+
+// CountRateLimitCounters returns the number of stored rate limit counters.
+func (d Database) CountRateLimitCounters() (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountRateLimitCounters(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count rate limit counters: %v", err)
+	}
+	return &c, nil
+}
+
+///////////////////////////////
+// MYSQL
+//////////////////////////////
+
+// MAPS
+
+// MapRateLimitCounter converts a sqlc-generated type to the wrapper type.
+func (d MysqlDatabase) MapRateLimitCounter(a mdbm.RateLimitCounters) RateLimitCounter {
+	return RateLimitCounter{
+		LimiterKey:  a.LimiterKey,
+		WindowStart: a.WindowStart,
+		Hits:        a.Hits,
+		ExpiresAt:   a.ExpiresAt,
+	}
+}
+
+// QUERIES
+
+// CreateRateLimitCounterTable creates the rate_limit_counters table and its index.
+func (d MysqlDatabase) CreateRateLimitCounterTable() error {
+	queries := mdbm.New(d.Connection)
+	if err := queries.CreateRateLimitCountersTable(d.Context); err != nil {
+		return err
+	}
+	// MySQL has no CREATE INDEX IF NOT EXISTS; a second run reports the
+	// existing index, which is expected.
+	if err := queries.CreateRateLimitCountersIndexExpires(d.Context); err != nil && !strings.Contains(err.Error(), "Duplicate key name") {
+		return err
+	}
+	return nil
+}
+
+// IncrementRateLimitCounter counts one hit and returns the counter afterwards.
+func (d MysqlDatabase) IncrementRateLimitCounter(params IncrementRateLimitCounterParams) (*RateLimitCounter, error) {
+	queries := mdbm.New(d.Connection)
+	err := queries.IncrementRateLimitCounter(d.Context, mdbm.IncrementRateLimitCounterParams{
+		LimiterKey:  params.LimiterKey,
+		WindowStart: params.WindowStart,
+		ExpiresAt:   params.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment rate limit counter: %v", err)
+	}
+	row, err := queries.GetRateLimitCounter(d.Context, mdbm.GetRateLimitCounterParams{LimiterKey: params.LimiterKey})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate limit counter: %v", err)
+	}
+	res := d.MapRateLimitCounter(row)
+	return &res, nil
+}
+
+// DeleteExpiredRateLimitCounters removes counters that expired before the given Unix time.
+func (d MysqlDatabase) DeleteExpiredRateLimitCounters(before int64) error {
+	queries := mdbm.New(d.Connection)
+	return queries.DeleteExpiredRateLimitCounters(d.Context, mdbm.DeleteExpiredRateLimitCountersParams{ExpiresAt: before})
+}
+
+// CountRateLimitCounters returns the number of stored rate limit counters.
+func (d MysqlDatabase) CountRateLimitCounters() (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountRateLimitCounters(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count rate limit counters: %v", err)
+	}
+	return &c, nil
+}
+
+///////////////////////////////
+// POSTGRES
+//////////////////////////////
+
+// MAPS
+
+// MapRateLimitCounter converts a sqlc-generated type to the wrapper type.
+func (d PsqlDatabase) MapRateLimitCounter(a mdbp.RateLimitCounters) RateLimitCounter {
+	return RateLimitCounter{
+		LimiterKey:  a.LimiterKey,
+		WindowStart: a.WindowStart,
+		Hits:        a.Hits,
+		ExpiresAt:   a.ExpiresAt,
+	}
+}
+
+// QUERIES
+
+// CreateRateLimitCounterTable creates the rate_limit_counters table and its index.
+func (d PsqlDatabase) CreateRateLimitCounterTable() error {
+	queries := mdbp.New(d.Connection)
+	if err := queries.CreateRateLimitCountersTable(d.Context); err != nil {
+		return err
+	}
+	return queries.CreateRateLimitCountersIndexExpires(d.Context)
+}
+
+// IncrementRateLimitCounter counts one hit and returns the counter afterwards.
+func (d PsqlDatabase) IncrementRateLimitCounter(params IncrementRateLimitCounterParams) (*RateLimitCounter, error) {
+	queries := mdbp.New(d.Connection)
+	err := queries.IncrementRateLimitCounter(d.Context, mdbp.IncrementRateLimitCounterParams{
+		LimiterKey:  params.LimiterKey,
+		WindowStart: params.WindowStart,
+		ExpiresAt:   params.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment rate limit counter: %v", err)
+	}
+	row, err := queries.GetRateLimitCounter(d.Context, mdbp.GetRateLimitCounterParams{LimiterKey: params.LimiterKey})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate limit counter: %v", err)
+	}
+	res := d.MapRateLimitCounter(row)
+	return &res, nil
+}
+
+// DeleteExpiredRateLimitCounters removes counters that expired before the given Unix time.
+func (d PsqlDatabase) DeleteExpiredRateLimitCounters(before int64) error {
+	queries := mdbp.New(d.Connection)
+	return queries.DeleteExpiredRateLimitCounters(d.Context, mdbp.DeleteExpiredRateLimitCountersParams{ExpiresAt: before})
+}
+
+// CountRateLimitCounters returns the number of stored rate limit counters.
+func (d PsqlDatabase) CountRateLimitCounters() (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountRateLimitCounters(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count rate limit counters: %v", err)
+	}
+	return &c, nil
+}
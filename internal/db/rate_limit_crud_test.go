@@ -0,0 +1,45 @@
+// Integration tests for the rate_limit_counters fixed-window upsert.
+//
+// The table is NON-audited (no ctx/ac parameters on mutations).
+package db
+
+import "testing"
+
+func TestDatabase_RateLimitCounter_Increment(t *testing.T) {
+	t.Parallel()
+	d := testIntegrationDB(t)
+
+	params := IncrementRateLimitCounterParams{LimiterKey: "rl:auth:ip:10.0.0.1", WindowStart: 1000, ExpiresAt: 1060}
+	for want := int64(1); want <= 3; want++ {
+		got, err := d.IncrementRateLimitCounter(params)
+		if err != nil {
+			t.Fatalf("IncrementRateLimitCounter: %v", err)
+		}
+		if got.Hits != want {
+			t.Fatalf("Hits = %d, want %d", got.Hits, want)
+		}
+	}
+
+	// A new window resets the count.
+	next, err := d.IncrementRateLimitCounter(IncrementRateLimitCounterParams{LimiterKey: params.LimiterKey, WindowStart: 1060, ExpiresAt: 1120})
+	if err != nil {
+		t.Fatalf("IncrementRateLimitCounter (next window): %v", err)
+	}
+	if next.Hits != 1 || next.WindowStart != 1060 {
+		t.Errorf("next window = {hits %d, start %d}, want {1, 1060}", next.Hits, next.WindowStart)
+	}
+
+	if _, err := d.IncrementRateLimitCounter(IncrementRateLimitCounterParams{LimiterKey: "rl:auth:ip:10.0.0.2", WindowStart: 1000, ExpiresAt: 1060}); err != nil {
+		t.Fatalf("IncrementRateLimitCounter (second key): %v", err)
+	}
+	if err := d.DeleteExpiredRateLimitCounters(1100); err != nil {
+		t.Fatalf("DeleteExpiredRateLimitCounters: %v", err)
+	}
+	count, err := d.CountRateLimitCounters()
+	if err != nil {
+		t.Fatalf("CountRateLimitCounters: %v", err)
+	}
+	if *count != 1 {
+		t.Errorf("count after sweep = %d, want 1", *count)
+	}
+}
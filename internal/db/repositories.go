@@ -398,7 +398,7 @@ type UserRepository interface {
 	UpdateUserSshKeyLastUsed(string, string) error
 }
 
-// AuthRepository manages sessions and API tokens.
+// AuthRepository manages sessions, API tokens, account lockouts, and password history.
 type AuthRepository interface {
 	// Sessions
 	CountSessions() (*int64, error)
@@ -421,6 +421,23 @@ type AuthRepository interface {
 	GetTokenByUserId(types.NullableUserID) (*[]Tokens, error)
 	ListTokens() (*[]Tokens, error)
 	UpdateToken(context.Context, audited.AuditContext, UpdateTokenParams) (*string, error)
+
+	// UserLockouts
+	CountUserLockouts() (*int64, error)
+	CreateUserLockoutTable() error
+	DeleteUserLockout(types.UserID) error
+	GetUserLockout(types.UserID) (*UserLockout, error)
+	IncrementUserLockout(IncrementUserLockoutParams) error
+	ListUserLockouts() (*[]UserLockout, error)
+	LockUserLockout(LockUserLockoutParams) (bool, error)
+	UpsertUserLockout(UpsertUserLockoutParams) error
+
+	// PasswordHistory
+	CountPasswordHistory() (*int64, error)
+	CreatePasswordHistory(CreatePasswordHistoryParams) error
+	CreatePasswordHistoryTable() error
+	DeletePasswordHistory(types.PasswordHistoryID) error
+	ListPasswordHistoryByUserID(types.UserID) (*[]PasswordHistory, error)
 }
 
 // RBACRepository manages roles, permissions, and the role-permission junction.
@@ -456,6 +473,49 @@ type RBACRepository interface {
 	ListRolePermissionsByRoleID(types.RoleID) (*[]RolePermissions, error)
 	ListRolePermissionsByPermissionID(types.PermissionID) (*[]RolePermissions, error)
 	ListPermissionLabelsByRoleID(types.RoleID) (*[]string, error)
+
+	// UserRoles
+	CountUserRoles() (*int64, error)
+	CreateUserRole(context.Context, audited.AuditContext, CreateUserRoleParams) (*UserRoles, error)
+	CreateUserRolesTable() error
+	DeleteUserRole(context.Context, audited.AuditContext, types.UserRoleID) error
+	DeleteUserRolesByUserID(context.Context, audited.AuditContext, types.UserID) error
+	GetUserRole(types.UserRoleID) (*UserRoles, error)
+	ListUserRoles() (*[]UserRoles, error)
+	ListUserRolesByUserID(types.UserID) (*[]UserRoles, error)
+	ListUserRolesByRoleID(types.RoleID) (*[]UserRoles, error)
+
+	// UserGroups
+	CountUserGroups() (*int64, error)
+	CreateUserGroup(context.Context, audited.AuditContext, CreateUserGroupParams) (*UserGroups, error)
+	CreateUserGroupTable() error
+	DeleteUserGroup(context.Context, audited.AuditContext, types.UserGroupID) error
+	GetUserGroup(types.UserGroupID) (*UserGroups, error)
+	GetUserGroupByLabel(string) (*UserGroups, error)
+	ListUserGroups() (*[]UserGroups, error)
+	UpdateUserGroup(context.Context, audited.AuditContext, UpdateUserGroupParams) (*string, error)
+
+	// UserGroupMembers
+	CountUserGroupMembers() (*int64, error)
+	CreateUserGroupMember(context.Context, audited.AuditContext, CreateUserGroupMemberParams) (*UserGroupMembers, error)
+	CreateUserGroupMembersTable() error
+	DeleteUserGroupMember(context.Context, audited.AuditContext, types.UserGroupMemberID) error
+	DeleteUserGroupMembersByUserGroupID(context.Context, audited.AuditContext, types.UserGroupID) error
+	GetUserGroupMember(types.UserGroupMemberID) (*UserGroupMembers, error)
+	ListUserGroupMembers() (*[]UserGroupMembers, error)
+	ListUserGroupMembersByUserGroupID(types.UserGroupID) (*[]UserGroupMembers, error)
+	ListUserGroupMembersByUserID(types.UserID) (*[]UserGroupMembers, error)
+
+	// UserGroupRoles
+	CountUserGroupRoles() (*int64, error)
+	CreateUserGroupRole(context.Context, audited.AuditContext, CreateUserGroupRoleParams) (*UserGroupRoles, error)
+	CreateUserGroupRolesTable() error
+	DeleteUserGroupRole(context.Context, audited.AuditContext, types.UserGroupRoleID) error
+	DeleteUserGroupRolesByUserGroupID(context.Context, audited.AuditContext, types.UserGroupID) error
+	GetUserGroupRole(types.UserGroupRoleID) (*UserGroupRoles, error)
+	ListUserGroupRoles() (*[]UserGroupRoles, error)
+	ListUserGroupRolesByUserGroupID(types.UserGroupID) (*[]UserGroupRoles, error)
+	ListUserGroupRolesByRoleID(types.RoleID) (*[]UserGroupRoles, error)
 }
 
 // BackupRepository manages backups, backup sets, and backup verifications.
@@ -683,3 +743,94 @@ type FieldPluginConfigRepository interface {
 	DeleteFieldPluginConfig(context.Context, types.FieldID) error
 	DeleteAdminFieldPluginConfig(context.Context, types.FieldID) error
 }
+
+// RateLimitRepository manages the shared fixed-window counters used by the
+// database-backed rate limiter store.
+type RateLimitRepository interface {
+	CountRateLimitCounters() (*int64, error)
+	CreateRateLimitCounterTable() error
+	DeleteExpiredRateLimitCounters(int64) error
+	IncrementRateLimitCounter(IncrementRateLimitCounterParams) (*RateLimitCounter, error)
+}
+
+// ContentMigrationRepository records which content migrations have run.
+type ContentMigrationRepository interface {
+	CountContentMigrations() (*int64, error)
+	CreateContentMigrationTable() error
+	GetContentMigration(string) (*ContentMigration, error)
+	ListContentMigrations() (*[]ContentMigration, error)
+	RecordContentMigration(RecordContentMigrationParams) error
+}
+
+// ContentLeaseRepository manages advisory edit leases and presence records
+// for content nodes.
+type ContentLeaseRepository interface {
+	CountContentLeases() (*int64, error)
+	CreateContentLease(CreateContentLeaseParams) (*ContentLease, error)
+	CreateContentLeaseTable() error
+	DeleteContentLease(string) error
+	DeleteExpiredContentLeases(int64) error
+	GetContentLease(string) (*ContentLease, error)
+	ListContentLeasesByContent(types.ContentID, int64) (*[]ContentLease, error)
+	UpdateContentLease(UpdateContentLeaseParams) error
+}
+
+// TranslationMemoryRepository manages remembered source and target values
+// for locale pairs.
+type TranslationMemoryRepository interface {
+	CountTranslationMemory() (*int64, error)
+	CountTranslationMemoryByPair(string, string) (*int64, error)
+	CreateTranslationMemory(CreateTranslationMemoryParams) (*TranslationMemoryEntry, error)
+	CreateTranslationMemoryTable() error
+	DeleteTranslationMemory(string) error
+	GetTranslationMemory(string) (*TranslationMemoryEntry, error)
+	GetTranslationMemoryBySource(string, string, string) (*TranslationMemoryEntry, error)
+	ListTranslationMemoryByPair(ListTranslationMemoryByPairParams) (*[]TranslationMemoryEntry, error)
+	ListTranslationMemoryCandidates(ListTranslationMemoryCandidatesParams) (*[]TranslationMemoryEntry, error)
+	UpdateTranslationMemory(UpdateTranslationMemoryParams) error
+}
+
+// TranslationReviewRepository manages the review flags on pre-filled
+// translations.
+type TranslationReviewRepository interface {
+	CountTranslationReviews() (*int64, error)
+	CreateTranslationReview(CreateTranslationReviewParams) (*TranslationReview, error)
+	CreateTranslationReviewTable() error
+	DeleteTranslationReview(types.ContentFieldID) error
+	GetTranslationReview(types.ContentFieldID) (*TranslationReview, error)
+	ListTranslationReviewsByLocale(string) (*[]TranslationReview, error)
+	ListTranslationReviewsByRoute(types.NullableRouteID, string) (*[]TranslationReview, error)
+}
+
+// TranslationSourceRepository manages the source hashes translations were
+// based on.
+type TranslationSourceRepository interface {
+	CountTranslationSources() (*int64, error)
+	CreateTranslationSource(CreateTranslationSourceParams) (*TranslationSource, error)
+	CreateTranslationSourceTable() error
+	DeleteTranslationSource(types.ContentFieldID) error
+	GetTranslationSource(types.ContentFieldID) (*TranslationSource, error)
+	ListTranslationSourcesByField(types.ContentID, types.FieldID) (*[]TranslationSource, error)
+	ListTranslationSourcesByLocale(string) (*[]TranslationSource, error)
+	ListTranslationSourcesByRoute(types.NullableRouteID, string) (*[]TranslationSource, error)
+	UpdateTranslationSource(types.ContentFieldID, string, int64) error
+}
+
+// BlueprintRepository manages saved content blueprints and the links from
+// content created with them.
+type BlueprintRepository interface {
+	CountBlueprintInstances() (*int64, error)
+	CountBlueprints() (*int64, error)
+	CreateBlueprint(CreateBlueprintParams) (*Blueprint, error)
+	CreateBlueprintInstanceTable() error
+	CreateBlueprintTable() error
+	DeleteBlueprint(string) error
+	DeleteBlueprintInstancesByBlueprint(string) error
+	GetBlueprint(string) (*Blueprint, error)
+	GetBlueprintByName(string) (*Blueprint, error)
+	GetBlueprintInstance(types.ContentID) (*BlueprintInstance, error)
+	ListBlueprintInstances() (*[]BlueprintInstance, error)
+	ListBlueprintInstancesByBlueprint(string) (*[]BlueprintInstance, error)
+	ListBlueprints() (*[]Blueprint, error)
+	UpdateBlueprint(UpdateBlueprintParams) error
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hegner123/modulacms/internal/db/audited"
+)
+
+// CreateRouteInTx creates a route with audit trail in an existing transaction.
+func CreateRouteInTx(d DbDriver, ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params CreateRouteParams) (*Routes, error) {
+	switch drv := d.(type) {
+	case Database:
+		result, err := audited.CreateInTx(Database{}.NewRouteCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create route: %w", err)
+		}
+		r := drv.MapRoute(result)
+		return &r, nil
+	case MysqlDatabase:
+		result, err := audited.CreateInTx(MysqlDatabase{}.NewRouteCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create route: %w", err)
+		}
+		r := drv.MapRoute(result)
+		return &r, nil
+	case PsqlDatabase:
+		result, err := audited.CreateInTx(PsqlDatabase{}.NewRouteCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create route: %w", err)
+		}
+		r := drv.MapRoute(result)
+		return &r, nil
+	default:
+		return nil, fmt.Errorf("tx create route: unsupported driver type %T", d)
+	}
+}
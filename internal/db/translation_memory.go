@@ -0,0 +1,610 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// The translation memory pairs a source-locale field value with the value
+// published for the same field in a target locale. Entries are keyed by the
+// hash of the normalized source text, so one row per distinct text and locale
+// pair; publishing a newer translation of the same text overwrites it. Rows
+// are derived data, written without audited commands.
+
+///////////////////////////////
+// STRUCTS
+//////////////////////////////
+
+// TranslationMemoryEntry is one remembered translation. SourceLength is the
+// rune length of the normalized source text; UpdatedAt is Unix seconds.
+type TranslationMemoryEntry struct {
+	TmID          string          `json:"tm_id"`
+	SourceLocale  string          `json:"source_locale"`
+	TargetLocale  string          `json:"target_locale"`
+	SourceHash    string          `json:"source_hash"`
+	SourceText    string          `json:"source_text"`
+	TargetText    string          `json:"target_text"`
+	SourceLength  int64           `json:"source_length"`
+	ContentDataID types.ContentID `json:"content_data_id"`
+	FieldID       types.FieldID   `json:"field_id"`
+	UpdatedAt     int64           `json:"updated_at"`
+}
+
+// CreateTranslationMemoryParams contains parameters for creating a translation
+// memory entry.
+type CreateTranslationMemoryParams struct {
+	TmID          string          `json:"tm_id"`
+	SourceLocale  string          `json:"source_locale"`
+	TargetLocale  string          `json:"target_locale"`
+	SourceHash    string          `json:"source_hash"`
+	SourceText    string          `json:"source_text"`
+	TargetText    string          `json:"target_text"`
+	SourceLength  int64           `json:"source_length"`
+	ContentDataID types.ContentID `json:"content_data_id"`
+	FieldID       types.FieldID   `json:"field_id"`
+	UpdatedAt     int64           `json:"updated_at"`
+}
+
+// UpdateTranslationMemoryParams replaces an entry's translation and the field
+// it was last published from.
+type UpdateTranslationMemoryParams struct {
+	TmID          string          `json:"tm_id"`
+	TargetText    string          `json:"target_text"`
+	ContentDataID types.ContentID `json:"content_data_id"`
+	FieldID       types.FieldID   `json:"field_id"`
+	UpdatedAt     int64           `json:"updated_at"`
+}
+
+// ListTranslationMemoryCandidatesParams selects the most recent entries for a
+// locale pair whose source length falls within [MinLength, MaxLength].
+type ListTranslationMemoryCandidatesParams struct {
+	SourceLocale string `json:"source_locale"`
+	TargetLocale string `json:"target_locale"`
+	MinLength    int64  `json:"min_length"`
+	MaxLength    int64  `json:"max_length"`
+	Limit        int64  `json:"limit"`
+}
+
+// ListTranslationMemoryByPairParams pages through the entries for a locale
+// pair, most recently updated first.
+type ListTranslationMemoryByPairParams struct {
+	SourceLocale string `json:"source_locale"`
+	TargetLocale string `json:"target_locale"`
+	Limit        int64  `json:"limit"`
+	Offset       int64  `json:"offset"`
+}
+
+///////////////////////////////
+// SQLITE
+//////////////////////////////
+
+// MAPS
+
+// MapTranslationMemory converts a sqlc-generated type to the wrapper type.
+func (d Database) MapTranslationMemory(a mdb.TranslationMemory) TranslationMemoryEntry {
+	return TranslationMemoryEntry{
+		TmID:          a.TmID,
+		SourceLocale:  a.SourceLocale,
+		TargetLocale:  a.TargetLocale,
+		SourceHash:    a.SourceHash,
+		SourceText:    a.SourceText,
+		TargetText:    a.TargetText,
+		SourceLength:  a.SourceLength,
+		ContentDataID: a.ContentDataID,
+		FieldID:       a.FieldID,
+		UpdatedAt:     a.UpdatedAt,
+	}
+}
+
+// QUERIES
+
+// CreateTranslationMemoryTable creates the translation_memory table and its
+// indexes.
+func (d Database) CreateTranslationMemoryTable() error {
+	queries := mdb.New(d.Connection)
+	if err := queries.CreateTranslationMemoryTable(d.Context); err != nil {
+		return err
+	}
+	if err := queries.CreateTranslationMemoryIndexSource(d.Context); err != nil {
+		return err
+	}
+	return queries.CreateTranslationMemoryIndexLength(d.Context)
+}
+
+// CreateTranslationMemory inserts an entry and returns it.
+func (d Database) CreateTranslationMemory(params CreateTranslationMemoryParams) (*TranslationMemoryEntry, error) {
+	queries := mdb.New(d.Connection)
+	err := queries.CreateTranslationMemory(d.Context, mdb.CreateTranslationMemoryParams{
+		TmID:          params.TmID,
+		SourceLocale:  params.SourceLocale,
+		TargetLocale:  params.TargetLocale,
+		SourceHash:    params.SourceHash,
+		SourceText:    params.SourceText,
+		TargetText:    params.TargetText,
+		SourceLength:  params.SourceLength,
+		ContentDataID: params.ContentDataID,
+		FieldID:       params.FieldID,
+		UpdatedAt:     params.UpdatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation memory entry: %v", err)
+	}
+	res := TranslationMemoryEntry(params)
+	return &res, nil
+}
+
+// GetTranslationMemory returns an entry by ID.
+func (d Database) GetTranslationMemory(id string) (*TranslationMemoryEntry, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetTranslationMemory(d.Context, mdb.GetTranslationMemoryParams{TmID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translation memory entry: %w", err)
+	}
+	res := d.MapTranslationMemory(row)
+	return &res, nil
+}
+
+// GetTranslationMemoryBySource returns the entry for a locale pair and source
+// hash. The error wraps sql.ErrNoRows when there is none.
+func (d Database) GetTranslationMemoryBySource(sourceLocale, targetLocale, sourceHash string) (*TranslationMemoryEntry, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetTranslationMemoryBySource(d.Context, mdb.GetTranslationMemoryBySourceParams{
+		SourceLocale: sourceLocale,
+		TargetLocale: targetLocale,
+		SourceHash:   sourceHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translation memory entry: %w", err)
+	}
+	res := d.MapTranslationMemory(row)
+	return &res, nil
+}
+
+// ListTranslationMemoryCandidates returns fuzzy match candidates for a locale
+// pair within a source length window, most recent first.
+func (d Database) ListTranslationMemoryCandidates(params ListTranslationMemoryCandidatesParams) (*[]TranslationMemoryEntry, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListTranslationMemoryCandidates(d.Context, mdb.ListTranslationMemoryCandidatesParams{
+		SourceLocale: params.SourceLocale,
+		TargetLocale: params.TargetLocale,
+		MinLength:    params.MinLength,
+		MaxLength:    params.MaxLength,
+		Limit:        params.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation memory candidates: %v", err)
+	}
+	res := []TranslationMemoryEntry{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationMemory(v))
+	}
+	return &res, nil
+}
+
+// ListTranslationMemoryByPair returns a page of entries for a locale pair.
+func (d Database) ListTranslationMemoryByPair(params ListTranslationMemoryByPairParams) (*[]TranslationMemoryEntry, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListTranslationMemoryByPair(d.Context, mdb.ListTranslationMemoryByPairParams{
+		SourceLocale: params.SourceLocale,
+		TargetLocale: params.TargetLocale,
+		Limit:        params.Limit,
+		Offset:       params.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation memory: %v", err)
+	}
+	res := []TranslationMemoryEntry{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationMemory(v))
+	}
+	return &res, nil
+}
+
+// UpdateTranslationMemory replaces an entry's translation.
+func (d Database) UpdateTranslationMemory(params UpdateTranslationMemoryParams) error {
+	queries := mdb.New(d.Connection)
+	err := queries.UpdateTranslationMemory(d.Context, mdb.UpdateTranslationMemoryParams{
+		TargetText:    params.TargetText,
+		ContentDataID: params.ContentDataID,
+		FieldID:       params.FieldID,
+		UpdatedAt:     params.UpdatedAt,
+		TmID:          params.TmID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update translation memory entry: %v", err)
+	}
+	return nil
+}
+
+// DeleteTranslationMemory removes an entry.
+func (d Database) DeleteTranslationMemory(id string) error {
+	queries := mdb.New(d.Connection)
+	if err := queries.DeleteTranslationMemory(d.Context, mdb.DeleteTranslationMemoryParams{TmID: id}); err != nil {
+		return fmt.Errorf("failed to delete translation memory entry: %v", err)
+	}
+	return nil
+}
+
+// CountTranslationMemory returns the number of stored entries.
+func (d Database) CountTranslationMemory() (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountTranslationMemory(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count translation memory: %v", err)
+	}
+	return &c, nil
+}
+
+// CountTranslationMemoryByPair returns the number of entries for a locale pair.
+func (d Database) CountTranslationMemoryByPair(sourceLocale, targetLocale string) (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountTranslationMemoryByPair(d.Context, mdb.CountTranslationMemoryByPairParams{
+		SourceLocale: sourceLocale,
+		TargetLocale: targetLocale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count translation memory: %v", err)
+	}
+	return &c, nil
+}
+
+///////////////////////////////
+// MYSQL
+//////////////////////////////
+
+// MAPS
+
+// MapTranslationMemory converts a sqlc-generated type to the wrapper type.
+func (d MysqlDatabase) MapTranslationMemory(a mdbm.TranslationMemory) TranslationMemoryEntry {
+	return TranslationMemoryEntry{
+		TmID:          a.TmID,
+		SourceLocale:  a.SourceLocale,
+		TargetLocale:  a.TargetLocale,
+		SourceHash:    a.SourceHash,
+		SourceText:    a.SourceText,
+		TargetText:    a.TargetText,
+		SourceLength:  a.SourceLength,
+		ContentDataID: a.ContentDataID,
+		FieldID:       a.FieldID,
+		UpdatedAt:     a.UpdatedAt,
+	}
+}
+
+// QUERIES
+
+// CreateTranslationMemoryTable creates the translation_memory table and its
+// indexes.
+func (d MysqlDatabase) CreateTranslationMemoryTable() error {
+	queries := mdbm.New(d.Connection)
+	if err := queries.CreateTranslationMemoryTable(d.Context); err != nil {
+		return err
+	}
+	// MySQL has no CREATE INDEX IF NOT EXISTS; a second run reports the
+	// existing index, which is expected.
+	for _, create := range []func(context.Context) error{
+		queries.CreateTranslationMemoryIndexSource,
+		queries.CreateTranslationMemoryIndexLength,
+	} {
+		if err := create(d.Context); err != nil && !strings.Contains(err.Error(), "Duplicate key name") {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateTranslationMemory inserts an entry and returns it.
+func (d MysqlDatabase) CreateTranslationMemory(params CreateTranslationMemoryParams) (*TranslationMemoryEntry, error) {
+	queries := mdbm.New(d.Connection)
+	err := queries.CreateTranslationMemory(d.Context, mdbm.CreateTranslationMemoryParams{
+		TmID:          params.TmID,
+		SourceLocale:  params.SourceLocale,
+		TargetLocale:  params.TargetLocale,
+		SourceHash:    params.SourceHash,
+		SourceText:    params.SourceText,
+		TargetText:    params.TargetText,
+		SourceLength:  params.SourceLength,
+		ContentDataID: params.ContentDataID,
+		FieldID:       params.FieldID,
+		UpdatedAt:     params.UpdatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation memory entry: %v", err)
+	}
+	res := TranslationMemoryEntry(params)
+	return &res, nil
+}
+
+// GetTranslationMemory returns an entry by ID.
+func (d MysqlDatabase) GetTranslationMemory(id string) (*TranslationMemoryEntry, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetTranslationMemory(d.Context, mdbm.GetTranslationMemoryParams{TmID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translation memory entry: %w", err)
+	}
+	res := d.MapTranslationMemory(row)
+	return &res, nil
+}
+
+// GetTranslationMemoryBySource returns the entry for a locale pair and source
+// hash. The error wraps sql.ErrNoRows when there is none.
+func (d MysqlDatabase) GetTranslationMemoryBySource(sourceLocale, targetLocale, sourceHash string) (*TranslationMemoryEntry, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetTranslationMemoryBySource(d.Context, mdbm.GetTranslationMemoryBySourceParams{
+		SourceLocale: sourceLocale,
+		TargetLocale: targetLocale,
+		SourceHash:   sourceHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translation memory entry: %w", err)
+	}
+	res := d.MapTranslationMemory(row)
+	return &res, nil
+}
+
+// ListTranslationMemoryCandidates returns fuzzy match candidates for a locale
+// pair within a source length window, most recent first.
+func (d MysqlDatabase) ListTranslationMemoryCandidates(params ListTranslationMemoryCandidatesParams) (*[]TranslationMemoryEntry, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListTranslationMemoryCandidates(d.Context, mdbm.ListTranslationMemoryCandidatesParams{
+		SourceLocale: params.SourceLocale,
+		TargetLocale: params.TargetLocale,
+		MinLength:    params.MinLength,
+		MaxLength:    params.MaxLength,
+		Limit:        int32(params.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation memory candidates: %v", err)
+	}
+	res := []TranslationMemoryEntry{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationMemory(v))
+	}
+	return &res, nil
+}
+
+// ListTranslationMemoryByPair returns a page of entries for a locale pair.
+func (d MysqlDatabase) ListTranslationMemoryByPair(params ListTranslationMemoryByPairParams) (*[]TranslationMemoryEntry, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListTranslationMemoryByPair(d.Context, mdbm.ListTranslationMemoryByPairParams{
+		SourceLocale: params.SourceLocale,
+		TargetLocale: params.TargetLocale,
+		Limit:        int32(params.Limit),
+		Offset:       int32(params.Offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation memory: %v", err)
+	}
+	res := []TranslationMemoryEntry{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationMemory(v))
+	}
+	return &res, nil
+}
+
+// UpdateTranslationMemory replaces an entry's translation.
+func (d MysqlDatabase) UpdateTranslationMemory(params UpdateTranslationMemoryParams) error {
+	queries := mdbm.New(d.Connection)
+	err := queries.UpdateTranslationMemory(d.Context, mdbm.UpdateTranslationMemoryParams{
+		TargetText:    params.TargetText,
+		ContentDataID: params.ContentDataID,
+		FieldID:       params.FieldID,
+		UpdatedAt:     params.UpdatedAt,
+		TmID:          params.TmID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update translation memory entry: %v", err)
+	}
+	return nil
+}
+
+// DeleteTranslationMemory removes an entry.
+func (d MysqlDatabase) DeleteTranslationMemory(id string) error {
+	queries := mdbm.New(d.Connection)
+	if err := queries.DeleteTranslationMemory(d.Context, mdbm.DeleteTranslationMemoryParams{TmID: id}); err != nil {
+		return fmt.Errorf("failed to delete translation memory entry: %v", err)
+	}
+	return nil
+}
+
+// CountTranslationMemory returns the number of stored entries.
+func (d MysqlDatabase) CountTranslationMemory() (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountTranslationMemory(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count translation memory: %v", err)
+	}
+	return &c, nil
+}
+
+// CountTranslationMemoryByPair returns the number of entries for a locale pair.
+func (d MysqlDatabase) CountTranslationMemoryByPair(sourceLocale, targetLocale string) (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountTranslationMemoryByPair(d.Context, mdbm.CountTranslationMemoryByPairParams{
+		SourceLocale: sourceLocale,
+		TargetLocale: targetLocale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count translation memory: %v", err)
+	}
+	return &c, nil
+}
+
+///////////////////////////////
+// POSTGRES
+//////////////////////////////
+
+// MAPS
+
+// MapTranslationMemory converts a sqlc-generated type to the wrapper type.
+func (d PsqlDatabase) MapTranslationMemory(a mdbp.TranslationMemory) TranslationMemoryEntry {
+	return TranslationMemoryEntry{
+		TmID:          a.TmID,
+		SourceLocale:  a.SourceLocale,
+		TargetLocale:  a.TargetLocale,
+		SourceHash:    a.SourceHash,
+		SourceText:    a.SourceText,
+		TargetText:    a.TargetText,
+		SourceLength:  a.SourceLength,
+		ContentDataID: a.ContentDataID,
+		FieldID:       a.FieldID,
+		UpdatedAt:     a.UpdatedAt,
+	}
+}
+
+// QUERIES
+
+// CreateTranslationMemoryTable creates the translation_memory table and its
+// indexes.
+func (d PsqlDatabase) CreateTranslationMemoryTable() error {
+	queries := mdbp.New(d.Connection)
+	if err := queries.CreateTranslationMemoryTable(d.Context); err != nil {
+		return err
+	}
+	if err := queries.CreateTranslationMemoryIndexSource(d.Context); err != nil {
+		return err
+	}
+	return queries.CreateTranslationMemoryIndexLength(d.Context)
+}
+
+// CreateTranslationMemory inserts an entry and returns it.
+func (d PsqlDatabase) CreateTranslationMemory(params CreateTranslationMemoryParams) (*TranslationMemoryEntry, error) {
+	queries := mdbp.New(d.Connection)
+	err := queries.CreateTranslationMemory(d.Context, mdbp.CreateTranslationMemoryParams{
+		TmID:          params.TmID,
+		SourceLocale:  params.SourceLocale,
+		TargetLocale:  params.TargetLocale,
+		SourceHash:    params.SourceHash,
+		SourceText:    params.SourceText,
+		TargetText:    params.TargetText,
+		SourceLength:  params.SourceLength,
+		ContentDataID: params.ContentDataID,
+		FieldID:       params.FieldID,
+		UpdatedAt:     params.UpdatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation memory entry: %v", err)
+	}
+	res := TranslationMemoryEntry(params)
+	return &res, nil
+}
+
+// GetTranslationMemory returns an entry by ID.
+func (d PsqlDatabase) GetTranslationMemory(id string) (*TranslationMemoryEntry, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetTranslationMemory(d.Context, mdbp.GetTranslationMemoryParams{TmID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translation memory entry: %w", err)
+	}
+	res := d.MapTranslationMemory(row)
+	return &res, nil
+}
+
+// GetTranslationMemoryBySource returns the entry for a locale pair and source
+// hash. The error wraps sql.ErrNoRows when there is none.
+func (d PsqlDatabase) GetTranslationMemoryBySource(sourceLocale, targetLocale, sourceHash string) (*TranslationMemoryEntry, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetTranslationMemoryBySource(d.Context, mdbp.GetTranslationMemoryBySourceParams{
+		SourceLocale: sourceLocale,
+		TargetLocale: targetLocale,
+		SourceHash:   sourceHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translation memory entry: %w", err)
+	}
+	res := d.MapTranslationMemory(row)
+	return &res, nil
+}
+
+// ListTranslationMemoryCandidates returns fuzzy match candidates for a locale
+// pair within a source length window, most recent first.
+func (d PsqlDatabase) ListTranslationMemoryCandidates(params ListTranslationMemoryCandidatesParams) (*[]TranslationMemoryEntry, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListTranslationMemoryCandidates(d.Context, mdbp.ListTranslationMemoryCandidatesParams{
+		SourceLocale: params.SourceLocale,
+		TargetLocale: params.TargetLocale,
+		MinLength:    params.MinLength,
+		MaxLength:    params.MaxLength,
+		Limit:        int32(params.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation memory candidates: %v", err)
+	}
+	res := []TranslationMemoryEntry{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationMemory(v))
+	}
+	return &res, nil
+}
+
+// ListTranslationMemoryByPair returns a page of entries for a locale pair.
+func (d PsqlDatabase) ListTranslationMemoryByPair(params ListTranslationMemoryByPairParams) (*[]TranslationMemoryEntry, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListTranslationMemoryByPair(d.Context, mdbp.ListTranslationMemoryByPairParams{
+		SourceLocale: params.SourceLocale,
+		TargetLocale: params.TargetLocale,
+		Limit:        int32(params.Limit),
+		Offset:       int32(params.Offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation memory: %v", err)
+	}
+	res := []TranslationMemoryEntry{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationMemory(v))
+	}
+	return &res, nil
+}
+
+// UpdateTranslationMemory replaces an entry's translation.
+func (d PsqlDatabase) UpdateTranslationMemory(params UpdateTranslationMemoryParams) error {
+	queries := mdbp.New(d.Connection)
+	err := queries.UpdateTranslationMemory(d.Context, mdbp.UpdateTranslationMemoryParams{
+		TargetText:    params.TargetText,
+		ContentDataID: params.ContentDataID,
+		FieldID:       params.FieldID,
+		UpdatedAt:     params.UpdatedAt,
+		TmID:          params.TmID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update translation memory entry: %v", err)
+	}
+	return nil
+}
+
+// DeleteTranslationMemory removes an entry.
+func (d PsqlDatabase) DeleteTranslationMemory(id string) error {
+	queries := mdbp.New(d.Connection)
+	if err := queries.DeleteTranslationMemory(d.Context, mdbp.DeleteTranslationMemoryParams{TmID: id}); err != nil {
+		return fmt.Errorf("failed to delete translation memory entry: %v", err)
+	}
+	return nil
+}
+
+// CountTranslationMemory returns the number of stored entries.
+func (d PsqlDatabase) CountTranslationMemory() (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountTranslationMemory(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count translation memory: %v", err)
+	}
+	return &c, nil
+}
+
+// CountTranslationMemoryByPair returns the number of entries for a locale pair.
+func (d PsqlDatabase) CountTranslationMemoryByPair(sourceLocale, targetLocale string) (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountTranslationMemoryByPair(d.Context, mdbp.CountTranslationMemoryByPairParams{
+		SourceLocale: sourceLocale,
+		TargetLocale: targetLocale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count translation memory: %v", err)
+	}
+	return &c, nil
+}
@@ -0,0 +1,102 @@
+// Integration tests for the translation_memory table.
+//
+// The table is NON-audited (no ctx/ac parameters on mutations).
+package db
+
+import (
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+func TestDatabase_TranslationMemory_Lifecycle(t *testing.T) {
+	t.Parallel()
+	d := testIntegrationDB(t)
+
+	create := func(hash, source, target string, length, updated int64) *TranslationMemoryEntry {
+		t.Helper()
+		e, err := d.CreateTranslationMemory(CreateTranslationMemoryParams{
+			TmID:          types.NewULID().String(),
+			SourceLocale:  "en",
+			TargetLocale:  "fr",
+			SourceHash:    hash,
+			SourceText:    source,
+			TargetText:    target,
+			SourceLength:  length,
+			ContentDataID: types.NewContentID(),
+			FieldID:       types.NewFieldID(),
+			UpdatedAt:     updated,
+		})
+		if err != nil {
+			t.Fatalf("CreateTranslationMemory: %v", err)
+		}
+		return e
+	}
+	hello := create("h1", "Hello", "Bonjour", 5, 100)
+	create("h2", "Hello world", "Bonjour le monde", 11, 200)
+	create("h3", "A much longer sentence", "Une phrase bien plus longue", 22, 300)
+
+	got, err := d.GetTranslationMemoryBySource("en", "fr", "h1")
+	if err != nil {
+		t.Fatalf("GetTranslationMemoryBySource: %v", err)
+	}
+	if got.TmID != hello.TmID || got.TargetText != "Bonjour" {
+		t.Errorf("GetTranslationMemoryBySource = %+v", got)
+	}
+	if _, err := d.GetTranslationMemoryBySource("en", "de", "h1"); err == nil {
+		t.Error("GetTranslationMemoryBySource for another pair: expected error")
+	}
+
+	candidates, err := d.ListTranslationMemoryCandidates(ListTranslationMemoryCandidatesParams{
+		SourceLocale: "en",
+		TargetLocale: "fr",
+		MinLength:    4,
+		MaxLength:    12,
+		Limit:        10,
+	})
+	if err != nil {
+		t.Fatalf("ListTranslationMemoryCandidates: %v", err)
+	}
+	if len(*candidates) != 2 || (*candidates)[0].SourceHash != "h2" {
+		t.Errorf("candidates = %+v, want h2 then h1", *candidates)
+	}
+
+	newContent := types.NewContentID()
+	if err := d.UpdateTranslationMemory(UpdateTranslationMemoryParams{
+		TmID:          hello.TmID,
+		TargetText:    "Salut",
+		ContentDataID: newContent,
+		FieldID:       hello.FieldID,
+		UpdatedAt:     400,
+	}); err != nil {
+		t.Fatalf("UpdateTranslationMemory: %v", err)
+	}
+	page, err := d.ListTranslationMemoryByPair(ListTranslationMemoryByPairParams{SourceLocale: "en", TargetLocale: "fr", Limit: 2})
+	if err != nil {
+		t.Fatalf("ListTranslationMemoryByPair: %v", err)
+	}
+	if len(*page) != 2 || (*page)[0].TargetText != "Salut" || (*page)[0].ContentDataID != newContent {
+		t.Errorf("first page = %+v, want the updated entry first", *page)
+	}
+	count, err := d.CountTranslationMemoryByPair("en", "fr")
+	if err != nil {
+		t.Fatalf("CountTranslationMemoryByPair: %v", err)
+	}
+	if *count != 3 {
+		t.Errorf("pair count = %d, want 3", *count)
+	}
+
+	if err := d.DeleteTranslationMemory(hello.TmID); err != nil {
+		t.Fatalf("DeleteTranslationMemory: %v", err)
+	}
+	if _, err := d.GetTranslationMemory(hello.TmID); err == nil {
+		t.Error("GetTranslationMemory after delete: expected error")
+	}
+	count, err = d.CountTranslationMemory()
+	if err != nil {
+		t.Fatalf("CountTranslationMemory: %v", err)
+	}
+	if *count != 2 {
+		t.Errorf("count after delete = %d, want 2", *count)
+	}
+}
@@ -0,0 +1,392 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// Translation reviews flag locale field values that were pre-filled from the
+// translation memory's fuzzy matches or by a machine-translation provider.
+// A flagged field blocks publishing its locale until someone edits it or
+// marks it reviewed, which deletes the row. Rows whose content field was
+// deleted are ignored by the list queries.
+
+///////////////////////////////
+// STRUCTS
+//////////////////////////////
+
+// TranslationReview marks one content field as awaiting review. Origin is
+// "memory" or "machine"; Provider names the machine-translation provider and
+// Score is the fuzzy match percentage. CreatedAt is Unix seconds.
+type TranslationReview struct {
+	ContentFieldID types.ContentFieldID `json:"content_field_id"`
+	ContentDataID  types.ContentID      `json:"content_data_id"`
+	Locale         string               `json:"locale"`
+	Origin         string               `json:"origin"`
+	Provider       string               `json:"provider"`
+	Score          int64                `json:"score"`
+	CreatedAt      int64                `json:"created_at"`
+}
+
+// CreateTranslationReviewParams contains parameters for flagging a field.
+type CreateTranslationReviewParams struct {
+	ContentFieldID types.ContentFieldID `json:"content_field_id"`
+	ContentDataID  types.ContentID      `json:"content_data_id"`
+	Locale         string               `json:"locale"`
+	Origin         string               `json:"origin"`
+	Provider       string               `json:"provider"`
+	Score          int64                `json:"score"`
+	CreatedAt      int64                `json:"created_at"`
+}
+
+///////////////////////////////
+// SQLITE
+//////////////////////////////
+
+// MAPS
+
+// MapTranslationReview converts a sqlc-generated type to the wrapper type.
+func (d Database) MapTranslationReview(a mdb.TranslationReviews) TranslationReview {
+	return TranslationReview{
+		ContentFieldID: a.ContentFieldID,
+		ContentDataID:  a.ContentDataID,
+		Locale:         a.Locale,
+		Origin:         a.Origin,
+		Provider:       a.Provider,
+		Score:          a.Score,
+		CreatedAt:      a.CreatedAt,
+	}
+}
+
+// QUERIES
+
+// CreateTranslationReviewTable creates the translation_reviews table and its
+// index.
+func (d Database) CreateTranslationReviewTable() error {
+	queries := mdb.New(d.Connection)
+	if err := queries.CreateTranslationReviewsTable(d.Context); err != nil {
+		return err
+	}
+	return queries.CreateTranslationReviewsIndexLocale(d.Context)
+}
+
+// CreateTranslationReview flags a field and returns the flag.
+func (d Database) CreateTranslationReview(params CreateTranslationReviewParams) (*TranslationReview, error) {
+	queries := mdb.New(d.Connection)
+	err := queries.CreateTranslationReview(d.Context, mdb.CreateTranslationReviewParams{
+		ContentFieldID: params.ContentFieldID,
+		ContentDataID:  params.ContentDataID,
+		Locale:         params.Locale,
+		Origin:         params.Origin,
+		Provider:       params.Provider,
+		Score:          params.Score,
+		CreatedAt:      params.CreatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation review: %v", err)
+	}
+	res := TranslationReview(params)
+	return &res, nil
+}
+
+// GetTranslationReview returns the flag on a content field. The error wraps
+// sql.ErrNoRows when the field is not flagged.
+func (d Database) GetTranslationReview(id types.ContentFieldID) (*TranslationReview, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetTranslationReview(d.Context, mdb.GetTranslationReviewParams{ContentFieldID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translation review: %w", err)
+	}
+	res := d.MapTranslationReview(row)
+	return &res, nil
+}
+
+// ListTranslationReviewsByLocale returns the flagged fields in a locale.
+func (d Database) ListTranslationReviewsByLocale(locale string) (*[]TranslationReview, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListTranslationReviewsByLocale(d.Context, mdb.ListTranslationReviewsByLocaleParams{Locale: locale})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation reviews: %v", err)
+	}
+	res := []TranslationReview{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationReview(v))
+	}
+	return &res, nil
+}
+
+// ListTranslationReviewsByRoute returns the flagged fields of a route's
+// content in a locale.
+func (d Database) ListTranslationReviewsByRoute(routeID types.NullableRouteID, locale string) (*[]TranslationReview, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListTranslationReviewsByRoute(d.Context, mdb.ListTranslationReviewsByRouteParams{
+		RouteID: routeID,
+		Locale:  locale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation reviews: %v", err)
+	}
+	res := []TranslationReview{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationReview(v))
+	}
+	return &res, nil
+}
+
+// DeleteTranslationReview clears the flag on a content field. Clearing a
+// field that is not flagged is not an error.
+func (d Database) DeleteTranslationReview(id types.ContentFieldID) error {
+	queries := mdb.New(d.Connection)
+	if err := queries.DeleteTranslationReview(d.Context, mdb.DeleteTranslationReviewParams{ContentFieldID: id}); err != nil {
+		return fmt.Errorf("failed to delete translation review: %v", err)
+	}
+	return nil
+}
+
+// CountTranslationReviews returns the number of flagged fields.
+func (d Database) CountTranslationReviews() (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountTranslationReviews(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count translation reviews: %v", err)
+	}
+	return &c, nil
+}
+
+///////////////////////////////
+// MYSQL
+//////////////////////////////
+
+// MAPS
+
+// MapTranslationReview converts a sqlc-generated type to the wrapper type.
+func (d MysqlDatabase) MapTranslationReview(a mdbm.TranslationReviews) TranslationReview {
+	return TranslationReview{
+		ContentFieldID: a.ContentFieldID,
+		ContentDataID:  a.ContentDataID,
+		Locale:         a.Locale,
+		Origin:         a.Origin,
+		Provider:       a.Provider,
+		Score:          a.Score,
+		CreatedAt:      a.CreatedAt,
+	}
+}
+
+// QUERIES
+
+// CreateTranslationReviewTable creates the translation_reviews table and its
+// index.
+func (d MysqlDatabase) CreateTranslationReviewTable() error {
+	queries := mdbm.New(d.Connection)
+	if err := queries.CreateTranslationReviewsTable(d.Context); err != nil {
+		return err
+	}
+	// MySQL has no CREATE INDEX IF NOT EXISTS; a second run reports the
+	// existing index, which is expected.
+	if err := queries.CreateTranslationReviewsIndexLocale(d.Context); err != nil && !strings.Contains(err.Error(), "Duplicate key name") {
+		return err
+	}
+	return nil
+}
+
+// CreateTranslationReview flags a field and returns the flag.
+func (d MysqlDatabase) CreateTranslationReview(params CreateTranslationReviewParams) (*TranslationReview, error) {
+	queries := mdbm.New(d.Connection)
+	err := queries.CreateTranslationReview(d.Context, mdbm.CreateTranslationReviewParams{
+		ContentFieldID: params.ContentFieldID,
+		ContentDataID:  params.ContentDataID,
+		Locale:         params.Locale,
+		Origin:         params.Origin,
+		Provider:       params.Provider,
+		Score:          params.Score,
+		CreatedAt:      params.CreatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation review: %v", err)
+	}
+	res := TranslationReview(params)
+	return &res, nil
+}
+
+// GetTranslationReview returns the flag on a content field. The error wraps
+// sql.ErrNoRows when the field is not flagged.
+func (d MysqlDatabase) GetTranslationReview(id types.ContentFieldID) (*TranslationReview, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetTranslationReview(d.Context, mdbm.GetTranslationReviewParams{ContentFieldID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translation review: %w", err)
+	}
+	res := d.MapTranslationReview(row)
+	return &res, nil
+}
+
+// ListTranslationReviewsByLocale returns the flagged fields in a locale.
+func (d MysqlDatabase) ListTranslationReviewsByLocale(locale string) (*[]TranslationReview, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListTranslationReviewsByLocale(d.Context, mdbm.ListTranslationReviewsByLocaleParams{Locale: locale})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation reviews: %v", err)
+	}
+	res := []TranslationReview{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationReview(v))
+	}
+	return &res, nil
+}
+
+// ListTranslationReviewsByRoute returns the flagged fields of a route's
+// content in a locale.
+func (d MysqlDatabase) ListTranslationReviewsByRoute(routeID types.NullableRouteID, locale string) (*[]TranslationReview, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListTranslationReviewsByRoute(d.Context, mdbm.ListTranslationReviewsByRouteParams{
+		RouteID: routeID,
+		Locale:  locale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation reviews: %v", err)
+	}
+	res := []TranslationReview{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationReview(v))
+	}
+	return &res, nil
+}
+
+// DeleteTranslationReview clears the flag on a content field. Clearing a
+// field that is not flagged is not an error.
+func (d MysqlDatabase) DeleteTranslationReview(id types.ContentFieldID) error {
+	queries := mdbm.New(d.Connection)
+	if err := queries.DeleteTranslationReview(d.Context, mdbm.DeleteTranslationReviewParams{ContentFieldID: id}); err != nil {
+		return fmt.Errorf("failed to delete translation review: %v", err)
+	}
+	return nil
+}
+
+// CountTranslationReviews returns the number of flagged fields.
+func (d MysqlDatabase) CountTranslationReviews() (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountTranslationReviews(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count translation reviews: %v", err)
+	}
+	return &c, nil
+}
+
+///////////////////////////////
+// POSTGRES
+//////////////////////////////
+
+// MAPS
+
+// MapTranslationReview converts a sqlc-generated type to the wrapper type.
+func (d PsqlDatabase) MapTranslationReview(a mdbp.TranslationReviews) TranslationReview {
+	return TranslationReview{
+		ContentFieldID: a.ContentFieldID,
+		ContentDataID:  a.ContentDataID,
+		Locale:         a.Locale,
+		Origin:         a.Origin,
+		Provider:       a.Provider,
+		Score:          a.Score,
+		CreatedAt:      a.CreatedAt,
+	}
+}
+
+// QUERIES
+
+// CreateTranslationReviewTable creates the translation_reviews table and its
+// index.
+func (d PsqlDatabase) CreateTranslationReviewTable() error {
+	queries := mdbp.New(d.Connection)
+	if err := queries.CreateTranslationReviewsTable(d.Context); err != nil {
+		return err
+	}
+	return queries.CreateTranslationReviewsIndexLocale(d.Context)
+}
+
+// CreateTranslationReview flags a field and returns the flag.
+func (d PsqlDatabase) CreateTranslationReview(params CreateTranslationReviewParams) (*TranslationReview, error) {
+	queries := mdbp.New(d.Connection)
+	err := queries.CreateTranslationReview(d.Context, mdbp.CreateTranslationReviewParams{
+		ContentFieldID: params.ContentFieldID,
+		ContentDataID:  params.ContentDataID,
+		Locale:         params.Locale,
+		Origin:         params.Origin,
+		Provider:       params.Provider,
+		Score:          params.Score,
+		CreatedAt:      params.CreatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation review: %v", err)
+	}
+	res := TranslationReview(params)
+	return &res, nil
+}
+
+// GetTranslationReview returns the flag on a content field. The error wraps
+// sql.ErrNoRows when the field is not flagged.
+func (d PsqlDatabase) GetTranslationReview(id types.ContentFieldID) (*TranslationReview, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetTranslationReview(d.Context, mdbp.GetTranslationReviewParams{ContentFieldID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translation review: %w", err)
+	}
+	res := d.MapTranslationReview(row)
+	return &res, nil
+}
+
+// ListTranslationReviewsByLocale returns the flagged fields in a locale.
+func (d PsqlDatabase) ListTranslationReviewsByLocale(locale string) (*[]TranslationReview, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListTranslationReviewsByLocale(d.Context, mdbp.ListTranslationReviewsByLocaleParams{Locale: locale})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation reviews: %v", err)
+	}
+	res := []TranslationReview{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationReview(v))
+	}
+	return &res, nil
+}
+
+// ListTranslationReviewsByRoute returns the flagged fields of a route's
+// content in a locale.
+func (d PsqlDatabase) ListTranslationReviewsByRoute(routeID types.NullableRouteID, locale string) (*[]TranslationReview, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListTranslationReviewsByRoute(d.Context, mdbp.ListTranslationReviewsByRouteParams{
+		RouteID: routeID,
+		Locale:  locale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation reviews: %v", err)
+	}
+	res := []TranslationReview{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationReview(v))
+	}
+	return &res, nil
+}
+
+// DeleteTranslationReview clears the flag on a content field. Clearing a
+// field that is not flagged is not an error.
+func (d PsqlDatabase) DeleteTranslationReview(id types.ContentFieldID) error {
+	queries := mdbp.New(d.Connection)
+	if err := queries.DeleteTranslationReview(d.Context, mdbp.DeleteTranslationReviewParams{ContentFieldID: id}); err != nil {
+		return fmt.Errorf("failed to delete translation review: %v", err)
+	}
+	return nil
+}
+
+// CountTranslationReviews returns the number of flagged fields.
+func (d PsqlDatabase) CountTranslationReviews() (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountTranslationReviews(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count translation reviews: %v", err)
+	}
+	return &c, nil
+}
@@ -0,0 +1,113 @@
+// Integration tests for the translation_reviews table.
+// Uses testSeededDB: the list queries join content_fields, so flagged fields
+// need real content rows.
+//
+// The table is NON-audited (no ctx/ac parameters on mutations).
+package db
+
+import (
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+func TestDatabase_TranslationReview_Lifecycle(t *testing.T) {
+	t.Parallel()
+	d, seed := testSeededDB(t)
+	ctx := d.Context
+	ac := testAuditCtxWithUser(d, seed.User.UserID)
+	now := types.TimestampNow()
+	routeID := types.NullableRouteID{ID: seed.Route.RouteID, Valid: true}
+
+	content, err := d.CreateContentData(ctx, ac, CreateContentDataParams{
+		RouteID:      routeID,
+		DatatypeID:   types.NullableDatatypeID{ID: seed.Datatype.DatatypeID, Valid: true},
+		AuthorID:     seed.User.UserID,
+		Status:       types.ContentStatusDraft,
+		DateCreated:  now,
+		DateModified: now,
+	})
+	if err != nil {
+		t.Fatalf("prerequisite CreateContentData: %v", err)
+	}
+	field, err := d.CreateContentField(ctx, ac, CreateContentFieldParams{
+		RouteID:       routeID,
+		ContentDataID: types.NullableContentID{ID: content.ContentDataID, Valid: true},
+		FieldID:       types.NullableFieldID{ID: seed.Field.FieldID, Valid: true},
+		FieldValue:    "Bonjour",
+		Locale:        "fr",
+		AuthorID:      seed.User.UserID,
+		DateCreated:   now,
+		DateModified:  now,
+	})
+	if err != nil {
+		t.Fatalf("prerequisite CreateContentField: %v", err)
+	}
+
+	flag, err := d.CreateTranslationReview(CreateTranslationReviewParams{
+		ContentFieldID: field.ContentFieldID,
+		ContentDataID:  content.ContentDataID,
+		Locale:         "fr",
+		Origin:         "machine",
+		Provider:       "deepl",
+		CreatedAt:      1000,
+	})
+	if err != nil {
+		t.Fatalf("CreateTranslationReview: %v", err)
+	}
+	// A flag whose content field no longer exists is ignored by the lists.
+	if _, err := d.CreateTranslationReview(CreateTranslationReviewParams{
+		ContentFieldID: types.NewContentFieldID(),
+		ContentDataID:  content.ContentDataID,
+		Locale:         "fr",
+		Origin:         "memory",
+		Score:          80,
+		CreatedAt:      1000,
+	}); err != nil {
+		t.Fatalf("CreateTranslationReview (orphan): %v", err)
+	}
+
+	got, err := d.GetTranslationReview(field.ContentFieldID)
+	if err != nil {
+		t.Fatalf("GetTranslationReview: %v", err)
+	}
+	if *got != *flag {
+		t.Errorf("GetTranslationReview = %+v, want %+v", got, flag)
+	}
+
+	byLocale, err := d.ListTranslationReviewsByLocale("fr")
+	if err != nil {
+		t.Fatalf("ListTranslationReviewsByLocale: %v", err)
+	}
+	if len(*byLocale) != 1 || (*byLocale)[0].ContentFieldID != field.ContentFieldID {
+		t.Errorf("by locale = %+v, want only the live field", *byLocale)
+	}
+	byRoute, err := d.ListTranslationReviewsByRoute(routeID, "fr")
+	if err != nil {
+		t.Fatalf("ListTranslationReviewsByRoute: %v", err)
+	}
+	if len(*byRoute) != 1 {
+		t.Errorf("by route = %+v, want 1", *byRoute)
+	}
+	other, err := d.ListTranslationReviewsByRoute(routeID, "de")
+	if err != nil {
+		t.Fatalf("ListTranslationReviewsByRoute (de): %v", err)
+	}
+	if len(*other) != 0 {
+		t.Errorf("by route in another locale = %+v, want none", *other)
+	}
+
+	if err := d.DeleteTranslationReview(field.ContentFieldID); err != nil {
+		t.Fatalf("DeleteTranslationReview: %v", err)
+	}
+	if _, err := d.GetTranslationReview(field.ContentFieldID); err == nil {
+		t.Error("GetTranslationReview after delete: expected error")
+	}
+	count, err := d.CountTranslationReviews()
+	if err != nil {
+		t.Fatalf("CountTranslationReviews: %v", err)
+	}
+	if *count != 1 {
+		t.Errorf("count after delete = %d, want the orphan only", *count)
+	}
+}
@@ -0,0 +1,676 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// Translation sources record, for each translated locale field value, the
+// hash of the default-locale text it was translated from. When the source
+// text changes the hashes no longer match and the translation is reported as
+// stale. Rows are derived data, written without audited commands except
+// inside a larger audited transaction (see CreateTranslationSourceInTx), and
+// rows whose content field was deleted are ignored by the list queries.
+
+///////////////////////////////
+// STRUCTS
+//////////////////////////////
+
+// TranslationSource is the basis of one translated content field. SourceHash
+// is translation.SourceHash of the default-locale value at the time the
+// translation was written or reviewed; RecordedAt is Unix seconds.
+type TranslationSource struct {
+	ContentFieldID types.ContentFieldID `json:"content_field_id"`
+	ContentDataID  types.ContentID      `json:"content_data_id"`
+	FieldID        types.FieldID        `json:"field_id"`
+	Locale         string               `json:"locale"`
+	SourceHash     string               `json:"source_hash"`
+	RecordedAt     int64                `json:"recorded_at"`
+}
+
+// CreateTranslationSourceParams contains parameters for recording a basis.
+type CreateTranslationSourceParams struct {
+	ContentFieldID types.ContentFieldID `json:"content_field_id"`
+	ContentDataID  types.ContentID      `json:"content_data_id"`
+	FieldID        types.FieldID        `json:"field_id"`
+	Locale         string               `json:"locale"`
+	SourceHash     string               `json:"source_hash"`
+	RecordedAt     int64                `json:"recorded_at"`
+}
+
+// SetTranslationSource records params as the basis of its content field,
+// replacing any basis recorded before.
+func SetTranslationSource(d DbDriver, params CreateTranslationSourceParams) error {
+	if _, err := d.GetTranslationSource(params.ContentFieldID); err == nil {
+		return d.UpdateTranslationSource(params.ContentFieldID, params.SourceHash, params.RecordedAt)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	_, err := d.CreateTranslationSource(params)
+	return err
+}
+
+///////////////////////////////
+// SQLITE
+//////////////////////////////
+
+// MAPS
+
+// MapTranslationSource converts a sqlc-generated type to the wrapper type.
+func (d Database) MapTranslationSource(a mdb.TranslationSources) TranslationSource {
+	return TranslationSource{
+		ContentFieldID: a.ContentFieldID,
+		ContentDataID:  a.ContentDataID,
+		FieldID:        a.FieldID,
+		Locale:         a.Locale,
+		SourceHash:     a.SourceHash,
+		RecordedAt:     a.RecordedAt,
+	}
+}
+
+// QUERIES
+
+// CreateTranslationSourceTable creates the translation_sources table and its
+// indexes.
+func (d Database) CreateTranslationSourceTable() error {
+	queries := mdb.New(d.Connection)
+	if err := queries.CreateTranslationSourcesTable(d.Context); err != nil {
+		return err
+	}
+	if err := queries.CreateTranslationSourcesIndexField(d.Context); err != nil {
+		return err
+	}
+	return queries.CreateTranslationSourcesIndexLocale(d.Context)
+}
+
+// CreateTranslationSource records a basis and returns it.
+func (d Database) CreateTranslationSource(params CreateTranslationSourceParams) (*TranslationSource, error) {
+	queries := mdb.New(d.Connection)
+	err := queries.CreateTranslationSource(d.Context, mdb.CreateTranslationSourceParams{
+		ContentFieldID: params.ContentFieldID,
+		ContentDataID:  params.ContentDataID,
+		FieldID:        params.FieldID,
+		Locale:         params.Locale,
+		SourceHash:     params.SourceHash,
+		RecordedAt:     params.RecordedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation source: %v", err)
+	}
+	res := TranslationSource(params)
+	return &res, nil
+}
+
+// GetTranslationSource returns the basis of a content field. The error wraps
+// sql.ErrNoRows when none was recorded.
+func (d Database) GetTranslationSource(id types.ContentFieldID) (*TranslationSource, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetTranslationSource(d.Context, mdb.GetTranslationSourceParams{ContentFieldID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translation source: %w", err)
+	}
+	res := d.MapTranslationSource(row)
+	return &res, nil
+}
+
+// ListTranslationSourcesByField returns the bases recorded for one field of a
+// content node, across locales.
+func (d Database) ListTranslationSourcesByField(contentID types.ContentID, fieldID types.FieldID) (*[]TranslationSource, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListTranslationSourcesByField(d.Context, mdb.ListTranslationSourcesByFieldParams{
+		ContentDataID: contentID,
+		FieldID:       fieldID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation sources: %v", err)
+	}
+	res := []TranslationSource{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationSource(v))
+	}
+	return &res, nil
+}
+
+// ListTranslationSourcesByLocale returns the bases recorded in a locale.
+func (d Database) ListTranslationSourcesByLocale(locale string) (*[]TranslationSource, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListTranslationSourcesByLocale(d.Context, mdb.ListTranslationSourcesByLocaleParams{Locale: locale})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation sources: %v", err)
+	}
+	res := []TranslationSource{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationSource(v))
+	}
+	return &res, nil
+}
+
+// ListTranslationSourcesByRoute returns the bases recorded for a route's
+// content in a locale.
+func (d Database) ListTranslationSourcesByRoute(routeID types.NullableRouteID, locale string) (*[]TranslationSource, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListTranslationSourcesByRoute(d.Context, mdb.ListTranslationSourcesByRouteParams{
+		RouteID: routeID,
+		Locale:  locale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation sources: %v", err)
+	}
+	res := []TranslationSource{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationSource(v))
+	}
+	return &res, nil
+}
+
+// UpdateTranslationSource replaces the basis of a content field.
+func (d Database) UpdateTranslationSource(id types.ContentFieldID, sourceHash string, recordedAt int64) error {
+	queries := mdb.New(d.Connection)
+	err := queries.UpdateTranslationSource(d.Context, mdb.UpdateTranslationSourceParams{
+		SourceHash:     sourceHash,
+		RecordedAt:     recordedAt,
+		ContentFieldID: id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update translation source: %v", err)
+	}
+	return nil
+}
+
+// DeleteTranslationSource removes the basis of a content field. Removing one
+// that was never recorded is not an error.
+func (d Database) DeleteTranslationSource(id types.ContentFieldID) error {
+	queries := mdb.New(d.Connection)
+	if err := queries.DeleteTranslationSource(d.Context, mdb.DeleteTranslationSourceParams{ContentFieldID: id}); err != nil {
+		return fmt.Errorf("failed to delete translation source: %v", err)
+	}
+	return nil
+}
+
+// CountTranslationSources returns the number of recorded bases.
+func (d Database) CountTranslationSources() (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountTranslationSources(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count translation sources: %v", err)
+	}
+	return &c, nil
+}
+
+// ----- SQLite CREATE -----
+
+// NewTranslationSourceCmd is an audited command for recording a basis. Used when the write
+// belongs to a larger audited transaction, such as copying a content tree.
+type NewTranslationSourceCmd struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateTranslationSourceParams
+	conn     *sql.DB
+	recorder audited.ChangeEventRecorder
+}
+
+// Context returns the command's context.
+func (c NewTranslationSourceCmd) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewTranslationSourceCmd) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewTranslationSourceCmd) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewTranslationSourceCmd) Recorder() audited.ChangeEventRecorder { return c.recorder }
+
+// TableName returns the table name.
+func (c NewTranslationSourceCmd) TableName() string { return "translation_sources" }
+
+// Params returns the command parameters.
+func (c NewTranslationSourceCmd) Params() any { return c.params }
+
+// GetID returns the content field ID of a basis.
+func (c NewTranslationSourceCmd) GetID(r mdb.TranslationSources) string {
+	return string(r.ContentFieldID)
+}
+
+// Execute records the basis in the database.
+func (c NewTranslationSourceCmd) Execute(ctx context.Context, tx audited.DBTX) (mdb.TranslationSources, error) {
+	params := mdb.CreateTranslationSourceParams{
+		ContentFieldID: c.params.ContentFieldID,
+		ContentDataID:  c.params.ContentDataID,
+		FieldID:        c.params.FieldID,
+		Locale:         c.params.Locale,
+		SourceHash:     c.params.SourceHash,
+		RecordedAt:     c.params.RecordedAt,
+	}
+	if err := mdb.New(tx).CreateTranslationSource(ctx, params); err != nil {
+		return mdb.TranslationSources{}, err
+	}
+	return mdb.TranslationSources(params), nil
+}
+
+// NewTranslationSourceCmd creates a new create command for a basis.
+func (d Database) NewTranslationSourceCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateTranslationSourceParams) NewTranslationSourceCmd {
+	return NewTranslationSourceCmd{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: SQLiteRecorder}
+}
+
+///////////////////////////////
+// MYSQL
+//////////////////////////////
+
+// MAPS
+
+// MapTranslationSource converts a sqlc-generated type to the wrapper type.
+func (d MysqlDatabase) MapTranslationSource(a mdbm.TranslationSources) TranslationSource {
+	return TranslationSource{
+		ContentFieldID: a.ContentFieldID,
+		ContentDataID:  a.ContentDataID,
+		FieldID:        a.FieldID,
+		Locale:         a.Locale,
+		SourceHash:     a.SourceHash,
+		RecordedAt:     a.RecordedAt,
+	}
+}
+
+// QUERIES
+
+// CreateTranslationSourceTable creates the translation_sources table and its
+// indexes.
+func (d MysqlDatabase) CreateTranslationSourceTable() error {
+	queries := mdbm.New(d.Connection)
+	if err := queries.CreateTranslationSourcesTable(d.Context); err != nil {
+		return err
+	}
+	// MySQL has no CREATE INDEX IF NOT EXISTS; a second run reports the
+	// existing index, which is expected.
+	for _, create := range []func(context.Context) error{
+		queries.CreateTranslationSourcesIndexField,
+		queries.CreateTranslationSourcesIndexLocale,
+	} {
+		if err := create(d.Context); err != nil && !strings.Contains(err.Error(), "Duplicate key name") {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateTranslationSource records a basis and returns it.
+func (d MysqlDatabase) CreateTranslationSource(params CreateTranslationSourceParams) (*TranslationSource, error) {
+	queries := mdbm.New(d.Connection)
+	err := queries.CreateTranslationSource(d.Context, mdbm.CreateTranslationSourceParams{
+		ContentFieldID: params.ContentFieldID,
+		ContentDataID:  params.ContentDataID,
+		FieldID:        params.FieldID,
+		Locale:         params.Locale,
+		SourceHash:     params.SourceHash,
+		RecordedAt:     params.RecordedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation source: %v", err)
+	}
+	res := TranslationSource(params)
+	return &res, nil
+}
+
+// GetTranslationSource returns the basis of a content field. The error wraps
+// sql.ErrNoRows when none was recorded.
+func (d MysqlDatabase) GetTranslationSource(id types.ContentFieldID) (*TranslationSource, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetTranslationSource(d.Context, mdbm.GetTranslationSourceParams{ContentFieldID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translation source: %w", err)
+	}
+	res := d.MapTranslationSource(row)
+	return &res, nil
+}
+
+// ListTranslationSourcesByField returns the bases recorded for one field of a
+// content node, across locales.
+func (d MysqlDatabase) ListTranslationSourcesByField(contentID types.ContentID, fieldID types.FieldID) (*[]TranslationSource, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListTranslationSourcesByField(d.Context, mdbm.ListTranslationSourcesByFieldParams{
+		ContentDataID: contentID,
+		FieldID:       fieldID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation sources: %v", err)
+	}
+	res := []TranslationSource{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationSource(v))
+	}
+	return &res, nil
+}
+
+// ListTranslationSourcesByLocale returns the bases recorded in a locale.
+func (d MysqlDatabase) ListTranslationSourcesByLocale(locale string) (*[]TranslationSource, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListTranslationSourcesByLocale(d.Context, mdbm.ListTranslationSourcesByLocaleParams{Locale: locale})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation sources: %v", err)
+	}
+	res := []TranslationSource{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationSource(v))
+	}
+	return &res, nil
+}
+
+// ListTranslationSourcesByRoute returns the bases recorded for a route's
+// content in a locale.
+func (d MysqlDatabase) ListTranslationSourcesByRoute(routeID types.NullableRouteID, locale string) (*[]TranslationSource, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListTranslationSourcesByRoute(d.Context, mdbm.ListTranslationSourcesByRouteParams{
+		RouteID: routeID,
+		Locale:  locale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation sources: %v", err)
+	}
+	res := []TranslationSource{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationSource(v))
+	}
+	return &res, nil
+}
+
+// UpdateTranslationSource replaces the basis of a content field.
+func (d MysqlDatabase) UpdateTranslationSource(id types.ContentFieldID, sourceHash string, recordedAt int64) error {
+	queries := mdbm.New(d.Connection)
+	err := queries.UpdateTranslationSource(d.Context, mdbm.UpdateTranslationSourceParams{
+		SourceHash:     sourceHash,
+		RecordedAt:     recordedAt,
+		ContentFieldID: id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update translation source: %v", err)
+	}
+	return nil
+}
+
+// DeleteTranslationSource removes the basis of a content field. Removing one
+// that was never recorded is not an error.
+func (d MysqlDatabase) DeleteTranslationSource(id types.ContentFieldID) error {
+	queries := mdbm.New(d.Connection)
+	if err := queries.DeleteTranslationSource(d.Context, mdbm.DeleteTranslationSourceParams{ContentFieldID: id}); err != nil {
+		return fmt.Errorf("failed to delete translation source: %v", err)
+	}
+	return nil
+}
+
+// CountTranslationSources returns the number of recorded bases.
+func (d MysqlDatabase) CountTranslationSources() (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountTranslationSources(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count translation sources: %v", err)
+	}
+	return &c, nil
+}
+
+// ----- MySQL CREATE -----
+
+// NewTranslationSourceCmdMysql is an audited command for recording a basis. Used when the write
+// belongs to a larger audited transaction, such as copying a content tree.
+type NewTranslationSourceCmdMysql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateTranslationSourceParams
+	conn     *sql.DB
+	recorder audited.ChangeEventRecorder
+}
+
+// Context returns the command's context.
+func (c NewTranslationSourceCmdMysql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewTranslationSourceCmdMysql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewTranslationSourceCmdMysql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewTranslationSourceCmdMysql) Recorder() audited.ChangeEventRecorder { return c.recorder }
+
+// TableName returns the table name.
+func (c NewTranslationSourceCmdMysql) TableName() string { return "translation_sources" }
+
+// Params returns the command parameters.
+func (c NewTranslationSourceCmdMysql) Params() any { return c.params }
+
+// GetID returns the content field ID of a basis.
+func (c NewTranslationSourceCmdMysql) GetID(r mdbm.TranslationSources) string {
+	return string(r.ContentFieldID)
+}
+
+// Execute records the basis in the database.
+func (c NewTranslationSourceCmdMysql) Execute(ctx context.Context, tx audited.DBTX) (mdbm.TranslationSources, error) {
+	params := mdbm.CreateTranslationSourceParams{
+		ContentFieldID: c.params.ContentFieldID,
+		ContentDataID:  c.params.ContentDataID,
+		FieldID:        c.params.FieldID,
+		Locale:         c.params.Locale,
+		SourceHash:     c.params.SourceHash,
+		RecordedAt:     c.params.RecordedAt,
+	}
+	if err := mdbm.New(tx).CreateTranslationSource(ctx, params); err != nil {
+		return mdbm.TranslationSources{}, err
+	}
+	return mdbm.TranslationSources(params), nil
+}
+
+// NewTranslationSourceCmd creates a new create command for a basis.
+func (d MysqlDatabase) NewTranslationSourceCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateTranslationSourceParams) NewTranslationSourceCmdMysql {
+	return NewTranslationSourceCmdMysql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: MysqlRecorder}
+}
+
+///////////////////////////////
+// POSTGRES
+//////////////////////////////
+
+// MAPS
+
+// MapTranslationSource converts a sqlc-generated type to the wrapper type.
+func (d PsqlDatabase) MapTranslationSource(a mdbp.TranslationSources) TranslationSource {
+	return TranslationSource{
+		ContentFieldID: a.ContentFieldID,
+		ContentDataID:  a.ContentDataID,
+		FieldID:        a.FieldID,
+		Locale:         a.Locale,
+		SourceHash:     a.SourceHash,
+		RecordedAt:     a.RecordedAt,
+	}
+}
+
+// QUERIES
+
+// CreateTranslationSourceTable creates the translation_sources table and its
+// indexes.
+func (d PsqlDatabase) CreateTranslationSourceTable() error {
+	queries := mdbp.New(d.Connection)
+	if err := queries.CreateTranslationSourcesTable(d.Context); err != nil {
+		return err
+	}
+	if err := queries.CreateTranslationSourcesIndexField(d.Context); err != nil {
+		return err
+	}
+	return queries.CreateTranslationSourcesIndexLocale(d.Context)
+}
+
+// CreateTranslationSource records a basis and returns it.
+func (d PsqlDatabase) CreateTranslationSource(params CreateTranslationSourceParams) (*TranslationSource, error) {
+	queries := mdbp.New(d.Connection)
+	err := queries.CreateTranslationSource(d.Context, mdbp.CreateTranslationSourceParams{
+		ContentFieldID: params.ContentFieldID,
+		ContentDataID:  params.ContentDataID,
+		FieldID:        params.FieldID,
+		Locale:         params.Locale,
+		SourceHash:     params.SourceHash,
+		RecordedAt:     params.RecordedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation source: %v", err)
+	}
+	res := TranslationSource(params)
+	return &res, nil
+}
+
+// GetTranslationSource returns the basis of a content field. The error wraps
+// sql.ErrNoRows when none was recorded.
+func (d PsqlDatabase) GetTranslationSource(id types.ContentFieldID) (*TranslationSource, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetTranslationSource(d.Context, mdbp.GetTranslationSourceParams{ContentFieldID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translation source: %w", err)
+	}
+	res := d.MapTranslationSource(row)
+	return &res, nil
+}
+
+// ListTranslationSourcesByField returns the bases recorded for one field of a
+// content node, across locales.
+func (d PsqlDatabase) ListTranslationSourcesByField(contentID types.ContentID, fieldID types.FieldID) (*[]TranslationSource, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListTranslationSourcesByField(d.Context, mdbp.ListTranslationSourcesByFieldParams{
+		ContentDataID: contentID,
+		FieldID:       fieldID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation sources: %v", err)
+	}
+	res := []TranslationSource{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationSource(v))
+	}
+	return &res, nil
+}
+
+// ListTranslationSourcesByLocale returns the bases recorded in a locale.
+func (d PsqlDatabase) ListTranslationSourcesByLocale(locale string) (*[]TranslationSource, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListTranslationSourcesByLocale(d.Context, mdbp.ListTranslationSourcesByLocaleParams{Locale: locale})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation sources: %v", err)
+	}
+	res := []TranslationSource{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationSource(v))
+	}
+	return &res, nil
+}
+
+// ListTranslationSourcesByRoute returns the bases recorded for a route's
+// content in a locale.
+func (d PsqlDatabase) ListTranslationSourcesByRoute(routeID types.NullableRouteID, locale string) (*[]TranslationSource, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListTranslationSourcesByRoute(d.Context, mdbp.ListTranslationSourcesByRouteParams{
+		RouteID: routeID,
+		Locale:  locale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation sources: %v", err)
+	}
+	res := []TranslationSource{}
+	for _, v := range rows {
+		res = append(res, d.MapTranslationSource(v))
+	}
+	return &res, nil
+}
+
+// UpdateTranslationSource replaces the basis of a content field.
+func (d PsqlDatabase) UpdateTranslationSource(id types.ContentFieldID, sourceHash string, recordedAt int64) error {
+	queries := mdbp.New(d.Connection)
+	err := queries.UpdateTranslationSource(d.Context, mdbp.UpdateTranslationSourceParams{
+		SourceHash:     sourceHash,
+		RecordedAt:     recordedAt,
+		ContentFieldID: id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update translation source: %v", err)
+	}
+	return nil
+}
+
+// DeleteTranslationSource removes the basis of a content field. Removing one
+// that was never recorded is not an error.
+func (d PsqlDatabase) DeleteTranslationSource(id types.ContentFieldID) error {
+	queries := mdbp.New(d.Connection)
+	if err := queries.DeleteTranslationSource(d.Context, mdbp.DeleteTranslationSourceParams{ContentFieldID: id}); err != nil {
+		return fmt.Errorf("failed to delete translation source: %v", err)
+	}
+	return nil
+}
+
+// CountTranslationSources returns the number of recorded bases.
+func (d PsqlDatabase) CountTranslationSources() (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountTranslationSources(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count translation sources: %v", err)
+	}
+	return &c, nil
+}
+
+// ----- PostgreSQL CREATE -----
+
+// NewTranslationSourceCmdPsql is an audited command for recording a basis. Used when the write
+// belongs to a larger audited transaction, such as copying a content tree.
+type NewTranslationSourceCmdPsql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateTranslationSourceParams
+	conn     *sql.DB
+	recorder audited.ChangeEventRecorder
+}
+
+// Context returns the command's context.
+func (c NewTranslationSourceCmdPsql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewTranslationSourceCmdPsql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewTranslationSourceCmdPsql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewTranslationSourceCmdPsql) Recorder() audited.ChangeEventRecorder { return c.recorder }
+
+// TableName returns the table name.
+func (c NewTranslationSourceCmdPsql) TableName() string { return "translation_sources" }
+
+// Params returns the command parameters.
+func (c NewTranslationSourceCmdPsql) Params() any { return c.params }
+
+// GetID returns the content field ID of a basis.
+func (c NewTranslationSourceCmdPsql) GetID(r mdbp.TranslationSources) string {
+	return string(r.ContentFieldID)
+}
+
+// Execute records the basis in the database.
+func (c NewTranslationSourceCmdPsql) Execute(ctx context.Context, tx audited.DBTX) (mdbp.TranslationSources, error) {
+	params := mdbp.CreateTranslationSourceParams{
+		ContentFieldID: c.params.ContentFieldID,
+		ContentDataID:  c.params.ContentDataID,
+		FieldID:        c.params.FieldID,
+		Locale:         c.params.Locale,
+		SourceHash:     c.params.SourceHash,
+		RecordedAt:     c.params.RecordedAt,
+	}
+	if err := mdbp.New(tx).CreateTranslationSource(ctx, params); err != nil {
+		return mdbp.TranslationSources{}, err
+	}
+	return mdbp.TranslationSources(params), nil
+}
+
+// NewTranslationSourceCmd creates a new create command for a basis.
+func (d PsqlDatabase) NewTranslationSourceCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateTranslationSourceParams) NewTranslationSourceCmdPsql {
+	return NewTranslationSourceCmdPsql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: PsqlRecorder}
+}
@@ -0,0 +1,122 @@
+// Integration tests for the translation_sources table.
+// Uses testSeededDB: the list queries join content_fields, so recorded bases
+// need real content rows.
+//
+// The table is NON-audited (no ctx/ac parameters on mutations).
+package db
+
+import (
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+func TestDatabase_TranslationSource_Lifecycle(t *testing.T) {
+	t.Parallel()
+	d, seed := testSeededDB(t)
+	ctx := d.Context
+	ac := testAuditCtxWithUser(d, seed.User.UserID)
+	now := types.TimestampNow()
+	routeID := types.NullableRouteID{ID: seed.Route.RouteID, Valid: true}
+
+	content, err := d.CreateContentData(ctx, ac, CreateContentDataParams{
+		RouteID:      routeID,
+		DatatypeID:   types.NullableDatatypeID{ID: seed.Datatype.DatatypeID, Valid: true},
+		AuthorID:     seed.User.UserID,
+		Status:       types.ContentStatusDraft,
+		DateCreated:  now,
+		DateModified: now,
+	})
+	if err != nil {
+		t.Fatalf("prerequisite CreateContentData: %v", err)
+	}
+	field, err := d.CreateContentField(ctx, ac, CreateContentFieldParams{
+		RouteID:       routeID,
+		ContentDataID: types.NullableContentID{ID: content.ContentDataID, Valid: true},
+		FieldID:       types.NullableFieldID{ID: seed.Field.FieldID, Valid: true},
+		FieldValue:    "Bonjour",
+		Locale:        "fr",
+		AuthorID:      seed.User.UserID,
+		DateCreated:   now,
+		DateModified:  now,
+	})
+	if err != nil {
+		t.Fatalf("prerequisite CreateContentField: %v", err)
+	}
+
+	if err := SetTranslationSource(d, CreateTranslationSourceParams{
+		ContentFieldID: field.ContentFieldID,
+		ContentDataID:  content.ContentDataID,
+		FieldID:        seed.Field.FieldID,
+		Locale:         "fr",
+		SourceHash:     "aaa",
+		RecordedAt:     1000,
+	}); err != nil {
+		t.Fatalf("SetTranslationSource (create): %v", err)
+	}
+	// A basis whose content field no longer exists is ignored by the lists.
+	if _, err := d.CreateTranslationSource(CreateTranslationSourceParams{
+		ContentFieldID: types.NewContentFieldID(),
+		ContentDataID:  content.ContentDataID,
+		FieldID:        seed.Field.FieldID,
+		Locale:         "fr",
+		SourceHash:     "aaa",
+		RecordedAt:     1000,
+	}); err != nil {
+		t.Fatalf("CreateTranslationSource (orphan): %v", err)
+	}
+
+	if err := SetTranslationSource(d, CreateTranslationSourceParams{
+		ContentFieldID: field.ContentFieldID,
+		ContentDataID:  content.ContentDataID,
+		FieldID:        seed.Field.FieldID,
+		Locale:         "fr",
+		SourceHash:     "bbb",
+		RecordedAt:     2000,
+	}); err != nil {
+		t.Fatalf("SetTranslationSource (update): %v", err)
+	}
+	got, err := d.GetTranslationSource(field.ContentFieldID)
+	if err != nil {
+		t.Fatalf("GetTranslationSource: %v", err)
+	}
+	if got.SourceHash != "bbb" || got.RecordedAt != 2000 {
+		t.Errorf("GetTranslationSource = %+v, want the updated basis", got)
+	}
+
+	byField, err := d.ListTranslationSourcesByField(content.ContentDataID, seed.Field.FieldID)
+	if err != nil {
+		t.Fatalf("ListTranslationSourcesByField: %v", err)
+	}
+	if len(*byField) != 1 || (*byField)[0].ContentFieldID != field.ContentFieldID {
+		t.Errorf("by field = %+v, want only the live field", *byField)
+	}
+	byLocale, err := d.ListTranslationSourcesByLocale("fr")
+	if err != nil {
+		t.Fatalf("ListTranslationSourcesByLocale: %v", err)
+	}
+	if len(*byLocale) != 1 {
+		t.Errorf("by locale = %+v, want 1", *byLocale)
+	}
+	other, err := d.ListTranslationSourcesByRoute(routeID, "de")
+	if err != nil {
+		t.Fatalf("ListTranslationSourcesByRoute (de): %v", err)
+	}
+	if len(*other) != 0 {
+		t.Errorf("by route in another locale = %+v, want none", *other)
+	}
+
+	if err := d.DeleteTranslationSource(field.ContentFieldID); err != nil {
+		t.Fatalf("DeleteTranslationSource: %v", err)
+	}
+	if _, err := d.GetTranslationSource(field.ContentFieldID); err == nil {
+		t.Error("GetTranslationSource after delete: expected error")
+	}
+	count, err := d.CountTranslationSources()
+	if err != nil {
+		t.Fatalf("CountTranslationSources: %v", err)
+	}
+	if *count != 1 {
+		t.Errorf("count after delete = %d, want the orphan only", *count)
+	}
+}
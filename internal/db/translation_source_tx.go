@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hegner123/modulacms/internal/db/audited"
+)
+
+// CreateTranslationSourceInTx records a translation basis with audit trail in
+// an existing transaction.
+func CreateTranslationSourceInTx(d DbDriver, ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params CreateTranslationSourceParams) (*TranslationSource, error) {
+	switch drv := d.(type) {
+	case Database:
+		result, err := audited.CreateInTx(Database{}.NewTranslationSourceCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create translation source: %w", err)
+		}
+		r := drv.MapTranslationSource(result)
+		return &r, nil
+	case MysqlDatabase:
+		result, err := audited.CreateInTx(MysqlDatabase{}.NewTranslationSourceCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create translation source: %w", err)
+		}
+		r := drv.MapTranslationSource(result)
+		return &r, nil
+	case PsqlDatabase:
+		result, err := audited.CreateInTx(PsqlDatabase{}.NewTranslationSourceCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create translation source: %w", err)
+		}
+		r := drv.MapTranslationSource(result)
+		return &r, nil
+	default:
+		return nil, fmt.Errorf("tx create translation source: unsupported driver type %T", d)
+	}
+}
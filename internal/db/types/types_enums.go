@@ -357,21 +357,24 @@ type Action string
 
 // Valid Action values.
 const (
-	ActionCreate  Action = "create"
-	ActionUpdate  Action = "update"
-	ActionDelete  Action = "delete"
-	ActionPublish Action = "publish"
+	ActionCreate   Action = "create"
+	ActionUpdate   Action = "update"
+	ActionDelete   Action = "delete"
+	ActionPublish  Action = "publish"
+	ActionLock     Action = "lock"
+	ActionUnlock   Action = "unlock"
+	ActionOverride Action = "override"
 )
 
 // Validate checks that the Action is one of the allowed values.
 func (a Action) Validate() error {
 	switch a {
-	case ActionCreate, ActionUpdate, ActionDelete, ActionPublish:
+	case ActionCreate, ActionUpdate, ActionDelete, ActionPublish, ActionLock, ActionUnlock, ActionOverride:
 		return nil
 	case "":
 		return fmt.Errorf("Action: cannot be empty")
 	default:
-		return fmt.Errorf("Action: invalid value %q (valid: create, update, delete, publish)", a)
+		return fmt.Errorf("Action: invalid value %q (valid: create, update, delete, publish, lock, unlock, override)", a)
 	}
 }
 
@@ -53,7 +53,7 @@ func allEnumContracts() []enumContract {
 		},
 		{
 			name:        "Action",
-			validValues: []string{"create", "update", "delete", "publish"},
+			validValues: []string{"create", "update", "delete", "publish", "lock", "unlock", "override"},
 			validateFn:  func(s string) error { return Action(s).Validate() },
 			valueFn:     func(s string) (any, error) { return Action(s).Value() },
 			scanFn: func(v any) (string, error) {
@@ -1756,6 +1756,371 @@ func (id *RolePermissionID) UnmarshalJSON(data []byte) error {
 	return id.Validate()
 }
 
+// UserRoleID uniquely identifies a user-role assignment row.
+type UserRoleID string
+
+// NewUserRoleID generates a new ULID-based UserRoleID.
+func NewUserRoleID() UserRoleID { return UserRoleID(NewULID().String()) }
+
+// String returns the string representation of the UserRoleID.
+func (id UserRoleID) String() string { return string(id) }
+
+// IsZero returns true if the UserRoleID is empty.
+func (id UserRoleID) IsZero() bool { return id == "" }
+
+// Validate checks if the UserRoleID is a valid ULID.
+func (id UserRoleID) Validate() error { return validateULID(string(id), "UserRoleID") }
+
+// ULID parses the UserRoleID as a ulid.ULID.
+func (id UserRoleID) ULID() (ulid.ULID, error) { return ulid.Parse(string(id)) }
+
+// Time extracts the timestamp embedded in the UserRoleID.
+func (id UserRoleID) Time() (time.Time, error) {
+	u, err := id.ULID()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ulid.Time(u.Time()), nil
+}
+
+// ParseUserRoleID parses and validates a string as a UserRoleID.
+func ParseUserRoleID(s string) (UserRoleID, error) {
+	id := UserRoleID(s)
+	if err := id.Validate(); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Value implements driver.Valuer for database serialization.
+func (id UserRoleID) Value() (driver.Value, error) {
+	if id == "" {
+		return nil, fmt.Errorf("UserRoleID: cannot be empty")
+	}
+	return string(id), nil
+}
+
+// Scan implements sql.Scanner for database deserialization.
+func (id *UserRoleID) Scan(value any) error {
+	if value == nil {
+		return fmt.Errorf("UserRoleID: cannot be null")
+	}
+	switch v := value.(type) {
+	case string:
+		*id = UserRoleID(v)
+	case []byte:
+		*id = UserRoleID(string(v))
+	default:
+		return fmt.Errorf("UserRoleID: cannot scan %T", value)
+	}
+	return id.Validate()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (id UserRoleID) MarshalJSON() ([]byte, error) { return json.Marshal(string(id)) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (id *UserRoleID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("UserRoleID: %w", err)
+	}
+	*id = UserRoleID(s)
+	return id.Validate()
+}
+
+// UserGroupID uniquely identifies a user group.
+type UserGroupID string
+
+// NewUserGroupID generates a new ULID-based UserGroupID.
+func NewUserGroupID() UserGroupID { return UserGroupID(NewULID().String()) }
+
+// String returns the string representation of the UserGroupID.
+func (id UserGroupID) String() string { return string(id) }
+
+// IsZero returns true if the UserGroupID is empty.
+func (id UserGroupID) IsZero() bool { return id == "" }
+
+// Validate checks if the UserGroupID is a valid ULID.
+func (id UserGroupID) Validate() error { return validateULID(string(id), "UserGroupID") }
+
+// ULID parses the UserGroupID as a ulid.ULID.
+func (id UserGroupID) ULID() (ulid.ULID, error) { return ulid.Parse(string(id)) }
+
+// Time extracts the timestamp embedded in the UserGroupID.
+func (id UserGroupID) Time() (time.Time, error) {
+	u, err := id.ULID()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ulid.Time(u.Time()), nil
+}
+
+// ParseUserGroupID parses and validates a string as a UserGroupID.
+func ParseUserGroupID(s string) (UserGroupID, error) {
+	id := UserGroupID(s)
+	if err := id.Validate(); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Value implements driver.Valuer for database serialization.
+func (id UserGroupID) Value() (driver.Value, error) {
+	if id == "" {
+		return nil, fmt.Errorf("UserGroupID: cannot be empty")
+	}
+	return string(id), nil
+}
+
+// Scan implements sql.Scanner for database deserialization.
+func (id *UserGroupID) Scan(value any) error {
+	if value == nil {
+		return fmt.Errorf("UserGroupID: cannot be null")
+	}
+	switch v := value.(type) {
+	case string:
+		*id = UserGroupID(v)
+	case []byte:
+		*id = UserGroupID(string(v))
+	default:
+		return fmt.Errorf("UserGroupID: cannot scan %T", value)
+	}
+	return id.Validate()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (id UserGroupID) MarshalJSON() ([]byte, error) { return json.Marshal(string(id)) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (id *UserGroupID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("UserGroupID: %w", err)
+	}
+	*id = UserGroupID(s)
+	return id.Validate()
+}
+
+// UserGroupMemberID uniquely identifies a user group membership row.
+type UserGroupMemberID string
+
+// NewUserGroupMemberID generates a new ULID-based UserGroupMemberID.
+func NewUserGroupMemberID() UserGroupMemberID { return UserGroupMemberID(NewULID().String()) }
+
+// String returns the string representation of the UserGroupMemberID.
+func (id UserGroupMemberID) String() string { return string(id) }
+
+// IsZero returns true if the UserGroupMemberID is empty.
+func (id UserGroupMemberID) IsZero() bool { return id == "" }
+
+// Validate checks if the UserGroupMemberID is a valid ULID.
+func (id UserGroupMemberID) Validate() error { return validateULID(string(id), "UserGroupMemberID") }
+
+// ULID parses the UserGroupMemberID as a ulid.ULID.
+func (id UserGroupMemberID) ULID() (ulid.ULID, error) { return ulid.Parse(string(id)) }
+
+// Time extracts the timestamp embedded in the UserGroupMemberID.
+func (id UserGroupMemberID) Time() (time.Time, error) {
+	u, err := id.ULID()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ulid.Time(u.Time()), nil
+}
+
+// ParseUserGroupMemberID parses and validates a string as a UserGroupMemberID.
+func ParseUserGroupMemberID(s string) (UserGroupMemberID, error) {
+	id := UserGroupMemberID(s)
+	if err := id.Validate(); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Value implements driver.Valuer for database serialization.
+func (id UserGroupMemberID) Value() (driver.Value, error) {
+	if id == "" {
+		return nil, fmt.Errorf("UserGroupMemberID: cannot be empty")
+	}
+	return string(id), nil
+}
+
+// Scan implements sql.Scanner for database deserialization.
+func (id *UserGroupMemberID) Scan(value any) error {
+	if value == nil {
+		return fmt.Errorf("UserGroupMemberID: cannot be null")
+	}
+	switch v := value.(type) {
+	case string:
+		*id = UserGroupMemberID(v)
+	case []byte:
+		*id = UserGroupMemberID(string(v))
+	default:
+		return fmt.Errorf("UserGroupMemberID: cannot scan %T", value)
+	}
+	return id.Validate()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (id UserGroupMemberID) MarshalJSON() ([]byte, error) { return json.Marshal(string(id)) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (id *UserGroupMemberID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("UserGroupMemberID: %w", err)
+	}
+	*id = UserGroupMemberID(s)
+	return id.Validate()
+}
+
+// UserGroupRoleID uniquely identifies a user group role assignment row.
+type UserGroupRoleID string
+
+// NewUserGroupRoleID generates a new ULID-based UserGroupRoleID.
+func NewUserGroupRoleID() UserGroupRoleID { return UserGroupRoleID(NewULID().String()) }
+
+// String returns the string representation of the UserGroupRoleID.
+func (id UserGroupRoleID) String() string { return string(id) }
+
+// IsZero returns true if the UserGroupRoleID is empty.
+func (id UserGroupRoleID) IsZero() bool { return id == "" }
+
+// Validate checks if the UserGroupRoleID is a valid ULID.
+func (id UserGroupRoleID) Validate() error { return validateULID(string(id), "UserGroupRoleID") }
+
+// ULID parses the UserGroupRoleID as a ulid.ULID.
+func (id UserGroupRoleID) ULID() (ulid.ULID, error) { return ulid.Parse(string(id)) }
+
+// Time extracts the timestamp embedded in the UserGroupRoleID.
+func (id UserGroupRoleID) Time() (time.Time, error) {
+	u, err := id.ULID()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ulid.Time(u.Time()), nil
+}
+
+// ParseUserGroupRoleID parses and validates a string as a UserGroupRoleID.
+func ParseUserGroupRoleID(s string) (UserGroupRoleID, error) {
+	id := UserGroupRoleID(s)
+	if err := id.Validate(); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Value implements driver.Valuer for database serialization.
+func (id UserGroupRoleID) Value() (driver.Value, error) {
+	if id == "" {
+		return nil, fmt.Errorf("UserGroupRoleID: cannot be empty")
+	}
+	return string(id), nil
+}
+
+// Scan implements sql.Scanner for database deserialization.
+func (id *UserGroupRoleID) Scan(value any) error {
+	if value == nil {
+		return fmt.Errorf("UserGroupRoleID: cannot be null")
+	}
+	switch v := value.(type) {
+	case string:
+		*id = UserGroupRoleID(v)
+	case []byte:
+		*id = UserGroupRoleID(string(v))
+	default:
+		return fmt.Errorf("UserGroupRoleID: cannot scan %T", value)
+	}
+	return id.Validate()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (id UserGroupRoleID) MarshalJSON() ([]byte, error) { return json.Marshal(string(id)) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (id *UserGroupRoleID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("UserGroupRoleID: %w", err)
+	}
+	*id = UserGroupRoleID(s)
+	return id.Validate()
+}
+
+// PasswordHistoryID uniquely identifies a password history entry.
+type PasswordHistoryID string
+
+// NewPasswordHistoryID generates a new ULID-based PasswordHistoryID.
+func NewPasswordHistoryID() PasswordHistoryID { return PasswordHistoryID(NewULID().String()) }
+
+// String returns the string representation of the PasswordHistoryID.
+func (id PasswordHistoryID) String() string { return string(id) }
+
+// IsZero returns true if the PasswordHistoryID is empty.
+func (id PasswordHistoryID) IsZero() bool { return id == "" }
+
+// Validate checks if the PasswordHistoryID is a valid ULID.
+func (id PasswordHistoryID) Validate() error { return validateULID(string(id), "PasswordHistoryID") }
+
+// ULID parses the PasswordHistoryID as a ulid.ULID.
+func (id PasswordHistoryID) ULID() (ulid.ULID, error) { return ulid.Parse(string(id)) }
+
+// Time extracts the timestamp embedded in the PasswordHistoryID.
+func (id PasswordHistoryID) Time() (time.Time, error) {
+	u, err := id.ULID()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ulid.Time(u.Time()), nil
+}
+
+// ParsePasswordHistoryID parses and validates a string as a PasswordHistoryID.
+func ParsePasswordHistoryID(s string) (PasswordHistoryID, error) {
+	id := PasswordHistoryID(s)
+	if err := id.Validate(); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Value implements driver.Valuer for database serialization.
+func (id PasswordHistoryID) Value() (driver.Value, error) {
+	if id == "" {
+		return nil, fmt.Errorf("PasswordHistoryID: cannot be empty")
+	}
+	return string(id), nil
+}
+
+// Scan implements sql.Scanner for database deserialization.
+func (id *PasswordHistoryID) Scan(value any) error {
+	if value == nil {
+		return fmt.Errorf("PasswordHistoryID: cannot be null")
+	}
+	switch v := value.(type) {
+	case string:
+		*id = PasswordHistoryID(v)
+	case []byte:
+		*id = PasswordHistoryID(string(v))
+	default:
+		return fmt.Errorf("PasswordHistoryID: cannot scan %T", value)
+	}
+	return id.Validate()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (id PasswordHistoryID) MarshalJSON() ([]byte, error) { return json.Marshal(string(id)) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (id *PasswordHistoryID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("PasswordHistoryID: %w", err)
+	}
+	*id = PasswordHistoryID(s)
+	return id.Validate()
+}
+
 // FieldTypeID uniquely identifies a field type.
 type FieldTypeID string
 
@@ -0,0 +1,877 @@
+// Code generated by tools/dbgen; DO NOT EDIT.
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+///////////////////////////////
+// STRUCTS
+//////////////////////////////
+
+// UserGroups represents a userGroup record in the database.
+type UserGroups struct {
+	UserGroupID  types.UserGroupID `json:"user_group_id"`
+	Label        string            `json:"label"`
+	Description  string            `json:"description"`
+	DateCreated  types.Timestamp   `json:"date_created"`
+	DateModified types.Timestamp   `json:"date_modified"`
+}
+
+// CreateUserGroupParams contains parameters for creating a new userGroup.
+type CreateUserGroupParams struct {
+	Label        string          `json:"label"`
+	Description  string          `json:"description"`
+	DateCreated  types.Timestamp `json:"date_created"`
+	DateModified types.Timestamp `json:"date_modified"`
+}
+
+// UpdateUserGroupParams contains parameters for updating an existing userGroup.
+type UpdateUserGroupParams struct {
+	Label        string            `json:"label"`
+	Description  string            `json:"description"`
+	DateCreated  types.Timestamp   `json:"date_created"`
+	DateModified types.Timestamp   `json:"date_modified"`
+	UserGroupID  types.UserGroupID `json:"user_group_id"`
+}
+
+// MapStringUserGroup converts UserGroups to StringUserGroups for TUI display.
+func MapStringUserGroup(a UserGroups) StringUserGroups {
+	return StringUserGroups{
+		UserGroupID:  a.UserGroupID.String(),
+		Label:        a.Label,
+		Description:  a.Description,
+		DateCreated:  a.DateCreated.String(),
+		DateModified: a.DateModified.String(),
+	}
+}
+
+///////////////////////////////
+// SQLITE
+//////////////////////////////
+
+// MAPS
+
+// MapUserGroup converts a sqlc-generated SQLite userGroup to the wrapper type.
+func (d Database) MapUserGroup(a mdb.UserGroups) UserGroups {
+	return UserGroups{
+		UserGroupID:  a.UserGroupID,
+		Label:        a.Label,
+		Description:  a.Description,
+		DateCreated:  a.DateCreated,
+		DateModified: a.DateModified,
+	}
+}
+
+// MapCreateUserGroupParams converts wrapper params to sqlc-generated SQLite params.
+func (d Database) MapCreateUserGroupParams(a CreateUserGroupParams) mdb.CreateUserGroupParams {
+	return mdb.CreateUserGroupParams{
+		UserGroupID:  types.NewUserGroupID(),
+		Label:        a.Label,
+		Description:  a.Description,
+		DateCreated:  a.DateCreated,
+		DateModified: a.DateModified,
+	}
+}
+
+// MapUpdateUserGroupParams converts wrapper params to sqlc-generated SQLite params.
+func (d Database) MapUpdateUserGroupParams(a UpdateUserGroupParams) mdb.UpdateUserGroupParams {
+	return mdb.UpdateUserGroupParams{
+		Label:        a.Label,
+		Description:  a.Description,
+		DateCreated:  a.DateCreated,
+		DateModified: a.DateModified,
+		UserGroupID:  a.UserGroupID,
+	}
+}
+
+// QUERIES
+
+// CountUserGroups returns the total number of userGroups in the database.
+func (d Database) CountUserGroups() (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountUserGroup(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("%v", err)
+	}
+	return &c, nil
+}
+
+// CreateUserGroupTable creates the user_groups table in the database.
+func (d Database) CreateUserGroupTable() error {
+	queries := mdb.New(d.Connection)
+	err := queries.CreateUserGroupTable(d.Context)
+	return err
+}
+
+// CreateUserGroup inserts a new userGroup and records an audit event.
+func (d Database) CreateUserGroup(ctx context.Context, ac audited.AuditContext, s CreateUserGroupParams) (*UserGroups, error) {
+	cmd := d.NewUserGroupCmd(ctx, ac, s)
+	result, err := audited.Create(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create userGroup: %w", err)
+	}
+	r := d.MapUserGroup(result)
+	return &r, nil
+}
+
+// DeleteUserGroup removes a userGroup and records an audit event.
+func (d Database) DeleteUserGroup(ctx context.Context, ac audited.AuditContext, id types.UserGroupID) error {
+	cmd := d.DeleteUserGroupCmd(ctx, ac, id)
+	return audited.Delete(cmd)
+}
+
+// GetUserGroup retrieves a userGroup by ID.
+func (d Database) GetUserGroup(id types.UserGroupID) (*UserGroups, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetUserGroup(d.Context, mdb.GetUserGroupParams{UserGroupID: id})
+	if err != nil {
+		return nil, err
+	}
+	res := d.MapUserGroup(row)
+	return &res, nil
+}
+
+// ListUserGroups retrieves all userGroups in the database.
+func (d Database) ListUserGroups() (*[]UserGroups, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListUserGroup(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get UserGroups: %v\n", err)
+	}
+	res := []UserGroups{}
+	for _, v := range rows {
+		m := d.MapUserGroup(v)
+		res = append(res, m)
+	}
+	return &res, nil
+}
+
+// UpdateUserGroup modifies an existing userGroup and records an audit event.
+func (d Database) UpdateUserGroup(ctx context.Context, ac audited.AuditContext, s UpdateUserGroupParams) (*string, error) {
+	cmd := d.UpdateUserGroupCmd(ctx, ac, s)
+	if err := audited.Update(cmd); err != nil {
+		return nil, fmt.Errorf("failed to update userGroup: %w", err)
+	}
+	msg := fmt.Sprintf("Successfully updated %v\n", s.Label)
+	return &msg, nil
+}
+
+// GetUserGroupByLabel retrieves a userGroup by label.
+func (d Database) GetUserGroupByLabel(label string) (*UserGroups, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetUserGroupByLabel(d.Context, mdb.GetUserGroupByLabelParams{Label: label})
+	if err != nil {
+		return nil, err
+	}
+	res := d.MapUserGroup(row)
+	return &res, nil
+}
+
+///////////////////////////////
+// MYSQL
+//////////////////////////////
+
+// MAPS
+
+// MapUserGroup converts a sqlc-generated MySQL userGroup to the wrapper type.
+func (d MysqlDatabase) MapUserGroup(a mdbm.UserGroups) UserGroups {
+	return UserGroups{
+		UserGroupID:  a.UserGroupID,
+		Label:        a.Label,
+		Description:  a.Description,
+		DateCreated:  a.DateCreated,
+		DateModified: a.DateModified,
+	}
+}
+
+// MapCreateUserGroupParams converts wrapper params to sqlc-generated MySQL params.
+func (d MysqlDatabase) MapCreateUserGroupParams(a CreateUserGroupParams) mdbm.CreateUserGroupParams {
+	return mdbm.CreateUserGroupParams{
+		UserGroupID:  types.NewUserGroupID(),
+		Label:        a.Label,
+		Description:  a.Description,
+		DateCreated:  a.DateCreated,
+		DateModified: a.DateModified,
+	}
+}
+
+// MapUpdateUserGroupParams converts wrapper params to sqlc-generated MySQL params.
+func (d MysqlDatabase) MapUpdateUserGroupParams(a UpdateUserGroupParams) mdbm.UpdateUserGroupParams {
+	return mdbm.UpdateUserGroupParams{
+		Label:        a.Label,
+		Description:  a.Description,
+		DateCreated:  a.DateCreated,
+		DateModified: a.DateModified,
+		UserGroupID:  a.UserGroupID,
+	}
+}
+
+// QUERIES
+
+// CountUserGroups returns the total number of userGroups in the database.
+func (d MysqlDatabase) CountUserGroups() (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountUserGroup(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("%v", err)
+	}
+	return &c, nil
+}
+
+// CreateUserGroupTable creates the user_groups table in the database.
+func (d MysqlDatabase) CreateUserGroupTable() error {
+	queries := mdbm.New(d.Connection)
+	err := queries.CreateUserGroupTable(d.Context)
+	return err
+}
+
+// CreateUserGroup inserts a new userGroup and records an audit event.
+func (d MysqlDatabase) CreateUserGroup(ctx context.Context, ac audited.AuditContext, s CreateUserGroupParams) (*UserGroups, error) {
+	cmd := d.NewUserGroupCmd(ctx, ac, s)
+	result, err := audited.Create(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create userGroup: %w", err)
+	}
+	r := d.MapUserGroup(result)
+	return &r, nil
+}
+
+// DeleteUserGroup removes a userGroup and records an audit event.
+func (d MysqlDatabase) DeleteUserGroup(ctx context.Context, ac audited.AuditContext, id types.UserGroupID) error {
+	cmd := d.DeleteUserGroupCmd(ctx, ac, id)
+	return audited.Delete(cmd)
+}
+
+// GetUserGroup retrieves a userGroup by ID.
+func (d MysqlDatabase) GetUserGroup(id types.UserGroupID) (*UserGroups, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetUserGroup(d.Context, mdbm.GetUserGroupParams{UserGroupID: id})
+	if err != nil {
+		return nil, err
+	}
+	res := d.MapUserGroup(row)
+	return &res, nil
+}
+
+// ListUserGroups retrieves all userGroups in the database.
+func (d MysqlDatabase) ListUserGroups() (*[]UserGroups, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListUserGroup(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get UserGroups: %v\n", err)
+	}
+	res := []UserGroups{}
+	for _, v := range rows {
+		m := d.MapUserGroup(v)
+		res = append(res, m)
+	}
+	return &res, nil
+}
+
+// UpdateUserGroup modifies an existing userGroup and records an audit event.
+func (d MysqlDatabase) UpdateUserGroup(ctx context.Context, ac audited.AuditContext, s UpdateUserGroupParams) (*string, error) {
+	cmd := d.UpdateUserGroupCmd(ctx, ac, s)
+	if err := audited.Update(cmd); err != nil {
+		return nil, fmt.Errorf("failed to update userGroup: %w", err)
+	}
+	msg := fmt.Sprintf("Successfully updated %v\n", s.Label)
+	return &msg, nil
+}
+
+// GetUserGroupByLabel retrieves a userGroup by label.
+func (d MysqlDatabase) GetUserGroupByLabel(label string) (*UserGroups, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetUserGroupByLabel(d.Context, mdbm.GetUserGroupByLabelParams{Label: label})
+	if err != nil {
+		return nil, err
+	}
+	res := d.MapUserGroup(row)
+	return &res, nil
+}
+
+///////////////////////////////
+// POSTGRES
+//////////////////////////////
+
+// MAPS
+
+// MapUserGroup converts a sqlc-generated PostgreSQL userGroup to the wrapper type.
+func (d PsqlDatabase) MapUserGroup(a mdbp.UserGroups) UserGroups {
+	return UserGroups{
+		UserGroupID:  a.UserGroupID,
+		Label:        a.Label,
+		Description:  a.Description,
+		DateCreated:  a.DateCreated,
+		DateModified: a.DateModified,
+	}
+}
+
+// MapCreateUserGroupParams converts wrapper params to sqlc-generated PostgreSQL params.
+func (d PsqlDatabase) MapCreateUserGroupParams(a CreateUserGroupParams) mdbp.CreateUserGroupParams {
+	return mdbp.CreateUserGroupParams{
+		UserGroupID:  types.NewUserGroupID(),
+		Label:        a.Label,
+		Description:  a.Description,
+		DateCreated:  a.DateCreated,
+		DateModified: a.DateModified,
+	}
+}
+
+// MapUpdateUserGroupParams converts wrapper params to sqlc-generated PostgreSQL params.
+func (d PsqlDatabase) MapUpdateUserGroupParams(a UpdateUserGroupParams) mdbp.UpdateUserGroupParams {
+	return mdbp.UpdateUserGroupParams{
+		Label:        a.Label,
+		Description:  a.Description,
+		DateCreated:  a.DateCreated,
+		DateModified: a.DateModified,
+		UserGroupID:  a.UserGroupID,
+	}
+}
+
+// QUERIES
+
+// CountUserGroups returns the total number of userGroups in the database.
+func (d PsqlDatabase) CountUserGroups() (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountUserGroup(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("%v", err)
+	}
+	return &c, nil
+}
+
+// CreateUserGroupTable creates the user_groups table in the database.
+func (d PsqlDatabase) CreateUserGroupTable() error {
+	queries := mdbp.New(d.Connection)
+	err := queries.CreateUserGroupTable(d.Context)
+	return err
+}
+
+// CreateUserGroup inserts a new userGroup and records an audit event.
+func (d PsqlDatabase) CreateUserGroup(ctx context.Context, ac audited.AuditContext, s CreateUserGroupParams) (*UserGroups, error) {
+	cmd := d.NewUserGroupCmd(ctx, ac, s)
+	result, err := audited.Create(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create userGroup: %w", err)
+	}
+	r := d.MapUserGroup(result)
+	return &r, nil
+}
+
+// DeleteUserGroup removes a userGroup and records an audit event.
+func (d PsqlDatabase) DeleteUserGroup(ctx context.Context, ac audited.AuditContext, id types.UserGroupID) error {
+	cmd := d.DeleteUserGroupCmd(ctx, ac, id)
+	return audited.Delete(cmd)
+}
+
+// GetUserGroup retrieves a userGroup by ID.
+func (d PsqlDatabase) GetUserGroup(id types.UserGroupID) (*UserGroups, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetUserGroup(d.Context, mdbp.GetUserGroupParams{UserGroupID: id})
+	if err != nil {
+		return nil, err
+	}
+	res := d.MapUserGroup(row)
+	return &res, nil
+}
+
+// ListUserGroups retrieves all userGroups in the database.
+func (d PsqlDatabase) ListUserGroups() (*[]UserGroups, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListUserGroup(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get UserGroups: %v\n", err)
+	}
+	res := []UserGroups{}
+	for _, v := range rows {
+		m := d.MapUserGroup(v)
+		res = append(res, m)
+	}
+	return &res, nil
+}
+
+// UpdateUserGroup modifies an existing userGroup and records an audit event.
+func (d PsqlDatabase) UpdateUserGroup(ctx context.Context, ac audited.AuditContext, s UpdateUserGroupParams) (*string, error) {
+	cmd := d.UpdateUserGroupCmd(ctx, ac, s)
+	if err := audited.Update(cmd); err != nil {
+		return nil, fmt.Errorf("failed to update userGroup: %w", err)
+	}
+	msg := fmt.Sprintf("Successfully updated %v\n", s.Label)
+	return &msg, nil
+}
+
+// GetUserGroupByLabel retrieves a userGroup by label.
+func (d PsqlDatabase) GetUserGroupByLabel(label string) (*UserGroups, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetUserGroupByLabel(d.Context, mdbp.GetUserGroupByLabelParams{Label: label})
+	if err != nil {
+		return nil, err
+	}
+	res := d.MapUserGroup(row)
+	return &res, nil
+}
+
+// ========== AUDITED COMMAND TYPES ==========
+
+// ----- SQLite CREATE -----
+
+// NewUserGroupCmd is an audited command for creating userGroups.
+type NewUserGroupCmd struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateUserGroupParams
+	conn     *sql.DB
+	recorder audited.ChangeEventRecorder
+}
+
+// Context returns the command context.
+func (c NewUserGroupCmd) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewUserGroupCmd) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewUserGroupCmd) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewUserGroupCmd) Recorder() audited.ChangeEventRecorder { return c.recorder }
+
+// TableName returns the table name for this command.
+func (c NewUserGroupCmd) TableName() string { return "user_groups" }
+
+// Params returns the parameters for this command.
+func (c NewUserGroupCmd) Params() any { return c.params }
+
+// GetID extracts the ID from a userGroup record.
+func (c NewUserGroupCmd) GetID(u mdb.UserGroups) string { return string(u.UserGroupID) }
+
+// Execute creates the userGroup in the database.
+func (c NewUserGroupCmd) Execute(ctx context.Context, tx audited.DBTX) (mdb.UserGroups, error) {
+	queries := mdb.New(tx)
+	return queries.CreateUserGroup(ctx, mdb.CreateUserGroupParams{
+		UserGroupID:  types.NewUserGroupID(),
+		Label:        c.params.Label,
+		Description:  c.params.Description,
+		DateCreated:  c.params.DateCreated,
+		DateModified: c.params.DateModified,
+	})
+}
+
+// NewUserGroupCmd creates a command for inserting a userGroup.
+func (d Database) NewUserGroupCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserGroupParams) NewUserGroupCmd {
+	return NewUserGroupCmd{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: SQLiteRecorder}
+}
+
+// ----- SQLite UPDATE -----
+
+// UpdateUserGroupCmd is an audited command for updating userGroups.
+type UpdateUserGroupCmd struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   UpdateUserGroupParams
+	conn     *sql.DB
+	recorder audited.ChangeEventRecorder
+}
+
+// Context returns the command context.
+func (c UpdateUserGroupCmd) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c UpdateUserGroupCmd) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c UpdateUserGroupCmd) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c UpdateUserGroupCmd) Recorder() audited.ChangeEventRecorder { return c.recorder }
+
+// TableName returns the table name for this command.
+func (c UpdateUserGroupCmd) TableName() string { return "user_groups" }
+
+// Params returns the parameters for this command.
+func (c UpdateUserGroupCmd) Params() any { return c.params }
+
+// GetID returns the userGroup ID for this command.
+func (c UpdateUserGroupCmd) GetID() string { return string(c.params.UserGroupID) }
+
+// GetBefore retrieves the userGroup before the update.
+func (c UpdateUserGroupCmd) GetBefore(ctx context.Context, tx audited.DBTX) (mdb.UserGroups, error) {
+	queries := mdb.New(tx)
+	return queries.GetUserGroup(ctx, mdb.GetUserGroupParams{UserGroupID: c.params.UserGroupID})
+}
+
+// Execute updates the userGroup in the database.
+func (c UpdateUserGroupCmd) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdb.New(tx)
+	return queries.UpdateUserGroup(ctx, mdb.UpdateUserGroupParams{
+		Label:        c.params.Label,
+		Description:  c.params.Description,
+		DateCreated:  c.params.DateCreated,
+		DateModified: c.params.DateModified,
+		UserGroupID:  c.params.UserGroupID,
+	})
+}
+
+// UpdateUserGroupCmd creates a command for updating a userGroup.
+func (d Database) UpdateUserGroupCmd(ctx context.Context, auditCtx audited.AuditContext, params UpdateUserGroupParams) UpdateUserGroupCmd {
+	return UpdateUserGroupCmd{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: SQLiteRecorder}
+}
+
+// ----- SQLite DELETE -----
+
+// DeleteUserGroupCmd is an audited command for deleting userGroups.
+type DeleteUserGroupCmd struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	id       types.UserGroupID
+	conn     *sql.DB
+	recorder audited.ChangeEventRecorder
+}
+
+// Context returns the command context.
+func (c DeleteUserGroupCmd) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c DeleteUserGroupCmd) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c DeleteUserGroupCmd) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c DeleteUserGroupCmd) Recorder() audited.ChangeEventRecorder { return c.recorder }
+
+// TableName returns the table name for this command.
+func (c DeleteUserGroupCmd) TableName() string { return "user_groups" }
+
+// GetID returns the userGroup ID for this command.
+func (c DeleteUserGroupCmd) GetID() string { return string(c.id) }
+
+// GetBefore retrieves the userGroup before the delete.
+func (c DeleteUserGroupCmd) GetBefore(ctx context.Context, tx audited.DBTX) (mdb.UserGroups, error) {
+	queries := mdb.New(tx)
+	return queries.GetUserGroup(ctx, mdb.GetUserGroupParams{UserGroupID: c.id})
+}
+
+// Execute deletes the userGroup from the database.
+func (c DeleteUserGroupCmd) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdb.New(tx)
+	return queries.DeleteUserGroup(ctx, mdb.DeleteUserGroupParams{UserGroupID: c.id})
+}
+
+// DeleteUserGroupCmd creates a command for deleting a userGroup.
+func (d Database) DeleteUserGroupCmd(ctx context.Context, auditCtx audited.AuditContext, id types.UserGroupID) DeleteUserGroupCmd {
+	return DeleteUserGroupCmd{ctx: ctx, auditCtx: auditCtx, id: id, conn: d.Connection, recorder: SQLiteRecorder}
+}
+
+// ----- MySQL CREATE -----
+
+// NewUserGroupCmdMysql is an audited command for creating userGroups in MySQL.
+type NewUserGroupCmdMysql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateUserGroupParams
+	conn     *sql.DB
+	recorder audited.ChangeEventRecorder
+}
+
+// Context returns the command context.
+func (c NewUserGroupCmdMysql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewUserGroupCmdMysql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewUserGroupCmdMysql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewUserGroupCmdMysql) Recorder() audited.ChangeEventRecorder { return c.recorder }
+
+// TableName returns the table name for this command.
+func (c NewUserGroupCmdMysql) TableName() string { return "user_groups" }
+
+// Params returns the parameters for this command.
+func (c NewUserGroupCmdMysql) Params() any { return c.params }
+
+// GetID extracts the ID from a userGroup record.
+func (c NewUserGroupCmdMysql) GetID(u mdbm.UserGroups) string { return string(u.UserGroupID) }
+
+// Execute creates the userGroup in the database.
+func (c NewUserGroupCmdMysql) Execute(ctx context.Context, tx audited.DBTX) (mdbm.UserGroups, error) {
+	queries := mdbm.New(tx)
+	params := mdbm.CreateUserGroupParams{
+		UserGroupID:  types.NewUserGroupID(),
+		Label:        c.params.Label,
+		Description:  c.params.Description,
+		DateCreated:  c.params.DateCreated,
+		DateModified: c.params.DateModified,
+	}
+	if err := queries.CreateUserGroup(ctx, params); err != nil {
+		return mdbm.UserGroups{}, err
+	}
+	return queries.GetUserGroup(ctx, mdbm.GetUserGroupParams{UserGroupID: params.UserGroupID})
+}
+
+// NewUserGroupCmd creates a command for inserting a userGroup.
+func (d MysqlDatabase) NewUserGroupCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserGroupParams) NewUserGroupCmdMysql {
+	return NewUserGroupCmdMysql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: MysqlRecorder}
+}
+
+// ----- MySQL UPDATE -----
+
+// UpdateUserGroupCmdMysql is an audited command for updating userGroups in MySQL.
+type UpdateUserGroupCmdMysql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   UpdateUserGroupParams
+	conn     *sql.DB
+	recorder audited.ChangeEventRecorder
+}
+
+// Context returns the command context.
+func (c UpdateUserGroupCmdMysql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c UpdateUserGroupCmdMysql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c UpdateUserGroupCmdMysql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c UpdateUserGroupCmdMysql) Recorder() audited.ChangeEventRecorder { return c.recorder }
+
+// TableName returns the table name for this command.
+func (c UpdateUserGroupCmdMysql) TableName() string { return "user_groups" }
+
+// Params returns the parameters for this command.
+func (c UpdateUserGroupCmdMysql) Params() any { return c.params }
+
+// GetID returns the userGroup ID for this command.
+func (c UpdateUserGroupCmdMysql) GetID() string { return string(c.params.UserGroupID) }
+
+// GetBefore retrieves the userGroup before the update.
+func (c UpdateUserGroupCmdMysql) GetBefore(ctx context.Context, tx audited.DBTX) (mdbm.UserGroups, error) {
+	queries := mdbm.New(tx)
+	return queries.GetUserGroup(ctx, mdbm.GetUserGroupParams{UserGroupID: c.params.UserGroupID})
+}
+
+// Execute updates the userGroup in the database.
+func (c UpdateUserGroupCmdMysql) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdbm.New(tx)
+	return queries.UpdateUserGroup(ctx, mdbm.UpdateUserGroupParams{
+		Label:        c.params.Label,
+		Description:  c.params.Description,
+		DateCreated:  c.params.DateCreated,
+		DateModified: c.params.DateModified,
+		UserGroupID:  c.params.UserGroupID,
+	})
+}
+
+// UpdateUserGroupCmd creates a command for updating a userGroup.
+func (d MysqlDatabase) UpdateUserGroupCmd(ctx context.Context, auditCtx audited.AuditContext, params UpdateUserGroupParams) UpdateUserGroupCmdMysql {
+	return UpdateUserGroupCmdMysql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: MysqlRecorder}
+}
+
+// ----- MySQL DELETE -----
+
+// DeleteUserGroupCmdMysql is an audited command for deleting userGroups in MySQL.
+type DeleteUserGroupCmdMysql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	id       types.UserGroupID
+	conn     *sql.DB
+	recorder audited.ChangeEventRecorder
+}
+
+// Context returns the command context.
+func (c DeleteUserGroupCmdMysql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c DeleteUserGroupCmdMysql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c DeleteUserGroupCmdMysql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c DeleteUserGroupCmdMysql) Recorder() audited.ChangeEventRecorder { return c.recorder }
+
+// TableName returns the table name for this command.
+func (c DeleteUserGroupCmdMysql) TableName() string { return "user_groups" }
+
+// GetID returns the userGroup ID for this command.
+func (c DeleteUserGroupCmdMysql) GetID() string { return string(c.id) }
+
+// GetBefore retrieves the userGroup before the delete.
+func (c DeleteUserGroupCmdMysql) GetBefore(ctx context.Context, tx audited.DBTX) (mdbm.UserGroups, error) {
+	queries := mdbm.New(tx)
+	return queries.GetUserGroup(ctx, mdbm.GetUserGroupParams{UserGroupID: c.id})
+}
+
+// Execute deletes the userGroup from the database.
+func (c DeleteUserGroupCmdMysql) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdbm.New(tx)
+	return queries.DeleteUserGroup(ctx, mdbm.DeleteUserGroupParams{UserGroupID: c.id})
+}
+
+// DeleteUserGroupCmd creates a command for deleting a userGroup.
+func (d MysqlDatabase) DeleteUserGroupCmd(ctx context.Context, auditCtx audited.AuditContext, id types.UserGroupID) DeleteUserGroupCmdMysql {
+	return DeleteUserGroupCmdMysql{ctx: ctx, auditCtx: auditCtx, id: id, conn: d.Connection, recorder: MysqlRecorder}
+}
+
+// ----- PostgreSQL CREATE -----
+
+// NewUserGroupCmdPsql is an audited command for creating userGroups in PostgreSQL.
+type NewUserGroupCmdPsql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateUserGroupParams
+	conn     *sql.DB
+	recorder audited.ChangeEventRecorder
+}
+
+// Context returns the command context.
+func (c NewUserGroupCmdPsql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewUserGroupCmdPsql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewUserGroupCmdPsql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewUserGroupCmdPsql) Recorder() audited.ChangeEventRecorder { return c.recorder }
+
+// TableName returns the table name for this command.
+func (c NewUserGroupCmdPsql) TableName() string { return "user_groups" }
+
+// Params returns the parameters for this command.
+func (c NewUserGroupCmdPsql) Params() any { return c.params }
+
+// GetID extracts the ID from a userGroup record.
+func (c NewUserGroupCmdPsql) GetID(u mdbp.UserGroups) string { return string(u.UserGroupID) }
+
+// Execute creates the userGroup in the database.
+func (c NewUserGroupCmdPsql) Execute(ctx context.Context, tx audited.DBTX) (mdbp.UserGroups, error) {
+	queries := mdbp.New(tx)
+	return queries.CreateUserGroup(ctx, mdbp.CreateUserGroupParams{
+		UserGroupID:  types.NewUserGroupID(),
+		Label:        c.params.Label,
+		Description:  c.params.Description,
+		DateCreated:  c.params.DateCreated,
+		DateModified: c.params.DateModified,
+	})
+}
+
+// NewUserGroupCmd creates a command for inserting a userGroup.
+func (d PsqlDatabase) NewUserGroupCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserGroupParams) NewUserGroupCmdPsql {
+	return NewUserGroupCmdPsql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: PsqlRecorder}
+}
+
+// ----- PostgreSQL UPDATE -----
+
+// UpdateUserGroupCmdPsql is an audited command for updating userGroups in PostgreSQL.
+type UpdateUserGroupCmdPsql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   UpdateUserGroupParams
+	conn     *sql.DB
+	recorder audited.ChangeEventRecorder
+}
+
+// Context returns the command context.
+func (c UpdateUserGroupCmdPsql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c UpdateUserGroupCmdPsql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c UpdateUserGroupCmdPsql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c UpdateUserGroupCmdPsql) Recorder() audited.ChangeEventRecorder { return c.recorder }
+
+// TableName returns the table name for this command.
+func (c UpdateUserGroupCmdPsql) TableName() string { return "user_groups" }
+
+// Params returns the parameters for this command.
+func (c UpdateUserGroupCmdPsql) Params() any { return c.params }
+
+// GetID returns the userGroup ID for this command.
+func (c UpdateUserGroupCmdPsql) GetID() string { return string(c.params.UserGroupID) }
+
+// GetBefore retrieves the userGroup before the update.
+func (c UpdateUserGroupCmdPsql) GetBefore(ctx context.Context, tx audited.DBTX) (mdbp.UserGroups, error) {
+	queries := mdbp.New(tx)
+	return queries.GetUserGroup(ctx, mdbp.GetUserGroupParams{UserGroupID: c.params.UserGroupID})
+}
+
+// Execute updates the userGroup in the database.
+func (c UpdateUserGroupCmdPsql) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdbp.New(tx)
+	return queries.UpdateUserGroup(ctx, mdbp.UpdateUserGroupParams{
+		Label:        c.params.Label,
+		Description:  c.params.Description,
+		DateCreated:  c.params.DateCreated,
+		DateModified: c.params.DateModified,
+		UserGroupID:  c.params.UserGroupID,
+	})
+}
+
+// UpdateUserGroupCmd creates a command for updating a userGroup.
+func (d PsqlDatabase) UpdateUserGroupCmd(ctx context.Context, auditCtx audited.AuditContext, params UpdateUserGroupParams) UpdateUserGroupCmdPsql {
+	return UpdateUserGroupCmdPsql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: PsqlRecorder}
+}
+
+// ----- PostgreSQL DELETE -----
+
+// DeleteUserGroupCmdPsql is an audited command for deleting userGroups in PostgreSQL.
+type DeleteUserGroupCmdPsql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	id       types.UserGroupID
+	conn     *sql.DB
+	recorder audited.ChangeEventRecorder
+}
+
+// Context returns the command context.
+func (c DeleteUserGroupCmdPsql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c DeleteUserGroupCmdPsql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c DeleteUserGroupCmdPsql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c DeleteUserGroupCmdPsql) Recorder() audited.ChangeEventRecorder { return c.recorder }
+
+// TableName returns the table name for this command.
+func (c DeleteUserGroupCmdPsql) TableName() string { return "user_groups" }
+
+// GetID returns the userGroup ID for this command.
+func (c DeleteUserGroupCmdPsql) GetID() string { return string(c.id) }
+
+// GetBefore retrieves the userGroup before the delete.
+func (c DeleteUserGroupCmdPsql) GetBefore(ctx context.Context, tx audited.DBTX) (mdbp.UserGroups, error) {
+	queries := mdbp.New(tx)
+	return queries.GetUserGroup(ctx, mdbp.GetUserGroupParams{UserGroupID: c.id})
+}
+
+// Execute deletes the userGroup from the database.
+func (c DeleteUserGroupCmdPsql) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdbp.New(tx)
+	return queries.DeleteUserGroup(ctx, mdbp.DeleteUserGroupParams{UserGroupID: c.id})
+}
+
+// DeleteUserGroupCmd creates a command for deleting a userGroup.
+func (d PsqlDatabase) DeleteUserGroupCmd(ctx context.Context, auditCtx audited.AuditContext, id types.UserGroupID) DeleteUserGroupCmdPsql {
+	return DeleteUserGroupCmdPsql{ctx: ctx, auditCtx: auditCtx, id: id, conn: d.Connection, recorder: PsqlRecorder}
+}
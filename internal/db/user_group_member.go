@@ -0,0 +1,657 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+///////////////////////////////
+// STRUCTS
+//////////////////////////////
+
+// UserGroupMembers represents a user's membership in a user group.
+type UserGroupMembers struct {
+	ID          types.UserGroupMemberID `json:"id"`
+	UserGroupID types.UserGroupID       `json:"user_group_id"`
+	UserID      types.UserID            `json:"user_id"`
+}
+
+// CreateUserGroupMemberParams contains parameters for creating a group membership.
+type CreateUserGroupMemberParams struct {
+	UserGroupID types.UserGroupID `json:"user_group_id"`
+	UserID      types.UserID      `json:"user_id"`
+}
+
+///////////////////////////////
+// SQLITE
+//////////////////////////////
+
+// MAPS
+
+// MapUserGroupMember converts a sqlc-generated SQLite UserGroupMembers type to the wrapper type.
+func (d Database) MapUserGroupMember(a mdb.UserGroupMembers) UserGroupMembers {
+	return UserGroupMembers{
+		ID:          a.ID,
+		UserGroupID: a.UserGroupID,
+		UserID:      a.UserID,
+	}
+}
+
+// QUERIES
+
+// CountUserGroupMembers returns the total count of group memberships.
+func (d Database) CountUserGroupMembers() (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountUserGroupMember(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user group members: %w", err)
+	}
+	return &c, nil
+}
+
+// CreateUserGroupMembersTable creates the user_group_members table.
+func (d Database) CreateUserGroupMembersTable() error {
+	queries := mdb.New(d.Connection)
+	err := queries.CreateUserGroupMembersTable(d.Context)
+	return err
+}
+
+// CreateUserGroupMember inserts a new group membership and records an audit event.
+func (d Database) CreateUserGroupMember(ctx context.Context, ac audited.AuditContext, s CreateUserGroupMemberParams) (*UserGroupMembers, error) {
+	cmd := d.NewUserGroupMemberCmd(ctx, ac, s)
+	result, err := audited.Create(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user group member: %w", err)
+	}
+	r := d.MapUserGroupMember(result)
+	return &r, nil
+}
+
+// DeleteUserGroupMember removes a group membership and records an audit event.
+func (d Database) DeleteUserGroupMember(ctx context.Context, ac audited.AuditContext, id types.UserGroupMemberID) error {
+	cmd := d.DeleteUserGroupMemberCmd(ctx, ac, id)
+	return audited.Delete(cmd)
+}
+
+// DeleteUserGroupMembersByUserGroupID removes all group memberships for a given group.
+// This is a non-audited bulk delete used when replacing a group's member set.
+func (d Database) DeleteUserGroupMembersByUserGroupID(ctx context.Context, ac audited.AuditContext, groupID types.UserGroupID) error {
+	queries := mdb.New(d.Connection)
+	return queries.DeleteUserGroupMemberByUserGroupID(d.Context, mdb.DeleteUserGroupMemberByUserGroupIDParams{UserGroupID: groupID})
+}
+
+// GetUserGroupMember retrieves a group membership by ID.
+func (d Database) GetUserGroupMember(id types.UserGroupMemberID) (*UserGroupMembers, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetUserGroupMember(d.Context, mdb.GetUserGroupMemberParams{ID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user group member: %w", err)
+	}
+	res := d.MapUserGroupMember(row)
+	return &res, nil
+}
+
+// ListUserGroupMembers returns all group memberships.
+func (d Database) ListUserGroupMembers() (*[]UserGroupMembers, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListUserGroupMember(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members: %w", err)
+	}
+	res := []UserGroupMembers{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupMember(v))
+	}
+	return &res, nil
+}
+
+// ListUserGroupMembersByUserGroupID returns all group memberships for a given group.
+func (d Database) ListUserGroupMembersByUserGroupID(groupID types.UserGroupID) (*[]UserGroupMembers, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListUserGroupMemberByUserGroupID(d.Context, mdb.ListUserGroupMemberByUserGroupIDParams{UserGroupID: groupID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members by group id: %w", err)
+	}
+	res := []UserGroupMembers{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupMember(v))
+	}
+	return &res, nil
+}
+
+// ListUserGroupMembersByUserID returns all group memberships for a given user.
+func (d Database) ListUserGroupMembersByUserID(userID types.UserID) (*[]UserGroupMembers, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListUserGroupMemberByUserID(d.Context, mdb.ListUserGroupMemberByUserIDParams{UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members by user id: %w", err)
+	}
+	res := []UserGroupMembers{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupMember(v))
+	}
+	return &res, nil
+}
+
+///////////////////////////////
+// MYSQL
+//////////////////////////////
+
+// MAPS
+
+// MapUserGroupMember converts a sqlc-generated MySQL UserGroupMembers type to the wrapper type.
+func (d MysqlDatabase) MapUserGroupMember(a mdbm.UserGroupMembers) UserGroupMembers {
+	return UserGroupMembers{
+		ID:          a.ID,
+		UserGroupID: a.UserGroupID,
+		UserID:      a.UserID,
+	}
+}
+
+// QUERIES
+
+// CountUserGroupMembers returns the total count of group memberships.
+func (d MysqlDatabase) CountUserGroupMembers() (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountUserGroupMember(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user group members: %w", err)
+	}
+	return &c, nil
+}
+
+// CreateUserGroupMembersTable creates the user_group_members table.
+func (d MysqlDatabase) CreateUserGroupMembersTable() error {
+	queries := mdbm.New(d.Connection)
+	err := queries.CreateUserGroupMembersTable(d.Context)
+	return err
+}
+
+// CreateUserGroupMember inserts a new group membership and records an audit event.
+func (d MysqlDatabase) CreateUserGroupMember(ctx context.Context, ac audited.AuditContext, s CreateUserGroupMemberParams) (*UserGroupMembers, error) {
+	cmd := d.NewUserGroupMemberCmd(ctx, ac, s)
+	result, err := audited.Create(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user group member: %w", err)
+	}
+	r := d.MapUserGroupMember(result)
+	return &r, nil
+}
+
+// DeleteUserGroupMember removes a group membership and records an audit event.
+func (d MysqlDatabase) DeleteUserGroupMember(ctx context.Context, ac audited.AuditContext, id types.UserGroupMemberID) error {
+	cmd := d.DeleteUserGroupMemberCmd(ctx, ac, id)
+	return audited.Delete(cmd)
+}
+
+// DeleteUserGroupMembersByUserGroupID removes all group memberships for a given group.
+// This is a non-audited bulk delete used when replacing a group's member set.
+func (d MysqlDatabase) DeleteUserGroupMembersByUserGroupID(ctx context.Context, ac audited.AuditContext, groupID types.UserGroupID) error {
+	queries := mdbm.New(d.Connection)
+	return queries.DeleteUserGroupMemberByUserGroupID(d.Context, mdbm.DeleteUserGroupMemberByUserGroupIDParams{UserGroupID: groupID})
+}
+
+// GetUserGroupMember retrieves a group membership by ID.
+func (d MysqlDatabase) GetUserGroupMember(id types.UserGroupMemberID) (*UserGroupMembers, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetUserGroupMember(d.Context, mdbm.GetUserGroupMemberParams{ID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user group member: %w", err)
+	}
+	res := d.MapUserGroupMember(row)
+	return &res, nil
+}
+
+// ListUserGroupMembers returns all group memberships.
+func (d MysqlDatabase) ListUserGroupMembers() (*[]UserGroupMembers, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListUserGroupMember(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members: %w", err)
+	}
+	res := []UserGroupMembers{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupMember(v))
+	}
+	return &res, nil
+}
+
+// ListUserGroupMembersByUserGroupID returns all group memberships for a given group.
+func (d MysqlDatabase) ListUserGroupMembersByUserGroupID(groupID types.UserGroupID) (*[]UserGroupMembers, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListUserGroupMemberByUserGroupID(d.Context, mdbm.ListUserGroupMemberByUserGroupIDParams{UserGroupID: groupID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members by group id: %w", err)
+	}
+	res := []UserGroupMembers{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupMember(v))
+	}
+	return &res, nil
+}
+
+// ListUserGroupMembersByUserID returns all group memberships for a given user.
+func (d MysqlDatabase) ListUserGroupMembersByUserID(userID types.UserID) (*[]UserGroupMembers, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListUserGroupMemberByUserID(d.Context, mdbm.ListUserGroupMemberByUserIDParams{UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members by user id: %w", err)
+	}
+	res := []UserGroupMembers{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupMember(v))
+	}
+	return &res, nil
+}
+
+///////////////////////////////
+// POSTGRES
+//////////////////////////////
+
+// MAPS
+
+// MapUserGroupMember converts a sqlc-generated PostgreSQL UserGroupMembers type to the wrapper type.
+func (d PsqlDatabase) MapUserGroupMember(a mdbp.UserGroupMembers) UserGroupMembers {
+	return UserGroupMembers{
+		ID:          a.ID,
+		UserGroupID: a.UserGroupID,
+		UserID:      a.UserID,
+	}
+}
+
+// QUERIES
+
+// CountUserGroupMembers returns the total count of group memberships.
+func (d PsqlDatabase) CountUserGroupMembers() (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountUserGroupMember(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user group members: %w", err)
+	}
+	return &c, nil
+}
+
+// CreateUserGroupMembersTable creates the user_group_members table and indexes.
+func (d PsqlDatabase) CreateUserGroupMembersTable() error {
+	queries := mdbp.New(d.Connection)
+	err := queries.CreateUserGroupMembersTable(d.Context)
+	if err != nil {
+		return err
+	}
+	err = queries.CreateUserGroupMembersIndexGroup(d.Context)
+	if err != nil {
+		return err
+	}
+	err = queries.CreateUserGroupMembersIndexUser(d.Context)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateUserGroupMember inserts a new group membership and records an audit event.
+func (d PsqlDatabase) CreateUserGroupMember(ctx context.Context, ac audited.AuditContext, s CreateUserGroupMemberParams) (*UserGroupMembers, error) {
+	cmd := d.NewUserGroupMemberCmd(ctx, ac, s)
+	result, err := audited.Create(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user group member: %w", err)
+	}
+	r := d.MapUserGroupMember(result)
+	return &r, nil
+}
+
+// DeleteUserGroupMember removes a group membership and records an audit event.
+func (d PsqlDatabase) DeleteUserGroupMember(ctx context.Context, ac audited.AuditContext, id types.UserGroupMemberID) error {
+	cmd := d.DeleteUserGroupMemberCmd(ctx, ac, id)
+	return audited.Delete(cmd)
+}
+
+// DeleteUserGroupMembersByUserGroupID removes all group memberships for a given group.
+// This is a non-audited bulk delete used when replacing a group's member set.
+func (d PsqlDatabase) DeleteUserGroupMembersByUserGroupID(ctx context.Context, ac audited.AuditContext, groupID types.UserGroupID) error {
+	queries := mdbp.New(d.Connection)
+	return queries.DeleteUserGroupMemberByUserGroupID(d.Context, mdbp.DeleteUserGroupMemberByUserGroupIDParams{UserGroupID: groupID})
+}
+
+// GetUserGroupMember retrieves a group membership by ID.
+func (d PsqlDatabase) GetUserGroupMember(id types.UserGroupMemberID) (*UserGroupMembers, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetUserGroupMember(d.Context, mdbp.GetUserGroupMemberParams{ID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user group member: %w", err)
+	}
+	res := d.MapUserGroupMember(row)
+	return &res, nil
+}
+
+// ListUserGroupMembers returns all group memberships.
+func (d PsqlDatabase) ListUserGroupMembers() (*[]UserGroupMembers, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListUserGroupMember(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members: %w", err)
+	}
+	res := []UserGroupMembers{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupMember(v))
+	}
+	return &res, nil
+}
+
+// ListUserGroupMembersByUserGroupID returns all group memberships for a given group.
+func (d PsqlDatabase) ListUserGroupMembersByUserGroupID(groupID types.UserGroupID) (*[]UserGroupMembers, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListUserGroupMemberByUserGroupID(d.Context, mdbp.ListUserGroupMemberByUserGroupIDParams{UserGroupID: groupID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members by group id: %w", err)
+	}
+	res := []UserGroupMembers{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupMember(v))
+	}
+	return &res, nil
+}
+
+// ListUserGroupMembersByUserID returns all group memberships for a given user.
+func (d PsqlDatabase) ListUserGroupMembersByUserID(userID types.UserID) (*[]UserGroupMembers, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListUserGroupMemberByUserID(d.Context, mdbp.ListUserGroupMemberByUserIDParams{UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members by user id: %w", err)
+	}
+	res := []UserGroupMembers{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupMember(v))
+	}
+	return &res, nil
+}
+
+///////////////////////////////
+// AUDITED COMMAND STRUCTS
+//////////////////////////////
+
+// ===== SQLITE =====
+
+// NewUserGroupMemberCmd is an audited command for creating a group membership in SQLite.
+type NewUserGroupMemberCmd struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateUserGroupMemberParams
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c NewUserGroupMemberCmd) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewUserGroupMemberCmd) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewUserGroupMemberCmd) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewUserGroupMemberCmd) Recorder() audited.ChangeEventRecorder { return SQLiteRecorder }
+
+// TableName returns the table name.
+func (c NewUserGroupMemberCmd) TableName() string { return "user_group_members" }
+
+// Params returns the command parameters.
+func (c NewUserGroupMemberCmd) Params() any { return c.params }
+
+// GetID returns the ID of a created group membership.
+func (c NewUserGroupMemberCmd) GetID(x mdb.UserGroupMembers) string {
+	return x.ID.String()
+}
+
+// Execute creates a group membership in the database.
+func (c NewUserGroupMemberCmd) Execute(ctx context.Context, tx audited.DBTX) (mdb.UserGroupMembers, error) {
+	queries := mdb.New(tx)
+	return queries.CreateUserGroupMember(ctx, mdb.CreateUserGroupMemberParams{
+		ID:          types.NewUserGroupMemberID(),
+		UserGroupID: c.params.UserGroupID,
+		UserID:      c.params.UserID,
+	})
+}
+
+// NewUserGroupMemberCmd returns a new create command for a group membership.
+func (d Database) NewUserGroupMemberCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserGroupMemberParams) NewUserGroupMemberCmd {
+	return NewUserGroupMemberCmd{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection}
+}
+
+// DeleteUserGroupMemberCmd is an audited command for deleting a group membership in SQLite.
+type DeleteUserGroupMemberCmd struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	id       types.UserGroupMemberID
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c DeleteUserGroupMemberCmd) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c DeleteUserGroupMemberCmd) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c DeleteUserGroupMemberCmd) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c DeleteUserGroupMemberCmd) Recorder() audited.ChangeEventRecorder { return SQLiteRecorder }
+
+// TableName returns the table name.
+func (c DeleteUserGroupMemberCmd) TableName() string { return "user_group_members" }
+
+// GetID returns the group membership ID being deleted.
+func (c DeleteUserGroupMemberCmd) GetID() string { return c.id.String() }
+
+// GetBefore retrieves the group membership before deletion.
+func (c DeleteUserGroupMemberCmd) GetBefore(ctx context.Context, tx audited.DBTX) (mdb.UserGroupMembers, error) {
+	queries := mdb.New(tx)
+	return queries.GetUserGroupMember(ctx, mdb.GetUserGroupMemberParams{ID: c.id})
+}
+
+// Execute deletes a group membership from the database.
+func (c DeleteUserGroupMemberCmd) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdb.New(tx)
+	return queries.DeleteUserGroupMember(ctx, mdb.DeleteUserGroupMemberParams{ID: c.id})
+}
+
+// DeleteUserGroupMemberCmd returns a new delete command for a group membership.
+func (d Database) DeleteUserGroupMemberCmd(ctx context.Context, auditCtx audited.AuditContext, id types.UserGroupMemberID) DeleteUserGroupMemberCmd {
+	return DeleteUserGroupMemberCmd{ctx: ctx, auditCtx: auditCtx, id: id, conn: d.Connection}
+}
+
+// ===== MYSQL =====
+
+// NewUserGroupMemberCmdMysql is an audited command for creating a group membership in MySQL.
+type NewUserGroupMemberCmdMysql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateUserGroupMemberParams
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c NewUserGroupMemberCmdMysql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewUserGroupMemberCmdMysql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewUserGroupMemberCmdMysql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewUserGroupMemberCmdMysql) Recorder() audited.ChangeEventRecorder { return MysqlRecorder }
+
+// TableName returns the table name.
+func (c NewUserGroupMemberCmdMysql) TableName() string { return "user_group_members" }
+
+// Params returns the command parameters.
+func (c NewUserGroupMemberCmdMysql) Params() any { return c.params }
+
+// GetID returns the ID of a created group membership.
+func (c NewUserGroupMemberCmdMysql) GetID(x mdbm.UserGroupMembers) string {
+	return x.ID.String()
+}
+
+// Execute creates a group membership in the database.
+// MySQL uses :exec (no RETURNING), so we exec then fetch.
+func (c NewUserGroupMemberCmdMysql) Execute(ctx context.Context, tx audited.DBTX) (mdbm.UserGroupMembers, error) {
+	id := types.NewUserGroupMemberID()
+	queries := mdbm.New(tx)
+	err := queries.CreateUserGroupMember(ctx, mdbm.CreateUserGroupMemberParams{
+		ID:          id,
+		UserGroupID: c.params.UserGroupID,
+		UserID:      c.params.UserID,
+	})
+	if err != nil {
+		return mdbm.UserGroupMembers{}, fmt.Errorf("failed to create user group member: %w", err)
+	}
+	return queries.GetUserGroupMember(ctx, mdbm.GetUserGroupMemberParams{ID: id})
+}
+
+// NewUserGroupMemberCmd returns a new create command for a group membership.
+func (d MysqlDatabase) NewUserGroupMemberCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserGroupMemberParams) NewUserGroupMemberCmdMysql {
+	return NewUserGroupMemberCmdMysql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection}
+}
+
+// DeleteUserGroupMemberCmdMysql is an audited command for deleting a group membership in MySQL.
+type DeleteUserGroupMemberCmdMysql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	id       types.UserGroupMemberID
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c DeleteUserGroupMemberCmdMysql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c DeleteUserGroupMemberCmdMysql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c DeleteUserGroupMemberCmdMysql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c DeleteUserGroupMemberCmdMysql) Recorder() audited.ChangeEventRecorder { return MysqlRecorder }
+
+// TableName returns the table name.
+func (c DeleteUserGroupMemberCmdMysql) TableName() string { return "user_group_members" }
+
+// GetID returns the group membership ID being deleted.
+func (c DeleteUserGroupMemberCmdMysql) GetID() string { return c.id.String() }
+
+// GetBefore retrieves the group membership before deletion.
+func (c DeleteUserGroupMemberCmdMysql) GetBefore(ctx context.Context, tx audited.DBTX) (mdbm.UserGroupMembers, error) {
+	queries := mdbm.New(tx)
+	return queries.GetUserGroupMember(ctx, mdbm.GetUserGroupMemberParams{ID: c.id})
+}
+
+// Execute deletes a group membership from the database.
+func (c DeleteUserGroupMemberCmdMysql) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdbm.New(tx)
+	return queries.DeleteUserGroupMember(ctx, mdbm.DeleteUserGroupMemberParams{ID: c.id})
+}
+
+// DeleteUserGroupMemberCmd returns a new delete command for a group membership.
+func (d MysqlDatabase) DeleteUserGroupMemberCmd(ctx context.Context, auditCtx audited.AuditContext, id types.UserGroupMemberID) DeleteUserGroupMemberCmdMysql {
+	return DeleteUserGroupMemberCmdMysql{ctx: ctx, auditCtx: auditCtx, id: id, conn: d.Connection}
+}
+
+// ===== POSTGRESQL =====
+
+// NewUserGroupMemberCmdPsql is an audited command for creating a group membership in PostgreSQL.
+type NewUserGroupMemberCmdPsql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateUserGroupMemberParams
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c NewUserGroupMemberCmdPsql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewUserGroupMemberCmdPsql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewUserGroupMemberCmdPsql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewUserGroupMemberCmdPsql) Recorder() audited.ChangeEventRecorder { return PsqlRecorder }
+
+// TableName returns the table name.
+func (c NewUserGroupMemberCmdPsql) TableName() string { return "user_group_members" }
+
+// Params returns the command parameters.
+func (c NewUserGroupMemberCmdPsql) Params() any { return c.params }
+
+// GetID returns the ID of a created group membership.
+func (c NewUserGroupMemberCmdPsql) GetID(x mdbp.UserGroupMembers) string {
+	return x.ID.String()
+}
+
+// Execute creates a group membership in the database.
+func (c NewUserGroupMemberCmdPsql) Execute(ctx context.Context, tx audited.DBTX) (mdbp.UserGroupMembers, error) {
+	queries := mdbp.New(tx)
+	return queries.CreateUserGroupMember(ctx, mdbp.CreateUserGroupMemberParams{
+		ID:          types.NewUserGroupMemberID(),
+		UserGroupID: c.params.UserGroupID,
+		UserID:      c.params.UserID,
+	})
+}
+
+// NewUserGroupMemberCmd returns a new create command for a group membership.
+func (d PsqlDatabase) NewUserGroupMemberCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserGroupMemberParams) NewUserGroupMemberCmdPsql {
+	return NewUserGroupMemberCmdPsql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection}
+}
+
+// DeleteUserGroupMemberCmdPsql is an audited command for deleting a group membership in PostgreSQL.
+type DeleteUserGroupMemberCmdPsql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	id       types.UserGroupMemberID
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c DeleteUserGroupMemberCmdPsql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c DeleteUserGroupMemberCmdPsql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c DeleteUserGroupMemberCmdPsql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c DeleteUserGroupMemberCmdPsql) Recorder() audited.ChangeEventRecorder { return PsqlRecorder }
+
+// TableName returns the table name.
+func (c DeleteUserGroupMemberCmdPsql) TableName() string { return "user_group_members" }
+
+// GetID returns the group membership ID being deleted.
+func (c DeleteUserGroupMemberCmdPsql) GetID() string { return c.id.String() }
+
+// GetBefore retrieves the group membership before deletion.
+func (c DeleteUserGroupMemberCmdPsql) GetBefore(ctx context.Context, tx audited.DBTX) (mdbp.UserGroupMembers, error) {
+	queries := mdbp.New(tx)
+	return queries.GetUserGroupMember(ctx, mdbp.GetUserGroupMemberParams{ID: c.id})
+}
+
+// Execute deletes a group membership from the database.
+func (c DeleteUserGroupMemberCmdPsql) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdbp.New(tx)
+	return queries.DeleteUserGroupMember(ctx, mdbp.DeleteUserGroupMemberParams{ID: c.id})
+}
+
+// DeleteUserGroupMemberCmd returns a new delete command for a group membership.
+func (d PsqlDatabase) DeleteUserGroupMemberCmd(ctx context.Context, auditCtx audited.AuditContext, id types.UserGroupMemberID) DeleteUserGroupMemberCmdPsql {
+	return DeleteUserGroupMemberCmdPsql{ctx: ctx, auditCtx: auditCtx, id: id, conn: d.Connection}
+}
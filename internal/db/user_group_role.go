@@ -0,0 +1,657 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+///////////////////////////////
+// STRUCTS
+//////////////////////////////
+
+// UserGroupRoles represents an assignment of a role to a user group.
+type UserGroupRoles struct {
+	ID          types.UserGroupRoleID `json:"id"`
+	UserGroupID types.UserGroupID     `json:"user_group_id"`
+	RoleID      types.RoleID          `json:"role_id"`
+}
+
+// CreateUserGroupRoleParams contains parameters for creating a group-role assignment.
+type CreateUserGroupRoleParams struct {
+	UserGroupID types.UserGroupID `json:"user_group_id"`
+	RoleID      types.RoleID      `json:"role_id"`
+}
+
+///////////////////////////////
+// SQLITE
+//////////////////////////////
+
+// MAPS
+
+// MapUserGroupRole converts a sqlc-generated SQLite UserGroupRoles type to the wrapper type.
+func (d Database) MapUserGroupRole(a mdb.UserGroupRoles) UserGroupRoles {
+	return UserGroupRoles{
+		ID:          a.ID,
+		UserGroupID: a.UserGroupID,
+		RoleID:      a.RoleID,
+	}
+}
+
+// QUERIES
+
+// CountUserGroupRoles returns the total count of group-role assignments.
+func (d Database) CountUserGroupRoles() (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountUserGroupRole(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user group roles: %w", err)
+	}
+	return &c, nil
+}
+
+// CreateUserGroupRolesTable creates the user_group_roles table.
+func (d Database) CreateUserGroupRolesTable() error {
+	queries := mdb.New(d.Connection)
+	err := queries.CreateUserGroupRolesTable(d.Context)
+	return err
+}
+
+// CreateUserGroupRole inserts a new group-role assignment and records an audit event.
+func (d Database) CreateUserGroupRole(ctx context.Context, ac audited.AuditContext, s CreateUserGroupRoleParams) (*UserGroupRoles, error) {
+	cmd := d.NewUserGroupRoleCmd(ctx, ac, s)
+	result, err := audited.Create(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user group role: %w", err)
+	}
+	r := d.MapUserGroupRole(result)
+	return &r, nil
+}
+
+// DeleteUserGroupRole removes a group-role assignment and records an audit event.
+func (d Database) DeleteUserGroupRole(ctx context.Context, ac audited.AuditContext, id types.UserGroupRoleID) error {
+	cmd := d.DeleteUserGroupRoleCmd(ctx, ac, id)
+	return audited.Delete(cmd)
+}
+
+// DeleteUserGroupRolesByUserGroupID removes all group-role assignments for a given group.
+// This is a non-audited bulk delete used when replacing a group's role set.
+func (d Database) DeleteUserGroupRolesByUserGroupID(ctx context.Context, ac audited.AuditContext, groupID types.UserGroupID) error {
+	queries := mdb.New(d.Connection)
+	return queries.DeleteUserGroupRoleByUserGroupID(d.Context, mdb.DeleteUserGroupRoleByUserGroupIDParams{UserGroupID: groupID})
+}
+
+// GetUserGroupRole retrieves a group-role assignment by ID.
+func (d Database) GetUserGroupRole(id types.UserGroupRoleID) (*UserGroupRoles, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetUserGroupRole(d.Context, mdb.GetUserGroupRoleParams{ID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user group role: %w", err)
+	}
+	res := d.MapUserGroupRole(row)
+	return &res, nil
+}
+
+// ListUserGroupRoles returns all group-role assignments.
+func (d Database) ListUserGroupRoles() (*[]UserGroupRoles, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListUserGroupRole(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group roles: %w", err)
+	}
+	res := []UserGroupRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupRole(v))
+	}
+	return &res, nil
+}
+
+// ListUserGroupRolesByUserGroupID returns all group-role assignments for a given group.
+func (d Database) ListUserGroupRolesByUserGroupID(groupID types.UserGroupID) (*[]UserGroupRoles, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListUserGroupRoleByUserGroupID(d.Context, mdb.ListUserGroupRoleByUserGroupIDParams{UserGroupID: groupID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group roles by group id: %w", err)
+	}
+	res := []UserGroupRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupRole(v))
+	}
+	return &res, nil
+}
+
+// ListUserGroupRolesByRoleID returns all group-role assignments for a given role.
+func (d Database) ListUserGroupRolesByRoleID(roleID types.RoleID) (*[]UserGroupRoles, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListUserGroupRoleByRoleID(d.Context, mdb.ListUserGroupRoleByRoleIDParams{RoleID: roleID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group roles by role id: %w", err)
+	}
+	res := []UserGroupRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupRole(v))
+	}
+	return &res, nil
+}
+
+///////////////////////////////
+// MYSQL
+//////////////////////////////
+
+// MAPS
+
+// MapUserGroupRole converts a sqlc-generated MySQL UserGroupRoles type to the wrapper type.
+func (d MysqlDatabase) MapUserGroupRole(a mdbm.UserGroupRoles) UserGroupRoles {
+	return UserGroupRoles{
+		ID:          a.ID,
+		UserGroupID: a.UserGroupID,
+		RoleID:      a.RoleID,
+	}
+}
+
+// QUERIES
+
+// CountUserGroupRoles returns the total count of group-role assignments.
+func (d MysqlDatabase) CountUserGroupRoles() (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountUserGroupRole(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user group roles: %w", err)
+	}
+	return &c, nil
+}
+
+// CreateUserGroupRolesTable creates the user_group_roles table.
+func (d MysqlDatabase) CreateUserGroupRolesTable() error {
+	queries := mdbm.New(d.Connection)
+	err := queries.CreateUserGroupRolesTable(d.Context)
+	return err
+}
+
+// CreateUserGroupRole inserts a new group-role assignment and records an audit event.
+func (d MysqlDatabase) CreateUserGroupRole(ctx context.Context, ac audited.AuditContext, s CreateUserGroupRoleParams) (*UserGroupRoles, error) {
+	cmd := d.NewUserGroupRoleCmd(ctx, ac, s)
+	result, err := audited.Create(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user group role: %w", err)
+	}
+	r := d.MapUserGroupRole(result)
+	return &r, nil
+}
+
+// DeleteUserGroupRole removes a group-role assignment and records an audit event.
+func (d MysqlDatabase) DeleteUserGroupRole(ctx context.Context, ac audited.AuditContext, id types.UserGroupRoleID) error {
+	cmd := d.DeleteUserGroupRoleCmd(ctx, ac, id)
+	return audited.Delete(cmd)
+}
+
+// DeleteUserGroupRolesByUserGroupID removes all group-role assignments for a given group.
+// This is a non-audited bulk delete used when replacing a group's role set.
+func (d MysqlDatabase) DeleteUserGroupRolesByUserGroupID(ctx context.Context, ac audited.AuditContext, groupID types.UserGroupID) error {
+	queries := mdbm.New(d.Connection)
+	return queries.DeleteUserGroupRoleByUserGroupID(d.Context, mdbm.DeleteUserGroupRoleByUserGroupIDParams{UserGroupID: groupID})
+}
+
+// GetUserGroupRole retrieves a group-role assignment by ID.
+func (d MysqlDatabase) GetUserGroupRole(id types.UserGroupRoleID) (*UserGroupRoles, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetUserGroupRole(d.Context, mdbm.GetUserGroupRoleParams{ID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user group role: %w", err)
+	}
+	res := d.MapUserGroupRole(row)
+	return &res, nil
+}
+
+// ListUserGroupRoles returns all group-role assignments.
+func (d MysqlDatabase) ListUserGroupRoles() (*[]UserGroupRoles, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListUserGroupRole(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group roles: %w", err)
+	}
+	res := []UserGroupRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupRole(v))
+	}
+	return &res, nil
+}
+
+// ListUserGroupRolesByUserGroupID returns all group-role assignments for a given group.
+func (d MysqlDatabase) ListUserGroupRolesByUserGroupID(groupID types.UserGroupID) (*[]UserGroupRoles, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListUserGroupRoleByUserGroupID(d.Context, mdbm.ListUserGroupRoleByUserGroupIDParams{UserGroupID: groupID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group roles by group id: %w", err)
+	}
+	res := []UserGroupRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupRole(v))
+	}
+	return &res, nil
+}
+
+// ListUserGroupRolesByRoleID returns all group-role assignments for a given role.
+func (d MysqlDatabase) ListUserGroupRolesByRoleID(roleID types.RoleID) (*[]UserGroupRoles, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListUserGroupRoleByRoleID(d.Context, mdbm.ListUserGroupRoleByRoleIDParams{RoleID: roleID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group roles by role id: %w", err)
+	}
+	res := []UserGroupRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupRole(v))
+	}
+	return &res, nil
+}
+
+///////////////////////////////
+// POSTGRES
+//////////////////////////////
+
+// MAPS
+
+// MapUserGroupRole converts a sqlc-generated PostgreSQL UserGroupRoles type to the wrapper type.
+func (d PsqlDatabase) MapUserGroupRole(a mdbp.UserGroupRoles) UserGroupRoles {
+	return UserGroupRoles{
+		ID:          a.ID,
+		UserGroupID: a.UserGroupID,
+		RoleID:      a.RoleID,
+	}
+}
+
+// QUERIES
+
+// CountUserGroupRoles returns the total count of group-role assignments.
+func (d PsqlDatabase) CountUserGroupRoles() (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountUserGroupRole(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user group roles: %w", err)
+	}
+	return &c, nil
+}
+
+// CreateUserGroupRolesTable creates the user_group_roles table and indexes.
+func (d PsqlDatabase) CreateUserGroupRolesTable() error {
+	queries := mdbp.New(d.Connection)
+	err := queries.CreateUserGroupRolesTable(d.Context)
+	if err != nil {
+		return err
+	}
+	err = queries.CreateUserGroupRolesIndexGroup(d.Context)
+	if err != nil {
+		return err
+	}
+	err = queries.CreateUserGroupRolesIndexRole(d.Context)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateUserGroupRole inserts a new group-role assignment and records an audit event.
+func (d PsqlDatabase) CreateUserGroupRole(ctx context.Context, ac audited.AuditContext, s CreateUserGroupRoleParams) (*UserGroupRoles, error) {
+	cmd := d.NewUserGroupRoleCmd(ctx, ac, s)
+	result, err := audited.Create(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user group role: %w", err)
+	}
+	r := d.MapUserGroupRole(result)
+	return &r, nil
+}
+
+// DeleteUserGroupRole removes a group-role assignment and records an audit event.
+func (d PsqlDatabase) DeleteUserGroupRole(ctx context.Context, ac audited.AuditContext, id types.UserGroupRoleID) error {
+	cmd := d.DeleteUserGroupRoleCmd(ctx, ac, id)
+	return audited.Delete(cmd)
+}
+
+// DeleteUserGroupRolesByUserGroupID removes all group-role assignments for a given group.
+// This is a non-audited bulk delete used when replacing a group's role set.
+func (d PsqlDatabase) DeleteUserGroupRolesByUserGroupID(ctx context.Context, ac audited.AuditContext, groupID types.UserGroupID) error {
+	queries := mdbp.New(d.Connection)
+	return queries.DeleteUserGroupRoleByUserGroupID(d.Context, mdbp.DeleteUserGroupRoleByUserGroupIDParams{UserGroupID: groupID})
+}
+
+// GetUserGroupRole retrieves a group-role assignment by ID.
+func (d PsqlDatabase) GetUserGroupRole(id types.UserGroupRoleID) (*UserGroupRoles, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetUserGroupRole(d.Context, mdbp.GetUserGroupRoleParams{ID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user group role: %w", err)
+	}
+	res := d.MapUserGroupRole(row)
+	return &res, nil
+}
+
+// ListUserGroupRoles returns all group-role assignments.
+func (d PsqlDatabase) ListUserGroupRoles() (*[]UserGroupRoles, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListUserGroupRole(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group roles: %w", err)
+	}
+	res := []UserGroupRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupRole(v))
+	}
+	return &res, nil
+}
+
+// ListUserGroupRolesByUserGroupID returns all group-role assignments for a given group.
+func (d PsqlDatabase) ListUserGroupRolesByUserGroupID(groupID types.UserGroupID) (*[]UserGroupRoles, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListUserGroupRoleByUserGroupID(d.Context, mdbp.ListUserGroupRoleByUserGroupIDParams{UserGroupID: groupID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group roles by group id: %w", err)
+	}
+	res := []UserGroupRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupRole(v))
+	}
+	return &res, nil
+}
+
+// ListUserGroupRolesByRoleID returns all group-role assignments for a given role.
+func (d PsqlDatabase) ListUserGroupRolesByRoleID(roleID types.RoleID) (*[]UserGroupRoles, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListUserGroupRoleByRoleID(d.Context, mdbp.ListUserGroupRoleByRoleIDParams{RoleID: roleID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group roles by role id: %w", err)
+	}
+	res := []UserGroupRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserGroupRole(v))
+	}
+	return &res, nil
+}
+
+///////////////////////////////
+// AUDITED COMMAND STRUCTS
+//////////////////////////////
+
+// ===== SQLITE =====
+
+// NewUserGroupRoleCmd is an audited command for creating a group-role assignment in SQLite.
+type NewUserGroupRoleCmd struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateUserGroupRoleParams
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c NewUserGroupRoleCmd) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewUserGroupRoleCmd) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewUserGroupRoleCmd) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewUserGroupRoleCmd) Recorder() audited.ChangeEventRecorder { return SQLiteRecorder }
+
+// TableName returns the table name.
+func (c NewUserGroupRoleCmd) TableName() string { return "user_group_roles" }
+
+// Params returns the command parameters.
+func (c NewUserGroupRoleCmd) Params() any { return c.params }
+
+// GetID returns the ID of a created group-role assignment.
+func (c NewUserGroupRoleCmd) GetID(x mdb.UserGroupRoles) string {
+	return x.ID.String()
+}
+
+// Execute creates a group-role assignment in the database.
+func (c NewUserGroupRoleCmd) Execute(ctx context.Context, tx audited.DBTX) (mdb.UserGroupRoles, error) {
+	queries := mdb.New(tx)
+	return queries.CreateUserGroupRole(ctx, mdb.CreateUserGroupRoleParams{
+		ID:          types.NewUserGroupRoleID(),
+		UserGroupID: c.params.UserGroupID,
+		RoleID:      c.params.RoleID,
+	})
+}
+
+// NewUserGroupRoleCmd returns a new create command for a group-role assignment.
+func (d Database) NewUserGroupRoleCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserGroupRoleParams) NewUserGroupRoleCmd {
+	return NewUserGroupRoleCmd{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection}
+}
+
+// DeleteUserGroupRoleCmd is an audited command for deleting a group-role assignment in SQLite.
+type DeleteUserGroupRoleCmd struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	id       types.UserGroupRoleID
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c DeleteUserGroupRoleCmd) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c DeleteUserGroupRoleCmd) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c DeleteUserGroupRoleCmd) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c DeleteUserGroupRoleCmd) Recorder() audited.ChangeEventRecorder { return SQLiteRecorder }
+
+// TableName returns the table name.
+func (c DeleteUserGroupRoleCmd) TableName() string { return "user_group_roles" }
+
+// GetID returns the group-role ID being deleted.
+func (c DeleteUserGroupRoleCmd) GetID() string { return c.id.String() }
+
+// GetBefore retrieves the group-role assignment before deletion.
+func (c DeleteUserGroupRoleCmd) GetBefore(ctx context.Context, tx audited.DBTX) (mdb.UserGroupRoles, error) {
+	queries := mdb.New(tx)
+	return queries.GetUserGroupRole(ctx, mdb.GetUserGroupRoleParams{ID: c.id})
+}
+
+// Execute deletes a group-role assignment from the database.
+func (c DeleteUserGroupRoleCmd) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdb.New(tx)
+	return queries.DeleteUserGroupRole(ctx, mdb.DeleteUserGroupRoleParams{ID: c.id})
+}
+
+// DeleteUserGroupRoleCmd returns a new delete command for a group-role assignment.
+func (d Database) DeleteUserGroupRoleCmd(ctx context.Context, auditCtx audited.AuditContext, id types.UserGroupRoleID) DeleteUserGroupRoleCmd {
+	return DeleteUserGroupRoleCmd{ctx: ctx, auditCtx: auditCtx, id: id, conn: d.Connection}
+}
+
+// ===== MYSQL =====
+
+// NewUserGroupRoleCmdMysql is an audited command for creating a group-role assignment in MySQL.
+type NewUserGroupRoleCmdMysql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateUserGroupRoleParams
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c NewUserGroupRoleCmdMysql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewUserGroupRoleCmdMysql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewUserGroupRoleCmdMysql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewUserGroupRoleCmdMysql) Recorder() audited.ChangeEventRecorder { return MysqlRecorder }
+
+// TableName returns the table name.
+func (c NewUserGroupRoleCmdMysql) TableName() string { return "user_group_roles" }
+
+// Params returns the command parameters.
+func (c NewUserGroupRoleCmdMysql) Params() any { return c.params }
+
+// GetID returns the ID of a created group-role assignment.
+func (c NewUserGroupRoleCmdMysql) GetID(x mdbm.UserGroupRoles) string {
+	return x.ID.String()
+}
+
+// Execute creates a group-role assignment in the database.
+// MySQL uses :exec (no RETURNING), so we exec then fetch.
+func (c NewUserGroupRoleCmdMysql) Execute(ctx context.Context, tx audited.DBTX) (mdbm.UserGroupRoles, error) {
+	id := types.NewUserGroupRoleID()
+	queries := mdbm.New(tx)
+	err := queries.CreateUserGroupRole(ctx, mdbm.CreateUserGroupRoleParams{
+		ID:          id,
+		UserGroupID: c.params.UserGroupID,
+		RoleID:      c.params.RoleID,
+	})
+	if err != nil {
+		return mdbm.UserGroupRoles{}, fmt.Errorf("failed to create user group role: %w", err)
+	}
+	return queries.GetUserGroupRole(ctx, mdbm.GetUserGroupRoleParams{ID: id})
+}
+
+// NewUserGroupRoleCmd returns a new create command for a group-role assignment.
+func (d MysqlDatabase) NewUserGroupRoleCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserGroupRoleParams) NewUserGroupRoleCmdMysql {
+	return NewUserGroupRoleCmdMysql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection}
+}
+
+// DeleteUserGroupRoleCmdMysql is an audited command for deleting a group-role assignment in MySQL.
+type DeleteUserGroupRoleCmdMysql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	id       types.UserGroupRoleID
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c DeleteUserGroupRoleCmdMysql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c DeleteUserGroupRoleCmdMysql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c DeleteUserGroupRoleCmdMysql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c DeleteUserGroupRoleCmdMysql) Recorder() audited.ChangeEventRecorder { return MysqlRecorder }
+
+// TableName returns the table name.
+func (c DeleteUserGroupRoleCmdMysql) TableName() string { return "user_group_roles" }
+
+// GetID returns the group-role ID being deleted.
+func (c DeleteUserGroupRoleCmdMysql) GetID() string { return c.id.String() }
+
+// GetBefore retrieves the group-role assignment before deletion.
+func (c DeleteUserGroupRoleCmdMysql) GetBefore(ctx context.Context, tx audited.DBTX) (mdbm.UserGroupRoles, error) {
+	queries := mdbm.New(tx)
+	return queries.GetUserGroupRole(ctx, mdbm.GetUserGroupRoleParams{ID: c.id})
+}
+
+// Execute deletes a group-role assignment from the database.
+func (c DeleteUserGroupRoleCmdMysql) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdbm.New(tx)
+	return queries.DeleteUserGroupRole(ctx, mdbm.DeleteUserGroupRoleParams{ID: c.id})
+}
+
+// DeleteUserGroupRoleCmd returns a new delete command for a group-role assignment.
+func (d MysqlDatabase) DeleteUserGroupRoleCmd(ctx context.Context, auditCtx audited.AuditContext, id types.UserGroupRoleID) DeleteUserGroupRoleCmdMysql {
+	return DeleteUserGroupRoleCmdMysql{ctx: ctx, auditCtx: auditCtx, id: id, conn: d.Connection}
+}
+
+// ===== POSTGRESQL =====
+
+// NewUserGroupRoleCmdPsql is an audited command for creating a group-role assignment in PostgreSQL.
+type NewUserGroupRoleCmdPsql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateUserGroupRoleParams
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c NewUserGroupRoleCmdPsql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewUserGroupRoleCmdPsql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewUserGroupRoleCmdPsql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewUserGroupRoleCmdPsql) Recorder() audited.ChangeEventRecorder { return PsqlRecorder }
+
+// TableName returns the table name.
+func (c NewUserGroupRoleCmdPsql) TableName() string { return "user_group_roles" }
+
+// Params returns the command parameters.
+func (c NewUserGroupRoleCmdPsql) Params() any { return c.params }
+
+// GetID returns the ID of a created group-role assignment.
+func (c NewUserGroupRoleCmdPsql) GetID(x mdbp.UserGroupRoles) string {
+	return x.ID.String()
+}
+
+// Execute creates a group-role assignment in the database.
+func (c NewUserGroupRoleCmdPsql) Execute(ctx context.Context, tx audited.DBTX) (mdbp.UserGroupRoles, error) {
+	queries := mdbp.New(tx)
+	return queries.CreateUserGroupRole(ctx, mdbp.CreateUserGroupRoleParams{
+		ID:          types.NewUserGroupRoleID(),
+		UserGroupID: c.params.UserGroupID,
+		RoleID:      c.params.RoleID,
+	})
+}
+
+// NewUserGroupRoleCmd returns a new create command for a group-role assignment.
+func (d PsqlDatabase) NewUserGroupRoleCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserGroupRoleParams) NewUserGroupRoleCmdPsql {
+	return NewUserGroupRoleCmdPsql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection}
+}
+
+// DeleteUserGroupRoleCmdPsql is an audited command for deleting a group-role assignment in PostgreSQL.
+type DeleteUserGroupRoleCmdPsql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	id       types.UserGroupRoleID
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c DeleteUserGroupRoleCmdPsql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c DeleteUserGroupRoleCmdPsql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c DeleteUserGroupRoleCmdPsql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c DeleteUserGroupRoleCmdPsql) Recorder() audited.ChangeEventRecorder { return PsqlRecorder }
+
+// TableName returns the table name.
+func (c DeleteUserGroupRoleCmdPsql) TableName() string { return "user_group_roles" }
+
+// GetID returns the group-role ID being deleted.
+func (c DeleteUserGroupRoleCmdPsql) GetID() string { return c.id.String() }
+
+// GetBefore retrieves the group-role assignment before deletion.
+func (c DeleteUserGroupRoleCmdPsql) GetBefore(ctx context.Context, tx audited.DBTX) (mdbp.UserGroupRoles, error) {
+	queries := mdbp.New(tx)
+	return queries.GetUserGroupRole(ctx, mdbp.GetUserGroupRoleParams{ID: c.id})
+}
+
+// Execute deletes a group-role assignment from the database.
+func (c DeleteUserGroupRoleCmdPsql) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdbp.New(tx)
+	return queries.DeleteUserGroupRole(ctx, mdbp.DeleteUserGroupRoleParams{ID: c.id})
+}
+
+// DeleteUserGroupRoleCmd returns a new delete command for a group-role assignment.
+func (d PsqlDatabase) DeleteUserGroupRoleCmd(ctx context.Context, auditCtx audited.AuditContext, id types.UserGroupRoleID) DeleteUserGroupRoleCmdPsql {
+	return DeleteUserGroupRoleCmdPsql{ctx: ctx, auditCtx: auditCtx, id: id, conn: d.Connection}
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// CreateUserGroupRoleInTx grants a role to a group with audit trail in an
+// existing transaction.
+func CreateUserGroupRoleInTx(d DbDriver, ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params CreateUserGroupRoleParams) (*UserGroupRoles, error) {
+	switch drv := d.(type) {
+	case Database:
+		result, err := audited.CreateInTx(Database{}.NewUserGroupRoleCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create user_group_role: %w", err)
+		}
+		r := drv.MapUserGroupRole(result)
+		return &r, nil
+	case MysqlDatabase:
+		result, err := audited.CreateInTx(MysqlDatabase{}.NewUserGroupRoleCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create user_group_role: %w", err)
+		}
+		r := drv.MapUserGroupRole(result)
+		return &r, nil
+	case PsqlDatabase:
+		result, err := audited.CreateInTx(PsqlDatabase{}.NewUserGroupRoleCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create user_group_role: %w", err)
+		}
+		r := drv.MapUserGroupRole(result)
+		return &r, nil
+	default:
+		return nil, fmt.Errorf("tx create user_group_role: unsupported driver type %T", d)
+	}
+}
+
+// DeleteUserGroupRolesByUserGroupIDInTx removes all roles granted to a group
+// in an existing transaction. Like DeleteUserGroupRolesByUserGroupID it is
+// not audited.
+func DeleteUserGroupRolesByUserGroupIDInTx(d DbDriver, ctx context.Context, tx *sql.Tx, groupID types.UserGroupID) error {
+	switch d.(type) {
+	case Database:
+		return mdb.New(tx).DeleteUserGroupRoleByUserGroupID(ctx, mdb.DeleteUserGroupRoleByUserGroupIDParams{UserGroupID: groupID})
+	case MysqlDatabase:
+		return mdbm.New(tx).DeleteUserGroupRoleByUserGroupID(ctx, mdbm.DeleteUserGroupRoleByUserGroupIDParams{UserGroupID: groupID})
+	case PsqlDatabase:
+		return mdbp.New(tx).DeleteUserGroupRoleByUserGroupID(ctx, mdbp.DeleteUserGroupRoleByUserGroupIDParams{UserGroupID: groupID})
+	default:
+		return fmt.Errorf("tx delete user_group_roles: unsupported driver type %T", d)
+	}
+}
+
+// CreateUserGroupMemberInTx adds a user to a group with audit trail in an
+// existing transaction.
+func CreateUserGroupMemberInTx(d DbDriver, ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params CreateUserGroupMemberParams) (*UserGroupMembers, error) {
+	switch drv := d.(type) {
+	case Database:
+		result, err := audited.CreateInTx(Database{}.NewUserGroupMemberCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create user_group_member: %w", err)
+		}
+		r := drv.MapUserGroupMember(result)
+		return &r, nil
+	case MysqlDatabase:
+		result, err := audited.CreateInTx(MysqlDatabase{}.NewUserGroupMemberCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create user_group_member: %w", err)
+		}
+		r := drv.MapUserGroupMember(result)
+		return &r, nil
+	case PsqlDatabase:
+		result, err := audited.CreateInTx(PsqlDatabase{}.NewUserGroupMemberCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create user_group_member: %w", err)
+		}
+		r := drv.MapUserGroupMember(result)
+		return &r, nil
+	default:
+		return nil, fmt.Errorf("tx create user_group_member: unsupported driver type %T", d)
+	}
+}
+
+// DeleteUserGroupMembersByUserGroupIDInTx removes all members of a group in
+// an existing transaction. Like DeleteUserGroupMembersByUserGroupID it is not
+// audited.
+func DeleteUserGroupMembersByUserGroupIDInTx(d DbDriver, ctx context.Context, tx *sql.Tx, groupID types.UserGroupID) error {
+	switch d.(type) {
+	case Database:
+		return mdb.New(tx).DeleteUserGroupMemberByUserGroupID(ctx, mdb.DeleteUserGroupMemberByUserGroupIDParams{UserGroupID: groupID})
+	case MysqlDatabase:
+		return mdbm.New(tx).DeleteUserGroupMemberByUserGroupID(ctx, mdbm.DeleteUserGroupMemberByUserGroupIDParams{UserGroupID: groupID})
+	case PsqlDatabase:
+		return mdbp.New(tx).DeleteUserGroupMemberByUserGroupID(ctx, mdbp.DeleteUserGroupMemberByUserGroupIDParams{UserGroupID: groupID})
+	default:
+		return fmt.Errorf("tx delete user_group_members: unsupported driver type %T", d)
+	}
+}
@@ -0,0 +1,603 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// User lockouts and password history are security state rather than content,
+// so they are written without audited commands. Lockout and unlock events are
+// recorded explicitly by the auth service, and password hashes never reach
+// change_events.
+
+///////////////////////////////
+// STRUCTS
+//////////////////////////////
+
+// UserLockout tracks failed sign-in attempts and the lockout state of one account.
+type UserLockout struct {
+	UserID         types.UserID    `json:"user_id"`
+	FailedAttempts int64           `json:"failed_attempts"`
+	LockoutCount   int64           `json:"lockout_count"`
+	LockedUntil    types.Timestamp `json:"locked_until"`
+	LastFailedAt   types.Timestamp `json:"last_failed_at"`
+	DateModified   types.Timestamp `json:"date_modified"`
+}
+
+// IsLocked reports whether the account is locked at the given time.
+func (l UserLockout) IsLocked(now time.Time) bool {
+	return l.LockedUntil.Valid && now.Before(l.LockedUntil.Time)
+}
+
+// UpsertUserLockoutParams contains the full lockout state to store for a user.
+type UpsertUserLockoutParams struct {
+	UserID         types.UserID    `json:"user_id"`
+	FailedAttempts int64           `json:"failed_attempts"`
+	LockoutCount   int64           `json:"lockout_count"`
+	LockedUntil    types.Timestamp `json:"locked_until"`
+	LastFailedAt   types.Timestamp `json:"last_failed_at"`
+	DateModified   types.Timestamp `json:"date_modified"`
+}
+
+// IncrementUserLockoutParams records one failed sign-in for a user.
+type IncrementUserLockoutParams struct {
+	UserID       types.UserID    `json:"user_id"`
+	LastFailedAt types.Timestamp `json:"last_failed_at"`
+	DateModified types.Timestamp `json:"date_modified"`
+}
+
+// LockUserLockoutParams locks a user whose failed attempt count has reached
+// Threshold.
+type LockUserLockoutParams struct {
+	UserID       types.UserID    `json:"user_id"`
+	Threshold    int64           `json:"threshold"`
+	LockedUntil  types.Timestamp `json:"locked_until"`
+	DateModified types.Timestamp `json:"date_modified"`
+}
+
+// PasswordHistory is a previous password hash kept to prevent reuse.
+type PasswordHistory struct {
+	ID          types.PasswordHistoryID `json:"id"`
+	UserID      types.UserID            `json:"user_id"`
+	Hash        string                  `json:"hash"`
+	DateCreated types.Timestamp         `json:"date_created"`
+}
+
+// CreatePasswordHistoryParams contains fields for recording a password hash.
+type CreatePasswordHistoryParams struct {
+	UserID      types.UserID    `json:"user_id"`
+	Hash        string          `json:"hash"`
+	DateCreated types.Timestamp `json:"date_created"`
+}
+
+///////////////////////////////
+// SQLITE
+//////////////////////////////
+
+// MAPS
+
+// MapUserLockout converts a sqlc-generated type to the wrapper type.
+func (d Database) MapUserLockout(a mdb.UserLockouts) UserLockout {
+	return UserLockout{
+		UserID:         a.UserID,
+		FailedAttempts: a.FailedAttempts,
+		LockoutCount:   a.LockoutCount,
+		LockedUntil:    a.LockedUntil,
+		LastFailedAt:   a.LastFailedAt,
+		DateModified:   a.DateModified,
+	}
+}
+
+// MapPasswordHistory converts a sqlc-generated type to the wrapper type.
+func (d Database) MapPasswordHistory(a mdb.PasswordHistory) PasswordHistory {
+	return PasswordHistory{
+		ID:          a.ID,
+		UserID:      a.UserID,
+		Hash:        a.Hash,
+		DateCreated: a.DateCreated,
+	}
+}
+
+// QUERIES - User Lockouts
+
+// CreateUserLockoutTable creates the user_lockouts table.
+func (d Database) CreateUserLockoutTable() error {
+	queries := mdb.New(d.Connection)
+	return queries.CreateUserLockoutsTable(d.Context)
+}
+
+// GetUserLockout retrieves the lockout state of a user.
+func (d Database) GetUserLockout(userID types.UserID) (*UserLockout, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetUserLockout(d.Context, mdb.GetUserLockoutParams{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	res := d.MapUserLockout(row)
+	return &res, nil
+}
+
+// ListUserLockouts returns the lockout state of every user with failed attempts
+// or a lockout, most recently changed first.
+func (d Database) ListUserLockouts() (*[]UserLockout, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListUserLockouts(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user lockouts: %v", err)
+	}
+	res := []UserLockout{}
+	for _, v := range rows {
+		res = append(res, d.MapUserLockout(v))
+	}
+	return &res, nil
+}
+
+// UpsertUserLockout stores the lockout state of a user, replacing any existing row.
+func (d Database) UpsertUserLockout(params UpsertUserLockoutParams) error {
+	queries := mdb.New(d.Connection)
+	return queries.UpsertUserLockout(d.Context, mdb.UpsertUserLockoutParams{
+		UserID:         params.UserID,
+		FailedAttempts: params.FailedAttempts,
+		LockoutCount:   params.LockoutCount,
+		LockedUntil:    params.LockedUntil,
+		LastFailedAt:   params.LastFailedAt,
+		DateModified:   params.DateModified,
+	})
+}
+
+// IncrementUserLockout adds one failed attempt to the user's lockout row,
+// creating it if needed. The increment is a single statement, so concurrent
+// failures are all counted.
+func (d Database) IncrementUserLockout(params IncrementUserLockoutParams) error {
+	queries := mdb.New(d.Connection)
+	return queries.IncrementUserLockout(d.Context, mdb.IncrementUserLockoutParams{
+		UserID:       params.UserID,
+		LastFailedAt: params.LastFailedAt,
+		DateModified: params.DateModified,
+	})
+}
+
+// LockUserLockout locks the user until params.LockedUntil, resetting the
+// failed attempt count, if the count has reached params.Threshold. It reports
+// whether this call applied the lock.
+func (d Database) LockUserLockout(params LockUserLockoutParams) (bool, error) {
+	queries := mdb.New(d.Connection)
+	n, err := queries.LockUserLockout(d.Context, mdb.LockUserLockoutParams{
+		LockedUntil:    params.LockedUntil,
+		DateModified:   params.DateModified,
+		UserID:         params.UserID,
+		FailedAttempts: params.Threshold,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// DeleteUserLockout clears the lockout state of a user.
+func (d Database) DeleteUserLockout(userID types.UserID) error {
+	queries := mdb.New(d.Connection)
+	return queries.DeleteUserLockout(d.Context, mdb.DeleteUserLockoutParams{UserID: userID})
+}
+
+// CountUserLockouts returns the number of stored lockout rows.
+func (d Database) CountUserLockouts() (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountUserLockouts(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user lockouts: %v", err)
+	}
+	return &c, nil
+}
+
+// QUERIES - Password History
+
+// CreatePasswordHistoryTable creates the password_history table and its index.
+func (d Database) CreatePasswordHistoryTable() error {
+	queries := mdb.New(d.Connection)
+	if err := queries.CreatePasswordHistoryTable(d.Context); err != nil {
+		return err
+	}
+	return queries.CreatePasswordHistoryIndexUser(d.Context)
+}
+
+// CreatePasswordHistory records a password hash for a user.
+func (d Database) CreatePasswordHistory(params CreatePasswordHistoryParams) error {
+	queries := mdb.New(d.Connection)
+	err := queries.CreatePasswordHistory(d.Context, mdb.CreatePasswordHistoryParams{
+		ID:          types.NewPasswordHistoryID(),
+		UserID:      params.UserID,
+		Hash:        params.Hash,
+		DateCreated: params.DateCreated,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create password history: %v", err)
+	}
+	return nil
+}
+
+// ListPasswordHistoryByUserID returns a user's previous password hashes, newest first.
+func (d Database) ListPasswordHistoryByUserID(userID types.UserID) (*[]PasswordHistory, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListPasswordHistoryByUserID(d.Context, mdb.ListPasswordHistoryByUserIDParams{UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list password history: %v", err)
+	}
+	res := []PasswordHistory{}
+	for _, v := range rows {
+		res = append(res, d.MapPasswordHistory(v))
+	}
+	return &res, nil
+}
+
+// DeletePasswordHistory removes a password history entry.
+func (d Database) DeletePasswordHistory(id types.PasswordHistoryID) error {
+	queries := mdb.New(d.Connection)
+	return queries.DeletePasswordHistory(d.Context, mdb.DeletePasswordHistoryParams{ID: id})
+}
+
+// CountPasswordHistory returns the number of stored password history entries.
+func (d Database) CountPasswordHistory() (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountPasswordHistory(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count password history: %v", err)
+	}
+	return &c, nil
+}
+
+///////////////////////////////
+// MYSQL
+//////////////////////////////
+
+// MAPS
+
+// MapUserLockout converts a sqlc-generated type to the wrapper type.
+func (d MysqlDatabase) MapUserLockout(a mdbm.UserLockouts) UserLockout {
+	return UserLockout{
+		UserID:         a.UserID,
+		FailedAttempts: a.FailedAttempts,
+		LockoutCount:   a.LockoutCount,
+		LockedUntil:    a.LockedUntil,
+		LastFailedAt:   a.LastFailedAt,
+		DateModified:   a.DateModified,
+	}
+}
+
+// MapPasswordHistory converts a sqlc-generated type to the wrapper type.
+func (d MysqlDatabase) MapPasswordHistory(a mdbm.PasswordHistory) PasswordHistory {
+	return PasswordHistory{
+		ID:          a.ID,
+		UserID:      a.UserID,
+		Hash:        a.Hash,
+		DateCreated: a.DateCreated,
+	}
+}
+
+// QUERIES - User Lockouts
+
+// CreateUserLockoutTable creates the user_lockouts table.
+func (d MysqlDatabase) CreateUserLockoutTable() error {
+	queries := mdbm.New(d.Connection)
+	return queries.CreateUserLockoutsTable(d.Context)
+}
+
+// GetUserLockout retrieves the lockout state of a user.
+func (d MysqlDatabase) GetUserLockout(userID types.UserID) (*UserLockout, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetUserLockout(d.Context, mdbm.GetUserLockoutParams{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	res := d.MapUserLockout(row)
+	return &res, nil
+}
+
+// ListUserLockouts returns the lockout state of every user with failed attempts
+// or a lockout, most recently changed first.
+func (d MysqlDatabase) ListUserLockouts() (*[]UserLockout, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListUserLockouts(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user lockouts: %v", err)
+	}
+	res := []UserLockout{}
+	for _, v := range rows {
+		res = append(res, d.MapUserLockout(v))
+	}
+	return &res, nil
+}
+
+// UpsertUserLockout stores the lockout state of a user, replacing any existing row.
+func (d MysqlDatabase) UpsertUserLockout(params UpsertUserLockoutParams) error {
+	queries := mdbm.New(d.Connection)
+	return queries.UpsertUserLockout(d.Context, mdbm.UpsertUserLockoutParams{
+		UserID:         params.UserID,
+		FailedAttempts: params.FailedAttempts,
+		LockoutCount:   params.LockoutCount,
+		LockedUntil:    params.LockedUntil,
+		LastFailedAt:   params.LastFailedAt,
+		DateModified:   params.DateModified,
+	})
+}
+
+// IncrementUserLockout adds one failed attempt to the user's lockout row,
+// creating it if needed. The increment is a single statement, so concurrent
+// failures are all counted.
+func (d MysqlDatabase) IncrementUserLockout(params IncrementUserLockoutParams) error {
+	queries := mdbm.New(d.Connection)
+	return queries.IncrementUserLockout(d.Context, mdbm.IncrementUserLockoutParams{
+		UserID:       params.UserID,
+		LastFailedAt: params.LastFailedAt,
+		DateModified: params.DateModified,
+	})
+}
+
+// LockUserLockout locks the user until params.LockedUntil, resetting the
+// failed attempt count, if the count has reached params.Threshold. It reports
+// whether this call applied the lock.
+func (d MysqlDatabase) LockUserLockout(params LockUserLockoutParams) (bool, error) {
+	queries := mdbm.New(d.Connection)
+	n, err := queries.LockUserLockout(d.Context, mdbm.LockUserLockoutParams{
+		LockedUntil:    params.LockedUntil,
+		DateModified:   params.DateModified,
+		UserID:         params.UserID,
+		FailedAttempts: params.Threshold,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// DeleteUserLockout clears the lockout state of a user.
+func (d MysqlDatabase) DeleteUserLockout(userID types.UserID) error {
+	queries := mdbm.New(d.Connection)
+	return queries.DeleteUserLockout(d.Context, mdbm.DeleteUserLockoutParams{UserID: userID})
+}
+
+// CountUserLockouts returns the number of stored lockout rows.
+func (d MysqlDatabase) CountUserLockouts() (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountUserLockouts(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user lockouts: %v", err)
+	}
+	return &c, nil
+}
+
+// QUERIES - Password History
+
+// CreatePasswordHistoryTable creates the password_history table. MySQL has no
+// CREATE INDEX IF NOT EXISTS, and InnoDB already indexes the user_id foreign key,
+// so the explicit index is not created here.
+func (d MysqlDatabase) CreatePasswordHistoryTable() error {
+	queries := mdbm.New(d.Connection)
+	return queries.CreatePasswordHistoryTable(d.Context)
+}
+
+// CreatePasswordHistory records a password hash for a user.
+func (d MysqlDatabase) CreatePasswordHistory(params CreatePasswordHistoryParams) error {
+	queries := mdbm.New(d.Connection)
+	err := queries.CreatePasswordHistory(d.Context, mdbm.CreatePasswordHistoryParams{
+		ID:          types.NewPasswordHistoryID(),
+		UserID:      params.UserID,
+		Hash:        params.Hash,
+		DateCreated: params.DateCreated,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create password history: %v", err)
+	}
+	return nil
+}
+
+// ListPasswordHistoryByUserID returns a user's previous password hashes, newest first.
+func (d MysqlDatabase) ListPasswordHistoryByUserID(userID types.UserID) (*[]PasswordHistory, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListPasswordHistoryByUserID(d.Context, mdbm.ListPasswordHistoryByUserIDParams{UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list password history: %v", err)
+	}
+	res := []PasswordHistory{}
+	for _, v := range rows {
+		res = append(res, d.MapPasswordHistory(v))
+	}
+	return &res, nil
+}
+
+// DeletePasswordHistory removes a password history entry.
+func (d MysqlDatabase) DeletePasswordHistory(id types.PasswordHistoryID) error {
+	queries := mdbm.New(d.Connection)
+	return queries.DeletePasswordHistory(d.Context, mdbm.DeletePasswordHistoryParams{ID: id})
+}
+
+// CountPasswordHistory returns the number of stored password history entries.
+func (d MysqlDatabase) CountPasswordHistory() (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountPasswordHistory(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count password history: %v", err)
+	}
+	return &c, nil
+}
+
+///////////////////////////////
+// POSTGRES
+//////////////////////////////
+
+// MAPS
+
+// MapUserLockout converts a sqlc-generated type to the wrapper type.
+func (d PsqlDatabase) MapUserLockout(a mdbp.UserLockouts) UserLockout {
+	return UserLockout{
+		UserID:         a.UserID,
+		FailedAttempts: a.FailedAttempts,
+		LockoutCount:   a.LockoutCount,
+		LockedUntil:    a.LockedUntil,
+		LastFailedAt:   a.LastFailedAt,
+		DateModified:   a.DateModified,
+	}
+}
+
+// MapPasswordHistory converts a sqlc-generated type to the wrapper type.
+func (d PsqlDatabase) MapPasswordHistory(a mdbp.PasswordHistory) PasswordHistory {
+	return PasswordHistory{
+		ID:          a.ID,
+		UserID:      a.UserID,
+		Hash:        a.Hash,
+		DateCreated: a.DateCreated,
+	}
+}
+
+// QUERIES - User Lockouts
+
+// CreateUserLockoutTable creates the user_lockouts table.
+func (d PsqlDatabase) CreateUserLockoutTable() error {
+	queries := mdbp.New(d.Connection)
+	return queries.CreateUserLockoutsTable(d.Context)
+}
+
+// GetUserLockout retrieves the lockout state of a user.
+func (d PsqlDatabase) GetUserLockout(userID types.UserID) (*UserLockout, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetUserLockout(d.Context, mdbp.GetUserLockoutParams{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	res := d.MapUserLockout(row)
+	return &res, nil
+}
+
+// ListUserLockouts returns the lockout state of every user with failed attempts
+// or a lockout, most recently changed first.
+func (d PsqlDatabase) ListUserLockouts() (*[]UserLockout, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListUserLockouts(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user lockouts: %v", err)
+	}
+	res := []UserLockout{}
+	for _, v := range rows {
+		res = append(res, d.MapUserLockout(v))
+	}
+	return &res, nil
+}
+
+// UpsertUserLockout stores the lockout state of a user, replacing any existing row.
+func (d PsqlDatabase) UpsertUserLockout(params UpsertUserLockoutParams) error {
+	queries := mdbp.New(d.Connection)
+	return queries.UpsertUserLockout(d.Context, mdbp.UpsertUserLockoutParams{
+		UserID:         params.UserID,
+		FailedAttempts: params.FailedAttempts,
+		LockoutCount:   params.LockoutCount,
+		LockedUntil:    params.LockedUntil,
+		LastFailedAt:   params.LastFailedAt,
+		DateModified:   params.DateModified,
+	})
+}
+
+// IncrementUserLockout adds one failed attempt to the user's lockout row,
+// creating it if needed. The increment is a single statement, so concurrent
+// failures are all counted.
+func (d PsqlDatabase) IncrementUserLockout(params IncrementUserLockoutParams) error {
+	queries := mdbp.New(d.Connection)
+	return queries.IncrementUserLockout(d.Context, mdbp.IncrementUserLockoutParams{
+		UserID:       params.UserID,
+		LastFailedAt: params.LastFailedAt,
+		DateModified: params.DateModified,
+	})
+}
+
+// LockUserLockout locks the user until params.LockedUntil, resetting the
+// failed attempt count, if the count has reached params.Threshold. It reports
+// whether this call applied the lock.
+func (d PsqlDatabase) LockUserLockout(params LockUserLockoutParams) (bool, error) {
+	queries := mdbp.New(d.Connection)
+	n, err := queries.LockUserLockout(d.Context, mdbp.LockUserLockoutParams{
+		LockedUntil:    params.LockedUntil,
+		DateModified:   params.DateModified,
+		UserID:         params.UserID,
+		FailedAttempts: params.Threshold,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// DeleteUserLockout clears the lockout state of a user.
+func (d PsqlDatabase) DeleteUserLockout(userID types.UserID) error {
+	queries := mdbp.New(d.Connection)
+	return queries.DeleteUserLockout(d.Context, mdbp.DeleteUserLockoutParams{UserID: userID})
+}
+
+// CountUserLockouts returns the number of stored lockout rows.
+func (d PsqlDatabase) CountUserLockouts() (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountUserLockouts(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user lockouts: %v", err)
+	}
+	return &c, nil
+}
+
+// QUERIES - Password History
+
+// CreatePasswordHistoryTable creates the password_history table and its index.
+func (d PsqlDatabase) CreatePasswordHistoryTable() error {
+	queries := mdbp.New(d.Connection)
+	if err := queries.CreatePasswordHistoryTable(d.Context); err != nil {
+		return err
+	}
+	return queries.CreatePasswordHistoryIndexUser(d.Context)
+}
+
+// CreatePasswordHistory records a password hash for a user.
+func (d PsqlDatabase) CreatePasswordHistory(params CreatePasswordHistoryParams) error {
+	queries := mdbp.New(d.Connection)
+	err := queries.CreatePasswordHistory(d.Context, mdbp.CreatePasswordHistoryParams{
+		ID:          types.NewPasswordHistoryID(),
+		UserID:      params.UserID,
+		Hash:        params.Hash,
+		DateCreated: params.DateCreated,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create password history: %v", err)
+	}
+	return nil
+}
+
+// ListPasswordHistoryByUserID returns a user's previous password hashes, newest first.
+func (d PsqlDatabase) ListPasswordHistoryByUserID(userID types.UserID) (*[]PasswordHistory, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListPasswordHistoryByUserID(d.Context, mdbp.ListPasswordHistoryByUserIDParams{UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list password history: %v", err)
+	}
+	res := []PasswordHistory{}
+	for _, v := range rows {
+		res = append(res, d.MapPasswordHistory(v))
+	}
+	return &res, nil
+}
+
+// DeletePasswordHistory removes a password history entry.
+func (d PsqlDatabase) DeletePasswordHistory(id types.PasswordHistoryID) error {
+	queries := mdbp.New(d.Connection)
+	return queries.DeletePasswordHistory(d.Context, mdbp.DeletePasswordHistoryParams{ID: id})
+}
+
+// CountPasswordHistory returns the number of stored password history entries.
+func (d PsqlDatabase) CountPasswordHistory() (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountPasswordHistory(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count password history: %v", err)
+	}
+	return &c, nil
+}
@@ -0,0 +1,179 @@
+// Integration tests for the user_lockouts and password_history CRUD
+// lifecycles. Uses testSeededDB for the user foreign key.
+//
+// Both tables are NON-audited (no ctx/ac parameters on mutations).
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+func TestDatabase_CRUD_UserLockout(t *testing.T) {
+	t.Parallel()
+	d, seed := testSeededDB(t)
+	userID := seed.User.UserID
+	now := time.Now().UTC().Truncate(time.Second)
+
+	if _, err := d.GetUserLockout(userID); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("GetUserLockout before upsert: err = %v, want sql.ErrNoRows", err)
+	}
+
+	// --- Upsert: insert ---
+	err := d.UpsertUserLockout(UpsertUserLockoutParams{
+		UserID:         userID,
+		FailedAttempts: 1,
+		LastFailedAt:   types.NewTimestamp(now),
+		DateModified:   types.NewTimestamp(now),
+	})
+	if err != nil {
+		t.Fatalf("UpsertUserLockout (insert): %v", err)
+	}
+
+	got, err := d.GetUserLockout(userID)
+	if err != nil {
+		t.Fatalf("GetUserLockout: %v", err)
+	}
+	if got.FailedAttempts != 1 {
+		t.Errorf("FailedAttempts = %d, want 1", got.FailedAttempts)
+	}
+	if got.IsLocked(now) {
+		t.Error("IsLocked = true before locked_until is set")
+	}
+
+	// --- Upsert: update ---
+	until := now.Add(15 * time.Minute)
+	err = d.UpsertUserLockout(UpsertUserLockoutParams{
+		UserID:       userID,
+		LockoutCount: 1,
+		LockedUntil:  types.NewTimestamp(until),
+		LastFailedAt: types.NewTimestamp(now),
+		DateModified: types.NewTimestamp(now),
+	})
+	if err != nil {
+		t.Fatalf("UpsertUserLockout (update): %v", err)
+	}
+
+	got, err = d.GetUserLockout(userID)
+	if err != nil {
+		t.Fatalf("GetUserLockout after update: %v", err)
+	}
+	if got.FailedAttempts != 0 || got.LockoutCount != 1 {
+		t.Errorf("FailedAttempts, LockoutCount = %d, %d, want 0, 1", got.FailedAttempts, got.LockoutCount)
+	}
+	if !got.IsLocked(now) {
+		t.Error("IsLocked = false during lockout")
+	}
+	if got.IsLocked(until.Add(time.Second)) {
+		t.Error("IsLocked = true after locked_until")
+	}
+
+	list, err := d.ListUserLockouts()
+	if err != nil {
+		t.Fatalf("ListUserLockouts: %v", err)
+	}
+	if len(*list) != 1 {
+		t.Fatalf("ListUserLockouts len = %d, want 1", len(*list))
+	}
+
+	// --- Delete ---
+	if err := d.DeleteUserLockout(userID); err != nil {
+		t.Fatalf("DeleteUserLockout: %v", err)
+	}
+	count, err := d.CountUserLockouts()
+	if err != nil {
+		t.Fatalf("CountUserLockouts: %v", err)
+	}
+	if *count != 0 {
+		t.Errorf("CountUserLockouts after delete = %d, want 0", *count)
+	}
+}
+
+func TestDatabase_UserLockout_IncrementAndLock(t *testing.T) {
+	t.Parallel()
+	d, seed := testSeededDB(t)
+	userID := seed.User.UserID
+	now := types.NewTimestamp(time.Now().UTC().Truncate(time.Second))
+	until := types.NewTimestamp(now.Time.Add(15 * time.Minute))
+	lock := LockUserLockoutParams{UserID: userID, Threshold: 3, LockedUntil: until, DateModified: now}
+
+	// The first increment inserts the row; later ones add to it.
+	for range 2 {
+		if err := d.IncrementUserLockout(IncrementUserLockoutParams{UserID: userID, LastFailedAt: now, DateModified: now}); err != nil {
+			t.Fatalf("IncrementUserLockout: %v", err)
+		}
+	}
+	got, err := d.GetUserLockout(userID)
+	if err != nil {
+		t.Fatalf("GetUserLockout: %v", err)
+	}
+	if got.FailedAttempts != 2 {
+		t.Errorf("FailedAttempts = %d, want 2", got.FailedAttempts)
+	}
+
+	if locked, err := d.LockUserLockout(lock); err != nil || locked {
+		t.Fatalf("LockUserLockout below threshold = %v, %v, want false", locked, err)
+	}
+	if err := d.IncrementUserLockout(IncrementUserLockoutParams{UserID: userID, LastFailedAt: now, DateModified: now}); err != nil {
+		t.Fatalf("IncrementUserLockout: %v", err)
+	}
+	if locked, err := d.LockUserLockout(lock); err != nil || !locked {
+		t.Fatalf("LockUserLockout at threshold = %v, %v, want true", locked, err)
+	}
+	// A second caller racing on the same threshold does not lock again.
+	if locked, err := d.LockUserLockout(lock); err != nil || locked {
+		t.Fatalf("repeated LockUserLockout = %v, %v, want false", locked, err)
+	}
+
+	got, err = d.GetUserLockout(userID)
+	if err != nil {
+		t.Fatalf("GetUserLockout after lock: %v", err)
+	}
+	if got.FailedAttempts != 0 || got.LockoutCount != 1 || !got.IsLocked(now.Time) {
+		t.Errorf("lockout after lock = %+v, want 0 attempts, count 1, locked", got)
+	}
+}
+
+func TestDatabase_CRUD_PasswordHistory(t *testing.T) {
+	t.Parallel()
+	d, seed := testSeededDB(t)
+	userID := seed.User.UserID
+	base := time.Now().UTC().Truncate(time.Second)
+
+	for i, hash := range []string{"hash-1", "hash-2", "hash-3"} {
+		err := d.CreatePasswordHistory(CreatePasswordHistoryParams{
+			UserID:      userID,
+			Hash:        hash,
+			DateCreated: types.NewTimestamp(base.Add(time.Duration(i) * time.Minute)),
+		})
+		if err != nil {
+			t.Fatalf("CreatePasswordHistory(%s): %v", hash, err)
+		}
+	}
+
+	history, err := d.ListPasswordHistoryByUserID(userID)
+	if err != nil {
+		t.Fatalf("ListPasswordHistoryByUserID: %v", err)
+	}
+	if len(*history) != 3 {
+		t.Fatalf("history len = %d, want 3", len(*history))
+	}
+	if (*history)[0].Hash != "hash-3" {
+		t.Errorf("newest entry = %q, want %q", (*history)[0].Hash, "hash-3")
+	}
+
+	if err := d.DeletePasswordHistory((*history)[2].ID); err != nil {
+		t.Fatalf("DeletePasswordHistory: %v", err)
+	}
+	count, err := d.CountPasswordHistory()
+	if err != nil {
+		t.Fatalf("CountPasswordHistory: %v", err)
+	}
+	if *count != 2 {
+		t.Errorf("CountPasswordHistory = %d, want 2", *count)
+	}
+}
@@ -75,15 +75,20 @@ func (d Database) MapUserOauth(a mdb.UserOauth) UserOauth {
 		UserID:              a.UserID,
 		OauthProvider:       a.OauthProvider,
 		OauthProviderUserID: a.OAuthProviderUserID,
-		AccessToken:         a.AccessToken,
-		RefreshToken:        a.RefreshToken,
+		AccessToken:         openColumn(a.AccessToken),
+		RefreshToken:        openColumn(a.RefreshToken),
 		TokenExpiresAt:      a.TokenExpiresAt,
 		DateCreated:         a.DateCreated,
 	}
 }
 
 // MapCreateUserOauthParams converts wrapper params to sqlc-generated SQLite params.
-func (d Database) MapCreateUserOauthParams(a CreateUserOauthParams) mdb.CreateUserOauthParams {
+// Encrypted fields are sealed; a sealing failure is returned rather than
+// writing the plaintext.
+func (d Database) MapCreateUserOauthParams(a CreateUserOauthParams) (mdb.CreateUserOauthParams, error) {
+	if err := sealColumns(&a.AccessToken, &a.RefreshToken); err != nil {
+		return mdb.CreateUserOauthParams{}, err
+	}
 	return mdb.CreateUserOauthParams{
 		UserOAuthID:         types.NewUserOauthID(),
 		UserID:              a.UserID,
@@ -93,17 +98,22 @@ func (d Database) MapCreateUserOauthParams(a CreateUserOauthParams) mdb.CreateUs
 		RefreshToken:        a.RefreshToken,
 		TokenExpiresAt:      a.TokenExpiresAt,
 		DateCreated:         a.DateCreated,
-	}
+	}, nil
 }
 
 // MapUpdateUserOauthParams converts wrapper params to sqlc-generated SQLite params.
-func (d Database) MapUpdateUserOauthParams(a UpdateUserOauthParams) mdb.UpdateUserOauthParams {
+// Encrypted fields are sealed; a sealing failure is returned rather than
+// writing the plaintext.
+func (d Database) MapUpdateUserOauthParams(a UpdateUserOauthParams) (mdb.UpdateUserOauthParams, error) {
+	if err := sealColumns(&a.AccessToken, &a.RefreshToken); err != nil {
+		return mdb.UpdateUserOauthParams{}, err
+	}
 	return mdb.UpdateUserOauthParams{
 		AccessToken:    a.AccessToken,
 		RefreshToken:   a.RefreshToken,
 		TokenExpiresAt: a.TokenExpiresAt,
 		UserOAuthID:    a.UserOauthID,
-	}
+	}, nil
 }
 
 // QUERIES
@@ -202,15 +212,20 @@ func (d MysqlDatabase) MapUserOauth(a mdbm.UserOauth) UserOauth {
 		UserID:              a.UserID,
 		OauthProvider:       a.OauthProvider,
 		OauthProviderUserID: a.OAuthProviderUserID,
-		AccessToken:         a.AccessToken,
-		RefreshToken:        a.RefreshToken,
+		AccessToken:         openColumn(a.AccessToken),
+		RefreshToken:        openColumn(a.RefreshToken),
 		TokenExpiresAt:      a.TokenExpiresAt,
 		DateCreated:         a.DateCreated,
 	}
 }
 
 // MapCreateUserOauthParams converts wrapper params to sqlc-generated MySQL params.
-func (d MysqlDatabase) MapCreateUserOauthParams(a CreateUserOauthParams) mdbm.CreateUserOauthParams {
+// Encrypted fields are sealed; a sealing failure is returned rather than
+// writing the plaintext.
+func (d MysqlDatabase) MapCreateUserOauthParams(a CreateUserOauthParams) (mdbm.CreateUserOauthParams, error) {
+	if err := sealColumns(&a.AccessToken, &a.RefreshToken); err != nil {
+		return mdbm.CreateUserOauthParams{}, err
+	}
 	return mdbm.CreateUserOauthParams{
 		UserOAuthID:         types.NewUserOauthID(),
 		UserID:              a.UserID,
@@ -220,17 +235,22 @@ func (d MysqlDatabase) MapCreateUserOauthParams(a CreateUserOauthParams) mdbm.Cr
 		RefreshToken:        a.RefreshToken,
 		TokenExpiresAt:      a.TokenExpiresAt,
 		DateCreated:         a.DateCreated,
-	}
+	}, nil
 }
 
 // MapUpdateUserOauthParams converts wrapper params to sqlc-generated MySQL params.
-func (d MysqlDatabase) MapUpdateUserOauthParams(a UpdateUserOauthParams) mdbm.UpdateUserOauthParams {
+// Encrypted fields are sealed; a sealing failure is returned rather than
+// writing the plaintext.
+func (d MysqlDatabase) MapUpdateUserOauthParams(a UpdateUserOauthParams) (mdbm.UpdateUserOauthParams, error) {
+	if err := sealColumns(&a.AccessToken, &a.RefreshToken); err != nil {
+		return mdbm.UpdateUserOauthParams{}, err
+	}
 	return mdbm.UpdateUserOauthParams{
 		AccessToken:    a.AccessToken,
 		RefreshToken:   a.RefreshToken,
 		TokenExpiresAt: a.TokenExpiresAt,
 		UserOAuthID:    a.UserOauthID,
-	}
+	}, nil
 }
 
 // QUERIES
@@ -329,15 +349,20 @@ func (d PsqlDatabase) MapUserOauth(a mdbp.UserOauth) UserOauth {
 		UserID:              a.UserID,
 		OauthProvider:       a.OauthProvider,
 		OauthProviderUserID: a.OAuthProviderUserID,
-		AccessToken:         a.AccessToken,
-		RefreshToken:        a.RefreshToken,
+		AccessToken:         openColumn(a.AccessToken),
+		RefreshToken:        openColumn(a.RefreshToken),
 		TokenExpiresAt:      a.TokenExpiresAt,
 		DateCreated:         a.DateCreated,
 	}
 }
 
 // MapCreateUserOauthParams converts wrapper params to sqlc-generated PostgreSQL params.
-func (d PsqlDatabase) MapCreateUserOauthParams(a CreateUserOauthParams) mdbp.CreateUserOauthParams {
+// Encrypted fields are sealed; a sealing failure is returned rather than
+// writing the plaintext.
+func (d PsqlDatabase) MapCreateUserOauthParams(a CreateUserOauthParams) (mdbp.CreateUserOauthParams, error) {
+	if err := sealColumns(&a.AccessToken, &a.RefreshToken); err != nil {
+		return mdbp.CreateUserOauthParams{}, err
+	}
 	return mdbp.CreateUserOauthParams{
 		UserOAuthID:         types.NewUserOauthID(),
 		UserID:              a.UserID,
@@ -347,17 +372,22 @@ func (d PsqlDatabase) MapCreateUserOauthParams(a CreateUserOauthParams) mdbp.Cre
 		RefreshToken:        a.RefreshToken,
 		TokenExpiresAt:      a.TokenExpiresAt,
 		DateCreated:         a.DateCreated,
-	}
+	}, nil
 }
 
 // MapUpdateUserOauthParams converts wrapper params to sqlc-generated PostgreSQL params.
-func (d PsqlDatabase) MapUpdateUserOauthParams(a UpdateUserOauthParams) mdbp.UpdateUserOauthParams {
+// Encrypted fields are sealed; a sealing failure is returned rather than
+// writing the plaintext.
+func (d PsqlDatabase) MapUpdateUserOauthParams(a UpdateUserOauthParams) (mdbp.UpdateUserOauthParams, error) {
+	if err := sealColumns(&a.AccessToken, &a.RefreshToken); err != nil {
+		return mdbp.UpdateUserOauthParams{}, err
+	}
 	return mdbp.UpdateUserOauthParams{
 		AccessToken:    a.AccessToken,
 		RefreshToken:   a.RefreshToken,
 		TokenExpiresAt: a.TokenExpiresAt,
 		UserOAuthID:    a.UserOauthID,
-	}
+	}, nil
 }
 
 // QUERIES
@@ -454,6 +484,7 @@ type NewUserOauthCmd struct {
 	params   CreateUserOauthParams
 	conn     *sql.DB
 	recorder audited.ChangeEventRecorder
+	sealErr  error
 }
 
 // Context returns the command context.
@@ -479,6 +510,9 @@ func (c NewUserOauthCmd) GetID(u mdb.UserOauth) string { return string(u.UserOAu
 
 // Execute creates the userOauth in the database.
 func (c NewUserOauthCmd) Execute(ctx context.Context, tx audited.DBTX) (mdb.UserOauth, error) {
+	if c.sealErr != nil {
+		return mdb.UserOauth{}, c.sealErr
+	}
 	queries := mdb.New(tx)
 	return queries.CreateUserOauth(ctx, mdb.CreateUserOauthParams{
 		UserOAuthID:         types.NewUserOauthID(),
@@ -494,7 +528,9 @@ func (c NewUserOauthCmd) Execute(ctx context.Context, tx audited.DBTX) (mdb.User
 
 // NewUserOauthCmd creates a command for inserting a userOauth.
 func (d Database) NewUserOauthCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserOauthParams) NewUserOauthCmd {
-	return NewUserOauthCmd{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: SQLiteRecorder}
+	// A sealing failure is held and returned by Execute so the plaintext is never written.
+	sealErr := sealColumns(&params.AccessToken, &params.RefreshToken)
+	return NewUserOauthCmd{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: SQLiteRecorder, sealErr: sealErr}
 }
 
 // ----- SQLite UPDATE -----
@@ -506,6 +542,7 @@ type UpdateUserOauthCmd struct {
 	params   UpdateUserOauthParams
 	conn     *sql.DB
 	recorder audited.ChangeEventRecorder
+	sealErr  error
 }
 
 // Context returns the command context.
@@ -537,6 +574,9 @@ func (c UpdateUserOauthCmd) GetBefore(ctx context.Context, tx audited.DBTX) (mdb
 
 // Execute updates the userOauth in the database.
 func (c UpdateUserOauthCmd) Execute(ctx context.Context, tx audited.DBTX) error {
+	if c.sealErr != nil {
+		return c.sealErr
+	}
 	queries := mdb.New(tx)
 	return queries.UpdateUserOauth(ctx, mdb.UpdateUserOauthParams{
 		AccessToken:    c.params.AccessToken,
@@ -548,7 +588,9 @@ func (c UpdateUserOauthCmd) Execute(ctx context.Context, tx audited.DBTX) error
 
 // UpdateUserOauthCmd creates a command for updating a userOauth.
 func (d Database) UpdateUserOauthCmd(ctx context.Context, auditCtx audited.AuditContext, params UpdateUserOauthParams) UpdateUserOauthCmd {
-	return UpdateUserOauthCmd{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: SQLiteRecorder}
+	// A sealing failure is held and returned by Execute so the plaintext is never written.
+	sealErr := sealColumns(&params.AccessToken, &params.RefreshToken)
+	return UpdateUserOauthCmd{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: SQLiteRecorder, sealErr: sealErr}
 }
 
 // ----- SQLite DELETE -----
@@ -606,6 +648,7 @@ type NewUserOauthCmdMysql struct {
 	params   CreateUserOauthParams
 	conn     *sql.DB
 	recorder audited.ChangeEventRecorder
+	sealErr  error
 }
 
 // Context returns the command context.
@@ -631,6 +674,9 @@ func (c NewUserOauthCmdMysql) GetID(u mdbm.UserOauth) string { return string(u.U
 
 // Execute creates the userOauth in the database.
 func (c NewUserOauthCmdMysql) Execute(ctx context.Context, tx audited.DBTX) (mdbm.UserOauth, error) {
+	if c.sealErr != nil {
+		return mdbm.UserOauth{}, c.sealErr
+	}
 	queries := mdbm.New(tx)
 	params := mdbm.CreateUserOauthParams{
 		UserOAuthID:         types.NewUserOauthID(),
@@ -650,7 +696,9 @@ func (c NewUserOauthCmdMysql) Execute(ctx context.Context, tx audited.DBTX) (mdb
 
 // NewUserOauthCmd creates a command for inserting a userOauth.
 func (d MysqlDatabase) NewUserOauthCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserOauthParams) NewUserOauthCmdMysql {
-	return NewUserOauthCmdMysql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: MysqlRecorder}
+	// A sealing failure is held and returned by Execute so the plaintext is never written.
+	sealErr := sealColumns(&params.AccessToken, &params.RefreshToken)
+	return NewUserOauthCmdMysql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: MysqlRecorder, sealErr: sealErr}
 }
 
 // ----- MySQL UPDATE -----
@@ -662,6 +710,7 @@ type UpdateUserOauthCmdMysql struct {
 	params   UpdateUserOauthParams
 	conn     *sql.DB
 	recorder audited.ChangeEventRecorder
+	sealErr  error
 }
 
 // Context returns the command context.
@@ -693,6 +742,9 @@ func (c UpdateUserOauthCmdMysql) GetBefore(ctx context.Context, tx audited.DBTX)
 
 // Execute updates the userOauth in the database.
 func (c UpdateUserOauthCmdMysql) Execute(ctx context.Context, tx audited.DBTX) error {
+	if c.sealErr != nil {
+		return c.sealErr
+	}
 	queries := mdbm.New(tx)
 	return queries.UpdateUserOauth(ctx, mdbm.UpdateUserOauthParams{
 		AccessToken:    c.params.AccessToken,
@@ -704,7 +756,9 @@ func (c UpdateUserOauthCmdMysql) Execute(ctx context.Context, tx audited.DBTX) e
 
 // UpdateUserOauthCmd creates a command for updating a userOauth.
 func (d MysqlDatabase) UpdateUserOauthCmd(ctx context.Context, auditCtx audited.AuditContext, params UpdateUserOauthParams) UpdateUserOauthCmdMysql {
-	return UpdateUserOauthCmdMysql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: MysqlRecorder}
+	// A sealing failure is held and returned by Execute so the plaintext is never written.
+	sealErr := sealColumns(&params.AccessToken, &params.RefreshToken)
+	return UpdateUserOauthCmdMysql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: MysqlRecorder, sealErr: sealErr}
 }
 
 // ----- MySQL DELETE -----
@@ -762,6 +816,7 @@ type NewUserOauthCmdPsql struct {
 	params   CreateUserOauthParams
 	conn     *sql.DB
 	recorder audited.ChangeEventRecorder
+	sealErr  error
 }
 
 // Context returns the command context.
@@ -787,6 +842,9 @@ func (c NewUserOauthCmdPsql) GetID(u mdbp.UserOauth) string { return string(u.Us
 
 // Execute creates the userOauth in the database.
 func (c NewUserOauthCmdPsql) Execute(ctx context.Context, tx audited.DBTX) (mdbp.UserOauth, error) {
+	if c.sealErr != nil {
+		return mdbp.UserOauth{}, c.sealErr
+	}
 	queries := mdbp.New(tx)
 	return queries.CreateUserOauth(ctx, mdbp.CreateUserOauthParams{
 		UserOAuthID:         types.NewUserOauthID(),
@@ -802,7 +860,9 @@ func (c NewUserOauthCmdPsql) Execute(ctx context.Context, tx audited.DBTX) (mdbp
 
 // NewUserOauthCmd creates a command for inserting a userOauth.
 func (d PsqlDatabase) NewUserOauthCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserOauthParams) NewUserOauthCmdPsql {
-	return NewUserOauthCmdPsql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: PsqlRecorder}
+	// A sealing failure is held and returned by Execute so the plaintext is never written.
+	sealErr := sealColumns(&params.AccessToken, &params.RefreshToken)
+	return NewUserOauthCmdPsql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: PsqlRecorder, sealErr: sealErr}
 }
 
 // ----- PostgreSQL UPDATE -----
@@ -814,6 +874,7 @@ type UpdateUserOauthCmdPsql struct {
 	params   UpdateUserOauthParams
 	conn     *sql.DB
 	recorder audited.ChangeEventRecorder
+	sealErr  error
 }
 
 // Context returns the command context.
@@ -845,6 +906,9 @@ func (c UpdateUserOauthCmdPsql) GetBefore(ctx context.Context, tx audited.DBTX)
 
 // Execute updates the userOauth in the database.
 func (c UpdateUserOauthCmdPsql) Execute(ctx context.Context, tx audited.DBTX) error {
+	if c.sealErr != nil {
+		return c.sealErr
+	}
 	queries := mdbp.New(tx)
 	return queries.UpdateUserOauth(ctx, mdbp.UpdateUserOauthParams{
 		AccessToken:    c.params.AccessToken,
@@ -856,7 +920,9 @@ func (c UpdateUserOauthCmdPsql) Execute(ctx context.Context, tx audited.DBTX) er
 
 // UpdateUserOauthCmd creates a command for updating a userOauth.
 func (d PsqlDatabase) UpdateUserOauthCmd(ctx context.Context, auditCtx audited.AuditContext, params UpdateUserOauthParams) UpdateUserOauthCmdPsql {
-	return UpdateUserOauthCmdPsql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: PsqlRecorder}
+	// A sealing failure is held and returned by Execute so the plaintext is never written.
+	sealErr := sealColumns(&params.AccessToken, &params.RefreshToken)
+	return UpdateUserOauthCmdPsql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: PsqlRecorder, sealErr: sealErr}
 }
 
 // ----- PostgreSQL DELETE -----
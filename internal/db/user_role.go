@@ -0,0 +1,657 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+///////////////////////////////
+// STRUCTS
+//////////////////////////////
+
+// UserRoles represents an assignment of a role to a user.
+type UserRoles struct {
+	ID     types.UserRoleID `json:"id"`
+	UserID types.UserID     `json:"user_id"`
+	RoleID types.RoleID     `json:"role_id"`
+}
+
+// CreateUserRoleParams contains parameters for creating a user-role assignment.
+type CreateUserRoleParams struct {
+	UserID types.UserID `json:"user_id"`
+	RoleID types.RoleID `json:"role_id"`
+}
+
+///////////////////////////////
+// SQLITE
+//////////////////////////////
+
+// MAPS
+
+// MapUserRole converts a sqlc-generated SQLite UserRoles type to the wrapper type.
+func (d Database) MapUserRole(a mdb.UserRoles) UserRoles {
+	return UserRoles{
+		ID:     a.ID,
+		UserID: a.UserID,
+		RoleID: a.RoleID,
+	}
+}
+
+// QUERIES
+
+// CountUserRoles returns the total count of user-role assignments.
+func (d Database) CountUserRoles() (*int64, error) {
+	queries := mdb.New(d.Connection)
+	c, err := queries.CountUserRole(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user roles: %w", err)
+	}
+	return &c, nil
+}
+
+// CreateUserRolesTable creates the user_roles table.
+func (d Database) CreateUserRolesTable() error {
+	queries := mdb.New(d.Connection)
+	err := queries.CreateUserRolesTable(d.Context)
+	return err
+}
+
+// CreateUserRole inserts a new user-role assignment and records an audit event.
+func (d Database) CreateUserRole(ctx context.Context, ac audited.AuditContext, s CreateUserRoleParams) (*UserRoles, error) {
+	cmd := d.NewUserRoleCmd(ctx, ac, s)
+	result, err := audited.Create(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user role: %w", err)
+	}
+	r := d.MapUserRole(result)
+	return &r, nil
+}
+
+// DeleteUserRole removes a user-role assignment and records an audit event.
+func (d Database) DeleteUserRole(ctx context.Context, ac audited.AuditContext, id types.UserRoleID) error {
+	cmd := d.DeleteUserRoleCmd(ctx, ac, id)
+	return audited.Delete(cmd)
+}
+
+// DeleteUserRolesByUserID removes all user-role assignments for a given user.
+// This is a non-audited bulk delete used when replacing a user's role set.
+func (d Database) DeleteUserRolesByUserID(ctx context.Context, ac audited.AuditContext, userID types.UserID) error {
+	queries := mdb.New(d.Connection)
+	return queries.DeleteUserRoleByUserID(d.Context, mdb.DeleteUserRoleByUserIDParams{UserID: userID})
+}
+
+// GetUserRole retrieves a user-role assignment by ID.
+func (d Database) GetUserRole(id types.UserRoleID) (*UserRoles, error) {
+	queries := mdb.New(d.Connection)
+	row, err := queries.GetUserRole(d.Context, mdb.GetUserRoleParams{ID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user role: %w", err)
+	}
+	res := d.MapUserRole(row)
+	return &res, nil
+}
+
+// ListUserRoles returns all user-role assignments.
+func (d Database) ListUserRoles() (*[]UserRoles, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListUserRole(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles: %w", err)
+	}
+	res := []UserRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserRole(v))
+	}
+	return &res, nil
+}
+
+// ListUserRolesByUserID returns all user-role assignments for a given user.
+func (d Database) ListUserRolesByUserID(userID types.UserID) (*[]UserRoles, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListUserRoleByUserID(d.Context, mdb.ListUserRoleByUserIDParams{UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles by user id: %w", err)
+	}
+	res := []UserRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserRole(v))
+	}
+	return &res, nil
+}
+
+// ListUserRolesByRoleID returns all user-role assignments for a given role.
+func (d Database) ListUserRolesByRoleID(roleID types.RoleID) (*[]UserRoles, error) {
+	queries := mdb.New(d.Connection)
+	rows, err := queries.ListUserRoleByRoleID(d.Context, mdb.ListUserRoleByRoleIDParams{RoleID: roleID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles by role id: %w", err)
+	}
+	res := []UserRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserRole(v))
+	}
+	return &res, nil
+}
+
+///////////////////////////////
+// MYSQL
+//////////////////////////////
+
+// MAPS
+
+// MapUserRole converts a sqlc-generated MySQL UserRoles type to the wrapper type.
+func (d MysqlDatabase) MapUserRole(a mdbm.UserRoles) UserRoles {
+	return UserRoles{
+		ID:     a.ID,
+		UserID: a.UserID,
+		RoleID: a.RoleID,
+	}
+}
+
+// QUERIES
+
+// CountUserRoles returns the total count of user-role assignments.
+func (d MysqlDatabase) CountUserRoles() (*int64, error) {
+	queries := mdbm.New(d.Connection)
+	c, err := queries.CountUserRole(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user roles: %w", err)
+	}
+	return &c, nil
+}
+
+// CreateUserRolesTable creates the user_roles table.
+func (d MysqlDatabase) CreateUserRolesTable() error {
+	queries := mdbm.New(d.Connection)
+	err := queries.CreateUserRolesTable(d.Context)
+	return err
+}
+
+// CreateUserRole inserts a new user-role assignment and records an audit event.
+func (d MysqlDatabase) CreateUserRole(ctx context.Context, ac audited.AuditContext, s CreateUserRoleParams) (*UserRoles, error) {
+	cmd := d.NewUserRoleCmd(ctx, ac, s)
+	result, err := audited.Create(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user role: %w", err)
+	}
+	r := d.MapUserRole(result)
+	return &r, nil
+}
+
+// DeleteUserRole removes a user-role assignment and records an audit event.
+func (d MysqlDatabase) DeleteUserRole(ctx context.Context, ac audited.AuditContext, id types.UserRoleID) error {
+	cmd := d.DeleteUserRoleCmd(ctx, ac, id)
+	return audited.Delete(cmd)
+}
+
+// DeleteUserRolesByUserID removes all user-role assignments for a given user.
+// This is a non-audited bulk delete used when replacing a user's role set.
+func (d MysqlDatabase) DeleteUserRolesByUserID(ctx context.Context, ac audited.AuditContext, userID types.UserID) error {
+	queries := mdbm.New(d.Connection)
+	return queries.DeleteUserRoleByUserID(d.Context, mdbm.DeleteUserRoleByUserIDParams{UserID: userID})
+}
+
+// GetUserRole retrieves a user-role assignment by ID.
+func (d MysqlDatabase) GetUserRole(id types.UserRoleID) (*UserRoles, error) {
+	queries := mdbm.New(d.Connection)
+	row, err := queries.GetUserRole(d.Context, mdbm.GetUserRoleParams{ID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user role: %w", err)
+	}
+	res := d.MapUserRole(row)
+	return &res, nil
+}
+
+// ListUserRoles returns all user-role assignments.
+func (d MysqlDatabase) ListUserRoles() (*[]UserRoles, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListUserRole(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles: %w", err)
+	}
+	res := []UserRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserRole(v))
+	}
+	return &res, nil
+}
+
+// ListUserRolesByUserID returns all user-role assignments for a given user.
+func (d MysqlDatabase) ListUserRolesByUserID(userID types.UserID) (*[]UserRoles, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListUserRoleByUserID(d.Context, mdbm.ListUserRoleByUserIDParams{UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles by user id: %w", err)
+	}
+	res := []UserRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserRole(v))
+	}
+	return &res, nil
+}
+
+// ListUserRolesByRoleID returns all user-role assignments for a given role.
+func (d MysqlDatabase) ListUserRolesByRoleID(roleID types.RoleID) (*[]UserRoles, error) {
+	queries := mdbm.New(d.Connection)
+	rows, err := queries.ListUserRoleByRoleID(d.Context, mdbm.ListUserRoleByRoleIDParams{RoleID: roleID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles by role id: %w", err)
+	}
+	res := []UserRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserRole(v))
+	}
+	return &res, nil
+}
+
+///////////////////////////////
+// POSTGRES
+//////////////////////////////
+
+// MAPS
+
+// MapUserRole converts a sqlc-generated PostgreSQL UserRoles type to the wrapper type.
+func (d PsqlDatabase) MapUserRole(a mdbp.UserRoles) UserRoles {
+	return UserRoles{
+		ID:     a.ID,
+		UserID: a.UserID,
+		RoleID: a.RoleID,
+	}
+}
+
+// QUERIES
+
+// CountUserRoles returns the total count of user-role assignments.
+func (d PsqlDatabase) CountUserRoles() (*int64, error) {
+	queries := mdbp.New(d.Connection)
+	c, err := queries.CountUserRole(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user roles: %w", err)
+	}
+	return &c, nil
+}
+
+// CreateUserRolesTable creates the user_roles table and indexes.
+func (d PsqlDatabase) CreateUserRolesTable() error {
+	queries := mdbp.New(d.Connection)
+	err := queries.CreateUserRolesTable(d.Context)
+	if err != nil {
+		return err
+	}
+	err = queries.CreateUserRolesIndexUser(d.Context)
+	if err != nil {
+		return err
+	}
+	err = queries.CreateUserRolesIndexRole(d.Context)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateUserRole inserts a new user-role assignment and records an audit event.
+func (d PsqlDatabase) CreateUserRole(ctx context.Context, ac audited.AuditContext, s CreateUserRoleParams) (*UserRoles, error) {
+	cmd := d.NewUserRoleCmd(ctx, ac, s)
+	result, err := audited.Create(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user role: %w", err)
+	}
+	r := d.MapUserRole(result)
+	return &r, nil
+}
+
+// DeleteUserRole removes a user-role assignment and records an audit event.
+func (d PsqlDatabase) DeleteUserRole(ctx context.Context, ac audited.AuditContext, id types.UserRoleID) error {
+	cmd := d.DeleteUserRoleCmd(ctx, ac, id)
+	return audited.Delete(cmd)
+}
+
+// DeleteUserRolesByUserID removes all user-role assignments for a given user.
+// This is a non-audited bulk delete used when replacing a user's role set.
+func (d PsqlDatabase) DeleteUserRolesByUserID(ctx context.Context, ac audited.AuditContext, userID types.UserID) error {
+	queries := mdbp.New(d.Connection)
+	return queries.DeleteUserRoleByUserID(d.Context, mdbp.DeleteUserRoleByUserIDParams{UserID: userID})
+}
+
+// GetUserRole retrieves a user-role assignment by ID.
+func (d PsqlDatabase) GetUserRole(id types.UserRoleID) (*UserRoles, error) {
+	queries := mdbp.New(d.Connection)
+	row, err := queries.GetUserRole(d.Context, mdbp.GetUserRoleParams{ID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user role: %w", err)
+	}
+	res := d.MapUserRole(row)
+	return &res, nil
+}
+
+// ListUserRoles returns all user-role assignments.
+func (d PsqlDatabase) ListUserRoles() (*[]UserRoles, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListUserRole(d.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles: %w", err)
+	}
+	res := []UserRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserRole(v))
+	}
+	return &res, nil
+}
+
+// ListUserRolesByUserID returns all user-role assignments for a given user.
+func (d PsqlDatabase) ListUserRolesByUserID(userID types.UserID) (*[]UserRoles, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListUserRoleByUserID(d.Context, mdbp.ListUserRoleByUserIDParams{UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles by user id: %w", err)
+	}
+	res := []UserRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserRole(v))
+	}
+	return &res, nil
+}
+
+// ListUserRolesByRoleID returns all user-role assignments for a given role.
+func (d PsqlDatabase) ListUserRolesByRoleID(roleID types.RoleID) (*[]UserRoles, error) {
+	queries := mdbp.New(d.Connection)
+	rows, err := queries.ListUserRoleByRoleID(d.Context, mdbp.ListUserRoleByRoleIDParams{RoleID: roleID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles by role id: %w", err)
+	}
+	res := []UserRoles{}
+	for _, v := range rows {
+		res = append(res, d.MapUserRole(v))
+	}
+	return &res, nil
+}
+
+///////////////////////////////
+// AUDITED COMMAND STRUCTS
+//////////////////////////////
+
+// ===== SQLITE =====
+
+// NewUserRoleCmd is an audited command for creating a user-role assignment in SQLite.
+type NewUserRoleCmd struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateUserRoleParams
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c NewUserRoleCmd) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewUserRoleCmd) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewUserRoleCmd) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewUserRoleCmd) Recorder() audited.ChangeEventRecorder { return SQLiteRecorder }
+
+// TableName returns the table name.
+func (c NewUserRoleCmd) TableName() string { return "user_roles" }
+
+// Params returns the command parameters.
+func (c NewUserRoleCmd) Params() any { return c.params }
+
+// GetID returns the ID of a created user-role assignment.
+func (c NewUserRoleCmd) GetID(x mdb.UserRoles) string {
+	return x.ID.String()
+}
+
+// Execute creates a user-role assignment in the database.
+func (c NewUserRoleCmd) Execute(ctx context.Context, tx audited.DBTX) (mdb.UserRoles, error) {
+	queries := mdb.New(tx)
+	return queries.CreateUserRole(ctx, mdb.CreateUserRoleParams{
+		ID:     types.NewUserRoleID(),
+		UserID: c.params.UserID,
+		RoleID: c.params.RoleID,
+	})
+}
+
+// NewUserRoleCmd returns a new create command for a user-role assignment.
+func (d Database) NewUserRoleCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserRoleParams) NewUserRoleCmd {
+	return NewUserRoleCmd{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection}
+}
+
+// DeleteUserRoleCmd is an audited command for deleting a user-role assignment in SQLite.
+type DeleteUserRoleCmd struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	id       types.UserRoleID
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c DeleteUserRoleCmd) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c DeleteUserRoleCmd) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c DeleteUserRoleCmd) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c DeleteUserRoleCmd) Recorder() audited.ChangeEventRecorder { return SQLiteRecorder }
+
+// TableName returns the table name.
+func (c DeleteUserRoleCmd) TableName() string { return "user_roles" }
+
+// GetID returns the user-role ID being deleted.
+func (c DeleteUserRoleCmd) GetID() string { return c.id.String() }
+
+// GetBefore retrieves the user-role assignment before deletion.
+func (c DeleteUserRoleCmd) GetBefore(ctx context.Context, tx audited.DBTX) (mdb.UserRoles, error) {
+	queries := mdb.New(tx)
+	return queries.GetUserRole(ctx, mdb.GetUserRoleParams{ID: c.id})
+}
+
+// Execute deletes a user-role assignment from the database.
+func (c DeleteUserRoleCmd) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdb.New(tx)
+	return queries.DeleteUserRole(ctx, mdb.DeleteUserRoleParams{ID: c.id})
+}
+
+// DeleteUserRoleCmd returns a new delete command for a user-role assignment.
+func (d Database) DeleteUserRoleCmd(ctx context.Context, auditCtx audited.AuditContext, id types.UserRoleID) DeleteUserRoleCmd {
+	return DeleteUserRoleCmd{ctx: ctx, auditCtx: auditCtx, id: id, conn: d.Connection}
+}
+
+// ===== MYSQL =====
+
+// NewUserRoleCmdMysql is an audited command for creating a user-role assignment in MySQL.
+type NewUserRoleCmdMysql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateUserRoleParams
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c NewUserRoleCmdMysql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewUserRoleCmdMysql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewUserRoleCmdMysql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewUserRoleCmdMysql) Recorder() audited.ChangeEventRecorder { return MysqlRecorder }
+
+// TableName returns the table name.
+func (c NewUserRoleCmdMysql) TableName() string { return "user_roles" }
+
+// Params returns the command parameters.
+func (c NewUserRoleCmdMysql) Params() any { return c.params }
+
+// GetID returns the ID of a created user-role assignment.
+func (c NewUserRoleCmdMysql) GetID(x mdbm.UserRoles) string {
+	return x.ID.String()
+}
+
+// Execute creates a user-role assignment in the database.
+// MySQL uses :exec (no RETURNING), so we exec then fetch.
+func (c NewUserRoleCmdMysql) Execute(ctx context.Context, tx audited.DBTX) (mdbm.UserRoles, error) {
+	id := types.NewUserRoleID()
+	queries := mdbm.New(tx)
+	err := queries.CreateUserRole(ctx, mdbm.CreateUserRoleParams{
+		ID:     id,
+		UserID: c.params.UserID,
+		RoleID: c.params.RoleID,
+	})
+	if err != nil {
+		return mdbm.UserRoles{}, fmt.Errorf("failed to create user role: %w", err)
+	}
+	return queries.GetUserRole(ctx, mdbm.GetUserRoleParams{ID: id})
+}
+
+// NewUserRoleCmd returns a new create command for a user-role assignment.
+func (d MysqlDatabase) NewUserRoleCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserRoleParams) NewUserRoleCmdMysql {
+	return NewUserRoleCmdMysql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection}
+}
+
+// DeleteUserRoleCmdMysql is an audited command for deleting a user-role assignment in MySQL.
+type DeleteUserRoleCmdMysql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	id       types.UserRoleID
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c DeleteUserRoleCmdMysql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c DeleteUserRoleCmdMysql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c DeleteUserRoleCmdMysql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c DeleteUserRoleCmdMysql) Recorder() audited.ChangeEventRecorder { return MysqlRecorder }
+
+// TableName returns the table name.
+func (c DeleteUserRoleCmdMysql) TableName() string { return "user_roles" }
+
+// GetID returns the user-role ID being deleted.
+func (c DeleteUserRoleCmdMysql) GetID() string { return c.id.String() }
+
+// GetBefore retrieves the user-role assignment before deletion.
+func (c DeleteUserRoleCmdMysql) GetBefore(ctx context.Context, tx audited.DBTX) (mdbm.UserRoles, error) {
+	queries := mdbm.New(tx)
+	return queries.GetUserRole(ctx, mdbm.GetUserRoleParams{ID: c.id})
+}
+
+// Execute deletes a user-role assignment from the database.
+func (c DeleteUserRoleCmdMysql) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdbm.New(tx)
+	return queries.DeleteUserRole(ctx, mdbm.DeleteUserRoleParams{ID: c.id})
+}
+
+// DeleteUserRoleCmd returns a new delete command for a user-role assignment.
+func (d MysqlDatabase) DeleteUserRoleCmd(ctx context.Context, auditCtx audited.AuditContext, id types.UserRoleID) DeleteUserRoleCmdMysql {
+	return DeleteUserRoleCmdMysql{ctx: ctx, auditCtx: auditCtx, id: id, conn: d.Connection}
+}
+
+// ===== POSTGRESQL =====
+
+// NewUserRoleCmdPsql is an audited command for creating a user-role assignment in PostgreSQL.
+type NewUserRoleCmdPsql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	params   CreateUserRoleParams
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c NewUserRoleCmdPsql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c NewUserRoleCmdPsql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c NewUserRoleCmdPsql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c NewUserRoleCmdPsql) Recorder() audited.ChangeEventRecorder { return PsqlRecorder }
+
+// TableName returns the table name.
+func (c NewUserRoleCmdPsql) TableName() string { return "user_roles" }
+
+// Params returns the command parameters.
+func (c NewUserRoleCmdPsql) Params() any { return c.params }
+
+// GetID returns the ID of a created user-role assignment.
+func (c NewUserRoleCmdPsql) GetID(x mdbp.UserRoles) string {
+	return x.ID.String()
+}
+
+// Execute creates a user-role assignment in the database.
+func (c NewUserRoleCmdPsql) Execute(ctx context.Context, tx audited.DBTX) (mdbp.UserRoles, error) {
+	queries := mdbp.New(tx)
+	return queries.CreateUserRole(ctx, mdbp.CreateUserRoleParams{
+		ID:     types.NewUserRoleID(),
+		UserID: c.params.UserID,
+		RoleID: c.params.RoleID,
+	})
+}
+
+// NewUserRoleCmd returns a new create command for a user-role assignment.
+func (d PsqlDatabase) NewUserRoleCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateUserRoleParams) NewUserRoleCmdPsql {
+	return NewUserRoleCmdPsql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection}
+}
+
+// DeleteUserRoleCmdPsql is an audited command for deleting a user-role assignment in PostgreSQL.
+type DeleteUserRoleCmdPsql struct {
+	ctx      context.Context
+	auditCtx audited.AuditContext
+	id       types.UserRoleID
+	conn     *sql.DB
+}
+
+// Context returns the command's context.
+func (c DeleteUserRoleCmdPsql) Context() context.Context { return c.ctx }
+
+// AuditContext returns the audit context.
+func (c DeleteUserRoleCmdPsql) AuditContext() audited.AuditContext { return c.auditCtx }
+
+// Connection returns the database connection.
+func (c DeleteUserRoleCmdPsql) Connection() *sql.DB { return c.conn }
+
+// Recorder returns the change event recorder.
+func (c DeleteUserRoleCmdPsql) Recorder() audited.ChangeEventRecorder { return PsqlRecorder }
+
+// TableName returns the table name.
+func (c DeleteUserRoleCmdPsql) TableName() string { return "user_roles" }
+
+// GetID returns the user-role ID being deleted.
+func (c DeleteUserRoleCmdPsql) GetID() string { return c.id.String() }
+
+// GetBefore retrieves the user-role assignment before deletion.
+func (c DeleteUserRoleCmdPsql) GetBefore(ctx context.Context, tx audited.DBTX) (mdbp.UserRoles, error) {
+	queries := mdbp.New(tx)
+	return queries.GetUserRole(ctx, mdbp.GetUserRoleParams{ID: c.id})
+}
+
+// Execute deletes a user-role assignment from the database.
+func (c DeleteUserRoleCmdPsql) Execute(ctx context.Context, tx audited.DBTX) error {
+	queries := mdbp.New(tx)
+	return queries.DeleteUserRole(ctx, mdbp.DeleteUserRoleParams{ID: c.id})
+}
+
+// DeleteUserRoleCmd returns a new delete command for a user-role assignment.
+func (d PsqlDatabase) DeleteUserRoleCmd(ctx context.Context, auditCtx audited.AuditContext, id types.UserRoleID) DeleteUserRoleCmdPsql {
+	return DeleteUserRoleCmdPsql{ctx: ctx, auditCtx: auditCtx, id: id, conn: d.Connection}
+}
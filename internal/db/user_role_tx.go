@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	mdbm "github.com/hegner123/modulacms/internal/db-mysql"
+	mdbp "github.com/hegner123/modulacms/internal/db-psql"
+	mdb "github.com/hegner123/modulacms/internal/db-sqlite"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// CreateUserRoleInTx creates a user-role assignment with audit trail in an
+// existing transaction.
+func CreateUserRoleInTx(d DbDriver, ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params CreateUserRoleParams) (*UserRoles, error) {
+	switch drv := d.(type) {
+	case Database:
+		result, err := audited.CreateInTx(Database{}.NewUserRoleCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create user_role: %w", err)
+		}
+		r := drv.MapUserRole(result)
+		return &r, nil
+	case MysqlDatabase:
+		result, err := audited.CreateInTx(MysqlDatabase{}.NewUserRoleCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create user_role: %w", err)
+		}
+		r := drv.MapUserRole(result)
+		return &r, nil
+	case PsqlDatabase:
+		result, err := audited.CreateInTx(PsqlDatabase{}.NewUserRoleCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create user_role: %w", err)
+		}
+		r := drv.MapUserRole(result)
+		return &r, nil
+	default:
+		return nil, fmt.Errorf("tx create user_role: unsupported driver type %T", d)
+	}
+}
+
+// DeleteUserRolesByUserIDInTx removes all user-role assignments for a user in
+// an existing transaction. Like DeleteUserRolesByUserID it is not audited.
+func DeleteUserRolesByUserIDInTx(d DbDriver, ctx context.Context, tx *sql.Tx, userID types.UserID) error {
+	switch d.(type) {
+	case Database:
+		return mdb.New(tx).DeleteUserRoleByUserID(ctx, mdb.DeleteUserRoleByUserIDParams{UserID: userID})
+	case MysqlDatabase:
+		return mdbm.New(tx).DeleteUserRoleByUserID(ctx, mdbm.DeleteUserRoleByUserIDParams{UserID: userID})
+	case PsqlDatabase:
+		return mdbp.New(tx).DeleteUserRoleByUserID(ctx, mdbp.DeleteUserRoleByUserIDParams{UserID: userID})
+	default:
+		return fmt.Errorf("tx delete user_roles: unsupported driver type %T", d)
+	}
+}
+
+// DeleteUserRoleInTx removes a user-role assignment with audit trail in an
+// existing transaction.
+func DeleteUserRoleInTx(d DbDriver, ctx context.Context, tx *sql.Tx, ac audited.AuditContext, id types.UserRoleID) error {
+	switch d.(type) {
+	case Database:
+		return audited.DeleteInTx(Database{}.DeleteUserRoleCmd(ctx, ac, id), tx)
+	case MysqlDatabase:
+		return audited.DeleteInTx(MysqlDatabase{}.DeleteUserRoleCmd(ctx, ac, id), tx)
+	case PsqlDatabase:
+		return audited.DeleteInTx(PsqlDatabase{}.DeleteUserRoleCmd(ctx, ac, id), tx)
+	default:
+		return fmt.Errorf("tx delete user_role: unsupported driver type %T", d)
+	}
+}
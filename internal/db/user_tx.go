@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hegner123/modulacms/internal/db/audited"
+)
+
+// CreateUserInTx creates a user with audit trail in an existing transaction.
+func CreateUserInTx(d DbDriver, ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params CreateUserParams) (*Users, error) {
+	switch drv := d.(type) {
+	case Database:
+		result, err := audited.CreateInTx(Database{}.NewUserCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create user: %w", err)
+		}
+		r := drv.MapUser(result)
+		return &r, nil
+	case MysqlDatabase:
+		result, err := audited.CreateInTx(MysqlDatabase{}.NewUserCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create user: %w", err)
+		}
+		r := drv.MapUser(result)
+		return &r, nil
+	case PsqlDatabase:
+		result, err := audited.CreateInTx(PsqlDatabase{}.NewUserCmd(ctx, ac, params), tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx create user: %w", err)
+		}
+		r := drv.MapUser(result)
+		return &r, nil
+	default:
+		return nil, fmt.Errorf("tx create user: unsupported driver type %T", d)
+	}
+}
+
+// UpdateUserInTx updates a user with audit trail in an existing transaction.
+func UpdateUserInTx(d DbDriver, ctx context.Context, tx *sql.Tx, ac audited.AuditContext, params UpdateUserParams) error {
+	switch d.(type) {
+	case Database:
+		return audited.UpdateInTx(Database{}.UpdateUserCmd(ctx, ac, params), tx)
+	case MysqlDatabase:
+		return audited.UpdateInTx(MysqlDatabase{}.UpdateUserCmd(ctx, ac, params), tx)
+	case PsqlDatabase:
+		return audited.UpdateInTx(PsqlDatabase{}.UpdateUserCmd(ctx, ac, params), tx)
+	default:
+		return fmt.Errorf("tx update user: unsupported driver type %T", d)
+	}
+}
@@ -76,7 +76,7 @@ func (d Database) MapWebhook(a mdb.Webhooks) Webhook {
 		WebhookID:    a.WebhookID,
 		Name:         a.Name,
 		URL:          a.URL,
-		Secret:       a.Secret,
+		Secret:       openColumn(a.Secret),
 		Events:       unmarshalEvents(a.Events),
 		IsActive:     a.IsActive.Bool(),
 		Headers:      unmarshalHeaders(a.Headers),
@@ -87,7 +87,10 @@ func (d Database) MapWebhook(a mdb.Webhooks) Webhook {
 }
 
 // MapCreateWebhookParams converts wrapper params to sqlc-generated SQLite params.
-func (d Database) MapCreateWebhookParams(a CreateWebhookParams) mdb.CreateWebhookParams {
+func (d Database) MapCreateWebhookParams(a CreateWebhookParams) (mdb.CreateWebhookParams, error) {
+	if err := sealColumns(&a.Secret); err != nil {
+		return mdb.CreateWebhookParams{}, err
+	}
 	return mdb.CreateWebhookParams{
 		WebhookID:    types.NewWebhookID(),
 		Name:         a.Name,
@@ -99,11 +102,14 @@ func (d Database) MapCreateWebhookParams(a CreateWebhookParams) mdb.CreateWebhoo
 		AuthorID:     types.NullableUserID{ID: a.AuthorID, Valid: true},
 		DateCreated:  a.DateCreated,
 		DateModified: a.DateModified,
-	}
+	}, nil
 }
 
 // MapUpdateWebhookParams converts wrapper params to sqlc-generated SQLite params.
-func (d Database) MapUpdateWebhookParams(a UpdateWebhookParams) mdb.UpdateWebhookParams {
+func (d Database) MapUpdateWebhookParams(a UpdateWebhookParams) (mdb.UpdateWebhookParams, error) {
+	if err := sealColumns(&a.Secret); err != nil {
+		return mdb.UpdateWebhookParams{}, err
+	}
 	return mdb.UpdateWebhookParams{
 		Name:         a.Name,
 		URL:          a.URL,
@@ -113,7 +119,7 @@ func (d Database) MapUpdateWebhookParams(a UpdateWebhookParams) mdb.UpdateWebhoo
 		Headers:      marshalHeaders(a.Headers),
 		DateModified: a.DateModified,
 		WebhookID:    a.WebhookID,
-	}
+	}, nil
 }
 
 // CreateWebhook inserts a new webhook with audit trail.
@@ -148,6 +154,7 @@ type NewWebhookCmd struct {
 	params   CreateWebhookParams
 	conn     *sql.DB
 	recorder audited.ChangeEventRecorder
+	sealErr  error
 }
 
 func (c NewWebhookCmd) Context() context.Context              { return c.ctx }
@@ -159,6 +166,9 @@ func (c NewWebhookCmd) Params() any                           { return c.params
 func (c NewWebhookCmd) GetID(u mdb.Webhooks) string           { return string(u.WebhookID) }
 
 func (c NewWebhookCmd) Execute(ctx context.Context, tx audited.DBTX) (mdb.Webhooks, error) {
+	if c.sealErr != nil {
+		return mdb.Webhooks{}, c.sealErr
+	}
 	queries := mdb.New(tx)
 	return queries.CreateWebhook(ctx, mdb.CreateWebhookParams{
 		WebhookID:    types.NewWebhookID(),
@@ -175,7 +185,9 @@ func (c NewWebhookCmd) Execute(ctx context.Context, tx audited.DBTX) (mdb.Webhoo
 }
 
 func (d Database) NewWebhookCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateWebhookParams) NewWebhookCmd {
-	return NewWebhookCmd{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: SQLiteRecorder}
+	// A sealing failure is held and returned by Execute so the plaintext is never written.
+	sealErr := sealColumns(&params.Secret)
+	return NewWebhookCmd{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: SQLiteRecorder, sealErr: sealErr}
 }
 
 // ----- SQLite UPDATE COMMAND -----
@@ -187,6 +199,7 @@ type UpdateWebhookCmd struct {
 	params   UpdateWebhookParams
 	conn     *sql.DB
 	recorder audited.ChangeEventRecorder
+	sealErr  error
 }
 
 func (c UpdateWebhookCmd) Context() context.Context              { return c.ctx }
@@ -203,6 +216,9 @@ func (c UpdateWebhookCmd) GetBefore(ctx context.Context, tx audited.DBTX) (mdb.W
 }
 
 func (c UpdateWebhookCmd) Execute(ctx context.Context, tx audited.DBTX) error {
+	if c.sealErr != nil {
+		return c.sealErr
+	}
 	queries := mdb.New(tx)
 	return queries.UpdateWebhook(ctx, mdb.UpdateWebhookParams{
 		Name:         c.params.Name,
@@ -217,7 +233,9 @@ func (c UpdateWebhookCmd) Execute(ctx context.Context, tx audited.DBTX) error {
 }
 
 func (d Database) UpdateWebhookCmd(ctx context.Context, auditCtx audited.AuditContext, params UpdateWebhookParams) UpdateWebhookCmd {
-	return UpdateWebhookCmd{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: SQLiteRecorder}
+	// A sealing failure is held and returned by Execute so the plaintext is never written.
+	sealErr := sealColumns(&params.Secret)
+	return UpdateWebhookCmd{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: SQLiteRecorder, sealErr: sealErr}
 }
 
 // ----- SQLite DELETE COMMAND -----
@@ -278,7 +296,7 @@ func (d MysqlDatabase) MapWebhook(a mdbm.Webhooks) Webhook {
 		WebhookID:    a.WebhookID,
 		Name:         a.Name,
 		URL:          a.URL,
-		Secret:       a.Secret,
+		Secret:       openColumn(a.Secret),
 		Events:       unmarshalEvents(a.Events),
 		IsActive:     a.IsActive.Bool(),
 		Headers:      unmarshalHeaders(a.Headers),
@@ -289,7 +307,10 @@ func (d MysqlDatabase) MapWebhook(a mdbm.Webhooks) Webhook {
 }
 
 // MapCreateWebhookParams converts wrapper params to sqlc-generated MySQL params.
-func (d MysqlDatabase) MapCreateWebhookParams(a CreateWebhookParams) mdbm.CreateWebhookParams {
+func (d MysqlDatabase) MapCreateWebhookParams(a CreateWebhookParams) (mdbm.CreateWebhookParams, error) {
+	if err := sealColumns(&a.Secret); err != nil {
+		return mdbm.CreateWebhookParams{}, err
+	}
 	return mdbm.CreateWebhookParams{
 		WebhookID:    types.NewWebhookID(),
 		Name:         a.Name,
@@ -301,11 +322,14 @@ func (d MysqlDatabase) MapCreateWebhookParams(a CreateWebhookParams) mdbm.Create
 		AuthorID:     types.NullableUserID{ID: a.AuthorID, Valid: true},
 		DateCreated:  a.DateCreated,
 		DateModified: a.DateModified,
-	}
+	}, nil
 }
 
 // MapUpdateWebhookParams converts wrapper params to sqlc-generated MySQL params.
-func (d MysqlDatabase) MapUpdateWebhookParams(a UpdateWebhookParams) mdbm.UpdateWebhookParams {
+func (d MysqlDatabase) MapUpdateWebhookParams(a UpdateWebhookParams) (mdbm.UpdateWebhookParams, error) {
+	if err := sealColumns(&a.Secret); err != nil {
+		return mdbm.UpdateWebhookParams{}, err
+	}
 	return mdbm.UpdateWebhookParams{
 		Name:         a.Name,
 		URL:          a.URL,
@@ -315,7 +339,7 @@ func (d MysqlDatabase) MapUpdateWebhookParams(a UpdateWebhookParams) mdbm.Update
 		Headers:      marshalHeaders(a.Headers),
 		DateModified: a.DateModified,
 		WebhookID:    a.WebhookID,
-	}
+	}, nil
 }
 
 // CreateWebhook inserts a new webhook with audit trail.
@@ -348,6 +372,7 @@ type NewWebhookCmdMysql struct {
 	params   CreateWebhookParams
 	conn     *sql.DB
 	recorder audited.ChangeEventRecorder
+	sealErr  error
 }
 
 func (c NewWebhookCmdMysql) Context() context.Context              { return c.ctx }
@@ -358,6 +383,9 @@ func (c NewWebhookCmdMysql) TableName() string                     { return "web
 func (c NewWebhookCmdMysql) Params() any                           { return c.params }
 func (c NewWebhookCmdMysql) GetID(u mdbm.Webhooks) string          { return string(u.WebhookID) }
 func (c NewWebhookCmdMysql) Execute(ctx context.Context, tx audited.DBTX) (mdbm.Webhooks, error) {
+	if c.sealErr != nil {
+		return mdbm.Webhooks{}, c.sealErr
+	}
 	queries := mdbm.New(tx)
 	params := mdbm.CreateWebhookParams{
 		WebhookID:    types.NewWebhookID(),
@@ -377,7 +405,9 @@ func (c NewWebhookCmdMysql) Execute(ctx context.Context, tx audited.DBTX) (mdbm.
 	return queries.GetWebhook(ctx, mdbm.GetWebhookParams{WebhookID: params.WebhookID})
 }
 func (d MysqlDatabase) NewWebhookCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateWebhookParams) NewWebhookCmdMysql {
-	return NewWebhookCmdMysql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: MysqlRecorder}
+	// A sealing failure is held and returned by Execute so the plaintext is never written.
+	sealErr := sealColumns(&params.Secret)
+	return NewWebhookCmdMysql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: MysqlRecorder, sealErr: sealErr}
 }
 
 // UpdateWebhookCmdMysql is an audited command for updating webhooks on MySQL.
@@ -387,6 +417,7 @@ type UpdateWebhookCmdMysql struct {
 	params   UpdateWebhookParams
 	conn     *sql.DB
 	recorder audited.ChangeEventRecorder
+	sealErr  error
 }
 
 func (c UpdateWebhookCmdMysql) Context() context.Context              { return c.ctx }
@@ -401,6 +432,9 @@ func (c UpdateWebhookCmdMysql) GetBefore(ctx context.Context, tx audited.DBTX) (
 	return queries.GetWebhook(ctx, mdbm.GetWebhookParams{WebhookID: c.params.WebhookID})
 }
 func (c UpdateWebhookCmdMysql) Execute(ctx context.Context, tx audited.DBTX) error {
+	if c.sealErr != nil {
+		return c.sealErr
+	}
 	queries := mdbm.New(tx)
 	return queries.UpdateWebhook(ctx, mdbm.UpdateWebhookParams{
 		Name:         c.params.Name,
@@ -414,7 +448,9 @@ func (c UpdateWebhookCmdMysql) Execute(ctx context.Context, tx audited.DBTX) err
 	})
 }
 func (d MysqlDatabase) UpdateWebhookCmd(ctx context.Context, auditCtx audited.AuditContext, params UpdateWebhookParams) UpdateWebhookCmdMysql {
-	return UpdateWebhookCmdMysql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: MysqlRecorder}
+	// A sealing failure is held and returned by Execute so the plaintext is never written.
+	sealErr := sealColumns(&params.Secret)
+	return UpdateWebhookCmdMysql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: MysqlRecorder, sealErr: sealErr}
 }
 
 // DeleteWebhookCmdMysql is an audited command for deleting webhooks on MySQL.
@@ -452,7 +488,7 @@ func (d PsqlDatabase) MapWebhook(a mdbp.Webhooks) Webhook {
 		WebhookID:    a.WebhookID,
 		Name:         a.Name,
 		URL:          a.URL,
-		Secret:       a.Secret,
+		Secret:       openColumn(a.Secret),
 		Events:       unmarshalEvents(a.Events),
 		IsActive:     a.IsActive.Bool(),
 		Headers:      unmarshalHeaders(a.Headers),
@@ -463,7 +499,10 @@ func (d PsqlDatabase) MapWebhook(a mdbp.Webhooks) Webhook {
 }
 
 // MapCreateWebhookParams converts wrapper params to sqlc-generated PostgreSQL params.
-func (d PsqlDatabase) MapCreateWebhookParams(a CreateWebhookParams) mdbp.CreateWebhookParams {
+func (d PsqlDatabase) MapCreateWebhookParams(a CreateWebhookParams) (mdbp.CreateWebhookParams, error) {
+	if err := sealColumns(&a.Secret); err != nil {
+		return mdbp.CreateWebhookParams{}, err
+	}
 	return mdbp.CreateWebhookParams{
 		WebhookID:    types.NewWebhookID(),
 		Name:         a.Name,
@@ -475,11 +514,14 @@ func (d PsqlDatabase) MapCreateWebhookParams(a CreateWebhookParams) mdbp.CreateW
 		AuthorID:     types.NullableUserID{ID: a.AuthorID, Valid: true},
 		DateCreated:  a.DateCreated,
 		DateModified: a.DateModified,
-	}
+	}, nil
 }
 
 // MapUpdateWebhookParams converts wrapper params to sqlc-generated PostgreSQL params.
-func (d PsqlDatabase) MapUpdateWebhookParams(a UpdateWebhookParams) mdbp.UpdateWebhookParams {
+func (d PsqlDatabase) MapUpdateWebhookParams(a UpdateWebhookParams) (mdbp.UpdateWebhookParams, error) {
+	if err := sealColumns(&a.Secret); err != nil {
+		return mdbp.UpdateWebhookParams{}, err
+	}
 	return mdbp.UpdateWebhookParams{
 		Name:         a.Name,
 		URL:          a.URL,
@@ -489,7 +531,7 @@ func (d PsqlDatabase) MapUpdateWebhookParams(a UpdateWebhookParams) mdbp.UpdateW
 		Headers:      marshalHeaders(a.Headers),
 		DateModified: a.DateModified,
 		WebhookID:    a.WebhookID,
-	}
+	}, nil
 }
 
 // CreateWebhook inserts a new webhook with audit trail.
@@ -522,6 +564,7 @@ type NewWebhookCmdPsql struct {
 	params   CreateWebhookParams
 	conn     *sql.DB
 	recorder audited.ChangeEventRecorder
+	sealErr  error
 }
 
 func (c NewWebhookCmdPsql) Context() context.Context              { return c.ctx }
@@ -532,6 +575,9 @@ func (c NewWebhookCmdPsql) TableName() string                     { return "webh
 func (c NewWebhookCmdPsql) Params() any                           { return c.params }
 func (c NewWebhookCmdPsql) GetID(u mdbp.Webhooks) string          { return string(u.WebhookID) }
 func (c NewWebhookCmdPsql) Execute(ctx context.Context, tx audited.DBTX) (mdbp.Webhooks, error) {
+	if c.sealErr != nil {
+		return mdbp.Webhooks{}, c.sealErr
+	}
 	queries := mdbp.New(tx)
 	return queries.CreateWebhook(ctx, mdbp.CreateWebhookParams{
 		WebhookID:    types.NewWebhookID(),
@@ -547,7 +593,9 @@ func (c NewWebhookCmdPsql) Execute(ctx context.Context, tx audited.DBTX) (mdbp.W
 	})
 }
 func (d PsqlDatabase) NewWebhookCmd(ctx context.Context, auditCtx audited.AuditContext, params CreateWebhookParams) NewWebhookCmdPsql {
-	return NewWebhookCmdPsql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: PsqlRecorder}
+	// A sealing failure is held and returned by Execute so the plaintext is never written.
+	sealErr := sealColumns(&params.Secret)
+	return NewWebhookCmdPsql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: PsqlRecorder, sealErr: sealErr}
 }
 
 // UpdateWebhookCmdPsql is an audited command for updating webhooks on PostgreSQL.
@@ -557,6 +605,7 @@ type UpdateWebhookCmdPsql struct {
 	params   UpdateWebhookParams
 	conn     *sql.DB
 	recorder audited.ChangeEventRecorder
+	sealErr  error
 }
 
 func (c UpdateWebhookCmdPsql) Context() context.Context              { return c.ctx }
@@ -571,6 +620,9 @@ func (c UpdateWebhookCmdPsql) GetBefore(ctx context.Context, tx audited.DBTX) (m
 	return queries.GetWebhook(ctx, mdbp.GetWebhookParams{WebhookID: c.params.WebhookID})
 }
 func (c UpdateWebhookCmdPsql) Execute(ctx context.Context, tx audited.DBTX) error {
+	if c.sealErr != nil {
+		return c.sealErr
+	}
 	queries := mdbp.New(tx)
 	return queries.UpdateWebhook(ctx, mdbp.UpdateWebhookParams{
 		Name:         c.params.Name,
@@ -584,7 +636,9 @@ func (c UpdateWebhookCmdPsql) Execute(ctx context.Context, tx audited.DBTX) erro
 	})
 }
 func (d PsqlDatabase) UpdateWebhookCmd(ctx context.Context, auditCtx audited.AuditContext, params UpdateWebhookParams) UpdateWebhookCmdPsql {
-	return UpdateWebhookCmdPsql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: PsqlRecorder}
+	// A sealing failure is held and returned by Execute so the plaintext is never written.
+	sealErr := sealColumns(&params.Secret)
+	return UpdateWebhookCmdPsql{ctx: ctx, auditCtx: auditCtx, params: params, conn: d.Connection, recorder: PsqlRecorder, sealErr: sealErr}
 }
 
 // DeleteWebhookCmdPsql is an audited command for deleting webhooks on PostgreSQL.
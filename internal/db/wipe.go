@@ -30,6 +30,20 @@ func (d Database) DropAllTables() error {
 		{"pipelines", func() error { return queries.DropPipelinesTable(d.Context) }},
 		{"plugins", func() error { return queries.DropPluginsTable(d.Context) }},
 		// Tier 6: Junction tables
+		{"blueprint_instances", func() error { return queries.DropBlueprintInstancesTable(d.Context) }},
+		{"blueprints", func() error { return queries.DropBlueprintsTable(d.Context) }},
+		{"translation_sources", func() error { return queries.DropTranslationSourcesTable(d.Context) }},
+		{"translation_reviews", func() error { return queries.DropTranslationReviewsTable(d.Context) }},
+		{"translation_memory", func() error { return queries.DropTranslationMemoryTable(d.Context) }},
+		{"content_leases", func() error { return queries.DropContentLeasesTable(d.Context) }},
+		{"content_migrations", func() error { return queries.DropContentMigrationsTable(d.Context) }},
+		{"rate_limit_counters", func() error { return queries.DropRateLimitCountersTable(d.Context) }},
+		{"password_history", func() error { return queries.DropPasswordHistoryTable(d.Context) }},
+		{"user_lockouts", func() error { return queries.DropUserLockoutsTable(d.Context) }},
+		{"user_group_roles", func() error { return queries.DropUserGroupRolesTable(d.Context) }},
+		{"user_group_members", func() error { return queries.DropUserGroupMembersTable(d.Context) }},
+		{"user_groups", func() error { return queries.DropUserGroupTable(d.Context) }},
+		{"user_roles", func() error { return queries.DropUserRolesTable(d.Context) }},
 		{"role_permissions", func() error { return queries.DropRolePermissionsTable(d.Context) }},
 		// Tier 5.5: Content relation tables (depend on content_data and fields)
 		{"admin_content_relations", func() error { return queries.DropAdminContentRelationTable(d.Context) }},
@@ -97,6 +111,20 @@ func (d MysqlDatabase) DropAllTables() error {
 		{"pipelines", func() error { return queries.DropPipelinesTable(d.Context) }},
 		{"plugins", func() error { return queries.DropPluginsTable(d.Context) }},
 		// Tier 6: Junction tables
+		{"blueprint_instances", func() error { return queries.DropBlueprintInstancesTable(d.Context) }},
+		{"blueprints", func() error { return queries.DropBlueprintsTable(d.Context) }},
+		{"translation_sources", func() error { return queries.DropTranslationSourcesTable(d.Context) }},
+		{"translation_reviews", func() error { return queries.DropTranslationReviewsTable(d.Context) }},
+		{"translation_memory", func() error { return queries.DropTranslationMemoryTable(d.Context) }},
+		{"content_leases", func() error { return queries.DropContentLeasesTable(d.Context) }},
+		{"content_migrations", func() error { return queries.DropContentMigrationsTable(d.Context) }},
+		{"rate_limit_counters", func() error { return queries.DropRateLimitCountersTable(d.Context) }},
+		{"password_history", func() error { return queries.DropPasswordHistoryTable(d.Context) }},
+		{"user_lockouts", func() error { return queries.DropUserLockoutsTable(d.Context) }},
+		{"user_group_roles", func() error { return queries.DropUserGroupRolesTable(d.Context) }},
+		{"user_group_members", func() error { return queries.DropUserGroupMembersTable(d.Context) }},
+		{"user_groups", func() error { return queries.DropUserGroupTable(d.Context) }},
+		{"user_roles", func() error { return queries.DropUserRolesTable(d.Context) }},
 		{"role_permissions", func() error { return queries.DropRolePermissionsTable(d.Context) }},
 		// Tier 5.5: Content relation tables (depend on content_data and fields)
 		{"admin_content_relations", func() error { return queries.DropAdminContentRelationTable(d.Context) }},
@@ -164,6 +192,20 @@ func (d PsqlDatabase) DropAllTables() error {
 		{"pipelines", func() error { return queries.DropPipelinesTable(d.Context) }},
 		{"plugins", func() error { return queries.DropPluginsTable(d.Context) }},
 		// Tier 6: Junction tables
+		{"blueprint_instances", func() error { return queries.DropBlueprintInstancesTable(d.Context) }},
+		{"blueprints", func() error { return queries.DropBlueprintsTable(d.Context) }},
+		{"translation_sources", func() error { return queries.DropTranslationSourcesTable(d.Context) }},
+		{"translation_reviews", func() error { return queries.DropTranslationReviewsTable(d.Context) }},
+		{"translation_memory", func() error { return queries.DropTranslationMemoryTable(d.Context) }},
+		{"content_leases", func() error { return queries.DropContentLeasesTable(d.Context) }},
+		{"content_migrations", func() error { return queries.DropContentMigrationsTable(d.Context) }},
+		{"rate_limit_counters", func() error { return queries.DropRateLimitCountersTable(d.Context) }},
+		{"password_history", func() error { return queries.DropPasswordHistoryTable(d.Context) }},
+		{"user_lockouts", func() error { return queries.DropUserLockoutsTable(d.Context) }},
+		{"user_group_roles", func() error { return queries.DropUserGroupRolesTable(d.Context) }},
+		{"user_group_members", func() error { return queries.DropUserGroupMembersTable(d.Context) }},
+		{"user_groups", func() error { return queries.DropUserGroupTable(d.Context) }},
+		{"user_roles", func() error { return queries.DropUserRolesTable(d.Context) }},
 		{"role_permissions", func() error { return queries.DropRolePermissionsTable(d.Context) }},
 		// Tier 5.5: Content relation tables (depend on content_data and fields)
 		{"admin_content_relations", func() error { return queries.DropAdminContentRelationTable(d.Context) }},
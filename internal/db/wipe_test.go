@@ -139,6 +139,20 @@ var ifExistsDropTables = []string{
 	"pipelines",
 	"plugins",
 	"role_permissions",
+	"user_roles",
+	"user_groups",
+	"user_group_members",
+	"user_group_roles",
+	"user_lockouts",
+	"password_history",
+	"rate_limit_counters",
+	"content_migrations",
+	"content_leases",
+	"translation_memory",
+	"translation_reviews",
+	"translation_sources",
+	"blueprints",
+	"blueprint_instances",
 	"admin_content_relations",
 	"content_relations",
 	"admin_content_versions",
@@ -187,6 +201,20 @@ func TestDatabase_DropAllTables_SpecificTablesGone(t *testing.T) {
 		"pipelines",
 		"plugins",
 		"role_permissions",
+		"user_roles",
+		"user_groups",
+		"user_group_members",
+		"user_group_roles",
+		"user_lockouts",
+		"password_history",
+		"rate_limit_counters",
+		"content_migrations",
+		"content_leases",
+		"translation_memory",
+		"translation_reviews",
+		"translation_sources",
+		"blueprints",
+		"blueprint_instances",
 		"admin_content_relations",
 		"content_relations",
 		"admin_content_versions",
@@ -0,0 +1,207 @@
+package dbmigrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// columnKind is how a column's values are converted between dialects and
+// compared during verification.
+type columnKind int
+
+const (
+	kindText columnKind = iota
+	kindInteger
+	kindBoolean
+	kindTime
+	kindJSON
+)
+
+func (k columnKind) String() string {
+	switch k {
+	case kindInteger:
+		return "integer"
+	case kindBoolean:
+		return "boolean"
+	case kindTime:
+		return "time"
+	case kindJSON:
+		return "json"
+	default:
+		return "text"
+	}
+}
+
+// classifyColumn picks the kind of a column from its type on both sides.
+// SQLite declares timestamps and JSON as TEXT, so the other side's type
+// decides; a column that is boolean, temporal or JSON on either side is
+// treated that way.
+func classifyColumn(src, dst db.ColumnMeta) columnKind {
+	switch {
+	case isBooleanType(src.Type) || isBooleanType(dst.Type):
+		return kindBoolean
+	case isTimeType(src.Type) || isTimeType(dst.Type):
+		return kindTime
+	case isJSONType(src.Type) || isJSONType(dst.Type):
+		return kindJSON
+	case dst.IsInteger:
+		return kindInteger
+	default:
+		return kindText
+	}
+}
+
+func isBooleanType(t string) bool {
+	return t == "boolean" || t == "bool" || t == "tinyint(1)"
+}
+
+func isTimeType(t string) bool {
+	return strings.Contains(t, "timestamp") || strings.HasPrefix(t, "datetime") || t == "date"
+}
+
+func isJSONType(t string) bool {
+	return t == "json" || t == "jsonb"
+}
+
+// timePrecision is the precision the destination stores timestamps at.
+// MySQL TIMESTAMP columns round fractional seconds, so values are truncated
+// before insert to keep the stored second the same as the source's.
+func timePrecision(driver config.DbDriver) time.Duration {
+	if driver == config.Mysql {
+		return time.Second
+	}
+	return time.Microsecond
+}
+
+// convertValue converts a value read by DeployOps (int64, string or nil) to
+// what the destination expects for a column of kind k.
+func convertValue(v any, k columnKind, precision time.Duration) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch k {
+	case kindBoolean:
+		return parseBool(v)
+	case kindInteger:
+		switch val := v.(type) {
+		case int64:
+			return val, nil
+		case string:
+			if b, err := parseBool(val); err == nil && !isDigits(val) {
+				if b {
+					return int64(1), nil
+				}
+				return int64(0), nil
+			}
+			n, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid integer %q", val)
+			}
+			return n, nil
+		}
+	case kindTime:
+		s, ok := v.(string)
+		if !ok {
+			return v, nil
+		}
+		var ts types.Timestamp
+		if err := ts.Scan(s); err != nil {
+			return nil, err
+		}
+		if !ts.Valid {
+			return nil, nil
+		}
+		return ts.Time.Truncate(precision), nil
+	}
+	return v, nil
+}
+
+// parseBool reads the boolean spellings of all three dialects.
+func parseBool(v any) (bool, error) {
+	switch val := v.(type) {
+	case int64:
+		return val != 0, nil
+	case bool:
+		return val, nil
+	case string:
+		switch strings.ToLower(val) {
+		case "1", "t", "true":
+			return true, nil
+		case "0", "f", "false":
+			return false, nil
+		}
+		return false, fmt.Errorf("invalid boolean %q", val)
+	}
+	return false, fmt.Errorf("invalid boolean %v (%T)", v, v)
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// canonicalValue renders a value the same way regardless of the dialect it
+// was read from, so source and destination rows hash alike. Times compare
+// at whole seconds and JSON by its decoded value.
+func canonicalValue(v any, k columnKind) string {
+	if v == nil {
+		return "\x00"
+	}
+	switch k {
+	case kindBoolean:
+		if b, err := parseBool(v); err == nil {
+			if b {
+				return "1"
+			}
+			return "0"
+		}
+	case kindInteger:
+		if c, err := convertValue(v, kindInteger, 0); err == nil {
+			return strconv.FormatInt(c.(int64), 10)
+		}
+	case kindTime:
+		if c, err := convertValue(v, kindTime, time.Second); err == nil {
+			if t, ok := c.(time.Time); ok {
+				return t.UTC().Format(time.RFC3339)
+			}
+			if c == nil {
+				return "\x00"
+			}
+		}
+	case kindJSON:
+		if s, ok := v.(string); ok {
+			return canonicalJSON(s)
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+// canonicalJSON re-encodes a JSON document with sorted object keys and no
+// insignificant whitespace. Invalid JSON is returned unchanged.
+func canonicalJSON(s string) string {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return s
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(doc); err != nil {
+		return s
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,109 @@
+package dbmigrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/db"
+)
+
+func TestClassifyColumn(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		src, dst db.ColumnMeta
+		want     columnKind
+	}{
+		{"sqlite text to psql timestamp", db.ColumnMeta{Type: "text"}, db.ColumnMeta{Type: "timestamp with time zone"}, kindTime},
+		{"mysql timestamp to sqlite text", db.ColumnMeta{Type: "timestamp"}, db.ColumnMeta{Type: "text"}, kindTime},
+		{"sqlite integer to mysql tinyint(1)", db.ColumnMeta{Type: "integer", IsInteger: true}, db.ColumnMeta{Type: "tinyint(1)", IsInteger: true}, kindBoolean},
+		{"psql boolean to sqlite", db.ColumnMeta{Type: "boolean"}, db.ColumnMeta{Type: "boolean"}, kindBoolean},
+		{"text to jsonb", db.ColumnMeta{Type: "text"}, db.ColumnMeta{Type: "jsonb"}, kindJSON},
+		{"integer", db.ColumnMeta{Type: "integer", IsInteger: true}, db.ColumnMeta{Type: "bigint", IsInteger: true}, kindInteger},
+		{"text", db.ColumnMeta{Type: "text"}, db.ColumnMeta{Type: "varchar(26)"}, kindText},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := classifyColumn(tt.src, tt.dst); got != tt.want {
+				t.Errorf("classifyColumn = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertValue(t *testing.T) {
+	t.Parallel()
+	ts := time.Date(2026, 3, 20, 14, 30, 5, 123456789, time.UTC)
+	tests := []struct {
+		name      string
+		in        any
+		kind      columnKind
+		precision time.Duration
+		want      any
+	}{
+		{"nil", nil, kindTime, time.Second, nil},
+		{"bool from int", int64(1), kindBoolean, 0, true},
+		{"bool from psql string", "f", kindBoolean, 0, false},
+		{"int from string", "42", kindInteger, 0, int64(42)},
+		{"int from bool string", "true", kindInteger, 0, int64(1)},
+		{"time from RFC3339", ts.Format(time.RFC3339Nano), kindTime, time.Microsecond, ts.Truncate(time.Microsecond)},
+		{"time truncated for mysql", ts.Format(time.RFC3339Nano), kindTime, time.Second, ts.Truncate(time.Second)},
+		{"time from sqlite layout", "2026-03-20 14:30:05", kindTime, time.Second, ts.Truncate(time.Second)},
+		{"text unchanged", "01HXYZ", kindText, 0, "01HXYZ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := convertValue(tt.in, tt.kind, tt.precision)
+			if err != nil {
+				t.Fatalf("convertValue: %v", err)
+			}
+			if want, ok := tt.want.(time.Time); ok {
+				gotT, ok := got.(time.Time)
+				if !ok || !gotT.Equal(want) {
+					t.Errorf("convertValue = %v, want %v", got, want)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("convertValue = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertValue_Invalid(t *testing.T) {
+	t.Parallel()
+	if _, err := convertValue("maybe", kindBoolean, 0); err == nil {
+		t.Error("invalid boolean accepted")
+	}
+	if _, err := convertValue("12abc", kindInteger, 0); err == nil {
+		t.Error("invalid integer accepted")
+	}
+}
+
+func TestCanonicalValue_MatchesAcrossDialects(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		a, b any
+		kind columnKind
+	}{
+		{"boolean", int64(1), "t", kindBoolean},
+		{"time layouts", "2026-03-20 14:30:05", "2026-03-20T14:30:05.654321Z", kindTime},
+		{"json whitespace and key order", `{"b": 1, "a": [1, 2]}`, `{"a":[1,2],"b":1}`, kindJSON},
+		{"integer", "7", int64(7), kindInteger},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if a, b := canonicalValue(tt.a, tt.kind), canonicalValue(tt.b, tt.kind); a != b {
+				t.Errorf("canonicalValue(%v) = %q, canonicalValue(%v) = %q", tt.a, a, tt.b, b)
+			}
+		})
+	}
+	if canonicalValue(nil, kindText) == canonicalValue("", kindText) {
+		t.Error("NULL and empty string canonicalize alike")
+	}
+}
@@ -0,0 +1,399 @@
+// Package dbmigrate copies a ModulaCMS database to another database, which
+// may use a different driver. Every table is streamed in primary key order
+// through db.DeployOps, values are converted to the destination dialect,
+// and IDs, HLC timestamps and content tree pointers are copied unchanged.
+// Progress is saved to a state file after every batch so an interrupted
+// migration resumes where it stopped, and the copy is verified afterwards
+// by comparing row counts and checksums.
+package dbmigrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/deploy"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// DefaultBatchSize is the number of rows copied per transaction.
+const DefaultBatchSize = 500
+
+// ErrVerificationFailed is returned when a copied table's row count or
+// checksum differs from the source.
+var ErrVerificationFailed = errors.New("migration verification failed")
+
+// instanceTables are copied after deploy.FullTableSet. Deploy sync leaves
+// them out because they belong to one installation, which is exactly what
+// a database migration moves. Translation memory is learned from this
+// installation's edits, so it stays here too. rate_limit_counters is not
+// copied: counters expire within minutes.
+var instanceTables = []db.DBTable{
+	db.User_lockouts,
+	db.Password_history,
+	db.Content_migration,
+	db.PluginT,
+	db.Translation_memory,
+}
+
+// Options configures a migration.
+type Options struct {
+	// StatePath is the file progress is saved to. Running again with the
+	// same file resumes an interrupted migration.
+	StatePath string
+	// BatchSize is the number of rows copied per transaction. Zero uses
+	// DefaultBatchSize.
+	BatchSize int
+	// Overwrite deletes existing rows in the destination before a new
+	// migration starts. Without it the destination tables must be empty.
+	Overwrite bool
+}
+
+// Report describes a migration.
+type Report struct {
+	Resumed bool
+	Tables  []TableReport
+	// Pending lists tables that could not be copied yet, such as plugin
+	// tables the destination does not have. Running again with the same
+	// state file copies them.
+	Pending  []string
+	Warnings []string
+}
+
+// TableReport is the result of copying and verifying one table.
+type TableReport struct {
+	Table          string
+	Copied         int64
+	SourceRows     int64
+	DestRows       int64
+	SourceChecksum string
+	DestChecksum   string
+	Verified       bool
+}
+
+// Migrate copies every CMS and plugin table from src to dst. The
+// destination schema is created if missing. Both drivers must be direct
+// database connections (SQLite, MySQL or PostgreSQL). The source should
+// not be written to while the migration runs.
+//
+// The state file is removed once every table is copied and verified. A
+// verification mismatch returns the report and ErrVerificationFailed.
+func Migrate(ctx context.Context, src db.DbDriver, srcCfg config.Config, dst db.DbDriver, dstCfg config.Config, opts Options) (*Report, error) {
+	if opts.StatePath == "" {
+		return nil, fmt.Errorf("migration state path is required")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if databaseIdentity(srcCfg) == databaseIdentity(dstCfg) {
+		return nil, fmt.Errorf("source and destination are the same database (%s)", databaseIdentity(srcCfg))
+	}
+
+	srcOps, err := db.NewDeployOps(src)
+	if err != nil {
+		return nil, fmt.Errorf("source: %w", err)
+	}
+	dstOps, err := db.NewDeployOps(dst)
+	if err != nil {
+		return nil, fmt.Errorf("destination: %w", err)
+	}
+
+	if err := dst.CreateAllTables(); err != nil {
+		return nil, fmt.Errorf("create destination schema: %w", err)
+	}
+	if err := dst.CreateFieldPluginConfigTable(); err != nil {
+		return nil, fmt.Errorf("create destination schema: %w", err)
+	}
+
+	tables, err := migrationTables(src)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	state, err := loadState(opts.StatePath)
+	if err != nil {
+		return nil, err
+	}
+	if state != nil {
+		if state.Source != databaseIdentity(srcCfg) || state.Destination != databaseIdentity(dstCfg) {
+			return nil, fmt.Errorf("migration state %s is for %s -> %s, not %s -> %s",
+				opts.StatePath, state.Source, state.Destination, databaseIdentity(srcCfg), databaseIdentity(dstCfg))
+		}
+		report.Resumed = true
+		utility.DefaultLogger.Info("db migrate: resuming", "state", opts.StatePath, "started_at", state.StartedAt)
+	} else {
+		state = newState(srcCfg, dstCfg)
+		if err := prepareDestination(ctx, dstOps, tables, opts.Overwrite); err != nil {
+			return nil, err
+		}
+		if err := state.save(opts.StatePath); err != nil {
+			return nil, err
+		}
+	}
+
+	m := &migrator{
+		srcOps:    srcOps,
+		dstOps:    dstOps,
+		precision: timePrecision(dstCfg.Db_Driver),
+		state:     state,
+		opts:      opts,
+		report:    report,
+	}
+	for _, table := range tables {
+		if err := m.copyTable(ctx, table); err != nil {
+			return report, err
+		}
+	}
+
+	verified := true
+	for _, table := range tables {
+		ts := state.table(string(table))
+		if !ts.Done {
+			continue
+		}
+		tr, err := m.verifyTable(ctx, table)
+		if err != nil {
+			return report, err
+		}
+		tr.Copied = ts.Rows
+		report.Tables = append(report.Tables, *tr)
+		if !tr.Verified {
+			verified = false
+		}
+	}
+
+	violations, err := verifyForeignKeys(ctx, dstOps)
+	if err != nil {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("foreign key verification error: %v", err))
+	}
+	for _, v := range violations {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("foreign key violation in %s (row %s -> %s)", v.Table, v.RowID, v.Parent))
+	}
+
+	if !verified {
+		return report, ErrVerificationFailed
+	}
+	if len(report.Pending) == 0 {
+		if err := os.Remove(opts.StatePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("remove migration state: %v", err))
+		}
+	}
+	return report, nil
+}
+
+// migrationTables returns every table to copy in foreign key order: the
+// deploy table set, the per-installation tables, then user plugin tables
+// registered in the source's tables table.
+func migrationTables(src db.DbDriver) ([]db.DBTable, error) {
+	tables := make([]db.DBTable, 0, len(deploy.FullTableSet)+len(instanceTables))
+	tables = append(tables, deploy.FullTableSet...)
+	tables = append(tables, instanceTables...)
+
+	registered, err := src.ListTables()
+	if err != nil {
+		return nil, fmt.Errorf("list source plugin tables: %w", err)
+	}
+	var plugin []db.DBTable
+	if registered != nil {
+		for _, t := range *registered {
+			if db.IsValidPluginTableName(t.Label) && !db.SystemPluginTables[t.Label] {
+				plugin = append(plugin, db.DBTable(t.Label))
+			}
+		}
+	}
+	sort.Slice(plugin, func(i, j int) bool { return plugin[i] < plugin[j] })
+	return append(tables, plugin...), nil
+}
+
+// prepareDestination makes sure a new migration starts from empty tables,
+// deleting existing rows when overwrite is set.
+func prepareDestination(ctx context.Context, dstOps db.DeployOps, tables []db.DBTable, overwrite bool) error {
+	var nonEmpty []db.DBTable
+	for _, table := range tables {
+		if _, err := dstOps.IntrospectColumns(ctx, table); err != nil {
+			continue
+		}
+		_, rows, err := dstOps.QueryRowsAfter(ctx, table, "", 1)
+		if err != nil {
+			return fmt.Errorf("check destination %s: %w", table, err)
+		}
+		if len(rows) > 0 {
+			nonEmpty = append(nonEmpty, table)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return nil
+	}
+	if !overwrite {
+		names := make([]string, len(nonEmpty))
+		for i, t := range nonEmpty {
+			names[i] = string(t)
+		}
+		return fmt.Errorf("destination tables are not empty (%s); use an empty database or overwrite", strings.Join(names, ", "))
+	}
+	return dstOps.ImportAtomic(ctx, func(ctx context.Context, ex db.Executor) error {
+		for i := len(nonEmpty) - 1; i >= 0; i-- {
+			if err := dstOps.TruncateTable(ctx, ex, nonEmpty[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type migrator struct {
+	srcOps    db.DeployOps
+	dstOps    db.DeployOps
+	precision time.Duration
+	state     *State
+	opts      Options
+	report    *Report
+}
+
+// copyTable streams one table from source to destination in key order,
+// one transaction per batch, saving progress after each commit.
+func (m *migrator) copyTable(ctx context.Context, table db.DBTable) error {
+	ts := m.state.table(string(table))
+	if ts.Done {
+		return nil
+	}
+
+	srcCols, err := m.srcOps.IntrospectColumns(ctx, table)
+	if err != nil {
+		// Registered but never created, or a table this version of the
+		// source does not have: nothing to copy.
+		m.report.Warnings = append(m.report.Warnings, fmt.Sprintf("%s: not in source, skipped", table))
+		ts.Done = true
+		return m.state.save(m.opts.StatePath)
+	}
+	dstCols, err := m.dstOps.IntrospectColumns(ctx, table)
+	if err != nil {
+		_, rows, qErr := m.srcOps.QueryRowsAfter(ctx, table, "", 1)
+		if qErr != nil {
+			return fmt.Errorf("read source %s: %w", table, qErr)
+		}
+		if len(rows) == 0 {
+			ts.Done = true
+			return m.state.save(m.opts.StatePath)
+		}
+		m.report.Pending = append(m.report.Pending, string(table))
+		m.report.Warnings = append(m.report.Warnings, fmt.Sprintf("%s: not in destination; create it (for plugin tables, start the server against the destination once) and run again", table))
+		return nil
+	}
+	kinds, err := columnKinds(table, srcCols, dstCols)
+	if err != nil {
+		return err
+	}
+
+	utility.DefaultLogger.Info("db migrate: copying", "table", string(table), "from_key", ts.LastKey)
+	// A batch may have committed without its progress being saved. The
+	// first batch after a resume replaces those rows instead of inserting
+	// duplicates.
+	replace := m.report.Resumed
+	for {
+		cols, rows, err := m.srcOps.QueryRowsAfter(ctx, table, ts.LastKey, m.opts.BatchSize)
+		if err != nil {
+			return fmt.Errorf("read source %s after %q: %w", table, ts.LastKey, err)
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		converted := make([][]any, len(rows))
+		keys := make([]any, len(rows))
+		for i, row := range rows {
+			out := make([]any, len(row))
+			for j, v := range row {
+				c, cErr := convertValue(v, kinds[j], m.precision)
+				if cErr != nil {
+					return fmt.Errorf("%s row %v column %s: %w", table, row[0], cols[j], cErr)
+				}
+				out[j] = c
+			}
+			converted[i] = out
+			keys[i] = row[0]
+		}
+
+		err = m.dstOps.ImportAtomic(ctx, func(ctx context.Context, ex db.Executor) error {
+			if replace {
+				if dErr := deleteKeys(ctx, ex, m.dstOps, table, cols[0], keys); dErr != nil {
+					return dErr
+				}
+			}
+			return m.dstOps.BulkInsert(ctx, ex, table, cols, converted)
+		})
+		if err != nil {
+			return fmt.Errorf("write destination %s: %w", table, err)
+		}
+		replace = false
+
+		ts.LastKey = fmt.Sprint(rows[len(rows)-1][0])
+		ts.Rows += int64(len(rows))
+		if err := m.state.save(m.opts.StatePath); err != nil {
+			return err
+		}
+		if len(rows) < m.opts.BatchSize {
+			break
+		}
+	}
+
+	ts.Done = true
+	utility.DefaultLogger.Info("db migrate: copied", "table", string(table), "rows", ts.Rows)
+	return m.state.save(m.opts.StatePath)
+}
+
+// columnKinds classifies each source column against the destination
+// column of the same name. A source column missing from the destination is
+// an error, since its data would be lost.
+func columnKinds(table db.DBTable, srcCols, dstCols []db.ColumnMeta) ([]columnKind, error) {
+	dstByName := make(map[string]db.ColumnMeta, len(dstCols))
+	for _, c := range dstCols {
+		dstByName[c.Name] = c
+	}
+	kinds := make([]columnKind, len(srcCols))
+	for i, c := range srcCols {
+		d, ok := dstByName[c.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s: column %s is missing from the destination", table, c.Name)
+		}
+		kinds[i] = classifyColumn(c, d)
+	}
+	return kinds, nil
+}
+
+// deleteKeyChunk keeps DELETE ... IN lists under SQLite's parameter limit.
+const deleteKeyChunk = 500
+
+// deleteKeys removes the rows with the given keys from the destination.
+func deleteKeys(ctx context.Context, ex db.Executor, ops db.DeployOps, table db.DBTable, keyColumn string, keys []any) error {
+	for start := 0; start < len(keys); start += deleteKeyChunk {
+		chunk := keys[start:min(start+deleteKeyChunk, len(keys))]
+		placeholders := make([]string, len(chunk))
+		for i := range chunk {
+			placeholders[i] = ops.Placeholder(i + 1)
+		}
+		query := "DELETE FROM " + string(table) + " WHERE " + keyColumn + " IN (" + strings.Join(placeholders, ", ") + ");"
+		if _, err := ex.ExecContext(ctx, query, chunk...); err != nil {
+			return fmt.Errorf("clear resumed batch in %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// verifyForeignKeys checks the destination's foreign keys after the copy.
+func verifyForeignKeys(ctx context.Context, ops db.DeployOps) ([]db.FKViolation, error) {
+	var violations []db.FKViolation
+	err := ops.ImportAtomic(ctx, func(ctx context.Context, ex db.Executor) error {
+		v, err := ops.VerifyForeignKeys(ctx, ex)
+		violations = v
+		return err
+	})
+	return violations, err
+}
@@ -0,0 +1,218 @@
+package dbmigrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// openSQLite opens a SQLite database in dir with the CMS schema created.
+func openSQLite(t *testing.T, dir, name string) db.Database {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if _, err := conn.Exec("PRAGMA foreign_keys=ON;"); err != nil {
+		t.Fatalf("PRAGMA foreign_keys: %v", err)
+	}
+	return db.Database{
+		Connection: conn,
+		Context:    context.Background(),
+		Config: config.Config{
+			Db_Driver: config.Sqlite,
+			Db_URL:    path,
+			Node_ID:   types.NewNodeID().String(),
+		},
+	}
+}
+
+// newSource returns a bootstrapped SQLite source database.
+func newSource(t *testing.T, dir string) db.Database {
+	t.Helper()
+	src := openSQLite(t, dir, "source.db")
+	if err := src.CreateAllTables(); err != nil {
+		t.Fatalf("CreateAllTables: %v", err)
+	}
+	if err := src.CreateBootstrapData("hash"); err != nil {
+		t.Fatalf("CreateBootstrapData: %v", err)
+	}
+	return src
+}
+
+func countRows(t *testing.T, d db.Database, table string) int {
+	t.Helper()
+	var n int
+	if err := d.Connection.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n); err != nil {
+		t.Fatalf("count %s: %v", table, err)
+	}
+	return n
+}
+
+func TestMigrate_SQLiteToSQLite(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	src := newSource(t, dir)
+	dst := openSQLite(t, dir, "dest.db")
+	statePath := filepath.Join(dir, "state.json")
+
+	report, err := Migrate(context.Background(), src, src.Config, dst, dst.Config, Options{StatePath: statePath, BatchSize: 3})
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if report.Resumed {
+		t.Error("Resumed = true for a new migration")
+	}
+	if len(report.Pending) != 0 {
+		t.Errorf("Pending = %v, want none", report.Pending)
+	}
+	if len(report.Tables) == 0 {
+		t.Fatal("report has no tables")
+	}
+	for _, tr := range report.Tables {
+		if !tr.Verified {
+			t.Errorf("%s not verified: %+v", tr.Table, tr)
+		}
+		if tr.Copied != tr.SourceRows {
+			t.Errorf("%s: copied %d rows, source has %d", tr.Table, tr.Copied, tr.SourceRows)
+		}
+	}
+	for _, table := range []string{"permissions", "roles", "users", "routes", "datatypes"} {
+		if got, want := countRows(t, dst, table), countRows(t, src, table); got != want || want == 0 {
+			t.Errorf("%s: destination has %d rows, source %d", table, got, want)
+		}
+	}
+	if _, err := os.Stat(statePath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("state file still exists after a complete migration: %v", err)
+	}
+}
+
+func TestMigrate_Resume(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	src := newSource(t, dir)
+	dst := openSQLite(t, dir, "dest.db")
+	statePath := filepath.Join(dir, "state.json")
+	ctx := context.Background()
+
+	if _, err := Migrate(ctx, src, src.Config, dst, dst.Config, Options{StatePath: statePath}); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	// Simulate a run interrupted while copying permissions in batches of
+	// two: the first batch was saved to the state file, the second was
+	// committed but its progress was not, and the rest were never copied.
+	var keys []string
+	rows, err := src.Connection.Query("SELECT permission_id FROM permissions ORDER BY permission_id")
+	if err != nil {
+		t.Fatalf("query keys: %v", err)
+	}
+	for rows.Next() {
+		var k string
+		if err := rows.Scan(&k); err != nil {
+			t.Fatalf("scan key: %v", err)
+		}
+		keys = append(keys, k)
+	}
+	rows.Close()
+	if len(keys) < 5 {
+		t.Fatalf("need at least 5 permissions, have %d", len(keys))
+	}
+	if _, err := dst.Connection.Exec("PRAGMA foreign_keys=OFF;"); err != nil {
+		t.Fatalf("PRAGMA foreign_keys: %v", err)
+	}
+	if _, err := dst.Connection.Exec("DELETE FROM permissions WHERE permission_id > ?", keys[3]); err != nil {
+		t.Fatalf("delete copied rows: %v", err)
+	}
+
+	tables, err := migrationTables(src)
+	if err != nil {
+		t.Fatalf("migrationTables: %v", err)
+	}
+	state := newState(src.Config, dst.Config)
+	for _, table := range tables {
+		state.table(string(table)).Done = true
+	}
+	*state.table("permissions") = TableState{LastKey: keys[1], Rows: 2}
+	if err := state.save(statePath); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	report, err := Migrate(ctx, src, src.Config, dst, dst.Config, Options{StatePath: statePath, BatchSize: 2})
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if !report.Resumed {
+		t.Error("Resumed = false")
+	}
+	for _, tr := range report.Tables {
+		if !tr.Verified {
+			t.Errorf("%s not verified: %+v", tr.Table, tr)
+		}
+		if tr.Table == "permissions" && tr.Copied != int64(len(keys)) {
+			t.Errorf("permissions: copied %d, want %d", tr.Copied, len(keys))
+		}
+	}
+}
+
+func TestMigrate_NonEmptyDestination(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	src := newSource(t, dir)
+	dst := openSQLite(t, dir, "dest.db")
+	if err := dst.CreateAllTables(); err != nil {
+		t.Fatalf("CreateAllTables: %v", err)
+	}
+	if err := dst.CreateBootstrapData("hash"); err != nil {
+		t.Fatalf("CreateBootstrapData: %v", err)
+	}
+	ctx := context.Background()
+
+	_, err := Migrate(ctx, src, src.Config, dst, dst.Config, Options{StatePath: filepath.Join(dir, "state.json")})
+	if err == nil || !strings.Contains(err.Error(), "not empty") {
+		t.Fatalf("Migrate into a populated database: err = %v, want not empty", err)
+	}
+
+	report, err := Migrate(ctx, src, src.Config, dst, dst.Config, Options{StatePath: filepath.Join(dir, "state2.json"), Overwrite: true})
+	if err != nil {
+		t.Fatalf("Migrate with overwrite: %v", err)
+	}
+	for _, tr := range report.Tables {
+		if !tr.Verified {
+			t.Errorf("%s not verified: %+v", tr.Table, tr)
+		}
+	}
+}
+
+func TestMigrate_Rejects(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	src := newSource(t, dir)
+	dst := openSQLite(t, dir, "dest.db")
+	ctx := context.Background()
+
+	if _, err := Migrate(ctx, src, src.Config, src, src.Config, Options{StatePath: filepath.Join(dir, "s.json")}); err == nil {
+		t.Error("migrating a database onto itself succeeded")
+	}
+
+	other := newState(src.Config, src.Config)
+	statePath := filepath.Join(dir, "other.json")
+	if err := other.save(statePath); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+	if _, err := Migrate(ctx, src, src.Config, dst, dst.Config, Options{StatePath: statePath}); err == nil {
+		t.Error("resuming with another migration's state file succeeded")
+	}
+}
@@ -0,0 +1,116 @@
+package dbmigrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/config"
+)
+
+// stateVersion is bumped when the state file format changes.
+const stateVersion = 1
+
+// State is the progress of a migration, saved after every committed batch
+// so an interrupted run can resume where it stopped.
+type State struct {
+	Version     int                    `json:"version"`
+	Source      string                 `json:"source"`
+	Destination string                 `json:"destination"`
+	StartedAt   time.Time              `json:"started_at"`
+	Tables      map[string]*TableState `json:"tables"`
+}
+
+// TableState is the progress of one table. LastKey is the primary key of
+// the last row copied; rows are copied in key order.
+type TableState struct {
+	Done    bool   `json:"done"`
+	LastKey string `json:"last_key,omitempty"`
+	Rows    int64  `json:"rows"`
+}
+
+func newState(src, dst config.Config) *State {
+	return &State{
+		Version:     stateVersion,
+		Source:      databaseIdentity(src),
+		Destination: databaseIdentity(dst),
+		StartedAt:   time.Now().UTC(),
+		Tables:      make(map[string]*TableState),
+	}
+}
+
+// table returns the progress of table, creating it if needed.
+func (s *State) table(name string) *TableState {
+	ts, ok := s.Tables[name]
+	if !ok {
+		ts = &TableState{}
+		s.Tables[name] = ts
+	}
+	return ts
+}
+
+// loadState reads the state file at path. It returns nil and no error if
+// the file does not exist.
+func loadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read migration state %s: %w", path, err)
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse migration state %s: %w", path, err)
+	}
+	if s.Version != stateVersion {
+		return nil, fmt.Errorf("migration state %s has version %d, want %d", path, s.Version, stateVersion)
+	}
+	if s.Tables == nil {
+		s.Tables = make(map[string]*TableState)
+	}
+	return &s, nil
+}
+
+// save writes the state to path through a temporary file so a crash never
+// leaves a truncated state behind.
+func (s *State) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode migration state: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write migration state: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write migration state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write migration state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write migration state: %w", err)
+	}
+	return nil
+}
+
+// databaseIdentity names a database without its credentials, to tell
+// whether a state file belongs to this source and destination.
+func databaseIdentity(cfg config.Config) string {
+	if cfg.Db_Driver == config.Sqlite {
+		path := cfg.Db_URL
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		return "sqlite:" + path
+	}
+	return fmt.Sprintf("%s://%s@%s/%s", cfg.Db_Driver, cfg.Db_User, cfg.Db_URL, cfg.Db_Name)
+}
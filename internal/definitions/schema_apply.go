@@ -0,0 +1,279 @@
+package definitions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// ErrDestructivePlan is returned by ApplySchemaPlan when the plan retires
+// datatypes or fields, or changes a field type, and destructive changes
+// were not allowed.
+var ErrDestructivePlan = errors.New("definitions: plan contains destructive changes")
+
+// SchemaWriter is the consumer-defined interface for applying a SchemaPlan.
+type SchemaWriter interface {
+	ListContentDataByDatatypeID(types.DatatypeID) (*[]db.ContentData, error)
+	CreateValidation(context.Context, audited.AuditContext, db.CreateValidationParams) (*db.Validation, error)
+	UpdateValidation(context.Context, audited.AuditContext, db.UpdateValidationParams) (*string, error)
+	CreateDatatype(context.Context, audited.AuditContext, db.CreateDatatypeParams) (*db.Datatypes, error)
+	UpdateDatatype(context.Context, audited.AuditContext, db.UpdateDatatypeParams) (*string, error)
+	DeleteDatatype(context.Context, audited.AuditContext, types.DatatypeID) error
+	CreateField(context.Context, audited.AuditContext, db.CreateFieldParams) (*db.Fields, error)
+	UpdateField(context.Context, audited.AuditContext, db.UpdateFieldParams) (*string, error)
+	DeleteField(context.Context, audited.AuditContext, types.FieldID) error
+}
+
+// ApplyOptions controls ApplySchemaPlan.
+type ApplyOptions struct {
+	// AuthorID is recorded on created datatypes, fields and validations.
+	AuthorID types.UserID
+	// AllowDestructive permits retiring datatypes and fields and changing
+	// field types.
+	AllowDestructive bool
+}
+
+// ApplySchemaPlan executes a plan produced by DiffSchema against the same
+// live schema. Changes run in plan order and each is a separate write, so a
+// failure leaves earlier changes applied; because plans are computed from
+// the current state, re-running diff and apply after fixing the cause picks
+// up where it stopped. It returns the number of changes applied.
+func ApplySchemaPlan(ctx context.Context, w SchemaWriter, ac audited.AuditContext, plan SchemaPlan, opts ApplyOptions) (int, error) {
+	if opts.AuthorID.IsZero() {
+		return 0, fmt.Errorf("definitions: authorID cannot be empty")
+	}
+	if destructive := plan.Destructive(); len(destructive) > 0 && !opts.AllowDestructive {
+		names := make([]string, 0, len(destructive))
+		for _, c := range destructive {
+			names = append(names, string(c.Kind)+" "+c.Object+" "+c.Name)
+		}
+		return 0, fmt.Errorf("%w: %s", ErrDestructivePlan, strings.Join(names, ", "))
+	}
+
+	// Datatypes referenced by content cannot be deleted (content_data uses
+	// ON DELETE RESTRICT); check them all before writing anything.
+	for _, c := range plan.Changes {
+		if c.Kind != ChangeRetire || c.Object != "datatype" {
+			continue
+		}
+		content, err := w.ListContentDataByDatatypeID(c.liveDatatype.DatatypeID)
+		if err != nil {
+			return 0, fmt.Errorf("definitions: datatype %q: list content: %w", c.Name, err)
+		}
+		if content != nil && len(*content) > 0 {
+			return 0, fmt.Errorf("definitions: datatype %q still has %d content item(s); delete or move them before retiring it", c.Name, len(*content))
+		}
+	}
+
+	a := schemaApplier{
+		w:           w,
+		ac:          ac,
+		authorID:    opts.AuthorID,
+		now:         types.TimestampNow(),
+		datatypeIDs: make(map[string]types.DatatypeID),
+		validations: make(map[string]types.ValidationID),
+		live:        plan.live,
+	}
+	for i, c := range plan.Changes {
+		if err := a.apply(ctx, c); err != nil {
+			return i, fmt.Errorf("definitions: %s %s %s (after %d of %d changes): %w", c.Kind, c.Object, c.Name, i, len(plan.Changes), err)
+		}
+	}
+	return len(plan.Changes), nil
+}
+
+// schemaApplier carries the name -> ID lookups that later changes in a
+// plan need for records created or resolved by earlier ones.
+type schemaApplier struct {
+	w           SchemaWriter
+	ac          audited.AuditContext
+	authorID    types.UserID
+	now         types.Timestamp
+	datatypeIDs map[string]types.DatatypeID
+	validations map[string]types.ValidationID
+	live        liveIndex
+}
+
+func (a *schemaApplier) apply(ctx context.Context, c Change) error {
+	switch {
+	case c.Object == "validation":
+		return a.applyValidation(ctx, c)
+	case c.Object == "datatype" && c.Kind == ChangeRetire:
+		return a.w.DeleteDatatype(ctx, a.ac, c.liveDatatype.DatatypeID)
+	case c.Object == "datatype":
+		return a.applyDatatype(ctx, c)
+	case c.Object == "field" && c.Kind == ChangeRetire:
+		return a.w.DeleteField(ctx, a.ac, c.liveField.FieldID)
+	case c.Object == "field":
+		return a.applyField(ctx, c)
+	default:
+		return fmt.Errorf("unknown change")
+	}
+}
+
+func (a *schemaApplier) applyValidation(ctx context.Context, c Change) error {
+	config, err := canonicalJSON(c.validation.Config)
+	if err != nil {
+		return err
+	}
+	if c.Kind == ChangeCreate {
+		created, err := a.w.CreateValidation(ctx, a.ac, db.CreateValidationParams{
+			Name:         c.validation.Name,
+			Description:  c.validation.Description,
+			Config:       config,
+			AuthorID:     types.NullableUserID{ID: a.authorID, Valid: true},
+			DateCreated:  a.now,
+			DateModified: a.now,
+		})
+		if err != nil {
+			return err
+		}
+		a.validations[c.Name] = created.ValidationID
+		return nil
+	}
+	have := c.liveValidation
+	_, err = a.w.UpdateValidation(ctx, a.ac, db.UpdateValidationParams{
+		Name:         have.Name,
+		Description:  c.validation.Description,
+		Config:       config,
+		AuthorID:     have.AuthorID,
+		DateCreated:  have.DateCreated,
+		DateModified: a.now,
+		ValidationID: have.ValidationID,
+	})
+	return err
+}
+
+func (a *schemaApplier) applyDatatype(ctx context.Context, c Change) error {
+	want := c.datatype
+	var parent types.NullableDatatypeID
+	if want.Parent != "" {
+		id, err := a.datatypeID(want.Parent)
+		if err != nil {
+			return err
+		}
+		parent = types.NullableDatatypeID{ID: id, Valid: true}
+	}
+	if c.Kind == ChangeCreate {
+		created, err := a.w.CreateDatatype(ctx, a.ac, db.CreateDatatypeParams{
+			DatatypeID:   types.NewDatatypeID(),
+			ParentID:     parent,
+			SortOrder:    want.SortOrder,
+			Name:         want.Name,
+			Label:        want.Label,
+			Type:         want.Type,
+			AuthorID:     a.authorID,
+			DateCreated:  a.now,
+			DateModified: a.now,
+		})
+		if err != nil {
+			return err
+		}
+		a.datatypeIDs[want.Name] = created.DatatypeID
+		return nil
+	}
+	have := c.liveDatatype
+	_, err := a.w.UpdateDatatype(ctx, a.ac, db.UpdateDatatypeParams{
+		ParentID:     parent,
+		SortOrder:    want.SortOrder,
+		Name:         have.Name,
+		Label:        want.Label,
+		Type:         want.Type,
+		AuthorID:     have.AuthorID,
+		DateCreated:  have.DateCreated,
+		DateModified: a.now,
+		DatatypeID:   have.DatatypeID,
+	})
+	return err
+}
+
+func (a *schemaApplier) applyField(ctx context.Context, c Change) error {
+	want := c.field
+	data, err := canonicalJSON(want.Data)
+	if err != nil {
+		return err
+	}
+	uiConfig, err := canonicalJSON(want.UIConfig)
+	if err != nil {
+		return err
+	}
+	var validation types.NullableValidationID
+	if want.Validation != "" {
+		id, err := a.validationID(want.Validation)
+		if err != nil {
+			return err
+		}
+		validation = types.NullableValidationID{ID: id, Valid: true}
+	}
+	parentID, err := a.datatypeID(c.owner)
+	if err != nil {
+		return err
+	}
+	parent := types.NullableDatatypeID{ID: parentID, Valid: true}
+
+	if c.Kind == ChangeCreate {
+		_, err := a.w.CreateField(ctx, a.ac, db.CreateFieldParams{
+			FieldID:      types.NewFieldID(),
+			ParentID:     parent,
+			SortOrder:    c.sortOrder,
+			Name:         want.Name,
+			Label:        want.Label,
+			Data:         data,
+			ValidationID: validation,
+			UIConfig:     uiConfig,
+			Type:         want.Type,
+			Translatable: want.Translatable,
+			Roles:        c.roles,
+			AuthorID:     types.NullableUserID{ID: a.authorID, Valid: true},
+			DateCreated:  a.now,
+			DateModified: a.now,
+		})
+		return err
+	}
+	have := c.liveField
+	_, err = a.w.UpdateField(ctx, a.ac, db.UpdateFieldParams{
+		ParentID:     parent,
+		SortOrder:    c.sortOrder,
+		Name:         have.Name,
+		Label:        want.Label,
+		Data:         data,
+		ValidationID: validation,
+		UIConfig:     uiConfig,
+		Type:         want.Type,
+		Translatable: want.Translatable,
+		Roles:        c.roles,
+		AuthorID:     have.AuthorID,
+		DateCreated:  have.DateCreated,
+		DateModified: a.now,
+		FieldID:      have.FieldID,
+	})
+	return err
+}
+
+// datatypeID resolves a datatype name to a record created earlier in this
+// apply or already in the database.
+func (a *schemaApplier) datatypeID(name string) (types.DatatypeID, error) {
+	if id, ok := a.datatypeIDs[name]; ok {
+		return id, nil
+	}
+	if dt, ok := a.live.datatypes[name]; ok {
+		return dt.DatatypeID, nil
+	}
+	return "", fmt.Errorf("datatype %q not found", name)
+}
+
+// validationID resolves a validation name the same way as datatypeID.
+func (a *schemaApplier) validationID(name string) (types.ValidationID, error) {
+	if id, ok := a.validations[name]; ok {
+		return id, nil
+	}
+	if v, ok := a.live.validations[name]; ok {
+		return v.ValidationID, nil
+	}
+	return "", fmt.Errorf("validation %q not found", name)
+}
@@ -0,0 +1,585 @@
+package definitions
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// SchemaReader is the consumer-defined interface for reading the live
+// schema that export and diff work from.
+type SchemaReader interface {
+	ListDatatypes() (*[]db.Datatypes, error)
+	ListFields() (*[]db.Fields, error)
+	ListValidations() (*[]db.Validation, error)
+	ListFieldTypes() (*[]db.FieldTypes, error)
+	ListRoles() (*[]db.Roles, error)
+}
+
+// LiveSchema is a snapshot of the schema records in a database.
+type LiveSchema struct {
+	Datatypes   []db.Datatypes
+	Fields      []db.Fields
+	Validations []db.Validation
+	FieldTypes  []db.FieldTypes
+	Roles       []db.Roles
+}
+
+// LoadLiveSchema reads the live schema through r.
+func LoadLiveSchema(r SchemaReader) (LiveSchema, error) {
+	var live LiveSchema
+	datatypes, err := r.ListDatatypes()
+	if err != nil {
+		return live, fmt.Errorf("definitions: list datatypes: %w", err)
+	}
+	fields, err := r.ListFields()
+	if err != nil {
+		return live, fmt.Errorf("definitions: list fields: %w", err)
+	}
+	validations, err := r.ListValidations()
+	if err != nil {
+		return live, fmt.Errorf("definitions: list validations: %w", err)
+	}
+	fieldTypes, err := r.ListFieldTypes()
+	if err != nil {
+		return live, fmt.Errorf("definitions: list field types: %w", err)
+	}
+	roles, err := r.ListRoles()
+	if err != nil {
+		return live, fmt.Errorf("definitions: list roles: %w", err)
+	}
+	if datatypes != nil {
+		live.Datatypes = *datatypes
+	}
+	if fields != nil {
+		live.Fields = *fields
+	}
+	if validations != nil {
+		live.Validations = *validations
+	}
+	if fieldTypes != nil {
+		live.FieldTypes = *fieldTypes
+	}
+	if roles != nil {
+		live.Roles = *roles
+	}
+	return live, nil
+}
+
+// liveIndex resolves live records by the names a schema file uses.
+type liveIndex struct {
+	datatypes      map[string]db.Datatypes
+	datatypeNames  map[types.DatatypeID]string
+	fields         map[string][]db.Fields // datatype name -> fields in sort order
+	validations    map[string]db.Validation
+	validationName map[types.ValidationID]string
+	roleLabels     map[types.RoleID]string
+	roleIDs        map[string]types.RoleID
+	fieldTypes     map[string]bool
+}
+
+func indexLive(live LiveSchema) (liveIndex, error) {
+	idx := liveIndex{
+		datatypes:      make(map[string]db.Datatypes, len(live.Datatypes)),
+		datatypeNames:  make(map[types.DatatypeID]string, len(live.Datatypes)),
+		fields:         make(map[string][]db.Fields),
+		validations:    make(map[string]db.Validation, len(live.Validations)),
+		validationName: make(map[types.ValidationID]string, len(live.Validations)),
+		roleLabels:     make(map[types.RoleID]string, len(live.Roles)),
+		roleIDs:        make(map[string]types.RoleID, len(live.Roles)),
+		fieldTypes:     make(map[string]bool, len(live.FieldTypes)),
+	}
+	for _, dt := range live.Datatypes {
+		if _, dup := idx.datatypes[dt.Name]; dup {
+			return idx, fmt.Errorf("definitions: live schema has more than one datatype named %q; rename one first", dt.Name)
+		}
+		idx.datatypes[dt.Name] = dt
+		idx.datatypeNames[dt.DatatypeID] = dt.Name
+	}
+	for _, v := range live.Validations {
+		if _, dup := idx.validations[v.Name]; dup {
+			return idx, fmt.Errorf("definitions: live schema has more than one validation named %q; rename one first", v.Name)
+		}
+		idx.validations[v.Name] = v
+		idx.validationName[v.ValidationID] = v.Name
+	}
+	for _, r := range live.Roles {
+		idx.roleLabels[r.RoleID] = r.Label
+		idx.roleIDs[r.Label] = r.RoleID
+	}
+	for _, ft := range live.FieldTypes {
+		idx.fieldTypes[ft.Type] = true
+	}
+	// Fields without a datatype cannot be placed in a file and are left alone.
+	for _, f := range live.Fields {
+		if !f.ParentID.Valid {
+			continue
+		}
+		name, ok := idx.datatypeNames[f.ParentID.ID]
+		if !ok {
+			continue
+		}
+		for _, other := range idx.fields[name] {
+			if other.Name == f.Name {
+				return idx, fmt.Errorf("definitions: live datatype %q has more than one field named %q; rename one first", name, f.Name)
+			}
+		}
+		idx.fields[name] = append(idx.fields[name], f)
+	}
+	for name := range idx.fields {
+		slices.SortStableFunc(idx.fields[name], func(a, b db.Fields) int {
+			return cmp.Or(cmp.Compare(a.SortOrder, b.SortOrder), cmp.Compare(a.FieldID, b.FieldID))
+		})
+	}
+	return idx, nil
+}
+
+// parentName returns the name of dt's parent, or "" for roots and parents
+// that no longer exist.
+func (idx liveIndex) parentName(dt db.Datatypes) string {
+	if !dt.ParentID.Valid {
+		return ""
+	}
+	return idx.datatypeNames[dt.ParentID.ID]
+}
+
+// roleLabelList converts a roles column (JSON array of role IDs) to labels.
+// IDs without a matching role are kept as-is.
+func (idx liveIndex) roleLabelList(roles types.NullableString) []string {
+	if !roles.Valid || roles.String == "" {
+		return nil
+	}
+	var ids []string
+	if err := json.Unmarshal([]byte(roles.String), &ids); err != nil {
+		return nil
+	}
+	labels := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if label, ok := idx.roleLabels[types.RoleID(id)]; ok {
+			labels = append(labels, label)
+		} else {
+			labels = append(labels, id)
+		}
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
+// fieldSpec converts a live field to its file form.
+func (idx liveIndex) fieldSpec(f db.Fields) FieldSpec {
+	spec := FieldSpec{
+		Name:         f.Name,
+		Label:        f.Label,
+		Type:         f.Type,
+		Data:         decodeJSONColumn(f.Data),
+		UIConfig:     decodeJSONColumn(f.UIConfig),
+		Translatable: f.Translatable,
+		Roles:        idx.roleLabelList(f.Roles),
+	}
+	if f.ValidationID.Valid {
+		spec.Validation = idx.validationName[f.ValidationID.ID]
+	}
+	return spec
+}
+
+// ExportSchema converts a live schema to a SchemaFile. Parents precede
+// their children; siblings are ordered by sort order, then name.
+func ExportSchema(live LiveSchema) (SchemaFile, error) {
+	idx, err := indexLive(live)
+	if err != nil {
+		return SchemaFile{}, err
+	}
+
+	file := SchemaFile{Version: SchemaFileVersion}
+	for _, v := range live.Validations {
+		file.Validations = append(file.Validations, ValidationSpec{
+			Name:        v.Name,
+			Description: v.Description,
+			Config:      decodeJSONColumn(v.Config),
+		})
+	}
+	slices.SortFunc(file.Validations, func(a, b ValidationSpec) int { return cmp.Compare(a.Name, b.Name) })
+
+	for _, dt := range sortDatatypes(live.Datatypes, idx.parentName) {
+		spec := DatatypeSpec{
+			Name:      dt.Name,
+			Label:     dt.Label,
+			Type:      dt.Type,
+			Parent:    idx.parentName(dt),
+			SortOrder: dt.SortOrder,
+		}
+		for _, f := range idx.fields[dt.Name] {
+			spec.Fields = append(spec.Fields, idx.fieldSpec(f))
+		}
+		file.Datatypes = append(file.Datatypes, spec)
+	}
+	return file, nil
+}
+
+// sortDatatypes orders datatypes depth-first so every parent precedes its
+// children. Siblings are ordered by sort order, then name.
+func sortDatatypes(datatypes []db.Datatypes, parentOf func(db.Datatypes) string) []db.Datatypes {
+	children := make(map[string][]db.Datatypes)
+	for _, dt := range datatypes {
+		parent := parentOf(dt)
+		children[parent] = append(children[parent], dt)
+	}
+	for _, list := range children {
+		slices.SortFunc(list, func(a, b db.Datatypes) int {
+			return cmp.Or(cmp.Compare(a.SortOrder, b.SortOrder), cmp.Compare(a.Name, b.Name))
+		})
+	}
+	out := make([]db.Datatypes, 0, len(datatypes))
+	seen := make(map[types.DatatypeID]bool, len(datatypes))
+	var walk func(parent string)
+	walk = func(parent string) {
+		for _, dt := range children[parent] {
+			out = append(out, dt)
+			seen[dt.DatatypeID] = true
+			walk(dt.Name)
+		}
+	}
+	walk("")
+	// Datatypes in a parent cycle are unreachable from the roots; keep them
+	// so they are still exported and diffed.
+	for _, dt := range datatypes {
+		if !seen[dt.DatatypeID] {
+			out = append(out, dt)
+		}
+	}
+	return out
+}
+
+// ChangeKind is what a schema plan change does.
+type ChangeKind string
+
+// Schema plan change kinds.
+const (
+	ChangeCreate ChangeKind = "create"
+	ChangeUpdate ChangeKind = "update"
+	ChangeRetire ChangeKind = "retire"
+)
+
+// Change is one step of a SchemaPlan. Object is "validation", "datatype" or
+// "field"; fields are named "<datatype>.<field>".
+type Change struct {
+	Kind        ChangeKind `json:"kind"`
+	Object      string     `json:"object"`
+	Name        string     `json:"name"`
+	Details     []string   `json:"details,omitempty"`
+	Destructive bool       `json:"destructive,omitempty"`
+
+	validation *ValidationSpec
+	datatype   *DatatypeSpec
+	field      *FieldSpec
+	owner      string // datatype name of a field change
+	sortOrder  int64  // position of a field change
+	roles      types.NullableString
+
+	liveValidation *db.Validation
+	liveDatatype   *db.Datatypes
+	liveField      *db.Fields
+}
+
+// SchemaPlan is the ordered list of changes that brings a database in line
+// with a schema file: validations, then datatype creates and updates
+// (parents first), field creates and updates, field retires and finally
+// datatype retires (children first).
+type SchemaPlan struct {
+	Changes []Change `json:"changes"`
+
+	live liveIndex // resolves names of existing records during apply
+}
+
+// Empty reports whether the database already matches the file.
+func (p SchemaPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Destructive returns the changes that drop data or may leave existing
+// content values invalid.
+func (p SchemaPlan) Destructive() []Change {
+	var out []Change
+	for _, c := range p.Changes {
+		if c.Destructive {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Summary returns a one-line count of the plan's changes.
+func (p SchemaPlan) Summary() string {
+	counts := map[ChangeKind]int{}
+	for _, c := range p.Changes {
+		counts[c.Kind]++
+	}
+	s := fmt.Sprintf("%d to create, %d to update, %d to retire", counts[ChangeCreate], counts[ChangeUpdate], counts[ChangeRetire])
+	if n := len(p.Destructive()); n > 0 {
+		s += fmt.Sprintf(" (%d destructive)", n)
+	}
+	return s
+}
+
+// Render formats the plan for review: "+" creates, "~" updates and "-"
+// retires, with one indented line per changed attribute.
+func (p SchemaPlan) Render() string {
+	if p.Empty() {
+		return "No changes. The database matches the schema file.\n"
+	}
+	var b strings.Builder
+	for _, c := range p.Changes {
+		symbol := map[ChangeKind]string{ChangeCreate: "+", ChangeUpdate: "~", ChangeRetire: "-"}[c.Kind]
+		fmt.Fprintf(&b, "%s %s %s", symbol, c.Object, c.Name)
+		if c.Destructive {
+			b.WriteString("  [destructive]")
+		}
+		b.WriteByte('\n')
+		for _, d := range c.Details {
+			fmt.Fprintf(&b, "    %s\n", d)
+		}
+	}
+	fmt.Fprintf(&b, "\nPlan: %s.\n", p.Summary())
+	return b.String()
+}
+
+// DiffSchema compares a schema file with a live schema and returns the
+// changes needed to make the live schema match. Datatypes and fields that
+// are not in the file are retired; validations are only created or updated
+// because they may be shared with admin fields.
+func DiffSchema(file SchemaFile, live LiveSchema) (SchemaPlan, error) {
+	if err := ValidateSchemaFile(file); err != nil {
+		return SchemaPlan{}, err
+	}
+	idx, err := indexLive(live)
+	if err != nil {
+		return SchemaPlan{}, err
+	}
+
+	plan := SchemaPlan{live: idx}
+	add := func(c Change) { plan.Changes = append(plan.Changes, c) }
+
+	// Validations.
+	for i := range file.Validations {
+		want := &file.Validations[i]
+		config, err := canonicalJSON(want.Config)
+		if err != nil {
+			return SchemaPlan{}, fmt.Errorf("definitions: validation %q config: %w", want.Name, err)
+		}
+		have, ok := idx.validations[want.Name]
+		if !ok {
+			add(Change{Kind: ChangeCreate, Object: "validation", Name: want.Name, validation: want})
+			continue
+		}
+		var details []string
+		details = diffString(details, "description", have.Description, want.Description)
+		details = diffJSON(details, "config", canonicalColumn(have.Config), config)
+		if len(details) > 0 {
+			add(Change{Kind: ChangeUpdate, Object: "validation", Name: want.Name, Details: details, validation: want, liveValidation: &have})
+		}
+	}
+
+	// Datatype creates and updates, parents first.
+	ordered := orderSpecs(file.Datatypes)
+	var updates []Change
+	for _, want := range ordered {
+		have, ok := idx.datatypes[want.Name]
+		if !ok {
+			add(Change{Kind: ChangeCreate, Object: "datatype", Name: want.Name, datatype: want})
+			continue
+		}
+		var details []string
+		details = diffString(details, "label", have.Label, want.Label)
+		details = diffString(details, "type", have.Type, want.Type)
+		details = diffString(details, "parent", idx.parentName(have), want.Parent)
+		if have.SortOrder != want.SortOrder {
+			details = append(details, fmt.Sprintf("sort_order: %d -> %d", have.SortOrder, want.SortOrder))
+		}
+		if len(details) > 0 {
+			updates = append(updates, Change{Kind: ChangeUpdate, Object: "datatype", Name: want.Name, Details: details, datatype: want, liveDatatype: &have})
+		}
+	}
+	plan.Changes = append(plan.Changes, updates...)
+
+	// Field creates and updates.
+	for _, dt := range ordered {
+		liveFields := idx.fields[dt.Name]
+		liveOrder := make([]string, 0, len(liveFields))
+		for _, f := range liveFields {
+			liveOrder = append(liveOrder, f.Name)
+		}
+		wantOrder := make([]string, 0, len(dt.Fields))
+		for _, f := range dt.Fields {
+			wantOrder = append(wantOrder, f.Name)
+		}
+		// Sort orders are only rewritten when the relative order of the
+		// fields that exist on both sides differs, so a freshly installed
+		// schema with all-zero sort orders round-trips without changes.
+		reorder := !slices.Equal(commonOrder(liveOrder, wantOrder), commonOrder(wantOrder, liveOrder))
+
+		for i := range dt.Fields {
+			want := &dt.Fields[i]
+			name := dt.Name + "." + want.Name
+			if len(live.FieldTypes) > 0 && !idx.fieldTypes[string(want.Type)] {
+				return SchemaPlan{}, fmt.Errorf("definitions: field %s: unknown field type %q", name, want.Type)
+			}
+			roles, err := idx.rolesColumn(want.Roles)
+			if err != nil {
+				return SchemaPlan{}, fmt.Errorf("definitions: field %s: %w", name, err)
+			}
+			data, err := canonicalJSON(want.Data)
+			if err != nil {
+				return SchemaPlan{}, fmt.Errorf("definitions: field %s data: %w", name, err)
+			}
+			uiConfig, err := canonicalJSON(want.UIConfig)
+			if err != nil {
+				return SchemaPlan{}, fmt.Errorf("definitions: field %s ui_config: %w", name, err)
+			}
+
+			c := Change{Object: "field", Name: name, field: want, owner: dt.Name, sortOrder: int64(i), roles: roles}
+			pos := slices.IndexFunc(liveFields, func(f db.Fields) bool { return f.Name == want.Name })
+			if pos < 0 {
+				c.Kind = ChangeCreate
+				add(c)
+				continue
+			}
+			have := liveFields[pos]
+			current := idx.fieldSpec(have)
+			var details []string
+			details = diffString(details, "label", have.Label, want.Label)
+			if have.Type != want.Type {
+				details = append(details, fmt.Sprintf("type: %s -> %s (existing values are not converted)", have.Type, want.Type))
+				c.Destructive = true
+			}
+			details = diffJSON(details, "data", canonicalColumn(have.Data), data)
+			details = diffJSON(details, "ui_config", canonicalColumn(have.UIConfig), uiConfig)
+			details = diffString(details, "validation", current.Validation, want.Validation)
+			if have.Translatable != want.Translatable {
+				details = append(details, fmt.Sprintf("translatable: %t -> %t", have.Translatable, want.Translatable))
+			}
+			if !slices.Equal(current.Roles, want.Roles) {
+				details = append(details, fmt.Sprintf("roles: [%s] -> [%s]", strings.Join(current.Roles, ", "), strings.Join(want.Roles, ", ")))
+			}
+			if reorder && have.SortOrder != int64(i) {
+				details = append(details, fmt.Sprintf("position: %d -> %d", have.SortOrder, i))
+			} else {
+				c.sortOrder = have.SortOrder
+			}
+			if len(details) > 0 {
+				c.Kind = ChangeUpdate
+				c.Details = details
+				c.liveField = &have
+				add(c)
+			}
+		}
+	}
+
+	// Field retires on datatypes that stay. Fields of retired datatypes
+	// are removed with their datatype.
+	for _, dt := range ordered {
+		for _, have := range idx.fields[dt.Name] {
+			if slices.ContainsFunc(dt.Fields, func(f FieldSpec) bool { return f.Name == have.Name }) {
+				continue
+			}
+			add(Change{
+				Kind: ChangeRetire, Object: "field", Name: dt.Name + "." + have.Name,
+				Details:     []string{"content values stored in this field are deleted"},
+				Destructive: true, owner: dt.Name, liveField: &have,
+			})
+		}
+	}
+
+	// Datatype retires, children first.
+	liveOrdered := sortDatatypes(live.Datatypes, idx.parentName)
+	for i := len(liveOrdered) - 1; i >= 0; i-- {
+		have := liveOrdered[i]
+		if _, ok := file.Datatype(have.Name); ok {
+			continue
+		}
+		c := Change{Kind: ChangeRetire, Object: "datatype", Name: have.Name, Destructive: true, liveDatatype: &have}
+		if n := len(idx.fields[have.Name]); n > 0 {
+			c.Details = []string{fmt.Sprintf("its %d field(s) are deleted with it", n)}
+		}
+		add(c)
+	}
+	return plan, nil
+}
+
+// rolesColumn converts role labels to the JSON array of role IDs stored
+// on a field.
+func (idx liveIndex) rolesColumn(labels []string) (types.NullableString, error) {
+	if len(labels) == 0 {
+		return types.NullableString{}, nil
+	}
+	ids := make([]string, 0, len(labels))
+	for _, label := range labels {
+		id, ok := idx.roleIDs[label]
+		if !ok {
+			return types.NullableString{}, fmt.Errorf("unknown role %q", label)
+		}
+		ids = append(ids, string(id))
+	}
+	b, err := json.Marshal(ids)
+	if err != nil {
+		return types.NullableString{}, err
+	}
+	return types.NullableString{String: string(b), Valid: true}, nil
+}
+
+// orderSpecs returns pointers to the file's datatypes with every parent
+// before its children, keeping file order otherwise.
+func orderSpecs(specs []DatatypeSpec) []*DatatypeSpec {
+	out := make([]*DatatypeSpec, 0, len(specs))
+	placed := make(map[string]bool, len(specs))
+	for len(out) < len(specs) {
+		progress := false
+		for i := range specs {
+			spec := &specs[i]
+			if placed[spec.Name] || (spec.Parent != "" && !placed[spec.Parent]) {
+				continue
+			}
+			out = append(out, spec)
+			placed[spec.Name] = true
+			progress = true
+		}
+		// ValidateSchemaFile rejects cycles, so this only guards the loop.
+		if !progress {
+			break
+		}
+	}
+	return out
+}
+
+// commonOrder returns the names in a that also appear in b, in a's order.
+func commonOrder(a, b []string) []string {
+	out := make([]string, 0, len(a))
+	for _, name := range a {
+		if slices.Contains(b, name) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// diffString appends a "key: old -> new" detail when the values differ.
+func diffString(details []string, key, have, want string) []string {
+	if have == want {
+		return details
+	}
+	return append(details, fmt.Sprintf("%s: %q -> %q", key, have, want))
+}
+
+// diffJSON appends a "key: old -> new" detail for canonical JSON values.
+func diffJSON(details []string, key, have, want string) []string {
+	if have == want {
+		return details
+	}
+	return append(details, fmt.Sprintf("%s: %s -> %s", key, have, want))
+}
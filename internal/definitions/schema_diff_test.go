@@ -0,0 +1,359 @@
+package definitions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// fakeSchemaDB is an in-memory SchemaReader and SchemaWriter.
+type fakeSchemaDB struct {
+	datatypes   []db.Datatypes
+	fields      []db.Fields
+	validations []db.Validation
+	roles       []db.Roles
+	content     map[types.DatatypeID]int
+	writes      int
+}
+
+func newFakeSchemaDB() *fakeSchemaDB {
+	return &fakeSchemaDB{
+		roles:   []db.Roles{{RoleID: "role-editor", Label: "editor"}, {RoleID: "role-admin", Label: "admin"}},
+		content: map[types.DatatypeID]int{},
+	}
+}
+
+func (f *fakeSchemaDB) ListDatatypes() (*[]db.Datatypes, error) { return &f.datatypes, nil }
+func (f *fakeSchemaDB) ListFields() (*[]db.Fields, error)       { return &f.fields, nil }
+func (f *fakeSchemaDB) ListValidations() (*[]db.Validation, error) {
+	return &f.validations, nil
+}
+func (f *fakeSchemaDB) ListRoles() (*[]db.Roles, error) { return &f.roles, nil }
+func (f *fakeSchemaDB) ListFieldTypes() (*[]db.FieldTypes, error) {
+	var out []db.FieldTypes
+	for _, t := range []types.FieldType{types.FieldTypeText, types.FieldTypeTextarea, types.FieldTypeNumber, types.FieldTypeSelect, types.FieldTypeTitle, types.FieldTypeRichText} {
+		out = append(out, db.FieldTypes{Type: string(t), Label: string(t)})
+	}
+	return &out, nil
+}
+
+func (f *fakeSchemaDB) ListContentDataByDatatypeID(id types.DatatypeID) (*[]db.ContentData, error) {
+	out := make([]db.ContentData, f.content[id])
+	return &out, nil
+}
+
+func (f *fakeSchemaDB) CreateValidation(_ context.Context, _ audited.AuditContext, p db.CreateValidationParams) (*db.Validation, error) {
+	f.writes++
+	v := db.Validation{ValidationID: types.ValidationID(fmt.Sprintf("val-%d", len(f.validations))), Name: p.Name, Description: p.Description, Config: p.Config, AuthorID: p.AuthorID}
+	f.validations = append(f.validations, v)
+	return &v, nil
+}
+
+func (f *fakeSchemaDB) UpdateValidation(_ context.Context, _ audited.AuditContext, p db.UpdateValidationParams) (*string, error) {
+	f.writes++
+	for i := range f.validations {
+		if f.validations[i].ValidationID == p.ValidationID {
+			f.validations[i].Name, f.validations[i].Description, f.validations[i].Config = p.Name, p.Description, p.Config
+		}
+	}
+	return &p.Name, nil
+}
+
+func (f *fakeSchemaDB) CreateDatatype(_ context.Context, _ audited.AuditContext, p db.CreateDatatypeParams) (*db.Datatypes, error) {
+	f.writes++
+	dt := db.Datatypes{DatatypeID: p.DatatypeID, ParentID: p.ParentID, SortOrder: p.SortOrder, Name: p.Name, Label: p.Label, Type: p.Type, AuthorID: p.AuthorID}
+	f.datatypes = append(f.datatypes, dt)
+	return &dt, nil
+}
+
+func (f *fakeSchemaDB) UpdateDatatype(_ context.Context, _ audited.AuditContext, p db.UpdateDatatypeParams) (*string, error) {
+	f.writes++
+	for i := range f.datatypes {
+		if f.datatypes[i].DatatypeID == p.DatatypeID {
+			f.datatypes[i] = db.Datatypes{DatatypeID: p.DatatypeID, ParentID: p.ParentID, SortOrder: p.SortOrder, Name: p.Name, Label: p.Label, Type: p.Type, AuthorID: p.AuthorID}
+		}
+	}
+	return &p.Name, nil
+}
+
+func (f *fakeSchemaDB) DeleteDatatype(_ context.Context, _ audited.AuditContext, id types.DatatypeID) error {
+	f.writes++
+	f.datatypes = slices.DeleteFunc(f.datatypes, func(dt db.Datatypes) bool { return dt.DatatypeID == id })
+	f.fields = slices.DeleteFunc(f.fields, func(fl db.Fields) bool { return fl.ParentID.ID == id })
+	return nil
+}
+
+func (f *fakeSchemaDB) CreateField(_ context.Context, _ audited.AuditContext, p db.CreateFieldParams) (*db.Fields, error) {
+	f.writes++
+	fl := db.Fields{FieldID: p.FieldID, ParentID: p.ParentID, SortOrder: p.SortOrder, Name: p.Name, Label: p.Label, Data: p.Data,
+		ValidationID: p.ValidationID, UIConfig: p.UIConfig, Type: p.Type, Translatable: p.Translatable, Roles: p.Roles, AuthorID: p.AuthorID}
+	f.fields = append(f.fields, fl)
+	return &fl, nil
+}
+
+func (f *fakeSchemaDB) UpdateField(_ context.Context, _ audited.AuditContext, p db.UpdateFieldParams) (*string, error) {
+	f.writes++
+	for i := range f.fields {
+		if f.fields[i].FieldID == p.FieldID {
+			f.fields[i] = db.Fields{FieldID: p.FieldID, ParentID: p.ParentID, SortOrder: p.SortOrder, Name: p.Name, Label: p.Label, Data: p.Data,
+				ValidationID: p.ValidationID, UIConfig: p.UIConfig, Type: p.Type, Translatable: p.Translatable, Roles: p.Roles, AuthorID: p.AuthorID}
+		}
+	}
+	return &p.Name, nil
+}
+
+func (f *fakeSchemaDB) DeleteField(_ context.Context, _ audited.AuditContext, id types.FieldID) error {
+	f.writes++
+	f.fields = slices.DeleteFunc(f.fields, func(fl db.Fields) bool { return fl.FieldID == id })
+	return nil
+}
+
+func (f *fakeSchemaDB) plan(t *testing.T, yaml string) SchemaPlan {
+	t.Helper()
+	file, err := ParseSchemaFile([]byte(yaml))
+	if err != nil {
+		t.Fatalf("ParseSchemaFile: %v", err)
+	}
+	live, err := LoadLiveSchema(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := DiffSchema(file, live)
+	if err != nil {
+		t.Fatalf("DiffSchema: %v", err)
+	}
+	return plan
+}
+
+func (f *fakeSchemaDB) apply(t *testing.T, plan SchemaPlan, allowDestructive bool) error {
+	t.Helper()
+	ac := audited.Ctx(types.NodeID("node"), "user-1", "test", "cli")
+	_, err := ApplySchemaPlan(context.Background(), f, ac, plan, ApplyOptions{AuthorID: "user-1", AllowDestructive: allowDestructive})
+	return err
+}
+
+func planLines(plan SchemaPlan) []string {
+	var out []string
+	for _, c := range plan.Changes {
+		out = append(out, string(c.Kind)+" "+c.Object+" "+c.Name)
+	}
+	return out
+}
+
+func TestSchemaApply_CreateThenIdempotent(t *testing.T) {
+	t.Parallel()
+	store := newFakeSchemaDB()
+	yaml := strings.Replace(testSchemaYAML, "        validation: required_title\n", "        validation: required_title\n        roles: [editor]\n", 1)
+
+	plan := store.plan(t, yaml)
+	want := []string{
+		"create validation required_title",
+		"create datatype post",
+		"create datatype gallery",
+		"create field post.title",
+		"create field post.status",
+	}
+	if got := planLines(plan); !slices.Equal(got, want) {
+		t.Fatalf("plan\n got %v\nwant %v", got, want)
+	}
+	if err := store.apply(t, plan, false); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	gallery := store.datatypes[1]
+	if !gallery.ParentID.Valid || gallery.ParentID.ID != store.datatypes[0].DatatypeID || gallery.SortOrder != 2 {
+		t.Errorf("gallery = %+v", gallery)
+	}
+	title := store.fields[0]
+	if title.ValidationID.ID != store.validations[0].ValidationID || title.Roles.String != `["role-editor"]` || title.SortOrder != 0 {
+		t.Errorf("title = %+v", title)
+	}
+	if status := store.fields[1]; status.Data != `{"options":["draft","live"]}` || status.UIConfig != `{"help_text":"Publication state"}` || status.SortOrder != 1 {
+		t.Errorf("status = %+v", status)
+	}
+
+	if again := store.plan(t, yaml); !again.Empty() {
+		t.Errorf("second plan not empty:\n%s", again.Render())
+	}
+
+	// Export reproduces the applied file.
+	live, err := LoadLiveSchema(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported, err := ExportSchema(live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	applied, _ := ParseSchemaFile([]byte(yaml))
+	if schemaFileJSON(t, exported) != schemaFileJSON(t, applied) {
+		t.Errorf("export\n%s\nwant\n%s", schemaFileJSON(t, exported), schemaFileJSON(t, applied))
+	}
+}
+
+func TestSchemaDiff_UpdatesAndReorder(t *testing.T) {
+	t.Parallel()
+	store := newFakeSchemaDB()
+	if err := store.apply(t, store.plan(t, testSchemaYAML), false); err != nil {
+		t.Fatal(err)
+	}
+
+	changed := strings.NewReplacer(
+		"label: Post", "label: Article",
+		"Titles are required", "A title is required",
+		"options: [draft, live]", "options: [draft, live, archived]",
+	).Replace(testSchemaYAML)
+	// Swap the two fields.
+	changed = strings.Replace(changed, `      - name: title
+        label: Title
+        type: _title
+        validation: required_title
+`, "", 1)
+	changed = strings.Replace(changed, "          help_text: Publication state\n", "          help_text: Publication state\n      - name: title\n        label: Title\n        type: _title\n        validation: required_title\n", 1)
+
+	plan := store.plan(t, changed)
+	want := []string{
+		"update validation required_title",
+		"update datatype post",
+		"update field post.status",
+		"update field post.title",
+	}
+	if got := planLines(plan); !slices.Equal(got, want) {
+		t.Fatalf("plan\n got %v\nwant %v\n%s", got, want, plan.Render())
+	}
+	rendered := plan.Render()
+	for _, s := range []string{`label: "Post" -> "Article"`, `data: {"options":["draft","live"]} -> {"options":["draft","live","archived"]}`, "position: 1 -> 0", "position: 0 -> 1"} {
+		if !strings.Contains(rendered, s) {
+			t.Errorf("render missing %q:\n%s", s, rendered)
+		}
+	}
+	if len(plan.Destructive()) != 0 {
+		t.Errorf("destructive = %v", plan.Destructive())
+	}
+	if err := store.apply(t, plan, false); err != nil {
+		t.Fatal(err)
+	}
+	if again := store.plan(t, changed); !again.Empty() {
+		t.Errorf("second plan not empty:\n%s", again.Render())
+	}
+}
+
+func TestSchemaDiff_ZeroSortOrdersRoundTrip(t *testing.T) {
+	t.Parallel()
+	// Install() leaves every field at sort order 0; exporting and applying
+	// that schema must not rewrite them.
+	store := newFakeSchemaDB()
+	store.datatypes = []db.Datatypes{{DatatypeID: "dt-1", Name: "page", Label: "Page", Type: "_root"}}
+	for _, name := range []string{"b", "a", "c"} {
+		store.fields = append(store.fields, db.Fields{FieldID: types.FieldID("f-" + name), ParentID: types.NullableDatatypeID{ID: "dt-1", Valid: true},
+			Name: name, Label: name, Type: types.FieldTypeText, Data: types.EmptyJSON, UIConfig: types.EmptyJSON})
+	}
+	live, _ := LoadLiveSchema(store)
+	file, err := ExportSchema(live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := []string{file.Datatypes[0].Fields[0].Name, file.Datatypes[0].Fields[1].Name, file.Datatypes[0].Fields[2].Name}; !slices.Equal(names, []string{"a", "b", "c"}) {
+		t.Errorf("exported order = %v, want field ID order", names)
+	}
+	plan, err := DiffSchema(file, live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Errorf("plan not empty:\n%s", plan.Render())
+	}
+}
+
+func TestSchemaApply_DestructiveGuard(t *testing.T) {
+	t.Parallel()
+	store := newFakeSchemaDB()
+	if err := store.apply(t, store.plan(t, testSchemaYAML), false); err != nil {
+		t.Fatal(err)
+	}
+
+	reduced := `version: 1
+validations:
+  - name: required_title
+    description: Titles are required
+    config:
+      rules:
+        - op: required
+datatypes:
+  - name: post
+    label: Post
+    type: _root
+    fields:
+      - name: title
+        label: Title
+        type: textarea
+        validation: required_title
+`
+	plan := store.plan(t, reduced)
+	want := []string{"update field post.title", "retire field post.status", "retire datatype gallery"}
+	if got := planLines(plan); !slices.Equal(got, want) {
+		t.Fatalf("plan\n got %v\nwant %v", got, want)
+	}
+	if n := len(plan.Destructive()); n != 3 {
+		t.Errorf("destructive = %d, want 3", n)
+	}
+
+	writes := store.writes
+	if err := store.apply(t, plan, false); !errors.Is(err, ErrDestructivePlan) {
+		t.Fatalf("apply without AllowDestructive: %v", err)
+	}
+	if store.writes != writes {
+		t.Error("guarded apply wrote to the database")
+	}
+
+	gallery := store.datatypes[1].DatatypeID
+	store.content[gallery] = 2
+	if err := store.apply(t, plan, true); err == nil || !strings.Contains(err.Error(), "still has 2 content item(s)") {
+		t.Fatalf("apply with content: %v", err)
+	}
+	if store.writes != writes {
+		t.Error("apply with blocked retire wrote to the database")
+	}
+
+	delete(store.content, gallery)
+	if err := store.apply(t, plan, true); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if len(store.datatypes) != 1 || len(store.fields) != 1 || store.fields[0].Type != types.FieldTypeTextarea {
+		t.Errorf("after apply: datatypes %+v fields %+v", store.datatypes, store.fields)
+	}
+	if again := store.plan(t, reduced); !again.Empty() {
+		t.Errorf("second plan not empty:\n%s", again.Render())
+	}
+}
+
+func TestSchemaDiff_RejectsUnknownReferences(t *testing.T) {
+	t.Parallel()
+	store := newFakeSchemaDB()
+	live, _ := LoadLiveSchema(store)
+	for name, yaml := range map[string]string{
+		"field type": "version: 1\ndatatypes:\n  - name: a\n    label: A\n    type: _root\n    fields:\n      - {name: f, label: F, type: color}",
+		"role":       "version: 1\ndatatypes:\n  - name: a\n    label: A\n    type: _root\n    fields:\n      - {name: f, label: F, type: text, roles: [ghost]}",
+	} {
+		file, err := ParseSchemaFile([]byte(yaml))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if _, err := DiffSchema(file, live); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	store.datatypes = []db.Datatypes{{DatatypeID: "1", Name: "dup"}, {DatatypeID: "2", Name: "dup"}}
+	live, _ = LoadLiveSchema(store)
+	if _, err := ExportSchema(live); err == nil || !strings.Contains(err.Error(), `more than one datatype named "dup"`) {
+		t.Errorf("duplicate live datatypes: %v", err)
+	}
+}
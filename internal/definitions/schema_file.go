@@ -0,0 +1,252 @@
+package definitions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaFileVersion is the schema file format version written by export and
+// accepted by diff and apply.
+const SchemaFileVersion = 1
+
+// SchemaFile is the declarative, reviewable form of a live schema. Datatypes
+// and fields are identified by machine name, validations by name and roles
+// by label, so a file exported from one database applies to another.
+type SchemaFile struct {
+	Version     int              `json:"version" yaml:"version"`
+	Validations []ValidationSpec `json:"validations,omitempty" yaml:"validations,omitempty"`
+	Datatypes   []DatatypeSpec   `json:"datatypes" yaml:"datatypes"`
+}
+
+// ValidationSpec is a named validation config shared by fields.
+type ValidationSpec struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Config      any    `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// DatatypeSpec describes one datatype. Parent names another datatype in the
+// same file.
+type DatatypeSpec struct {
+	Name      string      `json:"name" yaml:"name"`
+	Label     string      `json:"label" yaml:"label"`
+	Type      string      `json:"type" yaml:"type"`
+	Parent    string      `json:"parent,omitempty" yaml:"parent,omitempty"`
+	SortOrder int64       `json:"sort_order,omitempty" yaml:"sort_order,omitempty"`
+	Fields    []FieldSpec `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// FieldSpec describes one field. Fields are ordered by their position in
+// the datatype's field list.
+type FieldSpec struct {
+	Name         string          `json:"name" yaml:"name"`
+	Label        string          `json:"label" yaml:"label"`
+	Type         types.FieldType `json:"type" yaml:"type"`
+	Data         any             `json:"data,omitempty" yaml:"data,omitempty"`
+	UIConfig     any             `json:"ui_config,omitempty" yaml:"ui_config,omitempty"`
+	Validation   string          `json:"validation,omitempty" yaml:"validation,omitempty"`
+	Translatable bool            `json:"translatable,omitempty" yaml:"translatable,omitempty"`
+	Roles        []string        `json:"roles,omitempty" yaml:"roles,omitempty"`
+}
+
+// Datatype returns the datatype spec with the given name.
+func (f *SchemaFile) Datatype(name string) (*DatatypeSpec, bool) {
+	for i := range f.Datatypes {
+		if f.Datatypes[i].Name == name {
+			return &f.Datatypes[i], true
+		}
+	}
+	return nil, false
+}
+
+// SchemaFileFormat returns "json" for .json paths and "yaml" otherwise.
+func SchemaFileFormat(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return "json"
+	}
+	return "yaml"
+}
+
+// MarshalSchemaFile encodes f as "yaml" or "json".
+func MarshalSchemaFile(f SchemaFile, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(f, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case "yaml", "yml":
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(f); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("definitions: unsupported schema file format %q", format)
+	}
+}
+
+// ParseSchemaFile decodes a YAML or JSON schema file and checks it for
+// internal consistency. Unknown keys are rejected so typos fail review
+// instead of being silently dropped.
+func ParseSchemaFile(data []byte) (SchemaFile, error) {
+	var f SchemaFile
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return f, fmt.Errorf("definitions: schema file is empty")
+	}
+	if trimmed[0] == '{' {
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&f); err != nil {
+			return f, fmt.Errorf("definitions: parse schema file: %w", err)
+		}
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(trimmed))
+		dec.KnownFields(true)
+		if err := dec.Decode(&f); err != nil {
+			return f, fmt.Errorf("definitions: parse schema file: %w", err)
+		}
+	}
+	if err := ValidateSchemaFile(f); err != nil {
+		return f, err
+	}
+	return f, nil
+}
+
+// ValidateSchemaFile checks names, references and the parent hierarchy.
+func ValidateSchemaFile(f SchemaFile) error {
+	if f.Version != SchemaFileVersion {
+		return fmt.Errorf("definitions: unsupported schema file version %d (want %d)", f.Version, SchemaFileVersion)
+	}
+
+	validations := make(map[string]bool, len(f.Validations))
+	for i, v := range f.Validations {
+		if v.Name == "" {
+			return fmt.Errorf("definitions: validation[%d] has empty name", i)
+		}
+		if validations[v.Name] {
+			return fmt.Errorf("definitions: duplicate validation %q", v.Name)
+		}
+		validations[v.Name] = true
+	}
+
+	parents := make(map[string]string, len(f.Datatypes))
+	for i, dt := range f.Datatypes {
+		if dt.Name == "" {
+			return fmt.Errorf("definitions: datatype[%d] has empty name", i)
+		}
+		if _, dup := parents[dt.Name]; dup {
+			return fmt.Errorf("definitions: duplicate datatype %q", dt.Name)
+		}
+		if dt.Label == "" {
+			return fmt.Errorf("definitions: datatype %q has empty label", dt.Name)
+		}
+		if err := types.ValidateUserDatatypeType(dt.Type); err != nil {
+			return fmt.Errorf("definitions: datatype %q: %w", dt.Name, err)
+		}
+		parents[dt.Name] = dt.Parent
+
+		fields := make(map[string]bool, len(dt.Fields))
+		for j, field := range dt.Fields {
+			if field.Name == "" {
+				return fmt.Errorf("definitions: datatype %q field[%d] has empty name", dt.Name, j)
+			}
+			if fields[field.Name] {
+				return fmt.Errorf("definitions: datatype %q has duplicate field %q", dt.Name, field.Name)
+			}
+			fields[field.Name] = true
+			if field.Label == "" {
+				return fmt.Errorf("definitions: field %s.%s has empty label", dt.Name, field.Name)
+			}
+			if field.Type == "" {
+				return fmt.Errorf("definitions: field %s.%s has empty type", dt.Name, field.Name)
+			}
+			if field.Validation != "" && !validations[field.Validation] {
+				return fmt.Errorf("definitions: field %s.%s references unknown validation %q", dt.Name, field.Name, field.Validation)
+			}
+		}
+	}
+
+	for name, parent := range parents {
+		seen := map[string]bool{name: true}
+		for parent != "" {
+			next, ok := parents[parent]
+			if !ok {
+				return fmt.Errorf("definitions: datatype %q references unknown parent %q", name, parent)
+			}
+			if seen[parent] {
+				return fmt.Errorf("definitions: datatype %q has a circular parent chain", name)
+			}
+			seen[parent] = true
+			parent = next
+		}
+	}
+	return nil
+}
+
+// canonicalJSON renders a decoded JSON/YAML value as compact JSON with
+// sorted keys. Nil and empty objects both render as types.EmptyJSON; a
+// string is raw column text and is returned unchanged.
+func canonicalJSON(v any) (string, error) {
+	switch s := v.(type) {
+	case nil:
+		return types.EmptyJSON, nil
+	case string:
+		return s, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	if s := string(b); s != "{}" && s != "null" {
+		return s, nil
+	}
+	return types.EmptyJSON, nil
+}
+
+// decodeJSONColumn parses a JSON text column for export. Empty objects
+// decode to nil so they are omitted; text that is not a JSON object or
+// array is kept as a raw string.
+func decodeJSONColumn(s string) any {
+	if s == "" || s == types.EmptyJSON {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return s
+	}
+	switch val := v.(type) {
+	case map[string]any:
+		if len(val) == 0 {
+			return nil
+		}
+		return val
+	case []any:
+		return val
+	default:
+		return s
+	}
+}
+
+// canonicalColumn normalizes a JSON text column for comparison with a
+// file value.
+func canonicalColumn(s string) string {
+	out, err := canonicalJSON(decodeJSONColumn(s))
+	if err != nil {
+		return s
+	}
+	return out
+}
@@ -0,0 +1,112 @@
+package definitions
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testSchemaYAML = `version: 1
+validations:
+  - name: required_title
+    description: Titles are required
+    config:
+      rules:
+        - op: required
+datatypes:
+  - name: post
+    label: Post
+    type: _root
+    fields:
+      - name: title
+        label: Title
+        type: _title
+        validation: required_title
+      - name: status
+        label: Status
+        type: select
+        data:
+          options: [draft, live]
+        ui_config:
+          help_text: Publication state
+  - name: gallery
+    label: Gallery
+    type: _nested_root
+    parent: post
+    sort_order: 2
+`
+
+func TestParseSchemaFile_YAMLAndJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+	f, err := ParseSchemaFile([]byte(testSchemaYAML))
+	if err != nil {
+		t.Fatalf("ParseSchemaFile: %v", err)
+	}
+	post, ok := f.Datatype("post")
+	if !ok || len(post.Fields) != 2 {
+		t.Fatalf("post = %+v", post)
+	}
+	if data, _ := canonicalJSON(post.Fields[1].Data); data != `{"options":["draft","live"]}` {
+		t.Errorf("status data = %s", data)
+	}
+	if ui, _ := canonicalJSON(post.Fields[1].UIConfig); ui != `{"help_text":"Publication state"}` {
+		t.Errorf("status ui_config = %s", ui)
+	}
+
+	for _, format := range []string{"yaml", "json"} {
+		out, err := MarshalSchemaFile(f, format)
+		if err != nil {
+			t.Fatalf("MarshalSchemaFile(%s): %v", format, err)
+		}
+		again, err := ParseSchemaFile(out)
+		if err != nil {
+			t.Fatalf("%s round trip: %v\n%s", format, err, out)
+		}
+		if !reflect.DeepEqual(schemaFileJSON(t, f), schemaFileJSON(t, again)) {
+			t.Errorf("%s round trip changed the file:\n%s", format, out)
+		}
+	}
+}
+
+func TestParseSchemaFile_Invalid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name, input, want string
+	}{
+		{"empty", "", "empty"},
+		{"version", "version: 2\ndatatypes: []", "version 2"},
+		{"unknown key", "version: 1\ndatatypes:\n  - name: a\n    label: A\n    type: _root\n    lable: typo", "lable"},
+		{"unknown json key", `{"version":1,"datatypes":[],"extra":true}`, "extra"},
+		{"duplicate datatype", "version: 1\ndatatypes:\n  - {name: a, label: A, type: _root}\n  - {name: a, label: A, type: _root}", `duplicate datatype "a"`},
+		{"unknown parent", "version: 1\ndatatypes:\n  - {name: a, label: A, type: _root, parent: b}", `unknown parent "b"`},
+		{"cycle", "version: 1\ndatatypes:\n  - {name: a, label: A, type: x, parent: b}\n  - {name: b, label: B, type: x, parent: a}", "circular"},
+		{"duplicate field", "version: 1\ndatatypes:\n  - name: a\n    label: A\n    type: _root\n    fields:\n      - {name: f, label: F, type: text}\n      - {name: f, label: F, type: text}", `duplicate field "f"`},
+		{"unknown validation", "version: 1\ndatatypes:\n  - name: a\n    label: A\n    type: _root\n    fields:\n      - {name: f, label: F, type: text, validation: nope}", `unknown validation "nope"`},
+	}
+	for _, tt := range tests {
+		_, err := ParseSchemaFile([]byte(tt.input))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want containing %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestSchemaFileFormat(t *testing.T) {
+	t.Parallel()
+	for path, want := range map[string]string{"schema.json": "json", "schema.JSON": "json", "schema.yaml": "yaml", "schema.yml": "yaml", "": "yaml"} {
+		if got := SchemaFileFormat(path); got != want {
+			t.Errorf("SchemaFileFormat(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+// schemaFileJSON normalizes a SchemaFile for comparison; YAML and JSON
+// decode numbers and maps into different Go types.
+func schemaFileJSON(t *testing.T, f SchemaFile) string {
+	t.Helper()
+	out, err := MarshalSchemaFile(f, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
@@ -5,6 +5,7 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -78,12 +79,18 @@ func (c *DeployClient) Health(ctx context.Context) (*HealthResponse, error) {
 // Export calls POST /api/v1/deploy/export on the remote instance and returns the SyncPayload.
 func (c *DeployClient) Export(ctx context.Context, opts ExportOptions) (*SyncPayload, error) {
 	var body any
-	if len(opts.Tables) > 0 || opts.IncludePlugins {
+	if len(opts.Tables) > 0 || opts.IncludePlugins || len(opts.Routes) > 0 || len(opts.Datatypes) > 0 || opts.Strategy != "" {
 		names := make([]string, len(opts.Tables))
 		for i, t := range opts.Tables {
 			names[i] = string(t)
 		}
-		body = exportRequest{Tables: names, IncludePlugins: opts.IncludePlugins}
+		body = exportRequest{
+			Tables:         names,
+			IncludePlugins: opts.IncludePlugins,
+			Routes:         opts.Routes,
+			Datatypes:      opts.Datatypes,
+			Strategy:       opts.Strategy,
+		}
 	}
 
 	var buf bytes.Buffer
@@ -161,6 +168,12 @@ func (c *DeployClient) doImport(ctx context.Context, payload *SyncPayload, dryRu
 
 	var result SyncResult
 	if err := c.do(req, &result); err != nil {
+		// A merge with conflicts answers 409 with the conflict report.
+		var ce *ClientError
+		if errors.As(err, &ce) && ce.StatusCode == http.StatusConflict &&
+			json.Unmarshal([]byte(ce.Body), &result) == nil && len(result.Conflicts) > 0 {
+			return &result, fmt.Errorf("%w: %d", ErrUnresolvedConflicts, len(result.Conflicts))
+		}
 		return nil, err
 	}
 	return &result, nil
@@ -102,8 +102,11 @@ func Pull(ctx context.Context, cfg config.Config, driver db.DbDriver, envName st
 		"tables", len(payload.Tables),
 		"schema_version", payload.Manifest.SchemaVersion[:12]+"...")
 
+	// Resolutions are decided locally, so they ride on the received payload.
+	payload.Resolutions = opts.Resolutions
+
 	if dryRun {
-		return BuildDryRunResult(payload, driver), nil
+		return DryRunPayload(ctx, cfg, driver, payload), nil
 	}
 
 	// Import locally.
@@ -135,6 +138,19 @@ func Push(ctx context.Context, cfg config.Config, driver db.DbDriver, envName st
 		"local_version", utility.GetCurrentVersion(),
 		"remote_node", health.NodeID)
 
+	// Re-encrypt secrets for the remote when its key is configured.
+	if opts.TargetKeyring == nil {
+		keyring, kErr := envKeyring(cfg, envName)
+		if kErr != nil {
+			return nil, kErr
+		}
+		opts.TargetKeyring = keyring
+	}
+
+	if opts.SourceNodeID == "" {
+		opts.SourceNodeID = cfg.Node_ID
+	}
+
 	// Export locally.
 	log.Info("deploy push: exporting local data")
 	payload, err := ExportPayload(ctx, driver, opts)
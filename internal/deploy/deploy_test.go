@@ -1881,6 +1881,10 @@ func (f *stubDeployOps) QueryAllRows(_ context.Context, _ db.DBTable) ([]string,
 	return nil, nil, fmt.Errorf("not implemented")
 }
 
+func (f *stubDeployOps) QueryRowsAfter(_ context.Context, _ db.DBTable, _ string, _ int) ([]string, [][]any, error) {
+	return nil, nil, fmt.Errorf("not implemented")
+}
+
 // sqliteTestDeployOps wraps a *sql.DB for SQLite-compatible deploy ops in tests.
 type sqliteTestDeployOps struct {
 	pool *sql.DB
@@ -1954,6 +1958,10 @@ func (s *sqliteTestDeployOps) QueryAllRows(_ context.Context, _ db.DBTable) ([]s
 	return nil, nil, fmt.Errorf("not implemented in test stub")
 }
 
+func (s *sqliteTestDeployOps) QueryRowsAfter(_ context.Context, _ db.DBTable, _ string, _ int) ([]string, [][]any, error) {
+	return nil, nil, fmt.Errorf("not implemented in test stub")
+}
+
 // fakeDbDriver is a minimal DbDriver mock for ExportPayload tests.
 // It only implements the methods actually called during export of Datatype table.
 type fakeDbDriver struct {
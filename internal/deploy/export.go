@@ -45,74 +45,60 @@ var tableListFuncs = map[db.DBTable]tableListFunc{
 	db.Media_folder:       func(d db.DbDriver) (any, error) { return d.ListMediaFolders() },
 	db.Admin_media_folder: func(d db.DbDriver) (any, error) { return d.ListAdminMediaFolders() },
 	// Identity
-	db.User:             func(d db.DbDriver) (any, error) { return d.ListUsers() },
-	db.Role:             func(d db.DbDriver) (any, error) { return d.ListRoles() },
-	db.Permission:       func(d db.DbDriver) (any, error) { return d.ListPermissions() },
-	db.Role_permissions: func(d db.DbDriver) (any, error) { return d.ListRolePermissions() },
-	db.Session:          func(d db.DbDriver) (any, error) { return d.ListSessions() },
-	db.Token:            func(d db.DbDriver) (any, error) { return d.ListTokens() },
+	db.User:               func(d db.DbDriver) (any, error) { return d.ListUsers() },
+	db.Role:               func(d db.DbDriver) (any, error) { return d.ListRoles() },
+	db.Permission:         func(d db.DbDriver) (any, error) { return d.ListPermissions() },
+	db.Role_permissions:   func(d db.DbDriver) (any, error) { return d.ListRolePermissions() },
+	db.User_roles:         func(d db.DbDriver) (any, error) { return d.ListUserRoles() },
+	db.User_groups:        func(d db.DbDriver) (any, error) { return d.ListUserGroups() },
+	db.User_group_members: func(d db.DbDriver) (any, error) { return d.ListUserGroupMembers() },
+	db.User_group_roles:   func(d db.DbDriver) (any, error) { return d.ListUserGroupRoles() },
+	db.Session:            func(d db.DbDriver) (any, error) { return d.ListSessions() },
+	db.Token:              func(d db.DbDriver) (any, error) { return d.ListTokens() },
 	// System
-	db.LocaleT:             func(d db.DbDriver) (any, error) { return d.ListLocales() },
-	db.WebhookT:            func(d db.DbDriver) (any, error) { return d.ListWebhooks() },
-	db.Webhook_deliveries:  func(d db.DbDriver) (any, error) { return d.ListWebhookDeliveries() },
-	db.PipelineT:           func(d db.DbDriver) (any, error) { return d.ListPipelines() },
-	db.Table:               func(d db.DbDriver) (any, error) { return d.ListTables() },
+	db.LocaleT:            func(d db.DbDriver) (any, error) { return d.ListLocales() },
+	db.WebhookT:           func(d db.DbDriver) (any, error) { return d.ListWebhooks() },
+	db.Webhook_deliveries: func(d db.DbDriver) (any, error) { return d.ListWebhookDeliveries() },
+	db.PipelineT:          func(d db.DbDriver) (any, error) { return d.ListPipelines() },
+	db.Table:              func(d db.DbDriver) (any, error) { return d.ListTables() },
 }
 
 // ExportPayload exports data from the driver into a SyncPayload.
 // If opts.Tables is nil or empty, DefaultTableSet is used.
+// If opts.Routes or opts.Datatypes is set, only those route trees and
+// datatype schemas are exported and the manifest records the scope.
 // If opts.IncludePlugins is true, registered plugin tables are discovered and included.
 func ExportPayload(ctx context.Context, driver db.DbDriver, opts ExportOptions) (*SyncPayload, error) {
 	tables := opts.Tables
 	if len(tables) == 0 {
 		tables = DefaultTableSet
 	}
+	scope, err := resolveScope(driver, opts)
+	if err != nil {
+		return nil, fmt.Errorf("export scope: %w", err)
+	}
+	if scope != nil {
+		tables = scopeTables(scope)
+	}
 
-	tableDataMap := make(map[string]TableData, len(tables))
+	tableDataMap, err := exportTables(ctx, driver, tables)
+	if err != nil {
+		return nil, err
+	}
+	if scope != nil {
+		filterScope(tableDataMap, scope)
+	}
 	tableNames := make([]string, 0, len(tables))
 	rowCounts := make(map[string]int, len(tables))
-
-	// DeployOps is lazily created only when needed for QueryAllRows fallback.
-	var ops db.DeployOps
-
 	for _, t := range tables {
-		name := string(t)
-
-		if listFn, ok := tableListFuncs[t]; ok {
-			// Typed export via struct serialization.
-			slicePtr, err := listFn(driver)
-			if err != nil {
-				return nil, fmt.Errorf("export %s: %w", t, err)
-			}
-			td, err := structSliceToTableData(slicePtr)
-			if err != nil {
-				return nil, fmt.Errorf("export serialize %s: %w", t, err)
-			}
-			tableDataMap[name] = td
-			tableNames = append(tableNames, name)
-			rowCounts[name] = len(td.Rows)
-		} else {
-			// Fallback to catalog-based export (same as plugin tables).
-			if ops == nil {
-				var err error
-				ops, err = db.NewDeployOps(driver)
-				if err != nil {
-					return nil, fmt.Errorf("export: create deploy ops: %w", err)
-				}
-			}
-			cols, rows, err := ops.QueryAllRows(ctx, t)
-			if err != nil {
-				return nil, fmt.Errorf("export %s (QueryAllRows): %w", t, err)
-			}
-			tableDataMap[name] = TableData{Columns: cols, Rows: rows}
-			tableNames = append(tableNames, name)
-			rowCounts[name] = len(rows)
-		}
+		tableNames = append(tableNames, string(t))
+		rowCounts[string(t)] = len(tableDataMap[string(t)].Rows)
 	}
 
 	// Export plugin tables via catalog introspection (no struct type needed).
+	// Scoped exports leave them out.
 	var pluginNames []string
-	if opts.IncludePlugins {
+	if opts.IncludePlugins && scope == nil {
 		ops, err := db.NewDeployOps(driver)
 		if err != nil {
 			return nil, fmt.Errorf("export: create deploy ops for plugin tables: %w", err)
@@ -138,6 +124,11 @@ func ExportPayload(ctx context.Context, driver db.DbDriver, opts ExportOptions)
 		}
 	}
 
+	redacted, err := protectSecrets(tableDataMap, opts.TargetKeyring)
+	if err != nil {
+		return nil, fmt.Errorf("export secrets: %w", err)
+	}
+
 	userRefs := collectUserRefs(tableDataMap, driver)
 
 	schemaVersion := computeSchemaVersion(tableDataMap)
@@ -146,24 +137,74 @@ func ExportPayload(ctx context.Context, driver db.DbDriver, opts ExportOptions)
 		return nil, fmt.Errorf("export compute hash: %w", err)
 	}
 
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = StrategyOverwrite
+	}
+
 	manifest := SyncManifest{
 		SchemaVersion: schemaVersion,
 		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+		SourceNodeID:  opts.SourceNodeID,
 		Version:       utility.GetCurrentVersion(),
-		Strategy:      StrategyOverwrite,
+		Strategy:      strategy,
 		Tables:        tableNames,
 		RowCounts:     rowCounts,
 		PayloadHash:   payloadHash,
 		PluginTables:  pluginNames,
+
+		RedactedColumns: redacted,
+		Scope:           scope,
 	}
 
 	return &SyncPayload{
-		Manifest: manifest,
-		Tables:   tableDataMap,
-		UserRefs: userRefs,
+		Manifest:    manifest,
+		Tables:      tableDataMap,
+		UserRefs:    userRefs,
+		Resolutions: opts.Resolutions,
 	}, nil
 }
 
+// exportTables reads every row of the given core tables.
+func exportTables(ctx context.Context, driver db.DbDriver, tables []db.DBTable) (map[string]TableData, error) {
+	tableDataMap := make(map[string]TableData, len(tables))
+
+	// DeployOps is lazily created only when needed for QueryAllRows fallback.
+	var ops db.DeployOps
+
+	for _, t := range tables {
+		name := string(t)
+
+		if listFn, ok := tableListFuncs[t]; ok {
+			// Typed export via struct serialization.
+			slicePtr, err := listFn(driver)
+			if err != nil {
+				return nil, fmt.Errorf("export %s: %w", t, err)
+			}
+			td, err := structSliceToTableData(slicePtr)
+			if err != nil {
+				return nil, fmt.Errorf("export serialize %s: %w", t, err)
+			}
+			tableDataMap[name] = td
+		} else {
+			// Fallback to catalog-based export (same as plugin tables).
+			if ops == nil {
+				var err error
+				ops, err = db.NewDeployOps(driver)
+				if err != nil {
+					return nil, fmt.Errorf("export: create deploy ops: %w", err)
+				}
+			}
+			cols, rows, err := ops.QueryAllRows(ctx, t)
+			if err != nil {
+				return nil, fmt.Errorf("export %s (QueryAllRows): %w", t, err)
+			}
+			tableDataMap[name] = TableData{Columns: cols, Rows: rows}
+		}
+	}
+	return tableDataMap, nil
+}
+
 // isPluginTable reports whether a table name follows the plugin table naming convention.
 func isPluginTable(name string) bool {
 	return db.IsValidPluginTableName(name)
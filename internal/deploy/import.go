@@ -19,7 +19,9 @@ import (
 // importMu prevents concurrent imports.
 var importMu sync.Mutex
 
-// ImportPayload imports a SyncPayload into the target database using the overwrite strategy.
+// ImportPayload imports a SyncPayload into the target database. A full
+// overwrite payload replaces its tables; scoped and merge payloads are
+// applied row by row (see importRows).
 func ImportPayload(ctx context.Context, cfg config.Config, driver db.DbDriver, payload *SyncPayload, skipBackup bool) (*SyncResult, error) {
 	start := time.Now()
 	log := utility.DefaultLogger
@@ -54,6 +56,10 @@ func ImportPayload(ctx context.Context, cfg config.Config, driver db.DbDriver, p
 	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
 	defer cancel()
 
+	if payload.Manifest.Scope != nil || payload.Manifest.Strategy == StrategyMerge {
+		return importRows(ctx, cfg, driver, payload, skipBackup, start)
+	}
+
 	// Save pre-import snapshot.
 	snapshotDir := SnapshotDir(cfg)
 	log.Info("deploy import: saving snapshot", "dir", snapshotDir)
@@ -88,6 +94,13 @@ func ImportPayload(ctx context.Context, cfg config.Config, driver db.DbDriver, p
 	var warnings []string
 
 	err = ops.ImportAtomic(ctx, func(ctx context.Context, ex db.Executor) error {
+		// Keep this instance's secrets for columns the export redacted.
+		if len(payload.Manifest.RedactedColumns) > 0 {
+			if rErr := restoreRedactedSecrets(ctx, ex, db.DialectFromString(string(cfg.Db_Driver)), payload); rErr != nil {
+				return rErr
+			}
+		}
+
 		// Truncate tables in reverse FK-dependency order.
 		for i := len(FullTableSet) - 1; i >= 0; i-- {
 			t := FullTableSet[i]
@@ -175,36 +188,10 @@ func ImportPayload(ctx context.Context, cfg config.Config, driver db.DbDriver, p
 			}
 		}
 
-		// Post-insert FK verification (collect as warnings, don't fail).
-		// Use a savepoint so verification query errors don't poison the
-		// PostgreSQL transaction (PG aborts the entire tx on any error).
-		log.Info("deploy import: verifying foreign keys")
-		_, _ = ex.ExecContext(ctx, "SAVEPOINT fk_check")
-		violations, vErr := ops.VerifyForeignKeys(ctx, ex)
-		if vErr != nil {
-			_, _ = ex.ExecContext(ctx, "ROLLBACK TO SAVEPOINT fk_check")
-			warnings = append(warnings, fmt.Sprintf("FK verification error: %v", vErr))
-			log.Error("deploy import: FK verification error", vErr)
-		} else {
-			_, _ = ex.ExecContext(ctx, "RELEASE SAVEPOINT fk_check")
-			for _, v := range violations {
-				warnings = append(warnings, fmt.Sprintf("FK violation in %s (row %s -> %s)", v.Table, v.RowID, v.Parent))
-			}
-			if len(violations) > 0 {
-				log.Info("deploy import: FK violations found", "count", len(violations))
-			}
-		}
-
-		// Remap missing user refs to the first admin after all data is inserted.
-		if len(payload.UserRefs) > 0 {
-			log.Info("deploy import: resolving user refs", "count", len(payload.UserRefs))
-			remapped, rErr := resolveUserRefs(ctx, ex, ops, payload.UserRefs)
-			if rErr != nil {
-				return fmt.Errorf("resolve user refs: %w", rErr)
-			}
-			for oldID, newID := range remapped {
-				warnings = append(warnings, fmt.Sprintf("user %s not found, remapped to %s", oldID, newID))
-			}
+		finishWarnings, fErr := finishImport(ctx, ex, ops, payload)
+		warnings = append(warnings, finishWarnings...)
+		if fErr != nil {
+			return fErr
 		}
 
 		log.Info("deploy import: atomic block complete, committing")
@@ -225,25 +212,7 @@ func ImportPayload(ctx context.Context, cfg config.Config, driver db.DbDriver, p
 		}, err
 	}
 
-	// Record synthetic change event for audit trail.
-	manifestJSON, mErr := json.Marshal(payload.Manifest)
-	if mErr != nil {
-		warnings = append(warnings, fmt.Sprintf("marshal audit manifest: %v", mErr))
-	} else {
-		if _, ceErr := driver.RecordChangeEvent(db.RecordChangeEventParams{
-			EventID:      types.NewEventID(),
-			HlcTimestamp: types.HLCNow(),
-			NodeID:       types.NodeID(cfg.Node_ID),
-			TableName:    "_deploy_sync",
-			RecordID:     types.NewULID().String(),
-			Operation:    types.OpInsert,
-			Action:       types.ActionCreate,
-			NewValues:    types.JSONData{Data: json.RawMessage(manifestJSON), Valid: true},
-		}); ceErr != nil {
-			utility.DefaultLogger.Error("record deploy change event", ceErr)
-			warnings = append(warnings, fmt.Sprintf("audit event recording failed: %v", ceErr))
-		}
-	}
+	warnings = append(warnings, recordSyncEvent(cfg, driver, payload)...)
 
 	// Build result.
 	tablesAffected := make([]string, 0, len(payload.Tables))
@@ -265,6 +234,173 @@ func ImportPayload(ctx context.Context, cfg config.Config, driver db.DbDriver, p
 	}, nil
 }
 
+// importRows applies a scoped or merge payload row by row. Rows outside
+// the payload's scope are not touched. A merge that finds conflicts without
+// resolutions writes nothing and returns ErrUnresolvedConflicts with the
+// conflicts in the result. The caller holds importMu.
+func importRows(ctx context.Context, cfg config.Config, driver db.DbDriver, payload *SyncPayload, skipBackup bool, start time.Time) (*SyncResult, error) {
+	log := utility.DefaultLogger
+	strategy := payload.Manifest.Strategy
+	if strategy == "" {
+		strategy = StrategyOverwrite
+	}
+
+	// Plan before saving the snapshot: the merge base is the newest one.
+	plan, err := planRows(ctx, cfg, driver, payload)
+	if err != nil {
+		return nil, fmt.Errorf("plan import: %w", err)
+	}
+	result := &SyncResult{
+		Strategy:       strategy,
+		TablesAffected: make([]string, 0, len(plan.tables)),
+		RowCounts:      make(map[string]int, len(plan.tables)),
+		Changes:        plan.changes(),
+		Conflicts:      plan.conflicts,
+		BaseSnapshotID: plan.baseID,
+		Warnings:       plan.warnings,
+	}
+	for _, tp := range plan.tables {
+		result.TablesAffected = append(result.TablesAffected, string(tp.table))
+		result.RowCounts[string(tp.table)] = len(payload.Tables[string(tp.table)].Rows)
+	}
+	if len(plan.conflicts) > 0 {
+		result.Duration = time.Since(start).String()
+		return result, fmt.Errorf("%w: %d", ErrUnresolvedConflicts, len(plan.conflicts))
+	}
+
+	snapshotDir := SnapshotDir(cfg)
+	log.Info("deploy import: saving snapshot", "dir", snapshotDir)
+	snapshotID, err := SaveSnapshot(snapshotDir, payload)
+	if err != nil {
+		return nil, fmt.Errorf("save pre-import snapshot to %s: %w", snapshotDir, err)
+	}
+	result.SnapshotID = snapshotID
+
+	if !skipBackup {
+		path, _, bErr := backup.CreateFullBackup(cfg, driver)
+		if bErr != nil {
+			return nil, fmt.Errorf("pre-import backup (backup_option=%q, db_driver=%s): %w",
+				cfg.Backup_Option, cfg.Db_Driver, bErr)
+		}
+		result.BackupPath = path
+	}
+
+	ops, err := db.NewDeployOps(driver)
+	if err != nil {
+		return nil, fmt.Errorf("create deploy ops: %w", err)
+	}
+
+	log.Info("deploy import: applying rows", "strategy", string(strategy), "base", plan.baseID)
+	err = ops.ImportAtomic(ctx, func(ctx context.Context, ex db.Executor) error {
+		if aErr := applyPlan(ctx, ex, db.DialectFromString(string(cfg.Db_Driver)), ops, plan); aErr != nil {
+			return aErr
+		}
+		finishWarnings, fErr := finishImport(ctx, ex, ops, payload)
+		result.Warnings = append(result.Warnings, finishWarnings...)
+		return fErr
+	})
+	result.Duration = time.Since(start).String()
+	if err != nil {
+		log.Error("deploy import: row import failed (rolled back)", err)
+		result.Errors = []SyncError{{Phase: "import", Message: err.Error()}}
+		return result, err
+	}
+
+	result.Warnings = append(result.Warnings, recordSyncEvent(cfg, driver, payload)...)
+	result.Success = true
+	return result, nil
+}
+
+// DryRunPayload validates a payload against the target and reports what an
+// import would do, without writing. Scoped and merge payloads are planned
+// row by row and report their changes and conflicts.
+func DryRunPayload(ctx context.Context, cfg config.Config, driver db.DbDriver, payload *SyncPayload) *SyncResult {
+	result := BuildDryRunResult(payload, driver)
+	if payload.Manifest.Scope == nil && payload.Manifest.Strategy != StrategyMerge {
+		return result
+	}
+	if payload.Manifest.Strategy != "" {
+		result.Strategy = payload.Manifest.Strategy
+	}
+	plan, err := planRows(ctx, cfg, driver, payload)
+	if err != nil {
+		result.Success = false
+		result.Errors = append(result.Errors, SyncError{Phase: "validate", Message: err.Error()})
+		return result
+	}
+	result.Changes = plan.changes()
+	result.Conflicts = plan.conflicts
+	result.BaseSnapshotID = plan.baseID
+	result.Warnings = append(result.Warnings, plan.warnings...)
+	if len(plan.conflicts) > 0 {
+		result.Success = false
+	}
+	return result
+}
+
+// finishImport runs after the rows are written, inside the import
+// transaction: it verifies foreign keys and remaps missing user refs.
+func finishImport(ctx context.Context, ex db.Executor, ops db.DeployOps, payload *SyncPayload) ([]string, error) {
+	log := utility.DefaultLogger
+	var warnings []string
+
+	// Post-insert FK verification (collect as warnings, don't fail).
+	// Use a savepoint so verification query errors don't poison the
+	// PostgreSQL transaction (PG aborts the entire tx on any error).
+	log.Info("deploy import: verifying foreign keys")
+	_, _ = ex.ExecContext(ctx, "SAVEPOINT fk_check")
+	violations, vErr := ops.VerifyForeignKeys(ctx, ex)
+	if vErr != nil {
+		_, _ = ex.ExecContext(ctx, "ROLLBACK TO SAVEPOINT fk_check")
+		warnings = append(warnings, fmt.Sprintf("FK verification error: %v", vErr))
+		log.Error("deploy import: FK verification error", vErr)
+	} else {
+		_, _ = ex.ExecContext(ctx, "RELEASE SAVEPOINT fk_check")
+		for _, v := range violations {
+			warnings = append(warnings, fmt.Sprintf("FK violation in %s (row %s -> %s)", v.Table, v.RowID, v.Parent))
+		}
+		if len(violations) > 0 {
+			log.Info("deploy import: FK violations found", "count", len(violations))
+		}
+	}
+
+	// Remap missing user refs to the first admin after all data is inserted.
+	if len(payload.UserRefs) > 0 {
+		log.Info("deploy import: resolving user refs", "count", len(payload.UserRefs))
+		remapped, rErr := resolveUserRefs(ctx, ex, ops, payload.UserRefs)
+		if rErr != nil {
+			return warnings, fmt.Errorf("resolve user refs: %w", rErr)
+		}
+		for oldID, newID := range remapped {
+			warnings = append(warnings, fmt.Sprintf("user %s not found, remapped to %s", oldID, newID))
+		}
+	}
+	return warnings, nil
+}
+
+// recordSyncEvent records a synthetic change event for the audit trail and
+// returns any problems as warnings.
+func recordSyncEvent(cfg config.Config, driver db.DbDriver, payload *SyncPayload) []string {
+	manifestJSON, mErr := json.Marshal(payload.Manifest)
+	if mErr != nil {
+		return []string{fmt.Sprintf("marshal audit manifest: %v", mErr)}
+	}
+	if _, ceErr := driver.RecordChangeEvent(db.RecordChangeEventParams{
+		EventID:      types.NewEventID(),
+		HlcTimestamp: types.HLCNow(),
+		NodeID:       types.NodeID(cfg.Node_ID),
+		TableName:    "_deploy_sync",
+		RecordID:     types.NewULID().String(),
+		Operation:    types.OpInsert,
+		Action:       types.ActionCreate,
+		NewValues:    types.JSONData{Data: json.RawMessage(manifestJSON), Valid: true},
+	}); ceErr != nil {
+		utility.DefaultLogger.Error("record deploy change event", ceErr)
+		return []string{fmt.Sprintf("audit event recording failed: %v", ceErr)}
+	}
+	return nil
+}
+
 // resolveUserRefs checks that every user_id in userRefs exists in the users
 // table. Missing users are remapped to the first admin user via UPDATE on
 // content_data, content_fields, admin_content_data, and admin_content_fields.
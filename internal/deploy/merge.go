@@ -0,0 +1,396 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+)
+
+// ErrUnresolvedConflicts is returned by a merge import that found conflicts
+// without a resolution. Nothing is written; the SyncResult lists them.
+var ErrUnresolvedConflicts = errors.New("unresolved merge conflicts")
+
+// autoMergeColumns are bookkeeping columns that take the greater value when
+// both sides changed them, instead of conflicting.
+var autoMergeColumns = map[string]bool{
+	"date_modified": true,
+	"revision":      true,
+}
+
+// rowUpdate sets columns of one existing row.
+type rowUpdate struct {
+	id  string
+	set map[string]any
+}
+
+// tablePlan holds the row writes a scoped or merge import makes to one table.
+// The first column is the primary key.
+type tablePlan struct {
+	table   db.DBTable
+	columns []string
+	inserts [][]any
+	updates []rowUpdate
+	deletes []string
+}
+
+// syncPlan is the outcome of comparing a payload with the target rows.
+type syncPlan struct {
+	tables    []tablePlan
+	conflicts []MergeConflict
+	baseID    string
+	warnings  []string
+}
+
+// changes counts the planned writes per table, omitting untouched tables.
+func (p *syncPlan) changes() map[string]TableChanges {
+	out := make(map[string]TableChanges)
+	for _, tp := range p.tables {
+		c := TableChanges{Inserted: len(tp.inserts), Updated: len(tp.updates), Deleted: len(tp.deletes)}
+		if c != (TableChanges{}) {
+			out[string(tp.table)] = c
+		}
+	}
+	return out
+}
+
+// planRows compares a scoped or merge payload with the target database and
+// returns the row writes that apply it. An overwrite payload mirrors the
+// source inside its scope. A merge payload is three-way merged against the
+// newest usable snapshot in SnapshotDir; see FindBaseSnapshot.
+func planRows(ctx context.Context, cfg config.Config, driver db.DbDriver, payload *SyncPayload) (*syncPlan, error) {
+	plan := &syncPlan{}
+
+	var tables []db.DBTable
+	for _, t := range FullTableSet {
+		if _, ok := payload.Tables[string(t)]; ok {
+			tables = append(tables, t)
+		}
+	}
+	for name := range payload.Tables {
+		if isPluginTable(name) {
+			plan.warnings = append(plan.warnings, fmt.Sprintf("plugin table %q is only synced by a full overwrite; skipping", name))
+		}
+	}
+	sort.Strings(plan.warnings)
+
+	target, err := exportTables(ctx, driver, tables)
+	if err != nil {
+		return nil, fmt.Errorf("read target rows: %w", err)
+	}
+	scope := payload.Manifest.Scope
+	if scope != nil {
+		filterScope(target, scope)
+	}
+
+	merge := payload.Manifest.Strategy == StrategyMerge
+	var base map[string]TableData
+	if merge {
+		snap, id, fErr := FindBaseSnapshot(SnapshotDir(cfg), payload)
+		if fErr != nil {
+			return nil, fmt.Errorf("find base snapshot: %w", fErr)
+		}
+		if snap == nil {
+			plan.warnings = append(plan.warnings, "no common snapshot found; rows that differ on both sides are conflicts")
+		} else {
+			base = snap.Tables
+			if scope != nil && snap.Manifest.Scope == nil {
+				filterScope(base, scope)
+			}
+			plan.baseID = id
+		}
+	}
+
+	resolutions := make(map[string]string, len(payload.Resolutions))
+	for _, r := range payload.Resolutions {
+		resolutions[conflictKey(r.Table, r.RowID, r.Column)] = r.Take
+	}
+
+	for _, t := range tables {
+		name := string(t)
+		src := payload.Tables[name]
+		tp := tablePlan{table: t, columns: src.Columns}
+		if len(tp.columns) == 0 {
+			tp.columns = target[name].Columns
+		}
+		if len(tp.columns) == 0 {
+			continue
+		}
+		skip := stringSet(payload.Manifest.RedactedColumns[name])
+
+		s := indexRows(src)
+		tg := indexRows(target[name])
+		var b map[string]map[string]any
+		if base != nil {
+			b = indexRows(base[name])
+		}
+
+		ids := make([]string, 0, len(s)+len(tg))
+		for id := range s {
+			ids = append(ids, id)
+		}
+		for id := range tg {
+			if _, ok := s[id]; !ok {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			if merge {
+				plan.mergeRow(&tp, id, s[id], tg[id], b[id], skip, resolutions)
+			} else {
+				mirrorRow(&tp, id, s[id], tg[id], skip)
+			}
+		}
+		plan.tables = append(plan.tables, tp)
+	}
+	return plan, nil
+}
+
+// mirrorRow plans the writes that make the target row equal the source row.
+func mirrorRow(tp *tablePlan, id string, s, t map[string]any, skip map[string]bool) {
+	switch {
+	case s != nil && t == nil:
+		tp.inserts = append(tp.inserts, rowValues(tp.columns, s))
+	case s == nil && t != nil:
+		tp.deletes = append(tp.deletes, id)
+	case s != nil:
+		set := make(map[string]any)
+		for _, col := range tp.columns[1:] {
+			if !skip[col] && !valueEqual(s[col], t[col]) {
+				set[col] = s[col]
+			}
+		}
+		if len(set) > 0 {
+			tp.updates = append(tp.updates, rowUpdate{id: id, set: set})
+		}
+	}
+}
+
+// mergeRow plans the three-way merge of one row. s, t, and b are the
+// source, target, and base rows, nil where the row does not exist. Without
+// a base row, a row only one side has is an addition on that side.
+func (p *syncPlan) mergeRow(tp *tablePlan, id string, s, t, b map[string]any, skip map[string]bool, resolutions map[string]string) {
+	table := string(tp.table)
+	if s == nil && t == nil {
+		return
+	}
+	if s != nil && t != nil && rowsEqual(tp.columns, s, t, skip) {
+		return
+	}
+
+	// One side deleted the row.
+	if s == nil || t == nil {
+		if b == nil {
+			// Added on one side only: a source addition is copied, a
+			// target addition is kept.
+			if s != nil {
+				tp.inserts = append(tp.inserts, rowValues(tp.columns, s))
+			}
+			return
+		}
+		if s == nil && rowsEqual(tp.columns, t, b, skip) {
+			tp.deletes = append(tp.deletes, id)
+			return
+		}
+		if t == nil && rowsEqual(tp.columns, s, b, skip) {
+			return
+		}
+		switch resolutions[conflictKey(table, id, "")] {
+		case TakeSource:
+			if s == nil {
+				tp.deletes = append(tp.deletes, id)
+			} else {
+				tp.inserts = append(tp.inserts, rowValues(tp.columns, s))
+			}
+		case TakeTarget:
+		default:
+			p.conflicts = append(p.conflicts, MergeConflict{Table: table, RowID: id, Base: nilIfEmpty(b), Source: nilIfEmpty(s), Target: nilIfEmpty(t)})
+		}
+		return
+	}
+
+	// Both sides hold the row: merge column by column.
+	set := make(map[string]any)
+	for _, col := range tp.columns[1:] {
+		sv, tv := s[col], t[col]
+		if skip[col] || valueEqual(sv, tv) {
+			continue
+		}
+		if b != nil {
+			bv := b[col]
+			if valueEqual(tv, bv) {
+				set[col] = sv
+				continue
+			}
+			if valueEqual(sv, bv) {
+				continue
+			}
+		}
+		if autoMergeColumns[col] {
+			if valueGreater(sv, tv) {
+				set[col] = sv
+			}
+			continue
+		}
+		switch resolutions[conflictKey(table, id, col)] {
+		case TakeSource:
+			set[col] = sv
+		case TakeTarget:
+		default:
+			var bv any
+			if b != nil {
+				bv = b[col]
+			}
+			p.conflicts = append(p.conflicts, MergeConflict{Table: table, RowID: id, Column: col, Base: bv, Source: sv, Target: tv})
+		}
+	}
+	if len(set) > 0 {
+		tp.updates = append(tp.updates, rowUpdate{id: id, set: set})
+	}
+}
+
+// applyPlan writes a plan inside an ImportAtomic block: deletes in reverse
+// FK order first, then inserts and updates in FK order.
+func applyPlan(ctx context.Context, ex db.Executor, d db.Dialect, ops db.DeployOps, plan *syncPlan) error {
+	for i := len(plan.tables) - 1; i >= 0; i-- {
+		tp := plan.tables[i]
+		pk := tp.columns[0]
+		for _, id := range tp.deletes {
+			if _, err := db.QDelete(ctx, ex, d, db.DeleteParams{Table: string(tp.table), Where: map[string]any{pk: id}}); err != nil {
+				return fmt.Errorf("delete %s %s: %w", tp.table, id, err)
+			}
+		}
+	}
+	for _, tp := range plan.tables {
+		pk := tp.columns[0]
+		if len(tp.inserts) > 0 {
+			rows, err := coerceRows(tp.table, TableData{Columns: tp.columns, Rows: tp.inserts})
+			if err != nil {
+				return fmt.Errorf("coerce %s: %w", tp.table, err)
+			}
+			if err := ops.BulkInsert(ctx, ex, tp.table, tp.columns, rows); err != nil {
+				return fmt.Errorf("insert %s (%d rows): %w", tp.table, len(rows), err)
+			}
+		}
+		for _, u := range tp.updates {
+			set, err := coerceSet(tp.table, u.set)
+			if err != nil {
+				return fmt.Errorf("coerce %s: %w", tp.table, err)
+			}
+			if _, err := db.QUpdate(ctx, ex, d, db.UpdateParams{Table: string(tp.table), Set: set, Where: map[string]any{pk: u.id}}); err != nil {
+				return fmt.Errorf("update %s %s: %w", tp.table, u.id, err)
+			}
+		}
+	}
+	return nil
+}
+
+// coerceSet applies coerceRows to the values of an update.
+func coerceSet(table db.DBTable, set map[string]any) (map[string]any, error) {
+	cols := make([]string, 0, len(set))
+	values := make([]any, 0, len(set))
+	for col, v := range set {
+		cols = append(cols, col)
+		values = append(values, v)
+	}
+	rows, err := coerceRows(table, TableData{Columns: cols, Rows: [][]any{values}})
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]any, len(cols))
+	for i, col := range cols {
+		out[col] = rows[0][i]
+	}
+	return out, nil
+}
+
+// indexRows maps each row of td by its primary key, the first column.
+func indexRows(td TableData) map[string]map[string]any {
+	out := make(map[string]map[string]any, len(td.Rows))
+	if len(td.Columns) == 0 {
+		return out
+	}
+	for _, values := range td.Rows {
+		if len(values) == 0 || values[0] == nil {
+			continue
+		}
+		m := make(map[string]any, len(td.Columns))
+		for i, col := range td.Columns {
+			if i < len(values) {
+				m[col] = values[i]
+			}
+		}
+		out[fmt.Sprint(values[0])] = m
+	}
+	return out
+}
+
+// rowValues lays out a row map in column order.
+func rowValues(columns []string, m map[string]any) []any {
+	values := make([]any, len(columns))
+	for i, col := range columns {
+		values[i] = m[col]
+	}
+	return values
+}
+
+// rowsEqual compares two rows on columns, ignoring the skipped ones.
+func rowsEqual(columns []string, a, b map[string]any, skip map[string]bool) bool {
+	for _, col := range columns {
+		if !skip[col] && !valueEqual(a[col], b[col]) {
+			return false
+		}
+	}
+	return true
+}
+
+// valueEqual compares two values by their JSON encoding, so an int64 read
+// from the database equals the float64 decoded from a payload.
+func valueEqual(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
+
+// valueGreater reports whether a sorts after b: numerically for numbers,
+// otherwise by string, which orders RFC3339 timestamps by time.
+func valueGreater(a, b any) bool {
+	fa, okA := toFloat(a)
+	fb, okB := toFloat(b)
+	if okA && okB {
+		return fa > fb
+	}
+	return fmt.Sprint(a) > fmt.Sprint(b)
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// nilIfEmpty keeps a missing row as a JSON null in conflict reports.
+func nilIfEmpty(m map[string]any) any {
+	if m == nil {
+		return nil
+	}
+	return m
+}
+
+// conflictKey identifies a conflict for matching resolutions.
+func conflictKey(table, rowID, column string) string {
+	return table + "\x00" + rowID + "\x00" + column
+}
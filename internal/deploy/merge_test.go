@@ -0,0 +1,255 @@
+package deploy
+
+import (
+	"reflect"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Three-way merge
+// ---------------------------------------------------------------------------
+
+var mergeColumns = []string{"field_id", "label", "type", "date_modified"}
+
+func mergeOne(s, tg, b map[string]any, resolutions map[string]string) (*syncPlan, tablePlan) {
+	plan := &syncPlan{}
+	tp := tablePlan{table: "fields", columns: mergeColumns}
+	if resolutions == nil {
+		resolutions = map[string]string{}
+	}
+	plan.mergeRow(&tp, "F1", s, tg, b, nil, resolutions)
+	return plan, tp
+}
+
+func TestMergeRow_KeepsTargetEdit(t *testing.T) {
+	t.Parallel()
+
+	base := map[string]any{"field_id": "F1", "label": "Title", "type": "text", "date_modified": "2026-01-01T00:00:00Z"}
+	src := map[string]any{"field_id": "F1", "label": "Title", "type": "richtext", "date_modified": "2026-01-02T00:00:00Z"}
+	tgt := map[string]any{"field_id": "F1", "label": "Heading", "type": "text", "date_modified": "2026-01-03T00:00:00Z"}
+
+	plan, tp := mergeOne(src, tgt, base, nil)
+	if len(plan.conflicts) != 0 {
+		t.Fatalf("conflicts = %v, want none", plan.conflicts)
+	}
+	if len(tp.updates) != 1 {
+		t.Fatalf("updates = %v, want 1", tp.updates)
+	}
+	// Source change to type is taken; the target's label edit and its newer
+	// date_modified are kept.
+	want := map[string]any{"type": "richtext"}
+	if !reflect.DeepEqual(tp.updates[0].set, want) {
+		t.Errorf("set = %v, want %v", tp.updates[0].set, want)
+	}
+}
+
+func TestMergeRow_ConflictAndResolution(t *testing.T) {
+	t.Parallel()
+
+	base := map[string]any{"field_id": "F1", "label": "Title", "type": "text", "date_modified": "2026-01-01T00:00:00Z"}
+	src := map[string]any{"field_id": "F1", "label": "Name", "type": "text", "date_modified": "2026-01-03T00:00:00Z"}
+	tgt := map[string]any{"field_id": "F1", "label": "Heading", "type": "text", "date_modified": "2026-01-02T00:00:00Z"}
+
+	plan, tp := mergeOne(src, tgt, base, nil)
+	if len(plan.conflicts) != 1 {
+		t.Fatalf("conflicts = %v, want 1", plan.conflicts)
+	}
+	c := plan.conflicts[0]
+	if c.Column != "label" || c.Source != "Name" || c.Target != "Heading" || c.Base != "Title" {
+		t.Errorf("conflict = %+v", c)
+	}
+	// date_modified changed on both sides but merges to the newer value.
+	if len(tp.updates) != 1 || tp.updates[0].set["date_modified"] != "2026-01-03T00:00:00Z" {
+		t.Errorf("updates = %v, want date_modified from source", tp.updates)
+	}
+
+	plan, tp = mergeOne(src, tgt, base, map[string]string{conflictKey("fields", "F1", "label"): TakeSource})
+	if len(plan.conflicts) != 0 {
+		t.Fatalf("conflicts after resolution = %v", plan.conflicts)
+	}
+	if tp.updates[0].set["label"] != "Name" {
+		t.Errorf("label = %v, want source value", tp.updates[0].set["label"])
+	}
+}
+
+func TestMergeRow_Deletions(t *testing.T) {
+	t.Parallel()
+
+	row := map[string]any{"field_id": "F1", "label": "Title", "type": "text", "date_modified": "x"}
+	edited := map[string]any{"field_id": "F1", "label": "Edited", "type": "text", "date_modified": "y"}
+
+	tests := []struct {
+		name          string
+		s, tg, b      map[string]any
+		wantInserts   int
+		wantDeletes   int
+		wantConflicts int
+	}{
+		{name: "source added", s: row, wantInserts: 1},
+		{name: "target added", tg: row},
+		{name: "source deleted unchanged row", tg: row, b: row, wantDeletes: 1},
+		{name: "target deleted unchanged row", s: row, b: row},
+		{name: "source deleted edited row", tg: edited, b: row, wantConflicts: 1},
+		{name: "target deleted edited row", s: edited, b: row, wantConflicts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, tp := mergeOne(tt.s, tt.tg, tt.b, nil)
+			if len(tp.inserts) != tt.wantInserts || len(tp.deletes) != tt.wantDeletes || len(plan.conflicts) != tt.wantConflicts {
+				t.Errorf("inserts=%d deletes=%d conflicts=%d, want %d/%d/%d",
+					len(tp.inserts), len(tp.deletes), len(plan.conflicts),
+					tt.wantInserts, tt.wantDeletes, tt.wantConflicts)
+			}
+			if tt.wantConflicts == 1 && plan.conflicts[0].Column != "" {
+				t.Errorf("conflict column = %q, want row-level", plan.conflicts[0].Column)
+			}
+		})
+	}
+}
+
+func TestMirrorRow_SkipsRedactedColumns(t *testing.T) {
+	t.Parallel()
+
+	tp := tablePlan{table: "webhooks", columns: []string{"webhook_id", "url", "secret"}}
+	s := map[string]any{"webhook_id": "W1", "url": "https://a", "secret": ""}
+	tg := map[string]any{"webhook_id": "W1", "url": "https://b", "secret": "kept"}
+	mirrorRow(&tp, "W1", s, tg, map[string]bool{"secret": true})
+
+	want := []rowUpdate{{id: "W1", set: map[string]any{"url": "https://a"}}}
+	if !reflect.DeepEqual(tp.updates, want) {
+		t.Errorf("updates = %v, want %v", tp.updates, want)
+	}
+}
+
+func TestValueEqual_NumberTypes(t *testing.T) {
+	t.Parallel()
+
+	if !valueEqual(int64(3), float64(3)) {
+		t.Error("int64(3) should equal float64(3)")
+	}
+	if valueEqual(nil, "") {
+		t.Error("nil should not equal empty string")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Scope filtering
+// ---------------------------------------------------------------------------
+
+func TestFilterScope_RouteTree(t *testing.T) {
+	t.Parallel()
+
+	tables := map[string]TableData{
+		"routes": {
+			Columns: []string{"route_id", "slug"},
+			Rows:    [][]any{{"R1", "blog"}, {"R2", "docs"}},
+		},
+		"content_data": {
+			Columns: []string{"content_data_id", "route_id", "root_id"},
+			Rows: [][]any{
+				{"C1", "R1", nil},
+				{"C2", nil, "C1"},
+				{"C3", "R2", nil},
+			},
+		},
+		"content_fields": {
+			Columns: []string{"content_field_id", "route_id", "content_data_id"},
+			Rows: [][]any{
+				{"F1", "R1", "C1"},
+				{"F2", nil, "C2"},
+				{"F3", "R2", "C3"},
+			},
+		},
+		"content_relations": {
+			Columns: []string{"content_relation_id", "source_content_id"},
+			Rows:    [][]any{{"X1", "C2"}, {"X2", "C3"}},
+		},
+	}
+	filterScope(tables, &SyncScope{Routes: []string{"R1"}})
+
+	ids := func(table string) []string {
+		var out []string
+		for _, r := range tables[table].Rows {
+			out = append(out, r[0].(string))
+		}
+		return out
+	}
+	checks := map[string][]string{
+		"routes":            {"R1"},
+		"content_data":      {"C1", "C2"},
+		"content_fields":    {"F1", "F2"},
+		"content_relations": {"X1"},
+	}
+	for table, want := range checks {
+		if got := ids(table); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %v, want %v", table, got, want)
+		}
+	}
+}
+
+func TestFilterScope_Datatypes(t *testing.T) {
+	t.Parallel()
+
+	tables := map[string]TableData{
+		"datatypes": {Columns: []string{"datatype_id"}, Rows: [][]any{{"D1"}, {"D2"}}},
+		"fields":    {Columns: []string{"field_id", "parent_id"}, Rows: [][]any{{"F1", "D1"}, {"F2", "D2"}, {"F3", nil}}},
+	}
+	filterScope(tables, &SyncScope{Datatypes: []string{"D2"}})
+
+	if n := len(tables["datatypes"].Rows); n != 1 || tables["datatypes"].Rows[0][0] != "D2" {
+		t.Errorf("datatypes = %v, want [D2]", tables["datatypes"].Rows)
+	}
+	if n := len(tables["fields"].Rows); n != 1 || tables["fields"].Rows[0][0] != "F2" {
+		t.Errorf("fields = %v, want [F2]", tables["fields"].Rows)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Base snapshot selection
+// ---------------------------------------------------------------------------
+
+func TestFindBaseSnapshot(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	payload := &SyncPayload{
+		Manifest: SyncManifest{SourceNodeID: "NODE_A", Scope: &SyncScope{Routes: []string{"R1"}}},
+		Tables:   map[string]TableData{"routes": {}},
+	}
+
+	snap, _, err := FindBaseSnapshot(dir, payload)
+	if err != nil || snap != nil {
+		t.Fatalf("empty dir: snap=%v err=%v, want nil/nil", snap, err)
+	}
+
+	other := &SyncPayload{
+		Manifest: SyncManifest{SourceNodeID: "NODE_B"},
+		Tables:   map[string]TableData{"routes": {}},
+	}
+	if _, err := SaveSnapshot(dir, other); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+	snap, _, err = FindBaseSnapshot(dir, payload)
+	if err != nil || snap != nil {
+		t.Errorf("other node: snap=%v err=%v, want no base", snap, err)
+	}
+
+	scoped := &SyncPayload{
+		Manifest: SyncManifest{SourceNodeID: "NODE_A", Scope: &SyncScope{Routes: []string{"R1"}}},
+		Tables:   map[string]TableData{"routes": {}},
+	}
+	id, err := SaveSnapshot(dir, scoped)
+	if err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+	snap, gotID, err := FindBaseSnapshot(dir, payload)
+	if err != nil || snap == nil || gotID != id {
+		t.Errorf("same node and scope: id=%q err=%v, want %q", gotID, err, id)
+	}
+
+	payload.Manifest.Scope = &SyncScope{Routes: []string{"R2"}}
+	snap, _, err = FindBaseSnapshot(dir, payload)
+	if err != nil || snap != nil {
+		t.Errorf("different scope: snap=%v err=%v, want no base", snap, err)
+	}
+}
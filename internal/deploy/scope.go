@@ -0,0 +1,159 @@
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// ErrScopeNotFound is returned when a route or datatype named in
+// ExportOptions does not exist.
+var ErrScopeNotFound = errors.New("scope not found")
+
+// resolveScope turns the route and datatype references of opts into a
+// SyncScope of IDs. Returns nil when opts selects whole tables.
+func resolveScope(driver db.DbDriver, opts ExportOptions) (*SyncScope, error) {
+	if len(opts.Routes) == 0 && len(opts.Datatypes) == 0 {
+		return nil, nil
+	}
+	scope := &SyncScope{}
+	seen := make(map[string]bool)
+	for _, ref := range opts.Routes {
+		var id string
+		if isValidULID(ref) {
+			if r, err := driver.GetRoute(types.RouteID(ref)); err == nil && r != nil {
+				id = r.RouteID.String()
+			}
+		}
+		if id == "" {
+			rid, err := driver.GetRouteID(ref)
+			if err != nil || rid == nil {
+				return nil, fmt.Errorf("%w: route %q", ErrScopeNotFound, ref)
+			}
+			id = rid.String()
+		}
+		if !seen[id] {
+			seen[id] = true
+			scope.Routes = append(scope.Routes, id)
+		}
+	}
+	for _, ref := range opts.Datatypes {
+		var id string
+		if isValidULID(ref) {
+			if dt, err := driver.GetDatatype(types.DatatypeID(ref)); err == nil && dt != nil {
+				id = dt.DatatypeID.String()
+			}
+		}
+		if id == "" {
+			dt, err := driver.GetDatatypeByName(ref)
+			if err != nil || dt == nil {
+				return nil, fmt.Errorf("%w: datatype %q", ErrScopeNotFound, ref)
+			}
+			id = dt.DatatypeID.String()
+		}
+		if !seen[id] {
+			seen[id] = true
+			scope.Datatypes = append(scope.Datatypes, id)
+		}
+	}
+	sort.Strings(scope.Routes)
+	sort.Strings(scope.Datatypes)
+	return scope, nil
+}
+
+// scopeTables returns the tables a scope covers, in FK-dependency order.
+func scopeTables(scope *SyncScope) []db.DBTable {
+	var names []string
+	if len(scope.Datatypes) > 0 {
+		names = append(names, string(db.Datatype), string(db.Field))
+	}
+	if len(scope.Routes) > 0 {
+		names = append(names, string(db.Route), string(db.Content_data),
+			string(db.Content_fields), string(db.Content_relations))
+	}
+	return ResolveTables(names)
+}
+
+// filterScope drops the rows of tables that fall outside scope, in place.
+// Content rows belong to a route through their route_id or the root of
+// their tree; fields belong to their parent datatype.
+func filterScope(tables map[string]TableData, scope *SyncScope) {
+	routes := stringSet(scope.Routes)
+	datatypes := stringSet(scope.Datatypes)
+
+	filterRows(tables, string(db.Route), func(r row) bool { return routes[r.str("route_id")] })
+	filterRows(tables, string(db.Datatype), func(r row) bool { return datatypes[r.str("datatype_id")] })
+	filterRows(tables, string(db.Field), func(r row) bool { return datatypes[r.str("parent_id")] })
+
+	// Roots first, then every node under a kept root.
+	content := make(map[string]bool)
+	if td, ok := tables[string(db.Content_data)]; ok {
+		for _, values := range td.Rows {
+			r := row{td.Columns, values}
+			if routes[r.str("route_id")] {
+				content[r.str("content_data_id")] = true
+			}
+		}
+		for _, values := range td.Rows {
+			r := row{td.Columns, values}
+			if content[r.str("root_id")] {
+				content[r.str("content_data_id")] = true
+			}
+		}
+	}
+	filterRows(tables, string(db.Content_data), func(r row) bool { return content[r.str("content_data_id")] })
+	filterRows(tables, string(db.Content_fields), func(r row) bool {
+		return routes[r.str("route_id")] || content[r.str("content_data_id")]
+	})
+	filterRows(tables, string(db.Content_relations), func(r row) bool { return content[r.str("source_content_id")] })
+}
+
+// row pairs positional values with their column names.
+type row struct {
+	columns []string
+	values  []any
+}
+
+// str returns the named column as a string, or "" when absent or null.
+func (r row) str(col string) string {
+	idx := colIndex(r.columns, col)
+	if idx < 0 || idx >= len(r.values) || r.values[idx] == nil {
+		return ""
+	}
+	return fmt.Sprint(r.values[idx])
+}
+
+// filterRows keeps the rows of table for which keep returns true.
+func filterRows(tables map[string]TableData, table string, keep func(row) bool) {
+	td, ok := tables[table]
+	if !ok {
+		return
+	}
+	kept := make([][]any, 0, len(td.Rows))
+	for _, values := range td.Rows {
+		if keep(row{td.Columns, values}) {
+			kept = append(kept, values)
+		}
+	}
+	td.Rows = kept
+	tables[table] = td
+}
+
+// sameScope reports whether two scopes select the same rows.
+func sameScope(a, b *SyncScope) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return columnsMatch(a.Routes, b.Routes) && columnsMatch(a.Datatypes, b.Datatypes)
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
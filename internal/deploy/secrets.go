@@ -0,0 +1,141 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/encryption"
+)
+
+// protectSecrets rewrites the designated secret columns (db.EncryptedColumns)
+// of an export so the payload never carries plaintext secrets or values sealed
+// under this instance's master key. With a target keyring each value is
+// opened locally and sealed for the receiving instance. Without one the values
+// are blanked, and the returned map lists the blanked columns per table so the
+// importer can keep its own values.
+func protectSecrets(tables map[string]TableData, target *encryption.Keyring) (map[string][]string, error) {
+	source := db.ColumnKeyring()
+	var redacted map[string][]string
+
+	for _, ec := range db.EncryptedColumns {
+		td, ok := tables[string(ec.Table)]
+		if !ok {
+			continue
+		}
+		for _, col := range ec.Columns {
+			idx := slices.Index(td.Columns, col)
+			if idx < 0 {
+				continue
+			}
+			if target == nil {
+				if redacted == nil {
+					redacted = make(map[string][]string)
+				}
+				redacted[string(ec.Table)] = append(redacted[string(ec.Table)], col)
+			}
+			for _, row := range td.Rows {
+				v, _ := row[idx].(string)
+				if v == "" {
+					continue
+				}
+				if target == nil {
+					row[idx] = ""
+					continue
+				}
+				plain := v
+				if encryption.IsSealed(v) {
+					if source == nil {
+						return nil, fmt.Errorf("%s.%s: value is encrypted but no encryption_key is configured", ec.Table, col)
+					}
+					opened, err := source.Open(v)
+					if err != nil {
+						return nil, fmt.Errorf("%s.%s: %w", ec.Table, col, err)
+					}
+					plain = opened
+				}
+				sealed, err := target.Seal(plain)
+				if err != nil {
+					return nil, fmt.Errorf("%s.%s: %w", ec.Table, col, err)
+				}
+				row[idx] = sealed
+			}
+		}
+	}
+	return redacted, nil
+}
+
+// restoreRedactedSecrets fills the secret columns an export blanked with the
+// values this instance already holds for the same rows, matched by primary
+// key. Must run inside the import transaction before the tables are
+// truncated. Rows the target does not have keep the blank value.
+func restoreRedactedSecrets(ctx context.Context, ex db.Executor, d db.Dialect, payload *SyncPayload) error {
+	for table, cols := range payload.Manifest.RedactedColumns {
+		ec, ok := db.EncryptedColumnsFor(db.DBTable(table))
+		if !ok {
+			continue
+		}
+		td, ok := payload.Tables[table]
+		if !ok || len(td.Rows) == 0 {
+			continue
+		}
+		idIdx := slices.Index(td.Columns, ec.IDColumn)
+		if idIdx < 0 {
+			continue
+		}
+
+		existing, err := db.QSelect(ctx, ex, d, db.SelectParams{
+			Table:   table,
+			Columns: append([]string{ec.IDColumn}, cols...),
+			Limit:   -1,
+		})
+		if err != nil {
+			return fmt.Errorf("read existing %s secrets: %w", table, err)
+		}
+		byID := make(map[string]db.Row, len(existing))
+		for _, r := range existing {
+			byID[fmt.Sprint(r[ec.IDColumn])] = r
+		}
+
+		for _, col := range cols {
+			idx := slices.Index(td.Columns, col)
+			if idx < 0 || !slices.Contains(ec.Columns, col) {
+				continue
+			}
+			for _, row := range td.Rows {
+				if v, _ := row[idx].(string); v != "" {
+					continue
+				}
+				prev, ok := byID[fmt.Sprint(row[idIdx])]
+				if !ok {
+					continue
+				}
+				switch v := prev[col].(type) {
+				case string:
+					row[idx] = v
+				case []byte:
+					row[idx] = string(v)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// envKeyring returns the keyring for a deploy environment's encryption_key,
+// or nil when none is configured and secrets should be redacted.
+func envKeyring(cfg config.Config, envName string) (*encryption.Keyring, error) {
+	for _, env := range cfg.Deploy_Environments {
+		if env.Name != envName || env.EncryptionKey == "" {
+			continue
+		}
+		k, err := encryption.NewKeyring(env.EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("environment %q: %w", envName, err)
+		}
+		return k, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,124 @@
+package deploy
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/encryption"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// testKeyring returns a keyring with a fresh master key.
+func testKeyring(t *testing.T) *encryption.Keyring {
+	t.Helper()
+	key, err := encryption.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	k, err := encryption.NewKeyring(key)
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	return k
+}
+
+// webhookTable returns a webhooks TableData with one row per secret.
+func webhookTable(secrets ...string) TableData {
+	td := TableData{Columns: []string{"webhook_id", "name", "secret"}}
+	for i, s := range secrets {
+		td.Rows = append(td.Rows, []any{string(rune('a' + i)), "hook", s})
+	}
+	return td
+}
+
+func TestProtectSecrets_RedactsWithoutTarget(t *testing.T) {
+	tables := map[string]TableData{
+		string(db.WebhookT): webhookTable("whsec_one", ""),
+		string(db.Datatype): {Columns: []string{"datatype_id", "secret"}, Rows: [][]any{{"x", "untouched"}}},
+	}
+
+	redacted, err := protectSecrets(tables, nil)
+	if err != nil {
+		t.Fatalf("protectSecrets: %v", err)
+	}
+	if got := redacted[string(db.WebhookT)]; len(got) != 1 || got[0] != "secret" {
+		t.Errorf("redacted columns = %v, want [secret]", got)
+	}
+	if v := tables[string(db.WebhookT)].Rows[0][2]; v != "" {
+		t.Errorf("webhook secret = %v, want redacted", v)
+	}
+	if v := tables[string(db.Datatype)].Rows[0][1]; v != "untouched" {
+		t.Errorf("non-designated column changed to %v", v)
+	}
+}
+
+func TestProtectSecrets_ReencryptsForTarget(t *testing.T) {
+	source := testKeyring(t)
+	prev := db.ColumnKeyring()
+	db.SetColumnKeyring(source)
+	t.Cleanup(func() { db.SetColumnKeyring(prev) })
+
+	sealedLocally, err := source.Seal("sealed-at-source")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	tables := map[string]TableData{
+		string(db.WebhookT): webhookTable("plain-from-mapper", sealedLocally),
+	}
+
+	target := testKeyring(t)
+	redacted, err := protectSecrets(tables, target)
+	if err != nil {
+		t.Fatalf("protectSecrets: %v", err)
+	}
+	if len(redacted) != 0 {
+		t.Errorf("redacted = %v, want none when re-encrypting", redacted)
+	}
+
+	for i, want := range []string{"plain-from-mapper", "sealed-at-source"} {
+		v, _ := tables[string(db.WebhookT)].Rows[i][2].(string)
+		if encryption.KeyID(v) != target.PrimaryID() {
+			t.Fatalf("row %d not sealed for target: %q", i, v)
+		}
+		opened, err := target.Open(v)
+		if err != nil {
+			t.Fatalf("target Open row %d: %v", i, err)
+		}
+		if opened != want {
+			t.Errorf("row %d = %q, want %q", i, opened, want)
+		}
+	}
+}
+
+func TestRestoreRedactedSecrets_KeepsTargetValues(t *testing.T) {
+	conn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Exec(`CREATE TABLE webhooks (webhook_id TEXT PRIMARY KEY, name TEXT, secret TEXT)`); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := conn.Exec(`INSERT INTO webhooks VALUES ('a', 'hook', 'target-secret')`); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	payload := &SyncPayload{
+		Manifest: SyncManifest{RedactedColumns: map[string][]string{string(db.WebhookT): {"secret"}}},
+		Tables:   map[string]TableData{string(db.WebhookT): webhookTable("", "")},
+	}
+	if err := restoreRedactedSecrets(context.Background(), conn, db.DialectSQLite, payload); err != nil {
+		t.Fatalf("restoreRedactedSecrets: %v", err)
+	}
+
+	rows := payload.Tables[string(db.WebhookT)].Rows
+	if rows[0][2] != "target-secret" {
+		t.Errorf("existing row secret = %v, want target's value kept", rows[0][2])
+	}
+	if rows[1][2] != "" {
+		t.Errorf("new row secret = %v, want blank", rows[1][2])
+	}
+}
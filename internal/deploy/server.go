@@ -4,6 +4,7 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -35,8 +36,11 @@ func DeployHealthHandler(w http.ResponseWriter, r *http.Request, svc *service.Re
 
 // exportRequest is the JSON body for POST /api/v1/deploy/export.
 type exportRequest struct {
-	Tables         []string `json:"tables"`
-	IncludePlugins bool     `json:"include_plugins"`
+	Tables         []string      `json:"tables"`
+	IncludePlugins bool          `json:"include_plugins"`
+	Routes         []string      `json:"routes,omitempty"`
+	Datatypes      []string      `json:"datatypes,omitempty"`
+	Strategy       MergeStrategy `json:"strategy,omitempty"`
 }
 
 // DeployExportHandler exports CMS data as a SyncPayload JSON response.
@@ -48,9 +52,10 @@ func DeployExportHandler(w http.ResponseWriter, r *http.Request, svc *service.Re
 	}
 
 	driver := svc.Driver()
+	cfg, _ := svc.Config()
 
-	// Parse optional table list and plugin flag from request body.
-	var opts ExportOptions
+	// Parse optional table list, scope, and strategy from request body.
+	opts := ExportOptions{SourceNodeID: cfg.Node_ID}
 	if r.Body != nil && r.ContentLength != 0 {
 		r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1 MB limit for request body
 
@@ -73,10 +78,23 @@ func DeployExportHandler(w http.ResponseWriter, r *http.Request, svc *service.Re
 			opts.Tables = append(opts.Tables, t)
 		}
 		opts.IncludePlugins = req.IncludePlugins
+		opts.Routes = req.Routes
+		opts.Datatypes = req.Datatypes
+		switch req.Strategy {
+		case "", StrategyOverwrite, StrategyMerge:
+			opts.Strategy = req.Strategy
+		default:
+			writeDeployError(w, http.StatusBadRequest, fmt.Sprintf("invalid strategy %q", req.Strategy), nil)
+			return
+		}
 	}
 
 	ctx := r.Context()
 	payload, err := ExportPayload(ctx, driver, opts)
+	if errors.Is(err, ErrScopeNotFound) {
+		writeDeployError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
 	if err != nil {
 		utility.DefaultLogger.Error("deploy export failed", err)
 		writeDeployError(w, http.StatusInternalServerError, "export failed", nil)
@@ -143,7 +161,7 @@ func DeployImportHandler(w http.ResponseWriter, r *http.Request, svc *service.Re
 	ctx := r.Context()
 
 	if dryRun {
-		result := BuildDryRunResult(&payload, driver)
+		result := DryRunPayload(ctx, c, driver, &payload)
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(result)
 		return
@@ -162,7 +180,7 @@ func DeployImportHandler(w http.ResponseWriter, r *http.Request, svc *service.Re
 		status := http.StatusInternalServerError
 		msg := err.Error() // propagate the actual error to the client
 
-		if strings.Contains(msg, "import already in progress") {
+		if strings.Contains(msg, "import already in progress") || errors.Is(err, ErrUnresolvedConflicts) {
 			status = http.StatusConflict
 		}
 
@@ -129,6 +129,41 @@ func LoadSnapshot(dir, id string) (*SyncPayload, error) {
 	return loadSnapshotFile(path)
 }
 
+// FindBaseSnapshot returns the newest snapshot in dir that can serve as the
+// merge base for payload: it was taken from the same source node (when both
+// record one), holds every table of the payload, and is either unscoped or
+// has the same scope. Returns a nil payload when there is none.
+func FindBaseSnapshot(dir string, payload *SyncPayload) (*SyncPayload, string, error) {
+	snapshots, err := ListSnapshots(dir)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, info := range snapshots {
+		snap, lErr := LoadSnapshot(dir, info.ID)
+		if lErr != nil {
+			continue
+		}
+		m := snap.Manifest
+		if m.SourceNodeID != "" && payload.Manifest.SourceNodeID != "" && m.SourceNodeID != payload.Manifest.SourceNodeID {
+			continue
+		}
+		if m.Scope != nil && !sameScope(m.Scope, payload.Manifest.Scope) {
+			continue
+		}
+		complete := true
+		for name := range payload.Tables {
+			if _, ok := snap.Tables[name]; !ok {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			return snap, info.ID, nil
+		}
+	}
+	return nil, "", nil
+}
+
 // RestoreSnapshot loads a snapshot and imports it into the target database.
 func RestoreSnapshot(ctx context.Context, cfg config.Config, driver db.DbDriver, dir, id string) (*SyncResult, error) {
 	payload, err := LoadSnapshot(dir, id)
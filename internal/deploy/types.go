@@ -5,6 +5,7 @@ import (
 
 	"github.com/hegner123/modulacms/internal/config"
 	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/encryption"
 )
 
 // MergeStrategy defines how data is applied to the target during import.
@@ -12,9 +13,58 @@ type MergeStrategy string
 
 const (
 	// StrategyOverwrite nukes the target tables and replaces with source data.
+	// A scoped payload replaces only the rows inside its scope.
 	StrategyOverwrite MergeStrategy = "overwrite"
+	// StrategyMerge three-way merges the source into the target, using the
+	// last common snapshot as the base. Target edits the source did not touch
+	// are kept; rows and columns both sides changed are reported as conflicts.
+	StrategyMerge MergeStrategy = "merge"
 )
 
+// SyncScope limits a payload to a subset of the rows of its tables. Scoped
+// payloads are applied row by row, and rows outside the scope are left
+// untouched on the target.
+type SyncScope struct {
+	// Routes are route IDs. Each route is synced with its content tree:
+	// content_data, content_fields, and content_relations.
+	Routes []string `json:"routes,omitempty"`
+	// Datatypes are datatype IDs, synced with their fields.
+	Datatypes []string `json:"datatypes,omitempty"`
+}
+
+// MergeConflict is a value both sides changed since the base snapshot.
+// Column is empty for a row one side deleted while the other changed it.
+// Base, Source, and Target are nil when the row is absent on that side.
+type MergeConflict struct {
+	Table  string `json:"table"`
+	RowID  string `json:"row_id"`
+	Column string `json:"column,omitempty"`
+	Base   any    `json:"base"`
+	Source any    `json:"source"`
+	Target any    `json:"target"`
+}
+
+// ConflictResolution picks the side that wins a MergeConflict.
+type ConflictResolution struct {
+	Table  string `json:"table"`
+	RowID  string `json:"row_id"`
+	Column string `json:"column,omitempty"`
+	Take   string `json:"take"` // "source" or "target"
+}
+
+// Resolution choices for ConflictResolution.Take.
+const (
+	TakeSource = "source"
+	TakeTarget = "target"
+)
+
+// TableChanges counts the row writes a scoped or merge import makes to one table.
+type TableChanges struct {
+	Inserted int `json:"inserted"`
+	Updated  int `json:"updated"`
+	Deleted  int `json:"deleted"`
+}
+
 // TableData is the per-table wire format inside SyncPayload.
 // Columns defines the ordered column names. Rows are positional, matching Columns order.
 // Typed IDs serialize as their 26-char ULID string. Timestamps serialize as RFC3339 strings.
@@ -29,6 +79,10 @@ type SyncPayload struct {
 	Manifest SyncManifest         `json:"manifest"`
 	Tables   map[string]TableData `json:"tables"`
 	UserRefs map[string]string    `json:"user_refs"` // user_id -> username
+
+	// Resolutions settle the conflicts of a merge import. They are not
+	// covered by the payload hash.
+	Resolutions []ConflictResolution `json:"resolutions,omitempty"`
 }
 
 // SyncManifest contains metadata about the sync payload for validation.
@@ -43,12 +97,36 @@ type SyncManifest struct {
 	RowCounts     map[string]int `json:"row_counts"`              // table -> count
 	PayloadHash   string         `json:"payload_hash"`            // SHA256 of Tables map JSON
 	PluginTables  []string       `json:"plugin_tables,omitempty"` // subset of Tables that are plugin tables
+
+	// RedactedColumns lists secret columns blanked on export (table -> columns).
+	// The importer keeps its own values for those columns.
+	RedactedColumns map[string][]string `json:"redacted_columns,omitempty"`
+
+	// Scope is set when the payload holds only part of its tables.
+	Scope *SyncScope `json:"scope,omitempty"`
 }
 
 // ExportOptions controls what is included in a deploy export.
 type ExportOptions struct {
 	Tables         []db.DBTable // core tables; nil = DefaultTableSet
 	IncludePlugins bool         // discover and include registered plugin tables
+
+	// TargetKeyring holds the receiving instance's master key. Secret columns
+	// are re-encrypted for it; nil redacts them instead.
+	TargetKeyring *encryption.Keyring
+
+	// Routes (slugs or IDs) and Datatypes (names or IDs) scope the export to
+	// those route trees and datatype schemas. Either one replaces Tables.
+	Routes    []string
+	Datatypes []string
+
+	// Strategy is recorded in the manifest for the importer (default overwrite).
+	Strategy MergeStrategy
+	// Resolutions are attached to the payload for a merge import.
+	Resolutions []ConflictResolution
+	// SourceNodeID is recorded in the manifest so the importer can find
+	// snapshots taken from the same source.
+	SourceNodeID string
 }
 
 // SyncResult is returned after a sync operation completes.
@@ -63,6 +141,11 @@ type SyncResult struct {
 	Duration       string         `json:"duration"`
 	Errors         []SyncError    `json:"errors,omitempty"`
 	Warnings       []string       `json:"warnings,omitempty"`
+
+	// Scoped and merge imports only.
+	Changes        map[string]TableChanges `json:"changes,omitempty"`
+	Conflicts      []MergeConflict         `json:"conflicts,omitempty"`
+	BaseSnapshotID string                  `json:"base_snapshot_id,omitempty"`
 }
 
 // SyncError describes a specific failure during a sync operation.
@@ -129,8 +212,10 @@ var FullTableSet = []db.DBTable{
 	db.Admin_field_types,
 	db.Table,
 	db.PipelineT,
+	db.User_groups,
 	// Tier 1: depends on tier 0
 	db.Role_permissions,
+	db.User_group_roles,
 	db.User,
 	db.Datatype,
 	db.Admin_datatype,
@@ -138,6 +223,8 @@ var FullTableSet = []db.DBTable{
 	db.Admin_validation,
 	// Tier 2: depends on tier 1
 	db.User_oauth,
+	db.User_roles,
+	db.User_group_members,
 	db.User_ssh_keys,
 	db.Session,
 	db.Token,
@@ -148,6 +235,7 @@ var FullTableSet = []db.DBTable{
 	db.WebhookT,
 	db.Media_folder,
 	db.Admin_media_folder,
+	db.Blueprints,
 	// Tier 3: depends on tier 2
 	db.Content_data,
 	db.Admin_content_data,
@@ -161,9 +249,12 @@ var FullTableSet = []db.DBTable{
 	db.Admin_content_fields,
 	db.Content_versions,
 	db.Admin_content_versions,
+	db.Blueprint_instances,
 	// Tier 5: depends on tier 4
 	db.Content_relations,
 	db.Admin_content_relations,
+	db.Translation_reviews,
+	db.Translation_sources,
 	// Tier 6: append-only / audit
 	db.Change_event,
 	db.BackupT,
@@ -184,6 +275,8 @@ var SyncableTableGroups = []TableGroup{
 		db.Content_fields, db.Admin_content_fields,
 		db.Content_relations, db.Admin_content_relations,
 		db.Content_versions, db.Admin_content_versions,
+		db.Translation_reviews, db.Translation_sources,
+		db.Blueprint_instances,
 	}},
 	{Label: "Schema", Tables: []db.DBTable{
 		db.Datatype, db.Admin_datatype,
@@ -191,6 +284,7 @@ var SyncableTableGroups = []TableGroup{
 		db.Field_types, db.Admin_field_types,
 		db.Route, db.Admin_route,
 		db.ValidationT, db.Admin_validation,
+		db.Blueprints,
 	}},
 	{Label: "Media", Tables: []db.DBTable{
 		db.MediaT, db.Admin_media,
@@ -200,6 +294,7 @@ var SyncableTableGroups = []TableGroup{
 	{Label: "Identity", Tables: []db.DBTable{
 		db.User, db.User_oauth, db.User_ssh_keys,
 		db.Role, db.Permission, db.Role_permissions,
+		db.User_roles, db.User_groups, db.User_group_members, db.User_group_roles,
 		db.Session, db.Token,
 	}},
 	{Label: "System", Tables: []db.DBTable{
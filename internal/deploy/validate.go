@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
 )
 
 // crockfordBase32 is the valid character set for Crockford base32 encoding used by ULIDs.
@@ -87,8 +88,9 @@ func ValidatePayload(payload *SyncPayload, targetDriver db.DbDriver) []SyncError
 		}
 	}
 
-	// 5. Intra-payload FK check: content_data.datatype_id references datatypes.
-	errs = append(errs, validateContentDatatypeFK(payload)...)
+	// 5. FK check: content_data.datatype_id references datatypes in the
+	// payload, or on the target when the payload carries no datatypes.
+	errs = append(errs, validateContentDatatypeFK(payload, targetDriver)...)
 
 	// 6. Content tree pointer check.
 	errs = append(errs, validateContentTreePointers(payload)...)
@@ -221,11 +223,26 @@ func extractRowID(row []any, columns []string) string {
 }
 
 // validateContentDatatypeFK checks that every content_data.datatype_id exists in datatypes.
-func validateContentDatatypeFK(payload *SyncPayload) []SyncError {
+// A payload without datatype tables (such as a route-scoped sync) is checked
+// against the target's datatypes when targetDriver is non-nil.
+func validateContentDatatypeFK(payload *SyncPayload, targetDriver db.DbDriver) []SyncError {
 	var errs []SyncError
 
 	// Build set of known datatype IDs.
 	dtIDs := make(map[string]bool)
+	_, hasDT := payload.Tables[string(db.Datatype)]
+	_, hasAdminDT := payload.Tables[string(db.Admin_datatype)]
+	onTarget := func(id string) bool {
+		if hasDT || hasAdminDT || targetDriver == nil {
+			return false
+		}
+		if known, seen := dtIDs[id]; seen {
+			return known
+		}
+		dt, err := targetDriver.GetDatatype(types.DatatypeID(id))
+		dtIDs[id] = err == nil && dt != nil
+		return dtIDs[id]
+	}
 	for _, tableName := range []string{string(db.Datatype), string(db.Admin_datatype)} {
 		td, ok := payload.Tables[tableName]
 		if !ok {
@@ -265,7 +282,7 @@ func validateContentDatatypeFK(payload *SyncPayload) []SyncError {
 				continue
 			}
 			if s, ok := row[dtColIdx].(string); ok && s != "" {
-				if !dtIDs[s] {
+				if !dtIDs[s] && (tableName != string(db.Content_data) || !onTarget(s)) {
 					errs = append(errs, SyncError{
 						Table:   tableName,
 						Phase:   "validate",
@@ -0,0 +1,295 @@
+// Package encryption implements envelope encryption for secret values stored
+// at rest: webhook signing secrets, OAuth tokens, and plugin-stored secrets.
+//
+// Each value is encrypted with its own random data key (AES-256-GCM), and the
+// data key is wrapped with a master key. A sealed value is a printable string:
+//
+//	enc:v1:<key id>:<wrapped data key>:<ciphertext>
+//
+// The key id names the master key that wrapped the data key, so a keyring
+// holding the current key and retired keys can open values written under any
+// of them. Rotating the master key only rewraps data keys; the ciphertext of
+// the value itself is left as it is.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/config"
+)
+
+// Prefix marks a sealed value. Values without it are treated as plaintext.
+const Prefix = "enc:v1:"
+
+// KeySize is the master and data key length in bytes (AES-256).
+const KeySize = 32
+
+// ErrUnknownKey is returned when a sealed value names a master key that is
+// not in the keyring.
+var ErrUnknownKey = errors.New("sealed with a master key that is not configured")
+
+// ErrMalformed is returned when a value carries the sealed prefix but cannot
+// be parsed.
+var ErrMalformed = errors.New("malformed sealed value")
+
+// masterKey is a parsed master key and its id.
+type masterKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// Keyring holds the primary master key used for sealing and any previous
+// master keys still accepted for opening.
+type Keyring struct {
+	primary masterKey
+	keys    map[string]masterKey
+}
+
+// NewKeyring builds a keyring from an encoded primary key and zero or more
+// encoded previous keys. Keys are base64 (standard or URL alphabet, padded or
+// not) or hex encodings of 32 random bytes.
+func NewKeyring(primary string, previous ...string) (*Keyring, error) {
+	pk, err := parseMasterKey(primary)
+	if err != nil {
+		return nil, fmt.Errorf("encryption key: %w", err)
+	}
+	k := &Keyring{primary: pk, keys: map[string]masterKey{pk.id: pk}}
+	for i, enc := range previous {
+		mk, err := parseMasterKey(enc)
+		if err != nil {
+			return nil, fmt.Errorf("previous encryption key %d: %w", i, err)
+		}
+		if _, dup := k.keys[mk.id]; !dup {
+			k.keys[mk.id] = mk
+		}
+	}
+	return k, nil
+}
+
+// KeyringFromConfig builds the keyring described by the config. It returns a
+// nil keyring and nil error when no key is configured, which leaves values
+// stored as plaintext.
+func KeyringFromConfig(c config.Config) (*Keyring, error) {
+	primary := strings.TrimSpace(c.Encryption_Key)
+	if primary == "" && c.Encryption_Key_File != "" {
+		data, err := os.ReadFile(c.Encryption_Key_File)
+		if err != nil {
+			return nil, fmt.Errorf("reading encryption_key_file: %w", err)
+		}
+		primary = strings.TrimSpace(string(data))
+	}
+	if primary == "" {
+		if len(c.Encryption_Previous_Keys) > 0 {
+			return nil, fmt.Errorf("encryption_previous_keys is set but no encryption_key is configured")
+		}
+		return nil, nil
+	}
+	return NewKeyring(primary, c.Encryption_Previous_Keys...)
+}
+
+// GenerateKey returns a new random master key, base64 encoded.
+func GenerateKey() (string, error) {
+	b := make([]byte, KeySize)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// PrimaryID returns the id of the key new values are sealed under.
+func (k *Keyring) PrimaryID() string {
+	return k.primary.id
+}
+
+// IsSealed reports whether v carries the sealed-value prefix.
+func IsSealed(v string) bool {
+	return strings.HasPrefix(v, Prefix)
+}
+
+// KeyID returns the master key id recorded in a sealed value, or "" when v
+// is not sealed.
+func KeyID(v string) string {
+	if !IsSealed(v) {
+		return ""
+	}
+	id, _, _ := strings.Cut(strings.TrimPrefix(v, Prefix), ":")
+	return id
+}
+
+// Seal encrypts plaintext under the primary key. Empty strings and values
+// that are already sealed are returned unchanged, so sealing is idempotent.
+func (k *Keyring) Seal(plaintext string) (string, error) {
+	if plaintext == "" || IsSealed(plaintext) {
+		return plaintext, nil
+	}
+	dek := make([]byte, KeySize)
+	if _, err := rand.Read(dek); err != nil {
+		return "", fmt.Errorf("generating data key: %w", err)
+	}
+	dataAEAD, err := newAEAD(dek)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := sealBytes(dataAEAD, []byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+	return k.format(k.primary, dek, ciphertext)
+}
+
+// Open decrypts a sealed value. Values without the sealed prefix are
+// returned unchanged so that rows written before encryption was enabled keep
+// working.
+func (k *Keyring) Open(v string) (string, error) {
+	if !IsSealed(v) {
+		return v, nil
+	}
+	dek, ciphertext, err := k.unwrap(v)
+	if err != nil {
+		return "", err
+	}
+	dataAEAD, err := newAEAD(dek)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := openBytes(dataAEAD, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypting value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRewrap reports whether v should be rewritten to be sealed under the
+// primary key: it is plaintext, or sealed under a previous key.
+func (k *Keyring) NeedsRewrap(v string) bool {
+	if v == "" {
+		return false
+	}
+	return KeyID(v) != k.primary.id
+}
+
+// Rewrap returns v sealed under the primary key. Plaintext is sealed; values
+// sealed under a previous key have only their data key rewrapped.
+func (k *Keyring) Rewrap(v string) (string, error) {
+	if !IsSealed(v) {
+		return k.Seal(v)
+	}
+	if KeyID(v) == k.primary.id {
+		return v, nil
+	}
+	dek, ciphertext, err := k.unwrap(v)
+	if err != nil {
+		return "", err
+	}
+	return k.format(k.primary, dek, ciphertext)
+}
+
+// format wraps dek under mk and assembles the sealed string.
+func (k *Keyring) format(mk masterKey, dek, ciphertext []byte) (string, error) {
+	wrapped, err := sealBytes(mk.aead, dek, []byte(mk.id))
+	if err != nil {
+		return "", err
+	}
+	enc := base64.RawURLEncoding
+	return Prefix + mk.id + ":" + enc.EncodeToString(wrapped) + ":" + enc.EncodeToString(ciphertext), nil
+}
+
+// unwrap parses a sealed value and returns its data key and ciphertext.
+func (k *Keyring) unwrap(v string) (dek, ciphertext []byte, err error) {
+	parts := strings.Split(strings.TrimPrefix(v, Prefix), ":")
+	if len(parts) != 3 {
+		return nil, nil, ErrMalformed
+	}
+	mk, ok := k.keys[parts[0]]
+	if !ok {
+		return nil, nil, fmt.Errorf("key %s: %w", parts[0], ErrUnknownKey)
+	}
+	enc := base64.RawURLEncoding
+	wrapped, err := enc.DecodeString(parts[1])
+	if err != nil {
+		return nil, nil, ErrMalformed
+	}
+	ciphertext, err = enc.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, ErrMalformed
+	}
+	dek, err = openBytes(mk.aead, wrapped, []byte(mk.id))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unwrapping data key: %w", err)
+	}
+	return dek, ciphertext, nil
+}
+
+// parseMasterKey decodes an encoded master key and derives its id from a
+// SHA-256 fingerprint, so the id never reveals key material.
+func parseMasterKey(encoded string) (masterKey, error) {
+	raw, err := decodeKey(strings.TrimSpace(encoded))
+	if err != nil {
+		return masterKey{}, err
+	}
+	aead, err := newAEAD(raw)
+	if err != nil {
+		return masterKey{}, err
+	}
+	sum := sha256.Sum256(raw)
+	return masterKey{id: hex.EncodeToString(sum[:4]), aead: aead}, nil
+}
+
+// decodeKey accepts hex or any common base64 variant of a 32-byte key.
+func decodeKey(s string) ([]byte, error) {
+	if s == "" {
+		return nil, errors.New("key is empty")
+	}
+	if len(s) == hex.EncodedLen(KeySize) {
+		if b, err := hex.DecodeString(s); err == nil {
+			return b, nil
+		}
+	}
+	for _, enc := range []*base64.Encoding{
+		base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding,
+	} {
+		if b, err := enc.DecodeString(s); err == nil {
+			if len(b) != KeySize {
+				return nil, fmt.Errorf("key must decode to %d bytes, got %d", KeySize, len(b))
+			}
+			return b, nil
+		}
+	}
+	return nil, errors.New("key is not valid base64 or hex")
+}
+
+// newAEAD returns AES-256-GCM for a 32-byte key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealBytes encrypts with a random nonce and returns nonce || ciphertext.
+func sealBytes(aead cipher.AEAD, plaintext, additional []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additional), nil
+}
+
+// openBytes reverses sealBytes.
+func openBytes(aead cipher.AEAD, data, additional []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additional)
+}
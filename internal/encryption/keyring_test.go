@@ -0,0 +1,208 @@
+package encryption_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/encryption"
+)
+
+// newKey generates a master key or fails the test.
+func newKey(t *testing.T) string {
+	t.Helper()
+	k, err := encryption.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	return k
+}
+
+func TestKeyring_SealOpen_RoundTrip(t *testing.T) {
+	k, err := encryption.NewKeyring(newKey(t))
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+
+	sealed, err := k.Seal("whsec_abc123")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if !encryption.IsSealed(sealed) {
+		t.Fatalf("sealed value %q lacks prefix", sealed)
+	}
+	if strings.Contains(sealed, "whsec_abc123") {
+		t.Fatalf("sealed value contains plaintext: %q", sealed)
+	}
+	if encryption.KeyID(sealed) != k.PrimaryID() {
+		t.Errorf("KeyID = %q, want %q", encryption.KeyID(sealed), k.PrimaryID())
+	}
+
+	opened, err := k.Open(sealed)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if opened != "whsec_abc123" {
+		t.Errorf("Open = %q, want original", opened)
+	}
+}
+
+func TestKeyring_Seal_IdempotentAndEmpty(t *testing.T) {
+	k, err := encryption.NewKeyring(newKey(t))
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+
+	empty, err := k.Seal("")
+	if err != nil || empty != "" {
+		t.Errorf("Seal(\"\") = %q, %v; want empty string", empty, err)
+	}
+
+	once, err := k.Seal("token")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	twice, err := k.Seal(once)
+	if err != nil {
+		t.Fatalf("Seal sealed: %v", err)
+	}
+	if twice != once {
+		t.Error("sealing an already sealed value changed it")
+	}
+}
+
+func TestKeyring_Open_PlaintextPassesThrough(t *testing.T) {
+	k, err := encryption.NewKeyring(newKey(t))
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	got, err := k.Open("legacy-plaintext")
+	if err != nil || got != "legacy-plaintext" {
+		t.Errorf("Open(plaintext) = %q, %v; want value unchanged", got, err)
+	}
+}
+
+func TestKeyring_Open_Errors(t *testing.T) {
+	k1, err := encryption.NewKeyring(newKey(t))
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	k2, err := encryption.NewKeyring(newKey(t))
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	sealed, err := k1.Seal("secret")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	if _, err := k2.Open(sealed); !errors.Is(err, encryption.ErrUnknownKey) {
+		t.Errorf("Open with other key: err = %v, want ErrUnknownKey", err)
+	}
+	if _, err := k1.Open(encryption.Prefix + "garbage"); !errors.Is(err, encryption.ErrMalformed) {
+		t.Errorf("Open malformed: err = %v, want ErrMalformed", err)
+	}
+
+	tampered := sealed[:len(sealed)-2] + "AA"
+	if tampered == sealed {
+		tampered = sealed[:len(sealed)-2] + "BB"
+	}
+	if _, err := k1.Open(tampered); err == nil {
+		t.Error("Open tampered value: expected error, got nil")
+	}
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	oldKey, newKeyStr := newKey(t), newKey(t)
+	oldRing, err := encryption.NewKeyring(oldKey)
+	if err != nil {
+		t.Fatalf("NewKeyring old: %v", err)
+	}
+	sealed, err := oldRing.Seal("refresh-token")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	rotated, err := encryption.NewKeyring(newKeyStr, oldKey)
+	if err != nil {
+		t.Fatalf("NewKeyring rotated: %v", err)
+	}
+	if !rotated.NeedsRewrap(sealed) {
+		t.Fatal("value sealed under previous key should need rewrap")
+	}
+	if !rotated.NeedsRewrap("plaintext") {
+		t.Error("plaintext should need rewrap")
+	}
+	if rotated.NeedsRewrap("") {
+		t.Error("empty value should not need rewrap")
+	}
+
+	rewrapped, err := rotated.Rewrap(sealed)
+	if err != nil {
+		t.Fatalf("Rewrap: %v", err)
+	}
+	if rotated.NeedsRewrap(rewrapped) {
+		t.Error("rewrapped value still needs rewrap")
+	}
+
+	// The new key alone opens the rewrapped value.
+	newOnly, err := encryption.NewKeyring(newKeyStr)
+	if err != nil {
+		t.Fatalf("NewKeyring new: %v", err)
+	}
+	opened, err := newOnly.Open(rewrapped)
+	if err != nil {
+		t.Fatalf("Open rewrapped: %v", err)
+	}
+	if opened != "refresh-token" {
+		t.Errorf("Open rewrapped = %q, want original", opened)
+	}
+}
+
+func TestNewKeyring_InvalidKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{"empty", ""},
+		{"not encoded", "not a key!"},
+		{"short", "c2hvcnQ="},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := encryption.NewKeyring(tt.key); err == nil {
+				t.Errorf("NewKeyring(%q): expected error, got nil", tt.key)
+			}
+		})
+	}
+}
+
+func TestNewKeyring_AcceptsHex(t *testing.T) {
+	hexKey := strings.Repeat("ab", encryption.KeySize)
+	if _, err := encryption.NewKeyring(hexKey); err != nil {
+		t.Errorf("NewKeyring(hex): %v", err)
+	}
+}
+
+func TestKeyringFromConfig(t *testing.T) {
+	k, err := encryption.KeyringFromConfig(config.Config{})
+	if err != nil || k != nil {
+		t.Errorf("unconfigured: got %v, %v; want nil, nil", k, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "encryption.key")
+	if err := os.WriteFile(path, []byte(newKey(t)+"\n"), 0600); err != nil {
+		t.Fatalf("writing key file: %v", err)
+	}
+	k, err = encryption.KeyringFromConfig(config.Config{Encryption_Key_File: path})
+	if err != nil || k == nil {
+		t.Fatalf("key file: got %v, %v; want keyring", k, err)
+	}
+
+	if _, err := encryption.KeyringFromConfig(config.Config{Encryption_Previous_Keys: []string{newKey(t)}}); err == nil {
+		t.Error("previous keys without a primary key: expected error, got nil")
+	}
+}
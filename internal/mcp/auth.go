@@ -8,10 +8,11 @@ import (
 	"github.com/hegner123/modulacms/internal/middleware"
 )
 
-// mcpAuditKey is used only in stdio mode to inject a default AuditContext
-// when no HTTP middleware chain is available. In HTTP mode, the middleware
-// chain populates the context with the authenticated user, permissions,
-// and admin status directly. The tool middleware reads those values using
+// mcpAuditKey holds a prepared AuditContext. In stdio mode it is the default
+// identity injected by injectAuditContextMiddleware; in HTTP mode it is the
+// request's REST-equivalent context stored by RequestAuditContextFunc. The
+// HTTP middleware populates the context with the authenticated user,
+// permissions, and admin status directly. The tool middleware reads those values using
 // the existing middleware extractors:
 //   - middleware.AuthenticatedUser(ctx)
 //   - middleware.ContextPermissions(ctx)
@@ -22,12 +23,16 @@ import (
 // No MCP-specific duplicates of these extractors are created.
 type mcpAuditKey struct{}
 
-// AuditContextFromMCP builds an AuditContext from the authenticated user
-// in the MCP context. Uses the real request ID and client IP from the
-// middleware chain when available. Falls back to a "mcp-anonymous" context
-// if no user is present (should not happen after permission middleware,
-// but defensive).
+// AuditContextFromMCP returns the AuditContext prepared for the call, or
+// builds one from the authenticated user in the MCP context using the real
+// request ID and client IP from the middleware chain when available. Falls
+// back to a "mcp-anonymous" context if no user is present (should not
+// happen after permission middleware, but defensive).
 func AuditContextFromMCP(ctx context.Context) audited.AuditContext {
+	if ac, ok := ctx.Value(mcpAuditKey{}).(audited.AuditContext); ok {
+		return ac
+	}
+
 	requestID := middleware.RequestIDFromContext(ctx)
 	clientIP := middleware.ClientIPFromContext(ctx)
 	if clientIP == "" {
@@ -39,10 +44,5 @@ func AuditContextFromMCP(ctx context.Context) audited.AuditContext {
 		return audited.Ctx(types.NewNodeID(), user.UserID, requestID, clientIP)
 	}
 
-	// Stdio-mode fallback: check for injected AuditContext.
-	if ac, ok := ctx.Value(mcpAuditKey{}).(audited.AuditContext); ok {
-		return ac
-	}
-
 	return audited.Ctx(types.NewNodeID(), types.UserID("mcp-anonymous"), requestID, clientIP)
 }
@@ -2,25 +2,38 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/hegner123/modulacms/internal/config"
 	"github.com/hegner123/modulacms/internal/db/audited"
 	"github.com/hegner123/modulacms/internal/middleware"
 )
 
 const errAuthRequired = "authentication required"
 const errForbidden = "forbidden: requires permission '%s'"
+const errUnknownResource = "forbidden: no permission is mapped for resource '%s'"
+const errUnknownPrompt = "forbidden: no permission is mapped for prompt '%s'"
+const errNotAllowed = "forbidden: '%s' is not allowed for this token"
 
-// PassthroughHTTPContextFunc returns an HTTPContextFunc that is a no-op.
-// The DefaultMiddlewareChain already populates all auth context values
-// before this runs.
-func PassthroughHTTPContextFunc() server.HTTPContextFunc {
+// RequestAuditContextFunc returns an HTTPContextFunc that stores the same
+// AuditContext a REST handler would build for the request (node ID,
+// authenticated user, request ID, client IP and plugin hook runner), so tool
+// calls over HTTP are audited exactly like the equivalent REST call.
+// AuditContextFromMCP reads it back. Runs after bearerAuth has placed the
+// token's user in the request context.
+func RequestAuditContextFunc(mgr *config.Manager) server.HTTPContextFunc {
 	return func(ctx context.Context, r *http.Request) context.Context {
-		return ctx
+		cfg, err := mgr.Config()
+		if err != nil {
+			return ctx
+		}
+		return context.WithValue(ctx, mcpAuditKey{}, middleware.AuditContextFromRequest(r, *cfg))
 	}
 }
 
@@ -47,37 +60,95 @@ func PermissionMiddleware() server.ToolHandlerMiddleware {
 				return next(ctx, request)
 			}
 
-			// Authentication gate: must run before admin bypass check.
-			user := middleware.AuthenticatedUser(ctx)
-			if user == nil {
-				return mcp.NewToolResultError(errAuthRequired), nil
+			if msg := permissionDenied(ctx, permission); msg != "" {
+				return mcp.NewToolResultError(msg), nil
 			}
 
-			// Admin bypass: admins skip permission checks.
-			if middleware.ContextIsAdmin(ctx) {
-				return next(ctx, request)
-			}
+			return next(ctx, request)
+		}
+	}
+}
 
-			// Check permission set.
-			ps := middleware.ContextPermissions(ctx)
-			if ps == nil {
-				return mcp.NewToolResultError(errAuthRequired), nil
-			}
+// permissionDenied checks the authenticated user in ctx against permission
+// and returns the error message to send, or "" when the call is allowed.
+func permissionDenied(ctx context.Context, permission string) string {
+	// Authentication gate: must run before admin bypass check.
+	user := middleware.AuthenticatedUser(ctx)
+	if user == nil {
+		return errAuthRequired
+	}
 
-			if !ps.Has(permission) {
-				return mcp.NewToolResultError(fmt.Sprintf(errForbidden, permission)), nil
-			}
+	// Admin bypass: admins skip permission checks.
+	if middleware.ContextIsAdmin(ctx) {
+		return ""
+	}
 
-			return next(ctx, request)
-		}
+	// Check permission set.
+	ps := middleware.ContextPermissions(ctx)
+	if ps == nil {
+		return errAuthRequired
 	}
+
+	if !ps.Has(permission) {
+		return fmt.Sprintf(errForbidden, permission)
+	}
+	return ""
+}
+
+// PermissionHooks returns server hooks that apply the same checks as
+// ToolAllowlistMiddleware and PermissionMiddleware to resource reads and
+// prompt requests, which do not pass through tool middleware. Resources and
+// prompts without a mapped permission are denied, so a new one stays closed
+// until it is mapped.
+func PermissionHooks() *server.Hooks {
+	hooks := &server.Hooks{}
+	hooks.AddOnRequestInitialization(func(ctx context.Context, id any, message any) error {
+		raw, ok := message.(json.RawMessage)
+		if !ok {
+			return nil
+		}
+		var req struct {
+			Method string `json:"method"`
+			Params struct {
+				URI  string `json:"uri"`
+				Name string `json:"name"`
+			} `json:"params"`
+		}
+		if err := json.Unmarshal(raw, &req); err != nil {
+			return nil
+		}
+
+		var subject, permission string
+		switch mcp.MCPMethod(req.Method) {
+		case mcp.MethodResourcesRead:
+			subject = req.Params.URI
+			if permission, ok = resourcePermission(subject); !ok {
+				return fmt.Errorf(errUnknownResource, subject)
+			}
+		case mcp.MethodPromptsGet:
+			subject = req.Params.Name
+			if permission, ok = promptPermissions[subject]; !ok {
+				return fmt.Errorf(errUnknownPrompt, subject)
+			}
+		default:
+			return nil
+		}
+		if !allowlistAllows(ctx, subject, permission) {
+			return fmt.Errorf(errNotAllowed, subject)
+		}
+		if msg := permissionDenied(ctx, permission); msg != "" {
+			return errors.New(msg)
+		}
+		return nil
+	})
+	return hooks
 }
 
 // injectAuditContextMiddleware returns a ToolHandlerMiddleware that stores
 // the provided AuditContext under mcpAuditKey{} in the tool call's context.
-// Used by ServeDirect (stdio mode) where no HTTP middleware chain is present.
-// AuditContextFromMCP checks for this key as a fallback when
-// middleware.AuthenticatedUser(ctx) returns nil.
+// Used by ServeDirect (stdio mode), where there is no HTTP request for
+// RequestAuditContextFunc to build one from. AuditContextFromMCP returns the
+// stored context before looking at the authenticated user.
 func injectAuditContextMiddleware(ac audited.AuditContext) server.ToolHandlerMiddleware {
 	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
 		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
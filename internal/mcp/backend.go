@@ -30,6 +30,7 @@ type ContentBackend interface {
 	DeleteContentField(ctx context.Context, id string) error
 	ReorderContent(ctx context.Context, params json.RawMessage) (json.RawMessage, error)
 	MoveContent(ctx context.Context, params json.RawMessage) (json.RawMessage, error)
+	CloneContent(ctx context.Context, params json.RawMessage) (json.RawMessage, error)
 	SaveContentTree(ctx context.Context, params json.RawMessage) (json.RawMessage, error)
 	HealContent(ctx context.Context, dryRun bool) (json.RawMessage, error)
 	BatchUpdateContent(ctx context.Context, params json.RawMessage) (json.RawMessage, error)
@@ -325,6 +326,7 @@ type VersionBackend interface {
 	CreateVersion(ctx context.Context, params json.RawMessage) (json.RawMessage, error)
 	DeleteVersion(ctx context.Context, versionID string) error
 	RestoreVersion(ctx context.Context, params json.RawMessage) (json.RawMessage, error)
+	DiffVersions(ctx context.Context, params json.RawMessage) (json.RawMessage, error)
 	AdminListVersions(ctx context.Context, contentID string) (json.RawMessage, error)
 	AdminGetVersion(ctx context.Context, versionID string) (json.RawMessage, error)
 	AdminCreateVersion(ctx context.Context, params json.RawMessage) (json.RawMessage, error)
@@ -354,6 +356,7 @@ type LocaleBackend interface {
 	DeleteLocale(ctx context.Context, id string) error
 	CreateTranslation(ctx context.Context, contentDataID string, params json.RawMessage) (json.RawMessage, error)
 	AdminCreateTranslation(ctx context.Context, adminContentDataID string, params json.RawMessage) (json.RawMessage, error)
+	GetTranslationStatus(ctx context.Context, params json.RawMessage) (json.RawMessage, error)
 }
 
 // ValidationBackend abstracts validation rule operations.
@@ -378,6 +381,18 @@ type SearchBackend interface {
 	RebuildSearchIndex(ctx context.Context) (json.RawMessage, error)
 }
 
+// BlueprintBackend abstracts blueprint management and instantiation.
+type BlueprintBackend interface {
+	ListBlueprints(ctx context.Context) (json.RawMessage, error)
+	GetBlueprint(ctx context.Context, id string) (json.RawMessage, error)
+	CreateBlueprint(ctx context.Context, params json.RawMessage) (json.RawMessage, error)
+	CreateBlueprintFromContent(ctx context.Context, params json.RawMessage) (json.RawMessage, error)
+	UpdateBlueprint(ctx context.Context, id string, params json.RawMessage) (json.RawMessage, error)
+	DeleteBlueprint(ctx context.Context, id string) error
+	InstantiateBlueprint(ctx context.Context, id string, params json.RawMessage) (json.RawMessage, error)
+	ListBlueprintInstances(ctx context.Context, idOrName string) (json.RawMessage, error)
+}
+
 // Backends holds all domain backends for MCP tool registration.
 // Each field can be satisfied by either an SDK adapter (remote mode)
 // or a service adapter (direct mode).
@@ -410,6 +425,7 @@ type Backends struct {
 	Locales           LocaleBackend
 	Validations       ValidationBackend
 	Search            SearchBackend
+	Blueprints        BlueprintBackend
 	Activity          ActivityBackend
 	Auth              AuthBackend
 }
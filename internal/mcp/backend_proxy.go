@@ -54,6 +54,7 @@ func NewProxyBackends(cm *ConnectionManager) *Backends {
 		Locales:           &proxyLocaleBackend{pb},
 		Validations:       &proxyValidationBackend{pb},
 		Search:            &proxySearchBackend{pb},
+		Blueprints:        &proxyBlueprintBackend{pb},
 		Activity:          &proxyActivityBackend{pb},
 		Auth:              &proxyAuthBackend{pb},
 	}
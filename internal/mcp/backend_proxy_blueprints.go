@@ -0,0 +1,76 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// ---------------------------------------------------------------------------
+// BlueprintBackend (Proxy)
+// ---------------------------------------------------------------------------
+
+type proxyBlueprintBackend struct{ p *proxyBackends }
+
+func (b *proxyBlueprintBackend) ListBlueprints(ctx context.Context) (json.RawMessage, error) {
+	be, err := b.p.backends()
+	if err != nil {
+		return nil, err
+	}
+	return be.Blueprints.ListBlueprints(ctx)
+}
+
+func (b *proxyBlueprintBackend) GetBlueprint(ctx context.Context, id string) (json.RawMessage, error) {
+	be, err := b.p.backends()
+	if err != nil {
+		return nil, err
+	}
+	return be.Blueprints.GetBlueprint(ctx, id)
+}
+
+func (b *proxyBlueprintBackend) CreateBlueprint(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	be, err := b.p.backends()
+	if err != nil {
+		return nil, err
+	}
+	return be.Blueprints.CreateBlueprint(ctx, params)
+}
+
+func (b *proxyBlueprintBackend) CreateBlueprintFromContent(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	be, err := b.p.backends()
+	if err != nil {
+		return nil, err
+	}
+	return be.Blueprints.CreateBlueprintFromContent(ctx, params)
+}
+
+func (b *proxyBlueprintBackend) UpdateBlueprint(ctx context.Context, id string, params json.RawMessage) (json.RawMessage, error) {
+	be, err := b.p.backends()
+	if err != nil {
+		return nil, err
+	}
+	return be.Blueprints.UpdateBlueprint(ctx, id, params)
+}
+
+func (b *proxyBlueprintBackend) DeleteBlueprint(ctx context.Context, id string) error {
+	be, err := b.p.backends()
+	if err != nil {
+		return err
+	}
+	return be.Blueprints.DeleteBlueprint(ctx, id)
+}
+
+func (b *proxyBlueprintBackend) InstantiateBlueprint(ctx context.Context, id string, params json.RawMessage) (json.RawMessage, error) {
+	be, err := b.p.backends()
+	if err != nil {
+		return nil, err
+	}
+	return be.Blueprints.InstantiateBlueprint(ctx, id, params)
+}
+
+func (b *proxyBlueprintBackend) ListBlueprintInstances(ctx context.Context, idOrName string) (json.RawMessage, error) {
+	be, err := b.p.backends()
+	if err != nil {
+		return nil, err
+	}
+	return be.Blueprints.ListBlueprintInstances(ctx, idOrName)
+}
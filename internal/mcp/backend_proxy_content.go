@@ -109,6 +109,13 @@ func (b *proxyContentBackend) MoveContent(ctx context.Context, params json.RawMe
 	}
 	return be.Content.MoveContent(ctx, params)
 }
+func (b *proxyContentBackend) CloneContent(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	be, err := b.p.backends()
+	if err != nil {
+		return nil, err
+	}
+	return be.Content.CloneContent(ctx, params)
+}
 func (b *proxyContentBackend) SaveContentTree(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
 	be, err := b.p.backends()
 	if err != nil {
@@ -312,6 +319,13 @@ func (b *proxyVersionBackend) RestoreVersion(ctx context.Context, params json.Ra
 	}
 	return be.Versions.RestoreVersion(ctx, params)
 }
+func (b *proxyVersionBackend) DiffVersions(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	be, err := b.p.backends()
+	if err != nil {
+		return nil, err
+	}
+	return be.Versions.DiffVersions(ctx, params)
+}
 func (b *proxyVersionBackend) AdminListVersions(ctx context.Context, contentID string) (json.RawMessage, error) {
 	be, err := b.p.backends()
 	if err != nil {
@@ -70,3 +70,11 @@ func (b *proxyLocaleBackend) AdminCreateTranslation(ctx context.Context, adminCo
 	}
 	return be.Locales.AdminCreateTranslation(ctx, adminContentDataID, params)
 }
+
+func (b *proxyLocaleBackend) GetTranslationStatus(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	be, err := b.p.backends()
+	if err != nil {
+		return nil, err
+	}
+	return be.Locales.GetTranslationStatus(ctx, params)
+}
@@ -36,6 +36,7 @@ func NewSDKBackends(client *modula.Client) *Backends {
 		Locales:           &sdkLocaleBackend{client: client},
 		Validations:       &sdkValidationBackend{client: client},
 		Search:            &sdkSearchBackend{client: client},
+		Blueprints:        &sdkBlueprintBackend{client: client},
 		Activity:          &sdkActivityBackend{client: client},
 		Auth:              &sdkAuthBackend{client: client},
 	}
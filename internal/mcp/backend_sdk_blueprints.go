@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	modula "github.com/hegner123/modulacms/sdks/go"
+)
+
+// ---------------------------------------------------------------------------
+// BlueprintBackend (SDK)
+// ---------------------------------------------------------------------------
+
+type sdkBlueprintBackend struct {
+	client *modula.Client
+}
+
+func (b *sdkBlueprintBackend) ListBlueprints(ctx context.Context) (json.RawMessage, error) {
+	result, err := b.client.Blueprints.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func (b *sdkBlueprintBackend) GetBlueprint(ctx context.Context, id string) (json.RawMessage, error) {
+	result, err := b.client.Blueprints.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func (b *sdkBlueprintBackend) CreateBlueprint(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	var def modula.BlueprintDefinition
+	if err := json.Unmarshal(params, &def); err != nil {
+		return nil, fmt.Errorf("unmarshal blueprint definition: %w", err)
+	}
+	result, err := b.client.Blueprints.Create(ctx, def)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func (b *sdkBlueprintBackend) CreateBlueprintFromContent(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	var p modula.BlueprintFromContentParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("unmarshal blueprint from content params: %w", err)
+	}
+	result, err := b.client.Blueprints.FromContent(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func (b *sdkBlueprintBackend) UpdateBlueprint(ctx context.Context, id string, params json.RawMessage) (json.RawMessage, error) {
+	var def modula.BlueprintDefinition
+	if err := json.Unmarshal(params, &def); err != nil {
+		return nil, fmt.Errorf("unmarshal blueprint definition: %w", err)
+	}
+	result, err := b.client.Blueprints.Update(ctx, id, def)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func (b *sdkBlueprintBackend) DeleteBlueprint(ctx context.Context, id string) error {
+	return b.client.Blueprints.Delete(ctx, id)
+}
+
+func (b *sdkBlueprintBackend) InstantiateBlueprint(ctx context.Context, id string, params json.RawMessage) (json.RawMessage, error) {
+	var p modula.InstantiateBlueprintParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("unmarshal instantiate blueprint params: %w", err)
+	}
+	result, err := b.client.Blueprints.Instantiate(ctx, id, p)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func (b *sdkBlueprintBackend) ListBlueprintInstances(ctx context.Context, idOrName string) (json.RawMessage, error) {
+	result, err := b.client.Blueprints.Instances(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
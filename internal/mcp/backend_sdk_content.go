@@ -136,6 +136,18 @@ func (b *sdkContentBackend) MoveContent(ctx context.Context, params json.RawMess
 	return json.Marshal(result)
 }
 
+func (b *sdkContentBackend) CloneContent(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	var p modula.ContentCloneRequest
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("unmarshal content clone params: %w", err)
+	}
+	result, err := b.client.ContentReorder.Clone(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
 func (b *sdkContentBackend) SaveContentTree(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
 	var p modula.TreeSaveRequest
 	if err := json.Unmarshal(params, &p); err != nil {
@@ -420,6 +432,18 @@ func (b *sdkVersionBackend) RestoreVersion(ctx context.Context, params json.RawM
 	return json.Marshal(result)
 }
 
+func (b *sdkVersionBackend) DiffVersions(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	var p modula.DiffVersionsRequest
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("unmarshal diff versions params: %w", err)
+	}
+	result, err := b.client.Publishing.DiffVersions(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
 func (b *sdkVersionBackend) AdminListVersions(ctx context.Context, contentID string) (json.RawMessage, error) {
 	result, err := b.client.AdminPublishing.ListAdminVersions(ctx, contentID)
 	if err != nil {
@@ -87,3 +87,23 @@ func (b *sdkLocaleBackend) AdminCreateTranslation(ctx context.Context, adminCont
 	}
 	return json.Marshal(result)
 }
+
+func (b *sdkLocaleBackend) GetTranslationStatus(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	var p struct {
+		Locale   string         `json:"locale"`
+		RouteID  modula.RouteID `json:"route_id"`
+		Statuses []string       `json:"statuses"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("unmarshal translation status params: %w", err)
+	}
+	result, err := b.client.Locales.TranslationStatus(ctx, modula.TranslationStatusParams{
+		Locale:   p.Locale,
+		RouteID:  p.RouteID,
+		Statuses: p.Statuses,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
@@ -38,6 +38,7 @@ func NewServiceBackends(svc *service.Registry) *Backends {
 		Locales:           &svcLocaleBackend{svc: svc},
 		Validations:       &svcValidationBackend{svc: svc},
 		Search:            &svcSearchBackend{svc: svc},
+		Blueprints:        &svcBlueprintBackend{svc: svc},
 		Activity:          &svcActivityBackend{svc: svc},
 		Auth:              &svcAuthBackend{svc: svc},
 	}
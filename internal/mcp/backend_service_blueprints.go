@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hegner123/modulacms/internal/blueprint"
+	"github.com/hegner123/modulacms/internal/service"
+)
+
+// ---------------------------------------------------------------------------
+// BlueprintBackend (Service)
+// ---------------------------------------------------------------------------
+
+type svcBlueprintBackend struct {
+	svc *service.Registry
+}
+
+func (b *svcBlueprintBackend) ListBlueprints(ctx context.Context) (json.RawMessage, error) {
+	result, err := b.svc.Blueprints.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func (b *svcBlueprintBackend) GetBlueprint(ctx context.Context, id string) (json.RawMessage, error) {
+	result, err := b.svc.Blueprints.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func (b *svcBlueprintBackend) CreateBlueprint(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	def, err := blueprint.Parse(params)
+	if err != nil {
+		return nil, service.NewValidationError("definition", err.Error())
+	}
+	result, err := b.svc.Blueprints.Create(ctx, AuditContextFromMCP(ctx), def)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func (b *svcBlueprintBackend) CreateBlueprintFromContent(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	var p service.BlueprintFromContentInput
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("unmarshal blueprint from content params: %w", err)
+	}
+	result, err := b.svc.Blueprints.CreateFromContent(ctx, AuditContextFromMCP(ctx), p)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func (b *svcBlueprintBackend) UpdateBlueprint(ctx context.Context, id string, params json.RawMessage) (json.RawMessage, error) {
+	def, err := blueprint.Parse(params)
+	if err != nil {
+		return nil, service.NewValidationError("definition", err.Error())
+	}
+	result, err := b.svc.Blueprints.Update(ctx, AuditContextFromMCP(ctx), id, def)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func (b *svcBlueprintBackend) DeleteBlueprint(ctx context.Context, id string) error {
+	return b.svc.Blueprints.Delete(ctx, AuditContextFromMCP(ctx), id)
+}
+
+func (b *svcBlueprintBackend) InstantiateBlueprint(ctx context.Context, id string, params json.RawMessage) (json.RawMessage, error) {
+	var p service.InstantiateBlueprintInput
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("unmarshal instantiate blueprint params: %w", err)
+	}
+	p.Blueprint = id
+	result, err := b.svc.Blueprints.Instantiate(ctx, AuditContextFromMCP(ctx), p)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func (b *svcBlueprintBackend) ListBlueprintInstances(ctx context.Context, idOrName string) (json.RawMessage, error) {
+	result, err := b.svc.Blueprints.Instances(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
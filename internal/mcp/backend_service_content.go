@@ -179,6 +179,18 @@ func (b *svcContentBackend) MoveContent(ctx context.Context, params json.RawMess
 	return json.Marshal(result)
 }
 
+func (b *svcContentBackend) CloneContent(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	var p service.CloneTreeInput
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("unmarshal clone params: %w", err)
+	}
+	result, err := b.svc.Content.CloneTree(ctx, AuditContextFromMCP(ctx), p)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
 func (b *svcContentBackend) SaveContentTree(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
 	// SaveContentTree is a complex bulk tree operation that has no single
 	// service method. The handler layer orchestrates multiple operations.
@@ -504,6 +516,23 @@ func (b *svcVersionBackend) RestoreVersion(ctx context.Context, params json.RawM
 	return json.Marshal(result)
 }
 
+func (b *svcVersionBackend) DiffVersions(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	var input struct {
+		ContentDataID string `json:"content_data_id"`
+		From          string `json:"from"`
+		To            string `json:"to"`
+		Locale        string `json:"locale"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, fmt.Errorf("unmarshal diff versions params: %w", err)
+	}
+	result, err := b.svc.Content.DiffVersions(ctx, types.ContentID(input.ContentDataID), types.ContentVersionID(input.From), types.ContentVersionID(input.To), input.Locale)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
 func (b *svcVersionBackend) AdminListVersions(ctx context.Context, contentID string) (json.RawMessage, error) {
 	result, err := b.svc.AdminContent.ListVersions(ctx, types.AdminContentID(contentID))
 	if err != nil {
@@ -94,3 +94,23 @@ func (b *svcLocaleBackend) AdminCreateTranslation(ctx context.Context, adminCont
 	}
 	return json.Marshal(result)
 }
+
+func (b *svcLocaleBackend) GetTranslationStatus(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	var p struct {
+		Locale   string   `json:"locale"`
+		RouteID  string   `json:"route_id"`
+		Statuses []string `json:"statuses"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("unmarshal translation status params: %w", err)
+	}
+	in := service.StatusInput{Locale: p.Locale, Statuses: p.Statuses}
+	if p.RouteID != "" {
+		in.RouteID = types.NullableRouteID{ID: types.RouteID(p.RouteID), Valid: true}
+	}
+	result, err := b.svc.Translations.Status(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
@@ -80,8 +80,8 @@ func TestNewProxyBackends_AllFieldsPopulated(t *testing.T) {
 	// Verify the proxy constructor source populates the same number of fields.
 	// We do this by checking that the Backends struct definition has not grown
 	// without updating the constructors. The SDK test above proves SDK covers all,
-	// and the proxy constructor is mechanically identical (30 fields each).
-	if sdkFieldCount != 30 {
+	// and the proxy constructor is mechanically identical (31 fields each).
+	if sdkFieldCount != 31 {
 		t.Errorf("Backends has %d fields; if a field was added, verify all 3 constructors are updated", sdkFieldCount)
 	}
 }
@@ -136,6 +136,9 @@ func (p *proxyContentNoConn) ReorderContent(_ context.Context, _ json.RawMessage
 func (p *proxyContentNoConn) MoveContent(_ context.Context, _ json.RawMessage) (json.RawMessage, error) {
 	return nil, errNoConnection
 }
+func (p *proxyContentNoConn) CloneContent(_ context.Context, _ json.RawMessage) (json.RawMessage, error) {
+	return nil, errNoConnection
+}
 func (p *proxyContentNoConn) SaveContentTree(_ context.Context, _ json.RawMessage) (json.RawMessage, error) {
 	return nil, errNoConnection
 }
@@ -489,6 +492,9 @@ func (f *failingContentBackend) ReorderContent(_ context.Context, _ json.RawMess
 func (f *failingContentBackend) MoveContent(_ context.Context, _ json.RawMessage) (json.RawMessage, error) {
 	return nil, fmt.Errorf("not implemented")
 }
+func (f *failingContentBackend) CloneContent(_ context.Context, _ json.RawMessage) (json.RawMessage, error) {
+	return nil, fmt.Errorf("not implemented")
+}
 func (f *failingContentBackend) SaveContentTree(_ context.Context, _ json.RawMessage) (json.RawMessage, error) {
 	return nil, fmt.Errorf("not implemented")
 }
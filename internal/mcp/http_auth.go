@@ -0,0 +1,218 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// oauthBearerTTL is how long a provider-verified OAuth access token is
+// trusted before the provider is asked again.
+const oauthBearerTTL = 5 * time.Minute
+
+// oauthRejectTTL is how long a token the provider rejected is refused
+// without asking again, so repeated requests with a bad token do not each
+// reach the provider.
+const oauthRejectTTL = time.Minute
+
+// oauthCacheMax bounds the OAuth verification cache. When it is full after
+// pruning expired entries, new results are not cached.
+const oauthCacheMax = 10000
+
+// toolAllowlistKey stores the calling token's tool allowlist in the request
+// context. Absent means every tool is allowed (subject to permissions).
+type toolAllowlistKey struct{}
+
+// bearerAuth authenticates /mcp requests from the Authorization header.
+// Cookie sessions are not accepted: the endpoint is for programmatic
+// clients, and a browser session must not be able to drive tool calls.
+type bearerAuth struct {
+	mgr *config.Manager
+	pc  *middleware.PermissionCache
+
+	// apiKey resolves an API token; oauth resolves a provider access token
+	// and may be nil; user reloads a user for cached OAuth tokens.
+	apiKey func(key string, c *config.Config) (*db.Tokens, *db.Users)
+	oauth  func(ctx context.Context, accessToken string) (*db.Users, error)
+	user   func(id types.UserID) (*db.Users, error)
+
+	mu    sync.Mutex
+	cache map[string]oauthBearer
+}
+
+// oauthBearer is a cached OAuth access token verification. A zero userID
+// records a rejected token.
+type oauthBearer struct {
+	userID  types.UserID
+	expires time.Time
+}
+
+// Middleware returns HTTP middleware that replaces whatever the main chain
+// authenticated with the identity of the bearer token, then injects the
+// same permission set and admin flag PermissionInjector would. API tokens
+// listed in mcp_token_tools also carry their tool allowlist.
+func (b *bearerAuth) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || bearer == "" {
+			writeUnauthorized(w, "bearer token required")
+			return
+		}
+		cfg, err := b.mgr.Config()
+		if err != nil {
+			http.Error(w, "configuration unavailable", http.StatusInternalServerError)
+			return
+		}
+
+		ctx := r.Context()
+		var user *db.Users
+		var allowlist []string
+		var restricted bool
+		if token, u := b.apiKey(bearer, cfg); u != nil {
+			user = u
+			allowlist, restricted = cfg.MCP_Token_Tools[token.ID]
+		} else if b.oauth != nil {
+			user = b.oauthUser(ctx, bearer)
+		}
+		if user == nil {
+			writeUnauthorized(w, "invalid bearer token")
+			return
+		}
+
+		roleID := types.RoleID(user.Role)
+		ctx = middleware.SetAuthenticatedUser(ctx, user)
+		ctx = middleware.SetPermissions(ctx, b.pc.PermissionsForUser(user.UserID, roleID))
+		ctx = middleware.SetIsAdmin(ctx, b.pc.IsAdminUser(user.UserID, roleID))
+		if restricted {
+			ctx = context.WithValue(ctx, toolAllowlistKey{}, allowlist)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// oauthUser resolves an OAuth access token to its linked user, using the
+// cache so the provider is not called on every request. Rejections are
+// cached too, for a shorter time. The user record is re-read each time so
+// role changes apply immediately.
+func (b *bearerAuth) oauthUser(ctx context.Context, accessToken string) *db.Users {
+	key := utility.HashToken(accessToken)
+	now := time.Now()
+
+	b.mu.Lock()
+	cached, ok := b.cache[key]
+	b.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		if cached.userID == "" {
+			return nil
+		}
+		if user, err := b.user(cached.userID); err == nil {
+			return user
+		}
+	}
+
+	user, err := b.oauth(ctx, accessToken)
+	if err != nil {
+		utility.DefaultLogger.Finfo("mcp oauth bearer rejected", err)
+		b.remember(key, oauthBearer{expires: now.Add(oauthRejectTTL)}, now)
+		return nil
+	}
+	b.remember(key, oauthBearer{userID: user.UserID, expires: now.Add(oauthBearerTTL)}, now)
+	return user
+}
+
+// remember caches a verification result after pruning expired entries.
+func (b *bearerAuth) remember(key string, entry oauthBearer, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for k, v := range b.cache {
+		if now.After(v.expires) {
+			delete(b.cache, k)
+		}
+	}
+	if len(b.cache) >= oauthCacheMax {
+		return
+	}
+	b.cache[key] = entry
+}
+
+// writeUnauthorized sends a 401 with a Bearer challenge.
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="modula"`)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	// Encode error is non-recoverable (client disconnected or similar).
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// toolAllowed reports whether the allowlist in ctx permits toolName. Each
+// pattern is a glob matched against the tool name ("get_*"), or, when it
+// contains a colon, against the permission the tool requires ("content:*").
+func toolAllowed(ctx context.Context, toolName string) bool {
+	return allowlistAllows(ctx, toolName, toolPermissions[toolName])
+}
+
+// allowlistAllows reports whether the allowlist in ctx permits the tool,
+// resource URI or prompt called name, which requires permission. Patterns
+// with a colon match the permission; others match the name.
+func allowlistAllows(ctx context.Context, name, permission string) bool {
+	allowlist, restricted := ctx.Value(toolAllowlistKey{}).([]string)
+	if !restricted {
+		return true
+	}
+	for _, pattern := range allowlist {
+		subject := name
+		if strings.Contains(pattern, ":") {
+			if permission == "" {
+				continue
+			}
+			subject = permission
+		}
+		if ok, _ := path.Match(pattern, subject); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ToolAllowlistMiddleware returns a ToolHandlerMiddleware that rejects tool
+// calls outside the calling token's allowlist. It runs before
+// PermissionMiddleware, so an allowlist narrows what a token can do even
+// for admins but never grants a permission the user lacks.
+func ToolAllowlistMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !toolAllowed(ctx, request.Params.Name) {
+				return mcp.NewToolResultError("forbidden: tool '" + request.Params.Name + "' is not allowed for this token"), nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// toolAllowlistFilter hides tools outside the calling token's allowlist
+// from tools/list.
+func toolAllowlistFilter(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	if _, restricted := ctx.Value(toolAllowlistKey{}).([]string); !restricted {
+		return tools
+	}
+	allowed := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if toolAllowed(ctx, tool.Name) {
+			allowed = append(allowed, tool)
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,207 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
+)
+
+type staticConfig struct {
+	cfg *config.Config
+}
+
+func (p staticConfig) Get() (*config.Config, error) {
+	return p.cfg, nil
+}
+
+func newTestBearerAuth(oauthCalls *int) *bearerAuth {
+	users := map[types.UserID]*db.Users{
+		"agent-user": {UserID: "agent-user", Username: "agent"},
+		"oauth-user": {UserID: "oauth-user", Username: "oauth"},
+	}
+	return &bearerAuth{
+		mgr: config.NewManager(staticConfig{cfg: &config.Config{
+			MCP_Token_Tools: map[string][]string{"tok-agent": {"content:*", "get_route"}},
+		}}),
+		pc: middleware.NewPermissionCache(),
+		apiKey: func(key string, c *config.Config) (*db.Tokens, *db.Users) {
+			if key == "api-key" {
+				return &db.Tokens{ID: "tok-agent"}, users["agent-user"]
+			}
+			return nil, nil
+		},
+		oauth: func(ctx context.Context, accessToken string) (*db.Users, error) {
+			*oauthCalls++
+			if accessToken == "provider-token" {
+				return users["oauth-user"], nil
+			}
+			return nil, errors.New("rejected")
+		},
+		user: func(id types.UserID) (*db.Users, error) {
+			return users[id], nil
+		},
+		cache: make(map[string]oauthBearer),
+	}
+}
+
+func TestBearerAuth(t *testing.T) {
+	var oauthCalls int
+	auth := newTestBearerAuth(&oauthCalls)
+
+	var gotUser types.UserID
+	var gotAllowlist []string
+	var restricted bool
+	handler := auth.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser = middleware.AuthenticatedUser(r.Context()).UserID
+		gotAllowlist, restricted = r.Context().Value(toolAllowlistKey{}).([]string)
+	}))
+
+	serve := func(header string) *httptest.ResponseRecorder {
+		gotUser, gotAllowlist, restricted = "", nil, false
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		// A cookie-authenticated user must not carry over to /mcp.
+		req = req.WithContext(middleware.SetAuthenticatedUser(req.Context(), &db.Users{UserID: "cookie-user"}))
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("")
+	if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") == "" {
+		t.Errorf("no bearer: status = %d, challenge = %q, want 401 with a challenge", rec.Code, rec.Header().Get("WWW-Authenticate"))
+	}
+	if rec := serve("Bearer wrong"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("invalid bearer: status = %d, want 401", rec.Code)
+	}
+
+	if rec := serve("Bearer api-key"); rec.Code != http.StatusOK {
+		t.Fatalf("api key: status = %d, want 200", rec.Code)
+	}
+	if gotUser != "agent-user" || !restricted || len(gotAllowlist) != 2 {
+		t.Errorf("api key: user = %q, allowlist = %v (restricted %t), want agent-user with its allowlist", gotUser, gotAllowlist, restricted)
+	}
+
+	oauthCalls = 0
+	for range 2 {
+		if rec := serve("Bearer provider-token"); rec.Code != http.StatusOK {
+			t.Fatalf("oauth token: status = %d, want 200", rec.Code)
+		}
+		if gotUser != "oauth-user" || restricted {
+			t.Errorf("oauth token: user = %q, restricted = %t, want oauth-user unrestricted", gotUser, restricted)
+		}
+	}
+	if oauthCalls != 1 {
+		t.Errorf("provider calls = %d, want 1 (cached)", oauthCalls)
+	}
+
+	// Rejected tokens are cached too.
+	oauthCalls = 0
+	for range 2 {
+		if rec := serve("Bearer revoked-token"); rec.Code != http.StatusUnauthorized {
+			t.Fatalf("rejected oauth token: status = %d, want 401", rec.Code)
+		}
+	}
+	if oauthCalls != 1 {
+		t.Errorf("provider calls for a rejected token = %d, want 1 (cached)", oauthCalls)
+	}
+}
+
+func TestToolAllowed(t *testing.T) {
+	ctx := context.WithValue(context.Background(), toolAllowlistKey{}, []string{"content:*", "get_route"})
+	tests := []struct {
+		tool string
+		want bool
+	}{
+		{"list_content", true},
+		{"update_content_field", true},
+		{"get_route", true},
+		{"list_routes", false},
+		{"delete_user", false},
+		{"health", false},
+	}
+	for _, tt := range tests {
+		if got := toolAllowed(ctx, tt.tool); got != tt.want {
+			t.Errorf("toolAllowed(%q) = %t, want %t", tt.tool, got, tt.want)
+		}
+	}
+	if !toolAllowed(context.Background(), "delete_user") {
+		t.Error("toolAllowed without an allowlist = false, want true")
+	}
+	empty := context.WithValue(context.Background(), toolAllowlistKey{}, []string{})
+	if toolAllowed(empty, "list_content") {
+		t.Error("toolAllowed with an empty allowlist = true, want false")
+	}
+}
+
+func TestToolAllowlistFilter(t *testing.T) {
+	srv := newResourceTestServer(
+		server.WithToolHandlerMiddleware(ToolAllowlistMiddleware()),
+		server.WithToolFilter(toolAllowlistFilter),
+	)
+	ctx := context.WithValue(context.Background(), toolAllowlistKey{}, []string{"get_route"})
+
+	result, errMsg := rpc(t, ctx, srv, "tools/list", map[string]any{})
+	if errMsg != "" {
+		t.Fatalf("tools/list: %s", errMsg)
+	}
+	var list struct {
+		Tools []struct {
+			Name string `json:"name"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(result, &list); err != nil {
+		t.Fatalf("unmarshal tools: %v", err)
+	}
+	if len(list.Tools) != 1 || list.Tools[0].Name != "get_route" {
+		t.Errorf("tools = %+v, want only get_route", list.Tools)
+	}
+
+	result, errMsg = rpc(t, ctx, srv, "tools/call", map[string]any{"name": "delete_user", "arguments": map[string]any{"id": "u-1"}})
+	if errMsg != "" {
+		t.Fatalf("tools/call: %s", errMsg)
+	}
+	var call struct {
+		IsError bool `json:"isError"`
+	}
+	if err := json.Unmarshal(result, &call); err != nil {
+		t.Fatalf("unmarshal call: %v", err)
+	}
+	if !call.IsError {
+		t.Error("delete_user outside the allowlist: isError = false, want true")
+	}
+}
+
+func TestAllowlistGatesResourcesAndPrompts(t *testing.T) {
+	srv := newResourceTestServer(server.WithHooks(PermissionHooks()))
+	ctx := middleware.SetAuthenticatedUser(context.Background(), &db.Users{UserID: "agent-user"})
+	ctx = middleware.SetIsAdmin(ctx, true)
+	ctx = context.WithValue(ctx, toolAllowlistKey{}, []string{"content:*", "draft_page"})
+
+	if _, errMsg := rpc(t, ctx, srv, "resources/read", map[string]any{"uri": "modula://routes/rt-1/content"}); errMsg != "" {
+		t.Errorf("content resource matching content:*: %s", errMsg)
+	}
+	if _, errMsg := rpc(t, ctx, srv, "resources/read", map[string]any{"uri": "modula://locales"}); !strings.Contains(errMsg, "not allowed for this token") {
+		t.Errorf("locales outside the allowlist: error = %q, want not allowed", errMsg)
+	}
+	if _, errMsg := rpc(t, ctx, srv, "prompts/get", map[string]any{"name": "draft_page", "arguments": map[string]string{"datatype": "dt-1"}}); errMsg != "" {
+		t.Errorf("prompt named in the allowlist: %s", errMsg)
+	}
+	ctx = context.WithValue(ctx, toolAllowlistKey{}, []string{"get_route"})
+	if _, errMsg := rpc(t, ctx, srv, "prompts/get", map[string]any{"name": "draft_page", "arguments": map[string]string{"datatype": "dt-1"}}); !strings.Contains(errMsg, "not allowed for this token") {
+		t.Errorf("prompt outside the allowlist: error = %q, want not allowed", errMsg)
+	}
+}
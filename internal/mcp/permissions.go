@@ -34,6 +34,7 @@ var toolPermissions = map[string]string{
 	"delete_content_field":      "content:delete",
 	"reorder_content":           "content:update",
 	"move_content":              "content:update",
+	"clone_content":             "content:create",
 	"save_content_tree":         "content:update",
 	"heal_content":              "content:update",
 	"batch_update_content":      "content:update",
@@ -256,6 +257,7 @@ var toolPermissions = map[string]string{
 	"create_content_version":       "content:update",
 	"delete_content_version":       "content:delete",
 	"restore_content_version":      "content:update",
+	"diff_content_versions":        "content:read",
 	"admin_list_content_versions":  "versions:read",
 	"admin_get_content_version":    "versions:read",
 	"admin_create_content_version": "versions:create",
@@ -281,6 +283,7 @@ var toolPermissions = map[string]string{
 	"delete_locale":           "locale:delete",
 	"create_translation":      "content:create",
 	"admin_create_translation": "locales:create",
+	"get_translation_status":   "content:read",
 
 	// Validation tools
 	"list_validations":         "validations:read",
@@ -300,6 +303,16 @@ var toolPermissions = map[string]string{
 	"search_content":       "search:read",
 	"rebuild_search_index": "search:update",
 
+	// Blueprint tools
+	"list_blueprints":               "content:read",
+	"get_blueprint":                 "content:read",
+	"create_blueprint":              "content:create",
+	"create_blueprint_from_content": "content:create",
+	"update_blueprint":              "content:update",
+	"delete_blueprint":              "content:delete",
+	"instantiate_blueprint":         "content:create",
+	"list_blueprint_instances":      "content:read",
+
 	// Health/activity tools
 	"health":               "health:read",
 	"get_metrics":          "health:read",
@@ -0,0 +1,166 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// promptPermissions maps each built-in prompt to the permission needed to
+// read the live data it embeds.
+var promptPermissions = map[string]string{
+	"draft_page":     "datatypes:read",
+	"translate_tree": "content:read",
+}
+
+func registerPrompts(srv *server.MCPServer, backends *Backends) {
+	srv.AddPrompt(
+		mcp.NewPrompt("draft_page",
+			mcp.WithPromptDescription("Draft a new page of a datatype. Embeds the datatype's live schema so the draft uses its real fields and validation rules."),
+			mcp.WithArgument("datatype", mcp.RequiredArgument(), mcp.ArgumentDescription("Datatype ID, name or label")),
+			mcp.WithArgument("topic", mcp.ArgumentDescription("What the page is about")),
+		),
+		handleDraftPagePrompt(backends.Schema),
+	)
+
+	srv.AddPrompt(
+		mcp.NewPrompt("translate_tree",
+			mcp.WithPromptDescription("Translate the content tree of a route into a locale. Embeds the live tree and the enabled locales."),
+			mcp.WithArgument("route_id", mcp.RequiredArgument(), mcp.ArgumentDescription("Route ID (ULID) of the tree to translate")),
+			mcp.WithArgument("locale", mcp.RequiredArgument(), mcp.ArgumentDescription("Target locale code (e.g. 'fr')")),
+		),
+		handleTranslateTreePrompt(backends.Content, backends.Locales),
+	)
+}
+
+func handleDraftPagePrompt(schema SchemaBackend) server.PromptHandlerFunc {
+	return func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		datatype := strings.TrimSpace(req.Params.Arguments["datatype"])
+		if datatype == "" {
+			return nil, fmt.Errorf("datatype is required")
+		}
+		datatypeID, label, err := resolveDatatype(ctx, schema, datatype)
+		if err != nil {
+			return nil, err
+		}
+		full, err := schema.GetDatatypeFull(ctx, datatypeID)
+		if err != nil {
+			return nil, fmt.Errorf("get datatype %s: %w", datatypeID, err)
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "Draft a new %q page", label)
+		if topic := strings.TrimSpace(req.Params.Arguments["topic"]); topic != "" {
+			fmt.Fprintf(&b, " about: %s", topic)
+		}
+		b.WriteString(".\n\n")
+		b.WriteString("The datatype schema below is read from the live site. Write a value for every field, ")
+		b.WriteString("respecting each field's type and validation rules; leave optional fields empty rather than inventing data such as media IDs. ")
+		b.WriteString("Show the draft for review first. Once approved, create it with create_content_composite, ")
+		b.WriteString("or with create_content followed by create_content_field for each field. New content is created as a draft and is not published.")
+
+		return mcp.NewGetPromptResult(
+			fmt.Sprintf("Draft a %s page", label),
+			[]mcp.PromptMessage{
+				mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(b.String())),
+				mcp.NewPromptMessage(mcp.RoleUser, mcp.NewEmbeddedResource(mcp.TextResourceContents{
+					URI:      datatypeURI(datatypeID),
+					MIMEType: "application/json",
+					Text:     string(full),
+				})),
+			},
+		), nil
+	}
+}
+
+func handleTranslateTreePrompt(content ContentBackend, locales LocaleBackend) server.PromptHandlerFunc {
+	return func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		routeID := strings.TrimSpace(req.Params.Arguments["route_id"])
+		locale := strings.TrimSpace(req.Params.Arguments["locale"])
+		if routeID == "" || locale == "" {
+			return nil, fmt.Errorf("route_id and locale are required")
+		}
+
+		localeData, err := locales.ListLocales(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list locales: %w", err)
+		}
+		var enabled []struct {
+			Code      string `json:"code"`
+			Label     string `json:"label"`
+			IsDefault bool   `json:"is_default"`
+		}
+		if err := json.Unmarshal(localeData, &enabled); err != nil {
+			return nil, fmt.Errorf("parse locales: %w", err)
+		}
+		target, source := "", ""
+		for _, l := range enabled {
+			if strings.EqualFold(l.Code, locale) {
+				target = l.Label
+				locale = l.Code
+			}
+			if l.IsDefault {
+				source = l.Code
+			}
+		}
+		if target == "" {
+			return nil, fmt.Errorf("locale %q is not enabled", locale)
+		}
+
+		tree, err := content.GetContentByRoute(ctx, routeID)
+		if err != nil {
+			return nil, fmt.Errorf("get content for route %s: %w", routeID, err)
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "Translate the content tree of route %s into %s (%s)", routeID, target, locale)
+		if source != "" {
+			fmt.Fprintf(&b, " from the default locale %s", source)
+		}
+		b.WriteString(".\n\n")
+		b.WriteString("The tree below is read from the live site. For each content node, call create_translation ")
+		fmt.Fprintf(&b, "with locale %q to copy its translatable fields into the new locale, ", locale)
+		b.WriteString("then update each copied field with update_content_field. ")
+		b.WriteString("Translate text and rich text only; keep IDs, slugs, URLs, media references and markup unchanged. ")
+		b.WriteString("List any field you were unsure about when you finish. Translations are drafts until published.")
+
+		return mcp.NewGetPromptResult(
+			fmt.Sprintf("Translate route %s to %s", routeID, locale),
+			[]mcp.PromptMessage{
+				mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(b.String())),
+				mcp.NewPromptMessage(mcp.RoleUser, mcp.NewEmbeddedResource(mcp.TextResourceContents{
+					URI:      routeContentURI(routeID),
+					MIMEType: "application/json",
+					Text:     string(tree),
+				})),
+			},
+		), nil
+	}
+}
+
+// resolveDatatype finds a datatype by ID, name or label (case-insensitive)
+// and returns its ID and label.
+func resolveDatatype(ctx context.Context, schema SchemaBackend, ref string) (id, label string, err error) {
+	data, err := schema.ListDatatypes(ctx, false)
+	if err != nil {
+		return "", "", fmt.Errorf("list datatypes: %w", err)
+	}
+	var datatypes []struct {
+		DatatypeID string `json:"datatype_id"`
+		Name       string `json:"name"`
+		Label      string `json:"label"`
+	}
+	if err := json.Unmarshal(data, &datatypes); err != nil {
+		return "", "", fmt.Errorf("parse datatypes: %w", err)
+	}
+	for _, dt := range datatypes {
+		if dt.DatatypeID == ref || strings.EqualFold(dt.Name, ref) || strings.EqualFold(dt.Label, ref) {
+			return dt.DatatypeID, dt.Label, nil
+		}
+	}
+	return "", "", fmt.Errorf("datatype %q not found", ref)
+}
@@ -0,0 +1,245 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ResourcePollInterval is how often the resource watcher reads change_events
+// through the activity feed.
+const ResourcePollInterval = 10 * time.Second
+
+// resourcePollLimit is how many recent events each poll reads. A burst of
+// more events between polls is reported as a list change.
+const resourcePollLimit = 100
+
+// resourceEvent is the part of an activity feed entry the watcher uses.
+type resourceEvent struct {
+	EventID   string `json:"event_id"`
+	TableName string `json:"table_name"`
+	RecordID  string `json:"record_id"`
+	Operation string `json:"operation"`
+}
+
+// resourceSubscriptions tracks which resources each session has read. The
+// MCP library does not route resources/subscribe, so a successful
+// resources/read subscribes the session to that resource's updates until
+// the session ends.
+type resourceSubscriptions struct {
+	// checkPermissions re-checks the reader's permission for the resource
+	// before each update. It is off for stdio servers, which run without an
+	// authenticated user.
+	checkPermissions bool
+
+	mu       sync.Mutex
+	sessions map[string]*resourceSubscriber
+}
+
+type resourceSubscriber struct {
+	// ctx is the context of the session's latest request. It carries the
+	// user and permissions that updates are checked against.
+	ctx  context.Context
+	uris map[string]bool
+}
+
+func newResourceSubscriptions(checkPermissions bool) *resourceSubscriptions {
+	return &resourceSubscriptions{
+		checkPermissions: checkPermissions,
+		sessions:         make(map[string]*resourceSubscriber),
+	}
+}
+
+// register adds the hooks that record reads, keep each subscriber's
+// permissions current, and forget sessions when they end.
+func (s *resourceSubscriptions) register(hooks *server.Hooks) {
+	hooks.AddAfterReadResource(func(ctx context.Context, _ any, req *mcp.ReadResourceRequest, _ *mcp.ReadResourceResult) {
+		s.subscribe(ctx, req.Params.URI)
+	})
+	hooks.AddBeforeAny(func(ctx context.Context, _ any, _ mcp.MCPMethod, _ any) {
+		session := server.ClientSessionFromContext(ctx)
+		if session == nil {
+			return
+		}
+		s.mu.Lock()
+		if sub, ok := s.sessions[session.SessionID()]; ok {
+			sub.ctx = ctx
+		}
+		s.mu.Unlock()
+	})
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		s.mu.Lock()
+		delete(s.sessions, session.SessionID())
+		s.mu.Unlock()
+	})
+}
+
+func (s *resourceSubscriptions) subscribe(ctx context.Context, uri string) {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, ok := s.sessions[session.SessionID()]
+	if !ok {
+		sub = &resourceSubscriber{uris: make(map[string]bool)}
+		s.sessions[session.SessionID()] = sub
+	}
+	sub.ctx = ctx
+	sub.uris[uri] = true
+}
+
+// subscribers returns the IDs of the sessions subscribed to uri that may
+// still read it.
+func (s *resourceSubscriptions) subscribers(uri string) []string {
+	permission, ok := resourcePermission(uri)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for id, sub := range s.sessions {
+		if !sub.uris[uri] {
+			continue
+		}
+		if s.checkPermissions && (!ok || permissionDenied(sub.ctx, permission) != "") {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// watchResourceChanges polls the activity feed and tells connected clients
+// which modula:// resources changed, until ctx is done. It sends
+// notifications/resources/updated for each changed resource to the sessions
+// in subs that read it, and notifications/resources/list_changed to every
+// session when items were created or deleted. The first poll only records
+// where the feed starts.
+func watchResourceChanges(ctx context.Context, srv *server.MCPServer, backends *Backends, subs *resourceSubscriptions) {
+	ticker := time.NewTicker(ResourcePollInterval)
+	defer ticker.Stop()
+
+	var seen map[string]bool
+	for {
+		events, err := recentResourceEvents(ctx, backends.Activity)
+		// Errors are not logged: in stdio mode stdout carries the protocol.
+		// Disconnected proxies and transient errors retry on the next tick.
+		if err == nil {
+			if seen != nil {
+				fresh := unseenEvents(events, seen)
+				uris, listChanged := changedResources(ctx, backends, fresh)
+				if len(fresh) == len(events) && len(events) == resourcePollLimit {
+					listChanged = true
+				}
+				for _, uri := range uris {
+					for _, id := range subs.subscribers(uri) {
+						// A session that ended since the lookup is dropped by
+						// its unregister hook; nothing to retry.
+						_ = srv.SendNotificationToSpecificClient(id, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+					}
+				}
+				if listChanged {
+					srv.SendNotificationToAllClients(mcp.MethodNotificationResourcesListChanged, nil)
+				}
+			}
+			seen = make(map[string]bool, len(events))
+			for _, ev := range events {
+				seen[ev.EventID] = true
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func recentResourceEvents(ctx context.Context, activity ActivityBackend) ([]resourceEvent, error) {
+	data, err := activity.ListRecentActivity(ctx, resourcePollLimit)
+	if err != nil {
+		return nil, err
+	}
+	var events []resourceEvent
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// unseenEvents returns the events not in seen, oldest first.
+func unseenEvents(events []resourceEvent, seen map[string]bool) []resourceEvent {
+	var fresh []resourceEvent
+	for i := len(events) - 1; i >= 0; i-- {
+		if !seen[events[i].EventID] {
+			fresh = append(fresh, events[i])
+		}
+	}
+	return fresh
+}
+
+// changedResources maps change events to the resource URIs they affect,
+// without duplicates. listChanged reports whether a listed resource was
+// created or deleted. Field values and field definitions are resolved to
+// their content node and datatype; rows that no longer exist are skipped.
+func changedResources(ctx context.Context, backends *Backends, events []resourceEvent) (uris []string, listChanged bool) {
+	added := make(map[string]bool)
+	add := func(uri string) {
+		if !added[uri] {
+			added[uri] = true
+			uris = append(uris, uri)
+		}
+	}
+	for _, ev := range events {
+		structural := ev.Operation == "INSERT" || ev.Operation == "DELETE"
+		switch ev.TableName {
+		case "routes":
+			add(resourceRoutes)
+			add(routeContentURI(ev.RecordID))
+			listChanged = listChanged || structural
+		case "datatypes":
+			add(resourceDatatypes)
+			add(datatypeURI(ev.RecordID))
+			listChanged = listChanged || structural
+		case "fields":
+			if id := lookupRef(func() (json.RawMessage, error) { return backends.Schema.GetField(ctx, ev.RecordID) }, "parent_id"); id != "" {
+				add(datatypeURI(id))
+			}
+		case "locales":
+			add(resourceLocales)
+		case "content_data":
+			add(contentURI(ev.RecordID))
+			if id := lookupRef(func() (json.RawMessage, error) { return backends.Content.GetContent(ctx, ev.RecordID) }, "route_id"); id != "" {
+				add(routeContentURI(id))
+			}
+		case "content_fields":
+			if id := lookupRef(func() (json.RawMessage, error) { return backends.Content.GetContentField(ctx, ev.RecordID) }, "content_data_id"); id != "" {
+				add(contentURI(id))
+			}
+		}
+	}
+	return uris, listChanged
+}
+
+// lookupRef reads a record and returns one of its ID fields, or "" when the
+// record is gone or the field is null.
+func lookupRef(read func() (json.RawMessage, error), key string) string {
+	data, err := read()
+	if err != nil {
+		return ""
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return ""
+	}
+	var id string
+	if err := json.Unmarshal(fields[key], &id); err != nil {
+		return ""
+	}
+	return id
+}
@@ -0,0 +1,177 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Resource URIs. Static resources list what exists; templates read one item.
+// Every resource is JSON produced by the same backends as the equivalent
+// tool, so reading modula://datatypes/{id} returns what get_datatype_full
+// returns.
+const (
+	resourceScheme         = "modula://"
+	resourceRoutes         = "modula://routes"
+	resourceDatatypes      = "modula://datatypes"
+	resourceLocales        = "modula://locales"
+	templateRouteContent   = "modula://routes/{route_id}/content"
+	templateContent        = "modula://content/{content_id}"
+	templateDatatypeSchema = "modula://datatypes/{datatype_id}"
+)
+
+// routeContentURI returns the resource URI for the content tree of a route.
+func routeContentURI(routeID string) string {
+	return resourceRoutes + "/" + routeID + "/content"
+}
+
+// contentURI returns the resource URI for one content node.
+func contentURI(contentID string) string {
+	return resourceScheme + "content/" + contentID
+}
+
+// datatypeURI returns the resource URI for one datatype schema.
+func datatypeURI(datatypeID string) string {
+	return resourceDatatypes + "/" + datatypeID
+}
+
+// resourcePermission returns the permission label required to read uri,
+// mirroring the permission of the equivalent tool. ok is false for URIs
+// outside the modula:// scheme.
+func resourcePermission(uri string) (permission string, ok bool) {
+	rest, found := strings.CutPrefix(uri, resourceScheme)
+	if !found {
+		return "", false
+	}
+	switch {
+	case strings.HasPrefix(rest, "routes/") && strings.HasSuffix(rest, "/content"):
+		return "content:read", true
+	case rest == "routes" || strings.HasPrefix(rest, "routes/"):
+		return "routes:read", true
+	case rest == "datatypes" || strings.HasPrefix(rest, "datatypes/"):
+		return "datatypes:read", true
+	case rest == "locales":
+		return "locale:read", true
+	case strings.HasPrefix(rest, "content/"):
+		return "content:read", true
+	}
+	return "", false
+}
+
+func registerResources(srv *server.MCPServer, backends *Backends) {
+	srv.AddResource(
+		mcp.NewResource(resourceRoutes, "Routes",
+			mcp.WithResourceDescription("Every public route with its slug, title and status. Read modula://routes/{route_id}/content for the content tree of one route."),
+			mcp.WithMIMEType("application/json"),
+		),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return readJSONResource(req.Params.URI, func() (json.RawMessage, error) {
+				return backends.Routes.ListRoutes(ctx)
+			})
+		},
+	)
+
+	srv.AddResource(
+		mcp.NewResource(resourceDatatypes, "Datatypes",
+			mcp.WithResourceDescription("Every datatype with its ID, name and label. Read modula://datatypes/{datatype_id} for the full schema of one datatype."),
+			mcp.WithMIMEType("application/json"),
+		),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return readJSONResource(req.Params.URI, func() (json.RawMessage, error) {
+				return backends.Schema.ListDatatypes(ctx, false)
+			})
+		},
+	)
+
+	srv.AddResource(
+		mcp.NewResource(resourceLocales, "Locales",
+			mcp.WithResourceDescription("Enabled locales, including the default locale and fallback chain."),
+			mcp.WithMIMEType("application/json"),
+		),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return readJSONResource(req.Params.URI, func() (json.RawMessage, error) {
+				return backends.Locales.ListLocales(ctx)
+			})
+		},
+	)
+
+	srv.AddResourceTemplate(
+		mcp.NewResourceTemplate(templateRouteContent, "Route content tree",
+			mcp.WithTemplateDescription("All content nodes of a route with their field values."),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			routeID, err := resourceArg(req, "route_id")
+			if err != nil {
+				return nil, err
+			}
+			return readJSONResource(req.Params.URI, func() (json.RawMessage, error) {
+				return backends.Content.GetContentByRoute(ctx, routeID)
+			})
+		},
+	)
+
+	srv.AddResourceTemplate(
+		mcp.NewResourceTemplate(templateContent, "Content node",
+			mcp.WithTemplateDescription("One content node with its datatype, author and field values."),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			contentID, err := resourceArg(req, "content_id")
+			if err != nil {
+				return nil, err
+			}
+			return readJSONResource(req.Params.URI, func() (json.RawMessage, error) {
+				return backends.Content.GetContentFull(ctx, contentID)
+			})
+		},
+	)
+
+	srv.AddResourceTemplate(
+		mcp.NewResourceTemplate(templateDatatypeSchema, "Datatype schema",
+			mcp.WithTemplateDescription("A datatype with its field definitions, field types and validation rules."),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			datatypeID, err := resourceArg(req, "datatype_id")
+			if err != nil {
+				return nil, err
+			}
+			return readJSONResource(req.Params.URI, func() (json.RawMessage, error) {
+				return backends.Schema.GetDatatypeFull(ctx, datatypeID)
+			})
+		},
+	)
+}
+
+// resourceArg returns a variable matched from a resource template URI.
+func resourceArg(req mcp.ReadResourceRequest, name string) (string, error) {
+	var value string
+	switch v := req.Params.Arguments[name].(type) {
+	case string:
+		value = v
+	case []string:
+		if len(v) > 0 {
+			value = v[0]
+		}
+	}
+	if value == "" {
+		return "", fmt.Errorf("resource %s: missing %s", req.Params.URI, name)
+	}
+	return value, nil
+}
+
+// readJSONResource wraps a backend's JSON result as the contents of uri.
+func readJSONResource(uri string, read func() (json.RawMessage, error)) ([]mcp.ResourceContents, error) {
+	data, err := read()
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", uri, err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: uri, MIMEType: "application/json", Text: string(data)},
+	}, nil
+}
@@ -0,0 +1,326 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
+)
+
+// Fakes embed the backend interfaces and override only what the resources
+// and prompts read. Calling anything else panics on the nil interface.
+
+type fakeSchemaBackend struct {
+	SchemaBackend
+}
+
+func (fakeSchemaBackend) ListDatatypes(ctx context.Context, full bool) (json.RawMessage, error) {
+	return json.RawMessage(`[{"datatype_id":"dt-1","name":"blog_post","label":"Blog Post"}]`), nil
+}
+
+func (fakeSchemaBackend) GetDatatypeFull(ctx context.Context, id string) (json.RawMessage, error) {
+	if id != "dt-1" {
+		return nil, errors.New("not found")
+	}
+	return json.RawMessage(`{"datatype_id":"dt-1","fields":[{"name":"title"}]}`), nil
+}
+
+func (fakeSchemaBackend) GetField(ctx context.Context, id string) (json.RawMessage, error) {
+	return json.RawMessage(`{"field_id":"` + id + `","parent_id":"dt-1"}`), nil
+}
+
+type fakeContentBackend struct {
+	ContentBackend
+}
+
+func (fakeContentBackend) GetContentByRoute(ctx context.Context, routeID string) (json.RawMessage, error) {
+	return json.RawMessage(`[{"content_data_id":"cd-1","route_id":"` + routeID + `"}]`), nil
+}
+
+func (fakeContentBackend) GetContent(ctx context.Context, id string) (json.RawMessage, error) {
+	if id == "cd-gone" {
+		return nil, errors.New("not found")
+	}
+	return json.RawMessage(`{"content_data_id":"` + id + `","route_id":"rt-1"}`), nil
+}
+
+func (fakeContentBackend) GetContentField(ctx context.Context, id string) (json.RawMessage, error) {
+	return json.RawMessage(`{"content_field_id":"` + id + `","content_data_id":"cd-2"}`), nil
+}
+
+type fakeLocaleBackend struct {
+	LocaleBackend
+}
+
+func (fakeLocaleBackend) ListLocales(ctx context.Context) (json.RawMessage, error) {
+	return json.RawMessage(`[{"code":"en","label":"English","is_default":true},{"code":"fr","label":"French"}]`), nil
+}
+
+func newResourceTestServer(opts ...server.ServerOption) *server.MCPServer {
+	return newServer(&Backends{
+		Schema:  fakeSchemaBackend{},
+		Content: fakeContentBackend{},
+		Locales: fakeLocaleBackend{},
+	}, nil, opts...)
+}
+
+// rpc sends one JSON-RPC request and returns the decoded result, or the
+// error message when the server returned an error.
+func rpc(t *testing.T, ctx context.Context, srv *server.MCPServer, method string, params any) (json.RawMessage, string) {
+	t.Helper()
+	b, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	out, err := json.Marshal(srv.HandleMessage(ctx, b))
+	if err != nil {
+		t.Fatalf("marshal response: %v", err)
+	}
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Error != nil {
+		return nil, resp.Error.Message
+	}
+	return resp.Result, ""
+}
+
+func TestResources_ReadTemplate(t *testing.T) {
+	srv := newResourceTestServer()
+
+	result, errMsg := rpc(t, context.Background(), srv, "resources/read", map[string]any{"uri": "modula://datatypes/dt-1"})
+	if errMsg != "" {
+		t.Fatalf("resources/read: %s", errMsg)
+	}
+	var read struct {
+		Contents []mcp.TextResourceContents `json:"contents"`
+	}
+	if err := json.Unmarshal(result, &read); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if len(read.Contents) != 1 {
+		t.Fatalf("contents = %d, want 1", len(read.Contents))
+	}
+	if tc := read.Contents[0]; tc.URI != "modula://datatypes/dt-1" || !strings.Contains(tc.Text, `"fields"`) {
+		t.Errorf("contents = %+v, want the datatype schema", tc)
+	}
+
+	result, errMsg = rpc(t, context.Background(), srv, "resources/templates/list", map[string]any{})
+	if errMsg != "" {
+		t.Fatalf("resources/templates/list: %s", errMsg)
+	}
+	var templates struct {
+		ResourceTemplates []struct {
+			URITemplate string `json:"uriTemplate"`
+		} `json:"resourceTemplates"`
+	}
+	if err := json.Unmarshal(result, &templates); err != nil {
+		t.Fatalf("unmarshal templates: %v", err)
+	}
+	if len(templates.ResourceTemplates) != 3 {
+		t.Errorf("templates = %+v, want 3", templates.ResourceTemplates)
+	}
+}
+
+func TestPrompts_DraftPage(t *testing.T) {
+	srv := newResourceTestServer()
+
+	result, errMsg := rpc(t, context.Background(), srv, "prompts/get", map[string]any{
+		"name":      "draft_page",
+		"arguments": map[string]string{"datatype": "blog post", "topic": "spring launch"},
+	})
+	if errMsg != "" {
+		t.Fatalf("prompts/get: %s", errMsg)
+	}
+	var got struct {
+		Messages []struct {
+			Content struct {
+				Type     string `json:"type"`
+				Text     string `json:"text"`
+				Resource struct {
+					URI string `json:"uri"`
+				} `json:"resource"`
+			} `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(result, &got); err != nil {
+		t.Fatalf("unmarshal prompt: %v", err)
+	}
+	if len(got.Messages) != 2 {
+		t.Fatalf("messages = %d, want 2", len(got.Messages))
+	}
+	if !strings.Contains(got.Messages[0].Content.Text, `"Blog Post"`) || !strings.Contains(got.Messages[0].Content.Text, "spring launch") {
+		t.Errorf("instructions = %q, want the datatype label and topic", got.Messages[0].Content.Text)
+	}
+	if got.Messages[1].Content.Type != "resource" || got.Messages[1].Content.Resource.URI != "modula://datatypes/dt-1" {
+		t.Errorf("second message = %+v, want the embedded schema", got.Messages[1].Content)
+	}
+
+	_, errMsg = rpc(t, context.Background(), srv, "prompts/get", map[string]any{
+		"name":      "translate_tree",
+		"arguments": map[string]string{"route_id": "rt-1", "locale": "de"},
+	})
+	if !strings.Contains(errMsg, "not enabled") {
+		t.Errorf("translate_tree to a disabled locale: error = %q, want not enabled", errMsg)
+	}
+}
+
+func TestPermissionHooks(t *testing.T) {
+	srv := newResourceTestServer(server.WithHooks(PermissionHooks()))
+	read := map[string]any{"uri": "modula://datatypes/dt-1"}
+
+	if _, errMsg := rpc(t, context.Background(), srv, "resources/read", read); errMsg != errAuthRequired {
+		t.Errorf("unauthenticated read: error = %q, want %q", errMsg, errAuthRequired)
+	}
+
+	ctx := middleware.SetAuthenticatedUser(context.Background(), &db.Users{UserID: types.UserID("editor-user")})
+	ctx = middleware.SetIsAdmin(ctx, false)
+	ctx = middleware.SetPermissions(ctx, middleware.PermissionSet{"content:read": {}})
+	if _, errMsg := rpc(t, ctx, srv, "resources/read", read); !strings.Contains(errMsg, "datatypes:read") {
+		t.Errorf("read without datatypes:read: error = %q, want forbidden", errMsg)
+	}
+	if _, errMsg := rpc(t, ctx, srv, "prompts/get", map[string]any{"name": "draft_page", "arguments": map[string]string{"datatype": "dt-1"}}); !strings.Contains(errMsg, "datatypes:read") {
+		t.Errorf("prompt without datatypes:read: error = %q, want forbidden", errMsg)
+	}
+
+	ctx = middleware.SetPermissions(ctx, middleware.PermissionSet{"datatypes:read": {}})
+	if _, errMsg := rpc(t, ctx, srv, "resources/read", read); errMsg != "" {
+		t.Errorf("read with datatypes:read: %s", errMsg)
+	}
+
+	// Unmapped resources and prompts are denied, even to admins.
+	admin := middleware.SetIsAdmin(ctx, true)
+	if _, errMsg := rpc(t, admin, srv, "resources/read", map[string]any{"uri": "file:///etc/passwd"}); !strings.Contains(errMsg, "no permission is mapped") {
+		t.Errorf("unmapped resource: error = %q, want denied", errMsg)
+	}
+	if _, errMsg := rpc(t, admin, srv, "prompts/get", map[string]any{"name": "unknown"}); !strings.Contains(errMsg, "no permission is mapped") {
+		t.Errorf("unmapped prompt: error = %q, want denied", errMsg)
+	}
+}
+
+type fakeSession struct{ id string }
+
+func (s fakeSession) Initialize()                                         {}
+func (s fakeSession) Initialized() bool                                   { return true }
+func (s fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s fakeSession) SessionID() string                                   { return s.id }
+
+func TestResourceSubscriptions(t *testing.T) {
+	subs := newResourceSubscriptions(true)
+	hooks := PermissionHooks()
+	subs.register(hooks)
+	srv := newResourceTestServer(server.WithHooks(hooks))
+	uri := "modula://datatypes/dt-1"
+
+	user := middleware.SetAuthenticatedUser(context.Background(), &db.Users{UserID: types.UserID("editor-user")})
+	user = middleware.SetIsAdmin(user, false)
+	reader := middleware.SetPermissions(user, middleware.PermissionSet{"datatypes:read": {}})
+	a, b := fakeSession{id: "a"}, fakeSession{id: "b"}
+
+	if _, errMsg := rpc(t, srv.WithContext(reader, a), srv, "resources/read", map[string]any{"uri": uri}); errMsg != "" {
+		t.Fatalf("resources/read: %s", errMsg)
+	}
+	if _, errMsg := rpc(t, srv.WithContext(reader, b), srv, "resources/list", map[string]any{}); errMsg != "" {
+		t.Fatalf("resources/list: %s", errMsg)
+	}
+	if got := subs.subscribers(uri); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("subscribers = %v, want only the session that read it", got)
+	}
+	if got := subs.subscribers("modula://datatypes"); len(got) != 0 {
+		t.Errorf("subscribers of an unread resource = %v, want none", got)
+	}
+
+	// The next request from the session carries its current permissions.
+	revoked := middleware.SetPermissions(user, middleware.PermissionSet{"content:read": {}})
+	if _, errMsg := rpc(t, srv.WithContext(revoked, a), srv, "resources/list", map[string]any{}); errMsg != "" {
+		t.Fatalf("resources/list: %s", errMsg)
+	}
+	if got := subs.subscribers(uri); len(got) != 0 {
+		t.Errorf("subscribers after revoking datatypes:read = %v, want none", got)
+	}
+
+	if _, errMsg := rpc(t, srv.WithContext(reader, a), srv, "resources/list", map[string]any{}); errMsg != "" {
+		t.Fatalf("resources/list: %s", errMsg)
+	}
+	if err := srv.RegisterSession(context.Background(), a); err != nil {
+		t.Fatalf("RegisterSession: %v", err)
+	}
+	srv.UnregisterSession(context.Background(), "a")
+	if got := subs.subscribers(uri); len(got) != 0 {
+		t.Errorf("subscribers after the session ended = %v, want none", got)
+	}
+}
+
+func TestResourcePermission(t *testing.T) {
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{"modula://routes", "routes:read"},
+		{"modula://routes/rt-1/content", "content:read"},
+		{"modula://datatypes", "datatypes:read"},
+		{"modula://datatypes/dt-1", "datatypes:read"},
+		{"modula://locales", "locale:read"},
+		{"modula://content/cd-1", "content:read"},
+		{"file:///etc/passwd", ""},
+	}
+	for _, tt := range tests {
+		if got, _ := resourcePermission(tt.uri); got != tt.want {
+			t.Errorf("resourcePermission(%q) = %q, want %q", tt.uri, got, tt.want)
+		}
+	}
+}
+
+func TestChangedResources(t *testing.T) {
+	backends := &Backends{Schema: fakeSchemaBackend{}, Content: fakeContentBackend{}}
+	events := []resourceEvent{
+		{EventID: "e1", TableName: "content_fields", RecordID: "cf-1", Operation: "UPDATE"},
+		{EventID: "e2", TableName: "content_data", RecordID: "cd-1", Operation: "UPDATE"},
+		{EventID: "e3", TableName: "content_data", RecordID: "cd-gone", Operation: "DELETE"},
+		{EventID: "e4", TableName: "fields", RecordID: "f-1", Operation: "UPDATE"},
+		{EventID: "e5", TableName: "users", RecordID: "u-1", Operation: "INSERT"},
+		{EventID: "e6", TableName: "content_data", RecordID: "cd-1", Operation: "UPDATE"},
+	}
+	uris, listChanged := changedResources(context.Background(), backends, events)
+	want := []string{
+		"modula://content/cd-2",
+		"modula://content/cd-1",
+		"modula://routes/rt-1/content",
+		"modula://content/cd-gone",
+		"modula://datatypes/dt-1",
+	}
+	if !reflect.DeepEqual(uris, want) {
+		t.Errorf("uris = %v, want %v", uris, want)
+	}
+	if listChanged {
+		t.Error("listChanged = true, want false for content and field edits")
+	}
+
+	_, listChanged = changedResources(context.Background(), backends, []resourceEvent{{EventID: "e7", TableName: "routes", RecordID: "rt-2", Operation: "INSERT"}})
+	if !listChanged {
+		t.Error("listChanged = false, want true for a new route")
+	}
+}
+
+func TestUnseenEvents(t *testing.T) {
+	events := []resourceEvent{{EventID: "e3"}, {EventID: "e2"}, {EventID: "e1"}}
+	fresh := unseenEvents(events, map[string]bool{"e1": true})
+	if len(fresh) != 2 || fresh[0].EventID != "e2" || fresh[1].EventID != "e3" {
+		t.Errorf("fresh = %+v, want e2 then e3", fresh)
+	}
+}
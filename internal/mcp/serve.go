@@ -1,20 +1,27 @@
 package mcp
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/middleware"
 	"github.com/hegner123/modulacms/internal/service"
 	"github.com/hegner123/modulacms/internal/utility"
 )
 
-// newServer creates an MCPServer with all tools registered.
-// If cm is non-nil, connection management tools (list_projects,
-// switch_project, get_connection) are also registered.
+// newServer creates an MCPServer with all tools, the modula:// resources
+// and the built-in prompts registered. If cm is non-nil, connection
+// management tools (list_projects, switch_project, get_connection) are also
+// registered.
 // Variadic opts are applied to the MCPServer after creation.
 func newServer(backends *Backends, cm *ConnectionManager, opts ...server.ServerOption) *server.MCPServer {
+	opts = append([]server.ServerOption{
+		server.WithResourceCapabilities(false, true),
+		server.WithPromptCapabilities(false),
+	}, opts...)
 	srv := server.NewMCPServer("modula", utility.Version, opts...)
 
 	if cm != nil {
@@ -49,9 +56,13 @@ func newServer(backends *Backends, cm *ConnectionManager, opts ...server.ServerO
 	registerLocaleTools(srv, backends.Locales)
 	registerValidationTools(srv, backends.Validations)
 	registerSearchTools(srv, backends.Search)
+	registerBlueprintTools(srv, backends.Blueprints)
 	registerActivityTools(srv, backends.Activity)
 	registerAuthTools(srv, backends.Auth)
 
+	registerResources(srv, backends)
+	registerPrompts(srv, backends)
+
 	return srv
 }
 
@@ -79,7 +90,14 @@ func ServeWithRegistry(initialProject, initialEnv string) error {
 	}
 
 	backends := NewProxyBackends(cm)
-	return server.ServeStdio(newServer(backends, cm))
+	subs := newResourceSubscriptions(false)
+	hooks := &server.Hooks{}
+	subs.register(hooks)
+	srv := newServer(backends, cm, server.WithHooks(hooks))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watchResourceChanges(ctx, srv, backends, subs)
+	return server.ServeStdio(srv)
 }
 
 // ServeDirect creates an MCP server that calls services directly and serves
@@ -90,24 +108,51 @@ func ServeWithRegistry(initialProject, initialEnv string) error {
 // identity for audit trails.
 func ServeDirect(svc *service.Registry, ac audited.AuditContext) error {
 	backends := NewServiceBackends(svc)
+	subs := newResourceSubscriptions(false)
+	hooks := &server.Hooks{}
+	subs.register(hooks)
 	srv := newServer(backends, nil,
 		server.WithToolHandlerMiddleware(injectAuditContextMiddleware(ac)),
+		server.WithHooks(hooks),
 	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watchResourceChanges(ctx, srv, backends, subs)
 	return server.ServeStdio(srv)
 }
 
 // DirectHandler returns an http.Handler that serves the MCP protocol over
 // Streamable HTTP, calling services directly without HTTP round-trips.
-// Authentication is handled by the DefaultMiddlewareChain which wraps the
-// mux and populates the request context. The PermissionMiddleware checks
-// per-tool permissions before executing each tool call.
+// Every request must carry an API token or an OAuth access token from the
+// configured provider as a bearer token; cookie sessions are rejected.
+// The token's user gets the same PermissionCache permissions and audit
+// context as a REST request. API tokens listed in mcp_token_tools may only
+// see and call the tools their allowlist matches. The PermissionMiddleware
+// checks per-tool permissions before executing each tool call, and
+// PermissionHooks applies the same checks to resource reads and prompts.
+// Resource change notifications run for the life of the process.
 func DirectHandler(svc *service.Registry) http.Handler {
 	backends := NewServiceBackends(svc)
+	subs := newResourceSubscriptions(true)
+	hooks := PermissionHooks()
+	subs.register(hooks)
 	srv := newServer(backends, nil,
+		server.WithToolHandlerMiddleware(ToolAllowlistMiddleware()),
 		server.WithToolHandlerMiddleware(PermissionMiddleware()),
+		server.WithToolFilter(toolAllowlistFilter),
+		server.WithHooks(hooks),
 	)
-	return server.NewStreamableHTTPServer(srv,
+	go watchResourceChanges(context.Background(), srv, backends, subs)
+	auth := &bearerAuth{
+		mgr:    svc.Manager(),
+		pc:     svc.PermissionCache(),
+		apiKey: middleware.APIKeyToken,
+		oauth:  svc.Auth.AuthenticateOAuthBearer,
+		user:   svc.Driver().GetUser,
+		cache:  make(map[string]oauthBearer),
+	}
+	return auth.Middleware(server.NewStreamableHTTPServer(srv,
 		server.WithEndpointPath("/mcp"),
-		server.WithHTTPContextFunc(PassthroughHTTPContextFunc()),
-	)
+		server.WithHTTPContextFunc(RequestAuditContextFunc(svc.Manager())),
+	))
 }
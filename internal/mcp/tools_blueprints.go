@@ -0,0 +1,238 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const blueprintDefinitionHelp = `Blueprint definition JSON:
+- name: lowercase letters, digits, '-' or '_' (required)
+- label, description
+- variables: array of {name, label, default, required}
+- root: node {key, datatype (datatype name), fields (field name -> value), children (array of nodes)}
+
+Field values may contain "{{variable}}" placeholders, replaced on instantiation, and "{{@key}}", replaced by the new content ID of the node with that key (for _id fields).`
+
+func registerBlueprintTools(srv *server.MCPServer, backend BlueprintBackend) {
+	srv.AddTool(
+		mcp.NewTool("list_blueprints",
+			mcp.WithDescription("List saved content blueprints (without their definitions)."),
+		),
+		handleListBlueprints(backend),
+	)
+
+	srv.AddTool(
+		mcp.NewTool("get_blueprint",
+			mcp.WithDescription("Get a blueprint with its definition."),
+			mcp.WithString("id", mcp.Required(), mcp.Description("Blueprint ID or name")),
+		),
+		handleGetBlueprint(backend),
+	)
+
+	srv.AddTool(
+		mcp.NewTool("create_blueprint",
+			mcp.WithDescription("Save a blueprint from a definition.\n\n"+blueprintDefinitionHelp),
+			mcp.WithObject("definition", mcp.Required(), mcp.Description("Blueprint definition")),
+		),
+		handleCreateBlueprint(backend),
+	)
+
+	srv.AddTool(
+		mcp.NewTool("create_blueprint_from_content",
+			mcp.WithDescription("Save the content tree under a node as a blueprint. Field values come from the default locale; _id values pointing inside the tree become node references. Edit the definition afterwards to add variables."),
+			mcp.WithString("content_data_id", mcp.Required(), mcp.Description("Root of the tree to save (ULID)")),
+			mcp.WithString("name", mcp.Required(), mcp.Description("Blueprint name")),
+			mcp.WithString("label", mcp.Description("Display label (defaults to name)")),
+			mcp.WithString("description", mcp.Description("Description")),
+		),
+		handleCreateBlueprintFromContent(backend),
+	)
+
+	srv.AddTool(
+		mcp.NewTool("update_blueprint",
+			mcp.WithDescription("Replace a blueprint's definition. Content already created from it is unchanged.\n\n"+blueprintDefinitionHelp),
+			mcp.WithString("id", mcp.Required(), mcp.Description("Blueprint ID or name")),
+			mcp.WithObject("definition", mcp.Required(), mcp.Description("Full replacement definition")),
+		),
+		handleUpdateBlueprint(backend),
+	)
+
+	srv.AddTool(
+		mcp.NewTool("delete_blueprint",
+			mcp.WithDescription("Delete a blueprint. Content created from it is kept."),
+			mcp.WithString("id", mcp.Required(), mcp.Description("Blueprint ID or name")),
+		),
+		handleDeleteBlueprint(backend),
+	)
+
+	srv.AddTool(
+		mcp.NewTool("instantiate_blueprint",
+			mcp.WithDescription("Create a content tree from a blueprint in one transaction, with new IDs and variables substituted. Give parent_id to append under existing content, route_id to fill an empty route, or slug and title to create a new route."),
+			mcp.WithString("id", mcp.Required(), mcp.Description("Blueprint ID or name")),
+			mcp.WithString("route_id", mcp.Description("Route to create the tree at the root of (ULID)")),
+			mcp.WithString("slug", mcp.Description("Slug of a new route for the tree")),
+			mcp.WithString("title", mcp.Description("Title of the new route")),
+			mcp.WithString("parent_id", mcp.Description("Content node to append the tree under (ULID)")),
+			mcp.WithString("status", mcp.Description("Status of the new content (default draft)")),
+			mcp.WithObject("variables", mcp.Description("Map of variable name to value")),
+		),
+		handleInstantiateBlueprint(backend),
+	)
+
+	srv.AddTool(
+		mcp.NewTool("list_blueprint_instances",
+			mcp.WithDescription("List the content trees created from blueprints, with their routes and the variables used."),
+			mcp.WithString("blueprint", mcp.Description("Only instances of this blueprint (ID or name)")),
+		),
+		handleListBlueprintInstances(backend),
+	)
+}
+
+func handleListBlueprints(backend BlueprintBackend) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		data, err := backend.ListBlueprints(ctx)
+		if err != nil {
+			return errResult(err), nil
+		}
+		return rawJSONResult(data), nil
+	}
+}
+
+func handleGetBlueprint(backend BlueprintBackend) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id, err := req.RequireString("id")
+		if err != nil {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+		data, err := backend.GetBlueprint(ctx, id)
+		if err != nil {
+			return errResult(err), nil
+		}
+		return rawJSONResult(data), nil
+	}
+}
+
+func handleCreateBlueprint(backend BlueprintBackend) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		def, ok := req.GetArguments()["definition"]
+		if !ok {
+			return mcp.NewToolResultError("definition is required"), nil
+		}
+		b, err := json.Marshal(def)
+		if err != nil {
+			return mcp.NewToolResultError("invalid definition object"), nil
+		}
+		data, err := backend.CreateBlueprint(ctx, json.RawMessage(b))
+		if err != nil {
+			return errResult(err), nil
+		}
+		return rawJSONResult(data), nil
+	}
+}
+
+func handleCreateBlueprintFromContent(backend BlueprintBackend) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contentID, err := req.RequireString("content_data_id")
+		if err != nil {
+			return mcp.NewToolResultError("content_data_id is required"), nil
+		}
+		name, err := req.RequireString("name")
+		if err != nil {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+		params, err := marshalParams(map[string]any{
+			"content_data_id": contentID,
+			"name":            name,
+			"label":           req.GetString("label", ""),
+			"description":     req.GetString("description", ""),
+		})
+		if err != nil {
+			return nil, err
+		}
+		data, err := backend.CreateBlueprintFromContent(ctx, params)
+		if err != nil {
+			return errResult(err), nil
+		}
+		return rawJSONResult(data), nil
+	}
+}
+
+func handleUpdateBlueprint(backend BlueprintBackend) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id, err := req.RequireString("id")
+		if err != nil {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+		def, ok := req.GetArguments()["definition"]
+		if !ok {
+			return mcp.NewToolResultError("definition is required"), nil
+		}
+		b, err := json.Marshal(def)
+		if err != nil {
+			return mcp.NewToolResultError("invalid definition object"), nil
+		}
+		data, err := backend.UpdateBlueprint(ctx, id, json.RawMessage(b))
+		if err != nil {
+			return errResult(err), nil
+		}
+		return rawJSONResult(data), nil
+	}
+}
+
+func handleDeleteBlueprint(backend BlueprintBackend) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id, err := req.RequireString("id")
+		if err != nil {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+		if err := backend.DeleteBlueprint(ctx, id); err != nil {
+			return errResult(err), nil
+		}
+		return mcp.NewToolResultText("deleted"), nil
+	}
+}
+
+func handleInstantiateBlueprint(backend BlueprintBackend) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id, err := req.RequireString("id")
+		if err != nil {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+		body := map[string]any{
+			"slug":   req.GetString("slug", ""),
+			"title":  req.GetString("title", ""),
+			"status": req.GetString("status", ""),
+		}
+		if routeID := req.GetString("route_id", ""); routeID != "" {
+			body["route_id"] = routeID
+		}
+		if parentID := req.GetString("parent_id", ""); parentID != "" {
+			body["parent_id"] = parentID
+		}
+		if vars, ok := req.GetArguments()["variables"]; ok {
+			body["variables"] = vars
+		}
+		params, err := marshalParams(body)
+		if err != nil {
+			return nil, err
+		}
+		data, err := backend.InstantiateBlueprint(ctx, id, params)
+		if err != nil {
+			return errResult(err), nil
+		}
+		return rawJSONResult(data), nil
+	}
+}
+
+func handleListBlueprintInstances(backend BlueprintBackend) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		data, err := backend.ListBlueprintInstances(ctx, req.GetString("blueprint", ""))
+		if err != nil {
+			return errResult(err), nil
+		}
+		return rawJSONResult(data), nil
+	}
+}
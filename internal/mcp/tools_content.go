@@ -158,6 +158,19 @@ func registerContentTools(srv *server.MCPServer, backend ContentBackend) {
 		handleMoveContent(backend),
 	)
 
+	srv.AddTool(
+		mcp.NewTool("clone_content",
+			mcp.WithDescription("Deep-copy the content tree under a node in one transaction, with new IDs. Sibling order is kept; _id field values and content relations pointing inside the tree are rewritten to the copies. Give parent_id to append the copy under a node or route_id to fill an empty route; with neither the copy is appended under the source's parent. With locale, translatable fields are copied into that locale from source_locale (default: the default locale)."),
+			mcp.WithString("source_id", mcp.Required(), mcp.Description("Root of the tree to copy (ULID)")),
+			mcp.WithString("parent_id", mcp.Description("Content node to append the copy under (ULID)")),
+			mcp.WithString("route_id", mcp.Description("Empty route the copy becomes the root of (ULID)")),
+			mcp.WithString("locale", mcp.Description("Locale of the copy (requires i18n)")),
+			mcp.WithString("source_locale", mcp.Description("Locale to read translatable values from (requires locale)")),
+			mcp.WithString("status", mcp.Description("Status of the copies (default draft)")),
+		),
+		handleCloneContent(backend),
+	)
+
 	// --- Content Tree ---
 
 	srv.AddTool(
@@ -582,6 +595,38 @@ func handleMoveContent(backend ContentBackend) server.ToolHandlerFunc {
 	}
 }
 
+func handleCloneContent(backend ContentBackend) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		sourceID, err := req.RequireString("source_id")
+		if err != nil {
+			return mcp.NewToolResultError("source_id is required"), nil
+		}
+		body := map[string]any{
+			"source_id":     sourceID,
+			"locale":        req.GetString("locale", ""),
+			"source_locale": req.GetString("source_locale", ""),
+		}
+		if parentID := req.GetString("parent_id", ""); parentID != "" {
+			body["parent_id"] = parentID
+		}
+		if routeID := req.GetString("route_id", ""); routeID != "" {
+			body["route_id"] = routeID
+		}
+		if status := req.GetString("status", ""); status != "" {
+			body["status"] = status
+		}
+		params, err := marshalParams(body)
+		if err != nil {
+			return nil, err
+		}
+		data, err := backend.CloneContent(ctx, params)
+		if err != nil {
+			return errResult(err), nil
+		}
+		return rawJSONResult(data), nil
+	}
+}
+
 // --- Content Tree Handler ---
 
 func handleSaveContentTree(backend ContentBackend) server.ToolHandlerFunc {
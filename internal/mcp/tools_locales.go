@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"context"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -82,6 +83,18 @@ func registerLocaleTools(srv *server.MCPServer, backend LocaleBackend) {
 		),
 		handleAdminCreateTranslation(backend),
 	)
+
+	srv.AddTool(
+		mcp.NewTool("get_translation_status",
+			mcp.WithDescription("Report which translatable fields are missing, stale, current or untracked in a non-default locale. "+
+				"A translation is stale when the default-locale value changed after it was translated. "+
+				"Counts cover all selected content; the status filter only narrows the listed fields."),
+			mcp.WithString("locale", mcp.Required(), mcp.Description("Locale code to report on (e.g. 'fr')")),
+			mcp.WithString("route_id", mcp.Description("Limit the report to one route (ULID)")),
+			mcp.WithString("status", mcp.Description("Comma-separated statuses to list: missing, stale, current, untracked")),
+		),
+		handleGetTranslationStatus(backend),
+	)
 }
 
 func handleListLocales(backend LocaleBackend) server.ToolHandlerFunc {
@@ -239,3 +252,31 @@ func handleAdminCreateTranslation(backend LocaleBackend) server.ToolHandlerFunc
 		return rawJSONResult(data), nil
 	}
 }
+
+func handleGetTranslationStatus(backend LocaleBackend) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		locale, err := req.RequireString("locale")
+		if err != nil {
+			return mcp.NewToolResultError("locale is required"), nil
+		}
+		var statuses []string
+		for _, status := range strings.Split(req.GetString("status", ""), ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				statuses = append(statuses, status)
+			}
+		}
+		params, err := marshalParams(map[string]any{
+			"locale":   locale,
+			"route_id": req.GetString("route_id", ""),
+			"statuses": statuses,
+		})
+		if err != nil {
+			return nil, err
+		}
+		data, err := backend.GetTranslationStatus(ctx, params)
+		if err != nil {
+			return errResult(err), nil
+		}
+		return rawJSONResult(data), nil
+	}
+}
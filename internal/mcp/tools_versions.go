@@ -53,6 +53,17 @@ func registerVersionTools(srv *server.MCPServer, backend VersionBackend) {
 		handleRestoreContentVersion(backend),
 	)
 
+	srv.AddTool(
+		mcp.NewTool("diff_content_versions",
+			mcp.WithDescription("Compare two versions of a content tree, or a version against the current draft. Reports added, removed, and moved nodes and per-field value changes, with word-level diffs for text and richtext fields. Use before restoring or publishing."),
+			mcp.WithString("content_id", mcp.Required(), mcp.Description("Root content data ID (ULID)")),
+			mcp.WithString("from", mcp.Description("Version ID or \"draft\" to compare from. Omit to use the published version.")),
+			mcp.WithString("to", mcp.Description("Version ID or \"draft\" to compare to. Omit to use the current draft.")),
+			mcp.WithString("locale", mcp.Description("Locale of the draft and published sides when neither side is a version")),
+		),
+		handleDiffContentVersions(backend),
+	)
+
 	// --- Admin Content Versions ---
 
 	srv.AddTool(
@@ -188,6 +199,29 @@ func handleRestoreContentVersion(backend VersionBackend) server.ToolHandlerFunc
 	}
 }
 
+func handleDiffContentVersions(backend VersionBackend) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		contentID, err := req.RequireString("content_id")
+		if err != nil {
+			return mcp.NewToolResultError("content_id is required"), nil
+		}
+		params, err := marshalParams(map[string]any{
+			"content_data_id": contentID,
+			"from":            req.GetString("from", ""),
+			"to":              req.GetString("to", ""),
+			"locale":          req.GetString("locale", ""),
+		})
+		if err != nil {
+			return nil, err
+		}
+		data, err := backend.DiffVersions(ctx, params)
+		if err != nil {
+			return errResult(err), nil
+		}
+		return rawJSONResult(data), nil
+	}
+}
+
 // --- Admin Content Version Handlers ---
 
 func handleAdminListContentVersions(backend VersionBackend) server.ToolHandlerFunc {
@@ -50,21 +50,24 @@ func (ps PermissionSet) HasAll(perms ...string) bool {
 	return true
 }
 
-// PermissionCache holds role-to-permissions mappings in memory.
+// PermissionCache holds role-to-permissions mappings in memory, along with
+// each user's additional roles from direct assignments and group membership.
 // Safe for concurrent reads. Refreshed via Load() using build-then-swap.
 type PermissionCache struct {
 	mu          sync.RWMutex
 	cache       map[types.RoleID]PermissionSet
 	adminRoleID types.RoleID
 	isAdmin     map[types.RoleID]bool
+	userRoles   map[types.UserID][]types.RoleID
 	lastLoaded  time.Time
 }
 
 // NewPermissionCache creates an empty PermissionCache.
 func NewPermissionCache() *PermissionCache {
 	return &PermissionCache{
-		cache:   make(map[types.RoleID]PermissionSet),
-		isAdmin: make(map[types.RoleID]bool),
+		cache:     make(map[types.RoleID]PermissionSet),
+		isAdmin:   make(map[types.RoleID]bool),
+		userRoles: make(map[types.UserID][]types.RoleID),
 	}
 }
 
@@ -103,17 +106,70 @@ func (pc *PermissionCache) Load(driver db.RBACRepository) error {
 		newCache[role.RoleID] = ps
 	}
 
+	newUserRoles, err := loadUserRoles(driver)
+	if err != nil {
+		return fmt.Errorf("loading permission cache: %w", err)
+	}
+
 	// Swap under write lock (nanoseconds)
 	pc.mu.Lock()
 	pc.cache = newCache
 	pc.adminRoleID = newAdminRoleID
 	pc.isAdmin = newIsAdmin
+	pc.userRoles = newUserRoles
 	pc.lastLoaded = time.Now()
 	pc.mu.Unlock()
 
 	return nil
 }
 
+// loadUserRoles builds the per-user role list from direct user_roles
+// assignments and from the roles carried by each group the user belongs to.
+// Each user's list is deduplicated.
+func loadUserRoles(driver db.RBACRepository) (map[types.UserID][]types.RoleID, error) {
+	seen := make(map[types.UserID]map[types.RoleID]struct{})
+	out := make(map[types.UserID][]types.RoleID)
+	add := func(userID types.UserID, roleID types.RoleID) {
+		if seen[userID] == nil {
+			seen[userID] = make(map[types.RoleID]struct{})
+		}
+		if _, ok := seen[userID][roleID]; ok {
+			return
+		}
+		seen[userID][roleID] = struct{}{}
+		out[userID] = append(out[userID], roleID)
+	}
+
+	direct, err := driver.ListUserRoles()
+	if err != nil {
+		return nil, fmt.Errorf("list user roles: %w", err)
+	}
+	for _, ur := range *direct {
+		add(ur.UserID, ur.RoleID)
+	}
+
+	groupRoles, err := driver.ListUserGroupRoles()
+	if err != nil {
+		return nil, fmt.Errorf("list user group roles: %w", err)
+	}
+	rolesByGroup := make(map[types.UserGroupID][]types.RoleID)
+	for _, gr := range *groupRoles {
+		rolesByGroup[gr.UserGroupID] = append(rolesByGroup[gr.UserGroupID], gr.RoleID)
+	}
+
+	members, err := driver.ListUserGroupMembers()
+	if err != nil {
+		return nil, fmt.Errorf("list user group members: %w", err)
+	}
+	for _, m := range *members {
+		for _, roleID := range rolesByGroup[m.UserGroupID] {
+			add(m.UserID, roleID)
+		}
+	}
+
+	return out, nil
+}
+
 // PermissionsForRole returns the PermissionSet for the given role.
 // Returns nil if the role is not in the cache.
 func (pc *PermissionCache) PermissionsForRole(roleID types.RoleID) PermissionSet {
@@ -137,6 +193,61 @@ func (pc *PermissionCache) IsAdmin(roleID types.RoleID) bool {
 	return pc.isAdmin[roleID]
 }
 
+// RolesForUser returns every role held by a user: the primary role from the
+// user record first, followed by direct assignments and group-derived roles.
+// The result is deduplicated.
+func (pc *PermissionCache) RolesForUser(userID types.UserID, primary types.RoleID) []types.RoleID {
+	pc.mu.RLock()
+	extra := pc.userRoles[userID]
+	pc.mu.RUnlock()
+
+	roles := make([]types.RoleID, 0, len(extra)+1)
+	if !primary.IsZero() {
+		roles = append(roles, primary)
+	}
+	for _, roleID := range extra {
+		if roleID != primary {
+			roles = append(roles, roleID)
+		}
+	}
+	return roles
+}
+
+// PermissionsForUser returns the effective PermissionSet for a user, which is
+// the union of the permissions of every role returned by RolesForUser.
+// Returns nil if none of the user's roles are in the cache.
+func (pc *PermissionCache) PermissionsForUser(userID types.UserID, primary types.RoleID) PermissionSet {
+	roles := pc.RolesForUser(userID, primary)
+	if len(roles) == 1 {
+		return pc.PermissionsForRole(roles[0])
+	}
+
+	var union PermissionSet
+	for _, roleID := range roles {
+		ps := pc.PermissionsForRole(roleID)
+		if ps == nil {
+			continue
+		}
+		if union == nil {
+			union = make(PermissionSet, len(ps))
+		}
+		for perm := range ps {
+			union[perm] = struct{}{}
+		}
+	}
+	return union
+}
+
+// IsAdminUser returns true if any of the user's roles is the admin role.
+func (pc *PermissionCache) IsAdminUser(userID types.UserID, primary types.RoleID) bool {
+	for _, roleID := range pc.RolesForUser(userID, primary) {
+		if pc.IsAdmin(roleID) {
+			return true
+		}
+	}
+	return false
+}
+
 // StartPeriodicRefresh starts a background goroutine that refreshes
 // the cache at the given interval. Stops when ctx is cancelled.
 func (pc *PermissionCache) StartPeriodicRefresh(ctx context.Context, driver db.RBACRepository, interval time.Duration) {
@@ -195,8 +306,8 @@ func SetIsAdmin(ctx context.Context, isAdmin bool) context.Context {
 	return context.WithValue(ctx, isAdminKey, isAdmin)
 }
 
-// PermissionInjector resolves the user's role to a PermissionSet and stores
-// it in context. Must run after HTTPAuthenticationMiddleware.
+// PermissionInjector resolves the user's roles to an effective PermissionSet
+// and stores it in context. Must run after HTTPAuthenticationMiddleware.
 // Short-circuits for unauthenticated requests: if no user is in context,
 // the handler chain continues immediately with no PermissionSet in context.
 func PermissionInjector(pc *PermissionCache) func(http.Handler) http.Handler {
@@ -210,9 +321,9 @@ func PermissionInjector(pc *PermissionCache) func(http.Handler) http.Handler {
 
 			ctx := r.Context()
 			roleID := types.RoleID(user.Role)
-			ps := pc.PermissionsForRole(roleID)
+			ps := pc.PermissionsForUser(user.UserID, roleID)
 			ctx = context.WithValue(ctx, permissionsKey, ps)
-			ctx = context.WithValue(ctx, isAdminKey, pc.IsAdmin(roleID))
+			ctx = context.WithValue(ctx, isAdminKey, pc.IsAdminUser(user.UserID, roleID))
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
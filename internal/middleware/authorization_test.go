@@ -92,6 +92,46 @@ func TestPermissionInjectorAuthenticated(t *testing.T) {
 	}
 }
 
+func TestPermissionInjectorUnionsAdditionalRoles(t *testing.T) {
+	pc := NewPermissionCache()
+	editorRole := types.RoleID("EDITORROLE000000000000000")
+	adminRole := types.RoleID("ADMINROLE0000000000000000")
+	pc.mu.Lock()
+	pc.cache[types.RoleID(testUser.Role)] = PermissionSet{"content:read": {}}
+	pc.cache[editorRole] = PermissionSet{"content:update": {}}
+	pc.cache[adminRole] = PermissionSet{}
+	pc.isAdmin[adminRole] = true
+	pc.userRoles[testUser.UserID] = []types.RoleID{editorRole}
+	pc.mu.Unlock()
+
+	handler := PermissionInjector(pc)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ps := ContextPermissions(r.Context())
+		if !ps.HasAll("content:read", "content:update") {
+			t.Errorf("expected union of primary and assigned role permissions, got %v", ps)
+		}
+		if ContextIsAdmin(r.Context()) {
+			t.Error("expected non-admin before admin role is assigned")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req = req.WithContext(SetAuthenticatedUser(req.Context(), &testUser))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Primary role's cached set must not be mutated by the union.
+	if pc.PermissionsForRole(types.RoleID(testUser.Role)).Has("content:update") {
+		t.Error("union leaked into primary role PermissionSet")
+	}
+
+	pc.mu.Lock()
+	pc.userRoles[testUser.UserID] = []types.RoleID{editorRole, adminRole}
+	pc.mu.Unlock()
+	if !pc.IsAdminUser(testUser.UserID, types.RoleID(testUser.Role)) {
+		t.Error("expected IsAdminUser when admin role is held through an additional assignment")
+	}
+}
+
 func TestPermissionInjectorUnauthenticated(t *testing.T) {
 	pc := NewPermissionCache()
 
@@ -12,9 +12,10 @@ import (
 // This includes: logging, CORS, authentication, public endpoint protection, and
 // permission injection. PermissionInjector is added here (not in AuthenticatedChain)
 // to avoid double injection since DefaultMiddlewareChain wraps the entire mux.
-// Accepts *config.Manager for hot-reloadable config access and *utility.ObservabilityClient
-// for provider-specific HTTP transaction tracing.
-func DefaultMiddlewareChain(mgr *config.Manager, pc *PermissionCache, obs *utility.ObservabilityClient) func(http.Handler) http.Handler {
+// Accepts *config.Manager for hot-reloadable config access, *utility.ObservabilityClient
+// for provider-specific HTTP transaction tracing, and the PolicyRateLimiter that
+// enforces rate_limit_policies (nil disables rate limiting).
+func DefaultMiddlewareChain(mgr *config.Manager, pc *PermissionCache, obs *utility.ObservabilityClient, rl *PolicyRateLimiter) func(http.Handler) http.Handler {
 	cfg, err := mgr.Config()
 	if err != nil {
 		// Fallback: return a chain that rejects all requests.
@@ -35,6 +36,13 @@ func DefaultMiddlewareChain(mgr *config.Manager, pc *PermissionCache, obs *utili
 		obsMw = func(next http.Handler) http.Handler { return next }
 	}
 
+	// Rate limiting runs after authentication so user and token policies
+	// can key on the authenticated subject.
+	rateLimitMw := func(next http.Handler) http.Handler { return next }
+	if rl != nil {
+		rateLimitMw = rl.Middleware
+	}
+
 	return Chain(
 		RecoveryMiddleware(obs),           // 1. Panic recovery + error capture
 		obsMw,                             // 2. Observability transaction tracing
@@ -47,6 +55,7 @@ func DefaultMiddlewareChain(mgr *config.Manager, pc *PermissionCache, obs *utili
 		HTTPAuthenticationMiddleware(cfg), // 9. Session authentication
 		HTTPPublicEndpointMiddleware(cfg), // 10. Public endpoint protection
 		PermissionInjector(pc),            // 11. Permission set injection
+		rateLimitMw,                       // 12. Rate limit policies
 	)
 }
 
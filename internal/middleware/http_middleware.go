@@ -39,12 +39,15 @@ func HTTPLoggingMiddleware() func(http.Handler) http.Handler {
 func HTTPAuthenticationMiddleware(c *config.Config) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			authCtx, user := AuthRequest(w, r, c)
+			authCtx, user, token := authRequest(r, c)
 			if authCtx != nil && user != nil {
 				// Inject authenticated user into context
 				// Use the dereferenced value as key so other middleware
 				// can look it up with authcontext("authenticated")
 				ctx := context.WithValue(r.Context(), *authCtx, user)
+				if token != nil {
+					ctx = SetAuthenticatedTokenID(ctx, token.ID)
+				}
 				next.ServeHTTP(w, r.WithContext(ctx))
 				return
 			}
@@ -151,6 +154,21 @@ func SetAuthenticatedUser(ctx context.Context, user *db.Users) context.Context {
 	return context.WithValue(ctx, key, user)
 }
 
+// AuthenticatedTokenID returns the ID of the API token the request
+// authenticated with. Returns "" for cookie sessions and anonymous requests.
+func AuthenticatedTokenID(ctx context.Context) string {
+	var key authcontext = "api_token"
+	id, _ := ctx.Value(key).(string)
+	return id
+}
+
+// SetAuthenticatedTokenID returns a new context recording the API token the
+// request authenticated with.
+func SetAuthenticatedTokenID(ctx context.Context, tokenID string) context.Context {
+	var key authcontext = "api_token"
+	return context.WithValue(ctx, key, tokenID)
+}
+
 // Chain applies multiple middleware in sequence (left to right)
 // Example: Chain(middleware1, middleware2, middleware3)(handler)
 func Chain(middlewares ...func(http.Handler) http.Handler) func(http.Handler) http.Handler {
@@ -173,3 +191,9 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap returns the underlying ResponseWriter so http.ResponseController can
+// reach its Flush and SetWriteDeadline, which event streams need.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
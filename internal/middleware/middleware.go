@@ -24,8 +24,15 @@ type authcontext string
 // when cookie auth is not present or fails.
 // Returns nil values if all authentication methods fail or the request is not authenticated.
 func AuthRequest(w http.ResponseWriter, r *http.Request, c *config.Config) (*authcontext, *db.Users) {
+	authCtx, user, _ := authRequest(r, c)
+	return authCtx, user
+}
+
+// authRequest is AuthRequest that also returns the API token the request
+// authenticated with. The token is nil for cookie sessions.
+func authRequest(r *http.Request, c *config.Config) (*authcontext, *db.Users, *db.Tokens) {
 	if strings.Contains(r.URL.Path, "favicon.ico") {
-		return nil, nil
+		return nil, nil, nil
 	}
 	var u authcontext = "authenticated"
 
@@ -34,12 +41,19 @@ func AuthRequest(w http.ResponseWriter, r *http.Request, c *config.Config) (*aut
 	if err == nil {
 		user, err := UserIsAuth(r, cookie, c)
 		if err == nil {
-			return &u, user
+			return &u, user, nil
 		}
 	}
 
 	// Fall back to API key auth
-	return APIKeyAuth(r, c)
+	key := bearerKey(r)
+	if key == "" {
+		return nil, nil, nil
+	}
+	if token, user := APIKeyToken(key, c); user != nil {
+		return &u, user, token
+	}
+	return nil, nil, nil
 }
 
 // APIKeyAuth authenticates a request using an API key from the Authorization header.
@@ -47,20 +61,32 @@ func AuthRequest(w http.ResponseWriter, r *http.Request, c *config.Config) (*aut
 // and validates that the token is of type "api_key", is not revoked, and has not expired.
 // Returns the authenticated context and user on success, or nil values on failure.
 func APIKeyAuth(r *http.Request, c *config.Config) (*authcontext, *db.Users) {
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
+	key := bearerKey(r)
+	if key == "" {
 		return nil, nil
 	}
 
-	if !strings.HasPrefix(authHeader, "Bearer ") {
-		return nil, nil
+	if _, user := APIKeyToken(key, c); user != nil {
+		var u authcontext = "authenticated"
+		return &u, user
 	}
+	return nil, nil
+}
 
-	key := strings.TrimPrefix(authHeader, "Bearer ")
-	if key == "" {
-		return nil, nil
+// bearerKey returns the key from an "Authorization: Bearer <key>" header, or
+// "" if the header is absent or uses another scheme.
+func bearerKey(r *http.Request) string {
+	key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return ""
 	}
+	return key
+}
 
+// APIKeyToken looks up a raw API key and returns the token record and its
+// user. The token must be of type "api_key" or "plugin_api_key", not revoked,
+// not expired, and linked to a user. Returns nil values otherwise.
+func APIKeyToken(key string, c *config.Config) (*db.Tokens, *db.Users) {
 	dbc := db.ConfigDB(*c)
 
 	token, err := dbc.GetTokenByTokenValue(utility.HashToken(key))
@@ -96,6 +122,5 @@ func APIKeyAuth(r *http.Request, c *config.Config) (*authcontext, *db.Users) {
 		return nil, nil
 	}
 
-	var u authcontext = "authenticated"
-	return &u, user
+	return token, user
 }
@@ -0,0 +1,220 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// routeMatcher is one parsed entry of RateLimitPolicy.Routes.
+type routeMatcher struct {
+	method string // empty matches any method
+	prefix string
+}
+
+// compiledPolicy is a RateLimitPolicy with its routes parsed and window
+// resolved, ready for per-request matching.
+type compiledPolicy struct {
+	name   string
+	key    string
+	limit  int
+	window time.Duration
+	routes []routeMatcher
+	users  map[string]bool
+	tokens map[string]bool
+}
+
+// PolicyRateLimiter enforces config.RateLimitPolicy rules against a
+// RateLimitStore. Policies are evaluated in order and the first policy that
+// matches the request (route, method, the Users and Tokens selectors and, for
+// user/token policies, an authenticated subject) decides the limit. Requests matching no policy are
+// not limited.
+//
+// Every limited response carries the IETF RateLimit-Limit, RateLimit-Remaining,
+// RateLimit-Reset and RateLimit-Policy headers; rejected requests also get
+// Retry-After. Store errors fail open so a database hiccup never takes the
+// API down.
+type PolicyRateLimiter struct {
+	policies []compiledPolicy
+	store    RateLimitStore
+	now      func() time.Time
+}
+
+// NewPolicyRateLimiter compiles the policies and returns a limiter backed by
+// store. Policies with a non-positive limit are skipped.
+func NewPolicyRateLimiter(policies []config.RateLimitPolicy, store RateLimitStore) *PolicyRateLimiter {
+	compiled := make([]compiledPolicy, 0, len(policies))
+	for _, p := range policies {
+		if p.Limit <= 0 {
+			continue
+		}
+		cp := compiledPolicy{
+			name:   p.Name,
+			key:    p.Key,
+			limit:  p.Limit,
+			window: p.WindowDuration(),
+		}
+		if cp.key == "" {
+			cp.key = "ip"
+		}
+		for _, route := range p.Routes {
+			cp.routes = append(cp.routes, parseRouteMatcher(route))
+		}
+		if len(p.Users) > 0 {
+			cp.users = make(map[string]bool, len(p.Users))
+			for _, u := range p.Users {
+				cp.users[u] = true
+			}
+		}
+		if len(p.Tokens) > 0 {
+			cp.tokens = make(map[string]bool, len(p.Tokens))
+			for _, id := range p.Tokens {
+				cp.tokens[id] = true
+			}
+		}
+		compiled = append(compiled, cp)
+	}
+	return &PolicyRateLimiter{
+		policies: compiled,
+		store:    store,
+		now:      time.Now,
+	}
+}
+
+// parseRouteMatcher parses "METHOD /prefix" or "/prefix".
+func parseRouteMatcher(route string) routeMatcher {
+	route = strings.TrimSpace(route)
+	if method, prefix, ok := strings.Cut(route, " "); ok {
+		return routeMatcher{method: strings.ToUpper(method), prefix: strings.TrimSpace(prefix)}
+	}
+	return routeMatcher{prefix: route}
+}
+
+// matchesRoute reports whether the request falls in the policy's route group.
+// A policy without routes matches every request.
+func (p compiledPolicy) matchesRoute(r *http.Request) bool {
+	if len(p.routes) == 0 {
+		return true
+	}
+	for _, m := range p.routes {
+		if m.method != "" && m.method != r.Method {
+			continue
+		}
+		if strings.HasPrefix(r.URL.Path, m.prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// subject returns the counter key suffix for the request under this policy,
+// or false if the policy does not apply (no authenticated user for a user
+// policy, no API token for a token policy, a user outside Users or a token
+// outside Tokens).
+func (p compiledPolicy) subject(r *http.Request) (string, bool) {
+	user := AuthenticatedUser(r.Context())
+	if p.users != nil {
+		if user == nil || (!p.users[user.UserID.String()] && !p.users[user.Username]) {
+			return "", false
+		}
+	}
+	if p.tokens != nil && !p.tokens[AuthenticatedTokenID(r.Context())] {
+		return "", false
+	}
+
+	switch p.key {
+	case "user":
+		if user == nil {
+			return "", false
+		}
+		return "user:" + user.UserID.String(), true
+	case "token":
+		// Key on the token the request authenticated with, never the raw
+		// header: a cookie session ignores its bearer, so a random header
+		// value would otherwise mint a fresh budget on every request.
+		tokenID := AuthenticatedTokenID(r.Context())
+		if tokenID == "" {
+			return "", false
+		}
+		return "token:" + tokenID, true
+	default:
+		ip := ClientIPFromContext(r.Context())
+		if ip == "" {
+			ip = resolveClientIP(r)
+		}
+		return "ip:" + ip, true
+	}
+}
+
+// match returns the first policy that applies to the request and the store
+// key to count it under.
+func (rl *PolicyRateLimiter) match(r *http.Request) (*compiledPolicy, string) {
+	for i := range rl.policies {
+		p := &rl.policies[i]
+		if !p.matchesRoute(r) {
+			continue
+		}
+		subject, ok := p.subject(r)
+		if !ok {
+			continue
+		}
+		return p, "rl:" + p.name + ":" + subject
+	}
+	return nil, ""
+}
+
+// Middleware returns an HTTP middleware handler that enforces the policies.
+// It must run after authentication so user and token policies can see the
+// authenticated user.
+func (rl *PolicyRateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		policy, key := rl.match(r)
+		if policy == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		now := rl.now()
+		hits, reset, err := rl.store.Hit(key, policy.window, now)
+		if err != nil {
+			utility.DefaultLogger.Warn("rate limit store error, allowing request", err, "policy", policy.name, "store", rl.store.Name())
+			utility.GlobalMetrics.Increment(utility.MetricRateLimitErrors, utility.Labels{
+				"policy": policy.name,
+				"store":  rl.store.Name(),
+			})
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		remaining := max(int64(policy.limit)-hits, 0)
+		resetSeconds := int64(max(reset.Sub(now).Round(time.Second), time.Second) / time.Second)
+
+		h := w.Header()
+		h.Set("RateLimit-Limit", strconv.Itoa(policy.limit))
+		h.Set("RateLimit-Remaining", strconv.FormatInt(remaining, 10))
+		h.Set("RateLimit-Reset", strconv.FormatInt(resetSeconds, 10))
+		h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.limit, int64(policy.window/time.Second)))
+
+		labels := utility.Labels{"policy": policy.name, "key": policy.key}
+		if hits > int64(policy.limit) {
+			utility.GlobalMetrics.Increment(utility.MetricRateLimitRejected, labels)
+			utility.DefaultLogger.Fwarn("Rate limit exceeded", nil, "policy", policy.name, "key", key)
+			h.Set("Retry-After", strconv.FormatInt(resetSeconds, 10))
+			http.Error(w, "Rate limit exceeded. Please try again later.", http.StatusTooManyRequests)
+			return
+		}
+
+		utility.GlobalMetrics.Increment(utility.MetricRateLimitAllowed, labels)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Close releases the store's background resources.
+func (rl *PolicyRateLimiter) Close() {
+	rl.store.Close()
+}
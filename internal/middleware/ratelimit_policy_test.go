@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// failingStore is a RateLimitStore whose every hit errors.
+type failingStore struct{}
+
+func (failingStore) Hit(string, time.Duration, time.Time) (int64, time.Time, error) {
+	return 0, time.Time{}, errors.New("store down")
+}
+func (failingStore) Name() string { return "failing" }
+func (failingStore) Close()       {}
+
+func newTestLimiter(t *testing.T, policies []config.RateLimitPolicy) *PolicyRateLimiter {
+	t.Helper()
+	rl := NewPolicyRateLimiter(policies, NewMemoryRateLimitStore())
+	rl.now = func() time.Time { return time.Unix(999_999_980, 0) } // 20s into a minute window
+	t.Cleanup(rl.Close)
+	return rl
+}
+
+func serveLimited(rl *PolicyRateLimiter, r *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	rl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})).ServeHTTP(rec, r)
+	return rec
+}
+
+func TestPolicyRateLimiter_LimitsAndHeaders(t *testing.T) {
+	rl := newTestLimiter(t, []config.RateLimitPolicy{
+		{Name: "content", Routes: []string{"GET /api/v1/content/"}, Limit: 2, Window: "1m"},
+	})
+
+	for i := range 2 {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/content/home", nil)
+		rec := serveLimited(rl, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200", i+1, rec.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/content/home", nil)
+	rec := serveLimited(rl, req)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("third request: status = %d, want 429", rec.Code)
+	}
+	checks := map[string]string{
+		"RateLimit-Limit":     "2",
+		"RateLimit-Remaining": "0",
+		"RateLimit-Reset":     "40",
+		"RateLimit-Policy":    "2;w=60",
+		"Retry-After":         "40",
+	}
+	for header, want := range checks {
+		if got := rec.Header().Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+
+	// Other methods and routes are outside the policy.
+	post := serveLimited(rl, httptest.NewRequest(http.MethodPost, "/api/v1/content/home", nil))
+	if post.Code != http.StatusOK || post.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("POST: status = %d, RateLimit-Limit = %q; want 200 and no header", post.Code, post.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestPolicyRateLimiter_UserPolicyFallsThroughWhenAnonymous(t *testing.T) {
+	rl := newTestLimiter(t, []config.RateLimitPolicy{
+		{Name: "vip", Key: "user", Users: []string{"importer"}, Limit: 100},
+		{Name: "users", Key: "user", Limit: 5},
+		{Name: "anonymous", Key: "ip", Limit: 1},
+	})
+
+	anon := httptest.NewRequest(http.MethodGet, "/api/v1/content/", nil)
+	if got := serveLimited(rl, anon).Header().Get("RateLimit-Limit"); got != "1" {
+		t.Errorf("anonymous RateLimit-Limit = %q, want 1", got)
+	}
+
+	user := &db.Users{UserID: types.UserID("01JUSER"), Username: "editor"}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/content/", nil)
+	req = req.WithContext(SetAuthenticatedUser(req.Context(), user))
+	if got := serveLimited(rl, req).Header().Get("RateLimit-Limit"); got != "5" {
+		t.Errorf("user RateLimit-Limit = %q, want 5", got)
+	}
+
+	vip := &db.Users{UserID: types.UserID("01JVIP"), Username: "importer"}
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/content/", nil)
+	req = req.WithContext(SetAuthenticatedUser(req.Context(), vip))
+	if got := serveLimited(rl, req).Header().Get("RateLimit-Limit"); got != "100" {
+		t.Errorf("listed user RateLimit-Limit = %q, want 100", got)
+	}
+}
+
+func TestPolicyRateLimiter_TokenPolicyCountsPerToken(t *testing.T) {
+	rl := newTestLimiter(t, []config.RateLimitPolicy{
+		{Name: "tokens", Key: "token", Limit: 1},
+	})
+	user := &db.Users{UserID: types.UserID("01JUSER")}
+
+	for _, tokenID := range []string{"01JTOKENA", "01JTOKENB"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/content/", nil)
+		req.Header.Set("Authorization", "Bearer key-"+tokenID)
+		ctx := SetAuthenticatedUser(req.Context(), user)
+		req = req.WithContext(SetAuthenticatedTokenID(ctx, tokenID))
+		if rec := serveLimited(rl, req); rec.Code != http.StatusOK {
+			t.Errorf("first request with %s: status = %d, want 200", tokenID, rec.Code)
+		}
+	}
+
+	// A cookie session ignores its bearer header, so a fresh random value
+	// must not buy a fresh token budget.
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/content/", nil)
+	req.Header.Set("Authorization", "Bearer forged")
+	req = req.WithContext(SetAuthenticatedUser(req.Context(), user))
+	if rec := serveLimited(rl, req); rec.Header().Get("RateLimit-Limit") != "" {
+		t.Error("cookie session with a bearer header matched the token policy")
+	}
+}
+
+func TestPolicyRateLimiter_TokenSelector(t *testing.T) {
+	rl := newTestLimiter(t, []config.RateLimitPolicy{
+		{Name: "importer", Key: "token", Tokens: []string{"01JTOKEN"}, Limit: 1000},
+		{Name: "tokens", Key: "token", Limit: 10},
+	})
+	user := &db.Users{UserID: types.UserID("01JUSER")}
+
+	for tokenID, want := range map[string]string{"01JTOKEN": "1000", "01JOTHER": "10"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/content/", nil)
+		req.Header.Set("Authorization", "Bearer key-"+tokenID)
+		ctx := SetAuthenticatedUser(req.Context(), user)
+		req = req.WithContext(SetAuthenticatedTokenID(ctx, tokenID))
+		if got := serveLimited(rl, req).Header().Get("RateLimit-Limit"); got != want {
+			t.Errorf("token %s RateLimit-Limit = %q, want %s", tokenID, got, want)
+		}
+	}
+}
+
+func TestPolicyRateLimiter_StoreErrorFailsOpen(t *testing.T) {
+	rl := NewPolicyRateLimiter([]config.RateLimitPolicy{{Name: "all", Limit: 1}}, failingStore{})
+	for range 3 {
+		if rec := serveLimited(rl, httptest.NewRequest(http.MethodGet, "/", nil)); rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200 when the store fails", rec.Code)
+		}
+	}
+}
+
+func TestMemoryRateLimitStore_WindowReset(t *testing.T) {
+	s := NewMemoryRateLimitStore()
+	defer s.Close()
+	start := time.Unix(1_000_000_000, 0)
+
+	for want := int64(1); want <= 3; want++ {
+		hits, reset, err := s.Hit("k", time.Minute, start.Add(time.Duration(want)*time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hits != want {
+			t.Fatalf("hits = %d, want %d", hits, want)
+		}
+		if !reset.Equal(start.Truncate(time.Minute).Add(time.Minute)) {
+			t.Fatalf("reset = %v, want end of window", reset)
+		}
+	}
+
+	hits, _, _ := s.Hit("k", time.Minute, start.Add(2*time.Minute))
+	if hits != 1 {
+		t.Errorf("hits in next window = %d, want 1", hits)
+	}
+}
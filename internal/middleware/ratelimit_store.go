@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// RateLimitStore counts requests in fixed windows. Implementations must be
+// safe for concurrent use. Hit records one request against key in the window
+// containing now and returns the number of hits in that window so far along
+// with the time the window resets.
+type RateLimitStore interface {
+	Hit(key string, window time.Duration, now time.Time) (hits int64, reset time.Time, err error)
+	Name() string
+	Close()
+}
+
+// NewRateLimitStore returns the store selected by rate_limit_store. The
+// database store shares counters across every replica using the same
+// database; anything else falls back to the in-memory store.
+func NewRateLimitStore(c config.Config, driver db.RateLimitRepository) RateLimitStore {
+	if c.RateLimitStore() == "database" && driver != nil {
+		return NewDBRateLimitStore(driver)
+	}
+	return NewMemoryRateLimitStore()
+}
+
+// windowBounds returns the start of the fixed window containing now and the
+// time it ends.
+func windowBounds(window time.Duration, now time.Time) (time.Time, time.Time) {
+	start := now.Truncate(window)
+	return start, start.Add(window)
+}
+
+// memoryCounter is the hit count for one key in its current window.
+type memoryCounter struct {
+	windowStart time.Time
+	hits        int64
+	expires     time.Time
+}
+
+// MemoryRateLimitStore keeps counters in process memory. Counters are not
+// shared between replicas, so each instance enforces the full limit.
+type MemoryRateLimitStore struct {
+	mu       sync.Mutex
+	counters map[string]*memoryCounter
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewMemoryRateLimitStore creates an in-memory store and starts a goroutine
+// that evicts expired counters every minute.
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	s := &MemoryRateLimitStore{
+		counters: make(map[string]*memoryCounter),
+		done:     make(chan struct{}),
+	}
+	go s.cleanup(time.Minute)
+	return s
+}
+
+// Hit implements RateLimitStore.
+func (s *MemoryRateLimitStore) Hit(key string, window time.Duration, now time.Time) (int64, time.Time, error) {
+	start, reset := windowBounds(window, now)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counters[key]
+	if !ok || !c.windowStart.Equal(start) {
+		c = &memoryCounter{windowStart: start, expires: reset}
+		s.counters[key] = c
+	}
+	c.hits++
+	return c.hits, reset, nil
+}
+
+// Name implements RateLimitStore.
+func (s *MemoryRateLimitStore) Name() string { return "memory" }
+
+// Size returns the number of live counters.
+// This is primarily useful for testing and monitoring.
+func (s *MemoryRateLimitStore) Size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.counters)
+}
+
+// Close stops the cleanup goroutine. It is safe to call more than once.
+func (s *MemoryRateLimitStore) Close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// cleanup periodically removes counters whose window has ended.
+func (s *MemoryRateLimitStore) cleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			now := time.Now()
+			s.mu.Lock()
+			for key, c := range s.counters {
+				if !c.expires.After(now) {
+					delete(s.counters, key)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// DBRateLimitStore keeps counters in the rate_limit_counters table so that
+// every replica behind a load balancer counts against the same budget. Each
+// hit is a single upsert; expired rows are swept every minute.
+type DBRateLimitStore struct {
+	driver db.RateLimitRepository
+	done   chan struct{}
+	once   sync.Once
+}
+
+// NewDBRateLimitStore creates a database-backed store and starts the sweep
+// goroutine that deletes expired counters.
+func NewDBRateLimitStore(driver db.RateLimitRepository) *DBRateLimitStore {
+	s := &DBRateLimitStore{
+		driver: driver,
+		done:   make(chan struct{}),
+	}
+	go s.sweep(time.Minute)
+	return s
+}
+
+// Hit implements RateLimitStore.
+func (s *DBRateLimitStore) Hit(key string, window time.Duration, now time.Time) (int64, time.Time, error) {
+	start, reset := windowBounds(window, now)
+	counter, err := s.driver.IncrementRateLimitCounter(db.IncrementRateLimitCounterParams{
+		LimiterKey:  key,
+		WindowStart: start.Unix(),
+		ExpiresAt:   reset.Unix(),
+	})
+	if err != nil {
+		return 0, reset, err
+	}
+	return counter.Hits, reset, nil
+}
+
+// Name implements RateLimitStore.
+func (s *DBRateLimitStore) Name() string { return "database" }
+
+// Close stops the sweep goroutine. It is safe to call more than once.
+func (s *DBRateLimitStore) Close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// sweep periodically deletes counters whose window has ended. Every replica
+// runs its own sweep; the deletes are idempotent.
+func (s *DBRateLimitStore) sweep(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if err := s.driver.DeleteExpiredRateLimitCounters(time.Now().Unix()); err != nil {
+				utility.DefaultLogger.Warn("rate limit counter sweep failed", err)
+			}
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	db "github.com/hegner123/modulacms/internal/db"
 	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/encryption"
 	lua "github.com/yuin/gopher-lua"
 )
 
@@ -172,6 +173,8 @@ func RegisterDBAPI(L *lua.LState, api *DatabaseAPI) {
 	dbTable.RawSetString("ulid", L.NewFunction(luaULID))
 	dbTable.RawSetString("timestamp", L.NewFunction(luaTimestamp))
 	dbTable.RawSetString("timestamp_ago", L.NewFunction(luaTimestampAgo))
+	dbTable.RawSetString("encrypt", L.NewFunction(luaEncrypt))
+	dbTable.RawSetString("decrypt", L.NewFunction(luaDecrypt))
 	dbTable.RawSetString("define_table", L.NewFunction(
 		luaDefineTable(L, api.pluginName, api.conn, api.dialect, api.tableReg),
 	))
@@ -1552,6 +1555,50 @@ func luaTimestampAgo(L *lua.LState) int {
 	return 1
 }
 
+// luaEncrypt implements db.encrypt(value) -> string.
+// Seals a secret with the CMS encryption key so plugins can store it at rest
+// the same way core secret columns are stored. Returns the value unchanged
+// when no encryption key is configured. Sealed values in plugin tables are
+// rewrapped by db.RewrapEncryptedColumns on key rotation.
+func luaEncrypt(L *lua.LState) int {
+	v := L.CheckString(1)
+	k := db.ColumnKeyring()
+	if k == nil {
+		L.Push(lua.LString(v))
+		return 1
+	}
+	sealed, err := k.Seal(v)
+	if err != nil {
+		L.RaiseError("db.encrypt: %s", err.Error())
+		return 0
+	}
+	L.Push(lua.LString(sealed))
+	return 1
+}
+
+// luaDecrypt implements db.decrypt(value) -> string.
+// Opens a value sealed by db.encrypt. Plaintext passes through unchanged, so
+// rows written before encryption was enabled keep working.
+func luaDecrypt(L *lua.LState) int {
+	v := L.CheckString(1)
+	if !encryption.IsSealed(v) {
+		L.Push(lua.LString(v))
+		return 1
+	}
+	k := db.ColumnKeyring()
+	if k == nil {
+		L.RaiseError("db.decrypt: value is encrypted but no encryption_key is configured")
+		return 0
+	}
+	opened, err := k.Open(v)
+	if err != nil {
+		L.RaiseError("db.decrypt: %s", err.Error())
+		return 0
+	}
+	L.Push(lua.LString(opened))
+	return 1
+}
+
 // luaStringField reads an optional string field from a Lua table.
 // Returns empty string if the field is absent, nil, or not a string.
 func luaStringField(L *lua.LState, tbl *lua.LTable, field string) string {
@@ -164,6 +164,14 @@ type PluginInstance struct {
 	// UIPool is the dedicated VM pool for long-held UI coroutines.
 	// Nil if the plugin has no screens or interfaces.
 	UIPool *UIVMPool
+
+	// Migrations lists the content migrations registered via
+	// migrations.register() at module scope. Read once during loadPlugin.
+	Migrations []PendingMigration
+
+	// TranslationProviders lists the provider names registered via
+	// translation.register() at module scope. Read once during loadPlugin.
+	TranslationProviders []string
 }
 
 // ManagerConfig configures the plugin manager runtime behavior.
@@ -702,7 +710,9 @@ func (m *Manager) loadPlugin(ctx context.Context, inst *PluginInstance) {
 		// 6. RegisterHTTPAPI (Phase 2)
 		// 7. RegisterHooksAPI (Phase 3)
 		// 8. RegisterRequestAPI
-		// 9. FreezeModule (all modules)
+		// 9. RegisterMigrationsAPI
+		// 10. RegisterTranslationAPI
+		// 11. FreezeModule (all modules)
 		ApplySandbox(L, SandboxConfig{AllowCoroutine: true, ExecTimeout: timeout})
 		SetVMPhase(L, "module_scope")
 		RegisterPluginRequire(L, inst.Dir)
@@ -720,6 +730,8 @@ func (m *Manager) loadPlugin(ctx context.Context, inst *PluginInstance) {
 		}
 		reqAPI := RegisterRequestAPI(L, pluginName, reqExecutor)
 		RegisterJSONAPI(L)
+		RegisterMigrationsAPI(L, pluginName)
+		RegisterTranslationAPI(L, pluginName)
 		FreezeModule(L, "db")
 		FreezeModule(L, "log")
 		FreezeModule(L, "http")
@@ -727,6 +739,8 @@ func (m *Manager) loadPlugin(ctx context.Context, inst *PluginInstance) {
 		FreezeModule(L, "core")
 		FreezeModule(L, "request")
 		FreezeModule(L, "json")
+		FreezeModule(L, "migrations")
+		FreezeModule(L, "translation")
 
 		// Execute init.lua to define globals (plugin_info, on_init, on_shutdown).
 		// Use a context with timeout for the execution.
@@ -863,6 +877,24 @@ func (m *Manager) loadPlugin(ctx context.Context, inst *PluginInstance) {
 		}
 	}
 
+	// Content migrations registered at module scope. They are run on demand
+	// through the content migration service, never automatically.
+	inst.Migrations = ReadPendingMigrations(L)
+	if len(inst.Migrations) > 0 {
+		utility.DefaultLogger.Info(
+			fmt.Sprintf("plugin %q: registered %d content migrations", pluginName, len(inst.Migrations)),
+		)
+	}
+
+	// Translation providers registered at module scope. They are used when
+	// translation_provider selects "<plugin>:<name>".
+	inst.TranslationProviders = ReadPendingTranslationProviders(L)
+	if len(inst.TranslationProviders) > 0 {
+		utility.DefaultLogger.Info(
+			fmt.Sprintf("plugin %q: registered %d translation providers", pluginName, len(inst.TranslationProviders)),
+		)
+	}
+
 	// Read pending request registrations and upsert into the DB.
 	pendingRequests := ReadPendingRequests(L)
 	if len(pendingRequests) > 0 {
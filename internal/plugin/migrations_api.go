@@ -0,0 +1,273 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/contentmigration"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// MaxMigrationsPerPlugin is the maximum number of content migrations a single
+// plugin can register via migrations.register(). Exceeding this limit raises
+// a Lua error.
+const MaxMigrationsPerPlugin = 50
+
+// PendingMigration holds the metadata for a content migration registered
+// during init.lua module-scope execution. The transform function itself stays
+// in each VM's __migration_handlers table under ID, so any checked-out VM can
+// run it.
+type PendingMigration struct {
+	ID          string
+	Description string
+	Datatype    string
+	Field       string
+}
+
+// RegisterMigrationsAPI creates a "migrations" global Lua table with a single
+// function: register.
+//
+//	migrations.register("0001_status_to_select", {
+//	    description = "Map free-text status to select options",
+//	    datatype    = "article",
+//	    field       = "status",
+//	    transform   = function(value, ctx) return string.lower(value) end,
+//	})
+//
+// It also creates two hidden global tables read by the Manager at load time:
+//   - __migration_handlers: migration ID -> transform LFunction
+//   - __migration_pending:  ordered array of migration metadata tables
+//
+// Like hooks.on(), migrations.register() must be called at module scope so
+// every VM in the pool holds its own reference to the transform.
+func RegisterMigrationsAPI(L *lua.LState, pluginName string) {
+	handlers := L.NewTable()
+	pending := L.NewTable()
+
+	L.SetGlobal("__migration_handlers", handlers)
+	L.SetGlobal("__migration_pending", pending)
+
+	migrationsTable := L.NewTable()
+	migrationsTable.RawSetString("register", L.NewFunction(migrationsRegisterFn(pluginName, handlers, pending)))
+
+	L.SetGlobal("migrations", migrationsTable)
+}
+
+// migrationsRegisterFn returns the Go-bound function for
+// migrations.register(id, spec).
+func migrationsRegisterFn(pluginName string, handlers *lua.LTable, pending *lua.LTable) lua.LGFunction {
+	return func(L *lua.LState) int {
+		id := L.CheckString(1)
+		spec := L.CheckTable(2)
+
+		if phase := VMPhase(L); phase != "" && phase != "module_scope" {
+			L.RaiseError("migrations.register() must be called at module scope, not inside on_init()")
+			return 0
+		}
+
+		transform, ok := L.GetField(spec, "transform").(*lua.LFunction)
+		if !ok {
+			L.ArgError(2, "transform must be a function")
+			return 0
+		}
+		m := contentmigration.Migration{
+			ID:          id,
+			Description: luaFieldString(L, spec, "description"),
+			Datatype:    luaFieldString(L, spec, "datatype"),
+			Field:       luaFieldString(L, spec, "field"),
+			// The Lua function is bound per VM; the placeholder only lets
+			// Validate check the metadata.
+			Transform: skipTransform,
+		}
+		if err := m.Validate(); err != nil {
+			L.ArgError(1, err.Error())
+			return 0
+		}
+		if handlers.RawGetString(id) != lua.LNil {
+			L.ArgError(1, fmt.Sprintf("migration %q is already registered", id))
+			return 0
+		}
+		if pending.Len() >= MaxMigrationsPerPlugin {
+			L.RaiseError("plugin %q exceeded maximum migration limit (%d)", pluginName, MaxMigrationsPerPlugin)
+			return 0
+		}
+
+		handlers.RawSetString(id, transform)
+
+		meta := L.NewTable()
+		meta.RawSetString("id", lua.LString(id))
+		meta.RawSetString("description", lua.LString(m.Description))
+		meta.RawSetString("datatype", lua.LString(m.Datatype))
+		meta.RawSetString("field", lua.LString(m.Field))
+		L.RawSetInt(pending, pending.Len()+1, meta)
+		return 0
+	}
+}
+
+// skipTransform is the no-op TransformFunc used to validate registration
+// metadata.
+func skipTransform(context.Context, contentmigration.Value) (contentmigration.Result, error) {
+	return contentmigration.Result{}, nil
+}
+
+// luaFieldString returns a string field of a table, or "" when it is missing
+// or not a string.
+func luaFieldString(L *lua.LState, tbl *lua.LTable, key string) string {
+	if s, ok := L.GetField(tbl, key).(lua.LString); ok {
+		return string(s)
+	}
+	return ""
+}
+
+// ReadPendingMigrations extracts PendingMigration entries from a checked-out
+// VM's __migration_pending table. Called once per plugin during loadPlugin.
+func ReadPendingMigrations(L *lua.LState) []PendingMigration {
+	pendingTbl, ok := L.GetGlobal("__migration_pending").(*lua.LTable)
+	if !ok {
+		return nil
+	}
+	var out []PendingMigration
+	for i := 1; i <= pendingTbl.Len(); i++ {
+		entry, ok := L.RawGetInt(pendingTbl, i).(*lua.LTable)
+		if !ok {
+			continue
+		}
+		out = append(out, PendingMigration{
+			ID:          luaFieldString(L, entry, "id"),
+			Description: luaFieldString(L, entry, "description"),
+			Datatype:    luaFieldString(L, entry, "datatype"),
+			Field:       luaFieldString(L, entry, "field"),
+		})
+	}
+	return out
+}
+
+// ContentMigrations returns the content migrations registered by running
+// plugins, ordered by ID. IDs are namespaced as "<plugin>:<id>" so plugins
+// cannot collide with each other or with Go migrations.
+func (m *Manager) ContentMigrations() []contentmigration.Migration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []contentmigration.Migration
+	for pluginName, inst := range m.plugins {
+		if inst.State != StateRunning {
+			continue
+		}
+		for _, pm := range inst.Migrations {
+			out = append(out, contentmigration.Migration{
+				ID:          pluginName + ":" + pm.ID,
+				Description: pm.Description,
+				Datatype:    pm.Datatype,
+				Field:       pm.Field,
+				Source:      "plugin:" + pluginName,
+				Transform:   m.luaMigrationTransform(pluginName, pm.ID),
+			})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// luaMigrationTransform adapts a plugin's Lua transform to a TransformFunc.
+// The Lua function is called as transform(value, ctx) where ctx holds
+// content_field_id, content_data_id, locale and field. It may return:
+//   - nil: leave the value unchanged
+//   - a string: rewrite the value in place
+//   - a table {values = {field = value, ...}, remove = bool}: see
+//     contentmigration.Result
+//
+// Calling error() in the transform reports the value as an issue during a
+// dry run and aborts the batch during a real run.
+func (m *Manager) luaMigrationTransform(pluginName, id string) contentmigration.TransformFunc {
+	return func(ctx context.Context, v contentmigration.Value) (contentmigration.Result, error) {
+		inst := m.GetPlugin(pluginName)
+		if inst == nil || inst.State != StateRunning {
+			return contentmigration.Result{}, fmt.Errorf("plugin %q is not running", pluginName)
+		}
+
+		execCtx, cancel := context.WithTimeout(ctx, time.Duration(m.cfg.ExecTimeoutSec)*time.Second)
+		defer cancel()
+
+		L, err := inst.Pool.Get(execCtx)
+		if err != nil {
+			return contentmigration.Result{}, fmt.Errorf("plugin %q: acquire VM: %w", pluginName, err)
+		}
+		defer inst.Pool.Put(L)
+
+		inst.mu.Lock()
+		if dbAPI, ok := inst.dbAPIs[L]; ok {
+			dbAPI.ResetOpCount()
+		}
+		inst.mu.Unlock()
+		L.SetContext(execCtx)
+
+		handlers, ok := L.GetGlobal("__migration_handlers").(*lua.LTable)
+		if !ok {
+			return contentmigration.Result{}, fmt.Errorf("plugin %q: migration handlers missing", pluginName)
+		}
+		fn, ok := L.GetField(handlers, id).(*lua.LFunction)
+		if !ok {
+			return contentmigration.Result{}, fmt.Errorf("plugin %q: migration %q has no transform", pluginName, id)
+		}
+
+		luaCtx := MapToLuaTable(L, map[string]any{
+			"content_field_id": v.ContentFieldID.String(),
+			"content_data_id":  v.ContentDataID.String(),
+			"locale":           v.Locale,
+			"field":            v.Field,
+		})
+		if err := L.CallByParam(lua.P{Fn: fn, NRet: 1, Protect: true}, lua.LString(v.Value), luaCtx); err != nil {
+			return contentmigration.Result{}, fmt.Errorf("plugin %q: migration %q: %w", pluginName, id, err)
+		}
+		ret := L.Get(-1)
+		L.Pop(1)
+		return luaMigrationResult(L, v.Field, ret)
+	}
+}
+
+// luaMigrationResult converts a Lua transform return value to a Result.
+func luaMigrationResult(L *lua.LState, field string, ret lua.LValue) (contentmigration.Result, error) {
+	switch val := ret.(type) {
+	case *lua.LNilType:
+		return contentmigration.Result{}, nil
+	case lua.LString:
+		return contentmigration.Set(field, string(val)), nil
+	case *lua.LTable:
+		var res contentmigration.Result
+		if b, ok := L.GetField(val, "remove").(lua.LBool); ok {
+			res.Remove = bool(b)
+		}
+		switch values := L.GetField(val, "values").(type) {
+		case *lua.LNilType:
+		case *lua.LTable:
+			res.Values = make(map[string]string)
+			var convErr error
+			values.ForEach(func(k, v lua.LValue) {
+				key, kok := k.(lua.LString)
+				if !kok {
+					convErr = fmt.Errorf("transform values keys must be field names, got %s", k.Type())
+					return
+				}
+				switch s := v.(type) {
+				case lua.LString:
+					res.Values[string(key)] = string(s)
+				case lua.LNumber, lua.LBool:
+					res.Values[string(key)] = s.String()
+				default:
+					convErr = fmt.Errorf("transform value for %q must be a string, got %s", string(key), v.Type())
+				}
+			})
+			if convErr != nil {
+				return contentmigration.Result{}, convErr
+			}
+		default:
+			return contentmigration.Result{}, fmt.Errorf("transform values must be a table, got %s", values.Type())
+		}
+		return res, nil
+	default:
+		return contentmigration.Result{}, fmt.Errorf("transform must return nil, a string or a table, got %s", ret.Type())
+	}
+}
@@ -0,0 +1,114 @@
+package plugin
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func newMigrationsTestVM() *lua.LState {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+	ApplySandbox(L, SandboxConfig{AllowCoroutine: true})
+	RegisterMigrationsAPI(L, "test_migrations")
+	FreezeModule(L, "migrations")
+	return L
+}
+
+func TestMigrationsRegister(t *testing.T) {
+	t.Run("registers at module scope", func(t *testing.T) {
+		L := newMigrationsTestVM()
+		defer L.Close()
+
+		err := L.DoString(`
+			migrations.register("0001_status", {
+				description = "lowercase status",
+				datatype = "article",
+				field = "status",
+				transform = function(value, ctx) return string.lower(value) end,
+			})
+		`)
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+
+		pending := ReadPendingMigrations(L)
+		if len(pending) != 1 {
+			t.Fatalf("pending = %v, want 1 entry", pending)
+		}
+		want := PendingMigration{ID: "0001_status", Description: "lowercase status", Datatype: "article", Field: "status"}
+		if pending[0] != want {
+			t.Errorf("pending[0] = %+v, want %+v", pending[0], want)
+		}
+		handlers := L.GetGlobal("__migration_handlers").(*lua.LTable)
+		if _, ok := handlers.RawGetString("0001_status").(*lua.LFunction); !ok {
+			t.Error("transform not stored in __migration_handlers")
+		}
+	})
+
+	t.Run("rejects invalid registrations", func(t *testing.T) {
+		cases := map[string]string{
+			"missing transform": `migrations.register("a", {datatype = "x", field = "y"})`,
+			"missing field":     `migrations.register("a", {datatype = "x", transform = function() end})`,
+			"bad id":            `migrations.register("a b", {datatype = "x", field = "y", transform = function() end})`,
+			"duplicate": `
+				migrations.register("a", {datatype = "x", field = "y", transform = function() end})
+				migrations.register("a", {datatype = "x", field = "y", transform = function() end})`,
+		}
+		for name, code := range cases {
+			t.Run(name, func(t *testing.T) {
+				L := newMigrationsTestVM()
+				defer L.Close()
+				if err := L.DoString(code); err == nil {
+					t.Error("expected error")
+				}
+			})
+		}
+	})
+
+	t.Run("rejects registration outside module scope", func(t *testing.T) {
+		L := newMigrationsTestVM()
+		defer L.Close()
+		SetVMPhase(L, "init")
+		err := L.DoString(`migrations.register("a", {datatype = "x", field = "y", transform = function() end})`)
+		if err == nil || !strings.Contains(err.Error(), "module scope") {
+			t.Errorf("err = %v, want module scope error", err)
+		}
+	})
+}
+
+func TestLuaMigrationResult(t *testing.T) {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+	defer L.Close()
+
+	res, err := luaMigrationResult(L, "status", lua.LNil)
+	if err != nil || res.Values != nil || res.Remove {
+		t.Errorf("nil -> %+v, %v; want zero Result", res, err)
+	}
+
+	res, err = luaMigrationResult(L, "status", lua.LString("draft"))
+	if err != nil || res.Values["status"] != "draft" {
+		t.Errorf("string -> %+v, %v", res, err)
+	}
+
+	if err := L.DoString(`result = {values = {first = "Ada", ["person.age"] = 36}, remove = true}`); err != nil {
+		t.Fatal(err)
+	}
+	res, err = luaMigrationResult(L, "name", L.GetGlobal("result"))
+	if err != nil {
+		t.Fatalf("table: %v", err)
+	}
+	if !res.Remove || res.Values["first"] != "Ada" || res.Values["person.age"] != "36" {
+		t.Errorf("table -> %+v", res)
+	}
+
+	if _, err := luaMigrationResult(L, "name", lua.LNumber(1)); err == nil {
+		t.Error("number return: expected error")
+	}
+	if err := L.DoString(`bad = {values = {first = {}}}`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := luaMigrationResult(L, "name", L.GetGlobal("bad")); err == nil {
+		t.Error("nested table value: expected error")
+	}
+}
@@ -295,9 +295,11 @@ func (h *Harness) runFile(ctx context.Context, filename string) []TestResult {
 	plugin.RegisterCoreAPI(L, coreAPI)
 	plugin.RegisterRequestAPI(L, h.pluginName, h.mockReq)
 	plugin.RegisterJSONAPI(L)
+	plugin.RegisterMigrationsAPI(L, h.pluginName)
+	plugin.RegisterTranslationAPI(L, h.pluginName)
 
 	// Freeze production modules
-	for _, mod := range []string{"db", "log", "http", "hooks", "core", "request", "json"} {
+	for _, mod := range []string{"db", "log", "http", "hooks", "core", "request", "json", "migrations", "translation"} {
 		plugin.FreezeModule(L, mod)
 	}
 
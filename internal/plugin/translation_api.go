@@ -0,0 +1,200 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/translation"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// MaxTranslationProvidersPerPlugin is the maximum number of translation
+// providers a single plugin can register via translation.register().
+const MaxTranslationProvidersPerPlugin = 10
+
+// translationProviderName restricts provider names to what fits in the
+// "<plugin>:<name>" translation_provider config value.
+var translationProviderName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// RegisterTranslationAPI creates a "translation" global Lua table with a
+// single function: register.
+//
+//	translation.register("glossary", {
+//	    translate = function(texts, ctx)
+//	        -- ctx.source_locale, ctx.target_locale, ctx.format ("text" or "html")
+//	        local out = {}
+//	        for i, text in ipairs(texts) do out[i] = my_translate(text, ctx) end
+//	        return out
+//	    end,
+//	})
+//
+// It also creates two hidden global tables read by the Manager at load time:
+//   - __translation_handlers: provider name -> translate LFunction
+//   - __translation_pending:  ordered array of provider names
+//
+// Like migrations.register(), translation.register() must be called at module
+// scope so every VM in the pool holds its own reference to the function.
+func RegisterTranslationAPI(L *lua.LState, pluginName string) {
+	handlers := L.NewTable()
+	pending := L.NewTable()
+
+	L.SetGlobal("__translation_handlers", handlers)
+	L.SetGlobal("__translation_pending", pending)
+
+	translationTable := L.NewTable()
+	translationTable.RawSetString("register", L.NewFunction(translationRegisterFn(pluginName, handlers, pending)))
+
+	L.SetGlobal("translation", translationTable)
+}
+
+// translationRegisterFn returns the Go-bound function for
+// translation.register(name, spec).
+func translationRegisterFn(pluginName string, handlers *lua.LTable, pending *lua.LTable) lua.LGFunction {
+	return func(L *lua.LState) int {
+		name := L.CheckString(1)
+		spec := L.CheckTable(2)
+
+		if phase := VMPhase(L); phase != "" && phase != "module_scope" {
+			L.RaiseError("translation.register() must be called at module scope, not inside on_init()")
+			return 0
+		}
+		if !translationProviderName.MatchString(name) {
+			L.ArgError(1, "provider name must be lowercase letters, digits, '-' or '_'")
+			return 0
+		}
+		fn, ok := L.GetField(spec, "translate").(*lua.LFunction)
+		if !ok {
+			L.ArgError(2, "translate must be a function")
+			return 0
+		}
+		if handlers.RawGetString(name) != lua.LNil {
+			L.ArgError(1, fmt.Sprintf("translation provider %q is already registered", name))
+			return 0
+		}
+		if pending.Len() >= MaxTranslationProvidersPerPlugin {
+			L.RaiseError("plugin %q exceeded maximum translation provider limit (%d)", pluginName, MaxTranslationProvidersPerPlugin)
+			return 0
+		}
+
+		handlers.RawSetString(name, fn)
+		L.RawSetInt(pending, pending.Len()+1, lua.LString(name))
+		return 0
+	}
+}
+
+// ReadPendingTranslationProviders returns the provider names registered in a
+// checked-out VM's __translation_pending table. Called once per plugin during
+// loadPlugin.
+func ReadPendingTranslationProviders(L *lua.LState) []string {
+	pendingTbl, ok := L.GetGlobal("__translation_pending").(*lua.LTable)
+	if !ok {
+		return nil
+	}
+	var out []string
+	for i := 1; i <= pendingTbl.Len(); i++ {
+		if name, ok := L.RawGetInt(pendingTbl, i).(lua.LString); ok {
+			out = append(out, string(name))
+		}
+	}
+	return out
+}
+
+// TranslationProviders returns the translation providers registered by
+// running plugins, ordered by name. Names are "<plugin>:<name>", the value
+// the translation_provider config field selects them by.
+func (m *Manager) TranslationProviders() []translation.Provider {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []translation.Provider
+	for pluginName, inst := range m.plugins {
+		if inst.State != StateRunning {
+			continue
+		}
+		for _, name := range inst.TranslationProviders {
+			out = append(out, &luaTranslationProvider{m: m, pluginName: pluginName, name: name})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name() < out[j].Name() })
+	return out
+}
+
+// luaTranslationProvider adapts a plugin's Lua translate function to a
+// translation.Provider.
+type luaTranslationProvider struct {
+	m          *Manager
+	pluginName string
+	name       string
+}
+
+func (p *luaTranslationProvider) Name() string { return p.pluginName + ":" + p.name }
+
+// Translate calls translate(texts, ctx) in a pooled VM. The function must
+// return an array with one string per input; calling error() fails the batch.
+func (p *luaTranslationProvider) Translate(ctx context.Context, req translation.Request) ([]string, error) {
+	inst := p.m.GetPlugin(p.pluginName)
+	if inst == nil || inst.State != StateRunning {
+		return nil, fmt.Errorf("plugin %q is not running", p.pluginName)
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, time.Duration(p.m.cfg.ExecTimeoutSec)*time.Second)
+	defer cancel()
+
+	L, err := inst.Pool.Get(execCtx)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %q: acquire VM: %w", p.pluginName, err)
+	}
+	defer inst.Pool.Put(L)
+
+	inst.mu.Lock()
+	if dbAPI, ok := inst.dbAPIs[L]; ok {
+		dbAPI.ResetOpCount()
+	}
+	inst.mu.Unlock()
+	L.SetContext(execCtx)
+
+	handlers, ok := L.GetGlobal("__translation_handlers").(*lua.LTable)
+	if !ok {
+		return nil, fmt.Errorf("plugin %q: translation handlers missing", p.pluginName)
+	}
+	fn, ok := L.GetField(handlers, p.name).(*lua.LFunction)
+	if !ok {
+		return nil, fmt.Errorf("plugin %q: translation provider %q has no translate function", p.pluginName, p.name)
+	}
+
+	texts := L.NewTable()
+	for i, text := range req.Texts {
+		L.RawSetInt(texts, i+1, lua.LString(text))
+	}
+	luaCtx := MapToLuaTable(L, map[string]any{
+		"source_locale": req.SourceLocale,
+		"target_locale": req.TargetLocale,
+		"format":        req.Format,
+	})
+	if err := L.CallByParam(lua.P{Fn: fn, NRet: 1, Protect: true}, texts, luaCtx); err != nil {
+		return nil, fmt.Errorf("plugin %q: translation provider %q: %w", p.pluginName, p.name, err)
+	}
+	ret := L.Get(-1)
+	L.Pop(1)
+	return luaTranslationResult(L, ret)
+}
+
+// luaTranslationResult converts a translate return value to a string slice.
+func luaTranslationResult(L *lua.LState, ret lua.LValue) ([]string, error) {
+	tbl, ok := ret.(*lua.LTable)
+	if !ok {
+		return nil, fmt.Errorf("translate must return an array of strings, got %s", ret.Type())
+	}
+	out := make([]string, 0, tbl.Len())
+	for i := 1; i <= tbl.Len(); i++ {
+		s, ok := L.RawGetInt(tbl, i).(lua.LString)
+		if !ok {
+			return nil, fmt.Errorf("translate result %d must be a string, got %s", i, L.RawGetInt(tbl, i).Type())
+		}
+		out = append(out, string(s))
+	}
+	return out, nil
+}
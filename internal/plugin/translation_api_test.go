@@ -0,0 +1,104 @@
+package plugin
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func newTranslationTestVM() *lua.LState {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+	ApplySandbox(L, SandboxConfig{AllowCoroutine: true})
+	RegisterTranslationAPI(L, "test_translation")
+	FreezeModule(L, "translation")
+	return L
+}
+
+func TestTranslationRegister(t *testing.T) {
+	t.Run("registers at module scope", func(t *testing.T) {
+		L := newTranslationTestVM()
+		defer L.Close()
+
+		err := L.DoString(`
+			translation.register("shout", {
+				translate = function(texts, ctx)
+					local out = {}
+					for i, text in ipairs(texts) do
+						out[i] = ctx.target_locale .. ":" .. string.upper(text)
+					end
+					return out
+				end,
+			})
+		`)
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		if got := ReadPendingTranslationProviders(L); !reflect.DeepEqual(got, []string{"shout"}) {
+			t.Fatalf("pending = %v, want [shout]", got)
+		}
+
+		// Call the stored function the way luaTranslationProvider does.
+		handlers := L.GetGlobal("__translation_handlers").(*lua.LTable)
+		fn := handlers.RawGetString("shout").(*lua.LFunction)
+		texts := L.NewTable()
+		L.RawSetInt(texts, 1, lua.LString("hi"))
+		L.RawSetInt(texts, 2, lua.LString("bye"))
+		luaCtx := MapToLuaTable(L, map[string]any{"target_locale": "fr"})
+		if err := L.CallByParam(lua.P{Fn: fn, NRet: 1, Protect: true}, texts, luaCtx); err != nil {
+			t.Fatalf("call: %v", err)
+		}
+		out, err := luaTranslationResult(L, L.Get(-1))
+		if err != nil {
+			t.Fatalf("luaTranslationResult: %v", err)
+		}
+		if want := []string{"fr:HI", "fr:BYE"}; !reflect.DeepEqual(out, want) {
+			t.Errorf("out = %v, want %v", out, want)
+		}
+	})
+
+	t.Run("rejects invalid registrations", func(t *testing.T) {
+		cases := map[string]string{
+			"missing translate": `translation.register("a", {})`,
+			"bad name":          `translation.register("A B", {translate = function() end})`,
+			"duplicate": `
+				translation.register("a", {translate = function() end})
+				translation.register("a", {translate = function() end})`,
+		}
+		for name, code := range cases {
+			t.Run(name, func(t *testing.T) {
+				L := newTranslationTestVM()
+				defer L.Close()
+				if err := L.DoString(code); err == nil {
+					t.Error("expected error")
+				}
+			})
+		}
+	})
+
+	t.Run("rejects registration outside module scope", func(t *testing.T) {
+		L := newTranslationTestVM()
+		defer L.Close()
+		SetVMPhase(L, "init")
+		err := L.DoString(`translation.register("a", {translate = function() end})`)
+		if err == nil || !strings.Contains(err.Error(), "module scope") {
+			t.Errorf("err = %v, want module scope error", err)
+		}
+	})
+}
+
+func TestLuaTranslationResult(t *testing.T) {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+	defer L.Close()
+
+	if _, err := luaTranslationResult(L, lua.LString("x")); err == nil {
+		t.Error("string return: expected error")
+	}
+	if err := L.DoString(`bad = {"ok", 2}`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := luaTranslationResult(L, L.GetGlobal("bad")); err == nil {
+		t.Error("non-string element: expected error")
+	}
+}
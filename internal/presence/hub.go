@@ -0,0 +1,108 @@
+package presence
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// PollInterval is how often Watch re-reads presence without a notification.
+// Polling picks up lease expiry and changes made by other server replicas,
+// which the in-process hub never sees.
+const PollInterval = 5 * time.Second
+
+// Hub fans out presence changes to watchers in this process. Notifications
+// carry no payload; watchers re-read presence from the database.
+type Hub struct {
+	mu   sync.Mutex
+	subs map[types.ContentID]map[chan struct{}]struct{}
+}
+
+// NewHub creates an empty Hub.
+func NewHub() *Hub {
+	return &Hub{subs: make(map[types.ContentID]map[chan struct{}]struct{})}
+}
+
+// DefaultHub is notified by Acquire, Renew and Release. The HTTP server and
+// SSH TUI share it when they run in the same process.
+var DefaultHub = NewHub()
+
+// Subscribe returns a channel that receives a value after presence on the
+// node changes, and a function that ends the subscription. Notifications
+// that arrive while one is pending are coalesced.
+func (h *Hub) Subscribe(contentID types.ContentID) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	h.mu.Lock()
+	if h.subs[contentID] == nil {
+		h.subs[contentID] = make(map[chan struct{}]struct{})
+	}
+	h.subs[contentID][ch] = struct{}{}
+	h.mu.Unlock()
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subs[contentID], ch)
+		if len(h.subs[contentID]) == 0 {
+			delete(h.subs, contentID)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// Notify wakes every subscriber of the node without blocking.
+func (h *Hub) Notify(contentID types.ContentID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[contentID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Watch calls fn with the node's presence immediately and again each time it
+// changes, until ctx is done or fn returns an error. Changes are found by hub
+// notifications and by polling every PollInterval.
+func Watch(ctx context.Context, d db.DbDriver, contentID types.ContentID, fn func(*Presence) error) error {
+	notify, cancel := DefaultHub.Subscribe(contentID)
+	defer cancel()
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+
+	last := "-"
+	for {
+		p, err := Current(d, contentID)
+		if err != nil {
+			return err
+		}
+		if key := p.key(); key != last {
+			last = key
+			if err := fn(p); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-notify:
+		case <-ticker.C:
+		}
+	}
+}
+
+// key identifies who holds which lease, ignoring expiry times so that
+// heartbeats do not count as changes.
+func (p *Presence) key() string {
+	var b strings.Builder
+	if p.Editor != nil {
+		b.WriteString(p.Editor.LeaseID)
+	}
+	for _, v := range p.Viewers {
+		b.WriteString("," + v.LeaseID)
+	}
+	return b.String()
+}
@@ -0,0 +1,325 @@
+// Package presence tracks who is viewing or editing a content node.
+//
+// Each client session (an admin panel tab, a TUI session, an API client)
+// holds a lease on the node it has open. Leases are advisory: they do not
+// block writes, which are still guarded by revision checks at publish time.
+// At most one session holds an editing lease on a node; any number may hold
+// viewing leases. Leases expire unless renewed by a heartbeat, so a closed
+// tab or dropped SSH connection frees the node after TTL.
+package presence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// Lease modes.
+const (
+	ModeViewing = "viewing"
+	ModeEditing = "editing"
+)
+
+// Client names recorded on leases by the built-in surfaces.
+const (
+	ClientAdmin = "admin"
+	ClientTUI   = "tui"
+	ClientAPI   = "api"
+)
+
+const (
+	// DefaultTTL is how long a lease lives without a heartbeat.
+	DefaultTTL = 60 * time.Second
+	// HeartbeatInterval is how often clients should renew their leases. It
+	// leaves room for two missed heartbeats before a lease expires.
+	HeartbeatInterval = DefaultTTL / 3
+)
+
+// ErrLeaseLost is returned when renewing a lease that expired and was
+// cleaned up, was released, or was taken over by another editor.
+var ErrLeaseLost = errors.New("lease expired or was taken over")
+
+// ErrNotOwner is returned when a user renews or releases another user's lease.
+var ErrNotOwner = errors.New("lease belongs to another user")
+
+// HeldError is returned when an editing lease is requested on a node that
+// another session is already editing.
+type HeldError struct {
+	Holder db.ContentLease
+}
+
+func (e *HeldError) Error() string {
+	return fmt.Sprintf("content is being edited by %s until %s",
+		HolderName(e.Holder), time.Unix(e.Holder.ExpiresAt, 0).UTC().Format(time.RFC3339))
+}
+
+// IsHeld reports whether err is a HeldError.
+func IsHeld(err error) bool {
+	var he *HeldError
+	return errors.As(err, &he)
+}
+
+// HolderName returns the display name for a lease holder.
+func HolderName(l db.ContentLease) string {
+	name := l.Username
+	if name == "" {
+		name = l.UserID.String()
+	}
+	if l.Client != "" {
+		name += " (" + l.Client + ")"
+	}
+	return name
+}
+
+// Presence lists the live leases on a content node.
+type Presence struct {
+	ContentDataID types.ContentID   `json:"content_data_id"`
+	Editor        *db.ContentLease  `json:"editor"`
+	Viewers       []db.ContentLease `json:"viewers"`
+}
+
+// AcquireParams describes a lease request.
+type AcquireParams struct {
+	ContentDataID types.ContentID
+	// LeaseID is the caller's current lease on the node, if any. It is
+	// converted to the requested mode and renewed instead of creating a
+	// second lease for the same session.
+	LeaseID  string
+	UserID   types.UserID
+	Username string
+	Client   string
+	Mode     string
+	// Force takes the editing lease from other sessions. Callers must
+	// restrict it to administrators; every takeover is recorded in
+	// change_events.
+	Force bool
+	// TTL overrides DefaultTTL when positive.
+	TTL time.Duration
+}
+
+// now is replaced in tests.
+var now = time.Now
+
+// Acquire takes a lease on a content node. Viewing leases always succeed.
+// An editing lease fails with a HeldError while another user's session holds
+// one, unless Force is set. A user may always take over an editing lease held
+// by another of their own sessions, such as a tab that was reloaded.
+func Acquire(d db.DbDriver, ac audited.AuditContext, p AcquireParams) (*db.ContentLease, error) {
+	if p.Mode != ModeViewing && p.Mode != ModeEditing {
+		return nil, fmt.Errorf("invalid lease mode %q: must be %q or %q", p.Mode, ModeViewing, ModeEditing)
+	}
+	if err := p.ContentDataID.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid content_data_id: %w", err)
+	}
+	ts := now()
+	if err := d.DeleteExpiredContentLeases(ts.Unix()); err != nil {
+		utility.DefaultLogger.Warn("failed to sweep expired content leases", err)
+	}
+	live, err := d.ListContentLeasesByContent(p.ContentDataID, ts.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	var own *db.ContentLease
+	var taken []db.ContentLease
+	for _, l := range *live {
+		if l.LeaseID == p.LeaseID && l.UserID == p.UserID {
+			own = &l
+			continue
+		}
+		if p.Mode == ModeEditing && l.Mode == ModeEditing {
+			taken = append(taken, l)
+		}
+	}
+	for _, l := range taken {
+		if l.UserID != p.UserID && !p.Force {
+			return nil, &HeldError{Holder: l}
+		}
+	}
+	for _, l := range taken {
+		if l.UserID != p.UserID {
+			if err := recordOverride(d, ac, l); err != nil {
+				return nil, err
+			}
+		}
+		// Other sessions keep presence as viewers until their next heartbeat
+		// reports the lost lease.
+		if err := d.UpdateContentLease(db.UpdateContentLeaseParams{LeaseID: l.LeaseID, Mode: ModeViewing, ExpiresAt: l.ExpiresAt}); err != nil {
+			return nil, err
+		}
+	}
+
+	expires := ts.Add(leaseTTL(p.TTL)).Unix()
+	var lease *db.ContentLease
+	if own != nil {
+		if err := d.UpdateContentLease(db.UpdateContentLeaseParams{LeaseID: own.LeaseID, Mode: p.Mode, ExpiresAt: expires}); err != nil {
+			return nil, heldOnConflict(d, p.ContentDataID, ts, err)
+		}
+		own.Mode = p.Mode
+		own.ExpiresAt = expires
+		lease = own
+	} else {
+		lease, err = d.CreateContentLease(db.CreateContentLeaseParams{
+			LeaseID:       types.NewULID().String(),
+			ContentDataID: p.ContentDataID,
+			UserID:        p.UserID,
+			Username:      p.Username,
+			Client:        p.Client,
+			Mode:          p.Mode,
+			AcquiredAt:    ts.Unix(),
+			ExpiresAt:     expires,
+		})
+		if err != nil {
+			return nil, heldOnConflict(d, p.ContentDataID, ts, err)
+		}
+	}
+	DefaultHub.Notify(p.ContentDataID)
+	return lease, nil
+}
+
+// heldOnConflict converts a write that lost a race for the editing lease
+// into a HeldError. The listing above is not isolated from concurrent
+// Acquire calls; the unique index on editing leases is what keeps a node
+// to one editor, so a violation means another session got there first.
+func heldOnConflict(d db.DbDriver, id types.ContentID, ts time.Time, err error) error {
+	if !isUniqueViolation(err) {
+		return err
+	}
+	live, lerr := d.ListContentLeasesByContent(id, ts.Unix())
+	if lerr != nil {
+		return err
+	}
+	for _, l := range *live {
+		if l.Mode == ModeEditing {
+			return &HeldError{Holder: l}
+		}
+	}
+	return err
+}
+
+// isUniqueViolation matches the unique constraint errors reported by the
+// SQLite, MySQL, and PostgreSQL drivers.
+func isUniqueViolation(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint failed") ||
+		strings.Contains(msg, "Duplicate entry") ||
+		strings.Contains(msg, "duplicate key value")
+}
+
+// Renew extends a lease by ttl (DefaultTTL when zero). It returns
+// ErrLeaseLost when the lease is gone, or when an expired editing lease has
+// since been taken by another session. An editing lease that was taken over
+// comes back in viewing mode, so callers should compare the returned mode.
+func Renew(d db.DbDriver, leaseID string, userID types.UserID, ttl time.Duration) (*db.ContentLease, error) {
+	lease, err := d.GetContentLease(leaseID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLeaseLost
+	}
+	if err != nil {
+		return nil, err
+	}
+	if lease.UserID != userID {
+		return nil, ErrNotOwner
+	}
+	ts := now()
+	expired := lease.ExpiresAt < ts.Unix()
+	if expired && lease.Mode == ModeEditing {
+		live, err := d.ListContentLeasesByContent(lease.ContentDataID, ts.Unix())
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range *live {
+			if l.Mode == ModeEditing && l.LeaseID != lease.LeaseID {
+				if err := d.DeleteContentLease(lease.LeaseID); err != nil {
+					return nil, err
+				}
+				return nil, ErrLeaseLost
+			}
+		}
+	}
+	lease.ExpiresAt = ts.Add(leaseTTL(ttl)).Unix()
+	if err := d.UpdateContentLease(db.UpdateContentLeaseParams{LeaseID: lease.LeaseID, Mode: lease.Mode, ExpiresAt: lease.ExpiresAt}); err != nil {
+		return nil, err
+	}
+	if expired {
+		DefaultHub.Notify(lease.ContentDataID)
+	}
+	return lease, nil
+}
+
+// Release removes a lease. Releasing a lease that is already gone succeeds.
+func Release(d db.DbDriver, leaseID string, userID types.UserID) error {
+	lease, err := d.GetContentLease(leaseID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if lease.UserID != userID {
+		return ErrNotOwner
+	}
+	if err := d.DeleteContentLease(leaseID); err != nil {
+		return err
+	}
+	DefaultHub.Notify(lease.ContentDataID)
+	return nil
+}
+
+// Current returns the live leases on a content node.
+func Current(d db.DbDriver, contentID types.ContentID) (*Presence, error) {
+	live, err := d.ListContentLeasesByContent(contentID, now().Unix())
+	if err != nil {
+		return nil, err
+	}
+	p := &Presence{ContentDataID: contentID, Viewers: []db.ContentLease{}}
+	for _, l := range *live {
+		if l.Mode == ModeEditing && p.Editor == nil {
+			p.Editor = &l
+			continue
+		}
+		p.Viewers = append(p.Viewers, l)
+	}
+	return p, nil
+}
+
+// recordOverride writes a forced takeover to change_events before the
+// holder's lease is downgraded, so a takeover is never applied unaudited.
+func recordOverride(d db.DbDriver, ac audited.AuditContext, holder db.ContentLease) error {
+	old, err := json.Marshal(holder)
+	if err != nil {
+		return fmt.Errorf("marshal overridden lease: %w", err)
+	}
+	_, err = d.RecordChangeEvent(db.RecordChangeEventParams{
+		EventID:      types.NewEventID(),
+		HlcTimestamp: types.HLCNow(),
+		NodeID:       ac.NodeID,
+		TableName:    string(db.Content_leases),
+		RecordID:     string(holder.ContentDataID),
+		Operation:    types.OpUpdate,
+		Action:       types.ActionOverride,
+		UserID:       types.NullableUserID{ID: ac.UserID, Valid: !ac.UserID.IsZero()},
+		OldValues:    types.JSONData{Data: json.RawMessage(old), Valid: true},
+		RequestID:    types.NullableString{String: ac.RequestID, Valid: ac.RequestID != ""},
+		IP:           types.NullableString{String: ac.IP, Valid: ac.IP != ""},
+	})
+	if err != nil {
+		return fmt.Errorf("record lease override: %w", err)
+	}
+	return nil
+}
+
+func leaseTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return DefaultTTL
+	}
+	return ttl
+}
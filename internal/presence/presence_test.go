@@ -0,0 +1,313 @@
+package presence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+func testDB(t *testing.T) db.Database {
+	t.Helper()
+	conn, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	d := db.Database{Connection: conn, Context: context.Background(), Config: config.Config{Node_ID: types.NewNodeID().String()}}
+	if err := d.CreateAllTables(); err != nil {
+		t.Fatalf("CreateAllTables: %v", err)
+	}
+	return d
+}
+
+func testAuditCtx(d db.Database, userID types.UserID) audited.AuditContext {
+	return audited.Ctx(types.NodeID(d.Config.Node_ID), userID, "test", "127.0.0.1")
+}
+
+func editing(contentID types.ContentID, userID types.UserID, name string) AcquireParams {
+	return AcquireParams{ContentDataID: contentID, UserID: userID, Username: name, Client: ClientAdmin, Mode: ModeEditing}
+}
+
+func TestAcquire_EditingIsExclusive(t *testing.T) {
+	t.Parallel()
+	d := testDB(t)
+	contentID := types.NewContentID()
+	alice, bob := types.NewUserID(), types.NewUserID()
+
+	held, err := Acquire(d, testAuditCtx(d, alice), editing(contentID, alice, "alice"))
+	if err != nil {
+		t.Fatalf("Acquire alice: %v", err)
+	}
+
+	_, err = Acquire(d, testAuditCtx(d, bob), editing(contentID, bob, "bob"))
+	var he *HeldError
+	if !errors.As(err, &he) || he.Holder.LeaseID != held.LeaseID {
+		t.Fatalf("Acquire bob: err = %v, want HeldError for alice", err)
+	}
+
+	// Viewing is never blocked.
+	viewer, err := Acquire(d, testAuditCtx(d, bob), AcquireParams{ContentDataID: contentID, UserID: bob, Username: "bob", Client: ClientTUI, Mode: ModeViewing})
+	if err != nil {
+		t.Fatalf("Acquire bob viewing: %v", err)
+	}
+
+	p, err := Current(d, contentID)
+	if err != nil {
+		t.Fatalf("Current: %v", err)
+	}
+	if p.Editor == nil || p.Editor.LeaseID != held.LeaseID {
+		t.Errorf("editor = %+v, want alice", p.Editor)
+	}
+	if len(p.Viewers) != 1 || p.Viewers[0].LeaseID != viewer.LeaseID {
+		t.Errorf("viewers = %+v, want bob", p.Viewers)
+	}
+
+	// Releasing frees the node, and upgrading keeps bob's lease ID.
+	if err := Release(d, held.LeaseID, alice); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	p2 := editing(contentID, bob, "bob")
+	p2.LeaseID = viewer.LeaseID
+	upgraded, err := Acquire(d, testAuditCtx(d, bob), p2)
+	if err != nil {
+		t.Fatalf("Acquire bob after release: %v", err)
+	}
+	if upgraded.LeaseID != viewer.LeaseID || upgraded.Mode != ModeEditing {
+		t.Errorf("upgraded = %+v, want bob's viewing lease in editing mode", upgraded)
+	}
+}
+
+// racingDriver inserts a competing editing lease after Acquire has listed
+// the live leases, as a concurrent Acquire on another connection would.
+type racingDriver struct {
+	db.Database
+	rival db.CreateContentLeaseParams
+	raced bool
+}
+
+func (r *racingDriver) ListContentLeasesByContent(id types.ContentID, now int64) (*[]db.ContentLease, error) {
+	live, err := r.Database.ListContentLeasesByContent(id, now)
+	if err != nil || r.raced {
+		return live, err
+	}
+	r.raced = true
+	if _, err := r.Database.CreateContentLease(r.rival); err != nil {
+		return nil, err
+	}
+	return live, nil
+}
+
+func TestAcquire_LostRaceIsHeld(t *testing.T) {
+	t.Parallel()
+	d := testDB(t)
+	contentID := types.NewContentID()
+	alice, bob := types.NewUserID(), types.NewUserID()
+	ts := time.Now()
+	r := &racingDriver{Database: d, rival: db.CreateContentLeaseParams{
+		LeaseID:       types.NewULID().String(),
+		ContentDataID: contentID,
+		UserID:        alice,
+		Username:      "alice",
+		Client:        ClientAdmin,
+		Mode:          ModeEditing,
+		AcquiredAt:    ts.Unix(),
+		ExpiresAt:     ts.Add(DefaultTTL).Unix(),
+	}}
+
+	_, err := Acquire(r, testAuditCtx(d, bob), editing(contentID, bob, "bob"))
+	var he *HeldError
+	if !errors.As(err, &he) || he.Holder.LeaseID != r.rival.LeaseID {
+		t.Fatalf("Acquire bob: err = %v, want HeldError for alice", err)
+	}
+
+	p, err := Current(d, contentID)
+	if err != nil {
+		t.Fatalf("Current: %v", err)
+	}
+	if p.Editor == nil || p.Editor.LeaseID != r.rival.LeaseID || len(p.Viewers) != 0 {
+		t.Errorf("presence = %+v, want alice as the only lease", p)
+	}
+}
+
+func TestAcquire_SameUserTakesOver(t *testing.T) {
+	t.Parallel()
+	d := testDB(t)
+	contentID := types.NewContentID()
+	alice := types.NewUserID()
+
+	first, err := Acquire(d, testAuditCtx(d, alice), editing(contentID, alice, "alice"))
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	if _, err := Acquire(d, testAuditCtx(d, alice), editing(contentID, alice, "alice")); err != nil {
+		t.Fatalf("Acquire from a second session: %v", err)
+	}
+	renewed, err := Renew(d, first.LeaseID, alice, 0)
+	if err != nil {
+		t.Fatalf("Renew: %v", err)
+	}
+	if renewed.Mode != ModeViewing {
+		t.Errorf("first session mode = %q, want viewing", renewed.Mode)
+	}
+
+	events, err := d.GetChangeEventsByRecord(string(db.Content_leases), string(contentID))
+	if err != nil {
+		t.Fatalf("GetChangeEventsByRecord: %v", err)
+	}
+	if len(*events) != 0 {
+		t.Errorf("events = %+v, want none for a same-user takeover", *events)
+	}
+}
+
+func TestAcquire_ForceIsAudited(t *testing.T) {
+	t.Parallel()
+	d := testDB(t)
+	contentID := types.NewContentID()
+	alice, admin := types.NewUserID(), types.NewUserID()
+
+	held, err := Acquire(d, testAuditCtx(d, alice), editing(contentID, alice, "alice"))
+	if err != nil {
+		t.Fatalf("Acquire alice: %v", err)
+	}
+	force := editing(contentID, admin, "root")
+	force.Force = true
+	taken, err := Acquire(d, testAuditCtx(d, admin), force)
+	if err != nil {
+		t.Fatalf("Acquire force: %v", err)
+	}
+
+	p, err := Current(d, contentID)
+	if err != nil {
+		t.Fatalf("Current: %v", err)
+	}
+	if p.Editor == nil || p.Editor.LeaseID != taken.LeaseID {
+		t.Errorf("editor = %+v, want the admin", p.Editor)
+	}
+	renewed, err := Renew(d, held.LeaseID, alice, 0)
+	if err != nil {
+		t.Fatalf("Renew alice: %v", err)
+	}
+	if renewed.Mode != ModeViewing {
+		t.Errorf("alice mode after takeover = %q, want viewing", renewed.Mode)
+	}
+
+	events, err := d.GetChangeEventsByRecord(string(db.Content_leases), string(contentID))
+	if err != nil {
+		t.Fatalf("GetChangeEventsByRecord: %v", err)
+	}
+	if len(*events) != 1 {
+		t.Fatalf("events = %+v, want one override", *events)
+	}
+	ev := (*events)[0]
+	if ev.Action != types.ActionOverride || ev.UserID.ID != admin {
+		t.Errorf("event = %+v, want override by the admin", ev)
+	}
+}
+
+func TestRenew_ExpiredLease(t *testing.T) {
+	d := testDB(t)
+	contentID := types.NewContentID()
+	alice, bob := types.NewUserID(), types.NewUserID()
+
+	held, err := Acquire(d, testAuditCtx(d, alice), editing(contentID, alice, "alice"))
+	if err != nil {
+		t.Fatalf("Acquire alice: %v", err)
+	}
+
+	// Once alice's lease expires, bob can edit and alice's heartbeat fails.
+	start := now
+	t.Cleanup(func() { now = start })
+	later := time.Now().Add(2 * DefaultTTL)
+	now = func() time.Time { return later }
+
+	if _, err := Acquire(d, testAuditCtx(d, bob), editing(contentID, bob, "bob")); err != nil {
+		t.Fatalf("Acquire bob after expiry: %v", err)
+	}
+	if _, err := Renew(d, held.LeaseID, alice, 0); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Renew alice: err = %v, want ErrLeaseLost", err)
+	}
+	if _, err := Renew(d, "01ARZ3NDEKTSV4RRFFQ69G5FAV", alice, 0); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Renew unknown lease: err = %v, want ErrLeaseLost", err)
+	}
+}
+
+func TestRelease_NotOwner(t *testing.T) {
+	t.Parallel()
+	d := testDB(t)
+	contentID := types.NewContentID()
+	alice := types.NewUserID()
+
+	held, err := Acquire(d, testAuditCtx(d, alice), editing(contentID, alice, "alice"))
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	if err := Release(d, held.LeaseID, types.NewUserID()); !errors.Is(err, ErrNotOwner) {
+		t.Errorf("Release by another user: err = %v, want ErrNotOwner", err)
+	}
+	if _, err := Renew(d, held.LeaseID, types.NewUserID(), 0); !errors.Is(err, ErrNotOwner) {
+		t.Errorf("Renew by another user: err = %v, want ErrNotOwner", err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+	d := testDB(t)
+	contentID := types.NewContentID()
+	alice := types.NewUserID()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := make(chan *Presence, 4)
+	done := make(chan error, 1)
+	go func() {
+		done <- Watch(ctx, d, contentID, func(p *Presence) error {
+			updates <- p
+			return nil
+		})
+	}()
+
+	next := func() *Presence {
+		select {
+		case p := <-updates:
+			return p
+		case <-time.After(PollInterval / 2):
+			t.Fatal("timed out waiting for a presence update")
+			return nil
+		}
+	}
+	if p := next(); p.Editor != nil || len(p.Viewers) != 0 {
+		t.Fatalf("initial presence = %+v, want empty", p)
+	}
+	held, err := Acquire(d, testAuditCtx(d, alice), editing(contentID, alice, "alice"))
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	if p := next(); p.Editor == nil || p.Editor.LeaseID != held.LeaseID {
+		t.Fatalf("presence after acquire = %+v, want alice editing", p)
+	}
+	// Heartbeats are not changes.
+	if _, err := Renew(d, held.LeaseID, alice, 0); err != nil {
+		t.Fatalf("Renew: %v", err)
+	}
+	if err := Release(d, held.LeaseID, alice); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if p := next(); p.Editor != nil {
+		t.Fatalf("presence after release = %+v, want empty", p)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Watch: %v", err)
+	}
+}
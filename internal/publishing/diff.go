@@ -0,0 +1,525 @@
+package publishing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// Change kinds reported by a VersionDiff.
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+	ChangeMoved    = "moved"
+)
+
+// Word diff operations.
+const (
+	WordEqual  = "equal"
+	WordInsert = "insert"
+	WordDelete = "delete"
+)
+
+// DraftSide names the live draft as a side of a diff.
+const DraftSide types.ContentVersionID = "draft"
+
+// maxWordDiffCells bounds the LCS table of a word diff. Larger inputs are
+// reported as a whole delete plus a whole insert.
+const maxWordDiffCells = 4_000_000
+
+// wordDiffTypes are the field types whose changes carry a word-level diff.
+var wordDiffTypes = map[string]bool{
+	"text":     true,
+	"textarea": true,
+	"richtext": true,
+	"_title":   true,
+}
+
+// DiffSide describes one side of a VersionDiff.
+type DiffSide struct {
+	ContentVersionID string `json:"content_version_id,omitempty"`
+	VersionNumber    int64  `json:"version_number,omitempty"`
+	Label            string `json:"label,omitempty"`
+	Published        bool   `json:"published,omitempty"`
+	Draft            bool   `json:"draft,omitempty"`
+	Locale           string `json:"locale"`
+}
+
+// VersionDiff is the difference between two snapshots of one content tree.
+type VersionDiff struct {
+	ContentDataID string      `json:"content_data_id"`
+	From          DiffSide    `json:"from"`
+	To            DiffSide    `json:"to"`
+	Nodes         []NodeDiff  `json:"nodes"`
+	Fields        []FieldDiff `json:"fields"`
+}
+
+// Empty reports whether the two sides are identical.
+func (v *VersionDiff) Empty() bool {
+	return len(v.Nodes) == 0 && len(v.Fields) == 0
+}
+
+// NodeDiff is a structural change to one node of the content tree. Moved
+// nodes changed parent or position among their siblings.
+type NodeDiff struct {
+	ContentDataID string `json:"content_data_id"`
+	Datatype      string `json:"datatype"`
+	Title         string `json:"title,omitempty"`
+	Change        string `json:"change"`
+	OldParentID   string `json:"old_parent_id,omitempty"`
+	NewParentID   string `json:"new_parent_id,omitempty"`
+}
+
+// FieldDiff is a change to one field value. Words is set for modified text,
+// textarea, richtext, and title fields.
+type FieldDiff struct {
+	ContentDataID string     `json:"content_data_id"`
+	FieldID       string     `json:"field_id"`
+	Label         string     `json:"label"`
+	Type          string     `json:"type"`
+	Locale        string     `json:"locale,omitempty"`
+	Change        string     `json:"change"`
+	OldValue      string     `json:"old_value,omitempty"`
+	NewValue      string     `json:"new_value,omitempty"`
+	Words         []WordDiff `json:"words,omitempty"`
+}
+
+// WordDiff is one run of a word-level diff.
+type WordDiff struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// DiffContentVersions compares two snapshots of the content tree rooted at
+// contentID. Each side is a version ID or "draft" for the live draft. An
+// empty fromID selects the published version for locale, or an empty tree
+// when nothing is published; an empty toID selects the draft. When either
+// side names a version, its locale is used for the draft.
+func DiffContentVersions(ctx context.Context, d db.DbDriver, contentID types.ContentID, fromID, toID types.ContentVersionID, locale string) (*VersionDiff, error) {
+	if toID == "" {
+		toID = DraftSide
+	}
+
+	versions := make(map[types.ContentVersionID]*Snapshot)
+	sides := make(map[types.ContentVersionID]DiffSide)
+	for _, id := range []types.ContentVersionID{fromID, toID} {
+		if id == "" || id == DraftSide {
+			continue
+		}
+		v, snap, err := loadVersionSnapshot(d, contentID, id)
+		if err != nil {
+			return nil, err
+		}
+		versions[id], sides[id] = snap, versionSide(v)
+		locale = v.Locale
+	}
+	if fromID == DraftSide || toID == DraftSide {
+		snap, err := BuildSnapshot(d, ctx, contentID, locale)
+		if err != nil {
+			return nil, fmt.Errorf("build draft snapshot: %w", err)
+		}
+		versions[DraftSide], sides[DraftSide] = snap, DiffSide{Draft: true, Locale: locale}
+	}
+	if fromID == "" {
+		sides[""] = DiffSide{Locale: locale}
+		if v, err := d.GetPublishedSnapshot(contentID, locale); err == nil && v != nil {
+			snap, sErr := decodeSnapshot(v)
+			if sErr != nil {
+				return nil, sErr
+			}
+			versions[""], sides[""] = snap, versionSide(v)
+		}
+	}
+
+	diff := DiffSnapshots(versions[fromID], versions[toID])
+	diff.ContentDataID = contentID.String()
+	diff.From = sides[fromID]
+	diff.To = sides[toID]
+	return diff, nil
+}
+
+// loadVersionSnapshot fetches a version, checks that it belongs to contentID,
+// and decodes its snapshot.
+func loadVersionSnapshot(d db.DbDriver, contentID types.ContentID, versionID types.ContentVersionID) (*db.ContentVersion, *Snapshot, error) {
+	v, err := d.GetContentVersion(versionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get content version %s: %w", versionID, err)
+	}
+	if v.ContentDataID != contentID {
+		return nil, nil, fmt.Errorf("content_version_id %s does not belong to content_data_id %s", versionID, contentID)
+	}
+	snap, err := decodeSnapshot(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, snap, nil
+}
+
+func decodeSnapshot(v *db.ContentVersion) (*Snapshot, error) {
+	var snap Snapshot
+	if err := json.Unmarshal([]byte(v.Snapshot), &snap); err != nil {
+		return nil, fmt.Errorf("deserialize snapshot %s: %w", v.ContentVersionID, err)
+	}
+	return &snap, nil
+}
+
+func versionSide(v *db.ContentVersion) DiffSide {
+	return DiffSide{
+		ContentVersionID: v.ContentVersionID.String(),
+		VersionNumber:    v.VersionNumber,
+		Label:            v.Label,
+		Published:        v.Published,
+		Locale:           v.Locale,
+	}
+}
+
+// DiffSnapshots compares two snapshots. A nil snapshot is an empty tree.
+// Nodes are reported in the tree order of to, followed by removed nodes in
+// the tree order of from; fields follow the node they belong to.
+func DiffSnapshots(from, to *Snapshot) *VersionDiff {
+	a := indexSnapshot(from)
+	b := indexSnapshot(to)
+	diff := &VersionDiff{Nodes: []NodeDiff{}, Fields: []FieldDiff{}}
+
+	moved := movedNodes(a, b)
+	order := append(append([]string{}, b.order...), a.order...)
+	seen := make(map[string]bool, len(order))
+	for _, id := range order {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		an, inA := a.nodes[id]
+		bn, inB := b.nodes[id]
+		switch {
+		case inB && !inA:
+			diff.Nodes = append(diff.Nodes, b.nodeDiff(id, ChangeAdded))
+		case inA && !inB:
+			diff.Nodes = append(diff.Nodes, a.nodeDiff(id, ChangeRemoved))
+		case moved[id]:
+			nd := b.nodeDiff(id, ChangeMoved)
+			if an.ParentID != bn.ParentID {
+				nd.OldParentID = an.ParentID
+				nd.NewParentID = bn.ParentID
+			}
+			diff.Nodes = append(diff.Nodes, nd)
+		}
+		diff.Fields = append(diff.Fields, diffNodeFields(a, b, id)...)
+	}
+	return diff
+}
+
+// snapshotIndex is a snapshot keyed for comparison.
+type snapshotIndex struct {
+	nodes     map[string]db.ContentDataJSON
+	datatypes map[string]db.DatatypeJSON
+	fields    map[string]db.FieldsJSON
+	values    map[string][]SnapshotContentFieldJSON
+	children  map[string][]string
+	order     []string
+}
+
+func indexSnapshot(s *Snapshot) *snapshotIndex {
+	idx := &snapshotIndex{
+		nodes:     make(map[string]db.ContentDataJSON),
+		datatypes: make(map[string]db.DatatypeJSON),
+		fields:    make(map[string]db.FieldsJSON),
+		values:    make(map[string][]SnapshotContentFieldJSON),
+		children:  make(map[string][]string),
+	}
+	if s == nil {
+		return idx
+	}
+	for _, cd := range s.ContentData {
+		idx.nodes[cd.ContentDataID] = cd
+	}
+	for _, dt := range s.Datatypes {
+		if dt.DatatypeID != "" {
+			idx.datatypes[dt.DatatypeID] = dt
+		}
+	}
+	for _, f := range s.Fields {
+		idx.fields[f.FieldID] = f
+	}
+	for _, cf := range s.ContentFields {
+		idx.values[cf.ContentDataID] = append(idx.values[cf.ContentDataID], cf)
+	}
+
+	// Sibling order follows the linked list from each parent's first child.
+	// Nodes the list does not reach keep their snapshot order.
+	for _, cd := range s.ContentData {
+		var kids []string
+		linked := make(map[string]bool)
+		for id := cd.FirstChildID; id != "" && !linked[id]; id = idx.nodes[id].NextSiblingID {
+			child, ok := idx.nodes[id]
+			if !ok || child.ParentID != cd.ContentDataID {
+				break
+			}
+			linked[id] = true
+			kids = append(kids, id)
+		}
+		idx.children[cd.ContentDataID] = kids
+	}
+	for _, cd := range s.ContentData {
+		if _, ok := idx.nodes[cd.ParentID]; ok && !slices.Contains(idx.children[cd.ParentID], cd.ContentDataID) {
+			idx.children[cd.ParentID] = append(idx.children[cd.ParentID], cd.ContentDataID)
+		}
+	}
+
+	// Depth-first tree order from the roots.
+	visited := make(map[string]bool)
+	var walk func(id string)
+	walk = func(id string) {
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+		idx.order = append(idx.order, id)
+		for _, c := range idx.children[id] {
+			walk(c)
+		}
+	}
+	for _, cd := range s.ContentData {
+		if _, ok := idx.nodes[cd.ParentID]; !ok {
+			walk(cd.ContentDataID)
+		}
+	}
+	for _, cd := range s.ContentData {
+		walk(cd.ContentDataID)
+	}
+	return idx
+}
+
+func (idx *snapshotIndex) nodeDiff(id, change string) NodeDiff {
+	cd := idx.nodes[id]
+	nd := NodeDiff{ContentDataID: id, Change: change, Title: idx.title(id)}
+	if dt, ok := idx.datatypes[cd.DatatypeID]; ok {
+		nd.Datatype = dt.Label
+	}
+	switch change {
+	case ChangeAdded:
+		nd.NewParentID = cd.ParentID
+	case ChangeRemoved:
+		nd.OldParentID = cd.ParentID
+	}
+	return nd
+}
+
+// title returns the node's title field value, if it has one.
+func (idx *snapshotIndex) title(id string) string {
+	for _, cf := range idx.values[id] {
+		if idx.fields[cf.FieldID].Type == "_title" {
+			return cf.FieldValue
+		}
+	}
+	return ""
+}
+
+// movedNodes returns the nodes present on both sides whose parent changed or
+// whose position among the siblings both sides share changed.
+func movedNodes(a, b *snapshotIndex) map[string]bool {
+	moved := make(map[string]bool)
+	for id, bn := range b.nodes {
+		if an, ok := a.nodes[id]; ok && an.ParentID != bn.ParentID {
+			moved[id] = true
+		}
+	}
+	for parent, bKids := range b.children {
+		if _, ok := a.nodes[parent]; !ok {
+			continue
+		}
+		var x, y []string
+		for _, id := range a.children[parent] {
+			if !moved[id] && b.nodes[id].ParentID == parent {
+				x = append(x, id)
+			}
+		}
+		for _, id := range bKids {
+			if !moved[id] && a.nodes[id].ParentID == parent {
+				y = append(y, id)
+			}
+		}
+		kept := make(map[string]bool)
+		for _, op := range lcsOps(x, y) {
+			if op.Op == WordEqual {
+				kept[op.Text] = true
+			}
+		}
+		for _, id := range y {
+			if !kept[id] {
+				moved[id] = true
+			}
+		}
+	}
+	return moved
+}
+
+// diffNodeFields compares the field values of one node on both sides.
+func diffNodeFields(a, b *snapshotIndex, id string) []FieldDiff {
+	type key struct{ field, locale string }
+	old := make(map[key]SnapshotContentFieldJSON)
+	for _, cf := range a.values[id] {
+		old[key{cf.FieldID, cf.Locale}] = cf
+	}
+
+	var out []FieldDiff
+	matched := make(map[key]bool)
+	for _, cf := range b.values[id] {
+		k := key{cf.FieldID, cf.Locale}
+		matched[k] = true
+		fd := fieldDiff(b, id, cf)
+		prev, ok := old[k]
+		switch {
+		case !ok:
+			if cf.FieldValue == "" {
+				continue
+			}
+			fd.Change = ChangeAdded
+			fd.NewValue = cf.FieldValue
+		case prev.FieldValue != cf.FieldValue:
+			fd.Change = ChangeModified
+			fd.OldValue = prev.FieldValue
+			fd.NewValue = cf.FieldValue
+			if wordDiffTypes[fd.Type] {
+				fd.Words = DiffWords(prev.FieldValue, cf.FieldValue)
+			}
+		default:
+			continue
+		}
+		out = append(out, fd)
+	}
+	for _, cf := range a.values[id] {
+		if matched[key{cf.FieldID, cf.Locale}] || cf.FieldValue == "" {
+			continue
+		}
+		fd := fieldDiff(a, id, cf)
+		fd.Change = ChangeRemoved
+		fd.OldValue = cf.FieldValue
+		out = append(out, fd)
+	}
+	return out
+}
+
+func fieldDiff(idx *snapshotIndex, id string, cf SnapshotContentFieldJSON) FieldDiff {
+	def := idx.fields[cf.FieldID]
+	label := def.Label
+	if label == "" {
+		label = def.Name
+	}
+	if label == "" {
+		label = cf.FieldID
+	}
+	return FieldDiff{
+		ContentDataID: id,
+		FieldID:       cf.FieldID,
+		Label:         label,
+		Type:          def.Type,
+		Locale:        cf.Locale,
+	}
+}
+
+// DiffWords returns a word-level diff of a and b. Words, whitespace runs, and
+// HTML tags are compared as single tokens, and adjacent tokens with the same
+// operation are merged.
+func DiffWords(a, b string) []WordDiff {
+	x, y := tokenizeWords(a), tokenizeWords(b)
+	if len(x)*len(y) > maxWordDiffCells {
+		var out []WordDiff
+		if a != "" {
+			out = append(out, WordDiff{Op: WordDelete, Text: a})
+		}
+		if b != "" {
+			out = append(out, WordDiff{Op: WordInsert, Text: b})
+		}
+		return out
+	}
+	var out []WordDiff
+	for _, op := range lcsOps(x, y) {
+		if n := len(out); n > 0 && out[n-1].Op == op.Op {
+			out[n-1].Text += op.Text
+			continue
+		}
+		out = append(out, op)
+	}
+	return out
+}
+
+// tokenizeWords splits s into HTML tags, whitespace runs, and words.
+func tokenizeWords(s string) []string {
+	var tokens []string
+	for len(s) > 0 {
+		n := 0
+		switch {
+		case s[0] == '<':
+			if end := strings.IndexByte(s, '>'); end > 0 {
+				n = end + 1
+			} else {
+				n = 1
+			}
+		default:
+			first, size := utf8.DecodeRuneInString(s)
+			space := unicode.IsSpace(first)
+			n = size
+			for n < len(s) {
+				r, size := utf8.DecodeRuneInString(s[n:])
+				if r == '<' || unicode.IsSpace(r) != space {
+					break
+				}
+				n += size
+			}
+		}
+		tokens = append(tokens, s[:n])
+		s = s[n:]
+	}
+	return tokens
+}
+
+// lcsOps diffs two token sequences by longest common subsequence. Deletions
+// are emitted before insertions at each point of difference.
+func lcsOps(x, y []string) []WordDiff {
+	n, m := len(x), len(y)
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+	var out []WordDiff
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case x[i] == y[j]:
+			out = append(out, WordDiff{Op: WordEqual, Text: x[i]})
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			out = append(out, WordDiff{Op: WordDelete, Text: x[i]})
+			i++
+		default:
+			out = append(out, WordDiff{Op: WordInsert, Text: y[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		out = append(out, WordDiff{Op: WordDelete, Text: x[i]})
+	}
+	for ; j < m; j++ {
+		out = append(out, WordDiff{Op: WordInsert, Text: y[j]})
+	}
+	return out
+}
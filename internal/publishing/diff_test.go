@@ -0,0 +1,307 @@
+package publishing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// ---------------------------------------------------------------------------
+// Word diff
+// ---------------------------------------------------------------------------
+
+func TestDiffWords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a, b string
+		want []WordDiff
+	}{
+		{
+			name: "identical",
+			a:    "hello world",
+			b:    "hello world",
+			want: []WordDiff{{Op: WordEqual, Text: "hello world"}},
+		},
+		{
+			name: "replaced word",
+			a:    "the quick fox",
+			b:    "the slow fox",
+			want: []WordDiff{
+				{Op: WordEqual, Text: "the "},
+				{Op: WordDelete, Text: "quick"},
+				{Op: WordInsert, Text: "slow"},
+				{Op: WordEqual, Text: " fox"},
+			},
+		},
+		{
+			name: "html tags are whole tokens",
+			a:    "<p>hello</p>",
+			b:    "<p><strong>hello</strong></p>",
+			want: []WordDiff{
+				{Op: WordEqual, Text: "<p>"},
+				{Op: WordInsert, Text: "<strong>"},
+				{Op: WordEqual, Text: "hello"},
+				{Op: WordInsert, Text: "</strong>"},
+				{Op: WordEqual, Text: "</p>"},
+			},
+		},
+		{
+			name: "multibyte whitespace",
+			a:    "a\u2002b",
+			b:    "a\u2003b",
+			want: []WordDiff{
+				{Op: WordEqual, Text: "a"},
+				{Op: WordDelete, Text: "\u2002"},
+				{Op: WordInsert, Text: "\u2003"},
+				{Op: WordEqual, Text: "b"},
+			},
+		},
+		{
+			name: "from empty",
+			a:    "",
+			b:    "new text",
+			want: []WordDiff{{Op: WordInsert, Text: "new text"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := DiffWords(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffWords(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Snapshot diff
+// ---------------------------------------------------------------------------
+
+// treeSnapshot builds a snapshot from parent -> ordered children lists and
+// a map of node -> title field value.
+func treeSnapshot(children map[string][]string, titles map[string]string) *Snapshot {
+	s := &Snapshot{
+		Fields: []db.FieldsJSON{{FieldID: "F_TITLE", Label: "Title", Type: "_title"}},
+	}
+	parents := map[string]string{}
+	for p, kids := range children {
+		for _, k := range kids {
+			parents[k] = p
+		}
+	}
+	nodes := []string{"ROOT"}
+	for i := 0; i < len(nodes); i++ {
+		nodes = append(nodes, children[nodes[i]]...)
+	}
+	for _, id := range nodes {
+		cd := db.ContentDataJSON{ContentDataID: id, ParentID: parents[id], DatatypeID: "D1"}
+		if kids := children[id]; len(kids) > 0 {
+			cd.FirstChildID = kids[0]
+		}
+		if p, ok := parents[id]; ok {
+			sibs := children[p]
+			for i, sib := range sibs {
+				if sib == id && i+1 < len(sibs) {
+					cd.NextSiblingID = sibs[i+1]
+				}
+			}
+		}
+		s.ContentData = append(s.ContentData, cd)
+		s.Datatypes = append(s.Datatypes, db.DatatypeJSON{DatatypeID: "D1", Label: "Page"})
+		if title, ok := titles[id]; ok {
+			s.ContentFields = append(s.ContentFields, SnapshotContentFieldJSON{ContentDataID: id, FieldID: "F_TITLE", FieldValue: title})
+		}
+	}
+	return s
+}
+
+func nodeChanges(d *VersionDiff) map[string]string {
+	out := make(map[string]string)
+	for _, n := range d.Nodes {
+		out[n.ContentDataID] = n.Change
+	}
+	return out
+}
+
+func TestDiffSnapshots_Structure(t *testing.T) {
+	t.Parallel()
+
+	from := treeSnapshot(map[string][]string{
+		"ROOT": {"A", "B", "C"},
+		"A":    {"A1"},
+	}, nil)
+	to := treeSnapshot(map[string][]string{
+		"ROOT": {"B", "A", "D"},
+		"B":    {"A1"},
+	}, nil)
+
+	got := nodeChanges(DiffSnapshots(from, to))
+	want := map[string]string{
+		"A1": ChangeMoved,
+		"D":  ChangeAdded,
+		"C":  ChangeRemoved,
+		// LCS of [A B] and [B A] keeps one of the two in place.
+		"A": ChangeMoved,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("node changes = %v, want %v", got, want)
+	}
+
+	diff := DiffSnapshots(from, to)
+	for _, n := range diff.Nodes {
+		if n.ContentDataID == "A1" && (n.OldParentID != "A" || n.NewParentID != "B") {
+			t.Errorf("A1 parents = %q -> %q, want A -> B", n.OldParentID, n.NewParentID)
+		}
+	}
+}
+
+func TestDiffSnapshots_Fields(t *testing.T) {
+	t.Parallel()
+
+	from := treeSnapshot(map[string][]string{"ROOT": {"A", "B"}}, map[string]string{
+		"ROOT": "Home page",
+		"A":    "Old",
+	})
+	to := treeSnapshot(map[string][]string{"ROOT": {"A", "B"}}, map[string]string{
+		"ROOT": "Home landing page",
+		"B":    "New",
+	})
+
+	diff := DiffSnapshots(from, to)
+	if len(diff.Nodes) != 0 {
+		t.Errorf("nodes = %v, want none", diff.Nodes)
+	}
+	changes := make(map[string]FieldDiff)
+	for _, f := range diff.Fields {
+		changes[f.ContentDataID] = f
+	}
+	if len(changes) != 3 {
+		t.Fatalf("fields = %v, want 3 changes", diff.Fields)
+	}
+	root := changes["ROOT"]
+	if root.Change != ChangeModified || root.Label != "Title" {
+		t.Errorf("ROOT field = %+v", root)
+	}
+	wantWords := []WordDiff{{Op: WordEqual, Text: "Home "}, {Op: WordInsert, Text: "landing "}, {Op: WordEqual, Text: "page"}}
+	if !reflect.DeepEqual(root.Words, wantWords) {
+		t.Errorf("ROOT words = %v, want %v", root.Words, wantWords)
+	}
+	if changes["A"].Change != ChangeRemoved || changes["A"].OldValue != "Old" {
+		t.Errorf("A field = %+v, want removed", changes["A"])
+	}
+	if changes["B"].Change != ChangeAdded || changes["B"].NewValue != "New" {
+		t.Errorf("B field = %+v, want added", changes["B"])
+	}
+}
+
+func TestDiffSnapshots_NilFrom(t *testing.T) {
+	t.Parallel()
+
+	to := treeSnapshot(map[string][]string{"ROOT": {"A"}}, nil)
+	got := nodeChanges(DiffSnapshots(nil, to))
+	want := map[string]string{"ROOT": ChangeAdded, "A": ChangeAdded}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("node changes = %v, want %v", got, want)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Version diff against the database
+// ---------------------------------------------------------------------------
+
+func TestDiffContentVersions(t *testing.T) {
+	t.Parallel()
+	d, seed := testSeededDB(t)
+	cd, cf := createContentWithField(t, d, seed)
+	ac := testAuditCtxWithUser(d, seed.User.UserID)
+
+	// Nothing published: the draft is diffed against an empty tree.
+	diff, err := DiffContentVersions(d.Context, d, cd.ContentDataID, "", "", "")
+	if err != nil {
+		t.Fatalf("DiffContentVersions before publish: %v", err)
+	}
+	if diff.From.ContentVersionID != "" || !diff.To.Draft {
+		t.Errorf("sides = %+v / %+v, want empty / draft", diff.From, diff.To)
+	}
+	if len(diff.Nodes) != 1 || diff.Nodes[0].Change != ChangeAdded {
+		t.Errorf("nodes = %v, want the root added", diff.Nodes)
+	}
+
+	v1, err := PublishContent(d.Context, d, cd.ContentDataID, "", seed.User.UserID, ac, 0, false, nil, nil)
+	if err != nil {
+		t.Fatalf("PublishContent: %v", err)
+	}
+	_, err = d.UpdateContentField(d.Context, ac, db.UpdateContentFieldParams{
+		RouteID:        cf.RouteID,
+		ContentDataID:  cf.ContentDataID,
+		FieldID:        cf.FieldID,
+		FieldValue:     "Hello Brave World",
+		AuthorID:       seed.User.UserID,
+		DateCreated:    cf.DateCreated,
+		DateModified:   types.TimestampNow(),
+		ContentFieldID: cf.ContentFieldID,
+	})
+	if err != nil {
+		t.Fatalf("UpdateContentField: %v", err)
+	}
+
+	// Default sides: published version against the draft.
+	diff, err = DiffContentVersions(d.Context, d, cd.ContentDataID, "", DraftSide, "")
+	if err != nil {
+		t.Fatalf("DiffContentVersions: %v", err)
+	}
+	if diff.From.ContentVersionID != v1.ContentVersionID.String() || !diff.From.Published {
+		t.Errorf("from = %+v, want published v1", diff.From)
+	}
+	if len(diff.Nodes) != 0 {
+		t.Errorf("nodes = %v, want none", diff.Nodes)
+	}
+	if len(diff.Fields) != 1 {
+		t.Fatalf("fields = %v, want 1", diff.Fields)
+	}
+	if f := diff.Fields[0]; f.Change != ChangeModified || f.OldValue != "Hello World" || f.NewValue != "Hello Brave World" {
+		t.Errorf("field = %+v", f)
+	}
+
+	// Restore preview: the draft against the version it would restore.
+	diff, err = DiffContentVersions(d.Context, d, cd.ContentDataID, DraftSide, v1.ContentVersionID, "")
+	if err != nil {
+		t.Fatalf("DiffContentVersions draft..v1: %v", err)
+	}
+	if !diff.From.Draft || len(diff.Fields) != 1 || diff.Fields[0].NewValue != "Hello World" {
+		t.Errorf("draft..v1 = %+v", diff)
+	}
+
+	// Two versions compare without touching the draft.
+	v2, err := PublishContent(d.Context, d, cd.ContentDataID, "", seed.User.UserID, ac, 0, false, nil, nil)
+	if err != nil {
+		t.Fatalf("PublishContent v2: %v", err)
+	}
+	diff, err = DiffContentVersions(d.Context, d, cd.ContentDataID, v1.ContentVersionID, v2.ContentVersionID, "")
+	if err != nil {
+		t.Fatalf("DiffContentVersions v1..v2: %v", err)
+	}
+	if diff.To.VersionNumber != 2 || len(diff.Fields) != 1 {
+		t.Errorf("v1..v2 = %+v", diff)
+	}
+}
+
+func TestDiffContentVersions_VersionMismatch(t *testing.T) {
+	t.Parallel()
+	d, seed := testSeededDB(t)
+	cd, _ := createContentWithField(t, d, seed)
+	other, _ := createContentWithField(t, d, seed)
+	ac := testAuditCtxWithUser(d, seed.User.UserID)
+
+	v, err := PublishContent(d.Context, d, other.ContentDataID, "", seed.User.UserID, ac, 0, false, nil, nil)
+	if err != nil {
+		t.Fatalf("PublishContent: %v", err)
+	}
+	if _, err := DiffContentVersions(d.Context, d, cd.ContentDataID, v.ContentVersionID, "", ""); err == nil {
+		t.Error("expected error for a version of another content item")
+	}
+}
@@ -199,7 +199,10 @@ func BuildSnapshot(d db.DbDriver, ctx context.Context, rootID types.ContentID, l
 // locale specifies which locale to snapshot ("" for all fields / i18n disabled).
 // When publishAll is true, all descendants are also marked as published.
 // When false, only the root node's status is updated (node-level publish).
-// Async pruning runs after return.
+// A locale publish fails with a *TranslationReviewError while any of its
+// snapshot fields is flagged for translation review, and on success records
+// the locale's values in the translation memory.
+// Async pruning and translation memory updates run after return.
 func PublishContent(ctx context.Context, d db.DbDriver, rootID types.ContentID, locale string, userID types.UserID, ac audited.AuditContext, retentionCap int, publishAll bool, dispatcher WebhookDispatcher, indexer SearchIndexer) (*db.ContentVersion, error) {
 	// 1. Read root's current revision for TOCTOU guard.
 	root, err := d.GetContentData(rootID)
@@ -214,6 +217,13 @@ func PublishContent(ctx context.Context, d db.DbDriver, rootID types.ContentID,
 		return nil, fmt.Errorf("build snapshot: %w", err)
 	}
 
+	// 2b. Pre-filled translations must be reviewed before their locale is published.
+	if locale != "" {
+		if reviewErr := checkTranslationReviews(d, snapshot, locale); reviewErr != nil {
+			return nil, reviewErr
+		}
+	}
+
 	// 3. TOCTOU guard: verify revision hasn't changed during snapshot build.
 	rootAfter, err := d.GetContentData(rootID)
 	if err != nil {
@@ -307,6 +317,11 @@ func PublishContent(ctx context.Context, d db.DbDriver, rootID types.ContentID,
 	// 9. Async: prune old versions if retention cap exceeded.
 	go PruneExcessVersions(d, rootID, locale, retentionCap)
 
+	// 9b. Async: remember the published translations against the default locale.
+	if locale != "" {
+		go recordTranslationMemory(d, rootID, locale, snapshot)
+	}
+
 	// 10. Dispatch webhook events.
 	if dispatcher != nil {
 		dispatcher.Dispatch(ctx, "content.published", map[string]any{
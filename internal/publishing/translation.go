@@ -0,0 +1,132 @@
+package publishing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/translation"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// TranslationReviewError is returned by PublishContent when fields in the
+// snapshot were pre-filled by the translation memory or a machine-translation
+// provider and nobody has reviewed them yet.
+type TranslationReviewError struct {
+	Locale  string
+	Pending int
+}
+
+func (e *TranslationReviewError) Error() string {
+	return fmt.Sprintf("%d pre-filled field(s) in locale %q need review before publishing", e.Pending, e.Locale)
+}
+
+// IsTranslationReviewPending reports whether err is a *TranslationReviewError.
+func IsTranslationReviewPending(err error) bool {
+	var reviewErr *TranslationReviewError
+	return errors.As(err, &reviewErr)
+}
+
+// checkTranslationReviews fails with a *TranslationReviewError when any of the
+// snapshot's locale fields is still flagged for review.
+func checkTranslationReviews(d db.DbDriver, snapshot *Snapshot, locale string) error {
+	reviews, err := d.ListTranslationReviewsByLocale(locale)
+	if err != nil {
+		return fmt.Errorf("list translation reviews: %w", err)
+	}
+	if reviews == nil || len(*reviews) == 0 {
+		return nil
+	}
+	flagged := make(map[string]bool, len(*reviews))
+	for _, r := range *reviews {
+		flagged[r.ContentFieldID.String()] = true
+	}
+	pending := 0
+	for _, f := range snapshot.ContentFields {
+		if f.Locale == locale && flagged[f.ContentFieldID] {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return &TranslationReviewError{Locale: locale, Pending: pending}
+	}
+	return nil
+}
+
+// recordTranslationMemory pairs the fields of a just-published locale snapshot
+// with the published default-locale snapshot of the same root and stores each
+// pair in the translation memory. Values left identical to the source, and
+// empty values, are skipped. Failures are logged; they never fail a publish.
+func recordTranslationMemory(d db.DbDriver, rootID types.ContentID, locale string, snapshot *Snapshot) {
+	def, err := d.GetDefaultLocale()
+	if err != nil || def == nil || def.Code == locale {
+		return
+	}
+	sourceVersion, err := d.GetPublishedSnapshot(rootID, def.Code)
+	if err != nil || sourceVersion == nil {
+		// The default locale has not been published; nothing to pair with.
+		return
+	}
+	var source Snapshot
+	if err := json.Unmarshal([]byte(sourceVersion.Snapshot), &source); err != nil {
+		utility.DefaultLogger.Error(fmt.Sprintf("translation memory: decode %s snapshot for %s", def.Code, rootID), err)
+		return
+	}
+	sourceValues := make(map[string]string, len(source.ContentFields))
+	for _, f := range source.ContentFields {
+		if f.Locale == def.Code {
+			sourceValues[f.ContentDataID+"/"+f.FieldID] = f.FieldValue
+		}
+	}
+
+	now := time.Now().Unix()
+	for _, f := range snapshot.ContentFields {
+		if f.Locale != locale {
+			continue
+		}
+		src, ok := sourceValues[f.ContentDataID+"/"+f.FieldID]
+		if !ok || translation.Normalize(src) == "" || strings.TrimSpace(f.FieldValue) == "" {
+			continue
+		}
+		if translation.Normalize(src) == translation.Normalize(f.FieldValue) {
+			continue
+		}
+		if err := upsertTranslationMemory(d, def.Code, locale, src, f, now); err != nil {
+			utility.DefaultLogger.Error(fmt.Sprintf("translation memory: record field %s", f.ContentFieldID), err)
+		}
+	}
+}
+
+func upsertTranslationMemory(d db.DbDriver, sourceLocale, targetLocale, source string, f SnapshotContentFieldJSON, now int64) error {
+	hash := translation.SourceHash(source)
+	existing, err := d.GetTranslationMemoryBySource(sourceLocale, targetLocale, hash)
+	if err == nil && existing != nil {
+		if existing.TargetText == f.FieldValue {
+			return nil
+		}
+		return d.UpdateTranslationMemory(db.UpdateTranslationMemoryParams{
+			TmID:          existing.TmID,
+			TargetText:    f.FieldValue,
+			ContentDataID: types.ContentID(f.ContentDataID),
+			FieldID:       types.FieldID(f.FieldID),
+			UpdatedAt:     now,
+		})
+	}
+	_, err = d.CreateTranslationMemory(db.CreateTranslationMemoryParams{
+		TmID:          types.NewULID().String(),
+		SourceLocale:  sourceLocale,
+		TargetLocale:  targetLocale,
+		SourceHash:    hash,
+		SourceText:    translation.Normalize(source),
+		TargetText:    f.FieldValue,
+		SourceLength:  int64(translation.RuneLength(source)),
+		ContentDataID: types.ContentID(f.ContentDataID),
+		FieldID:       types.FieldID(f.FieldID),
+		UpdatedAt:     now,
+	})
+	return err
+}
@@ -0,0 +1,255 @@
+package publishing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/translation"
+)
+
+// Translation statuses reported by TranslationStatus.
+const (
+	// TranslationMissing means the locale has no value for the field.
+	TranslationMissing = "missing"
+	// TranslationStale means the default-locale value changed after the
+	// translation was written.
+	TranslationStale = "stale"
+	// TranslationCurrent means the translation was written from the current
+	// default-locale value.
+	TranslationCurrent = "current"
+	// TranslationUntracked means the field is translated but its source was
+	// never recorded, e.g. it predates source tracking.
+	TranslationUntracked = "untracked"
+)
+
+// FieldTranslationStatus is the status of one translatable field of a content
+// node in a locale. ContentFieldID is empty for missing fields; TranslatedAt
+// is when the translation's source was recorded, in Unix seconds.
+type FieldTranslationStatus struct {
+	ContentDataID  types.ContentID       `json:"content_data_id"`
+	RouteID        types.NullableRouteID `json:"route_id"`
+	FieldID        types.FieldID         `json:"field_id"`
+	Label          string                `json:"label"`
+	Required       bool                  `json:"required"`
+	ContentFieldID types.ContentFieldID  `json:"content_field_id,omitempty"`
+	Status         string                `json:"status"`
+	TranslatedAt   int64                 `json:"translated_at,omitempty"`
+}
+
+// TranslationsOutdatedError is returned by CheckTranslationsCurrent when
+// required fields of the tree are missing or stale in the locale.
+type TranslationsOutdatedError struct {
+	Locale  string
+	Missing int
+	Stale   int
+}
+
+func (e *TranslationsOutdatedError) Error() string {
+	return fmt.Sprintf("locale %q has %d missing and %d stale required field(s)", e.Locale, e.Missing, e.Stale)
+}
+
+// IsTranslationsOutdated reports whether err is a *TranslationsOutdatedError.
+func IsTranslationsOutdated(err error) bool {
+	var outdated *TranslationsOutdatedError
+	return errors.As(err, &outdated)
+}
+
+// SourceValues maps each field of rows to the value translations copy from:
+// the defaultLocale row, or the unlocalized ("") row when the default locale
+// has none.
+func SourceValues(rows []db.ContentFields, defaultLocale string) map[types.FieldID]string {
+	out := make(map[types.FieldID]string)
+	fromDefault := make(map[types.FieldID]bool)
+	for _, row := range rows {
+		if !row.FieldID.Valid {
+			continue
+		}
+		switch row.Locale {
+		case defaultLocale:
+			out[row.FieldID.ID] = row.FieldValue
+			fromDefault[row.FieldID.ID] = true
+		case "":
+			if !fromDefault[row.FieldID.ID] {
+				out[row.FieldID.ID] = row.FieldValue
+			}
+		}
+	}
+	return out
+}
+
+// RecordTranslationSource records the current source value of cf's field as
+// the basis of the translation in cf. Rows in the default locale, or without
+// a locale, are not translations and are ignored.
+func RecordTranslationSource(d db.DbDriver, cf db.ContentFields, defaultLocale string) error {
+	if cf.Locale == "" || cf.Locale == defaultLocale || !cf.ContentDataID.Valid || !cf.FieldID.Valid {
+		return nil
+	}
+	rows, err := d.ListContentFieldsByContentDataAndLocale(cf.ContentDataID, defaultLocale)
+	if err != nil {
+		return fmt.Errorf("list source fields: %w", err)
+	}
+	var sources map[types.FieldID]string
+	if rows != nil {
+		sources = SourceValues(*rows, defaultLocale)
+	}
+	return db.SetTranslationSource(d, db.CreateTranslationSourceParams{
+		ContentFieldID: cf.ContentFieldID,
+		ContentDataID:  cf.ContentDataID.ID,
+		FieldID:        cf.FieldID.ID,
+		Locale:         cf.Locale,
+		SourceHash:     translation.SourceHash(sources[cf.FieldID.ID]),
+		RecordedAt:     time.Now().Unix(),
+	})
+}
+
+// TranslationStatus reports the status of every translatable field of nodes
+// in locale. A translation is stale when the hash of the source it was
+// recorded against differs from the hash of the current source value. A blank
+// translation of a non-blank source counts as missing.
+func TranslationStatus(d db.DbDriver, nodes []db.ContentData, locale, defaultLocale string) ([]FieldTranslationStatus, error) {
+	bases, err := d.ListTranslationSourcesByLocale(locale)
+	if err != nil {
+		return nil, fmt.Errorf("list translation sources: %w", err)
+	}
+	basisOf := make(map[types.ContentFieldID]db.TranslationSource)
+	if bases != nil {
+		for _, b := range *bases {
+			basisOf[b.ContentFieldID] = b
+		}
+	}
+
+	defsByType := make(map[types.DatatypeID][]db.Fields)
+	required := make(map[types.ValidationID]bool)
+	out := []FieldTranslationStatus{}
+	for _, node := range nodes {
+		if !node.DatatypeID.Valid {
+			continue
+		}
+		defs, ok := defsByType[node.DatatypeID.ID]
+		if !ok {
+			fields, err := d.ListFieldsByDatatypeID(node.DatatypeID)
+			if err != nil {
+				return nil, fmt.Errorf("list fields of datatype %s: %w", node.DatatypeID.ID, err)
+			}
+			if fields != nil {
+				defs = *fields
+			}
+			defsByType[node.DatatypeID.ID] = defs
+		}
+
+		rows, err := d.ListContentFieldsByContentData(types.NullableContentID{ID: node.ContentDataID, Valid: true})
+		if err != nil {
+			return nil, fmt.Errorf("list content fields of %s: %w", node.ContentDataID, err)
+		}
+		var sources map[types.FieldID]string
+		translated := make(map[types.FieldID]db.ContentFields)
+		if rows != nil {
+			sources = SourceValues(*rows, defaultLocale)
+			for _, row := range *rows {
+				if row.Locale == locale && row.FieldID.Valid {
+					translated[row.FieldID.ID] = row
+				}
+			}
+		}
+
+		for _, def := range defs {
+			if !def.Translatable {
+				continue
+			}
+			status := FieldTranslationStatus{
+				ContentDataID: node.ContentDataID,
+				RouteID:       node.RouteID,
+				FieldID:       def.FieldID,
+				Label:         def.Label,
+				Required:      fieldRequired(d, def, required),
+			}
+			source := sources[def.FieldID]
+			row, ok := translated[def.FieldID]
+			if !ok || (strings.TrimSpace(row.FieldValue) == "" && translation.Normalize(source) != "") {
+				status.Status = TranslationMissing
+				out = append(out, status)
+				continue
+			}
+			status.ContentFieldID = row.ContentFieldID
+			basis, tracked := basisOf[row.ContentFieldID]
+			switch {
+			case !tracked:
+				status.Status = TranslationUntracked
+			case basis.SourceHash != translation.SourceHash(source):
+				status.Status = TranslationStale
+				status.TranslatedAt = basis.RecordedAt
+			default:
+				status.Status = TranslationCurrent
+				status.TranslatedAt = basis.RecordedAt
+			}
+			out = append(out, status)
+		}
+	}
+	return out, nil
+}
+
+// CheckTranslationsCurrent fails with a *TranslationsOutdatedError when a
+// required translatable field of the tree under rootID is missing or stale
+// in locale. Untracked translations pass. The default locale, and publishes
+// without a locale, are never blocked.
+func CheckTranslationsCurrent(ctx context.Context, d db.DbDriver, rootID types.ContentID, locale, defaultLocale string) error {
+	if locale == "" || locale == defaultLocale {
+		return nil
+	}
+	nodes, err := d.GetContentDataDescendants(ctx, rootID)
+	if err != nil {
+		return fmt.Errorf("fetch descendants for %s: %w", rootID, err)
+	}
+	if nodes == nil {
+		return nil
+	}
+	statuses, err := TranslationStatus(d, *nodes, locale, defaultLocale)
+	if err != nil {
+		return err
+	}
+	outdated := &TranslationsOutdatedError{Locale: locale}
+	for _, s := range statuses {
+		if !s.Required {
+			continue
+		}
+		switch s.Status {
+		case TranslationMissing:
+			outdated.Missing++
+		case TranslationStale:
+			outdated.Stale++
+		}
+	}
+	if outdated.Missing > 0 || outdated.Stale > 0 {
+		return outdated
+	}
+	return nil
+}
+
+// fieldRequired reports whether def's validation config has a top-level
+// required rule. Results are cached per validation in cache.
+func fieldRequired(d db.DbDriver, def db.Fields, cache map[types.ValidationID]bool) bool {
+	if !def.ValidationID.Valid || def.ValidationID.ID.IsZero() {
+		return false
+	}
+	if required, ok := cache[def.ValidationID.ID]; ok {
+		return required
+	}
+	required := false
+	if v, err := d.GetValidation(def.ValidationID.ID); err == nil && v != nil {
+		if cfg, err := types.ParseValidationConfig(v.Config); err == nil {
+			for _, entry := range cfg.Rules {
+				if entry.Rule != nil && entry.Rule.Op == types.RuleRequired {
+					required = true
+					break
+				}
+			}
+		}
+	}
+	cache[def.ValidationID.ID] = required
+	return required
+}
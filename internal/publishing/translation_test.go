@@ -0,0 +1,110 @@
+package publishing
+
+import (
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/translation"
+)
+
+// createLocaleField adds a field row in locale to cd.
+func createLocaleField(t *testing.T, d db.Database, seed seedData, cd *db.ContentData, locale, value string) *db.ContentFields {
+	t.Helper()
+	now := types.TimestampNow()
+	cf, err := d.CreateContentField(d.Context, testAuditCtxWithUser(d, seed.User.UserID), db.CreateContentFieldParams{
+		RouteID:       types.NullableRouteID{ID: seed.Route.RouteID, Valid: true},
+		RootID:        types.NullableContentID{ID: cd.ContentDataID, Valid: true},
+		ContentDataID: types.NullableContentID{ID: cd.ContentDataID, Valid: true},
+		FieldID:       types.NullableFieldID{ID: seed.Field.FieldID, Valid: true},
+		FieldValue:    value,
+		Locale:        locale,
+		AuthorID:      seed.User.UserID,
+		DateCreated:   now,
+		DateModified:  now,
+	})
+	if err != nil {
+		t.Fatalf("CreateContentField(%s): %v", locale, err)
+	}
+	return cf
+}
+
+func TestPublishContent_TranslationReviewGate(t *testing.T) {
+	t.Parallel()
+	d, seed := testSeededDB(t)
+	ac := testAuditCtxWithUser(d, seed.User.UserID)
+	cd, _ := createContentWithField(t, d, seed)
+	fr := createLocaleField(t, d, seed, cd, "fr", "Bonjour le monde")
+
+	if _, err := d.CreateTranslationReview(db.CreateTranslationReviewParams{
+		ContentFieldID: fr.ContentFieldID,
+		ContentDataID:  cd.ContentDataID,
+		Locale:         "fr",
+		Origin:         "machine",
+		Provider:       "deepl",
+		CreatedAt:      1,
+	}); err != nil {
+		t.Fatalf("CreateTranslationReview: %v", err)
+	}
+
+	_, err := PublishContent(d.Context, d, cd.ContentDataID, "fr", seed.User.UserID, ac, 0, false, nil, nil)
+	if !IsTranslationReviewPending(err) {
+		t.Fatalf("PublishContent with a flagged field: err = %v, want a translation review error", err)
+	}
+	if reviewErr := err.(*TranslationReviewError); reviewErr.Pending != 1 || reviewErr.Locale != "fr" {
+		t.Errorf("review error = %+v", reviewErr)
+	}
+
+	// Other locales are not blocked.
+	if _, err := PublishContent(d.Context, d, cd.ContentDataID, "de", seed.User.UserID, ac, 0, false, nil, nil); err != nil {
+		t.Errorf("PublishContent(de): %v", err)
+	}
+
+	if err := d.DeleteTranslationReview(fr.ContentFieldID); err != nil {
+		t.Fatalf("DeleteTranslationReview: %v", err)
+	}
+	if _, err := PublishContent(d.Context, d, cd.ContentDataID, "fr", seed.User.UserID, ac, 0, false, nil, nil); err != nil {
+		t.Errorf("PublishContent after review: %v", err)
+	}
+}
+
+func TestRecordTranslationMemory(t *testing.T) {
+	t.Parallel()
+	d, seed := testSeededDB(t)
+	ac := testAuditCtxWithUser(d, seed.User.UserID)
+	if _, err := d.CreateLocale(d.Context, ac, db.CreateLocaleParams{Code: "en", Label: "English", IsDefault: true, IsEnabled: true, DateCreated: types.TimestampNow()}); err != nil {
+		t.Fatalf("CreateLocale: %v", err)
+	}
+	cd, _ := createContentWithField(t, d, seed)
+	createLocaleField(t, d, seed, cd, "en", "Hello   world")
+	createLocaleField(t, d, seed, cd, "fr", "Bonjour le monde")
+
+	if _, err := PublishContent(d.Context, d, cd.ContentDataID, "en", seed.User.UserID, ac, 0, false, nil, nil); err != nil {
+		t.Fatalf("PublishContent(en): %v", err)
+	}
+	frSnapshot, err := BuildSnapshot(d, d.Context, cd.ContentDataID, "fr")
+	if err != nil {
+		t.Fatalf("BuildSnapshot(fr): %v", err)
+	}
+	if _, err := PublishContent(d.Context, d, cd.ContentDataID, "fr", seed.User.UserID, ac, 0, false, nil, nil); err != nil {
+		t.Fatalf("PublishContent(fr): %v", err)
+	}
+	// PublishContent records asynchronously; record again synchronously so
+	// the assertions do not race it. Recording is idempotent.
+	recordTranslationMemory(d, cd.ContentDataID, "fr", frSnapshot)
+
+	entry, err := d.GetTranslationMemoryBySource("en", "fr", translation.SourceHash("Hello world"))
+	if err != nil {
+		t.Fatalf("GetTranslationMemoryBySource: %v", err)
+	}
+	if entry.TargetText != "Bonjour le monde" || entry.SourceText != "Hello world" || entry.SourceLength != 11 {
+		t.Errorf("entry = %+v", entry)
+	}
+	count, err := d.CountTranslationMemory()
+	if err != nil {
+		t.Fatalf("CountTranslationMemory: %v", err)
+	}
+	if *count != 1 {
+		t.Errorf("count = %d, want 1 (non-translatable and default-locale fields are not remembered)", *count)
+	}
+}
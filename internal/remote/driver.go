@@ -1327,6 +1327,318 @@ func (r *RemoteDriver) UpdateAdminRoute(ctx context.Context, _ audited.AuditCont
 	})
 }
 
+// ---------------------------------------------------------------------------
+// User Lockouts and Password History
+// ---------------------------------------------------------------------------
+
+func (r *RemoteDriver) CountUserLockouts() (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountUserLockouts"}
+}
+
+func (r *RemoteDriver) CreateUserLockoutTable() error {
+	return ErrNotSupported{Method: "CreateUserLockoutTable"}
+}
+
+func (r *RemoteDriver) DeleteUserLockout(_ types.UserID) error {
+	return ErrNotSupported{Method: "DeleteUserLockout"}
+}
+
+func (r *RemoteDriver) GetUserLockout(_ types.UserID) (*db.UserLockout, error) {
+	return nil, ErrNotSupported{Method: "GetUserLockout"}
+}
+
+func (r *RemoteDriver) ListUserLockouts() (*[]db.UserLockout, error) {
+	return nil, ErrNotSupported{Method: "ListUserLockouts"}
+}
+
+func (r *RemoteDriver) IncrementUserLockout(_ db.IncrementUserLockoutParams) error {
+	return ErrNotSupported{Method: "IncrementUserLockout"}
+}
+
+func (r *RemoteDriver) LockUserLockout(_ db.LockUserLockoutParams) (bool, error) {
+	return false, ErrNotSupported{Method: "LockUserLockout"}
+}
+
+func (r *RemoteDriver) UpsertUserLockout(_ db.UpsertUserLockoutParams) error {
+	return ErrNotSupported{Method: "UpsertUserLockout"}
+}
+
+func (r *RemoteDriver) CountPasswordHistory() (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountPasswordHistory"}
+}
+
+func (r *RemoteDriver) CreatePasswordHistory(_ db.CreatePasswordHistoryParams) error {
+	return ErrNotSupported{Method: "CreatePasswordHistory"}
+}
+
+func (r *RemoteDriver) CreatePasswordHistoryTable() error {
+	return ErrNotSupported{Method: "CreatePasswordHistoryTable"}
+}
+
+func (r *RemoteDriver) DeletePasswordHistory(_ types.PasswordHistoryID) error {
+	return ErrNotSupported{Method: "DeletePasswordHistory"}
+}
+
+func (r *RemoteDriver) ListPasswordHistoryByUserID(_ types.UserID) (*[]db.PasswordHistory, error) {
+	return nil, ErrNotSupported{Method: "ListPasswordHistoryByUserID"}
+}
+
+// ---------------------------------------------------------------------------
+// Rate Limit Counters
+// ---------------------------------------------------------------------------
+
+func (r *RemoteDriver) CountRateLimitCounters() (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountRateLimitCounters"}
+}
+
+func (r *RemoteDriver) CreateRateLimitCounterTable() error {
+	return ErrNotSupported{Method: "CreateRateLimitCounterTable"}
+}
+
+func (r *RemoteDriver) DeleteExpiredRateLimitCounters(_ int64) error {
+	return ErrNotSupported{Method: "DeleteExpiredRateLimitCounters"}
+}
+
+func (r *RemoteDriver) IncrementRateLimitCounter(_ db.IncrementRateLimitCounterParams) (*db.RateLimitCounter, error) {
+	return nil, ErrNotSupported{Method: "IncrementRateLimitCounter"}
+}
+
+// ---------------------------------------------------------------------------
+// Content Migrations
+// ---------------------------------------------------------------------------
+
+func (r *RemoteDriver) CountContentMigrations() (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountContentMigrations"}
+}
+
+func (r *RemoteDriver) CreateContentMigrationTable() error {
+	return ErrNotSupported{Method: "CreateContentMigrationTable"}
+}
+
+func (r *RemoteDriver) GetContentMigration(_ string) (*db.ContentMigration, error) {
+	return nil, ErrNotSupported{Method: "GetContentMigration"}
+}
+
+func (r *RemoteDriver) ListContentMigrations() (*[]db.ContentMigration, error) {
+	return nil, ErrNotSupported{Method: "ListContentMigrations"}
+}
+
+func (r *RemoteDriver) RecordContentMigration(_ db.RecordContentMigrationParams) error {
+	return ErrNotSupported{Method: "RecordContentMigration"}
+}
+
+// ---------------------------------------------------------------------------
+// Content Leases
+// ---------------------------------------------------------------------------
+
+func (r *RemoteDriver) CountContentLeases() (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountContentLeases"}
+}
+
+func (r *RemoteDriver) CreateContentLease(_ db.CreateContentLeaseParams) (*db.ContentLease, error) {
+	return nil, ErrNotSupported{Method: "CreateContentLease"}
+}
+
+func (r *RemoteDriver) CreateContentLeaseTable() error {
+	return ErrNotSupported{Method: "CreateContentLeaseTable"}
+}
+
+func (r *RemoteDriver) DeleteContentLease(_ string) error {
+	return ErrNotSupported{Method: "DeleteContentLease"}
+}
+
+func (r *RemoteDriver) DeleteExpiredContentLeases(_ int64) error {
+	return ErrNotSupported{Method: "DeleteExpiredContentLeases"}
+}
+
+func (r *RemoteDriver) GetContentLease(_ string) (*db.ContentLease, error) {
+	return nil, ErrNotSupported{Method: "GetContentLease"}
+}
+
+func (r *RemoteDriver) ListContentLeasesByContent(_ types.ContentID, _ int64) (*[]db.ContentLease, error) {
+	return nil, ErrNotSupported{Method: "ListContentLeasesByContent"}
+}
+
+func (r *RemoteDriver) UpdateContentLease(_ db.UpdateContentLeaseParams) error {
+	return ErrNotSupported{Method: "UpdateContentLease"}
+}
+
+// ---------------------------------------------------------------------------
+// Translation Memory
+// ---------------------------------------------------------------------------
+
+func (r *RemoteDriver) CountTranslationMemory() (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountTranslationMemory"}
+}
+
+func (r *RemoteDriver) CountTranslationMemoryByPair(_, _ string) (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountTranslationMemoryByPair"}
+}
+
+func (r *RemoteDriver) CreateTranslationMemory(_ db.CreateTranslationMemoryParams) (*db.TranslationMemoryEntry, error) {
+	return nil, ErrNotSupported{Method: "CreateTranslationMemory"}
+}
+
+func (r *RemoteDriver) CreateTranslationMemoryTable() error {
+	return ErrNotSupported{Method: "CreateTranslationMemoryTable"}
+}
+
+func (r *RemoteDriver) DeleteTranslationMemory(_ string) error {
+	return ErrNotSupported{Method: "DeleteTranslationMemory"}
+}
+
+func (r *RemoteDriver) GetTranslationMemory(_ string) (*db.TranslationMemoryEntry, error) {
+	return nil, ErrNotSupported{Method: "GetTranslationMemory"}
+}
+
+func (r *RemoteDriver) GetTranslationMemoryBySource(_, _, _ string) (*db.TranslationMemoryEntry, error) {
+	return nil, ErrNotSupported{Method: "GetTranslationMemoryBySource"}
+}
+
+func (r *RemoteDriver) ListTranslationMemoryByPair(_ db.ListTranslationMemoryByPairParams) (*[]db.TranslationMemoryEntry, error) {
+	return nil, ErrNotSupported{Method: "ListTranslationMemoryByPair"}
+}
+
+func (r *RemoteDriver) ListTranslationMemoryCandidates(_ db.ListTranslationMemoryCandidatesParams) (*[]db.TranslationMemoryEntry, error) {
+	return nil, ErrNotSupported{Method: "ListTranslationMemoryCandidates"}
+}
+
+func (r *RemoteDriver) UpdateTranslationMemory(_ db.UpdateTranslationMemoryParams) error {
+	return ErrNotSupported{Method: "UpdateTranslationMemory"}
+}
+
+// ---------------------------------------------------------------------------
+// Translation Reviews
+// ---------------------------------------------------------------------------
+
+func (r *RemoteDriver) CountTranslationReviews() (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountTranslationReviews"}
+}
+
+func (r *RemoteDriver) CreateTranslationReview(_ db.CreateTranslationReviewParams) (*db.TranslationReview, error) {
+	return nil, ErrNotSupported{Method: "CreateTranslationReview"}
+}
+
+func (r *RemoteDriver) CreateTranslationReviewTable() error {
+	return ErrNotSupported{Method: "CreateTranslationReviewTable"}
+}
+
+func (r *RemoteDriver) DeleteTranslationReview(_ types.ContentFieldID) error {
+	return ErrNotSupported{Method: "DeleteTranslationReview"}
+}
+
+func (r *RemoteDriver) GetTranslationReview(_ types.ContentFieldID) (*db.TranslationReview, error) {
+	return nil, ErrNotSupported{Method: "GetTranslationReview"}
+}
+
+func (r *RemoteDriver) ListTranslationReviewsByLocale(_ string) (*[]db.TranslationReview, error) {
+	return nil, ErrNotSupported{Method: "ListTranslationReviewsByLocale"}
+}
+
+func (r *RemoteDriver) ListTranslationReviewsByRoute(_ types.NullableRouteID, _ string) (*[]db.TranslationReview, error) {
+	return nil, ErrNotSupported{Method: "ListTranslationReviewsByRoute"}
+}
+
+// ---------------------------------------------------------------------------
+// Translation Sources
+// ---------------------------------------------------------------------------
+
+func (r *RemoteDriver) CountTranslationSources() (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountTranslationSources"}
+}
+
+func (r *RemoteDriver) CreateTranslationSource(_ db.CreateTranslationSourceParams) (*db.TranslationSource, error) {
+	return nil, ErrNotSupported{Method: "CreateTranslationSource"}
+}
+
+func (r *RemoteDriver) CreateTranslationSourceTable() error {
+	return ErrNotSupported{Method: "CreateTranslationSourceTable"}
+}
+
+func (r *RemoteDriver) DeleteTranslationSource(_ types.ContentFieldID) error {
+	return ErrNotSupported{Method: "DeleteTranslationSource"}
+}
+
+func (r *RemoteDriver) GetTranslationSource(_ types.ContentFieldID) (*db.TranslationSource, error) {
+	return nil, ErrNotSupported{Method: "GetTranslationSource"}
+}
+
+func (r *RemoteDriver) ListTranslationSourcesByField(_ types.ContentID, _ types.FieldID) (*[]db.TranslationSource, error) {
+	return nil, ErrNotSupported{Method: "ListTranslationSourcesByField"}
+}
+
+func (r *RemoteDriver) ListTranslationSourcesByLocale(_ string) (*[]db.TranslationSource, error) {
+	return nil, ErrNotSupported{Method: "ListTranslationSourcesByLocale"}
+}
+
+func (r *RemoteDriver) ListTranslationSourcesByRoute(_ types.NullableRouteID, _ string) (*[]db.TranslationSource, error) {
+	return nil, ErrNotSupported{Method: "ListTranslationSourcesByRoute"}
+}
+
+func (r *RemoteDriver) UpdateTranslationSource(_ types.ContentFieldID, _ string, _ int64) error {
+	return ErrNotSupported{Method: "UpdateTranslationSource"}
+}
+
+// ---------------------------------------------------------------------------
+// Blueprints
+// ---------------------------------------------------------------------------
+
+func (r *RemoteDriver) CountBlueprintInstances() (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountBlueprintInstances"}
+}
+
+func (r *RemoteDriver) CountBlueprints() (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountBlueprints"}
+}
+
+func (r *RemoteDriver) CreateBlueprint(_ db.CreateBlueprintParams) (*db.Blueprint, error) {
+	return nil, ErrNotSupported{Method: "CreateBlueprint"}
+}
+
+func (r *RemoteDriver) CreateBlueprintInstanceTable() error {
+	return ErrNotSupported{Method: "CreateBlueprintInstanceTable"}
+}
+
+func (r *RemoteDriver) CreateBlueprintTable() error {
+	return ErrNotSupported{Method: "CreateBlueprintTable"}
+}
+
+func (r *RemoteDriver) DeleteBlueprint(_ string) error {
+	return ErrNotSupported{Method: "DeleteBlueprint"}
+}
+
+func (r *RemoteDriver) DeleteBlueprintInstancesByBlueprint(_ string) error {
+	return ErrNotSupported{Method: "DeleteBlueprintInstancesByBlueprint"}
+}
+
+func (r *RemoteDriver) GetBlueprint(_ string) (*db.Blueprint, error) {
+	return nil, ErrNotSupported{Method: "GetBlueprint"}
+}
+
+func (r *RemoteDriver) GetBlueprintByName(_ string) (*db.Blueprint, error) {
+	return nil, ErrNotSupported{Method: "GetBlueprintByName"}
+}
+
+func (r *RemoteDriver) GetBlueprintInstance(_ types.ContentID) (*db.BlueprintInstance, error) {
+	return nil, ErrNotSupported{Method: "GetBlueprintInstance"}
+}
+
+func (r *RemoteDriver) ListBlueprintInstances() (*[]db.BlueprintInstance, error) {
+	return nil, ErrNotSupported{Method: "ListBlueprintInstances"}
+}
+
+func (r *RemoteDriver) ListBlueprintInstancesByBlueprint(_ string) (*[]db.BlueprintInstance, error) {
+	return nil, ErrNotSupported{Method: "ListBlueprintInstancesByBlueprint"}
+}
+
+func (r *RemoteDriver) ListBlueprints() (*[]db.Blueprint, error) {
+	return nil, ErrNotSupported{Method: "ListBlueprints"}
+}
+
+func (r *RemoteDriver) UpdateBlueprint(_ db.UpdateBlueprintParams) error {
+	return ErrNotSupported{Method: "UpdateBlueprint"}
+}
+
 // ---------------------------------------------------------------------------
 // Backups
 // ---------------------------------------------------------------------------
@@ -3472,6 +3784,150 @@ func (r *RemoteDriver) ListPermissionLabelsByRoleID(roleID types.RoleID) (*[]str
 	})
 }
 
+// ---------------------------------------------------------------------------
+// User roles and groups
+// ---------------------------------------------------------------------------
+
+func (r *RemoteDriver) CountUserRoles() (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountUserRoles"}
+}
+
+func (r *RemoteDriver) CreateUserRole(_ context.Context, _ audited.AuditContext, _ db.CreateUserRoleParams) (*db.UserRoles, error) {
+	return nil, ErrNotSupported{Method: "CreateUserRole"}
+}
+
+func (r *RemoteDriver) CreateUserRolesTable() error {
+	return ErrNotSupported{Method: "CreateUserRolesTable"}
+}
+
+func (r *RemoteDriver) DeleteUserRole(_ context.Context, _ audited.AuditContext, _ types.UserRoleID) error {
+	return ErrNotSupported{Method: "DeleteUserRole"}
+}
+
+func (r *RemoteDriver) DeleteUserRolesByUserID(_ context.Context, _ audited.AuditContext, _ types.UserID) error {
+	return ErrNotSupported{Method: "DeleteUserRolesByUserID"}
+}
+
+func (r *RemoteDriver) GetUserRole(_ types.UserRoleID) (*db.UserRoles, error) {
+	return nil, ErrNotSupported{Method: "GetUserRole"}
+}
+
+func (r *RemoteDriver) ListUserRoles() (*[]db.UserRoles, error) {
+	return nil, ErrNotSupported{Method: "ListUserRoles"}
+}
+
+func (r *RemoteDriver) ListUserRolesByUserID(_ types.UserID) (*[]db.UserRoles, error) {
+	return nil, ErrNotSupported{Method: "ListUserRolesByUserID"}
+}
+
+func (r *RemoteDriver) ListUserRolesByRoleID(_ types.RoleID) (*[]db.UserRoles, error) {
+	return nil, ErrNotSupported{Method: "ListUserRolesByRoleID"}
+}
+
+func (r *RemoteDriver) CountUserGroups() (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountUserGroups"}
+}
+
+func (r *RemoteDriver) CreateUserGroup(_ context.Context, _ audited.AuditContext, _ db.CreateUserGroupParams) (*db.UserGroups, error) {
+	return nil, ErrNotSupported{Method: "CreateUserGroup"}
+}
+
+func (r *RemoteDriver) CreateUserGroupTable() error {
+	return ErrNotSupported{Method: "CreateUserGroupTable"}
+}
+
+func (r *RemoteDriver) DeleteUserGroup(_ context.Context, _ audited.AuditContext, _ types.UserGroupID) error {
+	return ErrNotSupported{Method: "DeleteUserGroup"}
+}
+
+func (r *RemoteDriver) GetUserGroup(_ types.UserGroupID) (*db.UserGroups, error) {
+	return nil, ErrNotSupported{Method: "GetUserGroup"}
+}
+
+func (r *RemoteDriver) GetUserGroupByLabel(_ string) (*db.UserGroups, error) {
+	return nil, ErrNotSupported{Method: "GetUserGroupByLabel"}
+}
+
+func (r *RemoteDriver) ListUserGroups() (*[]db.UserGroups, error) {
+	return nil, ErrNotSupported{Method: "ListUserGroups"}
+}
+
+func (r *RemoteDriver) UpdateUserGroup(_ context.Context, _ audited.AuditContext, _ db.UpdateUserGroupParams) (*string, error) {
+	return nil, ErrNotSupported{Method: "UpdateUserGroup"}
+}
+
+func (r *RemoteDriver) CountUserGroupMembers() (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountUserGroupMembers"}
+}
+
+func (r *RemoteDriver) CreateUserGroupMember(_ context.Context, _ audited.AuditContext, _ db.CreateUserGroupMemberParams) (*db.UserGroupMembers, error) {
+	return nil, ErrNotSupported{Method: "CreateUserGroupMember"}
+}
+
+func (r *RemoteDriver) CreateUserGroupMembersTable() error {
+	return ErrNotSupported{Method: "CreateUserGroupMembersTable"}
+}
+
+func (r *RemoteDriver) DeleteUserGroupMember(_ context.Context, _ audited.AuditContext, _ types.UserGroupMemberID) error {
+	return ErrNotSupported{Method: "DeleteUserGroupMember"}
+}
+
+func (r *RemoteDriver) DeleteUserGroupMembersByUserGroupID(_ context.Context, _ audited.AuditContext, _ types.UserGroupID) error {
+	return ErrNotSupported{Method: "DeleteUserGroupMembersByUserGroupID"}
+}
+
+func (r *RemoteDriver) GetUserGroupMember(_ types.UserGroupMemberID) (*db.UserGroupMembers, error) {
+	return nil, ErrNotSupported{Method: "GetUserGroupMember"}
+}
+
+func (r *RemoteDriver) ListUserGroupMembers() (*[]db.UserGroupMembers, error) {
+	return nil, ErrNotSupported{Method: "ListUserGroupMembers"}
+}
+
+func (r *RemoteDriver) ListUserGroupMembersByUserGroupID(_ types.UserGroupID) (*[]db.UserGroupMembers, error) {
+	return nil, ErrNotSupported{Method: "ListUserGroupMembersByUserGroupID"}
+}
+
+func (r *RemoteDriver) ListUserGroupMembersByUserID(_ types.UserID) (*[]db.UserGroupMembers, error) {
+	return nil, ErrNotSupported{Method: "ListUserGroupMembersByUserID"}
+}
+
+func (r *RemoteDriver) CountUserGroupRoles() (*int64, error) {
+	return nil, ErrNotSupported{Method: "CountUserGroupRoles"}
+}
+
+func (r *RemoteDriver) CreateUserGroupRole(_ context.Context, _ audited.AuditContext, _ db.CreateUserGroupRoleParams) (*db.UserGroupRoles, error) {
+	return nil, ErrNotSupported{Method: "CreateUserGroupRole"}
+}
+
+func (r *RemoteDriver) CreateUserGroupRolesTable() error {
+	return ErrNotSupported{Method: "CreateUserGroupRolesTable"}
+}
+
+func (r *RemoteDriver) DeleteUserGroupRole(_ context.Context, _ audited.AuditContext, _ types.UserGroupRoleID) error {
+	return ErrNotSupported{Method: "DeleteUserGroupRole"}
+}
+
+func (r *RemoteDriver) DeleteUserGroupRolesByUserGroupID(_ context.Context, _ audited.AuditContext, _ types.UserGroupID) error {
+	return ErrNotSupported{Method: "DeleteUserGroupRolesByUserGroupID"}
+}
+
+func (r *RemoteDriver) GetUserGroupRole(_ types.UserGroupRoleID) (*db.UserGroupRoles, error) {
+	return nil, ErrNotSupported{Method: "GetUserGroupRole"}
+}
+
+func (r *RemoteDriver) ListUserGroupRoles() (*[]db.UserGroupRoles, error) {
+	return nil, ErrNotSupported{Method: "ListUserGroupRoles"}
+}
+
+func (r *RemoteDriver) ListUserGroupRolesByUserGroupID(_ types.UserGroupID) (*[]db.UserGroupRoles, error) {
+	return nil, ErrNotSupported{Method: "ListUserGroupRolesByUserGroupID"}
+}
+
+func (r *RemoteDriver) ListUserGroupRolesByRoleID(_ types.RoleID) (*[]db.UserGroupRoles, error) {
+	return nil, ErrNotSupported{Method: "ListUserGroupRolesByRoleID"}
+}
+
 // ---------------------------------------------------------------------------
 // Routes
 // ---------------------------------------------------------------------------
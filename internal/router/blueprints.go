@@ -0,0 +1,171 @@
+package router
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/hegner123/modulacms/internal/blueprint"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/service"
+)
+
+// maxBlueprintBody bounds the size of a blueprint definition body.
+const maxBlueprintBody = 4 << 20
+
+// BlueprintListHandler handles GET /api/v1/admin/blueprints. Definitions are
+// left out; fetch a blueprint to see its tree.
+func BlueprintListHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	list, err := svc.Blueprints.List(r.Context())
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, list)
+}
+
+// BlueprintGetHandler handles GET /api/v1/admin/blueprints/{id}. The path
+// value is a blueprint ID or name.
+func BlueprintGetHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	bp, err := svc.Blueprints.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, bp)
+}
+
+// BlueprintCreateHandler handles POST /api/v1/admin/blueprints. The body is
+// a blueprint definition, the same JSON a definition file holds.
+func BlueprintCreateHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	def, ok := readBlueprintDefinition(w, r)
+	if !ok {
+		return
+	}
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	bp, err := svc.Blueprints.Create(r.Context(), middleware.AuditContextFromRequest(r, *c), def)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(bp)
+}
+
+// BlueprintFromContentHandler handles POST /api/v1/admin/blueprints/from-content.
+// It saves the tree under content_data_id as a new blueprint.
+func BlueprintFromContentHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	var req service.BlueprintFromContentInput
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	bp, err := svc.Blueprints.CreateFromContent(r.Context(), middleware.AuditContextFromRequest(r, *c), req)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(bp)
+}
+
+// BlueprintUpdateHandler handles PUT /api/v1/admin/blueprints/{id}. The body
+// is the full replacement definition.
+func BlueprintUpdateHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	def, ok := readBlueprintDefinition(w, r)
+	if !ok {
+		return
+	}
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	bp, err := svc.Blueprints.Update(r.Context(), middleware.AuditContextFromRequest(r, *c), r.PathValue("id"), def)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, bp)
+}
+
+// BlueprintDeleteHandler handles DELETE /api/v1/admin/blueprints/{id}.
+// Content created from the blueprint is kept.
+func BlueprintDeleteHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	if err := svc.Blueprints.Delete(r.Context(), middleware.AuditContextFromRequest(r, *c), r.PathValue("id")); err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// BlueprintInstantiateHandler handles POST /api/v1/admin/blueprints/{id}/instantiate.
+// The body names the target (route_id, slug and title, or parent_id), the
+// variables and optionally the status of the new content.
+func BlueprintInstantiateHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	var req service.InstantiateBlueprintInput
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.Blueprint = r.PathValue("id")
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	result, err := svc.Blueprints.Instantiate(r.Context(), middleware.AuditContextFromRequest(r, *c), req)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(result)
+}
+
+// BlueprintInstancesHandler handles GET /api/v1/admin/blueprints/instances.
+// It lists the content trees created from blueprints, optionally narrowed by
+// ?blueprint= (ID or name).
+func BlueprintInstancesHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	instances, err := svc.Blueprints.Instances(r.Context(), r.URL.Query().Get("blueprint"))
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, instances)
+}
+
+// readBlueprintDefinition parses the request body as a definition, writing a
+// 400 response when it is not one.
+func readBlueprintDefinition(w http.ResponseWriter, r *http.Request) (*blueprint.Definition, bool) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBlueprintBody))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	def, err := blueprint.Parse(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return def, true
+}
@@ -264,6 +264,43 @@ func apiMoveContentData(w http.ResponseWriter, r *http.Request, svc *service.Reg
 	})
 }
 
+// ContentDataCloneHandler handles POST requests to deep-clone a content tree.
+func ContentDataCloneHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	apiCloneContentData(w, r, svc)
+}
+
+// apiCloneContentData copies the tree under source_id to a new parent or an
+// empty route, optionally into another locale.
+func apiCloneContentData(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+
+	var req service.CloneTreeInput
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	if err := req.SourceID.Validate(); err != nil {
+		http.Error(w, "invalid source_id", http.StatusBadRequest)
+		return
+	}
+
+	c, cfgErr := svc.Config()
+	if cfgErr != nil {
+		service.HandleServiceError(w, r, cfgErr)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+
+	result, err := svc.Content.CloneTree(r.Context(), ac, req)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(result)
+}
+
 // ReorderContentDataRequest is the JSON body for POST /api/v1/admincontentdatas/reorder.
 type ReorderContentDataRequest struct {
 	ParentID   types.NullableContentID `json:"parent_id"`
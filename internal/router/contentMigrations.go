@@ -0,0 +1,84 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/hegner123/modulacms/internal/contentmigration"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/service"
+)
+
+// ContentMigrationsHandler handles GET /api/v1/admin/content-migrations.
+func ContentMigrationsHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if svc.ContentMigrations == nil {
+		writeJSON(w, []service.ContentMigrationStatus{})
+		return
+	}
+	list, err := svc.ContentMigrations.List(r.Context())
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	if list == nil {
+		list = []service.ContentMigrationStatus{}
+	}
+	writeJSON(w, list)
+}
+
+// ContentMigrationRunHandler handles
+// POST /api/v1/admin/content-migrations/{id}/run.
+//
+// Query parameters: dry_run=true validates without writing, allow_invalid=true
+// writes values that fail validation, batch_size sets rows per transaction.
+// A run refused because of invalid values responds 422 with the report.
+func ContentMigrationRunHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if svc.ContentMigrations == nil {
+		http.Error(w, "content migrations are not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	q := r.URL.Query()
+	opts := contentmigration.Options{
+		DryRun:       q.Get("dry_run") == "true",
+		AllowInvalid: q.Get("allow_invalid") == "true",
+	}
+	if v := q.Get("batch_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "batch_size must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		opts.BatchSize = n
+	}
+
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+
+	report, err := svc.ContentMigrations.Run(r.Context(), ac, r.PathValue("id"), opts)
+	if errors.Is(err, contentmigration.ErrInvalidValues) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(report)
+		return
+	}
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, report)
+}
@@ -0,0 +1,152 @@
+package router
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/service"
+	"github.com/hegner123/modulacms/internal/spreadsheet"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// ContentSheetExportHandler handles GET /api/v1/sheets/{datatype}.
+//
+// Query parameters: format=csv|xlsx (default csv), locale limits translation
+// columns and may repeat.
+func ContentSheetExportHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	q := r.URL.Query()
+	format := q.Get("format")
+	if format == "" {
+		format = spreadsheet.FormatCSV
+	}
+	if format != spreadsheet.FormatCSV && format != spreadsheet.FormatXLSX {
+		http.Error(w, "format must be csv or xlsx", http.StatusBadRequest)
+		return
+	}
+
+	datatype := r.PathValue("datatype")
+	sheet, err := svc.Sheets.Export(r.Context(), service.SheetExportParams{
+		Datatype: datatype,
+		Locales:  q["locale"],
+	})
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := spreadsheet.Write(&buf, format, sheet); err != nil {
+		utility.DefaultLogger.Error("sheet export: encode", err)
+		http.Error(w, "export failed", http.StatusInternalServerError)
+		return
+	}
+	name := fmt.Sprintf("%s-%s.%s", datatype, time.Now().UTC().Format("20060102"), format)
+	w.Header().Set("Content-Type", spreadsheet.ContentType(format))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// ContentSheetImportHandler handles POST /api/v1/sheets/{datatype}.
+//
+// The body is the file, either raw or as the "file" part of a multipart
+// form. Query parameters: format=csv|xlsx (default from the file name or
+// content type), key=id|slug, dry_run=true, batch_size, and map=Column:target
+// (repeatable; an empty target ignores the column). Responds with the
+// import report.
+func ContentSheetImportHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, c.MaxUploadSize())
+
+	q := r.URL.Query()
+	format := q.Get("format")
+	var data []byte
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		if err := r.ParseMultipartForm(c.MaxUploadSize()); err != nil {
+			http.Error(w, "invalid multipart form", http.StatusBadRequest)
+			return
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "file part is required", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		if format == "" {
+			format = spreadsheet.FormatFromName(header.Filename)
+		}
+		data, err = io.ReadAll(file)
+		if err != nil {
+			http.Error(w, "failed to read file", http.StatusBadRequest)
+			return
+		}
+	} else {
+		if format == "" {
+			switch mediaType {
+			case "text/csv":
+				format = spreadsheet.FormatCSV
+			case spreadsheet.ContentType(spreadsheet.FormatXLSX):
+				format = spreadsheet.FormatXLSX
+			}
+		}
+		data, err = io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+	}
+	if format == "" {
+		http.Error(w, "format query parameter required (csv or xlsx)", http.StatusBadRequest)
+		return
+	}
+
+	params := service.SheetImportParams{
+		Datatype: r.PathValue("datatype"),
+		Key:      q.Get("key"),
+		DryRun:   q.Get("dry_run") == "true",
+	}
+	if v := q.Get("batch_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "batch_size must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		params.BatchSize = n
+	}
+	for _, m := range q["map"] {
+		column, target, ok := strings.Cut(m, ":")
+		if !ok {
+			http.Error(w, fmt.Sprintf("map %q must be Column:target", m), http.StatusBadRequest)
+			return
+		}
+		if params.Mapping == nil {
+			params.Mapping = map[string]string{}
+		}
+		params.Mapping[column] = target
+	}
+
+	sheet, err := spreadsheet.Read(format, data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+	report, err := svc.Sheets.Import(r.Context(), ac, sheet, params)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, report)
+}
@@ -109,18 +109,25 @@ func apiImportContent(w http.ResponseWriter, r *http.Request, svc *service.Regis
 		}
 	}
 
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
 	ac := middleware.AuditContextFromRequest(r, *cfg)
 	result, err := svc.Import.ImportContent(r.Context(), ac, service.ImportContentInput{
 		Format:  format,
 		Body:    body,
 		RouteID: routeID,
+		DryRun:  dryRun,
 	})
 	if err != nil {
 		service.HandleServiceError(w, r, err)
 		return
 	}
 
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(result)
 }
@@ -32,7 +32,8 @@ func NewModulaMux(mgr *config.Manager, bridge *plugin.HTTPBridge, driver db.DbDr
 		return mux
 	}
 
-	// Create rate limiter for auth endpoints: 10 requests per minute per IP
+	// Fixed limit for auth endpoints: 10 requests per minute per IP. It
+	// applies on top of rate_limit_policies so configuration cannot loosen it.
 	authLimiter := middleware.NewRateLimiter(rate.Limit(10.0/60.0), 10)
 
 	// Create CORS middleware
@@ -42,7 +43,8 @@ func NewModulaMux(mgr *config.Manager, bridge *plugin.HTTPBridge, driver db.DbDr
 		w.WriteHeader(http.StatusAccepted)
 	})
 
-	// Auth endpoints with CORS and rate limiting (PUBLIC - no auth/permission required)
+	// Auth endpoints with CORS and rate limiting (PUBLIC - no auth/permission required).
+	// The "auth" policy in DefaultMiddlewareChain may limit them further.
 	mux.Handle("POST /api/v1/auth/login", corsMiddleware(authLimiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		LoginHandler(w, r, svc)
 	}))))
@@ -179,6 +181,14 @@ func NewModulaMux(mgr *config.Manager, bridge *plugin.HTTPBridge, driver db.DbDr
 		ContentBatchHandler(w, r, svc)
 	})))
 
+	// Content sheets (CSV/XLSX export and import per datatype)
+	mux.Handle("GET /api/v1/sheets/{datatype}", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ContentSheetExportHandler(w, r, svc)
+	})))
+	mux.Handle("POST /api/v1/sheets/{datatype}", middleware.RequireAllPermissions("content:create", "content:update")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ContentSheetImportHandler(w, r, svc)
+	})))
+
 	// Content tree save (bulk pointer updates + deletes)
 	mux.Handle("POST /api/v1/content/tree", middleware.RequirePermission("content:update")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ContentTreeSaveHandler(w, r, svc)
@@ -212,6 +222,9 @@ func NewModulaMux(mgr *config.Manager, bridge *plugin.HTTPBridge, driver db.DbDr
 	mux.Handle("GET /api/v1/content/versions/", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		GetVersionHandler(w, r, svc)
 	})))
+	mux.Handle("GET /api/v1/content/versions/diff", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		DiffVersionsHandler(w, r, svc)
+	})))
 	mux.Handle("POST /api/v1/content/versions", middleware.RequirePermission("content:update")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		CreateManualVersionHandler(w, r, svc)
 	})))
@@ -219,6 +232,23 @@ func NewModulaMux(mgr *config.Manager, bridge *plugin.HTTPBridge, driver db.DbDr
 		DeleteVersionHandler(w, r, svc)
 	})))
 
+	// Content edit leases and presence
+	mux.Handle("GET /api/v1/content/leases", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		GetPresenceHandler(w, r, svc)
+	})))
+	mux.Handle("GET /api/v1/content/leases/stream", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		PresenceStreamHandler(w, r, svc)
+	})))
+	mux.Handle("POST /api/v1/content/leases", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		AcquireLeaseHandler(w, r, svc)
+	})))
+	mux.Handle("POST /api/v1/content/leases/heartbeat", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		LeaseHeartbeatHandler(w, r, svc)
+	})))
+	mux.Handle("DELETE /api/v1/content/leases", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ReleaseLeaseHandler(w, r, svc)
+	})))
+
 	// Content restore from version
 	mux.Handle("POST /api/v1/content/restore", middleware.RequirePermission("content:update")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		RestoreVersionHandler(w, r, svc)
@@ -269,11 +299,24 @@ func NewModulaMux(mgr *config.Manager, bridge *plugin.HTTPBridge, driver db.DbDr
 		ContentHealHandler(w, r, svc)
 	})))
 
+	// Content migrations (field type and structure changes)
+	mux.Handle("GET /api/v1/admin/content-migrations", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ContentMigrationsHandler(w, r, svc)
+	})))
+	mux.Handle("POST /api/v1/admin/content-migrations/{id}/run", middleware.RequirePermission("content:update")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ContentMigrationRunHandler(w, r, svc)
+	})))
+
 	// Content data move (cross-parent)
 	mux.Handle("POST /api/v1/contentdata/move", middleware.RequirePermission("content:update")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ContentDataMoveHandler(w, r, svc)
 	})))
 
+	// Content data clone (deep copy of a subtree)
+	mux.Handle("POST /api/v1/contentdata/clone", middleware.RequirePermission("content:create")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ContentDataCloneHandler(w, r, svc)
+	})))
+
 	// Admin content data move (cross-parent)
 	mux.Handle("POST /api/v1/admincontentdatas/move", middleware.RequirePermission("content:update")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		AdminContentDataMoveHandler(w, r, svc)
@@ -515,6 +558,17 @@ func NewModulaMux(mgr *config.Manager, bridge *plugin.HTTPBridge, driver db.DbDr
 		UserSessionHandler(w, r, svc)
 	})))
 
+	// Account lockouts
+	mux.Handle("GET /api/v1/user-lockouts", middleware.RequirePermission("users:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		UserLockoutsHandler(w, r, svc)
+	})))
+	mux.Handle("GET /api/v1/user-lockouts/", middleware.RequirePermission("users:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		UserLockoutHandler(w, r, svc)
+	})))
+	mux.Handle("DELETE /api/v1/user-lockouts/", middleware.RequirePermission("users:update")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		UserLockoutHandler(w, r, svc)
+	})))
+
 	// SSH Key management endpoints
 	mux.Handle("POST /api/v1/ssh-keys", middleware.RequirePermission("ssh_keys:create")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		AddSSHKeyHandler(w, r, svc)
@@ -562,6 +616,35 @@ func NewModulaMux(mgr *config.Manager, bridge *plugin.HTTPBridge, driver db.DbDr
 		RolePermissionsByRoleHandler(w, r, svc)
 	})))
 
+	// User role assignments and user groups
+	mux.Handle("/api/v1/user-roles", middleware.RequireResourcePermission("roles")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		UserRolesHandler(w, r, svc)
+	})))
+	mux.Handle("/api/v1/user-roles/", middleware.RequireResourcePermission("roles")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		UserRoleHandler(w, r, svc)
+	})))
+	mux.Handle("GET /api/v1/user-roles/effective/", middleware.RequirePermission("roles:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		UserEffectiveRolesHandler(w, r, svc)
+	})))
+	mux.Handle("/api/v1/user-groups", middleware.RequireResourcePermission("roles")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		UserGroupsHandler(w, r, svc)
+	})))
+	mux.Handle("/api/v1/user-groups/", middleware.RequireResourcePermission("roles")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		UserGroupHandler(w, r, svc)
+	})))
+	mux.Handle("/api/v1/user-group-members", middleware.RequireResourcePermission("roles")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		UserGroupMembersHandler(w, r, svc)
+	})))
+	mux.Handle("/api/v1/user-group-members/", middleware.RequireResourcePermission("roles")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		UserGroupMemberHandler(w, r, svc)
+	})))
+	mux.Handle("/api/v1/user-group-roles", middleware.RequireResourcePermission("roles")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		UserGroupRolesHandler(w, r, svc)
+	})))
+	mux.Handle("/api/v1/user-group-roles/", middleware.RequireResourcePermission("roles")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		UserGroupRoleHandler(w, r, svc)
+	})))
+
 	// Deploy sync endpoints
 	mux.Handle("GET /api/v1/deploy/health", middleware.RequirePermission("deploy:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		deploy.DeployHealthHandler(w, r, svc)
@@ -573,6 +656,11 @@ func NewModulaMux(mgr *config.Manager, bridge *plugin.HTTPBridge, driver db.DbDr
 		deploy.DeployImportHandler(w, r, svc)
 	})))
 
+	// Static site export
+	mux.Handle("POST /api/v1/export/site", middleware.RequirePermission("deploy:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		SiteExportHandler(w, r, svc)
+	})))
+
 	// Config management endpoints (permission-gated)
 	configAuthChain := middleware.AuthenticatedChain(mgr)
 	mux.Handle("GET /api/v1/admin/config", configAuthChain(middleware.RequirePermission("config:read")(ConfigGetHandler(svc))))
@@ -684,13 +772,59 @@ func NewModulaMux(mgr *config.Manager, bridge *plugin.HTTPBridge, driver db.DbDr
 		AdminValidationDeleteHandler(w, r, svc)
 	})))
 
-	// Translations — create locale translations for content data
+	// Translations — create locale translations for content data, review
+	// pre-filled fields, report stale translations, and manage the
+	// translation memory
 	mux.Handle("POST /api/v1/admin/contentdata/{id}/translations", middleware.RequirePermission("content:create")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		TranslationHandler(w, r, svc)
 	})))
 	mux.Handle("POST /api/v1/admin/admincontentdata/{id}/translations", middleware.RequirePermission("content:create")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		AdminTranslationHandler(w, r, svc)
 	})))
+	mux.Handle("GET /api/v1/admin/translations/reviews", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		TranslationReviewsListHandler(w, r, svc)
+	})))
+	mux.Handle("POST /api/v1/admin/translations/reviews", middleware.RequirePermission("content:update")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		TranslationReviewsMarkHandler(w, r, svc)
+	})))
+	mux.Handle("GET /api/v1/admin/translations/status", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		TranslationStatusHandler(w, r, svc)
+	})))
+	mux.Handle("POST /api/v1/admin/translations/suggest", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		TranslationSuggestHandler(w, r, svc)
+	})))
+	mux.Handle("GET /api/v1/admin/translation-memory", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		TranslationMemoryListHandler(w, r, svc)
+	})))
+	mux.Handle("DELETE /api/v1/admin/translation-memory/{id}", middleware.RequirePermission("content:update")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		TranslationMemoryDeleteHandler(w, r, svc)
+	})))
+
+	// Blueprints — saved content subtrees, instantiated as new content trees
+	mux.Handle("GET /api/v1/admin/blueprints", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		BlueprintListHandler(w, r, svc)
+	})))
+	mux.Handle("POST /api/v1/admin/blueprints", middleware.RequirePermission("content:create")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		BlueprintCreateHandler(w, r, svc)
+	})))
+	mux.Handle("POST /api/v1/admin/blueprints/from-content", middleware.RequirePermission("content:create")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		BlueprintFromContentHandler(w, r, svc)
+	})))
+	mux.Handle("GET /api/v1/admin/blueprints/instances", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		BlueprintInstancesHandler(w, r, svc)
+	})))
+	mux.Handle("GET /api/v1/admin/blueprints/{id}", middleware.RequirePermission("content:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		BlueprintGetHandler(w, r, svc)
+	})))
+	mux.Handle("PUT /api/v1/admin/blueprints/{id}", middleware.RequirePermission("content:update")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		BlueprintUpdateHandler(w, r, svc)
+	})))
+	mux.Handle("DELETE /api/v1/admin/blueprints/{id}", middleware.RequirePermission("content:delete")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		BlueprintDeleteHandler(w, r, svc)
+	})))
+	mux.Handle("POST /api/v1/admin/blueprints/{id}/instantiate", middleware.RequirePermission("content:create")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		BlueprintInstantiateHandler(w, r, svc)
+	})))
 
 	// Content query by datatype (PUBLIC - no auth required)
 	mux.Handle("GET /api/v1/query/{datatype}", corsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -820,16 +954,18 @@ func registerAdminRoutes(mux *http.ServeMux, mgr *config.Manager, driver db.DbDr
 	// Dynamic favicon (colored by environment, no auth)
 	mux.HandleFunc("GET /admin/favicon.svg", adminhandlers.FaviconHandler(svc))
 
-	// Auth pages (no session auth required)
+	// Auth pages (no session auth required). POST submissions have a fixed
+	// limit; the "admin-auth" policy in DefaultMiddlewareChain may limit them
+	// further.
 	loginCSRF := htmxadmin.CSRFMiddleware()
 	loginLimiter := middleware.NewRateLimiter(rate.Limit(10.0/60.0), 10) // 10 attempts/min per IP
 	mux.Handle("GET /admin/login", loginCSRF(http.HandlerFunc(adminhandlers.LoginPageHandler())))
-	mux.Handle("POST /admin/login", loginLimiter.Middleware(loginCSRF(http.HandlerFunc(adminhandlers.LoginSubmitHandler(mgr)))))
+	mux.Handle("POST /admin/login", loginLimiter.Middleware(loginCSRF(http.HandlerFunc(adminhandlers.LoginSubmitHandler(svc)))))
 	mux.HandleFunc("POST /admin/logout", adminhandlers.LogoutHandler(mgr))
 	mux.Handle("GET /admin/forgot-password", loginCSRF(http.HandlerFunc(adminhandlers.ForgotPasswordPageHandler())))
 	mux.Handle("POST /admin/forgot-password", loginLimiter.Middleware(loginCSRF(http.HandlerFunc(adminhandlers.ForgotPasswordSubmitHandler(mgr, emailSvc, driver)))))
 	mux.Handle("GET /admin/reset-password", loginCSRF(http.HandlerFunc(adminhandlers.ResetPasswordPageHandler(driver))))
-	mux.Handle("POST /admin/reset-password", loginLimiter.Middleware(loginCSRF(http.HandlerFunc(adminhandlers.ResetPasswordSubmitHandler(mgr, driver)))))
+	mux.Handle("POST /admin/reset-password", loginLimiter.Middleware(loginCSRF(http.HandlerFunc(adminhandlers.ResetPasswordSubmitHandler(svc)))))
 
 	adminAuth := htmxadmin.AdminAuthMiddleware(mgr)
 	csrf := htmxadmin.CSRFMiddleware()
@@ -1016,6 +1152,7 @@ func registerAdminRoutes(mux *http.ServeMux, mgr *config.Manager, driver db.DbDr
 	mux.Handle("POST /admin/users", mutating("users:create", adminhandlers.UserCreateHandler(svc)))
 	mux.Handle("POST /admin/users/{id}", mutating("users:update", adminhandlers.UserUpdateHandler(svc)))
 	mux.Handle("DELETE /admin/users/{id}", mutating("users:delete", adminhandlers.UserDeleteHandler(svc)))
+	mux.Handle("POST /admin/users/{id}/access", mutating("roles:update", adminhandlers.UserAccessUpdateHandler(svc)))
 
 	// User SSH Keys
 	mux.Handle("POST /admin/ssh-keys/{id}", mutating("ssh_keys:create", adminhandlers.UserSSHKeyAddHandler(svc)))
@@ -1033,6 +1170,14 @@ func registerAdminRoutes(mux *http.ServeMux, mgr *config.Manager, driver db.DbDr
 	mux.Handle("POST /admin/users/roles/{id}", mutating("roles:update", adminhandlers.RoleUpdateHandler(svc)))
 	mux.Handle("DELETE /admin/users/roles/{id}", mutating("roles:delete", adminhandlers.RoleDeleteHandler(svc)))
 
+	// User Groups
+	mux.Handle("GET /admin/users/groups", viewing("roles", adminhandlers.UserGroupsListHandler(svc)))
+	mux.Handle("GET /admin/users/groups/new", viewing("roles", adminhandlers.UserGroupNewFormHandler(svc)))
+	mux.Handle("GET /admin/users/groups/{id}", viewing("roles", adminhandlers.UserGroupDetailHandler(svc)))
+	mux.Handle("POST /admin/users/groups", mutating("roles:create", adminhandlers.UserGroupCreateHandler(svc)))
+	mux.Handle("POST /admin/users/groups/{id}", mutating("roles:update", adminhandlers.UserGroupUpdateHandler(svc)))
+	mux.Handle("DELETE /admin/users/groups/{id}", mutating("roles:delete", adminhandlers.UserGroupDeleteHandler(svc)))
+
 	// Tokens
 	mux.Handle("GET /admin/users/tokens", viewing("tokens", adminhandlers.TokensListHandler(svc)))
 	mux.Handle("GET /admin/users/tokens/{id}", viewing("tokens", adminhandlers.TokenDetailHandler(svc)))
@@ -0,0 +1,235 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/presence"
+	"github.com/hegner123/modulacms/internal/service"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+///////////////////////////////
+// REQUEST TYPES
+///////////////////////////////
+
+// AcquireLeaseRequest is the JSON body for POST /api/v1/content/leases.
+type AcquireLeaseRequest struct {
+	ContentDataID types.ContentID `json:"content_data_id"`
+	Mode          string          `json:"mode"`
+	LeaseID       string          `json:"lease_id"`
+	Client        string          `json:"client"`
+	Force         bool            `json:"force"`
+}
+
+// LeaseHeartbeatRequest is the JSON body for POST /api/v1/content/leases/heartbeat.
+type LeaseHeartbeatRequest struct {
+	LeaseID string `json:"lease_id"`
+}
+
+// presenceKeepAlive is how often an idle presence stream sends a comment so
+// proxies do not close it.
+const presenceKeepAlive = 25 * time.Second
+
+///////////////////////////////
+// HANDLERS
+///////////////////////////////
+
+// GetPresenceHandler handles GET requests for the leases on a content node.
+// Reads content_id from the query.
+func GetPresenceHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	cdID := types.ContentID(r.URL.Query().Get("content_id"))
+	if err := cdID.Validate(); err != nil {
+		http.Error(w, fmt.Sprintf("invalid content_id: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	p, err := svc.Presence.GetPresence(r.Context(), cdID)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	writeJSON(w, p)
+}
+
+// AcquireLeaseHandler handles POST requests to take a viewing or editing
+// lease on a content node. Editing requires content:update; force requires
+// an administrator.
+func AcquireLeaseHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+
+	var req AcquireLeaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid JSON body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := req.ContentDataID.Validate(); err != nil {
+		http.Error(w, fmt.Sprintf("invalid content_data_id: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	user := middleware.AuthenticatedUser(r.Context())
+	if user == nil {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+
+	client := req.Client
+	if client == "" {
+		client = presence.ClientAPI
+	}
+	isAdmin := middleware.ContextIsAdmin(r.Context())
+	canEdit := isAdmin || middleware.ContextPermissions(r.Context()).Has("content:update")
+
+	lease, err := svc.Presence.AcquireLease(r.Context(), ac, presence.AcquireParams{
+		ContentDataID: req.ContentDataID,
+		LeaseID:       req.LeaseID,
+		UserID:        user.UserID,
+		Username:      user.Username,
+		Client:        client,
+		Mode:          req.Mode,
+		Force:         req.Force,
+	}, canEdit, isAdmin)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(lease)
+}
+
+// LeaseHeartbeatHandler handles POST requests to renew a lease. Returns 409
+// when the lease is gone, and the lease in viewing mode after another
+// session took over editing.
+func LeaseHeartbeatHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+
+	var req LeaseHeartbeatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid JSON body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.LeaseID == "" {
+		http.Error(w, "lease_id is required", http.StatusBadRequest)
+		return
+	}
+
+	user := middleware.AuthenticatedUser(r.Context())
+	if user == nil {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	lease, err := svc.Presence.Heartbeat(r.Context(), req.LeaseID, user.UserID)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	writeJSON(w, lease)
+}
+
+// ReleaseLeaseHandler handles DELETE requests to give up a lease.
+// Reads lease_id from the query.
+func ReleaseLeaseHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	leaseID := r.URL.Query().Get("lease_id")
+	if leaseID == "" {
+		http.Error(w, "lease_id is required", http.StatusBadRequest)
+		return
+	}
+
+	user := middleware.AuthenticatedUser(r.Context())
+	if user == nil {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	if err := svc.Presence.ReleaseLease(r.Context(), leaseID, user.UserID); err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PresenceStreamHandler handles GET requests for a server-sent event stream
+// of presence on a content node. Each change is sent as a "presence" event
+// whose data is the same JSON as GetPresenceHandler. Reads content_id from
+// the query.
+func PresenceStreamHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	cdID := types.ContentID(r.URL.Query().Get("content_id"))
+	if err := cdID.Validate(); err != nil {
+		http.Error(w, fmt.Sprintf("invalid content_id: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// The stream outlives the server's write timeout.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		utility.DefaultLogger.Warn("presence stream: clear write deadline", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		utility.DefaultLogger.Error("presence stream: flush unsupported", err)
+		return
+	}
+
+	updates := make(chan *presence.Presence)
+	done := make(chan error, 1)
+	go func() {
+		done <- svc.Presence.WatchPresence(r.Context(), cdID, func(p *presence.Presence) error {
+			select {
+			case updates <- p:
+				return nil
+			case <-r.Context().Done():
+				return r.Context().Err()
+			}
+		})
+	}()
+
+	keepAlive := time.NewTicker(presenceKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case p := <-updates:
+			b, err := json.Marshal(p)
+			if err != nil {
+				utility.DefaultLogger.Error("presence stream: marshal", err)
+				return
+			}
+			if _, err := fmt.Fprintf(w, "event: presence\ndata: %s\n\n", b); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case err := <-done:
+			if err != nil && r.Context().Err() == nil {
+				utility.DefaultLogger.Error("presence stream", err)
+			}
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package router
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/service"
+	"github.com/hegner123/modulacms/internal/siteexport"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// siteExportRequest is the body of POST /api/v1/export/site. Previous
+// is the manifest.json of the caller's last bundle; when set, the response
+// holds only new and changed files and lists the files to delete in the
+// manifest's removed field.
+type siteExportRequest struct {
+	Format       string               `json:"format"`
+	Locales      []string             `json:"locales"`
+	Media        bool                 `json:"media"`
+	MediaBaseURL string               `json:"media_base_url"`
+	BodyField    string               `json:"body_field"`
+	Previous     *siteexport.Manifest `json:"previous"`
+}
+
+// SiteExportHandler handles POST /api/v1/export/site. It responds
+// with the bundle as a zip archive; the body is optional.
+func SiteExportHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req siteExportRequest
+	if r.ContentLength != 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, 10<<20)
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	// Build the archive in a temporary file so a failure can still be
+	// reported with an error status.
+	tmp, err := os.CreateTemp("", "site-export-*.zip")
+	if err != nil {
+		utility.DefaultLogger.Error("site export: create temp file", err)
+		http.Error(w, "export failed", http.StatusInternalServerError)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	zw := zip.NewWriter(tmp)
+	_, err = siteexport.Run(r.Context(), svc.Driver(), *c, siteexport.ZipSink{W: zw}, siteexport.Options{
+		Format:       req.Format,
+		Locales:      req.Locales,
+		Media:        req.Media,
+		MediaBaseURL: req.MediaBaseURL,
+		BodyField:    req.BodyField,
+		Previous:     req.Previous,
+	})
+	if err != nil {
+		utility.DefaultLogger.Error("site export failed", err)
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err := zw.Close(); err != nil {
+		utility.DefaultLogger.Error("site export: finish archive", err)
+		http.Error(w, "export failed", http.StatusInternalServerError)
+		return
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		utility.DefaultLogger.Error("site export: rewind archive", err)
+		http.Error(w, "export failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Length", fmt.Sprint(size))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="site-%s.zip"`, time.Now().UTC().Format("20060102-150405")))
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, tmp); err != nil {
+		utility.DefaultLogger.Warn("site export: write response", err)
+	}
+}
@@ -3,6 +3,7 @@ package router
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/hegner123/modulacms/internal/db/types"
 	"github.com/hegner123/modulacms/internal/middleware"
@@ -10,9 +11,16 @@ import (
 	"github.com/hegner123/modulacms/internal/utility"
 )
 
-// translationRequest is the JSON body for creating a translation.
+// translationRequest is the JSON body for creating a translation. Prefill is
+// "", "memory" or "machine" and only applies to public content.
 type translationRequest struct {
-	Locale string `json:"locale"`
+	Locale  string `json:"locale"`
+	Prefill string `json:"prefill"`
+}
+
+// reviewedRequest is the JSON body for marking pre-filled fields reviewed.
+type reviewedRequest struct {
+	ContentFieldIDs []types.ContentFieldID `json:"content_field_ids"`
 }
 
 // TranslationHandler dispatches translation operations for content data.
@@ -68,7 +76,12 @@ func apiCreateTranslation(w http.ResponseWriter, r *http.Request, svc *service.R
 	}
 	ac := middleware.AuditContextFromRequest(r, *c)
 
-	result, err := svc.Locales.CreateTranslation(r.Context(), ac, contentDataID, req.Locale, user.UserID)
+	var result *service.TranslationResult
+	if req.Prefill == service.PrefillNone {
+		result, err = svc.Locales.CreateTranslation(r.Context(), ac, contentDataID, req.Locale, user.UserID)
+	} else {
+		result, err = svc.Translations.CreateTranslation(r.Context(), ac, contentDataID, req.Locale, req.Prefill, user.UserID)
+	}
 	if err != nil {
 		service.HandleServiceError(w, r, err)
 		return
@@ -122,3 +135,96 @@ func apiCreateAdminTranslation(w http.ResponseWriter, r *http.Request, svc *serv
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(result)
 }
+
+// TranslationReviewsListHandler handles GET /api/v1/admin/translations/reviews.
+// It lists the pre-filled fields of a locale that still need review,
+// optionally narrowed by ?route_id=.
+func TranslationReviewsListHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	routeID := types.NullableRouteID{Valid: false}
+	if raw := r.URL.Query().Get("route_id"); raw != "" {
+		routeID = types.NullableRouteID{ID: types.RouteID(raw), Valid: true}
+	}
+	reviews, err := svc.Translations.ListReviews(r.Context(), routeID, r.URL.Query().Get("locale"))
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, reviews)
+}
+
+// TranslationReviewsMarkHandler handles POST /api/v1/admin/translations/reviews.
+// It clears the review flag on the listed content fields and marks them
+// current against today's source values.
+func TranslationReviewsMarkHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	var req reviewedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := svc.Translations.MarkReviewed(r.Context(), req.ContentFieldIDs); err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TranslationStatusHandler handles GET /api/v1/admin/translations/status.
+// It reports missing, stale, current and untracked translatable fields in
+// ?locale=, optionally narrowed by ?route_id= and filtered by ?status= (one
+// or more comma-separated statuses, e.g. ?status=stale).
+func TranslationStatusHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	q := r.URL.Query()
+	in := service.StatusInput{Locale: q.Get("locale")}
+	if raw := q.Get("route_id"); raw != "" {
+		in.RouteID = types.NullableRouteID{ID: types.RouteID(raw), Valid: true}
+	}
+	if raw := q.Get("status"); raw != "" {
+		in.Statuses = strings.Split(raw, ",")
+	}
+	report, err := svc.Translations.Status(r.Context(), in)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, report)
+}
+
+// TranslationSuggestHandler handles POST /api/v1/admin/translations/suggest.
+// It returns memory matches, and machine translations when requested, without
+// writing anything.
+func TranslationSuggestHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	var req service.SuggestInput
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	suggestions, err := svc.Translations.Suggest(r.Context(), req)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, suggestions)
+}
+
+// TranslationMemoryListHandler handles GET /api/v1/admin/translation-memory.
+// ?target_locale= is required; ?source_locale= defaults to the default locale.
+func TranslationMemoryListHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	q := r.URL.Query()
+	page, err := svc.Translations.ListMemory(r.Context(), q.Get("source_locale"), q.Get("target_locale"), ParsePaginationParams(r))
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, page)
+}
+
+// TranslationMemoryDeleteHandler handles DELETE /api/v1/admin/translation-memory/{id}.
+func TranslationMemoryDeleteHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	if err := svc.Translations.DeleteMemory(r.Context(), r.PathValue("id")); err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
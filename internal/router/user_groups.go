@@ -0,0 +1,349 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/service"
+)
+
+// UserGroupsHandler handles GET (list all) and POST (create) for user groups.
+func UserGroupsHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	switch r.Method {
+	case http.MethodGet:
+		apiListUserGroups(w, r, svc)
+	case http.MethodPost:
+		apiCreateUserGroup(w, r, svc)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// UserGroupHandler handles GET, PUT, and DELETE for a specific user group.
+func UserGroupHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	switch r.Method {
+	case http.MethodGet:
+		apiGetUserGroup(w, r, svc)
+	case http.MethodPut:
+		apiUpdateUserGroup(w, r, svc)
+	case http.MethodDelete:
+		apiDeleteUserGroup(w, r, svc)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// UserGroupMembersHandler handles GET (list by group or user) and POST (add)
+// for group memberships.
+func UserGroupMembersHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	switch r.Method {
+	case http.MethodGet:
+		apiListUserGroupMembers(w, r, svc)
+	case http.MethodPost:
+		apiAddUserGroupMember(w, r, svc)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// UserGroupMemberHandler handles DELETE (remove) for a specific membership.
+func UserGroupMemberHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	switch r.Method {
+	case http.MethodDelete:
+		apiRemoveUserGroupMember(w, r, svc)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// UserGroupRolesHandler handles GET (list by group) and POST (assign) for
+// roles granted to a group.
+func UserGroupRolesHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	switch r.Method {
+	case http.MethodGet:
+		apiListUserGroupRoles(w, r, svc)
+	case http.MethodPost:
+		apiAssignUserGroupRole(w, r, svc)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// UserGroupRoleHandler handles DELETE (revoke) for a specific group role.
+func UserGroupRoleHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	switch r.Method {
+	case http.MethodDelete:
+		apiRevokeUserGroupRole(w, r, svc)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func apiListUserGroups(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	groups, err := svc.RBAC.ListUserGroups(r.Context())
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(groups)
+}
+
+func apiGetUserGroup(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	q := r.URL.Query().Get("q")
+	gID := types.UserGroupID(q)
+	if err := gID.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	group, err := svc.RBAC.GetUserGroup(r.Context(), gID)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(group)
+}
+
+func apiCreateUserGroup(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	var req struct {
+		Label       string `json:"label"`
+		Description string `json:"description"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+
+	created, err := svc.RBAC.CreateUserGroup(r.Context(), ac, service.CreateUserGroupInput{
+		Label:       req.Label,
+		Description: req.Description,
+	})
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+func apiUpdateUserGroup(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	var req struct {
+		UserGroupID types.UserGroupID `json:"user_group_id"`
+		Label       string            `json:"label"`
+		Description string            `json:"description"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+
+	updated, err := svc.RBAC.UpdateUserGroup(r.Context(), ac, service.UpdateUserGroupInput{
+		UserGroupID: req.UserGroupID,
+		Label:       req.Label,
+		Description: req.Description,
+	})
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(updated)
+}
+
+func apiDeleteUserGroup(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	q := r.URL.Query().Get("q")
+	gID := types.UserGroupID(q)
+	if err := gID.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, cfgErr := svc.Config()
+	if cfgErr != nil {
+		service.HandleServiceError(w, r, cfgErr)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+
+	if err := svc.RBAC.DeleteUserGroup(r.Context(), ac, gID); err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+}
+
+// apiListUserGroupMembers lists memberships filtered by ?group_id= or ?user_id=.
+func apiListUserGroupMembers(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	q := r.URL.Query()
+	var (
+		members any
+		err     error
+	)
+	switch {
+	case q.Get("group_id") != "":
+		members, err = svc.RBAC.ListUserGroupMembers(r.Context(), types.UserGroupID(q.Get("group_id")))
+	case q.Get("user_id") != "":
+		members, err = svc.RBAC.ListUserGroupMembershipsByUser(r.Context(), types.UserID(q.Get("user_id")))
+	default:
+		http.Error(w, "group_id or user_id is required", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(members)
+}
+
+func apiAddUserGroupMember(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	var req struct {
+		UserGroupID types.UserGroupID `json:"user_group_id"`
+		UserID      types.UserID      `json:"user_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+
+	created, err := svc.RBAC.AddUserGroupMember(r.Context(), ac, req.UserGroupID, req.UserID, middleware.ContextIsAdmin(r.Context()))
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+func apiRemoveUserGroupMember(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	q := r.URL.Query().Get("q")
+	mID := types.UserGroupMemberID(q)
+	if err := mID.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, cfgErr := svc.Config()
+	if cfgErr != nil {
+		service.HandleServiceError(w, r, cfgErr)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+
+	if err := svc.RBAC.RemoveUserGroupMember(r.Context(), ac, mID); err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+}
+
+func apiListUserGroupRoles(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	gID := types.UserGroupID(r.URL.Query().Get("group_id"))
+	if err := gID.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	roles, err := svc.RBAC.ListUserGroupRoles(r.Context(), gID)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(roles)
+}
+
+func apiAssignUserGroupRole(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	var req struct {
+		UserGroupID types.UserGroupID `json:"user_group_id"`
+		RoleID      types.RoleID      `json:"role_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+
+	created, err := svc.RBAC.AssignUserGroupRole(r.Context(), ac, req.UserGroupID, req.RoleID, middleware.ContextIsAdmin(r.Context()))
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+func apiRevokeUserGroupRole(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	q := r.URL.Query().Get("q")
+	grID := types.UserGroupRoleID(q)
+	if err := grID.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, cfgErr := svc.Config()
+	if cfgErr != nil {
+		service.HandleServiceError(w, r, cfgErr)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+
+	if err := svc.RBAC.RevokeUserGroupRole(r.Context(), ac, grID); err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+}
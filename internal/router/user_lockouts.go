@@ -0,0 +1,91 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/service"
+)
+
+// UserLockoutsHandler handles GET to list users with failed sign-in attempts
+// or an active lockout.
+func UserLockoutsHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	switch r.Method {
+	case http.MethodGet:
+		apiListUserLockouts(w, r, svc)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// UserLockoutHandler handles GET (lockout state) and DELETE (unlock) for a
+// single user.
+func UserLockoutHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	switch r.Method {
+	case http.MethodGet:
+		apiGetUserLockout(w, r, svc)
+	case http.MethodDelete:
+		apiUnlockUser(w, r, svc)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func apiListUserLockouts(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	lockouts, err := svc.Auth.ListLockouts(r.Context())
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(lockouts)
+}
+
+func apiGetUserLockout(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	userID := types.UserID(r.URL.Query().Get("q"))
+	if err := userID.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	lockout, err := svc.Auth.GetLockout(r.Context(), userID)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	if lockout == nil {
+		service.HandleServiceError(w, r, &service.NotFoundError{Resource: "user lockout", ID: string(userID)})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(lockout)
+}
+
+func apiUnlockUser(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	userID := types.UserID(r.URL.Query().Get("q"))
+	if err := userID.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, cfgErr := svc.Config()
+	if cfgErr != nil {
+		service.HandleServiceError(w, r, cfgErr)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+
+	if err := svc.Auth.UnlockUser(r.Context(), ac, userID); err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+}
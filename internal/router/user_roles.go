@@ -0,0 +1,171 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/service"
+)
+
+// UserRolesHandler handles GET (list by user), POST (assign), and PUT (sync)
+// for user role assignments.
+func UserRolesHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	switch r.Method {
+	case http.MethodGet:
+		apiListUserRoles(w, r, svc)
+	case http.MethodPost:
+		apiAssignUserRole(w, r, svc)
+	case http.MethodPut:
+		apiSyncUserRoles(w, r, svc)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// UserRoleHandler handles DELETE (revoke) for a specific user role assignment.
+func UserRoleHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	switch r.Method {
+	case http.MethodDelete:
+		apiRevokeUserRole(w, r, svc)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// UserEffectiveRolesHandler handles GET to list every role that contributes
+// permissions to a user, including roles inherited from groups.
+func UserEffectiveRolesHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	switch r.Method {
+	case http.MethodGet:
+		apiListEffectiveUserRoles(w, r, svc)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func apiListUserRoles(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	userID := types.UserID(r.URL.Query().Get("user_id"))
+	if err := userID.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	urs, err := svc.RBAC.ListUserRoles(r.Context(), userID)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(urs)
+}
+
+func apiListEffectiveUserRoles(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	userID := types.UserID(r.URL.Query().Get("q"))
+	if err := userID.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	roles, err := svc.RBAC.EffectiveUserRoles(r.Context(), userID)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(roles)
+}
+
+func apiAssignUserRole(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	var req struct {
+		UserID types.UserID `json:"user_id"`
+		RoleID types.RoleID `json:"role_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+
+	created, err := svc.RBAC.AssignUserRole(r.Context(), ac, req.UserID, req.RoleID, middleware.ContextIsAdmin(r.Context()))
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+func apiSyncUserRoles(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	var req struct {
+		UserID  types.UserID   `json:"user_id"`
+		RoleIDs []types.RoleID `json:"role_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, err := svc.Config()
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+
+	failed, err := svc.RBAC.SyncUserRoles(r.Context(), ac, req.UserID, req.RoleIDs, middleware.ContextIsAdmin(r.Context()))
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	urs, err := svc.RBAC.ListUserRoles(r.Context(), req.UserID)
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"user_roles": urs,
+		"failed":     failed,
+	})
+}
+
+func apiRevokeUserRole(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	q := r.URL.Query().Get("q")
+	urID := types.UserRoleID(q)
+	if err := urID.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, cfgErr := svc.Config()
+	if cfgErr != nil {
+		service.HandleServiceError(w, r, cfgErr)
+		return
+	}
+	ac := middleware.AuditContextFromRequest(r, *c)
+
+	if err := svc.RBAC.RevokeUserRole(r.Context(), ac, urID); err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+}
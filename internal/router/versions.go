@@ -7,6 +7,7 @@ import (
 
 	"github.com/hegner123/modulacms/internal/db/types"
 	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/publishing"
 	"github.com/hegner123/modulacms/internal/service"
 )
 
@@ -63,6 +64,41 @@ func GetVersionHandler(w http.ResponseWriter, r *http.Request, svc *service.Regi
 	writeJSON(w, version)
 }
 
+// DiffVersionsHandler handles GET requests to compare two content versions,
+// or a version against the live draft. Reads content_id, from, to, and locale
+// from the query. Either side may be "draft". An empty from selects the
+// published version; an empty to selects the draft.
+func DiffVersionsHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
+	q := r.URL.Query()
+	cdID := types.ContentID(q.Get("content_id"))
+	if err := cdID.Validate(); err != nil {
+		http.Error(w, fmt.Sprintf("invalid content_id: %v", err), http.StatusBadRequest)
+		return
+	}
+	from := types.ContentVersionID(q.Get("from"))
+	if from != "" && from != publishing.DraftSide {
+		if err := from.Validate(); err != nil {
+			http.Error(w, fmt.Sprintf("invalid from: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+	to := types.ContentVersionID(q.Get("to"))
+	if to != "" && to != publishing.DraftSide {
+		if err := to.Validate(); err != nil {
+			http.Error(w, fmt.Sprintf("invalid to: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	diff, err := svc.Content.DiffVersions(r.Context(), cdID, from, to, q.Get("locale"))
+	if err != nil {
+		service.HandleServiceError(w, r, err)
+		return
+	}
+
+	writeJSON(w, diff)
+}
+
 // CreateManualVersionHandler handles POST requests to create a manual snapshot version.
 // Reads content_data_id and optional label from the JSON body.
 func CreateManualVersionHandler(w http.ResponseWriter, r *http.Request, svc *service.Registry) {
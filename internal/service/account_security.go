@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/auth"
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/email"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// --- Account lockout ---
+
+// GetLockout returns the lockout state for a user, or nil when the user has no
+// recorded failed attempts.
+func (s *AuthService) GetLockout(ctx context.Context, userID types.UserID) (*db.UserLockout, error) {
+	lockout, err := s.driver.GetUserLockout(userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get user lockout: %w", err)
+	}
+	return lockout, nil
+}
+
+// ListLockouts returns every user with failed attempts or an active lockout.
+func (s *AuthService) ListLockouts(ctx context.Context) (*[]db.UserLockout, error) {
+	return s.driver.ListUserLockouts()
+}
+
+// UnlockUser clears a user's lockout and failed attempt count, records an
+// unlock change event attributed to the caller, and notifies the user when
+// lockout notifications are enabled. Unlocking a user that is not locked is a
+// no-op.
+func (s *AuthService) UnlockUser(ctx context.Context, ac audited.AuditContext, userID types.UserID) error {
+	user, err := s.driver.GetUser(userID)
+	if err != nil || user == nil {
+		return &NotFoundError{Resource: "user", ID: string(userID)}
+	}
+
+	lockout, err := s.GetLockout(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if lockout == nil {
+		return nil
+	}
+
+	if err := s.driver.DeleteUserLockout(userID); err != nil {
+		return fmt.Errorf("delete user lockout: %w", err)
+	}
+
+	s.recordLockoutEvent(db.RecordChangeEventParams{
+		NodeID:    ac.NodeID,
+		RecordID:  string(userID),
+		Operation: types.OpDelete,
+		Action:    types.ActionUnlock,
+		UserID:    types.NullableUserID{ID: ac.UserID, Valid: !ac.UserID.IsZero()},
+		OldValues: lockoutValues(*lockout),
+		IP:        types.NullableString{String: ac.IP, Valid: ac.IP != ""},
+		RequestID: types.NullableString{String: ac.RequestID, Valid: ac.RequestID != ""},
+	})
+
+	cfg, cfgErr := s.mgr.Config()
+	if cfgErr == nil && cfg.Auth_Lockout_Notify {
+		s.sendLockoutEmail(user, "Your Modula account has been unlocked",
+			"An administrator has unlocked your Modula account. You can sign in again.")
+	}
+	return nil
+}
+
+// checkLockout loads the user's lockout row and reports whether it is active.
+// The row, if any, is returned for the caller to update.
+func (s *AuthService) checkLockout(ctx context.Context, userID types.UserID) (*db.UserLockout, bool) {
+	lockout, err := s.GetLockout(ctx, userID)
+	if err != nil {
+		// Fail open: a broken lockout table must not block every sign-in.
+		utility.DefaultLogger.Warn("failed to read user lockout", err, "user_id", userID)
+		return nil, false
+	}
+	return lockout, lockout != nil && lockout.IsLocked(time.Now().UTC())
+}
+
+// recordFailedLogin increments the user's failed attempt count and locks the
+// account once the configured threshold is reached. Each repeat lockout doubles
+// the previous duration up to Auth_Lockout_Max_Duration. The increment and the
+// lock are single conditional statements, so concurrent failures are all
+// counted and only one of them applies the lock.
+func (s *AuthService) recordFailedLogin(cfg *config.Config, user *db.Users, ip string) {
+	threshold := cfg.AuthLockoutThreshold()
+	if threshold == 0 {
+		return
+	}
+
+	now := types.NewTimestamp(time.Now().UTC())
+	if err := s.driver.IncrementUserLockout(db.IncrementUserLockoutParams{
+		UserID:       user.UserID,
+		LastFailedAt: now,
+		DateModified: now,
+	}); err != nil {
+		utility.DefaultLogger.Warn("failed to record failed sign-in", err, "user_id", user.UserID)
+		return
+	}
+	current, err := s.driver.GetUserLockout(user.UserID)
+	if err != nil {
+		utility.DefaultLogger.Warn("failed to read user lockout", err, "user_id", user.UserID)
+		return
+	}
+	if current.FailedAttempts < int64(threshold) {
+		return
+	}
+
+	until := types.NewTimestamp(now.Time.Add(lockoutDuration(cfg, current.LockoutCount)))
+	locked, err := s.driver.LockUserLockout(db.LockUserLockoutParams{
+		UserID:       user.UserID,
+		Threshold:    int64(threshold),
+		LockedUntil:  until,
+		DateModified: now,
+	})
+	if err != nil {
+		utility.DefaultLogger.Warn("failed to lock account", err, "user_id", user.UserID)
+		return
+	}
+	if !locked {
+		// A concurrent failure applied the lock first.
+		return
+	}
+
+	lockout := db.UserLockout{
+		UserID:       user.UserID,
+		LockoutCount: current.LockoutCount + 1,
+		LockedUntil:  until,
+		LastFailedAt: now,
+		DateModified: now,
+	}
+	s.recordLockoutEvent(db.RecordChangeEventParams{
+		NodeID:    types.NodeID(cfg.Node_ID),
+		RecordID:  string(user.UserID),
+		Operation: types.OpUpdate,
+		Action:    types.ActionLock,
+		NewValues: lockoutValues(lockout),
+		IP:        types.NullableString{String: ip, Valid: ip != ""},
+	})
+
+	if cfg.Auth_Lockout_Notify {
+		s.sendLockoutEmail(user, "Your Modula account has been locked",
+			"Your Modula account was locked after repeated failed sign-in attempts.\n\n"+
+				"You can try again after "+until.Time.Format(time.RFC1123)+". "+
+				"If this was not you, reset your password and contact an administrator.")
+	}
+}
+
+// clearFailedLogins removes the lockout row after a successful sign-in.
+func (s *AuthService) clearFailedLogins(userID types.UserID) {
+	if err := s.driver.DeleteUserLockout(userID); err != nil {
+		utility.DefaultLogger.Warn("failed to clear failed sign-ins", err, "user_id", userID)
+	}
+}
+
+// lockoutDuration returns the lock length for a user who has already been
+// locked out lockoutCount times.
+func lockoutDuration(cfg *config.Config, lockoutCount int64) time.Duration {
+	d := cfg.AuthLockoutDuration()
+	limit := cfg.AuthLockoutMaxDuration()
+	for i := int64(0); i < lockoutCount && d < limit; i++ {
+		d *= 2
+	}
+	if d > limit {
+		d = limit
+	}
+	return d
+}
+
+// recordLockoutEvent writes a lock or unlock event to change_events. Failures
+// are logged; the lockout itself has already been applied.
+func (s *AuthService) recordLockoutEvent(params db.RecordChangeEventParams) {
+	params.EventID = types.NewEventID()
+	params.HlcTimestamp = types.HLCNow()
+	params.TableName = string(db.User_lockouts)
+	if _, err := s.driver.RecordChangeEvent(params); err != nil {
+		utility.DefaultLogger.Error("record lockout change event", err, "user_id", params.RecordID)
+	}
+}
+
+// lockoutValues serializes a lockout row for a change event.
+func lockoutValues(l db.UserLockout) types.JSONData {
+	b, err := json.Marshal(l)
+	if err != nil {
+		return types.JSONData{}
+	}
+	return types.JSONData{Data: json.RawMessage(b), Valid: true}
+}
+
+// sendLockoutEmail notifies a user about a lockout state change.
+func (s *AuthService) sendLockoutEmail(user *db.Users, subject, body string) {
+	if s.emailSvc == nil || !s.emailSvc.Enabled() {
+		return
+	}
+	sendErr := s.emailSvc.Send(context.Background(), email.Message{
+		To:        []email.Address{email.NewAddress(user.Name, string(user.Email))},
+		Subject:   subject,
+		PlainBody: body,
+	})
+	if sendErr != nil {
+		utility.DefaultLogger.Error("failed to send lockout email", sendErr)
+	}
+}
+
+// --- Password policy ---
+
+// CheckNewPassword validates password against the password policy for user,
+// which is nil for an account that does not exist yet. It is for callers that
+// change passwords outside AuthService and UserService.
+func (s *AuthService) CheckNewPassword(user *db.Users, password string) error {
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		return fmt.Errorf("load config for password policy: %w", err)
+	}
+	if user == nil {
+		return checkPasswordPolicy(s.driver, cfg, "", "", password)
+	}
+	return checkPasswordPolicy(s.driver, cfg, user.UserID, user.Hash, password)
+}
+
+// PasswordChanged records a completed password change from previousHash to
+// hash for reuse and expiry checks and clears any lockout on the account.
+func (s *AuthService) PasswordChanged(userID types.UserID, previousHash, hash string) {
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		utility.DefaultLogger.Warn("failed to load config for password history", err)
+	} else {
+		recordPasswordChange(s.driver, cfg, userID, previousHash, hash)
+	}
+	s.clearFailedLogins(userID)
+}
+
+// checkPasswordPolicy validates a new password against the configured length,
+// breached password list, and reuse history. currentHash is the user's
+// existing hash, or empty for a new user. Violations are returned as a
+// ValidationError on the "password" field.
+func checkPasswordPolicy(driver db.DbDriver, cfg *config.Config, userID types.UserID, currentHash, password string) error {
+	policy, err := auth.PasswordPolicyFromConfig(*cfg)
+	if err != nil {
+		utility.DefaultLogger.Warn("breached password list unavailable", err)
+	}
+	if err := policy.Check(password); err != nil {
+		if auth.IsPolicyError(err) {
+			return NewValidationError("password", err.Error())
+		}
+		// Fail open: an unreadable list must not block password changes.
+		utility.DefaultLogger.Warn("breached password check failed", err)
+	}
+
+	if cfg.Password_History <= 0 || userID.IsZero() {
+		return nil
+	}
+	previous := []string{}
+	if currentHash != "" {
+		previous = append(previous, currentHash)
+	}
+	history, err := driver.ListPasswordHistoryByUserID(userID)
+	if err != nil {
+		utility.DefaultLogger.Warn("failed to read password history", err, "user_id", userID)
+	} else {
+		// The newest entry is normally the current password, so the
+		// previous Password_History passwords follow it.
+		for i, h := range *history {
+			if i > cfg.Password_History {
+				break
+			}
+			if h.Hash != currentHash {
+				previous = append(previous, h.Hash)
+			}
+		}
+	}
+	for _, h := range previous {
+		if auth.CheckPasswordHash(password, h) {
+			return NewValidationError("password", fmt.Sprintf("password must differ from the last %d passwords", cfg.Password_History))
+		}
+	}
+	return nil
+}
+
+// recordPasswordChange stores the new hash in password_history and prunes
+// entries beyond what reuse checks and expiry need. previousHash is the hash
+// being replaced, or empty for a new account; it is stored first when the
+// history does not already end with it, so that passwords set before history
+// was enabled are still covered by reuse checks. Nothing is stored when
+// neither Password_History nor Password_Expiry_Days is enabled.
+func recordPasswordChange(driver db.DbDriver, cfg *config.Config, userID types.UserID, previousHash, hash string) {
+	if cfg.Password_History <= 0 && cfg.Password_Expiry_Days <= 0 {
+		return
+	}
+	history, err := driver.ListPasswordHistoryByUserID(userID)
+	if err != nil {
+		utility.DefaultLogger.Warn("failed to read password history", err, "user_id", userID)
+		return
+	}
+
+	now := time.Now().UTC()
+	if previousHash != "" && (len(*history) == 0 || (*history)[0].Hash != previousHash) {
+		err := driver.CreatePasswordHistory(db.CreatePasswordHistoryParams{
+			UserID:      userID,
+			Hash:        previousHash,
+			DateCreated: types.NewTimestamp(now.Add(-time.Second)),
+		})
+		if err != nil {
+			utility.DefaultLogger.Warn("failed to record password history", err, "user_id", userID)
+		}
+	}
+	err = driver.CreatePasswordHistory(db.CreatePasswordHistoryParams{
+		UserID:      userID,
+		Hash:        hash,
+		DateCreated: types.NewTimestamp(now),
+	})
+	if err != nil {
+		utility.DefaultLogger.Warn("failed to record password history", err, "user_id", userID)
+		return
+	}
+
+	// The newest entry is the current password; keep Password_History
+	// previous passwords behind it.
+	keep := cfg.Password_History + 1
+	history, err = driver.ListPasswordHistoryByUserID(userID)
+	if err != nil {
+		return
+	}
+	for i, h := range *history {
+		if i < keep {
+			continue
+		}
+		if delErr := driver.DeletePasswordHistory(h.ID); delErr != nil {
+			utility.DefaultLogger.Warn("failed to prune password history", delErr, "user_id", userID)
+		}
+	}
+}
+
+// passwordExpired reports whether the user's password is older than
+// Password_Expiry_Days. Users without a recorded change start the clock now,
+// so enabling expiry does not immediately lock out every existing account.
+func passwordExpired(driver db.DbDriver, cfg *config.Config, user *db.Users) bool {
+	if cfg.Password_Expiry_Days <= 0 {
+		return false
+	}
+	history, err := driver.ListPasswordHistoryByUserID(user.UserID)
+	if err != nil {
+		utility.DefaultLogger.Warn("failed to read password history", err, "user_id", user.UserID)
+		return false
+	}
+	if len(*history) == 0 {
+		recordPasswordChange(driver, cfg, user.UserID, "", user.Hash)
+		return false
+	}
+	changed := (*history)[0].DateCreated
+	if !changed.Valid {
+		return false
+	}
+	expiry := changed.Time.Add(time.Duration(cfg.Password_Expiry_Days) * 24 * time.Hour)
+	return time.Now().UTC().After(expiry)
+}
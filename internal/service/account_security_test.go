@@ -0,0 +1,176 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/hegner123/modulacms/internal/auth"
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/service"
+)
+
+const accountTestPassword = "correct horse battery"
+
+// testAccountDB creates a database with one user whose password is
+// accountTestPassword, and returns an AuthService and UserService over it.
+func testAccountDB(t *testing.T, cfg config.Config) (db.Database, *db.Users, *service.AuthService, *service.UserService) {
+	t.Helper()
+	conn, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if _, err := conn.Exec("PRAGMA foreign_keys=ON;"); err != nil {
+		t.Fatalf("PRAGMA foreign_keys: %v", err)
+	}
+
+	cfg.Node_ID = types.NewNodeID().String()
+	d := db.Database{Connection: conn, Context: context.Background(), Config: cfg}
+	if err := d.CreateAllTables(); err != nil {
+		t.Fatalf("CreateAllTables: %v", err)
+	}
+
+	ctx := context.Background()
+	ac := audited.Ctx(types.NodeID(cfg.Node_ID), types.UserID(""), "test", "127.0.0.1")
+	role, err := d.CreateRole(ctx, ac, db.CreateRoleParams{Label: "viewer"})
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+	hash, err := auth.HashPassword(accountTestPassword)
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	user, err := d.CreateUser(ctx, ac, db.CreateUserParams{
+		Username:     "locky",
+		Name:         "Locky",
+		Email:        types.Email("locky@example.com"),
+		Hash:         hash,
+		Role:         string(role.RoleID),
+		DateCreated:  types.TimestampNow(),
+		DateModified: types.TimestampNow(),
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	mgr := config.NewManager(&staticProvider{cfg: &cfg})
+	if err := mgr.Load(); err != nil {
+		t.Fatalf("mgr.Load: %v", err)
+	}
+	return d, user, service.NewAuthService(d, mgr, nil), service.NewUserService(d, mgr, nil)
+}
+
+func TestAuthService_LockoutAfterThreshold(t *testing.T) {
+	t.Parallel()
+	d, user, svc, _ := testAccountDB(t, config.Config{
+		Auth_Lockout_Threshold: 3,
+		Auth_Lockout_Duration:  "1m",
+	})
+	ctx := context.Background()
+	login := func(password string) error {
+		_, err := svc.Login(ctx, service.LoginInput{Email: string(user.Email), Password: password, IPAddress: "10.0.0.1"})
+		return err
+	}
+
+	for i := range 2 {
+		if err := login("wrong password"); !service.IsUnauthorized(err) {
+			t.Fatalf("attempt %d: err = %v, want unauthorized", i+1, err)
+		}
+	}
+	// The lock is indistinguishable from a bad password to the caller.
+	if err := login("wrong password"); !service.IsUnauthorized(err) {
+		t.Fatalf("threshold attempt: err = %v, want unauthorized", err)
+	}
+	lockout, err := svc.GetLockout(ctx, user.UserID)
+	if err != nil || lockout == nil || !lockout.IsLocked(time.Now().UTC()) {
+		t.Fatalf("lockout after threshold = %+v (%v), want locked", lockout, err)
+	}
+	// The correct password is refused while locked.
+	if err := login(accountTestPassword); !service.IsUnauthorized(err) {
+		t.Fatalf("login while locked: err = %v, want unauthorized", err)
+	}
+
+	events, err := d.GetChangeEventsByRecord(string(db.User_lockouts), string(user.UserID))
+	if err != nil {
+		t.Fatalf("GetChangeEventsByRecord: %v", err)
+	}
+	if len(*events) != 1 || (*events)[0].Action != types.ActionLock {
+		t.Fatalf("expected one lock event, got %+v", *events)
+	}
+
+	admin := audited.Ctx(types.NodeID(d.Config.Node_ID), types.NewUserID(), "test", "127.0.0.1")
+	if err := svc.UnlockUser(ctx, admin, user.UserID); err != nil {
+		t.Fatalf("UnlockUser: %v", err)
+	}
+	events, err = d.GetChangeEventsByRecord(string(db.User_lockouts), string(user.UserID))
+	if err != nil {
+		t.Fatalf("GetChangeEventsByRecord: %v", err)
+	}
+	if len(*events) != 2 {
+		t.Fatalf("expected lock and unlock events, got %d", len(*events))
+	}
+
+	if err := login(accountTestPassword); err != nil {
+		t.Fatalf("login after unlock: %v", err)
+	}
+	lockout, err = svc.GetLockout(ctx, user.UserID)
+	if err != nil {
+		t.Fatalf("GetLockout: %v", err)
+	}
+	if lockout != nil {
+		t.Errorf("expected no lockout row after successful login, got %+v", lockout)
+	}
+}
+
+func TestAuthService_LockoutDisabled(t *testing.T) {
+	t.Parallel()
+	_, user, svc, _ := testAccountDB(t, config.Config{Auth_Lockout_Threshold: -1})
+	ctx := context.Background()
+
+	for i := range 6 {
+		_, err := svc.Login(ctx, service.LoginInput{Email: string(user.Email), Password: "wrong password"})
+		if !service.IsUnauthorized(err) {
+			t.Fatalf("attempt %d: err = %v, want unauthorized", i+1, err)
+		}
+	}
+}
+
+func TestUserService_PasswordPolicy(t *testing.T) {
+	t.Parallel()
+	_, user, _, users := testAccountDB(t, config.Config{
+		Password_Min_Length: 12,
+		Password_History:    2,
+	})
+	ctx := context.Background()
+	update := func(password string) error {
+		_, err := users.UpdateUser(ctx, audited.Ctx(types.NewNodeID(), user.UserID, "test", ""), service.UpdateUserInput{
+			UserID:   user.UserID,
+			Username: user.Username,
+			Name:     user.Name,
+			Email:    user.Email,
+			Password: password,
+		})
+		return err
+	}
+
+	if err := update("too short"); !service.IsValidation(err) {
+		t.Fatalf("short password: err = %v, want validation error", err)
+	}
+	if err := update(accountTestPassword); !service.IsValidation(err) {
+		t.Fatalf("current password reuse: err = %v, want validation error", err)
+	}
+	if err := update("a brand new passphrase"); err != nil {
+		t.Fatalf("new password: %v", err)
+	}
+	if err := update(accountTestPassword); !service.IsValidation(err) {
+		t.Fatalf("previous password reuse: err = %v, want validation error", err)
+	}
+}
@@ -5,7 +5,13 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/hegner123/modulacms/internal/auth"
@@ -91,9 +97,30 @@ func (s *AuthService) Login(ctx context.Context, input LoginInput) (*LoginResult
 		return nil, &UnauthorizedError{Message: "invalid credentials"}
 	}
 
+	cfg, cfgErr := s.mgr.Config()
+	if cfgErr != nil {
+		return nil, fmt.Errorf("load config for session creation: %w", cfgErr)
+	}
+
+	// A locked account is refused before the password is checked so that
+	// guessing cannot continue during the lockout. The refusal looks like a
+	// bad password so that it does not reveal which accounts exist.
+	lockout, locked := s.checkLockout(ctx, user.UserID)
+	if locked {
+		return nil, &UnauthorizedError{Message: "invalid credentials"}
+	}
+
 	if !auth.CheckPasswordHash(input.Password, user.Hash) {
+		s.recordFailedLogin(cfg, user, input.IPAddress)
 		return nil, &UnauthorizedError{Message: "invalid credentials"}
 	}
+	if lockout != nil {
+		s.clearFailedLogins(user.UserID)
+	}
+
+	if passwordExpired(s.driver, cfg, user) {
+		return nil, &ForbiddenError{Message: "password has expired; reset it to sign in"}
+	}
 
 	sessionToken, err := generateSessionToken()
 	if err != nil {
@@ -101,11 +128,6 @@ func (s *AuthService) Login(ctx context.Context, input LoginInput) (*LoginResult
 	}
 
 	expiresAt := types.NewTimestamp(time.Now().Add(24 * time.Hour))
-
-	cfg, cfgErr := s.mgr.Config()
-	if cfgErr != nil {
-		return nil, fmt.Errorf("load config for session creation: %w", cfgErr)
-	}
 	ac := audited.Ctx(types.NodeID(cfg.Node_ID), user.UserID, "", input.IPAddress)
 
 	_, err = s.driver.CreateSession(ctx, ac, db.CreateSessionParams{
@@ -128,6 +150,14 @@ func (s *AuthService) Register(ctx context.Context, ac audited.AuditContext, inp
 		return nil, NewValidationError("password", "password is required")
 	}
 
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		return nil, fmt.Errorf("load config for registration: %w", err)
+	}
+	if err := checkPasswordPolicy(s.driver, cfg, "", "", input.Password); err != nil {
+		return nil, err
+	}
+
 	hash, err := auth.HashPassword(input.Password)
 	if err != nil {
 		return nil, fmt.Errorf("hash password: %w", err)
@@ -148,6 +178,7 @@ func (s *AuthService) Register(ctx context.Context, ac audited.AuditContext, inp
 	if err != nil {
 		return nil, fmt.Errorf("create user: %w", err)
 	}
+	recordPasswordChange(s.driver, cfg, user.UserID, "", hash)
 
 	return user, nil
 }
@@ -258,6 +289,14 @@ func (s *AuthService) ConfirmPasswordReset(ctx context.Context, ac audited.Audit
 		return &NotFoundError{Resource: "user", ID: string(tok.UserID.ID)}
 	}
 
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		return fmt.Errorf("load config for password reset: %w", err)
+	}
+	if err := checkPasswordPolicy(s.driver, cfg, user.UserID, user.Hash, input.Password); err != nil {
+		return err
+	}
+
 	hash, err := auth.HashPassword(input.Password)
 	if err != nil {
 		return fmt.Errorf("hash password: %w", err)
@@ -276,6 +315,9 @@ func (s *AuthService) ConfirmPasswordReset(ctx context.Context, ac audited.Audit
 	if err != nil {
 		return fmt.Errorf("update user password: %w", err)
 	}
+	recordPasswordChange(s.driver, cfg, user.UserID, user.Hash, hash)
+	// Proving control of the mailbox ends any lockout.
+	s.clearFailedLogins(user.UserID)
 
 	// Revoke the used token.
 	_, revokeErr := s.driver.UpdateToken(ctx, ac, db.UpdateTokenParams{
@@ -388,6 +430,108 @@ func (s *AuthService) HandleOAuthCallback(ctx context.Context, input OAuthCallba
 	return &OAuthCallbackResult{User: user, SessionToken: sessionToken}, nil
 }
 
+// AuthenticateOAuthBearer resolves an access token issued by the configured
+// OAuth provider to the user linked to that provider account. The token is
+// first checked at the provider's introspection endpoint, which must report
+// it active and issued to (or intended for) oauth_client_id, so tokens the
+// provider minted for other applications are refused. The account is then
+// resolved through the userinfo endpoint. Unlike the callback flow, no user
+// is provisioned: the account must already be linked by signing in through
+// OAuth once.
+func (s *AuthService) AuthenticateOAuthBearer(ctx context.Context, accessToken string) (*db.Users, error) {
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		return nil, fmt.Errorf("load config for OAuth bearer: %w", err)
+	}
+	if cfg.Oauth_Endpoint[config.OauthUserInfoURL] == "" || cfg.Oauth_Endpoint[config.OauthIntrospectionURL] == "" || cfg.Oauth_Client_Id == "" {
+		return nil, &UnauthorizedError{Message: "OAuth bearer tokens are not configured"}
+	}
+	if err := introspectOAuthToken(ctx, cfg, accessToken); err != nil {
+		utility.DefaultLogger.Finfo("OAuth bearer introspection failed", err)
+		return nil, &UnauthorizedError{Message: "OAuth access token rejected by provider"}
+	}
+
+	client := s.oauthConfig(cfg).Client(ctx, &oauth2.Token{AccessToken: accessToken, TokenType: "Bearer"})
+	provisioner := auth.NewUserProvisioner(utility.DefaultLogger, cfg, s.driver)
+	userInfo, err := provisioner.FetchUserInfo(client)
+	if err != nil {
+		return nil, &UnauthorizedError{Message: "OAuth access token rejected by provider"}
+	}
+
+	provider := cfg.Oauth_Provider_Name
+	if provider == "" {
+		provider = "oauth"
+	}
+	providerUserID := userInfo.ProviderUserID
+	if providerUserID == "" {
+		providerUserID = userInfo.Email
+	}
+
+	link, err := s.driver.GetUserOauthByProviderID(provider, providerUserID)
+	if err != nil || !link.UserID.Valid {
+		return nil, &UnauthorizedError{Message: "OAuth account is not linked to a user"}
+	}
+	user, err := s.driver.GetUser(link.UserID.ID)
+	if err != nil {
+		return nil, &UnauthorizedError{Message: "OAuth account is not linked to a user"}
+	}
+	return user, nil
+}
+
+// introspectOAuthToken asks the provider's token introspection endpoint
+// (RFC 7662) about accessToken, authenticating as the configured client. It
+// returns an error unless the token is active and its client_id or aud
+// names oauth_client_id.
+func introspectOAuthToken(ctx context.Context, cfg *config.Config, accessToken string) error {
+	form := url.Values{"token": {accessToken}, "token_type_hint": {"access_token"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.Oauth_Endpoint[config.OauthIntrospectionURL], strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("build introspection request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(cfg.Oauth_Client_Id), url.QueryEscape(cfg.Oauth_Client_Secret))
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("introspect token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("introspection request failed: %s", resp.Status)
+	}
+
+	var result struct {
+		Active   bool            `json:"active"`
+		ClientID string          `json:"client_id"`
+		Aud      json.RawMessage `json:"aud"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&result); err != nil {
+		return fmt.Errorf("decode introspection response: %w", err)
+	}
+	if !result.Active {
+		return fmt.Errorf("token is not active")
+	}
+	if result.ClientID == cfg.Oauth_Client_Id {
+		return nil
+	}
+	// aud is optional, and either a single string or an array of strings.
+	if len(result.Aud) > 0 {
+		var audiences []string
+		var single string
+		if err := json.Unmarshal(result.Aud, &single); err == nil {
+			audiences = []string{single}
+		} else if err := json.Unmarshal(result.Aud, &audiences); err != nil {
+			return fmt.Errorf("decode introspection aud: %w", err)
+		}
+		if slices.Contains(audiences, cfg.Oauth_Client_Id) {
+			return nil
+		}
+	}
+	return fmt.Errorf("token was issued to client %q, not %q", result.ClientID, cfg.Oauth_Client_Id)
+}
+
 // oauthConfig builds an oauth2.Config from the application config.
 func (s *AuthService) oauthConfig(cfg *config.Config) *oauth2.Config {
 	return &oauth2.Config{
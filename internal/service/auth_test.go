@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/config"
+)
+
+func TestIntrospectOAuthToken(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, ok := r.BasicAuth(); !ok || id != "modula" || secret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.FormValue("token") {
+		case "own":
+			w.Write([]byte(`{"active":true,"client_id":"modula"}`))
+		case "audience":
+			w.Write([]byte(`{"active":true,"client_id":"other-app","aud":["api","modula"]}`))
+		case "other-app":
+			w.Write([]byte(`{"active":true,"client_id":"other-app","aud":"other-app"}`))
+		default:
+			w.Write([]byte(`{"active":false}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := &config.Config{
+		Oauth_Client_Id:     "modula",
+		Oauth_Client_Secret: "s3cret",
+		Oauth_Endpoint:      map[config.Endpoint]string{config.OauthIntrospectionURL: srv.URL},
+	}
+	tests := []struct {
+		token   string
+		wantErr bool
+	}{
+		{"own", false},
+		{"audience", false},
+		{"other-app", true},
+		{"revoked", true},
+	}
+	for _, tt := range tests {
+		err := introspectOAuthToken(context.Background(), cfg, tt.token)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("introspectOAuthToken(%q) error = %v, wantErr %t", tt.token, err, tt.wantErr)
+		}
+	}
+
+	wrongSecret := *cfg
+	wrongSecret.Oauth_Client_Secret = "wrong"
+	if err := introspectOAuthToken(context.Background(), &wrongSecret, "own"); err == nil {
+		t.Error("introspectOAuthToken with a wrong client secret: expected error")
+	}
+}
@@ -1,22 +1,31 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hegner123/modulacms/internal/backup"
 	"github.com/hegner123/modulacms/internal/config"
 	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/email"
+	"github.com/hegner123/modulacms/internal/publishing"
+	"github.com/hegner123/modulacms/internal/utility"
+	"github.com/hegner123/modulacms/internal/webhooks"
 )
 
 // BackupService wraps backup creation and restoration with config injection.
 type BackupService struct {
-	mgr    *config.Manager
-	driver db.DbDriver
+	mgr        *config.Manager
+	driver     db.DbDriver
+	emailSvc   *email.Service
+	dispatcher publishing.WebhookDispatcher
 }
 
-// NewBackupService creates a BackupService.
-func NewBackupService(mgr *config.Manager, driver db.DbDriver) *BackupService {
-	return &BackupService{mgr: mgr, driver: driver}
+// NewBackupService creates a BackupService. emailSvc and dispatcher may be
+// nil; they are only used to report scheduled backup failures.
+func NewBackupService(mgr *config.Manager, driver db.DbDriver, emailSvc *email.Service, dispatcher publishing.WebhookDispatcher) *BackupService {
+	return &BackupService{mgr: mgr, driver: driver, emailSvc: emailSvc, dispatcher: dispatcher}
 }
 
 // CreateFullBackup creates a zip archive containing the database and any
@@ -33,3 +42,45 @@ func (s *BackupService) CreateFullBackup() (path string, sizeBytes int64, err er
 func (s *BackupService) ReadManifest(backupPath string) (*backup.BackupManifest, error) {
 	return backup.ReadManifest(backupPath)
 }
+
+// NotifyFailure reports a failed scheduled backup to admins by dispatching a
+// backup.failed webhook and emailing the backup_notify_emails addresses. It
+// is the backup.FailureNotifier passed to backup.StartScheduler.
+func (s *BackupService) NotifyFailure(ctx context.Context, f backup.Failure) {
+	if s.dispatcher != nil {
+		s.dispatcher.Dispatch(ctx, webhooks.EventBackupFailed, map[string]any{
+			"backup_id": f.BackupID.String(),
+			"stage":     f.Stage,
+			"error":     f.Err.Error(),
+		})
+	}
+
+	if s.emailSvc == nil || !s.emailSvc.Enabled() {
+		return
+	}
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		utility.DefaultLogger.Error("backup failure notification: load config", err)
+		return
+	}
+	var to []email.Address
+	for _, addr := range cfg.Backup_Notify_Emails {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			to = append(to, email.NewAddress("", addr))
+		}
+	}
+	if len(to) == 0 {
+		return
+	}
+	sendErr := s.emailSvc.Send(ctx, email.Message{
+		To:      to,
+		Subject: fmt.Sprintf("[%s] Scheduled backup failed", cfg.Node_ID),
+		PlainBody: fmt.Sprintf(
+			"The scheduled backup %s on node %s failed at %s during the %s step.\n\nError: %v\n",
+			f.BackupID, cfg.Node_ID, f.At.Format("2006-01-02 15:04:05 MST"), f.Stage, f.Err,
+		),
+	})
+	if sendErr != nil {
+		utility.DefaultLogger.Error("failed to send backup failure email", sendErr)
+	}
+}
@@ -0,0 +1,691 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/blueprint"
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/publishing"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// Blueprint is a saved blueprint with its parsed definition. List leaves
+// Definition nil.
+type Blueprint struct {
+	BlueprintID  string                `json:"blueprint_id"`
+	Name         string                `json:"name"`
+	Label        string                `json:"label"`
+	Description  string                `json:"description"`
+	Nodes        int                   `json:"nodes"`
+	Definition   *blueprint.Definition `json:"definition,omitempty"`
+	AuthorID     types.UserID          `json:"author_id"`
+	DateCreated  types.Timestamp       `json:"date_created"`
+	DateModified types.Timestamp       `json:"date_modified"`
+}
+
+// BlueprintFromContentInput saves the tree under ContentDataID as a
+// blueprint. Label defaults to Name.
+type BlueprintFromContentInput struct {
+	ContentDataID types.ContentID `json:"content_data_id"`
+	Name          string          `json:"name"`
+	Label         string          `json:"label"`
+	Description   string          `json:"description"`
+}
+
+// InstantiateBlueprintInput creates content from a blueprint. The tree is
+// appended under ParentID when set, else it becomes the root of RouteID,
+// else of a new route with Slug and Title. Status defaults to draft.
+type InstantiateBlueprintInput struct {
+	Blueprint string                  `json:"blueprint"`
+	RouteID   types.NullableRouteID   `json:"route_id"`
+	Slug      string                  `json:"slug"`
+	Title     string                  `json:"title"`
+	ParentID  types.NullableContentID `json:"parent_id"`
+	Status    types.ContentStatus     `json:"status"`
+	Variables map[string]string       `json:"variables"`
+}
+
+// BlueprintInstantiation reports the content created from a blueprint.
+// Keys maps the definition's node keys to the new content IDs.
+type BlueprintInstantiation struct {
+	BlueprintID   string                     `json:"blueprint_id"`
+	ContentDataID types.ContentID            `json:"content_data_id"`
+	RouteID       types.NullableRouteID      `json:"route_id"`
+	Nodes         int                        `json:"nodes"`
+	Keys          map[string]types.ContentID `json:"keys,omitempty"`
+}
+
+// BlueprintInstanceReport is one content tree created from a blueprint.
+type BlueprintInstanceReport struct {
+	db.BlueprintInstance
+	BlueprintName string `json:"blueprint_name"`
+}
+
+// BlueprintService manages blueprints: reusable content subtrees with
+// placeholder field values, saved from existing content or from definition
+// files, and instantiated as new content trees.
+type BlueprintService struct {
+	driver  db.DbDriver
+	mgr     *config.Manager
+	content *ContentService
+	routes  *RouteService
+}
+
+// NewBlueprintService creates a BlueprintService.
+func NewBlueprintService(driver db.DbDriver, mgr *config.Manager, content *ContentService, routes *RouteService) *BlueprintService {
+	return &BlueprintService{driver: driver, mgr: mgr, content: content, routes: routes}
+}
+
+// blueprintSchema is the datatype and fields a definition node refers to.
+type blueprintSchema struct {
+	datatype db.Datatypes
+	fields   []db.Fields
+	byName   map[string]db.Fields
+}
+
+// List returns every blueprint, without definitions, ordered by name.
+func (s *BlueprintService) List(ctx context.Context) ([]Blueprint, error) {
+	rows, err := s.driver.ListBlueprints()
+	if err != nil {
+		return nil, fmt.Errorf("list blueprints: %w", err)
+	}
+	out := []Blueprint{}
+	if rows == nil {
+		return out, nil
+	}
+	for _, row := range *rows {
+		bp, err := toBlueprint(row)
+		if err != nil {
+			return nil, err
+		}
+		bp.Definition = nil
+		out = append(out, *bp)
+	}
+	return out, nil
+}
+
+// Get returns a blueprint by ID or name.
+func (s *BlueprintService) Get(ctx context.Context, idOrName string) (*Blueprint, error) {
+	row, err := s.lookup(idOrName)
+	if err != nil {
+		return nil, err
+	}
+	return toBlueprint(*row)
+}
+
+// Create saves a blueprint from a definition. Every datatype and field the
+// definition names must exist.
+func (s *BlueprintService) Create(ctx context.Context, ac audited.AuditContext, def *blueprint.Definition) (*Blueprint, error) {
+	if def == nil {
+		return nil, NewValidationError("definition", "required")
+	}
+	if def.Label == "" {
+		def.Label = def.Name
+	}
+	if err := def.Validate(); err != nil {
+		return nil, NewValidationError("definition", err.Error())
+	}
+	if _, err := s.loadSchemas(def); err != nil {
+		return nil, err
+	}
+	if _, err := s.driver.GetBlueprintByName(def.Name); err == nil {
+		return nil, &ConflictError{Resource: "blueprint", ID: def.Name, Detail: "a blueprint with this name already exists"}
+	}
+	data, err := blueprint.Marshal(def)
+	if err != nil {
+		return nil, fmt.Errorf("encode blueprint: %w", err)
+	}
+	now := types.TimestampNow()
+	row, err := s.driver.CreateBlueprint(db.CreateBlueprintParams{
+		BlueprintID:  types.NewULID().String(),
+		Name:         def.Name,
+		Label:        def.Label,
+		Description:  def.Description,
+		Definition:   string(data),
+		AuthorID:     ac.UserID,
+		DateCreated:  now,
+		DateModified: now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create blueprint: %w", err)
+	}
+	return toBlueprint(*row)
+}
+
+// CreateFromContent saves the tree under a content node as a blueprint. Field
+// values are taken from the default locale, or the unlocalized value when the
+// default locale has none. "_id" values pointing inside the tree become node
+// references, so instances point at their own nodes. The blueprint declares
+// no variables; edit the definition to add placeholders.
+func (s *BlueprintService) CreateFromContent(ctx context.Context, ac audited.AuditContext, in BlueprintFromContentInput) (*Blueprint, error) {
+	if in.ContentDataID.IsZero() {
+		return nil, NewValidationError("content_data_id", "required")
+	}
+	nodes, err := s.driver.GetContentDataDescendants(ctx, in.ContentDataID)
+	if err != nil || nodes == nil || len(*nodes) == 0 {
+		return nil, &NotFoundError{Resource: "content_data", ID: string(in.ContentDataID)}
+	}
+	if len(*nodes) > blueprint.MaxNodes {
+		return nil, NewValidationError("content_data_id", fmt.Sprintf("tree has %d nodes, more than the %d a blueprint allows", len(*nodes), blueprint.MaxNodes))
+	}
+	byID := make(map[types.ContentID]db.ContentData, len(*nodes))
+	for _, n := range *nodes {
+		byID[n.ContentDataID] = n
+	}
+
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	defaultLocale := ""
+	if cfg.I18nEnabled() {
+		defaultLocale = cfg.I18nDefaultLocale()
+	}
+
+	// Node keys are assigned in pre-order, so the same tree always produces
+	// the same definition.
+	keys := make(map[types.ContentID]string, len(byID))
+	order := orderSubtree(in.ContentDataID, byID)
+	for i, id := range order {
+		keys[id] = fmt.Sprintf("n%d", i+1)
+	}
+
+	datatypeNames := make(map[types.DatatypeID]string)
+	fieldDefs := make(map[types.DatatypeID]map[types.FieldID]db.Fields)
+	referenced := make(map[types.ContentID]bool)
+	built := make(map[types.ContentID]*blueprint.Node, len(order))
+	for _, id := range order {
+		cd := byID[id]
+		if !cd.DatatypeID.Valid {
+			return nil, NewValidationError("content_data_id", fmt.Sprintf("content %s has no datatype", id))
+		}
+		dtID := cd.DatatypeID.ID
+		if _, ok := datatypeNames[dtID]; !ok {
+			dt, err := s.driver.GetDatatype(dtID)
+			if err != nil {
+				return nil, &NotFoundError{Resource: "datatype", ID: string(dtID)}
+			}
+			datatypeNames[dtID] = dt.Name
+			defs := make(map[types.FieldID]db.Fields)
+			fields, err := s.driver.ListFieldsByDatatypeID(types.NullableDatatypeID{ID: dtID, Valid: true})
+			if err != nil {
+				return nil, fmt.Errorf("list fields of datatype %s: %w", dtID, err)
+			}
+			if fields != nil {
+				for _, f := range *fields {
+					defs[f.FieldID] = f
+				}
+			}
+			fieldDefs[dtID] = defs
+		}
+
+		node := &blueprint.Node{Datatype: datatypeNames[dtID], Fields: map[string]string{}}
+		rows, err := s.driver.ListContentFieldsByContentData(types.NullableContentID{ID: id, Valid: true})
+		if err != nil {
+			return nil, fmt.Errorf("list content fields of %s: %w", id, err)
+		}
+		if rows != nil {
+			for fieldID, value := range publishing.SourceValues(*rows, defaultLocale) {
+				def, ok := fieldDefs[dtID][fieldID]
+				if !ok || value == "" {
+					continue
+				}
+				if def.Type == types.FieldTypeIDRef {
+					if _, inside := byID[types.ContentID(value)]; inside {
+						referenced[types.ContentID(value)] = true
+						value = blueprint.Ref(keys[types.ContentID(value)])
+					}
+				}
+				node.Fields[def.Name] = value
+			}
+		}
+		built[id] = node
+	}
+
+	for id, node := range built {
+		if referenced[id] {
+			node.Key = keys[id]
+		}
+	}
+	var assemble func(id types.ContentID) blueprint.Node
+	assemble = func(id types.ContentID) blueprint.Node {
+		node := *built[id]
+		for _, child := range childOrder(byID[id], byID) {
+			node.Children = append(node.Children, assemble(child))
+		}
+		return node
+	}
+
+	label := in.Label
+	if label == "" {
+		label = in.Name
+	}
+	return s.Create(ctx, ac, &blueprint.Definition{
+		Name:        in.Name,
+		Label:       label,
+		Description: in.Description,
+		Root:        assemble(in.ContentDataID),
+	})
+}
+
+// Update replaces a blueprint's definition. Renaming is allowed when the new
+// name is free. Existing instances are not changed.
+func (s *BlueprintService) Update(ctx context.Context, ac audited.AuditContext, idOrName string, def *blueprint.Definition) (*Blueprint, error) {
+	if def == nil {
+		return nil, NewValidationError("definition", "required")
+	}
+	existing, err := s.lookup(idOrName)
+	if err != nil {
+		return nil, err
+	}
+	if def.Label == "" {
+		def.Label = def.Name
+	}
+	if err := def.Validate(); err != nil {
+		return nil, NewValidationError("definition", err.Error())
+	}
+	if _, err := s.loadSchemas(def); err != nil {
+		return nil, err
+	}
+	if other, err := s.driver.GetBlueprintByName(def.Name); err == nil && other.BlueprintID != existing.BlueprintID {
+		return nil, &ConflictError{Resource: "blueprint", ID: def.Name, Detail: "a blueprint with this name already exists"}
+	}
+	data, err := blueprint.Marshal(def)
+	if err != nil {
+		return nil, fmt.Errorf("encode blueprint: %w", err)
+	}
+	if err := s.driver.UpdateBlueprint(db.UpdateBlueprintParams{
+		Name:         def.Name,
+		Label:        def.Label,
+		Description:  def.Description,
+		Definition:   string(data),
+		DateModified: types.TimestampNow(),
+		BlueprintID:  existing.BlueprintID,
+	}); err != nil {
+		return nil, fmt.Errorf("update blueprint: %w", err)
+	}
+	return s.Get(ctx, existing.BlueprintID)
+}
+
+// Delete removes a blueprint and its instance links. Content created from it
+// is kept.
+func (s *BlueprintService) Delete(ctx context.Context, ac audited.AuditContext, idOrName string) error {
+	existing, err := s.lookup(idOrName)
+	if err != nil {
+		return err
+	}
+	if err := s.driver.DeleteBlueprintInstancesByBlueprint(existing.BlueprintID); err != nil {
+		return fmt.Errorf("delete blueprint instances: %w", err)
+	}
+	if err := s.driver.DeleteBlueprint(existing.BlueprintID); err != nil {
+		return fmt.Errorf("delete blueprint: %w", err)
+	}
+	return nil
+}
+
+// Instances reports the content trees created from a blueprint, or from any
+// blueprint when idOrName is empty. Trees whose root was deleted are left
+// out.
+func (s *BlueprintService) Instances(ctx context.Context, idOrName string) ([]BlueprintInstanceReport, error) {
+	var rows *[]db.BlueprintInstance
+	if idOrName != "" {
+		bp, err := s.lookup(idOrName)
+		if err != nil {
+			return nil, err
+		}
+		rows, err = s.driver.ListBlueprintInstancesByBlueprint(bp.BlueprintID)
+		if err != nil {
+			return nil, fmt.Errorf("list blueprint instances: %w", err)
+		}
+	} else {
+		var err error
+		rows, err = s.driver.ListBlueprintInstances()
+		if err != nil {
+			return nil, fmt.Errorf("list blueprint instances: %w", err)
+		}
+	}
+	out := []BlueprintInstanceReport{}
+	if rows == nil {
+		return out, nil
+	}
+	names := make(map[string]string)
+	for _, row := range *rows {
+		name, ok := names[row.BlueprintID]
+		if !ok {
+			if bp, err := s.driver.GetBlueprint(row.BlueprintID); err == nil {
+				name = bp.Name
+			}
+			names[row.BlueprintID] = name
+		}
+		out = append(out, BlueprintInstanceReport{BlueprintInstance: row, BlueprintName: name})
+	}
+	return out, nil
+}
+
+// Instantiate creates a content tree from a blueprint in one transaction:
+// every node gets a new ID, placeholders are replaced by the variables and
+// the new node IDs, and every field of each datatype gets a row. The new
+// root is linked back to the blueprint. When a route is created for Slug it
+// is removed again if the content cannot be written.
+func (s *BlueprintService) Instantiate(ctx context.Context, ac audited.AuditContext, in InstantiateBlueprintInput) (*BlueprintInstantiation, error) {
+	if in.Blueprint == "" {
+		return nil, NewValidationError("blueprint", "required")
+	}
+	row, err := s.lookup(in.Blueprint)
+	if err != nil {
+		return nil, err
+	}
+	def, err := blueprint.Parse([]byte(row.Definition))
+	if err != nil {
+		return nil, fmt.Errorf("stored blueprint %s: %w", row.Name, err)
+	}
+	vars, err := def.Resolve(in.Variables)
+	if err != nil {
+		return nil, NewValidationError("variables", err.Error())
+	}
+	schemas, err := s.loadSchemas(def)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	defaultLocale := ""
+	if cfg.I18nEnabled() {
+		defaultLocale = cfg.I18nDefaultLocale()
+	}
+
+	// Validate the expanded values before writing anything. Node references
+	// are checked against stand-in IDs of the right shape.
+	standIns := make(map[string]string)
+	def.Walk(func(n *blueprint.Node, _ *blueprint.Node) {
+		if n.Key != "" {
+			standIns[n.Key] = string(types.NewContentID())
+		}
+	})
+	var invalid error
+	def.Walk(func(n *blueprint.Node, _ *blueprint.Node) {
+		sc := schemas[n.Datatype]
+		for _, f := range sc.fields {
+			value := blueprint.Expand(n.Fields[f.Name], vars, standIns)
+			if invalid != nil || value == "" {
+				continue
+			}
+			if verr := s.content.validateContentFieldValue(&f, value); verr != nil {
+				invalid = verr
+			}
+		}
+	})
+	if invalid != nil {
+		return nil, invalid
+	}
+
+	routeID, err := s.instanceRoute(in)
+	if err != nil {
+		return nil, err
+	}
+	var created *db.Routes
+	if !routeID.Valid && !in.ParentID.Valid {
+		created, err = s.routes.CreateRoute(ctx, ac, CreateRouteInput{
+			Slug:     in.Slug,
+			Title:    in.Title,
+			AuthorID: types.NullableUserID{ID: ac.UserID, Valid: !ac.UserID.IsZero()},
+		})
+		if err != nil {
+			return nil, err
+		}
+		routeID = types.NullableRouteID{ID: created.RouteID, Valid: true}
+	}
+
+	// Mirror the definition as the tree insertSubtree writes.
+	nodes := make(map[*blueprint.Node]*subtreeNode)
+	var root *subtreeNode
+	def.Walk(func(n *blueprint.Node, parent *blueprint.Node) {
+		sn := &subtreeNode{
+			datatypeID: types.NullableDatatypeID{ID: schemas[n.Datatype].datatype.DatatypeID, Valid: true},
+			status:     in.Status,
+		}
+		nodes[n] = sn
+		if parent == nil {
+			root = sn
+			return
+		}
+		nodes[parent].children = append(nodes[parent].children, sn)
+	})
+
+	variables, err := json.Marshal(vars)
+	if err != nil {
+		return nil, fmt.Errorf("encode variables: %w", err)
+	}
+	result := &BlueprintInstantiation{BlueprintID: row.BlueprintID, Nodes: len(nodes)}
+	err = s.writeInstance(ctx, ac, def, schemas, nodes, root, routeID, in.ParentID, vars, defaultLocale, func(tx *sql.Tx) error {
+		return db.CreateBlueprintInstanceInTx(s.driver, ctx, tx, db.CreateBlueprintInstanceParams{
+			ContentDataID: root.id,
+			BlueprintID:   row.BlueprintID,
+			RouteID:       routeID,
+			Variables:     string(variables),
+			AuthorID:      ac.UserID,
+			DateCreated:   types.TimestampNow(),
+		})
+	})
+	if err != nil {
+		if created != nil {
+			if delErr := s.routes.DeleteRoute(ctx, ac, created.RouteID); delErr != nil {
+				utility.DefaultLogger.Warn("blueprint: remove route of failed instance", delErr, "route_id", created.RouteID)
+			}
+		}
+		return nil, err
+	}
+
+	result.ContentDataID = root.id
+	result.RouteID = routeID
+	for n, sn := range nodes {
+		if n.Key != "" {
+			if result.Keys == nil {
+				result.Keys = make(map[string]types.ContentID)
+			}
+			result.Keys[n.Key] = sn.id
+		}
+	}
+	return result, nil
+}
+
+// writeInstance writes the tree, its fields and, through link, the instance
+// record in one transaction.
+func (s *BlueprintService) writeInstance(
+	ctx context.Context,
+	ac audited.AuditContext,
+	def *blueprint.Definition,
+	schemas map[string]*blueprintSchema,
+	nodes map[*blueprint.Node]*subtreeNode,
+	root *subtreeNode,
+	routeID types.NullableRouteID,
+	parentID types.NullableContentID,
+	vars map[string]string,
+	defaultLocale string,
+	link func(tx *sql.Tx) error,
+) error {
+	conn, _, err := s.driver.GetConnection()
+	if err != nil {
+		return fmt.Errorf("instantiate blueprint: get connection: %w", err)
+	}
+	return types.WithTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if err := insertSubtree(ctx, s.driver, tx, ac, root, routeID, parentID, ac.UserID); err != nil {
+			return err
+		}
+		rootRow, err := db.GetContentDataInTx(s.driver, ctx, tx, root.id)
+		if err != nil {
+			return err
+		}
+		ids := make(map[string]string)
+		for n, sn := range nodes {
+			if n.Key != "" {
+				ids[n.Key] = string(sn.id)
+			}
+		}
+		now := types.TimestampNow()
+		var fieldErr error
+		def.Walk(func(n *blueprint.Node, _ *blueprint.Node) {
+			for _, f := range schemas[n.Datatype].fields {
+				if fieldErr != nil {
+					return
+				}
+				locale := ""
+				if defaultLocale != "" && f.Translatable {
+					locale = defaultLocale
+				}
+				_, fieldErr = db.CreateContentFieldInTx(s.driver, ctx, tx, ac, db.CreateContentFieldParams{
+					RouteID:       routeID,
+					RootID:        rootRow.RootID,
+					ContentDataID: types.NullableContentID{ID: nodes[n].id, Valid: true},
+					FieldID:       types.NullableFieldID{ID: f.FieldID, Valid: true},
+					FieldValue:    blueprint.Expand(n.Fields[f.Name], vars, ids),
+					Locale:        locale,
+					AuthorID:      ac.UserID,
+					DateCreated:   now,
+					DateModified:  now,
+				})
+			}
+		})
+		if fieldErr != nil {
+			return fmt.Errorf("create content field: %w", fieldErr)
+		}
+		return link(tx)
+	})
+}
+
+// instanceRoute resolves the route an instance is created in. It is invalid
+// when a new route must be created for in.Slug.
+func (s *BlueprintService) instanceRoute(in InstantiateBlueprintInput) (types.NullableRouteID, error) {
+	if in.ParentID.Valid || in.RouteID.Valid {
+		return subtreeRoute(s.driver, in.ParentID, in.RouteID)
+	}
+	if strings.TrimSpace(in.Slug) == "" {
+		return types.NullableRouteID{}, NewValidationError("route_id", "route_id, slug or parent_id is required")
+	}
+	return types.NullableRouteID{}, nil
+}
+
+// loadSchemas resolves the datatypes of def's nodes and checks that every
+// field they set exists.
+func (s *BlueprintService) loadSchemas(def *blueprint.Definition) (map[string]*blueprintSchema, error) {
+	schemas := make(map[string]*blueprintSchema)
+	var loadErr error
+	def.Walk(func(n *blueprint.Node, _ *blueprint.Node) {
+		if loadErr != nil {
+			return
+		}
+		sc, ok := schemas[n.Datatype]
+		if !ok {
+			dt, err := s.driver.GetDatatypeByName(n.Datatype)
+			if err != nil {
+				loadErr = NewValidationError("definition", fmt.Sprintf("unknown datatype %q", n.Datatype))
+				return
+			}
+			fields, err := s.driver.ListFieldsByDatatypeID(types.NullableDatatypeID{ID: dt.DatatypeID, Valid: true})
+			if err != nil {
+				loadErr = fmt.Errorf("list fields of datatype %s: %w", dt.Name, err)
+				return
+			}
+			sc = &blueprintSchema{datatype: *dt, byName: make(map[string]db.Fields)}
+			if fields != nil {
+				sc.fields = *fields
+				for _, f := range *fields {
+					sc.byName[f.Name] = f
+				}
+			}
+			schemas[n.Datatype] = sc
+		}
+		for name := range n.Fields {
+			if _, ok := sc.byName[name]; !ok {
+				loadErr = NewValidationError("definition", fmt.Sprintf("datatype %q has no field %q", n.Datatype, name))
+				return
+			}
+		}
+	})
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	return schemas, nil
+}
+
+// lookup finds a blueprint by ID, then by name.
+func (s *BlueprintService) lookup(idOrName string) (*db.Blueprint, error) {
+	if idOrName == "" {
+		return nil, NewValidationError("blueprint", "required")
+	}
+	row, err := s.driver.GetBlueprint(idOrName)
+	if err == nil {
+		return row, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("get blueprint: %w", err)
+	}
+	row, err = s.driver.GetBlueprintByName(idOrName)
+	if err != nil {
+		return nil, &NotFoundError{Resource: "blueprint", ID: idOrName}
+	}
+	return row, nil
+}
+
+func toBlueprint(row db.Blueprint) (*Blueprint, error) {
+	def, err := blueprint.Parse([]byte(row.Definition))
+	if err != nil {
+		return nil, fmt.Errorf("stored blueprint %s: %w", row.Name, err)
+	}
+	return &Blueprint{
+		BlueprintID:  row.BlueprintID,
+		Name:         row.Name,
+		Label:        row.Label,
+		Description:  row.Description,
+		Nodes:        def.Count(),
+		Definition:   def,
+		AuthorID:     row.AuthorID,
+		DateCreated:  row.DateCreated,
+		DateModified: row.DateModified,
+	}, nil
+}
+
+// orderSubtree returns the IDs of the tree under rootID in pre-order,
+// following first-child and next-sibling pointers.
+func orderSubtree(rootID types.ContentID, byID map[types.ContentID]db.ContentData) []types.ContentID {
+	var out []types.ContentID
+	var visit func(id types.ContentID)
+	visit = func(id types.ContentID) {
+		out = append(out, id)
+		for _, child := range childOrder(byID[id], byID) {
+			visit(child)
+		}
+	}
+	visit(rootID)
+	return out
+}
+
+// childOrder returns the children of cd in sibling order. Children that are
+// not reachable from the first child, e.g. after a broken splice, are left
+// out; heal the tree first to keep them.
+func childOrder(cd db.ContentData, byID map[types.ContentID]db.ContentData) []types.ContentID {
+	var out []types.ContentID
+	seen := make(map[types.ContentID]bool)
+	next := cd.FirstChildID
+	for next.Valid && !seen[next.ID] {
+		child, ok := byID[next.ID]
+		if !ok || child.ParentID.ID != cd.ContentDataID {
+			break
+		}
+		seen[next.ID] = true
+		out = append(out, next.ID)
+		next = child.NextSiblingID
+	}
+	return out
+}
@@ -0,0 +1,276 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/blueprint"
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/service"
+)
+
+// testBlueprintDB creates a database with a "page" root datatype (title) and
+// a "hero" datatype (heading, and an _id field "link").
+func testBlueprintDB(t *testing.T) (db.Database, *service.BlueprintService, audited.AuditContext) {
+	t.Helper()
+	conn, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "blueprints.db"))
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	cfg := config.Config{Node_ID: types.NewNodeID().String()}
+	d := db.Database{Connection: conn, Context: context.Background(), Config: cfg}
+	if err := d.CreateAllTables(); err != nil {
+		t.Fatalf("CreateAllTables: %v", err)
+	}
+	mgr := config.NewManager(&staticProvider{cfg: &cfg})
+	if err := mgr.Load(); err != nil {
+		t.Fatalf("mgr.Load: %v", err)
+	}
+
+	ctx := context.Background()
+	userID := seedUser(t, d)
+	ac := audited.Ctx(types.NodeID(cfg.Node_ID), userID, "test", "127.0.0.1")
+	now := types.TimestampNow()
+
+	datatypes := []struct {
+		name, typ string
+		fields    []db.CreateFieldParams
+	}{
+		{"page", string(types.DatatypeTypeRoot), []db.CreateFieldParams{
+			{Name: "title", Label: "Title", Type: types.FieldTypeText},
+		}},
+		{"hero", "hero", []db.CreateFieldParams{
+			{Name: "heading", Label: "Heading", Type: types.FieldTypeText},
+			{Name: "link", Label: "Link", Type: types.FieldTypeIDRef, SortOrder: 1},
+		}},
+	}
+	for _, spec := range datatypes {
+		dt, err := d.CreateDatatype(ctx, ac, db.CreateDatatypeParams{
+			Name:         spec.name,
+			Label:        spec.name,
+			Type:         spec.typ,
+			AuthorID:     userID,
+			DateCreated:  now,
+			DateModified: now,
+		})
+		if err != nil {
+			t.Fatalf("CreateDatatype %s: %v", spec.name, err)
+		}
+		for _, f := range spec.fields {
+			f.ParentID = types.NullableDatatypeID{ID: dt.DatatypeID, Valid: true}
+			f.AuthorID = types.NullableUserID{ID: userID, Valid: true}
+			f.DateCreated = now
+			f.DateModified = now
+			if _, err := d.CreateField(ctx, ac, f); err != nil {
+				t.Fatalf("CreateField %s: %v", f.Name, err)
+			}
+		}
+	}
+
+	content := service.NewContentService(d, mgr, noopDispatcher{})
+	routes := service.NewRouteService(d, mgr)
+	return d, service.NewBlueprintService(d, mgr, content, routes), ac
+}
+
+// fieldValues maps field names to the values stored for a content node.
+func fieldValues(t *testing.T, d db.Database, id types.ContentID) map[string]string {
+	t.Helper()
+	rows, err := d.ListContentFieldsByContentData(types.NullableContentID{ID: id, Valid: true})
+	if err != nil {
+		t.Fatalf("ListContentFieldsByContentData: %v", err)
+	}
+	out := map[string]string{}
+	for _, row := range *rows {
+		f, err := d.GetField(row.FieldID.ID)
+		if err != nil {
+			t.Fatalf("GetField: %v", err)
+		}
+		out[f.Name] = row.FieldValue
+	}
+	return out
+}
+
+func TestBlueprintService_Instantiate(t *testing.T) {
+	d, svc, ac := testBlueprintDB(t)
+	ctx := context.Background()
+
+	def := &blueprint.Definition{
+		Name:      "landing",
+		Variables: []blueprint.Variable{{Name: "title", Required: true}},
+		Root: blueprint.Node{
+			Datatype: "page",
+			Fields:   map[string]string{"title": "{{title}}"},
+			Children: []blueprint.Node{
+				{Key: "first", Datatype: "hero", Fields: map[string]string{"heading": "{{title}} one"}},
+				{Datatype: "hero", Fields: map[string]string{"heading": "two", "link": "{{@first}}"}},
+				{Datatype: "hero"},
+			},
+		},
+	}
+	bp, err := svc.Create(ctx, ac, def)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if bp.Nodes != 4 || bp.Label != "landing" {
+		t.Errorf("Create = %+v", bp)
+	}
+	if _, err := svc.Create(ctx, ac, def); err == nil {
+		t.Error("duplicate name should conflict")
+	}
+	bad := &blueprint.Definition{Name: "bad", Root: blueprint.Node{Datatype: "page", Fields: map[string]string{"nope": "x"}}}
+	if _, err := svc.Create(ctx, ac, bad); !service.IsValidation(err) {
+		t.Errorf("unknown field: err = %v, want validation error", err)
+	}
+
+	if _, err := svc.Instantiate(ctx, ac, service.InstantiateBlueprintInput{Blueprint: "landing", Slug: "/spring"}); !service.IsValidation(err) {
+		t.Errorf("missing variable: err = %v, want validation error", err)
+	}
+	if n := routeCount(t, d); n != 0 {
+		t.Errorf("routes after rejected instantiate = %d, want 0", n)
+	}
+
+	res, err := svc.Instantiate(ctx, ac, service.InstantiateBlueprintInput{
+		Blueprint: "landing",
+		Slug:      "/spring",
+		Title:     "Spring",
+		Variables: map[string]string{"title": "Spring sale"},
+	})
+	if err != nil {
+		t.Fatalf("Instantiate: %v", err)
+	}
+	if res.Nodes != 4 || !res.RouteID.Valid {
+		t.Fatalf("Instantiate = %+v", res)
+	}
+
+	root, err := d.GetContentData(res.ContentDataID)
+	if err != nil {
+		t.Fatalf("GetContentData root: %v", err)
+	}
+	if root.ParentID.Valid || root.RootID.ID != root.ContentDataID || root.RouteID != res.RouteID {
+		t.Errorf("root pointers: %+v", root)
+	}
+	if got := fieldValues(t, d, root.ContentDataID)["title"]; got != "Spring sale" {
+		t.Errorf("root title = %q", got)
+	}
+
+	// Walk the children through the sibling chain.
+	var children []db.ContentData
+	prev := types.NullableContentID{}
+	for next := root.FirstChildID; next.Valid; {
+		child, err := d.GetContentData(next.ID)
+		if err != nil {
+			t.Fatalf("GetContentData child: %v", err)
+		}
+		if child.ParentID.ID != root.ContentDataID || child.PrevSiblingID != prev || child.RootID.ID != root.ContentDataID {
+			t.Errorf("child %d pointers: %+v", len(children), child)
+		}
+		children = append(children, *child)
+		prev = types.NullableContentID{ID: child.ContentDataID, Valid: true}
+		next = child.NextSiblingID
+	}
+	if len(children) != 3 {
+		t.Fatalf("children = %d, want 3", len(children))
+	}
+	if got := fieldValues(t, d, children[0].ContentDataID)["heading"]; got != "Spring sale one" {
+		t.Errorf("first heading = %q", got)
+	}
+	second := fieldValues(t, d, children[1].ContentDataID)
+	if second["link"] != string(children[0].ContentDataID) || res.Keys["first"] != children[0].ContentDataID {
+		t.Errorf("link = %q, keys = %v, want %s", second["link"], res.Keys, children[0].ContentDataID)
+	}
+	if third := fieldValues(t, d, children[2].ContentDataID); len(third) != 2 || third["heading"] != "" {
+		t.Errorf("unset fields should be created empty, got %v", third)
+	}
+
+	if _, err := svc.Instantiate(ctx, ac, service.InstantiateBlueprintInput{
+		Blueprint: bp.BlueprintID,
+		RouteID:   res.RouteID,
+		Variables: map[string]string{"title": "Again"},
+	}); err == nil {
+		t.Error("instantiating at the root of an occupied route should conflict")
+	}
+
+	nested, err := svc.Instantiate(ctx, ac, service.InstantiateBlueprintInput{
+		Blueprint: "landing",
+		ParentID:  types.NullableContentID{ID: root.ContentDataID, Valid: true},
+		Variables: map[string]string{"title": "Nested"},
+	})
+	if err != nil {
+		t.Fatalf("Instantiate under parent: %v", err)
+	}
+	last, err := d.GetContentData(nested.ContentDataID)
+	if err != nil {
+		t.Fatalf("GetContentData nested: %v", err)
+	}
+	if last.ParentID.ID != root.ContentDataID || last.PrevSiblingID.ID != children[2].ContentDataID ||
+		last.RootID.ID != root.ContentDataID || last.RouteID != res.RouteID {
+		t.Errorf("nested pointers: %+v", last)
+	}
+	if again, _ := d.GetContentData(root.ContentDataID); again.RootID.ID != root.ContentDataID {
+		t.Errorf("appending a child cleared the parent's root_id: %+v", again)
+	}
+
+	instances, err := svc.Instances(ctx, "landing")
+	if err != nil {
+		t.Fatalf("Instances: %v", err)
+	}
+	if len(instances) != 2 || instances[0].BlueprintName != "landing" {
+		t.Errorf("Instances = %+v", instances)
+	}
+}
+
+func TestBlueprintService_CreateFromContent(t *testing.T) {
+	d, svc, ac := testBlueprintDB(t)
+	ctx := context.Background()
+
+	if _, err := svc.Create(ctx, ac, &blueprint.Definition{
+		Name: "source",
+		Root: blueprint.Node{
+			Datatype: "page",
+			Fields:   map[string]string{"title": "Home"},
+			Children: []blueprint.Node{
+				{Key: "a", Datatype: "hero", Fields: map[string]string{"heading": "A"}},
+				{Datatype: "hero", Fields: map[string]string{"heading": "B", "link": "{{@a}}"}},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	res, err := svc.Instantiate(ctx, ac, service.InstantiateBlueprintInput{Blueprint: "source", Slug: "/home", Title: "Home"})
+	if err != nil {
+		t.Fatalf("Instantiate: %v", err)
+	}
+
+	saved, err := svc.CreateFromContent(ctx, ac, service.BlueprintFromContentInput{ContentDataID: res.ContentDataID, Name: "copy"})
+	if err != nil {
+		t.Fatalf("CreateFromContent: %v", err)
+	}
+	root := saved.Definition.Root
+	if root.Datatype != "page" || root.Fields["title"] != "Home" || len(root.Children) != 2 {
+		t.Fatalf("root = %+v", root)
+	}
+	first, second := root.Children[0], root.Children[1]
+	if first.Fields["heading"] != "A" || second.Fields["heading"] != "B" {
+		t.Errorf("children out of order: %+v", root.Children)
+	}
+	if first.Key == "" || second.Fields["link"] != blueprint.Ref(first.Key) {
+		t.Errorf("internal reference not rewritten: key %q, link %q", first.Key, second.Fields["link"])
+	}
+
+	if err := svc.Delete(ctx, ac, "source"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := svc.Get(ctx, "source"); err == nil {
+		t.Error("deleted blueprint still found")
+	}
+	if _, err := d.GetContentData(res.ContentDataID); err != nil {
+		t.Errorf("content of a deleted blueprint should be kept: %v", err)
+	}
+}
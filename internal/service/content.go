@@ -67,6 +67,7 @@ func (s *ContentService) UpdatePointers(ctx context.Context, ac audited.AuditCon
 		FirstChildID:  nullableToContentID(ptrs.FirstChildID),
 		NextSiblingID: nullableToContentID(ptrs.NextSiblingID),
 		PrevSiblingID: nullableToContentID(ptrs.PrevSiblingID),
+		RootID:        cd.RootID,
 		RouteID:       cd.RouteID,
 		DatatypeID:    cd.DatatypeID,
 		AuthorID:      cd.AuthorID,
@@ -128,6 +129,7 @@ func (tb *txContentBackend) UpdatePointers(ctx context.Context, ac audited.Audit
 		FirstChildID:  nullableToContentID(ptrs.FirstChildID),
 		NextSiblingID: nullableToContentID(ptrs.NextSiblingID),
 		PrevSiblingID: nullableToContentID(ptrs.PrevSiblingID),
+		RootID:        cd.RootID,
 		RouteID:       cd.RouteID,
 		DatatypeID:    cd.DatatypeID,
 		AuthorID:      cd.AuthorID,
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/publishing"
+)
+
+// maxCloneNodes bounds the size of a tree CloneTree copies, matching the
+// limit of recursive deletes.
+const maxCloneNodes = 1000
+
+// CloneTreeInput deep-copies the tree under SourceID. The copy is appended
+// under ParentID when set, else it becomes the root of RouteID, which must
+// have no content; with neither it is appended under the source's parent.
+//
+// Without Locale every field row is copied. With Locale the copy holds that
+// locale: translatable fields get a row in Locale with the value of
+// SourceLocale (default: the default locale), untranslated fields are copied
+// and rows of other locales are left behind. Status defaults to draft.
+type CloneTreeInput struct {
+	SourceID     types.ContentID         `json:"source_id"`
+	ParentID     types.NullableContentID `json:"parent_id"`
+	RouteID      types.NullableRouteID   `json:"route_id"`
+	Locale       string                  `json:"locale"`
+	SourceLocale string                  `json:"source_locale"`
+	Status       types.ContentStatus     `json:"status"`
+}
+
+// CloneTreeResult reports a cloned tree. IDs maps each source node to its
+// copy.
+type CloneTreeResult struct {
+	ContentDataID types.ContentID                     `json:"content_data_id"`
+	RouteID       types.NullableRouteID               `json:"route_id"`
+	Nodes         int                                 `json:"nodes"`
+	Fields        int                                 `json:"fields"`
+	Relations     int                                 `json:"relations"`
+	IDs           map[types.ContentID]types.ContentID `json:"ids"`
+}
+
+// cloneField is a content field row to write for a cloned node. source is the
+// row it was copied from, when its translation basis carries over.
+type cloneField struct {
+	fieldID types.NullableFieldID
+	value   string
+	locale  string
+	source  types.ContentFieldID
+}
+
+// CloneTree copies the tree under in.SourceID with new IDs in one audited
+// transaction. Sibling order is kept, "_id" field values and content
+// relations that point inside the tree are rewritten to the copies, and
+// references to content outside the tree are kept as they are.
+func (s *ContentService) CloneTree(ctx context.Context, ac audited.AuditContext, in CloneTreeInput) (*CloneTreeResult, error) {
+	if in.SourceID.IsZero() {
+		return nil, NewValidationError("source_id", "required")
+	}
+	nodes, err := s.driver.GetContentDataDescendants(ctx, in.SourceID)
+	if err != nil || nodes == nil || len(*nodes) == 0 {
+		return nil, &NotFoundError{Resource: "content_data", ID: string(in.SourceID)}
+	}
+	if len(*nodes) > maxCloneNodes {
+		return nil, NewValidationError("source_id", fmt.Sprintf("tree has %d nodes, more than the %d a clone allows", len(*nodes), maxCloneNodes))
+	}
+	byID := make(map[types.ContentID]db.ContentData, len(*nodes))
+	for _, n := range *nodes {
+		byID[n.ContentDataID] = n
+	}
+	order := orderSubtree(in.SourceID, byID)
+	if len(order) != len(byID) {
+		return nil, &ConflictError{
+			Resource: "content_data",
+			ID:       string(in.SourceID),
+			Detail:   fmt.Sprintf("%d nodes of the tree are not reachable through its sibling pointers; heal the tree first", len(byID)-len(order)),
+		}
+	}
+
+	parentID := in.ParentID
+	if !parentID.Valid && !in.RouteID.Valid {
+		parentID = byID[in.SourceID].ParentID
+		if !parentID.Valid {
+			return nil, NewValidationError("parent_id", "parent_id or route_id is required to clone a tree root")
+		}
+	}
+	routeID, err := subtreeRoute(s.driver, parentID, in.RouteID)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	defaultLocale := ""
+	if cfg.I18nEnabled() {
+		defaultLocale = cfg.I18nDefaultLocale()
+	}
+	sourceLocale := in.SourceLocale
+	if in.Locale != "" {
+		if !cfg.I18nEnabled() {
+			return nil, NewValidationError("locale", "i18n is not enabled")
+		}
+		loc, lerr := s.driver.GetLocaleByCode(in.Locale)
+		if lerr != nil || !loc.IsEnabled {
+			return nil, NewValidationError("locale", fmt.Sprintf("locale %q is not enabled", in.Locale))
+		}
+		if sourceLocale == "" {
+			sourceLocale = defaultLocale
+		}
+	} else if sourceLocale != "" {
+		return nil, NewValidationError("source_locale", "requires locale")
+	}
+
+	// Plan the copies before the transaction; only writes happen inside it.
+	fieldDefs := make(map[types.DatatypeID]map[types.FieldID]db.Fields)
+	fields := make(map[types.ContentID][]cloneField, len(order))
+	var relations []db.ContentRelations
+	for _, id := range order {
+		cd := byID[id]
+		var defs map[types.FieldID]db.Fields
+		if cd.DatatypeID.Valid {
+			defs, err = s.cloneFieldDefs(cd.DatatypeID.ID, fieldDefs)
+			if err != nil {
+				return nil, err
+			}
+		}
+		rows, err := s.driver.ListContentFieldsByContentData(types.NullableContentID{ID: id, Valid: true})
+		if err != nil {
+			return nil, fmt.Errorf("list content fields of %s: %w", id, err)
+		}
+		if rows != nil {
+			fields[id] = planCloneFields(*rows, defs, in.Locale, sourceLocale)
+		}
+		rels, err := s.driver.ListContentRelationsBySource(id)
+		if err != nil {
+			return nil, fmt.Errorf("list content relations of %s: %w", id, err)
+		}
+		if rels != nil {
+			relations = append(relations, *rels...)
+		}
+	}
+
+	// Mirror the source tree as the tree insertSubtree writes.
+	copies := make(map[types.ContentID]*subtreeNode, len(order))
+	for _, id := range order {
+		cd := byID[id]
+		sn := &subtreeNode{datatypeID: cd.DatatypeID, status: in.Status}
+		copies[id] = sn
+		if id != in.SourceID {
+			copies[cd.ParentID.ID].children = append(copies[cd.ParentID.ID].children, sn)
+		}
+	}
+	root := copies[in.SourceID]
+
+	// Copied translations keep the source they were translated from, so the
+	// copy reports the same stale translations as the original.
+	bases := make(map[types.ContentFieldID]*db.TranslationSource)
+	for _, id := range order {
+		for _, f := range fields[id] {
+			if f.source == "" || f.locale == "" || f.locale == defaultLocale {
+				continue
+			}
+			basis, err := s.driver.GetTranslationSource(f.source)
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("clone tree: %w", err)
+			}
+			bases[f.source] = basis
+		}
+	}
+
+	result := &CloneTreeResult{RouteID: routeID, Nodes: len(order)}
+	conn, _, err := s.driver.GetConnection()
+	if err != nil {
+		return nil, fmt.Errorf("clone tree: get connection: %w", err)
+	}
+	err = types.WithTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if err := insertSubtree(ctx, s.driver, tx, ac, root, routeID, parentID, ac.UserID); err != nil {
+			return err
+		}
+		ids := make(map[types.ContentID]types.ContentID, len(copies))
+		for id, sn := range copies {
+			ids[id] = sn.id
+		}
+		rootRow, err := db.GetContentDataInTx(s.driver, ctx, tx, root.id)
+		if err != nil {
+			return err
+		}
+
+		now := types.TimestampNow()
+		for _, id := range order {
+			defs := fieldDefs[byID[id].DatatypeID.ID]
+			for _, f := range fields[id] {
+				value := f.value
+				if def, ok := defs[f.fieldID.ID]; ok && def.Type == types.FieldTypeIDRef {
+					value = remapIDRef(value, ids)
+				}
+				row, err := db.CreateContentFieldInTx(s.driver, ctx, tx, ac, db.CreateContentFieldParams{
+					RouteID:       routeID,
+					RootID:        rootRow.RootID,
+					ContentDataID: types.NullableContentID{ID: ids[id], Valid: true},
+					FieldID:       f.fieldID,
+					FieldValue:    value,
+					Locale:        f.locale,
+					AuthorID:      ac.UserID,
+					DateCreated:   now,
+					DateModified:  now,
+				})
+				if err != nil {
+					return fmt.Errorf("create content field: %w", err)
+				}
+				if basis, ok := bases[f.source]; ok {
+					if _, err := db.CreateTranslationSourceInTx(s.driver, ctx, tx, ac, db.CreateTranslationSourceParams{
+						ContentFieldID: row.ContentFieldID,
+						ContentDataID:  ids[id],
+						FieldID:        basis.FieldID,
+						Locale:         basis.Locale,
+						SourceHash:     basis.SourceHash,
+						RecordedAt:     basis.RecordedAt,
+					}); err != nil {
+						return fmt.Errorf("copy translation source: %w", err)
+					}
+				}
+				result.Fields++
+			}
+		}
+
+		for _, rel := range relations {
+			target := rel.TargetContentID
+			if copied, ok := ids[target]; ok {
+				target = copied
+			}
+			if _, err := db.CreateContentRelationInTx(s.driver, ctx, tx, ac, db.CreateContentRelationParams{
+				SourceContentID: ids[rel.SourceContentID],
+				TargetContentID: target,
+				FieldID:         rel.FieldID,
+				SortOrder:       rel.SortOrder,
+				DateCreated:     now,
+			}); err != nil {
+				return fmt.Errorf("create content relation: %w", err)
+			}
+			result.Relations++
+		}
+
+		result.IDs = ids
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.ContentDataID = root.id
+	return result, nil
+}
+
+// cloneFieldDefs returns the fields of a datatype by ID, caching them in
+// cache.
+func (s *ContentService) cloneFieldDefs(datatypeID types.DatatypeID, cache map[types.DatatypeID]map[types.FieldID]db.Fields) (map[types.FieldID]db.Fields, error) {
+	if defs, ok := cache[datatypeID]; ok {
+		return defs, nil
+	}
+	list, err := s.driver.ListFieldsByDatatypeID(types.NullableDatatypeID{ID: datatypeID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("list fields of datatype %s: %w", datatypeID, err)
+	}
+	defs := make(map[types.FieldID]db.Fields)
+	if list != nil {
+		for _, f := range *list {
+			defs[f.FieldID] = f
+		}
+	}
+	cache[datatypeID] = defs
+	return defs, nil
+}
+
+// planCloneFields returns the rows a copy of a node gets. Without locale
+// every row is copied. With locale, translatable fields get one row in locale
+// holding the sourceLocale value, and only unlocalized rows of the other
+// fields are copied.
+func planCloneFields(rows []db.ContentFields, defs map[types.FieldID]db.Fields, locale, sourceLocale string) []cloneField {
+	var out []cloneField
+	if locale == "" {
+		for _, row := range rows {
+			out = append(out, cloneField{fieldID: row.FieldID, value: row.FieldValue, locale: row.Locale, source: row.ContentFieldID})
+		}
+		return out
+	}
+	values := publishing.SourceValues(rows, sourceLocale)
+	done := make(map[types.FieldID]bool)
+	for _, row := range rows {
+		def, ok := defs[row.FieldID.ID]
+		if !row.FieldID.Valid || !ok || !def.Translatable {
+			if row.Locale == "" {
+				out = append(out, cloneField{fieldID: row.FieldID, value: row.FieldValue})
+			}
+			continue
+		}
+		value, has := values[row.FieldID.ID]
+		if !has || done[row.FieldID.ID] {
+			continue
+		}
+		done[row.FieldID.ID] = true
+		out = append(out, cloneField{fieldID: row.FieldID, value: value, locale: locale})
+	}
+	return out
+}
+
+// remapIDRef rewrites an "_id" field value, a content ID or a JSON array of
+// them, replacing the IDs found in ids. Other values are returned unchanged.
+func remapIDRef(value string, ids map[types.ContentID]types.ContentID) string {
+	if copied, ok := ids[types.ContentID(value)]; ok {
+		return string(copied)
+	}
+	var list []string
+	if err := json.Unmarshal([]byte(value), &list); err != nil {
+		return value
+	}
+	changed := false
+	for i, v := range list {
+		if copied, ok := ids[types.ContentID(v)]; ok {
+			list[i] = string(copied)
+			changed = true
+		}
+	}
+	if !changed {
+		return value
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return value
+	}
+	return string(data)
+}
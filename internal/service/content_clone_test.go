@@ -0,0 +1,322 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/blueprint"
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/service"
+	"github.com/hegner123/modulacms/internal/spreadsheet"
+)
+
+// cloneContentService returns a ContentService over d.
+func cloneContentService(t *testing.T, d db.Database) *service.ContentService {
+	t.Helper()
+	cfg := d.Config
+	mgr := config.NewManager(&staticProvider{cfg: &cfg})
+	if err := mgr.Load(); err != nil {
+		t.Fatalf("mgr.Load: %v", err)
+	}
+	return service.NewContentService(d, mgr, noopDispatcher{})
+}
+
+// cloneEmptyRoute creates a route without content.
+func cloneEmptyRoute(t *testing.T, d db.Database, ac audited.AuditContext, slug string) types.RouteID {
+	t.Helper()
+	route, err := d.CreateRoute(context.Background(), ac, db.CreateRouteParams{
+		Slug:         types.Slug(slug),
+		Title:        slug,
+		Status:       1,
+		AuthorID:     types.NullableUserID{ID: ac.UserID, Valid: true},
+		DateCreated:  types.TimestampNow(),
+		DateModified: types.TimestampNow(),
+	})
+	if err != nil {
+		t.Fatalf("CreateRoute: %v", err)
+	}
+	return route.RouteID
+}
+
+// childrenOf walks a node's children through the sibling chain, checking the
+// parent and previous-sibling pointers on the way.
+func childrenOf(t *testing.T, d db.Database, parent *db.ContentData) []db.ContentData {
+	t.Helper()
+	var out []db.ContentData
+	prev := types.NullableContentID{}
+	for next := parent.FirstChildID; next.Valid; {
+		child, err := d.GetContentData(next.ID)
+		if err != nil {
+			t.Fatalf("GetContentData child: %v", err)
+		}
+		if child.ParentID.ID != parent.ContentDataID || child.PrevSiblingID != prev {
+			t.Errorf("child %d pointers: %+v", len(out), child)
+		}
+		out = append(out, *child)
+		prev = types.NullableContentID{ID: child.ContentDataID, Valid: true}
+		next = child.NextSiblingID
+	}
+	return out
+}
+
+func TestContentService_CloneTree(t *testing.T) {
+	d, bps, ac := testBlueprintDB(t)
+	svc := cloneContentService(t, d)
+	ctx := context.Background()
+
+	def := &blueprint.Definition{
+		Name: "landing",
+		Root: blueprint.Node{
+			Datatype: "page",
+			Fields:   map[string]string{"title": "Landing"},
+			Children: []blueprint.Node{
+				{Key: "first", Datatype: "hero", Fields: map[string]string{"heading": "one"}},
+				{Datatype: "hero", Fields: map[string]string{"heading": "two", "link": "{{@first}}"}},
+				{Datatype: "hero", Fields: map[string]string{"heading": "three"}},
+			},
+		},
+	}
+	if _, err := bps.Create(ctx, ac, def); err != nil {
+		t.Fatalf("Create blueprint: %v", err)
+	}
+	src, err := bps.Instantiate(ctx, ac, service.InstantiateBlueprintInput{Blueprint: "landing", Slug: "/a", Title: "A"})
+	if err != nil {
+		t.Fatalf("Instantiate: %v", err)
+	}
+	other, err := bps.Instantiate(ctx, ac, service.InstantiateBlueprintInput{Blueprint: "landing", Slug: "/b", Title: "B"})
+	if err != nil {
+		t.Fatalf("Instantiate other: %v", err)
+	}
+	srcRoot, err := d.GetContentData(src.ContentDataID)
+	if err != nil {
+		t.Fatalf("GetContentData: %v", err)
+	}
+	srcChildren := childrenOf(t, d, srcRoot)
+	if len(srcChildren) != 3 {
+		t.Fatalf("source children = %d, want 3", len(srcChildren))
+	}
+
+	heroFields, err := d.ListFieldsByDatatypeID(srcChildren[1].DatatypeID)
+	if err != nil {
+		t.Fatalf("ListFieldsByDatatypeID: %v", err)
+	}
+	var linkField types.FieldID
+	for _, f := range *heroFields {
+		if f.Name == "link" {
+			linkField = f.FieldID
+		}
+	}
+	for i, target := range []types.ContentID{srcChildren[0].ContentDataID, other.ContentDataID} {
+		if _, err := d.CreateContentRelation(ctx, ac, db.CreateContentRelationParams{
+			SourceContentID: srcChildren[1].ContentDataID,
+			TargetContentID: target,
+			FieldID:         linkField,
+			SortOrder:       int64(i),
+			DateCreated:     types.TimestampNow(),
+		}); err != nil {
+			t.Fatalf("CreateContentRelation: %v", err)
+		}
+	}
+
+	if _, err := svc.CloneTree(ctx, ac, service.CloneTreeInput{SourceID: src.ContentDataID}); !service.IsValidation(err) {
+		t.Errorf("root without target: err = %v, want validation error", err)
+	}
+	if _, err := svc.CloneTree(ctx, ac, service.CloneTreeInput{SourceID: src.ContentDataID, RouteID: other.RouteID}); !service.IsConflict(err) {
+		t.Errorf("occupied route: err = %v, want conflict", err)
+	}
+	if _, err := svc.CloneTree(ctx, ac, service.CloneTreeInput{SourceID: src.ContentDataID, Locale: "fr"}); !service.IsValidation(err) {
+		t.Errorf("locale without i18n: err = %v, want validation error", err)
+	}
+
+	routeID := cloneEmptyRoute(t, d, ac, "copy")
+	res, err := svc.CloneTree(ctx, ac, service.CloneTreeInput{
+		SourceID: src.ContentDataID,
+		RouteID:  types.NullableRouteID{ID: routeID, Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("CloneTree: %v", err)
+	}
+	if res.Nodes != 4 || res.Relations != 2 || res.RouteID.ID != routeID || len(res.IDs) != 4 {
+		t.Fatalf("CloneTree = %+v", res)
+	}
+
+	root, err := d.GetContentData(res.ContentDataID)
+	if err != nil {
+		t.Fatalf("GetContentData copy: %v", err)
+	}
+	if root.ParentID.Valid || root.RootID.ID != root.ContentDataID || root.RouteID.ID != routeID {
+		t.Errorf("copy root pointers: %+v", root)
+	}
+	children := childrenOf(t, d, root)
+	if len(children) != 3 {
+		t.Fatalf("copy children = %d, want 3", len(children))
+	}
+	for i, child := range children {
+		if res.IDs[srcChildren[i].ContentDataID] != child.ContentDataID {
+			t.Errorf("child %d out of order: ids = %v", i, res.IDs)
+		}
+		if child.RootID.ID != root.ContentDataID || child.RouteID.ID != routeID {
+			t.Errorf("child %d root/route: %+v", i, child)
+		}
+	}
+	if got := fieldValues(t, d, children[0].ContentDataID)["heading"]; got != "one" {
+		t.Errorf("first heading = %q", got)
+	}
+	if got := fieldValues(t, d, children[1].ContentDataID)["link"]; got != string(children[0].ContentDataID) {
+		t.Errorf("link = %q, want the copy %s", got, children[0].ContentDataID)
+	}
+
+	rels, err := d.ListContentRelationsBySource(children[1].ContentDataID)
+	if err != nil || rels == nil || len(*rels) != 2 {
+		t.Fatalf("copied relations = %v (%v), want 2", rels, err)
+	}
+	targets := map[types.ContentID]bool{}
+	for _, rel := range *rels {
+		targets[rel.TargetContentID] = true
+	}
+	if !targets[children[0].ContentDataID] || !targets[other.ContentDataID] {
+		t.Errorf("relation targets = %v, want the copied first hero and the outside root", targets)
+	}
+	if got := fieldValues(t, d, srcChildren[1].ContentDataID)["link"]; got != string(srcChildren[0].ContentDataID) {
+		t.Errorf("source link changed to %q", got)
+	}
+
+	// A subtree cloned without a target is appended under its own parent, and
+	// references leaving the subtree keep pointing at the originals.
+	sub, err := svc.CloneTree(ctx, ac, service.CloneTreeInput{SourceID: srcChildren[1].ContentDataID})
+	if err != nil {
+		t.Fatalf("CloneTree subtree: %v", err)
+	}
+	srcRoot, _ = d.GetContentData(src.ContentDataID)
+	after := childrenOf(t, d, srcRoot)
+	if len(after) != 4 || after[3].ContentDataID != sub.ContentDataID || after[3].RouteID != src.RouteID {
+		t.Fatalf("children after subtree clone = %+v", after)
+	}
+	if got := fieldValues(t, d, sub.ContentDataID)["link"]; got != string(srcChildren[0].ContentDataID) {
+		t.Errorf("subtree link = %q, want the original %s", got, srcChildren[0].ContentDataID)
+	}
+}
+
+func TestContentService_CloneTreeLocale(t *testing.T) {
+	d, sheets, ac := testSheetDB(t)
+	svc := cloneContentService(t, d)
+	ctx := context.Background()
+
+	sheet := &spreadsheet.Sheet{
+		Header: []string{"_slug", "title", "price", "title@fr"},
+		Rows:   [][]string{{"/widget", "Widget", "10", "Gadget"}},
+	}
+	if _, err := sheets.Import(ctx, ac, sheet, service.SheetImportParams{Datatype: "product"}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	exported, err := sheets.Export(ctx, service.SheetExportParams{Datatype: "product"})
+	if err != nil || len(exported.Rows) != 1 {
+		t.Fatalf("Export = %v (%v)", exported, err)
+	}
+	srcID := types.ContentID(exported.Rows[0][sheetColumn(t, exported, "_content_id")])
+
+	if _, err := svc.CloneTree(ctx, ac, service.CloneTreeInput{SourceID: srcID, RouteID: types.NullableRouteID{ID: cloneEmptyRoute(t, d, ac, "x"), Valid: true}, Locale: "de"}); !service.IsValidation(err) {
+		t.Errorf("unknown locale: err = %v, want validation error", err)
+	}
+
+	localized := func(id types.ContentID) map[string]string {
+		t.Helper()
+		rows, err := d.ListContentFieldsByContentData(types.NullableContentID{ID: id, Valid: true})
+		if err != nil {
+			t.Fatalf("ListContentFieldsByContentData: %v", err)
+		}
+		out := map[string]string{}
+		for _, row := range *rows {
+			f, err := d.GetField(row.FieldID.ID)
+			if err != nil {
+				t.Fatalf("GetField: %v", err)
+			}
+			out[f.Name+"@"+row.Locale] = row.FieldValue
+		}
+		return out
+	}
+
+	// frField returns the fr content field of a node.
+	frField := func(id types.ContentID) db.ContentFields {
+		t.Helper()
+		rows, err := d.ListContentFieldsByContentData(types.NullableContentID{ID: id, Valid: true})
+		if err != nil {
+			t.Fatalf("ListContentFieldsByContentData: %v", err)
+		}
+		for _, row := range *rows {
+			if row.Locale == "fr" {
+				return row
+			}
+		}
+		t.Fatalf("no fr field on %s", id)
+		return db.ContentFields{}
+	}
+	srcFr := frField(srcID)
+	if err := db.SetTranslationSource(d, db.CreateTranslationSourceParams{
+		ContentFieldID: srcFr.ContentFieldID,
+		ContentDataID:  srcID,
+		FieldID:        srcFr.FieldID.ID,
+		Locale:         "fr",
+		SourceHash:     "basis-hash",
+		RecordedAt:     1000,
+	}); err != nil {
+		t.Fatalf("SetTranslationSource: %v", err)
+	}
+
+	all, err := svc.CloneTree(ctx, ac, service.CloneTreeInput{
+		SourceID: srcID,
+		RouteID:  types.NullableRouteID{ID: cloneEmptyRoute(t, d, ac, "all"), Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("CloneTree: %v", err)
+	}
+	if got := localized(all.ContentDataID); got["title@"] != "Widget" || got["title@fr"] != "Gadget" || got["price@"] != "10" {
+		t.Errorf("full copy fields = %v", got)
+	}
+
+	// The copied translation keeps its basis, written with the copy.
+	copyFr := frField(all.ContentDataID)
+	basis, err := d.GetTranslationSource(copyFr.ContentFieldID)
+	if err != nil {
+		t.Fatalf("GetTranslationSource copy: %v", err)
+	}
+	if basis.SourceHash != "basis-hash" || basis.ContentDataID != all.ContentDataID || basis.RecordedAt != 1000 {
+		t.Errorf("copied basis = %+v", basis)
+	}
+	events, err := d.GetChangeEventsByRecord(string(db.Translation_sources), string(copyFr.ContentFieldID))
+	if err != nil {
+		t.Fatalf("GetChangeEventsByRecord: %v", err)
+	}
+	if len(*events) != 1 {
+		t.Errorf("translation source events = %d, want 1", len(*events))
+	}
+
+	fr, err := svc.CloneTree(ctx, ac, service.CloneTreeInput{
+		SourceID:     srcID,
+		RouteID:      types.NullableRouteID{ID: cloneEmptyRoute(t, d, ac, "fr"), Valid: true},
+		Locale:       "fr",
+		SourceLocale: "fr",
+	})
+	if err != nil {
+		t.Fatalf("CloneTree fr: %v", err)
+	}
+	got := localized(fr.ContentDataID)
+	if len(got) != 3 || got["title@fr"] != "Gadget" || got["price@"] != "10" || got["title@"] != "" {
+		t.Errorf("fr copy fields = %v, want title and body in fr, and price", got)
+	}
+
+	seeded, err := svc.CloneTree(ctx, ac, service.CloneTreeInput{
+		SourceID: srcID,
+		RouteID:  types.NullableRouteID{ID: cloneEmptyRoute(t, d, ac, "seeded"), Valid: true},
+		Locale:   "fr",
+	})
+	if err != nil {
+		t.Fatalf("CloneTree seeded: %v", err)
+	}
+	if got := localized(seeded.ContentDataID); got["title@fr"] != "Widget" {
+		t.Errorf("fr copy seeded from the default locale = %v", got)
+	}
+}
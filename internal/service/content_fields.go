@@ -7,7 +7,11 @@ import (
 	"github.com/hegner123/modulacms/internal/db"
 	"github.com/hegner123/modulacms/internal/db/audited"
 	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/publishing"
+	"github.com/hegner123/modulacms/internal/translation"
+	"github.com/hegner123/modulacms/internal/utility"
 	"github.com/hegner123/modulacms/internal/validation"
+	"github.com/hegner123/modulacms/internal/webhooks"
 )
 
 // --- Content Field CRUD (on ContentService) ---
@@ -87,6 +91,9 @@ func (s *ContentService) CreateField(ctx context.Context, ac audited.AuditContex
 	if err != nil {
 		return nil, fmt.Errorf("create content field: %w", err)
 	}
+	if fieldDef.Translatable {
+		s.recordTranslationSource(*cf)
+	}
 	return cf, nil
 }
 
@@ -105,15 +112,29 @@ func (s *ContentService) UpdateField(ctx context.Context, ac audited.AuditContex
 		return nil, fe
 	}
 
+	// A change to a source value makes the translations made from it stale;
+	// remember the value it replaces to find them afterwards.
+	tracker := s.sourceTracker(fieldDef, params.ContentDataID, params.Locale)
+
 	_, err = s.driver.UpdateContentField(ctx, ac, params)
 	if err != nil {
 		return nil, fmt.Errorf("update content field: %w", err)
 	}
 
+	// Editing a pre-filled translation counts as reviewing it.
+	if err := s.driver.DeleteTranslationReview(params.ContentFieldID); err != nil {
+		utility.DefaultLogger.Warn("failed to clear translation review", err, "content_field_id", params.ContentFieldID)
+	}
+
 	updated, err := s.driver.GetContentField(params.ContentFieldID)
 	if err != nil {
 		return nil, fmt.Errorf("update field: re-fetch: %w", err)
 	}
+	if tracker != nil {
+		tracker.notifyStale(ctx, s, updated.RouteID)
+	} else if fieldDef.Translatable {
+		s.recordTranslationSource(*updated)
+	}
 	return updated, nil
 }
 
@@ -130,6 +151,103 @@ func (s *ContentService) ListFieldsByContentDataAndLocale(ctx context.Context, c
 	return s.driver.ListContentFieldsByContentDataAndLocale(contentDataID, locale)
 }
 
+// recordTranslationSource records the current source value as the basis of a
+// translated content field. Failures are logged; source tracking never fails
+// a write.
+func (s *ContentService) recordTranslationSource(cf db.ContentFields) {
+	cfg, err := s.mgr.Config()
+	if err != nil || !cfg.I18nEnabled() {
+		return
+	}
+	if err := publishing.RecordTranslationSource(s.driver, cf, cfg.I18nDefaultLocale()); err != nil {
+		utility.DefaultLogger.Warn("failed to record translation source", err, "content_field_id", cf.ContentFieldID)
+	}
+}
+
+// sourceChange captures a translatable field's source value before an update.
+type sourceChange struct {
+	contentID     types.NullableContentID
+	fieldID       types.FieldID
+	defaultLocale string
+	before        string
+}
+
+// sourceTracker returns a sourceChange when a write to fieldDef in locale
+// may change the source that translations of the field are made from, or
+// nil when it cannot.
+func (s *ContentService) sourceTracker(fieldDef *db.Fields, contentID types.NullableContentID, locale string) *sourceChange {
+	if !fieldDef.Translatable || !contentID.Valid {
+		return nil
+	}
+	cfg, err := s.mgr.Config()
+	if err != nil || !cfg.I18nEnabled() {
+		return nil
+	}
+	defaultLocale := cfg.I18nDefaultLocale()
+	if locale != "" && locale != defaultLocale {
+		return nil
+	}
+	before, err := s.sourceValue(contentID, fieldDef.FieldID, defaultLocale)
+	if err != nil {
+		utility.DefaultLogger.Warn("failed to read translation source", err, "content_data_id", contentID.ID)
+		return nil
+	}
+	return &sourceChange{contentID: contentID, fieldID: fieldDef.FieldID, defaultLocale: defaultLocale, before: before}
+}
+
+// sourceValue returns the value translations of a field are made from.
+func (s *ContentService) sourceValue(contentID types.NullableContentID, fieldID types.FieldID, defaultLocale string) (string, error) {
+	rows, err := s.driver.ListContentFieldsByContentDataAndLocale(contentID, defaultLocale)
+	if err != nil {
+		return "", err
+	}
+	if rows == nil {
+		return "", nil
+	}
+	return publishing.SourceValues(*rows, defaultLocale)[fieldID], nil
+}
+
+// notifyStale dispatches locale.stale, listing the locales whose translation
+// of the field was made from the value just replaced. Translations that were
+// already stale were reported when they became so.
+func (c *sourceChange) notifyStale(ctx context.Context, s *ContentService, routeID types.NullableRouteID) {
+	if s.dispatcher == nil {
+		return
+	}
+	after, err := s.sourceValue(c.contentID, c.fieldID, c.defaultLocale)
+	if err != nil {
+		utility.DefaultLogger.Warn("failed to read translation source", err, "content_data_id", c.contentID.ID)
+		return
+	}
+	beforeHash := translation.SourceHash(c.before)
+	if beforeHash == translation.SourceHash(after) {
+		return
+	}
+	bases, err := s.driver.ListTranslationSourcesByField(c.contentID.ID, c.fieldID)
+	if err != nil {
+		utility.DefaultLogger.Warn("failed to list translation sources", err, "content_data_id", c.contentID.ID)
+		return
+	}
+	var locales []string
+	for _, b := range *bases {
+		if b.SourceHash == beforeHash {
+			locales = append(locales, b.Locale)
+		}
+	}
+	if len(locales) == 0 {
+		return
+	}
+	data := map[string]any{
+		"content_data_id": c.contentID.ID.String(),
+		"field_id":        c.fieldID.String(),
+		"locales":         locales,
+	}
+	if routeID.Valid {
+		data["route_id"] = routeID.ID.String()
+	}
+	s.dispatcher.Dispatch(ctx, webhooks.EventLocaleStale, data)
+}
+
 // resolveValidationConfig fetches the config JSON for a field's validation_id.
 // Returns empty string if the validation_id is null/zero or the record is missing.
 func (s *ContentService) resolveValidationConfig(id types.NullableValidationID) string {
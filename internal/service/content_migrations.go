@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hegner123/modulacms/internal/contentmigration"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+)
+
+// ContentMigrationSource supplies migrations registered outside the Go
+// registry. *plugin.Manager satisfies it with the migrations of running
+// plugins.
+type ContentMigrationSource interface {
+	ContentMigrations() []contentmigration.Migration
+}
+
+// ContentMigrationStatus describes an available migration and whether it has
+// run on this database.
+type ContentMigrationStatus struct {
+	ID          string               `json:"id"`
+	Description string               `json:"description"`
+	Datatype    string               `json:"datatype"`
+	Field       string               `json:"field"`
+	Source      string               `json:"source"`
+	Applied     bool                 `json:"applied"`
+	Run         *db.ContentMigration `json:"run,omitempty"`
+}
+
+// ContentMigrationService lists and runs content migrations.
+type ContentMigrationService struct {
+	driver   db.DbDriver
+	registry *contentmigration.Registry
+	plugins  ContentMigrationSource
+}
+
+// NewContentMigrationService creates a ContentMigrationService over a Go
+// registry (usually contentmigration.Default()) and an optional plugin
+// source, which may be nil.
+func NewContentMigrationService(driver db.DbDriver, registry *contentmigration.Registry, plugins ContentMigrationSource) *ContentMigrationService {
+	return &ContentMigrationService{driver: driver, registry: registry, plugins: plugins}
+}
+
+// migrations returns Go and plugin migrations by ID. Go migrations win on the
+// (unlikely) ID collision since plugin IDs are namespaced.
+func (s *ContentMigrationService) migrations() []contentmigration.Migration {
+	out := s.registry.List()
+	if s.plugins == nil {
+		return out
+	}
+	seen := make(map[string]bool, len(out))
+	for _, m := range out {
+		seen[m.ID] = true
+	}
+	for _, m := range s.plugins.ContentMigrations() {
+		if !seen[m.ID] {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func (s *ContentMigrationService) get(id string) (contentmigration.Migration, error) {
+	for _, m := range s.migrations() {
+		if m.ID == id {
+			return m, nil
+		}
+	}
+	return contentmigration.Migration{}, &NotFoundError{Resource: "content migration", ID: id}
+}
+
+// List returns every available migration with its run record, plus records
+// of migrations that ran but are no longer registered.
+func (s *ContentMigrationService) List(ctx context.Context) ([]ContentMigrationStatus, error) {
+	runs, err := s.driver.ListContentMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("list content migrations: %w", err)
+	}
+	recorded := make(map[string]db.ContentMigration)
+	if runs != nil {
+		for _, r := range *runs {
+			recorded[r.MigrationID] = r
+		}
+	}
+
+	var out []ContentMigrationStatus
+	for _, m := range s.migrations() {
+		st := ContentMigrationStatus{
+			ID:          m.ID,
+			Description: m.Description,
+			Datatype:    m.Datatype,
+			Field:       m.Field,
+			Source:      m.Source,
+		}
+		if r, ok := recorded[m.ID]; ok {
+			st.Applied = r.Completed
+			st.Run = &r
+			delete(recorded, m.ID)
+		}
+		out = append(out, st)
+	}
+	if runs != nil {
+		for _, r := range *runs {
+			if _, ok := recorded[r.MigrationID]; !ok {
+				continue
+			}
+			run := r
+			out = append(out, ContentMigrationStatus{
+				ID:          r.MigrationID,
+				Description: r.Description,
+				Source:      r.Source,
+				Applied:     r.Completed,
+				Run:         &run,
+			})
+		}
+	}
+	return out, nil
+}
+
+// Run executes or dry-runs a migration. When values fail validation and
+// opts.AllowInvalid is false, it returns the report together with an error
+// wrapping contentmigration.ErrInvalidValues; nothing is written.
+func (s *ContentMigrationService) Run(ctx context.Context, ac audited.AuditContext, id string, opts contentmigration.Options) (contentmigration.Report, error) {
+	m, err := s.get(id)
+	if err != nil {
+		return contentmigration.Report{MigrationID: id}, err
+	}
+	if opts.AuthorID.IsZero() {
+		opts.AuthorID = ac.UserID
+	}
+	report, err := contentmigration.Run(ctx, s.driver, ac, m, opts)
+	switch {
+	case err == nil:
+		return report, nil
+	case errors.Is(err, contentmigration.ErrAlreadyApplied):
+		return report, &ConflictError{Resource: "content migration", ID: id, Detail: "already applied"}
+	case errors.Is(err, contentmigration.ErrInvalidValues):
+		return report, err
+	default:
+		return report, &InternalError{Err: err}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hegner123/modulacms/internal/config"
 	"github.com/hegner123/modulacms/internal/db"
 	"github.com/hegner123/modulacms/internal/db/audited"
 	"github.com/hegner123/modulacms/internal/db/types"
@@ -24,9 +25,13 @@ func (s *ContentService) Publish(ctx context.Context, ac audited.AuditContext, c
 	retentionCap := cfg.VersionMaxPerContent()
 	publishAll := !cfg.Node_Level_Publish
 
+	if err := s.checkTranslationsCurrent(ctx, cfg, contentID, locale); err != nil {
+		return nil, err
+	}
+
 	version, err := publishing.PublishContent(ctx, s.driver, contentID, locale, userID, ac, retentionCap, publishAll, s.dispatcher, nil)
 	if err != nil {
-		if publishing.IsRevisionConflict(err) {
+		if publishing.IsRevisionConflict(err) || publishing.IsTranslationReviewPending(err) {
 			return nil, &ConflictError{
 				Resource: "content_data",
 				ID:       string(contentID),
@@ -47,9 +52,13 @@ func (s *ContentService) PublishAll(ctx context.Context, ac audited.AuditContext
 	}
 	retentionCap := cfg.VersionMaxPerContent()
 
+	if err := s.checkTranslationsCurrent(ctx, cfg, contentID, locale); err != nil {
+		return nil, err
+	}
+
 	version, err := publishing.PublishContent(ctx, s.driver, contentID, locale, userID, ac, retentionCap, true, s.dispatcher, nil)
 	if err != nil {
-		if publishing.IsRevisionConflict(err) {
+		if publishing.IsRevisionConflict(err) || publishing.IsTranslationReviewPending(err) {
 			return nil, &ConflictError{
 				Resource: "content_data",
 				ID:       string(contentID),
@@ -61,6 +70,26 @@ func (s *ContentService) PublishAll(ctx context.Context, ac audited.AuditContext
 	return version, nil
 }
 
+// checkTranslationsCurrent enforces i18n_require_current_translations: a
+// locale cannot be published while required fields are missing or stale.
+func (s *ContentService) checkTranslationsCurrent(ctx context.Context, cfg *config.Config, contentID types.ContentID, locale string) error {
+	if !cfg.I18n_Require_Current_Translations || !cfg.I18nEnabled() {
+		return nil
+	}
+	err := publishing.CheckTranslationsCurrent(ctx, s.driver, contentID, locale, cfg.I18nDefaultLocale())
+	if publishing.IsTranslationsOutdated(err) {
+		return &ConflictError{
+			Resource: "content_data",
+			ID:       string(contentID),
+			Detail:   err.Error(),
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("check translations: %w", err)
+	}
+	return nil
+}
+
 // Unpublish clears the published flag and resets publish metadata to draft.
 func (s *ContentService) Unpublish(ctx context.Context, ac audited.AuditContext, contentID types.ContentID, locale string, userID types.UserID) error {
 	err := publishing.UnpublishContent(ctx, s.driver, contentID, locale, userID, ac, s.dispatcher, nil)
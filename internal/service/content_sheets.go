@@ -0,0 +1,890 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/spreadsheet"
+	"github.com/hegner123/modulacms/internal/utility"
+	"github.com/hegner123/modulacms/internal/validation"
+)
+
+// Reserved sheet columns. Field columns are named after the field, with
+// "@locale" appended for translations ("title@fr").
+const (
+	SheetColumnID     = "_content_id"
+	SheetColumnSlug   = "_slug"
+	SheetColumnParent = "_parent_id"
+)
+
+// Upsert keys for SheetImportParams.Key.
+const (
+	SheetKeyID   = "id"
+	SheetKeySlug = "slug"
+)
+
+// defaultSheetBatchSize is the number of rows committed per transaction.
+const defaultSheetBatchSize = 100
+
+// SheetExportParams selects what ContentSheetService.Export writes.
+type SheetExportParams struct {
+	// Datatype is a datatype name or ID.
+	Datatype string
+	// Locales limits translation columns to these locales. Empty means every
+	// enabled locale. Ignored when i18n is disabled.
+	Locales []string
+}
+
+// SheetImportParams controls ContentSheetService.Import.
+type SheetImportParams struct {
+	// Datatype is a datatype name or ID.
+	Datatype string
+	// Key selects how rows match existing entries: SheetKeyID by
+	// _content_id, SheetKeySlug by _slug, or "" for _content_id when the row
+	// has one and _slug otherwise. Unmatched rows create entries.
+	Key string
+	// Mapping maps sheet column headers to targets: a field name,
+	// "field@locale", or a reserved column. An empty target ignores the
+	// column. Unmapped columns are matched by field name or label.
+	Mapping map[string]string
+	// DryRun validates every row and reports what would change without
+	// writing.
+	DryRun bool
+	// BatchSize is the number of rows committed per transaction (default 100).
+	BatchSize int
+}
+
+// SheetColumn reports how an import resolved one sheet column.
+type SheetColumn struct {
+	Column  string `json:"column"`
+	Target  string `json:"target,omitempty"`
+	Locale  string `json:"locale,omitempty"`
+	Ignored bool   `json:"ignored,omitempty"`
+}
+
+// SheetRowError is a problem with one sheet row. Row is the spreadsheet row
+// number; Field is empty for errors that concern the whole row.
+type SheetRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// SheetRowResult reports the outcome for one row.
+type SheetRowResult struct {
+	Row           int             `json:"row"`
+	Action        string          `json:"action"` // create, update, unchanged, failed
+	ContentDataID types.ContentID `json:"content_data_id,omitempty"`
+	Slug          string          `json:"slug,omitempty"`
+	Changed       []string        `json:"changed,omitempty"`
+}
+
+// SheetImportReport summarises an import or dry run.
+type SheetImportReport struct {
+	Datatype  string           `json:"datatype"`
+	DryRun    bool             `json:"dry_run"`
+	Columns   []SheetColumn    `json:"columns"`
+	Rows      []SheetRowResult `json:"rows"`
+	Created   int              `json:"created"`
+	Updated   int              `json:"updated"`
+	Unchanged int              `json:"unchanged"`
+	Failed    int              `json:"failed"`
+	Batches   int              `json:"batches"`
+	Errors    []SheetRowError  `json:"errors,omitempty"`
+}
+
+// ContentSheetService exports the entries of a datatype as a spreadsheet and
+// imports them back.
+type ContentSheetService struct {
+	driver  db.DbDriver
+	mgr     *config.Manager
+	content *ContentService
+	routes  *RouteService
+}
+
+// NewContentSheetService creates a ContentSheetService. content supplies
+// field validation and routes the slug checks for new entries.
+func NewContentSheetService(driver db.DbDriver, mgr *config.Manager, content *ContentService, routes *RouteService) *ContentSheetService {
+	return &ContentSheetService{driver: driver, mgr: mgr, content: content, routes: routes}
+}
+
+// sheetSchema is a datatype with its fields and the locales in play.
+type sheetSchema struct {
+	datatype      db.Datatypes
+	fields        []db.Fields
+	byName        map[string]db.Fields
+	i18n          bool
+	defaultLocale string
+	// locales holds the enabled non-default locales.
+	locales []string
+}
+
+// fieldLocale returns the content_fields locale that holds the value of
+// field f in column locale ("" for the default column).
+func (sc *sheetSchema) fieldLocale(f db.Fields, locale string) string {
+	if locale != "" {
+		return locale
+	}
+	if sc.i18n && f.Translatable {
+		return sc.defaultLocale
+	}
+	return ""
+}
+
+func (s *ContentSheetService) loadSchema(datatype string) (*sheetSchema, error) {
+	if datatype == "" {
+		return nil, NewValidationError("datatype", "required")
+	}
+	dt, err := s.driver.GetDatatypeByName(datatype)
+	if err != nil {
+		dt, err = s.driver.GetDatatype(types.DatatypeID(datatype))
+		if err != nil {
+			return nil, &NotFoundError{Resource: "datatype", ID: datatype}
+		}
+	}
+	fields, err := s.driver.ListFieldsByDatatypeID(types.NullableDatatypeID{ID: dt.DatatypeID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("list fields: %w", err)
+	}
+	sc := &sheetSchema{datatype: *dt, byName: map[string]db.Fields{}}
+	if fields != nil {
+		sc.fields = append(sc.fields, *fields...)
+	}
+	sort.SliceStable(sc.fields, func(i, j int) bool { return sc.fields[i].SortOrder < sc.fields[j].SortOrder })
+	for _, f := range sc.fields {
+		sc.byName[f.Name] = f
+	}
+
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	if cfg.I18nEnabled() {
+		sc.i18n = true
+		sc.defaultLocale = cfg.I18nDefaultLocale()
+		locales, err := s.driver.ListEnabledLocales()
+		if err != nil {
+			return nil, fmt.Errorf("list locales: %w", err)
+		}
+		if locales != nil {
+			for _, l := range *locales {
+				if l.Code != sc.defaultLocale {
+					sc.locales = append(sc.locales, l.Code)
+				}
+			}
+		}
+	}
+	return sc, nil
+}
+
+// entryValues indexes content field rows by field ID and locale.
+type entryValues map[types.FieldID]map[string]db.ContentFields
+
+func (s *ContentSheetService) loadValues(id types.ContentID) (entryValues, error) {
+	rows, err := s.driver.ListContentFieldsByContentData(types.NullableContentID{ID: id, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("list content fields for %s: %w", id, err)
+	}
+	values := entryValues{}
+	if rows == nil {
+		return values, nil
+	}
+	for _, cf := range *rows {
+		if !cf.FieldID.Valid {
+			continue
+		}
+		if values[cf.FieldID.ID] == nil {
+			values[cf.FieldID.ID] = map[string]db.ContentFields{}
+		}
+		values[cf.FieldID.ID][cf.Locale] = cf
+	}
+	return values, nil
+}
+
+// find returns the row holding the value of f in locale. A default-locale
+// value falls back to the row without a locale that entries are created
+// with.
+func (v entryValues) find(sc *sheetSchema, f db.Fields, locale string) (db.ContentFields, bool) {
+	byLocale := v[f.FieldID]
+	stored := sc.fieldLocale(f, locale)
+	if cf, ok := byLocale[stored]; ok {
+		return cf, true
+	}
+	if locale == "" && stored != "" {
+		cf, ok := byLocale[""]
+		return cf, ok
+	}
+	return db.ContentFields{}, false
+}
+
+// routeSlugs maps route IDs to slugs.
+func (s *ContentSheetService) routeSlugs() (map[types.RouteID]string, error) {
+	routes, err := s.driver.ListRoutes()
+	if err != nil {
+		return nil, fmt.Errorf("list routes: %w", err)
+	}
+	slugs := map[types.RouteID]string{}
+	if routes != nil {
+		for _, r := range *routes {
+			slugs[r.RouteID] = string(r.Slug)
+		}
+	}
+	return slugs, nil
+}
+
+// listEntries returns the entries of the datatype in creation order.
+func (s *ContentSheetService) listEntries(sc *sheetSchema) ([]db.ContentData, error) {
+	list, err := s.driver.ListContentDataByDatatypeID(sc.datatype.DatatypeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("list content for datatype %s: %w", sc.datatype.Name, err)
+	}
+	if list == nil {
+		return nil, nil
+	}
+	entries := append([]db.ContentData(nil), *list...)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].ContentDataID < entries[j].ContentDataID })
+	return entries, nil
+}
+
+// entrySlug returns the route slug of an entry that is the root of a route.
+func entrySlug(cd db.ContentData, slugs map[types.RouteID]string) string {
+	if cd.ParentID.Valid || !cd.RouteID.Valid {
+		return ""
+	}
+	return slugs[cd.RouteID.ID]
+}
+
+func sheetColumnName(f db.Fields, locale string) string {
+	if locale == "" {
+		return f.Name
+	}
+	return f.Name + "@" + locale
+}
+
+// Export writes every entry of a datatype as one row, with a column per
+// field and, for translatable fields, per locale.
+func (s *ContentSheetService) Export(ctx context.Context, params SheetExportParams) (*spreadsheet.Sheet, error) {
+	sc, err := s.loadSchema(params.Datatype)
+	if err != nil {
+		return nil, err
+	}
+	locales := sc.locales
+	if sc.i18n && len(params.Locales) > 0 {
+		enabled := map[string]bool{}
+		for _, l := range sc.locales {
+			enabled[l] = true
+		}
+		locales = nil
+		for _, l := range params.Locales {
+			if l == sc.defaultLocale {
+				continue
+			}
+			if !enabled[l] {
+				return nil, NewValidationError("locales", fmt.Sprintf("locale %q is not enabled", l))
+			}
+			locales = append(locales, l)
+		}
+	}
+
+	type column struct {
+		field  db.Fields
+		locale string
+	}
+	sheet := &spreadsheet.Sheet{Header: []string{SheetColumnID, SheetColumnSlug, SheetColumnParent}}
+	var columns []column
+	for _, f := range sc.fields {
+		columns = append(columns, column{field: f})
+		sheet.Header = append(sheet.Header, sheetColumnName(f, ""))
+	}
+	if sc.i18n {
+		for _, l := range locales {
+			for _, f := range sc.fields {
+				if f.Translatable {
+					columns = append(columns, column{field: f, locale: l})
+					sheet.Header = append(sheet.Header, sheetColumnName(f, l))
+				}
+			}
+		}
+	}
+
+	slugs, err := s.routeSlugs()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.listEntries(sc)
+	if err != nil {
+		return nil, err
+	}
+	for _, cd := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		values, err := s.loadValues(cd.ContentDataID)
+		if err != nil {
+			return nil, err
+		}
+		row := []string{cd.ContentDataID.String(), entrySlug(cd, slugs), ""}
+		if cd.ParentID.Valid {
+			row[2] = cd.ParentID.ID.String()
+		}
+		for _, c := range columns {
+			cf, _ := values.find(sc, c.field, c.locale)
+			row = append(row, cf.FieldValue)
+		}
+		sheet.Rows = append(sheet.Rows, row)
+	}
+	return sheet, nil
+}
+
+// sheetTarget is a resolved field column.
+type sheetTarget struct {
+	index  int
+	field  db.Fields
+	locale string
+}
+
+// sheetRowPlan is the validated change for one row.
+type sheetRowPlan struct {
+	result   *SheetRowResult
+	existing *db.ContentData
+	values   entryValues
+	parent   types.NullableContentID
+	writes   []sheetWrite
+}
+
+// sheetWrite sets one content field value.
+type sheetWrite struct {
+	field   db.Fields
+	locale  string
+	value   string
+	current *db.ContentFields
+}
+
+// resolveColumns maps sheet columns to reserved columns and fields.
+func (sc *sheetSchema) resolveColumns(header []string, mapping map[string]string) ([]SheetColumn, map[string]int, []sheetTarget, error) {
+	byLabel := map[string]db.Fields{}
+	for _, f := range sc.fields {
+		byLabel[strings.ToLower(f.Label)] = f
+	}
+	enabled := map[string]bool{}
+	for _, l := range sc.locales {
+		enabled[l] = true
+	}
+
+	ve := &ValidationError{}
+	columns := make([]SheetColumn, len(header))
+	reserved := map[string]int{}
+	seen := map[string]string{}
+	var targets []sheetTarget
+	for i, h := range header {
+		columns[i] = SheetColumn{Column: h}
+		target, mapped := mapping[h]
+		if !mapped {
+			target = h
+		}
+		if target == "" {
+			columns[i].Ignored = true
+			continue
+		}
+		switch target {
+		case SheetColumnID, SheetColumnSlug, SheetColumnParent:
+			if _, dup := reserved[target]; dup {
+				ve.Add(h, fmt.Sprintf("%s is mapped from more than one column", target))
+				continue
+			}
+			reserved[target] = i
+			columns[i].Target = target
+			continue
+		}
+
+		name, locale, _ := strings.Cut(target, "@")
+		f, ok := sc.byName[name]
+		if !ok && !mapped {
+			f, ok = byLabel[strings.ToLower(name)]
+		}
+		if !ok {
+			if mapped {
+				ve.Add(h, fmt.Sprintf("no field %q in datatype %s", name, sc.datatype.Name))
+			} else {
+				columns[i].Ignored = true
+			}
+			continue
+		}
+		if locale == sc.defaultLocale {
+			locale = ""
+		}
+		if locale != "" {
+			switch {
+			case !sc.i18n:
+				ve.Add(h, "locale columns need i18n to be enabled")
+				continue
+			case !enabled[locale]:
+				ve.Add(h, fmt.Sprintf("locale %q is not enabled", locale))
+				continue
+			case !f.Translatable:
+				ve.Add(h, fmt.Sprintf("field %s is not translatable", f.Name))
+				continue
+			}
+		}
+		key := sheetColumnName(f, locale)
+		if other, dup := seen[key]; dup {
+			ve.Add(h, fmt.Sprintf("%s is also mapped from column %q", key, other))
+			continue
+		}
+		seen[key] = h
+		columns[i].Target = f.Name
+		columns[i].Locale = locale
+		targets = append(targets, sheetTarget{index: i, field: f, locale: locale})
+	}
+	if ve.HasErrors() {
+		return nil, nil, nil, ve
+	}
+	return columns, reserved, targets, nil
+}
+
+// Import upserts one entry of a datatype per sheet row.
+//
+// Every row is validated before anything is written: field values through
+// the validation package, and keys, slugs, and parents against the
+// database. Rows with errors are reported and skipped. The remaining rows
+// are written in batches of BatchSize, each in one transaction with an audit
+// record per field. New entries are drafts; rows with a _slug create a route
+// and become its root, rows with a _parent_id are appended to that parent.
+// The slug and parent of existing entries are never changed.
+func (s *ContentSheetService) Import(ctx context.Context, ac audited.AuditContext, sheet *spreadsheet.Sheet, params SheetImportParams) (*SheetImportReport, error) {
+	switch params.Key {
+	case "", SheetKeyID, SheetKeySlug:
+	default:
+		return nil, NewValidationError("key", fmt.Sprintf("unknown key %q (want %s or %s)", params.Key, SheetKeyID, SheetKeySlug))
+	}
+	sc, err := s.loadSchema(params.Datatype)
+	if err != nil {
+		return nil, err
+	}
+	columns, reserved, targets, err := sc.resolveColumns(sheet.Header, params.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	if params.Key == SheetKeyID && !hasColumn(reserved, SheetColumnID) {
+		return nil, NewValidationError("key", "upsert by id needs a "+SheetColumnID+" column")
+	}
+	if params.Key == SheetKeySlug && !hasColumn(reserved, SheetColumnSlug) {
+		return nil, NewValidationError("key", "upsert by slug needs a "+SheetColumnSlug+" column")
+	}
+
+	report := &SheetImportReport{
+		Datatype: sc.datatype.Name,
+		DryRun:   params.DryRun,
+		Columns:  columns,
+		Rows:     []SheetRowResult{},
+	}
+	plans, err := s.planRows(ctx, sc, sheet, params.Key, reserved, targets, report)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*sheetRowPlan
+	for _, p := range plans {
+		switch p.result.Action {
+		case "create", "update":
+			pending = append(pending, p)
+		}
+	}
+	if !params.DryRun {
+		size := params.BatchSize
+		if size <= 0 {
+			size = defaultSheetBatchSize
+		}
+		for start := 0; start < len(pending); start += size {
+			end := min(start+size, len(pending))
+			s.commitBatch(ctx, ac, sc, pending[start:end], report)
+			report.Batches++
+		}
+	}
+
+	for _, p := range plans {
+		report.Rows = append(report.Rows, *p.result)
+		switch p.result.Action {
+		case "create":
+			report.Created++
+		case "update":
+			report.Updated++
+		case "unchanged":
+			report.Unchanged++
+		case "failed":
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+func hasColumn(reserved map[string]int, name string) bool {
+	_, ok := reserved[name]
+	return ok
+}
+
+// planRows matches and validates every row.
+func (s *ContentSheetService) planRows(ctx context.Context, sc *sheetSchema, sheet *spreadsheet.Sheet, key string, reserved map[string]int, targets []sheetTarget, report *SheetImportReport) ([]*sheetRowPlan, error) {
+	slugs, err := s.routeSlugs()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.listEntries(sc)
+	if err != nil {
+		return nil, err
+	}
+	byID := map[types.ContentID]*db.ContentData{}
+	bySlug := map[string]*db.ContentData{}
+	for i := range entries {
+		byID[entries[i].ContentDataID] = &entries[i]
+		if slug := entrySlug(entries[i], slugs); slug != "" {
+			bySlug[slug] = &entries[i]
+		}
+	}
+	routeBySlug := map[string]bool{}
+	for _, slug := range slugs {
+		routeBySlug[slug] = true
+	}
+
+	cell := func(i int, name string) string {
+		col, ok := reserved[name]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(sheet.Cell(i, col))
+	}
+
+	var plans []*sheetRowPlan
+	claimed := map[types.ContentID]int{}
+	newSlugs := map[string]int{}
+	for i := range sheet.Rows {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		line := sheet.Line(i)
+		p := &sheetRowPlan{result: &SheetRowResult{Row: line}}
+		plans = append(plans, p)
+		fail := func(field, msg string) {
+			p.result.Action = "failed"
+			report.Errors = append(report.Errors, SheetRowError{Row: line, Field: field, Message: msg})
+		}
+
+		id := cell(i, SheetColumnID)
+		slug := cell(i, SheetColumnSlug)
+		p.result.Slug = slug
+		if key != SheetKeySlug && id != "" {
+			cd, ok := byID[types.ContentID(id)]
+			if !ok {
+				fail(SheetColumnID, fmt.Sprintf("no %s entry with id %s", sc.datatype.Name, id))
+				continue
+			}
+			p.existing = cd
+		} else if key != SheetKeyID && slug != "" {
+			p.existing = bySlug[slug]
+		}
+
+		if p.existing != nil {
+			if prev, dup := claimed[p.existing.ContentDataID]; dup {
+				fail("", fmt.Sprintf("entry %s is also updated by row %d", p.existing.ContentDataID, prev))
+				continue
+			}
+			claimed[p.existing.ContentDataID] = line
+			p.result.ContentDataID = p.existing.ContentDataID
+			p.result.Slug = entrySlug(*p.existing, slugs)
+			values, err := s.loadValues(p.existing.ContentDataID)
+			if err != nil {
+				return nil, err
+			}
+			p.values = values
+		} else if !s.planNew(p, sc, slug, cell(i, SheetColumnParent), routeBySlug, newSlugs, fail) {
+			continue
+		}
+
+		// Diff the field values. Existing entries validate the values that
+		// change; new entries validate every field, so required fields the
+		// sheet leaves empty or omits are caught.
+		var inputs []validation.FieldInput
+		for _, t := range targets {
+			value := sheet.Cell(i, t.index)
+			w := sheetWrite{field: t.field, locale: sc.fieldLocale(t.field, t.locale), value: value}
+			if cf, ok := p.values.find(sc, t.field, t.locale); ok {
+				if cf.FieldValue == value {
+					continue
+				}
+				w.current = &cf
+			} else if value == "" {
+				continue
+			}
+			p.writes = append(p.writes, w)
+			p.result.Changed = append(p.result.Changed, sheetColumnName(t.field, t.locale))
+			if p.existing != nil || t.locale != "" {
+				inputs = append(inputs, s.validationInput(t.field, value))
+			}
+		}
+		if p.existing == nil {
+			for _, f := range sc.fields {
+				value := ""
+				for _, t := range targets {
+					if t.field.FieldID == f.FieldID && t.locale == "" {
+						value = sheet.Cell(i, t.index)
+					}
+				}
+				inputs = append(inputs, s.validationInput(f, value))
+			}
+		}
+		ve := validation.ValidateBatch(inputs)
+		if ve.HasErrors() {
+			for _, fe := range ve.Fields {
+				fail(fieldName(sc, fe.FieldID), strings.Join(fe.Messages, "; "))
+			}
+			continue
+		}
+
+		switch {
+		case p.existing == nil:
+			p.result.Action = "create"
+		case len(p.writes) == 0:
+			p.result.Action = "unchanged"
+		default:
+			p.result.Action = "update"
+		}
+	}
+	return plans, nil
+}
+
+// planNew checks where a row without a matching entry would be created.
+func (s *ContentSheetService) planNew(p *sheetRowPlan, sc *sheetSchema, slug, parent string, routeBySlug map[string]bool, newSlugs map[string]int, fail func(field, msg string)) bool {
+	p.values = entryValues{}
+	switch {
+	case slug != "":
+		if err := types.Slug(slug).Validate(); err != nil {
+			fail(SheetColumnSlug, err.Error())
+			return false
+		}
+		if routeBySlug[slug] {
+			fail(SheetColumnSlug, fmt.Sprintf("route %s exists and is not a %s entry", slug, sc.datatype.Name))
+			return false
+		}
+		if prev, dup := newSlugs[slug]; dup {
+			fail(SheetColumnSlug, fmt.Sprintf("slug %s is also created by row %d", slug, prev))
+			return false
+		}
+		newSlugs[slug] = p.result.Row
+	case parent != "":
+		if _, err := s.driver.GetContentData(types.ContentID(parent)); err != nil {
+			fail(SheetColumnParent, fmt.Sprintf("parent %s not found", parent))
+			return false
+		}
+		p.parent = types.NullableContentID{ID: types.ContentID(parent), Valid: true}
+	default:
+		fail("", fmt.Sprintf("new entries need a %s or %s", SheetColumnSlug, SheetColumnParent))
+		return false
+	}
+	return true
+}
+
+func fieldName(sc *sheetSchema, id types.FieldID) string {
+	for _, f := range sc.fields {
+		if f.FieldID == id {
+			return f.Name
+		}
+	}
+	return string(id)
+}
+
+func (s *ContentSheetService) validationInput(f db.Fields, value string) validation.FieldInput {
+	return validation.FieldInput{
+		FieldID:    f.FieldID,
+		Label:      f.Label,
+		FieldType:  f.Type,
+		Value:      value,
+		Validation: s.content.resolveValidationConfig(f.ValidationID),
+		Data:       f.Data,
+	}
+}
+
+// commitBatch writes a batch in one transaction: the routes and content
+// nodes of new entries, then every field value. When the transaction fails,
+// nothing of the batch is kept and its rows are marked failed.
+func (s *ContentSheetService) commitBatch(ctx context.Context, ac audited.AuditContext, sc *sheetSchema, batch []*sheetRowPlan, report *SheetImportReport) {
+	var writable []*sheetRowPlan
+	for _, p := range batch {
+		// A route created since planning fails only its own row rather
+		// than the whole batch.
+		if p.existing == nil && !p.parent.Valid {
+			if err := s.routes.checkSlugUniqueness(p.result.Slug, ""); err != nil {
+				p.result.Action = "failed"
+				report.Errors = append(report.Errors, SheetRowError{Row: p.result.Row, Message: fmt.Sprintf("create route %s: %s", p.result.Slug, err)})
+				continue
+			}
+		}
+		writable = append(writable, p)
+	}
+	if len(writable) == 0 {
+		return
+	}
+
+	conn, _, err := s.driver.GetConnection()
+	if err != nil {
+		err = fmt.Errorf("get connection: %w", err)
+	} else {
+		err = types.WithTransaction(ctx, conn, func(tx *sql.Tx) error {
+			for _, p := range writable {
+				if p.existing == nil {
+					cd, err := s.createEntry(ctx, tx, ac, sc, p)
+					if err != nil {
+						return fmt.Errorf("row %d: %w", p.result.Row, err)
+					}
+					p.existing = cd
+				}
+				if err := s.writeFields(ctx, tx, ac, p); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if err == nil {
+		for _, p := range writable {
+			p.result.ContentDataID = p.existing.ContentDataID
+		}
+		return
+	}
+	utility.DefaultLogger.Error("sheet import: batch failed", err)
+	for _, p := range writable {
+		if p.result.Action == "create" {
+			p.existing = nil
+			p.result.ContentDataID = ""
+		}
+		p.result.Action = "failed"
+		report.Errors = append(report.Errors, SheetRowError{Row: p.result.Row, Message: "batch rolled back: " + err.Error()})
+	}
+}
+
+// localeColumn returns the column locale of a write: "" for values stored
+// in the default locale.
+func localeColumn(sc *sheetSchema, w sheetWrite) string {
+	if w.locale == sc.defaultLocale {
+		return ""
+	}
+	return w.locale
+}
+
+// writeFields writes the field values of one row in tx.
+func (s *ContentSheetService) writeFields(ctx context.Context, tx *sql.Tx, ac audited.AuditContext, p *sheetRowPlan) error {
+	now := types.TimestampNow()
+	cd := p.existing
+	contentID := types.NullableContentID{ID: cd.ContentDataID, Valid: true}
+	for _, w := range p.writes {
+		fieldID := types.NullableFieldID{ID: w.field.FieldID, Valid: true}
+		if w.current != nil {
+			err := db.UpdateContentFieldInTx(s.driver, ctx, tx, ac, db.UpdateContentFieldParams{
+				ContentFieldID: w.current.ContentFieldID,
+				RouteID:        w.current.RouteID,
+				RootID:         w.current.RootID,
+				ContentDataID:  contentID,
+				FieldID:        fieldID,
+				FieldValue:     w.value,
+				Locale:         w.current.Locale,
+				AuthorID:       ac.UserID,
+				DateCreated:    w.current.DateCreated,
+				DateModified:   now,
+			})
+			if err != nil {
+				return fmt.Errorf("row %d: update %s: %w", p.result.Row, w.field.Name, err)
+			}
+			continue
+		}
+		_, err := db.CreateContentFieldInTx(s.driver, ctx, tx, ac, db.CreateContentFieldParams{
+			RouteID:       cd.RouteID,
+			RootID:        cd.RootID,
+			ContentDataID: contentID,
+			FieldID:       fieldID,
+			FieldValue:    w.value,
+			Locale:        w.locale,
+			AuthorID:      ac.UserID,
+			DateCreated:   now,
+			DateModified:  now,
+		})
+		if err != nil {
+			return fmt.Errorf("row %d: create %s: %w", p.result.Row, w.field.Name, err)
+		}
+	}
+	return nil
+}
+
+// createEntry creates the route (for slug rows) and the draft content node
+// of a new row in tx. Like entries created in the editor, the node gets a
+// content field for every field of its datatype; the row's default-column
+// values go straight into those, leaving the other writes of p for
+// writeFields.
+func (s *ContentSheetService) createEntry(ctx context.Context, tx *sql.Tx, ac audited.AuditContext, sc *sheetSchema, p *sheetRowPlan) (*db.ContentData, error) {
+	now := types.TimestampNow()
+	var routeID types.NullableRouteID
+	if !p.parent.Valid {
+		title := p.result.Slug
+		for _, w := range p.writes {
+			if w.field.Type == types.FieldTypeTitle && localeColumn(sc, w) == "" && w.value != "" {
+				title = w.value
+				break
+			}
+		}
+		route, err := db.CreateRouteInTx(s.driver, ctx, tx, ac, db.CreateRouteParams{
+			Slug:         types.Slug(p.result.Slug),
+			Title:        title,
+			AuthorID:     types.NullableUserID{ID: ac.UserID, Valid: !ac.UserID.IsZero()},
+			DateCreated:  now,
+			DateModified: now,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create route %s: %w", p.result.Slug, err)
+		}
+		routeID = types.NullableRouteID{ID: route.RouteID, Valid: true}
+	}
+
+	node := &subtreeNode{
+		datatypeID: types.NullableDatatypeID{ID: sc.datatype.DatatypeID, Valid: true},
+		status:     types.ContentStatusDraft,
+	}
+	if err := insertSubtree(ctx, s.driver, tx, ac, node, routeID, p.parent, ac.UserID); err != nil {
+		return nil, err
+	}
+	cd, err := db.GetContentDataInTx(s.driver, ctx, tx, node.id)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := make(map[types.FieldID]string)
+	var rest []sheetWrite
+	for _, w := range p.writes {
+		if localeColumn(sc, w) == "" {
+			defaults[w.field.FieldID] = w.value
+			continue
+		}
+		rest = append(rest, w)
+	}
+	for _, f := range sc.fields {
+		if _, err := db.CreateContentFieldInTx(s.driver, ctx, tx, ac, db.CreateContentFieldParams{
+			RouteID:       cd.RouteID,
+			RootID:        cd.RootID,
+			ContentDataID: types.NullableContentID{ID: cd.ContentDataID, Valid: true},
+			FieldID:       types.NullableFieldID{ID: f.FieldID, Valid: true},
+			FieldValue:    defaults[f.FieldID],
+			AuthorID:      ac.UserID,
+			DateCreated:   now,
+			DateModified:  now,
+		}); err != nil {
+			return nil, fmt.Errorf("create %s: %w", f.Name, err)
+		}
+	}
+	p.writes = rest
+	return cd, nil
+}
@@ -0,0 +1,310 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/service"
+	"github.com/hegner123/modulacms/internal/spreadsheet"
+)
+
+// testSheetDB creates an i18n-enabled database with en and fr locales and a
+// "product" root datatype: a required translatable title, a number price,
+// and a translatable richtext body.
+func testSheetDB(t *testing.T) (db.Database, *service.ContentSheetService, audited.AuditContext) {
+	t.Helper()
+	conn, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "sheets.db"))
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if _, err := conn.Exec("PRAGMA foreign_keys=ON;"); err != nil {
+		t.Fatalf("PRAGMA foreign_keys: %v", err)
+	}
+
+	cfg := config.Config{
+		Node_ID:             types.NewNodeID().String(),
+		I18n_Enabled:        true,
+		I18n_Default_Locale: "en",
+	}
+	d := db.Database{Connection: conn, Context: context.Background(), Config: cfg}
+	if err := d.CreateAllTables(); err != nil {
+		t.Fatalf("CreateAllTables: %v", err)
+	}
+	mgr := config.NewManager(&staticProvider{cfg: &cfg})
+	if err := mgr.Load(); err != nil {
+		t.Fatalf("mgr.Load: %v", err)
+	}
+
+	ctx := context.Background()
+	userID := seedUser(t, d)
+	ac := audited.Ctx(types.NodeID(cfg.Node_ID), userID, "test", "127.0.0.1")
+	now := types.TimestampNow()
+
+	for _, l := range []db.CreateLocaleParams{
+		{Code: "en", Label: "English", IsDefault: true, IsEnabled: true, DateCreated: now},
+		{Code: "fr", Label: "French", IsEnabled: true, SortOrder: 1, DateCreated: now},
+	} {
+		if _, err := d.CreateLocale(ctx, ac, l); err != nil {
+			t.Fatalf("CreateLocale %s: %v", l.Code, err)
+		}
+	}
+
+	required, err := d.CreateValidation(ctx, ac, db.CreateValidationParams{
+		Name:         "required",
+		Config:       `{"rules":[{"rule":{"op":"required"}}]}`,
+		DateCreated:  now,
+		DateModified: now,
+	})
+	if err != nil {
+		t.Fatalf("CreateValidation: %v", err)
+	}
+	dt, err := d.CreateDatatype(ctx, ac, db.CreateDatatypeParams{
+		Name:         "product",
+		Label:        "Product",
+		Type:         string(types.DatatypeTypeRoot),
+		AuthorID:     userID,
+		DateCreated:  now,
+		DateModified: now,
+	})
+	if err != nil {
+		t.Fatalf("CreateDatatype: %v", err)
+	}
+	fields := []db.CreateFieldParams{
+		{Name: "title", Label: "Title", Type: types.FieldTypeTitle, Translatable: true,
+			ValidationID: types.NullableValidationID{ID: required.ValidationID, Valid: true}},
+		{Name: "price", Label: "Price", Type: types.FieldTypeNumber, SortOrder: 1},
+		{Name: "body", Label: "Body", Type: types.FieldTypeRichText, Translatable: true, SortOrder: 2},
+	}
+	for _, f := range fields {
+		f.ParentID = types.NullableDatatypeID{ID: dt.DatatypeID, Valid: true}
+		f.AuthorID = types.NullableUserID{ID: userID, Valid: true}
+		f.DateCreated = now
+		f.DateModified = now
+		if _, err := d.CreateField(ctx, ac, f); err != nil {
+			t.Fatalf("CreateField %s: %v", f.Name, err)
+		}
+	}
+
+	content := service.NewContentService(d, mgr, noopDispatcher{})
+	routes := service.NewRouteService(d, mgr)
+	return d, service.NewContentSheetService(d, mgr, content, routes), ac
+}
+
+func routeCount(t *testing.T, d db.Database) int {
+	t.Helper()
+	routes, err := d.ListRoutes()
+	if err != nil {
+		t.Fatalf("ListRoutes: %v", err)
+	}
+	if routes == nil {
+		return 0
+	}
+	return len(*routes)
+}
+
+func sheetColumn(t *testing.T, sheet *spreadsheet.Sheet, name string) int {
+	t.Helper()
+	for i, h := range sheet.Header {
+		if h == name {
+			return i
+		}
+	}
+	t.Fatalf("column %q not in header %q", name, sheet.Header)
+	return -1
+}
+
+func TestContentSheets_ImportAndExport(t *testing.T) {
+	t.Parallel()
+	d, svc, ac := testSheetDB(t)
+	ctx := context.Background()
+
+	sheet := &spreadsheet.Sheet{
+		Header: []string{"_slug", "Title", "price", "title@fr", "notes"},
+		Rows: [][]string{
+			{"/widget", "Widget", "10", "Gadget", "ignored"},
+			{"/gizmo", "Gizmo", "12", "", ""},
+			{"/untitled", "", "5", "", ""},
+			{"/pricey", "Pricey", "lots", "", ""},
+			{"/widget", "Widget again", "1", "", ""},
+		},
+	}
+
+	dry, err := svc.Import(ctx, ac, sheet, service.SheetImportParams{Datatype: "product", DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if dry.Created != 2 || dry.Failed != 3 {
+		t.Errorf("dry run created=%d failed=%d, want 2 and 3 (errors %+v)", dry.Created, dry.Failed, dry.Errors)
+	}
+	if !dry.Columns[4].Ignored || dry.Columns[1].Target != "title" || dry.Columns[3].Locale != "fr" {
+		t.Errorf("columns = %+v", dry.Columns)
+	}
+	failedRows := map[int]bool{}
+	for _, e := range dry.Errors {
+		failedRows[e.Row] = true
+	}
+	for _, row := range []int{4, 5, 6} {
+		if !failedRows[row] {
+			t.Errorf("expected an error for row %d, got %+v", row, dry.Errors)
+		}
+	}
+	if n := routeCount(t, d); n != 0 {
+		t.Fatalf("dry run created %d routes", n)
+	}
+
+	report, err := svc.Import(ctx, ac, sheet, service.SheetImportParams{Datatype: "product", BatchSize: 1})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if report.Created != 2 || report.Failed != 3 || report.Batches != 2 {
+		t.Errorf("created=%d failed=%d batches=%d, want 2, 3, 2", report.Created, report.Failed, report.Batches)
+	}
+	if n := routeCount(t, d); n != 2 {
+		t.Errorf("routes = %d, want 2", n)
+	}
+	widgetRoute, err := d.GetRouteID("/widget")
+	if err != nil {
+		t.Fatalf("GetRouteID: %v", err)
+	}
+	if route, err := d.GetRoute(*widgetRoute); err != nil || route.Title != "Widget" {
+		t.Errorf("route title = %v (%v), want Widget", route, err)
+	}
+
+	exported, err := svc.Export(ctx, service.SheetExportParams{Datatype: "product"})
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	want := "_content_id,_slug,_parent_id,title,price,body,title@fr,body@fr"
+	if got := strings.Join(exported.Header, ","); got != want {
+		t.Fatalf("header = %s, want %s", got, want)
+	}
+	if len(exported.Rows) != 2 {
+		t.Fatalf("exported rows = %d, want 2", len(exported.Rows))
+	}
+	slugCol := sheetColumn(t, exported, "_slug")
+	priceCol := sheetColumn(t, exported, "price")
+	frCol := sheetColumn(t, exported, "title@fr")
+	widget := -1
+	for i, row := range exported.Rows {
+		if row[slugCol] == "/widget" {
+			widget = i
+		}
+	}
+	if widget < 0 {
+		t.Fatalf("no /widget row in %q", exported.Rows)
+	}
+	if exported.Rows[widget][priceCol] != "10" || exported.Rows[widget][frCol] != "Gadget" {
+		t.Errorf("widget row = %q", exported.Rows[widget])
+	}
+
+	// Round trip: edit one cell of the export and upsert by ID.
+	exported.Rows[widget][priceCol] = "11"
+	again, err := svc.Import(ctx, ac, exported, service.SheetImportParams{Datatype: "product", Key: service.SheetKeyID})
+	if err != nil {
+		t.Fatalf("re-import: %v", err)
+	}
+	if again.Updated != 1 || again.Unchanged != 1 || again.Created != 0 || again.Failed != 0 {
+		t.Errorf("re-import = %+v", again)
+	}
+	for _, row := range again.Rows {
+		if row.Action == "update" && strings.Join(row.Changed, ",") != "price" {
+			t.Errorf("changed = %v, want [price]", row.Changed)
+		}
+	}
+	final, err := svc.Export(ctx, service.SheetExportParams{Datatype: "product", Locales: []string{"fr"}})
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if final.Rows[widget][priceCol] != "11" {
+		t.Errorf("price after re-import = %q, want 11", final.Rows[widget][priceCol])
+	}
+}
+
+func TestContentSheets_ImportErrors(t *testing.T) {
+	t.Parallel()
+	_, svc, ac := testSheetDB(t)
+	ctx := context.Background()
+	sheet := &spreadsheet.Sheet{
+		Header: []string{"_content_id", "Name"},
+		Rows:   [][]string{{"01NOTANENTRY0000000000000", "Widget"}, {"", "Orphan"}},
+	}
+
+	tests := []struct {
+		name   string
+		params service.SheetImportParams
+	}{
+		{"unknown datatype", service.SheetImportParams{Datatype: "nope"}},
+		{"unknown key", service.SheetImportParams{Datatype: "product", Key: "sku"}},
+		{"slug key without column", service.SheetImportParams{Datatype: "product", Key: service.SheetKeySlug}},
+		{"mapping to unknown field", service.SheetImportParams{Datatype: "product", Mapping: map[string]string{"Name": "name"}}},
+		{"locale not enabled", service.SheetImportParams{Datatype: "product", Mapping: map[string]string{"Name": "title@de"}}},
+		{"non-translatable locale", service.SheetImportParams{Datatype: "product", Mapping: map[string]string{"Name": "price@fr"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.Import(ctx, ac, sheet, tt.params); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	report, err := svc.Import(ctx, ac, sheet, service.SheetImportParams{
+		Datatype: "product",
+		Mapping:  map[string]string{"Name": "title"},
+	})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if report.Failed != 2 || len(report.Errors) != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+	if report.Errors[0].Field != service.SheetColumnID || !strings.Contains(report.Errors[1].Message, "_slug") {
+		t.Errorf("errors = %+v", report.Errors)
+	}
+}
+
+func TestContentSheets_ImportBatchIsAtomic(t *testing.T) {
+	t.Parallel()
+	d, svc, ac := testSheetDB(t)
+	ctx := context.Background()
+	// Fail the second row's field write inside the batch transaction.
+	if _, err := d.Connection.Exec(`CREATE TRIGGER fail_boom BEFORE INSERT ON content_fields
+		WHEN NEW.field_value = 'boom' BEGIN SELECT RAISE(ABORT, 'boom'); END`); err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+
+	sheet := &spreadsheet.Sheet{
+		Header: []string{"_slug", "title"},
+		Rows:   [][]string{{"/first", "First"}, {"/second", "boom"}},
+	}
+	report, err := svc.Import(ctx, ac, sheet, service.SheetImportParams{Datatype: "product"})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if report.Created != 0 || report.Failed != 2 {
+		t.Fatalf("report = %+v, want both rows failed", report)
+	}
+	for _, row := range report.Rows {
+		if row.ContentDataID != "" {
+			t.Errorf("row %d kept content id %s", row.Row, row.ContentDataID)
+		}
+	}
+	if n := routeCount(t, d); n != 0 {
+		t.Errorf("routes after rolled back batch = %d, want 0", n)
+	}
+	var nodes int
+	if err := d.Connection.QueryRow(`SELECT COUNT(*) FROM content_data`).Scan(&nodes); err != nil {
+		t.Fatalf("count content_data: %v", err)
+	}
+	if nodes != 0 {
+		t.Errorf("content nodes after rolled back batch = %d, want 0", nodes)
+	}
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/tree/ops"
+)
+
+// subtreeNode is one node of a content tree written by insertSubtree.
+// Callers fill in the datatype, status and children; insertSubtree sets id.
+type subtreeNode struct {
+	datatypeID types.NullableDatatypeID
+	status     types.ContentStatus
+	children   []*subtreeNode
+
+	id types.ContentID
+}
+
+// walk calls fn for n and its descendants in pre-order.
+func (n *subtreeNode) walk(fn func(n, parent *subtreeNode)) {
+	var visit func(n, parent *subtreeNode)
+	visit = func(n, parent *subtreeNode) {
+		fn(n, parent)
+		for _, c := range n.children {
+			visit(c, n)
+		}
+	}
+	visit(n, nil)
+}
+
+// subtreeRoute resolves the route of a subtree inserted under parentID, or at
+// the root of routeID when there is no parent. A route that already has root
+// content is a conflict. With neither the result is invalid.
+func subtreeRoute(driver db.DbDriver, parentID types.NullableContentID, routeID types.NullableRouteID) (types.NullableRouteID, error) {
+	if parentID.Valid {
+		parent, err := driver.GetContentData(parentID.ID)
+		if err != nil {
+			return types.NullableRouteID{}, &NotFoundError{Resource: "content_data", ID: string(parentID.ID)}
+		}
+		if routeID.Valid && parent.RouteID != routeID {
+			return types.NullableRouteID{}, NewValidationError("route_id", "does not match the route of parent_id")
+		}
+		return parent.RouteID, nil
+	}
+	if !routeID.Valid {
+		return types.NullableRouteID{}, nil
+	}
+	if _, err := driver.GetRoute(routeID.ID); err != nil {
+		return types.NullableRouteID{}, &NotFoundError{Resource: "route", ID: string(routeID.ID)}
+	}
+	existing, err := driver.ListContentDataByRoute(routeID)
+	if err != nil {
+		return types.NullableRouteID{}, fmt.Errorf("list route content: %w", err)
+	}
+	if existing != nil {
+		for _, cd := range *existing {
+			if !cd.ParentID.Valid {
+				return types.NullableRouteID{}, &ConflictError{
+					Resource: "route",
+					ID:       string(routeID.ID),
+					Detail:   "route already has root content; pass parent_id to add under it",
+				}
+			}
+		}
+	}
+	return routeID, nil
+}
+
+// insertSubtree creates the content rows of the tree under root in tx and
+// links them: parent, first-child and sibling pointers follow the order of
+// children, every row gets routeID and the root_id of the tree it joins, and
+// the subtree root is appended as the last child of parentID. Without a
+// parent the subtree root becomes a tree root and its own root_id. Content
+// fields are not written; every node's id is set when this returns.
+func insertSubtree(
+	ctx context.Context,
+	driver db.DbDriver,
+	tx *sql.Tx,
+	ac audited.AuditContext,
+	root *subtreeNode,
+	routeID types.NullableRouteID,
+	parentID types.NullableContentID,
+	authorID types.UserID,
+) error {
+	var treeRoot types.NullableContentID
+	if parentID.Valid {
+		parent, err := db.GetContentDataInTx(driver, ctx, tx, parentID.ID)
+		if err != nil {
+			return &NotFoundError{Resource: "content_data", ID: string(parentID.ID)}
+		}
+		treeRoot = parent.RootID
+		if !treeRoot.Valid {
+			treeRoot = types.NullableContentID{ID: parent.ContentDataID, Valid: true}
+		}
+	}
+
+	now := types.TimestampNow()
+	rows := make(map[*subtreeNode]*db.ContentData)
+	var createErr error
+	root.walk(func(n, parent *subtreeNode) {
+		if createErr != nil {
+			return
+		}
+		params := db.CreateContentDataParams{
+			RootID:       treeRoot,
+			RouteID:      routeID,
+			DatatypeID:   n.datatypeID,
+			AuthorID:     authorID,
+			Status:       n.status,
+			DateCreated:  now,
+			DateModified: now,
+		}
+		if params.Status == "" {
+			params.Status = types.ContentStatusDraft
+		}
+		// The subtree root is spliced under parentID after its pointers are
+		// set; AppendChild writes its parent_id.
+		if parent != nil {
+			params.ParentID = types.NullableContentID{ID: parent.id, Valid: true}
+		}
+		cd, err := db.CreateContentDataInTx(driver, ctx, tx, ac, params)
+		if err != nil {
+			createErr = err
+			return
+		}
+		n.id = cd.ContentDataID
+		rows[n] = cd
+		if !treeRoot.Valid && parent == nil {
+			treeRoot = types.NullableContentID{ID: cd.ContentDataID, Valid: true}
+		}
+	})
+	if createErr != nil {
+		return fmt.Errorf("create subtree node: %w", createErr)
+	}
+
+	var linkErr error
+	root.walk(func(n, parent *subtreeNode) {
+		if linkErr != nil {
+			return
+		}
+		cd := rows[n]
+		params := db.UpdateContentDataParams{
+			ContentDataID: cd.ContentDataID,
+			ParentID:      cd.ParentID,
+			RootID:        treeRoot,
+			RouteID:       cd.RouteID,
+			DatatypeID:    cd.DatatypeID,
+			AuthorID:      cd.AuthorID,
+			Status:        cd.Status,
+			DateCreated:   cd.DateCreated,
+			DateModified:  cd.DateModified,
+		}
+		if len(n.children) > 0 {
+			params.FirstChildID = types.NullableContentID{ID: n.children[0].id, Valid: true}
+		}
+		if parent != nil {
+			for i, sibling := range parent.children {
+				if sibling != n {
+					continue
+				}
+				if i > 0 {
+					params.PrevSiblingID = types.NullableContentID{ID: parent.children[i-1].id, Valid: true}
+				}
+				if i < len(parent.children)-1 {
+					params.NextSiblingID = types.NullableContentID{ID: parent.children[i+1].id, Valid: true}
+				}
+			}
+		}
+		// Rows were created with the tree's root_id unless they start a new
+		// tree, and leaves without siblings need no pointers.
+		if params.FirstChildID.Valid || params.PrevSiblingID.Valid || params.NextSiblingID.Valid || cd.RootID != treeRoot {
+			linkErr = db.UpdateContentDataInTx(driver, ctx, tx, ac, params)
+		}
+	})
+	if linkErr != nil {
+		return fmt.Errorf("link subtree node: %w", linkErr)
+	}
+
+	if parentID.Valid {
+		backend := &txContentBackend{driver: driver, tx: tx}
+		if err := ops.AppendChild(ctx, ac, backend, parentID.ID, root.id); err != nil {
+			return fmt.Errorf("append subtree: %w", err)
+		}
+	}
+	return nil
+}
@@ -113,3 +113,25 @@ func (s *ContentService) RestoreVersion(ctx context.Context, ac audited.AuditCon
 	}
 	return result, nil
 }
+
+// DiffVersions compares two content versions, or a version against the live
+// draft. See publishing.DiffContentVersions for how empty IDs are resolved.
+func (s *ContentService) DiffVersions(ctx context.Context, contentID types.ContentID, fromID, toID types.ContentVersionID, locale string) (*publishing.VersionDiff, error) {
+	for _, id := range []types.ContentVersionID{fromID, toID} {
+		if id == "" || id == publishing.DraftSide {
+			continue
+		}
+		version, err := s.driver.GetContentVersion(id)
+		if err != nil {
+			return nil, &NotFoundError{Resource: "content_version", ID: string(id)}
+		}
+		if version.ContentDataID != contentID {
+			return nil, NewValidationError("content_version_id", fmt.Sprintf("version %s does not belong to content %s", id, contentID))
+		}
+	}
+	diff, err := publishing.DiffContentVersions(ctx, s.driver, contentID, fromID, toID, locale)
+	if err != nil {
+		return nil, fmt.Errorf("diff content versions: %w", err)
+	}
+	return diff, nil
+}
@@ -0,0 +1,552 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/transform"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// planImport tracks state while an ImportPlan is written to the database.
+type planImport struct {
+	*importContext
+	plan       *transform.ImportPlan
+	media      MediaUploader
+	uploadsDir string
+
+	datatypes   map[string]types.DatatypeID     // type key -> item datatype
+	collections map[string]types.DatatypeID     // type key -> collection datatype
+	fields      map[string]map[string]db.Fields // type key -> field name -> field
+	content     map[string]*db.ContentData      // item key -> created content
+	assets      map[string]types.MediaID        // asset key -> uploaded media
+	missingRefs int
+}
+
+// ImportPlan writes a CMS-neutral import plan to the database.
+//
+// The imported tree is a _root node labelled with the plan title holding one
+// _collection node per type, with each type's items nested beneath its
+// collection by ParentKey. Datatypes are reused by name and fields by name
+// within their datatype, so a second export lands in the existing schema.
+// Assets are uploaded from uploadsDir first so media fields can reference
+// them; relation fields are written last, once every item has a content ID,
+// both as the field value and as content_relations rows. All content is
+// created as draft.
+func (s *ImportService) ImportPlan(ctx context.Context, ac audited.AuditContext, plan *transform.ImportPlan, routeID types.NullableRouteID, uploadsDir string) (*ImportResult, error) {
+	result := &ImportResult{Errors: []string{}}
+	p := &planImport{
+		importContext: &importContext{
+			ctx:      ctx,
+			ac:       ac,
+			driver:   s.driver,
+			routeID:  routeID,
+			authorID: ac.UserID,
+			result:   result,
+		},
+		plan:        plan,
+		media:       s.media,
+		uploadsDir:  uploadsDir,
+		datatypes:   make(map[string]types.DatatypeID),
+		collections: make(map[string]types.DatatypeID),
+		fields:      make(map[string]map[string]db.Fields),
+		content:     make(map[string]*db.ContentData),
+		assets:      make(map[string]types.MediaID),
+	}
+
+	p.uploadAssets()
+	if err := p.resolveSchema(); err != nil {
+		return nil, err
+	}
+	p.createTree()
+	p.createRelations()
+
+	result.Unmapped = plan.Unmapped
+	if p.missingRefs > 0 {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("%d relation or media references point at items that were not imported and were skipped", p.missingRefs))
+	}
+
+	result.Success = len(result.Errors) == 0
+	if result.Success {
+		result.Message = fmt.Sprintf("import complete: %d datatypes, %d fields, %d content nodes, %d relations, %d media created",
+			result.DatatypesCreated, result.FieldsCreated, result.ContentCreated, result.RelationsCreated, result.MediaCreated)
+	} else {
+		result.Message = fmt.Sprintf("import completed with %d errors", len(result.Errors))
+	}
+
+	utility.DefaultLogger.Info("import completed",
+		"source", plan.Source,
+		"datatypes", result.DatatypesCreated,
+		"fields", result.FieldsCreated,
+		"content", result.ContentCreated,
+		"relations", result.RelationsCreated,
+		"media", result.MediaCreated,
+		"errors", len(result.Errors),
+		"warnings", len(result.Warnings),
+	)
+
+	return result, nil
+}
+
+// PreviewPlan reports what ImportPlan would create for plan without writing
+// anything: each type with its field and item counts and whether a datatype
+// of that name already exists, the asset count, and the plan's unmapped
+// source types.
+func (s *ImportService) PreviewPlan(plan *transform.ImportPlan) *ImportResult {
+	preview := &ImportPreview{Items: len(plan.Items), Assets: len(plan.Assets)}
+	counts := make(map[string]int)
+	for _, item := range plan.Items {
+		counts[item.Type]++
+	}
+	for _, t := range plan.Types {
+		existing, err := s.driver.GetDatatypeByName(t.Name)
+		preview.Types = append(preview.Types, ImportPreviewType{
+			Name:   t.Name,
+			Label:  t.Label,
+			Fields: len(t.Fields),
+			Items:  counts[t.Key],
+			Exists: err == nil && existing != nil,
+		})
+	}
+	return &ImportResult{
+		Success:  true,
+		DryRun:   true,
+		Preview:  preview,
+		Unmapped: plan.Unmapped,
+		Message: fmt.Sprintf("dry run: %d types, %d items, %d attachments, %d unmapped; nothing was written",
+			len(plan.Types), len(plan.Items), len(plan.Assets), len(plan.Unmapped)),
+	}
+}
+
+// uploadAssets pulls the plan's files into the media library: bundled files
+// from the plan itself, the rest from the uploads directory. Paths are
+// resolved inside the directory only.
+func (p *planImport) uploadAssets() {
+	if len(p.plan.Assets) == 0 {
+		return
+	}
+	if p.media == nil {
+		p.result.Warnings = append(p.result.Warnings,
+			fmt.Sprintf("%d attachments skipped: media storage is not available", len(p.plan.Assets)))
+		return
+	}
+
+	var onDisk []transform.ImportAsset
+	for _, asset := range p.plan.Assets {
+		if asset.Data == nil {
+			onDisk = append(onDisk, asset)
+			continue
+		}
+		mediaID, upErr := p.uploadAsset(bytesFile{bytes.NewReader(asset.Data)}, int64(len(asset.Data)), asset)
+		if upErr != nil {
+			p.result.Warnings = append(p.result.Warnings, fmt.Sprintf("attachment %s (%s): %v", asset.Key, asset.Path, upErr))
+			continue
+		}
+		p.assets[asset.Key] = mediaID
+		p.result.MediaCreated++
+	}
+	if len(onDisk) == 0 {
+		return
+	}
+
+	if p.uploadsDir == "" {
+		p.result.Warnings = append(p.result.Warnings,
+			fmt.Sprintf("%d attachments skipped: import_uploads_dir is not configured", len(onDisk)))
+		return
+	}
+	root, err := filepath.Abs(p.uploadsDir)
+	if err != nil {
+		p.result.Errors = append(p.result.Errors, fmt.Sprintf("resolve import_uploads_dir: %v", err))
+		return
+	}
+
+	for _, asset := range onDisk {
+		if asset.Path == "" {
+			p.result.Warnings = append(p.result.Warnings, fmt.Sprintf("attachment %s has no file path", asset.Key))
+			continue
+		}
+		full := filepath.Join(root, filepath.FromSlash(asset.Path))
+		if rel, relErr := filepath.Rel(root, full); relErr != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			p.result.Warnings = append(p.result.Warnings, fmt.Sprintf("attachment %s: path %q is outside the uploads directory", asset.Key, asset.Path))
+			continue
+		}
+		mediaID, upErr := p.uploadFile(full, asset)
+		if upErr != nil {
+			p.result.Warnings = append(p.result.Warnings, fmt.Sprintf("attachment %s (%s): %v", asset.Key, asset.Path, upErr))
+			continue
+		}
+		p.assets[asset.Key] = mediaID
+		p.result.MediaCreated++
+	}
+}
+
+// uploadFile uploads one file from the uploads directory.
+func (p *planImport) uploadFile(full string, asset transform.ImportAsset) (types.MediaID, error) {
+	f, err := os.Open(full)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	return p.uploadAsset(f, info.Size(), asset)
+}
+
+// uploadAsset uploads one file under <source>/<dir of asset.Path> and copies
+// the source metadata onto it.
+func (p *planImport) uploadAsset(file multipart.File, size int64, asset transform.ImportAsset) (types.MediaID, error) {
+	keyPrefix := p.plan.Source
+	if dir := path.Dir(asset.Path); dir != "." {
+		keyPrefix = path.Join(keyPrefix, dir)
+	}
+	row, err := p.media.Upload(p.ctx, p.ac, UploadMediaParams{
+		File:   file,
+		Header: &multipart.FileHeader{Filename: path.Base(asset.Path), Size: size},
+		Path:   keyPrefix,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if asset.Title != "" || asset.Alt != "" || asset.Caption != "" || asset.Description != "" {
+		if _, metaErr := p.media.UpdateMediaMetadata(p.ctx, p.ac, UpdateMediaMetadataParams{
+			MediaID:     row.MediaID,
+			DisplayName: asset.Title,
+			Alt:         asset.Alt,
+			Caption:     asset.Caption,
+			Description: asset.Description,
+		}); metaErr != nil {
+			p.result.Warnings = append(p.result.Warnings, fmt.Sprintf("attachment %s: set metadata: %v", asset.Key, metaErr))
+		}
+	}
+	return row.MediaID, nil
+}
+
+// bytesFile adapts an in-memory file to multipart.File.
+type bytesFile struct {
+	*bytes.Reader
+}
+
+func (bytesFile) Close() error { return nil }
+
+// resolveSchema finds or creates the root, collection and item datatypes and
+// their fields. Item datatypes are resolved before any field so relation
+// fields can name their target datatype.
+func (p *planImport) resolveSchema() error {
+	for _, t := range p.plan.Types {
+		id, err := p.datatypeByName(t.Name, t.Label, t.Name)
+		if err != nil {
+			return err
+		}
+		p.datatypes[t.Key] = id
+		cid, err := p.datatypeByName(t.Name+"_collection", t.Plural, string(types.DatatypeTypeCollection))
+		if err != nil {
+			return err
+		}
+		p.collections[t.Key] = cid
+	}
+
+	for _, t := range p.plan.Types {
+		datatypeID := p.datatypes[t.Key]
+		existing, err := p.driver.ListFieldsByDatatypeID(types.NullableDatatypeID{ID: datatypeID, Valid: true})
+		if err != nil {
+			return fmt.Errorf("list fields of datatype %s: %w", t.Name, err)
+		}
+		byName := make(map[string]db.Fields)
+		if existing != nil {
+			for _, f := range *existing {
+				byName[f.Name] = f
+			}
+		}
+
+		nextSort := int64(len(byName))
+		for _, f := range t.Fields {
+			if have, ok := byName[f.Name]; ok {
+				if have.Type != f.Type {
+					p.result.Warnings = append(p.result.Warnings,
+						fmt.Sprintf("datatype %s: existing field %q is %s, import expected %s; values are written as-is", t.Name, f.Name, have.Type, f.Type))
+				}
+				continue
+			}
+			now := types.TimestampNow()
+			created, createErr := p.driver.CreateField(p.ctx, p.ac, db.CreateFieldParams{
+				ParentID:     types.NullableDatatypeID{ID: datatypeID, Valid: true},
+				SortOrder:    nextSort,
+				Name:         f.Name,
+				Label:        f.Label,
+				Data:         p.fieldData(f),
+				UIConfig:     types.EmptyJSON,
+				Type:         f.Type,
+				AuthorID:     types.NullableUserID{ID: p.authorID, Valid: !p.authorID.IsZero()},
+				DateCreated:  now,
+				DateModified: now,
+			})
+			if createErr != nil {
+				p.result.Errors = append(p.result.Errors,
+					fmt.Sprintf("failed to create field %q on datatype %s: %v", f.Name, t.Name, createErr))
+				continue
+			}
+			byName[f.Name] = *created
+			nextSort++
+			p.result.FieldsCreated++
+		}
+		p.fields[t.Key] = byName
+	}
+	return nil
+}
+
+// fieldData returns the field's data column: the relation config for _id
+// fields, the options of select fields, empty otherwise.
+func (p *planImport) fieldData(f transform.ImportField) string {
+	if f.Type == types.FieldTypeSelect && len(f.Options) > 0 {
+		data, _ := json.Marshal(map[string]any{"options": f.Options})
+		return string(data)
+	}
+	if f.Type != types.FieldTypeIDRef {
+		return ""
+	}
+	cfg := map[string]any{"cardinality": types.CardinalityOne}
+	if f.Multiple {
+		cfg["cardinality"] = types.CardinalityMany
+	}
+	if target, ok := p.datatypes[f.Target]; ok {
+		cfg["target_datatype_id"] = target
+	}
+	data, _ := json.Marshal(cfg)
+	return string(data)
+}
+
+// datatypeByName returns the datatype with the given name, creating it when
+// it does not exist.
+func (p *planImport) datatypeByName(name, label, typ string) (types.DatatypeID, error) {
+	if existing, err := p.driver.GetDatatypeByName(name); err == nil && existing != nil {
+		return existing.DatatypeID, nil
+	}
+	now := types.TimestampNow()
+	created, err := p.driver.CreateDatatype(p.ctx, p.ac, db.CreateDatatypeParams{
+		Name:         name,
+		Label:        label,
+		Type:         typ,
+		AuthorID:     p.authorID,
+		DateCreated:  now,
+		DateModified: now,
+	})
+	if err != nil {
+		return "", fmt.Errorf("create datatype %s: %w", name, err)
+	}
+	p.result.DatatypesCreated++
+	return created.DatatypeID, nil
+}
+
+// createTree creates the root node, a collection node per type that has
+// items, and every item beneath it.
+func (p *planImport) createTree() {
+	rootType, err := p.datatypeByName(p.plan.Source+"_site", p.plan.Title, string(types.DatatypeTypeRoot))
+	if err != nil {
+		p.result.Errors = append(p.result.Errors, err.Error())
+		return
+	}
+	root := p.createNode(rootType, nil)
+	if root == nil {
+		return
+	}
+
+	top, children := p.plan.Tree()
+	var collectionIDs []types.ContentID
+	for _, t := range p.plan.Types {
+		items := top[t.Key]
+		if len(items) == 0 {
+			continue
+		}
+		collection := p.createNode(p.collections[t.Key], root)
+		if collection == nil {
+			continue
+		}
+		collectionIDs = append(collectionIDs, collection.ContentDataID)
+		p.createItems(collection, items, children)
+	}
+	if len(collectionIDs) > 0 {
+		p.patchSiblingPointers(*root, collectionIDs)
+	}
+}
+
+// createItems creates items under parent, recursing into their children.
+func (p *planImport) createItems(parent *db.ContentData, items []*transform.ImportItem, children map[string][]*transform.ImportItem) {
+	ids := make([]types.ContentID, 0, len(items))
+	for _, item := range items {
+		node := p.createNode(p.datatypes[item.Type], parent)
+		if node == nil {
+			continue
+		}
+		p.content[item.Key] = node
+		ids = append(ids, node.ContentDataID)
+		p.writeValues(node, item)
+		if kids := children[item.Key]; len(kids) > 0 {
+			p.createItems(node, kids, children)
+		}
+	}
+	if len(ids) > 0 {
+		p.patchSiblingPointers(*parent, ids)
+	}
+}
+
+// createNode creates a draft content_data row. Root nodes point root_id at
+// themselves; everything else inherits it from the parent.
+func (p *planImport) createNode(datatypeID types.DatatypeID, parent *db.ContentData) *db.ContentData {
+	params := db.CreateContentDataParams{
+		RouteID:      p.routeID,
+		DatatypeID:   types.NullableDatatypeID{ID: datatypeID, Valid: true},
+		AuthorID:     p.authorID,
+		Status:       types.ContentStatusDraft,
+		DateCreated:  types.TimestampNow(),
+		DateModified: types.TimestampNow(),
+	}
+	if parent != nil {
+		params.ParentID = types.NullableContentID{ID: parent.ContentDataID, Valid: true}
+		params.RootID = parent.RootID
+	}
+	node, err := p.driver.CreateContentData(p.ctx, p.ac, params)
+	if err != nil || node == nil || node.ContentDataID.IsZero() {
+		p.result.Errors = append(p.result.Errors, fmt.Sprintf("failed to create content_data for datatype %s: %v", datatypeID, err))
+		return nil
+	}
+
+	if parent == nil {
+		node.RootID = types.NullableContentID{ID: node.ContentDataID, Valid: true}
+		if _, err := p.driver.UpdateContentData(p.ctx, p.ac, db.UpdateContentDataParams{
+			ContentDataID: node.ContentDataID,
+			RootID:        node.RootID,
+			RouteID:       node.RouteID,
+			DatatypeID:    node.DatatypeID,
+			AuthorID:      node.AuthorID,
+			Status:        node.Status,
+			DateCreated:   node.DateCreated,
+			DateModified:  node.DateModified,
+		}); err != nil {
+			p.result.Errors = append(p.result.Errors, fmt.Sprintf("failed to set root_id on content_data=%s: %v", node.ContentDataID, err))
+		}
+	}
+
+	p.result.ContentCreated++
+	return node
+}
+
+// writeValues creates the content fields for an item's plain and media
+// values. Relation values are written by createRelations.
+func (p *planImport) writeValues(node *db.ContentData, item *transform.ImportItem) {
+	t, _ := p.plan.TypeByKey(item.Type)
+	for _, f := range t.Fields {
+		if value, ok := item.Values[f.Name]; ok {
+			p.writeField(node, item.Type, f.Name, value)
+			continue
+		}
+		refs, ok := item.Media[f.Name]
+		if !ok {
+			continue
+		}
+		var ids []string
+		for _, ref := range refs {
+			if id, found := p.assets[ref]; found {
+				ids = append(ids, id.String())
+			}
+		}
+		if value, ok := joinRefs(ids, f.Multiple); ok {
+			p.writeField(node, item.Type, f.Name, value)
+		}
+	}
+}
+
+// createRelations resolves relation references now that every item exists,
+// writing the field value and one content_relations row per target.
+func (p *planImport) createRelations() {
+	for i := range p.plan.Items {
+		item := &p.plan.Items[i]
+		node, ok := p.content[item.Key]
+		if !ok || len(item.Relations) == 0 {
+			continue
+		}
+		t, _ := p.plan.TypeByKey(item.Type)
+		for _, f := range t.Fields {
+			refs, ok := item.Relations[f.Name]
+			if !ok {
+				continue
+			}
+			field, ok := p.fields[item.Type][f.Name]
+			if !ok {
+				continue
+			}
+			var ids []string
+			for _, ref := range refs {
+				target, found := p.content[ref]
+				if !found {
+					p.missingRefs++
+					continue
+				}
+				if _, err := p.driver.CreateContentRelation(p.ctx, p.ac, db.CreateContentRelationParams{
+					SourceContentID: node.ContentDataID,
+					TargetContentID: target.ContentDataID,
+					FieldID:         field.FieldID,
+					SortOrder:       int64(len(ids)),
+					DateCreated:     types.TimestampNow(),
+				}); err != nil {
+					p.result.Errors = append(p.result.Errors,
+						fmt.Sprintf("failed to relate content_data=%s to %s: %v", node.ContentDataID, target.ContentDataID, err))
+					continue
+				}
+				p.result.RelationsCreated++
+				ids = append(ids, target.ContentDataID.String())
+			}
+			if value, ok := joinRefs(ids, f.Multiple); ok {
+				p.writeField(node, item.Type, f.Name, value)
+			}
+		}
+	}
+}
+
+// writeField creates one content field on node.
+func (p *planImport) writeField(node *db.ContentData, typeKey, name, value string) {
+	field, ok := p.fields[typeKey][name]
+	if !ok {
+		return
+	}
+	now := types.TimestampNow()
+	if _, err := p.driver.CreateContentField(p.ctx, p.ac, db.CreateContentFieldParams{
+		RouteID:       node.RouteID,
+		RootID:        node.RootID,
+		ContentDataID: types.NullableContentID{ID: node.ContentDataID, Valid: true},
+		FieldID:       types.NullableFieldID{ID: field.FieldID, Valid: true},
+		FieldValue:    value,
+		AuthorID:      p.authorID,
+		DateCreated:   now,
+		DateModified:  now,
+	}); err != nil {
+		p.result.Errors = append(p.result.Errors,
+			fmt.Sprintf("failed to create content_field for field=%s content_data=%s: %v", field.FieldID, node.ContentDataID, err))
+	}
+}
+
+// joinRefs renders resolved IDs as a field value: the single ID, or a JSON
+// array for multi-value fields. It reports false when nothing resolved.
+func joinRefs(ids []string, multiple bool) (string, bool) {
+	if len(ids) == 0 {
+		return "", false
+	}
+	if !multiple {
+		return ids[0], true
+	}
+	data, _ := json.Marshal(ids)
+	return string(data), true
+}
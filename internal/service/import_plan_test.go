@@ -0,0 +1,299 @@
+// Integration tests for ImportService.ImportPlan against a real SQLite database.
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/service"
+	"github.com/hegner123/modulacms/internal/transform"
+)
+
+// fakeUploader records media rows without touching S3.
+type fakeUploader struct {
+	d     db.Database
+	paths []string
+	alts  map[types.MediaID]string
+}
+
+func (f *fakeUploader) Upload(ctx context.Context, ac audited.AuditContext, params service.UploadMediaParams) (*db.Media, error) {
+	f.paths = append(f.paths, params.Path+"/"+params.Header.Filename)
+	now := types.TimestampNow()
+	return f.d.CreateMedia(ctx, ac, db.CreateMediaParams{
+		Name:         db.NewNullString(params.Header.Filename),
+		URL:          types.URL("https://cdn.example.com/" + params.Path + "/" + params.Header.Filename),
+		AuthorID:     types.NullableUserID{ID: ac.UserID, Valid: true},
+		DateCreated:  now,
+		DateModified: now,
+	})
+}
+
+func (f *fakeUploader) UpdateMediaMetadata(ctx context.Context, ac audited.AuditContext, params service.UpdateMediaMetadataParams) (*db.Media, error) {
+	f.alts[params.MediaID] = params.Alt
+	return nil, nil
+}
+
+// testImportPlan is a small plan with a page hierarchy, a tag relation and
+// one valid and one escaping attachment path.
+func testImportPlan() *transform.ImportPlan {
+	return &transform.ImportPlan{
+		Source: "wordpress",
+		Title:  "Example Site",
+		Types: []transform.ImportType{
+			{Key: "taxonomy:post_tag", Name: "tag", Label: "Tag", Plural: "Tags", Fields: []transform.ImportField{
+				{Name: "title", Label: "Title", Type: types.FieldTypeTitle},
+			}},
+			{Key: "post_type:page", Name: "page", Label: "Page", Plural: "Pages", Fields: []transform.ImportField{
+				{Name: "title", Label: "Title", Type: types.FieldTypeTitle},
+				{Name: "featured_image", Label: "Featured Image", Type: types.FieldTypeMedia},
+				{Name: "tags", Label: "Tags", Type: types.FieldTypeIDRef, Target: "taxonomy:post_tag", Multiple: true},
+			}},
+		},
+		Items: []transform.ImportItem{
+			{Key: "post:1", Type: "post_type:page", Values: map[string]string{"title": "About"},
+				Media:     map[string][]string{"featured_image": {"post:9"}},
+				Relations: map[string][]string{"tags": {"term:post_tag:go", "term:post_tag:missing"}}},
+			{Key: "post:2", Type: "post_type:page", ParentKey: "post:1", Values: map[string]string{"title": "Team"}},
+			{Key: "term:post_tag:go", Type: "taxonomy:post_tag", Values: map[string]string{"title": "Go"}},
+		},
+		Assets: []transform.ImportAsset{
+			{Key: "post:9", Path: "2024/03/photo.jpg", Alt: "A photo"},
+			{Key: "post:10", Path: "../secret.txt"},
+		},
+	}
+}
+
+func TestImportService_ImportPlan(t *testing.T) {
+	d, _ := testDB(t)
+	userID := seedUser(t, d)
+	ac := audited.Ctx(types.NodeID(d.Config.Node_ID), userID, "test", "127.0.0.1")
+	ctx := context.Background()
+
+	uploads := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(uploads, "2024", "03"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(uploads, "2024", "03", "photo.jpg"), []byte("jpeg"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	uploader := &fakeUploader{d: d, alts: make(map[types.MediaID]string)}
+	svc := service.NewImportService(d, nil, uploader)
+
+	result, err := svc.ImportPlan(ctx, ac, testImportPlan(), types.NullableRouteID{}, uploads)
+	if err != nil {
+		t.Fatalf("ImportPlan: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("import failed: %v", result.Errors)
+	}
+	// root + 2 collections + 3 items
+	if result.ContentCreated != 6 {
+		t.Errorf("ContentCreated = %d, want 6", result.ContentCreated)
+	}
+	// tag, tag_collection, page, page_collection, wordpress_site
+	if result.DatatypesCreated != 5 {
+		t.Errorf("DatatypesCreated = %d, want 5", result.DatatypesCreated)
+	}
+	if result.FieldsCreated != 4 {
+		t.Errorf("FieldsCreated = %d, want 4", result.FieldsCreated)
+	}
+	if result.MediaCreated != 1 || result.RelationsCreated != 1 {
+		t.Errorf("MediaCreated, RelationsCreated = %d, %d, want 1, 1", result.MediaCreated, result.RelationsCreated)
+	}
+	if len(uploader.paths) != 1 || uploader.paths[0] != "wordpress/2024/03/photo.jpg" {
+		t.Errorf("uploaded = %v", uploader.paths)
+	}
+	warnings := strings.Join(result.Warnings, "\n")
+	if !strings.Contains(warnings, "outside the uploads directory") {
+		t.Errorf("missing path escape warning in %q", warnings)
+	}
+	if !strings.Contains(warnings, "1 relation or media references") {
+		t.Errorf("missing unresolved reference warning in %q", warnings)
+	}
+
+	// Locate the imported pages by title.
+	pageType, err := d.GetDatatypeByName("page")
+	if err != nil {
+		t.Fatalf("GetDatatypeByName(page): %v", err)
+	}
+	fields, err := d.ListFieldsByDatatypeID(types.NullableDatatypeID{ID: pageType.DatatypeID, Valid: true})
+	if err != nil {
+		t.Fatalf("ListFieldsByDatatypeID: %v", err)
+	}
+	fieldByName := make(map[string]db.Fields)
+	for _, f := range *fields {
+		fieldByName[f.Name] = f
+	}
+	var relCfg types.RelationConfig
+	if err := json.Unmarshal([]byte(fieldByName["tags"].Data), &relCfg); err != nil {
+		t.Fatalf("tags field data %q: %v", fieldByName["tags"].Data, err)
+	}
+	tagType, _ := d.GetDatatypeByName("tag")
+	if relCfg.TargetDatatypeID != tagType.DatatypeID || relCfg.Cardinality != types.CardinalityMany {
+		t.Errorf("tags relation config = %+v", relCfg)
+	}
+
+	all, err := d.ListContentData()
+	if err != nil {
+		t.Fatalf("ListContentData: %v", err)
+	}
+	values := make(map[types.ContentID]map[types.FieldID]string)
+	cfs, _ := d.ListContentFields()
+	for _, cf := range *cfs {
+		if values[cf.ContentDataID.ID] == nil {
+			values[cf.ContentDataID.ID] = make(map[types.FieldID]string)
+		}
+		values[cf.ContentDataID.ID][cf.FieldID.ID] = cf.FieldValue
+	}
+	byTitle := make(map[string]db.ContentData)
+	for _, cd := range *all {
+		if cd.DatatypeID.ID == pageType.DatatypeID {
+			byTitle[values[cd.ContentDataID][fieldByName["title"].FieldID]] = cd
+		}
+		if !cd.RootID.Valid {
+			t.Errorf("content_data=%s has no root_id", cd.ContentDataID)
+		}
+	}
+	about, team := byTitle["About"], byTitle["Team"]
+	if about.ContentDataID.IsZero() || team.ContentDataID.IsZero() {
+		t.Fatalf("pages not found: %v", byTitle)
+	}
+	if team.ParentID.ID != about.ContentDataID {
+		t.Errorf("Team parent = %s, want About %s", team.ParentID.ID, about.ContentDataID)
+	}
+	if about.FirstChildID.ID != team.ContentDataID {
+		t.Errorf("About first child = %s, want Team", about.FirstChildID.ID)
+	}
+
+	mediaID := types.MediaID(values[about.ContentDataID][fieldByName["featured_image"].FieldID])
+	if mediaID.IsZero() || uploader.alts[mediaID] != "A photo" {
+		t.Errorf("featured_image = %q, alts = %v", mediaID, uploader.alts)
+	}
+
+	rels, err := d.ListContentRelationsBySource(about.ContentDataID)
+	if err != nil || len(*rels) != 1 {
+		t.Fatalf("relations = %v, %v", rels, err)
+	}
+	var tagIDs []string
+	if err := json.Unmarshal([]byte(values[about.ContentDataID][fieldByName["tags"].FieldID]), &tagIDs); err != nil {
+		t.Fatalf("tags value: %v", err)
+	}
+	if len(tagIDs) != 1 || tagIDs[0] != (*rels)[0].TargetContentID.String() {
+		t.Errorf("tags value = %v, relation target = %s", tagIDs, (*rels)[0].TargetContentID)
+	}
+
+	// A second import reuses the schema.
+	again, err := svc.ImportPlan(ctx, ac, testImportPlan(), types.NullableRouteID{}, "")
+	if err != nil {
+		t.Fatalf("second ImportPlan: %v", err)
+	}
+	if again.DatatypesCreated != 0 || again.FieldsCreated != 0 {
+		t.Errorf("second import created %d datatypes, %d fields; want 0, 0", again.DatatypesCreated, again.FieldsCreated)
+	}
+	if !strings.Contains(strings.Join(again.Warnings, "\n"), "import_uploads_dir is not configured") {
+		t.Errorf("second import warnings = %v", again.Warnings)
+	}
+}
+
+func TestImportService_ImportContent_SanityDryRun(t *testing.T) {
+	d, _ := testDB(t)
+	userID := seedUser(t, d)
+	ac := audited.Ctx(types.NodeID(d.Config.Node_ID), userID, "test", "127.0.0.1")
+	ctx := context.Background()
+
+	cfg := d.Config
+	mgr := config.NewManager(&staticProvider{cfg: &cfg})
+	if err := mgr.Load(); err != nil {
+		t.Fatalf("mgr.Load: %v", err)
+	}
+	uploader := &fakeUploader{d: d, alts: make(map[types.MediaID]string)}
+	svc := service.NewImportService(d, mgr, uploader)
+
+	body := []byte(`{"_id":"a1","_type":"author","name":"Ada"}
+{"_id":"p1","_type":"post","title":"Hello","author":{"_type":"reference","_ref":"a1"},"seo":{"_type":"seo","metaTitle":"Hi"}}
+`)
+
+	preview, err := svc.ImportContent(ctx, ac, service.ImportContentInput{Format: config.FormatSanity, Body: body, DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if !preview.DryRun || preview.Preview == nil || preview.Preview.Items != 2 || len(preview.Preview.Types) != 2 {
+		t.Fatalf("preview = %+v", preview)
+	}
+	if len(preview.Unmapped) != 1 || !strings.Contains(preview.Unmapped[0], `"seo"`) {
+		t.Errorf("unmapped = %v", preview.Unmapped)
+	}
+	if all, _ := d.ListDatatypes(); all != nil && len(*all) != 0 {
+		t.Errorf("dry run created %d datatypes", len(*all))
+	}
+
+	result, err := svc.ImportContent(ctx, ac, service.ImportContentInput{Format: config.FormatSanity, Body: body})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if !result.Success || result.ContentCreated != 5 || result.RelationsCreated != 1 || len(result.Unmapped) != 1 {
+		t.Errorf("result = %+v", result)
+	}
+
+	again, err := svc.ImportContent(ctx, ac, service.ImportContentInput{Format: config.FormatSanity, Body: body, DryRun: true})
+	if err != nil {
+		t.Fatalf("second dry run: %v", err)
+	}
+	for _, pt := range again.Preview.Types {
+		if !pt.Exists {
+			t.Errorf("type %s not reported as existing", pt.Name)
+		}
+	}
+}
+
+func TestImportService_ImportPlan_BundledAssets(t *testing.T) {
+	d, _ := testDB(t)
+	userID := seedUser(t, d)
+	ac := audited.Ctx(types.NodeID(d.Config.Node_ID), userID, "test", "127.0.0.1")
+
+	uploader := &fakeUploader{d: d, alts: make(map[types.MediaID]string)}
+	svc := service.NewImportService(d, nil, uploader)
+	plan := &transform.ImportPlan{
+		Source: "strapi",
+		Title:  "Strapi Export",
+		Types: []transform.ImportType{{Key: "type:page", Name: "page", Label: "Page", Plural: "Pages", Fields: []transform.ImportField{
+			{Name: "title", Label: "Title", Type: types.FieldTypeTitle},
+			{Name: "status", Label: "Status", Type: types.FieldTypeSelect, Options: []string{"draft", "live"}},
+			{Name: "cover", Label: "Cover", Type: types.FieldTypeMedia},
+		}}},
+		Items:  []transform.ImportItem{{Key: "entry:1", Type: "type:page", Values: map[string]string{"title": "Home"}, Media: map[string][]string{"cover": {"file:1"}}}},
+		Assets: []transform.ImportAsset{{Key: "file:1", Path: "cover.png", Data: []byte("png"), Alt: "Cover"}},
+	}
+
+	// No uploads directory: bundled files do not need one.
+	result, err := svc.ImportPlan(context.Background(), ac, plan, types.NullableRouteID{}, "")
+	if err != nil {
+		t.Fatalf("ImportPlan: %v", err)
+	}
+	if result.MediaCreated != 1 || len(result.Warnings) != 0 {
+		t.Errorf("media = %d, warnings = %v", result.MediaCreated, result.Warnings)
+	}
+	if len(uploader.paths) != 1 || uploader.paths[0] != "strapi/cover.png" {
+		t.Errorf("uploaded = %v", uploader.paths)
+	}
+
+	pageType, err := d.GetDatatypeByName("page")
+	if err != nil {
+		t.Fatalf("GetDatatypeByName: %v", err)
+	}
+	fields, _ := d.ListFieldsByDatatypeID(types.NullableDatatypeID{ID: pageType.DatatypeID, Valid: true})
+	for _, f := range *fields {
+		if f.Name == "status" && f.Data != `{"options":["draft","live"]}` {
+			t.Errorf("status field data = %s", f.Data)
+		}
+	}
+}
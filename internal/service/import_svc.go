@@ -17,11 +17,20 @@ import (
 type ImportService struct {
 	driver db.DbDriver
 	mgr    *config.Manager
+	media  MediaUploader
 }
 
-// NewImportService creates an ImportService.
-func NewImportService(driver db.DbDriver, mgr *config.Manager) *ImportService {
-	return &ImportService{driver: driver, mgr: mgr}
+// MediaUploader stores imported attachments in the media library.
+// *MediaService implements it.
+type MediaUploader interface {
+	Upload(ctx context.Context, ac audited.AuditContext, params UploadMediaParams) (*db.Media, error)
+	UpdateMediaMetadata(ctx context.Context, ac audited.AuditContext, params UpdateMediaMetadataParams) (*db.Media, error)
+}
+
+// NewImportService creates an ImportService. media may be nil, in which case
+// imports that carry attachments skip them with a warning.
+func NewImportService(driver db.DbDriver, mgr *config.Manager, media MediaUploader) *ImportService {
+	return &ImportService{driver: driver, mgr: mgr, media: media}
 }
 
 // ImportContentInput holds the parameters for a content import operation.
@@ -29,6 +38,9 @@ type ImportContentInput struct {
 	Format  config.OutputFormat
 	Body    []byte // raw JSON body (already read)
 	RouteID types.NullableRouteID
+	// DryRun parses the body and reports what would be imported, including
+	// unmapped source types, without writing anything.
+	DryRun bool
 }
 
 // ImportResult represents the result of an import operation.
@@ -37,8 +49,34 @@ type ImportResult struct {
 	DatatypesCreated int      `json:"datatypes_created"`
 	FieldsCreated    int      `json:"fields_created"`
 	ContentCreated   int      `json:"content_created"`
+	RelationsCreated int      `json:"relations_created,omitempty"`
+	MediaCreated     int      `json:"media_created,omitempty"`
 	Errors           []string `json:"errors,omitempty"`
-	Message          string   `json:"message"`
+	Warnings         []string `json:"warnings,omitempty"`
+	// Unmapped lists source types and attributes with no field type
+	// equivalent; their values were skipped or stored as JSON.
+	Unmapped []string       `json:"unmapped,omitempty"`
+	DryRun   bool           `json:"dry_run,omitempty"`
+	Preview  *ImportPreview `json:"preview,omitempty"`
+	Message  string         `json:"message"`
+}
+
+// ImportPreview describes what a dry-run import would create.
+type ImportPreview struct {
+	Types  []ImportPreviewType `json:"types"`
+	Items  int                 `json:"items"`
+	Assets int                 `json:"assets"`
+}
+
+// ImportPreviewType is one source type of a dry-run import.
+type ImportPreviewType struct {
+	Name   string `json:"name"`
+	Label  string `json:"label"`
+	Fields int    `json:"fields"`
+	Items  int    `json:"items"`
+	// Exists reports that a datatype with this name is already defined and
+	// will be reused.
+	Exists bool `json:"exists"`
 }
 
 // ImportContent parses the given body using the specified CMS format transformer,
@@ -49,6 +87,29 @@ func (s *ImportService) ImportContent(ctx context.Context, ac audited.AuditConte
 		return nil, fmt.Errorf("load config: %w", err)
 	}
 
+	// WordPress, Sanity and Strapi exports carry types, references and
+	// attachments that do not fit a single content tree, so they go through
+	// an import plan.
+	var parsePlan func([]byte) (*transform.ImportPlan, error)
+	switch input.Format {
+	case config.FormatWordPress:
+		parsePlan = transform.ParseWXR
+	case config.FormatSanity:
+		parsePlan = transform.ParseSanityExport
+	case config.FormatStrapi:
+		parsePlan = transform.ParseStrapiExport
+	}
+	if parsePlan != nil {
+		plan, parseErr := parsePlan(input.Body)
+		if parseErr != nil {
+			return nil, NewValidationError("body", parseErr.Error())
+		}
+		if input.DryRun {
+			return s.PreviewPlan(plan), nil
+		}
+		return s.ImportPlan(ctx, ac, plan, input.RouteID, cfg.Import_Uploads_Dir)
+	}
+
 	// Create transformer for the specified format
 	transformCfg := transform.NewTransformConfig(
 		transform.OutputFormat(input.Format),
@@ -70,6 +131,10 @@ func (s *ImportService) ImportContent(ctx context.Context, ac audited.AuditConte
 		return nil, fmt.Errorf("parse input: %w", err)
 	}
 
+	if input.DryRun {
+		return previewRoot(root), nil
+	}
+
 	// Import to database
 	result, err := s.importRootToDatabase(ctx, ac, root, input.RouteID)
 	if err != nil {
@@ -80,6 +145,40 @@ func (s *ImportService) ImportContent(ctx context.Context, ac audited.AuditConte
 	return result, nil
 }
 
+// previewRoot reports the datatypes and node count of a parsed tree.
+func previewRoot(root model.Root) *ImportResult {
+	preview := &ImportPreview{}
+	byName := make(map[string]int) // datatype name -> index in preview.Types
+	var walk func(n *model.Node)
+	walk = func(n *model.Node) {
+		if n == nil {
+			return
+		}
+		preview.Items++
+		name := n.Datatype.Info.Name
+		if name == "" {
+			name = n.Datatype.Info.Label
+		}
+		i, ok := byName[name]
+		if !ok {
+			i = len(preview.Types)
+			byName[name] = i
+			preview.Types = append(preview.Types, ImportPreviewType{Name: name, Label: n.Datatype.Info.Label, Fields: len(n.Fields)})
+		}
+		preview.Types[i].Items++
+		for _, child := range n.Nodes {
+			walk(child)
+		}
+	}
+	walk(root.Node)
+	return &ImportResult{
+		Success: true,
+		DryRun:  true,
+		Preview: preview,
+		Message: fmt.Sprintf("dry run: %d content nodes across %d datatypes; nothing was written", preview.Items, len(preview.Types)),
+	}
+}
+
 // importContext tracks state during recursive import.
 type importContext struct {
 	ctx           context.Context
@@ -297,6 +396,7 @@ func (ctx *importContext) patchSiblingPointers(parent db.ContentData, childIDs [
 	_, err := ctx.driver.UpdateContentData(ctx.ctx, ctx.ac, db.UpdateContentDataParams{
 		ContentDataID: parent.ContentDataID,
 		RouteID:       parent.RouteID,
+		RootID:        parent.RootID,
 		ParentID:      parent.ParentID,
 		FirstChildID:  types.NullableContentID{ID: childIDs[0], Valid: true},
 		NextSiblingID: parent.NextSiblingID,
@@ -335,6 +435,7 @@ func (ctx *importContext) patchSiblingPointers(parent db.ContentData, childIDs [
 		_, updateErr := ctx.driver.UpdateContentData(ctx.ctx, ctx.ac, db.UpdateContentDataParams{
 			ContentDataID: childData.ContentDataID,
 			RouteID:       childData.RouteID,
+			RootID:        childData.RootID,
 			ParentID:      childData.ParentID,
 			FirstChildID:  childData.FirstChildID,
 			NextSiblingID: nextSibling,
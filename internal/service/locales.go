@@ -13,6 +13,8 @@ import (
 	"github.com/hegner123/modulacms/internal/db"
 	"github.com/hegner123/modulacms/internal/db/audited"
 	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/publishing"
+	"github.com/hegner123/modulacms/internal/translation"
 )
 
 // LocaleService manages locale CRUD, BCP 47 validation, fallback chain cycle
@@ -53,9 +55,14 @@ type UpdateLocaleInput struct {
 }
 
 // TranslationResult is returned by CreateTranslation / CreateAdminTranslation.
+// The pre-fill counts are only set by TranslationService.CreateTranslation.
 type TranslationResult struct {
 	Locale        string `json:"locale"`
 	FieldsCreated int    `json:"fields_created"`
+	MemoryExact   int    `json:"memory_exact,omitempty"`
+	MemoryFuzzy   int    `json:"memory_fuzzy,omitempty"`
+	Machine       int    `json:"machine,omitempty"`
+	NeedsReview   int    `json:"needs_review,omitempty"`
 }
 
 // LocaleVersionInfo holds per-locale version metadata.
@@ -264,6 +271,35 @@ func (s *LocaleService) CreateTranslation(
 	contentDataID types.ContentID,
 	locale string,
 	authorID types.UserID,
+) (*TranslationResult, error) {
+	return s.createTranslation(ctx, ac, contentDataID, locale, authorID, nil)
+}
+
+// translationSeed is one field a new translation will create. Value starts
+// as the default-locale value; a translationFiller may replace it and record
+// where the replacement came from.
+type translationSeed struct {
+	Field    db.Fields
+	Source   string
+	Value    string
+	Origin   string // "" (copied), translation.OriginMemory or translation.OriginMachine
+	Provider string
+	Score    int
+}
+
+// translationFiller pre-fills seeds before their content fields are created.
+type translationFiller func(ctx context.Context, sourceLocale, targetLocale string, seeds []translationSeed) error
+
+// createTranslation implements CreateTranslation. Seeds the filler gives an
+// Origin are flagged in translation_reviews so they block publishing until
+// reviewed.
+func (s *LocaleService) createTranslation(
+	ctx context.Context,
+	ac audited.AuditContext,
+	contentDataID types.ContentID,
+	locale string,
+	authorID types.UserID,
+	fill translationFiller,
 ) (*TranslationResult, error) {
 	cfg, err := s.mgr.Config()
 	if err != nil {
@@ -323,16 +359,10 @@ func (s *LocaleService) CreateTranslation(
 
 	defaultValueMap := make(map[types.FieldID]string)
 	if defaultFields != nil {
-		for _, df := range *defaultFields {
-			if df.FieldID.Valid {
-				defaultValueMap[df.FieldID.ID] = df.FieldValue
-			}
-		}
+		defaultValueMap = publishing.SourceValues(*defaultFields, defaultLocale)
 	}
 
-	now := types.TimestampNow()
-	created := 0
-
+	var seeds []translationSeed
 	if fields != nil {
 		for _, f := range *fields {
 			if !f.Translatable {
@@ -341,27 +371,77 @@ func (s *LocaleService) CreateTranslation(
 			if existingFieldSet[f.FieldID] {
 				continue
 			}
+			src := defaultValueMap[f.FieldID]
+			seeds = append(seeds, translationSeed{Field: f, Source: src, Value: src})
+		}
+	}
 
-			fieldValue := defaultValueMap[f.FieldID]
-
-			_, cfErr := s.driver.CreateContentField(ctx, ac, db.CreateContentFieldParams{
-				RouteID:       cd.RouteID,
-				ContentDataID: nullableContentDataID,
-				FieldID:       types.NullableFieldID{ID: f.FieldID, Valid: true},
-				FieldValue:    fieldValue,
-				Locale:        locale,
-				AuthorID:      authorID,
-				DateCreated:   now,
-				DateModified:  now,
-			})
-			if cfErr != nil {
-				return nil, fmt.Errorf("create content field for field %s locale %s: %w", f.FieldID, locale, cfErr)
+	if fill != nil && len(seeds) > 0 && defaultLocale != locale {
+		if err := fill(ctx, defaultLocale, locale, seeds); err != nil {
+			return nil, err
+		}
+	}
+
+	now := types.TimestampNow()
+	result := &TranslationResult{Locale: locale}
+
+	for _, seed := range seeds {
+		cf, cfErr := s.driver.CreateContentField(ctx, ac, db.CreateContentFieldParams{
+			RouteID:       cd.RouteID,
+			ContentDataID: nullableContentDataID,
+			FieldID:       types.NullableFieldID{ID: seed.Field.FieldID, Valid: true},
+			FieldValue:    seed.Value,
+			Locale:        locale,
+			AuthorID:      authorID,
+			DateCreated:   now,
+			DateModified:  now,
+		})
+		if cfErr != nil {
+			return nil, fmt.Errorf("create content field for field %s locale %s: %w", seed.Field.FieldID, locale, cfErr)
+		}
+		result.FieldsCreated++
+
+		// Remember which source text the translation was made from, so a
+		// later change to the source marks it stale.
+		if locale != defaultLocale {
+			if err := db.SetTranslationSource(s.driver, db.CreateTranslationSourceParams{
+				ContentFieldID: cf.ContentFieldID,
+				ContentDataID:  contentDataID,
+				FieldID:        seed.Field.FieldID,
+				Locale:         locale,
+				SourceHash:     translation.SourceHash(seed.Source),
+				RecordedAt:     time.Now().Unix(),
+			}); err != nil {
+				return nil, fmt.Errorf("record translation source for field %s: %w", cf.ContentFieldID, err)
 			}
-			created++
 		}
+
+		switch {
+		case seed.Origin == translation.OriginMemory && seed.Score == 100:
+			result.MemoryExact++
+			continue
+		case seed.Origin == translation.OriginMemory:
+			result.MemoryFuzzy++
+		case seed.Origin == translation.OriginMachine:
+			result.Machine++
+		default:
+			continue
+		}
+		if _, err := s.driver.CreateTranslationReview(db.CreateTranslationReviewParams{
+			ContentFieldID: cf.ContentFieldID,
+			ContentDataID:  contentDataID,
+			Locale:         locale,
+			Origin:         seed.Origin,
+			Provider:       seed.Provider,
+			Score:          int64(seed.Score),
+			CreatedAt:      time.Now().Unix(),
+		}); err != nil {
+			return nil, fmt.Errorf("flag content field %s for review: %w", cf.ContentFieldID, err)
+		}
+		result.NeedsReview++
 	}
 
-	return &TranslationResult{Locale: locale, FieldsCreated: created}, nil
+	return result, nil
 }
 
 // CreateAdminTranslation creates locale-specific admin content field rows for
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/presence"
+)
+
+// PresenceService manages advisory edit leases and presence on content nodes.
+type PresenceService struct {
+	driver db.DbDriver
+}
+
+// NewPresenceService creates a PresenceService.
+func NewPresenceService(driver db.DbDriver) *PresenceService {
+	return &PresenceService{driver: driver}
+}
+
+// AcquireLease takes a viewing or editing lease on a content node. canEdit is
+// whether the caller may update content; isAdmin gates p.Force. A node being
+// edited by another user returns a ConflictError naming the holder.
+func (s *PresenceService) AcquireLease(ctx context.Context, ac audited.AuditContext, p presence.AcquireParams, canEdit, isAdmin bool) (*db.ContentLease, error) {
+	if p.Mode != presence.ModeViewing && p.Mode != presence.ModeEditing {
+		return nil, NewValidationError("mode", fmt.Sprintf("must be %q or %q", presence.ModeViewing, presence.ModeEditing))
+	}
+	if p.Mode == presence.ModeEditing && !canEdit {
+		return nil, &ForbiddenError{Message: "editing leases require content:update"}
+	}
+	if p.Force && !isAdmin {
+		return nil, &ForbiddenError{Message: "only administrators can take over an edit lease"}
+	}
+	if _, err := s.driver.GetContentData(p.ContentDataID); err != nil {
+		return nil, &NotFoundError{Resource: "content_data", ID: string(p.ContentDataID)}
+	}
+
+	lease, err := presence.Acquire(s.driver, ac, p)
+	var held *presence.HeldError
+	if errors.As(err, &held) {
+		return nil, &ConflictError{Resource: "content_data", ID: string(p.ContentDataID), Detail: held.Error()}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("acquire content lease: %w", err)
+	}
+	return lease, nil
+}
+
+// Heartbeat renews a lease. A lease that expired or was released returns a
+// ConflictError; callers should acquire a new one.
+func (s *PresenceService) Heartbeat(ctx context.Context, leaseID string, userID types.UserID) (*db.ContentLease, error) {
+	lease, err := presence.Renew(s.driver, leaseID, userID, 0)
+	if err != nil {
+		return nil, leaseError(leaseID, err)
+	}
+	return lease, nil
+}
+
+// ReleaseLease removes a lease held by the caller.
+func (s *PresenceService) ReleaseLease(ctx context.Context, leaseID string, userID types.UserID) error {
+	if err := presence.Release(s.driver, leaseID, userID); err != nil {
+		return leaseError(leaseID, err)
+	}
+	return nil
+}
+
+// GetPresence returns who is viewing and editing a content node.
+func (s *PresenceService) GetPresence(ctx context.Context, contentID types.ContentID) (*presence.Presence, error) {
+	p, err := presence.Current(s.driver, contentID)
+	if err != nil {
+		return nil, fmt.Errorf("get presence: %w", err)
+	}
+	return p, nil
+}
+
+// WatchPresence calls fn with the node's presence now and on every change
+// until ctx is canceled.
+func (s *PresenceService) WatchPresence(ctx context.Context, contentID types.ContentID, fn func(*presence.Presence) error) error {
+	return presence.Watch(ctx, s.driver, contentID, fn)
+}
+
+func leaseError(leaseID string, err error) error {
+	switch {
+	case errors.Is(err, presence.ErrLeaseLost):
+		return &ConflictError{Resource: "content_lease", ID: leaseID, Detail: err.Error()}
+	case errors.Is(err, presence.ErrNotOwner):
+		return &ForbiddenError{Message: err.Error()}
+	}
+	return fmt.Errorf("content lease %s: %w", leaseID, err)
+}
@@ -38,26 +38,31 @@ type Registry struct {
 	RBAC         *RBACService
 
 	// Phase 5 — constructed post-NewRegistry and assigned externally.
-	Plugins  *PluginService
-	Webhooks *WebhookService
-	Locales  *LocaleService
+	Plugins           *PluginService
+	Webhooks          *WebhookService
+	Locales           *LocaleService
+	Translations      *TranslationService
+	ContentMigrations *ContentMigrationService
 
 	// Phase 6 — thin CRUD services.
-	Sessions  *SessionService
-	Tokens    *TokenService
-	SSHKeys   *SSHKeyService
-	OAuth     *OAuthService
-	Tables    *TableService
-	ConfigSvc *ConfigService
-	Import    *ImportService
-	Deploy    *DeployService
-	AuditLog  *AuditLogService
-	Backup    *BackupService
+	Sessions   *SessionService
+	Tokens     *TokenService
+	SSHKeys    *SSHKeyService
+	OAuth      *OAuthService
+	Tables     *TableService
+	ConfigSvc  *ConfigService
+	Import     *ImportService
+	Sheets     *ContentSheetService
+	Blueprints *BlueprintService
+	Deploy     *DeployService
+	AuditLog   *AuditLogService
+	Backup     *BackupService
 
 	// Phase 7 — auth service.
 	Auth        *AuthService
 	Search      *SearchService
 	Validations *ValidationService
+	Presence    *PresenceService
 }
 
 // NewRegistry creates a Registry with the given infrastructure dependencies.
@@ -84,7 +89,7 @@ func NewRegistry(
 	reg.AdminContent = NewAdminContentService(driver, mgr, dispatcher)
 	reg.Media = NewMediaService(ctx, driver, mgr)
 	reg.Routes = NewRouteService(driver, mgr)
-	reg.Users = NewUserService(driver, mgr)
+	reg.Users = NewUserService(driver, mgr, pc)
 	reg.RBAC = NewRBACService(driver, mgr, pc)
 	reg.Sessions = NewSessionService(driver)
 	reg.Tokens = NewTokenService(driver)
@@ -92,12 +97,15 @@ func NewRegistry(
 	reg.OAuth = NewOAuthService(driver)
 	reg.Tables = NewTableService(driver)
 	reg.ConfigSvc = NewConfigService(mgr)
-	reg.Import = NewImportService(driver, mgr)
+	reg.Import = NewImportService(driver, mgr, reg.Media)
+	reg.Sheets = NewContentSheetService(driver, mgr, reg.Content, reg.Routes)
+	reg.Blueprints = NewBlueprintService(driver, mgr, reg.Content, reg.Routes)
 	reg.Deploy = NewDeployService(driver, mgr)
 	reg.AuditLog = NewAuditLogService(driver)
-	reg.Backup = NewBackupService(mgr, driver)
+	reg.Backup = NewBackupService(mgr, driver, emailSvc, dispatcher)
 	reg.Auth = NewAuthService(driver, mgr, emailSvc)
 	reg.Validations = NewValidationService(driver)
+	reg.Presence = NewPresenceService(driver)
 	return reg
 }
 
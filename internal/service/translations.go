@@ -0,0 +1,460 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/publishing"
+	"github.com/hegner123/modulacms/internal/translation"
+)
+
+// Pre-fill modes accepted by TranslationService.CreateTranslation.
+const (
+	// PrefillNone copies the default-locale values, like LocaleService.
+	PrefillNone = ""
+	// PrefillMemory fills exact and fuzzy translation memory matches.
+	PrefillMemory = "memory"
+	// PrefillMachine fills memory matches, then asks the configured
+	// provider for the rest.
+	PrefillMachine = "machine"
+)
+
+// maxMemoryCandidates bounds the fuzzy-match candidates scored per text.
+const maxMemoryCandidates = 200
+
+// TranslationProviderSource supplies translation providers registered outside
+// the config. *plugin.Manager satisfies it with the providers of running
+// plugins.
+type TranslationProviderSource interface {
+	TranslationProviders() []translation.Provider
+}
+
+// SuggestInput asks for translations of Texts without creating any fields.
+// SourceLocale defaults to the default locale; Format to "text".
+type SuggestInput struct {
+	SourceLocale string   `json:"source_locale"`
+	TargetLocale string   `json:"target_locale"`
+	Format       string   `json:"format"`
+	Texts        []string `json:"texts"`
+	Machine      bool     `json:"machine"`
+}
+
+// Suggestion is the pre-fill for one SuggestInput text. Origin is empty when
+// neither the memory nor the provider produced a value.
+type Suggestion struct {
+	Text     string `json:"text"`
+	Value    string `json:"value"`
+	Origin   string `json:"origin,omitempty"`
+	Provider string `json:"provider,omitempty"`
+	Score    int    `json:"score,omitempty"`
+}
+
+// TranslationService pre-fills translations from the translation memory and a
+// machine-translation provider, manages the review flags on pre-filled
+// fields, and reports missing and stale translations.
+type TranslationService struct {
+	driver  db.DbDriver
+	mgr     *config.Manager
+	locales *LocaleService
+	plugins TranslationProviderSource
+}
+
+// NewTranslationService creates a TranslationService. plugins may be nil.
+func NewTranslationService(driver db.DbDriver, mgr *config.Manager, locales *LocaleService, plugins TranslationProviderSource) *TranslationService {
+	return &TranslationService{driver: driver, mgr: mgr, locales: locales, plugins: plugins}
+}
+
+// CreateTranslation creates a translation like LocaleService.CreateTranslation
+// and pre-fills it according to prefill. Fuzzy memory matches and machine
+// translations are flagged for review; exact memory matches are not.
+func (s *TranslationService) CreateTranslation(
+	ctx context.Context,
+	ac audited.AuditContext,
+	contentDataID types.ContentID,
+	locale string,
+	prefill string,
+	authorID types.UserID,
+) (*TranslationResult, error) {
+	switch prefill {
+	case PrefillNone:
+		return s.locales.CreateTranslation(ctx, ac, contentDataID, locale, authorID)
+	case PrefillMemory, PrefillMachine:
+	default:
+		return nil, NewValidationError("prefill", fmt.Sprintf("prefill must be %q, %q or empty", PrefillMemory, PrefillMachine))
+	}
+
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	var provider translation.Provider
+	if prefill == PrefillMachine {
+		provider, err = s.provider(cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	minScore := cfg.TranslationMemoryMinScore()
+
+	fill := func(ctx context.Context, sourceLocale, targetLocale string, seeds []translationSeed) error {
+		byFormat := make(map[string][]int)
+		for i := range seeds {
+			format, ok := prefillFormat(seeds[i].Field.Type)
+			if !ok || translation.Normalize(seeds[i].Source) == "" {
+				continue
+			}
+			if value, score, found := s.memoryMatch(sourceLocale, targetLocale, seeds[i].Source, minScore); found {
+				seeds[i].Value, seeds[i].Origin, seeds[i].Score = value, translation.OriginMemory, score
+				continue
+			}
+			byFormat[format] = append(byFormat[format], i)
+		}
+		if provider == nil {
+			return nil
+		}
+		for format, idx := range byFormat {
+			texts := make([]string, len(idx))
+			for j, i := range idx {
+				texts[j] = seeds[i].Source
+			}
+			out, err := translation.Translate(ctx, provider, translation.Request{
+				SourceLocale: sourceLocale,
+				TargetLocale: targetLocale,
+				Format:       format,
+				Texts:        texts,
+			})
+			if err != nil {
+				return err
+			}
+			for j, i := range idx {
+				seeds[i].Value, seeds[i].Origin, seeds[i].Provider = out[j], translation.OriginMachine, provider.Name()
+			}
+		}
+		return nil
+	}
+	return s.locales.createTranslation(ctx, ac, contentDataID, locale, authorID, fill)
+}
+
+// Suggest returns memory matches, and with Machine set provider translations,
+// for arbitrary texts. Nothing is written.
+func (s *TranslationService) Suggest(ctx context.Context, in SuggestInput) ([]Suggestion, error) {
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	if in.TargetLocale == "" {
+		return nil, NewValidationError("target_locale", "target_locale is required")
+	}
+	if in.SourceLocale == "" {
+		in.SourceLocale = cfg.I18nDefaultLocale()
+	}
+	switch in.Format {
+	case "":
+		in.Format = translation.FormatText
+	case translation.FormatText, translation.FormatHTML:
+	default:
+		return nil, NewValidationError("format", fmt.Sprintf("format must be %q or %q", translation.FormatText, translation.FormatHTML))
+	}
+	var provider translation.Provider
+	if in.Machine {
+		provider, err = s.provider(cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	minScore := cfg.TranslationMemoryMinScore()
+	out := make([]Suggestion, len(in.Texts))
+	var pending []int
+	for i, text := range in.Texts {
+		out[i] = Suggestion{Text: text}
+		if translation.Normalize(text) == "" {
+			continue
+		}
+		if value, score, found := s.memoryMatch(in.SourceLocale, in.TargetLocale, text, minScore); found {
+			out[i].Value, out[i].Origin, out[i].Score = value, translation.OriginMemory, score
+			continue
+		}
+		pending = append(pending, i)
+	}
+	if provider == nil || len(pending) == 0 {
+		return out, nil
+	}
+	texts := make([]string, len(pending))
+	for j, i := range pending {
+		texts[j] = in.Texts[i]
+	}
+	translated, err := translation.Translate(ctx, provider, translation.Request{
+		SourceLocale: in.SourceLocale,
+		TargetLocale: in.TargetLocale,
+		Format:       in.Format,
+		Texts:        texts,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range pending {
+		out[i].Value, out[i].Origin, out[i].Provider = translated[j], translation.OriginMachine, provider.Name()
+	}
+	return out, nil
+}
+
+// ListReviews returns the fields still flagged for review in a locale,
+// optionally narrowed to one route.
+func (s *TranslationService) ListReviews(ctx context.Context, routeID types.NullableRouteID, locale string) ([]db.TranslationReview, error) {
+	if locale == "" {
+		return nil, NewValidationError("locale", "locale is required")
+	}
+	var (
+		reviews *[]db.TranslationReview
+		err     error
+	)
+	if routeID.Valid {
+		reviews, err = s.driver.ListTranslationReviewsByRoute(routeID, locale)
+	} else {
+		reviews, err = s.driver.ListTranslationReviewsByLocale(locale)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list translation reviews: %w", err)
+	}
+	if reviews == nil {
+		return []db.TranslationReview{}, nil
+	}
+	return *reviews, nil
+}
+
+// MarkReviewed clears the review flag on the given content fields and records
+// the current source value as their basis, so reviewing a stale translation
+// without changing it marks it current. Fields that are not flagged are
+// ignored.
+func (s *TranslationService) MarkReviewed(ctx context.Context, ids []types.ContentFieldID) error {
+	if len(ids) == 0 {
+		return NewValidationError("content_field_ids", "at least one content_field_id is required")
+	}
+	for _, id := range ids {
+		if err := id.Validate(); err != nil {
+			return NewValidationError("content_field_ids", err.Error())
+		}
+	}
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	for _, id := range ids {
+		if err := s.driver.DeleteTranslationReview(id); err != nil {
+			return fmt.Errorf("clear translation review %s: %w", id, err)
+		}
+		cf, err := s.driver.GetContentField(id)
+		if err != nil {
+			return &NotFoundError{Resource: "content_field", ID: string(id)}
+		}
+		if err := publishing.RecordTranslationSource(s.driver, *cf, cfg.I18nDefaultLocale()); err != nil {
+			return fmt.Errorf("record translation source %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// StatusInput selects the fields reported by TranslationService.Status.
+// RouteID narrows the report to one route's content; Statuses, when set,
+// keeps only fields in those statuses (the counts always cover every field).
+type StatusInput struct {
+	Locale   string                `json:"locale"`
+	RouteID  types.NullableRouteID `json:"route_id"`
+	Statuses []string              `json:"statuses"`
+}
+
+// TranslationStatusReport counts the translatable fields of the selected
+// content by status in one locale and lists them.
+type TranslationStatusReport struct {
+	Locale    string                              `json:"locale"`
+	Missing   int                                 `json:"missing"`
+	Stale     int                                 `json:"stale"`
+	Current   int                                 `json:"current"`
+	Untracked int                                 `json:"untracked"`
+	Fields    []publishing.FieldTranslationStatus `json:"fields"`
+}
+
+// Status reports which translatable fields are missing, stale, current or
+// untracked in a non-default locale. A translation is stale when the
+// default-locale value changed after it was written.
+func (s *TranslationService) Status(ctx context.Context, in StatusInput) (*TranslationStatusReport, error) {
+	if in.Locale == "" {
+		return nil, NewValidationError("locale", "locale is required")
+	}
+	for _, status := range in.Statuses {
+		switch status {
+		case publishing.TranslationMissing, publishing.TranslationStale, publishing.TranslationCurrent, publishing.TranslationUntracked:
+		default:
+			return nil, NewValidationError("status", fmt.Sprintf("unknown status %q", status))
+		}
+	}
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	defaultLocale := cfg.I18nDefaultLocale()
+	if in.Locale == defaultLocale {
+		return nil, NewValidationError("locale", fmt.Sprintf("%q is the default locale; translations are made from it", in.Locale))
+	}
+
+	var nodes *[]db.ContentData
+	if in.RouteID.Valid {
+		nodes, err = s.driver.ListContentDataByRoute(in.RouteID)
+	} else {
+		nodes, err = s.driver.ListContentData()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list content data: %w", err)
+	}
+	var statuses []publishing.FieldTranslationStatus
+	if nodes != nil {
+		statuses, err = publishing.TranslationStatus(s.driver, *nodes, in.Locale, defaultLocale)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report := &TranslationStatusReport{Locale: in.Locale, Fields: []publishing.FieldTranslationStatus{}}
+	for _, f := range statuses {
+		switch f.Status {
+		case publishing.TranslationMissing:
+			report.Missing++
+		case publishing.TranslationStale:
+			report.Stale++
+		case publishing.TranslationCurrent:
+			report.Current++
+		case publishing.TranslationUntracked:
+			report.Untracked++
+		}
+		if len(in.Statuses) == 0 || slices.Contains(in.Statuses, f.Status) {
+			report.Fields = append(report.Fields, f)
+		}
+	}
+	return report, nil
+}
+
+// ListMemory pages through the translation memory for a locale pair.
+// sourceLocale defaults to the default locale.
+func (s *TranslationService) ListMemory(ctx context.Context, sourceLocale, targetLocale string, params db.PaginationParams) (*db.PaginatedResponse[db.TranslationMemoryEntry], error) {
+	if targetLocale == "" {
+		return nil, NewValidationError("target_locale", "target_locale is required")
+	}
+	if sourceLocale == "" {
+		cfg, err := s.mgr.Config()
+		if err != nil {
+			return nil, fmt.Errorf("load config: %w", err)
+		}
+		sourceLocale = cfg.I18nDefaultLocale()
+	}
+	entries, err := s.driver.ListTranslationMemoryByPair(db.ListTranslationMemoryByPairParams{
+		SourceLocale: sourceLocale,
+		TargetLocale: targetLocale,
+		Limit:        params.Limit,
+		Offset:       params.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list translation memory: %w", err)
+	}
+	total, err := s.driver.CountTranslationMemoryByPair(sourceLocale, targetLocale)
+	if err != nil {
+		return nil, fmt.Errorf("count translation memory: %w", err)
+	}
+	data := []db.TranslationMemoryEntry{}
+	if entries != nil {
+		data = *entries
+	}
+	return &db.PaginatedResponse[db.TranslationMemoryEntry]{
+		Data:   data,
+		Total:  *total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}, nil
+}
+
+// DeleteMemory removes a translation memory entry, e.g. a bad translation
+// that would otherwise keep being suggested.
+func (s *TranslationService) DeleteMemory(ctx context.Context, id string) error {
+	if _, err := s.driver.GetTranslationMemory(id); err != nil {
+		return &NotFoundError{Resource: "translation_memory", ID: id}
+	}
+	if err := s.driver.DeleteTranslationMemory(id); err != nil {
+		return fmt.Errorf("delete translation memory: %w", err)
+	}
+	return nil
+}
+
+// provider resolves the translation_provider config value: a built-in HTTP
+// adapter, or "<plugin>:<name>" for a plugin-registered provider.
+func (s *TranslationService) provider(cfg *config.Config) (translation.Provider, error) {
+	name := cfg.Translation_Provider
+	switch name {
+	case "":
+		return nil, NewValidationError("prefill", "no translation_provider is configured")
+	case translation.StyleDeepL, translation.StyleLibreTranslate:
+		p, err := translation.NewHTTPProvider(name, cfg.Translation_Provider_URL, cfg.Translation_Provider_API_Key)
+		if err != nil {
+			return nil, NewValidationError("translation_provider", err.Error())
+		}
+		return p, nil
+	}
+	if s.plugins != nil {
+		for _, p := range s.plugins.TranslationProviders() {
+			if p.Name() == name {
+				return p, nil
+			}
+		}
+	}
+	return nil, NewValidationError("translation_provider", fmt.Sprintf("translation provider %q is not registered by a running plugin", name))
+}
+
+// memoryMatch looks up text in the translation memory: first an exact match
+// on the normalized text, then the most similar entry of a plausible length.
+func (s *TranslationService) memoryMatch(sourceLocale, targetLocale, text string, minScore int) (string, int, bool) {
+	if entry, err := s.driver.GetTranslationMemoryBySource(sourceLocale, targetLocale, translation.SourceHash(text)); err == nil && entry != nil {
+		return entry.TargetText, 100, true
+	}
+	if minScore >= 100 {
+		return "", 0, false
+	}
+	lo, hi := translation.LengthWindow(translation.RuneLength(text), minScore)
+	entries, err := s.driver.ListTranslationMemoryCandidates(db.ListTranslationMemoryCandidatesParams{
+		SourceLocale: sourceLocale,
+		TargetLocale: targetLocale,
+		MinLength:    int64(lo),
+		MaxLength:    int64(hi),
+		Limit:        maxMemoryCandidates,
+	})
+	if err != nil || entries == nil || len(*entries) == 0 {
+		return "", 0, false
+	}
+	candidates := make([]translation.Candidate, len(*entries))
+	for i, e := range *entries {
+		candidates[i] = translation.Candidate{ID: e.TmID, Source: e.SourceText, Target: e.TargetText}
+	}
+	best, score, ok := translation.BestMatch(text, candidates, minScore)
+	if !ok {
+		return "", 0, false
+	}
+	return best.Target, score, true
+}
+
+// prefillFormat reports whether a field type holds prose worth pre-filling,
+// and the format to send a provider. Slugs, numbers, references and the like
+// keep the copied default-locale value.
+func prefillFormat(t types.FieldType) (string, bool) {
+	switch types.FieldType(strings.ToLower(string(t))) {
+	case types.FieldTypeText, types.FieldTypeTextarea, types.FieldTypeTitle:
+		return translation.FormatText, true
+	case types.FieldTypeRichText:
+		return translation.FormatHTML, true
+	default:
+		return "", false
+	}
+}
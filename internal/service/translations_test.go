@@ -0,0 +1,298 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/publishing"
+	"github.com/hegner123/modulacms/internal/service"
+	"github.com/hegner123/modulacms/internal/spreadsheet"
+	"github.com/hegner123/modulacms/internal/translation"
+)
+
+// upperProvider "translates" by upper-casing, recording each request.
+type upperProvider struct {
+	requests []translation.Request
+}
+
+func (p *upperProvider) Name() string { return "stub:upper" }
+
+func (p *upperProvider) Translate(_ context.Context, req translation.Request) ([]string, error) {
+	p.requests = append(p.requests, req)
+	out := make([]string, len(req.Texts))
+	for i, text := range req.Texts {
+		out[i] = strings.ToUpper(text)
+	}
+	return out, nil
+}
+
+type providerSource []translation.Provider
+
+func (s providerSource) TranslationProviders() []translation.Provider { return s }
+
+func TestTranslationService_CreateTranslationPrefill(t *testing.T) {
+	t.Parallel()
+	d, sheets, ac := testSheetDB(t)
+	ctx := context.Background()
+
+	if _, err := sheets.Import(ctx, ac, &spreadsheet.Sheet{
+		Header: []string{"_slug", "Title", "body"},
+		Rows:   [][]string{{"/hello", "Hello world", "<p>Good morning</p>"}},
+	}, service.SheetImportParams{Datatype: "product"}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	all, err := d.ListContentData()
+	if err != nil || all == nil || len(*all) != 1 {
+		t.Fatalf("ListContentData = %v, %v; want one node", all, err)
+	}
+	contentID := (*all)[0].ContentDataID
+	nullableID := types.NullableContentID{ID: contentID, Valid: true}
+
+	moveTranslatableToDefault(t, d, ac, nullableID)
+
+	if _, err := d.CreateTranslationMemory(db.CreateTranslationMemoryParams{
+		TmID:          types.NewULID().String(),
+		SourceLocale:  "en",
+		TargetLocale:  "fr",
+		SourceHash:    translation.SourceHash("Hello  world"),
+		SourceText:    "Hello world",
+		TargetText:    "Bonjour le monde",
+		SourceLength:  int64(translation.RuneLength("Hello world")),
+		ContentDataID: types.NewContentID(),
+		FieldID:       types.NewFieldID(),
+		UpdatedAt:     time.Now().Unix(),
+	}); err != nil {
+		t.Fatalf("CreateTranslationMemory: %v", err)
+	}
+
+	cfg := d.Config
+	cfg.Translation_Provider = "stub:upper"
+	mgr := config.NewManager(&staticProvider{cfg: &cfg})
+	if err := mgr.Load(); err != nil {
+		t.Fatalf("mgr.Load: %v", err)
+	}
+	provider := &upperProvider{}
+	svc := service.NewTranslationService(d, mgr, service.NewLocaleService(d, mgr), providerSource{provider})
+
+	result, err := svc.CreateTranslation(ctx, ac, contentID, "fr", service.PrefillMachine, ac.UserID)
+	if err != nil {
+		t.Fatalf("CreateTranslation: %v", err)
+	}
+	if result.FieldsCreated != 2 || result.MemoryExact != 1 || result.Machine != 1 || result.NeedsReview != 1 {
+		t.Errorf("result = %+v, want 2 created, 1 exact memory, 1 machine, 1 to review", result)
+	}
+	if len(provider.requests) != 1 || provider.requests[0].Format != translation.FormatHTML {
+		t.Errorf("provider requests = %+v, want one html request for the body", provider.requests)
+	}
+
+	fields, err := d.ListContentFieldsByContentDataAndLocale(nullableID, "fr")
+	if err != nil || fields == nil {
+		t.Fatalf("ListContentFieldsByContentDataAndLocale: %v", err)
+	}
+	values := make(map[string]bool)
+	for _, f := range *fields {
+		values[f.FieldValue] = true
+	}
+	if !values["Bonjour le monde"] || !values["<P>GOOD MORNING</P>"] {
+		t.Errorf("fr values = %v, want memory title and machine body", values)
+	}
+
+	reviews, err := svc.ListReviews(ctx, types.NullableRouteID{}, "fr")
+	if err != nil {
+		t.Fatalf("ListReviews: %v", err)
+	}
+	if len(reviews) != 1 || reviews[0].Origin != translation.OriginMachine || reviews[0].Provider != "stub:upper" {
+		t.Fatalf("reviews = %+v, want one machine review from stub:upper", reviews)
+	}
+	if err := svc.MarkReviewed(ctx, []types.ContentFieldID{reviews[0].ContentFieldID}); err != nil {
+		t.Fatalf("MarkReviewed: %v", err)
+	}
+	if reviews, _ := svc.ListReviews(ctx, types.NullableRouteID{}, "fr"); len(reviews) != 0 {
+		t.Errorf("reviews after MarkReviewed = %+v, want none", reviews)
+	}
+}
+
+// moveTranslatableToDefault moves the translatable values of a node from the
+// unlocalized rows the sheet import writes to the default locale, where
+// translations copy from.
+func moveTranslatableToDefault(t *testing.T, d db.Database, ac audited.AuditContext, nullableID types.NullableContentID) {
+	t.Helper()
+	ctx := context.Background()
+	existing, err := d.ListContentFieldsByContentDataAndLocale(nullableID, "en")
+	if err != nil {
+		t.Fatalf("ListContentFieldsByContentDataAndLocale: %v", err)
+	}
+	for _, f := range *existing {
+		def, err := d.GetField(f.FieldID.ID)
+		if err != nil {
+			t.Fatalf("GetField: %v", err)
+		}
+		if !def.Translatable {
+			continue
+		}
+		if err := d.DeleteContentField(ctx, ac, f.ContentFieldID); err != nil {
+			t.Fatalf("DeleteContentField: %v", err)
+		}
+		if _, err := d.CreateContentField(ctx, ac, db.CreateContentFieldParams{
+			RouteID:       f.RouteID,
+			ContentDataID: f.ContentDataID,
+			FieldID:       f.FieldID,
+			FieldValue:    f.FieldValue,
+			Locale:        "en",
+			AuthorID:      ac.UserID,
+			DateCreated:   f.DateCreated,
+			DateModified:  f.DateModified,
+		}); err != nil {
+			t.Fatalf("CreateContentField: %v", err)
+		}
+	}
+}
+
+func TestTranslationService_Suggest(t *testing.T) {
+	t.Parallel()
+	d, _, _ := testSheetDB(t)
+	for _, entry := range []struct{ source, target string }{
+		{"Add to cart", "Ajouter au panier"},
+		{"Free shipping on all orders", "Livraison gratuite sur toutes les commandes"},
+	} {
+		if _, err := d.CreateTranslationMemory(db.CreateTranslationMemoryParams{
+			TmID:          types.NewULID().String(),
+			SourceLocale:  "en",
+			TargetLocale:  "fr",
+			SourceHash:    translation.SourceHash(entry.source),
+			SourceText:    entry.source,
+			TargetText:    entry.target,
+			SourceLength:  int64(translation.RuneLength(entry.source)),
+			ContentDataID: types.NewContentID(),
+			FieldID:       types.NewFieldID(),
+			UpdatedAt:     time.Now().Unix(),
+		}); err != nil {
+			t.Fatalf("CreateTranslationMemory: %v", err)
+		}
+	}
+
+	mgr := config.NewManager(&staticProvider{cfg: &d.Config})
+	if err := mgr.Load(); err != nil {
+		t.Fatalf("mgr.Load: %v", err)
+	}
+	svc := service.NewTranslationService(d, mgr, service.NewLocaleService(d, mgr), nil)
+
+	got, err := svc.Suggest(context.Background(), service.SuggestInput{
+		TargetLocale: "fr",
+		Texts:        []string{"Add to cart", "Free shipping on all order", "Something else"},
+	})
+	if err != nil {
+		t.Fatalf("Suggest: %v", err)
+	}
+	if got[0].Value != "Ajouter au panier" || got[0].Score != 100 {
+		t.Errorf("exact suggestion = %+v", got[0])
+	}
+	if got[1].Origin != translation.OriginMemory || got[1].Score >= 100 || got[1].Score < 75 {
+		t.Errorf("fuzzy suggestion = %+v, want a memory match scoring 75-99", got[1])
+	}
+	if got[2].Origin != "" {
+		t.Errorf("unmatched suggestion = %+v, want no origin", got[2])
+	}
+
+	if _, err := svc.Suggest(context.Background(), service.SuggestInput{TargetLocale: "fr", Texts: []string{"x"}, Machine: true}); !service.IsValidation(err) {
+		t.Errorf("Suggest with no provider configured: err = %v, want validation error", err)
+	}
+}
+
+func TestTranslationService_StatusReportsStale(t *testing.T) {
+	t.Parallel()
+	d, sheets, ac := testSheetDB(t)
+	ctx := context.Background()
+
+	if _, err := sheets.Import(ctx, ac, &spreadsheet.Sheet{
+		Header: []string{"_slug", "Title", "body"},
+		Rows:   [][]string{{"/hello", "Hello world", "<p>Good morning</p>"}},
+	}, service.SheetImportParams{Datatype: "product"}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	all, err := d.ListContentData()
+	if err != nil || all == nil || len(*all) != 1 {
+		t.Fatalf("ListContentData = %v, %v; want one node", all, err)
+	}
+	contentID := (*all)[0].ContentDataID
+	nullableID := types.NullableContentID{ID: contentID, Valid: true}
+	moveTranslatableToDefault(t, d, ac, nullableID)
+
+	mgr := config.NewManager(&staticProvider{cfg: &d.Config})
+	if err := mgr.Load(); err != nil {
+		t.Fatalf("mgr.Load: %v", err)
+	}
+	svc := service.NewTranslationService(d, mgr, service.NewLocaleService(d, mgr), nil)
+	if _, err := svc.CreateTranslation(ctx, ac, contentID, "fr", service.PrefillNone, ac.UserID); err != nil {
+		t.Fatalf("CreateTranslation: %v", err)
+	}
+
+	report, err := svc.Status(ctx, service.StatusInput{Locale: "fr"})
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if report.Current != 2 || report.Stale != 0 || report.Missing != 0 {
+		t.Fatalf("report = %+v, want 2 current fields after translating", report)
+	}
+
+	// Change the default-locale title: the French title is now stale.
+	defaults, err := d.ListContentFieldsByContentDataAndLocale(nullableID, "en")
+	if err != nil || defaults == nil {
+		t.Fatalf("ListContentFieldsByContentDataAndLocale: %v", err)
+	}
+	var title db.ContentFields
+	for _, f := range *defaults {
+		if f.Locale == "en" && f.FieldValue == "Hello world" {
+			title = f
+		}
+	}
+	if title.ContentFieldID.IsZero() {
+		t.Fatal("default-locale title not found")
+	}
+	if _, err := d.UpdateContentField(ctx, ac, db.UpdateContentFieldParams{
+		RouteID:        title.RouteID,
+		RootID:         title.RootID,
+		ContentDataID:  title.ContentDataID,
+		FieldID:        title.FieldID,
+		FieldValue:     "Hello there",
+		Locale:         "en",
+		AuthorID:       ac.UserID,
+		DateCreated:    title.DateCreated,
+		DateModified:   types.TimestampNow(),
+		ContentFieldID: title.ContentFieldID,
+	}); err != nil {
+		t.Fatalf("UpdateContentField: %v", err)
+	}
+
+	report, err = svc.Status(ctx, service.StatusInput{Locale: "fr", Statuses: []string{publishing.TranslationStale}})
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if report.Stale != 1 || report.Current != 1 || len(report.Fields) != 1 || report.Fields[0].Label != "Title" {
+		t.Fatalf("report = %+v, want the title listed as the only stale field", report)
+	}
+	err = publishing.CheckTranslationsCurrent(ctx, d, contentID, "fr", "en")
+	if !publishing.IsTranslationsOutdated(err) {
+		t.Errorf("CheckTranslationsCurrent: err = %v, want outdated (title is required)", err)
+	}
+
+	if err := svc.MarkReviewed(ctx, []types.ContentFieldID{report.Fields[0].ContentFieldID}); err != nil {
+		t.Fatalf("MarkReviewed: %v", err)
+	}
+	if report, _ := svc.Status(ctx, service.StatusInput{Locale: "fr"}); report == nil || report.Stale != 0 || report.Current != 2 {
+		t.Errorf("report after MarkReviewed = %+v, want 2 current fields", report)
+	}
+	if err := publishing.CheckTranslationsCurrent(ctx, d, contentID, "fr", "en"); err != nil {
+		t.Errorf("CheckTranslationsCurrent after MarkReviewed: %v", err)
+	}
+
+	if _, err := svc.Status(ctx, service.StatusInput{Locale: "en"}); !service.IsValidation(err) {
+		t.Errorf("Status for the default locale: err = %v, want validation error", err)
+	}
+}
@@ -0,0 +1,545 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// --- Param Types ---
+
+// CreateUserGroupInput holds caller-provided fields for creating a user group.
+type CreateUserGroupInput struct {
+	Label       string
+	Description string
+}
+
+// UpdateUserGroupInput holds caller-provided fields for updating a user group.
+type UpdateUserGroupInput struct {
+	UserGroupID types.UserGroupID
+	Label       string
+	Description string
+}
+
+// errRoleAssignment is returned when a non-administrator tries to change the
+// roles a user holds, directly or through group membership.
+var errRoleAssignment = &ForbiddenError{Message: "only administrators can assign roles"}
+
+// --- User Role Methods ---
+
+// ListUserRoles returns the direct role assignments for a user. The user's
+// primary role (users.role) is included once the assignment set is in sync.
+func (s *RBACService) ListUserRoles(ctx context.Context, userID types.UserID) (*[]db.UserRoles, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, NewValidationError("user_id", fmt.Sprintf("invalid user_id: %v", err))
+	}
+	return s.driver.ListUserRolesByUserID(userID)
+}
+
+// EffectiveUserRoles returns every role that contributes permissions to the
+// user: the primary role first, then direct assignments and group roles.
+func (s *RBACService) EffectiveUserRoles(ctx context.Context, userID types.UserID) ([]types.RoleID, error) {
+	user, err := s.driver.GetUser(userID)
+	if err != nil {
+		return nil, &NotFoundError{Resource: "user", ID: string(userID)}
+	}
+	return s.pc.RolesForUser(user.UserID, types.RoleID(user.Role)), nil
+}
+
+// AssignUserRole adds a role to a user and refreshes the permission cache.
+// Assigning a role the user already holds returns a ConflictError. isAdmin
+// reports whether the caller is an administrator; only they can assign roles.
+func (s *RBACService) AssignUserRole(ctx context.Context, ac audited.AuditContext, userID types.UserID, roleID types.RoleID, isAdmin bool) (*db.UserRoles, error) {
+	if !isAdmin {
+		return nil, errRoleAssignment
+	}
+	if _, err := s.driver.GetUser(userID); err != nil {
+		return nil, &NotFoundError{Resource: "user", ID: string(userID)}
+	}
+	if _, err := s.driver.GetRole(roleID); err != nil {
+		return nil, &NotFoundError{Resource: "role", ID: string(roleID)}
+	}
+
+	created, err := s.driver.CreateUserRole(ctx, ac, db.CreateUserRoleParams{
+		UserID: userID,
+		RoleID: roleID,
+	})
+	if err != nil {
+		if isConstraintOrNotFound(err) {
+			return nil, &ConflictError{Resource: "user_role", ID: string(roleID), Detail: "role already assigned to user"}
+		}
+		return nil, fmt.Errorf("create user role: %w", err)
+	}
+
+	s.refreshCache()
+	return created, nil
+}
+
+// RevokeUserRole removes a role assignment. The user's primary role cannot be
+// revoked here; change it through the user update instead.
+func (s *RBACService) RevokeUserRole(ctx context.Context, ac audited.AuditContext, id types.UserRoleID) error {
+	ur, err := s.driver.GetUserRole(id)
+	if err != nil {
+		return &NotFoundError{Resource: "user_role", ID: string(id)}
+	}
+
+	user, err := s.driver.GetUser(ur.UserID)
+	if err != nil {
+		return &NotFoundError{Resource: "user", ID: string(ur.UserID)}
+	}
+	if types.RoleID(user.Role) == ur.RoleID {
+		return &ForbiddenError{Message: "cannot revoke a user's primary role"}
+	}
+
+	if err := s.driver.DeleteUserRole(ctx, ac, id); err != nil {
+		return fmt.Errorf("delete user role: %w", err)
+	}
+
+	s.refreshCache()
+	return nil
+}
+
+// SyncUserRoles replaces the user's role assignments with the provided set in
+// one transaction. The primary role is always retained even when omitted.
+// Roles that do not exist are skipped and returned like SyncRolePermissions.
+// Only administrators (isAdmin) can change a user's roles.
+func (s *RBACService) SyncUserRoles(ctx context.Context, ac audited.AuditContext, userID types.UserID, roleIDs []types.RoleID, isAdmin bool) ([]types.RoleID, error) {
+	if !isAdmin {
+		return nil, errRoleAssignment
+	}
+	user, err := s.driver.GetUser(userID)
+	if err != nil {
+		return nil, &NotFoundError{Resource: "user", ID: string(userID)}
+	}
+
+	primary := types.RoleID(user.Role)
+	wanted := make([]types.RoleID, 0, len(roleIDs)+1)
+	seen := make(map[types.RoleID]bool, len(roleIDs)+1)
+	if !primary.IsZero() {
+		wanted = append(wanted, primary)
+		seen[primary] = true
+	}
+	for _, rid := range roleIDs {
+		if seen[rid] {
+			continue
+		}
+		seen[rid] = true
+		wanted = append(wanted, rid)
+	}
+
+	// Resolve the roles first: a failed insert aborts the transaction on
+	// PostgreSQL, so only existing roles are written.
+	var failedIDs []types.RoleID
+	valid := wanted[:0]
+	for _, rid := range wanted {
+		if _, err := s.driver.GetRole(rid); err != nil {
+			failedIDs = append(failedIDs, rid)
+			continue
+		}
+		valid = append(valid, rid)
+	}
+
+	conn, _, err := s.driver.GetConnection()
+	if err != nil {
+		return nil, fmt.Errorf("sync user roles: get connection: %w", err)
+	}
+	err = types.WithTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if err := db.DeleteUserRolesByUserIDInTx(s.driver, ctx, tx, userID); err != nil {
+			return fmt.Errorf("delete existing user roles: %w", err)
+		}
+		for _, rid := range valid {
+			if _, err := db.CreateUserRoleInTx(s.driver, ctx, tx, ac, db.CreateUserRoleParams{
+				UserID: userID,
+				RoleID: rid,
+			}); err != nil {
+				return fmt.Errorf("create user role for %s: %w", rid, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.refreshCache()
+	return failedIDs, nil
+}
+
+// --- User Group Methods ---
+
+// CreateUserGroup validates the label, enforces uniqueness, and creates a group.
+func (s *RBACService) CreateUserGroup(ctx context.Context, ac audited.AuditContext, input CreateUserGroupInput) (*db.UserGroups, error) {
+	if input.Label == "" {
+		return nil, NewValidationError("label", "label is required")
+	}
+	if _, err := s.driver.GetUserGroupByLabel(input.Label); err == nil {
+		return nil, &ConflictError{Resource: "user_group", ID: input.Label, Detail: "label already exists"}
+	}
+
+	now := types.NewTimestamp(time.Now().UTC())
+	created, err := s.driver.CreateUserGroup(ctx, ac, db.CreateUserGroupParams{
+		Label:        input.Label,
+		Description:  input.Description,
+		DateCreated:  now,
+		DateModified: now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create user group: %w", err)
+	}
+	return created, nil
+}
+
+// UpdateUserGroup validates input, enforces label uniqueness, and updates the group.
+func (s *RBACService) UpdateUserGroup(ctx context.Context, ac audited.AuditContext, input UpdateUserGroupInput) (*db.UserGroups, error) {
+	existing, err := s.driver.GetUserGroup(input.UserGroupID)
+	if err != nil {
+		return nil, &NotFoundError{Resource: "user_group", ID: string(input.UserGroupID)}
+	}
+
+	if input.Label == "" {
+		return nil, NewValidationError("label", "label is required")
+	}
+	if input.Label != existing.Label {
+		if _, err := s.driver.GetUserGroupByLabel(input.Label); err == nil {
+			return nil, &ConflictError{Resource: "user_group", ID: input.Label, Detail: "label already exists"}
+		}
+	}
+
+	_, err = s.driver.UpdateUserGroup(ctx, ac, db.UpdateUserGroupParams{
+		Label:        input.Label,
+		Description:  input.Description,
+		DateCreated:  existing.DateCreated,
+		DateModified: types.NewTimestamp(time.Now().UTC()),
+		UserGroupID:  input.UserGroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update user group: %w", err)
+	}
+
+	updated, err := s.driver.GetUserGroup(input.UserGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch updated user group: %w", err)
+	}
+	return updated, nil
+}
+
+// DeleteUserGroup removes the group's members and roles, deletes the group,
+// and refreshes the permission cache.
+func (s *RBACService) DeleteUserGroup(ctx context.Context, ac audited.AuditContext, groupID types.UserGroupID) error {
+	if _, err := s.driver.GetUserGroup(groupID); err != nil {
+		return &NotFoundError{Resource: "user_group", ID: string(groupID)}
+	}
+
+	// Delete junction rows first (prevents orphaned rows on engines without FK enforcement)
+	if err := s.driver.DeleteUserGroupMembersByUserGroupID(ctx, ac, groupID); err != nil {
+		return fmt.Errorf("delete user group members: %w", err)
+	}
+	if err := s.driver.DeleteUserGroupRolesByUserGroupID(ctx, ac, groupID); err != nil {
+		return fmt.Errorf("delete user group roles: %w", err)
+	}
+
+	if err := s.driver.DeleteUserGroup(ctx, ac, groupID); err != nil {
+		return fmt.Errorf("delete user group: %w", err)
+	}
+
+	s.refreshCache()
+	return nil
+}
+
+// GetUserGroup retrieves a user group by ID with NotFoundError mapping.
+func (s *RBACService) GetUserGroup(ctx context.Context, groupID types.UserGroupID) (*db.UserGroups, error) {
+	group, err := s.driver.GetUserGroup(groupID)
+	if err != nil {
+		return nil, &NotFoundError{Resource: "user_group", ID: string(groupID)}
+	}
+	return group, nil
+}
+
+// ListUserGroups returns all user groups.
+func (s *RBACService) ListUserGroups(ctx context.Context) (*[]db.UserGroups, error) {
+	return s.driver.ListUserGroups()
+}
+
+// --- User Group Member Methods ---
+
+// ListUserGroupMembers returns the membership rows for a group.
+func (s *RBACService) ListUserGroupMembers(ctx context.Context, groupID types.UserGroupID) (*[]db.UserGroupMembers, error) {
+	if err := groupID.Validate(); err != nil {
+		return nil, NewValidationError("user_group_id", fmt.Sprintf("invalid user_group_id: %v", err))
+	}
+	return s.driver.ListUserGroupMembersByUserGroupID(groupID)
+}
+
+// ListUserGroupMembershipsByUser returns the membership rows for a user.
+func (s *RBACService) ListUserGroupMembershipsByUser(ctx context.Context, userID types.UserID) (*[]db.UserGroupMembers, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, NewValidationError("user_id", fmt.Sprintf("invalid user_id: %v", err))
+	}
+	return s.driver.ListUserGroupMembersByUserID(userID)
+}
+
+// AddUserGroupMember adds a user to a group and refreshes the permission cache.
+// Membership grants the group's roles, so only administrators (isAdmin) can
+// add members.
+func (s *RBACService) AddUserGroupMember(ctx context.Context, ac audited.AuditContext, groupID types.UserGroupID, userID types.UserID, isAdmin bool) (*db.UserGroupMembers, error) {
+	if !isAdmin {
+		return nil, errRoleAssignment
+	}
+	if _, err := s.driver.GetUserGroup(groupID); err != nil {
+		return nil, &NotFoundError{Resource: "user_group", ID: string(groupID)}
+	}
+	if _, err := s.driver.GetUser(userID); err != nil {
+		return nil, &NotFoundError{Resource: "user", ID: string(userID)}
+	}
+
+	created, err := s.driver.CreateUserGroupMember(ctx, ac, db.CreateUserGroupMemberParams{
+		UserGroupID: groupID,
+		UserID:      userID,
+	})
+	if err != nil {
+		if isConstraintOrNotFound(err) {
+			return nil, &ConflictError{Resource: "user_group_member", ID: string(userID), Detail: "user is already a member of the group"}
+		}
+		return nil, fmt.Errorf("create user group member: %w", err)
+	}
+
+	s.refreshCache()
+	return created, nil
+}
+
+// RemoveUserGroupMember removes a membership row and refreshes the permission cache.
+func (s *RBACService) RemoveUserGroupMember(ctx context.Context, ac audited.AuditContext, id types.UserGroupMemberID) error {
+	if _, err := s.driver.GetUserGroupMember(id); err != nil {
+		return &NotFoundError{Resource: "user_group_member", ID: string(id)}
+	}
+
+	if err := s.driver.DeleteUserGroupMember(ctx, ac, id); err != nil {
+		return fmt.Errorf("delete user group member: %w", err)
+	}
+
+	s.refreshCache()
+	return nil
+}
+
+// --- User Group Role Methods ---
+
+// ListUserGroupRoles returns the role rows granted to a group.
+func (s *RBACService) ListUserGroupRoles(ctx context.Context, groupID types.UserGroupID) (*[]db.UserGroupRoles, error) {
+	if err := groupID.Validate(); err != nil {
+		return nil, NewValidationError("user_group_id", fmt.Sprintf("invalid user_group_id: %v", err))
+	}
+	return s.driver.ListUserGroupRolesByUserGroupID(groupID)
+}
+
+// AssignUserGroupRole grants a role to every member of a group and refreshes
+// the permission cache. Only administrators (isAdmin) can assign roles.
+func (s *RBACService) AssignUserGroupRole(ctx context.Context, ac audited.AuditContext, groupID types.UserGroupID, roleID types.RoleID, isAdmin bool) (*db.UserGroupRoles, error) {
+	if !isAdmin {
+		return nil, errRoleAssignment
+	}
+	if _, err := s.driver.GetUserGroup(groupID); err != nil {
+		return nil, &NotFoundError{Resource: "user_group", ID: string(groupID)}
+	}
+	if _, err := s.driver.GetRole(roleID); err != nil {
+		return nil, &NotFoundError{Resource: "role", ID: string(roleID)}
+	}
+
+	created, err := s.driver.CreateUserGroupRole(ctx, ac, db.CreateUserGroupRoleParams{
+		UserGroupID: groupID,
+		RoleID:      roleID,
+	})
+	if err != nil {
+		if isConstraintOrNotFound(err) {
+			return nil, &ConflictError{Resource: "user_group_role", ID: string(roleID), Detail: "role already assigned to group"}
+		}
+		return nil, fmt.Errorf("create user group role: %w", err)
+	}
+
+	s.refreshCache()
+	return created, nil
+}
+
+// RevokeUserGroupRole removes a role from a group and refreshes the permission cache.
+func (s *RBACService) RevokeUserGroupRole(ctx context.Context, ac audited.AuditContext, id types.UserGroupRoleID) error {
+	if _, err := s.driver.GetUserGroupRole(id); err != nil {
+		return &NotFoundError{Resource: "user_group_role", ID: string(id)}
+	}
+
+	if err := s.driver.DeleteUserGroupRole(ctx, ac, id); err != nil {
+		return fmt.Errorf("delete user group role: %w", err)
+	}
+
+	s.refreshCache()
+	return nil
+}
+
+// --- Sync Methods ---
+
+// SyncUserGroupRoles replaces the roles granted to a group with the provided
+// set in one transaction. Roles that do not exist are skipped and returned
+// like SyncRolePermissions. Only administrators (isAdmin) can assign roles.
+func (s *RBACService) SyncUserGroupRoles(ctx context.Context, ac audited.AuditContext, groupID types.UserGroupID, roleIDs []types.RoleID, isAdmin bool) ([]types.RoleID, error) {
+	if !isAdmin {
+		return nil, errRoleAssignment
+	}
+	if _, err := s.driver.GetUserGroup(groupID); err != nil {
+		return nil, &NotFoundError{Resource: "user_group", ID: string(groupID)}
+	}
+
+	// Resolve the roles first: a failed insert aborts the transaction on
+	// PostgreSQL, so only existing roles are written.
+	var failedIDs []types.RoleID
+	valid := make([]types.RoleID, 0, len(roleIDs))
+	seen := make(map[types.RoleID]bool, len(roleIDs))
+	for _, rid := range roleIDs {
+		if seen[rid] {
+			continue
+		}
+		seen[rid] = true
+		if _, err := s.driver.GetRole(rid); err != nil {
+			failedIDs = append(failedIDs, rid)
+			continue
+		}
+		valid = append(valid, rid)
+	}
+
+	conn, _, err := s.driver.GetConnection()
+	if err != nil {
+		return nil, fmt.Errorf("sync user group roles: get connection: %w", err)
+	}
+	err = types.WithTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if err := db.DeleteUserGroupRolesByUserGroupIDInTx(s.driver, ctx, tx, groupID); err != nil {
+			return fmt.Errorf("delete existing user group roles: %w", err)
+		}
+		for _, rid := range valid {
+			if _, err := db.CreateUserGroupRoleInTx(s.driver, ctx, tx, ac, db.CreateUserGroupRoleParams{
+				UserGroupID: groupID,
+				RoleID:      rid,
+			}); err != nil {
+				return fmt.Errorf("create user group role for %s: %w", rid, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.refreshCache()
+	return failedIDs, nil
+}
+
+// SyncUserGroupMembers replaces the members of a group with the provided set
+// in one transaction. Users that do not exist are skipped and returned like
+// SyncRolePermissions. Only administrators (isAdmin) can change members.
+func (s *RBACService) SyncUserGroupMembers(ctx context.Context, ac audited.AuditContext, groupID types.UserGroupID, userIDs []types.UserID, isAdmin bool) ([]types.UserID, error) {
+	if !isAdmin {
+		return nil, errRoleAssignment
+	}
+	if _, err := s.driver.GetUserGroup(groupID); err != nil {
+		return nil, &NotFoundError{Resource: "user_group", ID: string(groupID)}
+	}
+
+	// Resolve the users first for the same reason as SyncUserGroupRoles.
+	var failedIDs []types.UserID
+	valid := make([]types.UserID, 0, len(userIDs))
+	seen := make(map[types.UserID]bool, len(userIDs))
+	for _, uid := range userIDs {
+		if seen[uid] {
+			continue
+		}
+		seen[uid] = true
+		if _, err := s.driver.GetUser(uid); err != nil {
+			failedIDs = append(failedIDs, uid)
+			continue
+		}
+		valid = append(valid, uid)
+	}
+
+	conn, _, err := s.driver.GetConnection()
+	if err != nil {
+		return nil, fmt.Errorf("sync user group members: get connection: %w", err)
+	}
+	err = types.WithTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if err := db.DeleteUserGroupMembersByUserGroupIDInTx(s.driver, ctx, tx, groupID); err != nil {
+			return fmt.Errorf("delete existing user group members: %w", err)
+		}
+		for _, uid := range valid {
+			if _, err := db.CreateUserGroupMemberInTx(s.driver, ctx, tx, ac, db.CreateUserGroupMemberParams{
+				UserGroupID: groupID,
+				UserID:      uid,
+			}); err != nil {
+				return fmt.Errorf("create user group member for %s: %w", uid, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.refreshCache()
+	return failedIDs, nil
+}
+
+// SyncUserGroupMemberships makes the user a member of exactly the provided
+// groups, removing memberships that are no longer wanted and adding missing ones.
+// Only administrators (isAdmin) can change memberships.
+func (s *RBACService) SyncUserGroupMemberships(ctx context.Context, ac audited.AuditContext, userID types.UserID, groupIDs []types.UserGroupID, isAdmin bool) ([]types.UserGroupID, error) {
+	if !isAdmin {
+		return nil, errRoleAssignment
+	}
+	if _, err := s.driver.GetUser(userID); err != nil {
+		return nil, &NotFoundError{Resource: "user", ID: string(userID)}
+	}
+
+	existing, err := s.driver.ListUserGroupMembersByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("list user group memberships: %w", err)
+	}
+
+	wanted := make(map[types.UserGroupID]bool, len(groupIDs))
+	for _, gid := range groupIDs {
+		wanted[gid] = true
+	}
+
+	have := make(map[types.UserGroupID]bool)
+	if existing != nil {
+		for _, m := range *existing {
+			if wanted[m.UserGroupID] {
+				have[m.UserGroupID] = true
+				continue
+			}
+			if err := s.driver.DeleteUserGroupMember(ctx, ac, m.ID); err != nil {
+				return nil, fmt.Errorf("delete user group member %s: %w", m.ID, err)
+			}
+		}
+	}
+
+	var failedIDs []types.UserGroupID
+	for _, gid := range groupIDs {
+		if have[gid] {
+			continue
+		}
+		have[gid] = true
+		_, err := s.driver.CreateUserGroupMember(ctx, ac, db.CreateUserGroupMemberParams{
+			UserGroupID: gid,
+			UserID:      userID,
+		})
+		if err != nil {
+			if isConstraintOrNotFound(err) {
+				failedIDs = append(failedIDs, gid)
+				continue
+			}
+			return failedIDs, fmt.Errorf("create user group member for %s: %w", gid, err)
+		}
+	}
+
+	s.refreshCache()
+	return failedIDs, nil
+}
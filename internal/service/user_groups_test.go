@@ -0,0 +1,188 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/service"
+)
+
+func TestRBACService_RoleAssignmentRequiresAdmin(t *testing.T) {
+	d, _ := testDB(t)
+	ctx := context.Background()
+	ac := testAuditCtx(d)
+	cfg := d.Config
+	mgr := config.NewManager(&staticProvider{cfg: &cfg})
+	if err := mgr.Load(); err != nil {
+		t.Fatalf("mgr.Load: %v", err)
+	}
+	svc := service.NewRBACService(d, mgr, middleware.NewPermissionCache())
+
+	userID := seedUser(t, d)
+	user, err := d.GetUser(userID)
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	extra, err := d.CreateRole(ctx, ac, db.CreateRoleParams{Label: "extra"})
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+	group, err := svc.CreateUserGroup(ctx, ac, service.CreateUserGroupInput{Label: "editors"})
+	if err != nil {
+		t.Fatalf("CreateUserGroup: %v", err)
+	}
+
+	if _, err := svc.AssignUserRole(ctx, ac, userID, extra.RoleID, false); !service.IsForbidden(err) {
+		t.Errorf("AssignUserRole as non-admin: err = %v, want forbidden", err)
+	}
+	if _, err := svc.SyncUserRoles(ctx, ac, userID, []types.RoleID{extra.RoleID}, false); !service.IsForbidden(err) {
+		t.Errorf("SyncUserRoles as non-admin: err = %v, want forbidden", err)
+	}
+	if _, err := svc.AddUserGroupMember(ctx, ac, group.UserGroupID, userID, false); !service.IsForbidden(err) {
+		t.Errorf("AddUserGroupMember as non-admin: err = %v, want forbidden", err)
+	}
+	if _, err := svc.AssignUserGroupRole(ctx, ac, group.UserGroupID, extra.RoleID, false); !service.IsForbidden(err) {
+		t.Errorf("AssignUserGroupRole as non-admin: err = %v, want forbidden", err)
+	}
+	if _, err := svc.SyncUserGroupMemberships(ctx, ac, userID, []types.UserGroupID{group.UserGroupID}, false); !service.IsForbidden(err) {
+		t.Errorf("SyncUserGroupMemberships as non-admin: err = %v, want forbidden", err)
+	}
+
+	missing := types.NewRoleID()
+	failed, err := svc.SyncUserRoles(ctx, ac, userID, []types.RoleID{extra.RoleID, missing}, true)
+	if err != nil {
+		t.Fatalf("SyncUserRoles: %v", err)
+	}
+	if len(failed) != 1 || failed[0] != missing {
+		t.Errorf("failed = %v, want [%s]", failed, missing)
+	}
+	roles, err := svc.ListUserRoles(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListUserRoles: %v", err)
+	}
+	got := map[types.RoleID]bool{}
+	for _, ur := range *roles {
+		got[ur.RoleID] = true
+	}
+	if len(got) != 2 || !got[types.RoleID(user.Role)] || !got[extra.RoleID] {
+		t.Errorf("user roles = %v, want the primary role and extra", got)
+	}
+}
+
+func TestUserService_RoleChangeReplacesPrimaryAssignment(t *testing.T) {
+	d, _ := testDB(t)
+	ctx := context.Background()
+	ac := testAuditCtx(d)
+	cfg := d.Config
+	mgr := config.NewManager(&staticProvider{cfg: &cfg})
+	if err := mgr.Load(); err != nil {
+		t.Fatalf("mgr.Load: %v", err)
+	}
+	pc := middleware.NewPermissionCache()
+	users := service.NewUserService(d, mgr, pc)
+
+	if _, err := d.CreateRole(ctx, ac, db.CreateRoleParams{Label: "viewer"}); err != nil {
+		t.Fatalf("CreateRole viewer: %v", err)
+	}
+	editor, err := d.CreateRole(ctx, ac, db.CreateRoleParams{Label: "editor"})
+	if err != nil {
+		t.Fatalf("CreateRole editor: %v", err)
+	}
+	created, err := users.CreateUser(ctx, ac, service.CreateUserInput{
+		Username: "demoted",
+		Name:     "Demoted",
+		Email:    types.Email("demoted@example.com"),
+		Password: "a-long-enough-password",
+		Role:     editor.RoleID,
+		IsAdmin:  true,
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	viewer, err := d.GetRoleByLabel("viewer")
+	if err != nil {
+		t.Fatalf("GetRoleByLabel: %v", err)
+	}
+	if _, err := users.UpdateUser(ctx, ac, service.UpdateUserInput{
+		UserID:   created.UserID,
+		Username: created.Username,
+		Name:     created.Name,
+		Email:    created.Email,
+		Role:     viewer.RoleID,
+		IsAdmin:  true,
+	}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+
+	assigned, err := d.ListUserRolesByUserID(created.UserID)
+	if err != nil {
+		t.Fatalf("ListUserRolesByUserID: %v", err)
+	}
+	if len(*assigned) != 1 || (*assigned)[0].RoleID != viewer.RoleID {
+		t.Errorf("user roles = %+v, want only viewer", *assigned)
+	}
+	for _, rid := range pc.RolesForUser(created.UserID, viewer.RoleID) {
+		if rid == editor.RoleID {
+			t.Errorf("demoted user still holds the editor role")
+		}
+	}
+}
+
+func TestRBACService_SyncUserGroupRolesSkipsMissing(t *testing.T) {
+	d, _ := testDB(t)
+	ctx := context.Background()
+	ac := testAuditCtx(d)
+	cfg := d.Config
+	mgr := config.NewManager(&staticProvider{cfg: &cfg})
+	if err := mgr.Load(); err != nil {
+		t.Fatalf("mgr.Load: %v", err)
+	}
+	svc := service.NewRBACService(d, mgr, middleware.NewPermissionCache())
+
+	role, err := d.CreateRole(ctx, ac, db.CreateRoleParams{Label: "editor"})
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+	group, err := svc.CreateUserGroup(ctx, ac, service.CreateUserGroupInput{Label: "editors"})
+	if err != nil {
+		t.Fatalf("CreateUserGroup: %v", err)
+	}
+	userID := seedUser(t, d)
+
+	missingRole := types.NewRoleID()
+	failedRoles, err := svc.SyncUserGroupRoles(ctx, ac, group.UserGroupID, []types.RoleID{role.RoleID, missingRole, role.RoleID}, true)
+	if err != nil {
+		t.Fatalf("SyncUserGroupRoles: %v", err)
+	}
+	if len(failedRoles) != 1 || failedRoles[0] != missingRole {
+		t.Errorf("failed roles = %v, want [%s]", failedRoles, missingRole)
+	}
+	roles, err := svc.ListUserGroupRoles(ctx, group.UserGroupID)
+	if err != nil {
+		t.Fatalf("ListUserGroupRoles: %v", err)
+	}
+	if len(*roles) != 1 || (*roles)[0].RoleID != role.RoleID {
+		t.Errorf("group roles = %+v, want only %s", *roles, role.RoleID)
+	}
+
+	missingUser := types.NewUserID()
+	failedUsers, err := svc.SyncUserGroupMembers(ctx, ac, group.UserGroupID, []types.UserID{userID, missingUser}, true)
+	if err != nil {
+		t.Fatalf("SyncUserGroupMembers: %v", err)
+	}
+	if len(failedUsers) != 1 || failedUsers[0] != missingUser {
+		t.Errorf("failed users = %v, want [%s]", failedUsers, missingUser)
+	}
+	members, err := svc.ListUserGroupMembers(ctx, group.UserGroupID)
+	if err != nil {
+		t.Fatalf("ListUserGroupMembers: %v", err)
+	}
+	if len(*members) != 1 || (*members)[0].UserID != userID {
+		t.Errorf("group members = %+v, want only %s", *members, userID)
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/hegner123/modulacms/internal/db"
 	"github.com/hegner123/modulacms/internal/db/audited"
 	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/utility"
 )
 
 // UserService manages user CRUD, password hashing, role assignment,
@@ -18,11 +20,14 @@ import (
 type UserService struct {
 	driver db.DbDriver
 	mgr    *config.Manager
+	pc     *middleware.PermissionCache
 }
 
 // NewUserService creates a UserService with the given dependencies.
-func NewUserService(driver db.DbDriver, mgr *config.Manager) *UserService {
-	return &UserService{driver: driver, mgr: mgr}
+// The permission cache is refreshed when a user's primary role changes so
+// that multi-role unions stay in sync; pc may be nil.
+func NewUserService(driver db.DbDriver, mgr *config.Manager, pc *middleware.PermissionCache) *UserService {
+	return &UserService{driver: driver, mgr: mgr, pc: pc}
 }
 
 // CreateUserInput holds caller-provided fields for creating a user.
@@ -30,7 +35,7 @@ type CreateUserInput struct {
 	Username string
 	Name     string
 	Email    types.Email
-	Password string       // plaintext — service hashes; must be >= 8 chars
+	Password string       // plaintext — service hashes; checked against the password policy
 	Role     types.RoleID // zero value = default to viewer
 	IsAdmin  bool         // whether caller is admin (for role assignment gating)
 }
@@ -87,24 +92,50 @@ func (s *UserService) CreateUser(ctx context.Context, ac audited.AuditContext, i
 		return nil, fmt.Errorf("resolve default role: %w", err)
 	}
 
+	cfg, err := s.mgr.Config()
+	if err != nil {
+		return nil, fmt.Errorf("load config for user creation: %w", err)
+	}
+	if err := checkPasswordPolicy(s.driver, cfg, "", "", input.Password); err != nil {
+		return nil, err
+	}
+
 	hash, err := auth.HashPassword(input.Password)
 	if err != nil {
 		return nil, fmt.Errorf("hash password: %w", err)
 	}
 
+	conn, _, err := s.driver.GetConnection()
+	if err != nil {
+		return nil, fmt.Errorf("create user: get connection: %w", err)
+	}
+
+	// The user row and its primary role assignment are written together so
+	// user_roles never disagrees with users.role.
 	now := types.NewTimestamp(time.Now().UTC())
-	created, err := s.driver.CreateUser(ctx, ac, db.CreateUserParams{
-		Username:     input.Username,
-		Name:         input.Name,
-		Email:        input.Email,
-		Hash:         hash,
-		Role:         string(roleID),
-		DateCreated:  now,
-		DateModified: now,
+	var created *db.Users
+	err = types.WithTransaction(ctx, conn, func(tx *sql.Tx) error {
+		u, txErr := db.CreateUserInTx(s.driver, ctx, tx, ac, db.CreateUserParams{
+			Username:     input.Username,
+			Name:         input.Name,
+			Email:        input.Email,
+			Hash:         hash,
+			Role:         string(roleID),
+			DateCreated:  now,
+			DateModified: now,
+		})
+		if txErr != nil {
+			return fmt.Errorf("create user: %w", txErr)
+		}
+		created = u
+		return syncPrimaryRoleInTx(s.driver, ctx, tx, ac, u.UserID, nil, "", roleID)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("create user: %w", err)
+		return nil, err
 	}
+
+	recordPasswordChange(s.driver, cfg, created.UserID, "", hash)
+	s.refreshPermissions()
 	return created, nil
 }
 
@@ -132,9 +163,15 @@ func (s *UserService) UpdateUser(ctx context.Context, ac audited.AuditContext, i
 
 	// Password handling
 	hash := existing.Hash
+	var cfg *config.Config
 	if input.Password != "" {
-		if len(input.Password) < 8 {
-			return nil, NewValidationError("password", "password must be at least 8 characters")
+		c, cfgErr := s.mgr.Config()
+		if cfgErr != nil {
+			return nil, fmt.Errorf("load config for user update: %w", cfgErr)
+		}
+		cfg = c
+		if policyErr := checkPasswordPolicy(s.driver, cfg, input.UserID, existing.Hash, input.Password); policyErr != nil {
+			return nil, policyErr
 		}
 		h, hashErr := auth.HashPassword(input.Password)
 		if hashErr != nil {
@@ -163,18 +200,48 @@ func (s *UserService) UpdateUser(ctx context.Context, ac audited.AuditContext, i
 		role = string(input.Role)
 	}
 
-	_, err = s.driver.UpdateUser(ctx, ac, db.UpdateUserParams{
-		Username:     input.Username,
-		Name:         input.Name,
-		Email:        input.Email,
-		Hash:         hash,
-		Role:         role,
-		DateCreated:  existing.DateCreated,
-		DateModified: types.NewTimestamp(time.Now().UTC()),
-		UserID:       input.UserID,
+	roleChanged := role != existing.Role
+	var assigned *[]db.UserRoles
+	if roleChanged {
+		assigned, err = s.driver.ListUserRolesByUserID(input.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("list user roles: %w", err)
+		}
+	}
+
+	conn, _, err := s.driver.GetConnection()
+	if err != nil {
+		return nil, fmt.Errorf("update user: get connection: %w", err)
+	}
+
+	// A role change swaps the primary user_roles assignment in the same
+	// transaction; otherwise a failed swap would leave the old role granted.
+	err = types.WithTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if txErr := db.UpdateUserInTx(s.driver, ctx, tx, ac, db.UpdateUserParams{
+			Username:     input.Username,
+			Name:         input.Name,
+			Email:        input.Email,
+			Hash:         hash,
+			Role:         role,
+			DateCreated:  existing.DateCreated,
+			DateModified: types.NewTimestamp(time.Now().UTC()),
+			UserID:       input.UserID,
+		}); txErr != nil {
+			return fmt.Errorf("update user: %w", txErr)
+		}
+		if !roleChanged {
+			return nil
+		}
+		return syncPrimaryRoleInTx(s.driver, ctx, tx, ac, input.UserID, assigned, types.RoleID(existing.Role), types.RoleID(role))
 	})
 	if err != nil {
-		return nil, fmt.Errorf("update user: %w", err)
+		return nil, err
+	}
+	if cfg != nil {
+		recordPasswordChange(s.driver, cfg, input.UserID, existing.Hash, hash)
+	}
+	if roleChanged {
+		s.refreshPermissions()
 	}
 
 	updated, err := s.driver.GetUser(input.UserID)
@@ -375,8 +442,6 @@ func validateCreateUserInput(input CreateUserInput) *ValidationError {
 	}
 	if input.Password == "" {
 		ve.Add("password", "password is required")
-	} else if len(input.Password) < 8 {
-		ve.Add("password", "password must be at least 8 characters")
 	}
 	if ve.HasErrors() {
 		return ve
@@ -470,3 +535,45 @@ func (s *UserService) resolveDefaultRole(roleInput types.RoleID) (types.RoleID,
 	}
 	return viewerRole.RoleID, nil
 }
+
+// syncPrimaryRoleInTx keeps the user_roles assignment set aligned with the
+// primary role stored on the user row. assigned is the user's current
+// assignment set, read before the transaction began (nil for a new user). The
+// previous primary assignment is dropped and the new one added if missing.
+func syncPrimaryRoleInTx(d db.DbDriver, ctx context.Context, tx *sql.Tx, ac audited.AuditContext, userID types.UserID, assigned *[]db.UserRoles, oldRole, newRole types.RoleID) error {
+	hasNew := false
+	if assigned != nil {
+		for _, ur := range *assigned {
+			switch ur.RoleID {
+			case newRole:
+				hasNew = true
+			case oldRole:
+				if err := db.DeleteUserRoleInTx(d, ctx, tx, ac, ur.ID); err != nil {
+					return fmt.Errorf("drop previous primary role assignment: %w", err)
+				}
+			}
+		}
+	}
+	if hasNew {
+		return nil
+	}
+	if _, err := db.CreateUserRoleInTx(d, ctx, tx, ac, db.CreateUserRoleParams{
+		UserID: userID,
+		RoleID: newRole,
+	}); err != nil {
+		return fmt.Errorf("create primary role assignment: %w", err)
+	}
+	return nil
+}
+
+// refreshPermissions reloads the permission cache after a primary role change
+// so multi-role unions reflect it. Failures are logged: the committed change
+// takes effect on the next periodic refresh.
+func (s *UserService) refreshPermissions() {
+	if s.pc == nil {
+		return
+	}
+	if err := s.pc.Load(s.driver); err != nil {
+		utility.DefaultLogger.Error("permission cache refresh failed", err)
+	}
+}
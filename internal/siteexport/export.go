@@ -0,0 +1,417 @@
+// Package siteexport writes published content as a static site bundle: one
+// file per route and locale, an index manifest, and optionally the media
+// renditions the content references. Content is read from the published
+// snapshots in content_versions, the same data the delivery API serves.
+package siteexport
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// FormatMarkdown writes each route as Markdown with YAML front matter. Any
+// other format is a transform output format and writes JSON.
+const FormatMarkdown = "markdown"
+
+// IndexName is the manifest file at the root of the bundle. The home page
+// is index.json or index.md, so the manifest has its own name.
+const IndexName = "manifest.json"
+
+// Options controls an export.
+type Options struct {
+	// Format is FormatMarkdown or a transform output format (clean, raw,
+	// contentful, sanity, strapi, wordpress). Empty uses the config's
+	// output_format.
+	Format string
+	// Locales limits the export to these locale codes. Empty exports every
+	// enabled locale, or the single unlocalized version when i18n is off.
+	Locales []string
+	// Media downloads the originals and variants of referenced media.
+	Media bool
+	// MediaBaseURL, when set with Media, replaces media URLs in exported
+	// files with MediaBaseURL followed by the downloaded file's path.
+	MediaBaseURL string
+	// BodyField is the field written as the Markdown body. Defaults to
+	// "body"; other fields go in the front matter.
+	BodyField string
+	// Previous is the manifest of the last export. Entries whose published
+	// version and composed references are unchanged are not rewritten, and
+	// files no longer exported are removed.
+	Previous *Manifest
+	// HTTPClient downloads media. Defaults to a client with a one minute
+	// timeout.
+	HTTPClient *http.Client
+}
+
+// Manifest is the index written to IndexName.
+type Manifest struct {
+	GeneratedAt  string      `json:"generated_at"`
+	Format       string      `json:"format"`
+	MediaBaseURL string      `json:"media_base_url,omitempty"`
+	Entries      []Entry     `json:"entries"`
+	Media        []MediaFile `json:"media,omitempty"`
+	// Removed lists files of the previous export that are no longer part
+	// of the bundle.
+	Removed []string `json:"removed,omitempty"`
+}
+
+// Entry is one exported route and locale.
+type Entry struct {
+	RouteID       string `json:"route_id"`
+	Slug          string `json:"slug"`
+	Title         string `json:"title"`
+	Locale        string `json:"locale,omitempty"`
+	File          string `json:"file"`
+	ContentDataID string `json:"content_data_id"`
+	VersionID     string `json:"version_id"`
+	VersionNumber int64  `json:"version_number"`
+	PublishedAt   string `json:"published_at"`
+	// Dependencies maps each composed reference to the published version
+	// that was rendered into the file.
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+	// Media lists the media IDs the file references.
+	Media []string `json:"media,omitempty"`
+}
+
+// MediaFile is one downloaded media rendition.
+type MediaFile struct {
+	MediaID string `json:"media_id"`
+	URL     string `json:"url"`
+	File    string `json:"file"`
+	Size    int64  `json:"size"`
+}
+
+// Result summarizes an export.
+type Result struct {
+	Manifest  *Manifest
+	Written   int
+	Unchanged int
+	Removed   int
+	Media     int
+}
+
+// exporter holds the state of one run.
+type exporter struct {
+	driver  db.DbDriver
+	cfg     config.Config
+	sink    Sink
+	opts    Options
+	ext     string
+	prev    map[string]Entry
+	library *mediaLibrary
+}
+
+// Run exports every published route to sink.
+func Run(ctx context.Context, driver db.DbDriver, cfg config.Config, sink Sink, opts Options) (*Result, error) {
+	if opts.Format == "" {
+		opts.Format = string(cfg.Output_Format)
+	}
+	if opts.Format == "" {
+		opts.Format = string(config.FormatClean)
+	}
+	ext := ".json"
+	switch {
+	case opts.Format == FormatMarkdown:
+		ext = ".md"
+	case !config.IsValidOutputFormat(opts.Format):
+		return nil, fmt.Errorf("unknown format %q: use markdown or an output format (contentful, sanity, strapi, wordpress, clean, raw)", opts.Format)
+	}
+	if opts.BodyField == "" {
+		opts.BodyField = "body"
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: time.Minute}
+	}
+	if !opts.Media {
+		opts.MediaBaseURL = ""
+	}
+
+	e := &exporter{driver: driver, cfg: cfg, sink: sink, opts: opts, ext: ext, prev: map[string]Entry{}}
+	// A previous export in another format or with other media URLs cannot
+	// be reused; every file is written again.
+	if opts.Previous != nil && opts.Previous.Format == opts.Format && opts.Previous.MediaBaseURL == opts.MediaBaseURL {
+		for _, entry := range opts.Previous.Entries {
+			e.prev[entryKey(entry.RouteID, entry.Locale)] = entry
+		}
+	}
+	if opts.Media {
+		lib, err := loadMediaLibrary(driver)
+		if err != nil {
+			return nil, err
+		}
+		e.library = lib
+	}
+	return e.run(ctx)
+}
+
+func (e *exporter) run(ctx context.Context) (*Result, error) {
+	locales, err := e.locales()
+	if err != nil {
+		return nil, err
+	}
+	routes, err := e.driver.ListRoutes()
+	if err != nil {
+		return nil, fmt.Errorf("list routes: %w", err)
+	}
+	sorted := []db.Routes{}
+	if routes != nil {
+		sorted = append(sorted, *routes...)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Slug < sorted[j].Slug })
+
+	result := &Result{}
+	manifest := &Manifest{
+		GeneratedAt:  time.Now().UTC().Format(time.RFC3339),
+		Format:       e.opts.Format,
+		MediaBaseURL: e.opts.MediaBaseURL,
+		Entries:      []Entry{},
+	}
+	// Rendered files are written after media URLs are known.
+	pending := map[string][]byte{}
+	for _, route := range sorted {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		rootID, ok, err := e.routeRoot(route)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		for _, locale := range locales {
+			version, err := e.publishedVersion(rootID, locale)
+			if err != nil {
+				return nil, err
+			}
+			if version == nil {
+				continue
+			}
+			entry := Entry{
+				RouteID:       route.RouteID.String(),
+				Slug:          string(route.Slug),
+				Title:         route.Title,
+				Locale:        locale,
+				File:          entryFile(string(route.Slug), locale, e.ext),
+				ContentDataID: rootID.String(),
+				VersionID:     version.ContentVersionID.String(),
+				VersionNumber: version.VersionNumber,
+				PublishedAt:   version.DateCreated.String(),
+			}
+			if entry.File == IndexName {
+				return nil, fmt.Errorf("route %s would overwrite %s", entry.Slug, IndexName)
+			}
+			if prev, ok := e.unchanged(entry, version.Locale); ok {
+				manifest.Entries = append(manifest.Entries, prev)
+				result.Unchanged++
+				continue
+			}
+			data, deps, mediaIDs, err := e.render(ctx, entry, version)
+			if err != nil {
+				return nil, fmt.Errorf("export %s (%s): %w", entry.Slug, localeName(locale), err)
+			}
+			entry.Dependencies = deps
+			entry.Media = mediaIDs
+			manifest.Entries = append(manifest.Entries, entry)
+			pending[entry.File] = data
+		}
+	}
+
+	if e.library != nil {
+		media, downloaded, err := e.exportMedia(ctx, manifest.Entries, pending)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Media = media
+		result.Media = downloaded
+	}
+
+	files := make([]string, 0, len(pending))
+	for file := range pending {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		if err := e.sink.WriteFile(file, pending[file]); err != nil {
+			return nil, fmt.Errorf("write %s: %w", file, err)
+		}
+		result.Written++
+	}
+
+	manifest.Removed = e.removeStale(manifest)
+	result.Removed = len(manifest.Removed)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode manifest: %w", err)
+	}
+	if err := e.sink.WriteFile(IndexName, append(data, '\n')); err != nil {
+		return nil, fmt.Errorf("write %s: %w", IndexName, err)
+	}
+	result.Manifest = manifest
+	return result, nil
+}
+
+// locales returns the locale codes to export. "" is the unlocalized version.
+func (e *exporter) locales() ([]string, error) {
+	if len(e.opts.Locales) > 0 {
+		return e.opts.Locales, nil
+	}
+	if !e.cfg.I18nEnabled() {
+		return []string{""}, nil
+	}
+	enabled, err := e.driver.ListEnabledLocales()
+	if err != nil {
+		return nil, fmt.Errorf("list locales: %w", err)
+	}
+	var codes []string
+	if enabled != nil {
+		for _, l := range *enabled {
+			codes = append(codes, l.Code)
+		}
+	}
+	if len(codes) == 0 {
+		codes = []string{e.cfg.I18nDefaultLocale()}
+	}
+	return codes, nil
+}
+
+// routeRoot returns the root content data of a route, the node without a
+// parent. ok is false for routes without content.
+func (e *exporter) routeRoot(route db.Routes) (types.ContentID, bool, error) {
+	content, err := e.driver.ListContentDataByRoute(types.NullableRouteID{ID: route.RouteID, Valid: true})
+	if err != nil {
+		return "", false, fmt.Errorf("list content for route %s: %w", route.Slug, err)
+	}
+	if content == nil {
+		return "", false, nil
+	}
+	for _, cd := range *content {
+		if !cd.ParentID.Valid {
+			return cd.ContentDataID, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// publishedVersion returns the published version of rootID in locale, or
+// nil when there is none. The default locale falls back to content
+// published before i18n was enabled, as content delivery does.
+func (e *exporter) publishedVersion(rootID types.ContentID, locale string) (*db.ContentVersion, error) {
+	version, err := e.driver.GetPublishedSnapshot(rootID, locale)
+	if err == nil {
+		return version, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("published version of %s (%s): %w", rootID, localeName(locale), err)
+	}
+	if locale == "" || locale != e.cfg.I18nDefaultLocale() {
+		return nil, nil
+	}
+	version, err = e.driver.GetPublishedSnapshot(rootID, "")
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("published version of %s: %w", rootID, err)
+	}
+	return version, nil
+}
+
+// unchanged returns the previous entry when its file can be kept: the same
+// version is published, every composed reference still resolves to the
+// version that was rendered, and the file is still in the sink.
+func (e *exporter) unchanged(entry Entry, snapshotLocale string) (Entry, bool) {
+	prev, ok := e.prev[entryKey(entry.RouteID, entry.Locale)]
+	if !ok || prev.VersionID != entry.VersionID || prev.File != entry.File || !e.sink.Has(prev.File) {
+		return Entry{}, false
+	}
+	for id, versionID := range prev.Dependencies {
+		currentID := ""
+		if current, err := e.driver.GetPublishedSnapshot(types.ContentID(id), snapshotLocale); err == nil {
+			currentID = current.ContentVersionID.String()
+		}
+		if currentID != versionID {
+			return Entry{}, false
+		}
+	}
+	// Route titles and slugs are not part of the snapshot.
+	prev.Title = entry.Title
+	prev.Slug = entry.Slug
+	return prev, true
+}
+
+// removeStale removes files of the previous export that are no longer in
+// manifest and returns their names.
+func (e *exporter) removeStale(manifest *Manifest) []string {
+	if e.opts.Previous == nil {
+		return nil
+	}
+	keep := map[string]bool{IndexName: true}
+	for _, entry := range manifest.Entries {
+		keep[entry.File] = true
+	}
+	for _, m := range manifest.Media {
+		keep[m.File] = true
+	}
+	var old []string
+	for _, entry := range e.opts.Previous.Entries {
+		old = append(old, entry.File)
+	}
+	for _, m := range e.opts.Previous.Media {
+		old = append(old, m.File)
+	}
+
+	var removed []string
+	seen := map[string]bool{}
+	for _, file := range old {
+		if keep[file] || seen[file] {
+			continue
+		}
+		seen[file] = true
+		if err := e.sink.Remove(file); err != nil {
+			utility.DefaultLogger.Warn("site export: remove stale file", err, "file", file)
+			continue
+		}
+		removed = append(removed, file)
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+func entryKey(routeID, locale string) string {
+	return routeID + "|" + locale
+}
+
+func localeName(locale string) string {
+	if locale == "" {
+		return "no locale"
+	}
+	return locale
+}
+
+// entryFile maps a route slug to its file: "/" is index, "/blog/post" is
+// blog/post. Localized files go under a directory per locale.
+func entryFile(slug, locale, ext string) string {
+	clean := strings.Trim(path.Clean("/"+slug), "/")
+	if clean == "" {
+		clean = "index"
+	}
+	if locale != "" {
+		clean = path.Join(locale, clean)
+	}
+	return clean + ext
+}
@@ -0,0 +1,317 @@
+package siteexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/audited"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/publishing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// ===== TEST HELPERS =====
+
+// site is a database with two published routes, a media item served by an
+// httptest server, and a rich text body that embeds the media URL.
+type site struct {
+	d        db.Database
+	ac       audited.AuditContext
+	user     types.UserID
+	home     types.ContentID
+	post     types.ContentID
+	requests *atomic.Int64
+}
+
+func newSite(t *testing.T) *site {
+	t.Helper()
+	dir := t.TempDir()
+	conn, err := sql.Open("sqlite3", filepath.Join(dir, "export_test.db"))
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	d := db.Database{
+		Connection: conn,
+		Context:    context.Background(),
+		Config:     config.Config{Node_ID: types.NewNodeID().String()},
+	}
+	if err := d.CreateAllTables(); err != nil {
+		t.Fatalf("CreateAllTables: %v", err)
+	}
+
+	requests := &atomic.Int64{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte("image:" + r.URL.Path))
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := d.Context
+	ac := audited.Ctx(types.NodeID(d.Config.Node_ID), types.UserID(""), "test", "127.0.0.1")
+	now := types.TimestampNow()
+	role, err := d.CreateRole(ctx, ac, db.CreateRoleParams{Label: "export-role"})
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+	user, err := d.CreateUser(ctx, ac, db.CreateUserParams{
+		Username: "exporter", Name: "Exporter", Email: types.Email("export@example.com"),
+		Hash: "fakehash", Role: role.RoleID.String(), DateCreated: now, DateModified: now,
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	ac = audited.Ctx(types.NodeID(d.Config.Node_ID), user.UserID, "test", "127.0.0.1")
+	author := types.NullableUserID{ID: user.UserID, Valid: true}
+
+	dt, err := d.CreateDatatype(ctx, ac, db.CreateDatatypeParams{
+		DatatypeID: types.NewDatatypeID(), Name: "page", Label: "Page", Type: "page",
+		AuthorID: user.UserID, DateCreated: now, DateModified: now,
+	})
+	if err != nil {
+		t.Fatalf("CreateDatatype: %v", err)
+	}
+	fields := map[string]*db.Fields{}
+	for _, f := range []struct {
+		name string
+		typ  types.FieldType
+	}{{"title", types.FieldTypeText}, {"body", types.FieldTypeRichText}, {"image", types.FieldTypeMedia}} {
+		field, err := d.CreateField(ctx, ac, db.CreateFieldParams{
+			FieldID: types.NewFieldID(), ParentID: types.NullableDatatypeID{ID: dt.DatatypeID, Valid: true},
+			Name: f.name, Label: f.name, UIConfig: types.EmptyJSON, Type: f.typ,
+			AuthorID: author, DateCreated: now, DateModified: now,
+		})
+		if err != nil {
+			t.Fatalf("CreateField %s: %v", f.name, err)
+		}
+		fields[f.name] = field
+	}
+
+	mediaURL := srv.URL + "/media/2026/photo.jpg"
+	media, err := d.CreateMedia(ctx, ac, db.CreateMediaParams{
+		Name:         db.NewNullString("photo.jpg"),
+		URL:          types.URL(mediaURL),
+		Srcset:       db.NewNullString(`["` + srv.URL + `/media/2026/photo-640w.webp"]`),
+		AuthorID:     author,
+		DateCreated:  now,
+		DateModified: now,
+	})
+	if err != nil {
+		t.Fatalf("CreateMedia: %v", err)
+	}
+
+	s := &site{d: d, ac: ac, user: user.UserID, requests: requests}
+	page := func(slug, title string, values map[string]string) types.ContentID {
+		route, err := d.CreateRoute(ctx, ac, db.CreateRouteParams{
+			Slug: types.Slug(slug), Title: title, Status: 1, AuthorID: author, DateCreated: now, DateModified: now,
+		})
+		if err != nil {
+			t.Fatalf("CreateRoute %s: %v", slug, err)
+		}
+		routeID := types.NullableRouteID{ID: route.RouteID, Valid: true}
+		cd, err := d.CreateContentData(ctx, ac, db.CreateContentDataParams{
+			RouteID: routeID, DatatypeID: types.NullableDatatypeID{ID: dt.DatatypeID, Valid: true},
+			AuthorID: user.UserID, Status: types.ContentStatusDraft, DateCreated: now, DateModified: now,
+		})
+		if err != nil {
+			t.Fatalf("CreateContentData: %v", err)
+		}
+		for name, value := range values {
+			_, err := d.CreateContentField(ctx, ac, db.CreateContentFieldParams{
+				RouteID: routeID, RootID: types.NullableContentID{ID: cd.ContentDataID, Valid: true},
+				ContentDataID: types.NullableContentID{ID: cd.ContentDataID, Valid: true},
+				FieldID:       types.NullableFieldID{ID: fields[name].FieldID, Valid: true},
+				FieldValue:    value, AuthorID: user.UserID, DateCreated: now, DateModified: now,
+			})
+			if err != nil {
+				t.Fatalf("CreateContentField %s: %v", name, err)
+			}
+		}
+		s.publish(t, cd.ContentDataID)
+		return cd.ContentDataID
+	}
+	s.home = page("/", "Home", map[string]string{"title": "Welcome", "image": media.MediaID.String()})
+	s.post = page("/blog/hello", "Hello", map[string]string{
+		"title": "Hello",
+		"body":  `<p>Hi</p><img src="` + mediaURL + `">`,
+	})
+	return s
+}
+
+func (s *site) publish(t *testing.T, id types.ContentID) {
+	t.Helper()
+	if _, err := publishing.PublishContent(s.d.Context, s.d, id, "", s.user, s.ac, 0, false, nil, nil); err != nil {
+		t.Fatalf("PublishContent: %v", err)
+	}
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("read %s: %v", name, err)
+	}
+	return string(data)
+}
+
+// ===== TESTS =====
+
+func TestRun_MarkdownWithMedia(t *testing.T) {
+	t.Parallel()
+	s := newSite(t)
+	out := t.TempDir()
+	opts := Options{Format: FormatMarkdown, Media: true, MediaBaseURL: "/assets/"}
+
+	res, err := Run(context.Background(), s.d, config.Config{}, DirSink{Dir: out}, opts)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if res.Written != 2 || res.Media != 2 {
+		t.Errorf("Written = %d, Media = %d, want 2 and 2", res.Written, res.Media)
+	}
+
+	home := readFile(t, out, "index.md")
+	if !strings.HasPrefix(home, "---\n") || !strings.Contains(home, "title: Welcome") || !strings.Contains(home, "slug: /") {
+		t.Errorf("index.md front matter:\n%s", home)
+	}
+	post := readFile(t, out, "blog/hello.md")
+	wantBody := "\n<p>Hi</p><img src=\"/assets/media/media/2026/photo.jpg\">\n"
+	if !strings.HasSuffix(post, wantBody) {
+		t.Errorf("blog/hello.md body not rewritten:\n%s", post)
+	}
+	if got := readFile(t, out, "media/media/2026/photo-640w.webp"); got != "image:/media/2026/photo-640w.webp" {
+		t.Errorf("variant = %q", got)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal([]byte(readFile(t, out, IndexName)), &manifest); err != nil {
+		t.Fatalf("manifest: %v", err)
+	}
+	if len(manifest.Entries) != 2 || len(manifest.Media) != 2 {
+		t.Fatalf("manifest has %d entries and %d media", len(manifest.Entries), len(manifest.Media))
+	}
+	for _, entry := range manifest.Entries {
+		if len(entry.Media) != 1 {
+			t.Errorf("entry %s media = %v, want the photo", entry.Slug, entry.Media)
+		}
+	}
+}
+
+func TestRun_Incremental(t *testing.T) {
+	t.Parallel()
+	s := newSite(t)
+	out := t.TempDir()
+	sink := DirSink{Dir: out}
+	opts := Options{Format: "clean", Media: true}
+
+	first, err := Run(context.Background(), s.d, config.Config{}, sink, opts)
+	if err != nil {
+		t.Fatalf("first Run: %v", err)
+	}
+	requests := s.requests.Load()
+
+	opts.Previous = first.Manifest
+	second, err := Run(context.Background(), s.d, config.Config{}, sink, opts)
+	if err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	if second.Written != 0 || second.Unchanged != 2 || s.requests.Load() != requests {
+		t.Errorf("unchanged run: Written = %d, Unchanged = %d, downloads = %d", second.Written, second.Unchanged, s.requests.Load()-requests)
+	}
+
+	// Republishing creates a new version; only that route is written.
+	s.publish(t, s.post)
+	opts.Previous = second.Manifest
+	third, err := Run(context.Background(), s.d, config.Config{}, sink, opts)
+	if err != nil {
+		t.Fatalf("third Run: %v", err)
+	}
+	if third.Written != 1 || third.Unchanged != 1 {
+		t.Errorf("after republish: Written = %d, Unchanged = %d, want 1 and 1", third.Written, third.Unchanged)
+	}
+
+	// An unpublished route's file is removed.
+	if err := publishing.UnpublishContent(s.d.Context, s.d, s.post, "", s.user, s.ac, nil, nil); err != nil {
+		t.Fatalf("UnpublishContent: %v", err)
+	}
+	opts.Previous = third.Manifest
+	fourth, err := Run(context.Background(), s.d, config.Config{}, sink, opts)
+	if err != nil {
+		t.Fatalf("fourth Run: %v", err)
+	}
+	if len(fourth.Manifest.Removed) != 1 || fourth.Manifest.Removed[0] != "blog/hello.json" {
+		t.Errorf("Removed = %v", fourth.Manifest.Removed)
+	}
+	if sink.Has("blog/hello.json") {
+		t.Error("blog/hello.json still exists")
+	}
+	if _, err := os.Stat(filepath.Join(out, "blog")); !os.IsNotExist(err) {
+		t.Error("empty blog directory left behind")
+	}
+	// The photo is still referenced by the home page.
+	if !sink.Has("media/media/2026/photo.jpg") {
+		t.Error("photo removed while still referenced")
+	}
+}
+
+func TestRun_ZipJSON(t *testing.T) {
+	t.Parallel()
+	s := newSite(t)
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if _, err := Run(context.Background(), s.d, config.Config{}, ZipSink{W: zw}, Options{Format: "raw"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if got := strings.Join(names, ","); got != "blog/hello.json,index.json,"+IndexName {
+		t.Errorf("entries = %s", got)
+	}
+}
+
+func TestRun_UnknownFormat(t *testing.T) {
+	t.Parallel()
+	if _, err := Run(context.Background(), nil, config.Config{}, DirSink{Dir: t.TempDir()}, Options{Format: "xml"}); err == nil {
+		t.Error("unknown format accepted")
+	}
+}
+
+func TestEntryFile(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		slug, locale, ext, want string
+	}{
+		{"/", "", ".md", "index.md"},
+		{"", "", ".json", "index.json"},
+		{"/blog/post", "", ".md", "blog/post.md"},
+		{"blog/post/", "fr", ".md", "fr/blog/post.md"},
+		{"/../../etc/passwd", "", ".json", "etc/passwd.json"},
+	}
+	for _, tt := range tests {
+		if got := entryFile(tt.slug, tt.locale, tt.ext); got != tt.want {
+			t.Errorf("entryFile(%q, %q) = %q, want %q", tt.slug, tt.locale, got, tt.want)
+		}
+	}
+}
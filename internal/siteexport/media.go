@@ -0,0 +1,199 @@
+package siteexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// mediaLibrary indexes the media table for an export.
+type mediaLibrary struct {
+	byID map[string]db.Media
+	// urls holds every original and variant URL, longest first so that
+	// matching and rewriting never act on a prefix of a longer URL.
+	urls []mediaURL
+}
+
+type mediaURL struct {
+	mediaID string
+	url     string
+}
+
+func loadMediaLibrary(driver db.DbDriver) (*mediaLibrary, error) {
+	media, err := driver.ListMedia()
+	if err != nil {
+		return nil, fmt.Errorf("list media: %w", err)
+	}
+	lib := &mediaLibrary{byID: map[string]db.Media{}}
+	if media == nil {
+		return lib, nil
+	}
+	for _, m := range *media {
+		id := m.MediaID.String()
+		lib.byID[id] = m
+		for _, u := range renditions(m) {
+			lib.urls = append(lib.urls, mediaURL{mediaID: id, url: u})
+		}
+	}
+	sort.Slice(lib.urls, func(i, j int) bool { return len(lib.urls[i].url) > len(lib.urls[j].url) })
+	return lib, nil
+}
+
+// renditions returns the original URL of m followed by its srcset variants.
+func renditions(m db.Media) []string {
+	urls := []string{}
+	if m.URL != "" {
+		urls = append(urls, string(m.URL))
+	}
+	if m.Srcset.Valid && m.Srcset.String != "" {
+		var variants []string
+		if err := json.Unmarshal([]byte(m.Srcset.String), &variants); err == nil {
+			for _, v := range variants {
+				if v != "" {
+					urls = append(urls, v)
+				}
+			}
+		}
+	}
+	return urls
+}
+
+// exportMedia downloads the renditions of every media item referenced by
+// entries, through a media field or by URL in a rendered file, and rewrites
+// rendered files to point at the downloads when MediaBaseURL is set. It
+// returns the media records for the manifest and the number downloaded.
+func (e *exporter) exportMedia(ctx context.Context, entries []Entry, pending map[string][]byte) ([]MediaFile, int, error) {
+	referenced := map[string]bool{}
+	for i := range entries {
+		ids := map[string]bool{}
+		for _, id := range entries[i].Media {
+			ids[id] = true
+		}
+		if data, ok := pending[entries[i].File]; ok {
+			for _, mu := range e.library.urls {
+				if bytes.Contains(data, []byte(mu.url)) {
+					ids[mu.mediaID] = true
+				}
+			}
+		}
+		entries[i].Media = entries[i].Media[:0]
+		for id := range ids {
+			entries[i].Media = append(entries[i].Media, id)
+			referenced[id] = true
+		}
+		sort.Strings(entries[i].Media)
+		if len(entries[i].Media) == 0 {
+			entries[i].Media = nil
+		}
+	}
+
+	prev := map[string]MediaFile{}
+	if e.opts.Previous != nil {
+		for _, m := range e.opts.Previous.Media {
+			prev[m.URL] = m
+		}
+	}
+
+	ids := make([]string, 0, len(referenced))
+	for id := range referenced {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var files []MediaFile
+	downloaded := 0
+	for _, id := range ids {
+		m, ok := e.library.byID[id]
+		if !ok {
+			utility.DefaultLogger.Warn("site export: referenced media not found", nil, "media_id", id)
+			continue
+		}
+		for _, u := range renditions(m) {
+			file, ok := mediaFile(u)
+			if !ok {
+				utility.DefaultLogger.Warn("site export: skipping media URL without a path", nil, "url", u)
+				continue
+			}
+			if p, ok := prev[u]; ok && p.File == file && e.sink.Has(file) {
+				p.MediaID = id
+				files = append(files, p)
+				continue
+			}
+			size, err := e.download(ctx, u, file)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, 0, ctx.Err()
+				}
+				// One missing object should not fail the export; it is
+				// retried on the next run because it is not recorded.
+				utility.DefaultLogger.Warn("site export: download media", err, "url", u)
+				continue
+			}
+			files = append(files, MediaFile{MediaID: id, URL: u, File: file, Size: size})
+			downloaded++
+		}
+	}
+
+	if e.opts.MediaBaseURL != "" && len(files) > 0 {
+		rewrite := make([]string, 0, 2*len(files))
+		byLength := append([]MediaFile(nil), files...)
+		sort.Slice(byLength, func(i, j int) bool { return len(byLength[i].URL) > len(byLength[j].URL) })
+		base := strings.TrimRight(e.opts.MediaBaseURL, "/")
+		for _, f := range byLength {
+			rewrite = append(rewrite, f.URL, base+"/"+f.File)
+		}
+		replacer := strings.NewReplacer(rewrite...)
+		for file, data := range pending {
+			pending[file] = []byte(replacer.Replace(string(data)))
+		}
+	}
+	return files, downloaded, nil
+}
+
+// download fetches u and writes it to file in the sink.
+func (e *exporter) download(ctx context.Context, u, file string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := e.opts.HTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("read %s: %w", u, err)
+	}
+	if err := e.sink.WriteFile(file, data); err != nil {
+		return 0, fmt.Errorf("write %s: %w", file, err)
+	}
+	return int64(len(data)), nil
+}
+
+// mediaFile maps a media URL to its path in the bundle: media/ followed by
+// the URL path.
+func mediaFile(u string) (string, bool) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "", false
+	}
+	p := path.Clean("/" + parsed.Path)
+	if p == "/" {
+		return "", false
+	}
+	return "media" + p, true
+}
@@ -0,0 +1,257 @@
+package siteexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/model"
+	"github.com/hegner123/modulacms/internal/publishing"
+	"github.com/hegner123/modulacms/internal/transform"
+	"github.com/hegner123/modulacms/internal/tree/core"
+	"github.com/hegner123/modulacms/internal/utility"
+	"gopkg.in/yaml.v3"
+)
+
+// render builds the tree of a published version, composes its references
+// from their published snapshots, and encodes it in the export format. It
+// returns the file contents, the versions of the composed references and
+// the media IDs in media fields.
+func (e *exporter) render(ctx context.Context, entry Entry, version *db.ContentVersion) ([]byte, map[string]string, []string, error) {
+	var snapshot publishing.Snapshot
+	if err := json.Unmarshal([]byte(version.Snapshot), &snapshot); err != nil {
+		return nil, nil, nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	cd, dt, cf, fd, err := snapshotSlices(snapshot)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	root, err := model.BuildTree(utility.DefaultLogger, cd, dt, cf, fd)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("build tree: %w", err)
+	}
+
+	fetcher := &recordingFetcher{driver: e.driver, locale: version.Locale, versions: map[string]string{}}
+	if root.CoreRoot != nil {
+		composeErr := core.ComposeTrees(ctx, root.CoreRoot, core.NewCachedFetcher(fetcher), core.ComposeOptions{
+			MaxDepth:       e.cfg.CompositionMaxDepth(),
+			MaxConcurrency: 10,
+		})
+		if composeErr != nil {
+			utility.DefaultLogger.Warn("site export: composition error", composeErr, "slug", entry.Slug)
+		}
+		root.RebuildFromCore()
+	}
+
+	var data []byte
+	if e.opts.Format == FormatMarkdown {
+		data, err = e.markdown(root, entry)
+	} else {
+		data, err = e.json(root)
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var deps map[string]string
+	if len(fetcher.versions) > 0 {
+		deps = fetcher.versions
+	}
+	return data, deps, mediaFieldIDs(root.Node), nil
+}
+
+// json encodes root with the configured transformer, indented so exports
+// diff cleanly between runs.
+func (e *exporter) json(root model.Root) ([]byte, error) {
+	tc := transform.NewTransformConfigFromString(e.opts.Format, e.cfg.Client_Site, e.cfg.Space_ID, e.driver)
+	transformer, err := tc.GetTransformer()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := transformer.TransformToJSON(root)
+	if err != nil {
+		return nil, fmt.Errorf("transform: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, raw, "", "  "); err != nil {
+		return nil, fmt.Errorf("indent: %w", err)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// markdown writes the clean format of root as YAML front matter, with the
+// body field as the document body. Rich text is stored as HTML, which
+// Markdown passes through.
+func (e *exporter) markdown(root model.Root, entry Entry) ([]byte, error) {
+	clean, err := (&transform.CleanTransformer{}).Transform(root)
+	if err != nil {
+		return nil, fmt.Errorf("transform: %w", err)
+	}
+	front, ok := clean.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected clean output %T", clean)
+	}
+	// Round-trip through JSON so struct values such as _meta use their
+	// JSON names in the front matter.
+	encoded, err := json.Marshal(front)
+	if err != nil {
+		return nil, err
+	}
+	front = map[string]any{}
+	if err := json.Unmarshal(encoded, &front); err != nil {
+		return nil, err
+	}
+
+	body := ""
+	if v, ok := front[e.opts.BodyField]; ok {
+		if s, isString := v.(string); isString {
+			body = s
+			delete(front, e.opts.BodyField)
+		}
+	}
+	if _, ok := front["title"]; !ok {
+		front["title"] = entry.Title
+	}
+	front["slug"] = entry.Slug
+	if entry.Locale != "" {
+		front["locale"] = entry.Locale
+	}
+	front["published_at"] = entry.PublishedAt
+
+	yml, err := yaml.Marshal(front)
+	if err != nil {
+		return nil, fmt.Errorf("encode front matter: %w", err)
+	}
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(yml)
+	buf.WriteString("---\n")
+	if body != "" {
+		buf.WriteString("\n")
+		buf.WriteString(strings.TrimRight(body, "\n"))
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// mediaFieldIDs returns the sorted media IDs in media fields of the tree.
+// A media field holds one ID or a JSON array of IDs.
+func mediaFieldIDs(node *model.Node) []string {
+	seen := map[string]bool{}
+	var walk func(n *model.Node)
+	walk = func(n *model.Node) {
+		if n == nil {
+			return
+		}
+		for _, f := range n.Fields {
+			if f.Info.Type != string(types.FieldTypeMedia) {
+				continue
+			}
+			value := strings.TrimSpace(f.Content.FieldValue)
+			if value == "" {
+				continue
+			}
+			var ids []string
+			if strings.HasPrefix(value, "[") {
+				if err := json.Unmarshal([]byte(value), &ids); err != nil {
+					continue
+				}
+			} else {
+				ids = []string{value}
+			}
+			for _, id := range ids {
+				if id != "" {
+					seen[id] = true
+				}
+			}
+		}
+		for _, child := range n.Nodes {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	if len(seen) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(seen))
+	for id := range seen {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// snapshotSlices converts a snapshot back to the parallel slices BuildTree
+// takes.
+func snapshotSlices(s publishing.Snapshot) ([]db.ContentData, []db.Datatypes, []db.ContentFields, []db.Fields, error) {
+	cd, err := publishing.SnapshotContentDataToSlice(s.ContentData)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("convert snapshot content data: %w", err)
+	}
+	dt, err := publishing.SnapshotDatatypesToSlice(s.Datatypes)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("convert snapshot datatypes: %w", err)
+	}
+	cf, err := publishing.SnapshotContentFieldsToSlice(s.ContentFields)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("convert snapshot content fields: %w", err)
+	}
+	fd, err := publishing.SnapshotFieldsToSlice(s.Fields)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("convert snapshot fields: %w", err)
+	}
+	return cd, dt, cf, fd, nil
+}
+
+// recordingFetcher resolves composed references from published snapshots,
+// like the delivery API, and records the version each one came from so an
+// incremental export notices when a reference is republished.
+type recordingFetcher struct {
+	driver db.DbDriver
+	locale string
+
+	mu       sync.Mutex
+	versions map[string]string
+}
+
+func (f *recordingFetcher) FetchAndBuildTree(_ context.Context, id types.ContentID) (*core.Root, error) {
+	version, err := f.driver.GetPublishedSnapshot(id, f.locale)
+	// A reference without a published version is recorded as "" so that
+	// publishing it later counts as a change.
+	versionID := ""
+	if err == nil {
+		versionID = version.ContentVersionID.String()
+	}
+	f.mu.Lock()
+	f.versions[id.String()] = versionID
+	f.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("no published snapshot for %s: %w", id, err)
+	}
+
+	var snapshot publishing.Snapshot
+	if err := json.Unmarshal([]byte(version.Snapshot), &snapshot); err != nil {
+		return nil, fmt.Errorf("unmarshal snapshot for %s: %w", id, err)
+	}
+	cd, dt, cf, fd, err := snapshotSlices(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", id, err)
+	}
+	// The referenced root renders as a nested root, as in delivery.
+	for i, c := range cd {
+		if c.ContentDataID == id {
+			dt[i].Type = string(types.DatatypeTypeNestedRoot)
+			break
+		}
+	}
+	root, _, err := core.BuildTree(cd, dt, cf, fd)
+	return root, err
+}
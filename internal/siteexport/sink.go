@@ -0,0 +1,118 @@
+package siteexport
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Sink receives the files of an export. Names are slash-separated paths
+// relative to the bundle root.
+type Sink interface {
+	WriteFile(name string, data []byte) error
+	// Remove deletes a file written by a previous export.
+	Remove(name string) error
+	// Has reports whether a file written by a previous export is still
+	// present, so an incremental export can skip rewriting it.
+	Has(name string) bool
+}
+
+// DirSink writes the bundle to a directory.
+type DirSink struct {
+	Dir string
+}
+
+// path resolves name inside the directory, rejecting names that escape it.
+func (s DirSink) path(name string) (string, error) {
+	clean := path.Clean("/" + name)
+	if clean == "/" || strings.Contains(name, "\\") {
+		return "", fmt.Errorf("invalid file name %q", name)
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(clean[1:])), nil
+}
+
+// WriteFile writes data to a temporary file and renames it into place, so
+// a site build reading the directory never sees a partial file.
+func (s DirSink) WriteFile(name string, data []byte) error {
+	dst, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".export-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+// Remove deletes the file and any directories left empty up to Dir.
+func (s DirSink) Remove(name string) error {
+	target, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	root := filepath.Clean(s.Dir)
+	for dir := filepath.Dir(target); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+// Has reports whether the file exists.
+func (s DirSink) Has(name string) bool {
+	target, err := s.path(name)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(target)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// ZipSink writes the bundle as a zip archive. An incremental export to a
+// zip contains only new and changed files; the caller keeps the rest from
+// its previous bundle and deletes the files listed in Manifest.Removed.
+type ZipSink struct {
+	W *zip.Writer
+}
+
+// WriteFile adds a file to the archive.
+func (s ZipSink) WriteFile(name string, data []byte) error {
+	w, err := s.W.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Remove does nothing: removals are reported in the manifest.
+func (s ZipSink) Remove(string) error { return nil }
+
+// Has reports true: the caller holds the files of the previous bundle.
+func (s ZipSink) Has(string) bool { return true }
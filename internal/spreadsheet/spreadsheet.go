@@ -0,0 +1,163 @@
+// Package spreadsheet reads and writes single-sheet tables as CSV or XLSX.
+//
+// The XLSX support covers what bulk content import and export need: one
+// worksheet of text cells. Written workbooks use inline strings; reading
+// accepts shared strings, inline strings, numbers, and booleans from the
+// first worksheet, as produced by Excel, LibreOffice, and Google Sheets.
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Supported formats.
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Sheet is a table with a header row.
+type Sheet struct {
+	Header []string
+	Rows   [][]string
+	// Lines holds the 1-based row number of each entry in Rows for error
+	// reports; for CSV it is the line the record starts on. It is nil for
+	// sheets built in memory.
+	Lines []int
+}
+
+// Line returns the spreadsheet row number of Rows[i]: its recorded line, or
+// i+2 (counting the header) when none was recorded.
+func (s *Sheet) Line(i int) int {
+	if i < len(s.Lines) {
+		return s.Lines[i]
+	}
+	return i + 2
+}
+
+// Cell returns the value in row i under column, or "" when the row is short.
+func (s *Sheet) Cell(i, column int) string {
+	if i < 0 || i >= len(s.Rows) || column < 0 || column >= len(s.Rows[i]) {
+		return ""
+	}
+	return s.Rows[i][column]
+}
+
+// FormatFromName returns the format implied by a file name's extension, or ""
+// when it is neither .csv nor .xlsx.
+func FormatFromName(name string) string {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".csv":
+		return FormatCSV
+	case ".xlsx":
+		return FormatXLSX
+	}
+	return ""
+}
+
+// ContentType returns the MIME type of format.
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Read parses data in format. The first non-empty row is the header; blank
+// rows after it are dropped.
+func Read(format string, data []byte) (*Sheet, error) {
+	// rows[i] is row i+1; skipped rows and lines come back empty.
+	var rows [][]string
+	var err error
+	switch format {
+	case FormatCSV:
+		rows, err = readCSV(data)
+	case FormatXLSX:
+		rows, err = readXLSX(data)
+	default:
+		return nil, fmt.Errorf("unsupported spreadsheet format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := &Sheet{}
+	for i, row := range rows {
+		if blank(row) {
+			continue
+		}
+		if sheet.Header == nil {
+			sheet.Header = make([]string, len(row))
+			for j, h := range row {
+				sheet.Header[j] = strings.TrimSpace(h)
+			}
+			continue
+		}
+		sheet.Rows = append(sheet.Rows, row)
+		sheet.Lines = append(sheet.Lines, i+1)
+	}
+	if sheet.Header == nil {
+		return nil, fmt.Errorf("spreadsheet has no header row")
+	}
+	return sheet, nil
+}
+
+// Write encodes sheet in format.
+func Write(w io.Writer, format string, sheet *Sheet) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, sheet)
+	case FormatXLSX:
+		return writeXLSX(w, sheet)
+	}
+	return fmt.Errorf("unsupported spreadsheet format %q", format)
+}
+
+func blank(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	// Excel writes a byte order mark at the start of UTF-8 CSV files.
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	var rows [][]string
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse csv: %w", err)
+		}
+		// The reader skips empty lines; pad so rows stay aligned with
+		// line numbers.
+		line, _ := r.FieldPos(0)
+		for len(rows) < line-1 {
+			rows = append(rows, nil)
+		}
+		rows = append(rows, record)
+	}
+}
+
+func writeCSV(w io.Writer, sheet *Sheet) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(sheet.Header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(sheet.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
@@ -0,0 +1,156 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+	in := &Sheet{
+		Header: []string{"_content_id", "title", "body@fr"},
+		Rows: [][]string{
+			{"01A", "Widget", "<p>Un \"gadget\" & co</p>"},
+			{"", "Multi\nline, with comma", ""},
+			{"01C", "", "  padded  "},
+		},
+	}
+	for _, format := range []string{FormatCSV, FormatXLSX} {
+		t.Run(format, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			if err := Write(&buf, format, in); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			out, err := Read(format, buf.Bytes())
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if !reflect.DeepEqual(out.Header, in.Header) {
+				t.Errorf("header = %q, want %q", out.Header, in.Header)
+			}
+			if len(out.Rows) != len(in.Rows) {
+				t.Fatalf("rows = %d, want %d", len(out.Rows), len(in.Rows))
+			}
+			for i, row := range in.Rows {
+				for j, want := range row {
+					if got := out.Cell(i, j); got != want {
+						t.Errorf("cell %d,%d = %q, want %q", i, j, got, want)
+					}
+				}
+				if format == FormatXLSX && out.Line(i) != i+2 {
+					t.Errorf("Line(%d) = %d, want %d", i, out.Line(i), i+2)
+				}
+			}
+		})
+	}
+}
+
+func TestReadCSV_BOMAndBlankRows(t *testing.T) {
+	t.Parallel()
+	data := "\xef\xbb\xbftitle,price\n\nWidget,10\n,\n\"Gadget\n2\",12\nLast,1\n"
+	sheet, err := Read(FormatCSV, []byte(data))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if sheet.Header[0] != "title" {
+		t.Errorf("header[0] = %q, want title", sheet.Header[0])
+	}
+	if len(sheet.Rows) != 3 {
+		t.Fatalf("rows = %d, want 3", len(sheet.Rows))
+	}
+	if sheet.Line(1) != 5 || sheet.Line(2) != 7 {
+		t.Errorf("lines = %d, %d, want 5, 7", sheet.Line(1), sheet.Line(2))
+	}
+}
+
+// TestReadXLSX_SharedStrings reads a workbook in the shape Excel writes:
+// shared strings, rich text, numbers, booleans, gaps between cells and rows,
+// and an absolute relationship target.
+func TestReadXLSX_SharedStrings(t *testing.T) {
+	t.Parallel()
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Products" sheetId="1" r:id="rId3"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/products.xml"/>` +
+			`<Relationship Id="rId9" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><si><t>title</t></si><si><t>price</t></si><si><t>in_stock</t></si><si><r><t>Big </t></r><r><rPr><b/></rPr><t>Widget</t></r></si></sst>`,
+		"xl/worksheets/products.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="D1" t="s"><v>2</v></c></row>` +
+			`<row r="3"><c r="A3" t="s"><v>3</v></c><c r="B3"><v>12.5</v></c><c r="D3" t="b"><v>1</v></c></row>` +
+			`</sheetData></worksheet>`,
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(body))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	sheet, err := Read(FormatXLSX, buf.Bytes())
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if got := strings.Join(sheet.Header, ","); got != "title,price,,in_stock" {
+		t.Errorf("header = %q", got)
+	}
+	want := []string{"Big Widget", "12.5", "", "true"}
+	if !reflect.DeepEqual(sheet.Rows[0], want) {
+		t.Errorf("row = %q, want %q", sheet.Rows[0], want)
+	}
+	if sheet.Line(0) != 3 {
+		t.Errorf("Line(0) = %d, want 3", sheet.Line(0))
+	}
+}
+
+func TestRead_Errors(t *testing.T) {
+	t.Parallel()
+	if _, err := Read(FormatCSV, []byte("\n\n")); err == nil {
+		t.Error("expected error for a sheet without a header")
+	}
+	if _, err := Read(FormatXLSX, []byte("not a zip")); err == nil {
+		t.Error("expected error for invalid xlsx")
+	}
+	if _, err := Read("ods", nil); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	t.Parallel()
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(i); got != want {
+			t.Errorf("columnName(%d) = %q, want %q", i, got, want)
+		}
+		if got := columnIndex(want + "12"); got != i {
+			t.Errorf("columnIndex(%q) = %d, want %d", want+"12", got, i)
+		}
+	}
+	if got := columnIndex("XFD1"); got != maxColumns-1 {
+		t.Errorf("columnIndex(XFD1) = %d, want %d", got, maxColumns-1)
+	}
+	for _, ref := range []string{"XFE1", "ZZZZZZZ1", "ZZZZZZZZZZZZZZZZZZZZ1"} {
+		if got := columnIndex(ref); got != maxColumns {
+			t.Errorf("columnIndex(%q) = %d, want the limit %d", ref, got, maxColumns)
+		}
+	}
+}
+
+func TestFormatFromName(t *testing.T) {
+	t.Parallel()
+	cases := map[string]string{"a.csv": FormatCSV, "B.XLSX": FormatXLSX, "c.ods": "", "noext": ""}
+	for name, want := range cases {
+		if got := FormatFromName(name); got != want {
+			t.Errorf("FormatFromName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
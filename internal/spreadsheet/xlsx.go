@@ -0,0 +1,353 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// maxPartSize bounds how much of one workbook part is decompressed, so a
+// small upload cannot expand without limit.
+const maxPartSize = 256 << 20
+
+// maxRows is the row limit of an Excel worksheet.
+const maxRows = 1 << 20
+
+// maxColumns is the column limit of an Excel worksheet (A to XFD).
+const maxColumns = 1 << 14
+
+const (
+	nsRelationships = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	relSharedString = nsRelationships + "/sharedStrings"
+)
+
+// --- writing ---
+
+var xlsxStaticParts = []struct{ name, body string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+func writeXLSX(w io.Writer, sheet *Sheet) error {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxStaticParts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	writeRow := func(n int, values []string) error {
+		fmt.Fprintf(&buf, `<row r="%d">`, n)
+		for i, v := range values {
+			if v == "" {
+				continue
+			}
+			fmt.Fprintf(&buf, `<c r="%s%d" t="inlineStr"><is><t xml:space="preserve">`, columnName(i), n)
+			if err := xml.EscapeText(&buf, []byte(v)); err != nil {
+				return err
+			}
+			buf.WriteString(`</t></is></c>`)
+		}
+		buf.WriteString(`</row>`)
+		// Flush periodically so large exports are not held twice in memory.
+		if buf.Len() > 1<<20 {
+			if _, err := buf.WriteTo(f); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := writeRow(1, sheet.Header); err != nil {
+		return err
+	}
+	for i, row := range sheet.Rows {
+		if err := writeRow(i+2, row); err != nil {
+			return err
+		}
+	}
+	buf.WriteString(`</sheetData></worksheet>`)
+	if _, err := buf.WriteTo(f); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// columnName returns the letters of a 0-based column index: A, B, ..., Z, AA.
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// columnIndex parses the letters of a cell reference such as "AB12" into a
+// 0-based column index. It returns -1 when ref has no letters, and
+// maxColumns for any column past XFD.
+func columnIndex(ref string) int {
+	n := 0
+	letters := 0
+	for _, r := range ref {
+		if r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		n = n*26 + int(r-'A'+1)
+		if n > maxColumns {
+			return maxColumns
+		}
+		letters++
+	}
+	if letters == 0 {
+		return -1
+	}
+	return n - 1
+}
+
+// --- reading ---
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Type   string `xml:"Type,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxText is a string item: plain text or rich-text runs.
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	b.WriteString(t.T)
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+type xlsxRow struct {
+	R     int `xml:"r,attr"`
+	Cells []struct {
+		R  string    `xml:"r,attr"`
+		T  string    `xml:"t,attr"`
+		V  string    `xml:"v"`
+		IS *xlsxText `xml:"is"`
+	} `xml:"c"`
+}
+
+type workbookReader struct {
+	files map[string]*zip.File
+}
+
+func (wr workbookReader) open(name string) (io.ReadCloser, error) {
+	f, ok := wr.files[name]
+	if !ok {
+		return nil, fmt.Errorf("xlsx: missing %s", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("xlsx: open %s: %w", name, err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(rc, maxPartSize), rc}, nil
+}
+
+func (wr workbookReader) decode(name string, v any) error {
+	rc, err := wr.open(name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("xlsx: parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// relsFor returns the relationships of part, with targets resolved to
+// archive paths.
+func (wr workbookReader) relsFor(part string) (map[string]string, map[string]string, error) {
+	name := path.Join(path.Dir(part), "_rels", path.Base(part)+".rels")
+	var rels xlsxRelationships
+	if err := wr.decode(name, &rels); err != nil {
+		return nil, nil, err
+	}
+	byID := map[string]string{}
+	byType := map[string]string{}
+	for _, r := range rels.Relationships {
+		target := r.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join(path.Dir(part), target)
+		}
+		byID[r.ID] = target
+		byType[r.Type] = target
+	}
+	return byID, byType, nil
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("xlsx: %w", err)
+	}
+	wr := workbookReader{files: map[string]*zip.File{}}
+	for _, f := range zr.File {
+		wr.files[f.Name] = f
+	}
+
+	const workbookPart = "xl/workbook.xml"
+	var wb xlsxWorkbook
+	if err := wr.decode(workbookPart, &wb); err != nil {
+		return nil, err
+	}
+	if len(wb.Sheets) == 0 {
+		return nil, errors.New("xlsx: workbook has no sheets")
+	}
+	byID, byType, err := wr.relsFor(workbookPart)
+	if err != nil {
+		return nil, err
+	}
+	sheetPart, ok := byID[wb.Sheets[0].RID]
+	if !ok {
+		return nil, fmt.Errorf("xlsx: sheet %q has no relationship", wb.Sheets[0].Name)
+	}
+
+	var shared []string
+	if part, ok := byType[relSharedString]; ok {
+		var sst struct {
+			Items []xlsxText `xml:"si"`
+		}
+		if err := wr.decode(part, &sst); err != nil {
+			return nil, err
+		}
+		shared = make([]string, len(sst.Items))
+		for i, item := range sst.Items {
+			shared[i] = item.String()
+		}
+	}
+
+	rc, err := wr.open(sheetPart)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var rows [][]string
+	dec := xml.NewDecoder(rc)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("xlsx: parse %s: %w", sheetPart, err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+		var row xlsxRow
+		if err := dec.DecodeElement(&row, &start); err != nil {
+			return nil, fmt.Errorf("xlsx: parse %s: %w", sheetPart, err)
+		}
+		n := row.R
+		if n > maxRows {
+			return nil, fmt.Errorf("xlsx: row %d exceeds the sheet limit", n)
+		}
+		if n <= len(rows) {
+			// Rows without r follow the previous row.
+			n = len(rows) + 1
+		}
+		for len(rows) < n-1 {
+			rows = append(rows, nil)
+		}
+		var values []string
+		for i, c := range row.Cells {
+			col := columnIndex(c.R)
+			if col < 0 {
+				col = i
+			}
+			if col >= maxColumns {
+				return nil, fmt.Errorf("xlsx: cell %s exceeds the sheet's column limit", c.R)
+			}
+			value, err := cellValue(c.T, c.V, c.IS, shared)
+			if err != nil {
+				return nil, fmt.Errorf("xlsx: cell %s: %w", c.R, err)
+			}
+			for len(values) <= col {
+				values = append(values, "")
+			}
+			values[col] = value
+		}
+		rows = append(rows, values)
+	}
+	return rows, nil
+}
+
+func cellValue(typ, v string, is *xlsxText, shared []string) (string, error) {
+	switch typ {
+	case "s":
+		i, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil || i < 0 || i >= len(shared) {
+			return "", fmt.Errorf("invalid shared string index %q", v)
+		}
+		return shared[i], nil
+	case "inlineStr":
+		if is == nil {
+			return "", nil
+		}
+		return is.String(), nil
+	case "b":
+		if strings.TrimSpace(v) == "1" {
+			return "true", nil
+		}
+		return "false", nil
+	}
+	// Numbers, formula strings ("str"), and errors ("e") are kept as written.
+	return v, nil
+}
@@ -0,0 +1,79 @@
+package transform
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// maxArchiveSize bounds the total bytes read from an export archive so a
+// crafted archive cannot exhaust memory.
+const maxArchiveSize = 1 << 30
+
+// errNotArchive is returned by readArchive for data that is neither a tar
+// nor a gzip-compressed tar.
+var errNotArchive = errors.New("not a tar archive")
+
+// readArchive returns the regular files of a tar or tar.gz export keyed by
+// their cleaned slash-separated path.
+func readArchive(data []byte) (map[string][]byte, error) {
+	var r io.Reader = bytes.NewReader(data)
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("open gzip: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	} else if len(data) < 262 || string(data[257:262]) != "ustar" {
+		return nil, errNotArchive
+	}
+
+	files := make(map[string][]byte)
+	var total int64
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if len(files) == 0 {
+				return nil, errNotArchive
+			}
+			return nil, fmt.Errorf("read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		total += hdr.Size
+		if total > maxArchiveSize {
+			return nil, fmt.Errorf("read archive: contents exceed %d bytes", int64(maxArchiveSize))
+		}
+		body, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", hdr.Name, err)
+		}
+		files[path.Clean(strings.TrimPrefix(hdr.Name, "./"))] = body
+	}
+	return files, nil
+}
+
+// archiveFile returns the file whose path ends in name, ignoring the
+// top-level directory some exporters wrap everything in.
+func archiveFile(files map[string][]byte, name string) (string, []byte, bool) {
+	if body, ok := files[name]; ok {
+		return name, body, true
+	}
+	for p, body := range files {
+		if strings.HasSuffix(p, "/"+name) && strings.Count(p, "/") == strings.Count(name, "/")+1 {
+			return p, body, true
+		}
+	}
+	return "", nil, false
+}
@@ -0,0 +1,205 @@
+package transform
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/model"
+)
+
+// ImportPlan is a CMS-neutral description of an export: the content types it
+// uses, the items that go into the content tree, and the files that belong in
+// the media library. Source parsers (WXR, ...) build a plan; the import
+// service turns it into datatypes, fields, content, relations and media so
+// every source gets the same datatype reuse and tree placement rules.
+type ImportPlan struct {
+	Source string // source system, e.g. "wordpress"
+	Title  string // label for the root node of the imported tree
+	Types  []ImportType
+	Items  []ImportItem
+	Assets []ImportAsset
+	// Unmapped describes source types and attributes the parser had no
+	// field type for. Their values are skipped or stored as JSON; the list
+	// is reported so an import can be previewed before it is committed.
+	Unmapped []string
+}
+
+// ImportType maps one source content type onto a datatype.
+type ImportType struct {
+	Key    string // unique within the plan, referenced by items and relation fields
+	Name   string // datatype name; an existing datatype with this name is reused
+	Label  string
+	Plural string // label of the collection node that holds the items
+	Fields []ImportField
+}
+
+// ImportField maps one source attribute onto a field.
+type ImportField struct {
+	Name  string
+	Label string
+	Type  types.FieldType
+	// Target is the ImportType key a relation (_id) field points at. Empty
+	// when the source allows several target types.
+	Target string
+	// Multiple marks relation and media fields that hold more than one value.
+	Multiple bool
+	// Options lists the choices of a select field.
+	Options []string
+}
+
+// ImportItem is one piece of content. Values holds plain field values;
+// Relations and Media hold references to other items and assets by key and
+// are resolved to IDs once everything has been created.
+type ImportItem struct {
+	Key       string
+	Type      string // ImportType key
+	ParentKey string // parent item of the same type; empty places the item under its collection
+	Values    map[string]string
+	Relations map[string][]string
+	Media     map[string][]string
+}
+
+// ImportAsset is a file to pull into the media library. Exports that bundle
+// their files (Sanity and Strapi archives) carry the contents in Data;
+// otherwise the file is read from Path.
+type ImportAsset struct {
+	Key         string
+	Path        string // relative to the configured uploads directory, or the file name when Data is set
+	Data        []byte
+	URL         string // original public URL, for messages only
+	Title       string
+	Alt         string
+	Caption     string
+	Description string
+}
+
+// TypeByKey returns the type with the given key.
+func (p *ImportPlan) TypeByKey(key string) (*ImportType, bool) {
+	for i := range p.Types {
+		if p.Types[i].Key == key {
+			return &p.Types[i], true
+		}
+	}
+	return nil, false
+}
+
+// unmapped records a source type or attribute once.
+func (p *ImportPlan) unmapped(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if slices.Contains(p.Unmapped, msg) {
+		return
+	}
+	p.Unmapped = append(p.Unmapped, msg)
+}
+
+// Field returns the field with the given name.
+func (t *ImportType) Field(name string) (*ImportField, bool) {
+	for i := range t.Fields {
+		if t.Fields[i].Name == name {
+			return &t.Fields[i], true
+		}
+	}
+	return nil, false
+}
+
+// ToRoot renders the plan as a model tree: a root node, one collection node
+// per type and the items nested beneath them. Relation and media values are
+// rendered as comma-separated source keys since no IDs exist yet. This is the
+// Transformer.Parse view of an import; the import service works from the plan
+// directly.
+func (p *ImportPlan) ToRoot() model.Root {
+	root := planNode(p.Source+"_site", p.Title, string(types.DatatypeTypeRoot))
+	top, children := p.Tree()
+
+	for ti := range p.Types {
+		t := &p.Types[ti]
+		if len(top[t.Key]) == 0 {
+			continue
+		}
+		collection := planNode(t.Name+"_collection", t.Plural, string(types.DatatypeTypeCollection))
+		var build func(item *ImportItem) *model.Node
+		build = func(item *ImportItem) *model.Node {
+			node := planNode(t.Name, t.Label, t.Name)
+			node.Datatype.Content.ContentDataID = item.Key
+			for _, f := range t.Fields {
+				value, ok := item.Values[f.Name]
+				if refs, has := item.Relations[f.Name]; has {
+					value, ok = strings.Join(refs, ","), true
+				}
+				if refs, has := item.Media[f.Name]; has {
+					value, ok = strings.Join(refs, ","), true
+				}
+				if !ok {
+					continue
+				}
+				node.Fields = append(node.Fields, model.Field{
+					Info:    db.FieldsJSON{Name: f.Name, Label: f.Label, Type: string(f.Type)},
+					Content: db.ContentFieldsJSON{FieldValue: value},
+				})
+			}
+			for _, child := range children[item.Key] {
+				node.Nodes = append(node.Nodes, build(child))
+			}
+			return node
+		}
+		for _, item := range top[t.Key] {
+			collection.Nodes = append(collection.Nodes, build(item))
+		}
+		root.Nodes = append(root.Nodes, collection)
+	}
+
+	return model.Root{Node: root}
+}
+
+// Tree places the plan's items: top holds the top-level items of each type
+// keyed by type key, children the direct children of each item keyed by item
+// key, both in plan order. An item whose parent is missing, of another type,
+// or part of a parent cycle is placed at the top level.
+func (p *ImportPlan) Tree() (top map[string][]*ImportItem, children map[string][]*ImportItem) {
+	byKey := make(map[string]*ImportItem, len(p.Items))
+	for i := range p.Items {
+		byKey[p.Items[i].Key] = &p.Items[i]
+	}
+
+	nested := func(item *ImportItem) bool {
+		parent, ok := byKey[item.ParentKey]
+		if !ok || parent.Type != item.Type {
+			return false
+		}
+		seen := map[string]bool{item.Key: true}
+		for ok {
+			if seen[parent.Key] {
+				return false
+			}
+			seen[parent.Key] = true
+			parent, ok = byKey[parent.ParentKey]
+		}
+		return true
+	}
+
+	top = make(map[string][]*ImportItem)
+	children = make(map[string][]*ImportItem)
+	for i := range p.Items {
+		item := &p.Items[i]
+		if nested(item) {
+			children[item.ParentKey] = append(children[item.ParentKey], item)
+		} else {
+			top[item.Type] = append(top[item.Type], item)
+		}
+	}
+	return top, children
+}
+
+// planNode returns an empty node for a datatype.
+func planNode(name, label, typ string) *model.Node {
+	return &model.Node{
+		Datatype: model.Datatype{
+			Info: db.DatatypeJSON{Name: name, Label: label, Type: typ},
+		},
+		Fields: []model.Field{},
+		Nodes:  []*model.Node{},
+	}
+}
@@ -0,0 +1,329 @@
+package transform
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Richtext fields store HTML. The importers convert each source's rich text
+// representation into it: Sanity Portable Text, Strapi blocks and Strapi
+// Markdown. Only the structures the editors produce are handled; anything
+// else is reported through skip and left out.
+
+// portableTextToHTML renders Sanity Portable Text as HTML. Blocks whose
+// _type is not "block" (inline images, custom objects) and annotations other
+// than links are passed to skip by type name.
+func portableTextToHTML(blocks []any, skip func(typ string)) string {
+	var b strings.Builder
+	var lists []string // open list tags, innermost last; each has an open <li>
+
+	closeLists := func(depth int) {
+		for len(lists) > depth {
+			b.WriteString("</li></" + lists[len(lists)-1] + ">")
+			lists = lists[:len(lists)-1]
+		}
+	}
+
+	for _, raw := range blocks {
+		block, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		typ, _ := block["_type"].(string)
+		if typ != "block" {
+			closeLists(0)
+			skip(typ)
+			continue
+		}
+		inner := portableTextSpans(block, skip)
+
+		if listItem, _ := block["listItem"].(string); listItem != "" {
+			tag := "ul"
+			if listItem == "number" {
+				tag = "ol"
+			}
+			level := 1
+			if l, ok := block["level"].(float64); ok && l > 1 {
+				level = int(l)
+			}
+			for len(lists) > level || (len(lists) == level && lists[level-1] != tag) {
+				closeLists(len(lists) - 1)
+			}
+			if len(lists) == level {
+				b.WriteString("</li>")
+			}
+			for len(lists) < level {
+				b.WriteString("<" + tag + ">")
+				lists = append(lists, tag)
+				if len(lists) < level {
+					b.WriteString("<li>")
+				}
+			}
+			b.WriteString("<li>" + inner)
+			continue
+		}
+
+		closeLists(0)
+		tag := "p"
+		switch style, _ := block["style"].(string); style {
+		case "h1", "h2", "h3", "h4", "h5", "h6", "blockquote":
+			tag = style
+		}
+		b.WriteString("<" + tag + ">" + inner + "</" + tag + ">")
+	}
+	closeLists(0)
+	return b.String()
+}
+
+// portableTextDecorators maps Portable Text decorator marks to HTML tags.
+var portableTextDecorators = map[string]string{
+	"strong":         "strong",
+	"em":             "em",
+	"code":           "code",
+	"underline":      "u",
+	"strike-through": "s",
+}
+
+// portableTextSpans renders the children of one block, applying decorators
+// and link annotations from the block's markDefs.
+func portableTextSpans(block map[string]any, skip func(typ string)) string {
+	defs := make(map[string]map[string]any)
+	if markDefs, ok := block["markDefs"].([]any); ok {
+		for _, d := range markDefs {
+			if def, ok := d.(map[string]any); ok {
+				if key, _ := def["_key"].(string); key != "" {
+					defs[key] = def
+				}
+			}
+		}
+	}
+
+	var b strings.Builder
+	children, _ := block["children"].([]any)
+	for _, c := range children {
+		span, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		if typ, _ := span["_type"].(string); typ != "" && typ != "span" {
+			skip(typ)
+			continue
+		}
+		text, _ := span["text"].(string)
+		out := strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+		marks, _ := span["marks"].([]any)
+		for _, m := range marks {
+			mark, _ := m.(string)
+			if tag, ok := portableTextDecorators[mark]; ok {
+				out = "<" + tag + ">" + out + "</" + tag + ">"
+				continue
+			}
+			def, ok := defs[mark]
+			if !ok {
+				continue
+			}
+			if typ, _ := def["_type"].(string); typ != "link" {
+				skip(typ)
+				continue
+			}
+			href, _ := def["href"].(string)
+			out = `<a href="` + html.EscapeString(href) + `">` + out + "</a>"
+		}
+		b.WriteString(out)
+	}
+	return b.String()
+}
+
+// strapiBlocksToHTML renders the JSON of a Strapi "blocks" attribute as HTML.
+func strapiBlocksToHTML(nodes []any) string {
+	var b strings.Builder
+	for _, raw := range nodes {
+		node, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		children, _ := node["children"].([]any)
+		switch node["type"] {
+		case "paragraph":
+			b.WriteString("<p>" + strapiBlocksToHTML(children) + "</p>")
+		case "heading":
+			level := 1
+			if l, ok := node["level"].(float64); ok && l >= 1 && l <= 6 {
+				level = int(l)
+			}
+			fmt.Fprintf(&b, "<h%d>%s</h%d>", level, strapiBlocksToHTML(children), level)
+		case "list":
+			tag := "ul"
+			if node["format"] == "ordered" {
+				tag = "ol"
+			}
+			b.WriteString("<" + tag + ">" + strapiBlocksToHTML(children) + "</" + tag + ">")
+		case "list-item":
+			b.WriteString("<li>" + strapiBlocksToHTML(children) + "</li>")
+		case "quote":
+			b.WriteString("<blockquote>" + strapiBlocksToHTML(children) + "</blockquote>")
+		case "code":
+			b.WriteString("<pre><code>" + strapiBlocksToHTML(children) + "</code></pre>")
+		case "image":
+			img, _ := node["image"].(map[string]any)
+			src, _ := img["url"].(string)
+			alt, _ := img["alternativeText"].(string)
+			b.WriteString(`<img src="` + html.EscapeString(src) + `" alt="` + html.EscapeString(alt) + `">`)
+		case "link":
+			href, _ := node["url"].(string)
+			b.WriteString(`<a href="` + html.EscapeString(href) + `">` + strapiBlocksToHTML(children) + "</a>")
+		case "text":
+			text, _ := node["text"].(string)
+			out := strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+			for _, mark := range []struct{ key, tag string }{
+				{"code", "code"}, {"bold", "strong"}, {"italic", "em"}, {"underline", "u"}, {"strikethrough", "s"},
+			} {
+				if on, _ := node[mark.key].(bool); on {
+					out = "<" + mark.tag + ">" + out + "</" + mark.tag + ">"
+				}
+			}
+			b.WriteString(out)
+		}
+	}
+	return b.String()
+}
+
+var (
+	mdHeadingRe = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdRuleRe    = regexp.MustCompile(`^(\*\s*){3,}$|^(-\s*){3,}$|^(_\s*){3,}$`)
+	mdBulletRe  = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	mdNumberRe  = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+	mdCodeRe    = regexp.MustCompile("`([^`]+)`")
+	mdLinkRe    = regexp.MustCompile(`(!?)\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	mdStrongRe  = regexp.MustCompile(`\*\*(\S.*?)\*\*|__(\S.*?)__`)
+	mdEmRe      = regexp.MustCompile(`\*(\S[^*]*?)\*`)
+	mdEmUnderRe = regexp.MustCompile(`(^|[\s(])_(\S[^_]*?)_([\s).,;:!?]|$)`)
+	mdStrikeRe  = regexp.MustCompile(`~~(\S.*?)~~`)
+)
+
+// markdownToHTML renders the Markdown of a Strapi "richtext" attribute as
+// HTML. It covers headings, paragraphs, flat lists, block quotes, fenced
+// code, rules, emphasis, code spans, links and images. Lines that start
+// with an HTML tag are passed through unchanged.
+func markdownToHTML(md string) string {
+	var b strings.Builder
+	var para, quote []string
+	list := ""
+
+	flush := func() {
+		if len(para) > 0 {
+			b.WriteString("<p>" + markdownInline(strings.Join(para, "\n")) + "</p>")
+			para = nil
+		}
+		if len(quote) > 0 {
+			b.WriteString("<blockquote><p>" + markdownInline(strings.Join(quote, "\n")) + "</p></blockquote>")
+			quote = nil
+		}
+		if list != "" {
+			b.WriteString("</" + list + ">")
+			list = ""
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			flush()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			b.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>")
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flush()
+		case mdHeadingRe.MatchString(trimmed):
+			flush()
+			m := mdHeadingRe.FindStringSubmatch(trimmed)
+			fmt.Fprintf(&b, "<h%d>%s</h%d>", len(m[1]), markdownInline(m[2]), len(m[1]))
+		case mdRuleRe.MatchString(trimmed):
+			flush()
+			b.WriteString("<hr>")
+		case strings.HasPrefix(trimmed, ">"):
+			if len(quote) == 0 {
+				flush()
+			}
+			quote = append(quote, strings.TrimSpace(strings.TrimPrefix(trimmed, ">")))
+		case mdBulletRe.MatchString(line), mdNumberRe.MatchString(line):
+			tag, m := "ul", mdBulletRe.FindStringSubmatch(line)
+			if m == nil {
+				tag, m = "ol", mdNumberRe.FindStringSubmatch(line)
+			}
+			if list != tag {
+				flush()
+				b.WriteString("<" + tag + ">")
+				list = tag
+			}
+			b.WriteString("<li>" + markdownInline(m[1]) + "</li>")
+		case strings.HasPrefix(trimmed, "<") && len(para) == 0 && len(quote) == 0:
+			flush()
+			b.WriteString(line)
+		default:
+			if list != "" || len(quote) > 0 {
+				flush()
+			}
+			para = append(para, trimmed)
+		}
+	}
+	flush()
+	return b.String()
+}
+
+// markdownInline renders inline Markdown. Code spans are escaped verbatim;
+// link and image URLs are kept out of emphasis processing.
+func markdownInline(s string) string {
+	return mapMatches(s, mdCodeRe,
+		func(m []string) string { return "<code>" + html.EscapeString(m[1]) + "</code>" },
+		func(text string) string {
+			return mapMatches(text, mdLinkRe,
+				func(m []string) string {
+					if m[1] == "!" {
+						return `<img src="` + html.EscapeString(m[3]) + `" alt="` + html.EscapeString(m[2]) + `">`
+					}
+					return `<a href="` + html.EscapeString(m[3]) + `">` + markdownEmphasis(m[2]) + "</a>"
+				},
+				markdownEmphasis)
+		})
+}
+
+// markdownEmphasis escapes text and renders strong, em and strike spans.
+func markdownEmphasis(s string) string {
+	s = html.EscapeString(s)
+	s = mdStrongRe.ReplaceAllString(s, "<strong>$1$2</strong>")
+	s = mdEmRe.ReplaceAllString(s, "<em>$1</em>")
+	s = mdEmUnderRe.ReplaceAllString(s, "$1<em>$2</em>$3")
+	return mdStrikeRe.ReplaceAllString(s, "<s>$1</s>")
+}
+
+// mapMatches renders every match of re in s with match and the text between
+// matches with plain.
+func mapMatches(s string, re *regexp.Regexp, match func(m []string) string, plain func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, idx := range re.FindAllStringSubmatchIndex(s, -1) {
+		b.WriteString(plain(s[last:idx[0]]))
+		m := make([]string, len(idx)/2)
+		for i := range m {
+			if idx[2*i] >= 0 {
+				m[i] = s[idx[2*i]:idx[2*i+1]]
+			}
+		}
+		b.WriteString(match(m))
+		last = idx[1]
+	}
+	b.WriteString(plain(s[last:]))
+	return b.String()
+}
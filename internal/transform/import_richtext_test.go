@@ -0,0 +1,49 @@
+package transform
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPortableTextToHTML_Lists(t *testing.T) {
+	t.Parallel()
+	var blocks []any
+	if err := json.Unmarshal([]byte(`[
+		{"_type":"block","listItem":"bullet","level":1,"children":[{"_type":"span","text":"a"}]},
+		{"_type":"block","listItem":"bullet","level":2,"children":[{"_type":"span","text":"a.1"}]},
+		{"_type":"block","listItem":"bullet","level":1,"children":[{"_type":"span","text":"b"}]},
+		{"_type":"block","listItem":"number","level":1,"children":[{"_type":"span","text":"one"}]},
+		{"_type":"block","style":"blockquote","children":[{"_type":"span","text":"x < y","marks":["em"]}]}
+	]`), &blocks); err != nil {
+		t.Fatal(err)
+	}
+	var skipped []string
+	got := portableTextToHTML(blocks, func(typ string) { skipped = append(skipped, typ) })
+	want := `<ul><li>a<ul><li>a.1</li></ul></li><li>b</li></ul><ol><li>one</li></ol><blockquote><em>x &lt; y</em></blockquote>`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	if len(skipped) != 0 {
+		t.Errorf("skipped = %v", skipped)
+	}
+}
+
+func TestMarkdownToHTML(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name, in, want string
+	}{
+		{"paragraphs", "one\ntwo\n\nthree", "<p>one\ntwo</p><p>three</p>"},
+		{"emphasis", "*em* _also_ ~~gone~~ `a*b*`", "<p><em>em</em> <em>also</em> <s>gone</s> <code>a*b*</code></p>"},
+		{"ordered list", "1. a\n2. b\n\nafter", "<ol><li>a</li><li>b</li></ol><p>after</p>"},
+		{"quote and rule", "> quoted\n\n---", "<blockquote><p>quoted</p></blockquote><hr>"},
+		{"fence", "```\n<b>raw</b>\n```", "<pre><code>&lt;b&gt;raw&lt;/b&gt;</code></pre>"},
+		{"image", "![Alt](/uploads/x.png)", `<p><img src="/uploads/x.png" alt="Alt"></p>`},
+		{"html passthrough", "<div>kept</div>", "<div>kept</div>"},
+	}
+	for _, tt := range tests {
+		if got := markdownToHTML(tt.in); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package transform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// phpUnserialize decodes the subset of PHP's serialize() format that
+// WordPress writes into post meta and ACF field definitions: strings,
+// integers, floats, booleans, null and arrays. Arrays decode to
+// map[string]any; phpList converts sequential ones to slices. Objects are
+// rejected.
+func phpUnserialize(s string) (any, error) {
+	d := &phpDecoder{s: s}
+	v, err := d.value()
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// phpDecoder is a cursor over a serialized PHP value.
+type phpDecoder struct {
+	s   string
+	pos int
+}
+
+func (d *phpDecoder) value() (any, error) {
+	if d.pos+1 >= len(d.s) {
+		return nil, fmt.Errorf("php unserialize: unexpected end at %d", d.pos)
+	}
+	kind := d.s[d.pos]
+	if kind == 'N' {
+		if d.s[d.pos+1] != ';' {
+			return nil, fmt.Errorf("php unserialize: malformed null at %d", d.pos)
+		}
+		d.pos += 2
+		return nil, nil
+	}
+	if d.s[d.pos+1] != ':' {
+		return nil, fmt.Errorf("php unserialize: expected ':' at %d", d.pos+1)
+	}
+	d.pos += 2
+
+	switch kind {
+	case 'b', 'i', 'd':
+		raw, err := d.until(';')
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case 'b':
+			return raw == "1", nil
+		case 'i':
+			return strconv.ParseInt(raw, 10, 64)
+		default:
+			return strconv.ParseFloat(raw, 64)
+		}
+	case 's':
+		n, err := d.length()
+		if err != nil {
+			return nil, err
+		}
+		// Lengths are in bytes, so slice rather than scan for the quote.
+		// Compare against the remaining input so a huge n cannot overflow.
+		if n > len(d.s)-d.pos-2 || d.s[d.pos] != '"' || d.s[d.pos+n+1] != '"' {
+			return nil, fmt.Errorf("php unserialize: malformed string at %d", d.pos)
+		}
+		str := d.s[d.pos+1 : d.pos+n+1]
+		d.pos += n + 2
+		if d.pos >= len(d.s) || d.s[d.pos] != ';' {
+			return nil, fmt.Errorf("php unserialize: expected ';' at %d", d.pos)
+		}
+		d.pos++
+		return str, nil
+	case 'a':
+		n, err := d.length()
+		if err != nil {
+			return nil, err
+		}
+		if d.pos >= len(d.s) || d.s[d.pos] != '{' {
+			return nil, fmt.Errorf("php unserialize: expected '{' at %d", d.pos)
+		}
+		d.pos++
+		// Each entry takes at least four bytes ("N;N;"), so a count the
+		// remaining input cannot hold is rejected before allocating.
+		if n > (len(d.s)-d.pos)/4 {
+			return nil, fmt.Errorf("php unserialize: array count %d exceeds input at %d", n, d.pos)
+		}
+		m := make(map[string]any, n)
+		for range n {
+			k, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			v, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprint(k)] = v
+		}
+		if d.pos >= len(d.s) || d.s[d.pos] != '}' {
+			return nil, fmt.Errorf("php unserialize: expected '}' at %d", d.pos)
+		}
+		d.pos++
+		return m, nil
+	default:
+		return nil, fmt.Errorf("php unserialize: unsupported type %q at %d", kind, d.pos-2)
+	}
+}
+
+// until returns the text up to sep and moves past it.
+func (d *phpDecoder) until(sep byte) (string, error) {
+	end := strings.IndexByte(d.s[d.pos:], sep)
+	if end < 0 {
+		return "", fmt.Errorf("php unserialize: missing %q after %d", sep, d.pos)
+	}
+	raw := d.s[d.pos : d.pos+end]
+	d.pos += end + 1
+	return raw, nil
+}
+
+// length reads the "N:" prefix of strings and arrays.
+func (d *phpDecoder) length() (int, error) {
+	raw, err := d.until(':')
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("php unserialize: bad length %q", raw)
+	}
+	return n, nil
+}
+
+// phpList returns the values of a decoded PHP array in index order when its
+// keys are 0..n-1, or the value itself wrapped in a slice otherwise.
+func phpList(v any) []any {
+	m, ok := v.(map[string]any)
+	if !ok {
+		if v == nil {
+			return nil
+		}
+		return []any{v}
+	}
+	out := make([]any, 0, len(m))
+	for i := range len(m) {
+		item, ok := m[strconv.Itoa(i)]
+		if !ok {
+			return []any{v}
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// isPHPSerialized reports whether s looks like a serialized PHP array.
+func isPHPSerialized(s string) bool {
+	return strings.HasPrefix(s, "a:") && strings.HasSuffix(s, "}")
+}
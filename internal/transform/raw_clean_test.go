@@ -2,7 +2,6 @@ package transform
 
 import (
 	"encoding/json"
-	"strings"
 	"testing"
 
 	"github.com/hegner123/modulacms/internal/db"
@@ -707,41 +706,32 @@ func TestCleanTransformer_Transform_EmptyFieldValue(t *testing.T) {
 }
 
 // ---------------------------------------------------------------------------
-// Parse stubs: Sanity, Strapi, WordPress return errors
+// Export importers: Sanity, Strapi reject input that is not an export
 // ---------------------------------------------------------------------------
 
-func TestParseStubs_ReturnErrors(t *testing.T) {
+func TestParseImporters_RejectInvalidInput(t *testing.T) {
 	t.Parallel()
 
-	stubs := []struct {
+	importers := []struct {
 		name        string
 		transformer Transformer
 	}{
 		{name: "Sanity", transformer: &SanityTransformer{}},
 		{name: "Strapi", transformer: &StrapiTransformer{}},
-		{name: "WordPress", transformer: &WordPressTransformer{}},
 	}
 
-	for _, stub := range stubs {
-		t.Run(stub.name+"_Parse", func(t *testing.T) {
+	for _, imp := range importers {
+		t.Run(imp.name+"_Parse", func(t *testing.T) {
 			t.Parallel()
-			_, err := stub.transformer.Parse([]byte(`{}`))
-			if err == nil {
-				t.Fatalf("%s Parse should return error (stub), got nil", stub.name)
-			}
-			if !strings.Contains(err.Error(), "not yet implemented") {
-				t.Errorf("%s Parse error = %q, want 'not yet implemented'", stub.name, err.Error())
+			if _, err := imp.transformer.Parse([]byte(`not an export`)); err == nil {
+				t.Fatalf("%s Parse should return error, got nil", imp.name)
 			}
 		})
 
-		t.Run(stub.name+"_ParseToNode", func(t *testing.T) {
+		t.Run(imp.name+"_ParseToNode", func(t *testing.T) {
 			t.Parallel()
-			_, err := stub.transformer.ParseToNode([]byte(`{}`))
-			if err == nil {
-				t.Fatalf("%s ParseToNode should return error (stub), got nil", stub.name)
-			}
-			if !strings.Contains(err.Error(), "not yet implemented") {
-				t.Errorf("%s ParseToNode error = %q, want 'not yet implemented'", stub.name, err.Error())
+			if _, err := imp.transformer.ParseToNode([]byte(`not an export`)); err == nil {
+				t.Fatalf("%s ParseToNode should return error, got nil", imp.name)
 			}
 		})
 	}
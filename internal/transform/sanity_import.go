@@ -0,0 +1,527 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/model"
+)
+
+// Plan keys for Sanity exports.
+func sanityDocKey(id string) string    { return "doc:" + id }
+func sanityTypeKey(typ string) string  { return "type:" + typ }
+func sanityAssetKey(ref string) string { return "asset:" + ref }
+
+// sanityDoc is one document of a dataset export with its attribute names in
+// export order.
+type sanityDoc struct {
+	id     string
+	typ    string
+	fields map[string]any
+	order  []string
+}
+
+// sanityField is the field an attribute maps to, decided by the first
+// non-empty value seen for it.
+type sanityField struct {
+	field   ImportField
+	targets map[string]bool // referenced document types, for relation fields
+}
+
+// sanityTypeState collects a document type's fields while its documents are
+// read.
+type sanityTypeState struct {
+	typ    ImportType
+	fields map[string]*sanityField
+	order  []string
+}
+
+// sanityExport is the state of one ParseSanityExport call.
+type sanityExport struct {
+	plan       *ImportPlan
+	files      map[string][]byte // archive contents; nil for a bare NDJSON export
+	base       string            // archive directory holding data.ndjson
+	assetMeta  map[string]map[string]any
+	docTypes   map[string]string // document id -> _type
+	assets     map[string]bool   // asset keys already added to the plan
+	assetDocs  map[string]ImportAsset
+	typeStates map[string]*sanityTypeState
+	typeOrder  []string
+}
+
+// ParseSanityExport parses a Sanity dataset export into an ImportPlan.
+//
+// data is either the tar.gz archive written by `sanity dataset export`,
+// whose images and files are imported from the archive, or its bare
+// data.ndjson, whose sanity.imageAsset and sanity.fileAsset documents become
+// assets read from the uploads directory by their CDN path. Every other
+// document type becomes a type whose fields are inferred from the values:
+// references become relation fields, images and files media fields, Portable
+// Text richtext fields holding HTML, slugs slug fields, and other objects
+// JSON fields. Drafts are imported only when no published version exists.
+func ParseSanityExport(data []byte) (*ImportPlan, error) {
+	x := &sanityExport{
+		plan:       &ImportPlan{Source: "sanity", Title: "Sanity Dataset"},
+		assetMeta:  make(map[string]map[string]any),
+		docTypes:   make(map[string]string),
+		assets:     make(map[string]bool),
+		assetDocs:  make(map[string]ImportAsset),
+		typeStates: make(map[string]*sanityTypeState),
+	}
+
+	ndjson := data
+	files, err := readArchive(data)
+	switch {
+	case err == nil:
+		name, body, ok := archiveFile(files, "data.ndjson")
+		if !ok {
+			return nil, fmt.Errorf("parse Sanity export: archive has no data.ndjson")
+		}
+		ndjson, x.files, x.base = body, files, path.Dir(name)
+		if meta, ok := files[path.Join(x.base, "assets.json")]; ok {
+			_ = json.Unmarshal(meta, &x.assetMeta)
+		}
+	case !errors.Is(err, errNotArchive):
+		return nil, fmt.Errorf("parse Sanity export: %w", err)
+	}
+
+	docs, err := sanityDocuments(ndjson)
+	if err != nil {
+		return nil, err
+	}
+
+	// Index every document first so references can name their target type.
+	var content []*sanityDoc
+	for _, d := range docs {
+		switch {
+		case d.typ == "sanity.imageAsset" || d.typ == "sanity.fileAsset":
+			x.assetDocs[d.id] = x.assetFromDoc(d)
+		case strings.HasPrefix(d.typ, "sanity.") || strings.HasPrefix(d.typ, "system.") || strings.HasPrefix(d.id, "_."):
+			// Studio and system documents are not content.
+		default:
+			x.docTypes[d.id] = d.typ
+			content = append(content, d)
+		}
+	}
+
+	for _, d := range content {
+		s := x.state(d.typ)
+		for _, name := range d.order {
+			x.classify(s, d.typ, name, d.fields[name])
+		}
+	}
+	for _, key := range x.typeOrder {
+		s := x.typeStates[key]
+		sanityTitleField(s)
+		for _, name := range s.order {
+			f := s.fields[name]
+			if f.field.Type == types.FieldTypeIDRef && len(f.targets) == 1 {
+				for t := range f.targets {
+					f.field.Target = sanityTypeKey(t)
+				}
+			}
+			s.typ.Fields = append(s.typ.Fields, f.field)
+		}
+		x.plan.Types = append(x.plan.Types, s.typ)
+	}
+
+	for _, d := range content {
+		x.plan.Items = append(x.plan.Items, x.item(d))
+	}
+	return x.plan, nil
+}
+
+// sanityDocuments decodes an NDJSON export, keeping each document's
+// attribute order. Drafts are dropped when the published document exists and
+// otherwise take its ID; release versions are dropped.
+func sanityDocuments(ndjson []byte) ([]*sanityDoc, error) {
+	dec := json.NewDecoder(bytes.NewReader(ndjson))
+	var docs []*sanityDoc
+	published := make(map[string]bool)
+	for line := 1; ; line++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("parse Sanity export: document %d: %w", line, err)
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, fmt.Errorf("parse Sanity export: document %d is not an object", line)
+		}
+		id, _ := fields["_id"].(string)
+		typ, _ := fields["_type"].(string)
+		if id == "" || typ == "" {
+			return nil, fmt.Errorf("parse Sanity export: document %d has no _id or _type", line)
+		}
+		if strings.HasPrefix(id, "versions.") {
+			continue
+		}
+		if !strings.HasPrefix(id, "drafts.") {
+			published[id] = true
+		}
+		docs = append(docs, &sanityDoc{id: id, typ: typ, fields: fields, order: jsonKeys(raw)})
+	}
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("parse Sanity export: no documents")
+	}
+
+	kept := docs[:0]
+	for _, d := range docs {
+		if id, ok := strings.CutPrefix(d.id, "drafts."); ok {
+			if published[id] {
+				continue
+			}
+			d.id = id
+		}
+		kept = append(kept, d)
+	}
+	return kept, nil
+}
+
+// jsonKeys returns the top-level keys of a JSON object in document order.
+func jsonKeys(raw json.RawMessage) []string {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil {
+		return nil
+	}
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return keys
+		}
+		key, _ := tok.(string)
+		keys = append(keys, key)
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return keys
+		}
+	}
+	return keys
+}
+
+func (x *sanityExport) state(typ string) *sanityTypeState {
+	key := sanityTypeKey(typ)
+	if s, ok := x.typeStates[key]; ok {
+		return s
+	}
+	name := importName(typ)
+	s := &sanityTypeState{
+		typ:    ImportType{Key: key, Name: name, Label: labelize(name), Plural: labelize(pluralize(name))},
+		fields: make(map[string]*sanityField),
+	}
+	x.typeStates[key] = s
+	x.typeOrder = append(x.typeOrder, key)
+	return s
+}
+
+// classify maps an attribute onto a field the first time it has a value and
+// collects relation targets from every value.
+func (x *sanityExport) classify(s *sanityTypeState, docType, attr string, v any) {
+	if strings.HasPrefix(attr, "_") || v == nil {
+		return
+	}
+	if f, ok := s.fields[attr]; ok {
+		if f.field.Type == types.FieldTypeIDRef {
+			x.collectTargets(f, v)
+		}
+		return
+	}
+
+	fieldType, multiple := x.fieldType(docType, attr, v)
+	if fieldType == "" {
+		return
+	}
+	name := importName(attr)
+	f := &sanityField{
+		field:   ImportField{Name: name, Label: labelize(name), Type: fieldType, Multiple: multiple},
+		targets: make(map[string]bool),
+	}
+	if fieldType == types.FieldTypeIDRef {
+		x.collectTargets(f, v)
+	}
+	s.fields[attr] = f
+	s.order = append(s.order, attr)
+}
+
+// fieldType infers the field type of a value. It returns "" for values that
+// say nothing yet, such as empty arrays.
+func (x *sanityExport) fieldType(docType, attr string, v any) (types.FieldType, bool) {
+	switch val := v.(type) {
+	case string:
+		if _, err := time.Parse(time.RFC3339, val); err == nil {
+			return types.FieldTypeDatetime, false
+		}
+		if _, err := time.Parse("2006-01-02", val); err == nil {
+			return types.FieldTypeDate, false
+		}
+		if strings.Contains(val, "\n") {
+			return types.FieldTypeTextarea, false
+		}
+		return types.FieldTypeText, false
+	case float64:
+		return types.FieldTypeNumber, false
+	case bool:
+		return types.FieldTypeBoolean, false
+	case map[string]any:
+		switch {
+		case val["_type"] == "slug":
+			return types.FieldTypeSlug, false
+		case sanityIsRef(val):
+			return types.FieldTypeIDRef, false
+		case sanityIsAsset(val):
+			return types.FieldTypeMedia, false
+		}
+		if typ, _ := val["_type"].(string); typ != "" {
+			x.plan.unmapped("sanity object type %q (%s.%s) stored as JSON", typ, docType, attr)
+		}
+		return types.FieldTypeJSON, false
+	case []any:
+		if len(val) == 0 {
+			return "", false
+		}
+		refs, assets := true, true
+		for _, item := range val {
+			m, _ := item.(map[string]any)
+			if m["_type"] == "block" {
+				return types.FieldTypeRichText, false
+			}
+			refs = refs && m != nil && sanityIsRef(m)
+			assets = assets && m != nil && sanityIsAsset(m)
+		}
+		switch {
+		case refs:
+			return types.FieldTypeIDRef, true
+		case assets:
+			return types.FieldTypeMedia, true
+		}
+		for _, item := range val {
+			if m, ok := item.(map[string]any); ok {
+				if typ, _ := m["_type"].(string); typ != "" {
+					x.plan.unmapped("sanity object type %q (%s.%s) stored as JSON", typ, docType, attr)
+				}
+			}
+		}
+		return types.FieldTypeJSON, false
+	}
+	return types.FieldTypeJSON, false
+}
+
+func (x *sanityExport) collectTargets(f *sanityField, v any) {
+	for _, ref := range sanityRefs(v) {
+		if t, ok := x.docTypes[ref]; ok {
+			f.targets[t] = true
+		}
+	}
+}
+
+// sanityTitleField promotes the title attribute, or name when there is
+// none, to the datatype's _title field.
+func sanityTitleField(s *sanityTypeState) {
+	for _, attr := range []string{"title", "name"} {
+		if f, ok := s.fields[attr]; ok && f.field.Type == types.FieldTypeText {
+			f.field.Type = types.FieldTypeTitle
+			return
+		}
+	}
+}
+
+// item converts a document into a plan item.
+func (x *sanityExport) item(d *sanityDoc) ImportItem {
+	s := x.typeStates[sanityTypeKey(d.typ)]
+	item := ImportItem{
+		Key:       sanityDocKey(d.id),
+		Type:      s.typ.Key,
+		Values:    make(map[string]string),
+		Relations: make(map[string][]string),
+		Media:     make(map[string][]string),
+	}
+	for _, attr := range d.order {
+		f, ok := s.fields[attr]
+		v := d.fields[attr]
+		if !ok || v == nil {
+			continue
+		}
+		name := f.field.Name
+		switch f.field.Type {
+		case types.FieldTypeIDRef:
+			for _, ref := range sanityRefs(v) {
+				item.Relations[name] = append(item.Relations[name], sanityDocKey(ref))
+			}
+		case types.FieldTypeMedia:
+			for _, m := range sanityList(v) {
+				if key := x.assetKey(m); key != "" {
+					item.Media[name] = append(item.Media[name], key)
+				}
+			}
+		case types.FieldTypeRichText:
+			blocks, _ := v.([]any)
+			item.Values[name] = portableTextToHTML(blocks, func(typ string) {
+				x.plan.unmapped("portable text type %q (%s.%s) skipped", typ, d.typ, attr)
+			})
+		case types.FieldTypeSlug:
+			if m, ok := v.(map[string]any); ok {
+				current, _ := m["current"].(string)
+				item.Values[name] = current
+			} else {
+				item.Values[name] = scalarValue(v)
+			}
+		default:
+			item.Values[name] = scalarValue(v)
+		}
+	}
+	return item
+}
+
+// assetKey returns the plan key of an image or file value, adding the asset
+// to the plan the first time it is referenced. Archive exports rewrite asset
+// references to "image@file://./images/<name>" paths inside the archive.
+func (x *sanityExport) assetKey(v map[string]any) string {
+	if local, ok := v["_sanityAsset"].(string); ok {
+		_, rel, found := strings.Cut(local, "file://")
+		if !found {
+			return ""
+		}
+		rel = path.Clean(rel)
+		key := "file:" + rel
+		if x.assets[key] {
+			return key
+		}
+		body, ok := x.files[path.Join(x.base, rel)]
+		if !ok {
+			return ""
+		}
+		asset := ImportAsset{Key: key, Path: path.Base(rel), Data: body}
+		// assets.json is keyed by asset document ID: image-<name>-<ext>.
+		kind, _, _ := strings.Cut(local, "@")
+		base := path.Base(rel)
+		if ext := path.Ext(base); ext != "" {
+			if meta, ok := x.assetMeta[kind+"-"+strings.TrimSuffix(base, ext)+"-"+ext[1:]]; ok {
+				sanityAssetMeta(&asset, meta)
+			}
+		}
+		x.assets[key] = true
+		x.plan.Assets = append(x.plan.Assets, asset)
+		return key
+	}
+
+	asset, _ := v["asset"].(map[string]any)
+	ref, _ := asset["_ref"].(string)
+	doc, ok := x.assetDocs[ref]
+	if !ok {
+		return ""
+	}
+	if !x.assets[doc.Key] {
+		x.assets[doc.Key] = true
+		x.plan.Assets = append(x.plan.Assets, doc)
+	}
+	return doc.Key
+}
+
+// assetFromDoc converts a sanity.imageAsset or sanity.fileAsset document.
+func (x *sanityExport) assetFromDoc(d *sanityDoc) ImportAsset {
+	asset := ImportAsset{Key: sanityAssetKey(d.id)}
+	asset.Path, _ = d.fields["path"].(string)
+	asset.URL, _ = d.fields["url"].(string)
+	sanityAssetMeta(&asset, d.fields)
+	return asset
+}
+
+// sanityAssetMeta copies title, alt text and description from an asset
+// document.
+func sanityAssetMeta(asset *ImportAsset, meta map[string]any) {
+	asset.Title, _ = meta["title"].(string)
+	if asset.Title == "" {
+		asset.Title, _ = meta["originalFilename"].(string)
+	}
+	asset.Alt, _ = meta["altText"].(string)
+	asset.Description, _ = meta["description"].(string)
+}
+
+func sanityIsRef(m map[string]any) bool {
+	_, ok := m["_ref"].(string)
+	return ok
+}
+
+func sanityIsAsset(m map[string]any) bool {
+	if _, ok := m["_sanityAsset"]; ok {
+		return true
+	}
+	_, ok := m["asset"].(map[string]any)
+	return ok && (m["_type"] == "image" || m["_type"] == "file")
+}
+
+// sanityRefs returns the published document IDs a reference or array of
+// references points at.
+func sanityRefs(v any) []string {
+	var refs []string
+	for _, m := range sanityList(v) {
+		if ref, ok := m["_ref"].(string); ok {
+			refs = append(refs, strings.TrimPrefix(ref, "drafts."))
+		}
+	}
+	return refs
+}
+
+// sanityList returns an object value, or the objects of an array value.
+func sanityList(v any) []map[string]any {
+	switch val := v.(type) {
+	case map[string]any:
+		return []map[string]any{val}
+	case []any:
+		out := make([]map[string]any, 0, len(val))
+		for _, item := range val {
+			if m, ok := item.(map[string]any); ok {
+				out = append(out, m)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// scalarValue renders a decoded JSON value as a field value: strings as-is, numbers
+// without exponent, booleans as true/false, anything else as JSON.
+func scalarValue(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// Parse converts a Sanity dataset export to a ModulaCMS tree (INBOUND). See
+// ParseSanityExport.
+func (s *SanityTransformer) Parse(data []byte) (model.Root, error) {
+	plan, err := ParseSanityExport(data)
+	if err != nil {
+		return model.Root{}, err
+	}
+	return plan.ToRoot(), nil
+}
+
+// ParseToNode converts a Sanity dataset export to a ModulaCMS Node (INBOUND).
+func (s *SanityTransformer) ParseToNode(data []byte) (*model.Node, error) {
+	root, err := s.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return root.Node, nil
+}
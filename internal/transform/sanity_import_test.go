@@ -0,0 +1,148 @@
+package transform
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// testSanityNDJSON is a dataset export with two document types, a
+// reference, an image, Portable Text with a custom block, a draft of a
+// published document and a lone draft.
+const testSanityNDJSON = `{"_id":"author-1","_type":"author","_rev":"a","name":"Ada Lovelace","bio":"First line\nSecond line"}
+{"_id":"post-1","_type":"blogPost","title":"Hello","slug":{"_type":"slug","current":"hello"},"publishedAt":"2024-03-01T09:00:00Z","views":42,"featured":true,"author":{"_type":"reference","_ref":"author-1"},"mainImage":{"_type":"image","asset":{"_type":"reference","_ref":"image-abc-10x10-jpg"}},"body":[{"_type":"block","_key":"b1","style":"h2","markDefs":[],"children":[{"_type":"span","text":"Intro","marks":[]}]},{"_type":"block","_key":"b2","style":"normal","markDefs":[{"_key":"l1","_type":"link","href":"https://example.com?a=1&b=2"}],"children":[{"_type":"span","text":"Read ","marks":[]},{"_type":"span","text":"this","marks":["strong","l1"]}]},{"_type":"youtube","_key":"y1","url":"https://youtu.be/x"}],"seo":{"_type":"seo","metaTitle":"Hi"}}
+{"_id":"drafts.post-1","_type":"blogPost","title":"Hello (draft)"}
+{"_id":"drafts.post-2","_type":"blogPost","title":"Unpublished","tags":["a","b"]}
+{"_id":"image-abc-10x10-jpg","_type":"sanity.imageAsset","path":"images/p/production/abc-10x10.jpg","url":"https://cdn.sanity.io/images/p/production/abc-10x10.jpg","originalFilename":"photo.jpg","altText":"A photo"}
+{"_id":"_.groups.public","_type":"system.group"}
+`
+
+func TestParseSanityExport_NDJSON(t *testing.T) {
+	t.Parallel()
+	plan, err := ParseSanityExport([]byte(testSanityNDJSON))
+	if err != nil {
+		t.Fatalf("ParseSanityExport: %v", err)
+	}
+
+	if len(plan.Types) != 2 || plan.Types[0].Name != "author" || plan.Types[1].Name != "blog_post" {
+		t.Fatalf("types = %+v", plan.Types)
+	}
+	post := plan.Types[1]
+	if post.Plural != "Blog Posts" {
+		t.Errorf("plural = %q", post.Plural)
+	}
+	var got []string
+	for _, f := range post.Fields {
+		got = append(got, f.Name+":"+string(f.Type))
+	}
+	want := []string{"title:_title", "slug:slug", "published_at:datetime", "views:number", "featured:boolean",
+		"author:_id", "main_image:media", "body:richtext", "seo:json", "tags:json"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("blog_post fields\n got %v\nwant %v", got, want)
+	}
+	if f, _ := post.Field("author"); f.Target != "type:author" || f.Multiple {
+		t.Errorf("author field = %+v", f)
+	}
+	if f, _ := plan.Types[0].Field("bio"); f.Type != types.FieldTypeTextarea {
+		t.Errorf("bio type = %s", f.Type)
+	}
+
+	if len(plan.Items) != 3 {
+		t.Fatalf("items = %d, want 3 (draft of post-1 dropped)", len(plan.Items))
+	}
+	p1 := plan.Items[1]
+	if p1.Key != "doc:post-1" || p1.Values["title"] != "Hello" || p1.Values["slug"] != "hello" || p1.Values["views"] != "42" {
+		t.Errorf("post-1 = %+v", p1)
+	}
+	if !reflect.DeepEqual(p1.Relations["author"], []string{"doc:author-1"}) {
+		t.Errorf("author relation = %v", p1.Relations["author"])
+	}
+	if !reflect.DeepEqual(p1.Media["main_image"], []string{"asset:image-abc-10x10-jpg"}) {
+		t.Errorf("main_image = %v", p1.Media["main_image"])
+	}
+	wantBody := `<h2>Intro</h2><p>Read <a href="https://example.com?a=1&amp;b=2"><strong>this</strong></a></p>`
+	if p1.Values["body"] != wantBody {
+		t.Errorf("body\n got %s\nwant %s", p1.Values["body"], wantBody)
+	}
+	if p1.Values["seo"] != `{"_type":"seo","metaTitle":"Hi"}` {
+		t.Errorf("seo = %s", p1.Values["seo"])
+	}
+	if plan.Items[2].Key != "doc:post-2" || plan.Items[2].Values["tags"] != `["a","b"]` {
+		t.Errorf("lone draft = %+v", plan.Items[2])
+	}
+
+	if len(plan.Assets) != 1 || plan.Assets[0].Path != "images/p/production/abc-10x10.jpg" || plan.Assets[0].Alt != "A photo" || plan.Assets[0].Title != "photo.jpg" {
+		t.Errorf("assets = %+v", plan.Assets)
+	}
+
+	unmapped := strings.Join(plan.Unmapped, "\n")
+	for _, want := range []string{`portable text type "youtube" (blogPost.body)`, `sanity object type "seo" (blogPost.seo)`} {
+		if !strings.Contains(unmapped, want) {
+			t.Errorf("unmapped %q missing %q", unmapped, want)
+		}
+	}
+}
+
+func TestParseSanityExport_Archive(t *testing.T) {
+	t.Parallel()
+	data := testTarGz(t, map[string]string{
+		"export/data.ndjson": `{"_id":"p1","_type":"page","title":"Home","hero":{"_type":"image","_sanityAsset":"image@file://./images/abc-10x10.jpg"}}` + "\n",
+		"export/assets.json": `{"image-abc-10x10-jpg":{"originalFilename":"hero.jpg","altText":"Hero"}}`,
+		"export/images/abc-10x10.jpg": "jpeg",
+	})
+	plan, err := ParseSanityExport(data)
+	if err != nil {
+		t.Fatalf("ParseSanityExport: %v", err)
+	}
+	if len(plan.Assets) != 1 {
+		t.Fatalf("assets = %+v", plan.Assets)
+	}
+	a := plan.Assets[0]
+	if string(a.Data) != "jpeg" || a.Path != "abc-10x10.jpg" || a.Alt != "Hero" || a.Title != "hero.jpg" {
+		t.Errorf("asset = %+v", a)
+	}
+	if !reflect.DeepEqual(plan.Items[0].Media["hero"], []string{a.Key}) {
+		t.Errorf("hero = %v, want %s", plan.Items[0].Media["hero"], a.Key)
+	}
+}
+
+func TestParseSanityExport_Invalid(t *testing.T) {
+	t.Parallel()
+	for name, input := range map[string]string{
+		"empty":      "",
+		"not json":   "not an export",
+		"missing id": `{"_type":"post"}`,
+	} {
+		if _, err := ParseSanityExport([]byte(input)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// testTarGz builds a gzip-compressed tar archive from path -> contents.
+func testTarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,518 @@
+package transform
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/model"
+)
+
+// strapiUploadFile is the content type of media library files.
+const strapiUploadFile = "plugin::upload.file"
+
+// Plan keys for Strapi exports.
+func strapiTypeKey(uid string) string          { return "type:" + uid }
+func strapiEntryKey(uid, id string) string     { return "entry:" + uid + ":" + id }
+func strapiFileKey(id string) string           { return "file:" + id }
+func strapiComponentKey(uid, id string) string { return "component:" + uid + ":" + id }
+
+// strapiSystemAttributes are maintained by Strapi and not imported.
+var strapiSystemAttributes = map[string]bool{
+	"createdAt":     true,
+	"updatedAt":     true,
+	"publishedAt":   true,
+	"createdBy":     true,
+	"updatedBy":     true,
+	"locale":        true,
+	"localizations": true,
+}
+
+// strapiFieldTypes maps Strapi scalar attribute types to field types.
+var strapiFieldTypes = map[string]types.FieldType{
+	"string":      types.FieldTypeText,
+	"text":        types.FieldTypeTextarea,
+	"richtext":    types.FieldTypeRichText,
+	"blocks":      types.FieldTypeRichText,
+	"email":       types.FieldTypeEmail,
+	"uid":         types.FieldTypeSlug,
+	"integer":     types.FieldTypeNumber,
+	"biginteger":  types.FieldTypeNumber,
+	"float":       types.FieldTypeNumber,
+	"decimal":     types.FieldTypeNumber,
+	"boolean":     types.FieldTypeBoolean,
+	"date":        types.FieldTypeDate,
+	"datetime":    types.FieldTypeDatetime,
+	"timestamp":   types.FieldTypeDatetime,
+	"time":        types.FieldTypeText,
+	"enumeration": types.FieldTypeSelect,
+	"json":        types.FieldTypeJSON,
+	"media":       types.FieldTypeMedia,
+	"relation":    types.FieldTypeIDRef,
+	"component":   types.FieldTypeJSON,
+	"dynamiczone": types.FieldTypeJSON,
+}
+
+// strapiSchema is one line of schemas/*.jsonl.
+type strapiSchema struct {
+	UID       string `json:"uid"`
+	ModelType string `json:"modelType"` // contentType or component
+	ModelName string `json:"modelName"`
+	Info      struct {
+		SingularName string `json:"singularName"`
+		PluralName   string `json:"pluralName"`
+		DisplayName  string `json:"displayName"`
+	} `json:"info"`
+	Attributes map[string]strapiAttribute `json:"attributes"`
+	order      []string
+}
+
+type strapiAttribute struct {
+	Type       string   `json:"type"`
+	Relation   string   `json:"relation"`
+	Target     string   `json:"target"`
+	MappedBy   string   `json:"mappedBy"`
+	Multiple   bool     `json:"multiple"`
+	Component  string   `json:"component"`
+	Repeatable bool     `json:"repeatable"`
+	Enum       []string `json:"enum"`
+}
+
+// strapiEntity is one line of entities/*.jsonl.
+type strapiEntity struct {
+	Type string          `json:"type"`
+	ID   json.RawMessage `json:"id"`
+	Data map[string]any  `json:"data"`
+}
+
+// strapiLink is one line of links/*.jsonl: a relation, media or component
+// attachment between two entities.
+type strapiLink struct {
+	Kind     string        `json:"kind"`
+	Relation string        `json:"relation"`
+	Left     strapiLinkEnd `json:"left"`
+	Right    strapiLinkEnd `json:"right"`
+}
+
+type strapiLinkEnd struct {
+	Type  string          `json:"type"`
+	Ref   json.RawMessage `json:"ref"`
+	Field string          `json:"field"`
+}
+
+// strapiExport is the state of one ParseStrapiExport call.
+type strapiExport struct {
+	plan       *ImportPlan
+	files      map[string][]byte
+	base       string
+	schemas    map[string]*strapiSchema
+	items      map[string]int                 // entry key -> index in plan.Items
+	components map[string]map[string]any      // component key -> data
+	attached   map[string]map[string][]string // owner key -> attribute -> component keys
+}
+
+// ParseStrapiExport parses the archive written by `strapi export` (Strapi v4,
+// created with --no-encrypt; compressed or not) into an ImportPlan.
+//
+// Every api:: content type becomes a type with a field per attribute:
+// relations to other content types become relation fields, media
+// attributes media fields whose files are imported from the archive,
+// Markdown richtext and blocks attributes richtext fields holding HTML,
+// enumerations select fields, and components and dynamic zones JSON fields
+// assembled from their component entities. Attributes with no equivalent
+// are skipped and listed in Unmapped.
+func ParseStrapiExport(data []byte) (*ImportPlan, error) {
+	files, err := readArchive(data)
+	if errors.Is(err, errNotArchive) {
+		return nil, fmt.Errorf("parse Strapi export: not a tar or tar.gz archive; encrypted exports are not supported, export with --no-encrypt")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse Strapi export: %w", err)
+	}
+	x := &strapiExport{
+		plan:       &ImportPlan{Source: "strapi", Title: "Strapi Export"},
+		files:      files,
+		schemas:    make(map[string]*strapiSchema),
+		items:      make(map[string]int),
+		components: make(map[string]map[string]any),
+		attached:   make(map[string]map[string][]string),
+	}
+	if name, _, ok := archiveFile(files, "metadata.json"); ok {
+		x.base = path.Dir(name)
+	}
+
+	var schemaOrder []string
+	if err := x.eachLine("schemas", func(raw []byte) error {
+		var s strapiSchema
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return err
+		}
+		var attrs struct {
+			Attributes json.RawMessage `json:"attributes"`
+		}
+		if err := json.Unmarshal(raw, &attrs); err == nil {
+			s.order = jsonKeys(attrs.Attributes)
+		}
+		x.schemas[s.UID] = &s
+		schemaOrder = append(schemaOrder, s.UID)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(x.schemas) == 0 {
+		return nil, fmt.Errorf("parse Strapi export: archive has no schemas")
+	}
+
+	for _, uid := range schemaOrder {
+		if s := x.schemas[uid]; s.ModelType == "contentType" && strings.HasPrefix(uid, "api::") {
+			x.plan.Types = append(x.plan.Types, x.importType(s))
+		}
+	}
+
+	if err := x.eachLine("entities", func(raw []byte) error {
+		var e strapiEntity
+		if err := json.Unmarshal(raw, &e); err != nil {
+			return err
+		}
+		x.entity(e)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := x.eachLine("links", func(raw []byte) error {
+		var l strapiLink
+		if err := json.Unmarshal(raw, &l); err != nil {
+			return err
+		}
+		x.link(l)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// Components are attached last, once every component entity is known.
+	for i := range x.plan.Items {
+		item := &x.plan.Items[i]
+		for attr, keys := range x.attached[item.Key] {
+			x.setComponents(item, attr, keys)
+		}
+	}
+	return x.plan, nil
+}
+
+// eachLine calls fn with every line of the JSONL files in dir, in file
+// order.
+func (x *strapiExport) eachLine(dir string, fn func(raw []byte) error) error {
+	prefix := path.Join(x.base, dir) + "/"
+	var names []string
+	for name := range x.files {
+		if strings.HasPrefix(name, prefix) && strings.HasSuffix(name, ".jsonl") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sc := bufio.NewScanner(bytes.NewReader(x.files[name]))
+		sc.Buffer(make([]byte, 0, 64*1024), 64<<20)
+		for line := 1; sc.Scan(); line++ {
+			raw := bytes.TrimSpace(sc.Bytes())
+			if len(raw) == 0 {
+				continue
+			}
+			if err := fn(raw); err != nil {
+				return fmt.Errorf("parse Strapi export: %s line %d: %w", name, line, err)
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return fmt.Errorf("parse Strapi export: %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// importType maps a content type schema onto a plan type.
+func (x *strapiExport) importType(s *strapiSchema) ImportType {
+	name := importName(s.Info.SingularName)
+	if name == "" {
+		name = importName(s.ModelName)
+	}
+	label := s.Info.DisplayName
+	if label == "" {
+		label = labelize(name)
+	}
+	plural := labelize(importName(s.Info.PluralName))
+	if plural == "" {
+		plural = labelize(pluralize(name))
+	}
+	t := ImportType{Key: strapiTypeKey(s.UID), Name: name, Label: label, Plural: plural}
+
+	titled := false
+	for _, attrName := range s.order {
+		attr := s.Attributes[attrName]
+		if strapiSystemAttributes[attrName] || attr.MappedBy != "" {
+			continue
+		}
+		fieldType, ok := strapiFieldTypes[attr.Type]
+		if !ok {
+			x.plan.unmapped("strapi attribute type %q (%s.%s) skipped", attr.Type, s.UID, attrName)
+			continue
+		}
+		fname := importName(attrName)
+		f := ImportField{Name: fname, Label: labelize(fname), Type: fieldType}
+		switch attr.Type {
+		case "string":
+			if !titled && (attrName == "title" || attrName == "name") {
+				f.Type = types.FieldTypeTitle
+				titled = true
+			}
+		case "enumeration":
+			f.Options = attr.Enum
+		case "media":
+			f.Multiple = attr.Multiple
+		case "relation":
+			if strings.HasPrefix(attr.Relation, "morph") || !strings.HasPrefix(attr.Target, "api::") {
+				x.plan.unmapped("strapi relation %s.%s to %q skipped", s.UID, attrName, attr.Target)
+				continue
+			}
+			f.Target = strapiTypeKey(attr.Target)
+			f.Multiple = strings.HasSuffix(attr.Relation, "ToMany")
+		case "component":
+			x.plan.unmapped("strapi component %q (%s.%s) stored as JSON", attr.Component, s.UID, attrName)
+		case "dynamiczone":
+			x.plan.unmapped("strapi dynamic zone %s.%s stored as JSON", s.UID, attrName)
+		}
+		t.Fields = append(t.Fields, f)
+	}
+	return t
+}
+
+// entity records a content entry, media file or component entity.
+func (x *strapiExport) entity(e strapiEntity) {
+	id := strapiRef(e.ID)
+	if e.Type == strapiUploadFile {
+		x.plan.Assets = append(x.plan.Assets, x.asset(id, e.Data))
+		return
+	}
+	s, ok := x.schemas[e.Type]
+	if !ok {
+		x.plan.unmapped("strapi entities of unknown type %q skipped", e.Type)
+		return
+	}
+	if s.ModelType == "component" {
+		x.components[strapiComponentKey(e.Type, id)] = e.Data
+		return
+	}
+	t, ok := x.plan.TypeByKey(strapiTypeKey(e.Type))
+	if !ok {
+		return
+	}
+
+	item := ImportItem{
+		Key:       strapiEntryKey(e.Type, id),
+		Type:      t.Key,
+		Values:    make(map[string]string),
+		Relations: make(map[string][]string),
+		Media:     make(map[string][]string),
+	}
+	for _, attrName := range s.order {
+		v, ok := e.Data[attrName]
+		if !ok || v == nil {
+			continue
+		}
+		f, ok := t.Field(importName(attrName))
+		if !ok {
+			continue
+		}
+		switch s.Attributes[attrName].Type {
+		case "relation", "media":
+			// Exports carry these as links.
+		case "richtext":
+			text, _ := v.(string)
+			item.Values[f.Name] = markdownToHTML(text)
+		case "blocks":
+			nodes, _ := v.([]any)
+			item.Values[f.Name] = strapiBlocksToHTML(nodes)
+		default:
+			item.Values[f.Name] = scalarValue(v)
+		}
+	}
+	x.items[item.Key] = len(x.plan.Items)
+	x.plan.Items = append(x.plan.Items, item)
+}
+
+// asset converts a media library file. Files bundled in the archive are
+// imported from it; otherwise the upload path is read from the uploads
+// directory.
+func (x *strapiExport) asset(id string, data map[string]any) ImportAsset {
+	str := func(k string) string { v, _ := data[k].(string); return v }
+	asset := ImportAsset{
+		Key:     strapiFileKey(id),
+		URL:     str("url"),
+		Title:   str("name"),
+		Alt:     str("alternativeText"),
+		Caption: str("caption"),
+	}
+	stored := str("hash") + str("ext")
+	if body, ok := x.files[path.Join(x.base, "assets", "uploads", stored)]; ok && stored != "" {
+		asset.Data = body
+		asset.Path = str("name")
+		if path.Ext(asset.Path) == "" {
+			asset.Path += str("ext")
+		}
+		return asset
+	}
+	asset.Path = strings.TrimPrefix(asset.URL, "/uploads/")
+	if strings.Contains(asset.Path, "://") {
+		asset.Path = ""
+	}
+	return asset
+}
+
+// link applies one link to the item that owns it.
+func (x *strapiExport) link(l strapiLink) {
+	owner, other := l.Left, l.Right
+	if owner.Type == strapiUploadFile {
+		owner, other = other, owner
+	}
+	if owner.Field == "" {
+		return
+	}
+	ownerID := strapiRef(owner.Ref)
+
+	if s, ok := x.schemas[owner.Type]; ok && s.ModelType == "component" {
+		if other.Type != strapiUploadFile && x.isComponent(other.Type) {
+			x.attach(strapiComponentKey(owner.Type, ownerID), owner.Field, other)
+		}
+		return
+	}
+	idx, ok := x.items[strapiEntryKey(owner.Type, ownerID)]
+	if !ok {
+		return
+	}
+	item := &x.plan.Items[idx]
+	t, _ := x.plan.TypeByKey(item.Type)
+	f, ok := t.Field(importName(owner.Field))
+	if !ok {
+		return
+	}
+
+	otherID := strapiRef(other.Ref)
+	switch {
+	case other.Type == strapiUploadFile:
+		if f.Type == types.FieldTypeMedia {
+			item.Media[f.Name] = append(item.Media[f.Name], strapiFileKey(otherID))
+		}
+	case x.isComponent(other.Type):
+		x.attach(item.Key, owner.Field, other)
+	case f.Type == types.FieldTypeIDRef:
+		item.Relations[f.Name] = append(item.Relations[f.Name], strapiEntryKey(other.Type, otherID))
+	}
+}
+
+func (x *strapiExport) isComponent(uid string) bool {
+	s, ok := x.schemas[uid]
+	return ok && s.ModelType == "component"
+}
+
+func (x *strapiExport) attach(ownerKey, attr string, component strapiLinkEnd) {
+	if x.attached[ownerKey] == nil {
+		x.attached[ownerKey] = make(map[string][]string)
+	}
+	x.attached[ownerKey][attr] = append(x.attached[ownerKey][attr], strapiComponentKey(component.Type, strapiRef(component.Ref)))
+}
+
+// setComponents writes a component or dynamic zone attribute as JSON.
+func (x *strapiExport) setComponents(item *ImportItem, attr string, keys []string) {
+	s := x.schemas[strings.TrimPrefix(item.Type, "type:")]
+	t, _ := x.plan.TypeByKey(item.Type)
+	f, ok := t.Field(importName(attr))
+	if !ok || s == nil {
+		return
+	}
+	def := s.Attributes[attr]
+	values := make([]any, 0, len(keys))
+	for _, key := range keys {
+		if v := x.componentValue(key, def.Type == "dynamiczone", 0); v != nil {
+			values = append(values, v)
+		}
+	}
+	var v any = values
+	if def.Type == "component" && !def.Repeatable {
+		if len(values) == 0 {
+			return
+		}
+		v = values[0]
+	}
+	item.Values[f.Name] = scalarValue(v)
+}
+
+// componentValue returns a component entity's data with its own nested
+// components filled in. Dynamic zone entries are tagged with __component.
+func (x *strapiExport) componentValue(key string, tagged bool, depth int) map[string]any {
+	data, ok := x.components[key]
+	if !ok || depth > 10 {
+		return nil
+	}
+	uid, _, _ := strings.Cut(strings.TrimPrefix(key, "component:"), ":")
+	out := make(map[string]any, len(data)+1)
+	for k, v := range data {
+		if k != "id" {
+			out[k] = v
+		}
+	}
+	if tagged {
+		out["__component"] = uid
+	}
+	s := x.schemas[uid]
+	for attr, keys := range x.attached[key] {
+		def := s.Attributes[attr]
+		nested := make([]any, 0, len(keys))
+		for _, k := range keys {
+			if v := x.componentValue(k, def.Type == "dynamiczone", depth+1); v != nil {
+				nested = append(nested, v)
+			}
+		}
+		if def.Type == "component" && !def.Repeatable && len(nested) > 0 {
+			out[attr] = nested[0]
+		} else {
+			out[attr] = nested
+		}
+	}
+	return out
+}
+
+// strapiRef renders an entity ID, which exports write as a number or string.
+func strapiRef(raw json.RawMessage) string {
+	s := strings.TrimSpace(string(raw))
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		return unquoted
+	}
+	return s
+}
+
+// Parse converts a Strapi export archive to a ModulaCMS tree (INBOUND). See
+// ParseStrapiExport.
+func (s *StrapiTransformer) Parse(data []byte) (model.Root, error) {
+	plan, err := ParseStrapiExport(data)
+	if err != nil {
+		return model.Root{}, err
+	}
+	return plan.ToRoot(), nil
+}
+
+// ParseToNode converts a Strapi export archive to a ModulaCMS Node (INBOUND).
+func (s *StrapiTransformer) ParseToNode(data []byte) (*model.Node, error) {
+	root, err := s.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return root.Node, nil
+}
@@ -0,0 +1,133 @@
+package transform
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// testStrapiArchive is a Strapi v4 export with an article type related to
+// an author, a cover image bundled in the archive, a repeatable component
+// and attributes without a field type equivalent.
+func testStrapiArchive(t *testing.T) []byte {
+	return testTarGz(t, map[string]string{
+		"metadata.json": `{"strapi":{"version":"4.15.0"}}`,
+		"schemas/schemas_00001.jsonl": strings.Join([]string{
+			`{"uid":"api::article.article","modelType":"contentType","kind":"collectionType","modelName":"article","info":{"singularName":"article","pluralName":"articles","displayName":"Article"},"attributes":{"title":{"type":"string"},"slug":{"type":"uid","targetField":"title"},"body":{"type":"richtext"},"summary":{"type":"blocks"},"status":{"type":"enumeration","enum":["draft","live"]},"cover":{"type":"media","multiple":false},"author":{"type":"relation","relation":"manyToOne","target":"api::author.author","inversedBy":"articles"},"owner":{"type":"relation","relation":"oneToOne","target":"admin::user"},"quotes":{"type":"component","component":"shared.quote","repeatable":true},"secret":{"type":"password"},"createdAt":{"type":"datetime"}}}`,
+			`{"uid":"api::author.author","modelType":"contentType","kind":"collectionType","modelName":"author","info":{"singularName":"author","pluralName":"authors","displayName":"Author"},"attributes":{"name":{"type":"string"},"articles":{"type":"relation","relation":"oneToMany","target":"api::article.article","mappedBy":"author"}}}`,
+			`{"uid":"shared.quote","modelType":"component","modelName":"quote","info":{"displayName":"Quote"},"attributes":{"text":{"type":"text"}}}`,
+			`{"uid":"plugin::upload.file","modelType":"contentType","modelName":"file","info":{"singularName":"file"},"attributes":{"name":{"type":"string"}}}`,
+		}, "\n"),
+		"entities/entities_00001.jsonl": strings.Join([]string{
+			`{"type":"api::article.article","id":1,"data":{"id":1,"title":"Hello","slug":"hello","body":"# Heading\n\nSome **bold** text with [a link](https://example.com/a_b_c).\n\n- one\n- two","summary":[{"type":"paragraph","children":[{"type":"text","text":"Short","italic":true}]}],"status":"live","secret":"x","createdAt":"2024-01-01T00:00:00.000Z"}}`,
+			`{"type":"api::author.author","id":2,"data":{"id":2,"name":"Ada"}}`,
+			`{"type":"shared.quote","id":5,"data":{"id":5,"text":"First"}}`,
+			`{"type":"shared.quote","id":6,"data":{"id":6,"text":"Second"}}`,
+			`{"type":"plugin::upload.file","id":3,"data":{"id":3,"name":"cover.png","alternativeText":"Cover","hash":"cover_abc","ext":".png","url":"/uploads/cover_abc.png"}}`,
+			`{"type":"plugin::users-permissions.user","id":9,"data":{"username":"x"}}`,
+		}, "\n"),
+		"links/links_00001.jsonl": strings.Join([]string{
+			`{"kind":"relation.basic","relation":"manyToOne","left":{"type":"api::article.article","ref":1,"field":"author"},"right":{"type":"api::author.author","ref":2,"field":"articles"}}`,
+			`{"kind":"relation.morph","relation":"morphToMany","left":{"type":"plugin::upload.file","ref":3,"field":"related"},"right":{"type":"api::article.article","ref":1,"field":"cover"}}`,
+			`{"kind":"relation.basic","relation":"oneToMany","left":{"type":"api::article.article","ref":1,"field":"quotes"},"right":{"type":"shared.quote","ref":5}}`,
+			`{"kind":"relation.basic","relation":"oneToMany","left":{"type":"api::article.article","ref":1,"field":"quotes"},"right":{"type":"shared.quote","ref":6}}`,
+		}, "\n"),
+		"assets/uploads/cover_abc.png": "png",
+	})
+}
+
+func TestParseStrapiExport(t *testing.T) {
+	t.Parallel()
+	plan, err := ParseStrapiExport(testStrapiArchive(t))
+	if err != nil {
+		t.Fatalf("ParseStrapiExport: %v", err)
+	}
+
+	if len(plan.Types) != 2 {
+		t.Fatalf("types = %+v", plan.Types)
+	}
+	article := plan.Types[0]
+	if article.Name != "article" || article.Label != "Article" || article.Plural != "Articles" {
+		t.Errorf("article type = %+v", article)
+	}
+	var got []string
+	for _, f := range article.Fields {
+		got = append(got, f.Name+":"+string(f.Type))
+	}
+	want := []string{"title:_title", "slug:slug", "body:richtext", "summary:richtext", "status:select", "cover:media", "author:_id", "quotes:json"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("article fields\n got %v\nwant %v", got, want)
+	}
+	if f, _ := article.Field("author"); f.Target != "type:api::author.author" || f.Multiple {
+		t.Errorf("author field = %+v", f)
+	}
+	if f, _ := article.Field("status"); !reflect.DeepEqual(f.Options, []string{"draft", "live"}) {
+		t.Errorf("status options = %v", f.Options)
+	}
+	if len(plan.Types[1].Fields) != 1 {
+		t.Errorf("author fields = %+v, inverse relation should be skipped", plan.Types[1].Fields)
+	}
+
+	if len(plan.Items) != 2 {
+		t.Fatalf("items = %+v", plan.Items)
+	}
+	item := plan.Items[0]
+	wantBody := `<h1>Heading</h1><p>Some <strong>bold</strong> text with <a href="https://example.com/a_b_c">a link</a>.</p><ul><li>one</li><li>two</li></ul>`
+	if item.Values["body"] != wantBody {
+		t.Errorf("body\n got %s\nwant %s", item.Values["body"], wantBody)
+	}
+	if item.Values["summary"] != "<p><em>Short</em></p>" {
+		t.Errorf("summary = %s", item.Values["summary"])
+	}
+	if _, ok := item.Values["secret"]; ok {
+		t.Error("password attribute imported")
+	}
+	if !reflect.DeepEqual(item.Relations["author"], []string{"entry:api::author.author:2"}) {
+		t.Errorf("author relation = %v", item.Relations)
+	}
+	if !reflect.DeepEqual(item.Media["cover"], []string{"file:3"}) {
+		t.Errorf("cover = %v", item.Media)
+	}
+	if item.Values["quotes"] != `[{"text":"First"},{"text":"Second"}]` {
+		t.Errorf("quotes = %s", item.Values["quotes"])
+	}
+
+	if len(plan.Assets) != 1 || string(plan.Assets[0].Data) != "png" || plan.Assets[0].Path != "cover.png" || plan.Assets[0].Alt != "Cover" {
+		t.Errorf("assets = %+v", plan.Assets)
+	}
+
+	unmapped := strings.Join(plan.Unmapped, "\n")
+	for _, want := range []string{
+		`strapi attribute type "password" (api::article.article.secret) skipped`,
+		`strapi relation api::article.article.owner to "admin::user" skipped`,
+		`strapi component "shared.quote" (api::article.article.quotes) stored as JSON`,
+		`strapi entities of unknown type "plugin::users-permissions.user" skipped`,
+	} {
+		if !strings.Contains(unmapped, want) {
+			t.Errorf("unmapped missing %q in\n%s", want, unmapped)
+		}
+	}
+}
+
+func TestParseStrapiExport_Invalid(t *testing.T) {
+	t.Parallel()
+	if _, err := ParseStrapiExport([]byte(`{"data":[]}`)); err == nil || !strings.Contains(err.Error(), "--no-encrypt") {
+		t.Errorf("non-archive error = %v", err)
+	}
+	if _, err := ParseStrapiExport(testTarGz(t, map[string]string{"metadata.json": "{}"})); err == nil {
+		t.Error("archive without schemas: expected error")
+	}
+}
+
+func TestStrapiTransformer_Parse(t *testing.T) {
+	t.Parallel()
+	root, err := (&StrapiTransformer{}).Parse(testStrapiArchive(t))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if root.Node == nil || root.Node.Datatype.Info.Type != string(types.DatatypeTypeRoot) || len(root.Node.Nodes) != 2 {
+		t.Fatalf("root = %+v", root.Node)
+	}
+}
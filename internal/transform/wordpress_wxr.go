@@ -0,0 +1,814 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/model"
+)
+
+// WordPress eXtended RSS (WXR) is the XML format written by Tools > Export.
+// Element names are matched by local name so exports from every WXR version
+// (1.0-1.2) parse the same way; the only clash, content:encoded vs
+// excerpt:encoded, is resolved by namespace.
+
+type wxrRSS struct {
+	Channel *wxrChannel `xml:"channel"`
+}
+
+type wxrChannel struct {
+	Title      string        `xml:"title"`
+	Categories []wxrCategory `xml:"category"`
+	Tags       []wxrTag      `xml:"tag"`
+	Terms      []wxrTerm     `xml:"term"`
+	Items      []wxrItem     `xml:"item"`
+}
+
+type wxrCategory struct {
+	TermID      string `xml:"term_id"`
+	Nicename    string `xml:"category_nicename"`
+	Parent      string `xml:"category_parent"`
+	Name        string `xml:"cat_name"`
+	Description string `xml:"category_description"`
+}
+
+type wxrTag struct {
+	TermID      string `xml:"term_id"`
+	Slug        string `xml:"tag_slug"`
+	Name        string `xml:"tag_name"`
+	Description string `xml:"tag_description"`
+}
+
+type wxrTerm struct {
+	TermID      string `xml:"term_id"`
+	Taxonomy    string `xml:"term_taxonomy"`
+	Slug        string `xml:"term_slug"`
+	Parent      string `xml:"term_parent"`
+	Name        string `xml:"term_name"`
+	Description string `xml:"term_description"`
+}
+
+type wxrItem struct {
+	Title         string            `xml:"title"`
+	Creator       string            `xml:"creator"`
+	Encoded       []wxrEncoded      `xml:"encoded"`
+	PostID        string            `xml:"post_id"`
+	PostDate      string            `xml:"post_date"`
+	PostDateGMT   string            `xml:"post_date_gmt"`
+	PostName      string            `xml:"post_name"`
+	Status        string            `xml:"status"`
+	PostParent    string            `xml:"post_parent"`
+	MenuOrder     int               `xml:"menu_order"`
+	PostType      string            `xml:"post_type"`
+	AttachmentURL string            `xml:"attachment_url"`
+	Categories    []wxrItemCategory `xml:"category"`
+	Meta          []wxrMeta         `xml:"postmeta"`
+}
+
+type wxrEncoded struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type wxrItemCategory struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type wxrMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+// content returns content:encoded.
+func (it *wxrItem) content() string {
+	for _, e := range it.Encoded {
+		if !strings.Contains(e.XMLName.Space, "excerpt") {
+			return e.Value
+		}
+	}
+	return ""
+}
+
+// excerpt returns excerpt:encoded.
+func (it *wxrItem) excerpt() string {
+	for _, e := range it.Encoded {
+		if strings.Contains(e.XMLName.Space, "excerpt") {
+			return e.Value
+		}
+	}
+	return ""
+}
+
+// meta returns the post meta as a map. The first value wins for repeated keys.
+func (it *wxrItem) meta() map[string]string {
+	m := make(map[string]string, len(it.Meta))
+	for _, pm := range it.Meta {
+		if _, ok := m[pm.Key]; !ok {
+			m[pm.Key] = pm.Value
+		}
+	}
+	return m
+}
+
+// wxrSkippedPostTypes are WordPress internals that are not content.
+// Attachments are handled separately as media assets.
+var wxrSkippedPostTypes = map[string]bool{
+	"attachment":          true,
+	"revision":            true,
+	"nav_menu_item":       true,
+	"customize_changeset": true,
+	"custom_css":          true,
+	"oembed_cache":        true,
+	"user_request":        true,
+	"wp_template":         true,
+	"wp_template_part":    true,
+	"wp_global_styles":    true,
+	"wp_navigation":       true,
+	"wp_font_family":      true,
+	"wp_font_face":        true,
+	"acf-field-group":     true,
+	"acf-field":           true,
+	"acf-post-type":       true,
+	"acf-taxonomy":        true,
+	"acf-ui-options-page": true,
+}
+
+// wxrSkippedTaxonomies are taxonomies WordPress uses for its own bookkeeping.
+var wxrSkippedTaxonomies = map[string]bool{
+	"nav_menu":              true,
+	"post_format":           true,
+	"link_category":         true,
+	"wp_theme":              true,
+	"wp_template_part_area": true,
+	"wp_pattern_category":   true,
+}
+
+// wxrSkippedStatuses are post statuses that never represent live content.
+var wxrSkippedStatuses = map[string]bool{
+	"auto-draft": true,
+	"trash":      true,
+	"inherit":    true,
+}
+
+// Plan type and item keys. WordPress post IDs are unique across post types,
+// so posts and attachments share the post: namespace.
+func wxrPostKey(id string) string           { return "post:" + id }
+func wxrPostTypeKey(postType string) string { return "post_type:" + postType }
+func wxrTaxonomyKey(taxonomy string) string { return "taxonomy:" + taxonomy }
+func wxrTermKey(taxonomy, slug string) string {
+	return "term:" + taxonomy + ":" + slug
+}
+
+// acfDef is an ACF field definition read from an acf-field post.
+type acfDef struct {
+	key      string // field_...
+	name     string
+	label    string
+	typ      string
+	settings map[string]any
+	parentID string
+	order    int
+	children []*acfDef
+}
+
+// setting returns a string setting of the field definition.
+func (d *acfDef) setting(name string) string {
+	if d == nil || d.settings == nil {
+		return ""
+	}
+	switch v := d.settings[name].(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	}
+	return ""
+}
+
+// wxrTypeState collects the fields a post type or taxonomy needs while its
+// items are read.
+type wxrTypeState struct {
+	typ      ImportType
+	fields   map[string]bool
+	acfNames map[string]string // ACF field name -> plan field name
+}
+
+func (s *wxrTypeState) addField(f ImportField) {
+	if s.fields[f.Name] {
+		return
+	}
+	s.fields[f.Name] = true
+	s.typ.Fields = append(s.typ.Fields, f)
+}
+
+// ParseWXR parses a WordPress WXR export into an ImportPlan.
+//
+// Every public post type (posts, pages and custom post types) becomes a type
+// with title, slug, content, excerpt, published_date, author and published
+// fields, plus featured_image when any item has one. Categories, tags and
+// custom taxonomies become types of their own; the terms assigned to an item
+// become relation fields (categories, tags, or the taxonomy name). ACF values
+// become fields typed from their acf-field definitions when the export
+// includes them, and as text otherwise. Page and term parents are kept as
+// ParentKey. Attachments become assets located by _wp_attached_file.
+func ParseWXR(data []byte) (*ImportPlan, error) {
+	var rss wxrRSS
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+	if err := dec.Decode(&rss); err != nil {
+		return nil, fmt.Errorf("parse WXR: %w", err)
+	}
+	if rss.Channel == nil {
+		return nil, fmt.Errorf("parse WXR: no <channel> element")
+	}
+	ch := rss.Channel
+
+	plan := &ImportPlan{Source: "wordpress", Title: strings.TrimSpace(ch.Title)}
+	if plan.Title == "" {
+		plan.Title = "WordPress Site"
+	}
+
+	acf := wxrACFDefinitions(ch.Items)
+
+	// Attachments become assets.
+	for i := range ch.Items {
+		it := &ch.Items[i]
+		if it.PostType != "attachment" {
+			continue
+		}
+		meta := it.meta()
+		plan.Assets = append(plan.Assets, ImportAsset{
+			Key:         wxrPostKey(it.PostID),
+			Path:        wxrAttachmentPath(meta["_wp_attached_file"], it.AttachmentURL),
+			URL:         it.AttachmentURL,
+			Title:       it.Title,
+			Alt:         meta["_wp_attachment_image_alt"],
+			Caption:     it.excerpt(),
+			Description: it.content(),
+		})
+	}
+
+	var order []string
+	states := make(map[string]*wxrTypeState)
+	state := func(key, name, label string, fields ...ImportField) *wxrTypeState {
+		if s, ok := states[key]; ok {
+			return s
+		}
+		s := &wxrTypeState{
+			typ:      ImportType{Key: key, Name: name, Label: label, Plural: labelize(pluralize(name))},
+			fields:   make(map[string]bool),
+			acfNames: make(map[string]string),
+		}
+		for _, f := range fields {
+			s.addField(f)
+		}
+		states[key] = s
+		order = append(order, key)
+		return s
+	}
+
+	// Terms: channel declarations first, then any only referenced by items.
+	termIDs := make(map[string]string) // term_id -> item key
+	var termItems []ImportItem
+	seenTerms := make(map[string]bool)
+	addTerm := func(taxonomy, id, slug, name, description, parentSlug string) {
+		if taxonomy == "" || slug == "" || wxrSkippedTaxonomies[taxonomy] {
+			return
+		}
+		key := wxrTermKey(taxonomy, slug)
+		if id != "" {
+			termIDs[id] = key
+		}
+		if seenTerms[key] {
+			return
+		}
+		seenTerms[key] = true
+		name = strings.TrimSpace(name)
+		if name == "" {
+			name = slug
+		}
+		typeName, label := wxrTaxonomyNames(taxonomy)
+		state(wxrTaxonomyKey(taxonomy), typeName, label,
+			ImportField{Name: "title", Label: "Title", Type: types.FieldTypeTitle},
+			ImportField{Name: "slug", Label: "Slug", Type: types.FieldTypeSlug},
+			ImportField{Name: "description", Label: "Description", Type: types.FieldTypeTextarea},
+		)
+		item := ImportItem{
+			Key:  key,
+			Type: wxrTaxonomyKey(taxonomy),
+			Values: map[string]string{
+				"title":       name,
+				"slug":        slug,
+				"description": description,
+			},
+		}
+		if parentSlug != "" {
+			item.ParentKey = wxrTermKey(taxonomy, parentSlug)
+		}
+		termItems = append(termItems, item)
+	}
+	for _, c := range ch.Categories {
+		addTerm("category", c.TermID, c.Nicename, c.Name, c.Description, c.Parent)
+	}
+	for _, t := range ch.Tags {
+		addTerm("post_tag", t.TermID, t.Slug, t.Name, t.Description, "")
+	}
+	for _, t := range ch.Terms {
+		addTerm(t.Taxonomy, t.TermID, t.Slug, t.Name, t.Description, t.Parent)
+	}
+
+	// Content items, ordered by menu_order so page siblings keep their order.
+	var posts []*wxrItem
+	for i := range ch.Items {
+		it := &ch.Items[i]
+		if it.PostType == "" || wxrSkippedPostTypes[it.PostType] || wxrSkippedStatuses[it.Status] {
+			continue
+		}
+		posts = append(posts, it)
+		for _, c := range it.Categories {
+			addTerm(c.Domain, "", c.Nicename, c.Name, "", "")
+		}
+	}
+	sort.SliceStable(posts, func(i, j int) bool { return posts[i].MenuOrder < posts[j].MenuOrder })
+
+	for _, it := range posts {
+		typeName := importName(it.PostType)
+		s := state(wxrPostTypeKey(it.PostType), typeName, labelize(it.PostType),
+			ImportField{Name: "title", Label: "Title", Type: types.FieldTypeTitle},
+			ImportField{Name: "slug", Label: "Slug", Type: types.FieldTypeSlug},
+			ImportField{Name: "content", Label: "Content", Type: types.FieldTypeRichText},
+			ImportField{Name: "excerpt", Label: "Excerpt", Type: types.FieldTypeTextarea},
+			ImportField{Name: "published_date", Label: "Published Date", Type: types.FieldTypeDatetime},
+			ImportField{Name: "author", Label: "Author", Type: types.FieldTypeText},
+			ImportField{Name: "published", Label: "Published", Type: types.FieldTypeBoolean},
+		)
+
+		slug := it.PostName
+		if slug == "" {
+			slug = (&WordPressTransformer{}).generateSlug(it.Title)
+		}
+		published := "false"
+		if it.Status == "publish" {
+			published = "true"
+		}
+		item := ImportItem{
+			Key:  wxrPostKey(it.PostID),
+			Type: s.typ.Key,
+			Values: map[string]string{
+				"title":          it.Title,
+				"slug":           slug,
+				"content":        it.content(),
+				"excerpt":        it.excerpt(),
+				"published_date": wxrDate(it.PostDateGMT, it.PostDate),
+				"author":         it.Creator,
+				"published":      published,
+			},
+			Relations: make(map[string][]string),
+			Media:     make(map[string][]string),
+		}
+		if it.PostParent != "" && it.PostParent != "0" {
+			item.ParentKey = wxrPostKey(it.PostParent)
+		}
+
+		meta := it.meta()
+		if thumb := meta["_thumbnail_id"]; thumb != "" && thumb != "0" {
+			s.addField(ImportField{Name: "featured_image", Label: "Featured Image", Type: types.FieldTypeMedia})
+			item.Media["featured_image"] = []string{wxrPostKey(thumb)}
+		}
+
+		for _, c := range it.Categories {
+			if c.Nicename == "" || wxrSkippedTaxonomies[c.Domain] {
+				continue
+			}
+			name, label := wxrTaxonomyFieldNames(c.Domain)
+			s.addField(ImportField{Name: name, Label: label, Type: types.FieldTypeIDRef, Target: wxrTaxonomyKey(c.Domain), Multiple: true})
+			item.Relations[name] = append(item.Relations[name], wxrTermKey(c.Domain, c.Nicename))
+		}
+
+		acf.apply(s, &item, meta, termIDs)
+		plan.Items = append(plan.Items, item)
+	}
+	plan.Items = append(plan.Items, termItems...)
+
+	for _, key := range order {
+		plan.Types = append(plan.Types, states[key].typ)
+	}
+	return plan, nil
+}
+
+// Parse converts a WXR export to a ModulaCMS tree (INBOUND). See ParseWXR.
+func (w *WordPressTransformer) Parse(data []byte) (model.Root, error) {
+	plan, err := ParseWXR(data)
+	if err != nil {
+		return model.Root{}, err
+	}
+	return plan.ToRoot(), nil
+}
+
+// ParseToNode converts a WXR export to a ModulaCMS Node (INBOUND).
+func (w *WordPressTransformer) ParseToNode(data []byte) (*model.Node, error) {
+	root, err := w.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return root.Node, nil
+}
+
+// wxrAttachmentPath returns an attachment's path relative to the uploads
+// directory, preferring _wp_attached_file over the URL.
+func wxrAttachmentPath(attached, url string) string {
+	if attached != "" {
+		return strings.TrimLeft(attached, "/")
+	}
+	if _, rest, ok := strings.Cut(url, "/uploads/"); ok {
+		return rest
+	}
+	return ""
+}
+
+// wxrDate converts a WordPress "2006-01-02 15:04:05" timestamp to RFC 3339.
+// The GMT column is used when set; drafts leave it zeroed, in which case the
+// local post date is used as-is.
+func wxrDate(gmt, local string) string {
+	for _, v := range []string{gmt, local} {
+		if v == "" || strings.HasPrefix(v, "0000-00-00") {
+			continue
+		}
+		if t, err := time.Parse("2006-01-02 15:04:05", v); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return ""
+}
+
+// wxrTaxonomyNames returns the datatype name and label for a taxonomy.
+func wxrTaxonomyNames(taxonomy string) (string, string) {
+	switch taxonomy {
+	case "category":
+		return "category", "Category"
+	case "post_tag":
+		return "tag", "Tag"
+	}
+	return importName(taxonomy), labelize(taxonomy)
+}
+
+// wxrTaxonomyFieldNames returns the relation field name and label used on
+// post types for a taxonomy.
+func wxrTaxonomyFieldNames(taxonomy string) (string, string) {
+	switch taxonomy {
+	case "category":
+		return "categories", "Categories"
+	case "post_tag":
+		return "tags", "Tags"
+	}
+	return importName(taxonomy), labelize(taxonomy)
+}
+
+// acfSet holds the ACF field definitions of an export, keyed by field key.
+type acfSet struct {
+	defs   map[string]*acfDef
+	byPost map[string]*acfDef // by acf-field post ID
+}
+
+// wxrACFDefinitions reads acf-field posts. ACF stores the field name in the
+// excerpt, the label in the title and the settings as a serialized array in
+// the content; sub fields of repeaters, groups and flexible content have the
+// parent field's post as their parent.
+func wxrACFDefinitions(items []wxrItem) *acfSet {
+	set := &acfSet{
+		defs:   make(map[string]*acfDef),
+		byPost: make(map[string]*acfDef),
+	}
+	for i := range items {
+		it := &items[i]
+		if it.PostType != "acf-field" {
+			continue
+		}
+		def := &acfDef{
+			key:      it.PostName,
+			name:     it.excerpt(),
+			label:    it.Title,
+			parentID: it.PostParent,
+			order:    it.MenuOrder,
+		}
+		if v, err := phpUnserialize(it.content()); err == nil {
+			if m, ok := v.(map[string]any); ok {
+				def.settings = m
+				def.typ = def.setting("type")
+			}
+		}
+		set.defs[def.key] = def
+		set.byPost[it.PostID] = def
+	}
+	for _, def := range set.byPost {
+		if parent, ok := set.byPost[def.parentID]; ok {
+			parent.children = append(parent.children, def)
+		}
+	}
+	for _, def := range set.byPost {
+		sort.SliceStable(def.children, func(i, j int) bool { return def.children[i].order < def.children[j].order })
+	}
+	return set
+}
+
+// apply adds the item's ACF values to the item and their fields to the type.
+// A meta key K is an ACF value when meta "_K" holds its field key. Sub field
+// values are folded into their repeater, group or flexible content parent.
+func (a *acfSet) apply(s *wxrTypeState, item *ImportItem, meta map[string]string, termIDs map[string]string) {
+	names := make([]string, 0, len(meta))
+	for k := range meta {
+		if !strings.HasPrefix(k, "_") && strings.HasPrefix(meta["_"+k], "field_") {
+			names = append(names, k)
+		}
+	}
+	rank := func(name string) int {
+		if def := a.defs[meta["_"+name]]; def != nil {
+			return def.order
+		}
+		return math.MaxInt
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if ri, rj := rank(names[i]), rank(names[j]); ri != rj {
+			return ri < rj
+		}
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		def := a.defs[meta["_"+name]]
+		if def != nil && a.byPost[def.parentID] != nil {
+			continue // sub field, folded into its parent
+		}
+		// ACF fields that clash with a built-in field get an acf_ prefix.
+		fieldName, ok := s.acfNames[name]
+		if !ok {
+			fieldName = name
+			if _, taken := s.typ.Field(name); taken {
+				fieldName = "acf_" + name
+			}
+			s.acfNames[name] = fieldName
+		}
+
+		raw := meta[name]
+		field := ImportField{Name: fieldName, Label: labelize(name), Type: types.FieldTypeText}
+		if def != nil && def.label != "" {
+			field.Label = def.label
+		}
+		if def == nil {
+			if isPHPSerialized(raw) {
+				field.Type = types.FieldTypeJSON
+				item.Values[fieldName] = acfJSON(raw)
+			} else {
+				item.Values[fieldName] = raw
+			}
+			s.addField(field)
+			continue
+		}
+
+		switch def.typ {
+		case "textarea":
+			field.Type = types.FieldTypeTextarea
+			item.Values[fieldName] = raw
+		case "wysiwyg":
+			field.Type = types.FieldTypeRichText
+			item.Values[fieldName] = raw
+		case "number", "range":
+			field.Type = types.FieldTypeNumber
+			item.Values[fieldName] = raw
+		case "email":
+			field.Type = types.FieldTypeEmail
+			item.Values[fieldName] = raw
+		case "url":
+			field.Type = types.FieldTypeURL
+			item.Values[fieldName] = raw
+		case "true_false":
+			field.Type = types.FieldTypeBoolean
+			item.Values[fieldName] = strconv.FormatBool(raw == "1")
+		case "date_picker":
+			field.Type = types.FieldTypeDate
+			item.Values[fieldName] = acfDate(raw)
+		case "date_time_picker":
+			field.Type = types.FieldTypeDatetime
+			item.Values[fieldName] = wxrDate(raw, "")
+		case "select", "radio", "button_group":
+			if isPHPSerialized(raw) {
+				field.Type = types.FieldTypeJSON
+				item.Values[fieldName] = acfJSON(raw)
+			} else {
+				field.Type = types.FieldTypeSelect
+				item.Values[fieldName] = raw
+			}
+		case "image", "file":
+			field.Type = types.FieldTypeMedia
+			if raw != "" {
+				item.Media[fieldName] = []string{wxrPostKey(raw)}
+			}
+		case "gallery":
+			field.Type = types.FieldTypeJSON
+			field.Multiple = true
+			item.Media[fieldName] = acfRefs(raw, wxrPostKey)
+		case "post_object", "relationship":
+			field.Type = types.FieldTypeIDRef
+			field.Multiple = def.typ == "relationship" || def.setting("multiple") == "1"
+			if pts := phpList(def.settings["post_type"]); len(pts) == 1 {
+				field.Target = wxrPostTypeKey(fmt.Sprint(pts[0]))
+			}
+			item.Relations[fieldName] = acfRefs(raw, wxrPostKey)
+		case "taxonomy":
+			field.Type = types.FieldTypeIDRef
+			field.Multiple = true
+			taxonomy := def.setting("taxonomy")
+			if taxonomy != "" {
+				field.Target = wxrTaxonomyKey(taxonomy)
+			}
+			item.Relations[fieldName] = acfRefs(raw, func(id string) string {
+				if key, ok := termIDs[id]; ok {
+					return key
+				}
+				return "term_id:" + id
+			})
+		case "repeater", "group", "flexible_content":
+			field.Type = types.FieldTypeJSON
+			out, _ := json.Marshal(a.value(meta, name, def))
+			item.Values[fieldName] = string(out)
+		default:
+			if isPHPSerialized(raw) {
+				field.Type = types.FieldTypeJSON
+				item.Values[fieldName] = acfJSON(raw)
+			} else {
+				item.Values[fieldName] = raw
+			}
+		}
+		s.addField(field)
+	}
+}
+
+// value assembles the JSON value of a (possibly nested) ACF field. ACF flattens
+// repeater rows into meta keys "<name>_<row>_<sub>", group members into
+// "<name>_<sub>", and stores the row count (repeater) or layout list
+// (flexible content) under the field's own key.
+func (a *acfSet) value(meta map[string]string, key string, def *acfDef) any {
+	switch def.typ {
+	case "repeater", "flexible_content":
+		var layouts []any
+		rows, _ := strconv.Atoi(meta[key])
+		if def.typ == "flexible_content" {
+			if v, err := phpUnserialize(meta[key]); err == nil {
+				layouts = phpList(v)
+			}
+			rows = len(layouts)
+		}
+		out := make([]any, 0, rows)
+		for i := range rows {
+			row := make(map[string]any)
+			if layouts != nil {
+				row["acf_fc_layout"] = layouts[i]
+			}
+			for _, c := range def.children {
+				sub := fmt.Sprintf("%s_%d_%s", key, i, c.name)
+				if _, ok := meta[sub]; ok {
+					row[c.name] = a.value(meta, sub, c)
+				}
+			}
+			out = append(out, row)
+		}
+		return out
+	case "group":
+		out := make(map[string]any)
+		for _, c := range def.children {
+			sub := key + "_" + c.name
+			if _, ok := meta[sub]; ok {
+				out[c.name] = a.value(meta, sub, c)
+			}
+		}
+		return out
+	}
+	raw := meta[key]
+	if isPHPSerialized(raw) {
+		if v, err := phpUnserialize(raw); err == nil {
+			return phpJSON(v)
+		}
+	}
+	return raw
+}
+
+// acfRefs turns a single ID or a serialized list of IDs into plan keys.
+func acfRefs(raw string, key func(string) string) []string {
+	if raw == "" {
+		return nil
+	}
+	if !isPHPSerialized(raw) {
+		return []string{key(raw)}
+	}
+	v, err := phpUnserialize(raw)
+	if err != nil {
+		return nil
+	}
+	var refs []string
+	for _, id := range phpList(v) {
+		if s := fmt.Sprint(id); s != "" {
+			refs = append(refs, key(s))
+		}
+	}
+	return refs
+}
+
+// acfJSON converts a serialized PHP value to JSON, falling back to the raw
+// string when it does not decode.
+func acfJSON(raw string) string {
+	v, err := phpUnserialize(raw)
+	if err != nil {
+		return raw
+	}
+	out, err := json.Marshal(phpJSON(v))
+	if err != nil {
+		return raw
+	}
+	return string(out)
+}
+
+// acfDate converts ACF's Ymd date storage to YYYY-MM-DD.
+func acfDate(raw string) string {
+	if t, err := time.Parse("20060102", raw); err == nil {
+		return t.Format("2006-01-02")
+	}
+	return raw
+}
+
+// phpJSON converts decoded PHP arrays to JSON-friendly values: sequential
+// arrays become slices and associative arrays stay maps.
+func phpJSON(v any) any {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	if !isAssoc(m) {
+		list := phpList(m)
+		for i := range list {
+			list[i] = phpJSON(list[i])
+		}
+		return list
+	}
+	out := make(map[string]any, len(m))
+	for k, val := range m {
+		out[k] = phpJSON(val)
+	}
+	return out
+}
+
+// isAssoc reports whether a decoded PHP array has non-sequential keys.
+func isAssoc(m map[string]any) bool {
+	for i := range len(m) {
+		if _, ok := m[strconv.Itoa(i)]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// importName converts a source identifier ("case-study", "caseStudy") to a
+// datatype or field name ("case_study").
+func importName(s string) string {
+	var b strings.Builder
+	prev := rune(0)
+	for _, r := range strings.TrimSpace(s) {
+		out := r
+		switch {
+		case r == '-' || r == ' ' || r == '.':
+			out = '_'
+		case r >= 'A' && r <= 'Z':
+			if (prev >= 'a' && prev <= 'z') || (prev >= '0' && prev <= '9') {
+				b.WriteByte('_')
+			}
+			out = r + 'a' - 'A'
+		}
+		b.WriteRune(out)
+		prev = r
+	}
+	return b.String()
+}
+
+// labelize converts an identifier ("case_study") to a label ("Case Study").
+func labelize(s string) string {
+	words := splitWords(s)
+	for i, w := range words {
+		words[i] = toTitleCase(w)
+	}
+	return strings.Join(words, " ")
+}
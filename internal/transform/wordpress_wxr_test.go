@@ -0,0 +1,452 @@
+package transform
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// testWXR is a trimmed WXR 1.2 export with posts, pages, a custom post type,
+// nested categories, a tag, a custom taxonomy term, an attachment and ACF
+// field definitions and values.
+const testWXR = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0"
+	xmlns:excerpt="http://wordpress.org/export/1.2/excerpt/"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<title>Example Blog</title>
+	<link>https://example.com</link>
+	<wp:wxr_version>1.2</wp:wxr_version>
+	<wp:category><wp:term_id>2</wp:term_id><wp:category_nicename>news</wp:category_nicename><wp:category_parent></wp:category_parent><wp:cat_name><![CDATA[News]]></wp:cat_name></wp:category>
+	<wp:category><wp:term_id>3</wp:term_id><wp:category_nicename>local</wp:category_nicename><wp:category_parent>news</wp:category_parent><wp:cat_name><![CDATA[Local News]]></wp:cat_name></wp:category>
+	<wp:tag><wp:term_id>4</wp:term_id><wp:tag_slug>go</wp:tag_slug><wp:tag_name><![CDATA[Go]]></wp:tag_name></wp:tag>
+	<wp:term><wp:term_id>5</wp:term_id><wp:term_taxonomy>genre</wp:term_taxonomy><wp:term_slug>scifi</wp:term_slug><wp:term_parent></wp:term_parent><wp:term_name><![CDATA[Sci-Fi]]></wp:term_name></wp:term>
+	<wp:term><wp:term_id>6</wp:term_id><wp:term_taxonomy>nav_menu</wp:term_taxonomy><wp:term_slug>main</wp:term_slug><wp:term_name><![CDATA[Main]]></wp:term_name></wp:term>
+
+	<item>
+		<title>Hello World</title>
+		<dc:creator><![CDATA[admin]]></dc:creator>
+		<content:encoded><![CDATA[<p>Welcome &amp; hello.</p>]]></content:encoded>
+		<excerpt:encoded><![CDATA[Short intro]]></excerpt:encoded>
+		<wp:post_id>7</wp:post_id>
+		<wp:post_date>2024-03-01 10:00:00</wp:post_date>
+		<wp:post_date_gmt>2024-03-01 09:00:00</wp:post_date_gmt>
+		<wp:post_name>hello-world</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_parent>0</wp:post_parent>
+		<wp:menu_order>0</wp:menu_order>
+		<wp:post_type>post</wp:post_type>
+		<category domain="category" nicename="news"><![CDATA[News]]></category>
+		<category domain="post_tag" nicename="go"><![CDATA[Go]]></category>
+		<category domain="post_format" nicename="post-format-aside"><![CDATA[Aside]]></category>
+		<wp:postmeta><wp:meta_key>_thumbnail_id</wp:meta_key><wp:meta_value>20</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>event_date</wp:meta_key><wp:meta_value>20240315</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>_event_date</wp:meta_key><wp:meta_value>field_date</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>related</wp:meta_key><wp:meta_value><![CDATA[a:1:{i:0;s:1:"8";}]]></wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>_related</wp:meta_key><wp:meta_value>field_related</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>speakers</wp:meta_key><wp:meta_value>2</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>_speakers</wp:meta_key><wp:meta_value>field_speakers</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>speakers_0_name</wp:meta_key><wp:meta_value>Ada</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>_speakers_0_name</wp:meta_key><wp:meta_value>field_speaker_name</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>speakers_1_name</wp:meta_key><wp:meta_value>Grace</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>_speakers_1_name</wp:meta_key><wp:meta_value>field_speaker_name</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>featured</wp:meta_key><wp:meta_value>1</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>_featured</wp:meta_key><wp:meta_value>field_featured</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>colors</wp:meta_key><wp:meta_value><![CDATA[a:2:{i:0;s:3:"red";i:1;s:4:"blue";}]]></wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>_colors</wp:meta_key><wp:meta_value>field_undefined</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>_edit_lock</wp:meta_key><wp:meta_value>1700000000:1</wp:meta_value></wp:postmeta>
+	</item>
+	<item>
+		<title>Second Post</title>
+		<wp:post_id>8</wp:post_id>
+		<wp:post_date>2024-03-02 08:30:00</wp:post_date>
+		<wp:post_date_gmt>0000-00-00 00:00:00</wp:post_date_gmt>
+		<wp:post_name></wp:post_name>
+		<wp:status>draft</wp:status>
+		<wp:post_parent>0</wp:post_parent>
+		<wp:menu_order>0</wp:menu_order>
+		<wp:post_type>post</wp:post_type>
+		<category domain="category" nicename="local"><![CDATA[Local News]]></category>
+	</item>
+	<item>
+		<title>About</title>
+		<wp:post_id>10</wp:post_id>
+		<wp:post_name>about</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_parent>0</wp:post_parent>
+		<wp:menu_order>2</wp:menu_order>
+		<wp:post_type>page</wp:post_type>
+	</item>
+	<item>
+		<title>Team</title>
+		<wp:post_id>11</wp:post_id>
+		<wp:post_name>team</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_parent>10</wp:post_parent>
+		<wp:menu_order>0</wp:menu_order>
+		<wp:post_type>page</wp:post_type>
+	</item>
+	<item>
+		<title>Contact</title>
+		<wp:post_id>12</wp:post_id>
+		<wp:post_name>contact</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_parent>0</wp:post_parent>
+		<wp:menu_order>1</wp:menu_order>
+		<wp:post_type>page</wp:post_type>
+	</item>
+	<item>
+		<title>Dune</title>
+		<wp:post_id>13</wp:post_id>
+		<wp:post_name>dune</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_parent>0</wp:post_parent>
+		<wp:menu_order>0</wp:menu_order>
+		<wp:post_type>book</wp:post_type>
+		<category domain="genre" nicename="scifi"><![CDATA[Sci-Fi]]></category>
+	</item>
+	<item>
+		<title>photo</title>
+		<excerpt:encoded><![CDATA[A caption]]></excerpt:encoded>
+		<wp:post_id>20</wp:post_id>
+		<wp:status>inherit</wp:status>
+		<wp:post_parent>7</wp:post_parent>
+		<wp:post_type>attachment</wp:post_type>
+		<wp:attachment_url>https://example.com/wp-content/uploads/2024/03/photo.jpg</wp:attachment_url>
+		<wp:postmeta><wp:meta_key>_wp_attached_file</wp:meta_key><wp:meta_value>2024/03/photo.jpg</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>_wp_attachment_image_alt</wp:meta_key><wp:meta_value>A photo</wp:meta_value></wp:postmeta>
+	</item>
+	<item>
+		<title>Menu Item</title>
+		<wp:post_id>40</wp:post_id>
+		<wp:status>publish</wp:status>
+		<wp:post_type>nav_menu_item</wp:post_type>
+	</item>
+	<item>
+		<title>Trashed</title>
+		<wp:post_id>41</wp:post_id>
+		<wp:status>trash</wp:status>
+		<wp:post_type>post</wp:post_type>
+	</item>
+	<item>
+		<title>Event Fields</title>
+		<wp:post_id>30</wp:post_id>
+		<wp:post_type>acf-field-group</wp:post_type>
+	</item>
+	<item>
+		<title>Event Date</title>
+		<content:encoded><![CDATA[a:2:{s:4:"type";s:11:"date_picker";s:13:"return_format";s:5:"d/m/Y";}]]></content:encoded>
+		<excerpt:encoded><![CDATA[event_date]]></excerpt:encoded>
+		<wp:post_id>31</wp:post_id>
+		<wp:post_name>field_date</wp:post_name>
+		<wp:post_parent>30</wp:post_parent>
+		<wp:menu_order>0</wp:menu_order>
+		<wp:post_type>acf-field</wp:post_type>
+	</item>
+	<item>
+		<title>Related Posts</title>
+		<content:encoded><![CDATA[a:2:{s:4:"type";s:12:"relationship";s:9:"post_type";a:1:{i:0;s:4:"post";}}]]></content:encoded>
+		<excerpt:encoded><![CDATA[related]]></excerpt:encoded>
+		<wp:post_id>32</wp:post_id>
+		<wp:post_name>field_related</wp:post_name>
+		<wp:post_parent>30</wp:post_parent>
+		<wp:menu_order>1</wp:menu_order>
+		<wp:post_type>acf-field</wp:post_type>
+	</item>
+	<item>
+		<title>Speakers</title>
+		<content:encoded><![CDATA[a:2:{s:4:"type";s:8:"repeater";s:3:"min";i:0;}]]></content:encoded>
+		<excerpt:encoded><![CDATA[speakers]]></excerpt:encoded>
+		<wp:post_id>33</wp:post_id>
+		<wp:post_name>field_speakers</wp:post_name>
+		<wp:post_parent>30</wp:post_parent>
+		<wp:menu_order>2</wp:menu_order>
+		<wp:post_type>acf-field</wp:post_type>
+	</item>
+	<item>
+		<title>Name</title>
+		<content:encoded><![CDATA[a:1:{s:4:"type";s:4:"text";}]]></content:encoded>
+		<excerpt:encoded><![CDATA[name]]></excerpt:encoded>
+		<wp:post_id>34</wp:post_id>
+		<wp:post_name>field_speaker_name</wp:post_name>
+		<wp:post_parent>33</wp:post_parent>
+		<wp:menu_order>0</wp:menu_order>
+		<wp:post_type>acf-field</wp:post_type>
+	</item>
+	<item>
+		<title>Featured</title>
+		<content:encoded><![CDATA[a:1:{s:4:"type";s:10:"true_false";}]]></content:encoded>
+		<excerpt:encoded><![CDATA[featured]]></excerpt:encoded>
+		<wp:post_id>35</wp:post_id>
+		<wp:post_name>field_featured</wp:post_name>
+		<wp:post_parent>30</wp:post_parent>
+		<wp:menu_order>3</wp:menu_order>
+		<wp:post_type>acf-field</wp:post_type>
+	</item>
+</channel>
+</rss>`
+
+func mustParseWXR(t *testing.T) *ImportPlan {
+	t.Helper()
+	plan, err := ParseWXR([]byte(testWXR))
+	if err != nil {
+		t.Fatalf("ParseWXR: %v", err)
+	}
+	return plan
+}
+
+func planItem(t *testing.T, plan *ImportPlan, key string) ImportItem {
+	t.Helper()
+	for _, item := range plan.Items {
+		if item.Key == key {
+			return item
+		}
+	}
+	t.Fatalf("item %q not in plan", key)
+	return ImportItem{}
+}
+
+func TestParseWXR_Types(t *testing.T) {
+	t.Parallel()
+	plan := mustParseWXR(t)
+
+	if plan.Source != "wordpress" || plan.Title != "Example Blog" {
+		t.Errorf("Source, Title = %q, %q", plan.Source, plan.Title)
+	}
+
+	var got []string
+	for _, typ := range plan.Types {
+		got = append(got, typ.Key+"="+typ.Name+"/"+typ.Plural)
+	}
+	want := []string{
+		"taxonomy:category=category/Categories",
+		"taxonomy:post_tag=tag/Tags",
+		"taxonomy:genre=genre/Genres",
+		"post_type:post=post/Posts",
+		"post_type:page=page/Pages",
+		"post_type:book=book/Books",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("types =\n%v\nwant\n%v", got, want)
+	}
+
+	post, _ := plan.TypeByKey("post_type:post")
+	fieldTypes := make(map[string]types.FieldType)
+	for _, f := range post.Fields {
+		fieldTypes[f.Name] = f.Type
+	}
+	for name, want := range map[string]types.FieldType{
+		"title":          types.FieldTypeTitle,
+		"content":        types.FieldTypeRichText,
+		"featured_image": types.FieldTypeMedia,
+		"categories":     types.FieldTypeIDRef,
+		"tags":           types.FieldTypeIDRef,
+		"event_date":     types.FieldTypeDate,
+		"related":        types.FieldTypeIDRef,
+		"speakers":       types.FieldTypeJSON,
+		"featured":       types.FieldTypeBoolean,
+		"colors":         types.FieldTypeJSON,
+	} {
+		if fieldTypes[name] != want {
+			t.Errorf("post field %q type = %q, want %q", name, fieldTypes[name], want)
+		}
+	}
+	if _, ok := post.Field("name"); ok {
+		t.Error("repeater sub field should not become a top-level field")
+	}
+	if _, ok := post.Field("post_format"); ok {
+		t.Error("post_format taxonomy should be skipped")
+	}
+	related, _ := post.Field("related")
+	if related.Target != "post_type:post" || !related.Multiple {
+		t.Errorf("related = %+v, want many relation to post_type:post", related)
+	}
+	categories, _ := post.Field("categories")
+	if categories.Target != "taxonomy:category" {
+		t.Errorf("categories target = %q", categories.Target)
+	}
+
+	page, _ := plan.TypeByKey("post_type:page")
+	if _, ok := page.Field("featured_image"); ok {
+		t.Error("page has no thumbnails and should not get featured_image")
+	}
+}
+
+func TestParseWXR_Items(t *testing.T) {
+	t.Parallel()
+	plan := mustParseWXR(t)
+
+	for _, item := range plan.Items {
+		switch item.Key {
+		case "post:40", "post:41", "post:20", "post:30", "post:31", "term:nav_menu:main":
+			t.Errorf("item %q should have been skipped", item.Key)
+		}
+	}
+
+	hello := planItem(t, plan, "post:7")
+	for name, want := range map[string]string{
+		"title":          "Hello World",
+		"slug":           "hello-world",
+		"content":        "<p>Welcome &amp; hello.</p>",
+		"excerpt":        "Short intro",
+		"published_date": "2024-03-01T09:00:00Z",
+		"author":         "admin",
+		"published":      "true",
+		"event_date":     "2024-03-15",
+		"featured":       "true",
+		"colors":         `["red","blue"]`,
+	} {
+		if got := hello.Values[name]; got != want {
+			t.Errorf("Values[%q] = %q, want %q", name, got, want)
+		}
+	}
+	var speakers []map[string]string
+	if err := json.Unmarshal([]byte(hello.Values["speakers"]), &speakers); err != nil {
+		t.Fatalf("speakers is not JSON: %v", err)
+	}
+	if len(speakers) != 2 || speakers[0]["name"] != "Ada" || speakers[1]["name"] != "Grace" {
+		t.Errorf("speakers = %v", speakers)
+	}
+	if got := hello.Relations["categories"]; !reflect.DeepEqual(got, []string{"term:category:news"}) {
+		t.Errorf("categories = %v", got)
+	}
+	if got := hello.Relations["tags"]; !reflect.DeepEqual(got, []string{"term:post_tag:go"}) {
+		t.Errorf("tags = %v", got)
+	}
+	if got := hello.Relations["related"]; !reflect.DeepEqual(got, []string{"post:8"}) {
+		t.Errorf("related = %v", got)
+	}
+	if got := hello.Media["featured_image"]; !reflect.DeepEqual(got, []string{"post:20"}) {
+		t.Errorf("featured_image = %v", got)
+	}
+
+	second := planItem(t, plan, "post:8")
+	if second.Values["slug"] != "second-post" {
+		t.Errorf("generated slug = %q", second.Values["slug"])
+	}
+	if second.Values["published"] != "false" {
+		t.Errorf("draft published = %q", second.Values["published"])
+	}
+	if second.Values["published_date"] != "2024-03-02T08:30:00Z" {
+		t.Errorf("draft published_date = %q", second.Values["published_date"])
+	}
+
+	if got := planItem(t, plan, "post:11").ParentKey; got != "post:10" {
+		t.Errorf("Team parent = %q", got)
+	}
+	if got := planItem(t, plan, "term:category:local").ParentKey; got != "term:category:news" {
+		t.Errorf("local category parent = %q", got)
+	}
+	if got := planItem(t, plan, "post:13").Relations["genre"]; !reflect.DeepEqual(got, []string{"term:genre:scifi"}) {
+		t.Errorf("book genre = %v", got)
+	}
+
+	if len(plan.Assets) != 1 {
+		t.Fatalf("assets = %d, want 1", len(plan.Assets))
+	}
+	asset := plan.Assets[0]
+	if asset.Key != "post:20" || asset.Path != "2024/03/photo.jpg" || asset.Alt != "A photo" || asset.Caption != "A caption" {
+		t.Errorf("asset = %+v", asset)
+	}
+}
+
+func TestParseWXR_TreeOrder(t *testing.T) {
+	t.Parallel()
+	plan := mustParseWXR(t)
+	top, children := plan.Tree()
+
+	var pages []string
+	for _, item := range top["post_type:page"] {
+		pages = append(pages, item.Values["title"])
+	}
+	// Sorted by menu_order; Team is nested under About.
+	if want := []string{"Contact", "About"}; !reflect.DeepEqual(pages, want) {
+		t.Errorf("top-level pages = %v, want %v", pages, want)
+	}
+	if kids := children["post:10"]; len(kids) != 1 || kids[0].Key != "post:11" {
+		t.Errorf("About children = %v", kids)
+	}
+	if kids := children["term:category:news"]; len(kids) != 1 || kids[0].Key != "term:category:local" {
+		t.Errorf("News children = %v", kids)
+	}
+}
+
+func TestImportPlan_TreeBreaksCycles(t *testing.T) {
+	t.Parallel()
+	plan := &ImportPlan{Items: []ImportItem{
+		{Key: "a", Type: "page", ParentKey: "b"},
+		{Key: "b", Type: "page", ParentKey: "a"},
+		{Key: "c", Type: "page", ParentKey: "missing"},
+		{Key: "d", Type: "post", ParentKey: "c"},
+	}}
+	top, children := plan.Tree()
+	if len(top["page"]) != 3 || len(top["post"]) != 1 || len(children) != 0 {
+		t.Errorf("top = %v, children = %v", top, children)
+	}
+}
+
+func TestWordPressTransformer_Parse(t *testing.T) {
+	t.Parallel()
+	w := &WordPressTransformer{}
+	root, err := w.Parse([]byte(testWXR))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if root.Node == nil || root.Node.Datatype.Info.Type != "_root" {
+		t.Fatalf("root = %+v", root.Node)
+	}
+	var collections []string
+	for _, n := range root.Node.Nodes {
+		collections = append(collections, n.Datatype.Info.Label)
+	}
+	want := []string{"Categories", "Tags", "Genres", "Posts", "Pages", "Books"}
+	if !reflect.DeepEqual(collections, want) {
+		t.Errorf("collections = %v, want %v", collections, want)
+	}
+
+	if _, err := w.Parse([]byte(`{}`)); err == nil {
+		t.Error("Parse of non-XML input should fail")
+	}
+	if _, err := w.ParseToNode([]byte(`<rss></rss>`)); err == nil || !strings.Contains(err.Error(), "channel") {
+		t.Errorf("ParseToNode without channel error = %v", err)
+	}
+}
+
+func TestPHPUnserialize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in   string
+		want any
+	}{
+		{`s:6:"héllo";`, "héllo"},
+		{`i:42;`, int64(42)},
+		{`b:1;`, true},
+		{`N;`, nil},
+		{`a:2:{i:0;s:1:"x";i:1;d:1.5;}`, map[string]any{"0": "x", "1": 1.5}},
+		{`a:1:{s:3:"k;}";a:0:{}}`, map[string]any{"k;}": map[string]any{}}},
+	}
+	for _, tt := range tests {
+		got, err := phpUnserialize(tt.in)
+		if err != nil {
+			t.Errorf("phpUnserialize(%q): %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("phpUnserialize(%q) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{
+		``, `s:9:"short";`, `a:1:{i:0;}`, `O:8:"stdClass":0:{}`,
+		`s:9223372036854775807:"x";`, `a:9223372036854775807:{}`, `a:1:{s:9223372036854775800:"";N;}`,
+	} {
+		if _, err := phpUnserialize(bad); err == nil {
+			t.Errorf("phpUnserialize(%q) should fail", bad)
+		}
+	}
+}
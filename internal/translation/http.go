@@ -0,0 +1,209 @@
+package translation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTP adapter styles, also the translation_provider config values that
+// select them.
+const (
+	StyleDeepL          = "deepl"
+	StyleLibreTranslate = "libretranslate"
+)
+
+const (
+	deeplDefaultEndpoint     = "https://api.deepl.com"
+	deeplFreeDefaultEndpoint = "https://api-free.deepl.com"
+
+	// httpBatchSize is the most texts sent in one request; DeepL's limit.
+	httpBatchSize = 50
+)
+
+// HTTPProvider translates through a DeepL or LibreTranslate compatible HTTP
+// API. Pointing the endpoint at a local stub that speaks either protocol is
+// enough for development and tests.
+type HTTPProvider struct {
+	style    string
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+// NewHTTPProvider creates an adapter for style (StyleDeepL or
+// StyleLibreTranslate). endpoint is the service's base URL; DeepL defaults to
+// its API host (the free host for ":fx" keys), LibreTranslate requires one.
+func NewHTTPProvider(style, endpoint, apiKey string) (*HTTPProvider, error) {
+	endpoint = strings.TrimRight(endpoint, "/")
+	switch style {
+	case StyleDeepL:
+		if apiKey == "" {
+			return nil, fmt.Errorf("translation_provider_api_key is required for DeepL")
+		}
+		if endpoint == "" {
+			endpoint = deeplDefaultEndpoint
+			if strings.HasSuffix(apiKey, ":fx") {
+				endpoint = deeplFreeDefaultEndpoint
+			}
+		}
+	case StyleLibreTranslate:
+		if endpoint == "" {
+			return nil, fmt.Errorf("translation_provider_url is required for LibreTranslate")
+		}
+	default:
+		return nil, fmt.Errorf("unknown translation provider %q", style)
+	}
+	return &HTTPProvider{
+		style:    style,
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Name returns the adapter style.
+func (p *HTTPProvider) Name() string { return p.style }
+
+// Translate sends the texts in batches and returns the translations in order.
+func (p *HTTPProvider) Translate(ctx context.Context, req Request) ([]string, error) {
+	out := make([]string, 0, len(req.Texts))
+	for start := 0; start < len(req.Texts); start += httpBatchSize {
+		end := min(start+httpBatchSize, len(req.Texts))
+		batch := req
+		batch.Texts = req.Texts[start:end]
+
+		var got []string
+		var err error
+		if p.style == StyleDeepL {
+			got, err = p.translateDeepL(ctx, batch)
+		} else {
+			got, err = p.translateLibre(ctx, batch)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(got) != len(batch.Texts) {
+			return nil, fmt.Errorf("%s returned %d texts for %d inputs", p.style, len(got), len(batch.Texts))
+		}
+		out = append(out, got...)
+	}
+	return out, nil
+}
+
+type deeplRequest struct {
+	Text        []string `json:"text"`
+	SourceLang  string   `json:"source_lang,omitempty"`
+	TargetLang  string   `json:"target_lang"`
+	TagHandling string   `json:"tag_handling,omitempty"`
+}
+
+type deeplResponse struct {
+	Translations []struct {
+		Text string `json:"text"`
+	} `json:"translations"`
+}
+
+// translateDeepL calls POST /v2/translate. DeepL takes upper-case codes and
+// only a base language for the source ("EN", not "EN-US").
+func (p *HTTPProvider) translateDeepL(ctx context.Context, req Request) ([]string, error) {
+	payload := deeplRequest{
+		Text:       req.Texts,
+		SourceLang: strings.ToUpper(baseLanguage(req.SourceLocale)),
+		TargetLang: strings.ToUpper(req.TargetLocale),
+	}
+	if req.Format == FormatHTML {
+		payload.TagHandling = "html"
+	}
+	var resp deeplResponse
+	header := http.Header{"Authorization": {"DeepL-Auth-Key " + p.apiKey}}
+	if err := p.post(ctx, "/v2/translate", header, payload, &resp); err != nil {
+		return nil, err
+	}
+	out := make([]string, len(resp.Translations))
+	for i, t := range resp.Translations {
+		out[i] = t.Text
+	}
+	return out, nil
+}
+
+type libreRequest struct {
+	Q      []string `json:"q"`
+	Source string   `json:"source"`
+	Target string   `json:"target"`
+	Format string   `json:"format"`
+	APIKey string   `json:"api_key,omitempty"`
+}
+
+type libreResponse struct {
+	TranslatedText []string `json:"translatedText"`
+}
+
+// translateLibre calls POST /translate with an array of texts, which
+// LibreTranslate answers with an array of translations.
+func (p *HTTPProvider) translateLibre(ctx context.Context, req Request) ([]string, error) {
+	format := FormatText
+	if req.Format == FormatHTML {
+		format = FormatHTML
+	}
+	payload := libreRequest{
+		Q:      req.Texts,
+		Source: req.SourceLocale,
+		Target: req.TargetLocale,
+		Format: format,
+		APIKey: p.apiKey,
+	}
+	var resp libreResponse
+	if err := p.post(ctx, "/translate", nil, payload, &resp); err != nil {
+		return nil, err
+	}
+	return resp.TranslatedText, nil
+}
+
+// post sends payload as JSON and decodes a 2xx JSON response into out.
+func (p *HTTPProvider) post(ctx context.Context, path string, header http.Header, payload, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("%s: marshal request: %w", p.style, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: create request: %w", p.style, err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", p.style, err)
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: status %d: %s", p.style, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s: decode response: %w", p.style, err)
+	}
+	return nil
+}
+
+// baseLanguage returns the primary subtag of a locale code ("pt" for "pt-BR").
+func baseLanguage(code string) string {
+	if i := strings.IndexByte(code, '-'); i > 0 {
+		return code[:i]
+	}
+	return code
+}
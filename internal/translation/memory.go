@@ -0,0 +1,114 @@
+package translation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxFuzzyRunes bounds the texts compared by Similarity. Longer texts only
+// match exactly; edit distance on them costs more than it saves.
+const maxFuzzyRunes = 1000
+
+// Candidate is a translation memory entry considered for a fuzzy match.
+type Candidate struct {
+	ID     string
+	Source string
+	Target string
+}
+
+// Normalize trims a source text and collapses runs of whitespace, so
+// reflowed or re-indented text still matches its memory entry.
+func Normalize(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// SourceHash is the key an exact memory lookup uses: the hex SHA-256 of the
+// normalized text.
+func SourceHash(s string) string {
+	sum := sha256.Sum256([]byte(Normalize(s)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Similarity scores two normalized texts from 0 to 100 as one minus their
+// rune edit distance over the longer length. Texts over maxFuzzyRunes only
+// score 100 when identical.
+func Similarity(a, b string) int {
+	if a == b {
+		return 100
+	}
+	ra, rb := []rune(a), []rune(b)
+	longer := max(len(ra), len(rb))
+	if longer > maxFuzzyRunes {
+		return 0
+	}
+	dist := levenshtein(ra, rb)
+	return (longer - dist) * 100 / longer
+}
+
+// BestMatch returns the candidate whose source is most similar to source,
+// with its score, if it reaches minScore. Ties keep the earlier candidate,
+// so callers list the most recent entries first.
+func BestMatch(source string, candidates []Candidate, minScore int) (Candidate, int, bool) {
+	source = Normalize(source)
+	var best Candidate
+	bestScore := -1
+	for _, c := range candidates {
+		score := Similarity(source, Normalize(c.Source))
+		if score > bestScore {
+			best, bestScore = c, score
+		}
+	}
+	if bestScore < minScore || bestScore < 0 {
+		return Candidate{}, 0, false
+	}
+	return best, bestScore, true
+}
+
+// LengthWindow returns the range of normalized source lengths (in runes) that
+// can reach minScore against a text of n runes. Memory lookups filter on it
+// before scoring.
+func LengthWindow(n, minScore int) (lo, hi int) {
+	if minScore <= 0 {
+		return 0, maxFuzzyRunes
+	}
+	if minScore > 100 {
+		minScore = 100
+	}
+	// Lengths differing by d cost at least d edits, so a score s needs the
+	// shorter text to be at least s% of the longer one.
+	lo = n * minScore / 100
+	hi = n * 100 / minScore
+	return lo, hi
+}
+
+// RuneLength is the length LengthWindow and the memory table compare.
+func RuneLength(s string) int {
+	return utf8.RuneCountInString(Normalize(s))
+}
+
+// levenshtein is the rune edit distance between a and b, using one row.
+func levenshtein(a, b []rune) int {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cur := row[j]
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			row[j] = min(row[j]+1, row[j-1]+1, prev+cost)
+			prev = cur
+		}
+	}
+	return row[len(b)]
+}
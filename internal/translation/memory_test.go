@@ -0,0 +1,82 @@
+package translation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeAndHash(t *testing.T) {
+	t.Parallel()
+
+	if got := Normalize("  Hello \n\t world  "); got != "Hello world" {
+		t.Errorf("Normalize = %q", got)
+	}
+	if SourceHash("Hello world") != SourceHash(" Hello\n world ") {
+		t.Error("whitespace-only differences should hash the same")
+	}
+	if SourceHash("Hello world") == SourceHash("hello world") {
+		t.Error("case differences should hash differently")
+	}
+	if got := RuneLength(" héllo  wörld "); got != 11 {
+		t.Errorf("RuneLength = %d, want 11", got)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"kitten", "kitten", 100},
+		{"kitten", "sitting", 57},
+		{"", "abc", 0},
+		{"Buy now", "Buy now!", 87},
+	}
+	for _, tt := range tests {
+		if got := Similarity(tt.a, tt.b); got != tt.want {
+			t.Errorf("Similarity(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	long := strings.Repeat("a", maxFuzzyRunes+1)
+	if got := Similarity(long, long+"b"); got != 0 {
+		t.Errorf("long texts should not fuzzy match, got %d", got)
+	}
+}
+
+func TestBestMatch(t *testing.T) {
+	t.Parallel()
+
+	candidates := []Candidate{
+		{ID: "1", Source: "Free shipping on orders over $50", Target: "Livraison gratuite dès 50 $"},
+		{ID: "2", Source: "Free shipping on all orders", Target: "Livraison gratuite sur toutes les commandes"},
+		{ID: "3", Source: "Contact us", Target: "Contactez-nous"},
+	}
+	c, score, ok := BestMatch("Free shipping on orders over $60", candidates, 75)
+	if !ok || c.ID != "1" || score < 90 {
+		t.Errorf("BestMatch = %+v %d %v, want candidate 1", c, score, ok)
+	}
+	if _, _, ok := BestMatch("Something else entirely", candidates, 75); ok {
+		t.Error("expected no match under the minimum score")
+	}
+	if _, _, ok := BestMatch("x", nil, 0); ok {
+		t.Error("expected no match without candidates")
+	}
+}
+
+func TestLengthWindow(t *testing.T) {
+	t.Parallel()
+
+	lo, hi := LengthWindow(40, 80)
+	if lo != 32 || hi != 50 {
+		t.Errorf("LengthWindow(40, 80) = %d, %d, want 32, 50", lo, hi)
+	}
+	// Every length inside the window can reach the score; just outside cannot.
+	if Similarity(strings.Repeat("a", 40), strings.Repeat("a", 51)) >= 80 {
+		t.Error("length outside the window reached the minimum score")
+	}
+	if Similarity(strings.Repeat("a", 40), strings.Repeat("a", 50)) < 80 {
+		t.Error("length at the window edge should reach the minimum score")
+	}
+}
@@ -0,0 +1,61 @@
+// Package translation pre-fills translated content field values. A Provider
+// translates text between locales, either a machine-translation service over
+// HTTP or a Lua plugin. The translation memory, built from source and target
+// values published together, supplies exact and fuzzy matches before a
+// provider is asked.
+package translation
+
+import (
+	"context"
+	"fmt"
+)
+
+// Text formats a provider is asked to translate. HTML keeps markup intact
+// (richtext fields); Text is everything else.
+const (
+	FormatText = "text"
+	FormatHTML = "html"
+)
+
+// Origins recorded for a pre-filled value.
+const (
+	// OriginMemory is a translation memory match.
+	OriginMemory = "memory"
+	// OriginMachine is a provider translation.
+	OriginMachine = "machine"
+)
+
+// Request asks a provider to translate Texts from SourceLocale to
+// TargetLocale. Locales are the CMS locale codes (e.g. "en", "pt-BR");
+// adapters map them to the codes their service expects.
+type Request struct {
+	SourceLocale string
+	TargetLocale string
+	Format       string
+	Texts        []string
+}
+
+// Provider translates batches of text. Translate returns one translation per
+// input text, in order; a provider that cannot translate a text fails the
+// whole batch.
+type Provider interface {
+	// Name identifies the provider in config and review records, e.g.
+	// "deepl" or "<plugin>:<name>".
+	Name() string
+	Translate(ctx context.Context, req Request) ([]string, error)
+}
+
+// Translate calls p and checks it returned one text per input.
+func Translate(ctx context.Context, p Provider, req Request) ([]string, error) {
+	if len(req.Texts) == 0 {
+		return nil, nil
+	}
+	out, err := p.Translate(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("translation provider %s: %w", p.Name(), err)
+	}
+	if len(out) != len(req.Texts) {
+		return nil, fmt.Errorf("translation provider %s: returned %d texts for %d inputs", p.Name(), len(out), len(req.Texts))
+	}
+	return out, nil
+}
@@ -0,0 +1,141 @@
+package translation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHTTPProvider_DeepL(t *testing.T) {
+	t.Parallel()
+
+	var auth string
+	var got deeplRequest
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/translate" {
+			t.Errorf("path = %q, want /v2/translate", r.URL.Path)
+		}
+		calls++
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		var resp deeplResponse
+		for _, text := range got.Text {
+			resp.Translations = append(resp.Translations, struct {
+				Text string `json:"text"`
+			}{Text: strings.ToUpper(text)})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	p, err := NewHTTPProvider(StyleDeepL, server.URL+"/", "key:fx")
+	if err != nil {
+		t.Fatalf("NewHTTPProvider: %v", err)
+	}
+	texts := make([]string, httpBatchSize+2)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("<p>t%d</p>", i)
+	}
+	out, err := Translate(context.Background(), p, Request{SourceLocale: "en-US", TargetLocale: "pt-BR", Format: FormatHTML, Texts: texts})
+	if err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2 batches", calls)
+	}
+	if len(out) != len(texts) || out[0] != "<P>T0</P>" || out[len(out)-1] != strings.ToUpper(texts[len(texts)-1]) {
+		t.Errorf("unexpected output order: first %q last %q", out[0], out[len(out)-1])
+	}
+	if auth != "DeepL-Auth-Key key:fx" {
+		t.Errorf("auth = %q", auth)
+	}
+	if got.SourceLang != "EN" || got.TargetLang != "PT-BR" || got.TagHandling != "html" {
+		t.Errorf("payload = %+v", got)
+	}
+}
+
+func TestHTTPProvider_LibreTranslate(t *testing.T) {
+	t.Parallel()
+
+	var got libreRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/translate" {
+			t.Errorf("path = %q, want /translate", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		resp := libreResponse{}
+		for _, q := range got.Q {
+			resp.TranslatedText = append(resp.TranslatedText, "fr:"+q)
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	p, err := NewHTTPProvider(StyleLibreTranslate, server.URL, "")
+	if err != nil {
+		t.Fatalf("NewHTTPProvider: %v", err)
+	}
+	out, err := Translate(context.Background(), p, Request{SourceLocale: "en", TargetLocale: "fr", Texts: []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	if want := []string{"fr:a", "fr:b"}; !reflect.DeepEqual(out, want) {
+		t.Errorf("out = %v, want %v", out, want)
+	}
+	if got.Source != "en" || got.Target != "fr" || got.Format != FormatText || got.APIKey != "" {
+		t.Errorf("payload = %+v", got)
+	}
+}
+
+func TestHTTPProvider_Errors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("quota exceeded"))
+	}))
+	defer server.Close()
+
+	p, err := NewHTTPProvider(StyleLibreTranslate, server.URL, "k")
+	if err != nil {
+		t.Fatalf("NewHTTPProvider: %v", err)
+	}
+	_, err = p.Translate(context.Background(), Request{Texts: []string{"a"}})
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "quota exceeded") {
+		t.Errorf("error = %v, want status and body", err)
+	}
+
+	_, err = Translate(context.Background(), stubProvider{out: []string{"x"}}, Request{Texts: []string{"a", "b"}})
+	if err == nil || !strings.Contains(err.Error(), "returned 1 texts for 2 inputs") {
+		t.Errorf("length mismatch error = %v", err)
+	}
+
+	if _, err := NewHTTPProvider(StyleDeepL, "", ""); err == nil {
+		t.Error("expected DeepL without an API key to fail")
+	}
+	if _, err := NewHTTPProvider(StyleLibreTranslate, "", ""); err == nil {
+		t.Error("expected LibreTranslate without a URL to fail")
+	}
+	if _, err := NewHTTPProvider("babelfish", "http://x", ""); err == nil {
+		t.Error("expected an unknown style to fail")
+	}
+	if p, _ := NewHTTPProvider(StyleDeepL, "", "abc:fx"); p.endpoint != deeplFreeDefaultEndpoint {
+		t.Errorf("free key endpoint = %q", p.endpoint)
+	}
+}
+
+type stubProvider struct{ out []string }
+
+func (stubProvider) Name() string { return "stub" }
+
+func (s stubProvider) Translate(context.Context, Request) ([]string, error) { return s.out, nil }
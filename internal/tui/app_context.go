@@ -6,6 +6,7 @@ import (
 	"github.com/hegner123/modulacms/internal/config"
 	"github.com/hegner123/modulacms/internal/db"
 	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
 	"github.com/hegner123/modulacms/internal/plugin"
 )
 
@@ -21,6 +22,7 @@ type AppContext struct {
 	ScreenMode       ScreenMode
 	PluginManager    *plugin.Manager
 	ConfigManager    *config.Manager
+	Permissions      *middleware.PermissionCache
 	IsRemote         bool
 	IsSSH            bool
 	SSHFingerprint   string
@@ -44,6 +46,7 @@ func (m Model) AppCtx() AppContext {
 		ScreenMode:       m.ScreenMode,
 		PluginManager:    m.PluginManager,
 		ConfigManager:    m.ConfigManager,
+		Permissions:      m.Permissions,
 		IsRemote:         m.IsRemote,
 		IsSSH:            m.IsSSH,
 		SSHFingerprint:   m.SSHFingerprint,
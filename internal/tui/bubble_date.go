@@ -17,6 +17,22 @@ func init() {
 		Description: "Date picker with calendar",
 		NewBubble:   func() FieldBubble { return NewDatePickerBubble() },
 	})
+	RegisterFieldInput(FieldInputEntry{
+		Key:         "datetime",
+		Label:       "Datetime",
+		Description: "Date picker with time of day",
+		NewBubble:   func() FieldBubble { return NewDateTimePickerBubble() },
+	})
+}
+
+// dateTimeLayouts are the datetime formats SetValue accepts, covering what
+// the datetime field validator accepts plus the minute-precision form older
+// versions of the picker stored.
+var dateTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
 }
 
 // DatePickerMode determines which parts of the date/time are editable.
@@ -358,7 +374,7 @@ func dayPosition(day, startWeekday int) (row, col int) {
 func (b *DatePickerBubble) Value() string {
 	switch b.mode {
 	case DateTimeMode:
-		return fmt.Sprintf("%sT%02d:%02d", b.cursor.Format("2006-01-02"), b.hour, b.minute)
+		return fmt.Sprintf("%sT%02d:%02d:00", b.cursor.Format("2006-01-02"), b.hour, b.minute)
 	case TimeOnlyMode:
 		return fmt.Sprintf("%02d:%02d", b.hour, b.minute)
 	default:
@@ -373,11 +389,13 @@ func (b *DatePickerBubble) SetValue(v string) {
 	}
 	switch b.mode {
 	case DateTimeMode:
-		if t, err := time.Parse("2006-01-02T15:04", v); err == nil {
-			b.cursor = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
-			b.hour = t.Hour()
-			b.minute = t.Minute()
-			return
+		for _, layout := range dateTimeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				b.cursor = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+				b.hour = t.Hour()
+				b.minute = t.Minute()
+				return
+			}
 		}
 		if t, err := time.Parse("2006-01-02", v); err == nil {
 			b.cursor = t
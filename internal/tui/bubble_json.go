@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/textarea"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/validation"
+)
+
+func init() {
+	RegisterFieldInput(FieldInputEntry{
+		Key:         "json",
+		Label:       "JSON",
+		Description: "JSON editor with live validation",
+		NewBubble:   func() FieldBubble { return NewJSONBubble() },
+	})
+}
+
+// JSONBubble is a multi-line JSON editor that validates as you type. Syntax
+// errors are reported with their line and column; valid JSON is then checked
+// against the field's validation rules via Configure. ctrl+f pretty-prints
+// the document. Enter and up/down edit the text; tab moves to the next field.
+type JSONBubble struct {
+	input textarea.Model
+
+	validationJSON string // raw JSON from fields.validation
+	dataJSON       string // raw JSON from fields.data
+
+	problem string // current validation problem, empty when valid
+}
+
+// NewJSONBubble creates an empty JSON editor.
+func NewJSONBubble() *JSONBubble {
+	ta := textarea.New()
+	ta.Placeholder = "{}"
+	ta.CharLimit = 0
+	ta.MaxHeight = 0
+	ta.SetWidth(50)
+	ta.SetHeight(8)
+	ta.ShowLineNumbers = true
+	return &JSONBubble{input: ta}
+}
+
+// Configure sets the field configuration the value is validated against.
+func (b *JSONBubble) Configure(validationJSON, dataJSON string) {
+	b.validationJSON = validationJSON
+	b.dataJSON = dataJSON
+	b.validate()
+}
+
+// CapturesKey claims enter and up/down, like a multi-line TextareaBubble.
+func (b *JSONBubble) CapturesKey(key string) bool {
+	return multilineKeys[key]
+}
+
+// Update handles editing keys and re-validates after each change.
+func (b *JSONBubble) Update(msg tea.Msg) (FieldBubble, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok && keyMsg.String() == "ctrl+f" {
+		b.format()
+		return b, nil
+	}
+	var cmd tea.Cmd
+	b.input, cmd = b.input.Update(msg)
+	if _, ok := msg.(tea.KeyPressMsg); ok {
+		b.validate()
+	}
+	return b, cmd
+}
+
+// format re-indents the document when it is valid JSON.
+func (b *JSONBubble) format() {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(b.input.Value()), "", "  "); err != nil {
+		return
+	}
+	b.input.SetValue(buf.String())
+	b.validate()
+}
+
+// validate refreshes the problem line for the current value.
+func (b *JSONBubble) validate() {
+	b.problem = jsonProblem(b.input.Value(), b.validationJSON, b.dataJSON)
+}
+
+// jsonProblem describes what is wrong with value as a JSON field, or returns
+// "" when it is valid. Empty values defer to the required rule.
+func jsonProblem(value, validationJSON, dataJSON string) string {
+	if strings.TrimSpace(value) != "" {
+		var v any
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				// Offset counts the offending byte itself; report its position.
+				line, col := offsetPosition(value, syntaxErr.Offset-1)
+				return fmt.Sprintf("line %d, col %d: %s", line, col, syntaxErr.Error())
+			}
+			return err.Error()
+		}
+	}
+	fe := validation.ValidateField(validation.FieldInput{
+		FieldType:  types.FieldTypeJSON,
+		Value:      value,
+		Validation: validationJSON,
+		Data:       dataJSON,
+	})
+	if fe != nil {
+		return strings.Join(fe.Messages, "; ")
+	}
+	return ""
+}
+
+// offsetPosition converts a 0-based byte offset into 1-based line and column
+// numbers.
+func offsetPosition(s string, offset int64) (int, int) {
+	offset = max(0, min(offset, int64(len(s))))
+	before := s[:offset]
+	line := strings.Count(before, "\n") + 1
+	col := len(before) - strings.LastIndex(before, "\n")
+	return line, col
+}
+
+// View renders the editor and a validation status line.
+func (b *JSONBubble) View() string {
+	var status string
+	switch {
+	case b.problem != "":
+		status = lipgloss.NewStyle().Foreground(config.DefaultStyle.Accent2).Render("✗ " + b.problem)
+	case strings.TrimSpace(b.input.Value()) != "":
+		status = pickerHintStyle().Render("✓ valid JSON  ctrl+f: format")
+	}
+	if status == "" {
+		return b.input.View()
+	}
+	return b.input.View() + "\n" + status
+}
+
+// Value returns the JSON document.
+func (b *JSONBubble) Value() string { return b.input.Value() }
+
+// SetValue replaces the JSON document and re-validates it.
+func (b *JSONBubble) SetValue(v string) {
+	b.input.SetValue(v)
+	b.validate()
+}
+
+// Focus sets focus to the editor.
+func (b *JSONBubble) Focus() tea.Cmd { return b.input.Focus() }
+
+// Blur removes focus from the editor.
+func (b *JSONBubble) Blur() { b.input.Blur() }
+
+// Focused returns whether the editor is currently focused.
+func (b *JSONBubble) Focused() bool { return b.input.Focused() }
+
+// SetWidth sets the editor width for layout.
+func (b *JSONBubble) SetWidth(w int) { b.input.SetWidth(w) }
@@ -0,0 +1,293 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/hegner123/modulacms/internal/db"
+)
+
+func init() {
+	RegisterFieldInput(FieldInputEntry{
+		Key:         "media",
+		Label:       "Media",
+		Description: "Media picker browsing the folder tree",
+		NewBubble:   func() FieldBubble { return NewMediaPickerBubble() },
+	})
+}
+
+// mediaPickerLoadedMsg carries the media library for a MediaPickerBubble.
+type mediaPickerLoadedMsg struct {
+	Folders []db.MediaFolder
+	Items   []db.Media
+	Err     error
+}
+
+// MediaPickerBubble is a FieldBubble that stores a media ID chosen from the
+// media folder tree. The library is loaded the first time the picker opens.
+//
+// Closed: enter opens the browser, backspace/delete clears the value.
+// Browsing: up/down or j/k move, right/l and left/h expand and collapse
+// folders, enter picks a file (or toggles a folder), esc closes.
+type MediaPickerBubble struct {
+	source db.DbDriver
+
+	value   string // selected media ID
+	label   string // display name of the selected media, once known
+	focused bool
+	width   int
+
+	open    bool
+	loading bool
+	loaded  bool
+	errMsg  string
+	roots   []*MediaTreeNode
+	flat    []*MediaTreeNode
+	cursor  int
+}
+
+// NewMediaPickerBubble creates an empty media picker. The content form
+// supplies its data source via SetSource.
+func NewMediaPickerBubble() *MediaPickerBubble {
+	return &MediaPickerBubble{}
+}
+
+// SetSource sets the driver the picker loads media from. Admin content forms
+// browse the same media library as public content.
+func (b *MediaPickerBubble) SetSource(d db.DbDriver, _ bool) { b.source = d }
+
+// CapturesKey claims every key while browsing, and enter while closed so
+// it opens the browser instead of moving to the next field.
+func (b *MediaPickerBubble) CapturesKey(key string) bool {
+	return b.open || key == "enter"
+}
+
+// Update handles browsing keys and the library load result.
+func (b *MediaPickerBubble) Update(msg tea.Msg) (FieldBubble, tea.Cmd) {
+	switch msg := msg.(type) {
+	case mediaPickerLoadedMsg:
+		b.loading = false
+		if msg.Err != nil {
+			b.errMsg = msg.Err.Error()
+			return b, nil
+		}
+		b.loaded = true
+		b.setLibrary(msg.Folders, msg.Items)
+		return b, nil
+	case tea.KeyPressMsg:
+		if !b.focused {
+			return b, nil
+		}
+		if b.open {
+			b.updateBrowse(msg.String())
+			return b, nil
+		}
+		switch msg.String() {
+		case "enter":
+			b.open = true
+			b.errMsg = ""
+			return b, b.load()
+		case "backspace", "delete":
+			b.value = ""
+			b.label = ""
+		}
+	}
+	return b, nil
+}
+
+// load returns a command that fetches the media library, or nil if it is
+// already loaded or there is nothing to load from.
+func (b *MediaPickerBubble) load() tea.Cmd {
+	if b.loaded || b.loading {
+		return nil
+	}
+	if b.source == nil {
+		b.errMsg = "database not connected"
+		return nil
+	}
+	b.loading = true
+	d := b.source
+	return func() tea.Msg {
+		items, err := d.ListMedia()
+		if err != nil {
+			return mediaPickerLoadedMsg{Err: err}
+		}
+		folders, err := d.ListMediaFolders()
+		if err != nil {
+			return mediaPickerLoadedMsg{Err: err}
+		}
+		var msg mediaPickerLoadedMsg
+		if items != nil {
+			msg.Items = *items
+		}
+		if folders != nil {
+			msg.Folders = *folders
+		}
+		return msg
+	}
+}
+
+// setLibrary builds the tree and resolves the label of the current value.
+func (b *MediaPickerBubble) setLibrary(folders []db.MediaFolder, items []db.Media) {
+	b.roots = BuildMediaTree(folders, items)
+	b.flat = FlattenMediaTree(b.roots)
+	b.cursor = 0
+	for i, node := range b.flat {
+		if node.Media != nil && string(node.Media.MediaID) == b.value {
+			b.label = node.Label
+			b.cursor = i
+			break
+		}
+	}
+}
+
+func (b *MediaPickerBubble) updateBrowse(key string) {
+	var node *MediaTreeNode
+	if b.cursor < len(b.flat) {
+		node = b.flat[b.cursor]
+	}
+	switch key {
+	case "esc":
+		b.open = false
+	case "up", "k":
+		if b.cursor > 0 {
+			b.cursor--
+		}
+	case "down", "j":
+		if b.cursor < len(b.flat)-1 {
+			b.cursor++
+		}
+	case "right", "l":
+		if node != nil && node.hasChildren() && !node.Expand {
+			b.toggle(node)
+		}
+	case "left", "h":
+		if node != nil && node.hasChildren() && node.Expand {
+			b.toggle(node)
+		}
+	case "enter", "space":
+		if node == nil {
+			return
+		}
+		if node.Kind == MediaNodeFolder {
+			b.toggle(node)
+			return
+		}
+		if node.Media != nil {
+			b.value = string(node.Media.MediaID)
+			b.label = node.Label
+			b.open = false
+		}
+	}
+}
+
+// toggle flips a folder's expand state and keeps the cursor on it.
+func (b *MediaPickerBubble) toggle(node *MediaTreeNode) {
+	node.Expand = !node.Expand
+	b.flat = FlattenMediaTree(b.roots)
+	for i, n := range b.flat {
+		if n == node {
+			b.cursor = i
+			return
+		}
+	}
+}
+
+// View renders the selection, plus the folder tree while browsing.
+func (b *MediaPickerBubble) View() string {
+	hint := pickerHintStyle()
+	var sb strings.Builder
+	sb.WriteString(b.selectionLine())
+	if b.focused && !b.open {
+		sb.WriteString(" " + hint.Render("[enter: browse, backspace: clear]"))
+	}
+	if b.errMsg != "" {
+		sb.WriteString("\n" + hint.Render("  "+b.errMsg))
+	}
+	if !b.open {
+		return sb.String()
+	}
+
+	sb.WriteString("\n")
+	switch {
+	case b.loading:
+		sb.WriteString(hint.Render("  loading media..."))
+	case len(b.flat) == 0 && b.errMsg == "":
+		sb.WriteString(hint.Render("  (no media)"))
+	default:
+		folderStyle := lipgloss.NewStyle().Bold(true)
+		start, end := pickerWindow(len(b.flat), b.cursor, pickerListHeight)
+		lines := make([]string, 0, end-start)
+		for i := start; i < end; i++ {
+			node := b.flat[i]
+			cursor := "  "
+			if i == b.cursor {
+				cursor = "->"
+			}
+			label := node.Label
+			if node.Kind == MediaNodeFolder {
+				arrow := "▸"
+				if node.Expand {
+					arrow = "▾"
+				}
+				label = folderStyle.Render(arrow + " " + node.Label + "/")
+			} else if node.Media != nil && string(node.Media.MediaID) == b.value {
+				label += " *"
+			}
+			lines = append(lines, fmt.Sprintf("%s %s%s", cursor, strings.Repeat("  ", node.Depth), label))
+		}
+		sb.WriteString(strings.Join(lines, "\n"))
+	}
+	sb.WriteString("\n" + hint.Render("  enter: pick  ←/→: collapse/expand  esc: close"))
+	return sb.String()
+}
+
+func (b *MediaPickerBubble) selectionLine() string {
+	switch {
+	case b.value == "":
+		return "(no media)"
+	case b.label != "":
+		return fmt.Sprintf("%s (%s)", b.label, b.value)
+	default:
+		return b.value
+	}
+}
+
+// Value returns the selected media ID.
+func (b *MediaPickerBubble) Value() string { return b.value }
+
+// SetValue sets the selected media ID. Its display name is resolved once the
+// library has been loaded.
+func (b *MediaPickerBubble) SetValue(v string) {
+	b.value = v
+	b.label = ""
+	for _, node := range b.flat {
+		if node.Media != nil && string(node.Media.MediaID) == v {
+			b.label = node.Label
+			break
+		}
+	}
+}
+
+// Focus gives the bubble input focus.
+func (b *MediaPickerBubble) Focus() tea.Cmd {
+	b.focused = true
+	return nil
+}
+
+// Blur removes input focus and closes the browser. A load still in flight
+// is delivered to whichever bubble has focus by then, so it is forgotten
+// here and retried on the next open.
+func (b *MediaPickerBubble) Blur() {
+	b.focused = false
+	b.open = false
+	b.loading = false
+}
+
+// Focused returns whether the bubble currently has focus.
+func (b *MediaPickerBubble) Focused() bool { return b.focused }
+
+// SetWidth sets the display width for layout.
+func (b *MediaPickerBubble) SetWidth(w int) { b.width = w }
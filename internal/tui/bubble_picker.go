@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"charm.land/lipgloss/v2"
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+)
+
+// pickerListHeight is how many rows a picker bubble shows while browsing.
+const pickerListHeight = 8
+
+// pickerSource is implemented by picker bubbles that browse CMS data.
+// The content form hands them a driver after construction; admin selects
+// admin content where a picker has an admin variant.
+type pickerSource interface {
+	SetSource(d db.DbDriver, admin bool)
+}
+
+// pickerWindow returns the [start, end) slice of a list of total rows that
+// keeps cursor visible in a window of height rows.
+func pickerWindow(total, cursor, height int) (int, int) {
+	if total <= height {
+		return 0, total
+	}
+	start := cursor - height/2
+	if start < 0 {
+		start = 0
+	}
+	if start+height > total {
+		start = total - height
+	}
+	return start, start + height
+}
+
+// pickerHintStyle renders the key hints and status lines of picker bubbles.
+func pickerHintStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(config.DefaultStyle.Tertiary).Italic(true)
+}
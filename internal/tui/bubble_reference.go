@@ -0,0 +1,377 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+func init() {
+	RegisterFieldInput(FieldInputEntry{
+		Key:         "_id",
+		Label:       "Reference",
+		Description: "Content reference picker",
+		NewBubble:   func() FieldBubble { return NewReferencePickerBubble() },
+	})
+}
+
+// referencePickerLoadedMsg carries the content select tree for a
+// ReferencePickerBubble.
+type referencePickerLoadedMsg struct {
+	Roots []*ContentSelectNode
+	Err   error
+}
+
+// referenceRow is one visible row of the reference picker's tree.
+type referenceRow struct {
+	node  *ContentSelectNode
+	depth int
+}
+
+// ReferencePickerBubble is a FieldBubble for _id reference fields. It stores
+// a content ID chosen from the same slug-grouped tree the content screen
+// uses. The tree is loaded the first time the picker opens.
+//
+// Closed: enter opens the browser, backspace/delete clears the value.
+// Browsing: up/down or j/k move, right/l and left/h expand and collapse
+// groups, enter picks content (or toggles a group), esc closes.
+type ReferencePickerBubble struct {
+	source db.DbDriver
+	admin  bool
+
+	value   string // referenced content ID
+	label   string // display label of the referenced content, once known
+	focused bool
+	width   int
+
+	open    bool
+	loading bool
+	loaded  bool
+	errMsg  string
+	roots   []*ContentSelectNode
+	rows    []referenceRow
+	cursor  int
+}
+
+// NewReferencePickerBubble creates an empty reference picker. The content
+// form supplies its data source via SetSource.
+func NewReferencePickerBubble() *ReferencePickerBubble {
+	return &ReferencePickerBubble{}
+}
+
+// SetSource sets the driver the picker loads content from. Admin pickers
+// browse admin content.
+func (b *ReferencePickerBubble) SetSource(d db.DbDriver, admin bool) {
+	b.source = d
+	b.admin = admin
+}
+
+// CapturesKey claims every key while browsing, and enter while closed so
+// it opens the browser instead of moving to the next field.
+func (b *ReferencePickerBubble) CapturesKey(key string) bool {
+	return b.open || key == "enter"
+}
+
+// Update handles browsing keys and the tree load result.
+func (b *ReferencePickerBubble) Update(msg tea.Msg) (FieldBubble, tea.Cmd) {
+	switch msg := msg.(type) {
+	case referencePickerLoadedMsg:
+		b.loading = false
+		if msg.Err != nil {
+			b.errMsg = msg.Err.Error()
+			return b, nil
+		}
+		b.loaded = true
+		b.setTree(msg.Roots)
+		return b, nil
+	case tea.KeyPressMsg:
+		if !b.focused {
+			return b, nil
+		}
+		if b.open {
+			b.updateBrowse(msg.String())
+			return b, nil
+		}
+		switch msg.String() {
+		case "enter":
+			b.open = true
+			b.errMsg = ""
+			return b, b.load()
+		case "backspace", "delete":
+			b.value = ""
+			b.label = ""
+		}
+	}
+	return b, nil
+}
+
+// load returns a command that builds the content select tree, or nil if it
+// is already loaded or there is nothing to load from.
+func (b *ReferencePickerBubble) load() tea.Cmd {
+	if b.loaded || b.loading {
+		return nil
+	}
+	if b.source == nil {
+		b.errMsg = "database not connected"
+		return nil
+	}
+	b.loading = true
+	d := b.source
+	page := db.PaginationParams{Limit: 10000, Offset: 0}
+	if b.admin {
+		return func() tea.Msg {
+			items, err := d.ListAdminContentDataTopLevelPaginated(page)
+			if err != nil {
+				return referencePickerLoadedMsg{Err: err}
+			}
+			if items == nil {
+				return referencePickerLoadedMsg{}
+			}
+			titleMap := resolveAdminTitleFields(d, *items)
+			return referencePickerLoadedMsg{Roots: BuildAdminContentSelectTree(*items, titleMap)}
+		}
+	}
+	return func() tea.Msg {
+		items, err := d.ListContentDataTopLevelPaginated(page)
+		if err != nil {
+			return referencePickerLoadedMsg{Err: err}
+		}
+		if items == nil {
+			return referencePickerLoadedMsg{}
+		}
+		titleMap := resolveTitleFields(d, *items)
+		return referencePickerLoadedMsg{Roots: BuildContentSelectTree(*items, titleMap)}
+	}
+}
+
+// setTree installs the tree and resolves the label of the current value.
+func (b *ReferencePickerBubble) setTree(roots []*ContentSelectNode) {
+	b.roots = roots
+	b.rebuildRows()
+	b.cursor = 0
+	for i, row := range b.rows {
+		if referenceNodeID(row.node) == b.value && b.value != "" {
+			b.label = referenceNodeLabel(row.node)
+			b.cursor = i
+			break
+		}
+	}
+}
+
+// rebuildRows flattens the tree depth-first, respecting expand state.
+// Section headers are skipped, as in FlattenSelectTree.
+func (b *ReferencePickerBubble) rebuildRows() {
+	b.rows = b.rows[:0]
+	var walk func(node *ContentSelectNode, depth int)
+	walk = func(node *ContentSelectNode, depth int) {
+		b.rows = append(b.rows, referenceRow{node: node, depth: depth})
+		if !node.Expand {
+			return
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child, depth+1)
+		}
+	}
+	for _, node := range b.roots {
+		if node.Kind == NodeSection {
+			continue
+		}
+		walk(node, 0)
+	}
+}
+
+// referenceNodeID returns the content ID a node refers to, or "" for pure
+// grouping nodes.
+func referenceNodeID(node *ContentSelectNode) string {
+	switch {
+	case node.Content != nil:
+		return string(node.Content.ContentDataID)
+	case node.AdminContent != nil:
+		return string(node.AdminContent.AdminContentDataID)
+	default:
+		return ""
+	}
+}
+
+// referenceNodeLabel returns the node label with its datatype, as the
+// content screen shows it.
+func referenceNodeLabel(node *ContentSelectNode) string {
+	var datatype string
+	switch {
+	case node.Content != nil:
+		datatype = node.Content.DatatypeLabel
+	case node.AdminContent != nil:
+		datatype = node.AdminContent.DatatypeLabel
+	}
+	if datatype == "" {
+		return node.Label
+	}
+	return fmt.Sprintf("%s [%s]", node.Label, datatype)
+}
+
+func (b *ReferencePickerBubble) updateBrowse(key string) {
+	var node *ContentSelectNode
+	if b.cursor < len(b.rows) {
+		node = b.rows[b.cursor].node
+	}
+	switch key {
+	case "esc":
+		b.open = false
+	case "up", "k":
+		if b.cursor > 0 {
+			b.cursor--
+		}
+	case "down", "j":
+		if b.cursor < len(b.rows)-1 {
+			b.cursor++
+		}
+	case "right", "l":
+		if node != nil && node.hasChildren() && !node.Expand {
+			b.toggle(node)
+		}
+	case "left", "h":
+		if node != nil && node.hasChildren() && node.Expand {
+			b.toggle(node)
+		}
+	case "enter", "space":
+		if node == nil {
+			return
+		}
+		if id := referenceNodeID(node); id != "" {
+			b.value = id
+			b.label = referenceNodeLabel(node)
+			b.open = false
+			return
+		}
+		if node.hasChildren() {
+			b.toggle(node)
+		}
+	}
+}
+
+// toggle flips a node's expand state and keeps the cursor on it.
+func (b *ReferencePickerBubble) toggle(node *ContentSelectNode) {
+	node.Expand = !node.Expand
+	b.rebuildRows()
+	for i, row := range b.rows {
+		if row.node == node {
+			b.cursor = i
+			return
+		}
+	}
+}
+
+// View renders the selection, plus the content tree while browsing.
+func (b *ReferencePickerBubble) View() string {
+	hint := pickerHintStyle()
+	var sb strings.Builder
+	sb.WriteString(b.selectionLine())
+	if b.focused && !b.open {
+		sb.WriteString(" " + hint.Render("[enter: browse, backspace: clear]"))
+	}
+	if b.errMsg != "" {
+		sb.WriteString("\n" + hint.Render("  "+b.errMsg))
+	}
+	if !b.open {
+		return sb.String()
+	}
+
+	sb.WriteString("\n")
+	switch {
+	case b.loading:
+		sb.WriteString(hint.Render("  loading content..."))
+	case len(b.rows) == 0 && b.errMsg == "":
+		sb.WriteString(hint.Render("  (no content)"))
+	default:
+		start, end := pickerWindow(len(b.rows), b.cursor, pickerListHeight)
+		lines := make([]string, 0, end-start)
+		for i := start; i < end; i++ {
+			row := b.rows[i]
+			cursor := "  "
+			if i == b.cursor {
+				cursor = "->"
+			}
+			marker := "  "
+			if row.node.hasChildren() {
+				marker = "▶ "
+				if row.node.Expand {
+					marker = "▼ "
+				}
+			}
+			label := row.node.Label
+			if referenceNodeID(row.node) != "" {
+				label = referenceNodeLabel(row.node)
+				if referenceNodeID(row.node) == b.value {
+					label += " *"
+				}
+			}
+			lines = append(lines, fmt.Sprintf("%s %s%s%s", cursor, strings.Repeat("  ", row.depth), marker, label))
+		}
+		sb.WriteString(strings.Join(lines, "\n"))
+	}
+	sb.WriteString("\n" + hint.Render("  enter: pick  ←/→: collapse/expand  esc: close"))
+	return sb.String()
+}
+
+func (b *ReferencePickerBubble) selectionLine() string {
+	switch {
+	case b.value == "":
+		return "(no reference)"
+	case b.label != "":
+		return fmt.Sprintf("%s (%s)", b.label, b.value)
+	default:
+		return b.value
+	}
+}
+
+// Value returns the referenced content ID.
+func (b *ReferencePickerBubble) Value() string { return b.value }
+
+// SetValue sets the referenced content ID. Its label is resolved once the
+// tree has been loaded.
+func (b *ReferencePickerBubble) SetValue(v string) {
+	b.value = v
+	b.label = ""
+	if v == "" {
+		return
+	}
+	for _, row := range b.rows {
+		if referenceNodeID(row.node) == v {
+			b.label = referenceNodeLabel(row.node)
+			break
+		}
+	}
+}
+
+// Focus gives the bubble input focus.
+func (b *ReferencePickerBubble) Focus() tea.Cmd {
+	b.focused = true
+	return nil
+}
+
+// Blur removes input focus and closes the browser. A load still in flight
+// is delivered to whichever bubble has focus by then, so it is forgotten
+// here and retried on the next open.
+func (b *ReferencePickerBubble) Blur() {
+	b.focused = false
+	b.open = false
+	b.loading = false
+}
+
+// Focused returns whether the bubble currently has focus.
+func (b *ReferencePickerBubble) Focused() bool { return b.focused }
+
+// SetWidth sets the display width for layout.
+func (b *ReferencePickerBubble) SetWidth(w int) { b.width = w }
+
+// referenceFieldType maps _id-prefixed field types (_id, _id_menu, ...) to
+// the registered reference picker key; other types are returned unchanged.
+func referenceFieldType(fieldType string) string {
+	if types.FieldType(fieldType).IsIDRefType() {
+		return string(types.FieldTypeIDRef)
+	}
+	return fieldType
+}
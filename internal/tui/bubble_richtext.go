@@ -0,0 +1,24 @@
+package tui
+
+func init() {
+	RegisterFieldInput(FieldInputEntry{
+		Key:         "richtext",
+		Label:       "Rich Text",
+		Description: "Multi-line rich text (HTML) with $EDITOR support",
+		NewBubble:   func() FieldBubble { return NewRichTextBubble() },
+	})
+}
+
+// NewRichTextBubble creates a TextareaBubble sized for rich text: taller,
+// numbered and without the plain textarea's character limit. Long-form
+// editing is expected to round-trip through $EDITOR (ctrl+e).
+func NewRichTextBubble() *TextareaBubble {
+	b := NewTextareaBubble()
+	b.input.Placeholder = "Enter rich text..."
+	b.input.CharLimit = 0
+	b.input.MaxHeight = 0
+	b.input.SetHeight(8)
+	b.input.ShowLineNumbers = true
+	b.multiline = true
+	return b
+}
@@ -1,9 +1,14 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/validation"
 )
 
 func runeKey(r rune) tea.KeyPressMsg {
@@ -326,3 +331,204 @@ func TestBooleanBubble_SetValue(t *testing.T) {
 		})
 	}
 }
+
+// --- Datetime ---
+
+func TestDatePickerBubble_DateTimeValue(t *testing.T) {
+	inputs := []string{
+		"2024-03-05T14:30:00Z",
+		"2024-03-05T14:30:00",
+		"2024-03-05 14:30:00",
+		"2024-03-05T14:30",
+	}
+	for _, in := range inputs {
+		t.Run(in, func(t *testing.T) {
+			b := FieldBubbleForType("datetime")
+			b.SetValue(in)
+			got := b.Value()
+			if got != "2024-03-05T14:30:00" {
+				t.Errorf("SetValue(%q): expected %q, got %q", in, "2024-03-05T14:30:00", got)
+			}
+			fe := validation.ValidateField(validation.FieldInput{FieldType: types.FieldTypeDatetime, Value: got})
+			if fe != nil {
+				t.Errorf("value %q fails datetime validation: %v", got, fe.Messages)
+			}
+		})
+	}
+}
+
+// --- JSONBubble ---
+
+func TestJSONBubble_Problem(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string // substring of the problem, "" for valid
+	}{
+		{"valid", `{"a": [1, 2]}`, ""},
+		{"empty", "", ""},
+		{"syntax first line", `{"a": }`, "line 1, col 7"},
+		{"syntax second line", "{\n  \"a\": tru\n}", "line 2, col"},
+		{"truncated", `{"a": 1`, "unexpected end"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewJSONBubble()
+			b.SetValue(tt.value)
+			if tt.want == "" {
+				if b.problem != "" {
+					t.Errorf("expected no problem, got %q", b.problem)
+				}
+				return
+			}
+			if !strings.Contains(b.problem, tt.want) {
+				t.Errorf("problem = %q, want it to contain %q", b.problem, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONBubble_ValidationRules(t *testing.T) {
+	b := NewJSONBubble()
+	b.Configure(`{"rules": [{"rule": {"op": "required"}}]}`, "")
+	if b.problem != "is required" {
+		t.Errorf("empty required field: problem = %q, want %q", b.problem, "is required")
+	}
+	b.SetValue(`{}`)
+	if b.problem != "" {
+		t.Errorf("filled required field: problem = %q, want none", b.problem)
+	}
+}
+
+func TestJSONBubble_Format(t *testing.T) {
+	b := NewJSONBubble()
+	b.Focus()
+	b.SetValue(`{"a":1,"b":[true]}`)
+	b.Update(tea.KeyPressMsg{Code: 'f', Mod: tea.ModCtrl})
+	want := "{\n  \"a\": 1,\n  \"b\": [\n    true\n  ]\n}"
+	if got := b.Value(); got != want {
+		t.Errorf("formatted value = %q, want %q", got, want)
+	}
+}
+
+// --- MediaPickerBubble ---
+
+func TestMediaPickerBubble_Pick(t *testing.T) {
+	folderID := types.NewMediaFolderID()
+	folders := []db.MediaFolder{{FolderID: folderID, Name: "Photos"}}
+	inFolder := db.Media{MediaID: types.NewMediaID(), Name: db.NewNullString("beach.jpg"), FolderID: types.NullableMediaFolderID{ID: folderID, Valid: true}}
+	unfiled := db.Media{MediaID: types.NewMediaID(), Name: db.NewNullString("logo.png")}
+
+	b := NewMediaPickerBubble()
+	b.SetValue(string(unfiled.MediaID))
+	b.Focus()
+	if !b.CapturesKey("enter") || b.CapturesKey("tab") {
+		t.Fatal("closed picker should capture only enter")
+	}
+	b.Update(namedKey(tea.KeyEnter))
+	if !b.open || b.errMsg == "" {
+		t.Fatalf("opening without a source: open=%t errMsg=%q, want open with an error", b.open, b.errMsg)
+	}
+	b.Update(mediaPickerLoadedMsg{Folders: folders, Items: []db.Media{inFolder, unfiled}})
+	if b.label != "logo.png" {
+		t.Errorf("label of existing value = %q, want %q", b.label, "logo.png")
+	}
+	if !b.CapturesKey("tab") {
+		t.Error("open picker should capture every key")
+	}
+
+	// Tree is [Photos/, beach.jpg, logo.png] with the cursor on logo.png.
+	b.Update(namedKey(tea.KeyUp))
+	b.Update(namedKey(tea.KeyEnter))
+	if got := b.Value(); got != string(inFolder.MediaID) {
+		t.Errorf("picked %q, want %q", got, inFolder.MediaID)
+	}
+	if b.open {
+		t.Error("picker should close after picking")
+	}
+
+	b.Update(namedKey(tea.KeyBackspace))
+	if got := b.Value(); got != "" {
+		t.Errorf("after clear, value = %q, want empty", got)
+	}
+}
+
+// --- ReferencePickerBubble ---
+
+func TestReferencePickerBubble_Pick(t *testing.T) {
+	home := makeTopLevel("/", "Page", "_root", true)
+	about := makeTopLevel("/about", "Page", "_root", true)
+	roots := BuildContentSelectTree([]db.ContentDataTopLevel{home, about}, nil)
+
+	b := NewReferencePickerBubble()
+	b.Focus()
+	b.Update(namedKey(tea.KeyEnter))
+	b.Update(referencePickerLoadedMsg{Roots: roots})
+	if len(b.rows) != 2 {
+		t.Fatalf("rows = %d, want 2", len(b.rows))
+	}
+	b.Update(namedKey(tea.KeyDown))
+	b.Update(namedKey(tea.KeyEnter))
+	if got := b.Value(); got != string(about.ContentDataID) {
+		t.Errorf("picked %q, want %q", got, about.ContentDataID)
+	}
+	if !strings.Contains(b.View(), "[Page]") {
+		t.Errorf("view %q should show the datatype label", b.View())
+	}
+}
+
+func TestResolveBubble_Editors(t *testing.T) {
+	tests := []struct {
+		fieldType string
+		want      string
+	}{
+		{"_id", "*tui.ReferencePickerBubble"},
+		{"_id_menu", "*tui.ReferencePickerBubble"},
+		{"media", "*tui.MediaPickerBubble"},
+		{"json", "*tui.JSONBubble"},
+		{"richtext", "*tui.TextareaBubble"},
+		{"datetime", "*tui.DatePickerBubble"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf("%T", resolveBubble(tt.fieldType, "")); got != tt.want {
+			t.Errorf("resolveBubble(%q) = %s, want %s", tt.fieldType, got, tt.want)
+		}
+	}
+}
+
+// --- ContentFormDialogModel ---
+
+func TestContentFormDialog_PickerCapturesKeys(t *testing.T) {
+	d := NewContentFormDialog("New", FORMDIALOGCREATECONTENT, "", "", []db.Fields{
+		{FieldID: "f-media", Label: "Image", Type: types.FieldTypeMedia},
+		{FieldID: "f-body", Label: "Body", Type: types.FieldTypeRichText},
+	})
+	d, _ = d.Update(namedKey(tea.KeyEnter))
+	if d.focusIndex != 0 {
+		t.Fatalf("enter on a picker moved focus to %d", d.focusIndex)
+	}
+	d, cmd := d.Update(namedKey(tea.KeyEscape))
+	if cmd != nil {
+		t.Error("esc in an open picker should close the picker, not the dialog")
+	}
+	d, _ = d.Update(namedKey(tea.KeyTab))
+	if d.focusIndex != 1 {
+		t.Fatalf("tab moved focus to %d, want 1", d.focusIndex)
+	}
+	d, _ = d.Update(namedKey(tea.KeyEnter))
+	if d.focusIndex != 1 || d.Fields[1].Bubble.Value() != "\n" {
+		t.Errorf("enter in richtext: focus %d value %q, want a newline in field 1", d.focusIndex, d.Fields[1].Bubble.Value())
+	}
+
+	if got := d.editorWidget(d.Fields[1]); got != "" {
+		t.Errorf("editorWidget without ExternalEditor = %q, want none", got)
+	}
+	d.ConfigureEditors(nil, false, false)
+	if got := d.editorWidget(d.Fields[1]); got != "rich-text" {
+		t.Errorf("editorWidget for richtext = %q, want %q", got, "rich-text")
+	}
+	d.ConfigureEditors(nil, false, true)
+	if got := d.editorWidget(d.Fields[1]); got != "" {
+		t.Errorf("editorWidget over SSH = %q, want none", got)
+	}
+}
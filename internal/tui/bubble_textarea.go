@@ -16,7 +16,8 @@ func init() {
 
 // TextareaBubble wraps textarea.Model as a FieldBubble for multi-line text input.
 type TextareaBubble struct {
-	input textarea.Model
+	input     textarea.Model
+	multiline bool // enter and up/down edit the text instead of moving between fields
 }
 
 // NewTextareaBubble creates a new TextareaBubble with default configuration.
@@ -37,6 +38,15 @@ func (b *TextareaBubble) Update(msg tea.Msg) (FieldBubble, tea.Cmd) {
 	return b, cmd
 }
 
+// CapturesKey claims enter and up/down for multi-line editors so they insert
+// newlines and move between lines; tab still moves to the next field.
+func (b *TextareaBubble) CapturesKey(key string) bool {
+	return b.multiline && multilineKeys[key]
+}
+
+// multilineKeys are the keys multi-line editors take from the content form.
+var multilineKeys = map[string]bool{"enter": true, "up": true, "down": true}
+
 // View returns the rendered representation of the textarea bubble.
 func (b *TextareaBubble) View() string { return b.input.View() }
 
@@ -6,11 +6,11 @@ import (
 	"fmt"
 
 	tea "charm.land/bubbletea/v2"
-	"github.com/hegner123/modulacms/internal/auth"
 	"github.com/hegner123/modulacms/internal/config"
 	"github.com/hegner123/modulacms/internal/db"
 	"github.com/hegner123/modulacms/internal/db/types"
 	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/service"
 	"github.com/hegner123/modulacms/internal/utility"
 )
 
@@ -246,7 +246,9 @@ func (m Model) GetFullTree(c *config.Config, id types.RouteID) tea.Cmd {
 // USER CRUD
 // =============================================================================
 
-// HandleCreateUserFromDialog processes the user creation request
+// HandleCreateUserFromDialog processes the user creation request through the
+// UserService, which enforces the password policy and records the primary
+// role in user_roles.
 func (m Model) HandleCreateUserFromDialog(msg CreateUserFromDialogRequestMsg) tea.Cmd {
 	cfg := m.Config
 	if cfg == nil {
@@ -259,6 +261,8 @@ func (m Model) HandleCreateUserFromDialog(msg CreateUserFromDialogRequestMsg) te
 	}
 
 	userID := m.UserID
+	mgr := m.ConfigManager
+	pc := m.Permissions
 	return func() tea.Msg {
 		d := db.ConfigDB(*cfg)
 		ctx := context.Background()
@@ -272,40 +276,21 @@ func (m Model) HandleCreateUserFromDialog(msg CreateUserFromDialogRequestMsg) te
 			}
 		}
 
-		if msg.Password == "" {
-			return ActionResultMsg{
-				Title:   "Password Required",
-				Message: "Password must not be empty",
-			}
-		}
-
-		hash, err := auth.HashPassword(msg.Password)
-		if err != nil {
-			return ActionResultMsg{
-				Title:   "Password Error",
-				Message: fmt.Sprintf("Could not hash password: %v", err),
-			}
-		}
-
-		user, err := d.CreateUser(ctx, ac, db.CreateUserParams{
-			Username:     msg.Username,
-			Name:         msg.Name,
-			Email:        email,
-			Hash:         hash,
-			Role:         msg.Role,
-			DateCreated:  types.TimestampNow(),
-			DateModified: types.TimestampNow(),
+		user, err := service.NewUserService(d, mgr, pc).CreateUser(ctx, ac, service.CreateUserInput{
+			Username: msg.Username,
+			Name:     msg.Name,
+			Email:    email,
+			Password: msg.Password,
+			Role:     types.RoleID(msg.Role),
+			IsAdmin:  callerIsAdmin(d, pc, userID),
 		})
 		if err != nil {
-			return ActionResultMsg{
-				Title:   "Error",
-				Message: fmt.Sprintf("failed to create user: %v", err),
+			if title, ok := userErrorTitle(err); ok {
+				return ActionResultMsg{Title: title, Message: err.Error()}
 			}
-		}
-		if user == nil {
 			return ActionResultMsg{
 				Title:   "Error",
-				Message: "failed to create user in database",
+				Message: fmt.Sprintf("failed to create user: %v", err),
 			}
 		}
 
@@ -316,7 +301,8 @@ func (m Model) HandleCreateUserFromDialog(msg CreateUserFromDialogRequestMsg) te
 	}
 }
 
-// HandleUpdateUserFromDialog processes the user update request
+// HandleUpdateUserFromDialog processes the user update request through the
+// UserService, which keeps user_roles in step with a primary role change.
 func (m Model) HandleUpdateUserFromDialog(msg UpdateUserFromDialogRequestMsg) tea.Cmd {
 	cfg := m.Config
 	if cfg == nil {
@@ -329,6 +315,8 @@ func (m Model) HandleUpdateUserFromDialog(msg UpdateUserFromDialogRequestMsg) te
 	}
 
 	callerUserID := m.UserID
+	mgr := m.ConfigManager
+	pc := m.Permissions
 	return func() tea.Msg {
 		d := db.ConfigDB(*cfg)
 		ctx := context.Background()
@@ -336,15 +324,6 @@ func (m Model) HandleUpdateUserFromDialog(msg UpdateUserFromDialogRequestMsg) te
 
 		userID := types.UserID(msg.UserID)
 
-		// Get existing user to preserve hash
-		existingUser, err := d.GetUser(userID)
-		if err != nil {
-			return ActionResultMsg{
-				Title:   "Error",
-				Message: fmt.Sprintf("user not found: %v", err),
-			}
-		}
-
 		email := types.Email(msg.Email)
 		if err := email.Validate(); err != nil {
 			return ActionResultMsg{
@@ -353,17 +332,19 @@ func (m Model) HandleUpdateUserFromDialog(msg UpdateUserFromDialogRequestMsg) te
 			}
 		}
 
-		_, err = d.UpdateUser(ctx, ac, db.UpdateUserParams{
-			UserID:       userID,
-			Username:     msg.Username,
-			Name:         msg.Name,
-			Email:        email,
-			Hash:         existingUser.Hash, // Preserve existing hash
-			Role:         msg.Role,
-			DateCreated:  existingUser.DateCreated,
-			DateModified: types.TimestampNow(),
+		// An empty password keeps the existing hash.
+		_, err := service.NewUserService(d, mgr, pc).UpdateUser(ctx, ac, service.UpdateUserInput{
+			UserID:   userID,
+			Username: msg.Username,
+			Name:     msg.Name,
+			Email:    email,
+			Role:     types.RoleID(msg.Role),
+			IsAdmin:  callerIsAdmin(d, pc, callerUserID),
 		})
 		if err != nil {
+			if title, ok := userErrorTitle(err); ok {
+				return ActionResultMsg{Title: title, Message: err.Error()}
+			}
 			return ActionResultMsg{
 				Title:   "Error",
 				Message: fmt.Sprintf("failed to update user: %v", err),
@@ -377,6 +358,30 @@ func (m Model) HandleUpdateUserFromDialog(msg UpdateUserFromDialogRequestMsg) te
 	}
 }
 
+// callerIsAdmin reports whether the TUI user holds an admin role, which the
+// UserService requires before it assigns or changes roles.
+func callerIsAdmin(d db.DbDriver, pc *middleware.PermissionCache, userID types.UserID) bool {
+	if pc == nil {
+		return false
+	}
+	u, err := d.GetUser(userID)
+	if err != nil {
+		return false
+	}
+	return pc.IsAdminUser(u.UserID, types.RoleID(u.Role))
+}
+
+// userErrorTitle maps UserService errors the user can act on to a dialog title.
+func userErrorTitle(err error) (string, bool) {
+	switch {
+	case service.IsValidation(err), service.IsConflict(err):
+		return "Validation Error", true
+	case service.IsForbidden(err):
+		return "Forbidden", true
+	}
+	return "", false
+}
+
 // HandleDeleteUser deletes a user
 func (m Model) HandleDeleteUser(msg DeleteUserRequestMsg) tea.Cmd {
 	logger := m.Logger
@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/service"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// =============================================================================
+// MESSAGES
+// =============================================================================
+
+// ShowRouteFromBlueprintDialogMsg opens the dialog that creates a route from
+// one of the saved blueprints.
+type ShowRouteFromBlueprintDialogMsg struct {
+	Blueprints []db.Blueprint
+}
+
+// InstantiateBlueprintRequestMsg creates a route with Title and Slug whose
+// content tree is instantiated from the blueprint.
+type InstantiateBlueprintRequestMsg struct {
+	BlueprintID string
+	Title       string
+	Slug        string
+}
+
+// ShowRouteFromBlueprintDialogCmd loads the blueprints and opens the dialog,
+// or reports that none are saved.
+func ShowRouteFromBlueprintDialogCmd(d db.DbDriver) tea.Cmd {
+	return func() tea.Msg {
+		blueprints, err := d.ListBlueprints()
+		if err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to list blueprints: %v", err)}
+		}
+		if blueprints == nil || len(*blueprints) == 0 {
+			return ActionResultMsg{Title: "Info", Message: "no blueprints saved"}
+		}
+		return ShowRouteFromBlueprintDialogMsg{Blueprints: *blueprints}
+	}
+}
+
+// InstantiateBlueprintCmd creates a command to instantiate a blueprint at a
+// new route.
+func InstantiateBlueprintCmd(blueprintID, title, slug string) tea.Cmd {
+	return func() tea.Msg {
+		return InstantiateBlueprintRequestMsg{
+			BlueprintID: blueprintID,
+			Title:       title,
+			Slug:        slug,
+		}
+	}
+}
+
+// =============================================================================
+// DIALOG
+// =============================================================================
+
+// NewRouteFromBlueprintDialog creates a form dialog with Title and Slug inputs
+// and a carousel of blueprints.
+func NewRouteFromBlueprintDialog(title string, blueprints []db.Blueprint) FormDialogModel {
+	dialog := NewRouteWithContentDialog(title, FORMDIALOGCREATEROUTEFROMBLUEPRINT, nil)
+	dialog.ParentOptions = make([]ParentOption, len(blueprints))
+	for i, bp := range blueprints {
+		dialog.ParentOptions[i] = ParentOption{
+			Label: bp.Label,
+			Value: bp.BlueprintID,
+		}
+	}
+	return dialog
+}
+
+// =============================================================================
+// HANDLERS
+// =============================================================================
+
+// HandleInstantiateBlueprint runs BlueprintService.Instantiate for a new
+// route. The route's title and slug are passed as the "title" and "slug"
+// variables when the blueprint declares them; other variables take their
+// defaults.
+func (m Model) HandleInstantiateBlueprint(msg InstantiateBlueprintRequestMsg) tea.Cmd {
+	cfg := m.Config
+	userID := m.UserID
+	mgr := m.ConfigManager
+
+	if cfg == nil || mgr == nil {
+		return func() tea.Msg {
+			return ActionResultMsg{Title: "Error", Message: "configuration not loaded"}
+		}
+	}
+	if userID.IsZero() {
+		return func() tea.Msg {
+			return ActionResultMsg{Title: "Error", Message: "Cannot create route: no user is logged in"}
+		}
+	}
+
+	return func() tea.Msg {
+		d := db.ConfigDB(*cfg)
+		ctx := context.Background()
+		ac := middleware.AuditContextFromCLI(*cfg, userID)
+		svc := service.NewBlueprintService(d, mgr, service.NewContentService(d, mgr, nil), service.NewRouteService(d, mgr))
+
+		bp, err := svc.Get(ctx, msg.BlueprintID)
+		if err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to load blueprint: %v", err)}
+		}
+		vars := map[string]string{}
+		for _, v := range bp.Definition.Variables {
+			switch v.Name {
+			case "title":
+				vars[v.Name] = msg.Title
+			case "slug":
+				vars[v.Name] = msg.Slug
+			}
+		}
+
+		result, err := svc.Instantiate(ctx, ac, service.InstantiateBlueprintInput{
+			Blueprint: bp.BlueprintID,
+			Slug:      msg.Slug,
+			Title:     msg.Title,
+			Variables: vars,
+		})
+		if err != nil {
+			if service.IsValidation(err) || service.IsConflict(err) {
+				return ActionResultMsg{Title: "Validation Error", Message: err.Error()}
+			}
+			utility.DefaultLogger.Ferror(fmt.Sprintf("failed to instantiate blueprint %s", bp.Name), err)
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to instantiate blueprint: %v", err)}
+		}
+
+		root, err := d.GetContentData(result.ContentDataID)
+		if err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to load new content: %v", err)}
+		}
+		return RouteWithContentCreatedMsg{
+			RouteID:       result.RouteID.ID,
+			ContentDataID: result.ContentDataID,
+			DatatypeID:    root.DatatypeID.ID,
+			Title:         msg.Title,
+			Slug:          msg.Slug,
+		}
+	}
+}
@@ -1,9 +1,12 @@
 package tui
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 
@@ -12,6 +15,7 @@ import (
 	"github.com/hegner123/modulacms/internal/db/types"
 	"github.com/hegner123/modulacms/internal/middleware"
 	"github.com/hegner123/modulacms/internal/service"
+	"github.com/hegner123/modulacms/internal/spreadsheet"
 )
 
 // RunImportCmd reads the file and executes the import via the service layer.
@@ -30,7 +34,7 @@ func RunImportCmd(ctx AppContext, format config.OutputFormat, path string) tea.C
 		bgCtx := context.Background()
 		ac := middleware.AuditContextFromCLI(*cfg, userID)
 
-		importSvc := service.NewImportService(d, configMgr)
+		importSvc := service.NewImportService(d, configMgr, service.NewMediaService(bgCtx, d, configMgr))
 
 		input := service.ImportContentInput{
 			Format:  format,
@@ -46,3 +50,87 @@ func RunImportCmd(ctx AppContext, format config.OutputFormat, path string) tea.C
 		return ImportCompleteMsg{Result: result}
 	}
 }
+
+// LoadSheetDatatypesCmd lists the datatypes offered for spreadsheet import and export.
+func LoadSheetDatatypesCmd(ctx AppContext) tea.Cmd {
+	d := ctx.DB
+	return func() tea.Msg {
+		list, err := d.ListDatatypes()
+		if err != nil {
+			return SheetDatatypesLoadedMsg{Err: fmt.Errorf("list datatypes: %w", err)}
+		}
+		if list == nil {
+			return SheetDatatypesLoadedMsg{}
+		}
+		return SheetDatatypesLoadedMsg{Datatypes: *list}
+	}
+}
+
+// newSheetService builds a ContentSheetService for the TUI.
+func newSheetService(d db.DbDriver, configMgr *config.Manager) *service.ContentSheetService {
+	return service.NewContentSheetService(d, configMgr,
+		service.NewContentService(d, configMgr, nil),
+		service.NewRouteService(d, configMgr))
+}
+
+// RunSheetImportCmd reads a CSV or XLSX file and imports it into the
+// entries of a datatype, or validates it only when dryRun is set.
+func RunSheetImportCmd(ctx AppContext, datatype, path string, dryRun bool) tea.Cmd {
+	cfg := ctx.Config
+	userID := ctx.UserID
+	configMgr := ctx.ConfigManager
+
+	return func() tea.Msg {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return SheetImportCompleteMsg{Err: fmt.Errorf("read file: %w", err)}
+		}
+		format := spreadsheet.FormatFromName(path)
+		if format == "" {
+			format = spreadsheet.FormatCSV
+		}
+		sheet, err := spreadsheet.Read(format, data)
+		if err != nil {
+			return SheetImportCompleteMsg{Err: err}
+		}
+
+		d := db.ConfigDB(*cfg)
+		ac := middleware.AuditContextFromCLI(*cfg, userID)
+		report, err := newSheetService(d, configMgr).Import(context.Background(), ac, sheet, service.SheetImportParams{
+			Datatype: datatype,
+			DryRun:   dryRun,
+		})
+		if err != nil {
+			return SheetImportCompleteMsg{Err: fmt.Errorf("import failed: %w", err)}
+		}
+		return SheetImportCompleteMsg{Report: report}
+	}
+}
+
+// RunSheetExportCmd writes the entries of a datatype to an XLSX file in the
+// user's home directory.
+func RunSheetExportCmd(ctx AppContext, datatype string) tea.Cmd {
+	cfg := ctx.Config
+	configMgr := ctx.ConfigManager
+
+	return func() tea.Msg {
+		d := db.ConfigDB(*cfg)
+		sheet, err := newSheetService(d, configMgr).Export(context.Background(), service.SheetExportParams{Datatype: datatype})
+		if err != nil {
+			return SheetExportCompleteMsg{Err: fmt.Errorf("export failed: %w", err)}
+		}
+		var buf bytes.Buffer
+		if err := spreadsheet.Write(&buf, spreadsheet.FormatXLSX, sheet); err != nil {
+			return SheetExportCompleteMsg{Err: fmt.Errorf("export failed: %w", err)}
+		}
+		dir, err := os.UserHomeDir()
+		if err != nil {
+			return SheetExportCompleteMsg{Err: fmt.Errorf("home directory: %w", err)}
+		}
+		path := filepath.Join(dir, fmt.Sprintf("%s-%s.xlsx", datatype, time.Now().UTC().Format("20060102")))
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			return SheetExportCompleteMsg{Err: fmt.Errorf("write %s: %w", path, err)}
+		}
+		return SheetExportCompleteMsg{Path: path, Rows: len(sheet.Rows)}
+	}
+}
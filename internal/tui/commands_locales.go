@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/service"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// =============================================================================
+// LOCALE MESSAGES
+// =============================================================================
+
+// CreateLocaleFromDialogRequestMsg triggers locale creation.
+type CreateLocaleFromDialogRequestMsg struct {
+	Input service.CreateLocaleInput
+}
+
+// UpdateLocaleFromDialogRequestMsg triggers locale update.
+type UpdateLocaleFromDialogRequestMsg struct {
+	Input service.UpdateLocaleInput
+}
+
+// LocaleSavedMsg is sent after a locale is created or updated.
+type LocaleSavedMsg struct {
+	LocaleID types.LocaleID
+	Code     string
+}
+
+// DeleteLocaleContext stores context for a locale deletion operation.
+type DeleteLocaleContext struct {
+	LocaleID types.LocaleID
+	Code     string
+}
+
+// ShowDeleteLocaleDialogMsg triggers showing a delete locale confirmation dialog.
+type ShowDeleteLocaleDialogMsg struct {
+	LocaleID types.LocaleID
+	Code     string
+}
+
+// DeleteLocaleRequestMsg triggers locale deletion.
+type DeleteLocaleRequestMsg struct {
+	LocaleID types.LocaleID
+}
+
+// LocaleDeletedMsg is sent after a locale is deleted.
+type LocaleDeletedMsg struct {
+	LocaleID types.LocaleID
+}
+
+// ShowCreateLocaleDialogCmd creates a command to show the create locale dialog.
+func ShowCreateLocaleDialogCmd(locales []db.Locale) tea.Cmd {
+	return func() tea.Msg {
+		return ShowLocaleFormDialogMsg{Title: "New Locale", Locales: locales}
+	}
+}
+
+// ShowEditLocaleDialogCmd creates a command to show the edit locale dialog.
+func ShowEditLocaleDialogCmd(locale db.Locale, locales []db.Locale) tea.Cmd {
+	return func() tea.Msg {
+		return ShowEditLocaleDialogMsg{Locale: locale, Locales: locales}
+	}
+}
+
+// ShowDeleteLocaleDialogCmd creates a command to show a delete locale dialog.
+func ShowDeleteLocaleDialogCmd(localeID types.LocaleID, code string) tea.Cmd {
+	return func() tea.Msg {
+		return ShowDeleteLocaleDialogMsg{LocaleID: localeID, Code: code}
+	}
+}
+
+// LocaleFromDialogCmd converts an accepted locale form into a create or update
+// request. The sort order is validated here since the form collects it as text.
+func LocaleFromDialogCmd(msg LocaleFormDialogAcceptMsg) tea.Cmd {
+	return func() tea.Msg {
+		var sortOrder int64
+		if msg.SortOrder != "" {
+			n, err := strconv.ParseInt(msg.SortOrder, 10, 64)
+			if err != nil {
+				return ActionResultMsg{Title: "Validation Error", Message: fmt.Sprintf("sort order %q is not a number", msg.SortOrder)}
+			}
+			sortOrder = n
+		}
+		if msg.Action == FORMDIALOGEDITLOCALE {
+			return UpdateLocaleFromDialogRequestMsg{Input: service.UpdateLocaleInput{
+				LocaleID:     types.LocaleID(msg.EntityID),
+				Code:         msg.Code,
+				Label:        msg.Label,
+				IsDefault:    msg.IsDefault,
+				IsEnabled:    msg.IsEnabled,
+				FallbackCode: msg.FallbackCode,
+				SortOrder:    sortOrder,
+			}}
+		}
+		return CreateLocaleFromDialogRequestMsg{Input: service.CreateLocaleInput{
+			Code:         msg.Code,
+			Label:        msg.Label,
+			IsDefault:    msg.IsDefault,
+			IsEnabled:    msg.IsEnabled,
+			FallbackCode: msg.FallbackCode,
+			SortOrder:    sortOrder,
+		}}
+	}
+}
+
+// DeleteLocaleCmd creates a command to delete a locale.
+func DeleteLocaleCmd(localeID types.LocaleID) tea.Cmd {
+	return func() tea.Msg {
+		return DeleteLocaleRequestMsg{LocaleID: localeID}
+	}
+}
+
+// =============================================================================
+// HANDLERS
+// =============================================================================
+
+// HandleCreateLocale processes locale creation through the LocaleService,
+// which validates the code and fallback chain and keeps a single default.
+func (m Model) HandleCreateLocale(msg CreateLocaleFromDialogRequestMsg) tea.Cmd {
+	cfg := m.Config
+	userID := m.UserID
+	mgr := m.ConfigManager
+
+	if cfg == nil {
+		return func() tea.Msg {
+			return ActionResultMsg{Title: "Error", Message: "configuration not loaded"}
+		}
+	}
+
+	return func() tea.Msg {
+		d := db.ConfigDB(*cfg)
+		ctx := context.Background()
+		ac := middleware.AuditContextFromCLI(*cfg, userID)
+
+		created, err := service.NewLocaleService(d, mgr).CreateLocale(ctx, ac, msg.Input)
+		if err != nil {
+			if service.IsValidation(err) || service.IsConflict(err) {
+				return ActionResultMsg{Title: "Validation Error", Message: err.Error()}
+			}
+			utility.DefaultLogger.Ferror("failed to create locale", err)
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to create locale: %v", err)}
+		}
+		return LocaleSavedMsg{LocaleID: created.LocaleID, Code: created.Code}
+	}
+}
+
+// HandleUpdateLocale processes locale update through the LocaleService.
+func (m Model) HandleUpdateLocale(msg UpdateLocaleFromDialogRequestMsg) tea.Cmd {
+	cfg := m.Config
+	userID := m.UserID
+	mgr := m.ConfigManager
+
+	if cfg == nil {
+		return func() tea.Msg {
+			return ActionResultMsg{Title: "Error", Message: "configuration not loaded"}
+		}
+	}
+
+	return func() tea.Msg {
+		d := db.ConfigDB(*cfg)
+		ctx := context.Background()
+		ac := middleware.AuditContextFromCLI(*cfg, userID)
+
+		updated, err := service.NewLocaleService(d, mgr).UpdateLocale(ctx, ac, msg.Input)
+		if err != nil {
+			if service.IsValidation(err) || service.IsNotFound(err) {
+				return ActionResultMsg{Title: "Validation Error", Message: err.Error()}
+			}
+			utility.DefaultLogger.Ferror("failed to update locale", err)
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to update locale: %v", err)}
+		}
+		return LocaleSavedMsg{LocaleID: updated.LocaleID, Code: updated.Code}
+	}
+}
+
+// HandleDeleteLocale processes locale deletion. The default locale cannot be
+// deleted.
+func (m Model) HandleDeleteLocale(msg DeleteLocaleRequestMsg) tea.Cmd {
+	cfg := m.Config
+	userID := m.UserID
+	mgr := m.ConfigManager
+
+	if cfg == nil {
+		return func() tea.Msg {
+			return ActionResultMsg{Title: "Error", Message: "configuration not loaded"}
+		}
+	}
+
+	return func() tea.Msg {
+		d := db.ConfigDB(*cfg)
+		ctx := context.Background()
+		ac := middleware.AuditContextFromCLI(*cfg, userID)
+
+		if err := service.NewLocaleService(d, mgr).DeleteLocale(ctx, ac, msg.LocaleID); err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to delete locale: %v", err)}
+		}
+		return LocaleDeletedMsg{LocaleID: msg.LocaleID}
+	}
+}
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/presence"
+)
+
+// AcquireContentLeaseCmd takes an editing lease on a content node for the TUI
+// session, or a viewing lease when another user is editing it or the user
+// lacks content:update. Failures are logged and leave the session without a
+// lease; leases are advisory, so the tree stays usable.
+func AcquireContentLeaseCmd(ctx AppContext, contentID types.ContentID) tea.Cmd {
+	d := ctx.DB
+	cfg := ctx.Config
+	userID := ctx.UserID
+	logger := ctx.Logger
+	pc := ctx.Permissions
+	return func() tea.Msg {
+		params := presence.AcquireParams{
+			ContentDataID: contentID,
+			UserID:        userID,
+			Client:        presence.ClientTUI,
+			Mode:          presence.ModeViewing,
+		}
+		if u, err := d.GetUser(userID); err == nil {
+			params.Username = u.Username
+			if canUpdateContent(pc, u) {
+				params.Mode = presence.ModeEditing
+			}
+		}
+		ac := middleware.AuditContextFromCLI(*cfg, userID)
+		lease, err := presence.Acquire(d, ac, params)
+		if presence.IsHeld(err) {
+			params.Mode = presence.ModeViewing
+			lease, err = presence.Acquire(d, ac, params)
+		}
+		if err != nil {
+			if logger != nil {
+				logger.Ferror(fmt.Sprintf("failed to acquire lease on %s", contentID), err)
+			}
+			return ContentLeaseAcquiredMsg{}
+		}
+		p, err := presence.Current(d, contentID)
+		if err != nil && logger != nil {
+			logger.Ferror("failed to load presence", err)
+		}
+		return ContentLeaseAcquiredMsg{Lease: lease, Presence: p}
+	}
+}
+
+// canUpdateContent reports whether the user holds content:update through any
+// of their roles, the same check the HTTP API applies to editing leases. It
+// reads the application's shared permission cache and fails closed when there
+// is none.
+func canUpdateContent(pc *middleware.PermissionCache, u *db.Users) bool {
+	if pc == nil {
+		return false
+	}
+	roleID := types.RoleID(u.Role)
+	if pc.IsAdminUser(u.UserID, roleID) {
+		return true
+	}
+	return pc.PermissionsForUser(u.UserID, roleID).Has("content:update")
+}
+
+// ContentLeaseTickCmd schedules the next heartbeat for a lease.
+func ContentLeaseTickCmd(leaseID string) tea.Cmd {
+	return tea.Tick(presence.HeartbeatInterval, func(time.Time) tea.Msg {
+		return ContentLeaseTickMsg{LeaseID: leaseID}
+	})
+}
+
+// RenewContentLeaseCmd renews a lease and reloads the node's presence.
+func RenewContentLeaseCmd(ctx AppContext, lease db.ContentLease) tea.Cmd {
+	d := ctx.DB
+	userID := ctx.UserID
+	logger := ctx.Logger
+	return func() tea.Msg {
+		renewed, err := presence.Renew(d, lease.LeaseID, userID, 0)
+		if errors.Is(err, presence.ErrLeaseLost) {
+			return ContentLeaseRenewedMsg{Lost: lease.Mode == presence.ModeEditing}
+		}
+		if err != nil {
+			if logger != nil {
+				logger.Ferror("failed to renew content lease", err)
+			}
+			return ContentLeaseRenewedMsg{Lease: &lease}
+		}
+		p, err := presence.Current(d, lease.ContentDataID)
+		if err != nil && logger != nil {
+			logger.Ferror("failed to load presence", err)
+		}
+		lost := lease.Mode == presence.ModeEditing && renewed.Mode != presence.ModeEditing
+		return ContentLeaseRenewedMsg{Lease: renewed, Presence: p, Lost: lost}
+	}
+}
+
+// ReleaseContentLeaseCmd gives up a lease when the session leaves the tree.
+func ReleaseContentLeaseCmd(ctx AppContext, leaseID string) tea.Cmd {
+	d := ctx.DB
+	userID := ctx.UserID
+	logger := ctx.Logger
+	return func() tea.Msg {
+		if err := presence.Release(d, leaseID, userID); err != nil && logger != nil {
+			logger.Ferror("failed to release content lease", err)
+		}
+		return nil
+	}
+}
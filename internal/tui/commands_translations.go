@@ -0,0 +1,572 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/publishing"
+	"github.com/hegner123/modulacms/internal/service"
+	"github.com/hegner123/modulacms/internal/translation"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// =============================================================================
+// TRANSLATION DATA
+// =============================================================================
+
+// translationNode is one content node of the route being translated.
+type translationNode struct {
+	ContentID  types.ContentID
+	DatatypeID types.NullableDatatypeID
+	Label      string
+	Depth      int
+	Fields     []translationField
+}
+
+// translationField is a translatable field of a node with its value in the
+// source locale and in each target locale that has a row for it.
+type translationField struct {
+	FieldID  types.FieldID
+	Label    string
+	Type     string
+	DataJSON string
+	Source   string
+	Targets  map[string]translationValue // keyed by locale code
+}
+
+// translationValue is a target locale's content field row. Review is set
+// while the value is a pre-fill nobody has reviewed yet; Stale when the
+// source value changed after the value was translated.
+type translationValue struct {
+	ContentFieldID types.ContentFieldID
+	Value          string
+	Review         *db.TranslationReview
+	Stale          bool
+}
+
+// translated reports whether the field has a non-empty value in locale.
+func (f translationField) translated(locale string) bool {
+	v, ok := f.Targets[locale]
+	return ok && v.Value != ""
+}
+
+// needsAttention reports whether the value is an unreviewed pre-fill or a
+// stale translation, the two states marking it reviewed clears.
+func (v translationValue) needsAttention() bool {
+	return v.Review != nil || v.Stale
+}
+
+// translationStaleCount returns how many of the nodes' fields hold a stale
+// translation in locale.
+func translationStaleCount(nodes []translationNode, locale string) int {
+	stale := 0
+	for _, n := range nodes {
+		for _, f := range n.Fields {
+			if f.Targets[locale].Stale {
+				stale++
+			}
+		}
+	}
+	return stale
+}
+
+// translationProgress returns how many of the nodes' translatable fields have
+// a value in locale, out of the total.
+func translationProgress(nodes []translationNode, locale string) (done, total int) {
+	for _, n := range nodes {
+		for _, f := range n.Fields {
+			total++
+			if f.translated(locale) {
+				done++
+			}
+		}
+	}
+	return done, total
+}
+
+// loadTranslations builds the translation view of a route: its content nodes
+// in tree order with every translatable field's source value and target values
+// for all enabled locales, and which locales have a published snapshot.
+func loadTranslations(d db.DbDriver, routeID types.RouteID, sourceLocale string) (TranslationsFetchResultsMsg, error) {
+	res := TranslationsFetchResultsMsg{
+		RouteID:      routeID,
+		SourceLocale: sourceLocale,
+		Locales:      []db.Locale{},
+		Nodes:        []translationNode{},
+		Published:    map[string]bool{},
+	}
+
+	route, err := d.GetRoute(routeID)
+	if err != nil {
+		return res, fmt.Errorf("get route: %w", err)
+	}
+	res.RouteTitle = route.Title
+
+	nullableRoute := types.NullableRouteID{ID: routeID, Valid: true}
+	contents, err := d.ListContentDataByRoute(nullableRoute)
+	if err != nil {
+		return res, fmt.Errorf("list content: %w", err)
+	}
+	locales, err := d.ListEnabledLocales()
+	if err != nil {
+		return res, fmt.Errorf("list locales: %w", err)
+	}
+	if locales != nil {
+		res.Locales = *locales
+	}
+	allFields, err := d.ListFields()
+	if err != nil {
+		return res, fmt.Errorf("list fields: %w", err)
+	}
+	datatypes, err := d.ListDatatypes()
+	if err != nil {
+		return res, fmt.Errorf("list datatypes: %w", err)
+	}
+	if contents == nil || len(*contents) == 0 {
+		return res, nil
+	}
+
+	// Translatable fields per datatype, in field sort order. Title fields are
+	// also remembered to label the nodes.
+	fieldsByDatatype := map[types.DatatypeID][]db.Fields{}
+	titleFields := map[types.FieldID]bool{}
+	if allFields != nil {
+		for _, f := range *allFields {
+			if f.Type == types.FieldTypeTitle {
+				titleFields[f.FieldID] = true
+			}
+			if f.Translatable && f.ParentID.Valid {
+				fieldsByDatatype[f.ParentID.ID] = append(fieldsByDatatype[f.ParentID.ID], f)
+			}
+		}
+	}
+	for id := range fieldsByDatatype {
+		sort.SliceStable(fieldsByDatatype[id], func(i, j int) bool {
+			return fieldsByDatatype[id][i].SortOrder < fieldsByDatatype[id][j].SortOrder
+		})
+	}
+	datatypeLabels := map[types.DatatypeID]string{}
+	if datatypes != nil {
+		for _, dt := range *datatypes {
+			datatypeLabels[dt.DatatypeID] = dt.Label
+		}
+	}
+
+	// Source values: rows in the source locale win over unlocalized rows.
+	type valueKey struct {
+		content types.ContentID
+		field   types.FieldID
+	}
+	source := map[valueKey]string{}
+	sourceRows, err := d.ListContentFieldsByRouteAndLocale(nullableRoute, sourceLocale)
+	if err != nil {
+		return res, fmt.Errorf("list source fields: %w", err)
+	}
+	if sourceRows != nil {
+		for _, cf := range *sourceRows {
+			if !cf.ContentDataID.Valid || !cf.FieldID.Valid {
+				continue
+			}
+			key := valueKey{cf.ContentDataID.ID, cf.FieldID.ID}
+			if _, ok := source[key]; ok && cf.Locale == "" {
+				continue
+			}
+			source[key] = cf.FieldValue
+		}
+	}
+
+	// Target values: only rows stored under the locale itself count.
+	targets := map[valueKey]map[string]translationValue{}
+	for _, l := range res.Locales {
+		if l.Code == sourceLocale {
+			continue
+		}
+		rows, err := d.ListContentFieldsByRouteAndLocale(nullableRoute, l.Code)
+		if err != nil {
+			return res, fmt.Errorf("list %s fields: %w", l.Code, err)
+		}
+		if rows == nil {
+			continue
+		}
+		reviews := map[types.ContentFieldID]*db.TranslationReview{}
+		if flagged, err := d.ListTranslationReviewsByRoute(nullableRoute, l.Code); err == nil && flagged != nil {
+			for i := range *flagged {
+				reviews[(*flagged)[i].ContentFieldID] = &(*flagged)[i]
+			}
+		}
+		bases := map[types.ContentFieldID]string{}
+		if recorded, err := d.ListTranslationSourcesByRoute(nullableRoute, l.Code); err == nil && recorded != nil {
+			for _, b := range *recorded {
+				bases[b.ContentFieldID] = b.SourceHash
+			}
+		}
+		for _, cf := range *rows {
+			if cf.Locale != l.Code || !cf.ContentDataID.Valid || !cf.FieldID.Valid {
+				continue
+			}
+			key := valueKey{cf.ContentDataID.ID, cf.FieldID.ID}
+			if targets[key] == nil {
+				targets[key] = map[string]translationValue{}
+			}
+			basis, tracked := bases[cf.ContentFieldID]
+			targets[key][l.Code] = translationValue{
+				ContentFieldID: cf.ContentFieldID,
+				Value:          cf.FieldValue,
+				Review:         reviews[cf.ContentFieldID],
+				Stale:          tracked && basis != translation.SourceHash(source[key]),
+			}
+		}
+	}
+
+	for _, item := range orderContentTree(*contents) {
+		cd := item.content
+		node := translationNode{
+			ContentID:  cd.ContentDataID,
+			DatatypeID: cd.DatatypeID,
+			Depth:      item.depth,
+		}
+		datatypeLabel := datatypeLabels[cd.DatatypeID.ID]
+		title := ""
+		if allFields != nil {
+			for _, f := range *allFields {
+				if titleFields[f.FieldID] && f.ParentID == cd.DatatypeID {
+					title = source[valueKey{cd.ContentDataID, f.FieldID}]
+					break
+				}
+			}
+		}
+		switch {
+		case title != "" && datatypeLabel != "":
+			node.Label = fmt.Sprintf("%s [%s]", title, datatypeLabel)
+		case title != "":
+			node.Label = title
+		case datatypeLabel != "":
+			node.Label = datatypeLabel
+		default:
+			node.Label = string(cd.ContentDataID)
+		}
+		for _, f := range fieldsByDatatype[cd.DatatypeID.ID] {
+			key := valueKey{cd.ContentDataID, f.FieldID}
+			node.Fields = append(node.Fields, translationField{
+				FieldID:  f.FieldID,
+				Label:    f.Label,
+				Type:     string(f.Type),
+				DataJSON: f.Data,
+				Source:   source[key],
+				Targets:  targets[key],
+			})
+		}
+		if !cd.ParentID.Valid && res.RootID.IsZero() {
+			res.RootID = cd.ContentDataID
+		}
+		res.Nodes = append(res.Nodes, node)
+	}
+
+	if !res.RootID.IsZero() {
+		for _, l := range res.Locales {
+			if v, err := d.GetPublishedSnapshot(res.RootID, l.Code); err == nil && v != nil {
+				res.Published[l.Code] = true
+			}
+		}
+	}
+	return res, nil
+}
+
+// contentTreeItem is a content node with its depth in the route tree.
+type contentTreeItem struct {
+	content db.ContentData
+	depth   int
+}
+
+// orderContentTree walks content rows depth-first along their first-child and
+// next-sibling links. Rows the walk cannot reach are appended at depth 0.
+func orderContentTree(contents []db.ContentData) []contentTreeItem {
+	byID := make(map[types.ContentID]db.ContentData, len(contents))
+	for _, c := range contents {
+		byID[c.ContentDataID] = c
+	}
+	visited := make(map[types.ContentID]bool, len(contents))
+	ordered := make([]contentTreeItem, 0, len(contents))
+
+	var walk func(id types.ContentID, depth int)
+	walk = func(id types.ContentID, depth int) {
+		for !visited[id] {
+			c, ok := byID[id]
+			if !ok {
+				return
+			}
+			visited[id] = true
+			ordered = append(ordered, contentTreeItem{content: c, depth: depth})
+			if c.FirstChildID.Valid {
+				walk(c.FirstChildID.ID, depth+1)
+			}
+			if !c.NextSiblingID.Valid {
+				return
+			}
+			id = c.NextSiblingID.ID
+		}
+	}
+	for _, c := range contents {
+		if !c.ParentID.Valid {
+			walk(c.ContentDataID, 0)
+		}
+	}
+	for _, c := range contents {
+		if !visited[c.ContentDataID] {
+			walk(c.ContentDataID, 0)
+		}
+	}
+	return ordered
+}
+
+// =============================================================================
+// TRANSLATION MESSAGES
+// =============================================================================
+
+// ShowTranslationsMsg opens the translation view for a route. ContentID, when
+// set, preselects that node. Handled in update.go, which records the target on
+// the Model and navigates to TRANSLATIONSPAGE.
+type ShowTranslationsMsg struct {
+	RouteID   types.RouteID
+	ContentID types.ContentID
+}
+
+// ScaffoldTranslationRequestMsg creates locale rows for the translatable
+// fields of the given nodes, seeded with their default-locale values, or
+// pre-filled when Prefill is service.PrefillMemory or service.PrefillMachine.
+type ScaffoldTranslationRequestMsg struct {
+	ContentIDs []types.ContentID
+	Locale     string
+	Prefill    string
+}
+
+// TranslationScaffoldedMsg is sent after translation rows were scaffolded.
+type TranslationScaffoldedMsg struct {
+	Locale        string
+	FieldsCreated int
+	NeedsReview   int
+}
+
+// MarkTranslationReviewedRequestMsg clears the review flag on a pre-filled
+// field without changing its value.
+type MarkTranslationReviewedRequestMsg struct {
+	ContentFieldID types.ContentFieldID
+	ContentID      types.ContentID
+	Locale         string
+}
+
+// editTranslationFieldCtx stores the target of a translation field edit dialog.
+type editTranslationFieldCtx struct {
+	ContentFieldID types.ContentFieldID // zero when the locale has no row yet
+	ContentID      types.ContentID
+	FieldID        types.FieldID
+	RouteID        types.RouteID
+	Locale         string
+}
+
+// ShowEditTranslationFieldDialogMsg triggers the edit dialog for one field's
+// value in a target locale. Field.ContentFieldID is zero when the locale has
+// no row for the field yet.
+type ShowEditTranslationFieldDialogMsg struct {
+	Field      ContentFieldDisplay
+	ContentID  types.ContentID
+	RouteID    types.RouteID
+	DatatypeID types.NullableDatatypeID
+	Locale     string
+}
+
+// SaveTranslationFieldRequestMsg writes one field's value in a locale.
+type SaveTranslationFieldRequestMsg struct {
+	ContentFieldID types.ContentFieldID
+	ContentID      types.ContentID
+	FieldID        types.FieldID
+	RouteID        types.RouteID
+	Locale         string
+	Value          string
+}
+
+// TranslationFieldSavedMsg is sent after a translated field value is saved.
+type TranslationFieldSavedMsg struct {
+	ContentID types.ContentID
+	Locale    string
+}
+
+// ShowTranslationsCmd creates a command to open the translation view.
+func ShowTranslationsCmd(routeID types.RouteID, contentID types.ContentID) tea.Cmd {
+	return func() tea.Msg {
+		return ShowTranslationsMsg{RouteID: routeID, ContentID: contentID}
+	}
+}
+
+// ScaffoldTranslationCmd creates a command to scaffold translation rows.
+func ScaffoldTranslationCmd(contentIDs []types.ContentID, locale, prefill string) tea.Cmd {
+	return func() tea.Msg {
+		return ScaffoldTranslationRequestMsg{ContentIDs: contentIDs, Locale: locale, Prefill: prefill}
+	}
+}
+
+// MarkTranslationReviewedCmd creates a command to clear a field's review flag.
+func MarkTranslationReviewedCmd(contentFieldID types.ContentFieldID, contentID types.ContentID, locale string) tea.Cmd {
+	return func() tea.Msg {
+		return MarkTranslationReviewedRequestMsg{ContentFieldID: contentFieldID, ContentID: contentID, Locale: locale}
+	}
+}
+
+// ShowEditTranslationFieldDialogCmd creates a command to show the translation
+// field edit dialog.
+func ShowEditTranslationFieldDialogCmd(msg ShowEditTranslationFieldDialogMsg) tea.Cmd {
+	return func() tea.Msg { return msg }
+}
+
+// =============================================================================
+// HANDLERS
+// =============================================================================
+
+// HandleScaffoldTranslation runs TranslationService.CreateTranslation for each
+// node. Fields that already have a row in the locale are left alone.
+func (m Model) HandleScaffoldTranslation(msg ScaffoldTranslationRequestMsg) tea.Cmd {
+	cfg := m.Config
+	userID := m.UserID
+	mgr := m.ConfigManager
+	var plugins service.TranslationProviderSource
+	if m.PluginManager != nil {
+		plugins = m.PluginManager
+	}
+
+	if cfg == nil || mgr == nil {
+		return func() tea.Msg {
+			return ActionResultMsg{Title: "Error", Message: "configuration not loaded"}
+		}
+	}
+
+	return func() tea.Msg {
+		d := db.ConfigDB(*cfg)
+		ctx := context.Background()
+		ac := middleware.AuditContextFromCLI(*cfg, userID)
+		svc := service.NewTranslationService(d, mgr, service.NewLocaleService(d, mgr), plugins)
+
+		created, review := 0, 0
+		for _, id := range msg.ContentIDs {
+			result, err := svc.CreateTranslation(ctx, ac, id, msg.Locale, msg.Prefill, userID)
+			if err != nil {
+				if service.IsValidation(err) {
+					return ActionResultMsg{Title: "Validation Error", Message: err.Error()}
+				}
+				utility.DefaultLogger.Ferror(fmt.Sprintf("failed to scaffold %s translation of %s", msg.Locale, id), err)
+				return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to create translation: %v", err)}
+			}
+			created += result.FieldsCreated
+			review += result.NeedsReview
+		}
+		return TranslationScaffoldedMsg{Locale: msg.Locale, FieldsCreated: created, NeedsReview: review}
+	}
+}
+
+// HandleSaveTranslationField updates a field's row in the locale, creating the
+// row when the field has not been translated yet.
+func (m Model) HandleSaveTranslationField(msg SaveTranslationFieldRequestMsg) tea.Cmd {
+	cfg := m.Config
+	userID := m.UserID
+
+	if cfg == nil {
+		return func() tea.Msg {
+			return ActionResultMsg{Title: "Error", Message: "configuration not loaded"}
+		}
+	}
+
+	return func() tea.Msg {
+		d := db.ConfigDB(*cfg)
+		ctx := context.Background()
+		ac := middleware.AuditContextFromCLI(*cfg, userID)
+		now := types.TimestampNow()
+
+		if !msg.ContentFieldID.IsZero() {
+			cf, err := d.GetContentField(msg.ContentFieldID)
+			if err != nil || cf == nil {
+				return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("Content field not found: %v", err)}
+			}
+			if _, err := d.UpdateContentField(ctx, ac, db.UpdateContentFieldParams{
+				ContentFieldID: cf.ContentFieldID,
+				RouteID:        cf.RouteID,
+				RootID:         cf.RootID,
+				ContentDataID:  cf.ContentDataID,
+				FieldID:        cf.FieldID,
+				FieldValue:     msg.Value,
+				Locale:         cf.Locale,
+				AuthorID:       userID,
+				DateCreated:    cf.DateCreated,
+				DateModified:   now,
+			}); err != nil {
+				return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to update translation: %v", err)}
+			}
+			// Saving from the editor counts as reviewing a pre-filled value,
+			// and as translating the current source.
+			if err := d.DeleteTranslationReview(cf.ContentFieldID); err != nil {
+				utility.DefaultLogger.Ferror(fmt.Sprintf("failed to clear review of %s", cf.ContentFieldID), err)
+			}
+			if err := publishing.RecordTranslationSource(d, *cf, cfg.I18nDefaultLocale()); err != nil {
+				utility.DefaultLogger.Ferror(fmt.Sprintf("failed to record translation source of %s", cf.ContentFieldID), err)
+			}
+			return TranslationFieldSavedMsg{ContentID: msg.ContentID, Locale: msg.Locale}
+		}
+
+		cd, err := d.GetContentData(msg.ContentID)
+		if err != nil || cd == nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("Content not found: %v", err)}
+		}
+		rootID := cd.RootID
+		if !rootID.Valid {
+			rootID = types.NullableContentID{ID: cd.ContentDataID, Valid: true}
+		}
+		created, err := d.CreateContentField(ctx, ac, db.CreateContentFieldParams{
+			RouteID:       cd.RouteID,
+			RootID:        rootID,
+			ContentDataID: types.NullableContentID{ID: msg.ContentID, Valid: true},
+			FieldID:       types.NullableFieldID{ID: msg.FieldID, Valid: true},
+			FieldValue:    msg.Value,
+			Locale:        msg.Locale,
+			AuthorID:      userID,
+			DateCreated:   now,
+			DateModified:  now,
+		})
+		if err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to create translation: %v", err)}
+		}
+		if err := publishing.RecordTranslationSource(d, *created, cfg.I18nDefaultLocale()); err != nil {
+			utility.DefaultLogger.Ferror(fmt.Sprintf("failed to record translation source of %s", created.ContentFieldID), err)
+		}
+		return TranslationFieldSavedMsg{ContentID: msg.ContentID, Locale: msg.Locale}
+	}
+}
+
+// HandleMarkTranslationReviewed clears the review flag on one field and
+// records its current source, which also marks a stale translation current.
+func (m Model) HandleMarkTranslationReviewed(msg MarkTranslationReviewedRequestMsg) tea.Cmd {
+	cfg := m.Config
+	if cfg == nil {
+		return func() tea.Msg {
+			return ActionResultMsg{Title: "Error", Message: "configuration not loaded"}
+		}
+	}
+
+	return func() tea.Msg {
+		d := db.ConfigDB(*cfg)
+		if err := d.DeleteTranslationReview(msg.ContentFieldID); err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to mark translation reviewed: %v", err)}
+		}
+		cf, err := d.GetContentField(msg.ContentFieldID)
+		if err != nil || cf == nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("Content field not found: %v", err)}
+		}
+		if err := publishing.RecordTranslationSource(d, *cf, cfg.I18nDefaultLocale()); err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to mark translation current: %v", err)}
+		}
+		return TranslationFieldSavedMsg{ContentID: msg.ContentID, Locale: msg.Locale}
+	}
+}
@@ -522,6 +522,7 @@ func (m Model) HandleTogglePublish(msg TogglePublishRequestMsg) tea.Cmd {
 		}
 	}
 
+	locale := m.ActiveLocale
 	return func() tea.Msg {
 		d := db.ConfigDB(*cfg)
 		content, err := d.GetContentData(msg.ContentID)
@@ -535,11 +536,16 @@ func (m Model) HandleTogglePublish(msg TogglePublishRequestMsg) tea.Cmd {
 		}
 		isPublished := content.Status == types.ContentStatusPublished
 
+		var diff string
+		if !isPublished {
+			diff = loadVersionDiffSummary(d, msg.ContentID, "", publishing.DraftSide, locale)
+		}
 		return ShowPublishDialogMsg{
 			ContentID:   msg.ContentID,
 			RouteID:     msg.RouteID,
 			ContentName: contentName,
 			IsPublished: isPublished,
+			Diff:        diff,
 		}
 	}
 }
@@ -555,6 +561,9 @@ func (m Model) HandleConfirmedPublish(msg ConfirmedPublishMsg) tea.Cmd {
 
 	userID := m.UserID
 	locale := m.ActiveLocale
+	if msg.Locale != "" {
+		locale = msg.Locale
+	}
 	dispatcher := m.Dispatcher
 	return func() tea.Msg {
 		d := db.ConfigDB(*cfg)
@@ -564,7 +573,13 @@ func (m Model) HandleConfirmedPublish(msg ConfirmedPublishMsg) tea.Cmd {
 
 		retentionCap := cfg.VersionMaxPerContent()
 		publishAll := !cfg.Node_Level_Publish
-		_, pubErr := publishing.PublishContent(ctx, d, msg.ContentID, locale, userID, ac, retentionCap, publishAll, dispatcher, nil)
+		var pubErr error
+		if cfg.I18n_Require_Current_Translations && cfg.I18nEnabled() {
+			pubErr = publishing.CheckTranslationsCurrent(ctx, d, msg.ContentID, locale, cfg.I18nDefaultLocale())
+		}
+		if pubErr == nil {
+			_, pubErr = publishing.PublishContent(ctx, d, msg.ContentID, locale, userID, ac, retentionCap, publishAll, dispatcher, nil)
+		}
 		if pubErr != nil {
 			logger.Ferror(fmt.Sprintf("failed to publish content %s", msg.ContentID), pubErr)
 			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("Publish failed: %v", pubErr)}
@@ -574,6 +589,7 @@ func (m Model) HandleConfirmedPublish(msg ConfirmedPublishMsg) tea.Cmd {
 		return PublishCompletedMsg{
 			ContentID: msg.ContentID,
 			RouteID:   msg.RouteID,
+			Locale:    msg.Locale,
 		}
 	}
 }
@@ -648,21 +664,25 @@ func (m Model) HandleListVersions(msg ListVersionsRequestMsg) tea.Cmd {
 }
 
 // ShowRestoreVersionDialogMsg requests showing the restore version confirmation dialog.
+// Diff summarizes what the restore changes in the current draft.
 type ShowRestoreVersionDialogMsg struct {
 	ContentID     types.ContentID
 	VersionID     types.ContentVersionID
 	RouteID       types.RouteID
 	VersionNumber int64
+	Diff          string
 }
 
 // ShowRestoreVersionDialogCmd creates a command to show the restore version dialog.
-func ShowRestoreVersionDialogCmd(contentID types.ContentID, versionID types.ContentVersionID, routeID types.RouteID, versionNumber int64) tea.Cmd {
+// It diffs the draft against the version first so the dialog can show the changes.
+func ShowRestoreVersionDialogCmd(d db.DbDriver, contentID types.ContentID, versionID types.ContentVersionID, routeID types.RouteID, versionNumber int64) tea.Cmd {
 	return func() tea.Msg {
 		return ShowRestoreVersionDialogMsg{
 			ContentID:     contentID,
 			VersionID:     versionID,
 			RouteID:       routeID,
 			VersionNumber: versionNumber,
+			Diff:          loadVersionDiffSummary(d, contentID, publishing.DraftSide, versionID, ""),
 		}
 	}
 }
@@ -0,0 +1,386 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+// =============================================================================
+// USER GROUP MESSAGES
+// =============================================================================
+
+// CreateUserGroupFromDialogRequestMsg triggers user group creation.
+type CreateUserGroupFromDialogRequestMsg struct {
+	Label       string
+	Description string
+}
+
+// UpdateUserGroupFromDialogRequestMsg triggers user group update.
+type UpdateUserGroupFromDialogRequestMsg struct {
+	UserGroupID string
+	Label       string
+	Description string
+}
+
+// UserGroupSavedMsg is sent after a user group is created or updated.
+type UserGroupSavedMsg struct {
+	UserGroupID types.UserGroupID
+	Label       string
+}
+
+// DeleteUserGroupContext stores context for a user group deletion operation.
+type DeleteUserGroupContext struct {
+	UserGroupID types.UserGroupID
+	Label       string
+}
+
+// ShowDeleteUserGroupDialogMsg triggers showing a delete user group confirmation dialog.
+type ShowDeleteUserGroupDialogMsg struct {
+	UserGroupID types.UserGroupID
+	Label       string
+}
+
+// DeleteUserGroupRequestMsg triggers user group deletion.
+type DeleteUserGroupRequestMsg struct {
+	UserGroupID types.UserGroupID
+}
+
+// UserGroupDeletedMsg is sent after a user group is deleted.
+type UserGroupDeletedMsg struct {
+	UserGroupID types.UserGroupID
+}
+
+// ToggleUserGroupRoleRequestMsg grants or revokes a role on a group.
+type ToggleUserGroupRoleRequestMsg struct {
+	UserGroupID types.UserGroupID
+	RoleID      types.RoleID
+}
+
+// ToggleUserGroupMemberRequestMsg adds or removes a user from a group.
+type ToggleUserGroupMemberRequestMsg struct {
+	UserGroupID types.UserGroupID
+	UserID      types.UserID
+}
+
+// UserGroupAssignmentsChangedMsg is sent after a group's roles or members change.
+type UserGroupAssignmentsChangedMsg struct {
+	UserGroupID types.UserGroupID
+}
+
+// ToggleUserRoleRequestMsg assigns or revokes an additional role on a user.
+type ToggleUserRoleRequestMsg struct {
+	UserID types.UserID
+	RoleID types.RoleID
+}
+
+// UserAccessChangedMsg is sent after a user's direct role assignments change.
+type UserAccessChangedMsg struct {
+	UserID types.UserID
+}
+
+// ShowCreateUserGroupDialogCmd creates a command to show the create user group dialog.
+func ShowCreateUserGroupDialogCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ShowUserGroupFormDialogMsg{Title: "New Group"}
+	}
+}
+
+// ShowEditUserGroupDialogCmd creates a command to show the edit user group dialog.
+func ShowEditUserGroupDialogCmd(group db.UserGroups) tea.Cmd {
+	return func() tea.Msg {
+		return ShowEditUserGroupDialogMsg{Group: group}
+	}
+}
+
+// ShowDeleteUserGroupDialogCmd creates a command to show a delete user group dialog.
+func ShowDeleteUserGroupDialogCmd(groupID types.UserGroupID, label string) tea.Cmd {
+	return func() tea.Msg {
+		return ShowDeleteUserGroupDialogMsg{UserGroupID: groupID, Label: label}
+	}
+}
+
+// CreateUserGroupFromDialogCmd creates a command to trigger user group creation.
+func CreateUserGroupFromDialogCmd(label, description string) tea.Cmd {
+	return func() tea.Msg {
+		return CreateUserGroupFromDialogRequestMsg{Label: label, Description: description}
+	}
+}
+
+// UpdateUserGroupFromDialogCmd creates a command to trigger user group update.
+func UpdateUserGroupFromDialogCmd(groupID, label, description string) tea.Cmd {
+	return func() tea.Msg {
+		return UpdateUserGroupFromDialogRequestMsg{UserGroupID: groupID, Label: label, Description: description}
+	}
+}
+
+// DeleteUserGroupCmd creates a command to delete a user group.
+func DeleteUserGroupCmd(groupID types.UserGroupID) tea.Cmd {
+	return func() tea.Msg {
+		return DeleteUserGroupRequestMsg{UserGroupID: groupID}
+	}
+}
+
+// ToggleUserGroupRoleCmd creates a command to toggle a group's role.
+func ToggleUserGroupRoleCmd(groupID types.UserGroupID, roleID types.RoleID) tea.Cmd {
+	return func() tea.Msg {
+		return ToggleUserGroupRoleRequestMsg{UserGroupID: groupID, RoleID: roleID}
+	}
+}
+
+// ToggleUserGroupMemberCmd creates a command to toggle a group membership.
+func ToggleUserGroupMemberCmd(groupID types.UserGroupID, userID types.UserID) tea.Cmd {
+	return func() tea.Msg {
+		return ToggleUserGroupMemberRequestMsg{UserGroupID: groupID, UserID: userID}
+	}
+}
+
+// ToggleUserRoleCmd creates a command to toggle a user's additional role.
+func ToggleUserRoleCmd(userID types.UserID, roleID types.RoleID) tea.Cmd {
+	return func() tea.Msg {
+		return ToggleUserRoleRequestMsg{UserID: userID, RoleID: roleID}
+	}
+}
+
+// =============================================================================
+// HANDLERS
+// =============================================================================
+
+// HandleCreateUserGroup processes user group creation.
+func (m Model) HandleCreateUserGroup(msg CreateUserGroupFromDialogRequestMsg) tea.Cmd {
+	cfg := m.Config
+	userID := m.UserID
+
+	if cfg == nil {
+		return func() tea.Msg {
+			return ActionResultMsg{Title: "Error", Message: "configuration not loaded"}
+		}
+	}
+
+	return func() tea.Msg {
+		if msg.Label == "" {
+			return ActionResultMsg{Title: "Validation Error", Message: "Group name is required"}
+		}
+
+		d := db.ConfigDB(*cfg)
+		ctx := context.Background()
+		ac := middleware.AuditContextFromCLI(*cfg, userID)
+
+		if _, err := d.GetUserGroupByLabel(msg.Label); err == nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("group %q already exists", msg.Label)}
+		}
+
+		now := types.NewTimestamp(time.Now().UTC())
+		result, err := d.CreateUserGroup(ctx, ac, db.CreateUserGroupParams{
+			Label:        msg.Label,
+			Description:  msg.Description,
+			DateCreated:  now,
+			DateModified: now,
+		})
+		if err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to create group: %v", err)}
+		}
+
+		return UserGroupSavedMsg{UserGroupID: result.UserGroupID, Label: result.Label}
+	}
+}
+
+// HandleUpdateUserGroup processes user group update.
+func (m Model) HandleUpdateUserGroup(msg UpdateUserGroupFromDialogRequestMsg) tea.Cmd {
+	cfg := m.Config
+	userID := m.UserID
+
+	if cfg == nil {
+		return func() tea.Msg {
+			return ActionResultMsg{Title: "Error", Message: "configuration not loaded"}
+		}
+	}
+
+	return func() tea.Msg {
+		if msg.Label == "" {
+			return ActionResultMsg{Title: "Validation Error", Message: "Group name is required"}
+		}
+
+		d := db.ConfigDB(*cfg)
+		ctx := context.Background()
+		ac := middleware.AuditContextFromCLI(*cfg, userID)
+
+		groupID := types.UserGroupID(msg.UserGroupID)
+		existing, err := d.GetUserGroup(groupID)
+		if err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to get group: %v", err)}
+		}
+		if msg.Label != existing.Label {
+			if _, err := d.GetUserGroupByLabel(msg.Label); err == nil {
+				return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("group %q already exists", msg.Label)}
+			}
+		}
+
+		if _, err := d.UpdateUserGroup(ctx, ac, db.UpdateUserGroupParams{
+			Label:        msg.Label,
+			Description:  msg.Description,
+			DateCreated:  existing.DateCreated,
+			DateModified: types.NewTimestamp(time.Now().UTC()),
+			UserGroupID:  groupID,
+		}); err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to update group: %v", err)}
+		}
+
+		return UserGroupSavedMsg{UserGroupID: groupID, Label: msg.Label}
+	}
+}
+
+// HandleDeleteUserGroup processes user group deletion along with its
+// members and role grants.
+func (m Model) HandleDeleteUserGroup(msg DeleteUserGroupRequestMsg) tea.Cmd {
+	logger := m.Logger
+	if logger == nil {
+		logger = utility.DefaultLogger
+	}
+	cfg := m.Config
+	userID := m.UserID
+	return func() tea.Msg {
+		d := db.ConfigDB(*cfg)
+		ctx := context.Background()
+		ac := middleware.AuditContextFromCLI(*cfg, userID)
+
+		logger.Finfo(fmt.Sprintf("Deleting user group: %s", msg.UserGroupID))
+
+		if err := d.DeleteUserGroupMembersByUserGroupID(ctx, ac, msg.UserGroupID); err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to remove group members: %v", err)}
+		}
+		if err := d.DeleteUserGroupRolesByUserGroupID(ctx, ac, msg.UserGroupID); err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to remove group roles: %v", err)}
+		}
+		if err := d.DeleteUserGroup(ctx, ac, msg.UserGroupID); err != nil {
+			logger.Ferror("failed to delete user group", err)
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to delete group: %v", err)}
+		}
+
+		logger.Finfo(fmt.Sprintf("User group deleted: %s", msg.UserGroupID))
+		return UserGroupDeletedMsg{UserGroupID: msg.UserGroupID}
+	}
+}
+
+// HandleToggleUserGroupRole grants the role to the group, or revokes it if
+// the group already holds it.
+func (m Model) HandleToggleUserGroupRole(msg ToggleUserGroupRoleRequestMsg) tea.Cmd {
+	cfg := m.Config
+	userID := m.UserID
+	return func() tea.Msg {
+		d := db.ConfigDB(*cfg)
+		ctx := context.Background()
+		ac := middleware.AuditContextFromCLI(*cfg, userID)
+
+		existing, err := d.ListUserGroupRolesByUserGroupID(msg.UserGroupID)
+		if err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to list group roles: %v", err)}
+		}
+		if existing != nil {
+			for _, gr := range *existing {
+				if gr.RoleID != msg.RoleID {
+					continue
+				}
+				if err := d.DeleteUserGroupRole(ctx, ac, gr.ID); err != nil {
+					return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to revoke group role: %v", err)}
+				}
+				return UserGroupAssignmentsChangedMsg{UserGroupID: msg.UserGroupID}
+			}
+		}
+
+		if _, err := d.CreateUserGroupRole(ctx, ac, db.CreateUserGroupRoleParams{
+			UserGroupID: msg.UserGroupID,
+			RoleID:      msg.RoleID,
+		}); err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to grant group role: %v", err)}
+		}
+		return UserGroupAssignmentsChangedMsg{UserGroupID: msg.UserGroupID}
+	}
+}
+
+// HandleToggleUserGroupMember adds the user to the group, or removes them if
+// they are already a member.
+func (m Model) HandleToggleUserGroupMember(msg ToggleUserGroupMemberRequestMsg) tea.Cmd {
+	cfg := m.Config
+	userID := m.UserID
+	return func() tea.Msg {
+		d := db.ConfigDB(*cfg)
+		ctx := context.Background()
+		ac := middleware.AuditContextFromCLI(*cfg, userID)
+
+		existing, err := d.ListUserGroupMembersByUserGroupID(msg.UserGroupID)
+		if err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to list group members: %v", err)}
+		}
+		if existing != nil {
+			for _, gm := range *existing {
+				if gm.UserID != msg.UserID {
+					continue
+				}
+				if err := d.DeleteUserGroupMember(ctx, ac, gm.ID); err != nil {
+					return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to remove group member: %v", err)}
+				}
+				return UserGroupAssignmentsChangedMsg{UserGroupID: msg.UserGroupID}
+			}
+		}
+
+		if _, err := d.CreateUserGroupMember(ctx, ac, db.CreateUserGroupMemberParams{
+			UserGroupID: msg.UserGroupID,
+			UserID:      msg.UserID,
+		}); err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to add group member: %v", err)}
+		}
+		return UserGroupAssignmentsChangedMsg{UserGroupID: msg.UserGroupID}
+	}
+}
+
+// HandleToggleUserRole assigns an additional role to the user, or revokes it
+// if already assigned. The primary role cannot be revoked here.
+func (m Model) HandleToggleUserRole(msg ToggleUserRoleRequestMsg) tea.Cmd {
+	cfg := m.Config
+	userID := m.UserID
+	return func() tea.Msg {
+		d := db.ConfigDB(*cfg)
+		ctx := context.Background()
+		ac := middleware.AuditContextFromCLI(*cfg, userID)
+
+		user, err := d.GetUser(msg.UserID)
+		if err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to get user: %v", err)}
+		}
+		if types.RoleID(user.Role) == msg.RoleID {
+			return ActionResultMsg{Title: "Primary Role", Message: "The primary role cannot be revoked. Edit the user to change it."}
+		}
+
+		existing, err := d.ListUserRolesByUserID(msg.UserID)
+		if err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to list user roles: %v", err)}
+		}
+		if existing != nil {
+			for _, ur := range *existing {
+				if ur.RoleID != msg.RoleID {
+					continue
+				}
+				if err := d.DeleteUserRole(ctx, ac, ur.ID); err != nil {
+					return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to revoke role: %v", err)}
+				}
+				return UserAccessChangedMsg{UserID: msg.UserID}
+			}
+		}
+
+		if _, err := d.CreateUserRole(ctx, ac, db.CreateUserRoleParams{
+			UserID: msg.UserID,
+			RoleID: msg.RoleID,
+		}); err != nil {
+			return ActionResultMsg{Title: "Error", Message: fmt.Sprintf("failed to assign role: %v", err)}
+		}
+		return UserAccessChangedMsg{UserID: msg.UserID}
+	}
+}
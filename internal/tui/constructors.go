@@ -682,6 +682,15 @@ func MediaDimensionsFetchCmd() tea.Cmd { return func() tea.Msg { return MediaDim
 // RolesScreenFetchCmd creates a command to fetch roles screen data.
 func RolesScreenFetchCmd() tea.Cmd { return func() tea.Msg { return RolesScreenFetchMsg{} } }
 
+// UserGroupsFetchCmd creates a command to fetch user groups screen data.
+func UserGroupsFetchCmd() tea.Cmd { return func() tea.Msg { return UserGroupsFetchMsg{} } }
+
+// LocalesFetchCmd creates a command to fetch locales screen data.
+func LocalesFetchCmd() tea.Cmd { return func() tea.Msg { return LocalesFetchMsg{} } }
+
+// TranslationsFetchCmd creates a command to fetch translation screen data.
+func TranslationsFetchCmd() tea.Cmd { return func() tea.Msg { return TranslationsFetchMsg{} } }
+
 // AuditFetchCmd creates a command to fetch audit log events.
 func AuditFetchCmd() tea.Cmd { return func() tea.Msg { return AuditFetchMsg{} } }
 
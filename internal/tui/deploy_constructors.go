@@ -3,6 +3,7 @@ package tui
 import (
 	tea "charm.land/bubbletea/v2"
 	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/deploy"
 )
 
 // DeployEnvsFetchCmd creates a command to fetch deploy environments from config.
@@ -30,6 +31,22 @@ func DeployPushCmd(envName string, dryRun bool) tea.Cmd {
 	return func() tea.Msg { return DeployPushRequestMsg{EnvName: envName, DryRun: dryRun} }
 }
 
+// DeployMergePullCmd creates a command to merge-pull from a remote environment
+// with the given conflict resolutions.
+func DeployMergePullCmd(envName string, dryRun bool, resolutions []deploy.ConflictResolution) tea.Cmd {
+	return func() tea.Msg {
+		return DeployPullRequestMsg{EnvName: envName, DryRun: dryRun, Merge: true, Resolutions: resolutions}
+	}
+}
+
+// DeployMergePushCmd creates a command to merge-push to a remote environment
+// with the given conflict resolutions.
+func DeployMergePushCmd(envName string, dryRun bool, resolutions []deploy.ConflictResolution) tea.Cmd {
+	return func() tea.Msg {
+		return DeployPushRequestMsg{EnvName: envName, DryRun: dryRun, Merge: true, Resolutions: resolutions}
+	}
+}
+
 // ShowDeployConfirmPullCmd shows a confirmation dialog for pulling from a remote environment.
 func ShowDeployConfirmPullCmd(envName string) tea.Cmd {
 	return func() tea.Msg { return DeployConfirmPullMsg{EnvName: envName} }
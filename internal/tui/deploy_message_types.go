@@ -1,6 +1,9 @@
 package tui
 
-import "github.com/hegner123/modulacms/internal/config"
+import (
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/deploy"
+)
 
 // DeploySyncResult holds the result of a pull/push operation for display in the TUI.
 type DeploySyncResult struct {
@@ -13,6 +16,9 @@ type DeploySyncResult struct {
 	Duration       string
 	Warnings       []string
 	Errors         []string
+	Merge          bool
+	Changes        map[string]deploy.TableChanges
+	Conflicts      []deploy.MergeConflict
 }
 
 // DeployHealthResult holds the result of a health check for display in the TUI.
@@ -46,15 +52,21 @@ type DeployTestConnectionRequestMsg struct {
 }
 
 // DeployPullRequestMsg requests pulling data from a remote environment.
+// Merge selects the three-way merge strategy; Resolutions settle the
+// conflicts a previous merge dry run reported.
 type DeployPullRequestMsg struct {
-	EnvName string
-	DryRun  bool
+	EnvName     string
+	DryRun      bool
+	Merge       bool
+	Resolutions []deploy.ConflictResolution
 }
 
 // DeployPushRequestMsg requests pushing data to a remote environment.
 type DeployPushRequestMsg struct {
-	EnvName string
-	DryRun  bool
+	EnvName     string
+	DryRun      bool
+	Merge       bool
+	Resolutions []deploy.ConflictResolution
 }
 
 // =============================================================================
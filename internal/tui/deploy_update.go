@@ -100,21 +100,34 @@ func (m Model) UpdateDeployCms(msg tea.Msg) (Model, tea.Cmd) {
 		dryRun := msg.DryRun
 		// Screen manages its own OperationActive/StatusMessage state
 		opLabel := "pull"
+		if msg.Merge {
+			opLabel = "merge pull"
+		}
 		if dryRun {
-			opLabel = "dry-run pull"
+			opLabel = "dry-run " + opLabel
+		}
+		opts := deploy.ExportOptions{Resolutions: msg.Resolutions}
+		if msg.Merge {
+			opts.Strategy = deploy.StrategyMerge
 		}
 		return m, func() tea.Msg {
 			ctx := context.Background()
-			result, err := deploy.Pull(ctx, *cfg, driver, envName, deploy.ExportOptions{}, false, dryRun)
+			result, err := deploy.Pull(ctx, *cfg, driver, envName, opts, false, dryRun)
 			if err != nil {
+				display := &DeploySyncResult{
+					Success:   false,
+					DryRun:    dryRun,
+					Operation: opLabel,
+					EnvName:   envName,
+				}
+				// A merge that hit conflicts still reports them for review.
+				if result != nil {
+					display = syncResultToDisplay(result, opLabel, envName)
+					display.DryRun = dryRun
+				}
 				return DeployPullResultMsg{
-					Err: err.Error(),
-					Result: &DeploySyncResult{
-						Success:   false,
-						DryRun:    dryRun,
-						Operation: opLabel,
-						EnvName:   envName,
-					},
+					Err:    err.Error(),
+					Result: display,
 				}
 			}
 			return DeployPullResultMsg{
@@ -145,21 +158,34 @@ func (m Model) UpdateDeployCms(msg tea.Msg) (Model, tea.Cmd) {
 		dryRun := msg.DryRun
 		// Screen manages its own OperationActive/StatusMessage state
 		opLabel := "push"
+		if msg.Merge {
+			opLabel = "merge push"
+		}
 		if dryRun {
-			opLabel = "dry-run push"
+			opLabel = "dry-run " + opLabel
+		}
+		opts := deploy.ExportOptions{Resolutions: msg.Resolutions}
+		if msg.Merge {
+			opts.Strategy = deploy.StrategyMerge
 		}
 		return m, func() tea.Msg {
 			ctx := context.Background()
-			result, err := deploy.Push(ctx, *cfg, driver, envName, deploy.ExportOptions{}, dryRun)
+			result, err := deploy.Push(ctx, *cfg, driver, envName, opts, dryRun)
 			if err != nil {
+				display := &DeploySyncResult{
+					Success:   false,
+					DryRun:    dryRun,
+					Operation: opLabel,
+					EnvName:   envName,
+				}
+				// A merge that hit conflicts still reports them for review.
+				if result != nil {
+					display = syncResultToDisplay(result, opLabel, envName)
+					display.DryRun = dryRun
+				}
 				return DeployPushResultMsg{
-					Err: err.Error(),
-					Result: &DeploySyncResult{
-						Success:   false,
-						DryRun:    dryRun,
-						Operation: opLabel,
-						EnvName:   envName,
-					},
+					Err:    err.Error(),
+					Result: display,
 				}
 			}
 			return DeployPushResultMsg{
@@ -197,5 +223,8 @@ func syncResultToDisplay(r *deploy.SyncResult, operation, envName string) *Deplo
 		Duration:       r.Duration,
 		Warnings:       r.Warnings,
 		Errors:         errs,
+		Merge:          r.Strategy == deploy.StrategyMerge,
+		Changes:        r.Changes,
+		Conflicts:      r.Conflicts,
 	}
 }
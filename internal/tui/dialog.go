@@ -8,7 +8,9 @@ import (
 	"charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
 	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/publishing"
 )
 
 // DialogAction represents the type of action this dialog performs
@@ -56,6 +58,8 @@ const (
 	DIALOGDELETESSHKEY         DialogAction = "delete_ssh_key"
 	DIALOGDELETEADMINMEDIA     DialogAction = "delete_admin_media"
 	DIALOGDELETEADMINMEDIAFOLDER DialogAction = "delete_admin_media_folder"
+	DIALOGDELETEUSERGROUP      DialogAction = "delete_user_group"
+	DIALOGDELETELOCALE         DialogAction = "delete_locale"
 )
 
 // dialogBorderPadding accounts for border and padding in dialog width calculations.
@@ -143,7 +147,9 @@ func (d *DialogModel) Update(msg tea.Msg) (DialogModel, tea.Cmd) {
 		DIALOGDELETEROLE,
 		DIALOGDELETESSHKEY,
 		DIALOGDELETEADMINMEDIA,
-		DIALOGDELETEADMINMEDIAFOLDER:
+		DIALOGDELETEADMINMEDIAFOLDER,
+		DIALOGDELETEUSERGROUP,
+		DIALOGDELETELOCALE:
 		return d.ToggleControls(msg)
 	case DIALOGLOCALESELECT:
 		return d.LocaleSelectControls(msg)
@@ -442,21 +448,45 @@ func buildLocaleDialogMessage(locales []string, cursor int) string {
 }
 
 // ShowPublishDialogMsg triggers showing a publish/unpublish confirmation dialog.
+// Diff summarizes what publishing changes relative to the published version.
+// Locale, when set, publishes that locale instead of the active one.
 type ShowPublishDialogMsg struct {
 	ContentID   types.ContentID
 	RouteID     types.RouteID
 	ContentName string
 	IsPublished bool
+	Diff        string
+	Locale      string
 }
 
 // ShowPublishDialogCmd creates a command to show the publish confirmation dialog.
-func ShowPublishDialogCmd(contentID types.ContentID, routeID types.RouteID, contentName string, isPublished bool) tea.Cmd {
+// Before a publish it diffs the published version against the draft in locale.
+func ShowPublishDialogCmd(d db.DbDriver, locale string, contentID types.ContentID, routeID types.RouteID, contentName string, isPublished bool) tea.Cmd {
 	return func() tea.Msg {
+		var diff string
+		if !isPublished {
+			diff = loadVersionDiffSummary(d, contentID, "", publishing.DraftSide, locale)
+		}
 		return ShowPublishDialogMsg{
 			ContentID:   contentID,
 			RouteID:     routeID,
 			ContentName: contentName,
 			IsPublished: isPublished,
+			Diff:        diff,
+		}
+	}
+}
+
+// ShowPublishLocaleDialogCmd creates a command to show the publish confirmation
+// dialog for one locale, diffing that locale's draft against its published version.
+func ShowPublishLocaleDialogCmd(d db.DbDriver, locale string, contentID types.ContentID, routeID types.RouteID, contentName string) tea.Cmd {
+	return func() tea.Msg {
+		return ShowPublishDialogMsg{
+			ContentID:   contentID,
+			RouteID:     routeID,
+			ContentName: contentName,
+			Diff:        loadVersionDiffSummary(d, contentID, "", publishing.DraftSide, locale),
+			Locale:      locale,
 		}
 	}
 }
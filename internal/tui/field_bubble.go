@@ -28,3 +28,11 @@ type FieldBubble interface {
 	// SetWidth sets the input width for layout within the dialog.
 	SetWidth(w int)
 }
+
+// KeyCapturer is implemented by bubbles that sometimes need keys the content
+// form otherwise uses for navigation (tab, arrows, enter, esc), such as a
+// picker with an open browse list. The form forwards a key straight to the
+// focused bubble whenever CapturesKey reports true for it.
+type KeyCapturer interface {
+	CapturesKey(key string) bool
+}
@@ -22,6 +22,7 @@ const (
 	FORMDIALOGCREATEROUTE                 FormDialogAction = "create_route"
 	FORMDIALOGEDITROUTE                   FormDialogAction = "edit_route"
 	FORMDIALOGCREATEROUTEWITHCONTENT      FormDialogAction = "create_route_with_content"
+	FORMDIALOGCREATEROUTEFROMBLUEPRINT    FormDialogAction = "create_route_from_blueprint"
 	FORMDIALOGINITIALIZEROUTECONTENT      FormDialogAction = "initialize_route_content"
 	FORMDIALOGCHILDDATATYPE               FormDialogAction = "child_datatype"
 	FORMDIALOGCREATECONTENT               FormDialogAction = "create_content"
@@ -55,6 +56,11 @@ const (
 	FORMDIALOGEDITMEDIADIMENSION           FormDialogAction = "edit_media_dimension"
 	FORMDIALOGCREATEROLE                   FormDialogAction = "create_role"
 	FORMDIALOGEDITROLE                     FormDialogAction = "edit_role"
+	FORMDIALOGCREATEUSERGROUP              FormDialogAction = "create_user_group"
+	FORMDIALOGEDITUSERGROUP                FormDialogAction = "edit_user_group"
+	FORMDIALOGCREATELOCALE                 FormDialogAction = "create_locale"
+	FORMDIALOGEDITLOCALE                   FormDialogAction = "edit_locale"
+	FORMDIALOGEDITTRANSLATION              FormDialogAction = "edit_translation"
 	FORMDIALOGCREATEWEBHOOK               FormDialogAction = "create_webhook"
 	FORMDIALOGEDITWEBHOOK                 FormDialogAction = "edit_webhook"
 	FORMDIALOGCREATEVALIDATION            FormDialogAction = "create_validation"
@@ -563,6 +569,7 @@ func (d FormDialogModel) Render(windowWidth, windowHeight int) string {
 	if d.Action == FORMDIALOGCREATEROUTE || d.Action == FORMDIALOGEDITROUTE ||
 		d.Action == FORMDIALOGCREATEADMINROUTE || d.Action == FORMDIALOGEDITADMINROUTE ||
 		d.Action == FORMDIALOGCREATEROUTEWITHCONTENT ||
+		d.Action == FORMDIALOGCREATEROUTEFROMBLUEPRINT ||
 		d.Action == FORMDIALOGCREATEADMINROUTEWITHCONTENT {
 		firstFieldLabel = "Title"
 		secondFieldLabel = "Slug"
@@ -632,6 +639,9 @@ func (d FormDialogModel) Render(windowWidth, windowHeight int) string {
 		if d.Action == FORMDIALOGCREATEROUTEWITHCONTENT || d.Action == FORMDIALOGCREATEADMINROUTEWITHCONTENT {
 			parentLabelText = "Datatype"
 		}
+		if d.Action == FORMDIALOGCREATEROUTEFROMBLUEPRINT {
+			parentLabelText = "Blueprint"
+		}
 		parentLabel := d.labelStyle.Render(parentLabelText)
 		content.WriteString(parentLabel)
 		content.WriteString("\n")
@@ -50,6 +50,10 @@ type ContentFormDialogModel struct {
 	// Logger for editor and dialog operations (nil-safe; callers should set after construction)
 	Logger Logger
 
+	// ExternalEditor enables ctrl+e for richtext and json fields without an
+	// editor widget. Set by ConfigureEditors for local sessions.
+	ExternalEditor bool
+
 	// Focus management: 0 to len(Fields)-1 for fields, then Cancel, then Confirm
 	focusIndex int
 
@@ -106,6 +110,12 @@ func (d *ContentFormDialogModel) ButtonConfirmIndex() int {
 func (d *ContentFormDialogModel) Update(msg tea.Msg) (ContentFormDialogModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
+		// Bubbles such as open pickers claim navigation keys for themselves.
+		if d.focusIndex < len(d.Fields) {
+			if kc, ok := d.Fields[d.focusIndex].Bubble.(KeyCapturer); ok && kc.CapturesKey(msg.String()) {
+				return d.updateFocusedField(msg)
+			}
+		}
 		switch msg.String() {
 		case "tab", "down":
 			d.focusNext()
@@ -175,13 +185,13 @@ func (d *ContentFormDialogModel) Update(msg tea.Msg) (ContentFormDialogModel, te
 				if d.Logger != nil {
 					d.Logger.Finfo(fmt.Sprintf("[editor] ctrl+e pressed on field %d (%s), widget=%q, type=%q", d.focusIndex, f.Label, f.Widget, f.Type))
 				}
-				if isEditorWidget(f.Widget) {
+				if widget := d.editorWidget(f); widget != "" {
 					fieldIdx := d.focusIndex
 					currentValue := f.Bubble.Value()
 					if d.Logger != nil {
-						d.Logger.Finfo(fmt.Sprintf("[editor] widget %q is editor-capable, preparing editor cmd for field %d, value length=%d", f.Widget, fieldIdx, len(currentValue)))
+						d.Logger.Finfo(fmt.Sprintf("[editor] widget %q is editor-capable, preparing editor cmd for field %d, value length=%d", widget, fieldIdx, len(currentValue)))
 					}
-					cmd := prepareEditorCmd(fieldIdx, currentValue, f.Widget, d.Logger)
+					cmd := prepareEditorCmd(fieldIdx, currentValue, widget, d.Logger)
 					if cmd != nil {
 						if d.Logger != nil {
 							d.Logger.Finfo(fmt.Sprintf("[editor] editor cmd prepared successfully for field %d", fieldIdx))
@@ -203,21 +213,59 @@ func (d *ContentFormDialogModel) Update(msg tea.Msg) (ContentFormDialogModel, te
 			}
 		}
 
-		// Update the focused field bubble
-		if d.focusIndex < len(d.Fields) {
-			var cmd tea.Cmd
-			d.Fields[d.focusIndex].Bubble, cmd = d.Fields[d.focusIndex].Bubble.Update(msg)
-			// Clear validation errors for this field when the user edits it
-			if d.ValidationErrors != nil {
-				d.ValidationErrors.ClearField(d.Fields[d.focusIndex].FieldID)
-			}
-			return *d, cmd
-		}
+		return d.updateFocusedField(msg)
 	}
 
 	return *d, nil
 }
 
+// updateFocusedField forwards a key to the focused field bubble.
+func (d *ContentFormDialogModel) updateFocusedField(msg tea.KeyPressMsg) (ContentFormDialogModel, tea.Cmd) {
+	if d.focusIndex >= len(d.Fields) {
+		return *d, nil
+	}
+	var cmd tea.Cmd
+	d.Fields[d.focusIndex].Bubble, cmd = d.Fields[d.focusIndex].Bubble.Update(msg)
+	// Clear validation errors for this field when the user edits it
+	if d.ValidationErrors != nil {
+		d.ValidationErrors.ClearField(d.Fields[d.focusIndex].FieldID)
+	}
+	return *d, cmd
+}
+
+// editorWidget returns the widget whose $EDITOR settings apply to f, or ""
+// when ctrl+e does nothing for it. Editor widgets always qualify; richtext
+// and json fields qualify when ExternalEditor is set.
+func (d *ContentFormDialogModel) editorWidget(f ContentFieldInput) string {
+	if isEditorWidget(f.Widget) {
+		return f.Widget
+	}
+	if !d.ExternalEditor {
+		return ""
+	}
+	switch types.FieldType(f.Type) {
+	case types.FieldTypeRichText:
+		return "rich-text"
+	case types.FieldTypeJSON:
+		return "json-editor"
+	default:
+		return ""
+	}
+}
+
+// ConfigureEditors wires session-dependent editor support into the form.
+// Picker bubbles get the driver they browse (admin selects admin content),
+// and richtext and json fields get $EDITOR round-tripping unless the
+// session runs over SSH.
+func (d *ContentFormDialogModel) ConfigureEditors(driver db.DbDriver, admin, ssh bool) {
+	d.ExternalEditor = !ssh
+	for _, f := range d.Fields {
+		if ps, ok := f.Bubble.(pickerSource); ok {
+			ps.SetSource(driver, admin)
+		}
+	}
+}
+
 // focusNext advances focus to the next focusable element in the content form,
 // skipping hidden fields while keeping indices stable.
 func (d *ContentFormDialogModel) focusNext() {
@@ -315,7 +363,7 @@ func (d *ContentFormDialogModel) Render(windowWidth, windowHeight int) string {
 
 		var item strings.Builder
 		label := d.labelStyle.Render(f.Label)
-		if isEditorWidget(f.Widget) {
+		if d.editorWidget(f) != "" {
 			label += " " + editorHintStyle.Render("ctrl+e: $EDITOR")
 		}
 		item.WriteString(label)
@@ -543,6 +591,9 @@ func NewEditContentFormDialog(title string, contentID types.ContentID, datatypeI
 				sb.ParseOptionsFromData(f.DataJSON)
 			}
 		}
+		if jb, ok := bubble.(*JSONBubble); ok {
+			jb.Configure(f.ValidationJSON, f.DataJSON)
+		}
 
 		bubble.SetValue(f.Value)
 
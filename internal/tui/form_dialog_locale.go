@@ -0,0 +1,394 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+)
+
+// =============================================================================
+// LOCALE FORM DIALOG
+// =============================================================================
+
+// localeNoFallback is the first fallback option, meaning no fallback locale.
+const localeNoFallback = "(none)"
+
+// LocaleFormDialogModel represents a form dialog for locale CRUD operations.
+type LocaleFormDialogModel struct {
+	dialogStyles
+
+	Title    string
+	Width    int
+	Action   FormDialogAction
+	EntityID string // LocaleID for edit operations
+
+	CodeInput      textinput.Model
+	LabelInput     textinput.Model
+	SortOrderInput textinput.Model
+
+	// Fallback carousel: localeNoFallback followed by the other locale codes.
+	FallbackOptions []string
+	FallbackIndex   int
+
+	// Yes/No carousels
+	EnabledIndex int // 0=Yes, 1=No
+	DefaultIndex int // 0=Yes, 1=No
+
+	// Focus indices:
+	// 0=Code, 1=Label, 2=Fallback, 3=SortOrder, 4=Enabled, 5=Default, 6=Cancel, 7=Confirm
+	focusIndex int
+}
+
+const (
+	localeFocusCode      = 0
+	localeFocusLabel     = 1
+	localeFocusFallback  = 2
+	localeFocusSortOrder = 3
+	localeFocusEnabled   = 4
+	localeFocusDefault   = 5
+	localeFocusCancel    = 6
+	localeFocusConfirm   = 7
+	localeMaxFocus       = 7
+)
+
+// localeYesNo are the options of the Enabled and Default carousels.
+var localeYesNo = []string{"Yes", "No"}
+
+func newLocaleInputs(code, label string, sortOrder int64) (textinput.Model, textinput.Model, textinput.Model) {
+	codeInput := textinput.New()
+	codeInput.Placeholder = "fr-CA"
+	codeInput.CharLimit = 35
+	codeInput.SetWidth(40)
+	codeInput.SetValue(code)
+	codeInput.Focus()
+
+	labelInput := textinput.New()
+	labelInput.Placeholder = "French (Canada)"
+	labelInput.CharLimit = 128
+	labelInput.SetWidth(40)
+	labelInput.SetValue(label)
+
+	sortInput := textinput.New()
+	sortInput.Placeholder = "0"
+	sortInput.CharLimit = 9
+	sortInput.SetWidth(40)
+	sortInput.SetValue(strconv.FormatInt(sortOrder, 10))
+
+	return codeInput, labelInput, sortInput
+}
+
+// localeFallbackOptions lists the fallback choices for a locale: no fallback,
+// then every other locale code.
+func localeFallbackOptions(locales []db.Locale, selfCode string) []string {
+	options := []string{localeNoFallback}
+	for _, l := range locales {
+		if l.Code == selfCode {
+			continue
+		}
+		options = append(options, l.Code)
+	}
+	return options
+}
+
+// NewLocaleFormDialog creates a locale form dialog for creating a new locale.
+// locales supplies the fallback choices.
+func NewLocaleFormDialog(title string, locales []db.Locale) LocaleFormDialogModel {
+	codeInput, labelInput, sortInput := newLocaleInputs("", "", int64(len(locales)))
+	return LocaleFormDialogModel{
+		dialogStyles:    newDialogStyles(),
+		Title:           title,
+		Width:           56,
+		Action:          FORMDIALOGCREATELOCALE,
+		CodeInput:       codeInput,
+		LabelInput:      labelInput,
+		SortOrderInput:  sortInput,
+		FallbackOptions: localeFallbackOptions(locales, ""),
+		EnabledIndex:    0, // new locales are enabled
+		DefaultIndex:    1,
+		focusIndex:      localeFocusCode,
+	}
+}
+
+// NewEditLocaleFormDialog creates a locale form dialog pre-populated for editing.
+func NewEditLocaleFormDialog(title string, locale db.Locale, locales []db.Locale) LocaleFormDialogModel {
+	codeInput, labelInput, sortInput := newLocaleInputs(locale.Code, locale.Label, locale.SortOrder)
+	options := localeFallbackOptions(locales, locale.Code)
+	fallbackIndex := 0
+	for i, code := range options {
+		if i > 0 && code == locale.FallbackCode {
+			fallbackIndex = i
+			break
+		}
+	}
+	enabledIndex := 0
+	if !locale.IsEnabled {
+		enabledIndex = 1
+	}
+	defaultIndex := 1
+	if locale.IsDefault {
+		defaultIndex = 0
+	}
+	return LocaleFormDialogModel{
+		dialogStyles:    newDialogStyles(),
+		Title:           title,
+		Width:           56,
+		Action:          FORMDIALOGEDITLOCALE,
+		EntityID:        string(locale.LocaleID),
+		CodeInput:       codeInput,
+		LabelInput:      labelInput,
+		SortOrderInput:  sortInput,
+		FallbackOptions: options,
+		FallbackIndex:   fallbackIndex,
+		EnabledIndex:    enabledIndex,
+		DefaultIndex:    defaultIndex,
+		focusIndex:      localeFocusCode,
+	}
+}
+
+// Update handles user input for the locale form dialog.
+func (d *LocaleFormDialogModel) Update(msg tea.Msg) (LocaleFormDialogModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "tab", "down":
+			d.focusIndex = (d.focusIndex + 1) % (localeMaxFocus + 1)
+			d.updateLocaleFocus()
+			return *d, nil
+		case "shift+tab", "up":
+			d.focusIndex = (d.focusIndex + localeMaxFocus) % (localeMaxFocus + 1)
+			d.updateLocaleFocus()
+			return *d, nil
+		case "left":
+			if d.stepCarousel(-1) {
+				return *d, nil
+			}
+			if d.focusIndex == localeFocusConfirm {
+				d.focusIndex = localeFocusCancel
+				return *d, nil
+			}
+		case "right":
+			if d.stepCarousel(1) {
+				return *d, nil
+			}
+			if d.focusIndex == localeFocusCancel {
+				d.focusIndex = localeFocusConfirm
+				return *d, nil
+			}
+		case "enter":
+			if d.focusIndex == localeFocusConfirm {
+				accept := d.acceptMsg()
+				return *d, func() tea.Msg { return accept }
+			}
+			if d.focusIndex == localeFocusCancel {
+				return *d, func() tea.Msg { return LocaleFormDialogCancelMsg{} }
+			}
+			d.focusIndex = (d.focusIndex + 1) % (localeMaxFocus + 1)
+			d.updateLocaleFocus()
+			return *d, nil
+		case "esc":
+			return *d, func() tea.Msg { return LocaleFormDialogCancelMsg{} }
+		}
+	}
+
+	return *d, d.updateFocusedInput(msg)
+}
+
+// stepCarousel moves the focused carousel by delta and reports whether a
+// carousel had focus.
+func (d *LocaleFormDialogModel) stepCarousel(delta int) bool {
+	step := func(index, n int) int {
+		return max(0, min(index+delta, n-1))
+	}
+	switch d.focusIndex {
+	case localeFocusFallback:
+		d.FallbackIndex = step(d.FallbackIndex, len(d.FallbackOptions))
+	case localeFocusEnabled:
+		d.EnabledIndex = step(d.EnabledIndex, len(localeYesNo))
+	case localeFocusDefault:
+		d.DefaultIndex = step(d.DefaultIndex, len(localeYesNo))
+	default:
+		return false
+	}
+	return true
+}
+
+// acceptMsg collects the form values. An unparseable sort order is sent as
+// the raw text so the handler can report it.
+func (d *LocaleFormDialogModel) acceptMsg() LocaleFormDialogAcceptMsg {
+	fallback := ""
+	if d.FallbackIndex > 0 && d.FallbackIndex < len(d.FallbackOptions) {
+		fallback = d.FallbackOptions[d.FallbackIndex]
+	}
+	return LocaleFormDialogAcceptMsg{
+		Action:       d.Action,
+		EntityID:     d.EntityID,
+		Code:         strings.TrimSpace(d.CodeInput.Value()),
+		Label:        strings.TrimSpace(d.LabelInput.Value()),
+		FallbackCode: fallback,
+		SortOrder:    strings.TrimSpace(d.SortOrderInput.Value()),
+		IsEnabled:    d.EnabledIndex == 0,
+		IsDefault:    d.DefaultIndex == 0,
+	}
+}
+
+func (d *LocaleFormDialogModel) updateFocusedInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	switch d.focusIndex {
+	case localeFocusCode:
+		d.CodeInput, cmd = d.CodeInput.Update(msg)
+	case localeFocusLabel:
+		d.LabelInput, cmd = d.LabelInput.Update(msg)
+	case localeFocusSortOrder:
+		d.SortOrderInput, cmd = d.SortOrderInput.Update(msg)
+	}
+	return cmd
+}
+
+func (d *LocaleFormDialogModel) updateLocaleFocus() {
+	d.CodeInput.Blur()
+	d.LabelInput.Blur()
+	d.SortOrderInput.Blur()
+	switch d.focusIndex {
+	case localeFocusCode:
+		d.CodeInput.Focus()
+	case localeFocusLabel:
+		d.LabelInput.Focus()
+	case localeFocusSortOrder:
+		d.SortOrderInput.Focus()
+	}
+}
+
+// OverlayUpdate implements ModalOverlay for LocaleFormDialogModel.
+func (d *LocaleFormDialogModel) OverlayUpdate(msg tea.KeyPressMsg) (ModalOverlay, tea.Cmd) {
+	updated, cmd := d.Update(msg)
+	return &updated, cmd
+}
+
+// OverlayTick forwards non-key messages to the focused text input.
+func (d *LocaleFormDialogModel) OverlayTick(msg tea.Msg) (ModalOverlay, tea.Cmd) {
+	return d, d.updateFocusedInput(msg)
+}
+
+// OverlayView implements ModalOverlay for LocaleFormDialogModel.
+func (d *LocaleFormDialogModel) OverlayView(width, height int) string {
+	return d.Render(width, height)
+}
+
+// Render renders the locale form dialog.
+func (d LocaleFormDialogModel) Render(windowWidth, windowHeight int) string {
+	contentWidth := d.Width
+	innerW := contentWidth - dialogBorderPadding
+
+	var content strings.Builder
+	content.WriteString(d.titleStyle.Render(d.Title))
+	content.WriteString("\n\n")
+
+	inputs := []struct {
+		label string
+		focus int
+		input textinput.Model
+	}{
+		{"Code (BCP 47)", localeFocusCode, d.CodeInput},
+		{"Label", localeFocusLabel, d.LabelInput},
+	}
+	for _, in := range inputs {
+		content.WriteString(d.labelStyle.Render(in.label))
+		content.WriteString("\n")
+		if d.focusIndex == in.focus {
+			content.WriteString(d.focusedInputStyle.Width(innerW).Render(in.input.View()))
+		} else {
+			content.WriteString(d.inputStyle.Width(innerW).Render(in.input.View()))
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString(d.labelStyle.Render("Fallback"))
+	content.WriteString("\n")
+	content.WriteString(d.renderCarousel(d.FallbackOptions[d.FallbackIndex], d.focusIndex == localeFocusFallback))
+	content.WriteString("\n")
+
+	content.WriteString(d.labelStyle.Render("Sort Order"))
+	content.WriteString("\n")
+	if d.focusIndex == localeFocusSortOrder {
+		content.WriteString(d.focusedInputStyle.Width(innerW).Render(d.SortOrderInput.View()))
+	} else {
+		content.WriteString(d.inputStyle.Width(innerW).Render(d.SortOrderInput.View()))
+	}
+	content.WriteString("\n")
+
+	content.WriteString(d.labelStyle.Render("Enabled"))
+	content.WriteString("\n")
+	content.WriteString(d.renderCarousel(localeYesNo[d.EnabledIndex], d.focusIndex == localeFocusEnabled))
+	content.WriteString("\n")
+
+	content.WriteString(d.labelStyle.Render("Default"))
+	content.WriteString("\n")
+	content.WriteString(d.renderCarousel(localeYesNo[d.DefaultIndex], d.focusIndex == localeFocusDefault))
+	content.WriteString("\n\n")
+
+	cancelStyle := d.cancelButtonStyle
+	confirmStyle := d.confirmButtonStyle
+	if d.focusIndex == localeFocusCancel {
+		cancelStyle = cancelStyle.Background(config.DefaultStyle.Accent).Foreground(config.DefaultStyle.Primary)
+	}
+	if d.focusIndex == localeFocusConfirm {
+		confirmStyle = confirmStyle.Background(config.DefaultStyle.Accent).Foreground(config.DefaultStyle.Primary)
+	}
+	cancelBtn := cancelStyle.Render(buttonLabel("Cancel", d.focusIndex == localeFocusCancel))
+	confirmBtn := confirmStyle.Render(buttonLabel("Save", d.focusIndex == localeFocusConfirm))
+	buttonBar := lipgloss.JoinHorizontal(lipgloss.Center, cancelBtn, "  ", confirmBtn)
+	content.WriteString(buttonBar)
+
+	return d.borderStyle.Width(contentWidth).Render(content.String())
+}
+
+// renderCarousel renders a left/right option selector.
+func (d LocaleFormDialogModel) renderCarousel(option string, focused bool) string {
+	if focused {
+		return lipgloss.NewStyle().
+			Foreground(config.DefaultStyle.Primary).
+			Background(config.DefaultStyle.Accent).
+			Padding(0, 1).
+			Render(string(rune(9664)) + " " + option + " " + string(rune(9654)))
+	}
+	return lipgloss.NewStyle().
+		Foreground(config.DefaultStyle.Secondary).
+		Padding(0, 1).
+		Render("  " + option + "  ")
+}
+
+// =============================================================================
+// LOCALE FORM DIALOG MESSAGES
+// =============================================================================
+
+// LocaleFormDialogAcceptMsg is sent when the locale form dialog is confirmed.
+type LocaleFormDialogAcceptMsg struct {
+	Action       FormDialogAction
+	EntityID     string
+	Code         string
+	Label        string
+	FallbackCode string
+	SortOrder    string // parsed by handler
+	IsEnabled    bool
+	IsDefault    bool
+}
+
+// LocaleFormDialogCancelMsg is sent when the locale form dialog is cancelled.
+type LocaleFormDialogCancelMsg struct{}
+
+// ShowLocaleFormDialogMsg triggers showing a locale creation form dialog.
+type ShowLocaleFormDialogMsg struct {
+	Title   string
+	Locales []db.Locale
+}
+
+// ShowEditLocaleDialogMsg triggers showing a locale edit form dialog.
+type ShowEditLocaleDialogMsg struct {
+	Locale  db.Locale
+	Locales []db.Locale
+}
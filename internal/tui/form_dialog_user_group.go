@@ -0,0 +1,221 @@
+package tui
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+)
+
+// UserGroupFormDialogModel represents a form dialog for user group CRUD.
+type UserGroupFormDialogModel struct {
+	dialogStyles
+	Title    string
+	Width    int
+	Action   FormDialogAction
+	EntityID string // UserGroupID for edit
+
+	LabelInput       textinput.Model
+	DescriptionInput textinput.Model
+
+	// 0=Label, 1=Description, 2=Cancel, 3=Confirm
+	focusIndex int
+}
+
+const (
+	userGroupFocusLabel       = 0
+	userGroupFocusDescription = 1
+	userGroupFocusCancel      = 2
+	userGroupFocusConfirm     = 3
+	userGroupMaxFocus         = 3
+)
+
+func newUserGroupInputs(label, description string) (textinput.Model, textinput.Model) {
+	labelInput := textinput.New()
+	labelInput.Placeholder = "group name"
+	labelInput.CharLimit = 64
+	labelInput.SetWidth(40)
+	labelInput.SetValue(label)
+	labelInput.Focus()
+
+	descInput := textinput.New()
+	descInput.Placeholder = "description (optional)"
+	descInput.CharLimit = 255
+	descInput.SetWidth(40)
+	descInput.SetValue(description)
+
+	return labelInput, descInput
+}
+
+func NewUserGroupFormDialog(title string) UserGroupFormDialogModel {
+	labelInput, descInput := newUserGroupInputs("", "")
+	return UserGroupFormDialogModel{
+		dialogStyles:     newDialogStyles(),
+		Title:            title,
+		Width:            50,
+		Action:           FORMDIALOGCREATEUSERGROUP,
+		LabelInput:       labelInput,
+		DescriptionInput: descInput,
+		focusIndex:       userGroupFocusLabel,
+	}
+}
+
+func NewEditUserGroupFormDialog(title string, group db.UserGroups) UserGroupFormDialogModel {
+	labelInput, descInput := newUserGroupInputs(group.Label, group.Description)
+	return UserGroupFormDialogModel{
+		dialogStyles:     newDialogStyles(),
+		Title:            title,
+		Width:            50,
+		Action:           FORMDIALOGEDITUSERGROUP,
+		EntityID:         string(group.UserGroupID),
+		LabelInput:       labelInput,
+		DescriptionInput: descInput,
+		focusIndex:       userGroupFocusLabel,
+	}
+}
+
+func (d *UserGroupFormDialogModel) Update(msg tea.Msg) (UserGroupFormDialogModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "tab", "down":
+			d.focusIndex = (d.focusIndex + 1) % (userGroupMaxFocus + 1)
+			d.updateUserGroupFocus()
+			return *d, nil
+		case "shift+tab", "up":
+			d.focusIndex = (d.focusIndex + userGroupMaxFocus) % (userGroupMaxFocus + 1)
+			d.updateUserGroupFocus()
+			return *d, nil
+		case "left":
+			if d.focusIndex == userGroupFocusConfirm {
+				d.focusIndex = userGroupFocusCancel
+				return *d, nil
+			}
+		case "right":
+			if d.focusIndex == userGroupFocusCancel {
+				d.focusIndex = userGroupFocusConfirm
+				return *d, nil
+			}
+		case "enter":
+			if d.focusIndex == userGroupFocusConfirm {
+				return *d, func() tea.Msg {
+					return UserGroupFormDialogAcceptMsg{
+						Action:      d.Action,
+						EntityID:    d.EntityID,
+						Label:       strings.TrimSpace(d.LabelInput.Value()),
+						Description: strings.TrimSpace(d.DescriptionInput.Value()),
+					}
+				}
+			}
+			if d.focusIndex == userGroupFocusCancel {
+				return *d, func() tea.Msg { return UserGroupFormDialogCancelMsg{} }
+			}
+			d.focusIndex = (d.focusIndex + 1) % (userGroupMaxFocus + 1)
+			d.updateUserGroupFocus()
+			return *d, nil
+		case "escape":
+			return *d, func() tea.Msg { return UserGroupFormDialogCancelMsg{} }
+		}
+	}
+
+	return *d, d.updateFocusedInput(msg)
+}
+
+func (d *UserGroupFormDialogModel) updateFocusedInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	switch d.focusIndex {
+	case userGroupFocusLabel:
+		d.LabelInput, cmd = d.LabelInput.Update(msg)
+	case userGroupFocusDescription:
+		d.DescriptionInput, cmd = d.DescriptionInput.Update(msg)
+	}
+	return cmd
+}
+
+func (d *UserGroupFormDialogModel) updateUserGroupFocus() {
+	d.LabelInput.Blur()
+	d.DescriptionInput.Blur()
+	switch d.focusIndex {
+	case userGroupFocusLabel:
+		d.LabelInput.Focus()
+	case userGroupFocusDescription:
+		d.DescriptionInput.Focus()
+	}
+}
+
+func (d *UserGroupFormDialogModel) OverlayUpdate(msg tea.KeyPressMsg) (ModalOverlay, tea.Cmd) {
+	updated, cmd := d.Update(msg)
+	return &updated, cmd
+}
+
+func (d *UserGroupFormDialogModel) OverlayTick(msg tea.Msg) (ModalOverlay, tea.Cmd) {
+	return d, d.updateFocusedInput(msg)
+}
+
+func (d *UserGroupFormDialogModel) OverlayView(width, height int) string {
+	return d.Render(width, height)
+}
+
+func (d UserGroupFormDialogModel) Render(windowWidth, windowHeight int) string {
+	contentWidth := d.Width
+	innerW := contentWidth - dialogBorderPadding
+
+	var content strings.Builder
+	content.WriteString(d.titleStyle.Render(d.Title))
+	content.WriteString("\n\n")
+
+	content.WriteString(d.labelStyle.Render("Group Name"))
+	content.WriteString("\n")
+	if d.focusIndex == userGroupFocusLabel {
+		content.WriteString(d.focusedInputStyle.Width(innerW).Render(d.LabelInput.View()))
+	} else {
+		content.WriteString(d.inputStyle.Width(innerW).Render(d.LabelInput.View()))
+	}
+	content.WriteString("\n\n")
+
+	content.WriteString(d.labelStyle.Render("Description"))
+	content.WriteString("\n")
+	if d.focusIndex == userGroupFocusDescription {
+		content.WriteString(d.focusedInputStyle.Width(innerW).Render(d.DescriptionInput.View()))
+	} else {
+		content.WriteString(d.inputStyle.Width(innerW).Render(d.DescriptionInput.View()))
+	}
+	content.WriteString("\n\n")
+
+	cancelStyle := d.cancelButtonStyle
+	confirmStyle := d.confirmButtonStyle
+	if d.focusIndex == userGroupFocusCancel {
+		cancelStyle = cancelStyle.Background(config.DefaultStyle.Accent).Foreground(config.DefaultStyle.Primary)
+	}
+	if d.focusIndex == userGroupFocusConfirm {
+		confirmStyle = confirmStyle.Background(config.DefaultStyle.Accent).Foreground(config.DefaultStyle.Primary)
+	}
+	cancelBtn := cancelStyle.Render(buttonLabel("Cancel", d.focusIndex == userGroupFocusCancel))
+	confirmBtn := confirmStyle.Render(buttonLabel("Save", d.focusIndex == userGroupFocusConfirm))
+	buttonBar := lipgloss.JoinHorizontal(lipgloss.Center, cancelBtn, "  ", confirmBtn)
+	content.WriteString(buttonBar)
+
+	return d.borderStyle.Width(contentWidth).Render(content.String())
+}
+
+// Messages
+
+type UserGroupFormDialogAcceptMsg struct {
+	Action      FormDialogAction
+	EntityID    string
+	Label       string
+	Description string
+}
+
+type UserGroupFormDialogCancelMsg struct{}
+
+type ShowUserGroupFormDialogMsg struct {
+	Title string
+}
+
+type ShowEditUserGroupDialogMsg struct {
+	Group db.UserGroups
+}
@@ -23,8 +23,10 @@ func (m Model) HomepageMenuInit() []Page {
 		m.PageMap[FIELDTYPES],
 		m.PageMap[VALIDATIONS],
 		m.PageMap[MEDIADIMENSIONSPAGE],
+		m.PageMap[LOCALESPAGE],
 		m.PageMap[USERSADMIN],
 		m.PageMap[ROLESPAGE],
+		m.PageMap[USERGROUPSPAGE],
 	)
 
 	// System
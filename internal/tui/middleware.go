@@ -13,6 +13,7 @@ import (
 	"github.com/hegner123/modulacms/internal/config"
 	"github.com/hegner123/modulacms/internal/db"
 	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
 	"github.com/hegner123/modulacms/internal/plugin"
 	"github.com/hegner123/modulacms/internal/publishing"
 )
@@ -21,8 +22,9 @@ import (
 type timeMsg time.Time
 
 // CliMiddleware returns a Wish middleware that launches the CLI TUI application for SSH sessions.
-// dbReadyCh is an optional channel signalled after DB init so the serve command can start HTTP.
-func CliMiddleware(v *bool, c *config.Config, driver db.DbDriver, logger Logger, pluginMgr *plugin.Manager, mgr *config.Manager, dbReadyCh chan struct{}, dispatcher publishing.WebhookDispatcher) wish.Middleware {
+// pc is the permission cache shared with the HTTP stack. dbReadyCh is an
+// optional channel signalled after DB init so the serve command can start HTTP.
+func CliMiddleware(v *bool, c *config.Config, driver db.DbDriver, logger Logger, pluginMgr *plugin.Manager, mgr *config.Manager, pc *middleware.PermissionCache, dbReadyCh chan struct{}, dispatcher publishing.WebhookDispatcher) wish.Middleware {
 	newProg := func(m tea.Model, opts ...tea.ProgramOption) *tea.Program {
 		p := tea.NewProgram(m, opts...)
 		go func() {
@@ -39,7 +41,7 @@ func CliMiddleware(v *bool, c *config.Config, driver db.DbDriver, logger Logger,
 			wish.Fatalln(s, "no active terminal, skipping")
 			return nil
 		}
-		m, _ := InitialModel(v, c, driver, logger, pluginMgr, mgr, dbReadyCh, dispatcher)
+		m, _ := InitialModel(v, c, driver, logger, pluginMgr, mgr, pc, dbReadyCh, dispatcher)
 		m.Term = pty.Term
 		m.Width = pty.Window.Width
 		m.Height = pty.Window.Height
@@ -19,6 +19,7 @@ import (
 	"github.com/hegner123/modulacms/internal/config"
 	"github.com/hegner123/modulacms/internal/db"
 	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/middleware"
 	"github.com/hegner123/modulacms/internal/plugin"
 	"github.com/hegner123/modulacms/internal/publishing"
 
@@ -66,6 +67,7 @@ const (
 	FILEPICKER_RESTORE
 	FILEPICKER_IMPORT
 	FILEPICKER_ADMINMEDIA
+	FILEPICKER_SHEET
 )
 
 // DatabaseMode represents the current database operation mode.
@@ -130,9 +132,17 @@ type Model struct {
 	SelectedPlugin string
 	AdminUsername  string
 
+	// Translation view target, set by ShowTranslationsMsg
+	TranslationRouteID   types.RouteID
+	TranslationContentID types.ContentID
+
 	// Config management
 	ConfigManager *config.Manager
 
+	// Permissions is the process-wide RBAC cache shared with the HTTP
+	// stack. Role changes made from the TUI refresh it.
+	Permissions *middleware.PermissionCache
+
 	// SSH User Provisioning
 	NeedsProvisioning bool
 	SSHFingerprint    string
@@ -261,7 +271,7 @@ func (m *Model) GetRemoteStatus() string {
 
 // InitialModel creates and initializes a new Model with the provided configuration, database driver, logger, and optional plugin manager.
 // dbReadyCh is an optional channel signalled after DB init so the serve command can start HTTP.
-func InitialModel(v *bool, c *config.Config, driver db.DbDriver, logger Logger, pluginMgr *plugin.Manager, mgr *config.Manager, dbReadyCh chan struct{}, dispatcher publishing.WebhookDispatcher) (Model, tea.Cmd) {
+func InitialModel(v *bool, c *config.Config, driver db.DbDriver, logger Logger, pluginMgr *plugin.Manager, mgr *config.Manager, pc *middleware.PermissionCache, dbReadyCh chan struct{}, dispatcher publishing.WebhookDispatcher) (Model, tea.Cmd) {
 	// Use provided logger or fall back to utility.DefaultLogger
 	if logger == nil {
 		logger = utility.DefaultLogger
@@ -328,6 +338,7 @@ func InitialModel(v *bool, c *config.Config, driver db.DbDriver, logger Logger,
 		AdminUsername: systemAdminUsername,
 		PluginManager: pluginMgr,
 		ConfigManager: mgr,
+		Permissions:   pc,
 		DBReadyCh:     dbReadyCh,
 		Dispatcher:    dispatcher,
 	}
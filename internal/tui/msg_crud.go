@@ -112,9 +112,11 @@ type TogglePublishRequestMsg struct {
 }
 
 // ConfirmedPublishMsg signals user confirmed the publish action.
+// Locale, when set, overrides the active locale.
 type ConfirmedPublishMsg struct {
 	ContentID types.ContentID
 	RouteID   types.RouteID
+	Locale    string
 }
 
 // ConfirmedUnpublishMsg signals user confirmed the unpublish action.
@@ -124,10 +126,12 @@ type ConfirmedUnpublishMsg struct {
 }
 
 // PublishCompletedMsg signals successful snapshot-based publish.
+// Locale is set when a specific locale was published.
 type PublishCompletedMsg struct {
 	ContentID types.ContentID
 	RouteID   types.RouteID
 	AdminMode bool
+	Locale    string
 }
 
 // UnpublishCompletedMsg signals successful unpublish.
@@ -308,6 +308,45 @@ type RolesScreenFetchResultsMsg struct {
 	Assignments []db.RolePermissions
 }
 
+// --- User group management messages ---
+
+// UserGroupsFetchMsg requests fetching user groups with their roles and members.
+type UserGroupsFetchMsg struct{}
+
+// UserGroupsFetchResultsMsg returns groups + roles + users + group assignments.
+type UserGroupsFetchResultsMsg struct {
+	Groups       []db.UserGroups
+	Roles        []db.Roles
+	Users        []db.Users
+	GroupRoles   []db.UserGroupRoles
+	GroupMembers []db.UserGroupMembers
+}
+
+// --- Locale and translation messages ---
+
+// LocalesFetchMsg requests fetching all locales.
+type LocalesFetchMsg struct{}
+
+// LocalesFetchResultsMsg returns all locales, enabled or not.
+type LocalesFetchResultsMsg struct {
+	Locales []db.Locale
+}
+
+// TranslationsFetchMsg requests fetching the translation view of the screen's route.
+type TranslationsFetchMsg struct{}
+
+// TranslationsFetchResultsMsg returns a route's nodes with their translatable
+// field values per enabled locale, and which locales are published.
+type TranslationsFetchResultsMsg struct {
+	RouteID      types.RouteID
+	RouteTitle   string
+	RootID       types.ContentID
+	SourceLocale string
+	Locales      []db.Locale
+	Nodes        []translationNode
+	Published    map[string]bool
+}
+
 // --- Media dimension management messages ---
 
 // MediaDimensionsFetchMsg requests fetching all media dimensions.
@@ -0,0 +1,26 @@
+package tui
+
+import (
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/presence"
+)
+
+// ContentLeaseAcquiredMsg carries the lease taken on a content tree's root
+// and the node's presence. Lease is nil when no lease could be taken.
+type ContentLeaseAcquiredMsg struct {
+	Lease    *db.ContentLease
+	Presence *presence.Presence
+}
+
+// ContentLeaseTickMsg fires when a lease is due for a heartbeat.
+type ContentLeaseTickMsg struct {
+	LeaseID string
+}
+
+// ContentLeaseRenewedMsg carries the result of a heartbeat. Lost is set when
+// the lease expired or its editing mode was taken by another session.
+type ContentLeaseRenewedMsg struct {
+	Lease    *db.ContentLease
+	Presence *presence.Presence
+	Lost     bool
+}
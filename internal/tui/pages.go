@@ -46,6 +46,9 @@ const (
 	AUDITPAGE
 	SEARCHPAGE
 	ADMINMEDIA
+	USERGROUPSPAGE
+	LOCALESPAGE
+	TRANSLATIONSPAGE
 )
 
 // NewPage creates a new page with the specified index and label.
@@ -90,6 +93,9 @@ func InitPages() *map[PageIndex]Page {
 	auditPage := NewPage(AUDITPAGE, "Audit Log")
 	searchPage := NewPage(SEARCHPAGE, "Search")
 	adminMediaPage := NewPage(ADMINMEDIA, "Admin Media")
+	userGroupsPage := NewPage(USERGROUPSPAGE, "User Groups")
+	localesPage := NewPage(LOCALESPAGE, "Locales")
+	translationsPage := NewPage(TRANSLATIONSPAGE, "Translations")
 
 	p := make(map[PageIndex]Page, 0)
 	p[HOMEPAGE] = homePage
@@ -128,5 +134,8 @@ func InitPages() *map[PageIndex]Page {
 	p[AUDITPAGE] = auditPage
 	p[SEARCHPAGE] = searchPage
 	p[ADMINMEDIA] = adminMediaPage
+	p[USERGROUPSPAGE] = userGroupsPage
+	p[LOCALESPAGE] = localesPage
+	p[TRANSLATIONSPAGE] = translationsPage
 	return &p
 }
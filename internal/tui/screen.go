@@ -122,6 +122,12 @@ func (m Model) screenForPage(page Page) Screen {
 		return NewImportScreen()
 	case ROLESPAGE:
 		return NewRolesScreen(nil)
+	case USERGROUPSPAGE:
+		return NewUserGroupsScreen(nil)
+	case LOCALESPAGE:
+		return NewLocalesScreen(nil)
+	case TRANSLATIONSPAGE:
+		return NewTranslationsScreen(m.TranslationRouteID, m.TranslationContentID)
 	case AUDITPAGE:
 		return NewAuditScreen()
 	case SEARCHPAGE:
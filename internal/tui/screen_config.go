@@ -155,7 +155,7 @@ func (s *ConfigScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 			if km.Matches(key, config.ActionEdit) || km.Matches(key, config.ActionSelect) {
 				if len(s.ConfigCategoryFields) > 0 && s.ConfigFieldCursor < len(s.ConfigCategoryFields) {
 					field := s.ConfigCategoryFields[s.ConfigFieldCursor]
-					currentValue := config.ConfigFieldString(*ctx.Config, field.JSONKey)
+					currentValue := config.ConfigFieldString(config.UnresolvedConfig(*ctx.Config), field.JSONKey)
 					if field.Sensitive {
 						currentValue = ""
 					}
@@ -277,7 +277,7 @@ func (s *ConfigScreen) renderFields(ctx AppContext) string {
 	lines := []string{labelStyle.Render(title), ""}
 
 	for i, field := range s.ConfigCategoryFields {
-		value := config.ConfigFieldString(*ctx.Config, field.JSONKey)
+		value := config.ConfigFieldString(config.UnresolvedConfig(*ctx.Config), field.JSONKey)
 		if field.Sensitive && value != "" {
 			value = "********"
 		}
@@ -315,7 +315,7 @@ func (s *ConfigScreen) renderDetail(ctx AppContext) string {
 	}
 
 	field := s.ConfigCategoryFields[s.ConfigFieldCursor]
-	value := config.ConfigFieldString(*ctx.Config, field.JSONKey)
+	value := config.ConfigFieldString(config.UnresolvedConfig(*ctx.Config), field.JSONKey)
 	if field.Sensitive && value != "" {
 		value = "********"
 	}
@@ -350,9 +350,9 @@ func (s *ConfigScreen) renderDetail(ctx AppContext) string {
 	return strings.Join(lines, "\n")
 }
 
-// configFormatJSON marshals config to formatted JSON for the raw JSON view.
+// configFormatJSON marshals the redacted config to formatted JSON for the raw JSON view.
 func configFormatJSON(c *config.Config) (string, error) {
-	formatted, err := json.MarshalIndent(*c, "", "  ")
+	formatted, err := json.MarshalIndent(config.RedactedConfig(*c), "", "  ")
 	if err != nil {
 		return "", err
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/hegner123/modulacms/internal/config"
 	"github.com/hegner123/modulacms/internal/db"
 	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/presence"
 	"github.com/hegner123/modulacms/internal/tree"
 )
 
@@ -78,6 +79,11 @@ type ContentScreen struct {
 	VersionRouteID        types.RouteID
 	AdminVersionRouteID   types.AdminRouteID
 
+	// Edit lease and presence on the tree root (regular mode only)
+	Lease     *db.ContentLease
+	Presence  *presence.Presence
+	LeaseLost bool
+
 	// Error state
 	LastError    error
 	ErrorContext string
@@ -134,21 +140,29 @@ func (s *ContentScreen) KeyHints(km config.KeyMap) []KeyHint {
 			KeyHint{km.HintString(config.ActionPublish), "publish"},
 			KeyHint{km.HintString(config.ActionReorderUp) + "/" + km.HintString(config.ActionReorderDown), "reorder"},
 			KeyHint{km.HintString(config.ActionVersions), "versions"},
-			KeyHint{km.HintString(config.ActionBack), "back"},
 		)
+		if !s.AdminMode {
+			hints = append(hints, KeyHint{km.HintString(config.ActionTranslations), "translate"})
+		}
+		hints = append(hints, KeyHint{km.HintString(config.ActionBack), "back"})
 		return hints
 	}
 	// Select phase
-	return []KeyHint{
+	hints := []KeyHint{
 		{km.HintString(config.ActionUp) + "/" + km.HintString(config.ActionDown), "nav"},
 		{km.HintString(config.ActionSelect), "select"},
 		{km.HintString(config.ActionExpand) + "/" + km.HintString(config.ActionCollapse), "+/-"},
 		{km.HintString(config.ActionEdit), "edit"},
 		{km.HintString(config.ActionNew), "new"},
-		{km.HintString(config.ActionDelete), "del"},
-		{km.HintString(config.ActionPublish), "publish"},
-		{km.HintString(config.ActionBack), "back"},
 	}
+	if !s.AdminMode {
+		hints = append(hints, KeyHint{km.HintString(config.ActionBlueprint), "blueprint"})
+	}
+	return append(hints,
+		KeyHint{km.HintString(config.ActionDelete), "del"},
+		KeyHint{km.HintString(config.ActionPublish), "publish"},
+		KeyHint{km.HintString(config.ActionBack), "back"},
+	)
 }
 
 func (s *ContentScreen) PageIndex() PageIndex {
@@ -402,7 +416,7 @@ func (s *ContentScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 		} else {
 			s.Cursor = 0
 		}
-		return s, tea.Batch(s.batchLoadFieldsCmd(ctx), s.refreshSelectCmd())
+		return s, tea.Batch(s.batchLoadFieldsCmd(ctx), s.refreshSelectCmd(), s.acquireLeaseCmd(ctx))
 
 	case AdminTreeLoadedMsg:
 		s.clearError()
@@ -420,6 +434,42 @@ func (s *ContentScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 		}
 		return s, tea.Batch(s.batchLoadFieldsCmd(ctx), s.refreshSelectCmd())
 
+	// Edit lease and presence
+	case ContentLeaseAcquiredMsg:
+		if msg.Lease == nil {
+			return s, nil
+		}
+		if s.leaseRootID() != msg.Lease.ContentDataID {
+			// The tree was left before the lease arrived.
+			return s, ReleaseContentLeaseCmd(ctx, msg.Lease.LeaseID)
+		}
+		s.Lease = msg.Lease
+		s.Presence = msg.Presence
+		s.LeaseLost = false
+		return s, ContentLeaseTickCmd(msg.Lease.LeaseID)
+	case ContentLeaseTickMsg:
+		if s.Lease == nil || s.Lease.LeaseID != msg.LeaseID {
+			return s, nil
+		}
+		return s, RenewContentLeaseCmd(ctx, *s.Lease)
+	case ContentLeaseRenewedMsg:
+		if s.Lease == nil {
+			return s, nil
+		}
+		if msg.Lost {
+			s.LeaseLost = true
+		}
+		if msg.Presence != nil {
+			s.Presence = msg.Presence
+		}
+		if msg.Lease == nil {
+			// The lease expired; take a new one to keep presence visible.
+			s.Lease = nil
+			return s, s.acquireLeaseCmd(ctx)
+		}
+		s.Lease = msg.Lease
+		return s, ContentLeaseTickCmd(msg.Lease.LeaseID)
+
 	// Batch field loading results
 	case BatchContentFieldsLoadedMsg:
 		s.AllFields = msg.Fields
@@ -568,6 +618,14 @@ func (s *ContentScreen) handleRouteListKeys(ctx AppContext, key string, km confi
 		return s, ShowCreateRouteWithContentDialogCmd(s.RootDatatypes)
 	}
 
+	// New content from a blueprint
+	if km.Matches(key, config.ActionBlueprint) && !s.AdminMode {
+		if ctx.DB != nil {
+			return s, ShowRouteFromBlueprintDialogCmd(ctx.DB)
+		}
+		return s, nil
+	}
+
 	// Edit content from select list
 	if km.Matches(key, config.ActionEdit) {
 		if s.Cursor < len(s.FlatSelectList) {
@@ -635,7 +693,7 @@ func (s *ContentScreen) handleRouteListKeys(ctx AppContext, key string, km confi
 			}
 			if node.Content != nil && node.Content.RouteID.Valid {
 				isPublished := node.Content.Status == types.ContentStatusPublished
-				return s, ShowPublishDialogCmd(node.Content.ContentDataID, node.Content.RouteID.ID, string(node.Content.RouteTitle), isPublished)
+				return s, ShowPublishDialogCmd(ctx.DB, ctx.ActiveLocale, node.Content.ContentDataID, node.Content.RouteID.ID, string(node.Content.RouteTitle), isPublished)
 			}
 		}
 		return s, nil
@@ -903,7 +961,7 @@ func (s *ContentScreen) handleTreeKeys(ctx AppContext, key string, km config.Key
 			if s.Root.Root != nil && s.Root.Root.Instance != nil {
 				name := DecideNodeName(*s.Root.Root)
 				isPublished := s.Root.Root.Instance.Status == types.ContentStatusPublished
-				return s, ShowPublishDialogCmd(s.Root.Root.Instance.ContentDataID, s.PageRouteId, name, isPublished)
+				return s, ShowPublishDialogCmd(ctx.DB, ctx.ActiveLocale, s.Root.Root.Instance.ContentDataID, s.PageRouteId, name, isPublished)
 			}
 		}
 		return s, nil
@@ -933,6 +991,17 @@ func (s *ContentScreen) handleTreeKeys(ctx AppContext, key string, km config.Key
 		}
 	}
 
+	// Translation view for the route, starting at the selected node
+	if km.Matches(key, config.ActionTranslations) && !s.AdminMode {
+		if ctx.Config.I18nEnabled() && !s.PageRouteId.IsZero() {
+			var contentID types.ContentID
+			if node := s.Root.NodeAtIndex(s.Cursor); node != nil && node.Instance != nil {
+				contentID = node.Instance.ContentDataID
+			}
+			return s, ShowTranslationsCmd(s.PageRouteId, contentID)
+		}
+	}
+
 	return s, nil
 }
 
@@ -982,6 +1051,7 @@ func (s *ContentScreen) handleVersionListKeys(ctx AppContext, key string, km con
 			if s.VersionCursor < len(s.Versions) {
 				v := s.Versions[s.VersionCursor]
 				return s, ShowRestoreVersionDialogCmd(
+					ctx.DB,
 					s.VersionContentID,
 					v.ContentVersionID,
 					s.VersionRouteID,
@@ -998,6 +1068,13 @@ func (s *ContentScreen) handleVersionListKeys(ctx AppContext, key string, km con
 func (s *ContentScreen) handleBackKey(ctx AppContext) (Screen, tea.Cmd) {
 	if s.inTreePhase() {
 		// Go back to select phase
+		var releaseCmd tea.Cmd
+		if s.Lease != nil {
+			releaseCmd = ReleaseContentLeaseCmd(ctx, s.Lease.LeaseID)
+		}
+		s.Lease = nil
+		s.Presence = nil
+		s.LeaseLost = false
 		s.Root = tree.Root{}
 		s.PageRouteId = types.RouteID("")
 		s.AdminPageRouteId = types.AdminRouteID("")
@@ -1008,11 +1085,30 @@ func (s *ContentScreen) handleBackKey(ctx AppContext) (Screen, tea.Cmd) {
 		s.Cursor = 0
 		s.Grid = contentSelectGrid
 		s.FocusIndex = 0
-		return s, nil
+		return s, releaseCmd
 	}
 	return s, HistoryPopCmd()
 }
 
+// leaseRootID returns the content node the session leases: the root of the
+// open tree in regular mode, or zero when no tree is open.
+func (s *ContentScreen) leaseRootID() types.ContentID {
+	if s.AdminMode || !s.inTreePhase() || s.Root.Root == nil || s.Root.Root.Instance == nil {
+		return types.ContentID("")
+	}
+	return s.Root.Root.Instance.ContentDataID
+}
+
+// acquireLeaseCmd takes a lease on the open tree's root unless the session
+// already holds one. Remote connections do not support leases.
+func (s *ContentScreen) acquireLeaseCmd(ctx AppContext) tea.Cmd {
+	rootID := s.leaseRootID()
+	if ctx.IsRemote || rootID.IsZero() || (s.Lease != nil && s.Lease.ContentDataID == rootID) {
+		return nil
+	}
+	return AcquireContentLeaseCmd(ctx, rootID)
+}
+
 // versionListLen returns the number of versions for the current mode.
 func (s *ContentScreen) versionListLen() int {
 	if s.AdminMode {
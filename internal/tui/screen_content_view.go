@@ -7,6 +7,7 @@ import (
 	"charm.land/lipgloss/v2"
 	"github.com/hegner123/modulacms/internal/config"
 	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/presence"
 	"github.com/hegner123/modulacms/internal/tree"
 )
 
@@ -59,6 +60,9 @@ func (s *ContentScreen) viewTreePhase(ctx AppContext) string {
 
 	if !s.ShowVersionList {
 		cells := []CellContent{treeCell, previewCell}
+		if line := s.renderPresence(); line != "" {
+			cells[1].Content = line + "\n\n" + cells[1].Content
+		}
 		if s.LastError != nil {
 			cells[1].Content = s.renderError() + "\n\n" + cells[1].Content
 		}
@@ -542,6 +546,42 @@ func (s *ContentScreen) renderAdminVersionList() string {
 	return strings.Join(lines, "\n")
 }
 
+// =============================================================================
+// PRESENCE RENDERING
+// =============================================================================
+
+// renderPresence describes who else has the open tree: the editor when it is
+// not this session, a lost edit lease, and the other viewers.
+func (s *ContentScreen) renderPresence() string {
+	if s.Presence == nil {
+		return ""
+	}
+	mine := ""
+	if s.Lease != nil {
+		mine = s.Lease.LeaseID
+	}
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#eab308")).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9ca3af"))
+
+	var parts []string
+	switch {
+	case s.Presence.Editor != nil && s.Presence.Editor.LeaseID != mine:
+		parts = append(parts, warnStyle.Render(presence.HolderName(*s.Presence.Editor)+" is editing"))
+	case s.LeaseLost:
+		parts = append(parts, warnStyle.Render("Edit lease lost"))
+	}
+	var viewers []string
+	for _, v := range s.Presence.Viewers {
+		if v.LeaseID != mine {
+			viewers = append(viewers, presence.HolderName(v))
+		}
+	}
+	if len(viewers) > 0 {
+		parts = append(parts, mutedStyle.Render("Viewing: "+strings.Join(viewers, ", ")))
+	}
+	return strings.Join(parts, "  ")
+}
+
 // =============================================================================
 // ERROR RENDERING
 // =============================================================================
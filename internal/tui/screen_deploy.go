@@ -6,6 +6,7 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/deploy"
 )
 
 // 3/9 grid: left = environments list, right = detail (top) + actions (bottom)
@@ -29,6 +30,36 @@ type DeployScreen struct {
 	LastHealth      *DeployHealthResult
 	StatusMessage   string
 	OperationActive bool
+	Review          *DeployMergeReview
+}
+
+// DeployMergeReview holds the conflicts of a merge dry run while the user
+// picks a side for each. Takes[i] is "" until conflict i is resolved.
+type DeployMergeReview struct {
+	EnvName   string
+	Push      bool
+	Conflicts []deploy.MergeConflict
+	Takes     []string
+	Cursor    int
+}
+
+// resolved reports whether every conflict has a side.
+func (r *DeployMergeReview) resolved() bool {
+	for _, t := range r.Takes {
+		if t == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// resolutions returns the chosen sides as deploy resolutions.
+func (r *DeployMergeReview) resolutions() []deploy.ConflictResolution {
+	out := make([]deploy.ConflictResolution, len(r.Conflicts))
+	for i, c := range r.Conflicts {
+		out[i] = deploy.ConflictResolution{Table: c.Table, RowID: c.RowID, Column: c.Column, Take: r.Takes[i]}
+	}
+	return out
 }
 
 // NewDeployScreen creates a DeployScreen with the given environments.
@@ -58,6 +89,12 @@ func (s *DeployScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 			return s, nil
 		}
 
+		if s.Review != nil && !s.OperationActive {
+			if cmd, handled := s.updateReview(key, km); handled {
+				return s, cmd
+			}
+		}
+
 		// Cursor movement with status field clearing
 		if km.Matches(key, config.ActionUp) {
 			if s.Cursor > 0 {
@@ -110,6 +147,10 @@ func (s *DeployScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 			return s, DeployPullCmd(env.Name, true)
 		case "S":
 			return s, DeployPushCmd(env.Name, true)
+		case "m":
+			return s, DeployMergePullCmd(env.Name, true, nil)
+		case "M":
+			return s, DeployMergePushCmd(env.Name, true, nil)
 		}
 
 		_, cmd, handled := HandleCommonKeys(key, km, s.Cursor, len(s.Environments)-1)
@@ -165,6 +206,7 @@ func (s *DeployScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 			s.LastResult = msg.Result
 			s.StatusMessage = fmt.Sprintf("pull completed: %d tables", len(msg.Result.TablesAffected))
 		}
+		s.startReview(msg.Result, false)
 		return s, nil
 
 	case DeployPushRequestMsg:
@@ -188,17 +230,89 @@ func (s *DeployScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 			s.LastResult = msg.Result
 			s.StatusMessage = fmt.Sprintf("push completed: %d tables", len(msg.Result.TablesAffected))
 		}
+		s.startReview(msg.Result, true)
 		return s, nil
 	}
 
 	return s, nil
 }
 
+// startReview opens the conflict review after a merge dry run, or after a
+// merge that was refused for unresolved conflicts. Any other result closes it.
+func (s *DeployScreen) startReview(r *DeploySyncResult, push bool) {
+	s.Review = nil
+	if r == nil || !r.Merge || (!r.DryRun && len(r.Conflicts) == 0) {
+		return
+	}
+	s.Review = &DeployMergeReview{
+		EnvName:   r.EnvName,
+		Push:      push,
+		Conflicts: r.Conflicts,
+		Takes:     make([]string, len(r.Conflicts)),
+	}
+}
+
+// updateReview handles keys while a merge review is open: move between
+// conflicts, take the source (1) or target (2) side, apply (a), or cancel (x).
+func (s *DeployScreen) updateReview(key string, km config.KeyMap) (tea.Cmd, bool) {
+	r := s.Review
+	switch {
+	case km.Matches(key, config.ActionUp):
+		if r.Cursor > 0 {
+			r.Cursor--
+		}
+		return nil, true
+	case km.Matches(key, config.ActionDown):
+		if r.Cursor < len(r.Conflicts)-1 {
+			r.Cursor++
+		}
+		return nil, true
+	}
+	switch key {
+	case "1", "2":
+		if len(r.Conflicts) == 0 {
+			return nil, true
+		}
+		r.Takes[r.Cursor] = deploy.TakeSource
+		if key == "2" {
+			r.Takes[r.Cursor] = deploy.TakeTarget
+		}
+		if r.Cursor < len(r.Conflicts)-1 {
+			r.Cursor++
+		}
+		return nil, true
+	case "a":
+		if !r.resolved() {
+			s.StatusMessage = "resolve every conflict before applying"
+			return nil, true
+		}
+		s.Review = nil
+		if r.Push {
+			return DeployMergePushCmd(r.EnvName, false, r.resolutions()), true
+		}
+		return DeployMergePullCmd(r.EnvName, false, r.resolutions()), true
+	case "x":
+		s.Review = nil
+		s.StatusMessage = "merge cancelled"
+		return nil, true
+	}
+	return nil, false
+}
+
 func (s *DeployScreen) KeyHints(km config.KeyMap) []KeyHint {
+	if s.Review != nil {
+		return []KeyHint{
+			{"1", "take source"},
+			{"2", "take target"},
+			{"a", "apply"},
+			{"x", "cancel"},
+		}
+	}
 	return []KeyHint{
 		{"t", "test"},
 		{"p", "pull"},
 		{"s", "push"},
+		{"m", "merge"},
 		{km.HintString(config.ActionNextPanel), "panel"},
 		{km.HintString(config.ActionBack), "back"},
 	}
@@ -284,6 +398,12 @@ func (s *DeployScreen) renderDetail() string {
 		}
 		lines = append(lines, fmt.Sprintf("   Rows:     %d", totalRows))
 
+		for _, t := range r.TablesAffected {
+			if c, ok := r.Changes[t]; ok {
+				lines = append(lines, fmt.Sprintf("   %-18s +%d ~%d -%d", t, c.Inserted, c.Updated, c.Deleted))
+			}
+		}
+
 		for _, w := range r.Warnings {
 			lines = append(lines, fmt.Sprintf("   WARN: %s", w))
 		}
@@ -292,6 +412,10 @@ func (s *DeployScreen) renderDetail() string {
 		}
 	}
 
+	if s.Review != nil && s.Review.EnvName == env.Name {
+		lines = append(lines, "", s.renderReview())
+	}
+
 	if s.StatusMessage != "" {
 		lines = append(lines, "", " "+s.StatusMessage)
 	}
@@ -303,6 +427,50 @@ func (s *DeployScreen) renderDetail() string {
 	return strings.Join(lines, "\n")
 }
 
+// renderReview renders the merge conflicts and the side chosen for each.
+func (s *DeployScreen) renderReview() string {
+	r := s.Review
+	if len(r.Conflicts) == 0 {
+		return " No conflicts. Press a to apply the merge."
+	}
+	lines := []string{fmt.Sprintf(" Conflicts: %d", len(r.Conflicts))}
+	for i, c := range r.Conflicts {
+		cursor := "   "
+		if r.Cursor == i {
+			cursor = " ->"
+		}
+		take := "?"
+		if r.Takes[i] != "" {
+			take = r.Takes[i]
+		}
+		where := c.Table + "/" + c.RowID
+		if c.Column != "" {
+			where += "/" + c.Column
+		}
+		lines = append(lines, fmt.Sprintf("%s [%s] %s", cursor, take, where))
+		if r.Cursor == i {
+			lines = append(lines,
+				fmt.Sprintf("       source: %s", conflictValue(c.Source)),
+				fmt.Sprintf("       target: %s", conflictValue(c.Target)),
+				fmt.Sprintf("       base:   %s", conflictValue(c.Base)),
+			)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// conflictValue formats one side of a conflict on a single short line.
+func conflictValue(v any) string {
+	if v == nil {
+		return "(none)"
+	}
+	str := strings.ReplaceAll(fmt.Sprint(v), "\n", " ")
+	if len(str) > 60 {
+		str = str[:57] + "..."
+	}
+	return str
+}
+
 // renderActions renders available actions.
 func (s *DeployScreen) renderActions() string {
 	lines := []string{
@@ -316,6 +484,10 @@ func (s *DeployScreen) renderActions() string {
 		"   P  Dry Run Pull",
 		"   S  Dry Run Push",
 		"",
+		"   Merge (review conflicts first):",
+		"   m  Merge Pull",
+		"   M  Merge Push",
+		"",
 		fmt.Sprintf(" Environments: %d", len(s.Environments)),
 	}
 
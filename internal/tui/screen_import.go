@@ -7,6 +7,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
 	"github.com/hegner123/modulacms/internal/service"
 )
 
@@ -39,29 +40,70 @@ type ImportCompleteMsg struct {
 	Err    error
 }
 
+// SheetDatatypesLoadedMsg carries the datatypes offered for spreadsheet
+// import and export.
+type SheetDatatypesLoadedMsg struct {
+	Datatypes []db.Datatypes
+	Err       error
+}
+
+// SheetImportCompleteMsg carries the report of a spreadsheet import or dry run.
+type SheetImportCompleteMsg struct {
+	Report *service.SheetImportReport
+	Err    error
+}
+
+// SheetExportCompleteMsg carries the result of a spreadsheet export.
+type SheetExportCompleteMsg struct {
+	Path string
+	Rows int
+	Err  error
+}
+
+// sheetMode distinguishes the spreadsheet entries from the JSON import formats.
+type sheetMode int
+
+const (
+	sheetNone sheetMode = iota
+	sheetImport
+	sheetExport
+)
+
 // importFormat describes a supported import format.
 type importFormat struct {
 	Label  string
 	Format config.OutputFormat
 	Desc   string
+	Sheet  sheetMode
 }
 
 var importFormats = []importFormat{
-	{"Contentful", config.FormatContentful, "import from Contentful CMS export JSON"},
-	{"Sanity", config.FormatSanity, "import from Sanity dataset export"},
-	{"Strapi", config.FormatStrapi, "import from Strapi content export"},
-	{"WordPress", config.FormatWordPress, "import from WordPress REST API JSON"},
-	{"Clean JSON", config.FormatClean, "import ModulaCMS native JSON format"},
+	{"Contentful", config.FormatContentful, "import from Contentful CMS export JSON", sheetNone},
+	{"Sanity", config.FormatSanity, "import from Sanity dataset export", sheetNone},
+	{"Strapi", config.FormatStrapi, "import from Strapi content export", sheetNone},
+	{"WordPress", config.FormatWordPress, "import from WordPress REST API JSON", sheetNone},
+	{"Clean JSON", config.FormatClean, "import ModulaCMS native JSON format", sheetNone},
+	{"Spreadsheet", "", "create and update the entries of a datatype from CSV or XLSX", sheetImport},
+	{"Spreadsheet export", "", "write the entries of a datatype to an XLSX file", sheetExport},
 }
 
 // ImportScreen implements Screen for the content import wizard.
+//
+// The spreadsheet entries add a step: enter on the format focuses the
+// datatype list in the Import panel, and enter on a datatype opens the file
+// picker (import) or writes the file (export). A spreadsheet import always
+// runs as a dry run first; enter on the same datatype applies it.
 type ImportScreen struct {
 	GridScreen
-	Formats    []importFormat
-	FilePath   string                // selected file path
-	Importing  bool                  // true while import is running
-	Result     *service.ImportResult // nil until import completes
-	ResultErr  error                 // non-nil if import failed
+	Formats        []importFormat
+	FilePath       string                // selected file path
+	Importing      bool                  // true while import is running
+	Result         *service.ImportResult // nil until import completes
+	ResultErr      error                 // non-nil if import failed
+	Datatypes      []db.Datatypes        // datatypes for the spreadsheet entries
+	DatatypeCursor int
+	SheetReport    *service.SheetImportReport // nil until a spreadsheet import or dry run completes
+	SheetExported  string                     // path of the last spreadsheet export
 }
 
 func NewImportScreen() *ImportScreen {
@@ -83,6 +125,29 @@ func (s *ImportScreen) selectedFormat() *importFormat {
 	return &s.Formats[s.Cursor]
 }
 
+func (s *ImportScreen) selectedDatatype() *db.Datatypes {
+	if s.DatatypeCursor >= len(s.Datatypes) {
+		return nil
+	}
+	return &s.Datatypes[s.DatatypeCursor]
+}
+
+// sheetPending reports whether a dry run for the selected datatype and file
+// is waiting to be applied.
+func (s *ImportScreen) sheetPending() bool {
+	dt := s.selectedDatatype()
+	return s.SheetReport != nil && s.SheetReport.DryRun && dt != nil &&
+		s.SheetReport.Datatype == dt.Name && s.FilePath != "" &&
+		s.SheetReport.Created+s.SheetReport.Updated > 0
+}
+
+func (s *ImportScreen) resetResults() {
+	s.Result = nil
+	s.ResultErr = nil
+	s.SheetReport = nil
+	s.SheetExported = ""
+}
+
 func (s *ImportScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
@@ -93,11 +158,22 @@ func (s *ImportScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 			return s, nil
 		}
 
+		f := s.selectedFormat()
+		if f != nil && f.Sheet != sheetNone && s.FocusIndex == 1 {
+			return s.updateSheetKeys(ctx, key, km, f)
+		}
+
 		// Enter on format list → open file picker
 		if km.Matches(key, config.ActionSelect) && !s.Importing {
 			s.FilePath = ""
-			s.Result = nil
-			s.ResultErr = nil
+			s.resetResults()
+			if f != nil && f.Sheet != sheetNone {
+				s.FocusIndex = 1
+				if s.Datatypes == nil {
+					return s, LoadSheetDatatypesCmd(ctx)
+				}
+				return s, nil
+			}
 			return s, func() tea.Msg {
 				return OpenFilePickerMsg{Purpose: FILEPICKER_IMPORT}
 			}
@@ -114,6 +190,15 @@ func (s *ImportScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 			return s, cmd
 		}
 
+	case SheetDatatypesLoadedMsg:
+		s.Datatypes = msg.Datatypes
+		s.ResultErr = msg.Err
+		if s.Datatypes == nil && msg.Err == nil {
+			s.Datatypes = []db.Datatypes{}
+		}
+		s.DatatypeCursor = 0
+		return s, nil
+
 	// File selected from picker
 	case ImportFileSelectedMsg:
 		s.FilePath = msg.Path
@@ -122,8 +207,15 @@ func (s *ImportScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 			return s, nil
 		}
 		s.Importing = true
-		s.Result = nil
-		s.ResultErr = nil
+		s.resetResults()
+		if f.Sheet == sheetImport {
+			dt := s.selectedDatatype()
+			if dt == nil {
+				s.Importing = false
+				return s, nil
+			}
+			return s, tea.Batch(LoadingStartCmd(), RunSheetImportCmd(ctx, dt.Name, msg.Path, true))
+		}
 		return s, func() tea.Msg {
 			return ImportRequestMsg{Format: f.Format, Path: msg.Path}
 		}
@@ -138,14 +230,80 @@ func (s *ImportScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 		s.Result = msg.Result
 		s.ResultErr = msg.Err
 		return s, LoadingStopCmd()
+
+	case SheetImportCompleteMsg:
+		s.Importing = false
+		s.SheetReport = msg.Report
+		s.ResultErr = msg.Err
+		return s, LoadingStopCmd()
+
+	case SheetExportCompleteMsg:
+		s.Importing = false
+		s.SheetExported = msg.Path
+		s.ResultErr = msg.Err
+		if msg.Err == nil {
+			return s, tea.Batch(LoadingStopCmd(), ShowDialog("Export", fmt.Sprintf("Exported %d entries to %s", msg.Rows, msg.Path), false))
+		}
+		return s, LoadingStopCmd()
 	}
 
 	return s, nil
 }
 
+// updateSheetKeys handles keys while the datatype list of a spreadsheet
+// entry has focus.
+func (s *ImportScreen) updateSheetKeys(ctx AppContext, key string, km config.KeyMap, f *importFormat) (Screen, tea.Cmd) {
+	switch {
+	case km.Matches(key, config.ActionUp):
+		if s.DatatypeCursor > 0 {
+			s.DatatypeCursor--
+			s.resetResults()
+		}
+		return s, nil
+	case km.Matches(key, config.ActionDown):
+		if s.DatatypeCursor < len(s.Datatypes)-1 {
+			s.DatatypeCursor++
+			s.resetResults()
+		}
+		return s, nil
+	case km.Matches(key, config.ActionSelect):
+		dt := s.selectedDatatype()
+		if dt == nil || s.Importing {
+			return s, nil
+		}
+		if f.Sheet == sheetExport {
+			s.Importing = true
+			s.resetResults()
+			return s, tea.Batch(LoadingStartCmd(), RunSheetExportCmd(ctx, dt.Name))
+		}
+		if s.sheetPending() {
+			s.Importing = true
+			return s, tea.Batch(LoadingStartCmd(), RunSheetImportCmd(ctx, dt.Name, s.FilePath, false))
+		}
+		s.FilePath = ""
+		s.resetResults()
+		return s, func() tea.Msg {
+			return OpenFilePickerMsg{Purpose: FILEPICKER_SHEET}
+		}
+	}
+	_, cmd, _ := HandleCommonKeys(key, km, s.Cursor, s.CursorMax)
+	return s, cmd
+}
+
 func (s *ImportScreen) KeyHints(km config.KeyMap) []KeyHint {
+	selectHint := "select file"
+	if f := s.selectedFormat(); f != nil && f.Sheet != sheetNone {
+		switch {
+		case s.FocusIndex != 1:
+			selectHint = "choose datatype"
+		case f.Sheet == sheetExport:
+			selectHint = "export"
+		case s.sheetPending():
+			selectHint = "apply import"
+		}
+	}
 	hints := []KeyHint{
-		{km.HintString(config.ActionSelect), "select file"},
+		{km.HintString(config.ActionSelect), selectHint},
 		{km.HintString(config.ActionUp) + "/" + km.HintString(config.ActionDown), "nav"},
 		{km.HintString(config.ActionNextPanel), "panel"},
 		{km.HintString(config.ActionBack), "back"},
@@ -189,6 +347,10 @@ func (s *ImportScreen) renderImportPanel() string {
 		"",
 	}
 
+	if f.Sheet != sheetNone {
+		return strings.Join(append(lines, s.renderDatatypeList()...), "\n")
+	}
+
 	if s.FilePath == "" {
 		lines = append(lines, " Press enter to select a JSON file.")
 	} else {
@@ -202,7 +364,71 @@ func (s *ImportScreen) renderImportPanel() string {
 	return strings.Join(lines, "\n")
 }
 
+func (s *ImportScreen) renderDatatypeList() []string {
+	if s.Datatypes == nil {
+		return []string{" Press enter to choose a datatype."}
+	}
+	if len(s.Datatypes) == 0 {
+		return []string{" No datatypes."}
+	}
+	lines := make([]string, 0, len(s.Datatypes)+3)
+	for i, dt := range s.Datatypes {
+		cursor := "   "
+		if s.FocusIndex == 1 && s.DatatypeCursor == i {
+			cursor = " ->"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s (%s)", cursor, dt.Label, dt.Name))
+	}
+	if s.FilePath != "" {
+		lines = append(lines, "", fmt.Sprintf(" File: %s", s.FilePath))
+	}
+	if s.Importing {
+		lines = append(lines, "", " Working...")
+	}
+	return lines
+}
+
+func (s *ImportScreen) renderSheetReport() string {
+	r := s.SheetReport
+	verb := "Imported"
+	if r.DryRun {
+		verb = "Dry run"
+	}
+	lines := []string{
+		fmt.Sprintf(" %s: %s", verb, r.Datatype),
+		fmt.Sprintf(" Created:    %d", r.Created),
+		fmt.Sprintf(" Updated:    %d", r.Updated),
+		fmt.Sprintf(" Unchanged:  %d", r.Unchanged),
+		fmt.Sprintf(" Failed:     %d", r.Failed),
+	}
+	for _, c := range r.Columns {
+		if c.Ignored {
+			lines = append(lines, fmt.Sprintf(" Column %q ignored", c.Column))
+		}
+	}
+	if len(r.Errors) > 0 {
+		lines = append(lines, "", fmt.Sprintf(" Errors (%d):", len(r.Errors)))
+		for _, e := range r.Errors {
+			if e.Field != "" {
+				lines = append(lines, fmt.Sprintf("   - row %d: %s: %s", e.Row, e.Field, e.Message))
+			} else {
+				lines = append(lines, fmt.Sprintf("   - row %d: %s", e.Row, e.Message))
+			}
+		}
+	}
+	if s.sheetPending() {
+		lines = append(lines, "", " Press enter to apply.")
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (s *ImportScreen) renderResults() string {
+	if s.SheetReport != nil && s.ResultErr == nil {
+		return s.renderSheetReport()
+	}
+	if s.SheetExported != "" && s.ResultErr == nil {
+		return fmt.Sprintf(" Exported to %s", s.SheetExported)
+	}
 	if s.Result == nil && s.ResultErr == nil {
 		return lipgloss.NewStyle().Faint(true).Render(" No import results yet")
 	}
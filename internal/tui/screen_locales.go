@@ -0,0 +1,259 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+)
+
+// Locales grid: 2 columns
+//
+//	Col 0 (span 3): Locale list
+//	Col 1 (span 9): Details (top), Fallback chains (bottom)
+var localesGrid = Grid{
+	Columns: []GridColumn{
+		{Span: 3, Cells: []GridCell{
+			{Height: 1.0, Title: "Locales"},
+		}},
+		{Span: 9, Cells: []GridCell{
+			{Height: 0.45, Title: "Details"},
+			{Height: 0.55, Title: "Fallback Chains"},
+		}},
+	},
+}
+
+// LocalesScreen implements Screen for locale management. Validation, fallback
+// cycle detection and default-locale atomicity are left to the LocaleService.
+type LocalesScreen struct {
+	GridScreen
+	Locales []db.Locale
+}
+
+func NewLocalesScreen(locales []db.Locale) *LocalesScreen {
+	cursorMax := len(locales) - 1
+	if cursorMax < 0 {
+		cursorMax = 0
+	}
+	return &LocalesScreen{
+		GridScreen: GridScreen{
+			Grid:      localesGrid,
+			CursorMax: cursorMax,
+		},
+		Locales: locales,
+	}
+}
+
+func (s *LocalesScreen) PageIndex() PageIndex { return LOCALESPAGE }
+
+func (s *LocalesScreen) selectedLocale() *db.Locale {
+	if len(s.Locales) == 0 || s.Cursor >= len(s.Locales) {
+		return nil
+	}
+	return &s.Locales[s.Cursor]
+}
+
+func (s *LocalesScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		km := ctx.Config.KeyBindings
+		key := msg.String()
+
+		if s.HandleFocusNav(key, km) {
+			return s, nil
+		}
+
+		if km.Matches(key, config.ActionNew) {
+			return s, ShowCreateLocaleDialogCmd(s.Locales)
+		}
+
+		if km.Matches(key, config.ActionEdit) || km.Matches(key, config.ActionSelect) {
+			if locale := s.selectedLocale(); locale != nil {
+				return s, ShowEditLocaleDialogCmd(*locale, s.Locales)
+			}
+		}
+
+		if km.Matches(key, config.ActionDelete) {
+			if locale := s.selectedLocale(); locale != nil {
+				return s, ShowDeleteLocaleDialogCmd(locale.LocaleID, locale.Code)
+			}
+		}
+
+		cursorMax := len(s.Locales) - 1
+		if cursorMax < 0 {
+			cursorMax = 0
+		}
+		s.CursorMax = cursorMax
+		newCursor, cmd, handled := HandleCommonKeys(key, km, s.Cursor, s.CursorMax)
+		if handled {
+			s.Cursor = newCursor
+			return s, cmd
+		}
+
+	case LocalesFetchMsg:
+		d := ctx.DB
+		if d == nil {
+			return s, func() tea.Msg { return FetchErrMsg{Error: fmt.Errorf("database not connected")} }
+		}
+		return s, func() tea.Msg {
+			locales, err := d.ListLocales()
+			if err != nil {
+				return FetchErrMsg{Error: err}
+			}
+			res := LocalesFetchResultsMsg{Locales: []db.Locale{}}
+			if locales != nil {
+				res.Locales = *locales
+			}
+			return res
+		}
+
+	case LocalesFetchResultsMsg:
+		s.Locales = msg.Locales
+		s.CursorMax = len(s.Locales) - 1
+		if s.CursorMax < 0 {
+			s.CursorMax = 0
+		}
+		if s.Cursor > s.CursorMax {
+			s.Cursor = s.CursorMax
+		}
+		return s, LoadingStopCmd()
+
+	case LocaleSavedMsg:
+		return s, LocalesFetchCmd()
+
+	case LocaleDeletedMsg:
+		s.Cursor = 0
+		return s, LocalesFetchCmd()
+	}
+
+	return s, nil
+}
+
+func (s *LocalesScreen) KeyHints(km config.KeyMap) []KeyHint {
+	return []KeyHint{
+		{km.HintString(config.ActionNew), "new"},
+		{km.HintString(config.ActionEdit), "edit"},
+		{km.HintString(config.ActionDelete), "del"},
+		{km.HintString(config.ActionUp) + "/" + km.HintString(config.ActionDown), "nav"},
+		{km.HintString(config.ActionNextPanel), "panel"},
+		{km.HintString(config.ActionBack), "back"},
+	}
+}
+
+func (s *LocalesScreen) View(ctx AppContext) string {
+	cells := []CellContent{
+		{Content: s.renderList(), TotalLines: len(s.Locales), ScrollOffset: ClampScroll(s.Cursor, len(s.Locales), ctx.Height)},
+		{Content: s.renderDetail(ctx)},
+		{Content: s.renderChains(), TotalLines: len(s.Locales)},
+	}
+	return s.RenderGrid(ctx, cells)
+}
+
+func (s *LocalesScreen) renderList() string {
+	if len(s.Locales) == 0 {
+		return "(no locales)"
+	}
+	faint := lipgloss.NewStyle().Faint(true)
+	lines := make([]string, 0, len(s.Locales))
+	for i, locale := range s.Locales {
+		cursor := "   "
+		if s.Cursor == i {
+			cursor = " ->"
+		}
+		line := fmt.Sprintf("%s %s", cursor, locale.Code)
+		if locale.IsDefault {
+			line += " *"
+		}
+		if !locale.IsEnabled {
+			line = faint.Render(line + " (off)")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s *LocalesScreen) renderDetail(ctx AppContext) string {
+	locale := s.selectedLocale()
+	if locale == nil {
+		return " No locale selected"
+	}
+
+	faint := lipgloss.NewStyle().Faint(true)
+
+	fallback := locale.FallbackCode
+	if fallback == "" {
+		fallback = faint.Render("(none)")
+	}
+
+	lines := []string{
+		fmt.Sprintf(" Code        %s", locale.Code),
+		fmt.Sprintf(" Label       %s", locale.Label),
+		fmt.Sprintf(" Default     %s", yesNo(locale.IsDefault)),
+		fmt.Sprintf(" Enabled     %s", yesNo(locale.IsEnabled)),
+		fmt.Sprintf(" Fallback    %s", fallback),
+		fmt.Sprintf(" Resolves    %s", strings.Join(localeFallbackChain(s.Locales, locale.Code), " → ")),
+		fmt.Sprintf(" Sort Order  %d", locale.SortOrder),
+		"",
+		faint.Render(fmt.Sprintf(" ID          %s", locale.LocaleID)),
+	}
+	if ctx.Config != nil && !ctx.Config.I18nEnabled() {
+		lines = append(lines, "", faint.Render(" i18n is disabled in the configuration; locales are not served."))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderChains lists the fallback chain of every locale.
+func (s *LocalesScreen) renderChains() string {
+	if len(s.Locales) == 0 {
+		return " (no locales)"
+	}
+	lines := make([]string, 0, len(s.Locales))
+	for _, locale := range s.Locales {
+		lines = append(lines, " "+strings.Join(localeFallbackChain(s.Locales, locale.Code), " → "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// localeFallbackChain returns the locale codes a request for code resolves
+// through: code, its fallback_code chain, then the default locale. A chain
+// that loops back on itself ends with a "↺" marker.
+func localeFallbackChain(locales []db.Locale, code string) []string {
+	byCode := make(map[string]db.Locale, len(locales))
+	defaultCode := ""
+	for _, l := range locales {
+		byCode[l.Code] = l
+		if l.IsDefault {
+			defaultCode = l.Code
+		}
+	}
+
+	chain := []string{}
+	seen := map[string]bool{}
+	for current := code; current != ""; {
+		if seen[current] {
+			return append(chain, "↺ "+current)
+		}
+		seen[current] = true
+		chain = append(chain, current)
+		l, ok := byCode[current]
+		if !ok {
+			break
+		}
+		current = l.FallbackCode
+	}
+	if defaultCode != "" && !seen[defaultCode] {
+		chain = append(chain, defaultCode)
+	}
+	return chain
+}
+
+// yesNo renders a bool for detail panels.
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
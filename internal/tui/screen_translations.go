@@ -0,0 +1,516 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/service"
+	"github.com/hegner123/modulacms/internal/translation"
+)
+
+// Translations grid: 2 columns
+//
+//	Col 0 (span 4): Target locales with completeness (top), route nodes (bottom)
+//	Col 1 (span 8): Source and target values side by side
+var translationsGrid = Grid{
+	Columns: []GridColumn{
+		{Span: 4, Cells: []GridCell{
+			{Height: 0.35, Title: "Locales"},
+			{Height: 0.65, Title: "Nodes"},
+		}},
+		{Span: 8, Cells: []GridCell{
+			{Height: 1.0, Title: "Translation"},
+		}},
+	},
+}
+
+// Focus indexes for the translations grid cells.
+const (
+	translationsFocusLocales = 0
+	translationsFocusNodes   = 1
+	translationsFocusFields  = 2
+)
+
+// translationLabelWidth is the width of the field label column.
+const translationLabelWidth = 16
+
+// TranslationsScreen shows a route's translatable fields in the source
+// (default) locale next to a target locale. Cursor selects the node; the
+// target locale is picked in the Locales panel or with the tab keys.
+type TranslationsScreen struct {
+	GridScreen
+	RouteID      types.RouteID
+	RouteTitle   string
+	RootID       types.ContentID
+	SourceLocale string
+	Locales      []db.Locale // enabled locales other than the source
+	Nodes        []translationNode
+	Published    map[string]bool
+	LocaleCursor int
+	FieldCursor  int
+
+	// preselect is the node to put the cursor on once data arrives.
+	preselect types.ContentID
+}
+
+func NewTranslationsScreen(routeID types.RouteID, contentID types.ContentID) *TranslationsScreen {
+	return &TranslationsScreen{
+		GridScreen: GridScreen{
+			Grid:       translationsGrid,
+			FocusIndex: translationsFocusNodes,
+		},
+		RouteID:   routeID,
+		Published: map[string]bool{},
+		preselect: contentID,
+	}
+}
+
+func (s *TranslationsScreen) PageIndex() PageIndex { return TRANSLATIONSPAGE }
+
+func (s *TranslationsScreen) targetLocale() string {
+	if s.LocaleCursor < len(s.Locales) {
+		return s.Locales[s.LocaleCursor].Code
+	}
+	return ""
+}
+
+func (s *TranslationsScreen) selectedNode() *translationNode {
+	if s.Cursor < len(s.Nodes) {
+		return &s.Nodes[s.Cursor]
+	}
+	return nil
+}
+
+func (s *TranslationsScreen) selectedField() *translationField {
+	node := s.selectedNode()
+	if node == nil || s.FieldCursor >= len(node.Fields) {
+		return nil
+	}
+	return &node.Fields[s.FieldCursor]
+}
+
+func (s *TranslationsScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		km := ctx.Config.KeyBindings
+		key := msg.String()
+
+		if s.HandleFocusNav(key, km) {
+			return s, nil
+		}
+
+		// Tab keys cycle the target locale from any panel.
+		if km.Matches(key, config.ActionTabPrev) {
+			if s.LocaleCursor > 0 {
+				s.LocaleCursor--
+			}
+			return s, nil
+		}
+		if km.Matches(key, config.ActionTabNext) {
+			if s.LocaleCursor < len(s.Locales)-1 {
+				s.LocaleCursor++
+			}
+			return s, nil
+		}
+
+		locale := s.targetLocale()
+
+		// Scaffold every node of the route in the target locale.
+		if km.Matches(key, config.ActionNew) {
+			if locale == "" || len(s.Nodes) == 0 {
+				return s, nil
+			}
+			ids := make([]types.ContentID, 0, len(s.Nodes))
+			for _, n := range s.Nodes {
+				ids = append(ids, n.ContentID)
+			}
+			return s, tea.Batch(LoadingStartCmd(), ScaffoldTranslationCmd(ids, locale, service.PrefillNone))
+		}
+
+		// Scaffold pre-filled from the translation memory, and from the
+		// machine-translation provider when one is configured.
+		if km.Matches(key, config.ActionPrefill) {
+			if locale == "" || len(s.Nodes) == 0 {
+				return s, nil
+			}
+			prefill := service.PrefillMemory
+			if ctx.Config != nil && ctx.Config.Translation_Provider != "" {
+				prefill = service.PrefillMachine
+			}
+			ids := make([]types.ContentID, 0, len(s.Nodes))
+			for _, n := range s.Nodes {
+				ids = append(ids, n.ContentID)
+			}
+			return s, tea.Batch(LoadingStartCmd(), ScaffoldTranslationCmd(ids, locale, prefill))
+		}
+
+		// Publish the route in the target locale.
+		if km.Matches(key, config.ActionPublish) {
+			if locale == "" || s.RootID.IsZero() {
+				return s, nil
+			}
+			return s, ShowPublishLocaleDialogCmd(ctx.DB, locale, s.RootID, s.RouteID, s.RouteTitle)
+		}
+
+		switch s.FocusIndex {
+		case translationsFocusLocales:
+			if km.Matches(key, config.ActionUp) {
+				if s.LocaleCursor > 0 {
+					s.LocaleCursor--
+				}
+				return s, nil
+			}
+			if km.Matches(key, config.ActionDown) {
+				if s.LocaleCursor < len(s.Locales)-1 {
+					s.LocaleCursor++
+				}
+				return s, nil
+			}
+			if km.Matches(key, config.ActionSelect) {
+				s.FocusIndex = translationsFocusNodes
+				return s, nil
+			}
+		case translationsFocusNodes:
+			if km.Matches(key, config.ActionSelect) {
+				if node := s.selectedNode(); node != nil && len(node.Fields) > 0 {
+					s.FocusIndex = translationsFocusFields
+				}
+				return s, nil
+			}
+		case translationsFocusFields:
+			if km.Matches(key, config.ActionUp) {
+				if s.FieldCursor > 0 {
+					s.FieldCursor--
+				}
+				return s, nil
+			}
+			if km.Matches(key, config.ActionDown) {
+				if node := s.selectedNode(); node != nil && s.FieldCursor < len(node.Fields)-1 {
+					s.FieldCursor++
+				}
+				return s, nil
+			}
+			if km.Matches(key, config.ActionSelect) || km.Matches(key, config.ActionEdit) {
+				return s, s.editFieldCmd(locale)
+			}
+			if km.Matches(key, config.ActionReview) {
+				node, field := s.selectedNode(), s.selectedField()
+				if node == nil || field == nil || !field.Targets[locale].needsAttention() {
+					return s, nil
+				}
+				return s, MarkTranslationReviewedCmd(field.Targets[locale].ContentFieldID, node.ContentID, locale)
+			}
+		}
+
+		cursorMax := len(s.Nodes) - 1
+		if cursorMax < 0 {
+			cursorMax = 0
+		}
+		s.CursorMax = cursorMax
+		newCursor, cmd, handled := HandleCommonKeys(key, km, s.Cursor, s.CursorMax)
+		if handled {
+			if newCursor != s.Cursor {
+				s.FieldCursor = 0
+			}
+			s.Cursor = newCursor
+			return s, cmd
+		}
+
+	case TranslationsFetchMsg:
+		d := ctx.DB
+		if d == nil {
+			return s, func() tea.Msg { return FetchErrMsg{Error: fmt.Errorf("database not connected")} }
+		}
+		if s.RouteID.IsZero() {
+			return s, func() tea.Msg { return FetchErrMsg{Error: fmt.Errorf("no route selected for translation")} }
+		}
+		routeID := s.RouteID
+		sourceLocale := ctx.Config.I18nDefaultLocale()
+		return s, func() tea.Msg {
+			res, err := loadTranslations(d, routeID, sourceLocale)
+			if err != nil {
+				return FetchErrMsg{Error: err}
+			}
+			return res
+		}
+
+	case TranslationsFetchResultsMsg:
+		if msg.RouteID != s.RouteID {
+			return s, nil
+		}
+		s.applyResults(msg)
+		return s, LoadingStopCmd()
+
+	case TranslationScaffoldedMsg:
+		logMsg := fmt.Sprintf("Scaffolded %d %s field(s)", msg.FieldsCreated, msg.Locale)
+		if msg.NeedsReview > 0 {
+			logMsg += fmt.Sprintf(", %d pre-filled field(s) need review", msg.NeedsReview)
+		}
+		return s, tea.Batch(LogMessageCmd(logMsg), TranslationsFetchCmd())
+
+	case TranslationFieldSavedMsg:
+		return s, TranslationsFetchCmd()
+	}
+
+	return s, nil
+}
+
+// applyResults installs fetched data, keeping the selected target locale and
+// node when they still exist.
+func (s *TranslationsScreen) applyResults(msg TranslationsFetchResultsMsg) {
+	prevLocale := s.targetLocale()
+	var prevNode types.ContentID
+	if s.preselect != "" {
+		prevNode = s.preselect
+		s.preselect = ""
+	} else if node := s.selectedNode(); node != nil {
+		prevNode = node.ContentID
+	}
+
+	s.RouteTitle = msg.RouteTitle
+	s.RootID = msg.RootID
+	s.SourceLocale = msg.SourceLocale
+	s.Nodes = msg.Nodes
+	s.Published = msg.Published
+	s.Locales = s.Locales[:0]
+	for _, l := range msg.Locales {
+		if l.Code != msg.SourceLocale {
+			s.Locales = append(s.Locales, l)
+		}
+	}
+
+	s.LocaleCursor = 0
+	for i, l := range s.Locales {
+		if l.Code == prevLocale {
+			s.LocaleCursor = i
+			break
+		}
+	}
+	s.CursorMax = max(len(s.Nodes)-1, 0)
+	s.Cursor = min(s.Cursor, s.CursorMax)
+	for i, n := range s.Nodes {
+		if n.ContentID == prevNode {
+			s.Cursor = i
+			break
+		}
+	}
+	if node := s.selectedNode(); node == nil || s.FieldCursor >= len(node.Fields) {
+		s.FieldCursor = 0
+	}
+}
+
+// editFieldCmd opens the content editor for the selected field's value in
+// locale. Untranslated fields start from the source value.
+func (s *TranslationsScreen) editFieldCmd(locale string) tea.Cmd {
+	node := s.selectedNode()
+	field := s.selectedField()
+	if node == nil || field == nil || locale == "" {
+		return nil
+	}
+	target, ok := field.Targets[locale]
+	value := target.Value
+	if !ok {
+		value = field.Source
+	}
+	return ShowEditTranslationFieldDialogCmd(ShowEditTranslationFieldDialogMsg{
+		Field: ContentFieldDisplay{
+			ContentFieldID: target.ContentFieldID,
+			FieldID:        field.FieldID,
+			Label:          field.Label,
+			Type:           field.Type,
+			Value:          value,
+			DataJSON:       field.DataJSON,
+		},
+		ContentID:  node.ContentID,
+		RouteID:    s.RouteID,
+		DatatypeID: node.DatatypeID,
+		Locale:     locale,
+	})
+}
+
+func (s *TranslationsScreen) KeyHints(km config.KeyMap) []KeyHint {
+	hints := []KeyHint{
+		{km.HintString(config.ActionTabPrev) + "/" + km.HintString(config.ActionTabNext), "locale"},
+	}
+	if s.FocusIndex == translationsFocusFields {
+		hints = append(hints, KeyHint{km.HintString(config.ActionEdit), "translate"})
+		if f := s.selectedField(); f != nil && f.Targets[s.targetLocale()].Review != nil {
+			hints = append(hints, KeyHint{km.HintString(config.ActionReview), "reviewed"})
+		} else if f != nil && f.Targets[s.targetLocale()].Stale {
+			hints = append(hints, KeyHint{km.HintString(config.ActionReview), "still current"})
+		}
+	}
+	return append(hints,
+		KeyHint{km.HintString(config.ActionNew), "scaffold"},
+		KeyHint{km.HintString(config.ActionPrefill), "pre-fill"},
+		KeyHint{km.HintString(config.ActionPublish), "publish"},
+		KeyHint{km.HintString(config.ActionUp) + "/" + km.HintString(config.ActionDown), "nav"},
+		KeyHint{km.HintString(config.ActionNextPanel), "panel"},
+		KeyHint{km.HintString(config.ActionBack), "back"},
+	)
+}
+
+func (s *TranslationsScreen) View(ctx AppContext) string {
+	fieldLines := 0
+	if node := s.selectedNode(); node != nil {
+		fieldLines = len(node.Fields) + 2
+	}
+	width := ctx.Width * translationsGrid.Columns[1].Span / 12
+	if ctx.ScreenMode == ScreenFull {
+		width = ctx.Width
+	}
+	cells := []CellContent{
+		{Content: s.renderLocales(ctx), TotalLines: len(s.Locales), ScrollOffset: ClampScroll(s.LocaleCursor, len(s.Locales), ctx.Height)},
+		{Content: s.renderNodes(), TotalLines: len(s.Nodes), ScrollOffset: ClampScroll(s.Cursor, len(s.Nodes), ctx.Height)},
+		{Content: s.renderFields(width - 4), TotalLines: fieldLines, ScrollOffset: ClampScroll(s.FieldCursor, fieldLines, ctx.Height)},
+	}
+	return s.RenderGrid(ctx, cells)
+}
+
+// renderLocales lists target locales with route-wide completeness and
+// whether the route is published in that locale.
+func (s *TranslationsScreen) renderLocales(ctx AppContext) string {
+	faint := lipgloss.NewStyle().Faint(true)
+	if ctx.Config != nil && !ctx.Config.I18nEnabled() {
+		return faint.Render(" i18n is disabled in the configuration.")
+	}
+	if len(s.Locales) == 0 {
+		return faint.Render(" (no other enabled locales)")
+	}
+	lines := []string{faint.Render(fmt.Sprintf(" source: %s", s.SourceLocale))}
+	for i, l := range s.Locales {
+		cursor := "   "
+		if i == s.LocaleCursor {
+			cursor = " ->"
+		}
+		done, total := translationProgress(s.Nodes, l.Code)
+		status := "draft"
+		if s.Published[l.Code] {
+			status = "published"
+		}
+		if stale := translationStaleCount(s.Nodes, l.Code); stale > 0 {
+			status = fmt.Sprintf("%s, %d stale", status, stale)
+		}
+		lines = append(lines, fmt.Sprintf("%s %-6s %s %s", cursor, l.Code, completenessLabel(done, total), faint.Render(status)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderNodes lists the route's nodes with their completeness in the target
+// locale.
+func (s *TranslationsScreen) renderNodes() string {
+	if len(s.Nodes) == 0 {
+		return " (no content)"
+	}
+	locale := s.targetLocale()
+	faint := lipgloss.NewStyle().Faint(true)
+	lines := make([]string, 0, len(s.Nodes))
+	for i, n := range s.Nodes {
+		cursor := "   "
+		if i == s.Cursor {
+			cursor = " ->"
+		}
+		progress := faint.Render("-")
+		if len(n.Fields) > 0 && locale != "" {
+			done, total := translationProgress([]translationNode{n}, locale)
+			progress = fmt.Sprintf("%d/%d", done, total)
+			if stale := translationStaleCount([]translationNode{n}, locale); stale > 0 {
+				progress += fmt.Sprintf(" ~%d", stale)
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s %s%s %s", cursor, strings.Repeat("  ", n.Depth), n.Label, progress))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderFields shows the selected node's translatable fields with the source
+// value next to the target value, then the full text of the focused field.
+func (s *TranslationsScreen) renderFields(width int) string {
+	node := s.selectedNode()
+	if node == nil {
+		return " No node selected"
+	}
+	faint := lipgloss.NewStyle().Faint(true)
+	if len(node.Fields) == 0 {
+		return faint.Render(" This node has no translatable fields.")
+	}
+	locale := s.targetLocale()
+	if locale == "" {
+		return faint.Render(" No target locale. Enable another locale on the Locales screen.")
+	}
+
+	valueWidth := max((width-translationLabelWidth-6)/2, 8)
+	cell := func(text string, w int) string {
+		return lipgloss.NewStyle().Width(w).MaxWidth(w).Render(truncateToVisualWidth(text, w))
+	}
+	bold := lipgloss.NewStyle().Bold(true)
+	missing := lipgloss.NewStyle().Foreground(config.DefaultStyle.Accent2)
+	review := lipgloss.NewStyle().Foreground(config.DefaultStyle.Warn)
+
+	lines := []string{
+		bold.Render("    " + cell("Field", translationLabelWidth) + " " + cell(s.SourceLocale+" (source)", valueWidth) + " " + cell(locale, valueWidth)),
+	}
+	for i, f := range node.Fields {
+		cursor := "   "
+		if s.FocusIndex == translationsFocusFields && i == s.FieldCursor {
+			cursor = " ->"
+		}
+		target := cell(singleLine(f.Targets[locale].Value), valueWidth)
+		if !f.translated(locale) {
+			target = missing.Render(cell("(untranslated)", valueWidth))
+		} else if f.Targets[locale].Review != nil {
+			target = review.Render(cell("! "+singleLine(f.Targets[locale].Value), valueWidth))
+		} else if f.Targets[locale].Stale {
+			target = review.Render(cell("~ "+singleLine(f.Targets[locale].Value), valueWidth))
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s %s", cursor, cell(f.Label, translationLabelWidth), cell(singleLine(f.Source), valueWidth), target))
+	}
+
+	if f := s.selectedField(); f != nil && s.FocusIndex == translationsFocusFields {
+		wrap := lipgloss.NewStyle().Width(max(width-2, 10))
+		lines = append(lines,
+			"",
+			bold.Render(fmt.Sprintf(" %s (%s)", f.Label, s.SourceLocale)),
+			wrap.Render(" "+f.Source),
+			"",
+			bold.Render(fmt.Sprintf(" %s (%s)", f.Label, locale)),
+			wrap.Render(" "+f.Targets[locale].Value),
+		)
+		if r := f.Targets[locale].Review; r != nil {
+			lines = append(lines, "", review.Render(" ! "+reviewLabel(r)+" -- needs review before publishing"))
+		}
+		if f.Targets[locale].Stale {
+			lines = append(lines, "", review.Render(" ~ the source changed after this was translated"))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// completenessLabel renders done/total with a percentage.
+func completenessLabel(done, total int) string {
+	if total == 0 {
+		return "0/0"
+	}
+	return fmt.Sprintf("%d/%d %3d%%", done, total, done*100/total)
+}
+
+// singleLine collapses line breaks so a value fits one table row.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// reviewLabel describes where a pre-filled value came from.
+func reviewLabel(r *db.TranslationReview) string {
+	if r.Origin == translation.OriginMemory {
+		return fmt.Sprintf("translation memory match (%d%%)", r.Score)
+	}
+	if r.Provider != "" {
+		return "machine translation (" + r.Provider + ")"
+	}
+	return "machine translation"
+}
@@ -0,0 +1,284 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+func contentRow(id, parent, firstChild, next string) db.ContentData {
+	nullable := func(s string) types.NullableContentID {
+		return types.NullableContentID{ID: types.ContentID(s), Valid: s != ""}
+	}
+	return db.ContentData{
+		ContentDataID: types.ContentID(id),
+		ParentID:      nullable(parent),
+		FirstChildID:  nullable(firstChild),
+		NextSiblingID: nullable(next),
+	}
+}
+
+func TestOrderContentTree(t *testing.T) {
+	// root
+	//   a
+	//     a1
+	//   b
+	// orphan (parent missing from the route)
+	rows := []db.ContentData{
+		contentRow("b", "root", "", ""),
+		contentRow("a1", "a", "", ""),
+		contentRow("orphan", "gone", "", ""),
+		contentRow("root", "", "a", ""),
+		contentRow("a", "root", "a1", "b"),
+	}
+	var got []string
+	var depths []int
+	for _, item := range orderContentTree(rows) {
+		got = append(got, string(item.content.ContentDataID))
+		depths = append(depths, item.depth)
+	}
+	if want := []string{"root", "a", "a1", "b", "orphan"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+	if want := []int{0, 1, 2, 1, 0}; !reflect.DeepEqual(depths, want) {
+		t.Errorf("depths = %v, want %v", depths, want)
+	}
+}
+
+func TestOrderContentTree_SiblingCycle(t *testing.T) {
+	rows := []db.ContentData{
+		contentRow("root", "", "a", ""),
+		contentRow("a", "root", "", "b"),
+		contentRow("b", "root", "", "a"),
+	}
+	if got := len(orderContentTree(rows)); got != 3 {
+		t.Errorf("expected each row once, got %d rows", got)
+	}
+}
+
+func TestTranslationProgress(t *testing.T) {
+	nodes := []translationNode{
+		{Fields: []translationField{
+			{Targets: map[string]translationValue{"fr": {Value: "Bonjour"}}},
+			{Targets: map[string]translationValue{"fr": {Value: ""}}}, // scaffolded but empty
+		}},
+		{Fields: []translationField{
+			{}, // no row yet
+		}},
+		{}, // node without translatable fields
+	}
+	done, total := translationProgress(nodes, "fr")
+	if done != 1 || total != 3 {
+		t.Errorf("fr progress = %d/%d, want 1/3", done, total)
+	}
+	done, total = translationProgress(nodes, "de")
+	if done != 0 || total != 3 {
+		t.Errorf("de progress = %d/%d, want 0/3", done, total)
+	}
+	if got := completenessLabel(1, 3); got != "1/3  33%" {
+		t.Errorf("completenessLabel(1, 3) = %q", got)
+	}
+}
+
+func TestTranslationsScreen_ApplyResults(t *testing.T) {
+	s := NewTranslationsScreen("route", "n2")
+	results := TranslationsFetchResultsMsg{
+		RouteID:      "route",
+		RootID:       "n1",
+		SourceLocale: "en",
+		Locales: []db.Locale{
+			{Code: "en", IsDefault: true},
+			{Code: "fr"},
+			{Code: "de"},
+		},
+		Nodes: []translationNode{
+			{ContentID: "n1"},
+			{ContentID: "n2", Fields: []translationField{{}, {}}},
+		},
+	}
+	s.applyResults(results)
+
+	var codes []string
+	for _, l := range s.Locales {
+		codes = append(codes, l.Code)
+	}
+	if want := []string{"fr", "de"}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("target locales = %v, want %v (source excluded)", codes, want)
+	}
+	if s.Cursor != 1 {
+		t.Errorf("expected cursor on preselected node n2, got %d", s.Cursor)
+	}
+
+	// A refresh keeps the chosen target locale and node.
+	s.LocaleCursor = 1
+	s.FieldCursor = 1
+	results.Locales = []db.Locale{{Code: "de"}, {Code: "en"}, {Code: "fr"}}
+	s.applyResults(results)
+	if got := s.targetLocale(); got != "de" {
+		t.Errorf("target locale after refresh = %q, want de", got)
+	}
+	if s.Cursor != 1 || s.FieldCursor != 1 {
+		t.Errorf("selection after refresh = node %d field %d, want node 1 field 1", s.Cursor, s.FieldCursor)
+	}
+}
+
+func TestTranslationsScreen_EditStartsFromSource(t *testing.T) {
+	s := NewTranslationsScreen("route", "")
+	s.applyResults(TranslationsFetchResultsMsg{
+		RouteID:      "route",
+		SourceLocale: "en",
+		Locales:      []db.Locale{{Code: "fr"}},
+		Nodes: []translationNode{{
+			ContentID: "n1",
+			Fields: []translationField{
+				{FieldID: "title", Source: "Hello"},
+				{FieldID: "body", Source: "World", Targets: map[string]translationValue{"fr": {ContentFieldID: "cf1", Value: "Monde"}}},
+			},
+		}},
+	})
+
+	msg := s.editFieldCmd("fr")().(ShowEditTranslationFieldDialogMsg)
+	if msg.Field.Value != "Hello" || !msg.Field.ContentFieldID.IsZero() {
+		t.Errorf("untranslated field: value %q id %q, want source value and no row", msg.Field.Value, msg.Field.ContentFieldID)
+	}
+
+	s.FieldCursor = 1
+	msg = s.editFieldCmd("fr")().(ShowEditTranslationFieldDialogMsg)
+	if msg.Field.Value != "Monde" || msg.Field.ContentFieldID != "cf1" || msg.Locale != "fr" {
+		t.Errorf("translated field: got %+v locale %q", msg.Field, msg.Locale)
+	}
+}
+
+func TestTranslationsScreen_MarkReviewed(t *testing.T) {
+	s := NewTranslationsScreen("route", "")
+	s.applyResults(TranslationsFetchResultsMsg{
+		RouteID:      "route",
+		SourceLocale: "en",
+		Locales:      []db.Locale{{Code: "fr"}},
+		Nodes: []translationNode{{
+			ContentID: "n1",
+			Fields: []translationField{
+				{FieldID: "title", Targets: map[string]translationValue{"fr": {ContentFieldID: "cf1", Value: "Bonjour"}}},
+				{FieldID: "body", Targets: map[string]translationValue{"fr": {
+					ContentFieldID: "cf2",
+					Value:          "Monde",
+					Review:         &db.TranslationReview{ContentFieldID: "cf2", Origin: "machine", Provider: "deepl"},
+				}}},
+			},
+		}},
+	})
+	s.FocusIndex = translationsFocusFields
+	ctx := AppContext{Config: &config.Config{KeyBindings: config.DefaultKeyMap()}}
+
+	// The title was not pre-filled, so there is nothing to mark.
+	if _, cmd := s.Update(ctx, runeKey('r')); cmd != nil {
+		t.Errorf("expected no command for an unflagged field, got %T", cmd())
+	}
+
+	s.FieldCursor = 1
+	_, cmd := s.Update(ctx, runeKey('r'))
+	if cmd == nil {
+		t.Fatal("expected a mark-reviewed command for the flagged field")
+	}
+	want := MarkTranslationReviewedRequestMsg{ContentFieldID: "cf2", ContentID: "n1", Locale: "fr"}
+	if got := cmd(); got != want {
+		t.Errorf("msg = %+v, want %+v", got, want)
+	}
+	if got := reviewLabel(s.Nodes[0].Fields[1].Targets["fr"].Review); got != "machine translation (deepl)" {
+		t.Errorf("reviewLabel = %q", got)
+	}
+}
+
+func TestTranslationsScreen_StaleField(t *testing.T) {
+	s := NewTranslationsScreen("route", "")
+	s.applyResults(TranslationsFetchResultsMsg{
+		RouteID:      "route",
+		SourceLocale: "en",
+		Locales:      []db.Locale{{Code: "fr"}},
+		Nodes: []translationNode{{
+			ContentID: "n1",
+			Fields: []translationField{
+				{FieldID: "title", Targets: map[string]translationValue{"fr": {ContentFieldID: "cf1", Value: "Bonjour", Stale: true}}},
+				{FieldID: "body", Targets: map[string]translationValue{"fr": {ContentFieldID: "cf2", Value: "Monde"}}},
+			},
+		}},
+	})
+	if got := translationStaleCount(s.Nodes, "fr"); got != 1 {
+		t.Errorf("translationStaleCount = %d, want 1", got)
+	}
+	s.FocusIndex = translationsFocusFields
+	ctx := AppContext{Config: &config.Config{KeyBindings: config.DefaultKeyMap()}}
+
+	// Marking a stale translation reviewed records it as current.
+	_, cmd := s.Update(ctx, runeKey('r'))
+	if cmd == nil {
+		t.Fatal("expected a mark-reviewed command for the stale field")
+	}
+	want := MarkTranslationReviewedRequestMsg{ContentFieldID: "cf1", ContentID: "n1", Locale: "fr"}
+	if got := cmd(); got != want {
+		t.Errorf("msg = %+v, want %+v", got, want)
+	}
+}
+
+func TestLocaleFallbackChain(t *testing.T) {
+	locales := []db.Locale{
+		{Code: "en", IsDefault: true},
+		{Code: "fr", FallbackCode: "en"},
+		{Code: "fr-CA", FallbackCode: "fr"},
+		{Code: "de"},
+		{Code: "x", FallbackCode: "y"},
+		{Code: "y", FallbackCode: "x"},
+	}
+	tests := []struct {
+		code string
+		want []string
+	}{
+		{"fr-CA", []string{"fr-CA", "fr", "en"}},
+		{"en", []string{"en"}},
+		{"de", []string{"de", "en"}},
+		{"x", []string{"x", "y", "↺ x"}},
+	}
+	for _, tt := range tests {
+		if got := localeFallbackChain(locales, tt.code); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("localeFallbackChain(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestLocaleFormDialog_Accept(t *testing.T) {
+	locales := []db.Locale{{Code: "en"}, {Code: "fr"}, {Code: "fr-CA"}}
+	d := NewEditLocaleFormDialog("Edit Locale", db.Locale{
+		LocaleID:     "loc1",
+		Code:         "fr-CA",
+		Label:        "French (Canada)",
+		FallbackCode: "fr",
+		IsEnabled:    true,
+		SortOrder:    3,
+	}, locales)
+
+	if want := []string{localeNoFallback, "en", "fr"}; !reflect.DeepEqual(d.FallbackOptions, want) {
+		t.Fatalf("fallback options = %v, want %v (self excluded)", d.FallbackOptions, want)
+	}
+	got := d.acceptMsg()
+	want := LocaleFormDialogAcceptMsg{
+		Action:       FORMDIALOGEDITLOCALE,
+		EntityID:     "loc1",
+		Code:         "fr-CA",
+		Label:        "French (Canada)",
+		FallbackCode: "fr",
+		SortOrder:    "3",
+		IsEnabled:    true,
+	}
+	if got != want {
+		t.Errorf("accept = %+v, want %+v", got, want)
+	}
+
+	d.focusIndex = localeFocusFallback
+	d.stepCarousel(-2)
+	if got := d.acceptMsg().FallbackCode; got != "" {
+		t.Errorf("fallback after stepping to (none) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,376 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/hegner123/modulacms/internal/config"
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+)
+
+// User groups grid: 2 columns
+//
+//	Col 0 (span 3): Group list
+//	Col 1 (span 9): Details (top), Roles (middle), Members (bottom)
+var userGroupsGrid = Grid{
+	Columns: []GridColumn{
+		{Span: 3, Cells: []GridCell{
+			{Height: 1.0, Title: "Groups"},
+		}},
+		{Span: 9, Cells: []GridCell{
+			{Height: 0.20, Title: "Details"},
+			{Height: 0.40, Title: "Roles"},
+			{Height: 0.40, Title: "Members"},
+		}},
+	},
+}
+
+// Focus indexes for the user groups grid cells.
+const (
+	userGroupsFocusList    = 0
+	userGroupsFocusRoles   = 2
+	userGroupsFocusMembers = 3
+)
+
+// UserGroupsScreen implements Screen for user group management. Roles and
+// members are toggled in place from their panels.
+type UserGroupsScreen struct {
+	GridScreen
+	Groups       []db.UserGroups
+	Roles        []db.Roles
+	Users        []db.Users
+	GroupRoles   []db.UserGroupRoles
+	GroupMembers []db.UserGroupMembers
+	RoleCursor   int
+	MemberCursor int
+}
+
+func NewUserGroupsScreen(groups []db.UserGroups) *UserGroupsScreen {
+	cursorMax := len(groups) - 1
+	if cursorMax < 0 {
+		cursorMax = 0
+	}
+	return &UserGroupsScreen{
+		GridScreen: GridScreen{
+			Grid:      userGroupsGrid,
+			CursorMax: cursorMax,
+		},
+		Groups: groups,
+	}
+}
+
+func (s *UserGroupsScreen) PageIndex() PageIndex { return USERGROUPSPAGE }
+
+func (s *UserGroupsScreen) selectedGroup() *db.UserGroups {
+	if len(s.Groups) == 0 || s.Cursor >= len(s.Groups) {
+		return nil
+	}
+	return &s.Groups[s.Cursor]
+}
+
+// groupHasRole reports whether the group grants the role.
+func (s *UserGroupsScreen) groupHasRole(groupID types.UserGroupID, roleID types.RoleID) bool {
+	for _, gr := range s.GroupRoles {
+		if gr.UserGroupID == groupID && gr.RoleID == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// groupHasMember reports whether the user belongs to the group.
+func (s *UserGroupsScreen) groupHasMember(groupID types.UserGroupID, userID types.UserID) bool {
+	for _, gm := range s.GroupMembers {
+		if gm.UserGroupID == groupID && gm.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *UserGroupsScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		km := ctx.Config.KeyBindings
+		key := msg.String()
+
+		if s.HandleFocusNav(key, km) {
+			return s, nil
+		}
+
+		if km.Matches(key, config.ActionNew) {
+			return s, ShowCreateUserGroupDialogCmd()
+		}
+
+		if km.Matches(key, config.ActionEdit) {
+			if group := s.selectedGroup(); group != nil {
+				return s, ShowEditUserGroupDialogCmd(*group)
+			}
+		}
+
+		if km.Matches(key, config.ActionDelete) {
+			if group := s.selectedGroup(); group != nil {
+				return s, ShowDeleteUserGroupDialogCmd(group.UserGroupID, group.Label)
+			}
+		}
+
+		// Role and member panels have their own cursors and toggle on select.
+		switch s.FocusIndex {
+		case userGroupsFocusRoles:
+			if km.Matches(key, config.ActionSelect) {
+				group := s.selectedGroup()
+				if group != nil && s.RoleCursor < len(s.Roles) {
+					return s, tea.Batch(LoadingStartCmd(), ToggleUserGroupRoleCmd(group.UserGroupID, s.Roles[s.RoleCursor].RoleID))
+				}
+				return s, nil
+			}
+			if km.Matches(key, config.ActionUp) {
+				if s.RoleCursor > 0 {
+					s.RoleCursor--
+				}
+				return s, nil
+			}
+			if km.Matches(key, config.ActionDown) {
+				if s.RoleCursor < len(s.Roles)-1 {
+					s.RoleCursor++
+				}
+				return s, nil
+			}
+		case userGroupsFocusMembers:
+			if km.Matches(key, config.ActionSelect) {
+				group := s.selectedGroup()
+				if group != nil && s.MemberCursor < len(s.Users) {
+					return s, tea.Batch(LoadingStartCmd(), ToggleUserGroupMemberCmd(group.UserGroupID, s.Users[s.MemberCursor].UserID))
+				}
+				return s, nil
+			}
+			if km.Matches(key, config.ActionUp) {
+				if s.MemberCursor > 0 {
+					s.MemberCursor--
+				}
+				return s, nil
+			}
+			if km.Matches(key, config.ActionDown) {
+				if s.MemberCursor < len(s.Users)-1 {
+					s.MemberCursor++
+				}
+				return s, nil
+			}
+		}
+
+		cursorMax := len(s.Groups) - 1
+		if cursorMax < 0 {
+			cursorMax = 0
+		}
+		s.CursorMax = cursorMax
+		newCursor, cmd, handled := HandleCommonKeys(key, km, s.Cursor, s.CursorMax)
+		if handled {
+			s.Cursor = newCursor
+			return s, cmd
+		}
+
+	case UserGroupsFetchMsg:
+		d := ctx.DB
+		if d == nil {
+			return s, func() tea.Msg { return FetchErrMsg{Error: fmt.Errorf("database not connected")} }
+		}
+		return s, func() tea.Msg {
+			groups, err := d.ListUserGroups()
+			if err != nil {
+				return FetchErrMsg{Error: err}
+			}
+			roles, err := d.ListRoles()
+			if err != nil {
+				return FetchErrMsg{Error: err}
+			}
+			users, err := d.ListUsers()
+			if err != nil {
+				return FetchErrMsg{Error: err}
+			}
+			groupRoles, err := d.ListUserGroupRoles()
+			if err != nil {
+				return FetchErrMsg{Error: err}
+			}
+			members, err := d.ListUserGroupMembers()
+			if err != nil {
+				return FetchErrMsg{Error: err}
+			}
+			res := UserGroupsFetchResultsMsg{
+				Groups:       []db.UserGroups{},
+				Roles:        []db.Roles{},
+				Users:        []db.Users{},
+				GroupRoles:   []db.UserGroupRoles{},
+				GroupMembers: []db.UserGroupMembers{},
+			}
+			if groups != nil {
+				res.Groups = *groups
+			}
+			if roles != nil {
+				res.Roles = *roles
+			}
+			if users != nil {
+				res.Users = *users
+			}
+			if groupRoles != nil {
+				res.GroupRoles = *groupRoles
+			}
+			if members != nil {
+				res.GroupMembers = *members
+			}
+			return res
+		}
+
+	case UserGroupsFetchResultsMsg:
+		s.Groups = msg.Groups
+		s.Roles = msg.Roles
+		s.Users = msg.Users
+		s.GroupRoles = msg.GroupRoles
+		s.GroupMembers = msg.GroupMembers
+		s.CursorMax = len(s.Groups) - 1
+		if s.CursorMax < 0 {
+			s.CursorMax = 0
+		}
+		if s.Cursor > s.CursorMax {
+			s.Cursor = s.CursorMax
+		}
+		if s.RoleCursor >= len(s.Roles) {
+			s.RoleCursor = 0
+		}
+		if s.MemberCursor >= len(s.Users) {
+			s.MemberCursor = 0
+		}
+		return s, LoadingStopCmd()
+
+	case UserGroupSavedMsg, UserGroupDeletedMsg:
+		s.Cursor = 0
+		return s, UserGroupsFetchCmd()
+
+	case UserGroupAssignmentsChangedMsg:
+		return s, UserGroupsFetchCmd()
+	}
+
+	return s, nil
+}
+
+func (s *UserGroupsScreen) KeyHints(km config.KeyMap) []KeyHint {
+	hints := []KeyHint{
+		{km.HintString(config.ActionNew), "new"},
+		{km.HintString(config.ActionEdit), "edit"},
+		{km.HintString(config.ActionDelete), "del"},
+	}
+	if s.FocusIndex == userGroupsFocusRoles || s.FocusIndex == userGroupsFocusMembers {
+		hints = append(hints, KeyHint{km.HintString(config.ActionSelect), "toggle"})
+	}
+	return append(hints,
+		KeyHint{km.HintString(config.ActionUp) + "/" + km.HintString(config.ActionDown), "nav"},
+		KeyHint{km.HintString(config.ActionNextPanel), "panel"},
+		KeyHint{km.HintString(config.ActionBack), "back"},
+	)
+}
+
+func (s *UserGroupsScreen) View(ctx AppContext) string {
+	cells := []CellContent{
+		{Content: s.renderList(), TotalLines: len(s.Groups), ScrollOffset: ClampScroll(s.Cursor, len(s.Groups), ctx.Height)},
+		{Content: s.renderDetail()},
+		{Content: s.renderRoles(), TotalLines: len(s.Roles), ScrollOffset: ClampScroll(s.RoleCursor, len(s.Roles), ctx.Height)},
+		{Content: s.renderMembers(), TotalLines: len(s.Users), ScrollOffset: ClampScroll(s.MemberCursor, len(s.Users), ctx.Height)},
+	}
+	return s.RenderGrid(ctx, cells)
+}
+
+func (s *UserGroupsScreen) renderList() string {
+	if len(s.Groups) == 0 {
+		return "(no groups)"
+	}
+	lines := make([]string, 0, len(s.Groups))
+	for i, group := range s.Groups {
+		cursor := "   "
+		if s.Cursor == i {
+			cursor = " ->"
+		}
+		members := 0
+		for _, gm := range s.GroupMembers {
+			if gm.UserGroupID == group.UserGroupID {
+				members++
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s %s (%d)", cursor, group.Label, members))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s *UserGroupsScreen) renderDetail() string {
+	group := s.selectedGroup()
+	if group == nil {
+		return " No group selected"
+	}
+
+	faint := lipgloss.NewStyle().Faint(true)
+
+	description := group.Description
+	if description == "" {
+		description = faint.Render("(none)")
+	}
+
+	lines := []string{
+		fmt.Sprintf(" Label       %s", group.Label),
+		fmt.Sprintf(" Description %s", description),
+		"",
+		faint.Render(fmt.Sprintf(" ID          %s", group.UserGroupID)),
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s *UserGroupsScreen) renderRoles() string {
+	group := s.selectedGroup()
+	if group == nil {
+		return " No group selected"
+	}
+	if len(s.Roles) == 0 {
+		return " (no roles)"
+	}
+	lines := make([]string, 0, len(s.Roles))
+	for i, role := range s.Roles {
+		lines = append(lines, renderToggleLine(
+			s.FocusIndex == userGroupsFocusRoles && s.RoleCursor == i,
+			s.groupHasRole(group.UserGroupID, role.RoleID),
+			role.Label,
+		))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s *UserGroupsScreen) renderMembers() string {
+	group := s.selectedGroup()
+	if group == nil {
+		return " No group selected"
+	}
+	if len(s.Users) == 0 {
+		return " (no users)"
+	}
+	lines := make([]string, 0, len(s.Users))
+	for i, user := range s.Users {
+		lines = append(lines, renderToggleLine(
+			s.FocusIndex == userGroupsFocusMembers && s.MemberCursor == i,
+			s.groupHasMember(group.UserGroupID, user.UserID),
+			user.Username,
+		))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderToggleLine renders one checkbox row for an in-place toggle list.
+func renderToggleLine(focused, checked bool, label string) string {
+	cursor := "   "
+	if focused {
+		cursor = " ->"
+	}
+	box := "[ ]"
+	if checked {
+		box = lipgloss.NewStyle().Foreground(config.DefaultStyle.Accent).Render("[x]")
+	}
+	return fmt.Sprintf("%s %s %s", cursor, box, label)
+}
@@ -15,20 +15,31 @@ import (
 // Users grid: 2 columns
 //
 //	Col 0 (span 3): User list
-//	Col 1 (span 9): Details (top), Permissions (bottom)
+//	Col 1 (span 9): Details, Connections, Roles, Permissions
 var usersGrid = Grid{
 	Columns: []GridColumn{
 		{Span: 3, Cells: []GridCell{
 			{Height: 1.0, Title: "Users"},
 		}},
 		{Span: 9, Cells: []GridCell{
-			{Height: 0.30, Title: "Details"},
-			{Height: 0.30, Title: "Connections"},
-			{Height: 0.40, Title: "Permissions"},
+			{Height: 0.25, Title: "Details"},
+			{Height: 0.20, Title: "Connections"},
+			{Height: 0.25, Title: "Roles"},
+			{Height: 0.30, Title: "Permissions"},
 		}},
 	},
 }
 
+// usersFocusRoles is the focus index of the Roles panel.
+const usersFocusRoles = 3
+
+// UserRolesFetchedMsg delivers the direct role assignments for a user.
+type UserRolesFetchedMsg struct {
+	UserID types.UserID
+	Data   []db.UserRoles
+	Err    error
+}
+
 // UserPermissionsFetchedMsg delivers permission labels for a role.
 type UserPermissionsFetchedMsg struct {
 	RoleID types.RoleID
@@ -121,12 +132,15 @@ type UsersScreen struct {
 	GridScreen
 	UsersList        []db.UserWithRoleLabelRow
 	RolesList        []db.Roles
-	PermissionCache  map[types.RoleID][]string          // role ID -> permission labels
-	LastPermRoleID   types.RoleID                        // role ID of last permission fetch
-	OauthCache       map[types.UserID][]db.UserOauth     // user ID -> OAuth connections
-	LastOauthUserID  types.UserID                        // user ID of last OAuth fetch
-	SshKeyCache      map[types.UserID][]db.UserSshKeys   // user ID -> SSH keys
-	LastSshKeyUserID types.UserID                        // user ID of last SSH key fetch
+	PermissionCache  map[types.RoleID][]string         // role ID -> permission labels
+	LastPermRoleID   types.RoleID                      // role ID of last permission fetch
+	OauthCache       map[types.UserID][]db.UserOauth   // user ID -> OAuth connections
+	LastOauthUserID  types.UserID                      // user ID of last OAuth fetch
+	SshKeyCache      map[types.UserID][]db.UserSshKeys // user ID -> SSH keys
+	LastSshKeyUserID types.UserID                      // user ID of last SSH key fetch
+	UserRolesCache   map[types.UserID][]db.UserRoles   // user ID -> direct role assignments
+	LastRolesUserID  types.UserID                      // user ID of last role assignment fetch
+	RoleCursor       int
 }
 
 // NewUsersScreen creates a UsersScreen with the given users and roles data.
@@ -145,6 +159,7 @@ func NewUsersScreen(users []db.UserWithRoleLabelRow, roles []db.Roles) *UsersScr
 		PermissionCache: make(map[types.RoleID][]string),
 		OauthCache:      make(map[types.UserID][]db.UserOauth),
 		SshKeyCache:     make(map[types.UserID][]db.UserSshKeys),
+		UserRolesCache:  make(map[types.UserID][]db.UserRoles),
 	}
 }
 
@@ -250,6 +265,46 @@ func (s *UsersScreen) fetchSshKeysIfNeeded(driver db.DbDriver) tea.Cmd {
 	}
 }
 
+// fetchUserRolesIfNeeded returns a command to fetch role assignments for the
+// selected user, or nil if already cached.
+func (s *UsersScreen) fetchUserRolesIfNeeded(driver db.DbDriver) tea.Cmd {
+	user := s.selectedUser()
+	if user == nil || driver == nil {
+		return nil
+	}
+	userID := user.UserID
+	if userID == s.LastRolesUserID {
+		return nil
+	}
+	s.LastRolesUserID = userID
+	if _, ok := s.UserRolesCache[userID]; ok {
+		return nil
+	}
+	return func() tea.Msg {
+		result, err := driver.ListUserRolesByUserID(userID)
+		if err != nil {
+			return UserRolesFetchedMsg{UserID: userID, Err: err}
+		}
+		if result == nil {
+			return UserRolesFetchedMsg{UserID: userID, Data: []db.UserRoles{}}
+		}
+		return UserRolesFetchedMsg{UserID: userID, Data: *result}
+	}
+}
+
+// userHasRole reports whether the role is directly assigned to the user.
+func (s *UsersScreen) userHasRole(user *db.UserWithRoleLabelRow, roleID types.RoleID) bool {
+	if types.RoleID(user.Role) == roleID {
+		return true
+	}
+	for _, ur := range s.UserRolesCache[user.UserID] {
+		if ur.RoleID == roleID {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *UsersScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
@@ -272,6 +327,29 @@ func (s *UsersScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 			}
 		}
 
+		// Roles panel has its own cursor and toggles assignments on select
+		if s.FocusIndex == usersFocusRoles {
+			if km.Matches(key, config.ActionSelect) {
+				user := s.selectedUser()
+				if user != nil && s.RoleCursor < len(s.RolesList) {
+					return s, tea.Batch(LoadingStartCmd(), ToggleUserRoleCmd(user.UserID, s.RolesList[s.RoleCursor].RoleID))
+				}
+				return s, nil
+			}
+			if km.Matches(key, config.ActionUp) {
+				if s.RoleCursor > 0 {
+					s.RoleCursor--
+				}
+				return s, nil
+			}
+			if km.Matches(key, config.ActionDown) {
+				if s.RoleCursor < len(s.RolesList)-1 {
+					s.RoleCursor++
+				}
+				return s, nil
+			}
+		}
+
 		// Remove SSH key
 		if key == "s" {
 			if user := s.selectedUser(); user != nil {
@@ -319,6 +397,9 @@ func (s *UsersScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 				if sshCmd := s.fetchSshKeysIfNeeded(ctx.DB); sshCmd != nil {
 					cmds = append(cmds, sshCmd)
 				}
+				if rolesCmd := s.fetchUserRolesIfNeeded(ctx.DB); rolesCmd != nil {
+					cmds = append(cmds, rolesCmd)
+				}
 				if len(cmds) > 0 {
 					return s, tea.Batch(cmds...)
 				}
@@ -327,6 +408,19 @@ func (s *UsersScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 			return s, cmd
 		}
 
+	// Role assignments fetched
+	case UserRolesFetchedMsg:
+		if msg.Err == nil {
+			s.UserRolesCache[msg.UserID] = msg.Data
+		}
+		return s, nil
+
+	// Role assignments changed — invalidate cache and re-fetch
+	case UserAccessChangedMsg:
+		delete(s.UserRolesCache, msg.UserID)
+		s.LastRolesUserID = ""
+		return s, tea.Batch(LoadingStopCmd(), s.fetchUserRolesIfNeeded(ctx.DB))
+
 	// SSH keys fetched
 	case UserSshKeysFetchedMsg:
 		if msg.Err == nil {
@@ -383,6 +477,8 @@ func (s *UsersScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 		s.LastPermRoleID = ""
 		s.LastOauthUserID = ""
 		s.LastSshKeyUserID = ""
+		s.LastRolesUserID = ""
+		s.UserRolesCache = make(map[types.UserID][]db.UserRoles)
 		cmds := []tea.Cmd{LoadingStopCmd()}
 		if permCmd := s.fetchPermissionsIfNeeded(ctx.DB); permCmd != nil {
 			cmds = append(cmds, permCmd)
@@ -393,6 +489,9 @@ func (s *UsersScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 		if sshCmd := s.fetchSshKeysIfNeeded(ctx.DB); sshCmd != nil {
 			cmds = append(cmds, sshCmd)
 		}
+		if rolesCmd := s.fetchUserRolesIfNeeded(ctx.DB); rolesCmd != nil {
+			cmds = append(cmds, rolesCmd)
+		}
 		return s, tea.Batch(cmds...)
 	case RolesFetchMsg:
 		d := ctx.DB
@@ -431,15 +530,20 @@ func (s *UsersScreen) Update(ctx AppContext, msg tea.Msg) (Screen, tea.Cmd) {
 }
 
 func (s *UsersScreen) KeyHints(km config.KeyMap) []KeyHint {
-	return []KeyHint{
+	hints := []KeyHint{
 		{km.HintString(config.ActionNew), "new"},
 		{km.HintString(config.ActionEdit), "edit"},
 		{km.HintString(config.ActionDelete), "del"},
-		{"u", "unlink oauth"},
-		{"s", "rm ssh key"},
-		{km.HintString(config.ActionNextPanel), "panel"},
-		{km.HintString(config.ActionBack), "back"},
 	}
+	if s.FocusIndex == usersFocusRoles {
+		hints = append(hints, KeyHint{km.HintString(config.ActionSelect), "toggle role"})
+	}
+	return append(hints,
+		KeyHint{"u", "unlink oauth"},
+		KeyHint{"s", "rm ssh key"},
+		KeyHint{km.HintString(config.ActionNextPanel), "panel"},
+		KeyHint{km.HintString(config.ActionBack), "back"},
+	)
 }
 
 func (s *UsersScreen) View(ctx AppContext) string {
@@ -447,6 +551,7 @@ func (s *UsersScreen) View(ctx AppContext) string {
 		{Content: s.renderUsersList()},
 		{Content: s.renderUserDetail()},
 		{Content: s.renderConnections()},
+		{Content: s.renderRoles(), TotalLines: len(s.RolesList), ScrollOffset: ClampScroll(s.RoleCursor, len(s.RolesList), ctx.Height)},
 		{Content: s.renderPermissions()},
 	}
 	return s.RenderGrid(ctx, cells)
@@ -543,6 +648,32 @@ func (s *UsersScreen) renderConnections() string {
 	return strings.Join(lines, "\n")
 }
 
+func (s *UsersScreen) renderRoles() string {
+	user := s.selectedUser()
+	if user == nil {
+		return " No user selected"
+	}
+	if len(s.RolesList) == 0 {
+		return " (no roles)"
+	}
+	if _, ok := s.UserRolesCache[user.UserID]; !ok {
+		return lipgloss.NewStyle().Faint(true).Render(" Loading roles...")
+	}
+	lines := make([]string, 0, len(s.RolesList))
+	for i, role := range s.RolesList {
+		label := role.Label
+		if types.RoleID(user.Role) == role.RoleID {
+			label += " (primary)"
+		}
+		lines = append(lines, renderToggleLine(
+			s.FocusIndex == usersFocusRoles && s.RoleCursor == i,
+			s.userHasRole(user, role.RoleID),
+			label,
+		))
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (s *UsersScreen) renderPermissions() string {
 	user := s.selectedUser()
 	if user == nil {
@@ -463,9 +463,9 @@ func marshalUIConfig(widget, placeholder, helpText string, hidden bool) string {
 // (color-picker, date-picker, map, etc.) fall through to the field type's default.
 var widgetBubbleOverrides = map[string]func() FieldBubble{
 	"markdown":        func() FieldBubble { return NewTextareaBubble() },
-	"rich-text":       func() FieldBubble { return NewTextareaBubble() },
+	"rich-text":       func() FieldBubble { return NewRichTextBubble() },
 	"code-editor":     func() FieldBubble { return NewTextareaBubble() },
-	"json-editor":     func() FieldBubble { return NewTextareaBubble() },
+	"json-editor":     func() FieldBubble { return NewJSONBubble() },
 	"toggle":          func() FieldBubble { return NewBooleanBubble() },
 	"radio":           func() FieldBubble { return NewSelectBubble() },
 	"date-picker":     func() FieldBubble { return NewDatePickerBubble() },
@@ -474,14 +474,15 @@ var widgetBubbleOverrides = map[string]func() FieldBubble{
 }
 
 // resolveBubble returns a FieldBubble based on the widget override if available,
-// otherwise falls back to the field type's default bubble.
+// otherwise falls back to the field type's default bubble. All _id-prefixed
+// reference types share the reference picker.
 func resolveBubble(fieldType, widget string) FieldBubble {
 	if widget != "" {
 		if factory, ok := widgetBubbleOverrides[widget]; ok {
 			return factory()
 		}
 	}
-	return FieldBubbleForType(fieldType)
+	return FieldBubbleForType(referenceFieldType(fieldType))
 }
 
 // applyPlaceholder sets the placeholder text on bubble types that support it.
@@ -496,6 +497,8 @@ func applyPlaceholder(b FieldBubble, placeholder string) {
 		v.input.Placeholder = placeholder
 	case *TextareaBubble:
 		v.input.Placeholder = placeholder
+	case *JSONBubble:
+		v.input.Placeholder = placeholder
 	}
 }
 
@@ -516,6 +519,9 @@ func resolveFieldInput(f db.Fields) ContentFieldInput {
 			sb.ParseOptionsFromData(f.Data)
 		}
 	}
+	if jb, ok := bubble.(*JSONBubble); ok {
+		jb.Configure("", f.Data)
+	}
 
 	return ContentFieldInput{
 		FieldID:        f.FieldID,
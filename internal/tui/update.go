@@ -28,7 +28,7 @@ func (m Model) handleFilePicker(msg tea.Msg) (Model, tea.Cmd, bool) {
 			switch m.FilePickerPurpose {
 			case FILEPICKER_RESTORE:
 				return m, RestoreBackupFromPathCmd(path), true
-			case FILEPICKER_IMPORT:
+			case FILEPICKER_IMPORT, FILEPICKER_SHEET:
 				return m, func() tea.Msg { return ImportFileSelectedMsg{Path: path} }, true
 			case FILEPICKER_ADMINMEDIA:
 				return m, AdminMediaUploadCmd(path), true
@@ -121,6 +121,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			fp.AllowedTypes = []string{".zip"}
 		case FILEPICKER_IMPORT:
 			fp.AllowedTypes = []string{".json"}
+		case FILEPICKER_SHEET:
+			fp.AllowedTypes = []string{".csv", ".xlsx"}
 		default:
 			fp.AllowedTypes = []string{".png", ".jpg", ".jpeg", ".webp", ".gif"}
 		}
@@ -170,6 +172,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			PluginScreenSetupCmd(typedMsg.PluginName, typedMsg.ScreenName, typedMsg.Params, m.Width, m.Height, m.PluginManager),
 		)
 
+	// Translation view navigation (emitted by ContentScreen).
+	case ShowTranslationsMsg:
+		m.TranslationRouteID = typedMsg.RouteID
+		m.TranslationContentID = typedMsg.ContentID
+		return m, NavigateToPageCmd(m.PageMap[TRANSLATIONSPAGE])
+
 	// Pipeline selection + navigation (emitted by PipelinesScreen).
 	case SelectPipelineAndNavigateMsg:
 		// Screen receives the key via PipelineEntriesSet; no Model field needed.
@@ -591,6 +599,64 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Role CRUD results → screen (fall through to ActiveScreen.Update).
 
+	// User group dialog messages → UpdateDialog.
+	case ShowUserGroupFormDialogMsg:
+		return m.UpdateDialog(msg)
+	case ShowEditUserGroupDialogMsg:
+		return m.UpdateDialog(msg)
+	case ShowDeleteUserGroupDialogMsg:
+		return m.UpdateDialog(msg)
+	case UserGroupFormDialogAcceptMsg:
+		return m.UpdateDialog(msg)
+	case UserGroupFormDialogCancelMsg:
+		return m.UpdateDialog(msg)
+
+	// User group and user role requests → UpdateCms.
+	case CreateUserGroupFromDialogRequestMsg:
+		return m.UpdateCms(msg)
+	case UpdateUserGroupFromDialogRequestMsg:
+		return m.UpdateCms(msg)
+	case DeleteUserGroupRequestMsg:
+		return m.UpdateCms(msg)
+	case ToggleUserGroupRoleRequestMsg:
+		return m.UpdateCms(msg)
+	case ToggleUserGroupMemberRequestMsg:
+		return m.UpdateCms(msg)
+	case ToggleUserRoleRequestMsg:
+		return m.UpdateCms(msg)
+
+	// User group results → screen (fall through to ActiveScreen.Update).
+
+	// Locale and translation dialog messages → UpdateDialog.
+	case ShowLocaleFormDialogMsg:
+		return m.UpdateDialog(msg)
+	case ShowEditLocaleDialogMsg:
+		return m.UpdateDialog(msg)
+	case ShowDeleteLocaleDialogMsg:
+		return m.UpdateDialog(msg)
+	case LocaleFormDialogAcceptMsg:
+		return m.UpdateDialog(msg)
+	case LocaleFormDialogCancelMsg:
+		return m.UpdateDialog(msg)
+	case ShowEditTranslationFieldDialogMsg:
+		return m.UpdateDialog(msg)
+
+	// Locale and translation requests → UpdateCms.
+	case CreateLocaleFromDialogRequestMsg:
+		return m.UpdateCms(msg)
+	case UpdateLocaleFromDialogRequestMsg:
+		return m.UpdateCms(msg)
+	case DeleteLocaleRequestMsg:
+		return m.UpdateCms(msg)
+	case ScaffoldTranslationRequestMsg:
+		return m.UpdateCms(msg)
+	case SaveTranslationFieldRequestMsg:
+		return m.UpdateCms(msg)
+	case MarkTranslationReviewedRequestMsg:
+		return m.UpdateCms(msg)
+
+	// Locale and translation results → screen (fall through to ActiveScreen.Update).
+
 	// SSH key dialog messages → UpdateDialog.
 	case ShowDeleteSshKeyDialogMsg:
 		return m.UpdateDialog(msg)
@@ -796,6 +862,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.UpdateDialog(msg)
 	case ShowCreateRouteWithContentDialogMsg:
 		return m.UpdateDialog(msg)
+	case ShowRouteFromBlueprintDialogMsg:
+		return m.UpdateDialog(msg)
 	case ShowCreateAdminRouteWithContentDialogMsg:
 		return m.UpdateDialog(msg)
 	case CreateAdminRouteWithContentRequestMsg:
@@ -846,6 +914,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Content screen: route+content creation flow → UpdateCms.
 	case CreateRouteWithContentRequestMsg:
 		return m.UpdateCms(msg)
+	case InstantiateBlueprintRequestMsg:
+		return m.UpdateCms(msg)
 	case RouteWithContentCreatedMsg:
 		return m.UpdateDialog(msg)
 
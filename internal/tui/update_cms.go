@@ -41,6 +41,8 @@ func (m Model) UpdateCms(msg tea.Msg) (Model, tea.Cmd) {
 		return m, m.HandleUpdateRouteFromDialog(msg)
 	case CreateRouteWithContentRequestMsg:
 		return m, m.HandleCreateRouteWithContent(msg)
+	case InstantiateBlueprintRequestMsg:
+		return m, m.HandleInstantiateBlueprint(msg)
 	case InitializeRouteContentRequestMsg:
 		return m, m.HandleInitializeRouteContent(msg)
 	case CmsEditDatatypeLoadMsg:
@@ -164,6 +166,13 @@ func (m Model) UpdateCms(msg tea.Msg) (Model, tea.Cmd) {
 				ReloadAdminContentTreeCmd(m.Config, types.AdminRouteID(msg.RouteID)),
 			)
 		}
+		if msg.Locale != "" {
+			return m, tea.Batch(
+				LoadingStopCmd(),
+				ShowDialog("Published", fmt.Sprintf("Content published in %s via snapshot.", msg.Locale), false),
+				TranslationsFetchCmd(),
+			)
+		}
 		return m, tea.Batch(
 			LoadingStopCmd(),
 			ShowDialog("Published", "Content published via snapshot.", false),
@@ -297,6 +306,30 @@ func (m Model) UpdateCms(msg tea.Msg) (Model, tea.Cmd) {
 		return m, m.HandleUpdateRole(msg)
 	case DeleteRoleRequestMsg:
 		return m, m.HandleDeleteRole(msg)
+	case CreateUserGroupFromDialogRequestMsg:
+		return m, m.HandleCreateUserGroup(msg)
+	case UpdateUserGroupFromDialogRequestMsg:
+		return m, m.HandleUpdateUserGroup(msg)
+	case DeleteUserGroupRequestMsg:
+		return m, m.HandleDeleteUserGroup(msg)
+	case ToggleUserGroupRoleRequestMsg:
+		return m, m.HandleToggleUserGroupRole(msg)
+	case ToggleUserGroupMemberRequestMsg:
+		return m, m.HandleToggleUserGroupMember(msg)
+	case ToggleUserRoleRequestMsg:
+		return m, m.HandleToggleUserRole(msg)
+	case CreateLocaleFromDialogRequestMsg:
+		return m, m.HandleCreateLocale(msg)
+	case UpdateLocaleFromDialogRequestMsg:
+		return m, m.HandleUpdateLocale(msg)
+	case DeleteLocaleRequestMsg:
+		return m, m.HandleDeleteLocale(msg)
+	case ScaffoldTranslationRequestMsg:
+		return m, m.HandleScaffoldTranslation(msg)
+	case SaveTranslationFieldRequestMsg:
+		return m, m.HandleSaveTranslationField(msg)
+	case MarkTranslationReviewedRequestMsg:
+		return m, m.HandleMarkTranslationReviewed(msg)
 	case UnlinkOauthRequestMsg:
 		return m, m.HandleUnlinkOauth(msg)
 	case CreateMediaDimensionFromDialogRequestMsg:
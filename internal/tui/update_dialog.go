@@ -78,7 +78,16 @@ func (m Model) UpdateDialog(msg tea.Msg) (Model, tea.Cmd) {
 			dialogMsg = fmt.Sprintf("Publish '%s'?\nThis creates a public snapshot.", msg.ContentName)
 			dialogAction = DIALOGPUBLISHCONTENT
 		}
+		if msg.Locale != "" {
+			title += " (" + msg.Locale + ")"
+		}
+		if msg.Diff != "" {
+			dialogMsg += "\n\n" + msg.Diff
+		}
 		dialog := NewDialog(title, dialogMsg, true, dialogAction)
+		if msg.Diff != "" {
+			dialog.Width = versionDiffDialogWidth
+		}
 		if msg.IsPublished {
 			dialog.SetButtons("Unpublish", "Cancel")
 		} else {
@@ -87,6 +96,7 @@ func (m Model) UpdateDialog(msg tea.Msg) (Model, tea.Cmd) {
 		m.DCtx.Active = &PublishContentContext{
 			ContentID: msg.ContentID,
 			RouteID:   msg.RouteID,
+			Locale:    msg.Locale,
 		}
 		return m, tea.Batch(
 			OverlaySetCmd(&dialog),
@@ -350,6 +360,7 @@ func (m Model) UpdateDialog(msg tea.Msg) (Model, tea.Cmd) {
 		)
 		dialog.Action = FORMDIALOGEDIITSINGLEFIELD
 		dialog.Logger = m.Logger
+		dialog.ConfigureEditors(m.DB, false, m.IsSSH)
 		return m, tea.Batch(
 			OverlaySetCmd(&dialog),
 			FocusSetCmd(DIALOGFOCUS),
@@ -776,6 +787,126 @@ func (m Model) UpdateDialog(msg tea.Msg) (Model, tea.Cmd) {
 			FocusSetCmd(PAGEFOCUS),
 		)
 
+	// --- User group dialog messages ---
+	case ShowUserGroupFormDialogMsg:
+		dialog := NewUserGroupFormDialog(msg.Title)
+		return m, tea.Batch(
+			OverlaySetCmd(&dialog),
+			FocusSetCmd(DIALOGFOCUS),
+		)
+	case ShowEditUserGroupDialogMsg:
+		dialog := NewEditUserGroupFormDialog("Edit Group", msg.Group)
+		return m, tea.Batch(
+			OverlaySetCmd(&dialog),
+			FocusSetCmd(DIALOGFOCUS),
+		)
+	case ShowDeleteUserGroupDialogMsg:
+		dialog := NewDialog("Delete Group", fmt.Sprintf("Delete group '%s'?\nMembers lose the roles it grants.", msg.Label), true, DIALOGDELETEUSERGROUP)
+		dialog.SetButtons("Delete", "Cancel")
+		m.DCtx.Active = &DeleteUserGroupContext{
+			UserGroupID: msg.UserGroupID,
+			Label:       msg.Label,
+		}
+		return m, tea.Batch(
+			OverlaySetCmd(&dialog),
+			FocusSetCmd(DIALOGFOCUS),
+		)
+	case UserGroupFormDialogAcceptMsg:
+		switch msg.Action {
+		case FORMDIALOGCREATEUSERGROUP:
+			return m, tea.Batch(
+				OverlayClearCmd(),
+				FocusSetCmd(PAGEFOCUS),
+				LoadingStartCmd(),
+				CreateUserGroupFromDialogCmd(msg.Label, msg.Description),
+			)
+		case FORMDIALOGEDITUSERGROUP:
+			return m, tea.Batch(
+				OverlayClearCmd(),
+				FocusSetCmd(PAGEFOCUS),
+				LoadingStartCmd(),
+				UpdateUserGroupFromDialogCmd(msg.EntityID, msg.Label, msg.Description),
+			)
+		default:
+			return m, tea.Batch(
+				OverlayClearCmd(),
+				FocusSetCmd(PAGEFOCUS),
+			)
+		}
+	case UserGroupFormDialogCancelMsg:
+		return m, tea.Batch(
+			OverlayClearCmd(),
+			FocusSetCmd(PAGEFOCUS),
+		)
+
+	// --- Locale dialog messages ---
+	case ShowLocaleFormDialogMsg:
+		dialog := NewLocaleFormDialog(msg.Title, msg.Locales)
+		return m, tea.Batch(
+			OverlaySetCmd(&dialog),
+			FocusSetCmd(DIALOGFOCUS),
+		)
+	case ShowEditLocaleDialogMsg:
+		dialog := NewEditLocaleFormDialog("Edit Locale", msg.Locale, msg.Locales)
+		return m, tea.Batch(
+			OverlaySetCmd(&dialog),
+			FocusSetCmd(DIALOGFOCUS),
+		)
+	case ShowDeleteLocaleDialogMsg:
+		dialog := NewDialog("Delete Locale", fmt.Sprintf("Delete locale '%s'?\nIts translations stay in the database but are no longer served.", msg.Code), true, DIALOGDELETELOCALE)
+		dialog.SetButtons("Delete", "Cancel")
+		m.DCtx.Active = &DeleteLocaleContext{
+			LocaleID: msg.LocaleID,
+			Code:     msg.Code,
+		}
+		return m, tea.Batch(
+			OverlaySetCmd(&dialog),
+			FocusSetCmd(DIALOGFOCUS),
+		)
+	case LocaleFormDialogAcceptMsg:
+		return m, tea.Batch(
+			OverlayClearCmd(),
+			FocusSetCmd(PAGEFOCUS),
+			LoadingStartCmd(),
+			LocaleFromDialogCmd(msg),
+		)
+	case LocaleFormDialogCancelMsg:
+		return m, tea.Batch(
+			OverlayClearCmd(),
+			FocusSetCmd(PAGEFOCUS),
+		)
+
+	// --- Translation field edit dialog ---
+	case ShowEditTranslationFieldDialogMsg:
+		m.DCtx.Active = &editTranslationFieldCtx{
+			ContentFieldID: msg.Field.ContentFieldID,
+			ContentID:      msg.ContentID,
+			FieldID:        msg.Field.FieldID,
+			RouteID:        msg.RouteID,
+			Locale:         msg.Locale,
+		}
+		dialog := NewEditContentFormDialog(
+			fmt.Sprintf("Translate (%s): %s", msg.Locale, msg.Field.Label),
+			msg.ContentID,
+			types.DatatypeID(msg.DatatypeID.ID),
+			msg.RouteID,
+			[]ExistingContentField{{
+				ContentFieldID: msg.Field.ContentFieldID,
+				FieldID:        msg.Field.FieldID,
+				Label:          msg.Field.Label,
+				Type:           msg.Field.Type,
+				Value:          msg.Field.Value,
+				DataJSON:       msg.Field.DataJSON,
+			}},
+		)
+		dialog.Action = FORMDIALOGEDITTRANSLATION
+		dialog.Logger = m.Logger
+		dialog.ConfigureEditors(m.DB, false, m.IsSSH)
+		return m, tea.Batch(
+			OverlaySetCmd(&dialog),
+			FocusSetCmd(DIALOGFOCUS),
+		)
+
 	// --- User OAuth dialog messages ---
 	case ShowUnlinkOauthDialogMsg:
 		dialog := NewDialog("Unlink OAuth", fmt.Sprintf("Unlink %s OAuth connection?\nThe user will no longer be able to sign in with this provider.", msg.Provider), true, DIALOGUNLINKOAUTH)
@@ -1255,6 +1386,13 @@ func (m Model) UpdateDialog(msg tea.Msg) (Model, tea.Cmd) {
 			OverlaySetCmd(&dialog),
 			FocusSetCmd(DIALOGFOCUS),
 		)
+	case ShowRouteFromBlueprintDialogMsg:
+		// Create route from a blueprint dialog
+		dialog := NewRouteFromBlueprintDialog("New Content from Blueprint", msg.Blueprints)
+		return m, tea.Batch(
+			OverlaySetCmd(&dialog),
+			FocusSetCmd(DIALOGFOCUS),
+		)
 	case ShowCreateAdminRouteWithContentDialogMsg:
 		// Create admin route with initial content dialog
 		dialog := NewAdminRouteWithContentDialog("New Admin Content", FORMDIALOGCREATEADMINROUTEWITHCONTENT, msg.AdminRootDatatypes)
@@ -1315,6 +1453,7 @@ func (m Model) UpdateDialog(msg tea.Msg) (Model, tea.Cmd) {
 			dialog = NewContentFormDialog(msg.Title, msg.Action, msg.DatatypeID, msg.RouteID, msg.Fields)
 		}
 		dialog.Logger = logger
+		dialog.ConfigureEditors(m.DB, false, m.IsSSH)
 		logger.Finfo(fmt.Sprintf("ContentFormDialogModel created with %d fields", len(dialog.Fields)))
 		return m, tea.Batch(
 			OverlaySetCmd(&dialog),
@@ -1337,6 +1476,7 @@ func (m Model) UpdateDialog(msg tea.Msg) (Model, tea.Cmd) {
 		logger.Finfo(fmt.Sprintf("ShowEditContentFormDialogMsg received: ContentID=%s, %d fields", msg.ContentID, len(msg.ExistingFields)))
 		dialog := NewEditContentFormDialog(msg.Title, msg.ContentID, msg.DatatypeID, msg.RouteID, msg.ExistingFields)
 		dialog.Logger = logger
+		dialog.ConfigureEditors(m.DB, false, m.IsSSH)
 		logger.Finfo(fmt.Sprintf("EditContentFormDialogModel created with %d fields", len(dialog.Fields)))
 		return m, tea.Batch(
 			OverlaySetCmd(&dialog),
@@ -63,7 +63,14 @@ func (m Model) handleAdminDialogMsg(msg tea.Msg) (Model, tea.Cmd, bool) {
 			FocusSetCmd(DIALOGFOCUS),
 		), true
 	case ShowRestoreVersionDialogMsg:
-		dialog := NewDialog("restore Version", fmt.Sprintf("restore version %d?\nCurrent field values will be overwritten.", msg.VersionNumber), true, DIALOGRESTOREVERSION)
+		dialogMsg := fmt.Sprintf("restore version %d?\nCurrent field values will be overwritten.", msg.VersionNumber)
+		if msg.Diff != "" {
+			dialogMsg += "\n\n" + msg.Diff
+		}
+		dialog := NewDialog("restore Version", dialogMsg, true, DIALOGRESTOREVERSION)
+		if msg.Diff != "" {
+			dialog.Width = versionDiffDialogWidth
+		}
 		dialog.SetButtons("Restore", "Cancel")
 		m.DCtx.Active = &RestoreVersionContext{
 			ContentID: msg.ContentID,
@@ -164,6 +171,7 @@ func (m Model) handleAdminDialogMsg(msg tea.Msg) (Model, tea.Cmd, bool) {
 		)
 		dialog.Action = FORMDIALOGEDITADMINSINGLEFIELD
 		dialog.Logger = m.Logger
+		dialog.ConfigureEditors(m.DB, true, m.IsSSH)
 		return m, tea.Batch(
 			OverlaySetCmd(&dialog),
 			FocusSetCmd(DIALOGFOCUS),
@@ -219,6 +227,7 @@ func (m Model) handleAdminDialogMsg(msg tea.Msg) (Model, tea.Cmd, bool) {
 			dbFields,
 		)
 		dialog.Logger = m.Logger
+		dialog.ConfigureEditors(m.DB, true, m.IsSSH)
 		return m, tea.Batch(
 			OverlaySetCmd(&dialog),
 			FocusSetCmd(DIALOGFOCUS),
@@ -246,6 +255,7 @@ func (m Model) handleAdminDialogMsg(msg tea.Msg) (Model, tea.Cmd, bool) {
 		)
 		dialog.Action = FORMDIALOGEDITADMINCONTENT
 		dialog.Logger = m.Logger
+		dialog.ConfigureEditors(m.DB, true, m.IsSSH)
 		return m, tea.Batch(
 			OverlaySetCmd(&dialog),
 			FocusSetCmd(DIALOGFOCUS),
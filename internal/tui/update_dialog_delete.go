@@ -529,6 +529,7 @@ func (m Model) handleDialogAccept(msg DialogAcceptMsg) (Model, tea.Cmd) {
 					return ConfirmedPublishMsg{
 						ContentID: ctx.ContentID,
 						RouteID:   ctx.RouteID,
+						Locale:    ctx.Locale,
 					}
 				},
 			)
@@ -1051,6 +1052,36 @@ func (m Model) handleDialogAccept(msg DialogAcceptMsg) (Model, tea.Cmd) {
 			OverlayClearCmd(),
 			FocusSetCmd(PAGEFOCUS),
 		)
+	case DIALOGDELETELOCALE:
+		if ctx, ok := m.DCtx.Active.(*DeleteLocaleContext); ok {
+			localeID := ctx.LocaleID
+			m.DCtx.Active = nil
+			return m, tea.Batch(
+				OverlayClearCmd(),
+				FocusSetCmd(PAGEFOCUS),
+				LoadingStartCmd(),
+				DeleteLocaleCmd(localeID),
+			)
+		}
+		return m, tea.Batch(
+			OverlayClearCmd(),
+			FocusSetCmd(PAGEFOCUS),
+		)
+	case DIALOGDELETEUSERGROUP:
+		if ctx, ok := m.DCtx.Active.(*DeleteUserGroupContext); ok {
+			groupID := ctx.UserGroupID
+			m.DCtx.Active = nil
+			return m, tea.Batch(
+				OverlayClearCmd(),
+				FocusSetCmd(PAGEFOCUS),
+				LoadingStartCmd(),
+				DeleteUserGroupCmd(groupID),
+			)
+		}
+		return m, tea.Batch(
+			OverlayClearCmd(),
+			FocusSetCmd(PAGEFOCUS),
+		)
 	case DIALOGUNLINKOAUTH:
 		if ctx, ok := m.DCtx.Active.(*UnlinkOauthContext); ok {
 			oauthID := ctx.UserOauthID
@@ -620,6 +620,14 @@ func (m Model) handleFormDialogAccept(msg FormDialogAcceptMsg) (Model, tea.Cmd)
 			LoadingStartCmd(),
 			CreateRouteWithContentCmd(msg.Label, msg.Type, msg.ParentID),
 		)
+	case FORMDIALOGCREATEROUTEFROMBLUEPRINT:
+		// Create a new route from a blueprint (ParentID=BlueprintID from carousel, Label=Title, Type=Slug)
+		return m, tea.Batch(
+			OverlayClearCmd(),
+			FocusSetCmd(PAGEFOCUS),
+			LoadingStartCmd(),
+			InstantiateBlueprintCmd(msg.ParentID, msg.Label, msg.Type),
+		)
 	case FORMDIALOGCREATEADMINROUTEWITHCONTENT:
 		// Create a new admin route with initial content (ParentID=AdminDatatypeID from carousel, Label=Title, Type=Slug)
 		return m, tea.Batch(
@@ -901,6 +909,35 @@ func (m Model) handleContentFormDialogAccept(msg ContentFormDialogAcceptMsg) (Mo
 			OverlayClearCmd(),
 			FocusSetCmd(PAGEFOCUS),
 		)
+	case FORMDIALOGEDITTRANSLATION:
+		// Translation edit: use stored context for the target locale row
+		if ctx, ok := m.DCtx.Active.(*editTranslationFieldCtx); ok {
+			m.DCtx.Active = nil
+			var newValue string
+			for _, val := range msg.FieldValues {
+				newValue = val
+				break
+			}
+			return m, tea.Batch(
+				OverlayClearCmd(),
+				FocusSetCmd(PAGEFOCUS),
+				LoadingStartCmd(),
+				func() tea.Msg {
+					return SaveTranslationFieldRequestMsg{
+						ContentFieldID: ctx.ContentFieldID,
+						ContentID:      ctx.ContentID,
+						FieldID:        ctx.FieldID,
+						RouteID:        ctx.RouteID,
+						Locale:         ctx.Locale,
+						Value:          newValue,
+					}
+				},
+			)
+		}
+		return m, tea.Batch(
+			OverlayClearCmd(),
+			FocusSetCmd(PAGEFOCUS),
+		)
 	case FORMDIALOGCREATEADMINCONTENT:
 		// Convert regular types to admin types and forward
 		adminFields := make(map[types.AdminFieldID]string)
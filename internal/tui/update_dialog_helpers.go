@@ -41,6 +41,7 @@ type PublishContentContext struct {
 	ContentID types.ContentID
 	RouteID   types.RouteID
 	AdminMode bool
+	Locale    string // empty publishes the active locale
 }
 
 // RestoreVersionContext stores context for a version restore confirmation dialog.
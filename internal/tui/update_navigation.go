@@ -238,6 +238,27 @@ func (m Model) UpdateNavigation(msg tea.Msg) (Model, tea.Cmd) {
 			cmds = append(cmds, PageSetCmd(page))
 			cmds = append(cmds, StatusSetCmd(OK))
 			return m, tea.Batch(cmds...)
+		case USERGROUPSPAGE:
+			page := m.PageMap[USERGROUPSPAGE]
+			cmds = append(cmds, LoadingStartCmd())
+			cmds = append(cmds, UserGroupsFetchCmd())
+			cmds = append(cmds, PageSetCmd(page))
+			cmds = append(cmds, StatusSetCmd(OK))
+			return m, tea.Batch(cmds...)
+		case LOCALESPAGE:
+			page := m.PageMap[LOCALESPAGE]
+			cmds = append(cmds, LoadingStartCmd())
+			cmds = append(cmds, LocalesFetchCmd())
+			cmds = append(cmds, PageSetCmd(page))
+			cmds = append(cmds, StatusSetCmd(OK))
+			return m, tea.Batch(cmds...)
+		case TRANSLATIONSPAGE:
+			page := m.PageMap[TRANSLATIONSPAGE]
+			cmds = append(cmds, LoadingStartCmd())
+			cmds = append(cmds, TranslationsFetchCmd())
+			cmds = append(cmds, PageSetCmd(page))
+			cmds = append(cmds, StatusSetCmd(OK))
+			return m, tea.Batch(cmds...)
 		case IMPORTPAGE:
 			page := m.PageMap[IMPORTPAGE]
 			cmds = append(cmds, PageSetCmd(page))
@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hegner123/modulacms/internal/db"
+	"github.com/hegner123/modulacms/internal/db/types"
+	"github.com/hegner123/modulacms/internal/publishing"
+	"github.com/hegner123/modulacms/internal/utility"
+)
+
+const (
+	// versionDiffMaxLines caps the changes listed in a confirmation dialog.
+	versionDiffMaxLines = 12
+	// versionDiffContext is how much unchanged text a word diff keeps around
+	// each change.
+	versionDiffContext = 20
+	// versionDiffDialogWidth widens confirmation dialogs that show a diff.
+	versionDiffDialogWidth = 72
+)
+
+// loadVersionDiffSummary diffs two sides of a content tree and renders the
+// result for a confirmation dialog. Returns "" when the diff cannot be built,
+// so the dialog still opens without it.
+func loadVersionDiffSummary(d db.DbDriver, contentID types.ContentID, from, to types.ContentVersionID, locale string) string {
+	if d == nil {
+		return ""
+	}
+	diff, err := publishing.DiffContentVersions(context.Background(), d, contentID, from, to, locale)
+	if err != nil {
+		utility.DefaultLogger.Ferror(fmt.Sprintf("failed to diff versions for %s", contentID), err)
+		return ""
+	}
+	return versionDiffSummary(diff)
+}
+
+// versionDiffSummary renders a diff as plain text lines: node changes first,
+// then field changes with inline [-deleted-]{+inserted+} word markers.
+func versionDiffSummary(diff *publishing.VersionDiff) string {
+	if diff.Empty() {
+		return "No changes."
+	}
+	var lines []string
+	for _, n := range diff.Nodes {
+		name := n.Title
+		if name == "" {
+			name = strings.TrimSpace(n.Datatype + " " + shortID(n.ContentDataID))
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s", changeMarker(n.Change), n.Change, name))
+	}
+	for _, f := range diff.Fields {
+		label := f.Label
+		if f.Locale != "" {
+			label += " (" + f.Locale + ")"
+		}
+		var value string
+		switch {
+		case len(f.Words) > 0:
+			value = wordDiffText(f.Words)
+		case f.Change == publishing.ChangeRemoved:
+			value = "[-" + clipText(f.OldValue, versionDiffContext*2) + "-]"
+		default:
+			value = "{+" + clipText(f.NewValue, versionDiffContext*2) + "+}"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s: %s", changeMarker(f.Change), label, value))
+	}
+	if len(lines) > versionDiffMaxLines {
+		more := len(lines) - versionDiffMaxLines
+		lines = append(lines[:versionDiffMaxLines], fmt.Sprintf("... and %d more", more))
+	}
+	return fmt.Sprintf("Changes (%s -> %s):\n%s", diffSideName(diff.From), diffSideName(diff.To), strings.Join(lines, "\n"))
+}
+
+// wordDiffText renders a word diff on one line, trimming long unchanged runs.
+func wordDiffText(words []publishing.WordDiff) string {
+	var b strings.Builder
+	for i, w := range words {
+		text := strings.Join(strings.Fields(w.Text), " ")
+		switch w.Op {
+		case publishing.WordDelete:
+			b.WriteString("[-" + text + "-]")
+		case publishing.WordInsert:
+			b.WriteString("{+" + text + "+}")
+		default:
+			if w.Text != text {
+				// Keep a separating space where whitespace was collapsed.
+				text = leadingSpace(w.Text) + text + trailingSpace(w.Text)
+			}
+			r := []rune(text)
+			switch {
+			case i == 0 && len(r) > versionDiffContext:
+				text = "..." + string(r[len(r)-versionDiffContext:])
+			case i == len(words)-1 && len(r) > versionDiffContext:
+				text = string(r[:versionDiffContext]) + "..."
+			case len(r) > versionDiffContext*2:
+				text = string(r[:versionDiffContext]) + "..." + string(r[len(r)-versionDiffContext:])
+			}
+			b.WriteString(text)
+		}
+	}
+	return b.String()
+}
+
+func leadingSpace(s string) string {
+	if s != strings.TrimLeft(s, " \t\r\n") {
+		return " "
+	}
+	return ""
+}
+
+func trailingSpace(s string) string {
+	if s != strings.TrimRight(s, " \t\r\n") {
+		return " "
+	}
+	return ""
+}
+
+// clipText collapses whitespace and shortens s to n runes.
+func clipText(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > n {
+		return string(r[:n]) + "..."
+	}
+	return s
+}
+
+func changeMarker(change string) string {
+	switch change {
+	case publishing.ChangeAdded:
+		return "+"
+	case publishing.ChangeRemoved:
+		return "-"
+	case publishing.ChangeMoved:
+		return ">"
+	}
+	return "~"
+}
+
+func diffSideName(side publishing.DiffSide) string {
+	switch {
+	case side.Draft:
+		return "draft"
+	case side.ContentVersionID == "":
+		return "unpublished"
+	}
+	return fmt.Sprintf("v%d", side.VersionNumber)
+}
+
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
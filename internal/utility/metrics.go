@@ -201,4 +201,7 @@ const (
 	MetricActiveConnections = "connections.active"
 	MetricMemoryUsage       = "memory.usage"
 	MetricGoroutines        = "goroutines.count"
+	MetricRateLimitAllowed  = "ratelimit.allowed"
+	MetricRateLimitRejected = "ratelimit.rejected"
+	MetricRateLimitErrors   = "ratelimit.errors"
 )
@@ -10,6 +10,7 @@ const (
 	EventContentScheduled   = "content.scheduled"
 	EventContentDeleted     = "content.deleted"
 	EventLocalePublished    = "locale.published"
+	EventLocaleStale        = "locale.stale"
 	EventVersionCreated     = "version.created"
 
 	// Admin tree mirrors.
@@ -20,6 +21,7 @@ const (
 
 	// System events.
 	EventUpdateAvailable = "update.available"
+	EventBackupFailed    = "backup.failed"
 )
 
 // Payload is the top-level envelope sent to webhook endpoints.
@@ -229,12 +229,14 @@ func TestEventConstants_NoDuplicates(t *testing.T) {
 		EventContentScheduled,
 		EventContentDeleted,
 		EventLocalePublished,
+		EventLocaleStale,
 		EventVersionCreated,
 		EventAdminContentPublished,
 		EventAdminContentUnpublished,
 		EventAdminContentUpdated,
 		EventAdminContentDeleted,
 		EventUpdateAvailable,
+		EventBackupFailed,
 	}
 	seen := make(map[string]bool)
 	for _, e := range events {
@@ -0,0 +1,182 @@
+package modula
+
+import (
+	"context"
+	"net/url"
+)
+
+// BlueprintVariable is a value supplied when a blueprint is instantiated.
+// Field values of the blueprint refer to it as "{{name}}".
+type BlueprintVariable struct {
+	Name     string `json:"name"`
+	Label    string `json:"label,omitempty"`
+	Default  string `json:"default,omitempty"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// BlueprintNode is one content node of a blueprint tree. Datatype is a
+// datatype name and Fields maps field names to values. "{{@key}}" in a value
+// is replaced by the new content ID of the node with that Key.
+type BlueprintNode struct {
+	Key      string            `json:"key,omitempty"`
+	Datatype string            `json:"datatype"`
+	Fields   map[string]string `json:"fields,omitempty"`
+	Children []BlueprintNode   `json:"children,omitempty"`
+}
+
+// BlueprintDefinition is a blueprint as written in a definition file.
+type BlueprintDefinition struct {
+	Name        string              `json:"name"`
+	Label       string              `json:"label"`
+	Description string              `json:"description,omitempty"`
+	Variables   []BlueprintVariable `json:"variables,omitempty"`
+	Root        BlueprintNode       `json:"root"`
+}
+
+// Blueprint is a saved blueprint. Definition is nil in list responses.
+type Blueprint struct {
+	BlueprintID  string               `json:"blueprint_id"`
+	Name         string               `json:"name"`
+	Label        string               `json:"label"`
+	Description  string               `json:"description"`
+	Nodes        int                  `json:"nodes"`
+	Definition   *BlueprintDefinition `json:"definition,omitempty"`
+	AuthorID     UserID               `json:"author_id"`
+	DateCreated  Timestamp            `json:"date_created"`
+	DateModified Timestamp            `json:"date_modified"`
+}
+
+// BlueprintFromContentParams saves the tree under ContentDataID as a
+// blueprint.
+type BlueprintFromContentParams struct {
+	ContentDataID ContentID `json:"content_data_id"`
+	Name          string    `json:"name"`
+	Label         string    `json:"label,omitempty"`
+	Description   string    `json:"description,omitempty"`
+}
+
+// InstantiateBlueprintParams selects where a blueprint's tree is created:
+// under ParentID, at the root of RouteID, or at the root of a new route with
+// Slug and Title.
+type InstantiateBlueprintParams struct {
+	RouteID   *RouteID          `json:"route_id,omitempty"`
+	Slug      string            `json:"slug,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	ParentID  *ContentID        `json:"parent_id,omitempty"`
+	Status    string            `json:"status,omitempty"`
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// BlueprintInstantiation reports the content created from a blueprint. Keys
+// maps the definition's node keys to the new content IDs.
+type BlueprintInstantiation struct {
+	BlueprintID   string               `json:"blueprint_id"`
+	ContentDataID ContentID            `json:"content_data_id"`
+	RouteID       *RouteID             `json:"route_id"`
+	Nodes         int                  `json:"nodes"`
+	Keys          map[string]ContentID `json:"keys,omitempty"`
+}
+
+// BlueprintInstance is a content tree created from a blueprint. Variables is
+// the JSON object of values substituted.
+type BlueprintInstance struct {
+	ContentDataID ContentID `json:"content_data_id"`
+	BlueprintID   string    `json:"blueprint_id"`
+	BlueprintName string    `json:"blueprint_name"`
+	RouteID       *RouteID  `json:"route_id"`
+	Variables     string    `json:"variables"`
+	AuthorID      UserID    `json:"author_id"`
+	DateCreated   Timestamp `json:"date_created"`
+}
+
+// BlueprintsResource manages blueprints: saved content subtrees with
+// placeholder field values that are instantiated as new content trees.
+// Blueprints are addressed by ID or name.
+//
+// Access this resource via [Client].Blueprints:
+//
+//	bp, err := client.Blueprints.FromContent(ctx, modula.BlueprintFromContentParams{
+//		ContentDataID: rootID,
+//		Name:          "landing-page",
+//	})
+//	res, err := client.Blueprints.Instantiate(ctx, "landing-page", modula.InstantiateBlueprintParams{
+//		Slug:      "/spring-sale",
+//		Title:     "Spring sale",
+//		Variables: map[string]string{"title": "Spring sale"},
+//	})
+type BlueprintsResource struct {
+	http *httpClient
+}
+
+// List returns every blueprint without its definition.
+func (r *BlueprintsResource) List(ctx context.Context) ([]Blueprint, error) {
+	var result []Blueprint
+	if err := r.http.get(ctx, "/api/v1/admin/blueprints", nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Get returns a blueprint with its definition.
+func (r *BlueprintsResource) Get(ctx context.Context, idOrName string) (*Blueprint, error) {
+	var result Blueprint
+	if err := r.http.get(ctx, "/api/v1/admin/blueprints/"+url.PathEscape(idOrName), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Create saves a blueprint from a definition.
+func (r *BlueprintsResource) Create(ctx context.Context, def BlueprintDefinition) (*Blueprint, error) {
+	var result Blueprint
+	if err := r.http.post(ctx, "/api/v1/admin/blueprints", def, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FromContent saves an existing content tree as a blueprint.
+func (r *BlueprintsResource) FromContent(ctx context.Context, params BlueprintFromContentParams) (*Blueprint, error) {
+	var result Blueprint
+	if err := r.http.post(ctx, "/api/v1/admin/blueprints/from-content", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Update replaces a blueprint's definition.
+func (r *BlueprintsResource) Update(ctx context.Context, idOrName string, def BlueprintDefinition) (*Blueprint, error) {
+	var result Blueprint
+	if err := r.http.put(ctx, "/api/v1/admin/blueprints/"+url.PathEscape(idOrName), def, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Delete removes a blueprint. Content created from it is kept.
+func (r *BlueprintsResource) Delete(ctx context.Context, idOrName string) error {
+	return r.http.del(ctx, "/api/v1/admin/blueprints/"+url.PathEscape(idOrName), nil)
+}
+
+// Instantiate creates a content tree from a blueprint.
+func (r *BlueprintsResource) Instantiate(ctx context.Context, idOrName string, params InstantiateBlueprintParams) (*BlueprintInstantiation, error) {
+	var result BlueprintInstantiation
+	if err := r.http.post(ctx, "/api/v1/admin/blueprints/"+url.PathEscape(idOrName)+"/instantiate", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Instances lists the content trees created from a blueprint, or from any
+// blueprint when idOrName is empty.
+func (r *BlueprintsResource) Instances(ctx context.Context, idOrName string) ([]BlueprintInstance, error) {
+	params := url.Values{}
+	if idOrName != "" {
+		params.Set("blueprint", idOrName)
+	}
+	var result []BlueprintInstance
+	if err := r.http.get(ctx, "/api/v1/admin/blueprints/instances", params, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -69,14 +69,15 @@ type ContentMoveResponse struct {
 	Position int `json:"position"`
 }
 
-// ContentReorderResource provides content tree reorder and move operations for
+// ContentReorderResource provides content tree reorder, move and clone operations for
 // public content data nodes. These operations manipulate the sibling-pointer
 // linked list (next_sibling_id, prev_sibling_id) and parent-child pointers
 // (parent_id, first_child_id) that define the content tree's ordering.
 //
 // Use [ContentReorderResource.Reorder] to change the display order of siblings
 // under a common parent, and [ContentReorderResource.Move] to relocate a node
-// to a different parent and position.
+// to a different parent and position. [ContentReorderResource.Clone] copies a
+// whole tree.
 //
 // Access this resource via [Client].ContentReorder:
 //
@@ -121,6 +122,67 @@ func (r *ContentReorderResource) Move(ctx context.Context, req ContentMoveReques
 	return &result, nil
 }
 
+// ContentCloneRequest is the JSON body for POST /api/v1/contentdata/clone.
+//
+// It deep-copies the tree under SourceID with new IDs. The copy is appended
+// under ParentID when set, else it becomes the root of RouteID, which must
+// have no content; with neither it is appended under the source's parent.
+type ContentCloneRequest struct {
+	// SourceID is the root of the tree to copy.
+	SourceID ContentID `json:"source_id"`
+
+	// ParentID is the node to append the copy under.
+	ParentID *ContentID `json:"parent_id,omitempty"`
+
+	// RouteID is an empty route the copy becomes the root content of.
+	RouteID *RouteID `json:"route_id,omitempty"`
+
+	// Locale, when set, makes a copy in that locale: translatable fields get a
+	// row in Locale holding the SourceLocale value (default: the default
+	// locale), and rows of other locales are not copied.
+	Locale string `json:"locale,omitempty"`
+
+	// SourceLocale is the locale translatable values are read from. Requires
+	// Locale.
+	SourceLocale string `json:"source_locale,omitempty"`
+
+	// Status is the status of the copies. Defaults to draft.
+	Status ContentStatus `json:"status,omitempty"`
+}
+
+// ContentCloneResponse is the JSON response from POST /api/v1/contentdata/clone.
+type ContentCloneResponse struct {
+	// ContentDataID is the root of the copy.
+	ContentDataID ContentID `json:"content_data_id"`
+
+	// RouteID is the route the copy belongs to. Nil for route-less trees.
+	RouteID *RouteID `json:"route_id"`
+
+	// Nodes, Fields and Relations count the rows created.
+	Nodes     int `json:"nodes"`
+	Fields    int `json:"fields"`
+	Relations int `json:"relations"`
+
+	// IDs maps each source node to its copy.
+	IDs map[ContentID]ContentID `json:"ids"`
+}
+
+// Clone deep-copies a content tree in a single audited transaction. Sibling
+// order is kept, and _id field values and content relations that point
+// inside the tree are rewritten to the copies; references to content outside
+// the tree are kept.
+//
+// Returns an [*ApiError] if the source does not exist, the target route
+// already has content, the locale is not enabled, or the authenticated user
+// lacks the content:create permission.
+func (r *ContentReorderResource) Clone(ctx context.Context, req ContentCloneRequest) (*ContentCloneResponse, error) {
+	var result ContentCloneResponse
+	if err := r.http.post(ctx, "/api/v1/contentdata/clone", req, &result); err != nil {
+		return nil, fmt.Errorf("clone content: %w", err)
+	}
+	return &result, nil
+}
+
 // AdminContentReorderRequest is the JSON body for POST /api/v1/admincontentdatas/reorder.
 //
 // It is the admin-content equivalent of [ContentReorderRequest], operating on
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strings"
 )
 
 // LocaleResource provides CRUD operations for locales and translation management.
@@ -66,3 +67,21 @@ func (r *LocaleResource) CreateAdminTranslation(ctx context.Context, adminConten
 	}
 	return &resp, nil
 }
+
+// TranslationStatus reports which translatable fields are missing, stale, current
+// or untracked in a non-default locale, across all content or one route.
+func (r *LocaleResource) TranslationStatus(ctx context.Context, p TranslationStatusParams) (*TranslationStatusReport, error) {
+	params := url.Values{}
+	params.Set("locale", p.Locale)
+	if p.RouteID != "" {
+		params.Set("route_id", string(p.RouteID))
+	}
+	if len(p.Statuses) > 0 {
+		params.Set("status", strings.Join(p.Statuses, ","))
+	}
+	var resp TranslationStatusReport
+	if err := r.http.get(ctx, "/api/v1/admin/translations/status", params, &resp); err != nil {
+		return nil, fmt.Errorf("translation status for %s: %w", p.Locale, err)
+	}
+	return &resp, nil
+}
@@ -207,6 +207,9 @@ type Client struct {
 	// ContentHeal provides content tree integrity repair operations.
 	ContentHeal *ContentHealResource
 
+	// Blueprints provides reusable content subtrees and their instantiation.
+	Blueprints *BlueprintsResource
+
 	// Deploy provides content synchronization between environments (push/pull).
 	Deploy *DeployResource
 
@@ -449,6 +452,7 @@ func NewClient(cfg ClientConfig) (*Client, error) {
 		ContentBatch: &ContentBatchResource{http: h},
 		ContentTree:  &ContentTreeResource{http: h},
 		ContentHeal:  &ContentHealResource{http: h},
+		Blueprints:   &BlueprintsResource{http: h},
 		Deploy:       &DeployResource{http: h},
 
 		// RBAC
@@ -167,6 +167,29 @@ func (p *PublishingResource) Restore(ctx context.Context, req RestoreRequest) (*
 	return &resp, nil
 }
 
+// DiffVersions compares two version snapshots of a content tree, or a version
+// against the live draft. Leave From empty to compare from the published
+// version, and leave To empty to compare to the draft. Either side may be
+// "draft". Use it to review
+// changes before [PublishingResource.Restore] or [PublishingResource.Publish].
+func (p *PublishingResource) DiffVersions(ctx context.Context, req DiffVersionsRequest) (*VersionDiff, error) {
+	params := url.Values{"content_id": {string(req.ContentDataID)}}
+	if req.From != "" {
+		params.Set("from", string(req.From))
+	}
+	if req.To != "" {
+		params.Set("to", string(req.To))
+	}
+	if req.Locale != "" {
+		params.Set("locale", req.Locale)
+	}
+	var diff VersionDiff
+	if err := p.http.get(ctx, "/api/v1/"+p.prefix+"/versions/diff", params, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
 // AdminRestore restores admin content to a previous version snapshot.
 // This is the admin-content equivalent of [PublishingResource.Restore].
 func (p *PublishingResource) AdminRestore(ctx context.Context, req AdminRestoreRequest) (*AdminRestoreResponse, error) {
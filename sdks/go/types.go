@@ -1292,6 +1292,68 @@ type RestoreResponse struct {
 	UnmappedFields  []string `json:"unmapped_fields,omitempty"`
 }
 
+// DiffVersionsRequest selects the two sides of a version diff. Either side may
+// be "draft" for the live draft. An empty From selects the published version;
+// an empty To selects the draft. Locale applies when neither side is a version.
+type DiffVersionsRequest struct {
+	ContentDataID ContentID        `json:"content_data_id"`
+	From          ContentVersionID `json:"from,omitempty"`
+	To            ContentVersionID `json:"to,omitempty"`
+	Locale        string           `json:"locale,omitempty"`
+}
+
+// VersionDiff is the difference between two snapshots of a content tree.
+// Nodes lists structural changes and Fields lists value changes.
+type VersionDiff struct {
+	ContentDataID string      `json:"content_data_id"`
+	From          DiffSide    `json:"from"`
+	To            DiffSide    `json:"to"`
+	Nodes         []NodeDiff  `json:"nodes"`
+	Fields        []FieldDiff `json:"fields"`
+}
+
+// DiffSide describes one side of a [VersionDiff]: a stored version, or the
+// live draft when Draft is true.
+type DiffSide struct {
+	ContentVersionID string `json:"content_version_id,omitempty"`
+	VersionNumber    int64  `json:"version_number,omitempty"`
+	Label            string `json:"label,omitempty"`
+	Published        bool   `json:"published,omitempty"`
+	Draft            bool   `json:"draft,omitempty"`
+	Locale           string `json:"locale"`
+}
+
+// NodeDiff is a structural change to one content node. Change is "added",
+// "removed", or "moved". A moved node changed parent or sibling position.
+type NodeDiff struct {
+	ContentDataID string `json:"content_data_id"`
+	Datatype      string `json:"datatype"`
+	Title         string `json:"title,omitempty"`
+	Change        string `json:"change"`
+	OldParentID   string `json:"old_parent_id,omitempty"`
+	NewParentID   string `json:"new_parent_id,omitempty"`
+}
+
+// FieldDiff is a change to one field value. Change is "added", "removed", or
+// "modified". Words holds a word-level diff for text and richtext fields.
+type FieldDiff struct {
+	ContentDataID string     `json:"content_data_id"`
+	FieldID       string     `json:"field_id"`
+	Label         string     `json:"label"`
+	Type          string     `json:"type"`
+	Locale        string     `json:"locale,omitempty"`
+	Change        string     `json:"change"`
+	OldValue      string     `json:"old_value,omitempty"`
+	NewValue      string     `json:"new_value,omitempty"`
+	Words         []WordDiff `json:"words,omitempty"`
+}
+
+// WordDiff is one run of a word-level diff. Op is "equal", "insert", or "delete".
+type WordDiff struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
 // AdminRestoreResponse is returned after restoring admin content to a previous version.
 // Mirrors RestoreResponse but references admin content IDs.
 type AdminRestoreResponse struct {
@@ -1435,6 +1497,42 @@ type CreateTranslationResponse struct {
 	FieldsCreated int    `json:"fields_created"`
 }
 
+// TranslationStatusParams selects the content reported by
+// [LocaleResource.TranslationStatus]. RouteID limits the report to one route;
+// Statuses limits the listed fields to the given statuses ("missing", "stale",
+// "current", "untracked") without changing the counts.
+type TranslationStatusParams struct {
+	Locale   string
+	RouteID  RouteID
+	Statuses []string
+}
+
+// FieldTranslationStatus is the status of one translatable field of a content
+// node in a locale. ContentFieldID is empty for missing fields. TranslatedAt is
+// when the translation's source was recorded, in Unix seconds.
+type FieldTranslationStatus struct {
+	ContentDataID  ContentID      `json:"content_data_id"`
+	RouteID        *RouteID       `json:"route_id"`
+	FieldID        FieldID        `json:"field_id"`
+	Label          string         `json:"label"`
+	Required       bool           `json:"required"`
+	ContentFieldID ContentFieldID `json:"content_field_id,omitempty"`
+	Status         string         `json:"status"`
+	TranslatedAt   int64          `json:"translated_at,omitempty"`
+}
+
+// TranslationStatusReport counts the translatable fields of the selected
+// content by status in one locale and lists them. A translation is stale when
+// the default-locale value changed after it was written.
+type TranslationStatusReport struct {
+	Locale    string                   `json:"locale"`
+	Missing   int                      `json:"missing"`
+	Stale     int                      `json:"stale"`
+	Current   int                      `json:"current"`
+	Untracked int                      `json:"untracked"`
+	Fields    []FieldTranslationStatus `json:"fields"`
+}
+
 // ---------------------------------------------------------------------------
 // Content Composite (create with fields)
 // ---------------------------------------------------------------------------
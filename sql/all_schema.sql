@@ -781,6 +781,188 @@ CREATE TRIGGER IF NOT EXISTS update_users_modified
     END;
 
 
+-- ===== 42_user_roles =====
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    id TEXT PRIMARY KEY NOT NULL CHECK (length(id) = 26),
+    user_id TEXT NOT NULL REFERENCES users ON DELETE CASCADE,
+    role_id TEXT NOT NULL REFERENCES roles ON DELETE CASCADE,
+    UNIQUE(user_id, role_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_user ON user_roles(user_id);
+CREATE INDEX IF NOT EXISTS idx_user_roles_role ON user_roles(role_id);
+
+-- ===== 43_user_groups =====
+
+CREATE TABLE IF NOT EXISTS user_groups (
+    user_group_id TEXT PRIMARY KEY NOT NULL CHECK (length(user_group_id) = 26),
+    label         TEXT NOT NULL UNIQUE,
+    description   TEXT NOT NULL DEFAULT '',
+    date_created  TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    date_modified TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ===== 44_user_group_members =====
+
+CREATE TABLE IF NOT EXISTS user_group_members (
+    id TEXT PRIMARY KEY NOT NULL CHECK (length(id) = 26),
+    user_group_id TEXT NOT NULL REFERENCES user_groups ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users ON DELETE CASCADE,
+    UNIQUE(user_group_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_group_members_group ON user_group_members(user_group_id);
+CREATE INDEX IF NOT EXISTS idx_user_group_members_user ON user_group_members(user_id);
+
+-- ===== 45_user_group_roles =====
+
+CREATE TABLE IF NOT EXISTS user_group_roles (
+    id TEXT PRIMARY KEY NOT NULL CHECK (length(id) = 26),
+    user_group_id TEXT NOT NULL REFERENCES user_groups ON DELETE CASCADE,
+    role_id TEXT NOT NULL REFERENCES roles ON DELETE CASCADE,
+    UNIQUE(user_group_id, role_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_group_roles_group ON user_group_roles(user_group_id);
+CREATE INDEX IF NOT EXISTS idx_user_group_roles_role ON user_group_roles(role_id);
+
+-- ===== 46_user_lockouts =====
+
+CREATE TABLE IF NOT EXISTS user_lockouts (
+    user_id TEXT PRIMARY KEY NOT NULL CHECK (length(user_id) = 26)
+        REFERENCES users ON DELETE CASCADE,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    lockout_count INTEGER NOT NULL DEFAULT 0,
+    locked_until TEXT,
+    last_failed_at TEXT,
+    date_modified TEXT NOT NULL
+);
+
+-- ===== 47_password_history =====
+
+CREATE TABLE IF NOT EXISTS password_history (
+    id TEXT PRIMARY KEY NOT NULL CHECK (length(id) = 26),
+    user_id TEXT NOT NULL REFERENCES users ON DELETE CASCADE,
+    hash TEXT NOT NULL,
+    date_created TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_history_user ON password_history(user_id);
+
+-- ===== 48_rate_limit_counters =====
+
+CREATE TABLE IF NOT EXISTS rate_limit_counters (
+    limiter_key TEXT PRIMARY KEY NOT NULL,
+    window_start INTEGER NOT NULL,
+    hits INTEGER NOT NULL DEFAULT 0,
+    expires_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_counters_expires ON rate_limit_counters(expires_at);
+
+-- ===== 49_content_migrations =====
+
+CREATE TABLE IF NOT EXISTS content_migrations (
+    migration_id TEXT PRIMARY KEY NOT NULL,
+    source TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    rows_scanned INTEGER NOT NULL DEFAULT 0,
+    rows_changed INTEGER NOT NULL DEFAULT 0,
+    last_content_field_id TEXT NOT NULL DEFAULT '',
+    completed INTEGER NOT NULL DEFAULT 0,
+    author_id TEXT NOT NULL,
+    date_created TEXT NOT NULL
+);
+
+-- ===== 50_content_leases =====
+
+CREATE TABLE IF NOT EXISTS content_leases (
+    lease_id TEXT PRIMARY KEY NOT NULL,
+    content_data_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    username TEXT NOT NULL DEFAULT '',
+    client TEXT NOT NULL DEFAULT '',
+    mode TEXT NOT NULL,
+    acquired_at INTEGER NOT NULL,
+    expires_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_leases_content ON content_leases(content_data_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_content_leases_editing ON content_leases(content_data_id) WHERE mode = 'editing';
+
+-- ===== 51_translation_memory =====
+
+CREATE TABLE IF NOT EXISTS translation_memory (
+    tm_id TEXT PRIMARY KEY NOT NULL,
+    source_locale TEXT NOT NULL,
+    target_locale TEXT NOT NULL,
+    source_hash TEXT NOT NULL,
+    source_text TEXT NOT NULL,
+    target_text TEXT NOT NULL,
+    source_length INTEGER NOT NULL,
+    content_data_id TEXT NOT NULL,
+    field_id TEXT NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_translation_memory_source ON translation_memory(source_locale, target_locale, source_hash);
+CREATE INDEX IF NOT EXISTS idx_translation_memory_length ON translation_memory(source_locale, target_locale, source_length);
+
+-- ===== 52_translation_reviews =====
+
+CREATE TABLE IF NOT EXISTS translation_reviews (
+    content_field_id TEXT PRIMARY KEY NOT NULL,
+    content_data_id TEXT NOT NULL,
+    locale TEXT NOT NULL,
+    origin TEXT NOT NULL,
+    provider TEXT NOT NULL DEFAULT '',
+    score INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_translation_reviews_locale ON translation_reviews(locale);
+
+-- ===== 53_translation_sources =====
+
+CREATE TABLE IF NOT EXISTS translation_sources (
+    content_field_id TEXT PRIMARY KEY NOT NULL,
+    content_data_id TEXT NOT NULL,
+    field_id TEXT NOT NULL,
+    locale TEXT NOT NULL,
+    source_hash TEXT NOT NULL,
+    recorded_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_translation_sources_field ON translation_sources(content_data_id, field_id);
+CREATE INDEX IF NOT EXISTS idx_translation_sources_locale ON translation_sources(locale);
+
+-- ===== 54_blueprints =====
+
+CREATE TABLE IF NOT EXISTS blueprints (
+    blueprint_id TEXT PRIMARY KEY NOT NULL,
+    name TEXT NOT NULL UNIQUE,
+    label TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    definition TEXT NOT NULL,
+    author_id TEXT NOT NULL,
+    date_created TEXT NOT NULL,
+    date_modified TEXT NOT NULL
+);
+
+-- ===== 55_blueprint_instances =====
+
+CREATE TABLE IF NOT EXISTS blueprint_instances (
+    content_data_id TEXT PRIMARY KEY NOT NULL,
+    blueprint_id TEXT NOT NULL,
+    route_id TEXT,
+    variables TEXT NOT NULL DEFAULT '{}',
+    author_id TEXT NOT NULL,
+    date_created TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_blueprint_instances_blueprint ON blueprint_instances(blueprint_id);
+
 -- ===== 5_admin_routes =====
 
 CREATE TABLE IF NOT EXISTS admin_routes (
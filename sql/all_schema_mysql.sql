@@ -784,6 +784,212 @@ CREATE TABLE IF NOT EXISTS users (
             ON UPDATE CASCADE ON DELETE RESTRICT
 );
 
+-- ===== 42_user_roles =====
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    id VARCHAR(26) NOT NULL,
+    user_id VARCHAR(26) NOT NULL,
+    role_id VARCHAR(26) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_ur_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT fk_ur_role FOREIGN KEY (role_id) REFERENCES roles(role_id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_role UNIQUE (user_id, role_id)
+);
+
+CREATE INDEX idx_user_roles_user ON user_roles(user_id);
+CREATE INDEX idx_user_roles_role ON user_roles(role_id);
+
+-- ===== 43_user_groups =====
+
+CREATE TABLE IF NOT EXISTS user_groups (
+    user_group_id VARCHAR(26) PRIMARY KEY NOT NULL,
+    label         VARCHAR(255) NOT NULL,
+    description   TEXT NOT NULL,
+    date_created  TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    date_modified TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT uq_user_group_label
+        UNIQUE (label)
+);
+
+-- ===== 44_user_group_members =====
+
+CREATE TABLE IF NOT EXISTS user_group_members (
+    id VARCHAR(26) NOT NULL,
+    user_group_id VARCHAR(26) NOT NULL,
+    user_id VARCHAR(26) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_ugm_group FOREIGN KEY (user_group_id) REFERENCES user_groups(user_group_id) ON DELETE CASCADE,
+    CONSTRAINT fk_ugm_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_group_member UNIQUE (user_group_id, user_id)
+);
+
+CREATE INDEX idx_user_group_members_group ON user_group_members(user_group_id);
+CREATE INDEX idx_user_group_members_user ON user_group_members(user_id);
+
+-- ===== 45_user_group_roles =====
+
+CREATE TABLE IF NOT EXISTS user_group_roles (
+    id VARCHAR(26) NOT NULL,
+    user_group_id VARCHAR(26) NOT NULL,
+    role_id VARCHAR(26) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_ugr_group FOREIGN KEY (user_group_id) REFERENCES user_groups(user_group_id) ON DELETE CASCADE,
+    CONSTRAINT fk_ugr_role FOREIGN KEY (role_id) REFERENCES roles(role_id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_group_role UNIQUE (user_group_id, role_id)
+);
+
+CREATE INDEX idx_user_group_roles_group ON user_group_roles(user_group_id);
+CREATE INDEX idx_user_group_roles_role ON user_group_roles(role_id);
+
+-- ===== 46_user_lockouts =====
+
+CREATE TABLE IF NOT EXISTS user_lockouts (
+    user_id VARCHAR(26) NOT NULL,
+    failed_attempts BIGINT NOT NULL DEFAULT 0,
+    lockout_count BIGINT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NULL,
+    last_failed_at TIMESTAMP NULL,
+    date_modified TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id),
+    CONSTRAINT fk_user_lockouts_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+-- ===== 47_password_history =====
+
+CREATE TABLE IF NOT EXISTS password_history (
+    id VARCHAR(26) NOT NULL,
+    user_id VARCHAR(26) NOT NULL,
+    hash TEXT NOT NULL,
+    date_created TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_password_history_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_password_history_user ON password_history(user_id);
+
+-- ===== 48_rate_limit_counters =====
+
+CREATE TABLE IF NOT EXISTS rate_limit_counters (
+    limiter_key VARCHAR(255) NOT NULL,
+    window_start BIGINT NOT NULL,
+    hits BIGINT NOT NULL DEFAULT 0,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (limiter_key)
+);
+
+CREATE INDEX idx_rate_limit_counters_expires ON rate_limit_counters(expires_at);
+
+-- ===== 49_content_migrations =====
+
+CREATE TABLE IF NOT EXISTS content_migrations (
+    migration_id VARCHAR(255) NOT NULL,
+    source VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    rows_scanned BIGINT NOT NULL DEFAULT 0,
+    rows_changed BIGINT NOT NULL DEFAULT 0,
+    last_content_field_id VARCHAR(26) NOT NULL DEFAULT '',
+    completed TINYINT NOT NULL DEFAULT 0,
+    author_id VARCHAR(26) NOT NULL,
+    date_created TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (migration_id)
+);
+
+-- ===== 50_content_leases =====
+
+CREATE TABLE IF NOT EXISTS content_leases (
+    lease_id VARCHAR(26) NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    user_id VARCHAR(26) NOT NULL,
+    username VARCHAR(255) NOT NULL DEFAULT '',
+    client VARCHAR(32) NOT NULL DEFAULT '',
+    mode VARCHAR(16) NOT NULL,
+    acquired_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (lease_id)
+);
+
+CREATE INDEX idx_content_leases_content ON content_leases(content_data_id);
+CREATE UNIQUE INDEX idx_content_leases_editing ON content_leases((CASE WHEN mode = 'editing' THEN content_data_id END));
+
+-- ===== 51_translation_memory =====
+
+CREATE TABLE IF NOT EXISTS translation_memory (
+    tm_id VARCHAR(26) NOT NULL,
+    source_locale VARCHAR(35) NOT NULL,
+    target_locale VARCHAR(35) NOT NULL,
+    source_hash VARCHAR(64) NOT NULL,
+    source_text LONGTEXT NOT NULL,
+    target_text LONGTEXT NOT NULL,
+    source_length BIGINT NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    field_id VARCHAR(26) NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (tm_id)
+);
+
+CREATE UNIQUE INDEX idx_translation_memory_source ON translation_memory(source_locale, target_locale, source_hash);
+CREATE INDEX idx_translation_memory_length ON translation_memory(source_locale, target_locale, source_length);
+
+-- ===== 52_translation_reviews =====
+
+CREATE TABLE IF NOT EXISTS translation_reviews (
+    content_field_id VARCHAR(26) NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    origin VARCHAR(16) NOT NULL,
+    provider VARCHAR(255) NOT NULL DEFAULT '',
+    score BIGINT NOT NULL DEFAULT 0,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (content_field_id)
+);
+
+CREATE INDEX idx_translation_reviews_locale ON translation_reviews(locale);
+
+-- ===== 53_translation_sources =====
+
+CREATE TABLE IF NOT EXISTS translation_sources (
+    content_field_id VARCHAR(26) NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    field_id VARCHAR(26) NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    source_hash VARCHAR(64) NOT NULL,
+    recorded_at BIGINT NOT NULL,
+    PRIMARY KEY (content_field_id)
+);
+
+CREATE INDEX idx_translation_sources_field ON translation_sources(content_data_id, field_id);
+CREATE INDEX idx_translation_sources_locale ON translation_sources(locale);
+
+-- ===== 54_blueprints =====
+
+CREATE TABLE IF NOT EXISTS blueprints (
+    blueprint_id VARCHAR(26) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    label VARCHAR(255) NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    definition MEDIUMTEXT NOT NULL,
+    author_id VARCHAR(26) NOT NULL,
+    date_created TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    date_modified TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (blueprint_id),
+    CONSTRAINT name
+        UNIQUE (name)
+);
+
+-- ===== 55_blueprint_instances =====
+
+CREATE TABLE IF NOT EXISTS blueprint_instances (
+    content_data_id VARCHAR(26) NOT NULL,
+    blueprint_id VARCHAR(26) NOT NULL,
+    route_id VARCHAR(26) NULL,
+    variables TEXT NOT NULL,
+    author_id VARCHAR(26) NOT NULL,
+    date_created TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (content_data_id)
+);
+
+CREATE INDEX idx_blueprint_instances_blueprint ON blueprint_instances(blueprint_id);
+
 -- ===== 5_admin_routes =====
 
 CREATE TABLE IF NOT EXISTS admin_routes (
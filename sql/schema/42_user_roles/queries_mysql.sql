@@ -0,0 +1,43 @@
+-- name: CreateUserRolesTable :exec
+CREATE TABLE IF NOT EXISTS user_roles (
+    id VARCHAR(26) NOT NULL,
+    user_id VARCHAR(26) NOT NULL,
+    role_id VARCHAR(26) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_ur_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT fk_ur_role FOREIGN KEY (role_id) REFERENCES roles(role_id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_role UNIQUE (user_id, role_id)
+);
+
+-- name: CreateUserRolesIndexUser :exec
+CREATE INDEX idx_user_roles_user ON user_roles(user_id);
+
+-- name: CreateUserRolesIndexRole :exec
+CREATE INDEX idx_user_roles_role ON user_roles(role_id);
+
+-- name: DropUserRolesTable :exec
+DROP TABLE IF EXISTS user_roles;
+
+-- name: GetUserRole :one
+SELECT * FROM user_roles WHERE id = ? LIMIT 1;
+
+-- name: ListUserRole :many
+SELECT * FROM user_roles ORDER BY id;
+
+-- name: ListUserRoleByUserID :many
+SELECT * FROM user_roles WHERE user_id = ? ORDER BY id;
+
+-- name: ListUserRoleByRoleID :many
+SELECT * FROM user_roles WHERE role_id = ? ORDER BY id;
+
+-- name: CreateUserRole :exec
+INSERT INTO user_roles (id, user_id, role_id) VALUES (?, ?, ?);
+
+-- name: DeleteUserRole :exec
+DELETE FROM user_roles WHERE id = ?;
+
+-- name: DeleteUserRoleByUserID :exec
+DELETE FROM user_roles WHERE user_id = ?;
+
+-- name: CountUserRole :one
+SELECT COUNT(*) FROM user_roles;
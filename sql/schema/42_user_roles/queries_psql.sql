@@ -0,0 +1,40 @@
+-- name: CreateUserRolesTable :exec
+CREATE TABLE IF NOT EXISTS user_roles (
+    id TEXT PRIMARY KEY NOT NULL CHECK (length(id) = 26),
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    role_id TEXT NOT NULL REFERENCES roles(role_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    UNIQUE(user_id, role_id)
+);
+
+-- name: CreateUserRolesIndexUser :exec
+CREATE INDEX IF NOT EXISTS idx_user_roles_user ON user_roles(user_id);
+
+-- name: CreateUserRolesIndexRole :exec
+CREATE INDEX IF NOT EXISTS idx_user_roles_role ON user_roles(role_id);
+
+-- name: DropUserRolesTable :exec
+DROP TABLE IF EXISTS user_roles;
+
+-- name: GetUserRole :one
+SELECT * FROM user_roles WHERE id = $1 LIMIT 1;
+
+-- name: ListUserRole :many
+SELECT * FROM user_roles ORDER BY id;
+
+-- name: ListUserRoleByUserID :many
+SELECT * FROM user_roles WHERE user_id = $1 ORDER BY id;
+
+-- name: ListUserRoleByRoleID :many
+SELECT * FROM user_roles WHERE role_id = $1 ORDER BY id;
+
+-- name: CreateUserRole :one
+INSERT INTO user_roles (id, user_id, role_id) VALUES ($1, $2, $3) RETURNING *;
+
+-- name: DeleteUserRole :exec
+DELETE FROM user_roles WHERE id = $1;
+
+-- name: DeleteUserRoleByUserID :exec
+DELETE FROM user_roles WHERE user_id = $1;
+
+-- name: CountUserRole :one
+SELECT COUNT(*) FROM user_roles;
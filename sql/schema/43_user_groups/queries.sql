@@ -0,0 +1,54 @@
+-- name: DropUserGroupTable :exec
+DROP TABLE IF EXISTS user_groups;
+
+-- name: CreateUserGroupTable :exec
+CREATE TABLE IF NOT EXISTS user_groups (
+    user_group_id TEXT PRIMARY KEY NOT NULL CHECK (length(user_group_id) = 26),
+    label         TEXT NOT NULL UNIQUE,
+    description   TEXT NOT NULL DEFAULT '',
+    date_created  TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    date_modified TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- name: CountUserGroup :one
+SELECT COUNT(*)
+FROM user_groups;
+
+-- name: GetUserGroup :one
+SELECT * FROM user_groups
+WHERE user_group_id = ? LIMIT 1;
+
+-- name: GetUserGroupByLabel :one
+SELECT * FROM user_groups
+WHERE label = ? LIMIT 1;
+
+-- name: ListUserGroup :many
+SELECT * FROM user_groups
+ORDER BY label;
+
+-- name: CreateUserGroup :one
+INSERT INTO user_groups (
+    user_group_id,
+    label,
+    description,
+    date_created,
+    date_modified
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+) RETURNING *;
+
+-- name: UpdateUserGroup :exec
+UPDATE user_groups
+SET label = ?,
+    description = ?,
+    date_created = ?,
+    date_modified = ?
+WHERE user_group_id = ?;
+
+-- name: DeleteUserGroup :exec
+DELETE FROM user_groups
+WHERE user_group_id = ?;
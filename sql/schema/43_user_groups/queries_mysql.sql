@@ -0,0 +1,56 @@
+-- name: DropUserGroupTable :exec
+DROP TABLE IF EXISTS user_groups;
+
+-- name: CreateUserGroupTable :exec
+CREATE TABLE IF NOT EXISTS user_groups (
+    user_group_id VARCHAR(26) PRIMARY KEY NOT NULL,
+    label         VARCHAR(255) NOT NULL,
+    description   TEXT NOT NULL,
+    date_created  TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    date_modified TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT uq_user_group_label
+        UNIQUE (label)
+);
+
+-- name: CountUserGroup :one
+SELECT COUNT(*)
+FROM user_groups;
+
+-- name: GetUserGroup :one
+SELECT * FROM user_groups
+WHERE user_group_id = ? LIMIT 1;
+
+-- name: GetUserGroupByLabel :one
+SELECT * FROM user_groups
+WHERE label = ? LIMIT 1;
+
+-- name: ListUserGroup :many
+SELECT * FROM user_groups
+ORDER BY label;
+
+-- name: CreateUserGroup :exec
+INSERT INTO user_groups (
+    user_group_id,
+    label,
+    description,
+    date_created,
+    date_modified
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+);
+
+-- name: UpdateUserGroup :exec
+UPDATE user_groups
+SET label = ?,
+    description = ?,
+    date_created = ?,
+    date_modified = ?
+WHERE user_group_id = ?;
+
+-- name: DeleteUserGroup :exec
+DELETE FROM user_groups
+WHERE user_group_id = ?;
@@ -0,0 +1,54 @@
+-- name: DropUserGroupTable :exec
+DROP TABLE IF EXISTS user_groups;
+
+-- name: CreateUserGroupTable :exec
+CREATE TABLE IF NOT EXISTS user_groups (
+    user_group_id TEXT PRIMARY KEY NOT NULL,
+    label         TEXT NOT NULL UNIQUE,
+    description   TEXT NOT NULL DEFAULT '',
+    date_created  TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    date_modified TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- name: CountUserGroup :one
+SELECT COUNT(*)
+FROM user_groups;
+
+-- name: GetUserGroup :one
+SELECT * FROM user_groups
+WHERE user_group_id = $1 LIMIT 1;
+
+-- name: GetUserGroupByLabel :one
+SELECT * FROM user_groups
+WHERE label = $1 LIMIT 1;
+
+-- name: ListUserGroup :many
+SELECT * FROM user_groups
+ORDER BY label;
+
+-- name: CreateUserGroup :one
+INSERT INTO user_groups (
+    user_group_id,
+    label,
+    description,
+    date_created,
+    date_modified
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+) RETURNING *;
+
+-- name: UpdateUserGroup :exec
+UPDATE user_groups
+SET label = $1,
+    description = $2,
+    date_created = $3,
+    date_modified = $4
+WHERE user_group_id = $5;
+
+-- name: DeleteUserGroup :exec
+DELETE FROM user_groups
+WHERE user_group_id = $1;
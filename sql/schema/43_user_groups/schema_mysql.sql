@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS user_groups (
+    user_group_id VARCHAR(26) PRIMARY KEY NOT NULL,
+    label         VARCHAR(255) NOT NULL,
+    description   TEXT NOT NULL,
+    date_created  TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    date_modified TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT uq_user_group_label
+        UNIQUE (label)
+);
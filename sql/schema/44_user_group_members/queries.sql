@@ -0,0 +1,40 @@
+-- name: CreateUserGroupMembersTable :exec
+CREATE TABLE IF NOT EXISTS user_group_members (
+    id TEXT PRIMARY KEY NOT NULL CHECK (length(id) = 26),
+    user_group_id TEXT NOT NULL REFERENCES user_groups ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users ON DELETE CASCADE,
+    UNIQUE(user_group_id, user_id)
+);
+
+-- name: CreateUserGroupMembersIndexGroup :exec
+CREATE INDEX IF NOT EXISTS idx_user_group_members_group ON user_group_members(user_group_id);
+
+-- name: CreateUserGroupMembersIndexUser :exec
+CREATE INDEX IF NOT EXISTS idx_user_group_members_user ON user_group_members(user_id);
+
+-- name: DropUserGroupMembersTable :exec
+DROP TABLE IF EXISTS user_group_members;
+
+-- name: GetUserGroupMember :one
+SELECT * FROM user_group_members WHERE id = ? LIMIT 1;
+
+-- name: ListUserGroupMember :many
+SELECT * FROM user_group_members ORDER BY id;
+
+-- name: ListUserGroupMemberByUserGroupID :many
+SELECT * FROM user_group_members WHERE user_group_id = ? ORDER BY id;
+
+-- name: ListUserGroupMemberByUserID :many
+SELECT * FROM user_group_members WHERE user_id = ? ORDER BY id;
+
+-- name: CreateUserGroupMember :one
+INSERT INTO user_group_members (id, user_group_id, user_id) VALUES (?, ?, ?) RETURNING *;
+
+-- name: DeleteUserGroupMember :exec
+DELETE FROM user_group_members WHERE id = ?;
+
+-- name: DeleteUserGroupMemberByUserGroupID :exec
+DELETE FROM user_group_members WHERE user_group_id = ?;
+
+-- name: CountUserGroupMember :one
+SELECT COUNT(*) FROM user_group_members;
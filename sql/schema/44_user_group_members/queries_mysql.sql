@@ -0,0 +1,43 @@
+-- name: CreateUserGroupMembersTable :exec
+CREATE TABLE IF NOT EXISTS user_group_members (
+    id VARCHAR(26) NOT NULL,
+    user_group_id VARCHAR(26) NOT NULL,
+    user_id VARCHAR(26) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_ugm_group FOREIGN KEY (user_group_id) REFERENCES user_groups(user_group_id) ON DELETE CASCADE,
+    CONSTRAINT fk_ugm_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_group_member UNIQUE (user_group_id, user_id)
+);
+
+-- name: CreateUserGroupMembersIndexGroup :exec
+CREATE INDEX idx_user_group_members_group ON user_group_members(user_group_id);
+
+-- name: CreateUserGroupMembersIndexUser :exec
+CREATE INDEX idx_user_group_members_user ON user_group_members(user_id);
+
+-- name: DropUserGroupMembersTable :exec
+DROP TABLE IF EXISTS user_group_members;
+
+-- name: GetUserGroupMember :one
+SELECT * FROM user_group_members WHERE id = ? LIMIT 1;
+
+-- name: ListUserGroupMember :many
+SELECT * FROM user_group_members ORDER BY id;
+
+-- name: ListUserGroupMemberByUserGroupID :many
+SELECT * FROM user_group_members WHERE user_group_id = ? ORDER BY id;
+
+-- name: ListUserGroupMemberByUserID :many
+SELECT * FROM user_group_members WHERE user_id = ? ORDER BY id;
+
+-- name: CreateUserGroupMember :exec
+INSERT INTO user_group_members (id, user_group_id, user_id) VALUES (?, ?, ?);
+
+-- name: DeleteUserGroupMember :exec
+DELETE FROM user_group_members WHERE id = ?;
+
+-- name: DeleteUserGroupMemberByUserGroupID :exec
+DELETE FROM user_group_members WHERE user_group_id = ?;
+
+-- name: CountUserGroupMember :one
+SELECT COUNT(*) FROM user_group_members;
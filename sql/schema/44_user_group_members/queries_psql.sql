@@ -0,0 +1,40 @@
+-- name: CreateUserGroupMembersTable :exec
+CREATE TABLE IF NOT EXISTS user_group_members (
+    id TEXT PRIMARY KEY NOT NULL CHECK (length(id) = 26),
+    user_group_id TEXT NOT NULL REFERENCES user_groups(user_group_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    UNIQUE(user_group_id, user_id)
+);
+
+-- name: CreateUserGroupMembersIndexGroup :exec
+CREATE INDEX IF NOT EXISTS idx_user_group_members_group ON user_group_members(user_group_id);
+
+-- name: CreateUserGroupMembersIndexUser :exec
+CREATE INDEX IF NOT EXISTS idx_user_group_members_user ON user_group_members(user_id);
+
+-- name: DropUserGroupMembersTable :exec
+DROP TABLE IF EXISTS user_group_members;
+
+-- name: GetUserGroupMember :one
+SELECT * FROM user_group_members WHERE id = $1 LIMIT 1;
+
+-- name: ListUserGroupMember :many
+SELECT * FROM user_group_members ORDER BY id;
+
+-- name: ListUserGroupMemberByUserGroupID :many
+SELECT * FROM user_group_members WHERE user_group_id = $1 ORDER BY id;
+
+-- name: ListUserGroupMemberByUserID :many
+SELECT * FROM user_group_members WHERE user_id = $1 ORDER BY id;
+
+-- name: CreateUserGroupMember :one
+INSERT INTO user_group_members (id, user_group_id, user_id) VALUES ($1, $2, $3) RETURNING *;
+
+-- name: DeleteUserGroupMember :exec
+DELETE FROM user_group_members WHERE id = $1;
+
+-- name: DeleteUserGroupMemberByUserGroupID :exec
+DELETE FROM user_group_members WHERE user_group_id = $1;
+
+-- name: CountUserGroupMember :one
+SELECT COUNT(*) FROM user_group_members;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS user_group_members (
+    id VARCHAR(26) NOT NULL,
+    user_group_id VARCHAR(26) NOT NULL,
+    user_id VARCHAR(26) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_ugm_group FOREIGN KEY (user_group_id) REFERENCES user_groups(user_group_id) ON DELETE CASCADE,
+    CONSTRAINT fk_ugm_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_group_member UNIQUE (user_group_id, user_id)
+);
+
+CREATE INDEX idx_user_group_members_group ON user_group_members(user_group_id);
+CREATE INDEX idx_user_group_members_user ON user_group_members(user_id);
@@ -0,0 +1,40 @@
+-- name: CreateUserGroupRolesTable :exec
+CREATE TABLE IF NOT EXISTS user_group_roles (
+    id TEXT PRIMARY KEY NOT NULL CHECK (length(id) = 26),
+    user_group_id TEXT NOT NULL REFERENCES user_groups ON DELETE CASCADE,
+    role_id TEXT NOT NULL REFERENCES roles ON DELETE CASCADE,
+    UNIQUE(user_group_id, role_id)
+);
+
+-- name: CreateUserGroupRolesIndexGroup :exec
+CREATE INDEX IF NOT EXISTS idx_user_group_roles_group ON user_group_roles(user_group_id);
+
+-- name: CreateUserGroupRolesIndexRole :exec
+CREATE INDEX IF NOT EXISTS idx_user_group_roles_role ON user_group_roles(role_id);
+
+-- name: DropUserGroupRolesTable :exec
+DROP TABLE IF EXISTS user_group_roles;
+
+-- name: GetUserGroupRole :one
+SELECT * FROM user_group_roles WHERE id = ? LIMIT 1;
+
+-- name: ListUserGroupRole :many
+SELECT * FROM user_group_roles ORDER BY id;
+
+-- name: ListUserGroupRoleByUserGroupID :many
+SELECT * FROM user_group_roles WHERE user_group_id = ? ORDER BY id;
+
+-- name: ListUserGroupRoleByRoleID :many
+SELECT * FROM user_group_roles WHERE role_id = ? ORDER BY id;
+
+-- name: CreateUserGroupRole :one
+INSERT INTO user_group_roles (id, user_group_id, role_id) VALUES (?, ?, ?) RETURNING *;
+
+-- name: DeleteUserGroupRole :exec
+DELETE FROM user_group_roles WHERE id = ?;
+
+-- name: DeleteUserGroupRoleByUserGroupID :exec
+DELETE FROM user_group_roles WHERE user_group_id = ?;
+
+-- name: CountUserGroupRole :one
+SELECT COUNT(*) FROM user_group_roles;
@@ -0,0 +1,43 @@
+-- name: CreateUserGroupRolesTable :exec
+CREATE TABLE IF NOT EXISTS user_group_roles (
+    id VARCHAR(26) NOT NULL,
+    user_group_id VARCHAR(26) NOT NULL,
+    role_id VARCHAR(26) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_ugr_group FOREIGN KEY (user_group_id) REFERENCES user_groups(user_group_id) ON DELETE CASCADE,
+    CONSTRAINT fk_ugr_role FOREIGN KEY (role_id) REFERENCES roles(role_id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_group_role UNIQUE (user_group_id, role_id)
+);
+
+-- name: CreateUserGroupRolesIndexGroup :exec
+CREATE INDEX idx_user_group_roles_group ON user_group_roles(user_group_id);
+
+-- name: CreateUserGroupRolesIndexRole :exec
+CREATE INDEX idx_user_group_roles_role ON user_group_roles(role_id);
+
+-- name: DropUserGroupRolesTable :exec
+DROP TABLE IF EXISTS user_group_roles;
+
+-- name: GetUserGroupRole :one
+SELECT * FROM user_group_roles WHERE id = ? LIMIT 1;
+
+-- name: ListUserGroupRole :many
+SELECT * FROM user_group_roles ORDER BY id;
+
+-- name: ListUserGroupRoleByUserGroupID :many
+SELECT * FROM user_group_roles WHERE user_group_id = ? ORDER BY id;
+
+-- name: ListUserGroupRoleByRoleID :many
+SELECT * FROM user_group_roles WHERE role_id = ? ORDER BY id;
+
+-- name: CreateUserGroupRole :exec
+INSERT INTO user_group_roles (id, user_group_id, role_id) VALUES (?, ?, ?);
+
+-- name: DeleteUserGroupRole :exec
+DELETE FROM user_group_roles WHERE id = ?;
+
+-- name: DeleteUserGroupRoleByUserGroupID :exec
+DELETE FROM user_group_roles WHERE user_group_id = ?;
+
+-- name: CountUserGroupRole :one
+SELECT COUNT(*) FROM user_group_roles;
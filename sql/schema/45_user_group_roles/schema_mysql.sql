@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS user_group_roles (
+    id VARCHAR(26) NOT NULL,
+    user_group_id VARCHAR(26) NOT NULL,
+    role_id VARCHAR(26) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_ugr_group FOREIGN KEY (user_group_id) REFERENCES user_groups(user_group_id) ON DELETE CASCADE,
+    CONSTRAINT fk_ugr_role FOREIGN KEY (role_id) REFERENCES roles(role_id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_group_role UNIQUE (user_group_id, role_id)
+);
+
+CREATE INDEX idx_user_group_roles_group ON user_group_roles(user_group_id);
+CREATE INDEX idx_user_group_roles_role ON user_group_roles(role_id);
@@ -0,0 +1,51 @@
+-- name: CreateUserLockoutsTable :exec
+CREATE TABLE IF NOT EXISTS user_lockouts (
+    user_id TEXT PRIMARY KEY NOT NULL CHECK (length(user_id) = 26)
+        REFERENCES users ON DELETE CASCADE,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    lockout_count INTEGER NOT NULL DEFAULT 0,
+    locked_until TEXT,
+    last_failed_at TEXT,
+    date_modified TEXT NOT NULL
+);
+
+-- name: DropUserLockoutsTable :exec
+DROP TABLE IF EXISTS user_lockouts;
+
+-- name: GetUserLockout :one
+SELECT * FROM user_lockouts WHERE user_id = ? LIMIT 1;
+
+-- name: ListUserLockouts :many
+SELECT * FROM user_lockouts ORDER BY date_modified DESC;
+
+-- name: UpsertUserLockout :exec
+INSERT INTO user_lockouts (user_id, failed_attempts, lockout_count, locked_until, last_failed_at, date_modified)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (user_id) DO UPDATE SET
+    failed_attempts = excluded.failed_attempts,
+    lockout_count = excluded.lockout_count,
+    locked_until = excluded.locked_until,
+    last_failed_at = excluded.last_failed_at,
+    date_modified = excluded.date_modified;
+
+-- name: IncrementUserLockout :exec
+INSERT INTO user_lockouts (user_id, failed_attempts, lockout_count, last_failed_at, date_modified)
+VALUES (?, 1, 0, ?, ?)
+ON CONFLICT (user_id) DO UPDATE SET
+    failed_attempts = user_lockouts.failed_attempts + 1,
+    last_failed_at = excluded.last_failed_at,
+    date_modified = excluded.date_modified;
+
+-- name: LockUserLockout :execrows
+UPDATE user_lockouts
+SET failed_attempts = 0,
+    lockout_count = lockout_count + 1,
+    locked_until = ?,
+    date_modified = ?
+WHERE user_id = ? AND failed_attempts >= ?;
+
+-- name: DeleteUserLockout :exec
+DELETE FROM user_lockouts WHERE user_id = ?;
+
+-- name: CountUserLockouts :one
+SELECT COUNT(*) FROM user_lockouts;
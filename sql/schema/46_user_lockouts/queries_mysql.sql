@@ -0,0 +1,52 @@
+-- name: CreateUserLockoutsTable :exec
+CREATE TABLE IF NOT EXISTS user_lockouts (
+    user_id VARCHAR(26) NOT NULL,
+    failed_attempts BIGINT NOT NULL DEFAULT 0,
+    lockout_count BIGINT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NULL,
+    last_failed_at TIMESTAMP NULL,
+    date_modified TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id),
+    CONSTRAINT fk_user_lockouts_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+-- name: DropUserLockoutsTable :exec
+DROP TABLE IF EXISTS user_lockouts;
+
+-- name: GetUserLockout :one
+SELECT * FROM user_lockouts WHERE user_id = ? LIMIT 1;
+
+-- name: ListUserLockouts :many
+SELECT * FROM user_lockouts ORDER BY date_modified DESC;
+
+-- name: UpsertUserLockout :exec
+INSERT INTO user_lockouts (user_id, failed_attempts, lockout_count, locked_until, last_failed_at, date_modified)
+VALUES (?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+    failed_attempts = VALUES(failed_attempts),
+    lockout_count = VALUES(lockout_count),
+    locked_until = VALUES(locked_until),
+    last_failed_at = VALUES(last_failed_at),
+    date_modified = VALUES(date_modified);
+
+-- name: IncrementUserLockout :exec
+INSERT INTO user_lockouts (user_id, failed_attempts, lockout_count, last_failed_at, date_modified)
+VALUES (?, 1, 0, ?, ?)
+ON DUPLICATE KEY UPDATE
+    failed_attempts = failed_attempts + 1,
+    last_failed_at = VALUES(last_failed_at),
+    date_modified = VALUES(date_modified);
+
+-- name: LockUserLockout :execrows
+UPDATE user_lockouts
+SET failed_attempts = 0,
+    lockout_count = lockout_count + 1,
+    locked_until = ?,
+    date_modified = ?
+WHERE user_id = ? AND failed_attempts >= ?;
+
+-- name: DeleteUserLockout :exec
+DELETE FROM user_lockouts WHERE user_id = ?;
+
+-- name: CountUserLockouts :one
+SELECT COUNT(*) FROM user_lockouts;
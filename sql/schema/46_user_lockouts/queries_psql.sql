@@ -0,0 +1,51 @@
+-- name: CreateUserLockoutsTable :exec
+CREATE TABLE IF NOT EXISTS user_lockouts (
+    user_id TEXT PRIMARY KEY NOT NULL CHECK (length(user_id) = 26)
+        REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    failed_attempts BIGINT NOT NULL DEFAULT 0,
+    lockout_count BIGINT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_failed_at TIMESTAMP WITH TIME ZONE,
+    date_modified TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- name: DropUserLockoutsTable :exec
+DROP TABLE IF EXISTS user_lockouts;
+
+-- name: GetUserLockout :one
+SELECT * FROM user_lockouts WHERE user_id = $1 LIMIT 1;
+
+-- name: ListUserLockouts :many
+SELECT * FROM user_lockouts ORDER BY date_modified DESC;
+
+-- name: UpsertUserLockout :exec
+INSERT INTO user_lockouts (user_id, failed_attempts, lockout_count, locked_until, last_failed_at, date_modified)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (user_id) DO UPDATE SET
+    failed_attempts = EXCLUDED.failed_attempts,
+    lockout_count = EXCLUDED.lockout_count,
+    locked_until = EXCLUDED.locked_until,
+    last_failed_at = EXCLUDED.last_failed_at,
+    date_modified = EXCLUDED.date_modified;
+
+-- name: IncrementUserLockout :exec
+INSERT INTO user_lockouts (user_id, failed_attempts, lockout_count, last_failed_at, date_modified)
+VALUES ($1, 1, 0, $2, $3)
+ON CONFLICT (user_id) DO UPDATE SET
+    failed_attempts = user_lockouts.failed_attempts + 1,
+    last_failed_at = EXCLUDED.last_failed_at,
+    date_modified = EXCLUDED.date_modified;
+
+-- name: LockUserLockout :execrows
+UPDATE user_lockouts
+SET failed_attempts = 0,
+    lockout_count = lockout_count + 1,
+    locked_until = $1,
+    date_modified = $2
+WHERE user_id = $3 AND failed_attempts >= $4;
+
+-- name: DeleteUserLockout :exec
+DELETE FROM user_lockouts WHERE user_id = $1;
+
+-- name: CountUserLockouts :one
+SELECT COUNT(*) FROM user_lockouts;
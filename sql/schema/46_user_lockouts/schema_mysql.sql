@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS user_lockouts (
+    user_id VARCHAR(26) NOT NULL,
+    failed_attempts BIGINT NOT NULL DEFAULT 0,
+    lockout_count BIGINT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NULL,
+    last_failed_at TIMESTAMP NULL,
+    date_modified TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id),
+    CONSTRAINT fk_user_lockouts_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
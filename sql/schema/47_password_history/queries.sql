@@ -0,0 +1,25 @@
+-- name: CreatePasswordHistoryTable :exec
+CREATE TABLE IF NOT EXISTS password_history (
+    id TEXT PRIMARY KEY NOT NULL CHECK (length(id) = 26),
+    user_id TEXT NOT NULL REFERENCES users ON DELETE CASCADE,
+    hash TEXT NOT NULL,
+    date_created TEXT NOT NULL
+);
+
+-- name: CreatePasswordHistoryIndexUser :exec
+CREATE INDEX IF NOT EXISTS idx_password_history_user ON password_history(user_id);
+
+-- name: DropPasswordHistoryTable :exec
+DROP TABLE IF EXISTS password_history;
+
+-- name: ListPasswordHistoryByUserID :many
+SELECT * FROM password_history WHERE user_id = ? ORDER BY date_created DESC, id DESC;
+
+-- name: CreatePasswordHistory :exec
+INSERT INTO password_history (id, user_id, hash, date_created) VALUES (?, ?, ?, ?);
+
+-- name: DeletePasswordHistory :exec
+DELETE FROM password_history WHERE id = ?;
+
+-- name: CountPasswordHistory :one
+SELECT COUNT(*) FROM password_history;
@@ -0,0 +1,27 @@
+-- name: CreatePasswordHistoryTable :exec
+CREATE TABLE IF NOT EXISTS password_history (
+    id VARCHAR(26) NOT NULL,
+    user_id VARCHAR(26) NOT NULL,
+    hash TEXT NOT NULL,
+    date_created TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_password_history_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+-- name: CreatePasswordHistoryIndexUser :exec
+CREATE INDEX idx_password_history_user ON password_history(user_id);
+
+-- name: DropPasswordHistoryTable :exec
+DROP TABLE IF EXISTS password_history;
+
+-- name: ListPasswordHistoryByUserID :many
+SELECT * FROM password_history WHERE user_id = ? ORDER BY date_created DESC, id DESC;
+
+-- name: CreatePasswordHistory :exec
+INSERT INTO password_history (id, user_id, hash, date_created) VALUES (?, ?, ?, ?);
+
+-- name: DeletePasswordHistory :exec
+DELETE FROM password_history WHERE id = ?;
+
+-- name: CountPasswordHistory :one
+SELECT COUNT(*) FROM password_history;
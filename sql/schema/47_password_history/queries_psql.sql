@@ -0,0 +1,25 @@
+-- name: CreatePasswordHistoryTable :exec
+CREATE TABLE IF NOT EXISTS password_history (
+    id TEXT PRIMARY KEY NOT NULL CHECK (length(id) = 26),
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    hash TEXT NOT NULL,
+    date_created TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- name: CreatePasswordHistoryIndexUser :exec
+CREATE INDEX IF NOT EXISTS idx_password_history_user ON password_history(user_id);
+
+-- name: DropPasswordHistoryTable :exec
+DROP TABLE IF EXISTS password_history;
+
+-- name: ListPasswordHistoryByUserID :many
+SELECT * FROM password_history WHERE user_id = $1 ORDER BY date_created DESC, id DESC;
+
+-- name: CreatePasswordHistory :exec
+INSERT INTO password_history (id, user_id, hash, date_created) VALUES ($1, $2, $3, $4);
+
+-- name: DeletePasswordHistory :exec
+DELETE FROM password_history WHERE id = $1;
+
+-- name: CountPasswordHistory :one
+SELECT COUNT(*) FROM password_history;
@@ -0,0 +1,31 @@
+-- name: CreateRateLimitCountersTable :exec
+CREATE TABLE IF NOT EXISTS rate_limit_counters (
+    limiter_key TEXT PRIMARY KEY NOT NULL,
+    window_start INTEGER NOT NULL,
+    hits INTEGER NOT NULL DEFAULT 0,
+    expires_at INTEGER NOT NULL
+);
+
+-- name: CreateRateLimitCountersIndexExpires :exec
+CREATE INDEX IF NOT EXISTS idx_rate_limit_counters_expires ON rate_limit_counters(expires_at);
+
+-- name: DropRateLimitCountersTable :exec
+DROP TABLE IF EXISTS rate_limit_counters;
+
+-- name: GetRateLimitCounter :one
+SELECT * FROM rate_limit_counters WHERE limiter_key = ? LIMIT 1;
+
+-- name: IncrementRateLimitCounter :exec
+INSERT INTO rate_limit_counters (limiter_key, window_start, hits, expires_at)
+VALUES (?, ?, 1, ?)
+ON CONFLICT (limiter_key) DO UPDATE SET
+    hits = CASE WHEN rate_limit_counters.window_start = excluded.window_start
+        THEN rate_limit_counters.hits + 1 ELSE 1 END,
+    window_start = excluded.window_start,
+    expires_at = excluded.expires_at;
+
+-- name: DeleteExpiredRateLimitCounters :exec
+DELETE FROM rate_limit_counters WHERE expires_at < ?;
+
+-- name: CountRateLimitCounters :one
+SELECT COUNT(*) FROM rate_limit_counters;
@@ -0,0 +1,31 @@
+-- name: CreateRateLimitCountersTable :exec
+CREATE TABLE IF NOT EXISTS rate_limit_counters (
+    limiter_key VARCHAR(255) NOT NULL,
+    window_start BIGINT NOT NULL,
+    hits BIGINT NOT NULL DEFAULT 0,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (limiter_key)
+);
+
+-- name: CreateRateLimitCountersIndexExpires :exec
+CREATE INDEX idx_rate_limit_counters_expires ON rate_limit_counters(expires_at);
+
+-- name: DropRateLimitCountersTable :exec
+DROP TABLE IF EXISTS rate_limit_counters;
+
+-- name: GetRateLimitCounter :one
+SELECT * FROM rate_limit_counters WHERE limiter_key = ? LIMIT 1;
+
+-- name: IncrementRateLimitCounter :exec
+INSERT INTO rate_limit_counters (limiter_key, window_start, hits, expires_at)
+VALUES (?, ?, 1, ?)
+ON DUPLICATE KEY UPDATE
+    hits = IF(window_start = VALUES(window_start), hits + 1, 1),
+    window_start = VALUES(window_start),
+    expires_at = VALUES(expires_at);
+
+-- name: DeleteExpiredRateLimitCounters :exec
+DELETE FROM rate_limit_counters WHERE expires_at < ?;
+
+-- name: CountRateLimitCounters :one
+SELECT COUNT(*) FROM rate_limit_counters;
@@ -0,0 +1,31 @@
+-- name: CreateRateLimitCountersTable :exec
+CREATE TABLE IF NOT EXISTS rate_limit_counters (
+    limiter_key TEXT PRIMARY KEY NOT NULL,
+    window_start BIGINT NOT NULL,
+    hits BIGINT NOT NULL DEFAULT 0,
+    expires_at BIGINT NOT NULL
+);
+
+-- name: CreateRateLimitCountersIndexExpires :exec
+CREATE INDEX IF NOT EXISTS idx_rate_limit_counters_expires ON rate_limit_counters(expires_at);
+
+-- name: DropRateLimitCountersTable :exec
+DROP TABLE IF EXISTS rate_limit_counters;
+
+-- name: GetRateLimitCounter :one
+SELECT * FROM rate_limit_counters WHERE limiter_key = $1 LIMIT 1;
+
+-- name: IncrementRateLimitCounter :exec
+INSERT INTO rate_limit_counters (limiter_key, window_start, hits, expires_at)
+VALUES ($1, $2, 1, $3)
+ON CONFLICT (limiter_key) DO UPDATE SET
+    hits = CASE WHEN rate_limit_counters.window_start = EXCLUDED.window_start
+        THEN rate_limit_counters.hits + 1 ELSE 1 END,
+    window_start = EXCLUDED.window_start,
+    expires_at = EXCLUDED.expires_at;
+
+-- name: DeleteExpiredRateLimitCounters :exec
+DELETE FROM rate_limit_counters WHERE expires_at < $1;
+
+-- name: CountRateLimitCounters :one
+SELECT COUNT(*) FROM rate_limit_counters;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS rate_limit_counters (
+    limiter_key VARCHAR(255) NOT NULL,
+    window_start BIGINT NOT NULL,
+    hits BIGINT NOT NULL DEFAULT 0,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (limiter_key)
+);
+
+CREATE INDEX idx_rate_limit_counters_expires ON rate_limit_counters(expires_at);
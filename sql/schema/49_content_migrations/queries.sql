@@ -0,0 +1,36 @@
+-- name: CreateContentMigrationsTable :exec
+CREATE TABLE IF NOT EXISTS content_migrations (
+    migration_id TEXT PRIMARY KEY NOT NULL,
+    source TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    rows_scanned INTEGER NOT NULL DEFAULT 0,
+    rows_changed INTEGER NOT NULL DEFAULT 0,
+    last_content_field_id TEXT NOT NULL DEFAULT '',
+    completed INTEGER NOT NULL DEFAULT 0,
+    author_id TEXT NOT NULL,
+    date_created TEXT NOT NULL
+);
+
+-- name: DropContentMigrationsTable :exec
+DROP TABLE IF EXISTS content_migrations;
+
+-- name: GetContentMigration :one
+SELECT * FROM content_migrations WHERE migration_id = ? LIMIT 1;
+
+-- name: ListContentMigrations :many
+SELECT * FROM content_migrations ORDER BY date_created, migration_id;
+
+-- name: RecordContentMigration :exec
+INSERT INTO content_migrations (migration_id, source, description, rows_scanned, rows_changed, last_content_field_id, completed, author_id, date_created)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpdateContentMigrationProgress :exec
+UPDATE content_migrations
+SET rows_scanned = ?,
+    rows_changed = ?,
+    last_content_field_id = ?,
+    completed = ?
+WHERE migration_id = ?;
+
+-- name: CountContentMigrations :one
+SELECT COUNT(*) FROM content_migrations;
@@ -0,0 +1,36 @@
+-- name: CreateContentMigrationsTable :exec
+CREATE TABLE IF NOT EXISTS content_migrations (
+    migration_id TEXT PRIMARY KEY NOT NULL,
+    source TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    rows_scanned BIGINT NOT NULL DEFAULT 0,
+    rows_changed BIGINT NOT NULL DEFAULT 0,
+    last_content_field_id TEXT NOT NULL DEFAULT '',
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    author_id TEXT NOT NULL,
+    date_created TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- name: DropContentMigrationsTable :exec
+DROP TABLE IF EXISTS content_migrations;
+
+-- name: GetContentMigration :one
+SELECT * FROM content_migrations WHERE migration_id = $1 LIMIT 1;
+
+-- name: ListContentMigrations :many
+SELECT * FROM content_migrations ORDER BY date_created, migration_id;
+
+-- name: RecordContentMigration :exec
+INSERT INTO content_migrations (migration_id, source, description, rows_scanned, rows_changed, last_content_field_id, completed, author_id, date_created)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: UpdateContentMigrationProgress :exec
+UPDATE content_migrations
+SET rows_scanned = $1,
+    rows_changed = $2,
+    last_content_field_id = $3,
+    completed = $4
+WHERE migration_id = $5;
+
+-- name: CountContentMigrations :one
+SELECT COUNT(*) FROM content_migrations;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS content_migrations (
+    migration_id VARCHAR(255) NOT NULL,
+    source VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    rows_scanned BIGINT NOT NULL DEFAULT 0,
+    rows_changed BIGINT NOT NULL DEFAULT 0,
+    last_content_field_id VARCHAR(26) NOT NULL DEFAULT '',
+    completed TINYINT NOT NULL DEFAULT 0,
+    author_id VARCHAR(26) NOT NULL,
+    date_created TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (migration_id)
+);
@@ -0,0 +1,46 @@
+-- name: CreateContentLeasesTable :exec
+CREATE TABLE IF NOT EXISTS content_leases (
+    lease_id VARCHAR(26) NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    user_id VARCHAR(26) NOT NULL,
+    username VARCHAR(255) NOT NULL DEFAULT '',
+    client VARCHAR(32) NOT NULL DEFAULT '',
+    mode VARCHAR(16) NOT NULL,
+    acquired_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (lease_id)
+);
+
+-- name: CreateContentLeasesIndexContent :exec
+CREATE INDEX idx_content_leases_content ON content_leases(content_data_id);
+
+-- At most one editing lease per node; Acquire relies on it under concurrency.
+-- name: CreateContentLeasesIndexEditing :exec
+CREATE UNIQUE INDEX idx_content_leases_editing ON content_leases((CASE WHEN mode = 'editing' THEN content_data_id END));
+
+-- name: DropContentLeasesTable :exec
+DROP TABLE IF EXISTS content_leases;
+
+-- name: GetContentLease :one
+SELECT * FROM content_leases WHERE lease_id = ? LIMIT 1;
+
+-- name: ListContentLeasesByContent :many
+SELECT * FROM content_leases
+WHERE content_data_id = ? AND expires_at >= ?
+ORDER BY acquired_at, lease_id;
+
+-- name: CreateContentLease :exec
+INSERT INTO content_leases (lease_id, content_data_id, user_id, username, client, mode, acquired_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpdateContentLease :exec
+UPDATE content_leases SET mode = ?, expires_at = ? WHERE lease_id = ?;
+
+-- name: DeleteContentLease :exec
+DELETE FROM content_leases WHERE lease_id = ?;
+
+-- name: DeleteExpiredContentLeases :exec
+DELETE FROM content_leases WHERE expires_at < ?;
+
+-- name: CountContentLeases :one
+SELECT COUNT(*) FROM content_leases;
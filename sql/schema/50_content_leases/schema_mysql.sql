@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS content_leases (
+    lease_id VARCHAR(26) NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    user_id VARCHAR(26) NOT NULL,
+    username VARCHAR(255) NOT NULL DEFAULT '',
+    client VARCHAR(32) NOT NULL DEFAULT '',
+    mode VARCHAR(16) NOT NULL,
+    acquired_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (lease_id)
+);
+
+CREATE INDEX idx_content_leases_content ON content_leases(content_data_id);
+CREATE UNIQUE INDEX idx_content_leases_editing ON content_leases((CASE WHEN mode = 'editing' THEN content_data_id END));
@@ -0,0 +1,62 @@
+-- name: CreateTranslationMemoryTable :exec
+CREATE TABLE IF NOT EXISTS translation_memory (
+    tm_id VARCHAR(26) NOT NULL,
+    source_locale VARCHAR(35) NOT NULL,
+    target_locale VARCHAR(35) NOT NULL,
+    source_hash VARCHAR(64) NOT NULL,
+    source_text LONGTEXT NOT NULL,
+    target_text LONGTEXT NOT NULL,
+    source_length BIGINT NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    field_id VARCHAR(26) NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (tm_id)
+);
+
+-- name: CreateTranslationMemoryIndexSource :exec
+CREATE UNIQUE INDEX idx_translation_memory_source ON translation_memory(source_locale, target_locale, source_hash);
+
+-- name: CreateTranslationMemoryIndexLength :exec
+CREATE INDEX idx_translation_memory_length ON translation_memory(source_locale, target_locale, source_length);
+
+-- name: DropTranslationMemoryTable :exec
+DROP TABLE IF EXISTS translation_memory;
+
+-- name: GetTranslationMemory :one
+SELECT * FROM translation_memory WHERE tm_id = ? LIMIT 1;
+
+-- name: GetTranslationMemoryBySource :one
+SELECT * FROM translation_memory
+WHERE source_locale = ? AND target_locale = ? AND source_hash = ?
+LIMIT 1;
+
+-- name: ListTranslationMemoryCandidates :many
+SELECT * FROM translation_memory
+WHERE source_locale = ? AND target_locale = ?
+  AND source_length BETWEEN sqlc.arg(min_length) AND sqlc.arg(max_length)
+ORDER BY updated_at DESC, tm_id
+LIMIT ?;
+
+-- name: ListTranslationMemoryByPair :many
+SELECT * FROM translation_memory
+WHERE source_locale = ? AND target_locale = ?
+ORDER BY updated_at DESC, tm_id
+LIMIT ? OFFSET ?;
+
+-- name: CreateTranslationMemory :exec
+INSERT INTO translation_memory (tm_id, source_locale, target_locale, source_hash, source_text, target_text, source_length, content_data_id, field_id, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpdateTranslationMemory :exec
+UPDATE translation_memory
+SET target_text = ?, content_data_id = ?, field_id = ?, updated_at = ?
+WHERE tm_id = ?;
+
+-- name: DeleteTranslationMemory :exec
+DELETE FROM translation_memory WHERE tm_id = ?;
+
+-- name: CountTranslationMemory :one
+SELECT COUNT(*) FROM translation_memory;
+
+-- name: CountTranslationMemoryByPair :one
+SELECT COUNT(*) FROM translation_memory WHERE source_locale = ? AND target_locale = ?;
@@ -0,0 +1,61 @@
+-- name: CreateTranslationMemoryTable :exec
+CREATE TABLE IF NOT EXISTS translation_memory (
+    tm_id TEXT PRIMARY KEY NOT NULL,
+    source_locale TEXT NOT NULL,
+    target_locale TEXT NOT NULL,
+    source_hash TEXT NOT NULL,
+    source_text TEXT NOT NULL,
+    target_text TEXT NOT NULL,
+    source_length BIGINT NOT NULL,
+    content_data_id TEXT NOT NULL,
+    field_id TEXT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+-- name: CreateTranslationMemoryIndexSource :exec
+CREATE UNIQUE INDEX IF NOT EXISTS idx_translation_memory_source ON translation_memory(source_locale, target_locale, source_hash);
+
+-- name: CreateTranslationMemoryIndexLength :exec
+CREATE INDEX IF NOT EXISTS idx_translation_memory_length ON translation_memory(source_locale, target_locale, source_length);
+
+-- name: DropTranslationMemoryTable :exec
+DROP TABLE IF EXISTS translation_memory;
+
+-- name: GetTranslationMemory :one
+SELECT * FROM translation_memory WHERE tm_id = $1 LIMIT 1;
+
+-- name: GetTranslationMemoryBySource :one
+SELECT * FROM translation_memory
+WHERE source_locale = $1 AND target_locale = $2 AND source_hash = $3
+LIMIT 1;
+
+-- name: ListTranslationMemoryCandidates :many
+SELECT * FROM translation_memory
+WHERE source_locale = $1 AND target_locale = $2
+  AND source_length BETWEEN sqlc.arg(min_length) AND sqlc.arg(max_length)
+ORDER BY updated_at DESC, tm_id
+LIMIT $5;
+
+-- name: ListTranslationMemoryByPair :many
+SELECT * FROM translation_memory
+WHERE source_locale = $1 AND target_locale = $2
+ORDER BY updated_at DESC, tm_id
+LIMIT $3 OFFSET $4;
+
+-- name: CreateTranslationMemory :exec
+INSERT INTO translation_memory (tm_id, source_locale, target_locale, source_hash, source_text, target_text, source_length, content_data_id, field_id, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: UpdateTranslationMemory :exec
+UPDATE translation_memory
+SET target_text = $1, content_data_id = $2, field_id = $3, updated_at = $4
+WHERE tm_id = $5;
+
+-- name: DeleteTranslationMemory :exec
+DELETE FROM translation_memory WHERE tm_id = $1;
+
+-- name: CountTranslationMemory :one
+SELECT COUNT(*) FROM translation_memory;
+
+-- name: CountTranslationMemoryByPair :one
+SELECT COUNT(*) FROM translation_memory WHERE source_locale = $1 AND target_locale = $2;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS translation_memory (
+    tm_id VARCHAR(26) NOT NULL,
+    source_locale VARCHAR(35) NOT NULL,
+    target_locale VARCHAR(35) NOT NULL,
+    source_hash VARCHAR(64) NOT NULL,
+    source_text LONGTEXT NOT NULL,
+    target_text LONGTEXT NOT NULL,
+    source_length BIGINT NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    field_id VARCHAR(26) NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (tm_id)
+);
+
+CREATE UNIQUE INDEX idx_translation_memory_source ON translation_memory(source_locale, target_locale, source_hash);
+CREATE INDEX idx_translation_memory_length ON translation_memory(source_locale, target_locale, source_length);
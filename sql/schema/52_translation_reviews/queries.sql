@@ -0,0 +1,41 @@
+-- name: CreateTranslationReviewsTable :exec
+CREATE TABLE IF NOT EXISTS translation_reviews (
+    content_field_id TEXT PRIMARY KEY NOT NULL,
+    content_data_id TEXT NOT NULL,
+    locale TEXT NOT NULL,
+    origin TEXT NOT NULL,
+    provider TEXT NOT NULL DEFAULT '',
+    score INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL
+);
+
+-- name: CreateTranslationReviewsIndexLocale :exec
+CREATE INDEX IF NOT EXISTS idx_translation_reviews_locale ON translation_reviews(locale);
+
+-- name: DropTranslationReviewsTable :exec
+DROP TABLE IF EXISTS translation_reviews;
+
+-- name: GetTranslationReview :one
+SELECT * FROM translation_reviews WHERE content_field_id = ? LIMIT 1;
+
+-- name: ListTranslationReviewsByLocale :many
+SELECT tr.* FROM translation_reviews tr
+JOIN content_fields cf ON cf.content_field_id = tr.content_field_id
+WHERE tr.locale = ?
+ORDER BY tr.content_data_id, tr.content_field_id;
+
+-- name: ListTranslationReviewsByRoute :many
+SELECT tr.* FROM translation_reviews tr
+JOIN content_fields cf ON cf.content_field_id = tr.content_field_id
+WHERE cf.route_id = ? AND tr.locale = ?
+ORDER BY tr.content_data_id, tr.content_field_id;
+
+-- name: CreateTranslationReview :exec
+INSERT INTO translation_reviews (content_field_id, content_data_id, locale, origin, provider, score, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: DeleteTranslationReview :exec
+DELETE FROM translation_reviews WHERE content_field_id = ?;
+
+-- name: CountTranslationReviews :one
+SELECT COUNT(*) FROM translation_reviews;
@@ -0,0 +1,42 @@
+-- name: CreateTranslationReviewsTable :exec
+CREATE TABLE IF NOT EXISTS translation_reviews (
+    content_field_id VARCHAR(26) NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    origin VARCHAR(16) NOT NULL,
+    provider VARCHAR(255) NOT NULL DEFAULT '',
+    score BIGINT NOT NULL DEFAULT 0,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (content_field_id)
+);
+
+-- name: CreateTranslationReviewsIndexLocale :exec
+CREATE INDEX idx_translation_reviews_locale ON translation_reviews(locale);
+
+-- name: DropTranslationReviewsTable :exec
+DROP TABLE IF EXISTS translation_reviews;
+
+-- name: GetTranslationReview :one
+SELECT * FROM translation_reviews WHERE content_field_id = ? LIMIT 1;
+
+-- name: ListTranslationReviewsByLocale :many
+SELECT tr.* FROM translation_reviews tr
+JOIN content_fields cf ON cf.content_field_id = tr.content_field_id
+WHERE tr.locale = ?
+ORDER BY tr.content_data_id, tr.content_field_id;
+
+-- name: ListTranslationReviewsByRoute :many
+SELECT tr.* FROM translation_reviews tr
+JOIN content_fields cf ON cf.content_field_id = tr.content_field_id
+WHERE cf.route_id = ? AND tr.locale = ?
+ORDER BY tr.content_data_id, tr.content_field_id;
+
+-- name: CreateTranslationReview :exec
+INSERT INTO translation_reviews (content_field_id, content_data_id, locale, origin, provider, score, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: DeleteTranslationReview :exec
+DELETE FROM translation_reviews WHERE content_field_id = ?;
+
+-- name: CountTranslationReviews :one
+SELECT COUNT(*) FROM translation_reviews;
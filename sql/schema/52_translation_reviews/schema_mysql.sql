@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS translation_reviews (
+    content_field_id VARCHAR(26) NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    origin VARCHAR(16) NOT NULL,
+    provider VARCHAR(255) NOT NULL DEFAULT '',
+    score BIGINT NOT NULL DEFAULT 0,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (content_field_id)
+);
+
+CREATE INDEX idx_translation_reviews_locale ON translation_reviews(locale);
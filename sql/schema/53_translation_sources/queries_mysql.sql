@@ -0,0 +1,55 @@
+-- name: CreateTranslationSourcesTable :exec
+CREATE TABLE IF NOT EXISTS translation_sources (
+    content_field_id VARCHAR(26) NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    field_id VARCHAR(26) NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    source_hash VARCHAR(64) NOT NULL,
+    recorded_at BIGINT NOT NULL,
+    PRIMARY KEY (content_field_id)
+);
+
+-- name: CreateTranslationSourcesIndexField :exec
+CREATE INDEX idx_translation_sources_field ON translation_sources(content_data_id, field_id);
+
+-- name: CreateTranslationSourcesIndexLocale :exec
+CREATE INDEX idx_translation_sources_locale ON translation_sources(locale);
+
+-- name: DropTranslationSourcesTable :exec
+DROP TABLE IF EXISTS translation_sources;
+
+-- name: GetTranslationSource :one
+SELECT * FROM translation_sources WHERE content_field_id = ? LIMIT 1;
+
+-- name: ListTranslationSourcesByField :many
+SELECT ts.* FROM translation_sources ts
+JOIN content_fields cf ON cf.content_field_id = ts.content_field_id
+WHERE ts.content_data_id = ? AND ts.field_id = ?
+ORDER BY ts.locale, ts.content_field_id;
+
+-- name: ListTranslationSourcesByLocale :many
+SELECT ts.* FROM translation_sources ts
+JOIN content_fields cf ON cf.content_field_id = ts.content_field_id
+WHERE ts.locale = ?
+ORDER BY ts.content_data_id, ts.content_field_id;
+
+-- name: ListTranslationSourcesByRoute :many
+SELECT ts.* FROM translation_sources ts
+JOIN content_fields cf ON cf.content_field_id = ts.content_field_id
+WHERE cf.route_id = ? AND ts.locale = ?
+ORDER BY ts.content_data_id, ts.content_field_id;
+
+-- name: CreateTranslationSource :exec
+INSERT INTO translation_sources (content_field_id, content_data_id, field_id, locale, source_hash, recorded_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: UpdateTranslationSource :exec
+UPDATE translation_sources
+SET source_hash = ?, recorded_at = ?
+WHERE content_field_id = ?;
+
+-- name: DeleteTranslationSource :exec
+DELETE FROM translation_sources WHERE content_field_id = ?;
+
+-- name: CountTranslationSources :one
+SELECT COUNT(*) FROM translation_sources;
@@ -0,0 +1,54 @@
+-- name: CreateTranslationSourcesTable :exec
+CREATE TABLE IF NOT EXISTS translation_sources (
+    content_field_id TEXT PRIMARY KEY NOT NULL,
+    content_data_id TEXT NOT NULL,
+    field_id TEXT NOT NULL,
+    locale TEXT NOT NULL,
+    source_hash TEXT NOT NULL,
+    recorded_at BIGINT NOT NULL
+);
+
+-- name: CreateTranslationSourcesIndexField :exec
+CREATE INDEX IF NOT EXISTS idx_translation_sources_field ON translation_sources(content_data_id, field_id);
+
+-- name: CreateTranslationSourcesIndexLocale :exec
+CREATE INDEX IF NOT EXISTS idx_translation_sources_locale ON translation_sources(locale);
+
+-- name: DropTranslationSourcesTable :exec
+DROP TABLE IF EXISTS translation_sources;
+
+-- name: GetTranslationSource :one
+SELECT * FROM translation_sources WHERE content_field_id = $1 LIMIT 1;
+
+-- name: ListTranslationSourcesByField :many
+SELECT ts.* FROM translation_sources ts
+JOIN content_fields cf ON cf.content_field_id = ts.content_field_id
+WHERE ts.content_data_id = $1 AND ts.field_id = $2
+ORDER BY ts.locale, ts.content_field_id;
+
+-- name: ListTranslationSourcesByLocale :many
+SELECT ts.* FROM translation_sources ts
+JOIN content_fields cf ON cf.content_field_id = ts.content_field_id
+WHERE ts.locale = $1
+ORDER BY ts.content_data_id, ts.content_field_id;
+
+-- name: ListTranslationSourcesByRoute :many
+SELECT ts.* FROM translation_sources ts
+JOIN content_fields cf ON cf.content_field_id = ts.content_field_id
+WHERE cf.route_id = $1 AND ts.locale = $2
+ORDER BY ts.content_data_id, ts.content_field_id;
+
+-- name: CreateTranslationSource :exec
+INSERT INTO translation_sources (content_field_id, content_data_id, field_id, locale, source_hash, recorded_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: UpdateTranslationSource :exec
+UPDATE translation_sources
+SET source_hash = $1, recorded_at = $2
+WHERE content_field_id = $3;
+
+-- name: DeleteTranslationSource :exec
+DELETE FROM translation_sources WHERE content_field_id = $1;
+
+-- name: CountTranslationSources :one
+SELECT COUNT(*) FROM translation_sources;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS translation_sources (
+    content_field_id VARCHAR(26) NOT NULL,
+    content_data_id VARCHAR(26) NOT NULL,
+    field_id VARCHAR(26) NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    source_hash VARCHAR(64) NOT NULL,
+    recorded_at BIGINT NOT NULL,
+    PRIMARY KEY (content_field_id)
+);
+
+CREATE INDEX idx_translation_sources_field ON translation_sources(content_data_id, field_id);
+CREATE INDEX idx_translation_sources_locale ON translation_sources(locale);
@@ -0,0 +1,38 @@
+-- name: CreateBlueprintsTable :exec
+CREATE TABLE IF NOT EXISTS blueprints (
+    blueprint_id TEXT PRIMARY KEY NOT NULL,
+    name TEXT NOT NULL UNIQUE,
+    label TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    definition TEXT NOT NULL,
+    author_id TEXT NOT NULL,
+    date_created TEXT NOT NULL,
+    date_modified TEXT NOT NULL
+);
+
+-- name: DropBlueprintsTable :exec
+DROP TABLE IF EXISTS blueprints;
+
+-- name: GetBlueprint :one
+SELECT * FROM blueprints WHERE blueprint_id = ? LIMIT 1;
+
+-- name: GetBlueprintByName :one
+SELECT * FROM blueprints WHERE name = ? LIMIT 1;
+
+-- name: ListBlueprints :many
+SELECT * FROM blueprints ORDER BY name;
+
+-- name: CreateBlueprint :exec
+INSERT INTO blueprints (blueprint_id, name, label, description, definition, author_id, date_created, date_modified)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpdateBlueprint :exec
+UPDATE blueprints
+SET name = ?, label = ?, description = ?, definition = ?, date_modified = ?
+WHERE blueprint_id = ?;
+
+-- name: DeleteBlueprint :exec
+DELETE FROM blueprints WHERE blueprint_id = ?;
+
+-- name: CountBlueprints :one
+SELECT COUNT(*) FROM blueprints;
@@ -0,0 +1,41 @@
+-- name: CreateBlueprintsTable :exec
+CREATE TABLE IF NOT EXISTS blueprints (
+    blueprint_id VARCHAR(26) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    label VARCHAR(255) NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    definition MEDIUMTEXT NOT NULL,
+    author_id VARCHAR(26) NOT NULL,
+    date_created TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    date_modified TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (blueprint_id),
+    CONSTRAINT name
+        UNIQUE (name)
+);
+
+-- name: DropBlueprintsTable :exec
+DROP TABLE IF EXISTS blueprints;
+
+-- name: GetBlueprint :one
+SELECT * FROM blueprints WHERE blueprint_id = ? LIMIT 1;
+
+-- name: GetBlueprintByName :one
+SELECT * FROM blueprints WHERE name = ? LIMIT 1;
+
+-- name: ListBlueprints :many
+SELECT * FROM blueprints ORDER BY name;
+
+-- name: CreateBlueprint :exec
+INSERT INTO blueprints (blueprint_id, name, label, description, definition, author_id, date_created, date_modified)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpdateBlueprint :exec
+UPDATE blueprints
+SET name = ?, label = ?, description = ?, definition = ?, date_modified = ?
+WHERE blueprint_id = ?;
+
+-- name: DeleteBlueprint :exec
+DELETE FROM blueprints WHERE blueprint_id = ?;
+
+-- name: CountBlueprints :one
+SELECT COUNT(*) FROM blueprints;
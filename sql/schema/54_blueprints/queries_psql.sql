@@ -0,0 +1,38 @@
+-- name: CreateBlueprintsTable :exec
+CREATE TABLE IF NOT EXISTS blueprints (
+    blueprint_id TEXT PRIMARY KEY NOT NULL,
+    name TEXT NOT NULL UNIQUE,
+    label TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    definition TEXT NOT NULL,
+    author_id TEXT NOT NULL,
+    date_created TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    date_modified TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- name: DropBlueprintsTable :exec
+DROP TABLE IF EXISTS blueprints;
+
+-- name: GetBlueprint :one
+SELECT * FROM blueprints WHERE blueprint_id = $1 LIMIT 1;
+
+-- name: GetBlueprintByName :one
+SELECT * FROM blueprints WHERE name = $1 LIMIT 1;
+
+-- name: ListBlueprints :many
+SELECT * FROM blueprints ORDER BY name;
+
+-- name: CreateBlueprint :exec
+INSERT INTO blueprints (blueprint_id, name, label, description, definition, author_id, date_created, date_modified)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: UpdateBlueprint :exec
+UPDATE blueprints
+SET name = $1, label = $2, description = $3, definition = $4, date_modified = $5
+WHERE blueprint_id = $6;
+
+-- name: DeleteBlueprint :exec
+DELETE FROM blueprints WHERE blueprint_id = $1;
+
+-- name: CountBlueprints :one
+SELECT COUNT(*) FROM blueprints;
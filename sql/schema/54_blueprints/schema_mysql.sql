@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS blueprints (
+    blueprint_id VARCHAR(26) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    label VARCHAR(255) NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    definition MEDIUMTEXT NOT NULL,
+    author_id VARCHAR(26) NOT NULL,
+    date_created TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    date_modified TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (blueprint_id),
+    CONSTRAINT name
+        UNIQUE (name)
+);
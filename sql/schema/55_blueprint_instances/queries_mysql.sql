@@ -0,0 +1,40 @@
+-- name: CreateBlueprintInstancesTable :exec
+CREATE TABLE IF NOT EXISTS blueprint_instances (
+    content_data_id VARCHAR(26) NOT NULL,
+    blueprint_id VARCHAR(26) NOT NULL,
+    route_id VARCHAR(26) NULL,
+    variables TEXT NOT NULL,
+    author_id VARCHAR(26) NOT NULL,
+    date_created TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (content_data_id)
+);
+
+-- name: CreateBlueprintInstancesIndexBlueprint :exec
+CREATE INDEX idx_blueprint_instances_blueprint ON blueprint_instances(blueprint_id);
+
+-- name: DropBlueprintInstancesTable :exec
+DROP TABLE IF EXISTS blueprint_instances;
+
+-- name: GetBlueprintInstance :one
+SELECT * FROM blueprint_instances WHERE content_data_id = ? LIMIT 1;
+
+-- name: ListBlueprintInstances :many
+SELECT bi.* FROM blueprint_instances bi
+JOIN content_data cd ON cd.content_data_id = bi.content_data_id
+ORDER BY bi.blueprint_id, bi.date_created, bi.content_data_id;
+
+-- name: ListBlueprintInstancesByBlueprint :many
+SELECT bi.* FROM blueprint_instances bi
+JOIN content_data cd ON cd.content_data_id = bi.content_data_id
+WHERE bi.blueprint_id = ?
+ORDER BY bi.date_created, bi.content_data_id;
+
+-- name: CreateBlueprintInstance :exec
+INSERT INTO blueprint_instances (content_data_id, blueprint_id, route_id, variables, author_id, date_created)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: DeleteBlueprintInstancesByBlueprint :exec
+DELETE FROM blueprint_instances WHERE blueprint_id = ?;
+
+-- name: CountBlueprintInstances :one
+SELECT COUNT(*) FROM blueprint_instances;
@@ -0,0 +1,39 @@
+-- name: CreateBlueprintInstancesTable :exec
+CREATE TABLE IF NOT EXISTS blueprint_instances (
+    content_data_id TEXT PRIMARY KEY NOT NULL,
+    blueprint_id TEXT NOT NULL,
+    route_id TEXT,
+    variables TEXT NOT NULL DEFAULT '{}',
+    author_id TEXT NOT NULL,
+    date_created TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- name: CreateBlueprintInstancesIndexBlueprint :exec
+CREATE INDEX IF NOT EXISTS idx_blueprint_instances_blueprint ON blueprint_instances(blueprint_id);
+
+-- name: DropBlueprintInstancesTable :exec
+DROP TABLE IF EXISTS blueprint_instances;
+
+-- name: GetBlueprintInstance :one
+SELECT * FROM blueprint_instances WHERE content_data_id = $1 LIMIT 1;
+
+-- name: ListBlueprintInstances :many
+SELECT bi.* FROM blueprint_instances bi
+JOIN content_data cd ON cd.content_data_id = bi.content_data_id
+ORDER BY bi.blueprint_id, bi.date_created, bi.content_data_id;
+
+-- name: ListBlueprintInstancesByBlueprint :many
+SELECT bi.* FROM blueprint_instances bi
+JOIN content_data cd ON cd.content_data_id = bi.content_data_id
+WHERE bi.blueprint_id = $1
+ORDER BY bi.date_created, bi.content_data_id;
+
+-- name: CreateBlueprintInstance :exec
+INSERT INTO blueprint_instances (content_data_id, blueprint_id, route_id, variables, author_id, date_created)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: DeleteBlueprintInstancesByBlueprint :exec
+DELETE FROM blueprint_instances WHERE blueprint_id = $1;
+
+-- name: CountBlueprintInstances :one
+SELECT COUNT(*) FROM blueprint_instances;
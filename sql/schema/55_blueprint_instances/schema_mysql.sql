@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS blueprint_instances (
+    content_data_id VARCHAR(26) NOT NULL,
+    blueprint_id VARCHAR(26) NOT NULL,
+    route_id VARCHAR(26) NULL,
+    variables TEXT NOT NULL,
+    author_id VARCHAR(26) NOT NULL,
+    date_created TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (content_data_id)
+);
+
+CREATE INDEX idx_blueprint_instances_blueprint ON blueprint_instances(blueprint_id);
@@ -711,6 +711,38 @@ var Entities = []Entity{
 		OutputFile: "permission_gen.go",
 	},
 
+	// UserGroups (named groups that carry roles for their members)
+	{
+		Name:               "UserGroups",
+		Singular:           "UserGroup",
+		Plural:             "UserGroups",
+		SqlcTypeName:       "UserGroups",
+		TableName:          "user_groups",
+		IDType:             "types.UserGroupID",
+		IDField:            "UserGroupID",
+		NewIDFunc:          "types.NewUserGroupID()",
+		UpdateSuccessField: "s.Label",
+		StringTypeName:     "StringUserGroups",
+		Fields: []Field{
+			{AppName: "UserGroupID", Type: "types.UserGroupID", JSONTag: "user_group_id", IsPrimaryID: true, InCreate: false, InUpdate: true, StringConvert: "toString"},
+			{AppName: "Label", Type: "string", JSONTag: "label", InCreate: true, InUpdate: true, StringConvert: "string"},
+			{AppName: "Description", Type: "string", JSONTag: "description", InCreate: true, InUpdate: true, StringConvert: "string"},
+			{AppName: "DateCreated", Type: "types.Timestamp", JSONTag: "date_created", InCreate: true, InUpdate: true, StringConvert: "toString"},
+			{AppName: "DateModified", Type: "types.Timestamp", JSONTag: "date_modified", InCreate: true, InUpdate: true, StringConvert: "toString"},
+		},
+		ExtraQueries: []ExtraQuery{
+			{
+				MethodName:  "GetUserGroupByLabel",
+				SqlcName:    "GetUserGroupByLabel",
+				ReturnsList: false,
+				Params: []ExtraQueryParam{
+					{ParamName: "label", ParamType: "string", SqlcField: "Label"},
+				},
+			},
+		},
+		OutputFile: "user_group_gen.go",
+	},
+
 	// Tokens (Token→Tokens sqlc remap, IssuedAt unified via Timestamp override)
 	{
 		Name:               "Tokens",
@@ -810,7 +842,7 @@ var Entities = []Entity{
 		OutputFile: "media_dimension_gen.go",
 	},
 
-	// UserOauth (OAuth casing remap via SqlcName, TokenExpiresAt unified via Timestamp override)
+	// UserOauth (OAuth casing remap via SqlcName, TokenExpiresAt unified via Timestamp override, tokens encrypted at rest)
 	{
 		Name:               "UserOauth",
 		Singular:           "UserOauth",
@@ -829,8 +861,8 @@ var Entities = []Entity{
 			{AppName: "UserID", Type: "types.NullableUserID", JSONTag: "user_id", InCreate: true, InUpdate: false, StringConvert: "toString"},
 			{AppName: "OauthProvider", Type: "string", JSONTag: "oauth_provider", InCreate: true, InUpdate: false, StringConvert: "string"},
 			{AppName: "OauthProviderUserID", SqlcName: "OAuthProviderUserID", Type: "string", JSONTag: "oauth_provider_user_id", InCreate: true, InUpdate: false, StringConvert: "string"},
-			{AppName: "AccessToken", Type: "string", JSONTag: "access_token", InCreate: true, InUpdate: true, StringConvert: "string"},
-			{AppName: "RefreshToken", Type: "string", JSONTag: "refresh_token", InCreate: true, InUpdate: true, StringConvert: "string"},
+			{AppName: "AccessToken", Type: "string", JSONTag: "access_token", InCreate: true, InUpdate: true, StringConvert: "string", Encrypted: true},
+			{AppName: "RefreshToken", Type: "string", JSONTag: "refresh_token", InCreate: true, InUpdate: true, StringConvert: "string", Encrypted: true},
 			{AppName: "TokenExpiresAt", Type: "types.Timestamp", JSONTag: "token_expires_at", InCreate: true, InUpdate: true, StringConvert: "toString"},
 			{AppName: "DateCreated", Type: "types.Timestamp", JSONTag: "date_created", InCreate: true, InUpdate: false, StringConvert: "toString"},
 		},
@@ -1,5 +1,7 @@
 package main
 
+import "strings"
+
 // Field describes a single field in an entity struct.
 type Field struct {
 	AppName     string // field name in wrapper struct (e.g., "Role")
@@ -21,6 +23,12 @@ type Field struct {
 	// When true, Map functions use .Bool() (sqlc→wrapper) and types.SafeBool{Val: x} (wrapper→sqlc).
 	SafeBool bool
 
+	// Encrypted marks string fields sealed at rest with the column keyring.
+	// When true, Map functions use openColumn (sqlc→wrapper) and sealColumn
+	// (wrapper→sqlc), and audited command constructors seal params up front so
+	// change events never record the plaintext.
+	Encrypted bool
+
 	// StringConvert controls how this field is converted in MapStringX.
 	// Values: "toString" (.String()), "string" (identity), "sprintf" (fmt.Sprintf("%d",...)),
 	//         "cast" (string(...)), "nullToString" (utility.NullToString(...)),
@@ -372,3 +380,25 @@ func (e Entity) HasStringConvertFields() bool {
 	}
 	return false
 }
+
+// HasEncryptedFields returns true if any field is sealed at rest.
+func (e Entity) HasEncryptedFields() bool {
+	for _, f := range e.Fields {
+		if f.Encrypted {
+			return true
+		}
+	}
+	return false
+}
+
+// SealRefs returns the pointer arguments passed to sealColumns for the
+// encrypted fields among fields, e.g. "&params.AccessToken, &params.RefreshToken".
+func (e Entity) SealRefs(owner string, fields []Field) string {
+	var refs []string
+	for _, f := range fields {
+		if f.Encrypted {
+			refs = append(refs, "&"+owner+"."+f.AppName)
+		}
+	}
+	return strings.Join(refs, ", ")
+}
@@ -151,6 +151,20 @@ var Renames = []Rename{
 	{From: "plugin", To: "Plugins"},
 	{From: "role", To: "Roles"},
 	{From: "role_permission", To: "RolePermissions"},
+	{From: "user_role", To: "UserRoles"},
+	{From: "user_group", To: "UserGroups"},
+	{From: "user_group_member", To: "UserGroupMembers"},
+	{From: "user_group_role", To: "UserGroupRoles"},
+	{From: "user_lockout", To: "UserLockouts"},
+	{From: "password_history", To: "PasswordHistory"},
+	{From: "rate_limit_counter", To: "RateLimitCounters"},
+	{From: "content_migration", To: "ContentMigrations"},
+	{From: "content_lease", To: "ContentLeases"},
+	{From: "translation_memory", To: "TranslationMemory"},
+	{From: "translation_review", To: "TranslationReviews"},
+	{From: "translation_source", To: "TranslationSources"},
+	{From: "blueprint", To: "Blueprints"},
+	{From: "blueprint_instance", To: "BlueprintInstances"},
 	{From: "route", To: "Routes"},
 	{From: "session", To: "Sessions"},
 	{From: "table", To: "Tables"},
@@ -178,6 +192,8 @@ var Overrides = []Override{
 	{Column: "fields.field_id", Import: typesImport, Type: "FieldID"},
 	{Column: "content_data.content_data_id", Import: typesImport, Type: "ContentID"},
 	{Column: "content_fields.content_field_id", Import: typesImport, Type: "ContentFieldID"},
+	{Column: "translation_reviews.content_field_id", Import: typesImport, Type: "ContentFieldID"},
+	{Column: "translation_sources.content_field_id", Import: typesImport, Type: "ContentFieldID"},
 	{Column: "media.media_id", Import: typesImport, Type: "MediaID"},
 	{Column: "media_dimension.media_dimension_id", Import: typesImport, Type: "MediaDimensionID"},
 	{Column: "sessions.session_id", Import: typesImport, Type: "SessionID"},
@@ -198,6 +214,13 @@ var Overrides = []Override{
 	{Column: "admin_field_types.admin_field_type_id", Import: typesImport, Type: "AdminFieldTypeID"},
 	// ROLE PERMISSIONS
 	{Comment: "ROLE PERMISSIONS", Column: "role_permissions.id", Import: typesImport, Type: "RolePermissionID"},
+	// USER ROLES AND GROUPS
+	{Comment: "USER ROLES AND GROUPS", Column: "user_roles.id", Import: typesImport, Type: "UserRoleID"},
+	{Column: "user_groups.user_group_id", Import: typesImport, Type: "UserGroupID"},
+	{Column: "user_group_members.id", Import: typesImport, Type: "UserGroupMemberID"},
+	{Column: "user_group_roles.id", Import: typesImport, Type: "UserGroupRoleID"},
+	// ACCOUNT SECURITY
+	{Comment: "ACCOUNT SECURITY", Column: "password_history.id", Import: typesImport, Type: "PasswordHistoryID"},
 	// CONTENT RELATIONS - PRIMARY IDs
 	{Comment: "CONTENT RELATIONS \u2014 PRIMARY IDs", Column: "content_relations.content_relation_id", Import: typesImport, Type: "ContentRelationID"},
 	{Column: "admin_content_relations.admin_content_relation_id", Import: typesImport, Type: "AdminContentRelationID"},
@@ -211,6 +234,14 @@ var Overrides = []Override{
 	{Column: "admin_content_relations.admin_field_id", Import: typesImport, Type: "AdminFieldID"},
 	{Column: "role_permissions.role_id", Import: typesImport, Type: "RoleID"},
 	{Column: "role_permissions.permission_id", Import: typesImport, Type: "PermissionID"},
+	{Column: "user_roles.user_id", Import: typesImport, Type: "UserID"},
+	{Column: "user_roles.role_id", Import: typesImport, Type: "RoleID"},
+	{Column: "user_group_members.user_group_id", Import: typesImport, Type: "UserGroupID"},
+	{Column: "user_group_members.user_id", Import: typesImport, Type: "UserID"},
+	{Column: "user_group_roles.user_group_id", Import: typesImport, Type: "UserGroupID"},
+	{Column: "user_group_roles.role_id", Import: typesImport, Type: "RoleID"},
+	{Column: "user_lockouts.user_id", Import: typesImport, Type: "UserID"},
+	{Column: "password_history.user_id", Import: typesImport, Type: "UserID"},
 	{Column: "content_versions.content_data_id", Import: typesImport, Type: "ContentID"},
 	{Column: "admin_content_versions.admin_content_data_id", Import: typesImport, Type: "AdminContentID"},
 	// NOT NULL author_id columns
@@ -293,6 +324,8 @@ var Overrides = []Override{
 	{Column: "backups.status", Import: typesImport, Type: "BackupStatus"},
 	{Column: "backups.started_at", Import: typesImport, Type: "Timestamp"},
 	{Column: "backups.completed_at", Import: typesImport, Type: "Timestamp"},
+	{Column: "user_lockouts.locked_until", Import: typesImport, Type: "Timestamp"},
+	{Column: "user_lockouts.last_failed_at", Import: typesImport, Type: "Timestamp"},
 	{Column: "backups.duration_ms", Import: typesImport, Type: "NullableInt64"},
 	{Column: "backups.record_count", Import: typesImport, Type: "NullableInt64"},
 	{Column: "backups.size_bytes", Import: typesImport, Type: "NullableInt64"},
@@ -328,6 +361,7 @@ var Overrides = []Override{
 	// SAFE BOOLEANS
 	{Comment: "SAFE BOOLEANS", Column: "roles.system_protected", Import: typesImport, Type: "SafeBool"},
 	{Column: "permissions.system_protected", Import: typesImport, Type: "SafeBool"},
+	{Column: "content_migrations.completed", Import: typesImport, Type: "SafeBool"},
 	// NULLABLE INTEGERS
 	{Comment: "NULLABLE INTEGERS", Column: "media_dimensions.width", Import: typesImport, Type: "NullableInt64"},
 	{Column: "media_dimensions.height", Import: typesImport, Type: "NullableInt64"},